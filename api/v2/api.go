@@ -22,11 +22,13 @@ import (
 // OpenAPIV2 provides CDC v2 APIs
 type OpenAPIV2 struct {
 	server server.Server
+	// consistencyChecks are the consistency checks started by the api.
+	consistencyChecks *consistencyChecks
 }
 
 // NewOpenAPIV2 creates a new OpenAPIV2.
 func NewOpenAPIV2(c server.Server) OpenAPIV2 {
-	return OpenAPIV2{c}
+	return OpenAPIV2{server: c, consistencyChecks: newConsistencyChecks()}
 }
 
 // RegisterOpenAPIV2Routes registers routes for OpenAPI
@@ -37,6 +39,9 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	v2.Use(middleware.ErrorHandleMiddleware())
 
 	v2.GET("status", api.serverStatus)
+	v2.GET("memory", api.memoryUsage)
+	v2.GET("debug/unmatched_prewrites", api.unmatchedPrewrites)
+	v2.GET("debug/event_store", api.eventStoreStats)
 	v2.POST("log", api.setLogLevel)
 	// For compatibility with the old API.
 	// TiDB Operator relies on this API to determine whether the TiCDC node is healthy.
@@ -48,6 +53,14 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	authenticateMiddleware := middleware.AuthenticateMiddleware(api.server)
 	v2.GET("health", coordinatorMiddleware, api.serverHealth)
 
+	// fault injection apis, the faults are injected into this node. They only work in the
+	// test builds or if debug.enable-fault-injection is set, see checkFaultInjectionEnabled.
+	faultGroup := v2.Group("/debug/faults")
+	faultGroup.GET("", api.listFaults)
+	faultGroup.POST("", authenticateMiddleware, api.injectFault)
+	faultGroup.DELETE("", authenticateMiddleware, api.clearFaults)
+	faultGroup.DELETE("/:fault_id", authenticateMiddleware, api.removeFault)
+
 	// changefeed apis
 	changefeedGroup := v2.Group("/changefeeds")
 	changefeedGroup.GET("/:changefeed_id", coordinatorMiddleware, api.getChangeFeed)
@@ -58,8 +71,39 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.POST("/:changefeed_id/pause", coordinatorMiddleware, authenticateMiddleware, api.pauseChangefeed)
 	changefeedGroup.DELETE("/:changefeed_id", coordinatorMiddleware, authenticateMiddleware, api.deleteChangefeed)
 	changefeedGroup.POST("/:changefeed_id/move_table", coordinatorMiddleware, authenticateMiddleware, api.moveTable)
+	changefeedGroup.POST("/:changefeed_id/move_tables", coordinatorMiddleware, authenticateMiddleware, api.moveTables)
+	changefeedGroup.GET("/:changefeed_id/move_tables", coordinatorMiddleware, api.getTableMoves)
+	changefeedGroup.POST("/:changefeed_id/rebalance", coordinatorMiddleware, authenticateMiddleware, api.rebalance)
+	changefeedGroup.POST("/:changefeed_id/move_maintainer", coordinatorMiddleware, authenticateMiddleware, api.moveMaintainer)
+	changefeedGroup.POST("/:changefeed_id/backfill_table", coordinatorMiddleware, authenticateMiddleware, api.backfillTable)
+	changefeedGroup.POST("/:changefeed_id/quiesce", coordinatorMiddleware, authenticateMiddleware, api.quiesceChangefeed)
+	changefeedGroup.GET("/:changefeed_id/quiesce", coordinatorMiddleware, api.getQuiesceStatus)
+	changefeedGroup.DELETE("/:changefeed_id/quiesce", coordinatorMiddleware, authenticateMiddleware, api.releaseChangefeed)
+	changefeedGroup.POST("/:changefeed_id/resend_table_schema", coordinatorMiddleware, authenticateMiddleware, api.resendTableSchema)
 	changefeedGroup.GET("/:changefeed_id/get_dispatcher_count", coordinatorMiddleware, api.getDispatcherCount)
 	changefeedGroup.GET("/:changefeed_id/tables", coordinatorMiddleware, api.listTables)
+	changefeedGroup.GET("/:changefeed_id/lagging_tables", coordinatorMiddleware, api.listLaggingTables)
+	changefeedGroup.GET("/:changefeed_id/init_progress", coordinatorMiddleware, api.getInitProgress)
+	changefeedGroup.GET("/:changefeed_id/events", coordinatorMiddleware, api.listScheduleEvents)
+	changefeedGroup.POST("/:changefeed_id/consistency_check", coordinatorMiddleware, authenticateMiddleware, api.checkConsistency)
+	changefeedGroup.GET("/:changefeed_id/consistency_check/:check_id", coordinatorMiddleware, api.getConsistencyCheck)
+	changefeedGroup.POST("/:changefeed_id/import_finish", coordinatorMiddleware, authenticateMiddleware, api.finishImport)
+	changefeedGroup.POST("/:changefeed_id/ddl_intervention", coordinatorMiddleware, authenticateMiddleware, api.interveneDDL)
+	changefeedGroup.POST("/:changefeed_id/ingest_ack", coordinatorMiddleware, authenticateMiddleware, api.ackIngest)
+	changefeedGroup.GET("/:changefeed_id/export", coordinatorMiddleware, authenticateMiddleware, api.exportChangefeed)
+	v2.POST("/changefeed_import", coordinatorMiddleware, authenticateMiddleware, api.importChangefeed)
+
+	// changefeed watch api
+	v2.GET("/changefeed_events", coordinatorMiddleware, api.watchChangefeedEvents)
+
+	// changefeed template apis
+	templateGroup := v2.Group("/changefeed_templates")
+	templateGroup.Use(coordinatorMiddleware)
+	templateGroup.GET("", api.listChangefeedTemplates)
+	templateGroup.GET("/:template_name", api.getChangefeedTemplate)
+	templateGroup.PUT("/:template_name", authenticateMiddleware, api.putChangefeedTemplate)
+	templateGroup.DELETE("/:template_name", authenticateMiddleware, api.deleteChangefeedTemplate)
+	templateGroup.POST("/:template_name/apply", api.applyChangefeedTemplate)
 
 	// capture apis
 	captureGroup := v2.Group("/captures")
@@ -68,14 +112,23 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 
 	verifyTableGroup := v2.Group("/verify_table")
 	verifyTableGroup.POST("", api.verifyTable)
+	v2.POST("/verify_filter", api.verifyFilter)
 
 	// owner apis
 	ownerGroup := v2.Group("/owner")
 	ownerGroup.Use(coordinatorMiddleware)
 	ownerGroup.POST("/resign", api.resignOwner)
 
+	// rolling upgrade apis
+	upgradeGroup := v2.Group("/upgrade")
+	upgradeGroup.Use(coordinatorMiddleware)
+	upgradeGroup.POST("", authenticateMiddleware, api.startUpgrade)
+	upgradeGroup.GET("", api.getUpgradeStatus)
+	upgradeGroup.DELETE("", authenticateMiddleware, api.stopUpgrade)
+
 	// common APIs
 	v2.POST("/tso", api.QueryTso)
+	v2.POST("/fetch_row", authenticateMiddleware, api.fetchRow)
 
 	// unsafe apis
 	unsafeGroup := v2.Group("/unsafe")
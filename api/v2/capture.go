@@ -45,6 +45,11 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 				IsCoordinator: c.ID == info.ID,
 				AdvertiseAddr: c.AdvertiseAddr,
 				ClusterID:     h.server.GetEtcdClient().GetClusterID(),
+				Version:       c.Version,
+				GitHash:       c.GitHash,
+				CPUCores:      c.CPUCores,
+				MemoryTotal:   c.MemoryTotal,
+				Labels:        c.Labels,
 			})
 	}
 	resp := &ListResponse[Capture]{
@@ -20,18 +20,24 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamservice"
 	apperror "github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/ticdc/pkg/upstream"
 	"github.com/pingcap/ticdc/version"
 	"github.com/pingcap/tiflow/cdc/api"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -51,6 +57,7 @@ import (
 // @Accept json
 // @Produce json
 // @Param changefeed body ChangefeedConfig true "changefeed config"
+// @Param strict_decode query bool false "reject the unknown fields of the changefeed config"
 // @Success 200 {object} ChangeFeedInfo
 // @Failure 500,400 {object} model.HTTPError
 // @Router	/api/v2/changefeeds [post]
@@ -58,8 +65,7 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 	ctx := c.Request.Context()
 	cfg := &ChangefeedConfig{ReplicaConfig: GetDefaultReplicaConfig()}
 
-	if err := c.BindJSON(&cfg); err != nil {
-		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+	if !h.bindChangefeedConfig(c, cfg) {
 		return
 	}
 
@@ -93,7 +99,31 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
-	ts, logical, err := h.server.GetPdClient().GetTS(ctx)
+	// The changefeed replicates from the default upstream if the pd addresses are not specified,
+	// otherwise it replicates from the TiDB cluster of the pd addresses.
+	pdClient := h.server.GetPdClient()
+	var upstreamInfo *config.UpstreamInfo
+	if len(cfg.PDAddrs) > 0 {
+		up, err := appcontext.GetService[*upstream.Manager](appcontext.UpstreamManager).
+			Connect(cfg.PDAddrs, cfg.toCredential())
+		if err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrPDEtcdAPIError, err))
+			return
+		}
+		if up.ID != pdClient.GetClusterID(ctx) {
+			pdClient = up.PDClient
+			upstreamInfo = &config.UpstreamInfo{
+				ID:            up.ID,
+				PDEndpoints:   strings.Join(cfg.PDAddrs, ","),
+				KeyPath:       cfg.KeyPath,
+				CertPath:      cfg.CertPath,
+				CAPath:        cfg.CAPath,
+				CertAllowedCN: cfg.CertAllowedCN,
+			}
+		}
+	}
+
+	ts, logical, err := pdClient.GetTS(ctx)
 	if err != nil {
 		_ = c.Error(errors.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client"))
 		return
@@ -111,7 +141,7 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 	const ensureTTL = 60 * 60
 	if err = gc.EnsureChangefeedStartTsSafety(
 		ctx,
-		h.server.GetPdClient(),
+		pdClient,
 		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceCreating),
 		changefeedID,
 		ensureTTL, cfg.StartTs); err != nil {
@@ -144,9 +174,9 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
-	pdClient := h.server.GetPdClient()
 	info := &config.ChangeFeedInfo{
 		UpstreamID:     pdClient.GetClusterID(ctx),
+		UpstreamInfo:   upstreamInfo,
 		ChangefeedID:   changefeedID,
 		SinkURI:        cfg.SinkURI,
 		CreateTime:     time.Now(),
@@ -157,6 +187,11 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		CreatorVersion: version.ReleaseVersion,
 		Epoch:          owner.GenerateChangefeedEpoch(ctx, pdClient),
 	}
+	if cfg.PausedForImport {
+		// the changefeed holds the GC safepoint at the import start ts until the import finishes
+		info.State = model.StateStopped
+		info.PausedForImport = true
+	}
 
 	// verify sinkURI
 	tempChangefeedID := common.NewChangeFeedIDWithName("sink-uri-verify-changefeed-id")
@@ -293,6 +328,9 @@ func (h *OpenAPIV2) getChangeFeed(c *gin.Context) {
 	taskStatus := make([]model.CaptureTaskStatus, 0)
 	detail := toAPIModel(cfInfo, status.CheckpointTs,
 		status.CheckpointTs, taskStatus)
+	for _, w := range status.Warnings {
+		detail.Warnings = append(detail.Warnings, toAPIRunningError(w))
+	}
 	c.JSON(http.StatusOK, detail)
 }
 
@@ -307,11 +345,7 @@ func toAPIModel(
 	// if the state is normal, we shall not return the error info
 	// because changefeed will is retrying. errors will confuse the users
 	if info.State != model.StateNormal && info.Error != nil {
-		runningError = &RunningError{
-			Addr:    info.Error.Addr,
-			Code:    info.Error.Code,
-			Message: info.Error.Message,
-		}
+		runningError = toAPIRunningError(info.Error)
 	}
 
 	sinkURI, err := util.MaskSinkURI(info.SinkURI)
@@ -320,26 +354,50 @@ func toAPIModel(
 	}
 
 	apiInfoModel := &ChangeFeedInfo{
-		UpstreamID:     info.UpstreamID,
-		ID:             info.ChangefeedID.Name(),
-		Namespace:      info.ChangefeedID.Namespace(),
-		SinkURI:        sinkURI,
-		CreateTime:     info.CreateTime,
-		StartTs:        info.StartTs,
-		TargetTs:       info.TargetTs,
-		AdminJobType:   info.AdminJobType,
-		Config:         ToAPIReplicaConfig(info.Config),
-		State:          info.State,
-		Error:          runningError,
-		CreatorVersion: info.CreatorVersion,
-		CheckpointTs:   checkpointTs,
-		ResolvedTs:     resolvedTs,
-		CheckpointTime: model.JSONTime(oracle.GetTimeFromTS(checkpointTs)),
-		TaskStatus:     taskStatus,
+		UpstreamID:      info.UpstreamID,
+		ID:              info.ChangefeedID.Name(),
+		Namespace:       info.ChangefeedID.Namespace(),
+		SinkURI:         sinkURI,
+		CreateTime:      info.CreateTime,
+		StartTs:         info.StartTs,
+		TargetTs:        info.TargetTs,
+		AdminJobType:    info.AdminJobType,
+		Config:          ToAPIReplicaConfig(info.Config),
+		State:           info.State,
+		Error:           runningError,
+		CreatorVersion:  info.CreatorVersion,
+		PausedForImport: info.PausedForImport,
+		CheckpointTs:    checkpointTs,
+		ResolvedTs:      resolvedTs,
+		CheckpointTime:  model.JSONTime(oracle.GetTimeFromTS(checkpointTs)),
+		TaskStatus:      taskStatus,
+	}
+	if apiInfoModel.Config != nil && apiInfoModel.Config.Sink != nil {
+		for i, uri := range apiInfoModel.Config.Sink.AdditionalSinkURIs {
+			apiInfoModel.Config.Sink.AdditionalSinkURIs[i] = util.MaskSensitiveDataInURI(uri)
+		}
 	}
 	return apiInfoModel
 }
 
+// toAPIRunningError converts the running error to the api model,
+// the error code, class and retryability are resolved from the error.
+func toAPIRunningError(err *model.RunningError) *RunningError {
+	detail := apperror.NewErrorDetail(err.Code, err.Message)
+	runningError := &RunningError{
+		Addr:      err.Addr,
+		Code:      detail.Code,
+		Message:   err.Message,
+		Class:     string(detail.Class),
+		Retryable: detail.Retryable,
+	}
+	if !err.Time.IsZero() {
+		errTime := err.Time
+		runningError.Time = &errTime
+	}
+	return runningError
+}
+
 // deleteChangefeed handles delete changefeed request
 // @Summary Remove a changefeed
 // @Description Remove a changefeed
@@ -470,6 +528,14 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	// the changefeed paused for import would replicate the imported data again
+	// if it's resumed from its checkpoint, which is the import start ts.
+	if cfInfo.PausedForImport && cfg.OverwriteCheckpointTs == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"changefeed %s is paused for import, resume it by the import_finish api "+
+				"or set overwrite_checkpoint_ts", changefeedDisplayName.Name))
+		return
+	}
 
 	// If there is no overrideCheckpointTs, then check whether the currentCheckpointTs is smaller than gc safepoint or not.
 	newCheckpointTs := status.CheckpointTs
@@ -486,6 +552,12 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	if cfg.OverwriteCheckpointTs != 0 {
+		if err := verifyLogServiceAvailability(ctx, cfInfo.UpstreamID, newCheckpointTs); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
 	needRemoveGCSafePoint := false
 	defer func() {
 		if !needRemoveGCSafePoint {
@@ -526,6 +598,7 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 // @Param changefeed_id  path  string  true  "changefeed_id"
 // @Param namespace query string false "default"
 // @Param changefeedConfig body ChangefeedConfig true "changefeed config"
+// @Param strict_decode query bool false "reject the unknown fields of the changefeed config"
 // @Success 200 {object} ChangeFeedInfo
 // @Failure 500,400 {object} model.HTTPError
 // @Router /api/v2/changefeeds/{changefeed_id} [put]
@@ -561,8 +634,7 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 	}
 
 	updateCfConfig := &ChangefeedConfig{}
-	if err = c.BindJSON(updateCfConfig); err != nil {
-		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+	if !bindJSON(c, updateCfConfig) {
 		return
 	}
 
@@ -664,6 +736,44 @@ func verifyResumeChangefeedConfig(
 	return nil
 }
 
+// verifyLogServiceAvailability checks the log service of the upstream can serve the changefeed
+// restarted from the checkpoint ts, the check is skipped if the log service doesn't run on this node.
+func verifyLogServiceAvailability(ctx context.Context, upstreamID uint64, checkpointTs uint64) error {
+	logService, ok := appcontext.GetService[*upstreamservice.Manager](appcontext.UpstreamLogService).Get(upstreamID)
+	if !ok {
+		log.Info("the log service of the upstream is not found, skip checking its availability",
+			zap.Uint64("upstreamID", upstreamID),
+			zap.Uint64("checkpointTs", checkpointTs))
+		return nil
+	}
+	return checkLogServiceStartTs(ctx, logService.SchemaStore, logService.EventStore, checkpointTs)
+}
+
+// checkLogServiceStartTs returns an error if the schemas or the events after the ts can't be read,
+// the schema store drops the schemas before its gc ts, and the event store pulls the events from
+// the upstream, which only keeps the versions after the gc safe point.
+func checkLogServiceStartTs(
+	ctx context.Context,
+	schemaStore schemastore.SchemaStore,
+	eventStore eventstore.EventStore,
+	startTs uint64,
+) error {
+	if gcTs := schemaStore.GetGCTs(); startTs < gcTs {
+		return errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid checkpoint-ts %v, smaller than the gc ts %v of the schema store", startTs, gcTs)
+	}
+	safePoint, err := eventStore.GetGCSafePoint(ctx)
+	if err != nil {
+		return errors.ErrPDEtcdAPIError.Wrap(err)
+	}
+	if startTs < safePoint {
+		return errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid checkpoint-ts %v, smaller than the gc safe point %v of the upstream, "+
+				"the events can't be pulled by the event store", startTs, safePoint)
+	}
+	return nil
+}
+
 // moveTable handles move table in changefeed to target node,
 // it returns the move result(success or err)
 // This api is for inner test use, not public use. It may be removed in the future.
@@ -720,6 +830,129 @@ func (h *OpenAPIV2) moveTable(c *gin.Context) {
 	c.JSON(http.StatusOK, &EmptyResponse{})
 }
 
+// backfillTable re-replicates a table of the changefeed from an older ts,
+// the other tables of the changefeed are not touched.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/backfill_table?tableID={tableID}&startTs={startTs}
+// Note:
+// 1. the startTs must be within the gc retention and the gc max lag, it's protected by a service gc safepoint for an hour.
+// 2. the events already replicated are written to the downstream again, the mysql sink writes them in safe mode.
+// 3. the ddl of the table after the startTs is executed again, so the startTs is better after the last ddl of the table.
+func (h *OpenAPIV2) backfillTable(c *gin.Context) {
+	tableID, err := strconv.ParseInt(c.Query("tableID"), 10, 64)
+	if err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid tableID: %s", c.Query("tableID")))
+		return
+	}
+	startTs, err := strconv.ParseUint(c.Query("startTs"), 10, 64)
+	if err != nil || startTs == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid startTs: %s", c.Query("startTs")))
+		return
+	}
+
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changefeedID := cfInfo.ChangefeedID
+
+	maintainer, ok := h.server.GetMaintainerManager().GetMaintainerForChangefeed(changefeedID)
+	if !ok {
+		log.Error("maintainer not found for changefeed in this node", zap.String("changefeed", changefeedID.String()))
+		_ = c.Error(apperror.ErrMaintainerNotFounded)
+		return
+	}
+
+	// the gc safepoint is never held back more than the max lag, the backfill
+	// from an older ts would be failed by the coordinator soon.
+	physical, logical, err := h.server.GetPdClient().GetTS(c)
+	if err != nil {
+		_ = c.Error(errors.ErrPDEtcdAPIError.Wrap(err))
+		return
+	}
+	serverCfg := config.GetGlobalServerConfig()
+	if err := gc.CheckStartTsLag(oracle.ComposeTS(physical, logical), startTs,
+		serverCfg.GcTTL, time.Duration(serverCfg.GcMaxLag)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// 1h is enough for the incremental scan of the table.
+	gcTTL := int64(60 * 60)
+	err = gc.EnsureChangefeedStartTsSafety(c, h.server.GetPdClient(),
+		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceBackfilling),
+		changefeedID, gcTTL, startTs)
+	if err != nil {
+		if !errors.ErrStartTsBeforeGC.Equal(err) {
+			err = errors.ErrPDEtcdAPIError.Wrap(err)
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	if err := maintainer.BackfillTable(tableID, startTs); err != nil {
+		log.Error("failed to backfill table", zap.Error(err),
+			zap.String("changefeed", changefeedID.String()),
+			zap.Int64("tableID", tableID), zap.Uint64("startTs", startTs))
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// moveMaintainer handles move the maintainer of the changefeed to target node,
+// it returns the move result(success or err)
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/move_maintainer?targetNodeID={targetNodeID}
+// Note:
+// 1. targetNodeID is the node id to move the maintainer to
+// You can find the node id by using the list_captures api
+func (h *OpenAPIV2) moveMaintainer(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	targetNodeID := c.Query("targetNodeID")
+	if targetNodeID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("targetNodeID is required"))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = coordinator.MoveChangefeed(c, cfInfo.ChangefeedID, node.ID(targetNodeID))
+	if err != nil {
+		log.Error("failed to move maintainer", zap.Error(err),
+			zap.String("changefeed", cfInfo.ChangefeedID.String()), zap.String("targetNodeID", targetNodeID))
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
 // listTables lists all tables in a changefeed
 // Usage:
 // curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/tables
@@ -775,6 +1008,194 @@ func (h *OpenAPIV2) listTables(c *gin.Context) {
 	c.JSON(http.StatusOK, infos)
 }
 
+// listLaggingTables lists the tables which have the largest checkpoint lag in the changefeed,
+// they are the tables which drag the checkpoint of the changefeed.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/lagging_tables?limit={limit}
+// Note:
+// 1. limit is the max number of tables to return, default to 10, 0 means no limit
+func (h *OpenAPIV2) listLaggingTables(c *gin.Context) {
+	limit := defaultLaggingTablesLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid limit: %s", limitStr))
+			return
+		}
+	}
+
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	changefeedID := cfInfo.ChangefeedID
+
+	maintainerManager := h.server.GetMaintainerManager()
+	maintainer, ok := maintainerManager.GetMaintainerForChangefeed(changefeedID)
+	if !ok {
+		log.Error("maintainer not found for changefeed in this node", zap.String("changefeed", changefeedID.String()))
+		_ = c.Error(apperror.ErrMaintainerNotFounded)
+		return
+	}
+
+	tables := maintainer.GetLaggingTables(limit)
+	infos := make([]*LaggingTableInfo, 0, len(tables))
+	for _, table := range tables {
+		infos = append(infos, &LaggingTableInfo{
+			TableID:      table.TableID,
+			NodeID:       table.NodeID.String(),
+			CheckpointTs: table.CheckpointTs,
+			LagSeconds:   table.Lag.Seconds(),
+		})
+	}
+	c.JSON(http.StatusOK, infos)
+}
+
+// listScheduleEvents lists the scheduling events of the changefeed in chronological order, such as
+// the spans moved or split, the nodes removed and the barriers resolved, they help to correlate
+// the lag spikes with the scheduling activities.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/events?since={since}
+// Note:
+// 1. since is a duration such as 10m, or a time in RFC3339 format, only the events recorded after
+// it are returned, all the kept events are returned if it's not set
+// 2. the events are kept in the memory of the maintainer, they are lost when the maintainer is moved
+func (h *OpenAPIV2) listScheduleEvents(c *gin.Context) {
+	var since time.Time
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		if d, err := time.ParseDuration(sinceStr); err == nil && d >= 0 {
+			since = time.Now().Add(-d)
+		} else if since, err = time.Parse(time.RFC3339, sinceStr); err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid since: %s", sinceStr))
+			return
+		}
+	}
+
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	changefeedID := cfInfo.ChangefeedID
+
+	maintainerManager := h.server.GetMaintainerManager()
+	maintainer, ok := maintainerManager.GetMaintainerForChangefeed(changefeedID)
+	if !ok {
+		log.Error("maintainer not found for changefeed in this node", zap.String("changefeed", changefeedID.String()))
+		_ = c.Error(apperror.ErrMaintainerNotFounded)
+		return
+	}
+
+	events := maintainer.GetScheduleEvents(since)
+	infos := make([]*ScheduleEvent, 0, len(events))
+	for _, event := range events {
+		infos = append(infos, &ScheduleEvent{
+			Time:    event.Time,
+			Reason:  event.Reason,
+			Message: event.Message,
+		})
+	}
+	c.JSON(http.StatusOK, infos)
+}
+
+// getInitProgress returns the progress of the initialization of the changefeed,
+// it's useful to watch a changefeed with a large number of tables, which takes minutes to initialize.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/init_progress?wait={duration}
+// Note:
+// 1. wait is the max duration to wait for the changefeed to be initialized before returning, such as 10s,
+// it's capped by 1 minute, the progress is returned immediately if it's not set
+func (h *OpenAPIV2) getInitProgress(c *gin.Context) {
+	var wait time.Duration
+	if waitStr := c.Query("wait"); waitStr != "" {
+		var err error
+		wait, err = time.ParseDuration(waitStr)
+		if err != nil || wait < 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid wait: %s", waitStr))
+			return
+		}
+		if wait > maxInitProgressWait {
+			wait = maxInitProgressWait
+		}
+	}
+
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	changefeedID := cfInfo.ChangefeedID
+
+	maintainerManager := h.server.GetMaintainerManager()
+	maintainer, ok := maintainerManager.GetMaintainerForChangefeed(changefeedID)
+	if !ok {
+		log.Error("maintainer not found for changefeed in this node", zap.String("changefeed", changefeedID.String()))
+		_ = c.Error(apperror.ErrMaintainerNotFounded)
+		return
+	}
+
+	if wait > 0 {
+		ctx, cancel := context.WithTimeout(c, wait)
+		// the progress is returned even if the changefeed is not initialized in time
+		_ = maintainer.WaitForReady(ctx)
+		cancel()
+	}
+
+	progress := maintainer.GetInitializationProgress()
+	c.JSON(http.StatusOK, &InitProgress{
+		Phase:          progress.Phase,
+		TablesLoaded:   progress.TablesLoaded,
+		SpansCreated:   progress.SpansCreated,
+		SpansScheduled: progress.SpansScheduled,
+		SpansWorking:   progress.SpansWorking,
+		PercentWorking: progress.PercentWorking,
+	})
+}
+
 // getDispatcherCount returns the count of dispatcher.
 // getDispatcherCount is just for inner test use, not public use.
 func (h *OpenAPIV2) getDispatcherCount(c *gin.Context) {
@@ -810,6 +1231,40 @@ func (h *OpenAPIV2) getDispatcherCount(c *gin.Context) {
 	c.JSON(http.StatusOK, &DispatcherCount{Count: number})
 }
 
+// apiOpVarStrictDecode is the key of the query parameter which makes the request body decoded strictly.
+const apiOpVarStrictDecode = "strict_decode"
+
+// bindJSON binds the request body into obj. The unknown fields of the body are ignored
+// for compatibility with the existing clients, unless the strict_decode parameter is true,
+// then the body is rejected and the misspelled fields are reported.
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return false
+	}
+	if err := unmarshalRequestBody(c, body, obj); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return false
+	}
+	return true
+}
+
+// unmarshalRequestBody unmarshals the body into obj, it's strict only if the strict_decode parameter is true.
+func unmarshalRequestBody(c *gin.Context, body []byte, obj interface{}) error {
+	strict := false
+	if value := c.Query(apiOpVarStrictDecode); value != "" {
+		var err error
+		if strict, err = strconv.ParseBool(value); err != nil {
+			return errors.ErrAPIInvalidParam.GenWithStack("invalid %s: %s", apiOpVarStrictDecode, value)
+		}
+	}
+	if strict {
+		return config.StrictUnmarshalJSON("in the request body", body, obj)
+	}
+	return json.Unmarshal(body, obj)
+}
+
 func getNamespaceValueWithDefault(c *gin.Context) string {
 	namespace := c.Query(api.APIOpVarNamespace)
 	if namespace == "" {
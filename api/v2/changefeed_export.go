@@ -0,0 +1,265 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/owner"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// changefeedExportVersion is the version of the format of the exported changefeeds.
+const changefeedExportVersion = 1
+
+// apiOpVarIncludeSecrets is the query parameter to export the credentials of the changefeed.
+const apiOpVarIncludeSecrets = "include_secrets"
+
+// exportChangefeed exports the definition and the checkpoint of a paused changefeed, the
+// result can be imported into another TiCDC cluster attached to the same upstream.
+// The credentials in the sink uri and the config are masked unless include_secrets is true.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/export?include_secrets=true
+func (h *OpenAPIV2) exportChangefeed(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	includeSecrets := false
+	if value := c.Query(apiOpVarIncludeSecrets); value != "" {
+		var err error
+		if includeSecrets, err = strconv.ParseBool(value); err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid %s: %s",
+				apiOpVarIncludeSecrets, value))
+			return
+		}
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, status, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	// the checkpoint of a running changefeed keeps advancing, and the changefeed
+	// would be replicated by both clusters after it's imported.
+	if cfInfo.State != model.StateStopped {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"changefeed %s must be paused before it's exported, but its state is %s",
+			changefeedDisplayName.Name, cfInfo.State))
+		return
+	}
+	info, err := cfInfo.Clone()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	info.Error = nil
+	info.Warning = nil
+	if !includeSecrets {
+		if info.SinkURI, err = util.MaskSinkURI(info.SinkURI); err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+			return
+		}
+		info.Config.MaskSensitiveData()
+	}
+	c.JSON(http.StatusOK, &ChangefeedExport{
+		Version:      changefeedExportVersion,
+		ClusterID:    config.GetGlobalServerConfig().ClusterID,
+		UpstreamID:   info.UpstreamID,
+		CheckpointTs: status.CheckpointTs,
+		Info:         info,
+		Redacted:     !includeSecrets,
+	})
+}
+
+// importChangefeed creates a changefeed exported from another TiCDC cluster, it's started
+// from the checkpoint of the exported changefeed so only the data after it is replicated.
+// The credentials of a redacted export must be supplied again by sink_uri and replica_config.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeed_import -d @changefeed-test1.json
+func (h *OpenAPIV2) importChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	export := new(ChangefeedExport)
+	if err := c.BindJSON(export); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if export.Version != changefeedExportVersion {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"unsupported version %d of the exported changefeed, expect %d",
+			export.Version, changefeedExportVersion))
+		return
+	}
+	if export.Info == nil || export.Info.Config == nil || export.CheckpointTs == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"the definition or the checkpoint of the exported changefeed is missing"))
+		return
+	}
+	if export.SinkURI != "" {
+		export.Info.SinkURI = export.SinkURI
+	}
+	if export.ReplicaConfig != nil {
+		export.Info.Config = export.ReplicaConfig
+	}
+	// the masked credentials must never be used to create the changefeed
+	if export.Redacted && (export.SinkURI == "" || export.ReplicaConfig == nil) {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"the credentials of the exported changefeed are redacted, " +
+				"sink_uri and replica_config must be supplied again"))
+		return
+	}
+	info := export.Info
+	if err := model.ValidateChangefeedID(info.ChangefeedID.Name()); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			info.ChangefeedID.Name()))
+		return
+	}
+	if err := model.ValidateNamespace(info.ChangefeedID.Namespace()); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid namespace: %s",
+			info.ChangefeedID.Namespace()))
+		return
+	}
+
+	// the changefeed must be imported into a cluster attached to the same upstream,
+	// otherwise its checkpoint is meaningless.
+	pdClient := h.server.GetPdClient()
+	if info.UpstreamInfo != nil {
+		up, err := appcontext.GetService[*upstream.Manager](appcontext.UpstreamManager).
+			Connect(strings.Split(info.UpstreamInfo.PDEndpoints, ","), &security.Credential{
+				CAPath:        info.UpstreamInfo.CAPath,
+				CertPath:      info.UpstreamInfo.CertPath,
+				KeyPath:       info.UpstreamInfo.KeyPath,
+				CertAllowedCN: info.UpstreamInfo.CertAllowedCN,
+			})
+		if err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrPDEtcdAPIError, err))
+			return
+		}
+		if up.ID == pdClient.GetClusterID(ctx) {
+			// the upstream of the changefeed is the default upstream of this cluster
+			info.UpstreamInfo = nil
+		} else {
+			pdClient = up.PDClient
+		}
+	}
+	if upstreamID := pdClient.GetClusterID(ctx); upstreamID != export.UpstreamID {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"changefeed %s replicates from upstream %d, but the upstream is %d",
+			info.ChangefeedID.Name(), export.UpstreamID, upstreamID))
+		return
+	}
+
+	// Ensure the checkpoint ts is valid in the next 3600 seconds, aka 1 hour
+	const ensureTTL = 60 * 60
+	changefeedID := common.NewChangeFeedIDWithDisplayName(info.ChangefeedID.DisplayName)
+	if err := gc.EnsureChangefeedStartTsSafety(
+		ctx,
+		pdClient,
+		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceCreating),
+		changefeedID,
+		ensureTTL, export.CheckpointTs); err != nil {
+		if !errors.ErrStartTsBeforeGC.Equal(err) {
+			_ = c.Error(errors.ErrPDEtcdAPIError.Wrap(err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+	needRemoveGCSafePoint := true
+	defer func() {
+		if !needRemoveGCSafePoint {
+			return
+		}
+		err := gc.UndoEnsureChangefeedStartTsSafety(
+			ctx,
+			pdClient,
+			h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceCreating),
+			changefeedID,
+		)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}()
+
+	sinkURI, err := url.Parse(info.SinkURI)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+		return
+	}
+	if err := info.Config.ValidateAndAdjust(sinkURI); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrInvalidReplicaConfig, err))
+		return
+	}
+
+	// the changefeed is replicated from the exported checkpoint
+	info.ChangefeedID = changefeedID
+	info.StartTs = export.CheckpointTs
+	info.CreateTime = time.Now()
+	info.AdminJobType = model.AdminNone
+	info.Error = nil
+	info.Warning = nil
+	info.PausedBySchedule = false
+	info.Epoch = owner.GenerateChangefeedEpoch(ctx, pdClient)
+	if !info.PausedForImport {
+		info.State = model.StateNormal
+	}
+
+	tempChangefeedID := common.NewChangeFeedIDWithName("sink-uri-verify-changefeed-id")
+	if err := sink.VerifySink(ctx, info.ToChangefeedConfig(), tempChangefeedID); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+		return
+	}
+
+	co, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := co.CreateChangefeed(ctx, info); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	needRemoveGCSafePoint = false
+
+	log.Info("changefeed is imported",
+		zap.String("namespace", info.ChangefeedID.Namespace()),
+		zap.String("changefeed", info.ChangefeedID.Name()),
+		zap.String("sourceClusterID", export.ClusterID),
+		zap.Uint64("checkpointTs", export.CheckpointTs))
+	c.JSON(http.StatusOK, toAPIModel(info, info.StartTs, info.StartTs, nil))
+}
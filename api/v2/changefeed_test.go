@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSchemaStore struct {
+	schemastore.SchemaStore
+	gcTs uint64
+}
+
+func (m *mockSchemaStore) GetGCTs() uint64 {
+	return m.gcTs
+}
+
+type mockEventStore struct {
+	eventstore.EventStore
+	safePoint uint64
+}
+
+func (m *mockEventStore) GetGCSafePoint(_ context.Context) (uint64, error) {
+	return m.safePoint, nil
+}
+
+func TestCheckLogServiceStartTs(t *testing.T) {
+	ctx := context.Background()
+	schemaStore := &mockSchemaStore{gcTs: 100}
+	eventStore := &mockEventStore{safePoint: 200}
+
+	err := checkLogServiceStartTs(ctx, schemaStore, eventStore, 50)
+	require.ErrorContains(t, err, "smaller than the gc ts 100 of the schema store")
+	err = checkLogServiceStartTs(ctx, schemaStore, eventStore, 150)
+	require.ErrorContains(t, err, "smaller than the gc safe point 200 of the upstream")
+	require.NoError(t, checkLogServiceStartTs(ctx, schemaStore, eventStore, 200))
+}
+
+func TestBindJSONStrictDecode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"target_ts": 10, "replica_config": {"memory_quota": 1024, "memroy_quota": 1}}`
+	newContext := func(query string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPut, "/api/v2/changefeeds/test"+query, strings.NewReader(body))
+		return c
+	}
+
+	// the unknown fields are ignored by default for the compatibility with the existing clients
+	c := newContext("")
+	cfg := &ChangefeedConfig{}
+	require.True(t, bindJSON(c, cfg))
+	require.Empty(t, c.Errors)
+	require.Equal(t, uint64(10), cfg.TargetTs)
+	require.Equal(t, uint64(1024), cfg.ReplicaConfig.MemoryQuota)
+
+	c = newContext("?strict_decode=false")
+	require.True(t, bindJSON(c, &ChangefeedConfig{}))
+
+	// the unknown fields are rejected if the strict decoding is required
+	c = newContext("?strict_decode=true")
+	require.False(t, bindJSON(c, &ChangefeedConfig{}))
+	require.Len(t, c.Errors, 1)
+	require.ErrorContains(t, c.Errors[0].Err, "ErrAPIInvalidParam")
+	require.ErrorContains(t, c.Errors[0].Err, `"replica_config.memroy_quota" (did you mean "memory_quota"?)`)
+
+	c = newContext("?strict_decode=yes")
+	require.False(t, bindJSON(c, &ChangefeedConfig{}))
+	require.ErrorContains(t, c.Errors[0].Err, "invalid strict_decode")
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// ConsistencyCheckRunning means the consistency check is still running.
+	ConsistencyCheckRunning = "running"
+	// ConsistencyCheckFinished means the consistency check is finished, the result is available.
+	ConsistencyCheckFinished = "finished"
+	// ConsistencyCheckFailed means the consistency check is failed, the error is available.
+	ConsistencyCheckFailed = "failed"
+
+	// maxConsistencyChecks is the max number of the consistency checks kept in memory,
+	// the oldest finished checks are removed if it's exceeded.
+	maxConsistencyChecks = 64
+)
+
+// consistencyChecks keeps the consistency checks started on this node, the checks
+// are run in background since scanning a large table takes a long time.
+type consistencyChecks struct {
+	mu     sync.Mutex
+	nextID uint64
+	// checks are in the order they are started.
+	checks []*consistencyCheck
+}
+
+type consistencyCheck struct {
+	changefeedID common.ChangeFeedID
+	status       ConsistencyCheckStatus
+}
+
+func newConsistencyChecks() *consistencyChecks {
+	return &consistencyChecks{}
+}
+
+// start runs the check in background and returns its status, it fails if there
+// is a running check of the changefeed.
+func (cs *consistencyChecks) start(
+	changefeedID common.ChangeFeedID, check func() (*ConsistencyCheckResult, error),
+) (ConsistencyCheckStatus, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for _, c := range cs.checks {
+		if c.changefeedID == changefeedID && c.status.State == ConsistencyCheckRunning {
+			return ConsistencyCheckStatus{}, errors.ErrAPIInvalidParam.GenWithStack(
+				"consistency check %s of changefeed %s is running", c.status.ID, changefeedID.Name())
+		}
+	}
+	cs.nextID++
+	c := &consistencyCheck{
+		changefeedID: changefeedID,
+		status: ConsistencyCheckStatus{
+			ID:    strconv.FormatUint(cs.nextID, 10),
+			State: ConsistencyCheckRunning,
+		},
+	}
+	cs.checks = append(cs.checks, c)
+	cs.evict()
+
+	go func() {
+		result, err := check()
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		if err != nil {
+			log.Warn("consistency check failed",
+				zap.String("changefeed", changefeedID.String()),
+				zap.String("checkID", c.status.ID), zap.Error(err))
+			c.status.State = ConsistencyCheckFailed
+			c.status.Error = err.Error()
+			return
+		}
+		c.status.State = ConsistencyCheckFinished
+		c.status.Result = result
+	}()
+	return c.status, nil
+}
+
+// evict removes the oldest finished checks if there are too many checks.
+func (cs *consistencyChecks) evict() {
+	for i := 0; len(cs.checks) > maxConsistencyChecks && i < len(cs.checks); {
+		if cs.checks[i].status.State == ConsistencyCheckRunning {
+			i++
+			continue
+		}
+		cs.checks = append(cs.checks[:i], cs.checks[i+1:]...)
+	}
+}
+
+func (cs *consistencyChecks) get(changefeedID common.ChangeFeedID, id string) (ConsistencyCheckStatus, bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for _, c := range cs.checks {
+		if c.changefeedID == changefeedID && c.status.ID == id {
+			return c.status, true
+		}
+	}
+	return ConsistencyCheckStatus{}, false
+}
+
+// checkConsistency starts a check comparing the data of a table between the upstream and
+// the mysql compatible downstream of the changefeed at a syncpoint. The table is read from
+// the TiDB servers of the upstream of the changefeed with the given user, the check runs in
+// background and its progress can be queried by the returned id.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/consistency_check
+// -d '{"upstream_user":"root","schema":"test","table":"t","start_ts":0,"end_ts":18446744073709551615}'
+func (h *OpenAPIV2) checkConsistency(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	cfg := &ConsistencyCheckConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.Schema == "" || cfg.Table == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("schema and table must be specified"))
+		return
+	}
+	if cfg.StartTs > cfg.EndTs {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"start_ts %d is larger than end_ts %d", cfg.StartTs, cfg.EndTs))
+		return
+	}
+	if cfg.UpstreamUser == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("upstream_user must be specified"))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !util.GetOrZero(cfInfo.Config.EnableSyncPoint) {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"consistency check requires syncpoint to be enabled for changefeed %s", changefeedDisplayName.Name))
+		return
+	}
+	sinkURI, err := url.Parse(cfInfo.SinkURI)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+		return
+	}
+	if !sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"consistency check is only supported for mysql compatible sink, got %s", sinkURI.Scheme))
+		return
+	}
+
+	// the table is read from the upstream the changefeed replicates from
+	up, err := appcontext.GetService[*upstream.Manager](appcontext.UpstreamManager).
+		AddUpstreamByInfo(cfInfo.UpstreamInfo)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !up.IsNormal() {
+		_ = c.Error(errors.ErrUpstreamNotReady.GenWithStackByArgs(up.ID))
+		return
+	}
+
+	changefeedCfg := cfInfo.ToChangefeedConfig()
+	status, err := h.consistencyChecks.start(cfInfo.ChangefeedID, func() (*ConsistencyCheckResult, error) {
+		ctx := context.Background()
+		upstreamURI, err := upstreamTiDBURI(ctx, up, cfg)
+		if err != nil {
+			return nil, err
+		}
+		_, upstreamDB, err := mysql.NewMysqlConfigAndDB(ctx, cfInfo.ChangefeedID, upstreamURI, changefeedCfg)
+		if err != nil {
+			return nil, err
+		}
+		defer upstreamDB.Close()
+		_, downstreamDB, err := mysql.NewMysqlConfigAndDB(ctx, cfInfo.ChangefeedID, sinkURI, changefeedCfg)
+		if err != nil {
+			return nil, err
+		}
+		defer downstreamDB.Close()
+
+		checker := mysql.NewConsistencyChecker(cfInfo.ChangefeedID, upstreamDB, downstreamDB, cfg.ChunkSize)
+		result, err := checker.Check(ctx, cfg.Schema, cfg.Table, cfg.StartTs, cfg.EndTs)
+		if err != nil {
+			return nil, err
+		}
+		return toConsistencyCheckResult(result), nil
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, status)
+}
+
+// getConsistencyCheck returns the status of a consistency check, the result is
+// available after the check is finished.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/consistency_check/1
+func (h *OpenAPIV2) getConsistencyCheck(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	checkID := c.Param("check_id")
+	status, ok := h.consistencyChecks.get(cfInfo.ChangefeedID, checkID)
+	if !ok {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"consistency check %s of changefeed %s not found", checkID, changefeedDisplayName.Name))
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// upstreamTiDBURI returns the uri of a TiDB server of the upstream, the tls config of
+// the upstream is used to connect to it.
+func upstreamTiDBURI(ctx context.Context, up *upstream.Upstream, cfg *ConsistencyCheckConfig) (*url.URL, error) {
+	addrs, err := up.GetTiDBAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.ErrConsistencyCheck.GenWithStackByArgs(
+			fmt.Sprintf("no alive tidb server found in upstream %d", up.ID))
+	}
+	values := url.Values{}
+	if up.SecurityConfig != nil && up.SecurityConfig.IsTLSEnabled() {
+		values.Set("ssl-ca", up.SecurityConfig.CAPath)
+		values.Set("ssl-cert", up.SecurityConfig.CertPath)
+		values.Set("ssl-key", up.SecurityConfig.KeyPath)
+	}
+	return &url.URL{
+		Scheme:   sink.MySQLScheme,
+		User:     url.UserPassword(cfg.UpstreamUser, cfg.UpstreamPassword),
+		Host:     addrs[0],
+		Path:     "/",
+		RawQuery: values.Encode(),
+	}, nil
+}
+
+func toConsistencyCheckResult(result *mysql.ConsistencyCheckResult) *ConsistencyCheckResult {
+	res := &ConsistencyCheckResult{
+		PrimaryTs:   result.PrimaryTs,
+		SecondaryTs: result.SecondaryTs,
+		ChunkCount:  result.ChunkCount,
+		Consistent:  len(result.Mismatches) == 0,
+		Mismatches:  make([]*ChunkMismatch, 0, len(result.Mismatches)),
+	}
+	for _, m := range result.Mismatches {
+		res.Mismatches = append(res.Mismatches, &ChunkMismatch{
+			Index:              m.Index,
+			LowerBound:         m.LowerBound,
+			UpperBound:         m.UpperBound,
+			UpstreamCount:      m.UpstreamCount,
+			DownstreamCount:    m.DownstreamCount,
+			UpstreamChecksum:   m.UpstreamChecksum,
+			DownstreamChecksum: m.DownstreamChecksum,
+		})
+	}
+	return res
+}
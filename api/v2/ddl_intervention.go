@@ -0,0 +1,147 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/server"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// interveneDDL skips the ddl failed in the downstream or replaces it with the query supplied by
+// the user, and resumes the changefeed from its checkpoint ts, which is the barrier of the ddl.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/ddl_intervention -d '{"commit_ts": 1, "action": "skip"}'
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/ddl_intervention -d '{"commit_ts": 1, "action": "replace", "query": "..."}'
+// Note:
+// 1. the changefeed must be stopped or failed.
+// 2. only the mysql sink applies the intervention, the other sinks write the ddl as is.
+func (h *OpenAPIV2) interveneDDL(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	cfg := new(DDLInterventionConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	switch cfg.Action {
+	case config.DDLInterventionSkip:
+		cfg.Query = ""
+	case config.DDLInterventionReplace:
+		if strings.TrimSpace(cfg.Query) == "" {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("query is required to replace the ddl"))
+			return
+		}
+	default:
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid action: %s", cfg.Action))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, status, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	switch cfInfo.State {
+	case model.StateStopped, model.StateFailed:
+	default:
+		_ = c.Error(errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"can only intervene the ddl when the changefeed is stopped or failed"))
+		return
+	}
+	if cfg.CommitTs <= status.CheckpointTs {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid commit_ts %d, the ddls before the checkpoint ts %d are already replicated",
+			cfg.CommitTs, status.CheckpointTs))
+		return
+	}
+
+	newInfo, err := cfInfo.Clone()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	newInfo.SetDDLIntervention(&config.DDLIntervention{
+		CommitTs: cfg.CommitTs,
+		Action:   cfg.Action,
+		Query:    cfg.Query,
+	}, status.CheckpointTs)
+	if err := coordinator.UpdateChangefeed(ctx, newInfo); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !h.resumeFromCheckpoint(c, coordinator, cfInfo.ChangefeedID, status.CheckpointTs) {
+		return
+	}
+	log.Info("changefeed is resumed after the ddl intervention",
+		zap.String("changefeed", cfInfo.ChangefeedID.Name()),
+		zap.Uint64("commitTs", cfg.CommitTs),
+		zap.String("action", cfg.Action),
+		zap.String("query", cfg.Query))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// resumeFromCheckpoint resumes the stopped or failed changefeed from its checkpoint ts,
+// the error is added to the context and false is returned if it fails.
+func (h *OpenAPIV2) resumeFromCheckpoint(
+	c *gin.Context,
+	coordinator server.Coordinator,
+	changefeedID common.ChangeFeedID,
+	checkpointTs uint64,
+) bool {
+	ctx := c.Request.Context()
+	if err := verifyResumeChangefeedConfig(
+		ctx,
+		h.server.GetPdClient(),
+		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
+		changefeedID,
+		checkpointTs); err != nil {
+		_ = c.Error(err)
+		return false
+	}
+	err := coordinator.ResumeChangefeed(ctx, changefeedID, checkpointTs, false)
+	if err != nil {
+		if undoErr := gc.UndoEnsureChangefeedStartTsSafety(
+			ctx,
+			h.server.GetPdClient(),
+			h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
+			changefeedID,
+		); undoErr != nil {
+			_ = c.Error(undoErr)
+		}
+		_ = c.Error(err)
+		return false
+	}
+	return true
+}
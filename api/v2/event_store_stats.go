@@ -0,0 +1,32 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+)
+
+// eventStoreStats dumps the stats of the storage engine of the event store on this node,
+// such as the amplification, the compaction debt and the size of each level.
+// Usage:
+// curl -X GET "http://127.0.0.1:8300/api/v2/debug/event_store"
+func (h *OpenAPIV2) eventStoreStats(c *gin.Context) {
+	eventStore := appcontext.GetService[eventstore.EventStore](appcontext.EventStore)
+	c.Status(http.StatusOK)
+	eventStore.WriteEngineStats(c.Writer)
+}
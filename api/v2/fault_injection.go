@@ -0,0 +1,159 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/faultinject"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// injectFault injects a fault into this node, the message fault drops or delays the messages
+// sent from this node, the kill-maintainer fault kills the maintainer of the changefeed once
+// it runs on this node, and the stall-sink fault stalls the sinks of the changefeed on this node.
+// It's only available if the fault injection is enabled.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/debug/faults -d '{"kind": "message", "to": "...", "action": "delay", "delay": "1s"}'
+// curl -X POST http://127.0.0.1:8300/api/v2/debug/faults -d '{"kind": "kill-maintainer", "changefeed_id": "changefeed-test1"}'
+// curl -X POST http://127.0.0.1:8300/api/v2/debug/faults -d '{"kind": "stall-sink", "changefeed_id": "changefeed-test1", "duration": "30s"}'
+func (h *OpenAPIV2) injectFault(c *gin.Context) {
+	if !checkFaultInjectionEnabled(c) {
+		return
+	}
+	cfg := new(FaultConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	fault, err := toFault(cfg)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	fault.ID = faultinject.GetInjector().Add(fault)
+	c.JSON(http.StatusOK, toFaultConfig(fault))
+}
+
+// listFaults lists the faults injected into this node.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/debug/faults
+func (h *OpenAPIV2) listFaults(c *gin.Context) {
+	faults := faultinject.GetInjector().List()
+	res := make([]FaultConfig, 0, len(faults))
+	for _, f := range faults {
+		res = append(res, *toFaultConfig(f))
+	}
+	c.JSON(http.StatusOK, &ListResponse[FaultConfig]{
+		Total: len(res),
+		Items: res,
+	})
+}
+
+// removeFault removes a fault injected into this node.
+// Usage:
+// curl -X DELETE http://127.0.0.1:8300/api/v2/debug/faults/1
+func (h *OpenAPIV2) removeFault(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("fault_id"), 10, 64)
+	if err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid fault_id: %s", c.Param("fault_id")))
+		return
+	}
+	if !faultinject.GetInjector().Remove(id) {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("fault %d not found", id))
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// clearFaults removes all faults injected into this node.
+// Usage:
+// curl -X DELETE http://127.0.0.1:8300/api/v2/debug/faults
+func (h *OpenAPIV2) clearFaults(c *gin.Context) {
+	faultinject.GetInjector().Clear()
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+func checkFaultInjectionEnabled(c *gin.Context) bool {
+	if !faultinject.GetInjector().Enabled() {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"fault injection is not enabled, set debug.enable-fault-injection to enable it"))
+		return false
+	}
+	return true
+}
+
+func toFault(cfg *FaultConfig) (faultinject.Fault, error) {
+	fault := faultinject.Fault{Kind: faultinject.Kind(cfg.Kind)}
+	switch fault.Kind {
+	case faultinject.KindMessage:
+		fault.To = node.ID(cfg.To)
+		fault.Topic = cfg.Topic
+		fault.Action = faultinject.Action(cfg.Action)
+		switch fault.Action {
+		case faultinject.ActionDrop:
+		case faultinject.ActionDelay:
+			if cfg.Delay == nil || cfg.Delay.duration <= 0 {
+				return fault, errors.ErrAPIInvalidParam.GenWithStack("delay must be positive for the delay action")
+			}
+			fault.Delay = cfg.Delay.duration
+		default:
+			return fault, errors.ErrAPIInvalidParam.GenWithStack("invalid action: %s", cfg.Action)
+		}
+	case faultinject.KindKillMaintainer, faultinject.KindStallSink:
+		namespace := cfg.Namespace
+		if namespace == "" {
+			namespace = model.DefaultNamespace
+		}
+		if err := model.ValidateChangefeedID(cfg.ChangefeedID); err != nil {
+			return fault, errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", cfg.ChangefeedID)
+		}
+		fault.Changefeed = common.NewChangeFeedDisplayName(cfg.ChangefeedID, namespace)
+	default:
+		return fault, errors.ErrAPIInvalidParam.GenWithStack("invalid kind: %s", cfg.Kind)
+	}
+	if cfg.Duration != nil {
+		if cfg.Duration.duration <= 0 {
+			return fault, errors.ErrAPIInvalidParam.GenWithStack("duration must be positive")
+		}
+		fault.ExpireAt = time.Now().Add(cfg.Duration.duration)
+	}
+	return fault, nil
+}
+
+func toFaultConfig(f faultinject.Fault) *FaultConfig {
+	cfg := &FaultConfig{
+		ID:           f.ID,
+		Kind:         string(f.Kind),
+		To:           string(f.To),
+		Topic:        f.Topic,
+		Action:       string(f.Action),
+		Namespace:    f.Changefeed.Namespace,
+		ChangefeedID: f.Changefeed.Name,
+	}
+	if f.Delay > 0 {
+		cfg.Delay = &JSONDuration{f.Delay}
+	}
+	if !f.ExpireAt.IsZero() {
+		expireAt := f.ExpireAt
+		cfg.ExpireAt = &expireAt
+	}
+	return cfg
+}
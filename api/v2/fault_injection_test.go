@@ -0,0 +1,54 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultInjectionAuthentication(t *testing.T) {
+	original := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(original)
+	serverCfg := original.Clone()
+	serverCfg.Security = &security.Credential{ClientUserRequired: true}
+	config.StoreGlobalServerConfig(serverCfg)
+
+	s := newMockServer(t)
+	s.etcdClient.EXPECT().GetEtcdClient().Return(nil).AnyTimes()
+	router := gin.New()
+	RegisterOpenAPIV2Routes(router, NewOpenAPIV2(s))
+	serve := func(method, path, body string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// the faults can be listed without the credential
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v2/debug/faults", ""))
+	// injecting and removing the faults require the credential
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "/api/v2/debug/faults",
+		`{"kind": "kill-maintainer", "changefeed_id": "test"}`))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, "/api/v2/debug/faults", ""))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, "/api/v2/debug/faults/1", ""))
+}
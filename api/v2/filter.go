@@ -0,0 +1,102 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// verifyFilter shows which tables of the upstream are matched by the table filter rules,
+// so the rules can be checked before creating a changefeed with them. The tables are loaded
+// from the schema store of this node at the ts, the current ts is used if it's not specified.
+// System schemas are never replicated and not listed.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/verify_filter
+// -d '{"rules":["test.*","!test.t1"],"case_sensitive":false}'
+func (h *OpenAPIV2) verifyFilter(c *gin.Context) {
+	req := &VerifyFilterConfig{}
+	if err := c.BindJSON(req); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	matcher, err := filter.NewTableMatcher(&config.FilterConfig{Rules: req.Rules}, req.CaseSensitive)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	ts := req.Ts
+	if ts == 0 {
+		physical, logical, err := h.server.GetPdClient().GetTS(c.Request.Context())
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		ts = oracle.ComposeTS(physical, logical)
+	}
+	// load all tables except the ones of the system schemas
+	all, err := filter.NewFilter(&config.FilterConfig{}, "", false)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	tables, err := schemaStore.GetAllPhysicalTables(ts, all)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// the partitions of a table are listed once
+	names := make(map[TableName]int)
+	for _, table := range tables {
+		names[TableName{Schema: table.SchemaName, Table: table.TableName}]++
+	}
+	resp := &VerifyFilterResult{Ts: ts}
+	for _, table := range tables {
+		name := TableName{Schema: table.SchemaName, Table: table.TableName}
+		count, ok := names[name]
+		if !ok {
+			continue
+		}
+		delete(names, name)
+		name.TableID = table.TableID
+		name.IsPartition = count > 1
+		if matcher.MatchTable(name.Schema, name.Table) {
+			resp.MatchedTables = append(resp.MatchedTables, name)
+		} else {
+			resp.UnmatchedTables = append(resp.UnmatchedTables, name)
+		}
+	}
+	sortTableNames(resp.MatchedTables)
+	sortTableNames(resp.UnmatchedTables)
+	c.JSON(http.StatusOK, resp)
+}
+
+func sortTableNames(names []TableName) {
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Schema != names[j].Schema {
+			return names[i].Schema < names[j].Schema
+		}
+		return names[i].Table < names[j].Table
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// finishImport resumes the changefeed created paused for a physical import from the
+// import finish ts, the data imported between the start ts and the finish ts is skipped.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/import_finish -d '{"import_finish_ts": 1}'
+func (h *OpenAPIV2) finishImport(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	cfg := new(ImportFinishConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, status, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !cfInfo.PausedForImport || cfInfo.State != model.StateStopped {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"changefeed %s is not paused for import", changefeedDisplayName.Name))
+		return
+	}
+	if cfg.ImportFinishTs == 0 || cfg.ImportFinishTs < status.CheckpointTs {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid import_finish_ts %d, it must not be smaller than the import start ts %d",
+			cfg.ImportFinishTs, status.CheckpointTs))
+		return
+	}
+
+	if err := verifyResumeChangefeedConfig(
+		ctx,
+		h.server.GetPdClient(),
+		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
+		cfInfo.ChangefeedID,
+		cfg.ImportFinishTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	needRemoveGCSafePoint := false
+	defer func() {
+		if !needRemoveGCSafePoint {
+			return
+		}
+		err := gc.UndoEnsureChangefeedStartTsSafety(
+			ctx,
+			h.server.GetPdClient(),
+			h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
+			cfInfo.ChangefeedID,
+		)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}()
+
+	err = coordinator.ResumeChangefeed(ctx, cfInfo.ChangefeedID, cfg.ImportFinishTs, true)
+	if err != nil {
+		needRemoveGCSafePoint = true
+		_ = c.Error(err)
+		return
+	}
+	log.Info("changefeed is resumed after import",
+		zap.String("changefeed", cfInfo.ChangefeedID.Name()),
+		zap.Uint64("importStartTs", status.CheckpointTs),
+		zap.Uint64("importFinishTs", cfg.ImportFinishTs))
+	c.Errors = nil
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
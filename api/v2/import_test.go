@@ -0,0 +1,100 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportChangefeed(name string, pausedForImport bool) *config.ChangeFeedInfo {
+	return &config.ChangeFeedInfo{
+		ChangefeedID:    common.NewChangeFeedIDWithName(name),
+		State:           model.StateStopped,
+		PausedForImport: pausedForImport,
+		Config:          config.GetDefaultReplicaConfig(),
+	}
+}
+
+func TestFinishImport(t *testing.T) {
+	s := newMockServer(t)
+	h := NewOpenAPIV2(s)
+	info := newImportChangefeed("import", true)
+	s.coordinator.addChangefeed(info, 100)
+	params := gin.Params{{Key: "changefeed_id", Value: "import"}}
+
+	// the finish ts is earlier than the import start ts
+	_, errs := serveAPI(h.finishImport, http.MethodPost, params, []byte(`{"import_finish_ts": 99}`))
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "must not be smaller than the import start ts 100")
+	_, errs = serveAPI(h.finishImport, http.MethodPost, params, []byte(`{}`))
+	require.Len(t, errs, 1)
+	require.True(t, info.PausedForImport)
+
+	// the changefeed is resumed from the finish ts
+	w, errs := serveAPI(h.finishImport, http.MethodPost, params, []byte(`{"import_finish_ts": 200}`))
+	require.Empty(t, errs)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, info.PausedForImport)
+	require.Equal(t, model.StateNormal, info.State)
+	require.Equal(t, uint64(200), s.coordinator.statuses[info.ChangefeedID.DisplayName].CheckpointTs)
+
+	// the changefeed is no longer paused for import
+	_, errs = serveAPI(h.finishImport, http.MethodPost, params, []byte(`{"import_finish_ts": 300}`))
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "is not paused for import")
+}
+
+func TestFinishImportNotPausedForImport(t *testing.T) {
+	s := newMockServer(t)
+	h := NewOpenAPIV2(s)
+	// the changefeed is paused, but not for import
+	paused := newImportChangefeed("paused", false)
+	s.coordinator.addChangefeed(paused, 100)
+	// the changefeed is created for import, but it's resumed by others
+	running := newImportChangefeed("running", true)
+	running.State = model.StateNormal
+	s.coordinator.addChangefeed(running, 100)
+
+	for _, name := range []string{"paused", "running"} {
+		_, errs := serveAPI(h.finishImport, http.MethodPost,
+			gin.Params{{Key: "changefeed_id", Value: name}}, []byte(`{"import_finish_ts": 200}`))
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "is not paused for import")
+		require.Equal(t, uint64(100), s.coordinator.statuses[common.NewChangeFeedDisplayName(name, common.DefaultNamespace)].CheckpointTs)
+	}
+}
+
+func TestResumeChangefeedPausedForImport(t *testing.T) {
+	s := newMockServer(t)
+	h := NewOpenAPIV2(s)
+	info := newImportChangefeed("import", true)
+	s.coordinator.addChangefeed(info, 100)
+	params := gin.Params{{Key: "changefeed_id", Value: "import"}}
+
+	// the plain resume would replicate the imported data again
+	_, errs := serveAPI(h.resumeChangefeed, http.MethodPost, params, nil)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "is paused for import")
+	_, errs = serveAPI(h.resumeChangefeed, http.MethodPost, params, []byte(`{}`))
+	require.Len(t, errs, 1)
+	require.True(t, info.PausedForImport)
+	require.Equal(t, model.StateStopped, info.State)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// ackIngest acknowledges the ingest event the changefeed is paused at, and resumes the
+// changefeed from its checkpoint ts, the ingest event is passed after it's resumed.
+// It's used when the ingest-strategy of the changefeed is pause-until-ack, the users
+// should make sure the ingested data is already in the downstream before acknowledging it.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/ingest_ack -d '{"commit_ts": 1}'
+func (h *OpenAPIV2) ackIngest(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	cfg := new(IngestAckConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, status, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if cfInfo.State != model.StateStopped {
+		_ = c.Error(errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"can only acknowledge the ingest event when the changefeed is stopped"))
+		return
+	}
+	if cfg.CommitTs <= status.CheckpointTs {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid commit_ts %d, the events before the checkpoint ts %d are already replicated",
+			cfg.CommitTs, status.CheckpointTs))
+		return
+	}
+
+	newInfo, err := cfInfo.Clone()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	newInfo.AckIngestEvent(cfg.CommitTs, status.CheckpointTs)
+	if err := coordinator.UpdateChangefeed(ctx, newInfo); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !h.resumeFromCheckpoint(c, coordinator, cfInfo.ChangefeedID, status.CheckpointTs) {
+		return
+	}
+	log.Info("changefeed is resumed after the ingest event is acknowledged",
+		zap.String("changefeed", cfInfo.ChangefeedID.Name()),
+		zap.Uint64("commitTs", cfg.CommitTs))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
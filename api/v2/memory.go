@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/memory"
+)
+
+// memoryUsage Get the memory accounted by the components of a TiCDC node
+// @Summary Get the memory accounted by the components of a TiCDC node
+// @Description This API is a synchronous interface. If the request is successful,
+// the memory limits and the memory accounted by each component of the node are returned,
+// ordered by the priority in which the components are throttled.
+//
+// @Tags common,v2
+// @Produce json
+// @Success 200 {object} MemoryUsage
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/memory [get]
+func (h *OpenAPIV2) memoryUsage(c *gin.Context) {
+	registry := memory.GetGlobalRegistry()
+	soft, hard := registry.Limits()
+	usage := MemoryUsage{
+		SoftLimit:  soft,
+		HardLimit:  hard,
+		Total:      registry.Total(),
+		Components: make([]ComponentMemoryUsage, 0),
+	}
+	for _, a := range registry.Accounts() {
+		usage.Components = append(usage.Components, ComponentMemoryUsage{
+			Component: a.Component(),
+			Priority:  a.Priority().String(),
+			Used:      a.Used(),
+			Throttled: a.Throttled(),
+		})
+	}
+	c.IndentedJSON(http.StatusOK, usage)
+}
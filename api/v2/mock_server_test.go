@@ -0,0 +1,148 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	mock_etcd "github.com/pingcap/ticdc/pkg/etcd/mock"
+	"github.com/pingcap/ticdc/pkg/server"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+)
+
+// mockServer serves the api handlers with the mocked coordinator, pd client and etcd client.
+type mockServer struct {
+	server.Server
+	coordinator *mockCoordinator
+	pdClient    *mockPDClient
+	etcdClient  *mock_etcd.MockCDCEtcdClient
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(gomock.NewController(t))
+	etcdClient.EXPECT().GetEnsureGCServiceID(gomock.Any()).Return("ticdc-api-test").AnyTimes()
+	return &mockServer{
+		coordinator: newMockCoordinator(),
+		pdClient:    &mockPDClient{},
+		etcdClient:  etcdClient,
+	}
+}
+
+func (s *mockServer) GetCoordinator() (server.Coordinator, error) {
+	return s.coordinator, nil
+}
+
+func (s *mockServer) GetPdClient() pd.Client {
+	return s.pdClient
+}
+
+func (s *mockServer) GetEtcdClient() etcd.CDCEtcdClient {
+	return s.etcdClient
+}
+
+// mockCoordinator keeps the changefeeds in memory.
+type mockCoordinator struct {
+	server.Coordinator
+	infos    map[common.ChangeFeedDisplayName]*config.ChangeFeedInfo
+	statuses map[common.ChangeFeedDisplayName]*config.ChangeFeedStatus
+}
+
+func newMockCoordinator() *mockCoordinator {
+	return &mockCoordinator{
+		infos:    make(map[common.ChangeFeedDisplayName]*config.ChangeFeedInfo),
+		statuses: make(map[common.ChangeFeedDisplayName]*config.ChangeFeedStatus),
+	}
+}
+
+func (c *mockCoordinator) addChangefeed(info *config.ChangeFeedInfo, checkpointTs uint64) {
+	name := info.ChangefeedID.DisplayName
+	c.infos[name] = info
+	c.statuses[name] = &config.ChangeFeedStatus{CheckpointTs: checkpointTs}
+}
+
+func (c *mockCoordinator) GetChangefeed(
+	_ context.Context, name common.ChangeFeedDisplayName,
+) (*config.ChangeFeedInfo, *config.ChangeFeedStatus, error) {
+	info, ok := c.infos[name]
+	if !ok {
+		return nil, nil, errors.ErrChangeFeedNotExists.GenWithStackByArgs(name.Name)
+	}
+	return info, c.statuses[name], nil
+}
+
+func (c *mockCoordinator) CreateChangefeed(_ context.Context, info *config.ChangeFeedInfo) error {
+	if _, ok := c.infos[info.ChangefeedID.DisplayName]; ok {
+		return errors.ErrAPIInvalidParam.GenWithStack("changefeed %s already exists", info.ChangefeedID.Name())
+	}
+	c.addChangefeed(info, info.StartTs)
+	return nil
+}
+
+func (c *mockCoordinator) ResumeChangefeed(
+	_ context.Context, id common.ChangeFeedID, newCheckpointTs uint64, overwriteCheckpointTs bool,
+) error {
+	info, ok := c.infos[id.DisplayName]
+	if !ok {
+		return errors.ErrChangeFeedNotExists.GenWithStackByArgs(id.Name())
+	}
+	info.State = model.StateNormal
+	info.PausedForImport = false
+	if overwriteCheckpointTs {
+		c.statuses[id.DisplayName].CheckpointTs = newCheckpointTs
+	}
+	return nil
+}
+
+// mockPDClient returns the current tso and accepts all service gc safepoints.
+type mockPDClient struct {
+	pd.Client
+	clusterID uint64
+}
+
+func (c *mockPDClient) GetClusterID(context.Context) uint64 {
+	return c.clusterID
+}
+
+func (c *mockPDClient) GetTS(context.Context) (int64, int64, error) {
+	return oracle.GetPhysical(time.Now()), 0, nil
+}
+
+func (c *mockPDClient) UpdateServiceGCSafePoint(context.Context, string, int64, uint64) (uint64, error) {
+	return 0, nil
+}
+
+// serveAPI calls the handler with the request, and returns the response and the errors of the handler.
+func serveAPI(
+	handler gin.HandlerFunc, method string, params gin.Params, body []byte,
+) (*httptest.ResponseRecorder, []*gin.Error) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, "/", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w, c.Errors
+}
@@ -60,6 +60,21 @@ type TableName struct {
 	IsPartition bool   `json:"is_partition"`
 }
 
+// VerifyFilterConfig is used by the filter verification api
+type VerifyFilterConfig struct {
+	Rules         []string `json:"rules"`
+	CaseSensitive bool     `json:"case_sensitive"`
+	// Ts is the ts to load the tables at, the current ts is used if it's 0.
+	Ts uint64 `json:"ts"`
+}
+
+// VerifyFilterResult shows the tables matched by the table filter rules
+type VerifyFilterResult struct {
+	Ts              uint64      `json:"ts"`
+	MatchedTables   []TableName `json:"matched_tables"`
+	UnmatchedTables []TableName `json:"unmatched_tables"`
+}
+
 // VerifyTableConfig use to verify tables.
 // Only use by Open API v2.
 type VerifyTableConfig struct {
@@ -81,6 +96,63 @@ type ResumeChangefeedConfig struct {
 	OverwriteCheckpointTs uint64 `json:"overwrite_checkpoint_ts"`
 }
 
+// ImportFinishConfig is used by the import finish api
+type ImportFinishConfig struct {
+	// ImportFinishTs is the ts the physical import finishes, the changefeed
+	// is resumed from it and the data imported before it is skipped.
+	ImportFinishTs uint64 `json:"import_finish_ts"`
+}
+
+// ChangefeedExport is the portable definition and state of a changefeed, it's returned by
+// the export api and accepted by the import api of a cluster attached to the same upstream.
+type ChangefeedExport struct {
+	Version int `json:"version"`
+	// ClusterID is the id of the TiCDC cluster the changefeed is exported from.
+	ClusterID  string `json:"cluster_id"`
+	UpstreamID uint64 `json:"upstream_id"`
+	// CheckpointTs is the checkpoint of the exported changefeed, the imported one starts from it.
+	CheckpointTs uint64                 `json:"checkpoint_ts"`
+	Info         *config.ChangeFeedInfo `json:"info"`
+	// Redacted is true if the credentials in the sink uri and the config are masked,
+	// they must be supplied again by SinkURI and ReplicaConfig when it's imported.
+	Redacted bool `json:"redacted"`
+	// SinkURI replaces the sink uri of the exported changefeed when it's imported.
+	SinkURI string `json:"sink_uri,omitempty"`
+	// ReplicaConfig replaces the config of the exported changefeed when it's imported.
+	ReplicaConfig *config.ReplicaConfig `json:"replica_config,omitempty"`
+}
+
+// DDLInterventionConfig is used by the ddl intervention api
+type DDLInterventionConfig struct {
+	// CommitTs is the commit ts of the ddl failed in the downstream.
+	CommitTs uint64 `json:"commit_ts"`
+	// Action is either skip or replace.
+	Action string `json:"action"`
+	// Query is executed instead of the ddl if the action is replace.
+	Query string `json:"query"`
+}
+
+// IngestAckConfig is used by the ingest ack api
+type IngestAckConfig struct {
+	// CommitTs is the commit ts of the ingest event the changefeed is paused at.
+	CommitTs uint64 `json:"commit_ts"`
+}
+
+// ChangefeedTemplate is a named replica config profile used to create changefeeds
+type ChangefeedTemplate struct {
+	Name          string         `json:"name"`
+	Description   string         `json:"description,omitempty"`
+	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	UpdateTime    time.Time      `json:"update_time"`
+}
+
+// ChangefeedEvents is the response of the changefeed watch api
+type ChangefeedEvents struct {
+	// Revision is the latest revision, the next watch request waits for the events after it.
+	Revision uint64                    `json:"revision"`
+	Events   []*config.ChangefeedEvent `json:"events"`
+}
+
 // PDConfig is a configuration used to connect to pd
 type PDConfig struct {
 	PDAddrs       []string `json:"pd_addrs,omitempty"`
@@ -110,6 +182,18 @@ type SyncedStatusConfig struct {
 	CheckpointInterval int64 `json:"checkpoint_interval"`
 }
 
+// ScheduleConfig represents the windows in which the changefeed is paused automatically
+type ScheduleConfig struct {
+	PauseWindows []*PauseWindow `json:"pause_windows,omitempty"`
+}
+
+// PauseWindow represents a window which starts at the time matched by the
+// cron spec and lasts for the duration
+type PauseWindow struct {
+	Cron     string `json:"cron"`
+	Duration string `json:"duration"`
+}
+
 // MarshalJSON marshal changefeed common info to json
 // we need to set feed state to normal if it is uninitialized and pending to warning
 // to hide the detail of uninitialized and pending state from user
@@ -139,6 +223,12 @@ type ChangefeedConfig struct {
 	TargetTs      uint64         `json:"target_ts"`
 	SinkURI       string         `json:"sink_uri"`
 	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	// PausedForImport creates the changefeed paused at the start ts, which is the start ts
+	// of a physical import, it's resumed by the import finish api.
+	PausedForImport bool `json:"paused_for_import,omitempty"`
+	// Template is the name of the changefeed template whose replica config is used
+	// as the base, the replica config in the request overrides it.
+	Template string `json:"template,omitempty"`
 	PDConfig
 }
 
@@ -183,14 +273,16 @@ func (d *JSONDuration) UnmarshalJSON(b []byte) error {
 
 // ReplicaConfig is a duplicate of  config.ReplicaConfig
 type ReplicaConfig struct {
-	MemoryQuota           uint64 `json:"memory_quota"`
-	CaseSensitive         bool   `json:"case_sensitive"`
-	ForceReplicate        bool   `json:"force_replicate"`
-	IgnoreIneligibleTable bool   `json:"ignore_ineligible_table"`
-	CheckGCSafePoint      bool   `json:"check_gc_safe_point"`
-	EnableSyncPoint       *bool  `json:"enable_sync_point,omitempty"`
-	EnableTableMonitor    *bool  `json:"enable_table_monitor,omitempty"`
-	BDRMode               *bool  `json:"bdr_mode,omitempty"`
+	ConfigVersion         int     `json:"config_version,omitempty"`
+	MemoryQuota           uint64  `json:"memory_quota"`
+	CaseSensitive         bool    `json:"case_sensitive"`
+	ForceReplicate        bool    `json:"force_replicate"`
+	IgnoreIneligibleTable bool    `json:"ignore_ineligible_table"`
+	CheckGCSafePoint      bool    `json:"check_gc_safe_point"`
+	TimeZone              *string `json:"time_zone,omitempty"`
+	EnableSyncPoint       *bool   `json:"enable_sync_point,omitempty"`
+	EnableTableMonitor    *bool   `json:"enable_table_monitor,omitempty"`
+	BDRMode               *bool   `json:"bdr_mode,omitempty"`
 
 	SyncPointInterval  *JSONDuration `json:"sync_point_interval,omitempty" swaggertype:"string"`
 	SyncPointRetention *JSONDuration `json:"sync_point_retention,omitempty" swaggertype:"string"`
@@ -203,6 +295,10 @@ type ReplicaConfig struct {
 	Integrity                    *IntegrityConfig           `json:"integrity"`
 	ChangefeedErrorStuckDuration *JSONDuration              `json:"changefeed_error_stuck_duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig        `json:"synced_status,omitempty"`
+	LatencyMode                  *string                    `json:"latency_mode,omitempty"`
+	OldValueMode                 *string                    `json:"old_value_mode,omitempty"`
+	Schedule                     *ScheduleConfig            `json:"schedule,omitempty"`
+	IngestStrategy               *string                    `json:"ingest_strategy,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `json:"sql_mode,omitempty"`
@@ -217,10 +313,14 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 	res *config.ReplicaConfig,
 ) *config.ReplicaConfig {
+	if c.ConfigVersion != 0 {
+		res.ConfigVersion = c.ConfigVersion
+	}
 	res.MemoryQuota = c.MemoryQuota
 	res.CaseSensitive = c.CaseSensitive
 	res.ForceReplicate = c.ForceReplicate
 	res.CheckGCSafePoint = c.CheckGCSafePoint
+	res.TimeZone = c.TimeZone
 	res.EnableSyncPoint = c.EnableSyncPoint
 	res.EnableTableMonitor = c.EnableTableMonitor
 	res.IgnoreIneligibleTable = c.IgnoreIneligibleTable
@@ -275,6 +375,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				IndexName:      rule.IndexName,
 				Columns:        rule.Columns,
 				TopicRule:      rule.TopicRule,
+				Protocol:       rule.Protocol,
 			})
 		}
 		var columnSelectors []*config.ColumnSelector
@@ -339,8 +440,10 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 					MaxBatchSize:                   oldConfig.MaxBatchSize,
 					AvroEnableWatermark:            oldConfig.AvroEnableWatermark,
 					AvroDecimalHandlingMode:        oldConfig.AvroDecimalHandlingMode,
+					AvroDecimalMaxPrecision:        oldConfig.AvroDecimalMaxPrecision,
 					AvroBigintUnsignedHandlingMode: oldConfig.AvroBigintUnsignedHandlingMode,
 					EncodingFormat:                 oldConfig.EncodingFormat,
+					DebeziumEmitTombstone:          oldConfig.DebeziumEmitTombstone,
 				}
 			}
 
@@ -377,6 +480,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				DialTimeout:                  c.Sink.KafkaConfig.DialTimeout,
 				WriteTimeout:                 c.Sink.KafkaConfig.WriteTimeout,
 				ReadTimeout:                  c.Sink.KafkaConfig.ReadTimeout,
+				BatchLinger:                  c.Sink.KafkaConfig.BatchLinger,
+				BatchBytes:                   c.Sink.KafkaConfig.BatchBytes,
 				RequiredAcks:                 c.Sink.KafkaConfig.RequiredAcks,
 				SASLUser:                     c.Sink.KafkaConfig.SASLUser,
 				SASLPassword:                 c.Sink.KafkaConfig.SASLPassword,
@@ -424,6 +529,16 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				EnableBatchDML:               c.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         c.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: c.Sink.MySQLConfig.EnableCachePreparedStatement,
+				SessionVariables:             c.Sink.MySQLConfig.SessionVariables,
+				DDLDryRun:                    c.Sink.MySQLConfig.DDLDryRun,
+				ExactlyOnce:                  c.Sink.MySQLConfig.ExactlyOnce,
+			}
+			for _, override := range c.Sink.MySQLConfig.TableOverrides {
+				mysqlConfig.TableOverrides = append(mysqlConfig.TableOverrides, &config.MySQLTableOverride{
+					Matcher:     override.Matcher,
+					WorkerCount: override.WorkerCount,
+					MaxTxnRow:   override.MaxTxnRow,
+				})
 			}
 		}
 		var cloudStorageConfig *config.CloudStorageConfig
@@ -467,6 +582,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			OnlyOutputUpdatedColumns:         c.Sink.OnlyOutputUpdatedColumns,
 			DeleteOnlyOutputHandleKeyColumns: c.Sink.DeleteOnlyOutputHandleKeyColumns,
 			ContentCompatible:                c.Sink.ContentCompatible,
+			SplitHandleKeyUpdate:             c.Sink.SplitHandleKeyUpdate,
 			KafkaConfig:                      kafkaConfig,
 			MySQLConfig:                      mysqlConfig,
 			PulsarConfig:                     pulsarConfig,
@@ -501,6 +617,69 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		if c.Sink.SendAllBootstrapAtStart != nil {
 			res.Sink.SendAllBootstrapAtStart = util.AddressOf(*c.Sink.SendAllBootstrapAtStart)
 		}
+
+		if c.Sink.EnableRowCountAudit != nil {
+			res.Sink.EnableRowCountAudit = util.AddressOf(*c.Sink.EnableRowCountAudit)
+		}
+		if c.Sink.RowCountAuditTopic != nil {
+			res.Sink.RowCountAuditTopic = util.AddressOf(*c.Sink.RowCountAuditTopic)
+		}
+
+		if len(c.Sink.AdditionalSinkURIs) > 0 {
+			res.Sink.AdditionalSinkURIs = append([]string(nil), c.Sink.AdditionalSinkURIs...)
+		}
+
+		if c.Sink.DeadLetterQueue != nil {
+			res.Sink.DeadLetterQueue = &config.DeadLetterQueueConfig{
+				Topic: c.Sink.DeadLetterQueue.Topic,
+			}
+		}
+
+		if c.Sink.DDLTopic != nil {
+			res.Sink.DDLTopic = &config.DDLTopicConfig{
+				Topic:     c.Sink.DDLTopic.Topic,
+				Partition: c.Sink.DDLTopic.Partition,
+				Protocol:  c.Sink.DDLTopic.Protocol,
+			}
+		}
+
+		if c.Sink.MaxRowsPerSecond != nil {
+			res.Sink.MaxRowsPerSecond = util.AddressOf(*c.Sink.MaxRowsPerSecond)
+		}
+
+		if c.Sink.MaxBytesPerSecond != nil {
+			res.Sink.MaxBytesPerSecond = util.AddressOf(*c.Sink.MaxBytesPerSecond)
+		}
+
+		if c.Sink.Watermark != nil {
+			res.Sink.Watermark = &config.WatermarkConfig{
+				Interval: c.Sink.Watermark.Interval,
+				Scope:    c.Sink.Watermark.Scope,
+			}
+		}
+
+		if c.Sink.TTLDeletes != nil {
+			res.Sink.TTLDeletes = &config.TTLDeletesConfig{
+				Action: c.Sink.TTLDeletes.Action,
+				Topic:  c.Sink.TTLDeletes.Topic,
+			}
+		}
+
+		if c.Sink.RemovalCleanup != nil {
+			res.Sink.RemovalCleanup = &config.RemovalCleanupConfig{
+				DropSyncPointRows: c.Sink.RemovalCleanup.DropSyncPointRows,
+				WriteEOFMessage:   c.Sink.RemovalCleanup.WriteEOFMessage,
+			}
+		}
+
+		for _, rule := range c.Sink.RoutingRules {
+			res.Sink.RoutingRules = append(res.Sink.RoutingRules, &config.RoutingRule{
+				SourceSchema: rule.SourceSchema,
+				SourceTable:  rule.SourceTable,
+				TargetSchema: rule.TargetSchema,
+				TargetTable:  rule.TargetTable,
+			})
+		}
 	}
 	if c.Mounter != nil {
 		res.Mounter = &config.MounterConfig{
@@ -509,9 +688,27 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 	}
 	if c.Scheduler != nil {
 		res.Scheduler = &config.ChangefeedSchedulerConfig{
-			EnableTableAcrossNodes: c.Scheduler.EnableTableAcrossNodes,
-			RegionThreshold:        c.Scheduler.RegionThreshold,
-			WriteKeyThreshold:      c.Scheduler.WriteKeyThreshold,
+			EnableTableAcrossNodes:    c.Scheduler.EnableTableAcrossNodes,
+			RegionThreshold:           c.Scheduler.RegionThreshold,
+			WriteKeyThreshold:         c.Scheduler.WriteKeyThreshold,
+			EnableAutoTuneThreshold:   c.Scheduler.EnableAutoTuneThreshold,
+			PlacementStrategy:         c.Scheduler.PlacementStrategy,
+			MaxSpansPerTablePerNode:   c.Scheduler.MaxSpansPerTablePerNode,
+			BootstrapSkipTimeoutNodes: c.Scheduler.BootstrapSkipTimeoutNodes,
+			BootstrapStartTs:          c.Scheduler.BootstrapStartTs,
+			GroupBy:                   c.Scheduler.GroupBy,
+		}
+		if c.Scheduler.DDLBatchWindow != nil {
+			res.Scheduler.DDLBatchWindow = config.TomlDuration(c.Scheduler.DDLBatchWindow.duration)
+		}
+		if c.Scheduler.BootstrapTimeout != nil {
+			res.Scheduler.BootstrapTimeout = config.TomlDuration(c.Scheduler.BootstrapTimeout.duration)
+		}
+		for _, rule := range c.Scheduler.GroupTags {
+			res.Scheduler.GroupTags = append(res.Scheduler.GroupTags, &config.GroupTagRule{
+				Matcher: rule.Matcher,
+				Tag:     rule.Tag,
+			})
 		}
 	}
 	if c.Integrity != nil {
@@ -529,6 +726,27 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			CheckpointInterval:  c.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if c.LatencyMode != nil {
+		mode := config.LatencyMode(*c.LatencyMode)
+		res.LatencyMode = &mode
+	}
+	if c.OldValueMode != nil {
+		mode := config.OldValueMode(*c.OldValueMode)
+		res.OldValueMode = &mode
+	}
+	if c.IngestStrategy != nil {
+		strategy := config.IngestStrategy(*c.IngestStrategy)
+		res.IngestStrategy = &strategy
+	}
+	if c.Schedule != nil {
+		res.Schedule = &config.ScheduleConfig{}
+		for _, w := range c.Schedule.PauseWindows {
+			res.Schedule.PauseWindows = append(res.Schedule.PauseWindows, &config.PauseWindow{
+				Cron:     w.Cron,
+				Duration: w.Duration,
+			})
+		}
+	}
 	return res
 }
 
@@ -537,11 +755,13 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 	cloned := c.Clone()
 
 	res := &ReplicaConfig{
+		ConfigVersion:         cloned.ConfigVersion,
 		MemoryQuota:           cloned.MemoryQuota,
 		CaseSensitive:         cloned.CaseSensitive,
 		ForceReplicate:        cloned.ForceReplicate,
 		IgnoreIneligibleTable: cloned.IgnoreIneligibleTable,
 		CheckGCSafePoint:      cloned.CheckGCSafePoint,
+		TimeZone:              cloned.TimeZone,
 		EnableSyncPoint:       cloned.EnableSyncPoint,
 		EnableTableMonitor:    cloned.EnableTableMonitor,
 		BDRMode:               cloned.BDRMode,
@@ -579,6 +799,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				IndexName:     rule.IndexName,
 				Columns:       rule.Columns,
 				TopicRule:     rule.TopicRule,
+				Protocol:      rule.Protocol,
 			})
 		}
 		var columnSelectors []*ColumnSelector
@@ -610,8 +831,10 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 					MaxBatchSize:                   oldConfig.MaxBatchSize,
 					AvroEnableWatermark:            oldConfig.AvroEnableWatermark,
 					AvroDecimalHandlingMode:        oldConfig.AvroDecimalHandlingMode,
+					AvroDecimalMaxPrecision:        oldConfig.AvroDecimalMaxPrecision,
 					AvroBigintUnsignedHandlingMode: oldConfig.AvroBigintUnsignedHandlingMode,
 					EncodingFormat:                 oldConfig.EncodingFormat,
+					DebeziumEmitTombstone:          oldConfig.DebeziumEmitTombstone,
 				}
 			}
 
@@ -648,6 +871,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				DialTimeout:                  cloned.Sink.KafkaConfig.DialTimeout,
 				WriteTimeout:                 cloned.Sink.KafkaConfig.WriteTimeout,
 				ReadTimeout:                  cloned.Sink.KafkaConfig.ReadTimeout,
+				BatchLinger:                  cloned.Sink.KafkaConfig.BatchLinger,
+				BatchBytes:                   cloned.Sink.KafkaConfig.BatchBytes,
 				RequiredAcks:                 cloned.Sink.KafkaConfig.RequiredAcks,
 				SASLUser:                     cloned.Sink.KafkaConfig.SASLUser,
 				SASLPassword:                 cloned.Sink.KafkaConfig.SASLPassword,
@@ -695,6 +920,16 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				EnableBatchDML:               cloned.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         cloned.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: cloned.Sink.MySQLConfig.EnableCachePreparedStatement,
+				SessionVariables:             cloned.Sink.MySQLConfig.SessionVariables,
+				DDLDryRun:                    cloned.Sink.MySQLConfig.DDLDryRun,
+				ExactlyOnce:                  cloned.Sink.MySQLConfig.ExactlyOnce,
+			}
+			for _, override := range cloned.Sink.MySQLConfig.TableOverrides {
+				mysqlConfig.TableOverrides = append(mysqlConfig.TableOverrides, &MySQLTableOverride{
+					Matcher:     override.Matcher,
+					WorkerCount: override.WorkerCount,
+					MaxTxnRow:   override.MaxTxnRow,
+				})
 			}
 		}
 		var pulsarConfig *PulsarConfig
@@ -769,6 +1004,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			OnlyOutputUpdatedColumns:         cloned.Sink.OnlyOutputUpdatedColumns,
 			DeleteOnlyOutputHandleKeyColumns: cloned.Sink.DeleteOnlyOutputHandleKeyColumns,
 			ContentCompatible:                cloned.Sink.ContentCompatible,
+			SplitHandleKeyUpdate:             cloned.Sink.SplitHandleKeyUpdate,
 			KafkaConfig:                      kafkaConfig,
 			MySQLConfig:                      mysqlConfig,
 			PulsarConfig:                     pulsarConfig,
@@ -804,6 +1040,69 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		if cloned.Sink.DebeziumDisableSchema != nil {
 			res.Sink.DebeziumDisableSchema = util.AddressOf(*cloned.Sink.DebeziumDisableSchema)
 		}
+
+		if cloned.Sink.EnableRowCountAudit != nil {
+			res.Sink.EnableRowCountAudit = util.AddressOf(*cloned.Sink.EnableRowCountAudit)
+		}
+		if cloned.Sink.RowCountAuditTopic != nil {
+			res.Sink.RowCountAuditTopic = util.AddressOf(*cloned.Sink.RowCountAuditTopic)
+		}
+
+		if len(cloned.Sink.AdditionalSinkURIs) > 0 {
+			res.Sink.AdditionalSinkURIs = cloned.Sink.AdditionalSinkURIs
+		}
+
+		if cloned.Sink.DeadLetterQueue != nil {
+			res.Sink.DeadLetterQueue = &DeadLetterQueueConfig{
+				Topic: cloned.Sink.DeadLetterQueue.Topic,
+			}
+		}
+
+		if cloned.Sink.DDLTopic != nil {
+			res.Sink.DDLTopic = &DDLTopicConfig{
+				Topic:     cloned.Sink.DDLTopic.Topic,
+				Partition: cloned.Sink.DDLTopic.Partition,
+				Protocol:  cloned.Sink.DDLTopic.Protocol,
+			}
+		}
+
+		if cloned.Sink.MaxRowsPerSecond != nil {
+			res.Sink.MaxRowsPerSecond = util.AddressOf(*cloned.Sink.MaxRowsPerSecond)
+		}
+
+		if cloned.Sink.MaxBytesPerSecond != nil {
+			res.Sink.MaxBytesPerSecond = util.AddressOf(*cloned.Sink.MaxBytesPerSecond)
+		}
+
+		if cloned.Sink.Watermark != nil {
+			res.Sink.Watermark = &WatermarkConfig{
+				Interval: cloned.Sink.Watermark.Interval,
+				Scope:    cloned.Sink.Watermark.Scope,
+			}
+		}
+
+		if cloned.Sink.TTLDeletes != nil {
+			res.Sink.TTLDeletes = &TTLDeletesConfig{
+				Action: cloned.Sink.TTLDeletes.Action,
+				Topic:  cloned.Sink.TTLDeletes.Topic,
+			}
+		}
+
+		if cloned.Sink.RemovalCleanup != nil {
+			res.Sink.RemovalCleanup = &RemovalCleanupConfig{
+				DropSyncPointRows: cloned.Sink.RemovalCleanup.DropSyncPointRows,
+				WriteEOFMessage:   cloned.Sink.RemovalCleanup.WriteEOFMessage,
+			}
+		}
+
+		for _, rule := range cloned.Sink.RoutingRules {
+			res.Sink.RoutingRules = append(res.Sink.RoutingRules, &RoutingRule{
+				SourceSchema: rule.SourceSchema,
+				SourceTable:  rule.SourceTable,
+				TargetSchema: rule.TargetSchema,
+				TargetTable:  rule.TargetTable,
+			})
+		}
 	}
 	if cloned.Consistent != nil {
 		res.Consistent = &ConsistentConfig{
@@ -832,9 +1131,27 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 	}
 	if cloned.Scheduler != nil {
 		res.Scheduler = &ChangefeedSchedulerConfig{
-			EnableTableAcrossNodes: cloned.Scheduler.EnableTableAcrossNodes,
-			RegionThreshold:        cloned.Scheduler.RegionThreshold,
-			WriteKeyThreshold:      cloned.Scheduler.WriteKeyThreshold,
+			EnableTableAcrossNodes:    cloned.Scheduler.EnableTableAcrossNodes,
+			RegionThreshold:           cloned.Scheduler.RegionThreshold,
+			WriteKeyThreshold:         cloned.Scheduler.WriteKeyThreshold,
+			EnableAutoTuneThreshold:   cloned.Scheduler.EnableAutoTuneThreshold,
+			PlacementStrategy:         cloned.Scheduler.PlacementStrategy,
+			MaxSpansPerTablePerNode:   cloned.Scheduler.MaxSpansPerTablePerNode,
+			BootstrapSkipTimeoutNodes: cloned.Scheduler.BootstrapSkipTimeoutNodes,
+			BootstrapStartTs:          cloned.Scheduler.BootstrapStartTs,
+			GroupBy:                   cloned.Scheduler.GroupBy,
+		}
+		if cloned.Scheduler.DDLBatchWindow > 0 {
+			res.Scheduler.DDLBatchWindow = &JSONDuration{time.Duration(cloned.Scheduler.DDLBatchWindow)}
+		}
+		if cloned.Scheduler.BootstrapTimeout > 0 {
+			res.Scheduler.BootstrapTimeout = &JSONDuration{time.Duration(cloned.Scheduler.BootstrapTimeout)}
+		}
+		for _, rule := range cloned.Scheduler.GroupTags {
+			res.Scheduler.GroupTags = append(res.Scheduler.GroupTags, &GroupTagRule{
+				Matcher: rule.Matcher,
+				Tag:     rule.Tag,
+			})
 		}
 	}
 
@@ -853,6 +1170,27 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			CheckpointInterval:  cloned.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if cloned.LatencyMode != nil {
+		mode := string(*cloned.LatencyMode)
+		res.LatencyMode = &mode
+	}
+	if cloned.OldValueMode != nil {
+		mode := string(*cloned.OldValueMode)
+		res.OldValueMode = &mode
+	}
+	if cloned.IngestStrategy != nil {
+		strategy := string(*cloned.IngestStrategy)
+		res.IngestStrategy = &strategy
+	}
+	if cloned.Schedule != nil {
+		res.Schedule = &ScheduleConfig{}
+		for _, w := range cloned.Schedule.PauseWindows {
+			res.Schedule.PauseWindows = append(res.Schedule.PauseWindows, &PauseWindow{
+				Cron:     w.Cron,
+				Duration: w.Duration,
+			})
+		}
+	}
 	return res
 }
 
@@ -942,34 +1280,46 @@ type Table struct {
 // SinkConfig represents sink config for a changefeed
 // This is a duplicate of config.SinkConfig
 type SinkConfig struct {
-	Protocol                         *string             `json:"protocol,omitempty"`
-	SchemaRegistry                   *string             `json:"schema_registry,omitempty"`
-	CSVConfig                        *CSVConfig          `json:"csv,omitempty"`
-	DispatchRules                    []*DispatchRule     `json:"dispatchers,omitempty"`
-	ColumnSelectors                  []*ColumnSelector   `json:"column_selectors,omitempty"`
-	TxnAtomicity                     *string             `json:"transaction_atomicity,omitempty"`
-	EncoderConcurrency               *int                `json:"encoder_concurrency,omitempty"`
-	Terminator                       *string             `json:"terminator,omitempty"`
-	DateSeparator                    *string             `json:"date_separator,omitempty"`
-	EnablePartitionSeparator         *bool               `json:"enable_partition_separator,omitempty"`
-	FileIndexWidth                   *int                `json:"file_index_width,omitempty"`
-	EnableKafkaSinkV2                *bool               `json:"enable_kafka_sink_v2,omitempty"`
-	OnlyOutputUpdatedColumns         *bool               `json:"only_output_updated_columns,omitempty"`
-	DeleteOnlyOutputHandleKeyColumns *bool               `json:"delete_only_output_handle_key_columns"`
-	ContentCompatible                *bool               `json:"content_compatible"`
-	SafeMode                         *bool               `json:"safe_mode,omitempty"`
-	KafkaConfig                      *KafkaConfig        `json:"kafka_config,omitempty"`
-	PulsarConfig                     *PulsarConfig       `json:"pulsar_config,omitempty"`
-	MySQLConfig                      *MySQLConfig        `json:"mysql_config,omitempty"`
-	CloudStorageConfig               *CloudStorageConfig `json:"cloud_storage_config,omitempty"`
-	AdvanceTimeoutInSec              *uint               `json:"advance_timeout,omitempty"`
-	SendBootstrapIntervalInSec       *int64              `json:"send_bootstrap_interval_in_sec,omitempty"`
-	SendBootstrapInMsgCount          *int32              `json:"send_bootstrap_in_msg_count,omitempty"`
-	SendBootstrapToAllPartition      *bool               `json:"send_bootstrap_to_all_partition,omitempty"`
-	SendAllBootstrapAtStart          *bool               `json:"send-all-bootstrap-at-start,omitempty"`
-	DebeziumDisableSchema            *bool               `json:"debezium_disable_schema,omitempty"`
-	DebeziumConfig                   *DebeziumConfig     `json:"debezium,omitempty"`
-	OpenProtocolConfig               *OpenProtocolConfig `json:"open,omitempty"`
+	Protocol                         *string                `json:"protocol,omitempty"`
+	SchemaRegistry                   *string                `json:"schema_registry,omitempty"`
+	CSVConfig                        *CSVConfig             `json:"csv,omitempty"`
+	DispatchRules                    []*DispatchRule        `json:"dispatchers,omitempty"`
+	ColumnSelectors                  []*ColumnSelector      `json:"column_selectors,omitempty"`
+	TxnAtomicity                     *string                `json:"transaction_atomicity,omitempty"`
+	EncoderConcurrency               *int                   `json:"encoder_concurrency,omitempty"`
+	Terminator                       *string                `json:"terminator,omitempty"`
+	DateSeparator                    *string                `json:"date_separator,omitempty"`
+	EnablePartitionSeparator         *bool                  `json:"enable_partition_separator,omitempty"`
+	FileIndexWidth                   *int                   `json:"file_index_width,omitempty"`
+	EnableKafkaSinkV2                *bool                  `json:"enable_kafka_sink_v2,omitempty"`
+	OnlyOutputUpdatedColumns         *bool                  `json:"only_output_updated_columns,omitempty"`
+	DeleteOnlyOutputHandleKeyColumns *bool                  `json:"delete_only_output_handle_key_columns"`
+	ContentCompatible                *bool                  `json:"content_compatible"`
+	SplitHandleKeyUpdate             *bool                  `json:"split_handle_key_update,omitempty"`
+	SafeMode                         *bool                  `json:"safe_mode,omitempty"`
+	KafkaConfig                      *KafkaConfig           `json:"kafka_config,omitempty"`
+	PulsarConfig                     *PulsarConfig          `json:"pulsar_config,omitempty"`
+	MySQLConfig                      *MySQLConfig           `json:"mysql_config,omitempty"`
+	CloudStorageConfig               *CloudStorageConfig    `json:"cloud_storage_config,omitempty"`
+	AdvanceTimeoutInSec              *uint                  `json:"advance_timeout,omitempty"`
+	SendBootstrapIntervalInSec       *int64                 `json:"send_bootstrap_interval_in_sec,omitempty"`
+	SendBootstrapInMsgCount          *int32                 `json:"send_bootstrap_in_msg_count,omitempty"`
+	SendBootstrapToAllPartition      *bool                  `json:"send_bootstrap_to_all_partition,omitempty"`
+	SendAllBootstrapAtStart          *bool                  `json:"send-all-bootstrap-at-start,omitempty"`
+	DebeziumDisableSchema            *bool                  `json:"debezium_disable_schema,omitempty"`
+	EnableRowCountAudit              *bool                  `json:"enable_row_count_audit,omitempty"`
+	RowCountAuditTopic               *string                `json:"row_count_audit_topic,omitempty"`
+	AdditionalSinkURIs               []string               `json:"additional_sink_uris,omitempty"`
+	DeadLetterQueue                  *DeadLetterQueueConfig `json:"dead_letter_queue,omitempty"`
+	DDLTopic                         *DDLTopicConfig        `json:"ddl_topic,omitempty"`
+	MaxRowsPerSecond                 *int64                 `json:"max_rows_per_second,omitempty"`
+	MaxBytesPerSecond                *int64                 `json:"max_bytes_per_second,omitempty"`
+	RoutingRules                     []*RoutingRule         `json:"routing_rules,omitempty"`
+	Watermark                        *WatermarkConfig       `json:"watermark,omitempty"`
+	TTLDeletes                       *TTLDeletesConfig      `json:"ttl_deletes,omitempty"`
+	RemovalCleanup                   *RemovalCleanupConfig  `json:"removal_cleanup,omitempty"`
+	DebeziumConfig                   *DebeziumConfig        `json:"debezium,omitempty"`
+	OpenProtocolConfig               *OpenProtocolConfig    `json:"open,omitempty"`
 }
 
 // CSVConfig denotes the csv config
@@ -1001,6 +1351,37 @@ type DispatchRule struct {
 	IndexName     string   `json:"index,omitempty"`
 	Columns       []string `json:"columns,omitempty"`
 	TopicRule     string   `json:"topic,omitempty"`
+	Protocol      string   `json:"protocol,omitempty"`
+}
+
+// WatermarkConfig represents the config of the watermark messages.
+// This is a duplicate of config.WatermarkConfig
+type WatermarkConfig struct {
+	Interval string `json:"interval"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+// TTLDeletesConfig represents how to handle the rows deleted by the TiDB TTL jobs.
+// This is a duplicate of config.TTLDeletesConfig
+type TTLDeletesConfig struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic,omitempty"`
+}
+
+// RemovalCleanupConfig represents the cleanup done in the downstream when the changefeed is removed.
+// This is a duplicate of config.RemovalCleanupConfig
+type RemovalCleanupConfig struct {
+	DropSyncPointRows *bool `json:"drop_syncpoint_rows,omitempty"`
+	WriteEOFMessage   *bool `json:"write_eof_message,omitempty"`
+}
+
+// RoutingRule maps the upstream tables to the downstream.
+// This is a duplicate of config.RoutingRule
+type RoutingRule struct {
+	SourceSchema string `json:"source_schema"`
+	SourceTable  string `json:"source_table,omitempty"`
+	TargetSchema string `json:"target_schema,omitempty"`
+	TargetTable  string `json:"target_table,omitempty"`
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -1042,6 +1423,39 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region_threshold" json:"region_threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write_key_threshold" json:"write_key_threshold"`
+	// EnableAutoTuneThreshold set true to tune the thresholds by the traffic
+	// of the changefeed and the node count.
+	EnableAutoTuneThreshold bool `toml:"enable_auto_tune_threshold" json:"enable_auto_tune_threshold"`
+	// PlacementStrategy decides how the spans are placed to nodes, it's one of
+	// "balance" and "consistent-hash".
+	PlacementStrategy string `toml:"placement_strategy" json:"placement_strategy,omitempty"`
+	// MaxSpansPerTablePerNode is the max span count of a split table on one
+	// node, 0 means no limit.
+	MaxSpansPerTablePerNode int `toml:"max_spans_per_table_per_node" json:"max_spans_per_table_per_node,omitempty"`
+	// DDLBatchWindow is the max commit ts distance of the ddls scheduled in one
+	// round by the maintainer, 0 disables the batch.
+	DDLBatchWindow *JSONDuration `toml:"ddl_batch_window" json:"ddl_batch_window,omitempty" swaggertype:"string"`
+	// BootstrapTimeout is the time the maintainer waits for the bootstrap
+	// responses of all nodes, 0 means waiting forever.
+	BootstrapTimeout *JSONDuration `toml:"bootstrap_timeout" json:"bootstrap_timeout,omitempty" swaggertype:"string"`
+	// BootstrapSkipTimeoutNodes set true to proceed the bootstrap without the
+	// nodes which don't respond in the bootstrap timeout.
+	BootstrapSkipTimeoutNodes bool `toml:"bootstrap_skip_timeout_nodes" json:"bootstrap_skip_timeout_nodes,omitempty"`
+	// BootstrapStartTs overrides the start ts reported in the bootstrap of
+	// the maintainer, 0 means using the reported start ts.
+	BootstrapStartTs uint64 `toml:"bootstrap_start_ts" json:"bootstrap_start_ts,omitempty"`
+	// GroupBy decides how the spans covering whole tables are grouped, it's
+	// one of "schema" and "tag", empty means all of them are in one group.
+	GroupBy string `toml:"group_by" json:"group_by,omitempty"`
+	// GroupTags are the rules to tag the tables when GroupBy is "tag".
+	GroupTags []*GroupTagRule `toml:"group_tags" json:"group_tags,omitempty"`
+}
+
+// GroupTagRule tags the tables matched by the Matcher.
+// This is a duplicate of config.GroupTagRule
+type GroupTagRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	Tag     string   `toml:"tag" json:"tag"`
 }
 
 // IntegrityConfig is the config for integrity check
@@ -1081,6 +1495,11 @@ type ChangeFeedInfo struct {
 	State          model.FeedState    `json:"state,omitempty"`
 	Error          *RunningError      `json:"error,omitempty"`
 	CreatorVersion string             `json:"creator_version,omitempty"`
+	// PausedForImport is true if the changefeed is waiting for a physical import to finish
+	PausedForImport bool `json:"paused_for_import,omitempty"`
+	// Warnings are the problems the changefeed is recovering from, such as the schema store
+	// is unavailable, they don't change the state of the changefeed.
+	Warnings []*RunningError `json:"warnings,omitempty"`
 
 	ResolvedTs     uint64                    `json:"resolved_ts"`
 	CheckpointTs   uint64                    `json:"checkpoint_ts"`
@@ -1105,6 +1524,11 @@ type RunningError struct {
 	Addr    string     `json:"addr"`
 	Code    string     `json:"code"`
 	Message string     `json:"message"`
+	// Class is the class of the error, such as scheduling, codec, sink and puller,
+	// the automation tools can branch on it.
+	Class string `json:"class,omitempty"`
+	// Retryable is false if the changefeed will not be restarted automatically.
+	Retryable bool `json:"retryable"`
 }
 
 // toCredential generates a security.Credential from a PDConfig
@@ -1173,12 +1597,52 @@ type ServerStatus struct {
 	Liveness  Liveness `json:"liveness"`
 }
 
+// MemoryUsage holds the memory accounted by the components of a server
+type MemoryUsage struct {
+	SoftLimit  int64                  `json:"soft_limit"`
+	HardLimit  int64                  `json:"hard_limit"`
+	Total      int64                  `json:"total"`
+	Components []ComponentMemoryUsage `json:"components"`
+}
+
+// ComponentMemoryUsage holds the memory accounted by a component
+type ComponentMemoryUsage struct {
+	Component string `json:"component"`
+	Priority  string `json:"priority"`
+	Used      int64  `json:"used"`
+	Throttled bool   `json:"throttled"`
+}
+
+// RegionUnmatchedPrewrites holds the unmatched prewrite rows of a region
+type RegionUnmatchedPrewrites struct {
+	SubscriptionID uint64              `json:"subscription_id"`
+	RegionID       uint64              `json:"region_id"`
+	OldestAge      JSONDuration        `json:"oldest_age"`
+	Count          int                 `json:"count"`
+	Size           int64               `json:"size"`
+	Prewrites      []UnmatchedPrewrite `json:"prewrites"`
+}
+
+// UnmatchedPrewrite holds a prewrite row which is not matched by its commit or rollback
+type UnmatchedPrewrite struct {
+	KeyHash string       `json:"key_hash"`
+	StartTs uint64       `json:"start_ts"`
+	Size    int64        `json:"size"`
+	Age     JSONDuration `json:"age"`
+}
+
 // Capture holds common information of a capture in cdc
 type Capture struct {
 	ID            string `json:"id"`
 	IsCoordinator bool   `json:"is_coordinator"`
 	AdvertiseAddr string `json:"address"`
 	ClusterID     string `json:"cluster_id"`
+	Version       string `json:"version,omitempty"`
+	GitHash       string `json:"git_hash,omitempty"`
+	// CPUCores and MemoryTotal are the resources available to the node.
+	CPUCores    int               `json:"cpu_cores,omitempty"`
+	MemoryTotal uint64            `json:"memory_total,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
 }
 
 // CodecConfig represents a MQ codec configuration
@@ -1187,8 +1651,10 @@ type CodecConfig struct {
 	MaxBatchSize                   *int    `json:"max_batch_size,omitempty"`
 	AvroEnableWatermark            *bool   `json:"avro_enable_watermark,omitempty"`
 	AvroDecimalHandlingMode        *string `json:"avro_decimal_handling_mode,omitempty"`
+	AvroDecimalMaxPrecision        *int    `json:"avro_decimal_max_precision,omitempty"`
 	AvroBigintUnsignedHandlingMode *string `json:"avro_bigint_unsigned_handling_mode,omitempty"`
 	EncodingFormat                 *string `json:"encoding_format,omitempty"`
+	DebeziumEmitTombstone          *bool   `json:"debezium_emit_tombstone,omitempty"`
 }
 
 // PulsarConfig represents a pulsar sink configuration
@@ -1235,6 +1701,8 @@ type KafkaConfig struct {
 	DialTimeout                  *string                   `json:"dial_timeout,omitempty"`
 	WriteTimeout                 *string                   `json:"write_timeout,omitempty"`
 	ReadTimeout                  *string                   `json:"read_timeout,omitempty"`
+	BatchLinger                  *string                   `json:"batch_linger,omitempty"`
+	BatchBytes                   *int                      `json:"batch_bytes,omitempty"`
 	RequiredAcks                 *int                      `json:"required_acks,omitempty"`
 	SASLUser                     *string                   `json:"sasl_user,omitempty"`
 	SASLPassword                 *string                   `json:"sasl_password,omitempty"`
@@ -1281,6 +1749,22 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `json:"enable_batch_dml,omitempty"`
 	EnableMultiStatement         *bool   `json:"enable_multi_statement,omitempty"`
 	EnableCachePreparedStatement *bool   `json:"enable_cache_prepared_statement,omitempty"`
+	// SessionVariables are set on all the connections to the downstream.
+	SessionVariables map[string]string `json:"session_variables,omitempty"`
+	// DDLDryRun validates the DDLs against the downstream without executing them.
+	DDLDryRun *bool `json:"ddl_dry_run,omitempty"`
+	// ExactlyOnce filters the transactions already applied to the downstream after a failover.
+	ExactlyOnce *bool `json:"exactly_once,omitempty"`
+	// TableOverrides give the matched tables their own dml workers.
+	TableOverrides []*MySQLTableOverride `json:"table_overrides,omitempty"`
+}
+
+// MySQLTableOverride is the sink concurrency of the matched tables.
+// This is a duplicate of config.MySQLTableOverride
+type MySQLTableOverride struct {
+	Matcher     []string `json:"matcher"`
+	WorkerCount int      `json:"worker_count"`
+	MaxTxnRow   int      `json:"max_txn_row,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -1317,6 +1801,18 @@ type GlueSchemaRegistryConfig struct {
 	Token           string `json:"token,omitempty"`
 }
 
+// DeadLetterQueueConfig represents the dead letter queue config of the sink.
+type DeadLetterQueueConfig struct {
+	Topic string `json:"topic"`
+}
+
+// DDLTopicConfig represents the dedicated topic of the DDL events.
+type DDLTopicConfig struct {
+	Topic     string  `json:"topic"`
+	Partition *int32  `json:"partition,omitempty"`
+	Protocol  *string `json:"protocol,omitempty"`
+}
+
 // OpenProtocolConfig represents the configurations for open protocol encoding
 type OpenProtocolConfig struct {
 	OutputOldValue bool `json:"output_old_value"`
@@ -1346,3 +1842,154 @@ func newNodeTableInfo(nodeID string) *NodeTableInfo {
 func (t *NodeTableInfo) addTableID(tableID int64) {
 	t.TableIDs = append(t.TableIDs, tableID)
 }
+
+// defaultLaggingTablesLimit is the default number of tables returned by the lagging tables API.
+const defaultLaggingTablesLimit = 10
+
+// LaggingTableInfo is the checkpoint lag of a table in the changefeed.
+type LaggingTableInfo struct {
+	TableID      int64   `json:"table_id"`
+	NodeID       string  `json:"node_id"`
+	CheckpointTs uint64  `json:"checkpoint_ts"`
+	LagSeconds   float64 `json:"lag_seconds"`
+}
+
+// MoveTablesConfig is used by the bulk move table api
+type MoveTablesConfig struct {
+	Moves []TableMoveConfig `json:"moves"`
+}
+
+// TableMoveConfig moves a table to the target node.
+type TableMoveConfig struct {
+	TableID      int64  `json:"table_id"`
+	TargetNodeID string `json:"target_node_id"`
+}
+
+// SpanMoveInfo is the progress of a span moved by the bulk move or the rebalance api.
+type SpanMoveInfo struct {
+	DispatcherID string `json:"dispatcher_id"`
+	TableID      int64  `json:"table_id"`
+	SourceNodeID string `json:"source_node_id"`
+	TargetNodeID string `json:"target_node_id"`
+	// State is one of planned, moving, finished and failed.
+	State string `json:"state"`
+}
+
+// ScheduleEvent is a scheduling milestone of the changefeed.
+type ScheduleEvent struct {
+	Time    time.Time `json:"time"`
+	Reason  string    `json:"reason"`
+	Message string    `json:"message"`
+}
+
+// maxInitProgressWait is the max duration the init progress API waits for the changefeed to be initialized.
+const maxInitProgressWait = time.Minute
+
+// InitProgress is the progress of the initialization of a changefeed.
+type InitProgress struct {
+	// Phase is one of bootstrapping, scheduling and working.
+	Phase          string  `json:"phase"`
+	TablesLoaded   int     `json:"tables_loaded"`
+	SpansCreated   int     `json:"spans_created"`
+	SpansScheduled int     `json:"spans_scheduled"`
+	SpansWorking   int     `json:"spans_working"`
+	PercentWorking float64 `json:"percent_working"`
+}
+
+// ConsistencyCheckConfig is the request of the consistency check API.
+// The table is checked at the latest syncpoint whose primary ts is in [StartTs, EndTs],
+// it's read from the upstream of the changefeed as the UpstreamUser.
+type ConsistencyCheckConfig struct {
+	UpstreamUser     string `json:"upstream_user"`
+	UpstreamPassword string `json:"upstream_password,omitempty"`
+	Schema           string `json:"schema"`
+	Table            string `json:"table"`
+	StartTs          uint64 `json:"start_ts"`
+	EndTs            uint64 `json:"end_ts"`
+	ChunkSize        int    `json:"chunk_size"`
+}
+
+// ConsistencyCheckStatus is the status of a consistency check running in background,
+// Result is set if it's finished and Error is set if it's failed.
+type ConsistencyCheckStatus struct {
+	ID     string                  `json:"id"`
+	State  string                  `json:"state"`
+	Error  string                  `json:"error,omitempty"`
+	Result *ConsistencyCheckResult `json:"result,omitempty"`
+}
+
+// ChunkMismatch is a chunk whose data is different between upstream and downstream,
+// the chunk contains the rows whose primary key is in (LowerBound, UpperBound].
+type ChunkMismatch struct {
+	Index              int      `json:"index"`
+	LowerBound         []string `json:"lower_bound,omitempty"`
+	UpperBound         []string `json:"upper_bound,omitempty"`
+	UpstreamCount      int64    `json:"upstream_count"`
+	DownstreamCount    int64    `json:"downstream_count"`
+	UpstreamChecksum   uint64   `json:"upstream_checksum"`
+	DownstreamChecksum uint64   `json:"downstream_checksum"`
+}
+
+// ConsistencyCheckResult is the response of the consistency check API.
+type ConsistencyCheckResult struct {
+	PrimaryTs   uint64           `json:"primary_ts"`
+	SecondaryTs uint64           `json:"secondary_ts"`
+	ChunkCount  int              `json:"chunk_count"`
+	Consistent  bool             `json:"consistent"`
+	Mismatches  []*ChunkMismatch `json:"mismatches"`
+}
+
+// UpgradeConfig is the request of the rolling upgrade API.
+type UpgradeConfig struct {
+	// MaxLagSeconds is the max checkpoint lag of the changefeeds allowed to upgrade the next node.
+	MaxLagSeconds int64 `json:"max_lag_seconds"`
+}
+
+// QuiesceStatus is the response of the quiesce API.
+type QuiesceStatus struct {
+	// State is one of none, holding, flushed and missed.
+	State        string `json:"state"`
+	HoldTs       uint64 `json:"hold_ts"`
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+}
+
+// FetchRowRequest is the request of the fetch row API, the row is identified by the values
+// of its handle key columns, which are formatted as strings.
+type FetchRowRequest struct {
+	Schema    string            `json:"schema"`
+	Table     string            `json:"table"`
+	CommitTs  uint64            `json:"commit_ts"`
+	HandleKey map[string]string `json:"handle_key"`
+}
+
+// FetchRowResponse is the response of the fetch row API, nil values mean NULL.
+type FetchRowResponse struct {
+	// Columns is the row written at the commit ts, it's empty if the row is deleted.
+	Columns map[string]interface{} `json:"columns,omitempty"`
+	// PreColumns is the row before the commit ts, it's empty if the row is inserted.
+	PreColumns map[string]interface{} `json:"pre_columns,omitempty"`
+	// MySQLTypes is the full mysql type of each column.
+	MySQLTypes map[string]string `json:"mysql_types"`
+}
+
+// FaultConfig is a fault injected into the node by the fault injection API.
+type FaultConfig struct {
+	// ID is assigned when the fault is injected.
+	ID uint64 `json:"id"`
+	// Kind is one of message, kill-maintainer and stall-sink.
+	Kind string `json:"kind"`
+	// To and Topic match the messages sent from the node for the message fault,
+	// the empty ones match any.
+	To    string `json:"to,omitempty"`
+	Topic string `json:"topic,omitempty"`
+	// Action is drop or delay for the message fault.
+	Action string        `json:"action,omitempty"`
+	Delay  *JSONDuration `json:"delay,omitempty" swaggertype:"string"`
+	// Namespace and ChangefeedID is the changefeed whose maintainer is killed
+	// or whose sinks are stalled.
+	Namespace    string `json:"namespace,omitempty"`
+	ChangefeedID string `json:"changefeed_id,omitempty"`
+	// Duration is how long the fault lasts, it lasts until it's removed if not set.
+	Duration *JSONDuration `json:"duration,omitempty" swaggertype:"string"`
+	ExpireAt *time.Time    `json:"expire_at,omitempty"`
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/maintainer"
+	apperror "github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// quiesceChangefeed holds all dispatchers of the changefeed at a ts, the sinks stop writing
+// the events after it, so the downstream is consistent at the ts once the status API reports
+// it's flushed. The current ts is used if ts is not specified.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/quiesce?ts={ts}
+func (h *OpenAPIV2) quiesceChangefeed(c *gin.Context) {
+	var holdTs uint64
+	if ts := c.Query("ts"); ts != "" {
+		var err error
+		holdTs, err = strconv.ParseUint(ts, 10, 64)
+		if err != nil || holdTs == 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid ts: %s", ts))
+			return
+		}
+	}
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	holdTs, err := m.NewQuiesceTs(holdTs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !h.saveQuiesceTs(c, m, holdTs) {
+		return
+	}
+	c.JSON(http.StatusOK, toQuiesceStatus(m.GetQuiesceStatus()))
+}
+
+// getQuiesceStatus returns whether the sinks have flushed the events before the hold ts.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/quiesce
+func (h *OpenAPIV2) getQuiesceStatus(c *gin.Context) {
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, toQuiesceStatus(m.GetQuiesceStatus()))
+}
+
+// releaseChangefeed releases the quiesced changefeed.
+// Usage:
+// curl -X DELETE http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/quiesce
+func (h *OpenAPIV2) releaseChangefeed(c *gin.Context) {
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	if !h.saveQuiesceTs(c, m, 0) {
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// saveQuiesceTs persists the hold ts in the changefeed info before the maintainer holds the
+// dispatchers at it, so the hold is kept after the maintainer or the dispatchers are restarted.
+// The error is added to the context and false is returned if it fails.
+func (h *OpenAPIV2) saveQuiesceTs(c *gin.Context, m *maintainer.Maintainer, holdTs uint64) bool {
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return false
+	}
+	if err := coordinator.QuiesceChangefeed(c.Request.Context(), m.GetChangefeedID(), holdTs); err != nil {
+		_ = c.Error(err)
+		return false
+	}
+	m.Quiesce(holdTs)
+	return true
+}
+
+func toQuiesceStatus(status maintainer.QuiesceStatus) *QuiesceStatus {
+	return &QuiesceStatus{
+		State:        string(status.State),
+		HoldTs:       status.HoldTs,
+		CheckpointTs: status.CheckpointTs,
+	}
+}
+
+// getChangefeedMaintainer returns the maintainer of the changefeed in the request,
+// the error is set to the context if it's not found in this node.
+func (h *OpenAPIV2) getChangefeedMaintainer(c *gin.Context) (*maintainer.Maintainer, bool) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return nil, false
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return nil, false
+	}
+	cfInfo, _, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return nil, false
+	}
+	m, ok := h.server.GetMaintainerManager().GetMaintainerForChangefeed(cfInfo.ChangefeedID)
+	if !ok {
+		log.Error("maintainer not found for changefeed in this node", zap.String("changefeed", cfInfo.ChangefeedID.String()))
+		_ = c.Error(apperror.ErrMaintainerNotFounded)
+		return nil, false
+	}
+	return m, true
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
+)
+
+// fetchRow reads the full row changed at the commit ts from the event store of this node,
+// the row is identified by the values of its handle key columns. It's used by the consumers
+// to complete the messages which only contain the handle key columns, see the
+// `handle-key-only` option of the large message handle.
+// The row can be fetched only if the table is replicated by a dispatcher on this node,
+// the consumer should try the other nodes if ErrRowNotFound is returned.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/fetch_row
+// -d '{"schema":"test","table":"t","commit_ts":1,"handle_key":{"id":"1"}}'
+func (h *OpenAPIV2) fetchRow(c *gin.Context) {
+	req := &FetchRowRequest{}
+	if err := c.BindJSON(req); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if req.Schema == "" || req.Table == "" || req.CommitTs == 0 || len(req.HandleKey) == 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"schema, table, commit_ts and handle_key must be specified"))
+		return
+	}
+
+	f, err := filter.NewFilter(&config.FilterConfig{
+		Rules: []string{fmt.Sprintf("`%s`.`%s`", req.Schema, req.Table)},
+	}, "", true)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	tables, err := schemaStore.GetAllPhysicalTables(req.CommitTs, f)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	eventStore := appcontext.GetService[eventstore.EventStore](appcontext.EventStore)
+	for _, table := range tables {
+		if table.SchemaName != req.Schema || table.TableName != req.Table {
+			continue
+		}
+		tableInfo, err := schemaStore.GetTableInfo(table.TableID, req.CommitTs)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		// the iterator of the event store also decodes the events in the local timezone.
+		row, _, err := eventstore.FetchRow(eventStore, tableInfo, table.TableID, req.CommitTs, req.HandleKey, time.Local)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if row != nil {
+			mysqlTypes := make(map[string]string, len(tableInfo.GetColumns()))
+			for _, col := range tableInfo.GetColumns() {
+				if col != nil {
+					mysqlTypes[col.Name.O] = utils.GetMySQLType(col, true)
+				}
+			}
+			c.JSON(http.StatusOK, &FetchRowResponse{
+				Columns:    row.Columns,
+				PreColumns: row.PreColumns,
+				MySQLTypes: mysqlTypes,
+			})
+			return
+		}
+	}
+	_ = c.Error(errors.ErrRowNotFound.GenWithStackByArgs(
+		fmt.Sprintf("%s.%s at %d", req.Schema, req.Table, req.CommitTs)))
+}
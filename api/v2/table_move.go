@@ -0,0 +1,105 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/maintainer"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// moveTables moves the tables of the changefeed to the target nodes in bulk atomically,
+// none of the moves is applied if any of them is invalid or in scheduling.
+// The progress of the moves can be queried by the GET method.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/move_tables -d '{"moves": [{"table_id": 1, "target_node_id": "..."}]}'
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/move_tables
+func (h *OpenAPIV2) moveTables(c *gin.Context) {
+	cfg := new(MoveTablesConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	moves := make([]maintainer.TableMove, 0, len(cfg.Moves))
+	for _, move := range cfg.Moves {
+		moves = append(moves, maintainer.TableMove{
+			TableID:    move.TableID,
+			TargetNode: node.ID(move.TargetNodeID),
+		})
+	}
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	res, err := m.MoveTables(moves)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toSpanMoveInfos(res))
+}
+
+// getTableMoves returns the progress of the last bulk move or rebalance of the changefeed.
+func (h *OpenAPIV2) getTableMoves(c *gin.Context) {
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, toSpanMoveInfos(m.GetSpanMoves()))
+}
+
+// rebalance runs a round of the balance of the changefeed immediately, at most a batch of
+// spans are moved in a round. The planned moves are returned without being applied if
+// dry_run is true, otherwise the progress of the moves can be queried by the move_tables api.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/rebalance?dry_run=true
+func (h *OpenAPIV2) rebalance(c *gin.Context) {
+	dryRun := false
+	if s := c.Query("dry_run"); s != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(s)
+		if err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid dry_run: %s", s))
+			return
+		}
+	}
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	res, err := m.Rebalance(dryRun)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toSpanMoveInfos(res))
+}
+
+func toSpanMoveInfos(moves []maintainer.SpanMove) *ListResponse[SpanMoveInfo] {
+	infos := make([]SpanMoveInfo, 0, len(moves))
+	for _, move := range moves {
+		infos = append(infos, SpanMoveInfo{
+			DispatcherID: move.DispatcherID.String(),
+			TableID:      move.TableID,
+			SourceNodeID: move.SourceNode.String(),
+			TargetNodeID: move.TargetNode.String(),
+			State:        move.State,
+		})
+	}
+	return &ListResponse[SpanMoveInfo]{Total: len(infos), Items: infos}
+}
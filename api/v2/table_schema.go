@@ -0,0 +1,51 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/errors"
+)
+
+// resendTableSchema asks the sinks to resend the bootstrap messages carrying the schema of
+// the tables, so the consumers which join late can decode the rows as soon as possible.
+// All tables are resent if table_ids is not specified.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/resend_table_schema?table_ids=1,2
+func (h *OpenAPIV2) resendTableSchema(c *gin.Context) {
+	var tableIDs []int64
+	if ids := c.Query("table_ids"); ids != "" {
+		for _, id := range strings.Split(ids, ",") {
+			tableID, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64)
+			if err != nil {
+				_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid table_ids: %s", ids))
+				return
+			}
+			tableIDs = append(tableIDs, tableID)
+		}
+	}
+	m, ok := h.getChangefeedMaintainer(c)
+	if !ok {
+		return
+	}
+	if err := m.ResendTableSchema(tableIDs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
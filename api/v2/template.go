@@ -0,0 +1,202 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+const apiOpVarTemplateName = "template_name"
+
+// listChangefeedTemplates lists all changefeed templates
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeed_templates
+func (h *OpenAPIV2) listChangefeedTemplates(c *gin.Context) {
+	templates, err := h.server.GetEtcdClient().GetChangefeedTemplates(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	items := make([]ChangefeedTemplate, 0, len(templates))
+	for _, template := range templates {
+		items = append(items, toAPIChangefeedTemplate(template))
+	}
+	c.JSON(http.StatusOK, &ListResponse[ChangefeedTemplate]{
+		Total: len(items),
+		Items: items,
+	})
+}
+
+// getChangefeedTemplate gets a changefeed template by name
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeed_templates/kafka-canal-json
+func (h *OpenAPIV2) getChangefeedTemplate(c *gin.Context) {
+	template, err := h.server.GetEtcdClient().GetChangefeedTemplate(
+		c.Request.Context(), c.Param(apiOpVarTemplateName))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIChangefeedTemplate(template))
+}
+
+// putChangefeedTemplate creates or replaces a changefeed template, the replica config
+// not specified in the request is filled with the default value. The config is validated
+// with the sink uri when a changefeed is created with the template, and changefeeds
+// created with the template before are not affected.
+// Usage:
+// curl -X PUT http://127.0.0.1:8300/api/v2/changefeed_templates/kafka-canal-json \
+// -d '{"description":"...","replica_config":{"sink":{"protocol":"canal-json"}}}'
+func (h *OpenAPIV2) putChangefeedTemplate(c *gin.Context) {
+	name := c.Param(apiOpVarTemplateName)
+	if err := model.ValidateChangefeedID(name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid template name: %s", name))
+		return
+	}
+	cfg := &ChangefeedTemplate{ReplicaConfig: GetDefaultReplicaConfig()}
+	if !bindJSON(c, cfg) {
+		return
+	}
+	template := &config.ChangefeedTemplate{
+		Name:        name,
+		Description: cfg.Description,
+		Config:      cfg.ReplicaConfig.ToInternalReplicaConfig(),
+		UpdateTime:  time.Now(),
+	}
+	if err := h.server.GetEtcdClient().PutChangefeedTemplate(c.Request.Context(), template); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("changefeed template saved", zap.String("name", name))
+	c.JSON(http.StatusOK, toAPIChangefeedTemplate(template))
+}
+
+// deleteChangefeedTemplate deletes a changefeed template, changefeeds created
+// with the template are not affected.
+// Usage:
+// curl -X DELETE http://127.0.0.1:8300/api/v2/changefeed_templates/kafka-canal-json
+func (h *OpenAPIV2) deleteChangefeedTemplate(c *gin.Context) {
+	name := c.Param(apiOpVarTemplateName)
+	if err := h.server.GetEtcdClient().DeleteChangefeedTemplate(c.Request.Context(), name); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("changefeed template deleted", zap.String("name", name))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// applyChangefeedTemplate returns the replica config a changefeed would be created with,
+// which is the config of the template overridden by the replica config in the request.
+// The config is validated if the sink uri is specified, and the sensitive data of the
+// returned config is masked. Nothing is created.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeed_templates/kafka-canal-json/apply \
+// -d '{"replica_config":{"force_replicate":true}}'
+func (h *OpenAPIV2) applyChangefeedTemplate(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	cfg := &ChangefeedConfig{ReplicaConfig: GetDefaultReplicaConfig()}
+	cfg.Template = c.Param(apiOpVarTemplateName)
+	if !h.mergeChangefeedTemplate(c, cfg, body) {
+		return
+	}
+	replicaConfig := cfg.ReplicaConfig.ToInternalReplicaConfig()
+	if cfg.SinkURI != "" {
+		sinkURI, err := url.Parse(cfg.SinkURI)
+		if err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+			return
+		}
+		if err := replicaConfig.ValidateAndAdjust(sinkURI); err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+			return
+		}
+	}
+	// the secrets of the template are not returned, like the ones of a changefeed
+	replicaConfig.MaskSensitiveData()
+	c.JSON(http.StatusOK, ToAPIReplicaConfig(replicaConfig))
+}
+
+// bindChangefeedConfig binds the request body into cfg. If the body references a
+// template, the replica config of the template is used as the base of cfg and the
+// fields in the body override it.
+func (h *OpenAPIV2) bindChangefeedConfig(c *gin.Context, cfg *ChangefeedConfig) bool {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return false
+	}
+	var probe struct {
+		Template string `json:"template"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return false
+	}
+	cfg.Template = probe.Template
+	return h.mergeChangefeedTemplate(c, cfg, body)
+}
+
+// mergeChangefeedTemplate sets the replica config of cfg to the one of cfg.Template
+// if it's specified, and then unmarshals body over cfg.
+func (h *OpenAPIV2) mergeChangefeedTemplate(c *gin.Context, cfg *ChangefeedConfig, body []byte) bool {
+	if cfg.Template != "" {
+		template, err := h.server.GetEtcdClient().GetChangefeedTemplate(c.Request.Context(), cfg.Template)
+		if err != nil {
+			_ = c.Error(err)
+			return false
+		}
+		cfg.ReplicaConfig = ToAPIReplicaConfig(template.Config)
+	}
+	if len(body) == 0 {
+		return true
+	}
+	if err := unmarshalRequestBody(c, body, cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return false
+	}
+	return true
+}
+
+// toAPIChangefeedTemplate converts the template to the api model,
+// the sensitive data in the config is masked like the one of a changefeed.
+func toAPIChangefeedTemplate(template *config.ChangefeedTemplate) ChangefeedTemplate {
+	replicaConfig := template.Config.Clone()
+	replicaConfig.MaskSensitiveData()
+	return ChangefeedTemplate{
+		Name:          template.Name,
+		Description:   template.Description,
+		ReplicaConfig: ToAPIReplicaConfig(replicaConfig),
+		UpdateTime:    template.UpdateTime,
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/errors"
+)
+
+const defaultUnmatchedPrewriteDumpLimit = 100
+
+// unmatchedPrewrites dumps the prewrite rows cached by the log puller of this node which
+// are not matched by their commits or rollbacks yet, the keys are hashed.
+// It's used to find the orphan transactions when the checkpoint of a changefeed is stuck.
+// Usage:
+// curl -X GET "http://127.0.0.1:8300/api/v2/debug/unmatched_prewrites?min_age=10m&limit=100"
+// min_age filters the regions whose oldest unmatched prewrite is younger than it, default 0.
+// limit is the max number of prewrite rows dumped for each region, default 100 and 0 means no limit.
+func (h *OpenAPIV2) unmatchedPrewrites(c *gin.Context) {
+	var minAge time.Duration
+	if s := c.Query("min_age"); s != "" {
+		var err error
+		minAge, err = time.ParseDuration(s)
+		if err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid min_age: %s", s))
+			return
+		}
+	}
+	limit := defaultUnmatchedPrewriteDumpLimit
+	if s := c.Query("limit"); s != "" {
+		var err error
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid limit: %s", s))
+			return
+		}
+	}
+
+	regions := logpuller.DumpUnmatchedPrewrites(minAge, limit)
+	res := make([]RegionUnmatchedPrewrites, 0, len(regions))
+	for _, r := range regions {
+		region := RegionUnmatchedPrewrites{
+			SubscriptionID: uint64(r.SubscriptionID),
+			RegionID:       r.RegionID,
+			OldestAge:      JSONDuration{duration: r.OldestAge},
+			Count:          r.Count,
+			Size:           r.Size,
+			Prewrites:      make([]UnmatchedPrewrite, 0, len(r.Prewrites)),
+		}
+		for _, p := range r.Prewrites {
+			region.Prewrites = append(region.Prewrites, UnmatchedPrewrite{
+				KeyHash: p.KeyHash,
+				StartTs: p.StartTs,
+				Size:    p.Size,
+				Age:     JSONDuration{duration: p.Age},
+			})
+		}
+		res = append(res, region)
+	}
+	c.JSON(http.StatusOK, &ListResponse[RegionUnmatchedPrewrites]{
+		Total: len(res),
+		Items: res,
+	})
+}
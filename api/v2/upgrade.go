@@ -0,0 +1,92 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/errors"
+)
+
+// defaultUpgradeMaxLag is the max checkpoint lag of the changefeeds allowed to
+// upgrade the next node if it's not specified in the request.
+const defaultUpgradeMaxLag = time.Minute
+
+// startUpgrade starts the rolling upgrade of the cluster. The nodes are upgraded one by one,
+// the maintainers are moved out of a node before it's reported ready by the status API,
+// then the node should be stopped gracefully and restarted with the new version.
+// The next node is not upgraded until the checkpoint lag of all changefeeds is under the bound.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/upgrade -d '{"max_lag_seconds":60}'
+func (h *OpenAPIV2) startUpgrade(c *gin.Context) {
+	cfg := &UpgradeConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.MaxLagSeconds < 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid max_lag_seconds: %d", cfg.MaxLagSeconds))
+		return
+	}
+	maxLag := defaultUpgradeMaxLag
+	if cfg.MaxLagSeconds > 0 {
+		maxLag = time.Duration(cfg.MaxLagSeconds) * time.Second
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := coordinator.StartUpgrade(c, maxLag); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// getUpgradeStatus returns the status of the rolling upgrade.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/upgrade
+func (h *OpenAPIV2) getUpgradeStatus(c *gin.Context) {
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	status, err := coordinator.GetUpgradeStatus(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// stopUpgrade stops the rolling upgrade.
+// Usage:
+// curl -X DELETE http://127.0.0.1:8300/api/v2/upgrade
+func (h *OpenAPIV2) stopUpgrade(c *gin.Context) {
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := coordinator.StopUpgrade(c); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/cdc/api"
+)
+
+const (
+	// defaultWatchTimeout is how long a watch request waits for the events if the timeout
+	// is not specified in the request.
+	defaultWatchTimeout = 30 * time.Second
+	// maxWatchTimeout is the max time a watch request waits for the events.
+	maxWatchTimeout = 5 * time.Minute
+)
+
+// watchChangefeedEvents long-polls the changefeed events, such as state transitions,
+// checkpoint stalls and maintainer moves. It returns the events after the revision as soon
+// as there are any, or returns no event after the timeout. The revision in the response is
+// used by the next request. Only the events of the changefeed are returned if changefeed_id
+// is specified.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeed_events?revision={revision}&timeout={seconds}
+func (h *OpenAPIV2) watchChangefeedEvents(c *gin.Context) {
+	var revision uint64
+	if value := c.Query("revision"); value != "" {
+		var err error
+		revision, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid revision: %s", value))
+			return
+		}
+	}
+	timeout := defaultWatchTimeout
+	if value := c.Query("timeout"); value != "" {
+		seconds, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || time.Duration(seconds)*time.Second > maxWatchTimeout {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+				"invalid timeout: %s, it should be at most %d seconds", value, int(maxWatchTimeout.Seconds())))
+			return
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+	var id *common.ChangeFeedDisplayName
+	if name := c.Query(api.APIOpVarChangefeedID); name != "" {
+		displayName := common.NewChangeFeedDisplayName(name, getNamespaceValueWithDefault(c))
+		id = &displayName
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	events, latest, err := coordinator.WatchChangefeedEvents(ctx, revision, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if c.Request.Context().Err() != nil {
+		// the client is gone
+		return
+	}
+	c.JSON(http.StatusOK, &ChangefeedEvents{
+		Revision: latest,
+		Events:   events,
+	})
+}
@@ -35,6 +35,8 @@ func newCmdChangefeed(f factory.Factory) *cobra.Command {
 	cmds.AddCommand(newCmdRemoveChangefeed(f))
 	cmds.AddCommand(newCmdResumeChangefeed(f))
 	cmds.AddCommand(newCmdMoveTable(f))
+	cmds.AddCommand(newCmdExportChangefeed(f))
+	cmds.AddCommand(newCmdImportChangefeed(f))
 
 	return cmds
 }
@@ -86,9 +86,9 @@ func (o *changefeedCommonOptions) addFlags(cmd *cobra.Command) {
 	_ = cmd.PersistentFlags().MarkHidden("upstream-key")
 }
 
-// strictDecodeConfig do strictDecodeFile check and only verify the rules for now.
-func (o *changefeedCommonOptions) strictDecodeConfig(component string, cfg *config.ReplicaConfig) error {
-	err := util.StrictDecodeFile(o.configFile, component, cfg)
+// strictDecodeConfig decodes the config file, rejects the unknown fields and only verify the rules for now.
+func (o *changefeedCommonOptions) strictDecodeConfig(cfg *config.ReplicaConfig) error {
+	err := config.StrictDecodeReplicaConfigFile(o.configFile, cfg)
 	if err != nil {
 		return err
 	}
@@ -146,7 +146,7 @@ func (o *createChangefeedOptions) complete(f factory.Factory) error {
 func (o *createChangefeedOptions) completeReplicaCfg() error {
 	cfg := config.GetDefaultReplicaConfig()
 	if len(o.commonChangefeedOptions.configFile) > 0 {
-		if err := o.commonChangefeedOptions.strictDecodeConfig("TiCDC changefeed", cfg); err != nil {
+		if err := o.commonChangefeedOptions.strictDecodeConfig(cfg); err != nil {
 			return err
 		}
 	}
@@ -46,7 +46,7 @@ func TestStrictDecodeConfig(t *testing.T) {
 	require.Nil(t, cmd.ParseFlags([]string{fmt.Sprintf("--config=%s", path)}))
 
 	cfg := config.GetDefaultReplicaConfig()
-	err = o.strictDecodeConfig("cdc", cfg)
+	err = o.strictDecodeConfig(cfg)
 	require.Nil(t, err)
 
 	path = filepath.Join(dir, "config1.toml")
@@ -59,7 +59,7 @@ func TestStrictDecodeConfig(t *testing.T) {
 	require.Nil(t, cmd.ParseFlags([]string{fmt.Sprintf("--config=%s", path)}))
 
 	cfg = config.GetDefaultReplicaConfig()
-	err = o.strictDecodeConfig("cdc", cfg)
+	err = o.strictDecodeConfig(cfg)
 	require.NotNil(t, err)
 	require.Regexp(t, ".*CDC:ErrFilterRuleInvalid.*", err)
 }
@@ -81,7 +81,7 @@ func TestTomlFileToApiModel(t *testing.T) {
 	require.Nil(t, cmd.ParseFlags([]string{fmt.Sprintf("--config=%s", path)}))
 
 	cfg := config.GetDefaultReplicaConfig()
-	err = o.strictDecodeConfig("cdc", cfg)
+	err = o.strictDecodeConfig(cfg)
 	require.Nil(t, err)
 	apiModel := v2.ToAPIReplicaConfig(cfg)
 	cfg2 := apiModel.ToInternalReplicaConfig()
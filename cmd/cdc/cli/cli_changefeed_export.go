@@ -0,0 +1,147 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/ticdc/api/v2"
+	"github.com/pingcap/ticdc/cmd/cdc/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// exportChangefeedOptions defines flags for the `cli changefeed export` command.
+type exportChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	file         string
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *exportChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVarP(&o.file, "file", "f", "", "The file the exported changefeed is written to, it's printed if not set")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *exportChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli changefeed export` command.
+func (o *exportChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	export, err := o.apiClient.Changefeeds().Export(ctx, o.namespace, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	if o.file == "" {
+		return util.JSONPrint(cmd, export)
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.WriteFile(o.file, data, 0o600))
+}
+
+// newCmdExportChangefeed creates the `cli changefeed export` command.
+func newCmdExportChangefeed(f factory.Factory) *cobra.Command {
+	o := &exportChangefeedOptions{}
+	command := &cobra.Command{
+		Use:   "export",
+		Short: "Export a paused replication task (changefeed) with its checkpoint",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+	return command
+}
+
+// importChangefeedOptions defines flags for the `cli changefeed import` command.
+type importChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	file string
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *importChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.file, "file", "f", "", "The file of the exported changefeed")
+	_ = cmd.MarkPersistentFlagRequired("file")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *importChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli changefeed import` command.
+func (o *importChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	data, err := os.ReadFile(o.file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	export := &v2.ChangefeedExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return errors.Annotatef(err, "invalid exported changefeed %s", o.file)
+	}
+	info, err := o.apiClient.Changefeeds().Import(ctx, export)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Import changefeed successfully!\nID: %s\nCheckpointTs: %d\n", info.ID, export.CheckpointTs)
+	return nil
+}
+
+// newCmdImportChangefeed creates the `cli changefeed import` command.
+func newCmdImportChangefeed(f factory.Factory) *cobra.Command {
+	o := &importChangefeedOptions{}
+	command := &cobra.Command{
+		Use:   "import",
+		Short: "Import a replication task (changefeed) exported from another TiCDC cluster",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+	return command
+}
@@ -160,7 +160,7 @@ func (o *updateChangefeedOptions) applyChanges(oldInfo *v2.ChangeFeedInfo,
 			newInfo.SinkURI = o.commonChangefeedOptions.sinkURI
 		case "config":
 			cfg := newInfo.Config.ToInternalReplicaConfig()
-			if err = o.commonChangefeedOptions.strictDecodeConfig("TiCDC changefeed", cfg); err != nil {
+			if err = o.commonChangefeedOptions.strictDecodeConfig(cfg); err != nil {
 				log.Error("decode config file error", zap.Error(err))
 			}
 			newInfo.Config = v2.ToAPIReplicaConfig(cfg)
@@ -17,6 +17,7 @@ import (
 	"context"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/logger"
+	tiserver "github.com/pingcap/ticdc/pkg/server"
 	"github.com/pingcap/ticdc/server"
 	"github.com/pingcap/ticdc/version"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
@@ -105,12 +107,25 @@ func (o *options) run(cmd *cobra.Command) error {
 
 	util.LogHTTPProxies()
 
+	// The first SIGTERM drains the running server before the context is canceled,
+	// so its tables are handed over to other nodes with the final checkpoints.
+	var current atomic.Value
+	util.InitSignalHandling(func() <-chan struct{} {
+		if svr, ok := current.Load().(tiserver.Server); ok {
+			return svr.Drain()
+		}
+		done := make(chan struct{})
+		close(done)
+		return done
+	}, cancel)
+
 	for {
 		svr, err := server.New(o.serverConfig, o.pdEndpoints)
 		if err != nil {
 			log.Error("create cdc server failed", zap.Error(err))
 			return errors.Trace(err)
 		}
+		current.Store(svr)
 		log.Info("TiCDC(new arch) server created",
 			zap.Strings("pd", o.pdEndpoints), zap.Stringer("config", o.serverConfig))
 
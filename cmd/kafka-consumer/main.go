@@ -28,6 +28,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/kafkaconsumer"
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/pingcap/tiflow/pkg/version"
 	"go.uber.org/zap"
@@ -35,29 +36,40 @@ import (
 
 func main() {
 	var (
-		upstreamURIStr string
-		configFile     string
+		upstreamURIStr  string
+		configFile      string
+		ticdcAddr       string
+		logPath         string
+		enableProfiling bool
 	)
 	groupID := fmt.Sprintf("ticdc_kafka_consumer_%s", uuid.New().String())
-	consumerOption := newOption()
+	consumerOption := kafkaconsumer.NewOption()
 	flag.StringVar(&configFile, "config", "", "config file for changefeed")
 	flag.StringVar(&upstreamURIStr, "upstream-uri", "", "Kafka uri")
-	flag.StringVar(&consumerOption.downstreamURI, "downstream-uri", "", "downstream sink uri")
-	flag.StringVar(&consumerOption.schemaRegistryURI, "schema-registry-uri", "", "schema registry uri")
-	flag.StringVar(&consumerOption.upstreamTiDBDSN, "upstream-tidb-dsn", "", "upstream TiDB DSN")
-	flag.StringVar(&consumerOption.groupID, "consumer-group-id", groupID, "consumer group id")
-	flag.StringVar(&consumerOption.logPath, "log-file", "cdc_kafka_consumer.log", "log file path")
-	flag.StringVar(&consumerOption.logLevel, "log-level", "info", "log file path")
-	flag.StringVar(&consumerOption.timezone, "tz", "System", "Specify time zone of Kafka consumer")
-	flag.StringVar(&consumerOption.ca, "ca", "", "CA certificate path for Kafka SSL connection")
-	flag.StringVar(&consumerOption.cert, "cert", "", "Certificate path for Kafka SSL connection")
-	flag.StringVar(&consumerOption.key, "key", "", "Private key path for Kafka SSL connection")
-	flag.BoolVar(&consumerOption.enableProfiling, "enable-profiling", false, "enable pprof profiling")
+	flag.StringVar(&consumerOption.DownstreamURI, "downstream-uri", "", "downstream sink uri")
+	flag.StringVar(&consumerOption.SchemaRegistryURI, "schema-registry-uri", "", "schema registry uri")
+	flag.StringVar(&consumerOption.UpstreamTiDBDSN, "upstream-tidb-dsn", "", "upstream TiDB DSN")
+	flag.StringVar(&ticdcAddr, "ticdc-addr", "",
+		"comma separated TiCDC server addresses, used to fetch the full rows of the handle-key-only messages")
+	flag.StringVar(&consumerOption.GroupID, "consumer-group-id", groupID, "consumer group id")
+	flag.StringVar(&logPath, "log-file", "cdc_kafka_consumer.log", "log file path")
+	flag.StringVar(&consumerOption.LogLevel, "log-level", "info", "log file path")
+	flag.StringVar(&consumerOption.Timezone, "tz", "System", "Specify time zone of Kafka consumer")
+	flag.StringVar(&consumerOption.CA, "ca", "", "CA certificate path for Kafka SSL connection")
+	flag.StringVar(&consumerOption.Cert, "cert", "", "Certificate path for Kafka SSL connection")
+	flag.StringVar(&consumerOption.Key, "key", "", "Private key path for Kafka SSL connection")
+	flag.BoolVar(&consumerOption.EnableCheckpoint, "enable-checkpoint", false,
+		"record the applied watermark in the downstream to skip the applied events after restarting, "+
+			"the replayed events are written in safe mode, the consumer-group-id should be fixed to resume from the checkpoint")
+	flag.BoolVar(&enableProfiling, "enable-profiling", false, "enable pprof profiling")
 	flag.Parse()
+	if ticdcAddr != "" {
+		consumerOption.TiCDCAddresses = strings.Split(ticdcAddr, ",")
+	}
 
 	err := logutil.InitLogger(&logutil.Config{
-		Level: consumerOption.logLevel,
-		File:  consumerOption.logPath,
+		Level: consumerOption.LogLevel,
+		File:  logPath,
 	})
 	if err != nil {
 		log.Panic("init logger failed", zap.Error(err))
@@ -68,11 +80,6 @@ func main() {
 	if err != nil {
 		log.Panic("invalid upstream-uri", zap.Error(err))
 	}
-	scheme := strings.ToLower(upstreamURI.Scheme)
-	if scheme != "kafka" {
-		log.Panic("invalid upstream-uri scheme, the scheme of upstream-uri must be `kafka`",
-			zap.String("upstreamURI", upstreamURIStr))
-	}
 
 	err = consumerOption.Adjust(upstreamURI, configFile)
 	if err != nil {
@@ -80,9 +87,12 @@ func main() {
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	consumer := newConsumer(ctx, consumerOption)
+	consumer, err := kafkaconsumer.New(ctx, consumerOption)
+	if err != nil {
+		log.Panic("create kafka consumer failed", zap.Error(err))
+	}
 	var wg sync.WaitGroup
-	if consumerOption.enableProfiling {
+	if enableProfiling {
 		log.Info("profiling is enabled")
 		wg.Add(1)
 		go func() {
@@ -95,7 +105,9 @@ func main() {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		consumer.Consume(ctx)
+		if err := consumer.Consume(ctx); err != nil {
+			log.Panic("consume messages failed", zap.Error(err))
+		}
 	}()
 
 	sigterm := make(chan os.Signal, 1)
@@ -0,0 +1,124 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// nodeCapacities are the capacities reported by the maintainer managers of the nodes,
+// they are updated by the coordinator event loop and read by the schedulers.
+type nodeCapacities struct {
+	mu    sync.RWMutex
+	nodes map[node.ID]*heartbeatpb.NodeCapacity
+}
+
+func newNodeCapacities() *nodeCapacities {
+	return &nodeCapacities{nodes: make(map[node.ID]*heartbeatpb.NodeCapacity)}
+}
+
+func (c *nodeCapacities) update(id node.ID, capacity *heartbeatpb.NodeCapacity) {
+	if capacity == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes[id] = capacity
+}
+
+func (c *nodeCapacities) remove(id node.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.nodes, id)
+}
+
+// newMaintainerCapacity creates the capacity of the nodes for a schedule round,
+// maintainers is the number of the maintainers on each node.
+func (c *nodeCapacities) newMaintainerCapacity(maintainers map[node.ID]int) *maintainerCapacity {
+	return &maintainerCapacity{
+		capacities:  c.snapshot(),
+		maintainers: maintainers,
+	}
+}
+
+func (c *nodeCapacities) snapshot() map[node.ID]*heartbeatpb.NodeCapacity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	capacities := make(map[node.ID]*heartbeatpb.NodeCapacity, len(c.nodes))
+	for id, capacity := range c.nodes {
+		capacities[id] = capacity
+	}
+	return capacities
+}
+
+// maintainerCapacity limits the maintainers placed on the nodes by the max maintainers of the nodes,
+// the nodes which don't report the capacity are not limited.
+type maintainerCapacity struct {
+	capacities  map[node.ID]*heartbeatpb.NodeCapacity
+	maintainers map[node.ID]int
+}
+
+// Fits implements scheduler.NodeCapacity.
+func (c *maintainerCapacity) Fits(_ *changefeed.Changefeed, id node.ID) bool {
+	capacity, ok := c.capacities[id]
+	return !ok || capacity.MaxMaintainers <= 0 || int64(c.maintainers[id]) < capacity.MaxMaintainers
+}
+
+// Add implements scheduler.NodeCapacity.
+func (c *maintainerCapacity) Add(_ *changefeed.Changefeed, id node.ID) {
+	c.maintainers[id]++
+}
+
+// checkNodeCapacity returns an error if the cluster doesn't have the capacity for the changefeed,
+// it's called before a changefeed is created or resumed, the changefeeds are rejected instead of
+// overcommitting the nodes. The dispatcher manager of the changefeed is created on every node,
+// so the memory quota of the changefeed must fit the memory budget of all the nodes, and the
+// maintainer must fit at least one node.
+func (c *Controller) checkNodeCapacity(info *config.ChangeFeedInfo) error {
+	capacities := c.capacities.snapshot()
+	aliveNodes := c.nodeManager.GetAliveNodes()
+	var memoryQuota uint64
+	if info.Config != nil {
+		memoryQuota = info.Config.MemoryQuota
+	}
+	for id := range aliveNodes {
+		capacity, ok := capacities[id]
+		if !ok || capacity.MemoryBudget == 0 {
+			continue
+		}
+		if capacity.MemoryQuota+memoryQuota > capacity.MemoryBudget {
+			return errors.ErrNodeCapacityExceeded.GenWithStackByArgs(info.ChangefeedID.Name(),
+				fmt.Sprintf("the memory quota %d of the changefeed exceeds the memory budget %d of node %s, "+
+					"which is used %d", memoryQuota, capacity.MemoryBudget, id, capacity.MemoryQuota))
+		}
+	}
+	maintainers := c.capacities.newMaintainerCapacity(c.changefeedDB.GetTaskSizePerNode())
+	for id := range aliveNodes {
+		if maintainers.Fits(nil, id) {
+			return nil
+		}
+	}
+	if len(aliveNodes) == 0 {
+		return nil
+	}
+	return errors.ErrNodeCapacityExceeded.GenWithStackByArgs(info.ChangefeedID.Name(),
+		"all nodes reach the max maintainers")
+}
@@ -138,6 +138,17 @@ func (m *Backoff) CheckStatus(status *heartbeatpb.MaintainerStatus) (bool, model
 
 		// set the changefeed state to warning and waiting start the changefeed
 		m.isRestarting.Store(true)
+		// the changefeed is stopped without retry until it's resumed by the users
+		for _, err := range status.Err {
+			if ShouldPauseChangefeed(err) {
+				log.Warn("changefeed is paused by the error",
+					zap.String("namespace", m.id.Namespace()),
+					zap.String("changefeed", m.id.Name()),
+					zap.String("error", err.Message))
+				m.failed.Store(true)
+				return true, model.StateStopped, err
+			}
+		}
 		// if the checkpointTs is not advanced for a long time, we should stop the changefeed
 		failed, err := m.HandleError(status.Err)
 		if failed {
@@ -158,6 +169,11 @@ func ShouldFailChangefeed(e *heartbeatpb.RunningError) bool {
 	return cerrors.ShouldFailChangefeed(errors.New(e.Message + e.Code))
 }
 
+// ShouldPauseChangefeed return true if a running error pauses the changefeed.
+func ShouldPauseChangefeed(e *heartbeatpb.RunningError) bool {
+	return cerrors.ShouldPauseChangefeed(errors.New(e.Message + e.Code))
+}
+
 func (m *Backoff) HandleError(errs []*heartbeatpb.RunningError) (bool, *heartbeatpb.RunningError) {
 	// if there are a fastFail error in errs, we can just fastFail the changefeed
 	for _, err := range errs {
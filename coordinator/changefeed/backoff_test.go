@@ -179,3 +179,21 @@ func TestNormal(t *testing.T) {
 	require.False(t, backoff.retrying.Load())
 	require.False(t, backoff.isRestarting.Load())
 }
+
+func TestPausedByError(t *testing.T) {
+	backoff := NewBackoff(common.NewChangeFeedIDWithName("test"), time.Minute*30, 1)
+	changefeed, state, err := backoff.CheckStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 1,
+		Err: []*heartbeatpb.RunningError{
+			{Message: "test"},
+			{Code: "CDC:ErrIngestEventNotAcked", Message: "ingest event is not acknowledged"},
+		},
+	})
+	require.True(t, changefeed)
+	require.Equal(t, model.StateStopped, state)
+	require.Equal(t, "CDC:ErrIngestEventNotAcked", err.Code)
+	// the changefeed is not retried until it's resumed
+	require.False(t, backoff.ShouldRun())
+	backoff.resetErrRetry()
+	require.True(t, backoff.ShouldRun())
+}
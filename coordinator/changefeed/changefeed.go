@@ -16,6 +16,7 @@ package changefeed
 import (
 	"encoding/json"
 	"net/url"
+	"sort"
 	"sync"
 
 	"github.com/pingcap/log"
@@ -31,6 +32,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// trafficPerWeight is the traffic of a changefeed, in bytes per second,
+// which weighs the same as one table when balancing the maintainers.
+const trafficPerWeight = 64 * 1024
+
 // Changefeed is a memory present for changefeed info and status
 type Changefeed struct {
 	ID       common.ChangeFeedID
@@ -42,11 +47,23 @@ type Changefeed struct {
 	nodeIDMu sync.Mutex
 	nodeID   node.ID
 
-	configBytes []byte
+	// configBytes is the marshaled info sent to the maintainer, it's updated with the info.
+	configBytes atomic.Pointer[[]byte]
 	// it's saved to the backend db
 	lastSavedCheckpointTs *atomic.Uint64
+	// lastSavedBackfills are the backfills saved to the backend db with the checkpoint ts,
+	// the checkpoint ts of the changefeed doesn't go back for the backfilling tables.
+	lastSavedBackfills atomic.Pointer[[]*config.TableBackfill]
+	// lastSavedBarriers are the barrier coverages saved to the backend db with the checkpoint ts.
+	lastSavedBarriers atomic.Pointer[[]*config.BarrierCoverage]
 	// the heartbeatpb.MaintainerStatus is read only
 	status *atomic.Pointer[heartbeatpb.MaintainerStatus]
+	// weight is calculated by the last status which carries the table count,
+	// it's kept when the maintainer is moved and has not reported the table count yet.
+	weight *atomic.Int64
+	// epoch is bumped every time the changefeed is bound to a node, the maintainer
+	// fences its messages with it so the dispatchers can ignore a stale maintainer.
+	epoch atomic.Uint64
 
 	backoff *Backoff
 }
@@ -62,19 +79,13 @@ func NewChangefeed(cfID common.ChangeFeedID,
 		log.Panic("unable to marshal changefeed config",
 			zap.Error(err))
 	}
-	bytes, err := json.Marshal(info)
-	if err != nil {
-		log.Panic("unable to marshal changefeed config",
-			zap.Error(err))
-	}
 	log.Info("changefeed instance created",
 		zap.String("id", cfID.String()),
 		zap.Uint64("checkpointTs", checkpointTs),
 		zap.String("state", string(info.State)))
-	return &Changefeed{
+	cf := &Changefeed{
 		ID:                    cfID,
 		info:                  atomic.NewPointer(info),
-		configBytes:           bytes,
 		lastSavedCheckpointTs: atomic.NewUint64(checkpointTs),
 		isMQSink:              sink.IsMQScheme(uri.Scheme),
 		isNew:                 isNew,
@@ -84,8 +95,11 @@ func NewChangefeed(cfID common.ChangeFeedID,
 				CheckpointTs: checkpointTs,
 				FeedState:    string(info.State),
 			}),
+		weight:  atomic.NewInt64(1),
 		backoff: NewBackoff(cfID, *info.Config.ChangefeedErrorStuckDuration, checkpointTs),
 	}
+	cf.SetInfo(info)
+	return cf
 }
 
 func (c *Changefeed) GetInfo() *config.ChangeFeedInfo {
@@ -93,6 +107,12 @@ func (c *Changefeed) GetInfo() *config.ChangeFeedInfo {
 }
 
 func (c *Changefeed) SetInfo(info *config.ChangeFeedInfo) {
+	bytes, err := json.Marshal(info)
+	if err != nil {
+		log.Panic("unable to marshal changefeed config",
+			zap.Error(err))
+	}
+	c.configBytes.Store(&bytes)
 	c.info.Store(info)
 }
 
@@ -125,10 +145,19 @@ func (c *Changefeed) ShouldRun() bool {
 	return c.backoff.ShouldRun()
 }
 
+// UpdateStatus updates the status reported by the maintainer, the barrier coverages reported
+// incrementally are merged to the known ones, so the barriers of the stored status are full.
 func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool, model.FeedState, *heartbeatpb.RunningError) {
 	old := c.status.Load()
 	if newStatus != nil && newStatus.CheckpointTs >= old.CheckpointTs {
+		if newStatus != old {
+			newStatus.Barriers = mergeBarriers(old.Barriers, newStatus.Barriers, newStatus.BarriersFull)
+			newStatus.BarriersFull = true
+		}
 		c.status.Store(newStatus)
+		if newStatus.TableCount > 0 {
+			c.weight.Store(1 + newStatus.TableCount + int64(newStatus.EventSizePerSecond/trafficPerWeight))
+		}
 		info := c.GetInfo()
 		// the changefeed reaches the targetTs
 		if info.TargetTs != 0 && newStatus.CheckpointTs >= info.TargetTs {
@@ -151,6 +180,30 @@ func (c *Changefeed) GetStatus() *heartbeatpb.MaintainerStatus {
 	return c.status.Load()
 }
 
+// GetWeight returns the weight of the changefeed used to balance the maintainers,
+// it's calculated by the table count and the traffic reported by the maintainer.
+func (c *Changefeed) GetWeight() int64 {
+	return c.weight.Load()
+}
+
+// GetEpoch returns the epoch of the maintainer the changefeed is bound to.
+func (c *Changefeed) GetEpoch() uint64 {
+	return c.epoch.Load()
+}
+
+// bumpEpoch bumps the epoch of the changefeed and returns the new one.
+// The epochs assigned by a coordinator start from its epoch base, which is a PD TSO
+// fetched when the coordinator starts, so they are larger than the old ones assigned
+// by the previous coordinators.
+func (c *Changefeed) bumpEpoch(base uint64) uint64 {
+	epoch := c.epoch.Load() + 1
+	if epoch < base {
+		epoch = base
+	}
+	c.epoch.Store(epoch)
+	return epoch
+}
+
 func (c *Changefeed) SetLastSavedCheckPointTs(ts uint64) {
 	c.lastSavedCheckpointTs.Store(ts)
 }
@@ -159,15 +212,193 @@ func (c *Changefeed) GetLastSavedCheckPointTs() uint64 {
 	return c.lastSavedCheckpointTs.Load()
 }
 
-func (c *Changefeed) NewAddMaintainerMessage(server node.ID) *messaging.TargetMessage {
-	return messaging.NewSingleTargetMessage(server,
-		messaging.MaintainerManagerTopic,
-		&heartbeatpb.AddMaintainerRequest{
-			Id:             c.ID.ToPB(),
-			CheckpointTs:   c.GetStatus().CheckpointTs,
-			Config:         c.configBytes,
-			IsNewChangfeed: c.isNew,
+// SetLastSavedBackfills sets the backfills saved to the backend db.
+func (c *Changefeed) SetLastSavedBackfills(backfills []*config.TableBackfill) {
+	c.lastSavedBackfills.Store(&backfills)
+}
+
+// GetLastSavedBackfills returns the backfills saved to the backend db.
+func (c *Changefeed) GetLastSavedBackfills() []*config.TableBackfill {
+	if backfills := c.lastSavedBackfills.Load(); backfills != nil {
+		return *backfills
+	}
+	return nil
+}
+
+// SetLastSavedBarriers sets the barrier coverages saved to the backend db.
+func (c *Changefeed) SetLastSavedBarriers(barriers []*config.BarrierCoverage) {
+	c.lastSavedBarriers.Store(&barriers)
+}
+
+// GetLastSavedBarriers returns the barrier coverages saved to the backend db.
+func (c *Changefeed) GetLastSavedBarriers() []*config.BarrierCoverage {
+	if barriers := c.lastSavedBarriers.Load(); barriers != nil {
+		return *barriers
+	}
+	return nil
+}
+
+// RestoreSavedStatus restores the backfills and the barrier coverages loaded from the backend db,
+// they are sent to the next maintainer of the changefeed to resume the backfills and the barriers.
+func (c *Changefeed) RestoreSavedStatus(status *config.ChangeFeedStatus) {
+	c.SetLastSavedBackfills(status.Backfills)
+	c.SetLastSavedBarriers(status.Barriers)
+	old := c.status.Load()
+	c.status.Store(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: old.CheckpointTs,
+		FeedState:    old.FeedState,
+		Backfills:    BackfillsToPB(status.Backfills),
+		Barriers:     BarriersToPB(status.Barriers),
+	})
+}
+
+// NewSavedStatus returns the status saved to the backend db with the checkpoint ts,
+// the saved backfills and barrier coverages are kept in it.
+func (c *Changefeed) NewSavedStatus(checkpointTs uint64, progress config.Progress) *config.ChangeFeedStatus {
+	return &config.ChangeFeedStatus{
+		CheckpointTs: checkpointTs,
+		Progress:     progress,
+		Backfills:    c.GetLastSavedBackfills(),
+		Barriers:     c.GetLastSavedBarriers(),
+	}
+}
+
+// GetGCBlockingTs returns the ts the gc safepoint must not exceed for the changefeed,
+// which is the min of the saved checkpoint ts and the saved ts of the backfills.
+func (c *Changefeed) GetGCBlockingTs() uint64 {
+	ts := c.GetLastSavedCheckPointTs()
+	for _, b := range c.GetLastSavedBackfills() {
+		ts = min(ts, b.CheckpointTs)
+	}
+	return ts
+}
+
+// BackfillsToPB converts the saved backfills to the ones sent to the maintainer.
+func BackfillsToPB(backfills []*config.TableBackfill) []*heartbeatpb.TableBackfill {
+	if len(backfills) == 0 {
+		return nil
+	}
+	res := make([]*heartbeatpb.TableBackfill, 0, len(backfills))
+	for _, b := range backfills {
+		res = append(res, &heartbeatpb.TableBackfill{TableId: b.TableID, CheckpointTs: b.CheckpointTs})
+	}
+	return res
+}
+
+// BackfillsFromPB converts the backfills reported by the maintainer to the saved ones.
+func BackfillsFromPB(backfills []*heartbeatpb.TableBackfill) []*config.TableBackfill {
+	if len(backfills) == 0 {
+		return nil
+	}
+	res := make([]*config.TableBackfill, 0, len(backfills))
+	for _, b := range backfills {
+		res = append(res, &config.TableBackfill{TableID: b.TableId, CheckpointTs: b.CheckpointTs})
+	}
+	return res
+}
+
+// mergeBarriers applies the barrier coverages reported by the maintainer to the known ones.
+// The changed coverages are reported unless full is true, a coverage without any covered
+// span means the barrier is not partially reported any more. The result is sorted.
+func mergeBarriers(known, reported []*heartbeatpb.BarrierCoverage, full bool) []*heartbeatpb.BarrierCoverage {
+	type barrierKey struct {
+		blockTs     uint64
+		isSyncPoint bool
+	}
+	merged := make([]*heartbeatpb.BarrierCoverage, 0, len(known)+len(reported))
+	if !full {
+		updated := make(map[barrierKey]struct{}, len(reported))
+		for _, r := range reported {
+			updated[barrierKey{r.BlockTs, r.IsSyncPoint}] = struct{}{}
+		}
+		for _, k := range known {
+			if _, ok := updated[barrierKey{k.BlockTs, k.IsSyncPoint}]; !ok {
+				merged = append(merged, k)
+			}
+		}
+	}
+	for _, r := range reported {
+		if len(r.Covered) > 0 {
+			merged = append(merged, r)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].BlockTs != merged[j].BlockTs {
+			return merged[i].BlockTs < merged[j].BlockTs
+		}
+		return !merged[i].IsSyncPoint && merged[j].IsSyncPoint
+	})
+	return merged
+}
+
+// BarriersToPB converts the saved barrier coverages to the ones sent to the maintainer.
+func BarriersToPB(barriers []*config.BarrierCoverage) []*heartbeatpb.BarrierCoverage {
+	if len(barriers) == 0 {
+		return nil
+	}
+	res := make([]*heartbeatpb.BarrierCoverage, 0, len(barriers))
+	for _, b := range barriers {
+		covered := make([]*heartbeatpb.TableSpan, 0, len(b.Covered))
+		for _, span := range b.Covered {
+			covered = append(covered, &heartbeatpb.TableSpan{TableID: span.TableID, StartKey: span.StartKey, EndKey: span.EndKey})
+		}
+		res = append(res, &heartbeatpb.BarrierCoverage{
+			BlockTs:      b.BlockTs,
+			IsSyncPoint:  b.IsSyncPoint,
+			DynamicSplit: b.DynamicSplit,
+			Covered:      covered,
+		})
+	}
+	return res
+}
+
+// BarriersFromPB converts the barrier coverages reported by the maintainer to the saved ones.
+func BarriersFromPB(barriers []*heartbeatpb.BarrierCoverage) []*config.BarrierCoverage {
+	if len(barriers) == 0 {
+		return nil
+	}
+	res := make([]*config.BarrierCoverage, 0, len(barriers))
+	for _, b := range barriers {
+		covered := make([]*config.CoveredSpan, 0, len(b.Covered))
+		for _, span := range b.Covered {
+			covered = append(covered, &config.CoveredSpan{TableID: span.TableID, StartKey: span.StartKey, EndKey: span.EndKey})
+		}
+		res = append(res, &config.BarrierCoverage{
+			BlockTs:      b.BlockTs,
+			IsSyncPoint:  b.IsSyncPoint,
+			DynamicSplit: b.DynamicSplit,
+			Covered:      covered,
 		})
+	}
+	return res
+}
+
+// SafeModeEndTsOnResume returns the safe mode end ts after the checkpoint ts is overwritten by a resume,
+// the events before the old checkpoint ts are written again in safe mode if the checkpoint ts moves backward.
+func SafeModeEndTsOnResume(safeModeEndTs, checkpointTs, newCheckpointTs uint64) uint64 {
+	if newCheckpointTs < checkpointTs {
+		return max(safeModeEndTs, checkpointTs)
+	}
+	return safeModeEndTs
+}
+
+func (c *Changefeed) NewAddMaintainerMessage(server node.ID) *messaging.TargetMessage {
+	req := &heartbeatpb.AddMaintainerRequest{
+		Id:             c.ID.ToPB(),
+		CheckpointTs:   c.GetStatus().CheckpointTs,
+		Config:         *c.configBytes.Load(),
+		IsNewChangfeed: c.isNew,
+		Epoch:          c.GetEpoch(),
+	}
+	if !c.isNew {
+		// resume the barriers and the backfills reported by the previous maintainer
+		req.Barriers = c.GetStatus().Barriers
+		req.Backfills = c.GetStatus().Backfills
+	}
+	return messaging.NewSingleTargetMessage(server, messaging.MaintainerManagerTopic, req)
 }
 
 func (c *Changefeed) NewRemoveMaintainerMessage(server node.ID, caseCade, removed bool) *messaging.TargetMessage {
@@ -23,12 +23,15 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
 // ChangefeedDB is an in memory data struct that maintains all changefeeds
 type ChangefeedDB struct {
-	id                     string
+	id string
+	// epochBase is the base of the epochs of the maintainers, see SetEpochBase.
+	epochBase              atomic.Uint64
 	changefeeds            map[common.ChangeFeedID]*Changefeed
 	changefeedDisplayNames map[common.ChangeFeedDisplayName]common.ChangeFeedID
 
@@ -54,6 +57,12 @@ func NewChangefeedDB(version int64) *ChangefeedDB {
 	return db
 }
 
+// SetEpochBase sets the base of the epochs of the maintainers bound by the coordinator,
+// it must be a PD TSO fetched after the coordinator is elected.
+func (db *ChangefeedDB) SetEpochBase(base uint64) {
+	db.epochBase.Store(base)
+}
+
 func (db *ChangefeedDB) withRLock(action func()) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -166,10 +175,12 @@ func (db *ChangefeedDB) BindChangefeedToNode(old, new node.ID, task *Changefeed)
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
+	epoch := task.bumpEpoch(db.epochBase.Load())
 	log.Info("bind changefeed to node",
 		zap.String("changefeed", task.ID.String()),
 		zap.String("oldNode", old.String()),
-		zap.String("node", new.String()))
+		zap.String("node", new.String()),
+		zap.Uint64("epoch", epoch))
 	db.BindReplicaToNodeWithoutLock(old, new, task)
 }
 
@@ -252,7 +263,8 @@ func (db *ChangefeedDB) MarkMaintainerScheduling(cf *Changefeed) {
 	db.MarkSchedulingWithoutLock(cf)
 }
 
-// CalculateGCSafepoint calculates the minimum checkpointTs of all changefeeds that replicating the upstream TiDB cluster.
+// CalculateGCSafepoint calculates the minimum checkpointTs of all changefeeds that replicating the upstream TiDB cluster,
+// the backfilling tables of a changefeed block the gc at their own checkpoint ts.
 func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -261,10 +273,11 @@ func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 
 	for _, cf := range db.changefeeds {
 		info := cf.GetInfo()
-		if info == nil || !info.NeedBlockGC() {
+		// the changefeeds of the non-default upstreams are calculated by CalculateUpstreamGCSafepoints
+		if info == nil || !info.NeedBlockGC() || info.UpstreamInfo != nil {
 			continue
 		}
-		checkpointTs := cf.GetLastSavedCheckPointTs()
+		checkpointTs := cf.GetGCBlockingTs()
 		if minCpts > checkpointTs {
 			minCpts = checkpointTs
 		}
@@ -272,6 +285,37 @@ func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 	return minCpts
 }
 
+// UpstreamGCSafepoint is the gc safepoint of a non-default upstream.
+type UpstreamGCSafepoint struct {
+	Info         *config.UpstreamInfo
+	CheckpointTs uint64
+}
+
+// CalculateUpstreamGCSafepoints calculates the gc safepoint of each non-default upstream,
+// which is the minimum checkpoint of the changefeeds replicating from it, keyed by the upstream ID.
+func (db *ChangefeedDB) CalculateUpstreamGCSafepoints() map[uint64]*UpstreamGCSafepoint {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	result := make(map[uint64]*UpstreamGCSafepoint)
+	for _, cf := range db.changefeeds {
+		info := cf.GetInfo()
+		if info == nil || !info.NeedBlockGC() || info.UpstreamInfo == nil {
+			continue
+		}
+		checkpointTs := cf.GetGCBlockingTs()
+		safepoint, ok := result[info.UpstreamID]
+		if !ok {
+			result[info.UpstreamID] = &UpstreamGCSafepoint{Info: info.UpstreamInfo, CheckpointTs: checkpointTs}
+			continue
+		}
+		if safepoint.CheckpointTs > checkpointTs {
+			safepoint.CheckpointTs = checkpointTs
+		}
+	}
+	return result
+}
+
 // ReplaceStoppedChangefeed updates the stopped changefeed
 func (db *ChangefeedDB) ReplaceStoppedChangefeed(cf *config.ChangeFeedInfo) {
 	db.lock.Lock()
@@ -284,6 +328,7 @@ func (db *ChangefeedDB) ReplaceStoppedChangefeed(cf *config.ChangeFeedInfo) {
 	}
 	// todo: not create a new changefeed here?
 	newCf := NewChangefeed(cf.ChangefeedID, cf, oldCf.GetStatus().CheckpointTs, false)
+	newCf.RestoreSavedStatus(oldCf.NewSavedStatus(oldCf.GetStatus().CheckpointTs, config.ProgressNone))
 	db.stopped[cf.ChangefeedID] = newCf
 	db.changefeeds[cf.ChangefeedID] = newCf
 }
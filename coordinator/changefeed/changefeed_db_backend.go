@@ -26,8 +26,8 @@ type Backend interface {
 	GetAllChangefeeds(ctx context.Context) (map[common.ChangeFeedID]*ChangefeedMetaWrapper, error)
 	// CreateChangefeed saves changefeed info and status to db
 	CreateChangefeed(ctx context.Context, info *config.ChangeFeedInfo) error
-	// UpdateChangefeed updates changefeed info  to db
-	UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, checkpointTs uint64, progress config.Progress) error
+	// UpdateChangefeed updates changefeed info and status to db
+	UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, status *config.ChangeFeedStatus) error
 	// PauseChangefeed persists the pause status to db for a changefeed
 	PauseChangefeed(ctx context.Context, id common.ChangeFeedID) error
 	// DeleteChangefeed removes all related info of a changefeed from db
@@ -36,8 +36,12 @@ type Backend interface {
 	SetChangefeedProgress(ctx context.Context, id common.ChangeFeedID, progress config.Progress) error
 	// ResumeChangefeed persists the resumed status to db for a changefeed
 	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64) error
-	// UpdateChangefeedCheckpointTs persists the checkpointTs for changefeeds
-	UpdateChangefeedCheckpointTs(ctx context.Context, checkpointTs map[common.ChangeFeedID]uint64) error
+	// UpdateChangefeedCheckpointTs persists the status carrying the checkpointTs for changefeeds
+	UpdateChangefeedCheckpointTs(ctx context.Context, statuses map[common.ChangeFeedID]*config.ChangeFeedStatus) error
+	// GetUpgradeStatus returns the rolling upgrade status of the cluster, nil if no rolling upgrade is started
+	GetUpgradeStatus(ctx context.Context) (*config.UpgradeStatus, error)
+	// SaveUpgradeStatus persists the rolling upgrade status of the cluster, the status is removed if it's nil
+	SaveUpgradeStatus(ctx context.Context, status *config.UpgradeStatus) error
 }
 
 // ChangefeedMetaWrapper is a wrapper for the changefeed load from the DB
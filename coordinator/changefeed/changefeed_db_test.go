@@ -16,6 +16,7 @@ package changefeed
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
@@ -24,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/atomic"
 )
 
@@ -326,4 +328,66 @@ func TestCalculateGCSafepoint(t *testing.T) {
 		}, 7, true)
 	db.AddStoppedChangefeed(cf5)
 	require.Equal(t, uint64(7), db.CalculateGCSafepoint())
+
+	// the backfilling table blocks the gc at its own checkpoint ts
+	cf5.SetLastSavedBackfills([]*config.TableBackfill{{TableID: 1, CheckpointTs: 3}})
+	require.Equal(t, uint64(3), db.CalculateGCSafepoint())
+}
+
+func TestCalculateUpstreamGCSafepoints(t *testing.T) {
+	db := NewChangefeedDB(1216)
+	require.Empty(t, db.CalculateUpstreamGCSafepoints())
+
+	upstreamInfo := &config.UpstreamInfo{ID: 100, PDEndpoints: "http://127.0.0.1:2379"}
+	newChangefeed := func(name string, info *config.UpstreamInfo, checkpointTs uint64) *Changefeed {
+		cfID := common.NewChangeFeedIDWithName(name)
+		upstreamID := uint64(1)
+		if info != nil {
+			upstreamID = info.ID
+		}
+		return NewChangefeed(cfID,
+			&config.ChangeFeedInfo{
+				ChangefeedID: cfID,
+				UpstreamID:   upstreamID,
+				UpstreamInfo: info,
+				Config:       config.GetDefaultReplicaConfig(),
+				State:        model.StateStopped,
+			}, checkpointTs, true)
+	}
+	db.AddStoppedChangefeed(newChangefeed("default", nil, 5))
+	db.AddStoppedChangefeed(newChangefeed("upstream-1", upstreamInfo, 20))
+	db.AddStoppedChangefeed(newChangefeed("upstream-2", upstreamInfo, 12))
+
+	// the changefeeds of the non-default upstream do not block the default gc safepoint
+	require.Equal(t, uint64(5), db.CalculateGCSafepoint())
+	safepoints := db.CalculateUpstreamGCSafepoints()
+	require.Len(t, safepoints, 1)
+	require.Equal(t, uint64(12), safepoints[100].CheckpointTs)
+	require.Equal(t, upstreamInfo, safepoints[100].Info)
+}
+
+func TestBindChangefeedToNodeBumpsEpoch(t *testing.T) {
+	// the coordinator versions are etcd revisions, which may exceed 32 bits.
+	db := NewChangefeedDB(math.MaxUint32 + 3)
+	base := oracle.GoTimeToTS(time.Now())
+	db.SetEpochBase(base)
+	cf := &Changefeed{ID: common.NewChangeFeedIDWithName("test")}
+	cf.backoff = NewBackoff(cf.ID, 0, 0)
+	db.AddAbsentChangefeed(cf)
+
+	db.BindChangefeedToNode("", "node-1", cf)
+	require.Equal(t, base, cf.GetEpoch())
+	db.BindChangefeedToNode("node-1", "node-2", cf)
+	require.Equal(t, base+1, cf.GetEpoch())
+
+	// the epochs assigned by a new coordinator are larger than the old ones
+	newDB := NewChangefeedDB(math.MaxUint32 + 5)
+	newBase := oracle.GoTimeToTS(time.Now().Add(time.Second))
+	newDB.SetEpochBase(newBase)
+	newCf := &Changefeed{ID: cf.ID}
+	newCf.backoff = NewBackoff(cf.ID, 0, 0)
+	newDB.AddReplicatingMaintainer(newCf, "node-2")
+	newDB.BindChangefeedToNode("node-2", "node-3", newCf)
+	require.Equal(t, newBase, newCf.GetEpoch())
+	require.Greater(t, newCf.GetEpoch(), cf.GetEpoch())
 }
@@ -90,6 +90,26 @@ func TestChangefeed_UpdateStatus(t *testing.T) {
 	require.Equal(t, newStatus, cf.GetStatus())
 }
 
+func TestChangefeed_GetWeight(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		SinkURI: "kafka://127.0.0.1:9092",
+		State:   model.StateNormal,
+		Config:  config.GetDefaultReplicaConfig(),
+	}
+	cf := NewChangefeed(cfID, info, 100, true)
+	require.Equal(t, int64(1), cf.GetWeight())
+
+	_, _, _ = cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 200, TableCount: 10, EventSizePerSecond: 5 * trafficPerWeight,
+	})
+	require.Equal(t, int64(16), cf.GetWeight())
+
+	// the weight is kept if the table count is not reported
+	_, _, _ = cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 300})
+	require.Equal(t, int64(16), cf.GetWeight())
+}
+
 func TestChangefeed_IsMQSink(t *testing.T) {
 	cfID := common.NewChangeFeedIDWithName("test")
 	info := &config.ChangeFeedInfo{
@@ -116,6 +136,91 @@ func TestChangefeed_GetSetLastSavedCheckPointTs(t *testing.T) {
 	require.Equal(t, newTs, cf.GetLastSavedCheckPointTs())
 }
 
+func TestChangefeed_RestoreSavedStatus(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		SinkURI: "kafka://127.0.0.1:9092",
+		State:   model.StateNormal,
+		Config:  config.GetDefaultReplicaConfig(),
+	}
+	cf := NewChangefeed(cfID, info, 100, false)
+	require.Equal(t, uint64(100), cf.GetGCBlockingTs())
+
+	backfills := []*config.TableBackfill{{TableID: 1, CheckpointTs: 50}, {TableID: 2, CheckpointTs: 80}}
+	barriers := []*config.BarrierCoverage{{
+		BlockTs: 120,
+		Covered: []*config.CoveredSpan{{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}},
+	}}
+	cf.RestoreSavedStatus(&config.ChangeFeedStatus{CheckpointTs: 100, Backfills: backfills, Barriers: barriers})
+	require.Equal(t, backfills, cf.GetLastSavedBackfills())
+	require.Equal(t, barriers, cf.GetLastSavedBarriers())
+	require.Equal(t, uint64(50), cf.GetGCBlockingTs())
+	saved := cf.NewSavedStatus(100, config.ProgressStopping)
+	require.Equal(t, backfills, saved.Backfills)
+	require.Equal(t, barriers, saved.Barriers)
+
+	// the backfills are sent to the next maintainer
+	req := cf.NewAddMaintainerMessage("server-1").Message[0].(*heartbeatpb.AddMaintainerRequest)
+	require.Equal(t, uint64(100), req.CheckpointTs)
+	require.Equal(t, BackfillsToPB(backfills), req.Backfills)
+	require.Equal(t, backfills, BackfillsFromPB(req.Backfills))
+	require.Equal(t, BarriersToPB(barriers), req.Barriers)
+	require.Equal(t, barriers, BarriersFromPB(req.Barriers))
+
+	// a new changefeed starts without the backfills
+	cf.SetIsNew(true)
+	req = cf.NewAddMaintainerMessage("server-1").Message[0].(*heartbeatpb.AddMaintainerRequest)
+	require.Empty(t, req.Backfills)
+}
+
+func TestChangefeed_UpdateStatusMergeBarriers(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		SinkURI: "kafka://127.0.0.1:9092",
+		State:   model.StateNormal,
+		Config:  config.GetDefaultReplicaConfig(),
+	}
+	cf := NewChangefeed(cfID, info, 100, false)
+	coverage := func(blockTs uint64, tableIDs ...int64) *heartbeatpb.BarrierCoverage {
+		c := &heartbeatpb.BarrierCoverage{BlockTs: blockTs}
+		for _, id := range tableIDs {
+			c.Covered = append(c.Covered, &heartbeatpb.TableSpan{TableID: id})
+		}
+		return c
+	}
+
+	// a full report replaces the known coverages
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 100,
+		Barriers:     []*heartbeatpb.BarrierCoverage{coverage(130, 1), coverage(120, 2)},
+		BarriersFull: true,
+	})
+	require.Equal(t, []*heartbeatpb.BarrierCoverage{coverage(120, 2), coverage(130, 1)}, cf.GetStatus().Barriers)
+
+	// a delta updates the changed coverages only
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 101,
+		Barriers:     []*heartbeatpb.BarrierCoverage{coverage(130, 1, 3), coverage(140, 4)},
+	})
+	require.Equal(t, []*heartbeatpb.BarrierCoverage{coverage(120, 2), coverage(130, 1, 3), coverage(140, 4)},
+		cf.GetStatus().Barriers)
+
+	// a coverage without any covered span removes the barrier
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 102,
+		Barriers:     []*heartbeatpb.BarrierCoverage{coverage(120)},
+	})
+	require.Equal(t, []*heartbeatpb.BarrierCoverage{coverage(130, 1, 3), coverage(140, 4)}, cf.GetStatus().Barriers)
+
+	// a status without any barrier keeps the known coverages
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 103})
+	require.Len(t, cf.GetStatus().Barriers, 2)
+
+	// a full report without any barrier clears them
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 104, BarriersFull: true})
+	require.Empty(t, cf.GetStatus().Barriers)
+}
+
 func TestChangefeed_NewAddMaintainerMessage(t *testing.T) {
 	cfID := common.NewChangeFeedIDWithName("test")
 	info := &config.ChangeFeedInfo{
@@ -161,16 +161,12 @@ func (b *EtcdBackend) CreateChangefeed(ctx context.Context,
 	return nil
 }
 
-func (b *EtcdBackend) UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, checkpointTs uint64, progress config.Progress) error {
+func (b *EtcdBackend) UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, status *config.ChangeFeedStatus) error {
 	infoKey := etcd.GetEtcdKeyChangeFeedInfo(b.etcdClient.GetClusterID(), info.ChangefeedID.DisplayName)
 	newStr, err := info.Marshal()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	status := &config.ChangeFeedStatus{
-		CheckpointTs: checkpointTs,
-		Progress:     progress,
-	}
 	statusStr, err := status.Marshal()
 	if err != nil {
 		return errors.Trace(err)
@@ -199,6 +195,7 @@ func (b *EtcdBackend) PauseChangefeed(ctx context.Context, id common.ChangeFeedI
 		return errors.Trace(err)
 	}
 	info.State = model.StateStopped
+	info.PausedBySchedule = false
 	infoKey := etcd.GetEtcdKeyChangeFeedInfo(b.etcdClient.GetClusterID(), id.DisplayName)
 	inforValue, err := info.Marshal()
 	if err != nil {
@@ -257,19 +254,20 @@ func (b *EtcdBackend) ResumeChangefeed(ctx context.Context,
 		return errors.Trace(err)
 	}
 	info.State = model.StateNormal
-	newStr, err := info.Marshal()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	infoKey := etcd.GetEtcdKeyChangeFeedInfo(b.etcdClient.GetClusterID(), id.DisplayName)
-	opsThen := []clientv3.Op{
-		clientv3.OpPut(infoKey, newStr),
-	}
+	info.PausedBySchedule = false
+	info.PausedForImport = false
+	var opsThen []clientv3.Op
 	if newCheckpointTs > 0 {
 		status, _, err := b.etcdClient.GetChangeFeedStatus(ctx, id)
 		if err != nil {
 			return errors.Trace(err)
 		}
+		info.SafeModeEndTs = SafeModeEndTsOnResume(info.SafeModeEndTs, status.CheckpointTs, newCheckpointTs)
+		if status.CheckpointTs != newCheckpointTs {
+			// the tables are replicated from the new checkpoint ts, the backfills and the barriers are dropped
+			status.Backfills = nil
+			status.Barriers = nil
+		}
 		status.CheckpointTs = newCheckpointTs
 		jobValue, err := status.Marshal()
 		if err != nil {
@@ -278,6 +276,12 @@ func (b *EtcdBackend) ResumeChangefeed(ctx context.Context,
 		jobKey := etcd.GetEtcdKeyJob(b.etcdClient.GetClusterID(), id.DisplayName)
 		opsThen = append(opsThen, clientv3.OpPut(jobKey, jobValue))
 	}
+	newStr, err := info.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	infoKey := etcd.GetEtcdKeyChangeFeedInfo(b.etcdClient.GetClusterID(), id.DisplayName)
+	opsThen = append(opsThen, clientv3.OpPut(infoKey, newStr))
 
 	putResp, err := b.etcdClient.GetEtcdClient().Txn(ctx, nil, opsThen, []clientv3.Op{})
 	if err != nil {
@@ -315,7 +319,7 @@ func (b *EtcdBackend) SetChangefeedProgress(ctx context.Context, id common.Chang
 	return nil
 }
 
-func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[common.ChangeFeedID]uint64) error {
+func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, statuses map[common.ChangeFeedID]*config.ChangeFeedStatus) error {
 	opsThen := make([]clientv3.Op, 0, 128)
 	batchSize := 0
 
@@ -330,8 +334,7 @@ func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[
 		}
 		return err
 	}
-	for cfID, checkpointTs := range cps {
-		status := &config.ChangeFeedStatus{CheckpointTs: checkpointTs, Progress: config.ProgressNone}
+	for cfID, status := range statuses {
 		jobValue, err := status.Marshal()
 		if err != nil {
 			return errors.Trace(err)
@@ -355,6 +358,35 @@ func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[
 	return nil
 }
 
+func (b *EtcdBackend) GetUpgradeStatus(ctx context.Context) (*config.UpgradeStatus, error) {
+	resp, err := b.etcdClient.GetEtcdClient().Get(ctx, etcd.UpgradeStatusKey(b.etcdClient.GetClusterID()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	status := &config.UpgradeStatus{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, status); err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	return status, nil
+}
+
+func (b *EtcdBackend) SaveUpgradeStatus(ctx context.Context, status *config.UpgradeStatus) error {
+	key := etcd.UpgradeStatusKey(b.etcdClient.GetClusterID())
+	if status == nil {
+		_, err := b.etcdClient.GetEtcdClient().Delete(ctx, key)
+		return errors.Trace(err)
+	}
+	value, err := json.Marshal(status)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	_, err = b.etcdClient.GetEtcdClient().Put(ctx, key, string(value))
+	return errors.Trace(err)
+}
+
 // extractKeySuffix extracts the suffix of an etcd key, such as extracting
 // "6a6c6dd290bc8732" from /tidb/cdc/cluster/namespace/changefeed/info/6a6c6dd290bc8732
 // or from /tidb/cdc/cluster/namespace/changefeed/status/6a6c6dd290bc8732
@@ -121,12 +121,14 @@ func TestUpdateChangefeed(t *testing.T) {
 	backend := NewEtcdBackend(cdcClient)
 
 	etcdClient.EXPECT().Txn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("txn failed")).Times(1)
-	require.NotNil(t, backend.UpdateChangefeed(context.Background(), &config.ChangeFeedInfo{}, 0, config.ProgressStopping))
+	require.NotNil(t, backend.UpdateChangefeed(context.Background(), &config.ChangeFeedInfo{},
+		&config.ChangeFeedStatus{CheckpointTs: 0, Progress: config.ProgressStopping}))
 
 	// txn fail
 	etcdClient.EXPECT().Txn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&clientv3.TxnResponse{Succeeded: false}, nil).Times(1)
-	require.NotNil(t, backend.UpdateChangefeed(context.Background(), &config.ChangeFeedInfo{}, 0, config.ProgressStopping))
+	require.NotNil(t, backend.UpdateChangefeed(context.Background(), &config.ChangeFeedInfo{},
+		&config.ChangeFeedStatus{CheckpointTs: 0, Progress: config.ProgressStopping}))
 
 	etcdClient.EXPECT().Txn(gomock.Any(), gomock.Len(0), NewFuncMatcher(func(i interface{}) bool {
 		ops := i.([]clientv3.Op)
@@ -135,7 +137,8 @@ func TestUpdateChangefeed(t *testing.T) {
 		require.True(t, ops[1].IsPut())
 		return true
 	}), gomock.Any()).Return(&clientv3.TxnResponse{Succeeded: true}, nil).Times(1)
-	require.Nil(t, backend.UpdateChangefeed(context.Background(), &config.ChangeFeedInfo{}, 2, config.ProgressStopping))
+	require.Nil(t, backend.UpdateChangefeed(context.Background(), &config.ChangeFeedInfo{},
+		&config.ChangeFeedStatus{CheckpointTs: 2, Progress: config.ProgressStopping}))
 }
 
 func TestPauseChangefeed(t *testing.T) {
@@ -204,6 +207,24 @@ func TestResumeChangefeed(t *testing.T) {
 
 	err := backend.ResumeChangefeed(context.Background(), changefeedID, 200)
 	require.Nil(t, err)
+	require.Equal(t, uint64(0), info.SafeModeEndTs)
+
+	// the old checkpoint ts is saved as the safe mode end ts if the checkpoint ts moves backward
+	info = &config.ChangeFeedInfo{State: model.StateStopped}
+	status = &config.ChangeFeedStatus{CheckpointTs: 100}
+	cdcClient.EXPECT().GetChangeFeedInfo(gomock.Any(), changefeedID.DisplayName).Return(info, nil).Times(1)
+	cdcClient.EXPECT().GetChangeFeedStatus(gomock.Any(), changefeedID).Return(status, int64(0), nil).Times(1)
+	var saved *config.ChangeFeedInfo
+	etcdClient.EXPECT().Txn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ []clientv3.Cmp, opsThen, _ []clientv3.Op) (*clientv3.TxnResponse, error) {
+			saved = &config.ChangeFeedInfo{}
+			require.NoError(t, saved.Unmarshal(opsThen[len(opsThen)-1].ValueBytes()))
+			return &clientv3.TxnResponse{Succeeded: true}, nil
+		}).Times(1)
+	err = backend.ResumeChangefeed(context.Background(), changefeedID, 50)
+	require.Nil(t, err)
+	require.Equal(t, uint64(100), saved.SafeModeEndTs)
+	require.Equal(t, model.StateNormal, saved.State)
 }
 
 func TestSetChangefeedProgress(t *testing.T) {
@@ -236,16 +257,16 @@ func TestUpdateChangefeedCheckpointTs(t *testing.T) {
 	cdcClient.EXPECT().GetClusterID().Return("test-cluster-id").AnyTimes()
 	backend := NewEtcdBackend(cdcClient)
 
-	cps := map[common.ChangeFeedID]uint64{
-		common.NewChangeFeedIDWithName("test1"): 100,
+	cps := map[common.ChangeFeedID]*config.ChangeFeedStatus{
+		common.NewChangeFeedIDWithName("test1"): {CheckpointTs: 100},
 	}
 	etcdClient.EXPECT().Txn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&clientv3.TxnResponse{Succeeded: false}, nil).Times(1)
 	err := backend.UpdateChangefeedCheckpointTs(context.Background(), cps)
 	require.NotNil(t, err)
 
-	cps = make(map[common.ChangeFeedID]uint64)
+	cps = make(map[common.ChangeFeedID]*config.ChangeFeedStatus)
 	for i := 0; i < 129; i++ {
-		cps[common.NewChangeFeedIDWithName(fmt.Sprintf("%d", i))] = 100
+		cps[common.NewChangeFeedIDWithName(fmt.Sprintf("%d", i))] = &config.ChangeFeedStatus{CheckpointTs: 100}
 	}
 	etcdClient.EXPECT().Txn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&clientv3.TxnResponse{Succeeded: true}, nil).Times(2)
 	err = backend.UpdateChangefeedCheckpointTs(context.Background(), cps)
@@ -80,6 +80,21 @@ func (mr *MockBackendMockRecorder) GetAllChangefeeds(ctx interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllChangefeeds", reflect.TypeOf((*MockBackend)(nil).GetAllChangefeeds), ctx)
 }
 
+// GetUpgradeStatus mocks base method.
+func (m *MockBackend) GetUpgradeStatus(ctx context.Context) (*config.UpgradeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpgradeStatus", ctx)
+	ret0, _ := ret[0].(*config.UpgradeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpgradeStatus indicates an expected call of GetUpgradeStatus.
+func (mr *MockBackendMockRecorder) GetUpgradeStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpgradeStatus", reflect.TypeOf((*MockBackend)(nil).GetUpgradeStatus), ctx)
+}
+
 // PauseChangefeed mocks base method.
 func (m *MockBackend) PauseChangefeed(ctx context.Context, id common.ChangeFeedID) error {
 	m.ctrl.T.Helper()
@@ -108,6 +123,20 @@ func (mr *MockBackendMockRecorder) ResumeChangefeed(ctx, id, newCheckpointTs int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeChangefeed", reflect.TypeOf((*MockBackend)(nil).ResumeChangefeed), ctx, id, newCheckpointTs)
 }
 
+// SaveUpgradeStatus mocks base method.
+func (m *MockBackend) SaveUpgradeStatus(ctx context.Context, status *config.UpgradeStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUpgradeStatus", ctx, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUpgradeStatus indicates an expected call of SaveUpgradeStatus.
+func (mr *MockBackendMockRecorder) SaveUpgradeStatus(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUpgradeStatus", reflect.TypeOf((*MockBackend)(nil).SaveUpgradeStatus), ctx, status)
+}
+
 // SetChangefeedProgress mocks base method.
 func (m *MockBackend) SetChangefeedProgress(ctx context.Context, id common.ChangeFeedID, progress config.Progress) error {
 	m.ctrl.T.Helper()
@@ -123,29 +152,29 @@ func (mr *MockBackendMockRecorder) SetChangefeedProgress(ctx, id, progress inter
 }
 
 // UpdateChangefeed mocks base method.
-func (m *MockBackend) UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, checkpointTs uint64, progress config.Progress) error {
+func (m *MockBackend) UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, status *config.ChangeFeedStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateChangefeed", ctx, info, checkpointTs, progress)
+	ret := m.ctrl.Call(m, "UpdateChangefeed", ctx, info, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateChangefeed indicates an expected call of UpdateChangefeed.
-func (mr *MockBackendMockRecorder) UpdateChangefeed(ctx, info, checkpointTs, progress interface{}) *gomock.Call {
+func (mr *MockBackendMockRecorder) UpdateChangefeed(ctx, info, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangefeed", reflect.TypeOf((*MockBackend)(nil).UpdateChangefeed), ctx, info, checkpointTs, progress)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangefeed", reflect.TypeOf((*MockBackend)(nil).UpdateChangefeed), ctx, info, status)
 }
 
 // UpdateChangefeedCheckpointTs mocks base method.
-func (m *MockBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[common.ChangeFeedID]uint64) error {
+func (m *MockBackend) UpdateChangefeedCheckpointTs(ctx context.Context, statuses map[common.ChangeFeedID]*config.ChangeFeedStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateChangefeedCheckpointTs", ctx, cps)
+	ret := m.ctrl.Call(m, "UpdateChangefeedCheckpointTs", ctx, statuses)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateChangefeedCheckpointTs indicates an expected call of UpdateChangefeedCheckpointTs.
-func (mr *MockBackendMockRecorder) UpdateChangefeedCheckpointTs(ctx, cps interface{}) *gomock.Call {
+func (mr *MockBackendMockRecorder) UpdateChangefeedCheckpointTs(ctx, statuses interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangefeedCheckpointTs", reflect.TypeOf((*MockBackend)(nil).UpdateChangefeedCheckpointTs), ctx, cps)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangefeedCheckpointTs", reflect.TypeOf((*MockBackend)(nil).UpdateChangefeedCheckpointTs), ctx, statuses)
 }
@@ -0,0 +1,270 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	// standbyCatchUpTimeout is the max time to catch up with etcd when the standby takes over,
+	// the changefeeds are loaded from etcd if the mirror can not catch up in time.
+	standbyCatchUpTimeout = 3 * time.Second
+	// standbyRetryInterval is the interval to restart the mirror after it fails.
+	standbyRetryInterval = time.Second
+)
+
+// StandbyBackend is the EtcdBackend used by a standby coordinator node.
+// It mirrors the changefeed meta in etcd by watching it before the node becomes the coordinator,
+// the checkpoints persisted by the primary coordinator are consumed from the watch, so the
+// coordinator is bootstrapped by the mirrored meta instead of loading all changefeeds
+// from etcd when the node takes over. The alive nodes are already mirrored by the node manager
+// on every node.
+type StandbyBackend struct {
+	*EtcdBackend
+
+	mu       sync.RWMutex
+	infos    map[common.ChangeFeedDisplayName]*config.ChangeFeedInfo
+	statuses map[common.ChangeFeedDisplayName]*config.ChangeFeedStatus
+	// revisions is the mod revision of the mirrored keys, the deleted keys are kept with
+	// the revision they are deleted at, so a stale event never overwrites a newer one.
+	revisions map[string]int64
+	// ready is true after the mirror is loaded and it's kept up to date by the watch.
+	ready bool
+}
+
+// NewStandbyBackend creates a StandbyBackend
+func NewStandbyBackend(etcdClient etcd.CDCEtcdClient) *StandbyBackend {
+	return &StandbyBackend{
+		EtcdBackend: NewEtcdBackend(etcdClient),
+		infos:       make(map[common.ChangeFeedDisplayName]*config.ChangeFeedInfo),
+		statuses:    make(map[common.ChangeFeedDisplayName]*config.ChangeFeedStatus),
+		revisions:   make(map[string]int64),
+	}
+}
+
+func (b *StandbyBackend) changefeedPrefix() string {
+	return etcd.NamespacedPrefix(b.etcdClient.GetClusterID(), model.DefaultNamespace) + "/changefeed"
+}
+
+// Run keeps the mirror up to date until the context is canceled.
+func (b *StandbyBackend) Run(ctx context.Context) error {
+	for {
+		err := b.mirror(ctx)
+		b.mu.Lock()
+		b.ready = false
+		b.mu.Unlock()
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Warn("mirror changefeeds failed, retry later", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(standbyRetryInterval):
+		}
+	}
+}
+
+func (b *StandbyBackend) mirror(ctx context.Context) error {
+	prefix := b.changefeedPrefix()
+	resp, err := b.etcdClient.GetEtcdClient().Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b.mu.Lock()
+	b.infos = make(map[common.ChangeFeedDisplayName]*config.ChangeFeedInfo)
+	b.statuses = make(map[common.ChangeFeedDisplayName]*config.ChangeFeedStatus)
+	b.revisions = make(map[string]int64)
+	for _, kv := range resp.Kvs {
+		b.applyPut(kv)
+	}
+	b.ready = true
+	size := len(b.infos)
+	b.mu.Unlock()
+	log.Info("changefeeds are mirrored by the standby coordinator",
+		zap.Int("changefeeds", size), zap.Int64("revision", resp.Header.Revision))
+
+	watchCh := b.etcdClient.GetEtcdClient().Watch(ctx, prefix, "coordinator-standby",
+		clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case watchResp, ok := <-watchCh:
+			if !ok {
+				return errors.New("watch channel closed")
+			}
+			if err := watchResp.Err(); err != nil {
+				return errors.Trace(err)
+			}
+			b.mu.Lock()
+			for _, event := range watchResp.Events {
+				switch event.Type {
+				case mvccpb.PUT:
+					b.applyPut(event.Kv)
+				case mvccpb.DELETE:
+					b.applyDelete(string(event.Kv.Key), event.Kv.ModRevision)
+				}
+			}
+			b.mu.Unlock()
+		}
+	}
+}
+
+// applyPut applies a put changefeed info or status to the mirror, the caller must hold the lock.
+func (b *StandbyBackend) applyPut(kv *mvccpb.KeyValue) {
+	key := string(kv.Key)
+	if kv.ModRevision < b.revisions[key] {
+		return
+	}
+	b.revisions[key] = kv.ModRevision
+	ns, cf, isStatus := extractKeySuffix(key)
+	displayName := common.NewChangeFeedDisplayName(cf, ns)
+	if isStatus {
+		status := &config.ChangeFeedStatus{}
+		if err := status.Unmarshal(kv.Value); err != nil {
+			log.Warn("failed to unmarshal change feed Status, ignore",
+				zap.String("key", key), zap.Error(err))
+			delete(b.statuses, displayName)
+			return
+		}
+		b.statuses[displayName] = status
+		return
+	}
+	info := &config.ChangeFeedInfo{}
+	if err := info.Unmarshal(kv.Value); err != nil {
+		log.Warn("failed to unmarshal change feed Info, ignore",
+			zap.String("key", key), zap.Error(err))
+		delete(b.infos, displayName)
+		return
+	}
+	b.infos[displayName] = info
+}
+
+// applyDelete removes a changefeed info or status deleted at the revision from the mirror,
+// the caller must hold the lock.
+func (b *StandbyBackend) applyDelete(key string, revision int64) {
+	if revision < b.revisions[key] {
+		return
+	}
+	b.revisions[key] = revision
+	ns, cf, isStatus := extractKeySuffix(key)
+	if isStatus {
+		delete(b.statuses, common.NewChangeFeedDisplayName(cf, ns))
+	} else {
+		delete(b.infos, common.NewChangeFeedDisplayName(cf, ns))
+	}
+}
+
+// GetAllChangefeeds returns the mirrored changefeeds after the mirror catches up with
+// the latest changefeed meta and checkpoints written by the primary coordinator, or loads
+// them from etcd if the mirror is not ready in time or some changefeeds need to be fixed
+// by the EtcdBackend.
+func (b *StandbyBackend) GetAllChangefeeds(ctx context.Context) (map[common.ChangeFeedID]*ChangefeedMetaWrapper, error) {
+	catchUpCtx, cancel := context.WithTimeout(ctx, standbyCatchUpTimeout)
+	defer cancel()
+	cfMap, err := b.catchUp(catchUpCtx)
+	if err == nil && cfMap != nil {
+		log.Info("load all changefeeds from the standby mirror", zap.Int("size", len(cfMap)))
+		return cfMap, nil
+	}
+	log.Info("the standby mirror is not ready, load all changefeeds from etcd", zap.Error(err))
+	return b.EtcdBackend.GetAllChangefeeds(ctx)
+}
+
+// catchUp compares the mod revisions of the changefeed keys in etcd with the mirror, and
+// fetches the keys updated after the last event received by the watch, such as the final
+// checkpoints persisted by the primary coordinator before it exits. It returns nil if the
+// mirror is not ready or some changefeeds can not be used directly.
+func (b *StandbyBackend) catchUp(ctx context.Context) (map[common.ChangeFeedID]*ChangefeedMetaWrapper, error) {
+	b.mu.RLock()
+	ready := b.ready
+	b.mu.RUnlock()
+	if !ready {
+		return nil, nil
+	}
+
+	cli := b.etcdClient.GetEtcdClient()
+	resp, err := cli.Get(ctx, b.changefeedPrefix(), clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var stale []string
+	exists := make(map[string]struct{}, len(resp.Kvs))
+	b.mu.Lock()
+	for _, kv := range resp.Kvs {
+		key := string(kv.Key)
+		exists[key] = struct{}{}
+		if kv.ModRevision > b.revisions[key] {
+			stale = append(stale, key)
+		}
+	}
+	for key := range b.revisions {
+		if _, ok := exists[key]; !ok {
+			b.applyDelete(key, resp.Header.Revision)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, key := range stale {
+		kvResp, err := cli.Get(ctx, key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		b.mu.Lock()
+		if len(kvResp.Kvs) == 0 {
+			b.applyDelete(key, kvResp.Header.Revision)
+		} else {
+			b.applyPut(kvResp.Kvs[0])
+		}
+		b.mu.Unlock()
+	}
+	if len(stale) > 0 {
+		log.Info("the standby mirror caught up with etcd", zap.Int("staleKeys", len(stale)))
+	}
+	return b.snapshot(), nil
+}
+
+// snapshot returns a copy of the mirror, it returns nil if the mirror is not ready
+// or some changefeeds can not be used directly.
+func (b *StandbyBackend) snapshot() map[common.ChangeFeedID]*ChangefeedMetaWrapper {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.ready {
+		return nil
+	}
+	cfMap := make(map[common.ChangeFeedID]*ChangefeedMetaWrapper, len(b.infos))
+	for displayName, info := range b.infos {
+		status, exist := b.statuses[displayName]
+		// an old version info or a changefeed without status is fixed by the EtcdBackend.
+		if !exist || info.ChangefeedID.Name() == "" {
+			return nil
+		}
+		cfMap[info.ChangefeedID] = &ChangefeedMetaWrapper{Info: info, Status: status}
+	}
+	return cfMap
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	mock_etcd "github.com/pingcap/ticdc/pkg/etcd/mock"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func mustMarshalInfo(t *testing.T, name string) []byte {
+	info := &config.ChangeFeedInfo{ChangefeedID: common.NewChangeFeedIDWithName(name)}
+	data, err := info.Marshal()
+	require.NoError(t, err)
+	return []byte(data)
+}
+
+func mustMarshalStatus(t *testing.T, checkpointTs uint64) []byte {
+	status := &config.ChangeFeedStatus{CheckpointTs: checkpointTs}
+	data, err := status.Marshal()
+	require.NoError(t, err)
+	return []byte(data)
+}
+
+func infoKV(t *testing.T, name string, revision int64) *mvccpb.KeyValue {
+	return &mvccpb.KeyValue{
+		Key:         []byte("/tidb/cdc/default/default/changefeed/info/" + name),
+		Value:       mustMarshalInfo(t, name),
+		ModRevision: revision,
+	}
+}
+
+func statusKV(t *testing.T, name string, checkpointTs uint64, revision int64) *mvccpb.KeyValue {
+	return &mvccpb.KeyValue{
+		Key:         []byte("/tidb/cdc/default/default/changefeed/status/" + name),
+		Value:       mustMarshalStatus(t, checkpointTs),
+		ModRevision: revision,
+	}
+}
+
+func keysOnly(kvs ...*mvccpb.KeyValue) []*mvccpb.KeyValue {
+	res := make([]*mvccpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		res = append(res, &mvccpb.KeyValue{Key: kv.Key, ModRevision: kv.ModRevision})
+	}
+	return res
+}
+
+func checkpointsOf(cfs map[common.ChangeFeedID]*ChangefeedMetaWrapper) map[string]uint64 {
+	checkpoints := make(map[string]uint64)
+	for id, cf := range cfs {
+		checkpoints[id.Name()] = cf.Status.CheckpointTs
+	}
+	return checkpoints
+}
+
+func newStandbyBackendForTest(
+	t *testing.T, kvs ...*mvccpb.KeyValue,
+) (*StandbyBackend, *mock_etcd.MockClient, chan clientv3.WatchResponse, context.CancelFunc) {
+	ctrl := gomock.NewController(t)
+	cdcClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	etcdClient := mock_etcd.NewMockClient(ctrl)
+	cdcClient.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	cdcClient.EXPECT().GetClusterID().Return("test-cluster-id").AnyTimes()
+	backend := NewStandbyBackend(cdcClient)
+
+	// the initial load of the mirror
+	etcdClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&clientv3.GetResponse{
+			Header: &etcdserverpb.ResponseHeader{Revision: 10},
+			Kvs:    kvs,
+		}, nil).Times(1)
+	watchCh := make(chan clientv3.WatchResponse, 1)
+	etcdClient.EXPECT().Watch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(clientv3.WatchChan(watchCh)).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = backend.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		return backend.snapshot() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return backend, etcdClient, watchCh, func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestStandbyBackendGetAllChangefeeds(t *testing.T) {
+	backend, etcdClient, watchCh, stop := newStandbyBackendForTest(t,
+		infoKV(t, "test1", 5), statusKV(t, "test1", 100, 10))
+
+	// a changefeed is created after the mirror is loaded
+	test2 := []*mvccpb.KeyValue{infoKV(t, "test2", 12), statusKV(t, "test2", 200, 12)}
+	watchCh <- clientv3.WatchResponse{
+		Header: etcdserverpb.ResponseHeader{Revision: 12},
+		Events: []*clientv3.Event{{Type: mvccpb.PUT, Kv: test2[0]}, {Type: mvccpb.PUT, Kv: test2[1]}},
+	}
+	require.Eventually(t, func() bool {
+		return len(backend.snapshot()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the mirror is up to date, all changefeeds are loaded from it
+	etcdClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&clientv3.GetResponse{
+			Header: &etcdserverpb.ResponseHeader{Revision: 30},
+			Kvs:    keysOnly(infoKV(t, "test1", 5), statusKV(t, "test1", 100, 10), test2[0], test2[1]),
+		}, nil).Times(1)
+	cfs, err := backend.GetAllChangefeeds(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"test1": 100, "test2": 200}, checkpointsOf(cfs))
+
+	// the changefeed without status can not be loaded from the mirror
+	watchCh <- clientv3.WatchResponse{
+		Header: etcdserverpb.ResponseHeader{Revision: 13},
+		Events: []*clientv3.Event{
+			{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: test2[1].Key, ModRevision: 13}},
+		},
+	}
+	require.Eventually(t, func() bool {
+		return backend.snapshot() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stop()
+	require.Nil(t, backend.snapshot())
+}
+
+func TestStandbyBackendPromotion(t *testing.T) {
+	backend, etcdClient, watchCh, stop := newStandbyBackendForTest(t,
+		infoKV(t, "test1", 5), statusKV(t, "test1", 100, 10),
+		infoKV(t, "test2", 6), statusKV(t, "test2", 200, 10))
+	defer stop()
+
+	// the primary coordinator persisted the checkpoints before it exits, the checkpoint
+	// of test1 is received by the watch but the ones after it are not.
+	watchCh <- clientv3.WatchResponse{
+		Header: etcdserverpb.ResponseHeader{Revision: 15},
+		Events: []*clientv3.Event{{Type: mvccpb.PUT, Kv: statusKV(t, "test1", 150, 15)}},
+	}
+	require.Eventually(t, func() bool {
+		return checkpointsOf(backend.snapshot())["test1"] == 150
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the standby takes over, it catches up with the checkpoint of test1 and the
+	// removal of test2 which are not received by the watch yet.
+	etcdClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&clientv3.GetResponse{
+			Header: &etcdserverpb.ResponseHeader{Revision: 30},
+			Kvs:    keysOnly(infoKV(t, "test1", 5), statusKV(t, "test1", 180, 20)),
+		}, nil).Times(1)
+	etcdClient.EXPECT().Get(gomock.Any(), "/tidb/cdc/default/default/changefeed/status/test1").
+		Return(&clientv3.GetResponse{
+			Header: &etcdserverpb.ResponseHeader{Revision: 30},
+			Kvs:    []*mvccpb.KeyValue{statusKV(t, "test1", 180, 20)},
+		}, nil).Times(1)
+	cfs, err := backend.GetAllChangefeeds(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"test1": 180}, checkpointsOf(cfs))
+
+	// the stale events received by the watch later are ignored
+	watchCh <- clientv3.WatchResponse{
+		Header: etcdserverpb.ResponseHeader{Revision: 20},
+		Events: []*clientv3.Event{
+			{Type: mvccpb.PUT, Kv: statusKV(t, "test2", 250, 18)},
+			{Type: mvccpb.PUT, Kv: statusKV(t, "test1", 160, 19)},
+		},
+	}
+	// the events are applied once the following responses are received
+	watchCh <- clientv3.WatchResponse{Header: etcdserverpb.ResponseHeader{Revision: 20}}
+	watchCh <- clientv3.WatchResponse{Header: etcdserverpb.ResponseHeader{Revision: 20}}
+	require.Equal(t, map[string]uint64{"test1": 180}, checkpointsOf(backend.snapshot()))
+}
@@ -18,6 +18,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	"github.com/pingcap/ticdc/coordinator/operator"
@@ -39,13 +40,17 @@ import (
 	"go.uber.org/zap"
 )
 
+// checkPauseWindowInterval is the interval to check the pause windows of changefeeds.
+const checkPauseWindowInterval = 10 * time.Second
+
 // Controller schedules and balance changefeeds, there are 3 main components:
 //  1. scheduler: generate operators for handling different scheduling tasks.
 //  2. operatorController: manage all operators and execute them periodically.
 //  3. changefeedDB: store all changefeeds info and their status in memory.
 //  4. backend: the durable storage for storing changefeed metadata.
 type Controller struct {
-	version int64
+	version  int64
+	selfNode *node.Info
 
 	scheduler          *scheduler.Controller
 	operatorController *operator.Controller
@@ -68,6 +73,25 @@ type Controller struct {
 	stateChangedCh      chan *ChangefeedStateChangeEvent
 
 	lastPrintStatusTime time.Time
+	// lastCheckPauseWindowTime is the last time the pause windows of changefeeds are checked
+	lastCheckPauseWindowTime time.Time
+	// clock is used to mock the time of the pause windows in the unit test
+	clock clock.Clock
+	// pauseWindowMu protects resumedInPauseWindow
+	pauseWindowMu sync.Mutex
+	// resumedInPauseWindow is the end of the pause window in which the changefeed is
+	// resumed manually, the changefeed is not paused by the schedule until the window ends.
+	resumedInPauseWindow map[common.ChangeFeedID]time.Time
+
+	// upgradeMu protects upgrade, which is nil if no rolling upgrade is started
+	upgradeMu sync.Mutex
+	upgrade   *rollingUpgrade
+
+	// events are the changes of the changefeeds for the watchers
+	events *changefeedEvents
+	// capacities are the capacity limits of the nodes, the maintainers are only placed
+	// on the nodes which have the capacity for them.
+	capacities *nodeCapacities
 
 	apiLock sync.RWMutex
 }
@@ -86,19 +110,39 @@ func NewController(
 	backend changefeed.Backend,
 	eventCh *chann.DrainableChann[*Event],
 	taskScheduler threadpool.ThreadPool,
-	batchSize int, balanceInterval time.Duration,
+	batchSize int, minBalanceInterval, maxBalanceInterval time.Duration,
 ) *Controller {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	changefeedDB := changefeed.NewChangefeedDB(version)
 
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 	oc := operator.NewOperatorController(mc, selfNode, changefeedDB, backend, nodeManager, batchSize)
+	capacities := newNodeCapacities()
+	newCapacity := func() scheduler.NodeCapacity[*changefeed.Changefeed] {
+		return capacities.newMaintainerCapacity(changefeedDB.GetTaskSizePerNode())
+	}
+	var balanceScheduler scheduler.Scheduler
+	if isWeightPlacement() {
+		weightScheduler := scheduler.NewWeightBalanceScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, minBalanceInterval,
+			(*changefeed.Changefeed).GetWeight, oc.NewMoveMaintainerOperator)
+		weightScheduler.SetBalanceInterval(scheduler.NewBalanceInterval(minBalanceInterval, maxBalanceInterval))
+		weightScheduler.SetNodeCapacity(newCapacity)
+		balanceScheduler = weightScheduler
+	} else {
+		balance := scheduler.NewBalanceScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, minBalanceInterval, oc.NewMoveMaintainerOperator)
+		balance.SetBalanceInterval(scheduler.NewBalanceInterval(minBalanceInterval, maxBalanceInterval))
+		balance.SetNodeCapacity(newCapacity)
+		balanceScheduler = balance
+	}
+	basicScheduler := scheduler.NewBasicScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, oc.NewAddMaintainerOperator)
+	basicScheduler.SetNodeCapacity(newCapacity)
 	c := &Controller{
 		version:      version,
+		selfNode:     selfNode,
 		bootstrapped: atomic.NewBool(false),
 		scheduler: scheduler.NewController(map[string]scheduler.Scheduler{
-			scheduler.BasicScheduler:   scheduler.NewBasicScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, oc.NewAddMaintainerOperator),
-			scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, balanceInterval, oc.NewMoveMaintainerOperator),
+			scheduler.BasicScheduler:   basicScheduler,
+			scheduler.BalanceScheduler: balanceScheduler,
 		}),
 		eventCh:             eventCh,
 		operatorController:  oc,
@@ -111,6 +155,11 @@ func NewController(
 		updatedChangefeedCh: updatedChangefeedCh,
 		stateChangedCh:      stateChangedCh,
 		lastPrintStatusTime: time.Now(),
+		clock:               clock.New(),
+		events:              newChangefeedEvents(),
+		capacities:          capacities,
+
+		resumedInPauseWindow: make(map[common.ChangeFeedID]time.Time),
 	}
 	c.bootstrapper = bootstrap.NewBootstrapper[heartbeatpb.CoordinatorBootstrapResponse]("coordinator", c.newBootstrapMessage)
 	// init bootstrapper nodes
@@ -174,6 +223,17 @@ func (c *Controller) onPeriodTask() {
 	// resend bootstrap message
 	c.sendMessages(c.bootstrapper.ResendBootstrapMessage())
 	c.collectMetrics()
+	if c.bootstrapped.Load() && time.Since(c.lastCheckPauseWindowTime) > checkPauseWindowInterval {
+		c.checkPauseWindows(context.Background(), c.clock.Now())
+		c.lastCheckPauseWindowTime = time.Now()
+	}
+	if c.bootstrapped.Load() {
+		c.advanceUpgrade(time.Now())
+	}
+	if c.bootstrapped.Load() && time.Since(c.events.lastCheck) > watchChangefeedInterval {
+		c.events.observe(time.Now(), c.changefeedDB.GetAllChangefeeds())
+		c.events.lastCheck = time.Now()
+	}
 }
 
 func (c *Controller) onMessage(msg *messaging.TargetMessage) {
@@ -183,6 +243,7 @@ func (c *Controller) onMessage(msg *messaging.TargetMessage) {
 	case messaging.TypeMaintainerHeartbeatRequest:
 		if c.bootstrapper.CheckAllNodeInitialized() {
 			req := msg.Message[0].(*heartbeatpb.MaintainerHeartbeat)
+			c.capacities.update(msg.From, req.Capacity)
 			c.HandleStatus(msg.From, req.Statuses)
 		}
 	default:
@@ -228,7 +289,9 @@ func (c *Controller) sendMessages(msgs []*messaging.TargetMessage) {
 func (c *Controller) onMaintainerBootstrapResponse(msg *messaging.TargetMessage) {
 	log.Info("received maintainer bootstrap response",
 		zap.Any("server", msg.From))
-	cachedResp := c.bootstrapper.HandleBootstrapResponse(msg.From, msg.Message[0].(*heartbeatpb.CoordinatorBootstrapResponse))
+	resp := msg.Message[0].(*heartbeatpb.CoordinatorBootstrapResponse)
+	c.capacities.update(msg.From, resp.Capacity)
+	cachedResp := c.bootstrapper.HandleBootstrapResponse(msg.From, resp)
 	c.onBootstrapDone(cachedResp)
 }
 
@@ -338,10 +401,14 @@ func (c *Controller) FinishBootstrap(workingMap map[common.ChangeFeedID]remoteMa
 		log.Panic("load all changefeeds failed", zap.Error(err))
 	}
 	log.Info("load all changefeeds", zap.Int("size", len(cfs)))
+	if err := c.restoreUpgrade(context.Background()); err != nil {
+		log.Panic("restore rolling upgrade failed", zap.Error(err))
+	}
 	for cfID, cfMeta := range cfs {
 		rm, ok := workingMap[cfID]
 		if !ok {
 			cf := changefeed.NewChangefeed(cfID, cfMeta.Info, cfMeta.Status.CheckpointTs, false)
+			cf.RestoreSavedStatus(cfMeta.Status)
 			if shouldRunChangefeed(cf.GetInfo().State) {
 				c.changefeedDB.AddAbsentChangefeed(cf)
 			} else {
@@ -351,6 +418,7 @@ func (c *Controller) FinishBootstrap(workingMap map[common.ChangeFeedID]remoteMa
 			log.Info("maintainer already working in other server",
 				zap.String("changefeed", cfID.String()))
 			cf := changefeed.NewChangefeed(cfID, cfMeta.Info, rm.status.CheckpointTs, false)
+			cf.RestoreSavedStatus(cfMeta.Status)
 			c.changefeedDB.AddReplicatingMaintainer(cf, rm.nodeID)
 			// delete it
 			delete(workingMap, cfID)
@@ -399,11 +467,22 @@ func (c *Controller) CreateChangefeed(ctx context.Context, info *config.ChangeFe
 	if ok := c.operatorController.HasOperator(info.ChangefeedID.DisplayName); ok {
 		return errors.New("changefeed is in scheduling")
 	}
+	if info.State != model.StateStopped {
+		if err := c.checkNodeCapacity(info); err != nil {
+			return err
+		}
+	}
 	err := c.backend.CreateChangefeed(ctx, info)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	c.changefeedDB.AddAbsentChangefeed(changefeed.NewChangefeed(info.ChangefeedID, info, info.StartTs, true))
+	cf := changefeed.NewChangefeed(info.ChangefeedID, info, info.StartTs, true)
+	if info.State == model.StateStopped {
+		// the changefeed is created paused, such as waiting for a physical import
+		c.changefeedDB.AddStoppedChangefeed(cf)
+		return nil
+	}
+	c.changefeedDB.AddAbsentChangefeed(cf)
 	return nil
 }
 
@@ -420,6 +499,7 @@ func (c *Controller) RemoveChangefeed(ctx context.Context, id common.ChangeFeedI
 		return 0, errors.Trace(err)
 	}
 	c.operatorController.StopChangefeed(ctx, id, true)
+	c.forgetResumeInPauseWindow(id)
 	return cf.GetStatus().CheckpointTs, nil
 }
 
@@ -438,9 +518,11 @@ func (c *Controller) PauseChangefeed(ctx context.Context, id common.ChangeFeedID
 		return errors.Trace(err)
 	} else {
 		clone.State = model.StateStopped
+		clone.PausedBySchedule = false
 		cf.SetInfo(clone)
 	}
 	c.operatorController.StopChangefeed(ctx, id, false)
+	c.forgetResumeInPauseWindow(id)
 	return nil
 }
 
@@ -452,17 +534,35 @@ func (c *Controller) ResumeChangefeed(ctx context.Context, id common.ChangeFeedI
 	if cf == nil {
 		return errors.New("changefeed not found")
 	}
+	if err := c.checkNodeCapacity(cf.GetInfo()); err != nil {
+		return err
+	}
 	if err := c.backend.ResumeChangefeed(ctx, id, newCheckpointTs); err != nil {
 		return errors.Trace(err)
 	}
+	status := cf.GetStatus()
 	if clone, err := cf.GetInfo().Clone(); err != nil {
 		return errors.Trace(err)
 	} else {
 		clone.State = model.StateNormal
+		clone.PausedBySchedule = false
+		clone.PausedForImport = false
+		if overwriteCheckpointTs {
+			clone.SafeModeEndTs = changefeed.SafeModeEndTsOnResume(clone.SafeModeEndTs, status.CheckpointTs, newCheckpointTs)
+		}
 		cf.SetInfo(clone)
 	}
 
-	status := cf.GetStatus()
+	if overwriteCheckpointTs {
+		logOverwrittenCheckpointTs(id, status.CheckpointTs, newCheckpointTs)
+		// the tables are replicated from the new checkpoint ts, the backfills and the barriers are dropped
+		if status.CheckpointTs != newCheckpointTs {
+			status.Backfills = nil
+			status.Barriers = nil
+			cf.SetLastSavedBackfills(nil)
+			cf.SetLastSavedBarriers(nil)
+		}
+	}
 	status.CheckpointTs = newCheckpointTs
 	_, _, err := cf.UpdateStatus(status)
 	if err != nil {
@@ -470,6 +570,132 @@ func (c *Controller) ResumeChangefeed(ctx context.Context, id common.ChangeFeedI
 	}
 
 	c.changefeedDB.Resume(id, true, overwriteCheckpointTs)
+	c.recordResumeInPauseWindow(cf)
+	return nil
+}
+
+// logOverwrittenCheckpointTs logs the events affected by overwriting the checkpoint ts.
+// The events in the overlap window are replicated again after the checkpoint ts moves backward,
+// they are written in safe mode up to the SafeModeEndTs saved in the changefeed info, so the
+// duplicated rows are overwritten.
+func logOverwrittenCheckpointTs(id common.ChangeFeedID, checkpointTs, newCheckpointTs uint64) {
+	switch {
+	case newCheckpointTs < checkpointTs:
+		log.Info("checkpoint ts moves backward, the events in the overlap window are replicated again in safe mode",
+			zap.String("changefeed", id.String()),
+			zap.Uint64("overlapStartTs", newCheckpointTs),
+			zap.Uint64("overlapEndTs", checkpointTs))
+	case newCheckpointTs > checkpointTs:
+		log.Warn("checkpoint ts moves forward, the events in the gap are skipped",
+			zap.String("changefeed", id.String()),
+			zap.Uint64("gapStartTs", checkpointTs),
+			zap.Uint64("gapEndTs", newCheckpointTs))
+	}
+}
+
+// checkPauseWindows pauses the changefeeds in their pause windows, and resumes
+// the changefeeds paused by the schedule once the windows end.
+// A changefeed paused by the schedule is stopped, so its checkpoint ts still
+// blocks the GC safepoint and it's resumed from the checkpoint ts.
+// The normal and the warning changefeeds are paused, the warning ones are
+// retried by the backoff otherwise. The failed changefeeds and the ones stopped
+// by the users or by the errors are left alone, and they are not resumed when
+// the windows end. A changefeed resumed manually in a window is not paused again
+// until the window ends.
+func (c *Controller) checkPauseWindows(ctx context.Context, now time.Time) {
+	for _, cf := range c.changefeedDB.GetAllChangefeeds() {
+		info := cf.GetInfo()
+		if info.Config == nil {
+			continue
+		}
+		inWindow := info.Config.Schedule.InPauseWindow(now)
+		switch {
+		case inWindow && (info.State == model.StateNormal || info.State == model.StateWarning):
+			if c.isResumedInPauseWindow(cf.ID, now) {
+				continue
+			}
+			if err := c.pauseChangefeedBySchedule(ctx, cf); err != nil {
+				log.Warn("pause changefeed by schedule failed",
+					zap.String("changefeed", cf.ID.String()), zap.Error(err))
+				continue
+			}
+			log.Info("changefeed is paused by schedule",
+				zap.String("changefeed", cf.ID.String()),
+				zap.String("state", string(info.State)),
+				zap.Uint64("checkpointTs", cf.GetStatus().CheckpointTs))
+		case !inWindow && info.State == model.StateStopped && info.PausedBySchedule:
+			checkpointTs := cf.GetLastSavedCheckPointTs()
+			if err := c.ResumeChangefeed(ctx, cf.ID, checkpointTs, false); err != nil {
+				log.Warn("resume changefeed by schedule failed",
+					zap.String("changefeed", cf.ID.String()), zap.Error(err))
+				continue
+			}
+			log.Info("changefeed is resumed by schedule",
+				zap.String("changefeed", cf.ID.String()),
+				zap.Uint64("checkpointTs", checkpointTs))
+		}
+	}
+}
+
+// recordResumeInPauseWindow records the end of the pause window if the changefeed
+// is resumed in it. The schedule only resumes the changefeeds out of the windows,
+// so the recorded resumes are the manual ones.
+func (c *Controller) recordResumeInPauseWindow(cf *changefeed.Changefeed) {
+	info := cf.GetInfo()
+	if info.Config == nil || info.Config.Schedule == nil || len(info.Config.Schedule.PauseWindows) == 0 {
+		return
+	}
+	end, ok := info.Config.Schedule.PauseWindowEnd(c.clock.Now())
+	if !ok {
+		return
+	}
+	c.pauseWindowMu.Lock()
+	defer c.pauseWindowMu.Unlock()
+	c.resumedInPauseWindow[cf.ID] = end
+	log.Info("changefeed is resumed in the pause window, it's not paused until the window ends",
+		zap.String("changefeed", cf.ID.String()),
+		zap.Time("windowEnd", end))
+}
+
+// isResumedInPauseWindow returns true if the changefeed is resumed manually in the
+// pause window containing now, the expired records are dropped.
+func (c *Controller) isResumedInPauseWindow(id common.ChangeFeedID, now time.Time) bool {
+	c.pauseWindowMu.Lock()
+	defer c.pauseWindowMu.Unlock()
+	end, ok := c.resumedInPauseWindow[id]
+	if !ok {
+		return false
+	}
+	if now.Before(end) {
+		return true
+	}
+	delete(c.resumedInPauseWindow, id)
+	return false
+}
+
+func (c *Controller) forgetResumeInPauseWindow(id common.ChangeFeedID) {
+	c.pauseWindowMu.Lock()
+	defer c.pauseWindowMu.Unlock()
+	delete(c.resumedInPauseWindow, id)
+}
+
+func (c *Controller) pauseChangefeedBySchedule(ctx context.Context, cf *changefeed.Changefeed) error {
+	c.apiLock.Lock()
+	defer c.apiLock.Unlock()
+
+	clone, err := cf.GetInfo().Clone()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	clone.State = model.StateStopped
+	clone.PausedBySchedule = true
+	err = c.backend.UpdateChangefeed(ctx, clone,
+		cf.NewSavedStatus(cf.GetLastSavedCheckPointTs(), config.ProgressStopping))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cf.SetInfo(clone)
+	c.operatorController.StopChangefeed(ctx, cf.ID, false)
 	return nil
 }
 
@@ -481,7 +707,8 @@ func (c *Controller) UpdateChangefeed(ctx context.Context, change *config.Change
 	if cf == nil {
 		return errors.New("changefeed not found")
 	}
-	if err := c.backend.UpdateChangefeed(ctx, change, cf.GetStatus().CheckpointTs, config.ProgressStopping); err != nil {
+	if err := c.backend.UpdateChangefeed(ctx, change,
+		cf.NewSavedStatus(cf.GetStatus().CheckpointTs, config.ProgressStopping)); err != nil {
 		return errors.Trace(err)
 	}
 	c.changefeedDB.ReplaceStoppedChangefeed(change)
@@ -510,7 +737,78 @@ func (c *Controller) GetChangefeed(_ context.Context, changefeedDisplayName comm
 	if cf == nil {
 		return nil, nil, errors.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
 	}
-	return cf.GetInfo(), &config.ChangeFeedStatus{CheckpointTs: cf.GetStatus().CheckpointTs}, nil
+	status := cf.GetStatus()
+	var warnings []*model.RunningError
+	for _, w := range status.Warnings {
+		warnings = append(warnings, &model.RunningError{
+			Addr:    w.Node,
+			Code:    w.Code,
+			Message: w.Message,
+		})
+	}
+	return cf.GetInfo(), &config.ChangeFeedStatus{CheckpointTs: status.CheckpointTs, Warnings: warnings}, nil
+}
+
+// WatchChangefeedEvents returns the changefeed events after the revision, it blocks until
+// there are such events or ctx is done. If id is not nil, only the events of the changefeed
+// are returned. The latest revision is also returned.
+func (c *Controller) WatchChangefeedEvents(
+	ctx context.Context, revision uint64, id *common.ChangeFeedDisplayName,
+) ([]*config.ChangefeedEvent, uint64, error) {
+	events, latest := c.events.wait(ctx, revision, id)
+	return events, latest, nil
+}
+
+// QuiesceChangefeed persists the ts the dispatchers of the changefeed are held at in the
+// changefeed info, so the restarted maintainer and dispatchers are held at the same ts.
+// The changefeed is released if the holdTs is 0.
+func (c *Controller) QuiesceChangefeed(ctx context.Context, id common.ChangeFeedID, holdTs uint64) error {
+	c.apiLock.Lock()
+	defer c.apiLock.Unlock()
+
+	cf := c.changefeedDB.GetByID(id)
+	if cf == nil {
+		return errors.ErrChangeFeedNotExists.GenWithStackByArgs(id.Name())
+	}
+	clone, err := cf.GetInfo().Clone()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	clone.QuiesceTs = holdTs
+	if err := c.backend.UpdateChangefeed(ctx, clone,
+		cf.NewSavedStatus(cf.GetLastSavedCheckPointTs(), config.ProgressNone)); err != nil {
+		return errors.Trace(err)
+	}
+	cf.SetInfo(clone)
+	log.Info("the quiesce ts of the changefeed is saved",
+		zap.String("changefeed", id.Name()),
+		zap.Uint64("holdTs", holdTs))
+	return nil
+}
+
+// MoveChangefeed moves the maintainer of the changefeed to the target node.
+func (c *Controller) MoveChangefeed(_ context.Context, id common.ChangeFeedID, target node.ID) error {
+	c.apiLock.Lock()
+	defer c.apiLock.Unlock()
+
+	cf := c.changefeedDB.GetByID(id)
+	if cf == nil {
+		return errors.ErrChangeFeedNotExists.GenWithStackByArgs(id.Name())
+	}
+	if _, ok := c.nodeManager.GetAliveNodes()[target]; !ok {
+		return errors.ErrCaptureNotExist.GenWithStackByArgs(target)
+	}
+	origin := cf.GetNodeID()
+	if origin == "" {
+		return errors.ErrChangefeedMoveRefused.GenWithStackByArgs(id.Name(), "changefeed is not running on any node")
+	}
+	if origin == target {
+		return nil
+	}
+	if !c.operatorController.AddOperator(c.operatorController.NewMoveMaintainerOperator(cf, origin, target)) {
+		return errors.ErrChangefeedMoveRefused.GenWithStackByArgs(id.Name(), "changefeed is in scheduling")
+	}
+	return nil
 }
 
 // GetTask queries a task by channgefeed ID, return nil if not found
@@ -520,9 +818,19 @@ func (c *Controller) GetTask(id common.ChangeFeedID) *changefeed.Changefeed {
 
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
+	c.capacities.remove(id)
 	c.operatorController.OnNodeRemoved(id)
 }
 
+// isWeightPlacement returns true if the maintainers are balanced by the weight of the changefeeds.
+func isWeightPlacement() bool {
+	cfg := config.GetGlobalServerConfig()
+	if cfg == nil || cfg.Debug == nil || cfg.Debug.Scheduler == nil {
+		return false
+	}
+	return cfg.Debug.Scheduler.MaintainerPlacementStrategy == config.PlacementStrategyWeight
+}
+
 func (c *Controller) submitPeriodTask() {
 	task := func() time.Time {
 		c.eventCh.In() <- &Event{eventType: EventPeriod}
@@ -16,11 +16,14 @@ package coordinator
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/golang/mock/gomock"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	"github.com/pingcap/ticdc/coordinator/changefeed/mock"
 	"github.com/pingcap/ticdc/coordinator/operator"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
@@ -28,6 +31,7 @@ import (
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/atomic"
 )
 
@@ -38,6 +42,8 @@ func TestResumeChangefeed(t *testing.T) {
 	controller := &Controller{
 		backend:      backend,
 		changefeedDB: changefeedDB,
+		nodeManager:  watcher.NewNodeManager(nil, nil),
+		capacities:   newNodeCapacities(),
 	}
 	cfID := common.NewChangeFeedIDWithName("test")
 	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
@@ -58,6 +64,17 @@ func TestResumeChangefeed(t *testing.T) {
 	backend.EXPECT().ResumeChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	require.Nil(t, controller.ResumeChangefeed(context.Background(), cfID, 12, false))
 	require.Equal(t, model.StateNormal, changefeedDB.GetByID(cfID).GetInfo().State)
+
+	// the window replicated again after the checkpoint ts moves backward is written in safe mode
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 20})
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), cfID, uint64(10)).Return(nil).Times(1)
+	require.Nil(t, controller.ResumeChangefeed(context.Background(), cfID, 10, true))
+	require.Equal(t, uint64(10), cf.GetStatus().CheckpointTs)
+	require.Equal(t, uint64(20), cf.GetInfo().SafeModeEndTs)
+	// the safe mode end ts is kept if the checkpoint ts moves forward
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), cfID, uint64(15)).Return(nil).Times(1)
+	require.Nil(t, controller.ResumeChangefeed(context.Background(), cfID, 15, true))
+	require.Equal(t, uint64(20), cf.GetInfo().SafeModeEndTs)
 }
 
 func TestPauseChangefeed(t *testing.T) {
@@ -96,6 +113,46 @@ func TestPauseChangefeed(t *testing.T) {
 	require.Equal(t, 1, changefeedDB.GetStoppedSize())
 }
 
+func TestQuiesceChangefeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	controller := &Controller{
+		backend:      backend,
+		changefeedDB: changefeedDB,
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+		State:        model.StateNormal,
+		SinkURI:      "mysql://127.0.0.1:3306",
+	}, 10, false)
+	changefeedDB.AddReplicatingMaintainer(cf, "node1")
+	require.Error(t, controller.QuiesceChangefeed(context.Background(), common.NewChangeFeedIDWithName("test1"), 20))
+
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed")).Times(1)
+	require.Error(t, controller.QuiesceChangefeed(context.Background(), cfID, 20))
+	require.Zero(t, cf.GetInfo().QuiesceTs)
+
+	// the hold ts is saved with the status of the running changefeed
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(),
+		&config.ChangeFeedStatus{CheckpointTs: 10, Progress: config.ProgressNone}).
+		DoAndReturn(func(_ context.Context, info *config.ChangeFeedInfo, _ *config.ChangeFeedStatus) error {
+			require.Equal(t, uint64(20), info.QuiesceTs)
+			return nil
+		}).Times(1)
+	require.NoError(t, controller.QuiesceChangefeed(context.Background(), cfID, 20))
+	require.Equal(t, uint64(20), cf.GetInfo().QuiesceTs)
+
+	// the next maintainer is held at the same ts
+	req := cf.NewAddMaintainerMessage("node2").Message[0].(*heartbeatpb.AddMaintainerRequest)
+	info := &config.ChangeFeedInfo{}
+	require.NoError(t, info.Unmarshal(req.Config))
+	require.Equal(t, uint64(20), info.QuiesceTs)
+	require.Equal(t, uint64(20), info.ToChangefeedConfig().QuiesceTs)
+}
+
 func TestUpdateChangefeed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	backend := mock_changefeed.NewMockBackend(ctrl)
@@ -123,11 +180,11 @@ func TestUpdateChangefeed(t *testing.T) {
 		ChangefeedID: common.NewChangeFeedIDWithName("test1"),
 	}))
 
-	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed")).Times(1)
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed")).Times(1)
 	require.NotNil(t, controller.UpdateChangefeed(context.Background(), newConfig))
 	require.Equal(t, false, changefeedDB.GetByID(cfID).IsMQSink())
 
-	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	require.Nil(t, controller.UpdateChangefeed(context.Background(), newConfig))
 	require.Equal(t, true, changefeedDB.GetByID(cfID).IsMQSink())
 	require.Equal(t, 1, changefeedDB.GetStoppedSize())
@@ -256,6 +313,8 @@ func TestCreateChangefeed(t *testing.T) {
 		operatorController: operator.NewOperatorController(nil, node.NewInfo("node1", ""),
 			changefeedDB, backend, nodeManager, 10),
 		bootstrapped: atomic.NewBool(false),
+		nodeManager:  nodeManager,
+		capacities:   newNodeCapacities(),
 	}
 	cfID := common.NewChangeFeedIDWithName("test")
 	cfConfig := &config.ChangeFeedInfo{
@@ -291,4 +350,353 @@ func TestCreateChangefeed(t *testing.T) {
 		SinkURI:      "kafka://127.0.0.1:9092",
 	}
 	require.NotNil(t, controller.CreateChangefeed(context.Background(), cf2Config))
+
+	// the changefeed paused for import is not scheduled until it's resumed
+	cf3ID := common.NewChangeFeedIDWithName("test3")
+	cf3Config := &config.ChangeFeedInfo{
+		ChangefeedID:    cf3ID,
+		State:           model.StateStopped,
+		PausedForImport: true,
+		StartTs:         10,
+		Config:          config.GetDefaultReplicaConfig(),
+		SinkURI:         "kafka://127.0.0.1:9092",
+	}
+	backend.EXPECT().CreateChangefeed(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	require.Nil(t, controller.CreateChangefeed(context.Background(), cf3Config))
+	require.Equal(t, 1, changefeedDB.GetStoppedSize())
+
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), cf3ID, uint64(20)).Return(nil).Times(1)
+	require.Nil(t, controller.ResumeChangefeed(context.Background(), cf3ID, 20, true))
+	require.Equal(t, 0, changefeedDB.GetStoppedSize())
+	require.False(t, changefeedDB.GetByID(cf3ID).GetInfo().PausedForImport)
+	require.Equal(t, uint64(20), changefeedDB.GetByID(cf3ID).GetStatus().CheckpointTs)
+}
+
+func TestCheckNodeCapacity(t *testing.T) {
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	self := node.NewInfo("localhost:8300", "")
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()[self.ID] = self
+	controller := &Controller{
+		changefeedDB: changefeedDB,
+		nodeManager:  nodeManager,
+		capacities:   newNodeCapacities(),
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+	}
+	info.Config.MemoryQuota = 100
+	// the nodes which don't report the capacity are not limited
+	require.Nil(t, controller.checkNodeCapacity(info))
+
+	controller.capacities.update(self.ID, &heartbeatpb.NodeCapacity{MemoryBudget: 150, MemoryQuota: 60})
+	require.True(t, errors.ErrNodeCapacityExceeded.Equal(controller.checkNodeCapacity(info)))
+	controller.capacities.update(self.ID, &heartbeatpb.NodeCapacity{MemoryBudget: 150, MemoryQuota: 50})
+	require.Nil(t, controller.checkNodeCapacity(info))
+
+	controller.capacities.update(self.ID, &heartbeatpb.NodeCapacity{MaxMaintainers: 1})
+	require.Nil(t, controller.checkNodeCapacity(info))
+	changefeedDB.AddReplicatingMaintainer(changefeed.NewChangefeed(cfID, info, 1, true), self.ID)
+	require.True(t, errors.ErrNodeCapacityExceeded.Equal(controller.checkNodeCapacity(info)))
+	// the capacity of the removed node is not counted
+	controller.capacities.remove(self.ID)
+	require.Nil(t, controller.checkNodeCapacity(info))
+}
+
+func TestCheckPauseWindows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	self := node.NewInfo("localhost:8300", "")
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()[self.ID] = self
+	controller := &Controller{
+		backend:      backend,
+		changefeedDB: changefeedDB,
+		operatorController: operator.NewOperatorController(nil, node.NewInfo("node1", ""),
+			changefeedDB, backend, nodeManager, 10),
+		nodeManager: nodeManager,
+		capacities:  newNodeCapacities(),
+		clock:       clock.NewMock(),
+
+		resumedInPauseWindow: make(map[common.ChangeFeedID]time.Time),
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Schedule = &config.ScheduleConfig{
+		PauseWindows: []*config.PauseWindow{{Cron: "0 2 * * *", Duration: "2h"}},
+	}
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       replicaConfig,
+		State:        model.StateNormal,
+		SinkURI:      "mysql://127.0.0.1:3306",
+	}, 10, true)
+	changefeedDB.AddReplicatingMaintainer(cf, "node1")
+
+	// out of the window, nothing happens
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 1, 0, 0, 0, time.Local))
+	require.Equal(t, model.StateNormal, changefeedDB.GetByID(cfID).GetInfo().State)
+
+	// in the window, the changefeed is paused with the saved checkpoint ts
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(),
+		&config.ChangeFeedStatus{CheckpointTs: 10, Progress: config.ProgressStopping}).Return(nil).Times(1)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 3, 0, 0, 0, time.Local))
+	info := changefeedDB.GetByID(cfID).GetInfo()
+	require.Equal(t, model.StateStopped, info.State)
+	require.True(t, info.PausedBySchedule)
+	require.Equal(t, 1, changefeedDB.GetStoppedSize())
+
+	// still in the window, nothing happens
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 3, 59, 0, 0, time.Local))
+
+	// the window ends, the changefeed is resumed from the checkpoint ts
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), cfID, uint64(10)).Return(nil).Times(1)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 4, 0, 0, 0, time.Local))
+	info = changefeedDB.GetByID(cfID).GetInfo()
+	require.Equal(t, model.StateNormal, info.State)
+	require.False(t, info.PausedBySchedule)
+
+	// a changefeed paused manually is not resumed by the schedule
+	backend.EXPECT().SetChangefeedProgress(gomock.Any(), cfID, gomock.Any()).Return(nil).AnyTimes()
+	backend.EXPECT().PauseChangefeed(gomock.Any(), cfID).Return(nil).Times(1)
+	require.Nil(t, controller.PauseChangefeed(context.Background(), cfID))
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 5, 0, 0, 0, time.Local))
+	require.Equal(t, model.StateStopped, changefeedDB.GetByID(cfID).GetInfo().State)
+}
+
+func newPauseWindowTestController(t *testing.T) (*Controller, *mock_changefeed.MockBackend, *clock.Mock) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	self := node.NewInfo("localhost:8300", "")
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()[self.ID] = self
+	mc := clock.NewMock()
+	controller := &Controller{
+		backend:      backend,
+		changefeedDB: changefeedDB,
+		operatorController: operator.NewOperatorController(nil, node.NewInfo("node1", ""),
+			changefeedDB, backend, nodeManager, 10),
+		nodeManager: nodeManager,
+		capacities:  newNodeCapacities(),
+		clock:       mc,
+
+		resumedInPauseWindow: make(map[common.ChangeFeedID]time.Time),
+	}
+	backend.EXPECT().SetChangefeedProgress(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return controller, backend, mc
+}
+
+func addPauseWindowTestChangefeed(controller *Controller, name string, state model.FeedState) common.ChangeFeedID {
+	cfID := common.NewChangeFeedIDWithName(name)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Schedule = &config.ScheduleConfig{
+		PauseWindows: []*config.PauseWindow{{Cron: "0 2 * * *", Duration: "2h"}},
+	}
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       replicaConfig,
+		State:        state,
+		SinkURI:      "mysql://127.0.0.1:3306",
+	}, 10, true)
+	switch state {
+	case model.StateNormal:
+		controller.changefeedDB.AddReplicatingMaintainer(cf, "node1")
+	case model.StateWarning:
+		// the warning changefeed is retried by the backoff
+		controller.changefeedDB.AddAbsentChangefeed(cf)
+	default:
+		controller.changefeedDB.AddStoppedChangefeed(cf)
+	}
+	return cfID
+}
+
+func TestCheckPauseWindowsManualResume(t *testing.T) {
+	controller, backend, mc := newPauseWindowTestController(t)
+	changefeedDB := controller.changefeedDB
+	cfID := addPauseWindowTestChangefeed(controller, "test", model.StateNormal)
+
+	// paused by the schedule in the window
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 2, 0, 0, 0, time.Local))
+	require.Equal(t, model.StateStopped, changefeedDB.GetByID(cfID).GetInfo().State)
+
+	// resumed manually in the window, it's not paused again until the window ends
+	mc.Set(time.Date(2025, 1, 1, 2, 30, 0, 0, time.Local))
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), cfID, uint64(10)).Return(nil).Times(1)
+	require.Nil(t, controller.ResumeChangefeed(context.Background(), cfID, 10, false))
+	info := changefeedDB.GetByID(cfID).GetInfo()
+	require.Equal(t, model.StateNormal, info.State)
+	require.False(t, info.PausedBySchedule)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 2, 31, 0, 0, time.Local))
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 3, 59, 0, 0, time.Local))
+	require.Equal(t, model.StateNormal, changefeedDB.GetByID(cfID).GetInfo().State)
+
+	// the record is dropped once the window ends, the next window pauses the changefeed again
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 4, 0, 0, 0, time.Local))
+	require.Equal(t, model.StateNormal, changefeedDB.GetByID(cfID).GetInfo().State)
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 2, 2, 0, 0, 0, time.Local))
+	info = changefeedDB.GetByID(cfID).GetInfo()
+	require.Equal(t, model.StateStopped, info.State)
+	require.True(t, info.PausedBySchedule)
+	require.Empty(t, controller.resumedInPauseWindow)
+
+	// a resume out of the windows is not recorded
+	mc.Set(time.Date(2025, 1, 2, 5, 0, 0, 0, time.Local))
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), cfID, uint64(10)).Return(nil).Times(1)
+	require.Nil(t, controller.ResumeChangefeed(context.Background(), cfID, 10, false))
+	require.Empty(t, controller.resumedInPauseWindow)
+}
+
+func TestCheckPauseWindowsStates(t *testing.T) {
+	controller, backend, mc := newPauseWindowTestController(t)
+	changefeedDB := controller.changefeedDB
+	warningID := addPauseWindowTestChangefeed(controller, "warning", model.StateWarning)
+	failedID := addPauseWindowTestChangefeed(controller, "failed", model.StateFailed)
+	stoppedID := addPauseWindowTestChangefeed(controller, "stopped", model.StateStopped)
+
+	// only the warning changefeed is paused, the failed and the stopped ones are left alone
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, info *config.ChangeFeedInfo, _ *config.ChangeFeedStatus) error {
+			require.Equal(t, warningID, info.ChangefeedID)
+			return nil
+		}).Times(1)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 3, 0, 0, 0, time.Local))
+	info := changefeedDB.GetByID(warningID).GetInfo()
+	require.Equal(t, model.StateStopped, info.State)
+	require.True(t, info.PausedBySchedule)
+	require.Equal(t, model.StateFailed, changefeedDB.GetByID(failedID).GetInfo().State)
+	require.Equal(t, model.StateStopped, changefeedDB.GetByID(stoppedID).GetInfo().State)
+
+	// only the changefeed paused by the schedule is resumed once the window ends
+	mc.Set(time.Date(2025, 1, 1, 4, 0, 0, 0, time.Local))
+	backend.EXPECT().ResumeChangefeed(gomock.Any(), warningID, uint64(10)).Return(nil).Times(1)
+	controller.checkPauseWindows(context.Background(), time.Date(2025, 1, 1, 4, 0, 0, 0, time.Local))
+	require.Equal(t, model.StateNormal, changefeedDB.GetByID(warningID).GetInfo().State)
+	require.Equal(t, model.StateFailed, changefeedDB.GetByID(failedID).GetInfo().State)
+	require.Equal(t, model.StateStopped, changefeedDB.GetByID(stoppedID).GetInfo().State)
+}
+
+func TestRollingUpgrade(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	self := node.NewInfo("localhost:8300", "")
+	other := node.NewInfo("localhost:8301", "")
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()[self.ID] = self
+	nodeManager.GetAliveNodes()[other.ID] = other
+	// the upgrade status persisted in the backend
+	var persisted *config.UpgradeStatus
+	backend.EXPECT().SaveUpgradeStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, status *config.UpgradeStatus) error {
+			persisted = status
+			return nil
+		}).AnyTimes()
+	controller := &Controller{
+		selfNode:     self,
+		backend:      backend,
+		changefeedDB: changefeedDB,
+		nodeManager:  nodeManager,
+		operatorController: operator.NewOperatorController(nil, self,
+			changefeedDB, backend, nodeManager, 10),
+	}
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.Local)
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+		State:        model.StateNormal,
+		SinkURI:      "mysql://127.0.0.1:3306",
+	}, oracle.GoTimeToTS(now.Add(-time.Minute)), true)
+	changefeedDB.AddReplicatingMaintainer(cf, self.ID)
+
+	ctx := context.Background()
+	status, err := controller.GetUpgradeStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, config.UpgradeStateNone, status.State)
+	require.NoError(t, controller.StartUpgrade(ctx, 10*time.Second))
+	err = controller.StartUpgrade(ctx, 10*time.Second)
+	require.True(t, errors.ErrRollingUpgradeRunning.Equal(err))
+	require.Equal(t, config.UpgradeStateRunning, persisted.State)
+
+	// the coordinator node is upgraded at last
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeStateRunning, status.State)
+	require.Len(t, status.Nodes, 2)
+	require.Equal(t, other.ID.String(), status.Nodes[0].ID)
+	require.Equal(t, self.ID.String(), status.Nodes[1].ID)
+
+	// the changefeed lags behind too much
+	controller.advanceUpgrade(now)
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeNodePending, status.Nodes[0].State)
+	require.Contains(t, status.Message, "checkpoint lag")
+
+	// no maintainer on the node, it's ready to be upgraded
+	now = now.Add(-55 * time.Second)
+	controller.advanceUpgrade(now)
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeNodeReady, status.Nodes[0].State)
+	require.Equal(t, config.UpgradeNodeReady, persisted.Nodes[0].State)
+	// the node is not schedulable before it's upgraded
+	require.True(t, nodeManager.IsNodeStopping(other.ID))
+	require.NotContains(t, nodeManager.GetSchedulableNodes(), other.ID)
+
+	// the node is stopped and rejoins with a new id
+	delete(nodeManager.GetAliveNodes(), other.ID)
+	controller.advanceUpgrade(now)
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeNodeRestarting, status.Nodes[0].State)
+	upgraded := node.NewInfo("localhost:8301", "")
+	nodeManager.GetAliveNodes()[upgraded.ID] = upgraded
+	controller.advanceUpgrade(now)
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeNodeUpgraded, status.Nodes[0].State)
+	require.Equal(t, upgraded.ID.String(), status.Nodes[0].NewID)
+
+	// the maintainer is moved out of the coordinator node
+	controller.advanceUpgrade(now)
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeNodeDraining, status.Nodes[1].State)
+	op := controller.operatorController.GetOperator(cfID)
+	require.NotNil(t, op)
+	require.ElementsMatch(t, []node.ID{self.ID, upgraded.ID}, op.AffectedNodes())
+	require.Equal(t, []node.ID{upgraded.ID}, keysOf(nodeManager.GetSchedulableNodes()))
+
+	// the upgrade is resumed by a new coordinator
+	restoredNodeManager := watcher.NewNodeManager(nil, nil)
+	restoredNodeManager.GetAliveNodes()[self.ID] = self
+	restoredNodeManager.GetAliveNodes()[upgraded.ID] = upgraded
+	restoredBackend := mock_changefeed.NewMockBackend(ctrl)
+	restoredBackend.EXPECT().GetUpgradeStatus(gomock.Any()).Return(persisted, nil).Times(1)
+	restored := &Controller{
+		selfNode:     upgraded,
+		backend:      restoredBackend,
+		changefeedDB: changefeed.NewChangefeedDB(1216),
+		nodeManager:  restoredNodeManager,
+	}
+	require.NoError(t, restored.restoreUpgrade(ctx))
+	restoredStatus, _ := restored.GetUpgradeStatus(ctx)
+	require.Equal(t, status, restoredStatus)
+	require.Equal(t, 1, restored.upgrade.current)
+	require.True(t, restoredNodeManager.IsNodeStopping(self.ID))
+
+	require.NoError(t, controller.StopUpgrade(ctx))
+	status, _ = controller.GetUpgradeStatus(ctx)
+	require.Equal(t, config.UpgradeStateNone, status.State)
+	require.Nil(t, persisted)
+	require.False(t, nodeManager.IsNodeStopping(self.ID))
+}
+
+func keysOf(nodes map[node.ID]*node.Info) []node.ID {
+	ids := make([]node.ID, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	return ids
 }
@@ -16,11 +16,13 @@ package coordinator
 import (
 	"context"
 	"math"
+	"reflect"
 	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -30,13 +32,16 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/server"
+	"github.com/pingcap/ticdc/pkg/tracing"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/ticdc/pkg/upstream"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/chann"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/model"
-	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -60,6 +65,9 @@ type coordinator struct {
 	gcManager gc.Manager
 	pdClient  pd.Client
 	pdClock   pdutil.Clock
+	clusterID string
+	// upstreamID -> gc manager of the non-default upstream
+	upstreamGCManagers map[uint64]gc.Manager
 
 	eventCh             *chann.DrainableChann[*Event]
 	updatedChangefeedCh chan map[common.ChangeFeedID]*changefeed.Changefeed
@@ -70,6 +78,20 @@ type coordinator struct {
 	closed atomic.Bool
 }
 
+// fetchEpochBase returns the base of the epochs of the maintainers bound by the coordinator.
+// It's a PD TSO, which is larger than the bases of the previous coordinators.
+func fetchEpochBase(pdClient pd.Client, pdClock pdutil.Clock) uint64 {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	physical, logical, err := pdClient.GetTS(ctx)
+	if err != nil {
+		log.Warn("coordinator fails to get the tso as the epoch base, use the pd clock",
+			zap.Error(err))
+		return oracle.GoTimeToTS(pdClock.CurrentTime())
+	}
+	return oracle.ComposeTS(physical, logical)
+}
+
 func New(node *node.Info,
 	pdClient pd.Client,
 	pdClock pdutil.Clock,
@@ -77,17 +99,20 @@ func New(node *node.Info,
 	clusterID string,
 	version int64,
 	batchSize int,
-	balanceCheckInterval time.Duration,
+	minBalanceInterval, maxBalanceInterval time.Duration,
 ) server.Coordinator {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	serverCfg := config.GetGlobalServerConfig()
 	c := &coordinator{
 		version:             version,
 		nodeInfo:            node,
 		lastTickTime:        time.Now(),
-		gcManager:           gc.NewManager(clusterID, pdClient, pdClock),
+		gcManager:           gc.NewManager(clusterID, pdClient, pdClock, serverCfg.GcTTL, time.Duration(serverCfg.GcMaxLag)),
 		eventCh:             chann.NewAutoDrainChann[*Event](),
 		pdClient:            pdClient,
 		pdClock:             pdClock,
+		clusterID:           clusterID,
+		upstreamGCManagers:  make(map[uint64]gc.Manager),
 		mc:                  mc,
 		updatedChangefeedCh: make(chan map[common.ChangeFeedID]*changefeed.Changefeed, 1024),
 		stateChangedCh:      make(chan *ChangefeedStateChangeEvent, 8),
@@ -105,10 +130,12 @@ func New(node *node.Info,
 		c.eventCh,
 		c.taskScheduler,
 		batchSize,
-		balanceCheckInterval,
+		minBalanceInterval,
+		maxBalanceInterval,
 	)
 
 	c.controller = controller
+	controller.changefeedDB.SetEpochBase(fetchEpochBase(pdClient, pdClock))
 
 	// receive messages
 	mc.RegisterHandler(messaging.CoordinatorTopic, c.recvMessages)
@@ -203,10 +230,10 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 	cfInfo.State = event.State
 	cfInfo.Error = event.err
 	progress := config.ProgressNone
-	if event.State == model.StateFailed || event.State == model.StateFinished {
+	if event.State == model.StateFailed || event.State == model.StateFinished || event.State == model.StateStopped {
 		progress = config.ProgressStopping
 	}
-	if err := c.backend.UpdateChangefeed(context.Background(), cfInfo, cf.GetStatus().CheckpointTs, progress); err != nil {
+	if err := c.backend.UpdateChangefeed(context.Background(), cfInfo, cf.NewSavedStatus(cf.GetStatus().CheckpointTs, progress)); err != nil {
 		log.Error("failed to update changefeed state",
 			zap.Error(err))
 		return errors.Trace(err)
@@ -217,7 +244,7 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 	case model.StateWarning:
 		c.controller.operatorController.StopChangefeed(ctx, event.ChangefeedID, false)
 		c.controller.changefeedDB.Resume(event.ChangefeedID, false, false)
-	case model.StateFailed, model.StateFinished:
+	case model.StateFailed, model.StateFinished, model.StateStopped:
 		c.controller.operatorController.StopChangefeed(ctx, event.ChangefeedID, false)
 	default:
 	}
@@ -225,11 +252,21 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 }
 
 func (c *coordinator) saveCheckpointTs(ctx context.Context, cfs map[common.ChangeFeedID]*changefeed.Changefeed) error {
-	statusMap := make(map[common.ChangeFeedID]uint64)
+	statusMap := make(map[common.ChangeFeedID]*config.ChangeFeedStatus)
 	for _, upCf := range cfs {
-		reportedCheckpointTs := upCf.GetStatus().CheckpointTs
-		if upCf.GetLastSavedCheckPointTs() < reportedCheckpointTs {
-			statusMap[upCf.ID] = reportedCheckpointTs
+		reported := upCf.GetStatus()
+		backfills := changefeed.BackfillsFromPB(reported.Backfills)
+		barriers := changefeed.BarriersFromPB(reported.Barriers)
+		// the backfills and the barriers are saved with the checkpoint ts, they are resumed after a failover
+		if upCf.GetLastSavedCheckPointTs() < reported.CheckpointTs ||
+			!reflect.DeepEqual(upCf.GetLastSavedBackfills(), backfills) ||
+			!reflect.DeepEqual(upCf.GetLastSavedBarriers(), barriers) {
+			statusMap[upCf.ID] = &config.ChangeFeedStatus{
+				CheckpointTs: max(upCf.GetLastSavedCheckPointTs(), reported.CheckpointTs),
+				Progress:     config.ProgressNone,
+				Backfills:    backfills,
+				Barriers:     barriers,
+			}
 		}
 	}
 	if len(statusMap) == 0 {
@@ -241,15 +278,23 @@ func (c *coordinator) saveCheckpointTs(ctx context.Context, cfs map[common.Chang
 		return errors.Trace(err)
 	}
 	// update the last saved checkpoint ts and send checkpointTs to maintainer
-	for id, cp := range statusMap {
+	for id, status := range statusMap {
 		cf, ok := cfs[id]
 		if !ok {
 			continue
 		}
+		cp := status.CheckpointTs
 		cf.SetLastSavedCheckPointTs(cp)
+		cf.SetLastSavedBackfills(status.Backfills)
+		cf.SetLastSavedBarriers(status.Barriers)
 		if cf.IsMQSink() {
+			spanCtx, span := tracing.Start(ctx, "coordinator.SendCheckpointTs",
+				attribute.String("changefeed", cf.ID.Name()),
+				attribute.Int64("checkpointTs", int64(cp)))
 			msg := cf.NewCheckpointTsMessage(cf.GetLastSavedCheckPointTs())
+			msg.TraceContext = tracing.Inject(spanCtx)
 			c.sendMessages([]*messaging.TargetMessage{msg})
+			span.End()
 		}
 	}
 	return nil
@@ -275,6 +320,32 @@ func (c *coordinator) UpdateChangefeed(ctx context.Context, change *config.Chang
 	return c.controller.UpdateChangefeed(ctx, change)
 }
 
+func (c *coordinator) QuiesceChangefeed(ctx context.Context, id common.ChangeFeedID, holdTs uint64) error {
+	return c.controller.QuiesceChangefeed(ctx, id, holdTs)
+}
+
+func (c *coordinator) MoveChangefeed(ctx context.Context, id common.ChangeFeedID, target node.ID) error {
+	return c.controller.MoveChangefeed(ctx, id, target)
+}
+
+func (c *coordinator) StartUpgrade(ctx context.Context, maxLag time.Duration) error {
+	return c.controller.StartUpgrade(ctx, maxLag)
+}
+
+func (c *coordinator) StopUpgrade(ctx context.Context) error {
+	return c.controller.StopUpgrade(ctx)
+}
+
+func (c *coordinator) WatchChangefeedEvents(
+	ctx context.Context, revision uint64, id *common.ChangeFeedDisplayName,
+) ([]*config.ChangefeedEvent, uint64, error) {
+	return c.controller.WatchChangefeedEvents(ctx, revision, id)
+}
+
+func (c *coordinator) GetUpgradeStatus(ctx context.Context) (*config.UpgradeStatus, error) {
+	return c.controller.GetUpgradeStatus(ctx)
+}
+
 func (c *coordinator) ListChangefeeds(ctx context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error) {
 	return c.controller.ListChangefeeds(ctx)
 }
@@ -314,16 +385,91 @@ func (c *coordinator) sendMessages(msgs []*messaging.TargetMessage) {
 func (c *coordinator) updateGCSafepoint(
 	ctx context.Context,
 ) error {
+	// fail the stale changefeeds first, so they don't block the gc safepoint
+	if err := c.failStaleChangefeeds(ctx); err != nil {
+		return errors.Trace(err)
+	}
 	minCheckpointTs := c.controller.changefeedDB.CalculateGCSafepoint()
 	// check if the upstream has a changefeed, if not we should update the gc safepoint
 	if minCheckpointTs == math.MaxUint64 {
 		ts := c.pdClock.CurrentTime()
 		minCheckpointTs = oracle.GoTimeToTS(ts)
 	}
-	// When the changefeed starts up, CDC will do a snapshot read at
-	// (checkpointTs - 1) from TiKV, so (checkpointTs - 1) should be an upper
-	// bound for the GC safepoint.
-	gcSafepointUpperBound := minCheckpointTs - 1
-	err := c.gcManager.TryUpdateGCSafePoint(ctx, gcSafepointUpperBound, false)
-	return errors.Trace(err)
+	err := c.gcManager.TryUpdateGCSafePoint(ctx, minCheckpointTs, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for upstreamID, safepoint := range c.controller.changefeedDB.CalculateUpstreamGCSafepoints() {
+		gcManager, ok := c.getGCManager(safepoint.Info)
+		if !ok {
+			continue
+		}
+		if err := gcManager.TryUpdateGCSafePoint(ctx, safepoint.CheckpointTs, false); err != nil {
+			// the gc safepoint of a non-default upstream should not block the others
+			log.Warn("update the gc safepoint of the upstream failed",
+				zap.Uint64("upstreamID", upstreamID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// getGCManager returns the gc manager of the upstream, the default one is returned if info is nil.
+// False is returned if the upstream is not ready yet.
+func (c *coordinator) getGCManager(info *config.UpstreamInfo) (gc.Manager, bool) {
+	if info == nil {
+		return c.gcManager, true
+	}
+	if gcManager, ok := c.upstreamGCManagers[info.ID]; ok {
+		return gcManager, true
+	}
+	up, err := appcontext.GetService[*upstream.Manager](appcontext.UpstreamManager).AddUpstreamByInfo(info)
+	if err != nil || !up.IsNormal() {
+		log.Info("upstream is not ready, skip updating its gc safepoint",
+			zap.Uint64("upstreamID", info.ID), zap.Error(err))
+		return nil, false
+	}
+	serverCfg := config.GetGlobalServerConfig()
+	gcManager := gc.NewManager(c.clusterID, up.PDClient, up.PDClock, serverCfg.GcTTL, time.Duration(serverCfg.GcMaxLag))
+	c.upstreamGCManagers[info.ID] = gcManager
+	return gcManager, true
+}
+
+// failStaleChangefeeds fails the changefeeds whose data has been or will be GC,
+// they must be resumed manually with a new checkpoint ts. The gc blocking ts is
+// checked instead of the checkpoint ts, a backfill lagging behind the max lag is
+// not protected by the gc safepoint either.
+func (c *coordinator) failStaleChangefeeds(ctx context.Context) error {
+	for _, cf := range c.controller.changefeedDB.GetAllChangefeeds() {
+		info := cf.GetInfo()
+		if info == nil || !info.NeedBlockGC() {
+			continue
+		}
+		gcManager, ok := c.getGCManager(info.UpstreamInfo)
+		if !ok {
+			continue
+		}
+		err := gcManager.CheckStaleCheckpointTs(cf.ID, cf.GetGCBlockingTs())
+		if err == nil {
+			continue
+		}
+		log.Warn("changefeed is stale, fail it",
+			zap.String("changefeed", cf.ID.String()),
+			zap.Uint64("checkpointTs", cf.GetLastSavedCheckPointTs()),
+			zap.Uint64("gcBlockingTs", cf.GetGCBlockingTs()),
+			zap.Error(err))
+		if err := c.handleStateChangedEvent(ctx, &ChangefeedStateChangeEvent{
+			ChangefeedID: cf.ID,
+			State:        model.StateFailed,
+			err: &model.RunningError{
+				Time:    time.Now(),
+				Addr:    c.nodeInfo.AdvertiseAddr,
+				Code:    string(apperror.ErrorCode(err)),
+				Message: err.Error(),
+			},
+		}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
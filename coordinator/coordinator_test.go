@@ -29,19 +29,23 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	mock_changefeed "github.com/pingcap/ticdc/coordinator/changefeed/mock"
+	"github.com/pingcap/ticdc/coordinator/operator"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -51,6 +55,10 @@ type mockPdClient struct {
 	pd.Client
 }
 
+func (m *mockPdClient) GetTS(ctx context.Context) (int64, int64, error) {
+	return oracle.GetPhysical(time.Now()), 0, nil
+}
+
 func (m *mockPdClient) UpdateServiceGCSafePoint(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error) {
 	return safePoint, nil
 }
@@ -260,6 +268,7 @@ func TestCoordinatorScheduling(t *testing.T) {
 	backend := mock_changefeed.NewMockBackend(ctrl)
 	cfs := make(map[common.ChangeFeedID]*changefeed.ChangefeedMetaWrapper)
 	backend.EXPECT().GetAllChangefeeds(gomock.Any()).Return(cfs, nil).AnyTimes()
+	backend.EXPECT().GetUpgradeStatus(gomock.Any()).Return(nil, nil).AnyTimes()
 	for i := 0; i < cfSize; i++ {
 		cfID := common.NewChangeFeedIDWithDisplayName(common.ChangeFeedDisplayName{
 			Name:      fmt.Sprintf("%d", i),
@@ -275,7 +284,7 @@ func TestCoordinatorScheduling(t *testing.T) {
 		}
 	}
 
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, "default", 100, 10000, time.Minute)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, "default", 100, 10000, time.Minute, time.Minute)
 	co := cr.(*coordinator)
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -324,8 +333,9 @@ func TestScaleNode(t *testing.T) {
 		}
 	}
 	backend.EXPECT().GetAllChangefeeds(gomock.Any()).Return(cfs, nil).AnyTimes()
+	backend.EXPECT().GetUpgradeStatus(gomock.Any()).Return(nil, nil).AnyTimes()
 
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10, time.Millisecond*10)
 
 	// run coordinator
 	go func() { cr.Run(ctx) }()
@@ -436,9 +446,10 @@ func TestBootstrapWithUnStoppedChangefeed(t *testing.T) {
 		stopingCf1.Info.ChangefeedID:  stopingCf1,
 		stopingCf2.Info.ChangefeedID:  stopingCf2,
 	}, nil).AnyTimes()
+	backend.EXPECT().GetUpgradeStatus(gomock.Any()).Return(nil, nil).AnyTimes()
 	backend.EXPECT().DeleteChangefeed(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	backend.EXPECT().SetChangefeedProgress(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10, time.Millisecond*10)
 
 	// run coordinator
 	go func() { cr.Run(ctx) }()
@@ -504,3 +515,49 @@ func newMockEtcdClient(ownerID string) *mockEtcdClient {
 func (m *mockEtcdClient) GetOwnerID(ctx context.Context) (model.CaptureID, error) {
 	return model.CaptureID(m.ownerID), nil
 }
+
+func (m *mockEtcdClient) GetNodeInfo(ctx context.Context, id string) (*node.Info, error) {
+	return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(id)
+}
+
+func TestFailStaleChangefeedWithLaggingBackfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	self := node.NewInfo("localhost:8300", "")
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()[self.ID] = self
+	co := &coordinator{
+		nodeInfo:  self,
+		backend:   backend,
+		gcManager: gc.NewManager("test", &mockPdClient{}, pdutil.NewClock4Test(), 3600, 10*time.Minute),
+		controller: &Controller{
+			backend:      backend,
+			changefeedDB: changefeedDB,
+			operatorController: operator.NewOperatorController(nil, self,
+				changefeedDB, backend, nodeManager, 10),
+		},
+	}
+	now := time.Now()
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+		State:        model.StateNormal,
+		SinkURI:      "mysql://127.0.0.1:3306",
+	}, oracle.GoTimeToTS(now.Add(-time.Minute)), true)
+	changefeedDB.AddReplicatingMaintainer(cf, self.ID)
+
+	// the checkpoint and the backfill are both fresh
+	cf.SetLastSavedBackfills([]*config.TableBackfill{{TableID: 1, CheckpointTs: oracle.GoTimeToTS(now.Add(-5 * time.Minute))}})
+	require.NoError(t, co.failStaleChangefeeds(context.Background()))
+	require.Equal(t, model.StateNormal, cf.GetInfo().State)
+
+	// the backfill lags past the max lag, its range is not protected by the gc safepoint any more
+	cf.SetLastSavedBackfills([]*config.TableBackfill{{TableID: 1, CheckpointTs: oracle.GoTimeToTS(now.Add(-time.Hour))}})
+	backend.EXPECT().UpdateChangefeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	require.NoError(t, co.failStaleChangefeeds(context.Background()))
+	info := cf.GetInfo()
+	require.Equal(t, model.StateFailed, info.State)
+	require.Contains(t, info.Error.Message, string(errors.ErrGCTTLExceeded.RFCCode()))
+}
@@ -0,0 +1,347 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// rollingUpgrade sequences the upgrade of the nodes one by one. For each node,
+//  1. waits until no changefeed lags behind more than maxLag and no maintainer is in scheduling.
+//  2. moves the maintainers out of the node.
+//  3. reports the node is ready, the node is stopped gracefully by the operator,
+//     which drains the dispatchers, and is restarted with the new version.
+//  4. waits until a node with the same address rejoins the cluster.
+//
+// The coordinator node is upgraded at last, the upgrade is finished after it's stopped.
+// The node being drained is excluded from scheduling, and the upgrade status is persisted,
+// so the upgrade is resumed by the new coordinator after the coordinator node is upgraded.
+type rollingUpgrade struct {
+	maxLag  time.Duration
+	nodes   []*config.UpgradeNodeStatus
+	current int
+	message string
+	// persisted is false if the status is changed after it's persisted last time.
+	persisted bool
+}
+
+func newRollingUpgrade(maxLag time.Duration, aliveNodes map[node.ID]*node.Info, self node.ID) *rollingUpgrade {
+	nodes := make([]*config.UpgradeNodeStatus, 0, len(aliveNodes))
+	for _, n := range aliveNodes {
+		nodes = append(nodes, &config.UpgradeNodeStatus{
+			ID:            n.ID.String(),
+			AdvertiseAddr: n.AdvertiseAddr,
+			Version:       n.Version,
+			State:         config.UpgradeNodePending,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		iSelf, jSelf := nodes[i].ID == self.String(), nodes[j].ID == self.String()
+		if iSelf != jSelf {
+			return jSelf
+		}
+		return nodes[i].AdvertiseAddr < nodes[j].AdvertiseAddr
+	})
+	return &rollingUpgrade{maxLag: maxLag, nodes: nodes}
+}
+
+// restoreRollingUpgrade restores the rolling upgrade from the persisted status.
+func restoreRollingUpgrade(status *config.UpgradeStatus) *rollingUpgrade {
+	u := &rollingUpgrade{
+		maxLag:    time.Duration(status.MaxLagSeconds) * time.Second,
+		nodes:     make([]*config.UpgradeNodeStatus, 0, len(status.Nodes)),
+		message:   status.Message,
+		persisted: true,
+	}
+	for i := range status.Nodes {
+		n := status.Nodes[i]
+		u.nodes = append(u.nodes, &n)
+		if n.State == config.UpgradeNodeUpgraded {
+			u.current = i + 1
+		}
+	}
+	return u
+}
+
+// draining returns the node whose maintainers are being moved out or are moved out,
+// no task should be scheduled to it.
+func (u *rollingUpgrade) draining() (node.ID, bool) {
+	if u.finished() {
+		return "", false
+	}
+	n := u.nodes[u.current]
+	if n.State == config.UpgradeNodeDraining || n.State == config.UpgradeNodeReady {
+		return node.ID(n.ID), true
+	}
+	return "", false
+}
+
+func (u *rollingUpgrade) finished() bool {
+	return u.current >= len(u.nodes)
+}
+
+func (u *rollingUpgrade) status() *config.UpgradeStatus {
+	status := &config.UpgradeStatus{
+		State:         config.UpgradeStateRunning,
+		MaxLagSeconds: int64(u.maxLag / time.Second),
+		Nodes:         make([]config.UpgradeNodeStatus, 0, len(u.nodes)),
+		Message:       u.message,
+	}
+	if u.finished() {
+		status.State = config.UpgradeStateFinished
+	}
+	for _, n := range u.nodes {
+		status.Nodes = append(status.Nodes, *n)
+	}
+	return status
+}
+
+// StartUpgrade starts the rolling upgrade of all alive nodes, the next node is not
+// upgraded until the checkpoint lag of all working changefeeds is less than maxLag.
+func (c *Controller) StartUpgrade(ctx context.Context, maxLag time.Duration) error {
+	c.upgradeMu.Lock()
+	defer c.upgradeMu.Unlock()
+	if c.upgrade != nil && !c.upgrade.finished() {
+		return errors.ErrRollingUpgradeRunning.GenWithStackByArgs()
+	}
+	if maxLag <= 0 {
+		return errors.ErrAPIInvalidParam.GenWithStack("max lag must be positive")
+	}
+	u := newRollingUpgrade(maxLag, c.nodeManager.GetAliveNodes(), c.selfNode.ID)
+	if err := c.backend.SaveUpgradeStatus(ctx, u.status()); err != nil {
+		return errors.Trace(err)
+	}
+	u.persisted = true
+	c.upgrade = u
+	log.Info("rolling upgrade started",
+		zap.Duration("maxLag", maxLag),
+		zap.Int("nodes", len(c.upgrade.nodes)))
+	return nil
+}
+
+// StopUpgrade stops the rolling upgrade, the node being upgraded is not touched.
+func (c *Controller) StopUpgrade(ctx context.Context) error {
+	c.upgradeMu.Lock()
+	defer c.upgradeMu.Unlock()
+	if c.upgrade == nil {
+		return nil
+	}
+	if err := c.backend.SaveUpgradeStatus(ctx, nil); err != nil {
+		return errors.Trace(err)
+	}
+	if id, ok := c.upgrade.draining(); ok {
+		c.nodeManager.UnmarkNodeStopping(id)
+	}
+	log.Info("rolling upgrade stopped", zap.Int("upgradedNodes", c.upgrade.current))
+	c.upgrade = nil
+	return nil
+}
+
+// GetUpgradeStatus returns the status of the rolling upgrade.
+func (c *Controller) GetUpgradeStatus(_ context.Context) (*config.UpgradeStatus, error) {
+	c.upgradeMu.Lock()
+	defer c.upgradeMu.Unlock()
+	if c.upgrade == nil {
+		return &config.UpgradeStatus{State: config.UpgradeStateNone}, nil
+	}
+	return c.upgrade.status(), nil
+}
+
+// restoreUpgrade resumes the rolling upgrade persisted by the previous coordinator,
+// it's called when the coordinator is bootstrapped.
+func (c *Controller) restoreUpgrade(ctx context.Context) error {
+	status, err := c.backend.GetUpgradeStatus(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if status == nil {
+		return nil
+	}
+	u := restoreRollingUpgrade(status)
+	if id, ok := u.draining(); ok {
+		c.nodeManager.MarkNodeStopping(id)
+	}
+	c.upgradeMu.Lock()
+	c.upgrade = u
+	c.upgradeMu.Unlock()
+	log.Info("rolling upgrade restored",
+		zap.Int("upgradedNodes", u.current),
+		zap.Int("nodes", len(u.nodes)))
+	return nil
+}
+
+// advanceUpgrade drives the rolling upgrade, it's called periodically.
+func (c *Controller) advanceUpgrade(now time.Time) {
+	c.upgradeMu.Lock()
+	defer c.upgradeMu.Unlock()
+	u := c.upgrade
+	if u == nil {
+		return
+	}
+	defer c.persistUpgrade(u)
+	if u.finished() {
+		return
+	}
+	aliveNodes := c.nodeManager.GetAliveNodes()
+	n := u.nodes[u.current]
+	id := node.ID(n.ID)
+	_, alive := aliveNodes[id]
+	switch n.State {
+	case config.UpgradeNodePending:
+		if msg, ok := c.checkUpgradeHealthy(now, u.maxLag); !ok {
+			u.message = msg
+			return
+		}
+		if !alive {
+			// the node is offline before it's upgraded, wait for it to rejoin.
+			c.updateUpgradeNode(u, n, config.UpgradeNodeRestarting)
+			return
+		}
+		c.updateUpgradeNode(u, n, config.UpgradeNodeDraining)
+		c.nodeManager.MarkNodeStopping(id)
+		fallthrough
+	case config.UpgradeNodeDraining:
+		if !alive {
+			c.updateUpgradeNode(u, n, config.UpgradeNodeRestarting)
+			return
+		}
+		if remaining := c.moveMaintainersOut(id); remaining > 0 {
+			u.message = fmt.Sprintf("moving %d maintainers out of node %s", remaining, n.AdvertiseAddr)
+			return
+		}
+		c.updateUpgradeNode(u, n, config.UpgradeNodeReady)
+	case config.UpgradeNodeReady:
+		if !alive {
+			c.updateUpgradeNode(u, n, config.UpgradeNodeRestarting)
+			return
+		}
+		c.nodeManager.MarkNodeStopping(id)
+		if len(c.changefeedDB.GetByNodeID(id)) > 0 && len(aliveNodes) > 1 {
+			// some maintainers are scheduled to the node again
+			c.updateUpgradeNode(u, n, config.UpgradeNodeDraining)
+		}
+	case config.UpgradeNodeRestarting:
+		for _, info := range aliveNodes {
+			if info.AdvertiseAddr == n.AdvertiseAddr && info.ID != id {
+				n.NewID = info.ID.String()
+				n.NewVersion = info.Version
+				c.updateUpgradeNode(u, n, config.UpgradeNodeUpgraded)
+				u.current++
+				if u.finished() {
+					u.message = "all nodes are upgraded"
+					log.Info("rolling upgrade finished", zap.Int("nodes", len(u.nodes)))
+				}
+				return
+			}
+		}
+		u.message = fmt.Sprintf("waiting for node %s to rejoin the cluster", n.AdvertiseAddr)
+	}
+}
+
+func (c *Controller) updateUpgradeNode(u *rollingUpgrade, n *config.UpgradeNodeStatus, state config.UpgradeNodeState) {
+	log.Info("rolling upgrade node state changed",
+		zap.String("node", n.ID),
+		zap.String("address", n.AdvertiseAddr),
+		zap.String("from", string(n.State)),
+		zap.String("to", string(state)))
+	n.State = state
+	u.persisted = false
+	switch state {
+	case config.UpgradeNodeReady:
+		u.message = fmt.Sprintf("node %s is ready to be upgraded", n.AdvertiseAddr)
+	case config.UpgradeNodeRestarting:
+		u.message = fmt.Sprintf("waiting for node %s to rejoin the cluster", n.AdvertiseAddr)
+	default:
+		u.message = ""
+	}
+}
+
+// persistUpgrade persists the status of the rolling upgrade if it's changed,
+// it's retried in the next round if it fails.
+func (c *Controller) persistUpgrade(u *rollingUpgrade) {
+	if u.persisted {
+		return
+	}
+	if err := c.backend.SaveUpgradeStatus(context.Background(), u.status()); err != nil {
+		log.Warn("failed to persist the rolling upgrade status, retry later", zap.Error(err))
+		return
+	}
+	u.persisted = true
+}
+
+// checkUpgradeHealthy returns false with the reason if the next node should not be upgraded now.
+func (c *Controller) checkUpgradeHealthy(now time.Time, maxLag time.Duration) (string, bool) {
+	if size := c.operatorController.OperatorSize(); size > 0 {
+		return fmt.Sprintf("waiting for %d maintainers in scheduling", size), false
+	}
+	for _, cf := range c.changefeedDB.GetAllChangefeeds() {
+		if cf.GetNodeID() == "" {
+			continue
+		}
+		lag := now.Sub(oracle.GetTimeFromTS(cf.GetStatus().CheckpointTs))
+		if lag > maxLag {
+			return fmt.Sprintf("waiting for the checkpoint lag of changefeed %s (%s) to be less than %s",
+				cf.ID.Name(), lag.Round(time.Second), maxLag), false
+		}
+	}
+	return "", true
+}
+
+// moveMaintainersOut moves the maintainers on the node to the other nodes,
+// it returns the number of the maintainers still on the node.
+func (c *Controller) moveMaintainersOut(id node.ID) int {
+	cfs := c.changefeedDB.GetByNodeID(id)
+	// the draining node is marked as stopping, it's not schedulable.
+	schedulableNodes := c.nodeManager.GetSchedulableNodes()
+	if len(cfs) == 0 || len(schedulableNodes) == 0 {
+		// the maintainers of a single node cluster are rescheduled after the node rejoins
+		return 0
+	}
+	count := make(map[node.ID]int, len(schedulableNodes))
+	for nodeID := range schedulableNodes {
+		if nodeID != id {
+			count[nodeID] = len(c.changefeedDB.GetByNodeID(nodeID))
+		}
+	}
+	for _, cf := range cfs {
+		if c.operatorController.GetOperator(cf.ID) != nil {
+			continue
+		}
+		target := leastLoadedNode(count)
+		if c.operatorController.AddOperator(c.operatorController.NewMoveMaintainerOperator(cf, id, target)) {
+			count[target]++
+		}
+	}
+	return len(cfs)
+}
+
+func leastLoadedNode(count map[node.ID]int) node.ID {
+	var target node.ID
+	for id, n := range count {
+		if target == "" || n < count[target] || (n == count[target] && id < target) {
+			target = id
+		}
+	}
+	return target
+}
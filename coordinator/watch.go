@@ -0,0 +1,221 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+const (
+	// maxChangefeedEvents is the number of the latest changefeed events kept for the watchers.
+	maxChangefeedEvents = 4096
+	// checkpointStallThreshold is how long the checkpoint of a working changefeed
+	// has not advanced before a checkpoint-stalled event is published.
+	checkpointStallThreshold = time.Minute
+	// watchChangefeedInterval is the interval to compare the changefeeds with the last observation.
+	watchChangefeedInterval = time.Second
+)
+
+// observedChangefeed is the last observation of a changefeed.
+type observedChangefeed struct {
+	state        model.FeedState
+	node         node.ID
+	checkpointTs uint64
+	// advanceTime is the time the checkpoint advanced last time, or the changefeed
+	// started to work.
+	advanceTime time.Time
+	stalled     bool
+}
+
+// changefeedEvents turns the changes of the changefeeds into events and keeps the
+// latest ones, so watchers long-poll the events instead of the status of all changefeeds.
+// The changes are found by comparing the changefeeds with the last observation periodically.
+type changefeedEvents struct {
+	mu       sync.Mutex
+	events   []*config.ChangefeedEvent
+	revision uint64
+	// notify is closed and replaced when new events are published.
+	notify chan struct{}
+
+	// the fields below are only accessed by the controller
+	observed    map[common.ChangeFeedID]*observedChangefeed
+	initialized bool
+	lastCheck   time.Time
+}
+
+func newChangefeedEvents() *changefeedEvents {
+	return &changefeedEvents{
+		notify:   make(chan struct{}),
+		observed: make(map[common.ChangeFeedID]*observedChangefeed),
+	}
+}
+
+// observe compares the changefeeds with the last observation and publishes the changes.
+// The changefeeds found by the first observation are not reported as created.
+func (e *changefeedEvents) observe(now time.Time, cfs []*changefeed.Changefeed) {
+	var events []*config.ChangefeedEvent
+	newEvent := func(tp config.ChangefeedEventType, id common.ChangeFeedID) *config.ChangefeedEvent {
+		event := &config.ChangefeedEvent{
+			Type:         tp,
+			Namespace:    id.Namespace(),
+			ChangefeedID: id.Name(),
+			Time:         now,
+		}
+		events = append(events, event)
+		return event
+	}
+
+	seen := make(map[common.ChangeFeedID]struct{}, len(cfs))
+	for _, cf := range cfs {
+		id := cf.GetID()
+		seen[id] = struct{}{}
+		info := cf.GetInfo()
+		nodeID := cf.GetNodeID()
+		checkpointTs := cf.GetStatus().CheckpointTs
+
+		o, ok := e.observed[id]
+		if !ok {
+			e.observed[id] = &observedChangefeed{
+				state:        info.State,
+				node:         nodeID,
+				checkpointTs: checkpointTs,
+				advanceTime:  now,
+			}
+			if e.initialized {
+				event := newEvent(config.ChangefeedEventCreated, id)
+				event.State = info.State
+				event.CheckpointTs = checkpointTs
+			}
+			continue
+		}
+
+		if info.State != o.state {
+			event := newEvent(config.ChangefeedEventStateChanged, id)
+			event.State = info.State
+			event.PrevState = o.state
+			event.CheckpointTs = checkpointTs
+			if info.Error != nil {
+				event.Message = info.Error.Message
+			}
+			o.state = info.State
+		}
+		if nodeID != o.node {
+			if nodeID != "" && o.node != "" {
+				event := newEvent(config.ChangefeedEventMaintainerMoved, id)
+				event.State = info.State
+				event.Node = nodeID.String()
+				event.PrevNode = o.node.String()
+			}
+			o.node = nodeID
+		}
+
+		switch {
+		case checkpointTs > o.checkpointTs:
+			o.checkpointTs = checkpointTs
+			o.advanceTime = now
+			if o.stalled {
+				event := newEvent(config.ChangefeedEventCheckpointResumed, id)
+				event.State = info.State
+				event.CheckpointTs = checkpointTs
+				o.stalled = false
+			}
+		case info.State != model.StateNormal || nodeID == "":
+			// the checkpoint of a changefeed not working is expected to not advance
+			o.advanceTime = now
+			o.stalled = false
+		case !o.stalled && now.Sub(o.advanceTime) >= checkpointStallThreshold:
+			event := newEvent(config.ChangefeedEventCheckpointStalled, id)
+			event.State = info.State
+			event.CheckpointTs = checkpointTs
+			event.Node = nodeID.String()
+			o.stalled = true
+		}
+	}
+	for id, o := range e.observed {
+		if _, ok := seen[id]; !ok {
+			event := newEvent(config.ChangefeedEventRemoved, id)
+			event.PrevState = o.state
+			delete(e.observed, id)
+		}
+	}
+	e.initialized = true
+	e.publish(events...)
+}
+
+func (e *changefeedEvents) publish(events ...*config.ChangefeedEvent) {
+	if len(events) == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, event := range events {
+		e.revision++
+		event.Revision = e.revision
+		e.events = append(e.events, event)
+	}
+	if len(e.events) > maxChangefeedEvents {
+		e.events = append([]*config.ChangefeedEvent(nil), e.events[len(e.events)-maxChangefeedEvents:]...)
+	}
+	close(e.notify)
+	e.notify = make(chan struct{})
+}
+
+// wait returns the events after the revision, it blocks until there are such events
+// or ctx is done. If id is not empty, only the events of the changefeed are returned.
+// The latest revision is also returned, which is used by the next wait.
+// The revision restarts from zero if the coordinator changes, so a revision greater than
+// the latest one is treated as zero.
+func (e *changefeedEvents) wait(
+	ctx context.Context, revision uint64, id *common.ChangeFeedDisplayName,
+) ([]*config.ChangefeedEvent, uint64) {
+	for {
+		e.mu.Lock()
+		if revision > e.revision {
+			revision = 0
+		}
+		var events []*config.ChangefeedEvent
+		for _, event := range e.events {
+			if event.Revision <= revision {
+				continue
+			}
+			if id != nil && (event.Namespace != id.Namespace || event.ChangefeedID != id.Name) {
+				continue
+			}
+			events = append(events, event)
+		}
+		latest, notify := e.revision, e.notify
+		e.mu.Unlock()
+
+		if len(events) > 0 {
+			return events, latest
+		}
+		// skip the events not matched
+		if latest > revision {
+			revision = latest
+		}
+		select {
+		case <-ctx.Done():
+			return nil, revision
+		case <-notify:
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveChangefeedEvents(t *testing.T) {
+	events := newChangefeedEvents()
+	cfID := common.NewChangeFeedIDWithName("test")
+	newChangefeed := func(id common.ChangeFeedID) *changefeed.Changefeed {
+		return changefeed.NewChangefeed(id, &config.ChangeFeedInfo{
+			ChangefeedID: id,
+			Config:       config.GetDefaultReplicaConfig(),
+			State:        model.StateNormal,
+			SinkURI:      "kafka://127.0.0.1:9092",
+		}, 10, true)
+	}
+	cf := newChangefeed(cfID)
+	cf.SetNodeID("node1")
+
+	now := time.Now()
+	// the changefeeds found by the first observation are not reported
+	events.observe(now, []*changefeed.Changefeed{cf})
+	require.Equal(t, uint64(0), events.revision)
+
+	// a new changefeed
+	cf2ID := common.NewChangeFeedIDWithName("test2")
+	cf2 := newChangefeed(cf2ID)
+	events.observe(now, []*changefeed.Changefeed{cf, cf2})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, latest := events.wait(ctx, 0, nil)
+	require.Len(t, result, 1)
+	require.Equal(t, config.ChangefeedEventCreated, result[0].Type)
+	require.Equal(t, "test2", result[0].ChangefeedID)
+	require.Equal(t, uint64(1), latest)
+
+	// the checkpoint stalls and resumes
+	events.observe(now.Add(checkpointStallThreshold), []*changefeed.Changefeed{cf, cf2})
+	result, latest = events.wait(ctx, latest, nil)
+	require.Len(t, result, 1)
+	require.Equal(t, config.ChangefeedEventCheckpointStalled, result[0].Type)
+	require.Equal(t, "test", result[0].ChangefeedID)
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 20})
+	events.observe(now.Add(checkpointStallThreshold+time.Second), []*changefeed.Changefeed{cf, cf2})
+	result, latest = events.wait(ctx, latest, nil)
+	require.Len(t, result, 1)
+	require.Equal(t, config.ChangefeedEventCheckpointResumed, result[0].Type)
+	require.Equal(t, uint64(20), result[0].CheckpointTs)
+
+	// the state changes and the maintainer moves
+	info, err := cf.GetInfo().Clone()
+	require.NoError(t, err)
+	info.State = model.StateWarning
+	cf.SetInfo(info)
+	cf.SetNodeID(node.ID("node2"))
+	events.observe(now.Add(2*checkpointStallThreshold), []*changefeed.Changefeed{cf, cf2})
+	result, latest = events.wait(ctx, latest, nil)
+	require.Len(t, result, 2)
+	require.Equal(t, config.ChangefeedEventStateChanged, result[0].Type)
+	require.Equal(t, model.StateNormal, result[0].PrevState)
+	require.Equal(t, model.StateWarning, result[0].State)
+	require.Equal(t, config.ChangefeedEventMaintainerMoved, result[1].Type)
+	require.Equal(t, "node1", result[1].PrevNode)
+	require.Equal(t, "node2", result[1].Node)
+
+	// a removed changefeed, only the events of it are watched
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		id := cf2ID.DisplayName
+		result, _ := events.wait(ctx, latest, &id)
+		require.Len(t, result, 1)
+		require.Equal(t, config.ChangefeedEventRemoved, result[0].Type)
+	}()
+	events.publish(&config.ChangefeedEvent{Type: config.ChangefeedEventStateChanged, ChangefeedID: "test"})
+	events.observe(now.Add(2*checkpointStallThreshold), []*changefeed.Changefeed{cf})
+	<-done
+
+	// no event after the latest revision, and a revision from the previous coordinator
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer shortCancel()
+	result, latest = events.wait(shortCtx, events.revision, nil)
+	require.Empty(t, result)
+	require.Equal(t, events.revision, latest)
+	result, _ = events.wait(ctx, latest+100, nil)
+	require.Len(t, result, int(latest))
+}
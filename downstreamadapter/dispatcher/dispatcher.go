@@ -14,7 +14,9 @@
 package dispatcher
 
 import (
+	"context"
 	"math/rand"
+	"strconv"
 	"sync/atomic"
 	"time"
 
@@ -27,8 +29,13 @@ import (
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/pkg/tracing"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +46,7 @@ type EventDispatcher interface {
 	GetChangefeedID() common.ChangeFeedID
 	GetTableSpan() *heartbeatpb.TableSpan
 	GetFilterConfig() *eventpb.FilterConfig
+	GetUpstreamID() uint64
 	EnableSyncPoint() bool
 	GetSyncPointInterval() time.Duration
 	GetResolvedTs() uint64
@@ -97,6 +105,8 @@ type Dispatcher struct {
 	componentStatus *ComponentStateWithMutex
 	// the config of filter
 	filterConfig *eventpb.FilterConfig
+	// upstreamID is the ID of the TiDB cluster the dispatcher pulls events from.
+	upstreamID uint64
 
 	// tableInfo is the latest table info of the dispatcher's corresponding table.
 	tableInfo *common.TableInfo
@@ -122,6 +132,8 @@ type Dispatcher struct {
 
 	// blockEventStatus is used to store the current pending ddl/sync point event and its block status.
 	blockEventStatus BlockEventStatus
+	// barrierState is the block events the maintainer is waiting for involving the dispatcher.
+	barrierState BarrierState
 
 	// tableProgress is used to calculate the checkpointTs of the dispatcher
 	tableProgress *TableProgress
@@ -142,6 +154,17 @@ type Dispatcher struct {
 	// such as error of flush ddl events
 	// errCh is shared in the eventDispatcherManager
 	errCh chan error
+
+	// metricTableFlushLag records the duration between the commitTs of the dml events
+	// and the time they are flushed to downstream.
+	metricTableFlushLag prometheus.Observer
+
+	// rateLimiter limits the rows and bytes emitted to the sink, it's nil if the rate limit is disabled.
+	rateLimiter *RateLimiter
+	// quiescer holds the events after the quiesce ts, it's shared by the event dispatcher manager.
+	quiescer *Quiescer
+	// ingestPolicy decides how the ingest events are handled, it's shared by the event dispatcher manager.
+	ingestPolicy *IngestPolicy
 }
 
 func NewDispatcher(
@@ -155,8 +178,12 @@ func NewDispatcher(
 	schemaIDToDispatchers *SchemaIDToDispatchers,
 	syncPointConfig *syncpoint.SyncPointConfig,
 	filterConfig *eventpb.FilterConfig,
+	upstreamID uint64,
 	currentPdTs uint64,
 	errCh chan error,
+	rateLimiter *RateLimiter,
+	quiescer *Quiescer,
+	ingestPolicy *IngestPolicy,
 ) *Dispatcher {
 	dispatcher := &Dispatcher{
 		changefeedID:          changefeedID,
@@ -169,6 +196,7 @@ func NewDispatcher(
 		componentStatus:       newComponentStateWithMutex(heartbeatpb.ComponentState_Working),
 		resolvedTs:            startTs,
 		filterConfig:          filterConfig,
+		upstreamID:            upstreamID,
 		isRemoving:            atomic.Bool{},
 		blockEventStatus:      BlockEventStatus{blockPendingEvent: nil},
 		tableProgress:         NewTableProgress(),
@@ -177,6 +205,11 @@ func NewDispatcher(
 		resendTaskMap:         newResendTaskMap(),
 		creationPDTs:          currentPdTs,
 		errCh:                 errCh,
+		metricTableFlushLag: metrics.DispatcherTableFlushLagDuration.WithLabelValues(
+			changefeedID.Namespace(), changefeedID.Name(), strconv.FormatInt(tableSpan.TableID, 10)),
+		rateLimiter:  rateLimiter,
+		quiescer:     quiescer,
+		ingestPolicy: ingestPolicy,
 	}
 
 	dispatcher.addToStatusDynamicStream()
@@ -209,7 +242,8 @@ func (d *Dispatcher) InitializeTableSchemaStore(schemaInfo []*heartbeatpb.Schema
 // If we get a dispatcher action, we need to check whether the action is for the current pending ddl event. If so, we can deal the ddl event based on the action.
 // 1. If the action is a write, we need to add the ddl event to the sink for writing to downstream.
 // 2. If the action is a pass, we just need to pass the event
-func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.DispatcherStatus) {
+// The ctx carries the span of the maintainer's barrier handling if tracing is enabled.
+func (d *Dispatcher) HandleDispatcherStatus(ctx context.Context, dispatcherStatus *heartbeatpb.DispatcherStatus) {
 	log.Debug("dispatcher handle dispatcher status",
 		zap.Any("dispatcherStatus", dispatcherStatus),
 		zap.Stringer("dispatcher", d.id),
@@ -237,7 +271,11 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 			d.blockEventStatus.updateBlockStage(heartbeatpb.BlockStage_WRITING)
 			if action.Action == heartbeatpb.Action_Write {
 				failpoint.Inject("BlockOrWaitBeforeWrite", nil)
+				_, span := tracing.Start(ctx, "sink.WriteBlockEvent",
+					attribute.String("dispatcher", d.id.String()),
+					attribute.Int64("commitTs", int64(pendingEvent.GetCommitTs())))
 				err := d.AddBlockEventToSink(pendingEvent)
+				tracing.End(span, err)
 				if err != nil {
 					select {
 					case d.errCh <- err:
@@ -272,6 +310,64 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 	}
 }
 
+// HandleBarrierState updates the block events the maintainer is waiting for involving the dispatcher.
+func (d *Dispatcher) HandleBarrierState(state *heartbeatpb.DispatcherBarrierState) {
+	d.barrierState.update(state.BlockedEvents)
+}
+
+// checkBarrierState returns true if the dispatcher is paused by a pending block event which the
+// maintainer is still waiting for, and logs the events the maintainer is waiting for periodically
+// if it's blocked for a long time.
+func (d *Dispatcher) checkBarrierState() bool {
+	pendingEvent, blockStage := d.blockEventStatus.getEventAndStage()
+	if pendingEvent == nil {
+		return false
+	}
+	blockedEvents, duration := d.barrierState.get()
+	if len(blockedEvents) == 0 {
+		return false
+	}
+	if duration >= barrierStallLogInterval && d.barrierState.shouldLog() {
+		log.Warn("dispatcher is blocked by the maintainer for a long time",
+			zap.Stringer("changefeedID", d.changefeedID),
+			zap.Stringer("dispatcher", d.id),
+			zap.Int64("tableID", d.tableSpan.TableID),
+			zap.Uint64("pendingCommitTs", pendingEvent.GetCommitTs()),
+			zap.String("blockStage", blockStage.String()),
+			zap.Duration("duration", duration),
+			zap.Any("blockedEvents", blockedEvents))
+	}
+	return true
+}
+
+// throttleWakeCallback reserves the rate limit for the dml events, and returns the callback
+// which wakes the dynamic stream after the throttled duration if the rate limit is exceeded.
+// The dispatcher doesn't sleep here to avoid blocking other dispatchers in the dynamic stream.
+func (d *Dispatcher) throttleWakeCallback(dispatcherEvents []DispatcherEvent, wakeCallback func()) func() {
+	if d.rateLimiter == nil {
+		return wakeCallback
+	}
+	var rows, bytes int64
+	for _, dispatcherEvent := range dispatcherEvents {
+		if dml, ok := dispatcherEvent.Event.(*commonEvent.DMLEvent); ok {
+			rows += int64(dml.Len())
+			bytes += dml.GetRowsSize()
+		}
+	}
+	delay := d.rateLimiter.Reserve(rows, bytes)
+	if delay <= 0 {
+		return wakeCallback
+	}
+	wakeAt := time.Now().Add(delay)
+	return func() {
+		if wait := time.Until(wakeAt); wait > 0 {
+			time.AfterFunc(wait, wakeCallback)
+			return
+		}
+		wakeCallback()
+	}
+}
+
 // HandleEvents can batch handle events about resolvedTs Event and DML Event.
 // While for DDLEvent and SyncPointEvent, they should be handled separately,
 // because they are block events.
@@ -279,8 +375,17 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 // by setting them with different event types in DispatcherEventsHandler.GetType
 // When we handle events, we don't have any previous events still in sink.
 func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallback func()) (block bool) {
+	holdTs := d.quiescer.HoldTs()
+	if held := firstHeld(dispatcherEvents, holdTs); held < len(dispatcherEvents) {
+		return d.handleEventsWithHold(dispatcherEvents, held, holdTs, wakeCallback)
+	}
+	return d.handleEvents(dispatcherEvents, wakeCallback)
+}
+
+func (d *Dispatcher) handleEvents(dispatcherEvents []DispatcherEvent, wakeCallback func()) (block bool) {
 	// Only return false when all events are resolvedTs Event.
 	block = false
+	wakeCallback = d.throttleWakeCallback(dispatcherEvents, wakeCallback)
 	// Dispatcher is ready, handle the events
 	for _, dispatcherEvent := range dispatcherEvents {
 		log.Debug("dispatcher receive all event",
@@ -313,8 +418,10 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			}
 			block = true
 			dml.ReplicatingTs = d.creationPDTs
+			dml.TableSpan = d.tableSpan
 			dml.AssembleRows(d.tableInfo)
 			dml.AddPostFlushFunc(func() {
+				d.metricTableFlushLag.Observe(time.Since(oracle.GetTimeFromTS(dml.GetCommitTs())).Seconds())
 				// Considering dml event in sink may be written to downstream not in order,
 				// thus, we use tableProgress.Empty() to ensure these events are flushed to downstream completely
 				// and wake dynamic stream to handle the next events.
@@ -331,7 +438,10 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			block = true
 			ddl := event.(*commonEvent.DDLEvent)
 			// Update the table info of the dispatcher, when it receives ddl event.
-			d.tableInfo = ddl.TableInfo
+			// The ingest event doesn't change the schema, so it has no table info.
+			if !ddl.IsIngest {
+				d.tableInfo = ddl.TableInfo
+			}
 			log.Info("dispatcher receive ddl event",
 				zap.Stringer("dispatcher", d.id),
 				zap.String("query", ddl.Query),
@@ -374,6 +484,14 @@ func (d *Dispatcher) AddDMLEventToSink(event *commonEvent.DMLEvent) {
 }
 
 func (d *Dispatcher) AddBlockEventToSink(event commonEvent.BlockEvent) error {
+	// the ingest event has no ddl to write, it's passed once the ingest policy allows it
+	if ddl, ok := event.(*commonEvent.DDLEvent); ok && ddl.IsIngest {
+		if err := d.ingestPolicy.check(ddl); err != nil {
+			return err
+		}
+		d.PassBlockEventToSink(event)
+		return nil
+	}
 	d.tableProgress.Add(event)
 	return d.sink.WriteBlockEvent(event)
 }
@@ -596,6 +714,10 @@ func (d *Dispatcher) GetFilterConfig() *eventpb.FilterConfig {
 	return d.filterConfig
 }
 
+func (d *Dispatcher) GetUpstreamID() uint64 {
+	return d.upstreamID
+}
+
 func (d *Dispatcher) GetSyncPointInterval() time.Duration {
 	if d.syncPointConfig != nil {
 		return d.syncPointConfig.SyncPointInterval
@@ -607,6 +729,7 @@ func (d *Dispatcher) Remove() {
 	log.Info("table event dispatcher component status changed to stopping",
 		zap.String("table", d.tableSpan.String()))
 	d.isRemoving.Store(true)
+	d.quiescer.forget(d.id)
 
 	dispatcherStatusDynamicStream := GetDispatcherStatusDynamicStream()
 	err := dispatcherStatusDynamicStream.RemovePath(d.id)
@@ -682,12 +805,23 @@ func (d *Dispatcher) GetHeartBeatInfo(h *HeartBeatInfo) {
 	h.ComponentStatus = d.GetComponentStatus()
 	h.TableSpan = d.GetTableSpan()
 	h.IsRemoving = d.GetRemovingStatus()
+	h.BlockedByBarrier = d.checkBarrierState()
 }
 
 func (d *Dispatcher) GetEventSizePerSecond() float32 {
 	return d.tableProgress.GetEventSizePerSecond()
 }
 
+// GetSampledKeys returns the comparable keys sampled from the recent dml events of the dispatcher,
+// they are reported to the maintainer to find the split points of the hot span.
+func (d *Dispatcher) GetSampledKeys() [][]byte {
+	keys := d.tableProgress.GetSampledKeys()
+	for i, key := range keys {
+		keys[i] = spanz.ToComparableKey(key)
+	}
+	return keys
+}
+
 func (d *Dispatcher) HandleCheckpointTs(checkpointTs uint64) {
 	d.sink.AddCheckpointTs(checkpointTs)
 }
@@ -23,6 +23,8 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/node"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/pkg/spanz"
@@ -32,9 +34,10 @@ import (
 // TODO: Merge this file into dispatcher_test.go after refactoring the dispatcher test.
 
 type mockSink struct {
-	dmls     []*commonEvent.DMLEvent
-	isNormal bool
-	sinkType common.SinkType
+	dmls        []*commonEvent.DMLEvent
+	blockEvents []commonEvent.BlockEvent
+	isNormal    bool
+	sinkType    common.SinkType
 }
 
 func (s *mockSink) AddDMLEvent(event *commonEvent.DMLEvent) {
@@ -42,6 +45,7 @@ func (s *mockSink) AddDMLEvent(event *commonEvent.DMLEvent) {
 }
 
 func (s *mockSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	s.blockEvents = append(s.blockEvents, event)
 	event.PostFlush()
 	return nil
 }
@@ -53,6 +57,12 @@ func (s *mockSink) PassBlockEvent(event commonEvent.BlockEvent) {
 func (s *mockSink) AddCheckpointTs(ts uint64) {
 }
 
+func (s *mockSink) AuditRowCount(_ uint64) {}
+
+func (s *mockSink) ResendTableSchema(_ []int64) {}
+
+func (s *mockSink) WriteEOF() {}
+
 func (s *mockSink) SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore) {
 }
 
@@ -120,8 +130,12 @@ func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Dis
 			SyncPointRetention: time.Duration(10 * time.Minute),
 		}, // syncPointConfig
 		nil,          // filterConfig
+		0,            // upstreamID
 		common.Ts(0), // pdTs
 		make(chan error, 1),
+		nil, // rateLimiter
+		nil, // quiescer
+		nil, // ingestPolicy
 	)
 }
 
@@ -238,7 +252,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	require.Equal(t, 0, dispatcher.resendTaskMap.Len())
 
 	// 2.2 block ddl event, but need to communicate with maintainer(add table)
@@ -279,7 +293,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatusPrev)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatusPrev)
 	require.Equal(t, 1, dispatcher.resendTaskMap.Len())
 
 	dispatcherStatus = &heartbeatpb.DispatcherStatus{
@@ -288,7 +302,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	require.Equal(t, 0, dispatcher.resendTaskMap.Len())
 
 	// clear the event in tableProgress when receive the ack
@@ -328,7 +342,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	require.Equal(t, 0, dispatcher.resendTaskMap.Len())
 	// pending event
 	require.NotNil(t, dispatcher.blockEventStatus.blockPendingEvent)
@@ -347,7 +361,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	checkpointTs, isEmpty = tableProgress.GetCheckpointTs()
 	require.Equal(t, true, isEmpty)
 	require.Equal(t, uint64(4), checkpointTs)
@@ -382,7 +396,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: true,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	require.Equal(t, 0, dispatcher.resendTaskMap.Len())
 	// pending event
 	require.NotNil(t, dispatcher.blockEventStatus.blockPendingEvent)
@@ -396,7 +410,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: true,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	checkpointTs, isEmpty = tableProgress.GetCheckpointTs()
 	require.Equal(t, true, isEmpty)
 	require.Equal(t, uint64(5), checkpointTs)
@@ -464,7 +478,7 @@ func TestUncompeleteTableSpanDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	require.Equal(t, 0, dispatcher.resendTaskMap.Len())
 	// pending event
 	require.NotNil(t, dispatcher.blockEventStatus.blockPendingEvent)
@@ -483,7 +497,7 @@ func TestUncompeleteTableSpanDispatcherHandleEvents(t *testing.T) {
 			IsSyncPoint: false,
 		},
 	}
-	dispatcher.HandleDispatcherStatus(dispatcherStatus)
+	dispatcher.HandleDispatcherStatus(context.Background(), dispatcherStatus)
 	checkpointTs = dispatcher.GetCheckpointTs()
 	require.Equal(t, uint64(1), checkpointTs)
 	require.Equal(t, 1, count)
@@ -707,3 +721,65 @@ func TestDispatcherClose(t *testing.T) {
 		require.Equal(t, uint64(0), watermark.ResolvedTs)
 	}
 }
+
+func TestDispatcherBarrierState(t *testing.T) {
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	blockedEvents := []*heartbeatpb.BlockedEvent{{CommitTs: 10}}
+
+	// the dispatcher is not paused by a block event
+	dispatcher.HandleBarrierState(&heartbeatpb.DispatcherBarrierState{BlockedEvents: blockedEvents})
+	require.False(t, dispatcher.checkBarrierState())
+
+	ddlEvent := &commonEvent.DDLEvent{
+		FinishedTs: 10,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0, 1},
+		},
+	}
+	dispatcher.blockEventStatus.setBlockEvent(ddlEvent, heartbeatpb.BlockStage_WAITING)
+	require.True(t, dispatcher.checkBarrierState())
+	events, _ := dispatcher.barrierState.get()
+	require.Equal(t, blockedEvents, events)
+
+	// the maintainer is not waiting for the event anymore
+	dispatcher.HandleBarrierState(&heartbeatpb.DispatcherBarrierState{})
+	require.False(t, dispatcher.checkBarrierState())
+}
+
+func TestDispatcherPassIngestEvent(t *testing.T) {
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	dispatcher.ingestPolicy = NewIngestPolicy(dispatcher.changefeedID, config.IngestStrategyPauseUntilAck, []uint64{3})
+	tableInfo := &common.TableInfo{TableName: common.TableName{Schema: "test", Table: "t", TableID: 1}}
+	dispatcher.SetInitialTableInfo(tableInfo)
+	nodeID := node.NewID()
+	blockedTables := &commonEvent.InfluencedTables{
+		InfluenceType: commonEvent.InfluenceTypeNormal,
+		TableIDs:      []int64{1},
+	}
+
+	// the ingest event isn't passed until it's acknowledged
+	ingest := &commonEvent.DDLEvent{FinishedTs: 2, IsIngest: true, Query: "IMPORT INTO `test`.`t`", BlockedTables: blockedTables}
+	dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ingest)}, callback)
+	err := <-dispatcher.errCh
+	require.True(t, errors.ErrIngestEventNotAcked.Equal(err))
+	require.Empty(t, sink.blockEvents)
+
+	// the acknowledged ingest event is passed without writing it to the sink,
+	// and the table info of the dispatcher is kept
+	ingest = &commonEvent.DDLEvent{FinishedTs: 3, IsIngest: true, Query: "IMPORT INTO `test`.`t`", BlockedTables: blockedTables}
+	dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ingest)}, callback)
+	require.Empty(t, dispatcher.errCh)
+	require.Empty(t, sink.blockEvents)
+	require.Equal(t, tableInfo, dispatcher.tableInfo)
+	checkpointTs, _ := dispatcher.tableProgress.GetCheckpointTs()
+	require.Equal(t, uint64(2), checkpointTs)
+
+	// the ddls are never affected by the ingest policy
+	ddl := &commonEvent.DDLEvent{FinishedTs: 4, Query: "alter table t add index idx(v)", BlockedTables: blockedTables, TableInfo: tableInfo}
+	dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ddl)}, callback)
+	require.Empty(t, dispatcher.errCh)
+	require.Equal(t, []commonEvent.BlockEvent{ddl}, sink.blockEvents)
+}
@@ -22,8 +22,10 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/tracing"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/ticdc/utils/threadpool"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -104,6 +106,55 @@ func (b *BlockEventStatus) getEventAndStage() (commonEvent.BlockEvent, heartbeat
 	return b.blockPendingEvent, b.blockStage
 }
 
+// barrierStallLogInterval is the interval of logging that the dispatcher is blocked by the maintainer.
+const barrierStallLogInterval = 10 * time.Second
+
+// BarrierState stores the block events the maintainer is waiting for involving the dispatcher,
+// it's reported by the maintainer when it handles the block status of the dispatcher.
+type BarrierState struct {
+	mutex         sync.Mutex
+	blockedEvents []*heartbeatpb.BlockedEvent
+	// since is when the maintainer begins to report the dispatcher is blocked
+	since       time.Time
+	lastLogTime time.Time
+}
+
+func (b *BarrierState) update(blockedEvents []*heartbeatpb.BlockedEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if len(blockedEvents) == 0 {
+		b.blockedEvents = nil
+		b.since = time.Time{}
+		return
+	}
+	// the dispatcher is blocked by a new event
+	if len(b.blockedEvents) == 0 || b.blockedEvents[0].CommitTs != blockedEvents[0].CommitTs {
+		b.since = time.Now()
+	}
+	b.blockedEvents = blockedEvents
+}
+
+// get returns the block events and how long the dispatcher is blocked.
+func (b *BarrierState) get() ([]*heartbeatpb.BlockedEvent, time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if len(b.blockedEvents) == 0 {
+		return nil, 0
+	}
+	return b.blockedEvents, time.Since(b.since)
+}
+
+// shouldLog returns true if the stall is not logged in the last interval.
+func (b *BarrierState) shouldLog() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if time.Since(b.lastLogTime) < barrierStallLogInterval {
+		return false
+	}
+	b.lastLogTime = time.Now()
+	return true
+}
+
 type SchemaIDToDispatchers struct {
 	mutex sync.RWMutex
 	m     map[int64]map[common.DispatcherID]interface{}
@@ -190,6 +241,8 @@ type HeartBeatInfo struct {
 	TableSpan       *heartbeatpb.TableSpan
 	ComponentStatus heartbeatpb.ComponentState
 	IsRemoving      bool
+	// BlockedByBarrier is true if the dispatcher is waiting for the maintainer to resolve the block event
+	BlockedByBarrier bool
 }
 
 // Resend Task is reponsible for resending the TableSpanBlockStatus message with ddl info to maintainer each 50ms.
@@ -260,12 +313,19 @@ func NewDispatcherEvent(from *node.ID, event commonEvent.Event) DispatcherEvent
 type DispatcherStatusWithID struct {
 	id     common.DispatcherID
 	status *heartbeatpb.DispatcherStatus
+	// traceContext is the trace context carried by the message from the maintainer.
+	traceContext map[string]string
 }
 
-func NewDispatcherStatusWithID(dispatcherStatus *heartbeatpb.DispatcherStatus, dispatcherID common.DispatcherID) DispatcherStatusWithID {
+func NewDispatcherStatusWithID(
+	dispatcherStatus *heartbeatpb.DispatcherStatus,
+	traceContext map[string]string,
+	dispatcherID common.DispatcherID,
+) DispatcherStatusWithID {
 	return DispatcherStatusWithID{
-		status: dispatcherStatus,
-		id:     dispatcherID,
+		status:       dispatcherStatus,
+		id:           dispatcherID,
+		traceContext: traceContext,
 	}
 }
 
@@ -294,7 +354,10 @@ func (h *DispatcherStatusHandler) Path(event DispatcherStatusWithID) common.Disp
 
 func (h *DispatcherStatusHandler) Handle(dispatcher *Dispatcher, events ...DispatcherStatusWithID) (await bool) {
 	for _, event := range events {
-		dispatcher.HandleDispatcherStatus(event.GetDispatcherStatus())
+		ctx, span := tracing.StartFromRemote(event.traceContext, "dispatcher.HandleDispatcherStatus",
+			attribute.String("dispatcher", dispatcher.id.String()))
+		dispatcher.HandleDispatcherStatus(ctx, event.GetDispatcherStatus())
+		span.End()
 	}
 	return false
 }
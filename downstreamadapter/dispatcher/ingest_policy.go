@@ -0,0 +1,76 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// IngestPolicy decides how the dispatchers handle the ingest events. An ingest event marks the
+// data ingested into TiKV as SST files by a finished IMPORT INTO job, the ingested data is not in
+// the change log, so the downstream may be inconsistent with the upstream after the event. The
+// event has no ddl to execute in the downstream, it's always passed after the policy allows it,
+// and the real ddls are never affected by the policy. It's shared by all the dispatchers of the
+// changefeed in the node, and a nil IngestPolicy passes the ingest events silently.
+type IngestPolicy struct {
+	changefeedID common.ChangeFeedID
+	strategy     config.IngestStrategy
+	// acked are the commit ts of the ingest events acknowledged by the users,
+	// they are passed even if the strategy is pause-until-ack.
+	acked map[uint64]struct{}
+}
+
+// NewIngestPolicy creates an IngestPolicy, it returns nil if the ingest events are
+// passed silently.
+func NewIngestPolicy(changefeedID common.ChangeFeedID, strategy config.IngestStrategy, ackedTs []uint64) *IngestPolicy {
+	if strategy == "" || strategy == config.IngestStrategyReplicate {
+		return nil
+	}
+	p := &IngestPolicy{
+		changefeedID: changefeedID,
+		strategy:     strategy,
+		acked:        make(map[uint64]struct{}, len(ackedTs)),
+	}
+	for _, ts := range ackedTs {
+		p.acked[ts] = struct{}{}
+	}
+	return p
+}
+
+// check returns an error if the ingest event can't be passed until the users take actions.
+// The caller passes the ingest event if it returns nil, it's only called with the ingest events.
+func (p *IngestPolicy) check(event *commonEvent.DDLEvent) error {
+	if p == nil {
+		return nil
+	}
+	switch p.strategy {
+	case config.IngestStrategyError:
+		return errors.ErrIngestEventUnsupported.GenWithStackByArgs(event.GetCommitTs(), event.Query)
+	case config.IngestStrategySkip:
+		log.Warn("skip the ingest event, the ingested data is not replicated",
+			zap.Stringer("changefeedID", p.changefeedID),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("query", event.Query))
+	case config.IngestStrategyPauseUntilAck:
+		if _, ok := p.acked[event.GetCommitTs()]; !ok {
+			return errors.ErrIngestEventNotAcked.GenWithStackByArgs(event.GetCommitTs(), event.Query)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestPolicyCheck(t *testing.T) {
+	cfID := common.NewChangefeedID()
+	ingest := &commonEvent.DDLEvent{FinishedTs: 10, IsIngest: true, Query: "IMPORT INTO `test`.`t`"}
+
+	// the ingest events are passed silently by default
+	require.Nil(t, NewIngestPolicy(cfID, "", nil))
+	require.Nil(t, NewIngestPolicy(cfID, config.IngestStrategyReplicate, nil))
+	var nilPolicy *IngestPolicy
+	require.NoError(t, nilPolicy.check(ingest))
+
+	p := NewIngestPolicy(cfID, config.IngestStrategyError, nil)
+	err := p.check(ingest)
+	require.True(t, errors.ErrIngestEventUnsupported.Equal(err))
+	require.True(t, errors.ShouldFailChangefeed(err))
+
+	p = NewIngestPolicy(cfID, config.IngestStrategySkip, nil)
+	require.NoError(t, p.check(ingest))
+
+	p = NewIngestPolicy(cfID, config.IngestStrategyPauseUntilAck, []uint64{5})
+	err = p.check(ingest)
+	require.True(t, errors.ErrIngestEventNotAcked.Equal(err))
+	require.True(t, errors.ShouldPauseChangefeed(err))
+	require.False(t, errors.ShouldFailChangefeed(err))
+	// the acknowledged ingest event is passed
+	p = NewIngestPolicy(cfID, config.IngestStrategyPauseUntilAck, []uint64{5, 10})
+	require.NoError(t, p.check(ingest))
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"go.uber.org/zap"
+)
+
+// Quiescer holds the dispatchers of a changefeed at a ts, so the downstream is consistent
+// at the ts after all dispatchers flush the events before it. It's shared by all the
+// dispatchers of the changefeed in the node.
+//
+// A dispatcher holds the events whose commitTs is larger than the holdTs and blocks the
+// dynamic stream, its resolvedTs is advanced to the holdTs, so the checkpointTs reported
+// to the maintainer reaches the holdTs after the events before it are flushed.
+// The held events are handled again when the holdTs is released or moved forward.
+type Quiescer struct {
+	holdTs atomic.Uint64
+
+	mu sync.Mutex
+	// held is the function to handle the held events of each dispatcher again.
+	held map[common.DispatcherID]func()
+}
+
+// NewQuiescer creates a Quiescer which holds the dispatchers at the holdTs,
+// 0 means the dispatchers are not held.
+func NewQuiescer(holdTs uint64) *Quiescer {
+	q := &Quiescer{held: make(map[common.DispatcherID]func())}
+	q.holdTs.Store(holdTs)
+	return q
+}
+
+// HoldTs returns the ts the dispatchers are held at, 0 means the dispatchers are not held.
+func (q *Quiescer) HoldTs() uint64 {
+	if q == nil {
+		return 0
+	}
+	return q.holdTs.Load()
+}
+
+// Hold holds the dispatchers at the ts, the dispatchers are released if the ts is 0.
+func (q *Quiescer) Hold(ts uint64) {
+	q.mu.Lock()
+	old := q.holdTs.Swap(ts)
+	if old == ts {
+		q.mu.Unlock()
+		return
+	}
+	var resumes []func()
+	if ts == 0 || ts > old {
+		// the held events may be handled now
+		for id, resume := range q.held {
+			resumes = append(resumes, resume)
+			delete(q.held, id)
+		}
+	}
+	q.mu.Unlock()
+	log.Info("quiescer hold ts changed",
+		zap.Uint64("oldHoldTs", old), zap.Uint64("holdTs", ts),
+		zap.Int("resumedDispatchers", len(resumes)))
+	for _, resume := range resumes {
+		resume()
+	}
+}
+
+// hold records the resume function of the dispatcher, it's called at once if
+// the holdTs is changed after the dispatcher decides to hold the events.
+func (q *Quiescer) hold(id common.DispatcherID, holdTs uint64, resume func()) {
+	q.mu.Lock()
+	if q.holdTs.Load() == holdTs {
+		q.held[id] = resume
+		q.mu.Unlock()
+		return
+	}
+	q.mu.Unlock()
+	resume()
+}
+
+// forget drops the held events of the removed dispatcher.
+func (q *Quiescer) forget(id common.DispatcherID) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	delete(q.held, id)
+	q.mu.Unlock()
+}
+
+// firstHeld returns the index of the first event which must be held, the events
+// after it are held too to keep the order. It returns len(events) if no event is held.
+func firstHeld(events []DispatcherEvent, holdTs uint64) int {
+	if holdTs == 0 {
+		return len(events)
+	}
+	for i, e := range events {
+		if e.Event.GetType() == commonEvent.TypeHandshakeEvent {
+			continue
+		}
+		if e.Event.GetCommitTs() > holdTs {
+			return i
+		}
+	}
+	return len(events)
+}
+
+// handleEventsWithHold handles the events before the held one, and holds the others
+// until the quiescer is released. It always blocks the dynamic stream.
+func (d *Dispatcher) handleEventsWithHold(events []DispatcherEvent, held int, holdTs uint64, wakeCallback func()) bool {
+	// the stream is woken by the held events after they are handled.
+	d.handleEvents(events[:held], func() {})
+	// the events are received in order, all events before the holdTs are received.
+	if atomic.LoadUint64(&d.resolvedTs) < holdTs {
+		atomic.StoreUint64(&d.resolvedTs, holdTs)
+	}
+	heldEvents := events[held:]
+	log.Debug("dispatcher holds events",
+		zap.Stringer("dispatcher", d.id),
+		zap.Uint64("holdTs", holdTs),
+		zap.Int("heldEvents", len(heldEvents)))
+	d.quiescer.hold(d.id, holdTs, func() {
+		if d.isRemoving.Load() {
+			return
+		}
+		if !d.HandleEvents(heldEvents, wakeCallback) {
+			wakeCallback()
+		}
+	})
+	return true
+}
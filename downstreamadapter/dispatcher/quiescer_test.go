@@ -0,0 +1,76 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuiescerHoldAndRelease(t *testing.T) {
+	var nilQuiescer *Quiescer
+	require.Zero(t, nilQuiescer.HoldTs())
+
+	q := NewQuiescer(0)
+	q.Hold(10)
+	require.Equal(t, uint64(10), q.HoldTs())
+
+	resumed := 0
+	id := common.NewDispatcherID()
+	q.hold(id, 10, func() { resumed++ })
+	require.Zero(t, resumed)
+
+	// moving the hold ts backward doesn't resume the held dispatchers.
+	q.Hold(5)
+	require.Zero(t, resumed)
+	q.hold(id, 5, func() { resumed++ })
+
+	// release resumes the held dispatchers once.
+	q.Hold(0)
+	require.Equal(t, 1, resumed)
+	q.Hold(0)
+	require.Equal(t, 1, resumed)
+
+	// the dispatcher is resumed at once if the hold ts has changed.
+	q.hold(id, 10, func() { resumed++ })
+	require.Equal(t, 2, resumed)
+
+	// the removed dispatcher is not resumed.
+	q.Hold(20)
+	q.hold(id, 20, func() { resumed++ })
+	q.forget(id)
+	q.Hold(0)
+	require.Equal(t, 2, resumed)
+
+	// the restarted dispatchers are held at the persisted hold ts
+	require.Equal(t, uint64(30), NewQuiescer(30).HoldTs())
+}
+
+func TestFirstHeld(t *testing.T) {
+	nodeID := node.NewID()
+	events := []DispatcherEvent{
+		NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 5}),
+		NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 10}),
+		NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 15}),
+		NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 20}),
+	}
+	require.Equal(t, 4, firstHeld(events, 0))
+	require.Equal(t, 2, firstHeld(events, 10))
+	require.Equal(t, 0, firstHeld(events, 1))
+	require.Equal(t, 4, firstHeld(events, 20))
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter limits the rows and bytes the dispatchers of a changefeed emit to the sink.
+// It's shared by all the dispatchers of the changefeed in the node, so the limit is
+// enforced by each node independently.
+//
+// The limiter never blocks the caller, it reserves the tokens for the events and returns
+// how long the dispatcher should wait before handling the next events, the dispatcher
+// delays waking the dynamic stream by the duration after the events are flushed.
+type RateLimiter struct {
+	rows  *rate.Limiter
+	bytes *rate.Limiter
+
+	metricThrottledDuration prometheus.Counter
+}
+
+// NewRateLimiter creates a RateLimiter, the limit is disabled if it's not positive.
+// It returns nil if both limits are disabled.
+func NewRateLimiter(changefeedID common.ChangeFeedID, rowsPerSecond, bytesPerSecond int64) *RateLimiter {
+	if rowsPerSecond <= 0 && bytesPerSecond <= 0 {
+		return nil
+	}
+	l := &RateLimiter{
+		metricThrottledDuration: metrics.DispatcherRateLimitThrottledDuration.
+			WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
+	}
+	// The burst is the limit of one second, so the events in one second can be emitted at once.
+	if rowsPerSecond > 0 {
+		l.rows = rate.NewLimiter(rate.Limit(rowsPerSecond), int(rowsPerSecond))
+	}
+	if bytesPerSecond > 0 {
+		l.bytes = rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+	}
+	return l
+}
+
+// Reserve reserves the tokens for the rows and bytes, and returns the duration
+// to wait before the next events can be emitted.
+func (l *RateLimiter) Reserve(rows, bytes int64) time.Duration {
+	now := time.Now()
+	delay := reserve(l.rows, rows, now)
+	if d := reserve(l.bytes, bytes, now); d > delay {
+		delay = d
+	}
+	if delay > 0 {
+		l.metricThrottledDuration.Add(delay.Seconds())
+	}
+	return delay
+}
+
+// reserve reserves n tokens from the limiter. The tokens larger than the burst
+// are reserved in multiple times, so a large event is throttled instead of being rejected.
+func reserve(limiter *rate.Limiter, n int64, now time.Time) time.Duration {
+	if limiter == nil || n <= 0 {
+		return 0
+	}
+	var delay time.Duration
+	burst := int64(limiter.Burst())
+	for n > 0 {
+		tokens := n
+		if tokens > burst {
+			tokens = burst
+		}
+		delay = limiter.ReserveN(now, int(tokens)).DelayFrom(now)
+		n -= tokens
+	}
+	return delay
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	require.Nil(t, NewRateLimiter(changefeedID, 0, 0))
+
+	limiter := NewRateLimiter(changefeedID, 10, 0)
+	// the first second is covered by the burst.
+	require.Zero(t, limiter.Reserve(10, 1024))
+
+	delay := limiter.Reserve(10, 1024)
+	require.InDelta(t, time.Second.Seconds(), delay.Seconds(), 0.1)
+
+	// the rows larger than the burst are throttled instead of rejected.
+	delay = limiter.Reserve(25, 0)
+	require.InDelta(t, (3500 * time.Millisecond).Seconds(), delay.Seconds(), 0.1)
+
+	// the larger delay of rows and bytes is used.
+	limiter = NewRateLimiter(changefeedID, 100, 1000)
+	require.Zero(t, limiter.Reserve(1, 1000))
+	delay = limiter.Reserve(1, 2000)
+	require.InDelta(t, (2 * time.Second).Seconds(), delay.Seconds(), 0.1)
+}
@@ -15,6 +15,7 @@ package dispatcher
 
 import (
 	"container/list"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -41,8 +42,16 @@ type TableProgress struct {
 	cumulateEventSize int64
 	// it used to calculate the sum-dml-event-size/s for each dispatcher
 	lastQueryTime time.Time
+
+	// sampledKeys are the keys sampled from the flushed dml events since the last query,
+	// sampledEventCount is the number of the dml events the keys are sampled from.
+	sampledKeys       [][]byte
+	sampledEventCount int
 }
 
+// maxSampledKeys is the max number of the keys sampled between two queries.
+const maxSampledKeys = 32
+
 // Ts represents a timestamp pair, used for sorting primarily by commitTs and secondarily by startTs.
 type Ts struct {
 	commitTs uint64
@@ -85,6 +94,21 @@ func (p *TableProgress) Remove(event commonEvent.Event) {
 		delete(p.elemMap, ts)
 	}
 	p.cumulateEventSize += event.GetSize()
+	if dml, ok := event.(*commonEvent.DMLEvent); ok && len(dml.SampledKey) > 0 {
+		p.sampleKey(dml.SampledKey)
+	}
+}
+
+// sampleKey keeps a uniform sample of the keys by reservoir sampling.
+func (p *TableProgress) sampleKey(key []byte) {
+	p.sampledEventCount++
+	if len(p.sampledKeys) < maxSampledKeys {
+		p.sampledKeys = append(p.sampledKeys, key)
+		return
+	}
+	if i := rand.Intn(p.sampledEventCount); i < maxSampledKeys {
+		p.sampledKeys[i] = key
+	}
 }
 
 // Empty checks if the TableProgress is empty.
@@ -134,3 +158,15 @@ func (p *TableProgress) GetEventSizePerSecond() float32 {
 
 	return eventSizePerSecond
 }
+
+// GetSampledKeys returns the keys sampled from the dml events flushed since the last query,
+// and clears them to prepare for the next query.
+func (p *TableProgress) GetSampledKeys() [][]byte {
+	p.rwMutex.Lock()
+	defer p.rwMutex.Unlock()
+
+	keys := p.sampledKeys
+	p.sampledKeys = nil
+	p.sampledEventCount = 0
+	return keys
+}
@@ -15,6 +15,8 @@ package dispatchermanager
 
 import (
 	"context"
+	"math"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -26,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/upstreamservice"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
@@ -34,6 +37,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -56,6 +60,9 @@ One EventDispatcherManager has one backend sink.
 type EventDispatcherManager struct {
 	changefeedID common.ChangeFeedID
 	maintainerID node.ID
+	// maintainerEpoch is the largest epoch of the maintainers seen by the manager,
+	// the messages of the maintainers with a smaller epoch are ignored.
+	maintainerEpoch atomic.Uint64
 
 	pdClock pdutil.Clock
 
@@ -73,8 +80,9 @@ type EventDispatcherManager struct {
 	schemaIDToDispatchers *dispatcher.SchemaIDToDispatchers
 
 	// statusesChan is used to store the status of dispatchers when status changed
-	// and push to heartbeatRequestQueue
-	statusesChan chan TableSpanStatusWithSeq
+	// and push to heartbeatRequestQueue. The statuses of a batch of dispatchers
+	// are sent together, so the batch is acknowledged in a single heartbeat.
+	statusesChan chan []TableSpanStatusWithSeq
 	// heartbeatRequestQueue is used to store the heartbeat request from all the dispatchers.
 	// heartbeat collector will consume the heartbeat request from the queue and send the response to each dispatcher.
 	heartbeatRequestQueue *HeartbeatRequestQueue
@@ -92,8 +100,19 @@ type EventDispatcherManager struct {
 
 	// sink is used to send all the events to the downstream.
 	sink sink.Sink
+	// rateLimiter is shared by all the dispatchers to limit the rows and bytes emitted to the sink,
+	// it's nil if the rate limit is disabled.
+	rateLimiter *dispatcher.RateLimiter
+	// quiescer is shared by all the dispatchers to hold them at the ts requested by the maintainer.
+	quiescer *dispatcher.Quiescer
+	// ingestPolicy is shared by all the dispatchers to decide how the ingest events are handled.
+	ingestPolicy *dispatcher.IngestPolicy
 
 	latestWatermark Watermark
+	// reportedStatuses are the statuses of the dispatchers last reported to the maintainer,
+	// they are used to only report the changed statuses between two complete statuses.
+	// It's only accessed by the heartbeat task.
+	reportedStatuses map[common.DispatcherID]reportedStatus
 
 	// collect the error in all the dispatchers and sink module
 	// when we get the error, we will report the error to the maintainer
@@ -104,6 +123,13 @@ type EventDispatcherManager struct {
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
 
+	// draining is set when the node is shutting down, no new dispatchers will be created after that.
+	draining atomic.Bool
+	// maintainerCapabilities are the capabilities negotiated with the maintainer in the bootstrap.
+	maintainerCapabilities atomic.Pointer[heartbeatpb.Capabilities]
+	// dispatcherCount is the number of the table dispatchers, it's counted in the resources used by the node.
+	dispatcherCount atomic.Int64
+
 	metricTableTriggerEventDispatcherCount prometheus.Gauge
 	metricEventDispatcherCount             prometheus.Gauge
 	metricCreateDispatcherDuration         prometheus.Observer
@@ -111,6 +137,7 @@ type EventDispatcherManager struct {
 	metricCheckpointTsLag                  prometheus.Gauge
 	metricResolvedTs                       prometheus.Gauge
 	metricResolvedTsLag                    prometheus.Gauge
+	metricBlockedDispatcherCount           prometheus.Gauge
 }
 
 // return actual startTs of the table trigger event dispatcher
@@ -122,24 +149,43 @@ func NewEventDispatcherManager(
 	startTs uint64,
 	maintainerID node.ID,
 	newChangefeed bool,
-) (*EventDispatcherManager, uint64, error) {
+) (_ *EventDispatcherManager, _ uint64, err error) {
+	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
+	if cfConfig.UpstreamInfo != nil {
+		// The dispatchers pull events from the log service of the upstream on this node,
+		// so make sure it's created before any dispatcher is registered.
+		logServiceManager := appcontext.GetService[*upstreamservice.Manager](appcontext.UpstreamLogService)
+		holder := upstreamservice.DispatcherManagerHolder(changefeedID)
+		logService, err := logServiceManager.Acquire(cfConfig.UpstreamInfo, holder)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		defer func() {
+			if err != nil {
+				logServiceManager.Release(cfConfig.UpstreamInfo.ID, holder)
+			}
+		}()
+		pdClock = logService.Upstream.PDClock
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	var wg sync.WaitGroup
-	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
 	manager := &EventDispatcherManager{
 		dispatcherMap:                          newDispatcherMap(),
 		changefeedID:                           changefeedID,
 		maintainerID:                           maintainerID,
 		pdClock:                                pdClock,
-		statusesChan:                           make(chan TableSpanStatusWithSeq, 8192),
+		statusesChan:                           make(chan []TableSpanStatusWithSeq, 8192),
 		blockStatusesChan:                      make(chan *heartbeatpb.TableSpanBlockStatus, 1024*1024),
 		errCh:                                  make(chan error, 1),
 		wg:                                     wg,
 		cancel:                                 cancel,
 		config:                                 cfConfig,
-		filterConfig:                           toFilterConfigPB(cfConfig.Filter),
+		filterConfig:                           toFilterConfigPB(cfConfig.Filter, cfConfig.CaseSensitive, cfConfig.GetTimeZone()),
 		schemaIDToDispatchers:                  dispatcher.NewSchemaIDToDispatchers(),
 		latestWatermark:                        NewWatermark(startTs),
+		quiescer:                               dispatcher.NewQuiescer(cfConfig.QuiesceTs),
+		ingestPolicy:                           dispatcher.NewIngestPolicy(changefeedID, cfConfig.IngestStrategy, cfConfig.AckedIngestTs),
 		metricTableTriggerEventDispatcherCount: metrics.TableTriggerEventDispatcherGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricEventDispatcherCount:             metrics.EventDispatcherGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricCreateDispatcherDuration:         metrics.CreateDispatcherDuration.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
@@ -147,6 +193,7 @@ func NewEventDispatcherManager(
 		metricCheckpointTsLag:                  metrics.EventDispatcherManagerCheckpointTsLagGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricResolvedTs:                       metrics.EventDispatcherManagerResolvedTsGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricResolvedTsLag:                    metrics.EventDispatcherManagerResolvedTsLagGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
+		metricBlockedDispatcherCount:           metrics.EventDispatcherManagerBlockedDispatcherGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 	}
 
 	// Set Sync Point Config
@@ -158,7 +205,18 @@ func NewEventDispatcherManager(
 		}
 	}
 
-	var err error
+	if cfConfig.SinkConfig != nil {
+		manager.rateLimiter = dispatcher.NewRateLimiter(changefeedID,
+			util.GetOrZero(cfConfig.SinkConfig.MaxRowsPerSecond),
+			util.GetOrZero(cfConfig.SinkConfig.MaxBytesPerSecond))
+	}
+
+	captureOldValue, err := sink.CaptureOldValue(manager.config)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	manager.filterConfig.DisableOldValue = !captureOldValue
+
 	manager.sink, err = sink.NewSink(ctx, manager.config, manager.changefeedID)
 	if err != nil {
 		return nil, 0, errors.Trace(err)
@@ -221,6 +279,7 @@ func NewEventDispatcherManager(
 		zap.Stringer("maintainerID", maintainerID),
 		zap.Uint64("startTs", startTs),
 		zap.Uint64("tableTriggerStartTs", tableTriggerStartTs))
+	node.AddMemoryQuota(int64(cfConfig.MemoryQuota))
 	return manager, tableTriggerStartTs, nil
 }
 
@@ -241,8 +300,16 @@ func (e *EventDispatcherManager) close(removeChangefeed bool) {
 	defer e.closing.Store(false)
 
 	toCloseDispatchers := make([]*dispatcher.Dispatcher, 0)
+	tableIDs := make(map[int64]struct{})
+	// only the node holding the table trigger event dispatcher receives the checkpointTs
+	// of the changefeed, so it's the only one sending the EOF to the downstream.
+	hasTableTriggerEventDispatcher := false
 	e.dispatcherMap.ForEach(func(id common.DispatcherID, dispatcher *dispatcher.Dispatcher) {
+		tableIDs[dispatcher.GetTableSpan().TableID] = struct{}{}
 		appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).RemoveDispatcher(dispatcher)
+		if dispatcher.IsTableTriggerEventDispatcher() {
+			hasTableTriggerEventDispatcher = true
+		}
 		if dispatcher.IsTableTriggerEventDispatcher() && e.sink.SinkType() != common.MysqlSinkType {
 			err := appcontext.GetService[*HeartBeatCollector](appcontext.HeartbeatCollector).RemoveCheckpointTsMessage(e.changefeedID)
 			if err != nil {
@@ -279,6 +346,10 @@ func (e *EventDispatcherManager) close(removeChangefeed bool) {
 		e.heartBeatTask.Cancel()
 	}
 
+	// the EOF must be sent before the sink is closed
+	if removeChangefeed && hasTableTriggerEventDispatcher {
+		e.sink.WriteEOF()
+	}
 	e.sink.Close(removeChangefeed)
 	e.cancel()
 	e.wg.Wait()
@@ -290,6 +361,24 @@ func (e *EventDispatcherManager) close(removeChangefeed bool) {
 	metrics.EventDispatcherManagerResolvedTsGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerCheckpointTsLagGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerResolvedTsLagGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
+	metrics.EventDispatcherManagerBlockedDispatcherGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
+	for tableID := range tableIDs {
+		metrics.DispatcherTableFlushLagDuration.DeleteLabelValues(
+			e.changefeedID.Namespace(), e.changefeedID.Name(), strconv.FormatInt(tableID, 10))
+	}
+	metrics.DispatcherRateLimitThrottledDuration.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
+	for _, complete := range []bool{true, false} {
+		metrics.DispatcherHeartbeatStatusCount.DeleteLabelValues(
+			e.changefeedID.Namespace(), e.changefeedID.Name(), heartbeatStatusType(complete))
+	}
+
+	node.AddDispatcherCount(-e.dispatcherCount.Swap(0))
+	node.AddMemoryQuota(-int64(e.config.MemoryQuota))
+
+	if e.config.UpstreamInfo != nil {
+		appcontext.GetService[*upstreamservice.Manager](appcontext.UpstreamLogService).
+			Release(e.config.UpstreamInfo.ID, upstreamservice.DispatcherManagerHolder(e.changefeedID))
+	}
 
 	e.closed.Store(true)
 	log.Info("event dispatcher manager closed", zap.Stringer("changefeedID", e.changefeedID))
@@ -349,6 +438,11 @@ func (e *EventDispatcherManager) InitalizeTableTriggerEventDispatcher(schemaInfo
 // 1. newDispatchers is called by NewTableTriggerEventDispatcher(just means when creating table trigger event dispatcher)
 // 2. changefeed is total new created, or resumed with overwriteCheckpointTs
 func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, removeDDLTs bool) error {
+	if e.draining.Load() {
+		log.Info("event dispatcher manager is draining, ignore the new dispatchers",
+			zap.Stringer("changefeedID", e.changefeedID), zap.Int("count", len(infos)))
+		return nil
+	}
 	start := time.Now()
 
 	dispatcherIds := make([]common.DispatcherID, 0, len(infos))
@@ -367,7 +461,9 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 		startTsList = append(startTsList, int64(info.StartTs))
 		tableSpans = append(tableSpans, info.TableSpan)
 		schemaIds = append(schemaIds, info.SchemaID)
-		pdTsList = append(pdTsList, info.CurrentPDTs)
+		// the events committed before the pd ts are written in safe mode, it covers the
+		// window replicated again after the checkpoint ts is moved backward by a resume.
+		pdTsList = append(pdTsList, max(info.CurrentPDTs, e.config.SafeModeEndTs))
 	}
 
 	if len(dispatcherIds) == 0 {
@@ -393,6 +489,7 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 		newStartTsList = startTsList
 	}
 
+	statuses := make([]TableSpanStatusWithSeq, 0, len(dispatcherIds))
 	for idx, id := range dispatcherIds {
 		d := dispatcher.NewDispatcher(
 			e.changefeedID,
@@ -403,8 +500,12 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 			e.schemaIDToDispatchers,
 			e.syncPointConfig,
 			e.filterConfig,
+			e.config.UpstreamID,
 			pdTsList[idx],
-			e.errCh)
+			e.errCh,
+			e.rateLimiter,
+			e.quiescer,
+			e.ingestPolicy)
 
 		if e.heartBeatTask == nil {
 			e.heartBeatTask = newHeartBeatTask(e)
@@ -421,19 +522,21 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 		}
 
 		seq := e.dispatcherMap.Set(id, d)
-		e.statusesChan <- TableSpanStatusWithSeq{
+		statuses = append(statuses, TableSpanStatusWithSeq{
 			TableSpanStatus: &heartbeatpb.TableSpanStatus{
 				ID:              id.ToPB(),
 				ComponentStatus: heartbeatpb.ComponentState_Working,
 			},
 			StartTs: uint64(newStartTsList[idx]),
 			Seq:     seq,
-		}
+		})
 
 		if d.IsTableTriggerEventDispatcher() {
 			e.metricTableTriggerEventDispatcherCount.Inc()
 		} else {
 			e.metricEventDispatcherCount.Inc()
+			e.dispatcherCount.Add(1)
+			node.AddDispatcherCount(1)
 		}
 
 		log.Info("new dispatcher created",
@@ -444,6 +547,7 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 			zap.Any("startTs", newStartTsList[idx]))
 
 	}
+	e.statusesChan <- statuses
 	e.metricCreateDispatcherDuration.Observe(time.Since(start).Seconds() / float64(len(dispatcherIds)))
 	log.Info("batch create new dispatchers",
 		zap.Any("changefeedID", e.changefeedID.Name()),
@@ -503,7 +607,7 @@ func (e *EventDispatcherManager) collectBlockStatusRequest(ctx context.Context)
 		case blockStatus := <-e.blockStatusesChan:
 			blockStatusMessage = append(blockStatusMessage, blockStatus)
 
-			delay := time.NewTimer(10 * time.Millisecond)
+			delay := time.NewTimer(e.config.LatencyMode.Profile().StatusBatchInterval)
 		loop:
 			for {
 				select {
@@ -543,33 +647,31 @@ func (e *EventDispatcherManager) collectComponentStatusWhenChanged(ctx context.C
 			ResolvedTs:   watermark.ResolvedTs,
 			Seq:          watermark.Seq,
 		}
+		appendStatuses := func(tableSpanStatuses []TableSpanStatusWithSeq) {
+			for _, tableSpanStatus := range tableSpanStatuses {
+				if len(statusMessage) == 0 || newWatermark.Seq < tableSpanStatus.Seq {
+					newWatermark.Seq = tableSpanStatus.Seq
+				}
+				statusMessage = append(statusMessage, tableSpanStatus.TableSpanStatus)
+				if tableSpanStatus.StartTs != 0 && tableSpanStatus.StartTs < newWatermark.CheckpointTs {
+					newWatermark.CheckpointTs = tableSpanStatus.StartTs
+				}
+				if tableSpanStatus.StartTs != 0 && tableSpanStatus.StartTs < newWatermark.ResolvedTs {
+					newWatermark.ResolvedTs = tableSpanStatus.StartTs
+				}
+			}
+		}
 		select {
 		case <-ctx.Done():
 			return
-		case tableSpanStatus := <-e.statusesChan:
-			statusMessage = append(statusMessage, tableSpanStatus.TableSpanStatus)
-			newWatermark.Seq = tableSpanStatus.Seq
-			if tableSpanStatus.StartTs != 0 && tableSpanStatus.StartTs < newWatermark.CheckpointTs {
-				newWatermark.CheckpointTs = tableSpanStatus.StartTs
-			}
-			if tableSpanStatus.StartTs != 0 && tableSpanStatus.StartTs < newWatermark.ResolvedTs {
-				newWatermark.ResolvedTs = tableSpanStatus.StartTs
-			}
-			delay := time.NewTimer(10 * time.Millisecond)
+		case tableSpanStatuses := <-e.statusesChan:
+			appendStatuses(tableSpanStatuses)
+			delay := time.NewTimer(e.config.LatencyMode.Profile().StatusBatchInterval)
 		loop:
 			for {
 				select {
-				case tableSpanStatus := <-e.statusesChan:
-					statusMessage = append(statusMessage, tableSpanStatus.TableSpanStatus)
-					if newWatermark.Seq < tableSpanStatus.Seq {
-						newWatermark.Seq = tableSpanStatus.Seq
-					}
-					if tableSpanStatus.StartTs != 0 && tableSpanStatus.StartTs < newWatermark.CheckpointTs {
-						newWatermark.CheckpointTs = tableSpanStatus.StartTs
-					}
-					if tableSpanStatus.StartTs != 0 && tableSpanStatus.StartTs < newWatermark.ResolvedTs {
-						newWatermark.ResolvedTs = tableSpanStatus.StartTs
-					}
+				case tableSpanStatuses := <-e.statusesChan:
+					appendStatuses(tableSpanStatuses)
 				case <-delay.C:
 					break loop
 				}
@@ -598,7 +700,8 @@ func (e *EventDispatcherManager) collectComponentStatusWhenChanged(ctx context.C
 //
 // Parameters:
 //   - needCompleteStatus: when true, includes detailed status for all dispatchers in the response.
-//     When false, only includes minimal information and watermarks to reduce message size.
+//     When false, only includes the statuses changed since they are reported last time and watermarks
+//     to reduce message size.
 //
 // Returns a HeartBeatRequest containing the aggregated information.
 func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatus bool) *heartbeatpb.HeartBeatRequest {
@@ -606,11 +709,18 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 		ChangefeedID:    e.changefeedID.ToPB(),
 		CompeleteStatus: needCompleteStatus,
 		Watermark:       heartbeatpb.NewMaxWatermark(),
+		QuiesceTs:       e.quiescer.HoldTs(),
+		Capacity:        node.GetLocalCapacity(),
+	}
+	if needCompleteStatus || e.reportedStatuses == nil {
+		// rebuild the reported statuses to forget the dispatchers removed by other paths.
+		e.reportedStatuses = make(map[common.DispatcherID]reportedStatus, e.dispatcherMap.Len())
 	}
 
 	toRemoveDispatcherIDs := make([]common.DispatcherID, 0)
 	removedDispatcherSchemaIDs := make([]int64, 0)
 	heartBeatInfo := &dispatcher.HeartBeatInfo{}
+	blockedDispatcherCount := 0
 
 	seq := e.dispatcherMap.ForEach(func(id common.DispatcherID, dispatcherItem *dispatcher.Dispatcher) {
 		dispatcherItem.GetHeartBeatInfo(heartBeatInfo)
@@ -634,13 +744,22 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 		}
 
 		message.Watermark.UpdateMin(heartBeatInfo.Watermark)
-		if needCompleteStatus {
+		if heartBeatInfo.BlockedByBarrier {
+			blockedDispatcherCount++
+		}
+		if needCompleteStatus || e.statusChanged(id, heartBeatInfo) {
 			message.Statuses = append(message.Statuses, &heartbeatpb.TableSpanStatus{
 				ID:                 id.ToPB(),
 				ComponentStatus:    heartBeatInfo.ComponentStatus,
 				CheckpointTs:       heartBeatInfo.Watermark.CheckpointTs,
 				EventSizePerSecond: dispatcherItem.GetEventSizePerSecond(),
+				SampledKeys:        dispatcherItem.GetSampledKeys(),
 			})
+			e.reportedStatuses[id] = reportedStatus{
+				state:            heartBeatInfo.ComponentStatus,
+				checkpointTs:     heartBeatInfo.Watermark.CheckpointTs,
+				blockedByBarrier: heartBeatInfo.BlockedByBarrier,
+			}
 		}
 	})
 	message.Watermark.Seq = seq
@@ -650,11 +769,20 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 	if !e.closing.Load() {
 		for idx, id := range toRemoveDispatcherIDs {
 			e.cleanDispatcher(id, removedDispatcherSchemaIDs[idx])
+			delete(e.reportedStatuses, id)
 		}
 	}
+	metrics.DispatcherHeartbeatStatusCount.WithLabelValues(
+		e.changefeedID.Namespace(), e.changefeedID.Name(), heartbeatStatusType(needCompleteStatus),
+	).Add(float64(len(message.Statuses)))
 
 	e.metricCheckpointTs.Set(float64(message.Watermark.CheckpointTs))
 	e.metricResolvedTs.Set(float64(message.Watermark.ResolvedTs))
+	e.metricBlockedDispatcherCount.Set(float64(blockedDispatcherCount))
+	// the watermark is max value if there is no dispatcher in the node.
+	if message.Watermark.CheckpointTs != math.MaxUint64 {
+		e.sink.AuditRowCount(message.Watermark.CheckpointTs)
+	}
 
 	phyCheckpointTs := oracle.ExtractPhysical(message.Watermark.CheckpointTs)
 	phyResolvedTs := oracle.ExtractPhysical(message.Watermark.ResolvedTs)
@@ -665,11 +793,160 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 	return &message
 }
 
-func (e *EventDispatcherManager) removeDispatcher(id common.DispatcherID) {
+// reportedStatus is the status of a dispatcher reported to the maintainer.
+type reportedStatus struct {
+	state            heartbeatpb.ComponentState
+	checkpointTs     uint64
+	blockedByBarrier bool
+}
+
+// statusDeltaCheckpointThreshold is how far the checkpoint of a dispatcher must advance
+// before it's reported again in a heartbeat without the complete statuses. It's less than
+// completeStatusResyncInterval, so the checkpoints known by the maintainer lag behind
+// by the threshold at most.
+const statusDeltaCheckpointThreshold = 5 * time.Second
+
+// statusChanged returns true if the status of the dispatcher should be reported in a heartbeat
+// without the complete statuses, that is the dispatcher is new, its state is changed, it's
+// blocked by or passes a barrier, or its checkpoint advances beyond the threshold since the
+// last report. The checkpoint of a dispatcher passing a barrier is reported at once, as the
+// maintainer checks it to find the dispatchers which have passed the barrier.
+func (e *EventDispatcherManager) statusChanged(id common.DispatcherID, info *dispatcher.HeartBeatInfo) bool {
+	last, ok := e.reportedStatuses[id]
+	if !ok || last.state != info.ComponentStatus {
+		return true
+	}
+	if info.Watermark.CheckpointTs == last.checkpointTs {
+		return false
+	}
+	if last.blockedByBarrier != info.BlockedByBarrier {
+		return true
+	}
+	advanced := oracle.ExtractPhysical(info.Watermark.CheckpointTs) - oracle.ExtractPhysical(last.checkpointTs)
+	return advanced >= statusDeltaCheckpointThreshold.Milliseconds()
+}
+
+func heartbeatStatusType(complete bool) string {
+	if complete {
+		return "complete"
+	}
+	return "delta"
+}
+
+// Drain removes all the dispatchers after the events in the sink are flushed, and reports their
+// final checkpoints to the maintainer in a single heartbeat, so the maintainer can reschedule them
+// at once instead of waiting for the node to be expired. If the sink can't be flushed before ctx
+// is done, the maintainer waits for the node to be expired to reschedule the dispatchers.
+func (e *EventDispatcherManager) Drain(ctx context.Context) {
+	e.draining.Store(true)
+	log.Info("draining event dispatcher manager", zap.Stringer("changefeedID", e.changefeedID))
+
+	dispatchers := make([]drainingDispatcher, 0, e.dispatcherMap.Len())
+	e.dispatcherMap.ForEach(func(id common.DispatcherID, d *dispatcher.Dispatcher) {
+		dispatchers = append(dispatchers, d)
+	})
+	for _, d := range dispatchers {
+		e.removeDispatcher(d.GetId())
+	}
+
+	message := e.newDrainedHeartbeat(ctx, dispatchers)
+	e.heartbeatRequestQueue.Enqueue(&HeartBeatRequestWithTargetID{TargetID: e.GetMaintainerID(), Request: message})
+	log.Info("event dispatcher manager drained",
+		zap.Stringer("changefeedID", e.changefeedID),
+		zap.Int("dispatcherCount", len(dispatchers)),
+		zap.Int("closedCount", len(message.Statuses)),
+		zap.Uint64("checkpointTs", message.Watermark.CheckpointTs))
+}
+
+// drainingDispatcher is the dispatcher being closed by the draining.
+type drainingDispatcher interface {
+	GetId() common.DispatcherID
+	TryClose() (heartbeatpb.Watermark, bool)
+	GetCheckpointTs() uint64
+	GetResolvedTs() uint64
+}
+
+// newDrainedHeartbeat waits for the dispatchers to be closed and builds the heartbeat reporting them.
+// Only the closed dispatchers are reported stopped. The sink of a dispatcher not closed before ctx
+// is done may be still flushing, so the node is not reported stopping, otherwise the maintainer would
+// reschedule the span while the old sink writes it.
+func (e *EventDispatcherManager) newDrainedHeartbeat(
+	ctx context.Context, dispatchers []drainingDispatcher,
+) *heartbeatpb.HeartBeatRequest {
+	message := &heartbeatpb.HeartBeatRequest{
+		ChangefeedID:    e.changefeedID.ToPB(),
+		Watermark:       heartbeatpb.NewMaxWatermark(),
+		CompeleteStatus: true,
+	}
+	allClosed := true
+	for _, d := range dispatchers {
+		watermark, closed := waitDispatcherClosed(ctx, d)
+		message.Watermark.UpdateMin(watermark)
+		if !closed {
+			allClosed = false
+			continue
+		}
+		message.Statuses = append(message.Statuses, &heartbeatpb.TableSpanStatus{
+			ID:              d.GetId().ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Stopped,
+			CheckpointTs:    watermark.CheckpointTs,
+		})
+	}
+	// the maintainer which does not support it waits for the node to be removed.
+	message.NodeStopping = allClosed && e.MaintainerSupports(heartbeatpb.CapabilityNodeStopping)
+	message.Watermark.Seq = e.dispatcherMap.GetSeq()
+	return message
+}
+
+// waitDispatcherClosed waits until the events of the dispatcher in the sink are flushed,
+// it returns the watermark the dispatcher has reached and false if ctx is done before that.
+func waitDispatcherClosed(ctx context.Context, d drainingDispatcher) (heartbeatpb.Watermark, bool) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if watermark, ok := d.TryClose(); ok {
+			return watermark, true
+		}
+		select {
+		case <-ctx.Done():
+			log.Warn("dispatcher is not flushed before draining timeout",
+				zap.Stringer("dispatcherID", d.GetId()), zap.Error(ctx.Err()))
+			return heartbeatpb.Watermark{
+				CheckpointTs: d.GetCheckpointTs(),
+				ResolvedTs:   d.GetResolvedTs(),
+			}, false
+		case <-ticker.C:
+		}
+	}
+}
+
+// removeDispatchers removes a batch of dispatchers, the stopped status of the
+// dispatchers which don't exist is reported in a single heartbeat.
+func (e *EventDispatcherManager) removeDispatchers(ids []common.DispatcherID) {
+	statuses := make([]TableSpanStatusWithSeq, 0)
+	for _, id := range ids {
+		if !e.removeDispatcher(id) {
+			statuses = append(statuses, TableSpanStatusWithSeq{
+				TableSpanStatus: &heartbeatpb.TableSpanStatus{
+					ID:              id.ToPB(),
+					ComponentStatus: heartbeatpb.ComponentState_Stopped,
+				},
+				Seq: e.dispatcherMap.GetSeq(),
+			})
+		}
+	}
+	if len(statuses) > 0 {
+		e.statusesChan <- statuses
+	}
+}
+
+// removeDispatcher starts removing the dispatcher, it returns false if the dispatcher doesn't exist.
+// The removed dispatcher reports the stopped status when it's closed.
+func (e *EventDispatcherManager) removeDispatcher(id common.DispatcherID) bool {
 	dispatcher, ok := e.dispatcherMap.Get(id)
 	if ok {
 		if dispatcher.GetRemovingStatus() {
-			return
+			return true
 		}
 		appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).RemoveDispatcher(dispatcher)
 
@@ -680,19 +957,16 @@ func (e *EventDispatcherManager) removeDispatcher(id common.DispatcherID) {
 		}
 
 		dispatcher.Remove()
-	} else {
-		e.statusesChan <- TableSpanStatusWithSeq{
-			TableSpanStatus: &heartbeatpb.TableSpanStatus{
-				ID:              id.ToPB(),
-				ComponentStatus: heartbeatpb.ComponentState_Stopped,
-			},
-			Seq: e.dispatcherMap.GetSeq(),
-		}
+		return true
 	}
+	return false
 }
 
 // cleanDispatcher is called when the dispatcher is removed successfully.
 func (e *EventDispatcherManager) cleanDispatcher(id common.DispatcherID, schemaID int64) {
+	if d, ok := e.dispatcherMap.Get(id); ok {
+		defer e.cleanTableMetrics(d.GetTableSpan().TableID)
+	}
 	e.dispatcherMap.Delete(id)
 	e.schemaIDToDispatchers.Delete(schemaID, id)
 	if e.tableTriggerEventDispatcher != nil && e.tableTriggerEventDispatcher.GetId() == id {
@@ -700,11 +974,27 @@ func (e *EventDispatcherManager) cleanDispatcher(id common.DispatcherID, schemaI
 		e.metricTableTriggerEventDispatcherCount.Dec()
 	} else {
 		e.metricEventDispatcherCount.Dec()
+		e.dispatcherCount.Add(-1)
+		node.AddDispatcherCount(-1)
 	}
 	log.Info("table event dispatcher completely stopped, and delete it from event dispatcher manager",
 		zap.Any("dispatcherID", id))
 }
 
+// cleanTableMetrics deletes the metrics of the table if no dispatcher of it is left in the manager.
+func (e *EventDispatcherManager) cleanTableMetrics(tableID int64) {
+	exist := false
+	e.dispatcherMap.ForEach(func(_ common.DispatcherID, d *dispatcher.Dispatcher) {
+		if d.GetTableSpan().TableID == tableID {
+			exist = true
+		}
+	})
+	if !exist {
+		metrics.DispatcherTableFlushLagDuration.DeleteLabelValues(
+			e.changefeedID.Namespace(), e.changefeedID.Name(), strconv.FormatInt(tableID, 10))
+	}
+}
+
 func (e *EventDispatcherManager) GetDispatcherMap() *DispatcherMap {
 	return e.dispatcherMap
 }
@@ -717,6 +1007,49 @@ func (e *EventDispatcherManager) SetMaintainerID(maintainerID node.ID) {
 	e.maintainerID = maintainerID
 }
 
+// CheckMaintainerEpoch returns false if the message is sent by a stale maintainer, whose epoch
+// is less than the largest one the manager has seen, the larger epoch is recorded otherwise.
+// The message with epoch 0 is sent by a maintainer which is not fenced, it's always accepted.
+func (e *EventDispatcherManager) CheckMaintainerEpoch(epoch uint64) bool {
+	if epoch == 0 {
+		return true
+	}
+	for {
+		current := e.maintainerEpoch.Load()
+		if epoch < current {
+			log.Warn("ignore the message of a stale maintainer",
+				zap.Stringer("changefeedID", e.changefeedID),
+				zap.Uint64("epoch", epoch),
+				zap.Uint64("currentEpoch", current))
+			return false
+		}
+		if epoch == current || e.maintainerEpoch.CompareAndSwap(current, epoch) {
+			return true
+		}
+	}
+}
+
+// SetMaintainerCapabilities sets the capabilities negotiated with the maintainer.
+func (e *EventDispatcherManager) SetMaintainerCapabilities(capabilities heartbeatpb.Capabilities) {
+	e.maintainerCapabilities.Store(&capabilities)
+}
+
+// MaintainerSupports returns true if the capability is supported by the maintainer.
+func (e *EventDispatcherManager) MaintainerSupports(capability string) bool {
+	capabilities := e.maintainerCapabilities.Load()
+	return capabilities != nil && capabilities.Has(capability)
+}
+
+// SetQuiesceTs holds the dispatchers at the ts, they are released if the ts is 0.
+func (e *EventDispatcherManager) SetQuiesceTs(ts uint64) {
+	e.quiescer.Hold(ts)
+}
+
+// ResendTableSchema asks the sink to resend the schema of the tables.
+func (e *EventDispatcherManager) ResendTableSchema(tableIDs []int64) {
+	e.sink.ResendTableSchema(tableIDs)
+}
+
 func (e *EventDispatcherManager) GetTableTriggerEventDispatcher() *dispatcher.Dispatcher {
 	return e.tableTriggerEventDispatcher
 }
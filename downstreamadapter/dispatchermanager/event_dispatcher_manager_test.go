@@ -0,0 +1,120 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatchermanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestCompleteStatusTicks(t *testing.T) {
+	require.Equal(t, 10, completeStatusTicks(time.Second))
+	require.Equal(t, 20, completeStatusTicks(500*time.Millisecond))
+	// the complete statuses are reported in every heartbeat if the heartbeats are sparse.
+	require.Equal(t, 1, completeStatusTicks(time.Minute))
+}
+
+func TestStatusChanged(t *testing.T) {
+	e := &EventDispatcherManager{reportedStatuses: make(map[common.DispatcherID]reportedStatus)}
+	id := common.NewDispatcherID()
+	now := time.Now()
+	info := &dispatcher.HeartBeatInfo{ComponentStatus: heartbeatpb.ComponentState_Working}
+	info.Watermark.CheckpointTs = oracle.GoTimeToTS(now)
+
+	// a new dispatcher is reported
+	require.True(t, e.statusChanged(id, info))
+	e.reportedStatuses[id] = reportedStatus{
+		state:        info.ComponentStatus,
+		checkpointTs: info.Watermark.CheckpointTs,
+	}
+	require.False(t, e.statusChanged(id, info))
+
+	// the checkpoint advances within the threshold
+	info.Watermark.CheckpointTs = oracle.GoTimeToTS(now.Add(statusDeltaCheckpointThreshold / 2))
+	require.False(t, e.statusChanged(id, info))
+	// the checkpoint advances beyond the threshold
+	info.Watermark.CheckpointTs = oracle.GoTimeToTS(now.Add(statusDeltaCheckpointThreshold))
+	require.True(t, e.statusChanged(id, info))
+
+	// the state changes
+	info.Watermark.CheckpointTs = e.reportedStatuses[id].checkpointTs
+	info.ComponentStatus = heartbeatpb.ComponentState_Stopped
+	require.True(t, e.statusChanged(id, info))
+	info.ComponentStatus = heartbeatpb.ComponentState_Working
+
+	// the dispatcher passes the barrier, its checkpoint is reported at once
+	e.reportedStatuses[id] = reportedStatus{
+		state:            info.ComponentStatus,
+		checkpointTs:     info.Watermark.CheckpointTs,
+		blockedByBarrier: true,
+	}
+	require.False(t, e.statusChanged(id, info))
+	info.Watermark.CheckpointTs++
+	require.True(t, e.statusChanged(id, info))
+	require.Less(t, statusDeltaCheckpointThreshold, completeStatusResyncInterval)
+}
+
+type mockDrainingDispatcher struct {
+	id           common.DispatcherID
+	flushed      bool
+	checkpointTs uint64
+}
+
+func (d *mockDrainingDispatcher) GetId() common.DispatcherID { return d.id }
+
+func (d *mockDrainingDispatcher) TryClose() (heartbeatpb.Watermark, bool) {
+	if !d.flushed {
+		return heartbeatpb.Watermark{}, false
+	}
+	return heartbeatpb.Watermark{CheckpointTs: d.checkpointTs, ResolvedTs: d.checkpointTs}, true
+}
+
+func (d *mockDrainingDispatcher) GetCheckpointTs() uint64 { return d.checkpointTs }
+
+func (d *mockDrainingDispatcher) GetResolvedTs() uint64 { return d.checkpointTs }
+
+func TestDrainedHeartbeat(t *testing.T) {
+	e := &EventDispatcherManager{
+		changefeedID:  common.NewChangeFeedIDWithName("test"),
+		dispatcherMap: newDispatcherMap(),
+	}
+	e.SetMaintainerCapabilities(heartbeatpb.NegotiateCapabilities(1, heartbeatpb.LocalCapabilities()))
+	flushed := &mockDrainingDispatcher{id: common.NewDispatcherID(), flushed: true, checkpointTs: 100}
+	flushing := &mockDrainingDispatcher{id: common.NewDispatcherID(), checkpointTs: 90}
+
+	// all the dispatchers are closed, the node is reported stopping
+	message := e.newDrainedHeartbeat(context.Background(), []drainingDispatcher{flushed})
+	require.True(t, message.NodeStopping)
+	require.Len(t, message.Statuses, 1)
+	require.Equal(t, heartbeatpb.ComponentState_Stopped, message.Statuses[0].ComponentStatus)
+	require.Equal(t, uint64(100), message.Statuses[0].CheckpointTs)
+	require.Equal(t, uint64(100), message.Watermark.CheckpointTs)
+
+	// the timeout fires before a dispatcher is flushed, it's not reported stopped
+	// and the maintainer waits for the node to be expired
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	message = e.newDrainedHeartbeat(ctx, []drainingDispatcher{flushed, flushing})
+	require.False(t, message.NodeStopping)
+	require.Len(t, message.Statuses, 1)
+	require.Equal(t, flushed.id.ToPB(), message.Statuses[0].ID)
+	require.Equal(t, uint64(90), message.Watermark.CheckpointTs)
+}
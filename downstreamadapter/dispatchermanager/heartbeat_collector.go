@@ -39,6 +39,8 @@ Recieve messages include:
  1. HeartBeatResponse: the ack and actions for block events(Need a better name)
  2. SchedulerDispatcherRequest: ask for create or remove a dispatcher
  3. CheckpointTsMessage: the latest checkpoint ts of the changefeed, it only for the MQ-class Sink
+ 4. QuiesceRequest: the ts the dispatchers of the changefeed are held at
+ 5. ResendTableSchemaRequest: ask the sink to resend the schema of the tables
 
 HeartBeatCollector is an instance-level component.
 */
@@ -53,6 +55,9 @@ type HeartBeatCollector struct {
 	schedulerDispatcherRequestDynamicStream dynstream.DynamicStream[int, common.GID, SchedulerDispatcherRequest, *EventDispatcherManager, *SchedulerDispatcherRequestHandler]
 	checkpointTsMessageDynamicStream        dynstream.DynamicStream[int, common.GID, CheckpointTsMessage, *EventDispatcherManager, *CheckpointTsMessageHandler]
 
+	// managers is the registered event dispatcher managers, common.GID -> *EventDispatcherManager
+	managers sync.Map
+
 	mc messaging.MessageCenter
 }
 
@@ -93,6 +98,7 @@ func (c *HeartBeatCollector) RegisterEventDispatcherManager(m *EventDispatcherMa
 	if err != nil {
 		return errors.Trace(err)
 	}
+	c.managers.Store(m.changefeedID.Id, m)
 	return nil
 }
 
@@ -102,6 +108,7 @@ func (c *HeartBeatCollector) RegisterCheckpointTsMessageDs(m *EventDispatcherMan
 }
 
 func (c *HeartBeatCollector) RemoveEventDispatcherManager(m *EventDispatcherManager) error {
+	c.managers.Delete(m.changefeedID.Id)
 	err := c.heartBeatResponseDynamicStream.RemovePath(m.changefeedID.Id)
 	if err != nil {
 		return errors.Trace(err)
@@ -156,19 +163,46 @@ func (c *HeartBeatCollector) RecvMessages(_ context.Context, msg *messaging.Targ
 		heartBeatResponseDynamicStream := GetHeartBeatResponseDynamicStream()
 		heartBeatResponseDynamicStream.Push(
 			common.NewChangefeedGIDFromPB(heartbeatResponse.ChangefeedID),
-			NewHeartBeatResponse(heartbeatResponse))
+			NewHeartBeatResponse(heartbeatResponse, msg.TraceContext))
 	case messaging.TypeScheduleDispatcherRequest:
-		schedulerDispatcherRequest := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
-		c.schedulerDispatcherRequestDynamicStream.Push(
-			common.NewChangefeedGIDFromPB(schedulerDispatcherRequest.ChangefeedID),
-			NewSchedulerDispatcherRequest(schedulerDispatcherRequest))
-		// TODO: check metrics
-		metrics.HandleDispatcherRequsetCounter.WithLabelValues("default", schedulerDispatcherRequest.ChangefeedID.Name, "receive").Inc()
+		// the maintainer batches the requests to the same node in one message
+		for _, m := range msg.Message {
+			schedulerDispatcherRequest := m.(*heartbeatpb.ScheduleDispatcherRequest)
+			c.schedulerDispatcherRequestDynamicStream.Push(
+				common.NewChangefeedGIDFromPB(schedulerDispatcherRequest.ChangefeedID),
+				NewSchedulerDispatcherRequest(schedulerDispatcherRequest))
+			// TODO: check metrics
+			metrics.HandleDispatcherRequsetCounter.WithLabelValues("default", schedulerDispatcherRequest.ChangefeedID.Name, "receive").Inc()
+		}
 	case messaging.TypeCheckpointTsMessage:
 		checkpointTsMessage := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
 		c.checkpointTsMessageDynamicStream.Push(
 			common.NewChangefeedIDFromPB(checkpointTsMessage.ChangefeedID).Id,
-			NewCheckpointTsMessage(checkpointTsMessage))
+			NewCheckpointTsMessage(checkpointTsMessage, msg.TraceContext))
+	case messaging.TypeQuiesceRequest:
+		req := msg.Message[0].(*heartbeatpb.QuiesceRequest)
+		m, ok := c.managers.Load(common.NewChangefeedGIDFromPB(req.ChangefeedID))
+		if !ok {
+			log.Warn("event dispatcher manager not found, ignore the quiesce request",
+				zap.String("changefeed", req.ChangefeedID.Name))
+			return nil
+		}
+		manager := m.(*EventDispatcherManager)
+		if manager.CheckMaintainerEpoch(req.MaintainerEpoch) {
+			manager.SetQuiesceTs(req.HoldTs)
+		}
+	case messaging.TypeResendTableSchemaRequest:
+		req := msg.Message[0].(*heartbeatpb.ResendTableSchemaRequest)
+		m, ok := c.managers.Load(common.NewChangefeedGIDFromPB(req.ChangefeedID))
+		if !ok {
+			log.Warn("event dispatcher manager not found, ignore the resend table schema request",
+				zap.String("changefeed", req.ChangefeedID.Name))
+			return nil
+		}
+		manager := m.(*EventDispatcherManager)
+		if manager.CheckMaintainerEpoch(req.MaintainerEpoch) {
+			manager.ResendTableSchema(req.TableIds)
+		}
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -23,8 +23,10 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/tracing"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/ticdc/utils/threadpool"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -104,10 +106,12 @@ func (d *DispatcherMap) ForEach(fn func(id common.DispatcherID, dispatcher *disp
 	return seq
 }
 
-func toFilterConfigPB(filter *config.FilterConfig) *eventpb.FilterConfig {
+func toFilterConfigPB(filter *config.FilterConfig, caseSensitive bool, timezone string) *eventpb.FilterConfig {
 	filterConfig := &eventpb.FilterConfig{
 		Rules:            filter.Rules,
 		IgnoreTxnStartTs: filter.IgnoreTxnStartTs,
+		CaseSensitive:    caseSensitive,
+		TimeZone:         timezone,
 		EventFilters:     make([]*eventpb.EventFilterRule, len(filter.EventFilters)),
 	}
 
@@ -162,6 +166,15 @@ func (w *Watermark) Set(watermark *heartbeatpb.Watermark) {
 	w.Watermark = watermark
 }
 
+// completeStatusResyncInterval is the interval the statuses of all the dispatchers are reported
+// to the maintainer, to resync the statuses which may be lost or changed by other paths.
+const completeStatusResyncInterval = 10 * time.Second
+
+// completeStatusTicks returns every how many heartbeats the complete statuses are reported.
+func completeStatusTicks(executeInterval time.Duration) int {
+	return max(1, int(completeStatusResyncInterval/executeInterval))
+}
+
 // HeartbeatTask is a perioic task to collect the heartbeat status from event dispatcher manager and push to heartbeatRequestQueue
 type HeartBeatTask struct {
 	taskHandle *threadpool.TaskHandle
@@ -184,10 +197,10 @@ func (t *HeartBeatTask) Execute() time.Time {
 	if t.manager.closed.Load() {
 		return time.Time{}
 	}
-	executeInterval := time.Millisecond * 200
-	completeStatusInterval := int(time.Second * 10 / executeInterval)
+	executeInterval := t.manager.config.LatencyMode.Profile().HeartbeatInterval
+	// the heartbeats between two complete statuses only carry the changed statuses.
 	t.statusTick++
-	needCompleteStatus := (t.statusTick)%completeStatusInterval == 0
+	needCompleteStatus := t.statusTick%completeStatusTicks(executeInterval) == 0
 	message := t.manager.aggregateDispatcherHeartbeats(needCompleteStatus)
 	t.manager.heartbeatRequestQueue.Enqueue(&HeartBeatRequestWithTargetID{TargetID: t.manager.GetMaintainerID(), Request: message})
 	return time.Now().Add(executeInterval)
@@ -250,13 +263,18 @@ func (h *SchedulerDispatcherRequestHandler) Path(scheduleDispatcherRequest Sched
 }
 
 func (h *SchedulerDispatcherRequestHandler) Handle(eventDispatcherManager *EventDispatcherManager, reqs ...SchedulerDispatcherRequest) bool {
-	// If req is about remove dispatcher, then there will only be one request in reqs.
+	// The requests in reqs have the same schedule action, since create and remove
+	// requests are in different data groups.
 	infos := make([]dispatcherCreateInfo, 0, len(reqs))
+	removed := make([]common.DispatcherID, 0)
 	for _, req := range reqs {
 		if req.ScheduleDispatcherRequest == nil {
 			log.Warn("scheduleDispatcherRequest is nil, skip")
 			continue
 		}
+		if !eventDispatcherManager.CheckMaintainerEpoch(req.MaintainerEpoch) {
+			continue
+		}
 		config := req.Config
 		dispatcherID := common.NewDispatcherIDFromPB(config.DispatcherID)
 		switch req.ScheduleAction {
@@ -269,12 +287,12 @@ func (h *SchedulerDispatcherRequestHandler) Handle(eventDispatcherManager *Event
 				CurrentPDTs: config.CurrentPdTs,
 			})
 		case heartbeatpb.ScheduleAction_Remove:
-			if len(reqs) != 1 {
-				log.Error("invalid remove dispatcher request count in one batch", zap.Int("count", len(reqs)))
-			}
-			eventDispatcherManager.removeDispatcher(dispatcherID)
+			removed = append(removed, dispatcherID)
 		}
 	}
+	if len(removed) > 0 {
+		eventDispatcherManager.removeDispatchers(removed)
+	}
 	if len(infos) > 0 {
 		err := eventDispatcherManager.newDispatchers(infos, false)
 		if err != nil {
@@ -304,12 +322,13 @@ func (h *SchedulerDispatcherRequestHandler) GetTimestamp(event SchedulerDispatch
 }
 
 func (h *SchedulerDispatcherRequestHandler) GetType(event SchedulerDispatcherRequest) dynstream.EventType {
-	// we do batch for create dispatcher now.
+	// we do batch for create and remove dispatcher, the requests are batched
+	// by the maintainer in one message to reduce the message count.
 	switch event.ScheduleAction {
 	case heartbeatpb.ScheduleAction_Create:
 		return dynstream.EventType{DataGroup: 1, Property: dynstream.BatchableData}
 	case heartbeatpb.ScheduleAction_Remove:
-		return dynstream.EventType{DataGroup: 2, Property: dynstream.NonBatchable}
+		return dynstream.EventType{DataGroup: 2, Property: dynstream.BatchableData}
 	default:
 		log.Panic("unknown schedule action", zap.Int("action", int(event.ScheduleAction)))
 	}
@@ -336,10 +355,12 @@ func GetHeartBeatResponseDynamicStream() dynstream.DynamicStream[int, common.GID
 
 type HeartBeatResponse struct {
 	*heartbeatpb.HeartBeatResponse
+	// traceContext is the trace context carried by the message from the maintainer.
+	traceContext map[string]string
 }
 
-func NewHeartBeatResponse(resp *heartbeatpb.HeartBeatResponse) HeartBeatResponse {
-	return HeartBeatResponse{resp}
+func NewHeartBeatResponse(resp *heartbeatpb.HeartBeatResponse, traceContext map[string]string) HeartBeatResponse {
+	return HeartBeatResponse{HeartBeatResponse: resp, traceContext: traceContext}
 }
 
 func SetHeartBeatResponseDynamicStream(dynamicStream dynstream.DynamicStream[int, common.GID, HeartBeatResponse, *EventDispatcherManager, *HeartBeatResponseHandler]) {
@@ -364,6 +385,9 @@ func (h *HeartBeatResponseHandler) Handle(eventDispatcherManager *EventDispatche
 		panic("invalid response count")
 	}
 	heartbeatResponse := resps[0]
+	if !eventDispatcherManager.CheckMaintainerEpoch(heartbeatResponse.MaintainerEpoch) {
+		return false
+	}
 	dispatcherStatuses := heartbeatResponse.GetDispatcherStatuses()
 	for _, dispatcherStatus := range dispatcherStatuses {
 		influencedDispatchersType := dispatcherStatus.InfluencedDispatchers.InfluenceType
@@ -373,7 +397,7 @@ func (h *HeartBeatResponseHandler) Handle(eventDispatcherManager *EventDispatche
 				dispId := common.NewDispatcherIDFromPB(dispatcherID)
 				h.dispatcherStatusDynamicStream.Push(
 					dispId,
-					dispatcher.NewDispatcherStatusWithID(dispatcherStatus, dispId))
+					dispatcher.NewDispatcherStatusWithID(dispatcherStatus, heartbeatResponse.traceContext, dispId))
 			}
 		case heartbeatpb.InfluenceType_DB:
 			schemaID := dispatcherStatus.InfluencedDispatchers.SchemaID
@@ -381,18 +405,23 @@ func (h *HeartBeatResponseHandler) Handle(eventDispatcherManager *EventDispatche
 			dispatcherIds := eventDispatcherManager.GetAllDispatchers(schemaID)
 			for _, id := range dispatcherIds {
 				if id != excludeDispatcherID {
-					h.dispatcherStatusDynamicStream.Push(id, dispatcher.NewDispatcherStatusWithID(dispatcherStatus, id))
+					h.dispatcherStatusDynamicStream.Push(id, dispatcher.NewDispatcherStatusWithID(dispatcherStatus, heartbeatResponse.traceContext, id))
 				}
 			}
 		case heartbeatpb.InfluenceType_All:
 			excludeDispatcherID := common.NewDispatcherIDFromPB(dispatcherStatus.InfluencedDispatchers.ExcludeDispatcherId)
 			eventDispatcherManager.GetDispatcherMap().ForEach(func(id common.DispatcherID, _ *dispatcher.Dispatcher) {
 				if id != excludeDispatcherID {
-					h.dispatcherStatusDynamicStream.Push(id, dispatcher.NewDispatcherStatusWithID(dispatcherStatus, id))
+					h.dispatcherStatusDynamicStream.Push(id, dispatcher.NewDispatcherStatusWithID(dispatcherStatus, heartbeatResponse.traceContext, id))
 				}
 			})
 		}
 	}
+	for _, state := range heartbeatResponse.GetBarrierStates() {
+		if d, ok := eventDispatcherManager.GetDispatcherMap().Get(common.NewDispatcherIDFromPB(state.DispatcherID)); ok {
+			d.HandleBarrierState(state)
+		}
+	}
 	return false
 }
 
@@ -429,10 +458,12 @@ func GetCheckpointTsMessageDynamicStream() dynstream.DynamicStream[int, common.G
 
 type CheckpointTsMessage struct {
 	*heartbeatpb.CheckpointTsMessage
+	// traceContext is the trace context carried by the message from the maintainer.
+	traceContext map[string]string
 }
 
-func NewCheckpointTsMessage(msg *heartbeatpb.CheckpointTsMessage) CheckpointTsMessage {
-	return CheckpointTsMessage{msg}
+func NewCheckpointTsMessage(msg *heartbeatpb.CheckpointTsMessage, traceContext map[string]string) CheckpointTsMessage {
+	return CheckpointTsMessage{CheckpointTsMessage: msg, traceContext: traceContext}
 }
 
 type CheckpointTsMessageHandler struct{}
@@ -452,7 +483,10 @@ func (h *CheckpointTsMessageHandler) Handle(eventDispatcherManager *EventDispatc
 	}
 	checkpointTsMessage := messages[0]
 	if eventDispatcherManager.tableTriggerEventDispatcher != nil {
+		_, span := tracing.StartFromRemote(checkpointTsMessage.traceContext, "dispatcher.HandleCheckpointTs",
+			attribute.Int64("checkpointTs", int64(checkpointTsMessage.CheckpointTs)))
 		eventDispatcherManager.tableTriggerEventDispatcher.HandleCheckpointTs(checkpointTsMessage.CheckpointTs)
+		span.End()
 	}
 	return false
 }
@@ -16,6 +16,7 @@ package dispatcherorchestrator
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/pingcap/log"
@@ -35,7 +36,9 @@ import (
 // DispatcherOrchestrator coordinates the creation, deletion, and management of event dispatcher managers
 // for different change feeds based on maintainer bootstrap messages.
 type DispatcherOrchestrator struct {
-	mc                 messaging.MessageCenter
+	mc messaging.MessageCenter
+	// mutex protects dispatcherManagers, which is also read when the node is drained.
+	mutex              sync.Mutex
 	dispatcherManagers map[common.ChangeFeedID]*dispatchermanager.EventDispatcherManager
 }
 
@@ -49,6 +52,9 @@ func New() *DispatcherOrchestrator {
 }
 
 func (m *DispatcherOrchestrator) RecvMaintainerRequest(_ context.Context, msg *messaging.TargetMessage) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	switch req := msg.Message[0].(type) {
 	case *heartbeatpb.MaintainerBootstrapRequest:
 		return m.handleAddDispatcherManager(msg.From, req)
@@ -73,6 +79,9 @@ func (m *DispatcherOrchestrator) handlePostBootstrap(from node.ID, req *heartbea
 			zap.Any("changefeedID", cfId.Name()))
 		return nil
 	}
+	if !manager.CheckMaintainerEpoch(req.MaintainerEpoch) {
+		return nil
+	}
 	if manager.GetTableTriggerEventDispatcher().GetId() != common.NewDispatcherIDFromPB(req.TableTriggerEventDispatcherId) {
 		log.Error("Receive post bootstrap request but the table trigger event dispatcher id is not match",
 			zap.Any("changefeedID", cfId.Name()),
@@ -123,6 +132,11 @@ func (m *DispatcherOrchestrator) handleAddDispatcherManager(from node.ID, req *h
 	manager, exists := m.dispatcherManagers[cfId]
 	var err error
 	var startTs uint64
+	// the bootstrap request of a stale maintainer is ignored, otherwise the manager
+	// reports its status to the stale maintainer instead of the new one.
+	if exists && !manager.CheckMaintainerEpoch(req.MaintainerEpoch) {
+		return nil
+	}
 	if !exists {
 		cfConfig := &config.ChangefeedConfig{}
 		if err = json.Unmarshal(req.Config, cfConfig); err != nil {
@@ -147,6 +161,7 @@ func (m *DispatcherOrchestrator) handleAddDispatcherManager(from node.ID, req *h
 			}
 			return m.sendResponse(from, messaging.MaintainerManagerTopic, response)
 		}
+		manager.CheckMaintainerEpoch(req.MaintainerEpoch)
 		m.dispatcherManagers[cfId] = manager
 		metrics.EventDispatcherManagerGauge.WithLabelValues(cfId.Namespace(), cfId.Name()).Inc()
 	} else {
@@ -178,6 +193,18 @@ func (m *DispatcherOrchestrator) handleAddDispatcherManager(from node.ID, req *h
 		log.Info("maintainer changed",
 			zap.String("changefeed", cfId.Name()), zap.String("maintainer", from.String()))
 	}
+	// the maintainer may be moved to a node of another version during the rolling upgrade,
+	// so the capabilities are negotiated in every bootstrap.
+	capabilities := heartbeatpb.NegotiateCapabilities(req.ProtocolVersion, req.Capabilities)
+	manager.SetMaintainerCapabilities(capabilities)
+	if req.ProtocolVersion != heartbeatpb.ProtocolVersion {
+		log.Info("maintainer protocol version mismatch",
+			zap.String("changefeed", cfId.Name()),
+			zap.String("maintainer", from.String()),
+			zap.Uint32("maintainerVersion", req.ProtocolVersion),
+			zap.Uint32("localVersion", heartbeatpb.ProtocolVersion),
+			zap.Strings("capabilities", req.Capabilities))
+	}
 
 	response := createBootstrapResponse(req.ChangefeedID, manager, startTs)
 	return m.sendResponse(from, messaging.MaintainerManagerTopic, response)
@@ -191,6 +218,9 @@ func (m *DispatcherOrchestrator) handleRemoveDispatcherManager(from node.ID, req
 	}
 
 	if manager, ok := m.dispatcherManagers[cfId]; ok {
+		if !manager.CheckMaintainerEpoch(req.MaintainerEpoch) {
+			return nil
+		}
 		if closed := manager.TryClose(req.Removed); closed {
 			delete(m.dispatcherManagers, cfId)
 			metrics.EventDispatcherManagerGauge.WithLabelValues(cfId.Namespace(), cfId.Name()).Dec()
@@ -205,10 +235,36 @@ func (m *DispatcherOrchestrator) handleRemoveDispatcherManager(from node.ID, req
 	return m.sendResponse(from, messaging.MaintainerTopic, response)
 }
 
+// Drain flushes the dispatchers of all changefeeds on this node and reports their final
+// checkpoints to the maintainers. It's called when the node is shutting down gracefully.
+func (m *DispatcherOrchestrator) Drain(ctx context.Context) {
+	m.mutex.Lock()
+	managers := make([]*dispatchermanager.EventDispatcherManager, 0, len(m.dispatcherManagers))
+	for _, manager := range m.dispatcherManagers {
+		managers = append(managers, manager)
+	}
+	m.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, manager := range managers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			manager.Drain(ctx)
+		}()
+	}
+	wg.Wait()
+	log.Info("all dispatcher managers are drained", zap.Int("count", len(managers)))
+}
+
 func createBootstrapResponse(changefeedID *heartbeatpb.ChangefeedID, manager *dispatchermanager.EventDispatcherManager, startTs uint64) *heartbeatpb.MaintainerBootstrapResponse {
 	response := &heartbeatpb.MaintainerBootstrapResponse{
-		ChangefeedID: changefeedID,
-		Spans:        make([]*heartbeatpb.BootstrapTableSpan, 0, manager.GetDispatcherMap().Len()),
+		ChangefeedID:    changefeedID,
+		Spans:           make([]*heartbeatpb.BootstrapTableSpan, 0, manager.GetDispatcherMap().Len()),
+		ProtocolVersion: heartbeatpb.ProtocolVersion,
+		Capabilities:    heartbeatpb.LocalCapabilities(),
+		Capacity:        node.GetLocalCapacity(),
+		Load:            node.GetLocalLoad(),
 	}
 
 	if startTs != 0 {
@@ -309,6 +309,7 @@ func (c *EventCollector) mustSendDispatcherRequest(target node.ID, topic string,
 			TableSpan: req.Dispatcher.GetTableSpan(),
 			StartTs:   req.StartTs,
 			OnlyReuse: req.OnlyUse,
+			ClusterId: req.Dispatcher.GetUpstreamID(),
 		},
 	}
 
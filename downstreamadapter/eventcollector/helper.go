@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/memory"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"go.uber.org/zap"
 )
@@ -28,6 +29,8 @@ func NewEventDynamicStream(collector *EventCollector) dynstream.DynamicStream[co
 	option.UseBuffer = true
 	// Enable memory control for dispatcher events dynamic stream.
 	option.EnableMemoryControl = true
+	// The pending events of the dispatchers are the first to be throttled if the memory is exceeded.
+	option.MemoryAccount = memory.GetGlobalRegistry().Register(memory.ComponentDispatcherQueue, memory.PriorityLow)
 	if option.EnableMemoryControl {
 		log.Info("New EventDynamicStream, memory control is enabled")
 	} else {
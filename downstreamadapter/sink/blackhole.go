@@ -76,6 +76,12 @@ func (s *BlackHoleSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
 func (s *BlackHoleSink) AddCheckpointTs(ts uint64) {
 }
 
+func (s *BlackHoleSink) AuditRowCount(_ uint64) {}
+
+func (s *BlackHoleSink) ResendTableSchema(_ []int64) {}
+
+func (s *BlackHoleSink) WriteEOF() {}
+
 func (s *BlackHoleSink) GetStartTsList(tableIds []int64, startTsList []int64) ([]int64, error) {
 	return []int64{}, nil
 }
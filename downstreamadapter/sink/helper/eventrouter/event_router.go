@@ -27,6 +27,8 @@ import (
 type Rule struct {
 	partitionDispatcher partition.PartitionGenerator
 	topicGenerator      topic.TopicGenerator
+	// protocol is the protocol of the matched tables.
+	protocol config.Protocol
 	tableFilter.Filter
 }
 
@@ -34,6 +36,7 @@ type Rule struct {
 // an event should be dispatched to.
 type EventRouter struct {
 	defaultTopic string
+	protocol     config.Protocol
 	rules        []Rule
 }
 
@@ -61,23 +64,37 @@ func NewEventRouter(sinkConfig *config.SinkConfig, protocol config.Protocol, def
 
 		d := partition.GetPartitionGenerator(ruleConfig.PartitionRule, scheme, ruleConfig.IndexName, ruleConfig.Columns)
 
-		topicGenerator, err := topic.GetTopicGenerator(ruleConfig.TopicRule, defaultTopic, protocol, scheme)
+		ruleProtocol := protocol
+		if ruleConfig.Protocol != "" {
+			ruleProtocol, err = config.ParseSinkProtocolFromString(ruleConfig.Protocol)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+			}
+		}
+		// the topic expression is validated by the protocol of the matched tables
+		topicGenerator, err := topic.GetTopicGenerator(ruleConfig.TopicRule, defaultTopic, ruleProtocol, scheme)
 		if err != nil {
 			return nil, err
 		}
-		rules = append(rules, Rule{partitionDispatcher: d, topicGenerator: topicGenerator, Filter: f})
+		rules = append(rules, Rule{partitionDispatcher: d, topicGenerator: topicGenerator, protocol: ruleProtocol, Filter: f})
 	}
 
 	return &EventRouter{
 		defaultTopic: defaultTopic,
+		protocol:     protocol,
 		rules:        rules,
 	}, nil
 }
 
 // GetTopicForRowChange returns the target topic for row changes.
 func (s *EventRouter) GetTopicForRowChange(tableInfo *common.TableInfo) string {
-	topicGenerator := s.matchTopicGenerator(tableInfo.TableName.Schema, tableInfo.TableName.Table)
-	return topicGenerator.Substitute(tableInfo.TableName.Schema, tableInfo.TableName.Table)
+	return s.GetTopicForTable(tableInfo.TableName.Schema, tableInfo.TableName.Table)
+}
+
+// GetTopicForTable returns the target topic for the table.
+func (s *EventRouter) GetTopicForTable(schema, table string) string {
+	topicGenerator := s.matchTopicGenerator(schema, table)
+	return topicGenerator.Substitute(schema, table)
 }
 
 // GetTopicForDDL returns the target topic for DDL.
@@ -135,6 +152,38 @@ func (s *EventRouter) GetPartitionDispatcher(schema, table string) partition.Par
 	return partitionGenerator
 }
 
+// GetProtocolForTable returns the protocol of the table, it's the protocol of the sink
+// unless it's overridden by the matched dispatch rule.
+func (s *EventRouter) GetProtocolForTable(schema, table string) config.Protocol {
+	for _, rule := range s.rules {
+		if !rule.MatchTable(schema, table) {
+			continue
+		}
+		return rule.protocol
+	}
+	log.Panic("the dispatch rule must cover all tables")
+	return config.ProtocolUnknown
+}
+
+// GetProtocol returns the protocol of the sink.
+func (s *EventRouter) GetProtocol() config.Protocol {
+	return s.protocol
+}
+
+// GetOverrideProtocols returns the protocols overriding the protocol of the sink
+// for some tables, they are deduplicated.
+func (s *EventRouter) GetOverrideProtocols() []config.Protocol {
+	var protocols []config.Protocol
+	seen := map[config.Protocol]bool{s.protocol: true}
+	for _, rule := range s.rules {
+		if !seen[rule.protocol] {
+			seen[rule.protocol] = true
+			protocols = append(protocols, rule.protocol)
+		}
+	}
+	return protocols
+}
+
 // GetDefaultTopic returns the default topic name.
 func (s *EventRouter) GetDefaultTopic() string {
 	return s.defaultTopic
@@ -328,3 +328,39 @@ func TestGetTopicForDDL(t *testing.T) {
 		require.Equal(t, test.expectedTopic, d.GetTopicForDDL(test.ddl))
 	}
 }
+
+func TestProtocolOverride(t *testing.T) {
+	t.Parallel()
+
+	sinkConfig := &config.SinkConfig{
+		DispatchRules: []*config.DispatchRule{
+			{
+				Matcher:   []string{"legacy.*"},
+				TopicRule: "legacy_{table}",
+				Protocol:  "canal-json",
+			},
+			{
+				Matcher:   []string{"legacy_*.*"},
+				TopicRule: "{schema}_legacy",
+				Protocol:  "canal-json",
+			},
+		},
+	}
+	d, err := NewEventRouter(sinkConfig, config.ProtocolOpen, "test", sink.KafkaScheme)
+	require.NoError(t, err)
+
+	require.Equal(t, config.ProtocolOpen, d.GetProtocol())
+	require.Equal(t, []config.Protocol{config.ProtocolCanalJSON}, d.GetOverrideProtocols())
+	require.Equal(t, config.ProtocolCanalJSON, d.GetProtocolForTable("legacy", "t1"))
+	require.Equal(t, config.ProtocolCanalJSON, d.GetProtocolForTable("legacy_1", "t1"))
+	require.Equal(t, config.ProtocolOpen, d.GetProtocolForTable("test", "t1"))
+
+	// the tables of the override protocol are routed to their own topics
+	require.Equal(t, "legacy_t1", d.GetTopicForTable("legacy", "t1"))
+	require.Equal(t, "legacy_1_legacy", d.GetTopicForTable("legacy_1", "t1"))
+	require.Equal(t, "test", d.GetTopicForTable("test", "t1"))
+
+	sinkConfig.DispatchRules[0].Protocol = "unknown"
+	_, err = NewEventRouter(sinkConfig, config.ProtocolOpen, "test", sink.KafkaScheme)
+	require.Error(t, err)
+}
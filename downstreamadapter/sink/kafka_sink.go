@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/url"
 	"sync/atomic"
+	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
@@ -30,10 +31,14 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/kafka"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	putil "github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
+// writeEOFTimeout is the max time to wait for the EOF message sent when the changefeed is removed.
+const writeEOFTimeout = 10 * time.Second
+
 type KafkaSink struct {
 	changefeedID common.ChangeFeedID
 
@@ -46,6 +51,12 @@ type KafkaSink struct {
 	topicManager     topicmanager.TopicManager
 	statistics       *metrics.Statistics
 	metricsCollector kafka.MetricsCollector
+	// auditor is nil if the row count audit is disabled.
+	auditor *rowCountAuditor
+	// router is nil if there is no routing rule.
+	router *util.TableRouter
+	// writeEOFOnRemove broadcasts the EOF message to the topics when the changefeed is removed.
+	writeEOFOnRemove bool
 
 	// isNormal means the sink does not meet error.
 	// if sink is normal, isNormal is 1, otherwise is 0
@@ -57,8 +68,11 @@ func (s *KafkaSink) SinkType() common.SinkType {
 	return common.KafkaSinkType
 }
 
-func verifyKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, uri *url.URL, sinkConfig *config.SinkConfig) error {
-	components, _, err := worker.GetKafkaSinkComponent(ctx, changefeedID, uri, sinkConfig)
+func verifyKafkaSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, uri *url.URL,
+	sinkConfig *config.SinkConfig, timezone string,
+) error {
+	components, _, err := worker.GetKafkaSinkComponent(ctx, changefeedID, uri, sinkConfig, timezone)
 	if components.AdminClient != nil {
 		components.AdminClient.Close()
 	}
@@ -69,9 +83,10 @@ func verifyKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, uri
 }
 
 func newKafkaSink(
-	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL,
+	sinkConfig *config.SinkConfig, timezone string, latencyMode config.LatencyMode,
 ) (*KafkaSink, error) {
-	kafkaComponent, protocol, err := worker.GetKafkaSinkComponent(ctx, changefeedID, sinkURI, sinkConfig)
+	kafkaComponent, protocol, err := worker.GetKafkaSinkComponent(ctx, changefeedID, sinkURI, sinkConfig, timezone)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -98,7 +113,9 @@ func newKafkaSink(
 		kafkaComponent.ColumnSelector,
 		kafkaComponent.EventRouter,
 		kafkaComponent.TopicManager,
-		statistics)
+		statistics,
+		latencyMode,
+		deadLetterTopic(sinkConfig))
 
 	syncProducer, err := kafkaComponent.Factory.SyncProducer()
 	if err != nil {
@@ -111,8 +128,13 @@ func newKafkaSink(
 		ddlProducer,
 		kafkaComponent.Encoder,
 		kafkaComponent.EventRouter,
+		kafkaComponent.TableRouter,
 		kafkaComponent.TopicManager,
-		statistics)
+		statistics,
+		kafkaComponent.DDLTopic,
+		sinkConfig.Watermark)
+	dmlWorker.SetTTLDeletes(sinkConfig.TTLDeletes)
+	ddlWorker.SetProtocolEncoders(kafkaComponent.ProtocolEncoders)
 
 	sink := &KafkaSink{
 		changefeedID:     changefeedID,
@@ -121,9 +143,17 @@ func newKafkaSink(
 		adminClient:      kafkaComponent.AdminClient,
 		topicManager:     kafkaComponent.TopicManager,
 		statistics:       statistics,
+		router:           kafkaComponent.TableRouter,
 		ctx:              ctx,
 		metricsCollector: kafkaComponent.Factory.MetricsCollector(kafkaComponent.AdminClient),
 	}
+	if putil.GetOrZero(sinkConfig.EnableRowCountAudit) {
+		ddlWorker.SetRowCountAuditTopic(putil.GetOrZero(sinkConfig.RowCountAuditTopic))
+		sink.auditor = newRowCountAuditor(changefeedID, ddlWorker.WriteRowCountAudit)
+	}
+	if sinkConfig.RemovalCleanup != nil {
+		sink.writeEOFOnRemove = putil.GetOrZero(sinkConfig.RemovalCleanup.WriteEOFMessage)
+	}
 	return sink, nil
 }
 
@@ -139,6 +169,11 @@ func (s *KafkaSink) Run(ctx context.Context) error {
 		s.metricsCollector.Run(ctx)
 		return nil
 	})
+	if s.auditor != nil {
+		g.Go(func() error {
+			return s.auditor.run(ctx)
+		})
+	}
 	err := g.Wait()
 	atomic.StoreUint32(&s.isNormal, 0)
 	return errors.Trace(err)
@@ -149,6 +184,12 @@ func (s *KafkaSink) IsNormal() bool {
 }
 
 func (s *KafkaSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	if s.router != nil {
+		event = s.router.RouteDMLEvent(event)
+	}
+	if s.auditor != nil {
+		s.auditor.addDMLEvent(event)
+	}
 	s.dmlWorker.AddDMLEvent(event)
 }
 
@@ -164,6 +205,19 @@ func (s *KafkaSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
 			v.PostFlush()
 			return nil
 		}
+		if s.router != nil {
+			routed, err := s.router.RouteDDLEvent(v)
+			if err != nil {
+				atomic.StoreUint32(&s.isNormal, 0)
+				return errors.Trace(err)
+			}
+			if routed == nil {
+				// the ddl is not applied to the merged table
+				v.PostFlush()
+				return nil
+			}
+			v = routed
+		}
 		err := s.ddlWorker.WriteBlockEvent(s.ctx, v)
 		if err != nil {
 			atomic.StoreUint32(&s.isNormal, 0)
@@ -187,10 +241,36 @@ func (s *KafkaSink) AddCheckpointTs(ts uint64) {
 	s.ddlWorker.AddCheckpoint(ts)
 }
 
+func (s *KafkaSink) AuditRowCount(checkpointTs uint64) {
+	if s.auditor != nil {
+		s.auditor.advance(checkpointTs)
+	}
+}
+
+func (s *KafkaSink) ResendTableSchema(tableIDs []int64) {
+	if !s.dmlWorker.ResendTableSchema(tableIDs) {
+		log.Info("the protocol does not send the table schema, ignore the resend request",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()))
+	}
+}
+
 func (s *KafkaSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
 	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
 }
 
+func (s *KafkaSink) WriteEOF() {
+	if !s.writeEOFOnRemove {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), writeEOFTimeout)
+	defer cancel()
+	if err := s.ddlWorker.WriteEOF(ctx); err != nil {
+		log.Warn("write EOF message meet error",
+			zap.String("changefeed", s.changefeedID.String()), zap.Error(err))
+	}
+}
+
 func (s *KafkaSink) Close(_ bool) {
 	s.ddlWorker.Close()
 	s.dmlWorker.Close()
@@ -199,6 +279,14 @@ func (s *KafkaSink) Close(_ bool) {
 	s.statistics.Close()
 }
 
+// deadLetterTopic returns the topic of the dead letter queue, it's empty if the dead letter queue is disabled.
+func deadLetterTopic(sinkConfig *config.SinkConfig) string {
+	if sinkConfig.DeadLetterQueue == nil {
+		return ""
+	}
+	return sinkConfig.DeadLetterQueue.Topic
+}
+
 func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, producer.DDLProducer, error) {
 	ctx := context.Background()
 	changefeedID := common.NewChangefeedID4Test("test", "test")
@@ -237,7 +325,9 @@ func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, producer.DDLProduc
 		kafkaComponent.ColumnSelector,
 		kafkaComponent.EventRouter,
 		kafkaComponent.TopicManager,
-		statistics)
+		statistics,
+		config.LatencyModeBalanced,
+		"")
 
 	ddlMockProducer := producer.NewMockDDLProducer()
 	ddlWorker := worker.NewKafkaDDLWorker(
@@ -246,8 +336,13 @@ func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, producer.DDLProduc
 		ddlMockProducer,
 		kafkaComponent.Encoder,
 		kafkaComponent.EventRouter,
+		kafkaComponent.TableRouter,
 		kafkaComponent.TopicManager,
-		statistics)
+		statistics,
+		kafkaComponent.DDLTopic,
+		nil)
+	dmlWorker.SetTTLDeletes(sinkConfig.TTLDeletes)
+	ddlWorker.SetProtocolEncoders(kafkaComponent.ProtocolEncoders)
 
 	sink := &KafkaSink{
 		changefeedID:     changefeedID,
@@ -0,0 +1,310 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// multiSink fans out the events of a changefeed to multiple targets.
+// The events are pulled and dispatched only once, each target gets its own copy
+// of the event and flushes it independently. The event is regarded as flushed
+// only after all targets have flushed it, so the checkpoint of the changefeed
+// is the minimum of the checkpoints of all targets.
+type multiSink struct {
+	changefeedID common.ChangeFeedID
+	targets      []*sinkTarget
+}
+
+// sinkTarget is one of the targets of the multiSink, it tracks the checkpoint of the target.
+type sinkTarget struct {
+	sink Sink
+	uri  string
+
+	mu sync.Mutex
+	// pending is the number of the unflushed events of each commitTs.
+	pending map[uint64]int
+	// checkpointTs is the checkpoint of the changefeed received by AddCheckpointTs.
+	checkpointTs uint64
+	// flushedTs is the max commitTs of the flushed events.
+	flushedTs uint64
+
+	metricCheckpointTs prometheus.Gauge
+}
+
+// targetConfigs returns the config of each target, the first one is the config of the sink uri.
+func targetConfigs(cfg *config.ChangefeedConfig) []*config.ChangefeedConfig {
+	configs := []*config.ChangefeedConfig{cfg}
+	for _, uri := range cfg.SinkConfig.AdditionalSinkURIs {
+		targetCfg := *cfg
+		targetCfg.SinkURI = uri
+		configs = append(configs, &targetCfg)
+	}
+	return configs
+}
+
+func newMultiSink(ctx context.Context, cfg *config.ChangefeedConfig, changefeedID common.ChangeFeedID) (*multiSink, error) {
+	s := &multiSink{changefeedID: changefeedID}
+	for _, targetCfg := range targetConfigs(cfg) {
+		target, err := newSingleSink(ctx, targetCfg, changefeedID)
+		if err != nil {
+			s.Close(false)
+			return nil, err
+		}
+		if target.SinkType() == common.MysqlSinkType {
+			target.Close(false)
+			s.Close(false)
+			return nil, cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+				"mysql compatible sink can not be used as one of the multiple sink targets")
+		}
+		s.targets = append(s.targets, newSinkTarget(changefeedID, target, targetCfg.SinkURI))
+	}
+	log.Info("multiple sink targets created",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.Int("targetCount", len(s.targets)))
+	return s, nil
+}
+
+func newSinkTarget(changefeedID common.ChangeFeedID, s Sink, uri string) *sinkTarget {
+	maskedURI, err := putil.MaskSinkURI(uri)
+	if err != nil {
+		maskedURI = putil.MaskSensitiveDataInURI(uri)
+	}
+	return &sinkTarget{
+		sink:    s,
+		uri:     maskedURI,
+		pending: make(map[uint64]int),
+		metricCheckpointTs: metrics.SinkTargetCheckpointTsGauge.
+			WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), maskedURI),
+	}
+}
+
+func (t *sinkTarget) addPending(commitTs uint64) {
+	t.mu.Lock()
+	t.pending[commitTs]++
+	t.mu.Unlock()
+}
+
+func (t *sinkTarget) markFlushed(commitTs uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[commitTs]--
+	if t.pending[commitTs] <= 0 {
+		delete(t.pending, commitTs)
+	}
+	if commitTs > t.flushedTs {
+		t.flushedTs = commitTs
+	}
+}
+
+// getCheckpointTs returns the checkpoint of the target, all the events
+// whose commitTs is not larger than it have been flushed by the target.
+func (t *sinkTarget) getCheckpointTs() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	checkpointTs := t.flushedTs
+	if len(t.pending) > 0 {
+		checkpointTs = math.MaxUint64
+		for commitTs := range t.pending {
+			if commitTs-1 < checkpointTs {
+				checkpointTs = commitTs - 1
+			}
+		}
+	}
+	if checkpointTs < t.checkpointTs {
+		checkpointTs = t.checkpointTs
+	}
+	return checkpointTs
+}
+
+func (t *sinkTarget) updateCheckpointTs(ts uint64) {
+	t.mu.Lock()
+	if ts > t.checkpointTs {
+		t.checkpointTs = ts
+	}
+	t.mu.Unlock()
+	t.metricCheckpointTs.Set(float64(t.getCheckpointTs()))
+}
+
+func (s *multiSink) SinkType() common.SinkType {
+	return s.targets[0].sink.SinkType()
+}
+
+func (s *multiSink) IsNormal() bool {
+	for _, target := range s.targets {
+		if !target.sink.IsNormal() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *multiSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	remaining := int32(len(s.targets))
+	commitTs := event.CommitTs
+	for _, target := range s.targets {
+		target := target
+		// shallow copy the event, so that each target has its own row offset and callbacks.
+		copied := *event
+		copied.PostTxnFlushed = []func(){func() {
+			target.markFlushed(commitTs)
+			if atomic.AddInt32(&remaining, -1) == 0 {
+				event.PostFlush()
+			}
+		}}
+		target.addPending(commitTs)
+		target.sink.AddDMLEvent(&copied)
+	}
+}
+
+// copyBlockEvent returns a copy of the block event for each target,
+// the event is flushed after all the copies are flushed.
+func (s *multiSink) copyBlockEvent(event commonEvent.BlockEvent) []commonEvent.BlockEvent {
+	remaining := int32(len(s.targets))
+	postFlush := func() {
+		if atomic.AddInt32(&remaining, -1) == 0 {
+			event.PostFlush()
+		}
+	}
+	copies := make([]commonEvent.BlockEvent, 0, len(s.targets))
+	for range s.targets {
+		switch v := event.(type) {
+		case *commonEvent.DDLEvent:
+			copied := *v
+			copied.PostTxnFlushed = []func(){postFlush}
+			copies = append(copies, &copied)
+		case *commonEvent.SyncPointEvent:
+			copied := *v
+			copied.PostTxnFlushed = []func(){postFlush}
+			copies = append(copies, &copied)
+		default:
+			log.Panic("unknown block event type", zap.Any("event", event))
+		}
+	}
+	return copies
+}
+
+func (s *multiSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	copies := s.copyBlockEvent(event)
+	for i, target := range s.targets {
+		if err := target.sink.WriteBlockEvent(copies[i]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (s *multiSink) PassBlockEvent(event commonEvent.BlockEvent) {
+	copies := s.copyBlockEvent(event)
+	for i, target := range s.targets {
+		target.sink.PassBlockEvent(copies[i])
+	}
+}
+
+func (s *multiSink) AddCheckpointTs(ts uint64) {
+	for _, target := range s.targets {
+		target.sink.AddCheckpointTs(ts)
+		target.updateCheckpointTs(ts)
+	}
+}
+
+func (s *multiSink) AuditRowCount(checkpointTs uint64) {
+	for _, target := range s.targets {
+		target.sink.AuditRowCount(checkpointTs)
+	}
+}
+
+func (s *multiSink) ResendTableSchema(tableIDs []int64) {
+	for _, target := range s.targets {
+		target.sink.ResendTableSchema(tableIDs)
+	}
+}
+
+func (s *multiSink) WriteEOF() {
+	for _, target := range s.targets {
+		target.sink.WriteEOF()
+	}
+}
+
+func (s *multiSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	for _, target := range s.targets {
+		target.sink.SetTableSchemaStore(tableSchemaStore)
+	}
+}
+
+func (s *multiSink) Close(removeChangefeed bool) {
+	for _, target := range s.targets {
+		target.sink.Close(removeChangefeed)
+	}
+	metrics.SinkTargetCheckpointTsGauge.DeletePartialMatch(prometheus.Labels{
+		"namespace": s.changefeedID.Namespace(), "changefeed": s.changefeedID.Name(),
+	})
+}
+
+// Run runs all the targets. The targets are isolated from each other, the failure of a
+// target is reported without canceling the other targets, so they keep flushing their
+// events and advancing their checkpoints until the sink is closed.
+func (s *multiSink) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(s.targets))
+	for _, target := range s.targets {
+		target := target
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := target.sink.Run(ctx)
+			if err != nil && errors.Cause(err) != context.Canceled {
+				log.Warn("sink target exited with error",
+					zap.String("namespace", s.changefeedID.Namespace()),
+					zap.String("changefeed", s.changefeedID.Name()),
+					zap.String("target", target.uri),
+					zap.Error(err))
+				errCh <- errors.Annotatef(err, "sink target %s failed", target.uri)
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-done:
+		// a target may fail right before all the targets exit
+		select {
+		case err := <-errCh:
+			return err
+		default:
+		}
+		return errors.Trace(ctx.Err())
+	}
+}
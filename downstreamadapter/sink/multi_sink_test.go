@@ -0,0 +1,130 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+// delayedSink holds the dml events until flush is called.
+type delayedSink struct {
+	BlackHoleSink
+	events []*commonEvent.DMLEvent
+}
+
+func (s *delayedSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	s.events = append(s.events, event)
+}
+
+func (s *delayedSink) flush() {
+	for _, event := range s.events {
+		event.PostFlush()
+	}
+	s.events = nil
+}
+
+func TestMultiSinkFlushAfterAllTargets(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	fast := &BlackHoleSink{}
+	slow := &delayedSink{}
+	s := &multiSink{
+		changefeedID: changefeedID,
+		targets: []*sinkTarget{
+			newSinkTarget(changefeedID, fast, "blackhole://fast"),
+			newSinkTarget(changefeedID, slow, "blackhole://slow"),
+		},
+	}
+	defer s.Close(false)
+
+	count := 0
+	for _, commitTs := range []uint64{10, 20} {
+		s.AddDMLEvent(&commonEvent.DMLEvent{
+			CommitTs:       commitTs,
+			PostTxnFlushed: []func(){func() { count++ }},
+		})
+	}
+	// the events are not flushed until all targets flush them.
+	require.Equal(t, 0, count)
+
+	s.AddCheckpointTs(5)
+	require.Equal(t, uint64(20), s.targets[0].getCheckpointTs())
+	require.Equal(t, uint64(9), s.targets[1].getCheckpointTs())
+
+	slow.flush()
+	require.Equal(t, 2, count)
+	require.Equal(t, uint64(20), s.targets[1].getCheckpointTs())
+
+	ddlCount := 0
+	err := s.WriteBlockEvent(&commonEvent.DDLEvent{
+		FinishedTs:     30,
+		PostTxnFlushed: []func(){func() { ddlCount++ }},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, ddlCount)
+}
+
+// runningSink runs until the context is canceled, or returns err immediately if it's set.
+type runningSink struct {
+	BlackHoleSink
+	err     error
+	stopped chan struct{}
+}
+
+func (s *runningSink) Run(ctx context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	<-ctx.Done()
+	close(s.stopped)
+	return ctx.Err()
+}
+
+func TestMultiSinkIsolateTargetFailure(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	failed := &runningSink{err: errors.New("target failed")}
+	healthy := &runningSink{stopped: make(chan struct{})}
+	s := &multiSink{
+		changefeedID: changefeedID,
+		targets: []*sinkTarget{
+			newSinkTarget(changefeedID, healthy, "blackhole://healthy"),
+			newSinkTarget(changefeedID, failed, "blackhole://failed"),
+		},
+	}
+	defer s.Close(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := s.Run(ctx)
+	require.ErrorContains(t, err, "target failed")
+	require.ErrorContains(t, err, "blackhole://failed")
+
+	// the healthy target is not canceled by the failed one
+	select {
+	case <-healthy.stopped:
+		require.FailNow(t, "the healthy target is stopped")
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case <-healthy.stopped:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the healthy target is not stopped")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"sync"
 	"sync/atomic"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -27,6 +28,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -38,17 +40,39 @@ const (
 	prime = 31
 )
 
+// overrideKey is the upstream name of a table matched by the table overrides.
+type overrideKey struct {
+	schema string
+	table  string
+}
+
 // MysqlSink is responsible for writing data to mysql downstream.
 // Including DDL and DML.
 type MysqlSink struct {
 	changefeedID common.ChangeFeedID
 
-	ddlWorker   *worker.MysqlDDLWorker
+	ddlWorker *worker.MysqlDDLWorker
+	// dmlWorker contains the default workers followed by the workers of the table overrides.
 	dmlWorker   []*worker.MysqlDMLWorker
 	workerCount int
 
+	// tableOverrides are the tables applied by their own workers, overrideWorkers[i]
+	// are the workers of tableOverrides[i].
+	tableOverrides  []*mysql.TableOverride
+	overrideWorkers [][]*worker.MysqlDMLWorker
+	// overrideIndex caches the index of the override matched by the overrideKey of the table,
+	// it's -1 if no override is matched. It's keyed by the names instead of the table id,
+	// since a table matches another override after it's renamed.
+	overrideIndex sync.Map
+
 	db         *sql.DB
 	statistics *metrics.Statistics
+	// auditor is nil if the row count audit is disabled.
+	auditor *rowCountAuditor
+	// router is nil if there is no routing rule.
+	router *util.TableRouter
+	// dropSyncPointOnRemove deletes the syncpoint rows when the changefeed is removed.
+	dropSyncPointOnRemove bool
 
 	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
 }
@@ -97,21 +121,46 @@ func newMysqlSinkWithDBAndConfig(
 		dmlWorker:    make([]*worker.MysqlDMLWorker, workerCount),
 		workerCount:  workerCount,
 		statistics:   stat,
+		router:       cfg.TableRouter,
 		isNormal:     1,
+
+		dropSyncPointOnRemove: cfg.DropSyncPointOnRemove,
 	}
 	formatVectorType := mysql.ShouldFormatVectorType(db, cfg)
 	for i := 0; i < workerCount; i++ {
 		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, changefeedID, stat, formatVectorType)
 	}
+	for _, override := range cfg.TableOverrides {
+		overrideCfg := *cfg
+		overrideCfg.MaxTxnRow = override.MaxTxnRow
+		workers := make([]*worker.MysqlDMLWorker, 0, override.WorkerCount)
+		for i := 0; i < override.WorkerCount; i++ {
+			w := worker.NewMysqlDMLWorker(ctx, db, &overrideCfg, len(mysqlSink.dmlWorker), changefeedID, stat, formatVectorType)
+			mysqlSink.dmlWorker = append(mysqlSink.dmlWorker, w)
+			workers = append(workers, w)
+		}
+		mysqlSink.tableOverrides = append(mysqlSink.tableOverrides, override)
+		mysqlSink.overrideWorkers = append(mysqlSink.overrideWorkers, workers)
+	}
 	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, changefeedID, stat, formatVectorType)
+	if cfg.EnableRowCountAudit {
+		mysqlSink.auditor = newRowCountAuditor(changefeedID, func(_ context.Context, counts []audit.RowCount) error {
+			return mysqlSink.ddlWorker.WriteRowCountAudit(counts)
+		})
+	}
 	return mysqlSink
 }
 
 func (s *MysqlSink) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
-	for i := 0; i < s.workerCount; i++ {
+	for _, w := range s.dmlWorker {
+		g.Go(func() error {
+			return w.Run(ctx)
+		})
+	}
+	if s.auditor != nil {
 		g.Go(func() error {
-			return s.dmlWorker[i].Run(ctx)
+			return s.auditor.run(ctx)
 		})
 	}
 	err := g.Wait()
@@ -133,6 +182,21 @@ func (s *MysqlSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore)
 }
 
 func (s *MysqlSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	// The overrides match the upstream names, so they are checked before the event is routed.
+	workers := s.getOverrideWorkers(event)
+	if s.router != nil {
+		event = s.router.RouteDMLEvent(event)
+	}
+	if s.auditor != nil {
+		s.auditor.addDMLEvent(event)
+	}
+	if workers != nil {
+		// The events of a table span are sent to the same worker to keep their order,
+		// and the spans of the table are applied concurrently.
+		index := event.DispatcherID.Low % uint64(len(workers))
+		workers[index].AddDMLEvent(event)
+		return
+	}
 	// Considering that the parity of tableID is not necessarily even,
 	// directly dividing by the number of buckets may cause unevenness between buckets.
 	// Therefore, we first take the modulus of the prime number and then take the modulus of the bucket.
@@ -140,11 +204,51 @@ func (s *MysqlSink) AddDMLEvent(event *commonEvent.DMLEvent) {
 	s.dmlWorker[index].AddDMLEvent(event)
 }
 
+// getOverrideWorkers returns the workers of the override matched by the table of the event,
+// it returns nil if no override is matched.
+func (s *MysqlSink) getOverrideWorkers(event *commonEvent.DMLEvent) []*worker.MysqlDMLWorker {
+	if len(s.tableOverrides) == 0 || event.TableInfo == nil {
+		return nil
+	}
+	key := overrideKey{schema: event.TableInfo.GetSchemaName(), table: event.TableInfo.GetTableName()}
+	if index, ok := s.overrideIndex.Load(key); ok {
+		if index.(int) < 0 {
+			return nil
+		}
+		return s.overrideWorkers[index.(int)]
+	}
+	index := -1
+	for i, override := range s.tableOverrides {
+		if override.Match(key.schema, key.table) {
+			index = i
+			break
+		}
+	}
+	s.overrideIndex.Store(key, index)
+	if index < 0 {
+		return nil
+	}
+	return s.overrideWorkers[index]
+}
+
 func (s *MysqlSink) PassBlockEvent(event commonEvent.BlockEvent) {
 	event.PostFlush()
 }
 
 func (s *MysqlSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	if ddl, ok := event.(*commonEvent.DDLEvent); ok && s.router != nil {
+		routed, err := s.router.RouteDDLEvent(ddl)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return err
+		}
+		if routed == nil {
+			// the ddl is not applied to the merged table
+			ddl.PostFlush()
+			return nil
+		}
+		event = routed
+	}
 	err := s.ddlWorker.WriteBlockEvent(event)
 	if err != nil {
 		atomic.StoreUint32(&s.isNormal, 0)
@@ -155,6 +259,16 @@ func (s *MysqlSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
 
 func (s *MysqlSink) AddCheckpointTs(_ uint64) {}
 
+func (s *MysqlSink) ResendTableSchema(_ []int64) {}
+
+func (s *MysqlSink) WriteEOF() {}
+
+func (s *MysqlSink) AuditRowCount(checkpointTs uint64) {
+	if s.auditor != nil {
+		s.auditor.advance(checkpointTs)
+	}
+}
+
 func (s *MysqlSink) GetStartTsList(
 	tableIds []int64,
 	startTsList []int64,
@@ -185,9 +299,19 @@ func (s *MysqlSink) Close(removeChangefeed bool) {
 			log.Warn("close mysql sink, remove changefeed meet error",
 				zap.Any("changefeed", s.changefeedID.String()), zap.Error(err))
 		}
+		if err := s.ddlWorker.RemoveDMLProgress(); err != nil {
+			log.Warn("close mysql sink, remove dml progress meet error",
+				zap.Any("changefeed", s.changefeedID.String()), zap.Error(err))
+		}
+		if s.dropSyncPointOnRemove {
+			if err := s.ddlWorker.RemoveSyncPointRows(); err != nil {
+				log.Warn("close mysql sink, remove syncpoint rows meet error",
+					zap.Any("changefeed", s.changefeedID.String()), zap.Error(err))
+			}
+		}
 	}
-	for i := 0; i < s.workerCount; i++ {
-		s.dmlWorker[i].Close()
+	for _, w := range s.dmlWorker {
+		w.Close()
 	}
 
 	s.ddlWorker.Close()
@@ -14,19 +14,34 @@
 package sink
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 var count = 0
 
 // Test callback and tableProgress works as expected after AddDMLEvent
 func TestMysqlSinkBasicFunctionality(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	old := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(old)
+
 	sink, mock := MysqlSinkForTest()
 
 	count = 0
@@ -117,6 +132,13 @@ func TestMysqlSinkBasicFunctionality(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, count, 3)
+
+	// the flush of the DML events is traced
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "sink.FlushDMLEvents", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.String("sink", "mysql"))
+	require.Contains(t, spans[0].Attributes(), attribute.Int("rows", 2))
 }
 
 // test the situation meets error when executing DML
@@ -201,3 +223,77 @@ func TestMysqlSinkMeetsDDLError(t *testing.T) {
 
 	require.Equal(t, sink.IsNormal(), false)
 }
+
+// test the events of the overridden tables are sent to their own workers
+func TestMysqlSinkTableOverride(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
+		TableOverrides: []*config.MySQLTableOverride{
+			{Matcher: []string{"test.big"}, WorkerCount: 2, MaxTxnRow: 1024},
+		},
+	}
+	cfg := mysql.NewMysqlConfig()
+	sinkURI, err := url.Parse("mysql://root@127.0.0.1:3306")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Apply(sinkURI, changefeedID, &config.ChangefeedConfig{SinkConfig: replicaConfig.Sink}))
+	require.Len(t, cfg.TableOverrides, 1)
+	require.Equal(t, 1024, cfg.TableOverrides[0].MaxTxnRow)
+
+	sink := newMysqlSinkWithDBAndConfig(context.Background(), changefeedID, 1, cfg, db)
+	require.Len(t, sink.dmlWorker, 3)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table big (id int primary key)")
+	helper.DDL2Job("create table small (id int primary key)")
+
+	smallEvent := helper.DML2Event("test", "small", "insert into small values (1)")
+	sink.AddDMLEvent(smallEvent)
+	require.Equal(t, smallEvent, <-sink.dmlWorker[0].GetEventChan())
+
+	for low := uint64(0); low < 2; low++ {
+		bigEvent := helper.DML2Event("test", "big", fmt.Sprintf("insert into big values (%d)", low))
+		bigEvent.DispatcherID = common.DispatcherID{Low: low}
+		sink.AddDMLEvent(bigEvent)
+		require.Equal(t, bigEvent, <-sink.dmlWorker[1+low].GetEventChan())
+	}
+
+	// the renamed table keeps its table id, but it's matched by its new name
+	renamedEvent := helper.DML2Event("test", "big", "insert into big values (10)")
+	renamedEvent.TableInfo = renamedEvent.TableInfo.CloneWithName("test", "renamed")
+	sink.AddDMLEvent(renamedEvent)
+	require.Equal(t, renamedEvent, <-sink.dmlWorker[0].GetEventChan())
+}
+
+func TestMysqlSinkTableOverrideCaseFolding(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
+		TableOverrides: []*config.MySQLTableOverride{
+			{Matcher: []string{"test.`straße`"}, WorkerCount: 1},
+		},
+	}
+	cfg := mysql.NewMysqlConfig()
+	sinkURI, err := url.Parse("mysql://root@127.0.0.1:3306")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Apply(sinkURI, changefeedID, &config.ChangefeedConfig{SinkConfig: replicaConfig.Sink}))
+	sink := newMysqlSinkWithDBAndConfig(context.Background(), changefeedID, 1, cfg, db)
+	require.Len(t, sink.dmlWorker, 2)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key)")
+
+	// the names are matched by the case folding, the same as the filter of the changefeed
+	event := helper.DML2Event("test", "t", "insert into t values (1)")
+	event.TableInfo = event.TableInfo.CloneWithName("test", "STRASSE")
+	sink.AddDMLEvent(event)
+	require.Equal(t, event, <-sink.dmlWorker[1].GetEventChan())
+}
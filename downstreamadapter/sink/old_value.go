@@ -0,0 +1,104 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink"
+	putil "github.com/pingcap/tiflow/pkg/util"
+)
+
+// CaptureOldValue returns whether the old values of the rows should be captured from TiKV
+// for the changefeed, according to its old value mode. It returns an error if the old
+// values are disabled but the sink or the filter of the changefeed needs them.
+func CaptureOldValue(cfg *config.ChangefeedConfig) (bool, error) {
+	switch cfg.OldValueMode {
+	case "", config.OldValueModeEnabled:
+		return true, nil
+	case config.OldValueModeDisabled, config.OldValueModeAuto:
+	default:
+		return false, cfg.OldValueMode.Validate()
+	}
+
+	need, reason := cfg.Filter.NeedOldValue(), "the event filter"
+	if !need {
+		configs := []*config.ChangefeedConfig{cfg}
+		if cfg.SinkConfig != nil {
+			configs = targetConfigs(cfg)
+		}
+		for _, target := range configs {
+			var err error
+			if need, err = sinkNeedOldValue(target); err != nil {
+				return false, err
+			}
+			if need {
+				reason = fmt.Sprintf("the sink %s", putil.MaskSensitiveDataInURI(target.SinkURI))
+				break
+			}
+		}
+	}
+	if need && cfg.OldValueMode == config.OldValueModeDisabled {
+		return false, cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("old-value-mode is %s, but the old values are needed by %s",
+				config.OldValueModeDisabled, reason))
+	}
+	return need, nil
+}
+
+// sinkNeedOldValue returns true if the sink writes the old values of the rows to the downstream.
+func sinkNeedOldValue(cfg *config.ChangefeedConfig) (bool, error) {
+	sinkURI, err := url.Parse(cfg.SinkURI)
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	scheme := sink.GetScheme(sinkURI)
+	switch {
+	case scheme == sink.BlackHoleScheme:
+		return false, nil
+	case sink.IsMQScheme(scheme) || sink.IsStorageScheme(scheme):
+	default:
+		// the mysql sink locates the updated and deleted rows by their old values.
+		return true, nil
+	}
+
+	sinkConfig := cfg.SinkConfig
+	if sinkConfig == nil {
+		return true, nil
+	}
+	// the dead letter records carry the full rows before the change.
+	if sinkConfig.DeadLetterQueue != nil {
+		return true, nil
+	}
+	// the deleted rows are dispatched by the values of the columns other than the handle key.
+	for _, rule := range sinkConfig.DispatchRules {
+		if rule.IndexName != "" || len(rule.Columns) != 0 {
+			return true, nil
+		}
+	}
+	protocol, err := helper.GetProtocol(putil.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return false, err
+	}
+	codecConfig := common.NewConfig(protocol)
+	if err := codecConfig.Apply(sinkURI, sinkConfig); err != nil {
+		return false, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	return codecConfig.NeedOldValue(), nil
+}
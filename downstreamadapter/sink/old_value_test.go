@@ -0,0 +1,128 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureOldValue(t *testing.T) {
+	newConfig := func(mode config.OldValueMode, sinkURI string, protocol string) *config.ChangefeedConfig {
+		sinkConfig := config.GetDefaultReplicaConfig().Sink
+		if protocol != "" {
+			sinkConfig.Protocol = util.AddressOf(protocol)
+		}
+		return &config.ChangefeedConfig{
+			SinkURI:      sinkURI,
+			SinkConfig:   sinkConfig,
+			Filter:       &config.FilterConfig{},
+			OldValueMode: mode,
+		}
+	}
+
+	cases := []struct {
+		cfg     *config.ChangefeedConfig
+		capture bool
+		err     bool
+	}{
+		{newConfig("", "mysql://127.0.0.1:3306", ""), true, false},
+		{newConfig(config.OldValueModeEnabled, "blackhole://", ""), true, false},
+		{newConfig(config.OldValueModeAuto, "blackhole://", ""), false, false},
+		{newConfig(config.OldValueModeAuto, "mysql://127.0.0.1:3306", ""), true, false},
+		{newConfig(config.OldValueModeDisabled, "mysql://127.0.0.1:3306", ""), false, true},
+		{newConfig(config.OldValueModeAuto, "kafka://127.0.0.1:9092/topic", "canal-json"), true, false},
+		{newConfig(config.OldValueModeDisabled, "kafka://127.0.0.1:9092/topic", "avro"), false, false},
+		{newConfig(config.OldValueModeAuto, "kafka://127.0.0.1:9092/topic", "open-protocol"), true, false},
+	}
+	for i, c := range cases {
+		capture, err := CaptureOldValue(c.cfg)
+		if c.err {
+			require.Error(t, err, i)
+			continue
+		}
+		require.NoError(t, err, i)
+		require.Equal(t, c.capture, capture, i)
+	}
+
+	// the open protocol doesn't need the old values if neither the before values
+	// of the updates nor the full deleted rows are output.
+	cfg := newConfig(config.OldValueModeAuto, "kafka://127.0.0.1:9092/topic", "open-protocol")
+	cfg.SinkConfig.OpenProtocol.OutputOldValue = false
+	capture, err := CaptureOldValue(cfg)
+	require.NoError(t, err)
+	require.True(t, capture)
+	cfg.OldValueMode = config.OldValueModeDisabled
+	cfg.SinkConfig.DeleteOnlyOutputHandleKeyColumns = util.AddressOf(true)
+	capture, err = CaptureOldValue(cfg)
+	require.NoError(t, err)
+	require.False(t, capture)
+
+	// the dead letter records carry the full rows.
+	cfg.SinkConfig.DeadLetterQueue = &config.DeadLetterQueueConfig{Topic: "dlq"}
+	_, err = CaptureOldValue(cfg)
+	require.ErrorContains(t, err, "old values are needed")
+	cfg.SinkConfig.DeadLetterQueue = nil
+
+	// but the filter evaluating the old values needs them.
+	cfg.Filter.EventFilters = []*config.EventFilterRule{{IgnoreDeleteValueExpr: "a > 1"}}
+	_, err = CaptureOldValue(cfg)
+	require.ErrorContains(t, err, "event filter")
+}
+
+// TestCaptureOldValueForDeletes checks the old values are captured in the auto mode
+// for the protocols which encode the full deleted rows, even if the before values of
+// the updates are not output.
+func TestCaptureOldValueForDeletes(t *testing.T) {
+	cases := []struct {
+		sinkURI  string
+		protocol string
+		// capture is whether the old values are captured by default.
+		capture bool
+		// keyOnly is whether the old values are captured if the deleted rows only
+		// output the handle key columns.
+		keyOnly bool
+	}{
+		{"kafka://127.0.0.1:9092/topic", "canal-json", true, true},
+		{"kafka://127.0.0.1:9092/topic", "open-protocol", true, false},
+		{"kafka://127.0.0.1:9092/topic", "debezium", true, false},
+		{"kafka://127.0.0.1:9092/topic", "avro", false, false},
+		{"kafka://127.0.0.1:9092/topic", "simple", true, true},
+		{"file:///tmp/cdc", "csv", true, true},
+	}
+	for _, c := range cases {
+		sinkConfig := config.GetDefaultReplicaConfig().Sink
+		sinkConfig.Protocol = util.AddressOf(c.protocol)
+		sinkConfig.OpenProtocol.OutputOldValue = false
+		sinkConfig.Debezium.OutputOldValue = false
+		sinkConfig.CSVConfig.OutputOldValue = false
+		cfg := &config.ChangefeedConfig{
+			SinkURI:      c.sinkURI,
+			SinkConfig:   sinkConfig,
+			Filter:       &config.FilterConfig{},
+			OldValueMode: config.OldValueModeAuto,
+		}
+		capture, err := CaptureOldValue(cfg)
+		require.NoError(t, err, c.protocol)
+		require.Equal(t, c.capture, capture, c.protocol)
+
+		sinkConfig.DeleteOnlyOutputHandleKeyColumns = util.AddressOf(true)
+		capture, err = CaptureOldValue(cfg)
+		require.NoError(t, err, c.protocol)
+		require.Equal(t, c.keyOnly, capture, c.protocol)
+	}
+}
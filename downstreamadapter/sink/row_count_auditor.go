@@ -0,0 +1,88 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
+	"go.uber.org/zap"
+)
+
+// rowCountAuditor counts the rows flushed by the sink per table, and writes the counts
+// to the downstream each time the checkpointTs of the node advances.
+type rowCountAuditor struct {
+	changefeedID   common.ChangeFeedID
+	counter        *audit.Counter
+	checkpointTsCh chan uint64
+	write          func(ctx context.Context, counts []audit.RowCount) error
+}
+
+func newRowCountAuditor(
+	changefeedID common.ChangeFeedID,
+	write func(ctx context.Context, counts []audit.RowCount) error,
+) *rowCountAuditor {
+	return &rowCountAuditor{
+		changefeedID:   changefeedID,
+		counter:        audit.NewCounter(0),
+		checkpointTsCh: make(chan uint64, 16),
+		write:          write,
+	}
+}
+
+// addDMLEvent counts the rows of the event after it's flushed.
+func (a *rowCountAuditor) addDMLEvent(event *commonEvent.DMLEvent) {
+	schema, table := event.TableInfo.GetSchemaName(), event.TableInfo.GetTableName()
+	tableID, commitTs, rows := event.PhysicalTableID, event.GetCommitTs(), int64(event.Len())
+	event.AddPostFlushFunc(func() {
+		a.counter.Add(schema, table, tableID, commitTs, rows)
+	})
+}
+
+// advance notifies the auditor the checkpointTs of the node is advanced.
+// It never blocks, if the channel is full the checkpointTs is skipped,
+// and its rows are reported in the following interval.
+func (a *rowCountAuditor) advance(checkpointTs uint64) {
+	select {
+	case a.checkpointTsCh <- checkpointTs:
+	default:
+	}
+}
+
+func (a *rowCountAuditor) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case checkpointTs := <-a.checkpointTsCh:
+			counts := a.counter.Advance(checkpointTs)
+			if len(counts) == 0 {
+				continue
+			}
+			// The audit is only used for reconciliation, so the failure is logged
+			// instead of blocking the changefeed.
+			if err := a.write(ctx, counts); err != nil {
+				log.Warn("failed to write row count audit",
+					zap.String("namespace", a.changefeedID.Namespace()),
+					zap.String("changefeed", a.changefeedID.Name()),
+					zap.Uint64("checkpointTs", checkpointTs),
+					zap.Int("tableCount", len(counts)),
+					zap.Error(err))
+			}
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/sinkuri"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/pkg/sink"
 )
@@ -33,6 +34,16 @@ type Sink interface {
 	WriteBlockEvent(event commonEvent.BlockEvent) error
 	PassBlockEvent(event commonEvent.BlockEvent)
 	AddCheckpointTs(ts uint64)
+	// AuditRowCount is called when the checkpointTs of the dispatchers in the node advances,
+	// the sink writes the number of rows flushed per table if the row count audit is enabled.
+	AuditRowCount(checkpointTs uint64)
+	// ResendTableSchema resends the schema of the tables to the downstream if the protocol
+	// carries the schema in the bootstrap messages, all tables are resent if tableIDs is empty.
+	ResendTableSchema(tableIDs []int64)
+	// WriteEOF tells the downstream no more events of the changefeed will be sent when the
+	// changefeed is removed. It's only called on the node holding the table trigger event
+	// dispatcher before the sink is closed, so the EOF is sent once with the final checkpointTs.
+	WriteEOF()
 
 	SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore)
 	Close(removeChangefeed bool)
@@ -40,6 +51,13 @@ type Sink interface {
 }
 
 func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID) (Sink, error) {
+	if config.SinkConfig != nil && len(config.SinkConfig.AdditionalSinkURIs) > 0 {
+		return newMultiSink(ctx, config, changefeedID)
+	}
+	return newSingleSink(ctx, config, changefeedID)
+}
+
+func newSingleSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID) (Sink, error) {
 	sinkURI, err := url.Parse(config.SinkURI)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
@@ -49,7 +67,7 @@ func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		return newMySQLSink(ctx, changefeedID, 16, config, sinkURI)
 	case sink.KafkaScheme, sink.KafkaSSLScheme:
-		return newKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig)
+		return newKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig, config.GetTimeZone(), config.LatencyMode)
 	case sink.BlackHoleScheme:
 		return newBlackHoleSink()
 	}
@@ -57,16 +75,33 @@ func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID
 }
 
 func VerifySink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID) error {
-	sinkURI, err := url.Parse(config.SinkURI)
+	if _, err := CaptureOldValue(config); err != nil {
+		return err
+	}
+	if config.SinkConfig != nil {
+		for _, cfg := range targetConfigs(config) {
+			if err := verifySingleSink(ctx, cfg, changefeedID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return verifySingleSink(ctx, config, changefeedID)
+}
+
+func verifySingleSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID) error {
+	// the options of the sink uri are only validated when the changefeed is created or updated,
+	// so the existing changefeeds with the unknown options can still be started.
+	sinkURI, err := sinkuri.Parse(config.SinkURI)
 	if err != nil {
-		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		return err
 	}
 	scheme := sink.GetScheme(sinkURI)
 	switch scheme {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		return verifyMySQLSink(ctx, sinkURI, config)
 	case sink.KafkaScheme, sink.KafkaSSLScheme:
-		return verifyKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig)
+		return verifyKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig, config.GetTimeZone())
 	case sink.BlackHoleScheme:
 		return nil
 	}
@@ -16,6 +16,7 @@ package worker
 import (
 	"context"
 	"net/url"
+	"strings"
 
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
@@ -27,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/codec"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/pingcap/ticdc/pkg/sink/kafka/franz"
 	v2 "github.com/pingcap/ticdc/pkg/sink/kafka/v2"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/br/pkg/utils"
@@ -38,15 +40,33 @@ type KafkaComponent struct {
 	Encoder        common.EventEncoder
 	ColumnSelector *columnselector.ColumnSelectors
 	EventRouter    *eventrouter.EventRouter
-	TopicManager   topicmanager.TopicManager
-	AdminClient    kafka.ClusterAdminClient
-	Factory        kafka.Factory
+	// TableRouter is nil if there is no routing rule.
+	TableRouter  *util.TableRouter
+	TopicManager topicmanager.TopicManager
+	AdminClient  kafka.ClusterAdminClient
+	Factory      kafka.Factory
+	// DDLTopic is nil if the DDL events are sent to the topics of the tables.
+	DDLTopic *DDLTopic
+	// ProtocolEncoders are the encoders of the protocols overriding the protocol of the sink
+	// for some tables, they encode the DDL and checkpoint events of these tables.
+	ProtocolEncoders map[config.Protocol]common.EventEncoder
+}
+
+// DDLTopic is the dedicated topic of the DDL events, which has its own encoder.
+type DDLTopic struct {
+	Name string
+	// Partition is the partition the DDL events are sent to,
+	// nil means they are dispatched by the rule of the protocol.
+	Partition *int32
+	Protocol  config.Protocol
+	Encoder   common.EventEncoder
 }
 
 func getKafkaSinkComponentWithFactory(ctx context.Context,
 	changefeedID commonType.ChangeFeedID,
 	sinkURI *url.URL,
 	sinkConfig *config.SinkConfig,
+	timezone string,
 	factoryCreator kafka.FactoryCreator,
 ) (KafkaComponent, config.Protocol, error) {
 	kafkaComponent := KafkaComponent{}
@@ -101,39 +121,140 @@ func getKafkaSinkComponentWithFactory(ctx context.Context,
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
 
+	kafkaComponent.TableRouter, err = util.NewTableRouter(sinkConfig.RoutingRules)
+	if err != nil {
+		return kafkaComponent, protocol, errors.Trace(err)
+	}
+
 	kafkaComponent.ColumnSelector, err = columnselector.NewColumnSelectors(sinkConfig)
 	if err != nil {
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
 
-	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, options.MaxMessageBytes)
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, timezone, options.MaxMessageBytes)
 	if err != nil {
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
 
-	kafkaComponent.EncoderGroup, err = codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
+	encoderGroup, err := codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
 	if err != nil {
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
+	if overrides := kafkaComponent.EventRouter.GetOverrideProtocols(); len(overrides) > 0 {
+		encoderConfigs := make(map[config.Protocol]*common.Config, len(overrides))
+		kafkaComponent.ProtocolEncoders = make(map[config.Protocol]common.EventEncoder, len(overrides))
+		for _, p := range overrides {
+			encoderConfigs[p], err = util.GetEncoderConfig(changefeedID, sinkURI, p, sinkConfig, timezone, options.MaxMessageBytes)
+			if err != nil {
+				return kafkaComponent, protocol, errors.Trace(err)
+			}
+			kafkaComponent.ProtocolEncoders[p], err = codec.NewEventEncoder(ctx, encoderConfigs[p])
+			if err != nil {
+				return kafkaComponent, protocol, errors.Trace(err)
+			}
+		}
+		err = encoderGroup.SetProtocolOverrides(ctx, kafkaComponent.EventRouter.GetProtocolForTable, encoderConfigs)
+		if err != nil {
+			return kafkaComponent, protocol, errors.Trace(err)
+		}
+	}
+	kafkaComponent.EncoderGroup = encoderGroup
 
 	kafkaComponent.Encoder, err = codec.NewEventEncoder(ctx, encoderConfig)
 	if err != nil {
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
+
+	if sinkConfig.DDLTopic != nil {
+		kafkaComponent.DDLTopic, err = newDDLTopic(ctx, changefeedID, sinkURI, sinkConfig, timezone,
+			options.MaxMessageBytes, kafkaComponent.TopicManager)
+		if err != nil {
+			return kafkaComponent, protocol, errors.Trace(err)
+		}
+	}
 	return kafkaComponent, protocol, nil
 }
 
+func newDDLTopic(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	sinkURI *url.URL,
+	sinkConfig *config.SinkConfig,
+	timezone string,
+	maxMessageBytes int,
+	topicManager topicmanager.TopicManager,
+) (*DDLTopic, error) {
+	cfg := sinkConfig.DDLTopic
+	protocol, err := helper.GetProtocol(cfg.GetProtocol(utils.GetOrZero(sinkConfig.Protocol)))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, timezone, maxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encoder, err := codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	partitionNum, err := topicManager.CreateTopicAndWaitUntilVisible(ctx, cfg.Topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Partition != nil && *cfg.Partition >= partitionNum {
+		return nil, errors.ErrKafkaInvalidConfig.GenWithStack(
+			"the partition %d of the ddl topic %s is out of range, the topic has %d partitions",
+			*cfg.Partition, cfg.Topic, partitionNum)
+	}
+	return &DDLTopic{
+		Name:      cfg.Topic,
+		Partition: cfg.Partition,
+		Protocol:  protocol,
+		Encoder:   encoder,
+	}, nil
+}
+
 func GetKafkaSinkComponent(
 	ctx context.Context,
 	changefeedID commonType.ChangeFeedID,
 	sinkURI *url.URL,
 	sinkConfig *config.SinkConfig,
+	timezone string,
 ) (KafkaComponent, config.Protocol, error) {
-	factoryCreator := kafka.NewSaramaFactory
-	if utils.GetOrZero(sinkConfig.EnableKafkaSinkV2) {
-		factoryCreator = v2.NewFactory
+	factoryCreator, err := getKafkaFactoryCreator(sinkURI, sinkConfig)
+	if err != nil {
+		return KafkaComponent{}, config.ProtocolUnknown, errors.Trace(err)
+	}
+	return getKafkaSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, timezone, factoryCreator)
+}
+
+// kafkaFactoryCreators are the kafka clients can be selected by the sink uri.
+var kafkaFactoryCreators = map[string]kafka.FactoryCreator{
+	kafka.ClientSarama:  kafka.NewSaramaFactory,
+	kafka.ClientKafkaGo: v2.NewFactory,
+	kafka.ClientFranzGo: franz.NewFactory,
+}
+
+// getKafkaFactoryCreator returns the factory creator of the kafka client selected by
+// the `kafka-client` parameter of the sink uri. If the parameter is not set, the kafka-go
+// client is used only if the `enable-kafka-sink-v2` is set.
+func getKafkaFactoryCreator(
+	sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (kafka.FactoryCreator, error) {
+	client := strings.ToLower(strings.TrimSpace(sinkURI.Query().Get("kafka-client")))
+	if client == "" {
+		if utils.GetOrZero(sinkConfig.EnableKafkaSinkV2) {
+			return v2.NewFactory, nil
+		}
+		return kafka.NewSaramaFactory, nil
+	}
+	creator, ok := kafkaFactoryCreators[client]
+	if !ok {
+		return nil, errors.ErrKafkaInvalidConfig.GenWithStack(
+			"unsupported kafka client %s, only %s, %s and %s are supported",
+			client, kafka.ClientSarama, kafka.ClientKafkaGo, kafka.ClientFranzGo)
 	}
-	return getKafkaSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, factoryCreator)
+	return creator, nil
 }
 
 func GetKafkaSinkComponentForTest(
@@ -142,5 +263,5 @@ func GetKafkaSinkComponentForTest(
 	sinkURI *url.URL,
 	sinkConfig *config.SinkConfig,
 ) (KafkaComponent, config.Protocol, error) {
-	return getKafkaSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, kafka.NewMockFactory)
+	return getKafkaSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, config.GetGlobalServerConfig().TZ, kafka.NewMockFactory)
 }
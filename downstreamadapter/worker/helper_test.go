@@ -0,0 +1,57 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/pingcap/ticdc/pkg/sink/kafka/franz"
+	v2 "github.com/pingcap/ticdc/pkg/sink/kafka/v2"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKafkaFactoryCreator(t *testing.T) {
+	sameCreator := func(expected, actual kafka.FactoryCreator) {
+		require.Equal(t, reflect.ValueOf(expected).Pointer(), reflect.ValueOf(actual).Pointer())
+	}
+
+	cases := []struct {
+		uri      string
+		enableV2 bool
+		expected kafka.FactoryCreator
+	}{
+		{"kafka://127.0.0.1:9092/test", false, kafka.NewSaramaFactory},
+		{"kafka://127.0.0.1:9092/test", true, v2.NewFactory},
+		{"kafka://127.0.0.1:9092/test?kafka-client=kafka-go", false, v2.NewFactory},
+		{"kafka://127.0.0.1:9092/test?kafka-client=Sarama", true, kafka.NewSaramaFactory},
+		{"kafka://127.0.0.1:9092/test?kafka-client=franz-go", false, franz.NewFactory},
+	}
+	for _, c := range cases {
+		sinkURI, err := url.Parse(c.uri)
+		require.NoError(t, err)
+		creator, err := getKafkaFactoryCreator(sinkURI, &config.SinkConfig{EnableKafkaSinkV2: util.AddressOf(c.enableV2)})
+		require.NoError(t, err)
+		sameCreator(c.expected, creator)
+	}
+
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?kafka-client=unknown")
+	require.NoError(t, err)
+	_, err = getKafkaFactoryCreator(sinkURI, &config.SinkConfig{})
+	require.ErrorContains(t, err, "unsupported kafka client unknown")
+}
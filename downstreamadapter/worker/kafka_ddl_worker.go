@@ -15,6 +15,8 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/log"
@@ -26,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"go.uber.org/zap"
@@ -37,9 +40,15 @@ type KafkaDDLWorker struct {
 	changeFeedID commonType.ChangeFeedID
 
 	checkpointTsChan chan uint64
+	protocol         config.Protocol
 	encoder          common.EventEncoder
+	// protocolEncoders are the encoders of the protocols overriding the protocol of
+	// the sink for some tables, they encode the events sent to the topics of these tables.
+	protocolEncoders map[config.Protocol]common.EventEncoder
 	// eventRouter used to route events to the right topic and partition.
 	eventRouter *eventrouter.EventRouter
+	// tableRouter maps the tables to the downstream names, it's nil if there is no routing rule.
+	tableRouter *util.TableRouter
 	// topicManager used to manage topics.
 	// It is also responsible for creating topics.
 	topicManager topicmanager.TopicManager
@@ -49,8 +58,16 @@ type KafkaDDLWorker struct {
 
 	tableSchemaStore *util.TableSchemaStore
 
-	statistics    *metrics.Statistics
-	partitionRule DDLDispatchRule
+	statistics *metrics.Statistics
+	// ddlTopic is the dedicated topic of the DDL events, it's nil if
+	// the DDL events are sent to the topics of the tables.
+	ddlTopic *DDLTopic
+	// watermark is nil if the checkpoint ts is only sent when it advances.
+	watermark *config.WatermarkConfig
+	// checkpointTs is the latest checkpoint ts added, it's carried by the EOF message.
+	checkpointTs atomic.Uint64
+	// rowCountAuditTopic is the dedicated topic the row count audit messages are sent to.
+	rowCountAuditTopic string
 }
 
 // DDLDispatchRule is the dispatch rule for DDL event.
@@ -80,17 +97,23 @@ func NewKafkaDDLWorker(
 	producer producer.DDLProducer,
 	encoder common.EventEncoder,
 	eventRouter *eventrouter.EventRouter,
+	tableRouter *util.TableRouter,
 	topicManager topicmanager.TopicManager,
 	statistics *metrics.Statistics,
+	ddlTopic *DDLTopic,
+	watermark *config.WatermarkConfig,
 ) *KafkaDDLWorker {
 	return &KafkaDDLWorker{
 		changeFeedID:     id,
+		protocol:         protocol,
 		encoder:          encoder,
 		producer:         producer,
 		eventRouter:      eventRouter,
+		tableRouter:      tableRouter,
 		topicManager:     topicManager,
 		statistics:       statistics,
-		partitionRule:    getDDLDispatchRule(protocol),
+		ddlTopic:         ddlTopic,
+		watermark:        watermark,
 		checkpointTsChan: make(chan uint64, 16),
 	}
 }
@@ -100,6 +123,12 @@ func (w *KafkaDDLWorker) Run(ctx context.Context) error {
 }
 
 func (w *KafkaDDLWorker) AddCheckpoint(ts uint64) {
+	for {
+		old := w.checkpointTs.Load()
+		if ts <= old || w.checkpointTs.CompareAndSwap(old, ts) {
+			break
+		}
+	}
 	w.checkpointTsChan <- ts
 }
 
@@ -107,15 +136,40 @@ func (w *KafkaDDLWorker) SetTableSchemaStore(tableSchemaStore *util.TableSchemaS
 	w.tableSchemaStore = tableSchemaStore
 }
 
+// SetProtocolEncoders sets the encoders of the protocols overriding the protocol of the sink.
+func (w *KafkaDDLWorker) SetProtocolEncoders(encoders map[config.Protocol]common.EventEncoder) {
+	w.protocolEncoders = encoders
+}
+
+// getEncoder returns the protocol and the encoder of the table.
+func (w *KafkaDDLWorker) getEncoder(schema, table string) (config.Protocol, common.EventEncoder) {
+	// the DDL events of the schemas are sent to the default topic
+	if len(w.protocolEncoders) == 0 || table == "" {
+		return w.protocol, w.encoder
+	}
+	protocol := w.eventRouter.GetProtocolForTable(schema, table)
+	if encoder, ok := w.protocolEncoders[protocol]; ok {
+		return protocol, encoder
+	}
+	return w.protocol, w.encoder
+}
+
 func (w *KafkaDDLWorker) WriteBlockEvent(ctx context.Context, event *event.DDLEvent) error {
 	for _, e := range event.GetEvents() {
-		message, err := w.encoder.EncodeDDLEvent(e)
+		if w.ddlTopic != nil {
+			if err := w.sendToDDLTopic(ctx, e); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		protocol, encoder := w.getEncoder(ddlSchemaTableName(e))
+		message, err := encoder.EncodeDDLEvent(e)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		topic := w.eventRouter.GetTopicForDDL(e)
 
-		if w.partitionRule == PartitionAll {
+		if getDDLDispatchRule(protocol) == PartitionAll {
 			partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
 			if err != nil {
 				return errors.Trace(err)
@@ -137,6 +191,133 @@ func (w *KafkaDDLWorker) WriteBlockEvent(ctx context.Context, event *event.DDLEv
 	return nil
 }
 
+// ddlSchemaTableName returns the table the DDL event is routed by, it's the same table
+// used by the event router to decide the topic of the DDL event.
+func ddlSchemaTableName(e *event.DDLEvent) (string, string) {
+	if e.GetPrevSchemaName() != "" {
+		return e.GetPrevSchemaName(), e.GetPrevTableName()
+	}
+	return e.GetCurrentSchemaName(), e.GetCurrentTableName()
+}
+
+// sendToDDLTopic encodes the DDL event by the protocol of the DDL topic and sends it to the DDL topic.
+func (w *KafkaDDLWorker) sendToDDLTopic(ctx context.Context, e *event.DDLEvent) error {
+	message, err := w.ddlTopic.Encoder.EncodeDDLEvent(e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	topic := w.ddlTopic.Name
+	var partition int32
+	if w.ddlTopic.Partition != nil {
+		partition = *w.ddlTopic.Partition
+	} else if getDDLDispatchRule(w.ddlTopic.Protocol) == PartitionAll {
+		partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return w.statistics.RecordDDLExecution(func() error {
+			return w.producer.SyncBroadcastMessage(ctx, topic, partitionNum, message)
+		})
+	}
+	return w.statistics.RecordDDLExecution(func() error {
+		return w.producer.SyncSendMessage(ctx, topic, partition, message)
+	})
+}
+
+// SetRowCountAuditTopic sets the dedicated topic the row count audit messages are sent to.
+func (w *KafkaDDLWorker) SetRowCountAuditTopic(topic string) {
+	w.rowCountAuditTopic = topic
+}
+
+// WriteRowCountAudit sends the row counts to the row count audit topic, so the
+// consumers of the data topics never see the audit messages.
+func (w *KafkaDDLWorker) WriteRowCountAudit(ctx context.Context, counts []audit.RowCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	value, err := audit.EncodeMessage(counts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// create the audit topic if it does not exist, the audit messages are
+	// ordered by the checkpoints, so they are always sent to partition 0.
+	if _, err = w.topicManager.GetPartitionNum(ctx, w.rowCountAuditTopic); err != nil {
+		return errors.Trace(err)
+	}
+	return w.producer.SyncSendMessage(ctx, w.rowCountAuditTopic, 0, common.NewMsg(nil, value))
+}
+
+// EOFMessageType is the type of the message sent to the MQ downstream when the changefeed is removed.
+const EOFMessageType = "EOF"
+
+// EOFMessageKey is the key of the EOF message.
+var EOFMessageKey = []byte("ticdc-eof")
+
+// eofMessage tells the consumers no more events of the changefeed will be sent to the topic.
+// It's a control message independent of the protocol of the sink: it's not encoded by the
+// configured encoder, the consumers identify it by EOFMessageKey and decode the value as JSON.
+type eofMessage struct {
+	Type         string `json:"type"`
+	ClusterID    string `json:"cluster_id"`
+	Namespace    string `json:"namespace"`
+	Changefeed   string `json:"changefeed"`
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+}
+
+// WriteEOF broadcasts the EOF message to all partitions of the topics of the changefeed,
+// including the default topic and the DDL topic. The final checkpoint ts is sent by the
+// configured encoder before the EOF message, so the consumers see it before the EOF.
+func (w *KafkaDDLWorker) WriteEOF(ctx context.Context) error {
+	checkpointTs := w.checkpointTs.Load()
+	if checkpointTs != 0 && w.tableSchemaStore != nil {
+		if _, err := w.sendCheckpoint(ctx, checkpointTs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	value, err := json.Marshal(&eofMessage{
+		Type:         EOFMessageType,
+		ClusterID:    config.GetGlobalServerConfig().ClusterID,
+		Namespace:    w.changeFeedID.Namespace(),
+		Changefeed:   w.changeFeedID.Name(),
+		CheckpointTs: checkpointTs,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	topics := []string{w.eventRouter.GetDefaultTopic()}
+	if w.ddlTopic != nil {
+		topics = append(topics, w.ddlTopic.Name)
+	}
+	if w.tableSchemaStore != nil {
+		tableNames := w.tableSchemaStore.GetAllTableNames(checkpointTs)
+		if w.tableRouter != nil {
+			tableNames = w.tableRouter.RouteSchemaTableNames(tableNames)
+		}
+		topics = append(topics, w.eventRouter.GetActiveTopics(tableNames)...)
+	}
+	sent := make(map[string]struct{}, len(topics))
+	for _, topic := range topics {
+		if _, ok := sent[topic]; ok {
+			continue
+		}
+		sent[topic] = struct{}{}
+		partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = w.producer.SyncBroadcastMessage(ctx, topic, partitionNum, common.NewMsg(EOFMessageKey, value))
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	log.Info("EOF message sent",
+		zap.String("namespace", w.changeFeedID.Namespace()),
+		zap.String("changefeed", w.changeFeedID.Name()),
+		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Strings("topics", topics))
+	return nil
+}
+
 func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents(ctx context.Context) error {
 	checkpointTsMessageDuration := metrics.CheckpointTsMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	checkpointTsMessageCount := metrics.CheckpointTsMessageCount.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
@@ -146,11 +327,30 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents(ctx context.Context) erro
 		metrics.CheckpointTsMessageCount.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	}()
 
+	// the watermark timer is nil if the watermark is disabled, it's reset after each
+	// message, so the checkpoint ts is sent again once no message is sent in the interval.
 	var (
-		msg          *common.Message
-		partitionNum int32
-		err          error
+		watermarkTimer <-chan time.Time
+		resetWatermark = func() {}
+		lastTs         uint64
 	)
+	if w.watermark != nil {
+		timer := time.NewTimer(w.watermark.GetInterval())
+		defer timer.Stop()
+		watermarkTimer = timer.C
+		resetWatermark = func() { timer.Reset(w.watermark.GetInterval()) }
+	}
+	send := func(ts uint64) error {
+		start := time.Now()
+		sent, err := w.sendCheckpoint(ctx, ts)
+		resetWatermark()
+		if err != nil || !sent {
+			return err
+		}
+		checkpointTsMessageCount.Inc()
+		checkpointTsMessageDuration.Observe(time.Since(start).Seconds())
+		return nil
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -162,50 +362,98 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents(ctx context.Context) erro
 					zap.String("changefeed", w.changeFeedID.Name()))
 				return nil
 			}
-			start := time.Now()
-
-			msg, err = w.encoder.EncodeCheckpointEvent(ts)
-			if err != nil {
+			lastTs = max(lastTs, ts)
+			if err := send(ts); err != nil {
 				return errors.Trace(err)
 			}
-
-			if msg == nil {
+		case <-watermarkTimer:
+			if lastTs == 0 {
+				resetWatermark()
 				continue
 			}
-			tableNames := w.tableSchemaStore.GetAllTableNames(ts)
-			// NOTICE: When there are no tables to replicate,
-			// we need to send checkpoint ts to the default topic.
-			// This will be compatible with the old behavior.
-			if len(tableNames) == 0 {
-				topic := w.eventRouter.GetDefaultTopic()
-				partitionNum, err = w.topicManager.GetPartitionNum(ctx, topic)
-				if err != nil {
-					return errors.Trace(err)
-				}
-				log.Debug("Emit checkpointTs to default topic",
-					zap.String("topic", topic), zap.Uint64("checkpointTs", ts), zap.Any("partitionNum", partitionNum))
-				err = w.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
-				if err != nil {
-					return errors.Trace(err)
-				}
-			} else {
-				topics := w.eventRouter.GetActiveTopics(tableNames)
-				for _, topic := range topics {
-					partitionNum, err = w.topicManager.GetPartitionNum(ctx, topic)
-					if err != nil {
-						return errors.Trace(err)
-					}
-					err = w.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
-					if err != nil {
-						return errors.Trace(err)
-					}
-				}
+			if err := send(lastTs); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// sendCheckpoint sends the checkpoint ts to the topics of the tables, it returns
+// false if the protocol doesn't encode the checkpoint ts.
+func (w *KafkaDDLWorker) sendCheckpoint(ctx context.Context, ts uint64) (bool, error) {
+	msg, err := w.encoder.EncodeCheckpointEvent(ts)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if msg == nil && len(w.protocolEncoders) == 0 {
+		return false, nil
+	}
+	tableNames := w.tableSchemaStore.GetAllTableNames(ts)
+	if w.tableRouter != nil {
+		tableNames = w.tableRouter.RouteSchemaTableNames(tableNames)
+	}
+	// NOTICE: When there are no tables to replicate,
+	// we need to send checkpoint ts to the default topic.
+	// This will be compatible with the old behavior.
+	var topics []string
+	if len(tableNames) == 0 {
+		topics = []string{w.eventRouter.GetDefaultTopic()}
+		log.Debug("Emit checkpointTs to default topic",
+			zap.String("topic", topics[0]), zap.Uint64("checkpointTs", ts))
+	} else {
+		topics = w.eventRouter.GetActiveTopics(tableNames)
+	}
+	// the topics of the tables with an override protocol get the checkpoint message of that protocol
+	messages := map[config.Protocol]*common.Message{w.protocol: msg}
+	topicProtocols := w.getTopicProtocols(tableNames)
+	sent := false
+	for _, topic := range topics {
+		protocol, ok := topicProtocols[topic]
+		if !ok {
+			protocol = w.protocol
+		}
+		message, ok := messages[protocol]
+		if !ok {
+			message, err = w.protocolEncoders[protocol].EncodeCheckpointEvent(ts)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			messages[protocol] = message
+		}
+		if message == nil {
+			continue
+		}
+		sent = true
+		if w.watermark != nil && w.watermark.GetScope() == config.WatermarkScopeTopic {
+			err = w.producer.SyncSendMessage(ctx, topic, 0, message)
+		} else {
+			var partitionNum int32
+			partitionNum, err = w.topicManager.GetPartitionNum(ctx, topic)
+			if err != nil {
+				return false, errors.Trace(err)
 			}
+			err = w.producer.SyncBroadcastMessage(ctx, topic, partitionNum, message)
+		}
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	return sent, nil
+}
 
-			checkpointTsMessageCount.Inc()
-			checkpointTsMessageDuration.Observe(time.Since(start).Seconds())
+// getTopicProtocols returns the override protocols of the topics of the tables.
+func (w *KafkaDDLWorker) getTopicProtocols(tableNames []*event.SchemaTableName) map[string]config.Protocol {
+	if len(w.protocolEncoders) == 0 {
+		return nil
+	}
+	topicProtocols := make(map[string]config.Protocol)
+	for _, name := range tableNames {
+		protocol, _ := w.getEncoder(name.SchemaName, name.TableName)
+		if protocol != w.protocol {
+			topicProtocols[w.eventRouter.GetTopicForTable(name.SchemaName, name.TableName)] = protocol
 		}
 	}
+	return topicProtocols
 }
 
 func (w *KafkaDDLWorker) Close() {
@@ -15,6 +15,7 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"testing"
@@ -26,6 +27,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
 	"github.com/pingcap/ticdc/pkg/sink/kafka"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/pkg/errors"
@@ -35,14 +37,18 @@ import (
 // ddl | checkpoint ts
 
 func kafkaDDLWorkerForTest(t *testing.T) *KafkaDDLWorker {
+	return kafkaDDLWorkerWithAutoCreateTopicForTest(t, false)
+}
+
+func kafkaDDLWorkerWithAutoCreateTopicForTest(t *testing.T, autoCreateTopic bool) *KafkaDDLWorker {
 	ctx := context.Background()
 	changefeedID := common.NewChangefeedID4Test("test", "test")
 	openProtocol := "open-protocol"
 	sinkConfig := &config.SinkConfig{Protocol: &openProtocol}
 	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
 		"&max-message-bytes=1048576&partition-num=1" +
-		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip&protocol=open-protocol"
-	uri := fmt.Sprintf(uriTemplate, "127.0.0.1:9092", kafka.DefaultMockTopicName)
+		"&kafka-client-id=unit-test&auto-create-topic=%t&compression=gzip&protocol=open-protocol"
+	uri := fmt.Sprintf(uriTemplate, "127.0.0.1:9092", kafka.DefaultMockTopicName, autoCreateTopic)
 
 	sinkURI, err := url.Parse(uri)
 	require.NoError(t, err)
@@ -52,8 +58,8 @@ func kafkaDDLWorkerForTest(t *testing.T) *KafkaDDLWorker {
 	statistics := metrics.NewStatistics(changefeedID, "KafkaSink")
 	ddlMockProducer := producer.NewMockDDLProducer()
 	ddlWorker := NewKafkaDDLWorker(changefeedID, protocol, ddlMockProducer,
-		kafkaComponent.Encoder, kafkaComponent.EventRouter, kafkaComponent.TopicManager,
-		statistics)
+		kafkaComponent.Encoder, kafkaComponent.EventRouter, kafkaComponent.TableRouter, kafkaComponent.TopicManager,
+		statistics, kafkaComponent.DDLTopic, nil)
 	return ddlWorker
 }
 
@@ -130,3 +136,131 @@ func TestWriteCheckpointTs(t *testing.T) {
 	require.Len(t, ddlWorker.producer.(*producer.MockProducer).GetAllEvents(), 2)
 	cancel()
 }
+
+func TestWriteWatermark(t *testing.T) {
+	ddlWorker := kafkaDDLWorkerForTest(t)
+	ddlWorker.watermark = &config.WatermarkConfig{Interval: "100ms", Scope: config.WatermarkScopeTopic}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchemaStore := util.NewTableSchemaStore([]*heartbeatpb.SchemaInfo{}, common.KafkaSinkType)
+	ddlWorker.SetTableSchemaStore(tableSchemaStore)
+	go func() {
+		_ = ddlWorker.Run(ctx)
+	}()
+
+	mockProducer := ddlWorker.producer.(*producer.MockProducer)
+	// no watermark is sent before the first checkpoint ts
+	time.Sleep(300 * time.Millisecond)
+	require.Empty(t, mockProducer.GetAllEvents())
+
+	// the checkpoint ts is sent again even if it doesn't advance
+	ddlWorker.AddCheckpoint(1)
+	require.Eventually(t, func() bool {
+		return len(mockProducer.GetEvents(kafka.DefaultMockTopicName, 0)) >= 3
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestWriteEOF(t *testing.T) {
+	ddlWorker := kafkaDDLWorkerForTest(t)
+	tableSchemaStore := util.NewTableSchemaStore([]*heartbeatpb.SchemaInfo{}, common.KafkaSinkType)
+	ddlWorker.SetTableSchemaStore(tableSchemaStore)
+	ddlWorker.AddCheckpoint(10)
+	// the checkpoint ts never goes back
+	ddlWorker.AddCheckpoint(5)
+	require.Equal(t, uint64(10), ddlWorker.checkpointTs.Load())
+
+	err := ddlWorker.WriteEOF(context.Background())
+	require.NoError(t, err)
+
+	// the final checkpoint ts encoded by the configured encoder is sent before the EOF message
+	events := ddlWorker.producer.(*producer.MockProducer).GetEvents(kafka.DefaultMockTopicName, 0)
+	require.Len(t, events, 2)
+	expected, err := ddlWorker.encoder.EncodeCheckpointEvent(10)
+	require.NoError(t, err)
+	require.Equal(t, expected.Value, events[0].Value)
+	require.Equal(t, EOFMessageKey, events[1].Key)
+	var msg eofMessage
+	require.NoError(t, json.Unmarshal(events[1].Value, &msg))
+	require.Equal(t, EOFMessageType, msg.Type)
+	require.Equal(t, "test", msg.Changefeed)
+	require.Equal(t, uint64(10), msg.CheckpointTs)
+}
+
+func TestWriteRowCountAudit(t *testing.T) {
+	// the audit topic is created like the other topics
+	ddlWorker := kafkaDDLWorkerWithAutoCreateTopicForTest(t, true)
+	ddlWorker.SetRowCountAuditTopic("audit")
+
+	counts := []audit.RowCount{
+		{Schema: "test", Table: "t1", TableID: 1, StartTs: 1, EndTs: 10, Count: 2},
+		{Schema: "test", Table: "t2", TableID: 2, StartTs: 1, EndTs: 10, Count: 3},
+	}
+	err := ddlWorker.WriteRowCountAudit(context.Background(), counts)
+	require.NoError(t, err)
+
+	mockProducer := ddlWorker.producer.(*producer.MockProducer)
+	// the audit messages are never sent to the data topics
+	require.Empty(t, mockProducer.GetEvents(kafka.DefaultMockTopicName, 0))
+	events := mockProducer.GetEvents("audit", 0)
+	require.Len(t, events, 1)
+	expected, err := audit.EncodeMessage(counts)
+	require.NoError(t, err)
+	require.Equal(t, expected, events[0].Value)
+}
+
+func TestWriteDDLEventsToDDLTopic(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	ctx := context.Background()
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	openProtocol, canalJSONProtocol := "open-protocol", "canal-json"
+	sinkConfig := &config.SinkConfig{
+		Protocol: &openProtocol,
+		DDLTopic: &config.DDLTopicConfig{
+			Topic:    "ddl-events",
+			Protocol: &canalJSONProtocol,
+		},
+	}
+	uri := fmt.Sprintf("kafka://127.0.0.1:9092/%s?kafka-version=0.9.0.0&max-message-bytes=1048576"+
+		"&partition-num=1&kafka-client-id=unit-test&auto-create-topic=true&protocol=open-protocol",
+		kafka.DefaultMockTopicName)
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	kafkaComponent, protocol, err := GetKafkaSinkComponentForTest(ctx, changefeedID, sinkURI, sinkConfig)
+	require.NoError(t, err)
+	require.NotNil(t, kafkaComponent.DDLTopic, nil)
+	require.Equal(t, config.ProtocolCanalJSON, kafkaComponent.DDLTopic.Protocol)
+
+	ddlWorker := NewKafkaDDLWorker(changefeedID, protocol, producer.NewMockDDLProducer(),
+		kafkaComponent.Encoder, kafkaComponent.EventRouter, kafkaComponent.TableRouter, kafkaComponent.TopicManager,
+		metrics.NewStatistics(changefeedID, "KafkaSink"), kafkaComponent.DDLTopic, nil)
+
+	flushed := false
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		PostTxnFlushed: []func(){
+			func() { flushed = true },
+		},
+	}
+	require.NoError(t, ddlWorker.WriteBlockEvent(ctx, ddlEvent))
+	require.True(t, flushed)
+
+	mockProducer := ddlWorker.producer.(*producer.MockProducer)
+	require.Len(t, mockProducer.GetAllEvents(), 1)
+	messages := mockProducer.GetEvents("ddl-events", 0)
+	require.Len(t, messages, 1)
+	require.Contains(t, string(messages[0].Value), `"isDdl":true`)
+	require.Empty(t, mockProducer.GetEvents(kafka.DefaultMockTopicName, 0))
+}
@@ -26,22 +26,19 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/faultinject"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/codec"
+	codecCommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/tracing"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	// batchSize is the maximum size of the number of messages in a batch.
-	batchSize = 2048
-	// batchInterval is the interval of the worker to collect a batch of messages.
-	// It shouldn't be too large, otherwise it will lead to a high latency.
-	batchInterval = 15 * time.Millisecond
-)
-
 // KafkaDMLWorker worker will send messages to the DML producer on a batch basis.
 type KafkaDMLWorker struct {
 	changeFeedID common.ChangeFeedID
@@ -63,6 +60,22 @@ type KafkaDMLWorker struct {
 
 	// statistics is used to record DML metrics.
 	statistics *metrics.Statistics
+
+	// batchSize is the maximum size of the number of messages in a batch.
+	batchSize int
+	// batchInterval is the interval of the worker to collect a batch of messages.
+	// Both of them are decided by the latency mode of the changefeed.
+	batchInterval time.Duration
+
+	// deadLetterTopic is the topic the rows which fail to be encoded are sent to,
+	// it's empty if the dead letter queue is disabled.
+	deadLetterTopic string
+	// deadLetterTopicReady indicates the dead letter topic has been created.
+	deadLetterTopicReady bool
+
+	// ttlDeletes decides how to handle the rows deleted by the TTL jobs,
+	// they are replicated as the other rows if it's nil.
+	ttlDeletes *config.TTLDeletesConfig
 }
 
 // NewKafkaDMLWorker creates a dml flush worker for kafka
@@ -75,7 +88,10 @@ func NewKafkaDMLWorker(
 	eventRouter *eventrouter.EventRouter,
 	topicManager topicmanager.TopicManager,
 	statistics *metrics.Statistics,
+	latencyMode config.LatencyMode,
+	deadLetterTopic string,
 ) *KafkaDMLWorker {
+	profile := latencyMode.Profile()
 	return &KafkaDMLWorker{
 		changeFeedID:   id,
 		protocol:       protocol,
@@ -87,9 +103,18 @@ func NewKafkaDMLWorker(
 		topicManager:   topicManager,
 		producer:       producer,
 		statistics:     statistics,
+		batchSize:      profile.SinkBatchSize,
+		batchInterval:  profile.MQBatchInterval,
+
+		deadLetterTopic: deadLetterTopic,
 	}
 }
 
+// SetTTLDeletes sets how to handle the rows deleted by the TTL jobs.
+func (w *KafkaDMLWorker) SetTTLDeletes(cfg *config.TTLDeletesConfig) {
+	w.ttlDeletes = cfg
+}
+
 func (w *KafkaDMLWorker) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
 	g.Go(func() error {
@@ -144,6 +169,7 @@ func (w *KafkaDMLWorker) calculateKeyPartitions(ctx context.Context) error {
 
 			rowsCount := uint64(event.Len())
 			rowCallback := toRowCallback(event.PostTxnFlushed, rowsCount)
+			ttlAction := w.ttlDeletes.GetAction()
 
 			for {
 				row, ok := event.GetNextRow()
@@ -151,17 +177,30 @@ func (w *KafkaDMLWorker) calculateKeyPartitions(ctx context.Context) error {
 					break
 				}
 
-				index, key, err := partitionGenerator.GeneratePartitionIndexAndKey(&row, partitionNum, event.TableInfo, event.CommitTs)
+				rowTopic, rowPartitionNum := topic, partitionNum
+				if ttlAction != config.TTLDeleteActionReplicate && isTTLDelete(&row, event.TxnSource) {
+					if ttlAction == config.TTLDeleteActionDrop {
+						rowCallback()
+						continue
+					}
+					rowTopic = w.ttlDeletes.Topic
+					rowPartitionNum, err = w.topicManager.GetPartitionNum(ctx, rowTopic)
+					if err != nil {
+						return errors.Trace(err)
+					}
+				}
+
+				index, key, err := partitionGenerator.GeneratePartitionIndexAndKey(&row, rowPartitionNum, event.TableInfo, event.CommitTs)
 				if err != nil {
 					return errors.Trace(err)
 				}
 
 				mqEvent := &commonEvent.MQRowEvent{
 					Key: model.TopicPartitionKey{
-						Topic:          topic,
+						Topic:          rowTopic,
 						Partition:      index,
 						PartitionKey:   key,
-						TotalPartition: partitionNum,
+						TotalPartition: rowPartitionNum,
 					},
 					RowEvent: commonEvent.RowEvent{
 						TableInfo:      event.TableInfo,
@@ -181,6 +220,12 @@ func (w *KafkaDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent) {
 	w.eventChan <- event
 }
 
+// ResendTableSchema makes the bootstrap messages of the tables resent, it returns
+// false if the protocol does not send bootstrap messages.
+func (w *KafkaDMLWorker) ResendTableSchema(tableIDs []int64) bool {
+	return w.encoderGroup.ResendBootstrap(tableIDs)
+}
+
 func (w *KafkaDMLWorker) addMQRowEvent(event *commonEvent.MQRowEvent) {
 	w.rowChan <- event
 }
@@ -226,9 +271,9 @@ func (w *KafkaDMLWorker) batchEncodeRun(ctx context.Context) error {
 		metrics.WorkerBatchSize.DeleteLabelValues(namespace, changefeed)
 	}()
 
-	ticker := time.NewTicker(batchInterval)
+	ticker := time.NewTicker(w.batchInterval)
 	defer ticker.Stop()
-	msgsBuf := make([]*commonEvent.MQRowEvent, batchSize)
+	msgsBuf := make([]*commonEvent.MQRowEvent, w.batchSize)
 	for {
 		start := time.Now()
 		msgCount, err := w.batch(ctx, msgsBuf, ticker)
@@ -280,7 +325,7 @@ func (w *KafkaDMLWorker) batch(ctx context.Context, buffer []*commonEvent.MQRowE
 
 	// Reset the ticker to start a new batching.
 	// We need to stop batching when the interval is reached.
-	ticker.Reset(batchInterval)
+	ticker.Reset(w.batchInterval)
 	for {
 		select {
 		case <-ctx.Done():
@@ -335,24 +380,73 @@ func (w *KafkaDMLWorker) sendMessages(ctx context.Context) error {
 			if err = future.Ready(ctx); err != nil {
 				return errors.Trace(err)
 			}
-			for _, message := range future.Messages {
-				start := time.Now()
-				if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
-					if err = w.producer.AsyncSendMessage(
-						ctx,
-						future.Key.Topic,
-						future.Key.Partition,
-						message); err != nil {
-						return 0, 0, err
-					}
-					return message.GetRowsCount(), int64(message.Length()), nil
-				}); err != nil {
-					return errors.Trace(err)
-				}
-				metricSendMessageDuration.Observe(time.Since(start).Seconds())
+			// the messages are held if the sink of the changefeed is stalled by the injected fault.
+			faultinject.GetInjector().WaitSinkStall(w.changeFeedID.DisplayName, ctx.Done())
+			_, span := tracing.Start(ctx, "sink.FlushDMLEvents",
+				attribute.String("sink", "kafka"),
+				attribute.String("changefeed", w.changeFeedID.Name()),
+				attribute.String("topic", future.Key.Topic),
+				attribute.Int("partition", int(future.Key.Partition)),
+				attribute.Int("messages", len(future.Messages)),
+				attribute.Int("deadLetters", len(future.DeadLetters)))
+			err = w.flushMessages(ctx, future.Key, future.Messages, future.DeadLetters, metricSendMessageDuration)
+			tracing.End(span, err)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// flushMessages sends the encoded messages of the topic partition to the producer,
+// and the messages of the rows which fail to be encoded to the dead letter topic.
+func (w *KafkaDMLWorker) flushMessages(
+	ctx context.Context,
+	key model.TopicPartitionKey,
+	messages, deadLetters []*codecCommon.Message,
+	metricSendMessageDuration prometheus.Observer,
+) error {
+	for _, message := range messages {
+		start := time.Now()
+		if err := w.statistics.RecordBatchExecution(func() (int, int64, error) {
+			// the message is released once it's acked by the broker,
+			// so collect the statistics before sending it.
+			rowsCount, length := message.GetRowsCount(), int64(message.Length())
+			if err := w.producer.AsyncSendMessage(ctx, key.Topic, key.Partition, message); err != nil {
+				return 0, 0, err
 			}
+			return rowsCount, length, nil
+		}); err != nil {
+			return errors.Trace(err)
 		}
+		metricSendMessageDuration.Observe(time.Since(start).Seconds())
+	}
+	for _, message := range deadLetters {
+		if err := w.sendDeadLetter(ctx, message); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// sendDeadLetter sends the message of the row which fails to be encoded to the dead letter topic.
+func (w *KafkaDMLWorker) sendDeadLetter(ctx context.Context, message *codecCommon.Message) error {
+	if w.deadLetterTopic == "" {
+		return errors.ErrKafkaSendMessage.GenWithStack("dead letter topic is not set")
+	}
+	if !w.deadLetterTopicReady {
+		// the topic is created if it doesn't exist.
+		if _, err := w.topicManager.GetPartitionNum(ctx, w.deadLetterTopic); err != nil {
+			return errors.Trace(err)
+		}
+		w.deadLetterTopicReady = true
+	}
+	if err := w.producer.AsyncSendMessage(ctx, w.deadLetterTopic, 0, message); err != nil {
+		return errors.Trace(err)
 	}
+	metrics.DeadLetterQueueRowsCounter.
+		WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name()).Inc()
+	return nil
 }
 
 func (w *KafkaDMLWorker) Close() {
@@ -28,6 +28,10 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 var count int
@@ -53,12 +57,16 @@ func kafkaDMLWorkerForTest(t *testing.T) *KafkaDMLWorker {
 	dmlWorker := NewKafkaDMLWorker(changefeedID, protocol, dmlMockProducer,
 		kafkaComponent.EncoderGroup, kafkaComponent.ColumnSelector,
 		kafkaComponent.EventRouter, kafkaComponent.TopicManager,
-		statistics)
+		statistics, config.LatencyModeBalanced, "")
 	return dmlWorker
 }
 
 func TestWriteEvents(t *testing.T) {
 	count = 0
+	recorder := tracetest.NewSpanRecorder()
+	old := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(old)
 
 	helper := commonEvent.NewEventTestHelper(t)
 	defer helper.Close()
@@ -88,4 +96,13 @@ func TestWriteEvents(t *testing.T) {
 	require.Len(t, dmlWorker.producer.(*producer.MockProducer).GetAllEvents(), 2)
 	require.Equal(t, count, 1)
 	cancel()
+
+	// the flush of the messages of each topic partition is traced
+	spans := recorder.Ended()
+	require.NotEmpty(t, spans)
+	for _, span := range spans {
+		require.Equal(t, "sink.FlushDMLEvents", span.Name())
+		require.Contains(t, span.Attributes(), attribute.String("sink", "kafka"))
+		require.Contains(t, span.Attributes(), attribute.String("topic", kafka.DefaultMockTopicName))
+	}
 }
@@ -23,9 +23,13 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/faultinject"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -38,6 +42,8 @@ type MysqlDMLWorker struct {
 	id          int
 
 	maxRows int
+	// flushInterval is the max time to wait for more events before flushing.
+	flushInterval time.Duration
 }
 
 func NewMysqlDMLWorker(
@@ -50,11 +56,12 @@ func NewMysqlDMLWorker(
 	formatVectorType bool,
 ) *MysqlDMLWorker {
 	return &MysqlDMLWorker{
-		mysqlWriter:  mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics, formatVectorType),
-		id:           id,
-		maxRows:      config.MaxTxnRow,
-		eventChan:    make(chan *commonEvent.DMLEvent, 16),
-		changefeedID: changefeedID,
+		mysqlWriter:   mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics, formatVectorType),
+		id:            id,
+		maxRows:       config.MaxTxnRow,
+		flushInterval: config.FlushInterval,
+		eventChan:     make(chan *commonEvent.DMLEvent, 16),
+		changefeedID:  changefeedID,
 	}
 }
 
@@ -91,7 +98,7 @@ func (w *MysqlDMLWorker) Run(ctx context.Context) error {
 				needFlush = true
 			}
 			if !needFlush {
-				delay := time.NewTimer(10 * time.Millisecond)
+				delay := time.NewTimer(w.flushInterval)
 				for !needFlush {
 					select {
 					case txnEvent := <-w.eventChan:
@@ -113,8 +120,17 @@ func (w *MysqlDMLWorker) Run(ctx context.Context) error {
 					}
 				}
 			}
+			// the flush is blocked if the sink of the changefeed is stalled by the injected fault.
+			faultinject.GetInjector().WaitSinkStall(w.changefeedID.DisplayName, ctx.Done())
 			start := time.Now()
+			_, span := tracing.Start(ctx, "sink.FlushDMLEvents",
+				attribute.String("sink", "mysql"),
+				attribute.String("changefeed", w.changefeedID.Name()),
+				attribute.Int("worker", w.id),
+				attribute.Int("events", len(events)),
+				attribute.Int("rows", rows))
 			err := w.mysqlWriter.Flush(events)
+			tracing.End(span, err)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -196,10 +212,25 @@ func (w *MysqlDDLWorker) WriteBlockEvent(event commonEvent.BlockEvent) error {
 	return nil
 }
 
+// WriteRowCountAudit writes the row counts into the row count audit table.
+func (w *MysqlDDLWorker) WriteRowCountAudit(counts []audit.RowCount) error {
+	return w.mysqlWriter.FlushRowCountAudit(counts)
+}
+
 func (w *MysqlDDLWorker) RemoveDDLTsItem() error {
 	return w.mysqlWriter.RemoveDDLTsItem()
 }
 
+// RemoveDMLProgress removes the dml progress of the changefeed written in the exactly-once mode.
+func (w *MysqlDDLWorker) RemoveDMLProgress() error {
+	return w.mysqlWriter.RemoveDMLProgress()
+}
+
+// RemoveSyncPointRows removes the syncpoint rows of the changefeed.
+func (w *MysqlDDLWorker) RemoveSyncPointRows() error {
+	return w.mysqlWriter.RemoveSyncPointRows()
+}
+
 func (w *MysqlDDLWorker) Close() {
 	w.mysqlWriter.Close()
 }
@@ -0,0 +1,26 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+)
+
+// isTTLDelete returns true if the row is deleted by a TTL job. The internal session
+// of the TTL job marks its transactions in the txn source, so the user deletes of
+// the expired rows are not taken as TTL deletes.
+func isTTLDelete(row *commonEvent.RowChange, txnSource uint64) bool {
+	return row.RowType == commonEvent.RowTypeDelete && common.IsTTLJobSource(txnSource)
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTTLDelete(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, created_at datetime) TTL = `created_at` + INTERVAL 1 DAY")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, '2024-01-01 00:00:00')")
+	insert, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	ttlSource := common.SetTTLJobSource(0, common.TTLJobDeleteSource)
+	require.False(t, isTTLDelete(&insert, ttlSource))
+
+	deleted := commonEvent.RowChange{PreRow: insert.Row, RowType: commonEvent.RowTypeDelete}
+	require.True(t, isTTLDelete(&deleted, ttlSource))
+	// a user delete of the expired row is not a TTL delete.
+	require.False(t, isTTLDelete(&deleted, 0))
+	// the txn source written by TiCDC for the bidirectional replication is not a TTL job source.
+	require.False(t, isTTLDelete(&deleted, 1))
+}
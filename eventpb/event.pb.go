@@ -188,6 +188,9 @@ type FilterConfig struct {
 	Rules            []string           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
 	IgnoreTxnStartTs []uint64           `protobuf:"varint,2,rep,packed,name=ignore_txn_start_ts,json=ignoreTxnStartTs,proto3" json:"ignore_txn_start_ts,omitempty"`
 	EventFilters     []*EventFilterRule `protobuf:"bytes,3,rep,name=EventFilters,proto3" json:"EventFilters,omitempty"`
+	CaseSensitive    bool               `protobuf:"varint,4,opt,name=case_sensitive,json=caseSensitive,proto3" json:"case_sensitive,omitempty"`
+	TimeZone         string             `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	DisableOldValue  bool               `protobuf:"varint,6,opt,name=disable_old_value,json=disableOldValue,proto3" json:"disable_old_value,omitempty"`
 }
 
 func (m *FilterConfig) Reset()         { *m = FilterConfig{} }
@@ -244,6 +247,27 @@ func (m *FilterConfig) GetEventFilters() []*EventFilterRule {
 	return nil
 }
 
+func (m *FilterConfig) GetCaseSensitive() bool {
+	if m != nil {
+		return m.CaseSensitive
+	}
+	return false
+}
+
+func (m *FilterConfig) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *FilterConfig) GetDisableOldValue() bool {
+	if m != nil {
+		return m.DisableOldValue
+	}
+	return false
+}
+
 type ResolvedTs struct {
 }
 
@@ -534,6 +558,7 @@ type RegisterDispatcherRequest struct {
 	SyncPointTs       uint64                    `protobuf:"varint,9,opt,name=sync_point_ts,json=syncPointTs,proto3" json:"sync_point_ts,omitempty"`
 	SyncPointInterval uint64                    `protobuf:"varint,10,opt,name=sync_point_interval,json=syncPointInterval,proto3" json:"sync_point_interval,omitempty"`
 	OnlyReuse         bool                      `protobuf:"varint,11,opt,name=only_reuse,json=onlyReuse,proto3" json:"only_reuse,omitempty"`
+	ClusterId         uint64                    `protobuf:"varint,12,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
 }
 
 func (m *RegisterDispatcherRequest) Reset()         { *m = RegisterDispatcherRequest{} }
@@ -646,6 +671,13 @@ func (m *RegisterDispatcherRequest) GetOnlyReuse() bool {
 	return false
 }
 
+func (m *RegisterDispatcherRequest) GetClusterId() uint64 {
+	if m != nil {
+		return m.ClusterId
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
@@ -662,68 +694,71 @@ func init() {
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 962 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x51, 0x6f, 0xe2, 0x46,
-	0x17, 0x8d, 0x81, 0x00, 0xbe, 0x26, 0x1b, 0x67, 0xb2, 0xd9, 0xcf, 0xd9, 0xec, 0xf2, 0xb1, 0x3c,
-	0x54, 0x34, 0x52, 0x49, 0x4b, 0x5b, 0x55, 0x5a, 0x55, 0x2b, 0xa5, 0xe0, 0x6c, 0xfd, 0xb0, 0x09,
-	0x1a, 0x9c, 0x95, 0xda, 0x17, 0xcb, 0xd8, 0x03, 0x71, 0xeb, 0x8c, 0x1d, 0xcf, 0xc0, 0xc2, 0xbf,
-	0x68, 0x9f, 0xfb, 0x87, 0xfa, 0xb8, 0x8f, 0x7d, 0x6b, 0x95, 0x48, 0xed, 0xdf, 0xa8, 0x3c, 0x63,
-	0x8c, 0x59, 0xaa, 0x4a, 0x7d, 0x62, 0xe6, 0x9e, 0x73, 0x67, 0xee, 0x39, 0x73, 0xaf, 0x81, 0x43,
-	0x32, 0x27, 0x94, 0xc7, 0xe3, 0x33, 0xf1, 0xdb, 0x8d, 0x93, 0x88, 0x47, 0xa8, 0x96, 0x05, 0x9f,
-	0x9e, 0xdc, 0x10, 0x37, 0xe1, 0x63, 0xe2, 0xa6, 0x8c, 0x7c, 0x2d, 0x59, 0xed, 0xdf, 0x4b, 0xb0,
-	0x6f, 0xa6, 0xc4, 0x8b, 0x20, 0xe4, 0x24, 0xc1, 0xb3, 0x90, 0x20, 0x03, 0x6a, 0xb7, 0x2e, 0xf7,
-	0x6e, 0x48, 0x62, 0x28, 0xad, 0x72, 0x47, 0xc5, 0xab, 0x2d, 0x7a, 0x01, 0x8d, 0x60, 0x4a, 0xa3,
-	0x84, 0x38, 0xe2, 0x70, 0xa3, 0x24, 0x60, 0x4d, 0xc6, 0xc4, 0x31, 0xe8, 0x39, 0x40, 0x46, 0x61,
-	0x77, 0xa1, 0x51, 0x16, 0x04, 0x55, 0x46, 0x46, 0x77, 0x21, 0xfa, 0x0a, 0x8c, 0x0c, 0x0e, 0x28,
-	0x23, 0x09, 0x77, 0xe6, 0x6e, 0x38, 0x23, 0x0e, 0x59, 0xc4, 0x89, 0x51, 0x69, 0x29, 0x1d, 0x15,
-	0x1f, 0x49, 0xdc, 0x12, 0xf0, 0xdb, 0x14, 0x35, 0x17, 0x71, 0x82, 0x5e, 0xc1, 0xb3, 0x2c, 0x71,
-	0x16, 0xfb, 0x2e, 0x27, 0x0e, 0x25, 0xef, 0x8a, 0xc9, 0xbb, 0x22, 0x39, 0x3b, 0xfc, 0x5a, 0x50,
-	0x2e, 0xc9, 0xbb, 0x7f, 0xc9, 0x8f, 0x42, 0xbf, 0x98, 0x5f, 0xdd, 0xce, 0xbf, 0x0a, 0xfd, 0x75,
-	0xfe, 0xba, 0x70, 0x9f, 0x84, 0x84, 0x93, 0x62, 0x6e, 0xad, 0x58, 0xf8, 0x40, 0xc0, 0x79, 0x62,
-	0xfb, 0x67, 0x05, 0x1a, 0xd2, 0xdc, 0x7e, 0x44, 0x27, 0xc1, 0x14, 0x3d, 0x86, 0xdd, 0x64, 0x16,
-	0x12, 0x96, 0x99, 0x2b, 0x37, 0xe8, 0x13, 0x38, 0xcc, 0xce, 0xe7, 0x0b, 0xea, 0x30, 0xee, 0x26,
-	0xdc, 0xe1, 0x4c, 0x38, 0x5c, 0xc1, 0xba, 0x84, 0xec, 0x05, 0x1d, 0xa5, 0x80, 0xcd, 0xd0, 0xd7,
-	0xd0, 0x28, 0x3c, 0x1b, 0x13, 0x46, 0x6b, 0x3d, 0xa3, 0x9b, 0x3d, 0x7a, 0xf7, 0x83, 0x37, 0xc5,
-	0x1b, 0xec, 0x76, 0x03, 0x00, 0x13, 0x16, 0x85, 0x73, 0xe2, 0xdb, 0xac, 0x3d, 0x83, 0x5d, 0xf9,
-	0x76, 0x3a, 0x94, 0x7f, 0x24, 0x4b, 0x43, 0x69, 0x29, 0x9d, 0x06, 0x4e, 0x97, 0x69, 0xad, 0x42,
-	0xa7, 0x51, 0x12, 0x31, 0xb9, 0x41, 0x4f, 0xa1, 0xbe, 0xf2, 0xc6, 0x28, 0x0b, 0x20, 0xdf, 0xa3,
-	0x0e, 0xd4, 0xa2, 0xd8, 0xe1, 0xcb, 0x98, 0x88, 0xf7, 0x7c, 0xd4, 0xdb, 0xcf, 0x6b, 0xba, 0x8a,
-	0xed, 0x65, 0x4c, 0x70, 0x35, 0x12, 0xbf, 0xed, 0x1f, 0xa0, 0x6e, 0x2f, 0xa8, 0xbc, 0xf9, 0x23,
-	0xa8, 0x0a, 0x96, 0x34, 0x45, 0xeb, 0x3d, 0xda, 0x14, 0x82, 0x33, 0x14, 0x9d, 0x80, 0xea, 0x45,
-	0xb7, 0xb7, 0x41, 0xe6, 0x8d, 0xd2, 0xa9, 0xe0, 0xba, 0x0c, 0xd8, 0x0c, 0x1d, 0x43, 0x3d, 0xf7,
-	0xad, 0x2c, 0xb0, 0x1a, 0x93, 0x76, 0xb5, 0x35, 0x50, 0x6d, 0x77, 0x1c, 0x12, 0x8b, 0x4e, 0xa2,
-	0xf6, 0x5f, 0x0a, 0xa8, 0xd2, 0x0e, 0x42, 0x7c, 0xf4, 0x29, 0x40, 0xea, 0xf8, 0xc6, 0xf5, 0x07,
-	0xf9, 0xf5, 0xab, 0x0a, 0xb1, 0xca, 0xb3, 0x15, 0x43, 0xff, 0x07, 0x2d, 0xc9, 0xdc, 0x5b, 0x97,
-	0x01, 0x49, 0x6e, 0x28, 0x7a, 0x05, 0x7b, 0x7e, 0xc0, 0x62, 0x39, 0x34, 0x4e, 0xe0, 0x8b, 0x6a,
-	0xb4, 0xde, 0x71, 0xb7, 0x30, 0x89, 0xdd, 0x41, 0xce, 0xb0, 0x06, 0xb8, 0xb1, 0xe6, 0x5b, 0xbe,
-	0xe8, 0x10, 0x97, 0x07, 0x91, 0x70, 0xb0, 0x84, 0xe5, 0x06, 0x7d, 0x06, 0xc0, 0x53, 0x0d, 0x4e,
-	0x40, 0x27, 0x91, 0xe8, 0x77, 0xad, 0x87, 0xd6, 0x85, 0xae, 0xe4, 0x61, 0x95, 0xe7, 0x4a, 0x7f,
-	0xa9, 0xc0, 0x31, 0x26, 0xd3, 0x80, 0x71, 0x92, 0xac, 0xef, 0xc3, 0xe4, 0x6e, 0x46, 0x18, 0x4f,
-	0xcb, 0xf4, 0x6e, 0x5c, 0x3a, 0x25, 0x13, 0x42, 0xfc, 0xb4, 0x4c, 0xe5, 0x1f, 0xca, 0xec, 0xe7,
-	0x8c, 0xb4, 0xcc, 0x35, 0xdf, 0xf2, 0xb7, 0x65, 0x96, 0xfe, 0x9b, 0xcc, 0x2f, 0x57, 0x82, 0x58,
-	0xec, 0xd2, 0xcc, 0xa3, 0x27, 0x1b, 0xc9, 0x42, 0xd4, 0x28, 0x76, 0x69, 0x26, 0x2a, 0x5d, 0x6e,
-	0x3c, 0x73, 0x65, 0xe3, 0x99, 0xd3, 0xf6, 0x60, 0x24, 0x99, 0xcb, 0x6a, 0xe4, 0x17, 0xa1, 0x2e,
-	0x03, 0x96, 0x8f, 0xbe, 0x00, 0xcd, 0xf5, 0x78, 0x10, 0x51, 0xd9, 0x9d, 0x55, 0xd1, 0x9d, 0x87,
-	0xb9, 0x81, 0xe7, 0x02, 0x13, 0x1d, 0x0a, 0x6e, 0xbe, 0x46, 0x2f, 0x61, 0x6f, 0x22, 0xa6, 0xc6,
-	0xf1, 0xc4, 0xf8, 0x8a, 0x61, 0xd7, 0x7a, 0x47, 0x79, 0x5e, 0x71, 0xb6, 0x71, 0x63, 0x52, 0x9c,
-	0xf4, 0x53, 0x38, 0x20, 0x54, 0x2a, 0x5c, 0x52, 0xcf, 0x89, 0xa3, 0x80, 0x72, 0xa3, 0xde, 0x52,
-	0x3a, 0x75, 0xbc, 0x2f, 0x81, 0xd1, 0x92, 0x7a, 0xc3, 0x34, 0x8c, 0xda, 0xb0, 0xb7, 0x26, 0xa5,
-	0xd2, 0x54, 0x21, 0x4d, 0x63, 0x2b, 0x86, 0xcd, 0x50, 0x17, 0x0e, 0x0b, 0x9c, 0x80, 0x72, 0x92,
-	0xcc, 0xdd, 0xd0, 0x00, 0xc1, 0x3c, 0xc8, 0x99, 0x56, 0x06, 0xa4, 0xdf, 0xe2, 0x88, 0x86, 0x4b,
-	0x27, 0x21, 0x33, 0x46, 0x0c, 0x4d, 0x5c, 0xac, 0xa6, 0x11, 0x9c, 0x06, 0x4e, 0x3f, 0x86, 0xaa,
-	0x1c, 0x49, 0xb4, 0x07, 0xaa, 0x5c, 0x0d, 0x67, 0x5c, 0xdf, 0x41, 0x3a, 0x34, 0xe4, 0x56, 0x7e,
-	0xcb, 0x74, 0xe5, 0xf4, 0x4f, 0x05, 0x60, 0x6d, 0x10, 0x3a, 0x81, 0xff, 0x9d, 0xf7, 0x6d, 0xeb,
-	0xea, 0xd2, 0xb1, 0xbf, 0x1b, 0x9a, 0xce, 0xf5, 0xe5, 0x68, 0x68, 0xf6, 0xad, 0x0b, 0xcb, 0x1c,
-	0xe8, 0x3b, 0xc8, 0x80, 0xc7, 0x45, 0x10, 0x9b, 0xaf, 0xad, 0x91, 0x6d, 0x62, 0x5d, 0x41, 0x4f,
-	0x00, 0x6d, 0x22, 0x6f, 0xae, 0xde, 0x9a, 0x7a, 0x09, 0x1d, 0xc1, 0x41, 0x31, 0x3e, 0x3c, 0xbf,
-	0x1e, 0x99, 0x7a, 0x79, 0x9b, 0x3e, 0xba, 0x7e, 0x63, 0xea, 0x95, 0x0f, 0xe9, 0xd8, 0x1c, 0x99,
-	0xb6, 0xbe, 0x8b, 0x5a, 0xf0, 0x6c, 0xeb, 0x14, 0xa7, 0xff, 0xed, 0xf9, 0xe5, 0x6b, 0xf3, 0xc2,
-	0x34, 0x07, 0x7a, 0x15, 0xbd, 0x80, 0xe7, 0xdb, 0x07, 0x16, 0x29, 0xb5, 0x6f, 0x5e, 0xfe, 0x7a,
-	0xdf, 0x54, 0xde, 0xdf, 0x37, 0x95, 0x3f, 0xee, 0x9b, 0xca, 0x4f, 0x0f, 0xcd, 0x9d, 0xf7, 0x0f,
-	0xcd, 0x9d, 0xdf, 0x1e, 0x9a, 0x3b, 0xdf, 0xb7, 0xa6, 0x01, 0xbf, 0x99, 0x8d, 0xbb, 0x5e, 0x74,
-	0x7b, 0x16, 0x07, 0x74, 0xea, 0xb9, 0xf1, 0x19, 0x0f, 0x3c, 0xdf, 0x3b, 0xcb, 0x3a, 0x61, 0x5c,
-	0x15, 0x7f, 0xa9, 0x9f, 0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x04, 0x89, 0x5d, 0x24, 0x8f, 0x07,
-	0x00, 0x00,
+	// 1009 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x41, 0x6f, 0xe3, 0x44,
+	0x14, 0xae, 0x93, 0x34, 0x89, 0x9f, 0xd3, 0xad, 0x3b, 0xdd, 0x2e, 0xee, 0x76, 0x37, 0x74, 0x23,
+	0x81, 0x4a, 0x25, 0x52, 0x08, 0x20, 0xa4, 0x15, 0x5a, 0xa9, 0xb4, 0xee, 0xe2, 0xc3, 0xb6, 0xd5,
+	0xd8, 0x5d, 0x89, 0xbd, 0x58, 0xae, 0x3d, 0x49, 0x0d, 0xee, 0xd8, 0xf5, 0x4c, 0xb2, 0x0d, 0x37,
+	0xfe, 0x01, 0x77, 0xfe, 0x10, 0xc7, 0x3d, 0x72, 0x03, 0xb5, 0x12, 0xfc, 0x0d, 0x34, 0x33, 0x8e,
+	0xe3, 0x6c, 0x10, 0x12, 0x27, 0xcf, 0xbc, 0xef, 0x7b, 0x6f, 0xde, 0xfb, 0xde, 0xbc, 0x31, 0x6c,
+	0x92, 0x09, 0xa1, 0x3c, 0xbb, 0x3c, 0x90, 0xdf, 0x7e, 0x96, 0xa7, 0x3c, 0x45, 0xad, 0xc2, 0xf8,
+	0x78, 0xe7, 0x8a, 0x04, 0x39, 0xbf, 0x24, 0x81, 0x60, 0x94, 0x6b, 0xc5, 0xea, 0xfd, 0x51, 0x83,
+	0x75, 0x5b, 0x10, 0x4f, 0xe2, 0x84, 0x93, 0x1c, 0x8f, 0x13, 0x82, 0x2c, 0x68, 0x5d, 0x07, 0x3c,
+	0xbc, 0x22, 0xb9, 0xa5, 0xed, 0xd6, 0xf7, 0x74, 0x3c, 0xdb, 0xa2, 0x67, 0xd0, 0x89, 0x47, 0x34,
+	0xcd, 0x89, 0x2f, 0x83, 0x5b, 0x35, 0x09, 0x1b, 0xca, 0x26, 0xc3, 0xa0, 0xa7, 0x00, 0x05, 0x85,
+	0xdd, 0x24, 0x56, 0x5d, 0x12, 0x74, 0x65, 0x71, 0x6f, 0x12, 0xf4, 0x35, 0x58, 0x05, 0x1c, 0x53,
+	0x46, 0x72, 0xee, 0x4f, 0x82, 0x64, 0x4c, 0x7c, 0x72, 0x9b, 0xe5, 0x56, 0x63, 0x57, 0xdb, 0xd3,
+	0xf1, 0x96, 0xc2, 0x1d, 0x09, 0xbf, 0x16, 0xa8, 0x7d, 0x9b, 0xe5, 0xe8, 0x05, 0x3c, 0x29, 0x1c,
+	0xc7, 0x59, 0x14, 0x70, 0xe2, 0x53, 0xf2, 0xb6, 0xea, 0xbc, 0x2a, 0x9d, 0x8b, 0xe0, 0x17, 0x92,
+	0x72, 0x4a, 0xde, 0xfe, 0x87, 0x7f, 0x9a, 0x44, 0x55, 0xff, 0xe6, 0xb2, 0xff, 0x59, 0x12, 0xcd,
+	0xfd, 0xe7, 0x89, 0x47, 0x24, 0x21, 0x9c, 0x54, 0x7d, 0x5b, 0xd5, 0xc4, 0x8f, 0x25, 0x5c, 0x3a,
+	0xf6, 0x7e, 0xae, 0x41, 0x47, 0x89, 0x7b, 0x94, 0xd2, 0x61, 0x3c, 0x42, 0x0f, 0x61, 0x35, 0x1f,
+	0x27, 0x84, 0x15, 0xe2, 0xaa, 0x0d, 0xfa, 0x14, 0x36, 0x8b, 0xf8, 0xfc, 0x96, 0xfa, 0x8c, 0x07,
+	0x39, 0xf7, 0x39, 0x93, 0x0a, 0x37, 0xb0, 0xa9, 0x20, 0xef, 0x96, 0xba, 0x02, 0xf0, 0x18, 0xfa,
+	0x06, 0x3a, 0x95, 0xb6, 0x31, 0x29, 0xb4, 0x31, 0xb0, 0xfa, 0x45, 0xd3, 0xfb, 0xef, 0xf5, 0x14,
+	0x2f, 0xb0, 0xd1, 0x47, 0xf0, 0x20, 0x0c, 0x18, 0xf1, 0x19, 0xa1, 0x2c, 0xe6, 0xf1, 0x84, 0x48,
+	0xed, 0xdb, 0x78, 0x4d, 0x58, 0xdd, 0x99, 0x11, 0xed, 0x80, 0xce, 0xe3, 0x6b, 0xe2, 0xff, 0x94,
+	0x52, 0x52, 0x08, 0xdc, 0x16, 0x86, 0x37, 0x29, 0x25, 0x68, 0x1f, 0x36, 0xa2, 0x98, 0x05, 0x97,
+	0x49, 0x45, 0x4a, 0xa9, 0x62, 0x1b, 0xaf, 0x17, 0xc0, 0x4c, 0xc0, 0x5e, 0x07, 0x00, 0x13, 0x96,
+	0x26, 0x13, 0x12, 0x79, 0xac, 0x37, 0x86, 0x55, 0x75, 0x57, 0x4c, 0xa8, 0xff, 0x48, 0xa6, 0x96,
+	0xb6, 0xab, 0xed, 0x75, 0xb0, 0x58, 0x0a, 0x6d, 0x54, 0xa0, 0x9a, 0xb4, 0xa9, 0x0d, 0x7a, 0x0c,
+	0xed, 0x59, 0x28, 0xab, 0x2e, 0x81, 0x72, 0x8f, 0xf6, 0xa0, 0x95, 0x66, 0x3e, 0x9f, 0x66, 0xaa,
+	0x86, 0x07, 0x83, 0xf5, 0x52, 0x83, 0xb3, 0xcc, 0x9b, 0x66, 0x04, 0x37, 0x53, 0xf9, 0xed, 0xfd,
+	0x00, 0x6d, 0xef, 0x96, 0xaa, 0x93, 0x3f, 0x86, 0xa6, 0x64, 0xa9, 0x26, 0x18, 0x83, 0x07, 0x8b,
+	0xc2, 0xe1, 0x02, 0x15, 0x0a, 0x84, 0xe9, 0xf5, 0x75, 0x5c, 0xf4, 0x42, 0xdb, 0x6b, 0xe0, 0xb6,
+	0x32, 0x78, 0x0c, 0x6d, 0x43, 0xbb, 0xec, 0x53, 0x5d, 0x62, 0x2d, 0xa6, 0xda, 0xd3, 0x33, 0x40,
+	0xf7, 0x84, 0x02, 0x0e, 0x1d, 0xa6, 0xbd, 0xbf, 0x35, 0xd0, 0x95, 0xfc, 0x84, 0x44, 0xe8, 0x33,
+	0x00, 0xd1, 0xe1, 0x85, 0xe3, 0x37, 0xca, 0xe3, 0x67, 0x19, 0x62, 0x9d, 0x17, 0x2b, 0x86, 0x3e,
+	0x04, 0x23, 0x2f, 0xd4, 0x9b, 0xa7, 0x01, 0x79, 0x29, 0x28, 0x7a, 0x01, 0x6b, 0x51, 0xcc, 0x32,
+	0x35, 0xa4, 0x7e, 0x1c, 0xc9, 0x6c, 0x8c, 0xc1, 0x76, 0xbf, 0x32, 0xf9, 0xfd, 0xe3, 0x92, 0xe1,
+	0x1c, 0xe3, 0xce, 0x9c, 0xef, 0x44, 0xf2, 0x46, 0x06, 0x3c, 0x4e, 0xa5, 0x82, 0x35, 0xac, 0x36,
+	0xe8, 0x73, 0x00, 0x2e, 0xdb, 0x1b, 0xd3, 0x61, 0x2a, 0xdb, 0x6f, 0x0c, 0xd0, 0x3c, 0xd1, 0x59,
+	0x79, 0x58, 0xe7, 0x65, 0xa5, 0xbf, 0x36, 0x60, 0x1b, 0x93, 0x51, 0xcc, 0x38, 0xc9, 0xe7, 0xe7,
+	0x61, 0x72, 0x33, 0x26, 0x8c, 0x8b, 0x34, 0xc3, 0xab, 0x80, 0x8e, 0xc8, 0x90, 0x90, 0x48, 0xa4,
+	0xa9, 0xfd, 0x4b, 0x9a, 0x47, 0x25, 0x43, 0xa4, 0x39, 0xe7, 0x3b, 0xd1, 0x72, 0x99, 0xb5, 0xff,
+	0x57, 0xe6, 0x57, 0xb3, 0x82, 0x58, 0x16, 0xd0, 0x42, 0xa3, 0x47, 0x0b, 0xce, 0xb2, 0x28, 0x37,
+	0x0b, 0x68, 0x51, 0x94, 0x58, 0x2e, 0xb4, 0xb9, 0xb1, 0xd0, 0x66, 0x71, 0x3d, 0x18, 0xc9, 0x27,
+	0x2a, 0x9b, 0x62, 0x40, 0x94, 0xc1, 0x89, 0xd0, 0x97, 0x60, 0x04, 0x21, 0x8f, 0x53, 0xaa, 0x6e,
+	0x67, 0x53, 0xde, 0xce, 0xcd, 0x52, 0xc0, 0x43, 0x89, 0xc9, 0x1b, 0x0a, 0x41, 0xb9, 0x46, 0xcf,
+	0x61, 0x6d, 0x28, 0xa7, 0xd4, 0x0f, 0xe5, 0x73, 0x21, 0x1f, 0x17, 0x63, 0xb0, 0x55, 0xfa, 0x55,
+	0xdf, 0x12, 0xdc, 0x19, 0x56, 0x76, 0x62, 0x24, 0x09, 0x55, 0x15, 0x4e, 0x69, 0xe8, 0x67, 0x69,
+	0x4c, 0xb9, 0xd5, 0x56, 0x23, 0xa9, 0x00, 0x77, 0x4a, 0xc3, 0x73, 0x61, 0x46, 0x3d, 0x58, 0x9b,
+	0x93, 0x44, 0x69, 0xba, 0x2c, 0xcd, 0x60, 0x33, 0x86, 0xc7, 0x50, 0x1f, 0x36, 0x2b, 0x9c, 0x98,
+	0x72, 0x92, 0x4f, 0x82, 0xc4, 0x02, 0xc9, 0xdc, 0x28, 0x99, 0x4e, 0x01, 0x88, 0xb7, 0x3f, 0xa5,
+	0xc9, 0xd4, 0xcf, 0xc9, 0x98, 0x11, 0xcb, 0x90, 0x07, 0xeb, 0xc2, 0x82, 0x85, 0x61, 0xff, 0x13,
+	0x68, 0xaa, 0x91, 0x44, 0x6b, 0xa0, 0xab, 0xd5, 0xf9, 0x98, 0x9b, 0x2b, 0xc8, 0x84, 0x8e, 0xda,
+	0xaa, 0xb7, 0xd3, 0xd4, 0xf6, 0xff, 0xd2, 0x00, 0xe6, 0x02, 0xa1, 0x1d, 0xf8, 0xe0, 0xf0, 0xc8,
+	0x73, 0xce, 0x4e, 0x7d, 0xef, 0xfb, 0x73, 0xdb, 0xbf, 0x38, 0x75, 0xcf, 0xed, 0x23, 0xe7, 0xc4,
+	0xb1, 0x8f, 0xcd, 0x15, 0x64, 0xc1, 0xc3, 0x2a, 0x88, 0xed, 0x97, 0x8e, 0xeb, 0xd9, 0xd8, 0xd4,
+	0xd0, 0x23, 0x40, 0x8b, 0xc8, 0xab, 0xb3, 0xd7, 0xb6, 0x59, 0x43, 0x5b, 0xb0, 0x51, 0xb5, 0x9f,
+	0x1f, 0x5e, 0xb8, 0xb6, 0x59, 0x5f, 0xa6, 0xbb, 0x17, 0xaf, 0x6c, 0xb3, 0xf1, 0x3e, 0x1d, 0xdb,
+	0xae, 0xed, 0x99, 0xab, 0x68, 0x17, 0x9e, 0x2c, 0x45, 0xf1, 0x8f, 0xbe, 0x3b, 0x3c, 0x7d, 0x69,
+	0x9f, 0xd8, 0xf6, 0xb1, 0xd9, 0x44, 0xcf, 0xe0, 0xe9, 0x72, 0xc0, 0x2a, 0xa5, 0xf5, 0xed, 0xf3,
+	0xdf, 0xee, 0xba, 0xda, 0xbb, 0xbb, 0xae, 0xf6, 0xe7, 0x5d, 0x57, 0xfb, 0xe5, 0xbe, 0xbb, 0xf2,
+	0xee, 0xbe, 0xbb, 0xf2, 0xfb, 0x7d, 0x77, 0xe5, 0xcd, 0xee, 0x28, 0xe6, 0x57, 0xe3, 0xcb, 0x7e,
+	0x98, 0x5e, 0x1f, 0x64, 0x31, 0x1d, 0x85, 0x41, 0x76, 0xc0, 0xe3, 0x30, 0x0a, 0x0f, 0x8a, 0x9b,
+	0x70, 0xd9, 0x94, 0xbf, 0xf0, 0x2f, 0xfe, 0x19, 0x00, 0x30, 0xc7, 0x2f, 0x88, 0xff, 0x07, 0x00,
+	0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -824,6 +859,33 @@ func (m *FilterConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.DisableOldValue {
+		i--
+		if m.DisableOldValue {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.TimeZone) > 0 {
+		i -= len(m.TimeZone)
+		copy(dAtA[i:], m.TimeZone)
+		i = encodeVarintEvent(dAtA, i, uint64(len(m.TimeZone)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.CaseSensitive {
+		i--
+		if m.CaseSensitive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.EventFilters) > 0 {
 		for iNdEx := len(m.EventFilters) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1102,6 +1164,11 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.ClusterId != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ClusterId))
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.OnlyReuse {
 		i--
 		if m.OnlyReuse {
@@ -1279,6 +1346,16 @@ func (m *FilterConfig) Size() (n int) {
 			n += 1 + l + sovEvent(uint64(l))
 		}
 	}
+	if m.CaseSensitive {
+		n += 2
+	}
+	l = len(m.TimeZone)
+	if l > 0 {
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.DisableOldValue {
+		n += 2
+	}
 	return n
 }
 
@@ -1418,6 +1495,9 @@ func (m *RegisterDispatcherRequest) Size() (n int) {
 	if m.OnlyReuse {
 		n += 2
 	}
+	if m.ClusterId != 0 {
+		n += 1 + sovEvent(uint64(m.ClusterId))
+	}
 	return n
 }
 
@@ -1872,6 +1952,78 @@ func (m *FilterConfig) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaseSensitive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CaseSensitive = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeZone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeZone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableOldValue", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableOldValue = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -2793,6 +2945,25 @@ func (m *RegisterDispatcherRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.OnlyReuse = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+			}
+			m.ClusterId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClusterId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -226,6 +226,9 @@ type HeartBeatRequest struct {
 	Statuses        []*TableSpanStatus `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
 	CompeleteStatus bool               `protobuf:"varint,4,opt,name=compeleteStatus,proto3" json:"compeleteStatus,omitempty"`
 	Err             *RunningError      `protobuf:"bytes,5,opt,name=err,proto3" json:"err,omitempty"`
+	NodeStopping    bool               `protobuf:"varint,6,opt,name=nodeStopping,proto3" json:"nodeStopping,omitempty"`
+	QuiesceTs       uint64             `protobuf:"varint,7,opt,name=quiesceTs,proto3" json:"quiesceTs,omitempty"`
+	Capacity        *NodeCapacity      `protobuf:"bytes,8,opt,name=capacity,proto3" json:"capacity,omitempty"`
 }
 
 func (m *HeartBeatRequest) Reset()         { *m = HeartBeatRequest{} }
@@ -296,6 +299,27 @@ func (m *HeartBeatRequest) GetErr() *RunningError {
 	return nil
 }
 
+func (m *HeartBeatRequest) GetNodeStopping() bool {
+	if m != nil {
+		return m.NodeStopping
+	}
+	return false
+}
+
+func (m *HeartBeatRequest) GetQuiesceTs() uint64 {
+	if m != nil {
+		return m.QuiesceTs
+	}
+	return 0
+}
+
+func (m *HeartBeatRequest) GetCapacity() *NodeCapacity {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
 type Watermark struct {
 	CheckpointTs uint64 `protobuf:"varint,1,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
 	ResolvedTs   uint64 `protobuf:"varint,2,opt,name=resolvedTs,proto3" json:"resolvedTs,omitempty"`
@@ -602,6 +626,10 @@ func (m *DispatcherStatus) GetAck() *ACK {
 type HeartBeatResponse struct {
 	ChangefeedID       *ChangefeedID       `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	DispatcherStatuses []*DispatcherStatus `protobuf:"bytes,2,rep,name=dispatcherStatuses,proto3" json:"dispatcherStatuses,omitempty"`
+	// the block events the maintainer is waiting for, of the dispatchers reported the block status.
+	BarrierStates []*DispatcherBarrierState `protobuf:"bytes,3,rep,name=barrierStates,proto3" json:"barrierStates,omitempty"`
+	// the epoch of the maintainer, the dispatcher manager ignores the messages of a stale maintainer.
+	MaintainerEpoch uint64 `protobuf:"varint,4,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
 }
 
 func (m *HeartBeatResponse) Reset()         { *m = HeartBeatResponse{} }
@@ -651,6 +679,20 @@ func (m *HeartBeatResponse) GetDispatcherStatuses() []*DispatcherStatus {
 	return nil
 }
 
+func (m *HeartBeatResponse) GetBarrierStates() []*DispatcherBarrierState {
+	if m != nil {
+		return m.BarrierStates
+	}
+	return nil
+}
+
+func (m *HeartBeatResponse) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
 type CheckpointTsMessage struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	CheckpointTs uint64        `protobuf:"varint,2,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
@@ -781,9 +823,10 @@ func (m *DispatcherConfig) GetCurrentPdTs() uint64 {
 }
 
 type ScheduleDispatcherRequest struct {
-	ChangefeedID   *ChangefeedID     `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
-	Config         *DispatcherConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
-	ScheduleAction ScheduleAction    `protobuf:"varint,3,opt,name=scheduleAction,proto3,enum=heartbeatpb.ScheduleAction" json:"scheduleAction,omitempty"`
+	ChangefeedID    *ChangefeedID     `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	Config          *DispatcherConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	ScheduleAction  ScheduleAction    `protobuf:"varint,3,opt,name=scheduleAction,proto3,enum=heartbeatpb.ScheduleAction" json:"scheduleAction,omitempty"`
+	MaintainerEpoch uint64            `protobuf:"varint,4,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
 }
 
 func (m *ScheduleDispatcherRequest) Reset()         { *m = ScheduleDispatcherRequest{} }
@@ -840,8 +883,17 @@ func (m *ScheduleDispatcherRequest) GetScheduleAction() ScheduleAction {
 	return ScheduleAction_Create
 }
 
+func (m *ScheduleDispatcherRequest) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
 type MaintainerHeartbeat struct {
 	Statuses []*MaintainerStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// the capacity of the node, it's used to limit the maintainers placed on the node.
+	Capacity *NodeCapacity `protobuf:"bytes,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
 }
 
 func (m *MaintainerHeartbeat) Reset()         { *m = MaintainerHeartbeat{} }
@@ -884,12 +936,25 @@ func (m *MaintainerHeartbeat) GetStatuses() []*MaintainerStatus {
 	return nil
 }
 
+func (m *MaintainerHeartbeat) GetCapacity() *NodeCapacity {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
 type MaintainerStatus struct {
-	ChangefeedID *ChangefeedID   `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
-	FeedState    string          `protobuf:"bytes,2,opt,name=feed_state,json=feedState,proto3" json:"feed_state,omitempty"`
-	State        ComponentState  `protobuf:"varint,3,opt,name=state,proto3,enum=heartbeatpb.ComponentState" json:"state,omitempty"`
-	CheckpointTs uint64          `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
-	Err          []*RunningError `protobuf:"bytes,5,rep,name=err,proto3" json:"err,omitempty"`
+	ChangefeedID       *ChangefeedID      `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	FeedState          string             `protobuf:"bytes,2,opt,name=feed_state,json=feedState,proto3" json:"feed_state,omitempty"`
+	State              ComponentState     `protobuf:"varint,3,opt,name=state,proto3,enum=heartbeatpb.ComponentState" json:"state,omitempty"`
+	CheckpointTs       uint64             `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+	Err                []*RunningError    `protobuf:"bytes,5,rep,name=err,proto3" json:"err,omitempty"`
+	TableCount         int64              `protobuf:"varint,6,opt,name=table_count,json=tableCount,proto3" json:"table_count,omitempty"`
+	EventSizePerSecond float32            `protobuf:"fixed32,7,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	Warnings           []*RunningError    `protobuf:"bytes,8,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Barriers           []*BarrierCoverage `protobuf:"bytes,9,rep,name=barriers,proto3" json:"barriers,omitempty"`
+	Backfills          []*TableBackfill   `protobuf:"bytes,10,rep,name=backfills,proto3" json:"backfills,omitempty"`
+	BarriersFull       bool               `protobuf:"varint,11,opt,name=barriers_full,json=barriersFull,proto3" json:"barriers_full,omitempty"`
 }
 
 func (m *MaintainerStatus) Reset()         { *m = MaintainerStatus{} }
@@ -960,6 +1025,174 @@ func (m *MaintainerStatus) GetErr() []*RunningError {
 	return nil
 }
 
+func (m *MaintainerStatus) GetTableCount() int64 {
+	if m != nil {
+		return m.TableCount
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetEventSizePerSecond() float32 {
+	if m != nil {
+		return m.EventSizePerSecond
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetWarnings() []*RunningError {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetBarriers() []*BarrierCoverage {
+	if m != nil {
+		return m.Barriers
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetBackfills() []*TableBackfill {
+	if m != nil {
+		return m.Backfills
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetBarriersFull() bool {
+	if m != nil {
+		return m.BarriersFull
+	}
+	return false
+}
+
+// BarrierCoverage is the compact coverage of the dispatchers which have reported a barrier,
+// the adjacent spans are merged.
+type BarrierCoverage struct {
+	BlockTs     uint64 `protobuf:"varint,1,opt,name=block_ts,json=blockTs,proto3" json:"block_ts,omitempty"`
+	IsSyncPoint bool   `protobuf:"varint,2,opt,name=is_sync_point,json=isSyncPoint,proto3" json:"is_sync_point,omitempty"`
+	// dynamic_split is true if the covered spans are the key ranges of the split tables,
+	// otherwise only the table ids of the covered spans are meaningful.
+	DynamicSplit bool         `protobuf:"varint,3,opt,name=dynamic_split,json=dynamicSplit,proto3" json:"dynamic_split,omitempty"`
+	Covered      []*TableSpan `protobuf:"bytes,4,rep,name=covered,proto3" json:"covered,omitempty"`
+}
+
+func (m *BarrierCoverage) Reset()         { *m = BarrierCoverage{} }
+func (m *BarrierCoverage) String() string { return proto.CompactTextString(m) }
+func (*BarrierCoverage) ProtoMessage()    {}
+func (*BarrierCoverage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{13}
+}
+func (m *BarrierCoverage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BarrierCoverage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BarrierCoverage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BarrierCoverage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BarrierCoverage.Merge(m, src)
+}
+func (m *BarrierCoverage) XXX_Size() int {
+	return m.Size()
+}
+func (m *BarrierCoverage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BarrierCoverage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BarrierCoverage proto.InternalMessageInfo
+
+func (m *BarrierCoverage) GetBlockTs() uint64 {
+	if m != nil {
+		return m.BlockTs
+	}
+	return 0
+}
+
+func (m *BarrierCoverage) GetIsSyncPoint() bool {
+	if m != nil {
+		return m.IsSyncPoint
+	}
+	return false
+}
+
+func (m *BarrierCoverage) GetDynamicSplit() bool {
+	if m != nil {
+		return m.DynamicSplit
+	}
+	return false
+}
+
+func (m *BarrierCoverage) GetCovered() []*TableSpan {
+	if m != nil {
+		return m.Covered
+	}
+	return nil
+}
+
+// TableBackfill is the progress of a table re-replicated from an older ts,
+// the table is re-replicated from the checkpoint ts if the backfill is interrupted.
+type TableBackfill struct {
+	TableId      int64  `protobuf:"varint,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	CheckpointTs uint64 `protobuf:"varint,2,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+}
+
+func (m *TableBackfill) Reset()         { *m = TableBackfill{} }
+func (m *TableBackfill) String() string { return proto.CompactTextString(m) }
+func (*TableBackfill) ProtoMessage()    {}
+func (*TableBackfill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{14}
+}
+func (m *TableBackfill) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TableBackfill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TableBackfill.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TableBackfill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableBackfill.Merge(m, src)
+}
+func (m *TableBackfill) XXX_Size() int {
+	return m.Size()
+}
+func (m *TableBackfill) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableBackfill.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableBackfill proto.InternalMessageInfo
+
+func (m *TableBackfill) GetTableId() int64 {
+	if m != nil {
+		return m.TableId
+	}
+	return 0
+}
+
+func (m *TableBackfill) GetCheckpointTs() uint64 {
+	if m != nil {
+		return m.CheckpointTs
+	}
+	return 0
+}
+
 type CoordinatorBootstrapRequest struct {
 	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
@@ -968,7 +1201,7 @@ func (m *CoordinatorBootstrapRequest) Reset()         { *m = CoordinatorBootstra
 func (m *CoordinatorBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*CoordinatorBootstrapRequest) ProtoMessage()    {}
 func (*CoordinatorBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{13}
+	return fileDescriptor_6d584080fdadb670, []int{15}
 }
 func (m *CoordinatorBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1006,13 +1239,14 @@ func (m *CoordinatorBootstrapRequest) GetVersion() int64 {
 
 type CoordinatorBootstrapResponse struct {
 	Statuses []*MaintainerStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Capacity *NodeCapacity       `protobuf:"bytes,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
 }
 
 func (m *CoordinatorBootstrapResponse) Reset()         { *m = CoordinatorBootstrapResponse{} }
 func (m *CoordinatorBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*CoordinatorBootstrapResponse) ProtoMessage()    {}
 func (*CoordinatorBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{14}
+	return fileDescriptor_6d584080fdadb670, []int{16}
 }
 func (m *CoordinatorBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1048,18 +1282,34 @@ func (m *CoordinatorBootstrapResponse) GetStatuses() []*MaintainerStatus {
 	return nil
 }
 
+func (m *CoordinatorBootstrapResponse) GetCapacity() *NodeCapacity {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
 type AddMaintainerRequest struct {
 	Id             *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Config         []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
 	CheckpointTs   uint64        `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	IsNewChangfeed bool          `protobuf:"varint,4,opt,name=is_new_changfeed,json=isNewChangfeed,proto3" json:"is_new_changfeed,omitempty"`
+	// the epoch is bumped every time the changefeed is scheduled to a node, it's carried by the
+	// messages the maintainer sends to the dispatcher managers to fence the stale maintainers.
+	Epoch uint64 `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// barriers are the coverages of the barriers last reported by the maintainer,
+	// they are used to resume the barriers after the maintainer is bootstrapped.
+	Barriers []*BarrierCoverage `protobuf:"bytes,6,rep,name=barriers,proto3" json:"barriers,omitempty"`
+	// backfills are the backfilling tables last reported by the maintainer,
+	// they are resumed after the maintainer is bootstrapped.
+	Backfills []*TableBackfill `protobuf:"bytes,7,rep,name=backfills,proto3" json:"backfills,omitempty"`
 }
 
 func (m *AddMaintainerRequest) Reset()         { *m = AddMaintainerRequest{} }
 func (m *AddMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*AddMaintainerRequest) ProtoMessage()    {}
 func (*AddMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{15}
+	return fileDescriptor_6d584080fdadb670, []int{17}
 }
 func (m *AddMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1116,6 +1366,27 @@ func (m *AddMaintainerRequest) GetIsNewChangfeed() bool {
 	return false
 }
 
+func (m *AddMaintainerRequest) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *AddMaintainerRequest) GetBarriers() []*BarrierCoverage {
+	if m != nil {
+		return m.Barriers
+	}
+	return nil
+}
+
+func (m *AddMaintainerRequest) GetBackfills() []*TableBackfill {
+	if m != nil {
+		return m.Backfills
+	}
+	return nil
+}
+
 type RemoveMaintainerRequest struct {
 	Id      *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Cascade bool          `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
@@ -1126,7 +1397,7 @@ func (m *RemoveMaintainerRequest) Reset()         { *m = RemoveMaintainerRequest
 func (m *RemoveMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveMaintainerRequest) ProtoMessage()    {}
 func (*RemoveMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{16}
+	return fileDescriptor_6d584080fdadb670, []int{18}
 }
 func (m *RemoveMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1182,13 +1453,19 @@ type MaintainerBootstrapRequest struct {
 	StartTs                       uint64        `protobuf:"varint,3,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
 	TableTriggerEventDispatcherId *DispatcherID `protobuf:"bytes,4,opt,name=table_trigger_event_dispatcher_id,json=tableTriggerEventDispatcherId,proto3" json:"table_trigger_event_dispatcher_id,omitempty"`
 	IsNewChangfeed                bool          `protobuf:"varint,5,opt,name=is_new_changfeed,json=isNewChangfeed,proto3" json:"is_new_changfeed,omitempty"`
+	// protocol_version and capabilities are used to negotiate the features with the dispatcher manager
+	// which may be a different version during the rolling upgrade.
+	ProtocolVersion uint32   `protobuf:"varint,6,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities    []string `protobuf:"bytes,7,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// the epoch of the maintainer, the dispatcher manager ignores the messages of a stale maintainer.
+	MaintainerEpoch uint64 `protobuf:"varint,8,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
 }
 
 func (m *MaintainerBootstrapRequest) Reset()         { *m = MaintainerBootstrapRequest{} }
 func (m *MaintainerBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{17}
+	return fileDescriptor_6d584080fdadb670, []int{19}
 }
 func (m *MaintainerBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1252,6 +1529,27 @@ func (m *MaintainerBootstrapRequest) GetIsNewChangfeed() bool {
 	return false
 }
 
+func (m *MaintainerBootstrapRequest) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *MaintainerBootstrapRequest) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *MaintainerBootstrapRequest) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
 type MaintainerBootstrapResponse struct {
 	ChangefeedID *ChangefeedID         `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	Spans        []*BootstrapTableSpan `protobuf:"bytes,2,rep,name=spans,proto3" json:"spans,omitempty"`
@@ -1264,13 +1562,21 @@ type MaintainerBootstrapResponse struct {
 	// when it is restarted to keep correctness.
 	// If the table trigger event dispatcher is not created in this node, we can return 0 as the checkpointTs.
 	CheckpointTs uint64 `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+	// the protocol version and the capabilities supported by the dispatcher manager.
+	ProtocolVersion uint32   `protobuf:"varint,5,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities    []string `protobuf:"bytes,6,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// the capacity of the node, it's used to limit the dispatchers placed on the node.
+	Capacity *NodeCapacity `protobuf:"bytes,7,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// the memory usage and the backlog of the node, a recovering maintainer places the
+	// absent spans away from the loaded nodes.
+	Load *NodeLoad `protobuf:"bytes,8,opt,name=load,proto3" json:"load,omitempty"`
 }
 
 func (m *MaintainerBootstrapResponse) Reset()         { *m = MaintainerBootstrapResponse{} }
 func (m *MaintainerBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{18}
+	return fileDescriptor_6d584080fdadb670, []int{20}
 }
 func (m *MaintainerBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1327,17 +1633,46 @@ func (m *MaintainerBootstrapResponse) GetCheckpointTs() uint64 {
 	return 0
 }
 
+func (m *MaintainerBootstrapResponse) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *MaintainerBootstrapResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *MaintainerBootstrapResponse) GetCapacity() *NodeCapacity {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
+func (m *MaintainerBootstrapResponse) GetLoad() *NodeLoad {
+	if m != nil {
+		return m.Load
+	}
+	return nil
+}
+
 type MaintainerPostBootstrapRequest struct {
 	ChangefeedID                  *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	TableTriggerEventDispatcherId *DispatcherID `protobuf:"bytes,2,opt,name=table_trigger_event_dispatcher_id,json=tableTriggerEventDispatcherId,proto3" json:"table_trigger_event_dispatcher_id,omitempty"`
 	Schemas                       []*SchemaInfo `protobuf:"bytes,3,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	MaintainerEpoch               uint64        `protobuf:"varint,4,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
 }
 
 func (m *MaintainerPostBootstrapRequest) Reset()         { *m = MaintainerPostBootstrapRequest{} }
 func (m *MaintainerPostBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerPostBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerPostBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{19}
+	return fileDescriptor_6d584080fdadb670, []int{21}
 }
 func (m *MaintainerPostBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1387,6 +1722,13 @@ func (m *MaintainerPostBootstrapRequest) GetSchemas() []*SchemaInfo {
 	return nil
 }
 
+func (m *MaintainerPostBootstrapRequest) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
 type MaintainerPostBootstrapResponse struct {
 	ChangefeedID                  *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	TableTriggerEventDispatcherId *DispatcherID `protobuf:"bytes,2,opt,name=table_trigger_event_dispatcher_id,json=tableTriggerEventDispatcherId,proto3" json:"table_trigger_event_dispatcher_id,omitempty"`
@@ -1397,7 +1739,7 @@ func (m *MaintainerPostBootstrapResponse) Reset()         { *m = MaintainerPostB
 func (m *MaintainerPostBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerPostBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerPostBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{20}
+	return fileDescriptor_6d584080fdadb670, []int{22}
 }
 func (m *MaintainerPostBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1457,7 +1799,7 @@ func (m *SchemaInfo) Reset()         { *m = SchemaInfo{} }
 func (m *SchemaInfo) String() string { return proto.CompactTextString(m) }
 func (*SchemaInfo) ProtoMessage()    {}
 func (*SchemaInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{21}
+	return fileDescriptor_6d584080fdadb670, []int{23}
 }
 func (m *SchemaInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1516,7 +1858,7 @@ func (m *TableInfo) Reset()         { *m = TableInfo{} }
 func (m *TableInfo) String() string { return proto.CompactTextString(m) }
 func (*TableInfo) ProtoMessage()    {}
 func (*TableInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{22}
+	return fileDescriptor_6d584080fdadb670, []int{24}
 }
 func (m *TableInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1572,7 +1914,7 @@ func (m *BootstrapTableSpan) Reset()         { *m = BootstrapTableSpan{} }
 func (m *BootstrapTableSpan) String() string { return proto.CompactTextString(m) }
 func (*BootstrapTableSpan) ProtoMessage()    {}
 func (*BootstrapTableSpan) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{23}
+	return fileDescriptor_6d584080fdadb670, []int{25}
 }
 func (m *BootstrapTableSpan) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1647,13 +1989,15 @@ type MaintainerCloseRequest struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	// true when remove changefeed, false when pause the changefeed.
 	Removed bool `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	// the epoch of the maintainer, the dispatcher manager ignores the messages of a stale maintainer.
+	MaintainerEpoch uint64 `protobuf:"varint,3,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
 }
 
 func (m *MaintainerCloseRequest) Reset()         { *m = MaintainerCloseRequest{} }
 func (m *MaintainerCloseRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseRequest) ProtoMessage()    {}
 func (*MaintainerCloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{24}
+	return fileDescriptor_6d584080fdadb670, []int{26}
 }
 func (m *MaintainerCloseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1696,6 +2040,13 @@ func (m *MaintainerCloseRequest) GetRemoved() bool {
 	return false
 }
 
+func (m *MaintainerCloseRequest) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
 type MaintainerCloseResponse struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	Success      bool          `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
@@ -1705,7 +2056,7 @@ func (m *MaintainerCloseResponse) Reset()         { *m = MaintainerCloseResponse
 func (m *MaintainerCloseResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseResponse) ProtoMessage()    {}
 func (*MaintainerCloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{25}
+	return fileDescriptor_6d584080fdadb670, []int{27}
 }
 func (m *MaintainerCloseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1760,7 +2111,7 @@ func (m *InfluencedTables) Reset()         { *m = InfluencedTables{} }
 func (m *InfluencedTables) String() string { return proto.CompactTextString(m) }
 func (*InfluencedTables) ProtoMessage()    {}
 func (*InfluencedTables) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{26}
+	return fileDescriptor_6d584080fdadb670, []int{28}
 }
 func (m *InfluencedTables) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1819,7 +2170,7 @@ func (m *Table) Reset()         { *m = Table{} }
 func (m *Table) String() string { return proto.CompactTextString(m) }
 func (*Table) ProtoMessage()    {}
 func (*Table) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{27}
+	return fileDescriptor_6d584080fdadb670, []int{29}
 }
 func (m *Table) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1872,7 +2223,7 @@ func (m *SchemaIDChange) Reset()         { *m = SchemaIDChange{} }
 func (m *SchemaIDChange) String() string { return proto.CompactTextString(m) }
 func (*SchemaIDChange) ProtoMessage()    {}
 func (*SchemaIDChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{28}
+	return fileDescriptor_6d584080fdadb670, []int{30}
 }
 func (m *SchemaIDChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1937,7 +2288,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{29}
+	return fileDescriptor_6d584080fdadb670, []int{31}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2031,7 +2382,7 @@ func (m *TableSpanBlockStatus) Reset()         { *m = TableSpanBlockStatus{} }
 func (m *TableSpanBlockStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanBlockStatus) ProtoMessage()    {}
 func (*TableSpanBlockStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{30}
+	return fileDescriptor_6d584080fdadb670, []int{32}
 }
 func (m *TableSpanBlockStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2079,13 +2430,14 @@ type TableSpanStatus struct {
 	ComponentStatus    ComponentState `protobuf:"varint,2,opt,name=component_status,json=componentStatus,proto3,enum=heartbeatpb.ComponentState" json:"component_status,omitempty"`
 	CheckpointTs       uint64         `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	EventSizePerSecond float32        `protobuf:"fixed32,4,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	SampledKeys        [][]byte       `protobuf:"bytes,5,rep,name=sampled_keys,json=sampledKeys,proto3" json:"sampled_keys,omitempty"`
 }
 
 func (m *TableSpanStatus) Reset()         { *m = TableSpanStatus{} }
 func (m *TableSpanStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanStatus) ProtoMessage()    {}
 func (*TableSpanStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{31}
+	return fileDescriptor_6d584080fdadb670, []int{33}
 }
 func (m *TableSpanStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2142,6 +2494,13 @@ func (m *TableSpanStatus) GetEventSizePerSecond() float32 {
 	return 0
 }
 
+func (m *TableSpanStatus) GetSampledKeys() [][]byte {
+	if m != nil {
+		return m.SampledKeys
+	}
+	return nil
+}
+
 type BlockStatusRequest struct {
 	ChangefeedID  *ChangefeedID           `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	BlockStatuses []*TableSpanBlockStatus `protobuf:"bytes,2,rep,name=blockStatuses,proto3" json:"blockStatuses,omitempty"`
@@ -2151,7 +2510,7 @@ func (m *BlockStatusRequest) Reset()         { *m = BlockStatusRequest{} }
 func (m *BlockStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockStatusRequest) ProtoMessage()    {}
 func (*BlockStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{32}
+	return fileDescriptor_6d584080fdadb670, []int{34}
 }
 func (m *BlockStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2205,7 +2564,7 @@ func (m *RunningError) Reset()         { *m = RunningError{} }
 func (m *RunningError) String() string { return proto.CompactTextString(m) }
 func (*RunningError) ProtoMessage()    {}
 func (*RunningError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{33}
+	return fileDescriptor_6d584080fdadb670, []int{35}
 }
 func (m *RunningError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2271,7 +2630,7 @@ func (m *DispatcherID) Reset()         { *m = DispatcherID{} }
 func (m *DispatcherID) String() string { return proto.CompactTextString(m) }
 func (*DispatcherID) ProtoMessage()    {}
 func (*DispatcherID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{34}
+	return fileDescriptor_6d584080fdadb670, []int{36}
 }
 func (m *DispatcherID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2325,7 +2684,7 @@ func (m *ChangefeedID) Reset()         { *m = ChangefeedID{} }
 func (m *ChangefeedID) String() string { return proto.CompactTextString(m) }
 func (*ChangefeedID) ProtoMessage()    {}
 func (*ChangefeedID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{35}
+	return fileDescriptor_6d584080fdadb670, []int{37}
 }
 func (m *ChangefeedID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2382,6 +2741,382 @@ func (m *ChangefeedID) GetNamespace() string {
 	return ""
 }
 
+// QuiesceRequest holds all dispatchers of the changefeed at the holdTs,
+// the events after it are not written to the sink until the holdTs is reset to 0.
+type QuiesceRequest struct {
+	ChangefeedID    *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	HoldTs          uint64        `protobuf:"varint,2,opt,name=holdTs,proto3" json:"holdTs,omitempty"`
+	MaintainerEpoch uint64        `protobuf:"varint,3,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
+}
+
+func (m *QuiesceRequest) Reset()         { *m = QuiesceRequest{} }
+func (m *QuiesceRequest) String() string { return proto.CompactTextString(m) }
+func (*QuiesceRequest) ProtoMessage()    {}
+func (*QuiesceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{38}
+}
+func (m *QuiesceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuiesceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuiesceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuiesceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuiesceRequest.Merge(m, src)
+}
+func (m *QuiesceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuiesceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuiesceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuiesceRequest proto.InternalMessageInfo
+
+func (m *QuiesceRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *QuiesceRequest) GetHoldTs() uint64 {
+	if m != nil {
+		return m.HoldTs
+	}
+	return 0
+}
+
+func (m *QuiesceRequest) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
+// ResendTableSchemaRequest asks the sink to resend the schema of the tables to the downstream,
+// all tables are resent if the table ids are empty.
+type ResendTableSchemaRequest struct {
+	ChangefeedID    *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	TableIds        []int64       `protobuf:"varint,2,rep,packed,name=table_ids,json=tableIds,proto3" json:"table_ids,omitempty"`
+	MaintainerEpoch uint64        `protobuf:"varint,3,opt,name=maintainer_epoch,json=maintainerEpoch,proto3" json:"maintainer_epoch,omitempty"`
+}
+
+func (m *ResendTableSchemaRequest) Reset()         { *m = ResendTableSchemaRequest{} }
+func (m *ResendTableSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*ResendTableSchemaRequest) ProtoMessage()    {}
+func (*ResendTableSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{39}
+}
+func (m *ResendTableSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResendTableSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResendTableSchemaRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResendTableSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResendTableSchemaRequest.Merge(m, src)
+}
+func (m *ResendTableSchemaRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResendTableSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResendTableSchemaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResendTableSchemaRequest proto.InternalMessageInfo
+
+func (m *ResendTableSchemaRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *ResendTableSchemaRequest) GetTableIds() []int64 {
+	if m != nil {
+		return m.TableIds
+	}
+	return nil
+}
+
+func (m *ResendTableSchemaRequest) GetMaintainerEpoch() uint64 {
+	if m != nil {
+		return m.MaintainerEpoch
+	}
+	return 0
+}
+
+// BlockedEvent is a block event which is not resolved by the maintainer.
+type BlockedEvent struct {
+	CommitTs    uint64 `protobuf:"varint,1,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
+	IsSyncPoint bool   `protobuf:"varint,2,opt,name=IsSyncPoint,proto3" json:"IsSyncPoint,omitempty"`
+	// the maintainer selected the writer and is waiting for the event being written if true,
+	// otherwise it's waiting for all influenced dispatchers reporting the event.
+	Selected bool `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
+}
+
+func (m *BlockedEvent) Reset()         { *m = BlockedEvent{} }
+func (m *BlockedEvent) String() string { return proto.CompactTextString(m) }
+func (*BlockedEvent) ProtoMessage()    {}
+func (*BlockedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{40}
+}
+func (m *BlockedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockedEvent.Merge(m, src)
+}
+func (m *BlockedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockedEvent proto.InternalMessageInfo
+
+func (m *BlockedEvent) GetCommitTs() uint64 {
+	if m != nil {
+		return m.CommitTs
+	}
+	return 0
+}
+
+func (m *BlockedEvent) GetIsSyncPoint() bool {
+	if m != nil {
+		return m.IsSyncPoint
+	}
+	return false
+}
+
+func (m *BlockedEvent) GetSelected() bool {
+	if m != nil {
+		return m.Selected
+	}
+	return false
+}
+
+// DispatcherBarrierState is the block events involving the dispatcher which are not resolved,
+// it's empty if the dispatcher is not blocked by the maintainer.
+type DispatcherBarrierState struct {
+	DispatcherID  *DispatcherID   `protobuf:"bytes,1,opt,name=dispatcherID,proto3" json:"dispatcherID,omitempty"`
+	BlockedEvents []*BlockedEvent `protobuf:"bytes,2,rep,name=blockedEvents,proto3" json:"blockedEvents,omitempty"`
+}
+
+func (m *DispatcherBarrierState) Reset()         { *m = DispatcherBarrierState{} }
+func (m *DispatcherBarrierState) String() string { return proto.CompactTextString(m) }
+func (*DispatcherBarrierState) ProtoMessage()    {}
+func (*DispatcherBarrierState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{41}
+}
+func (m *DispatcherBarrierState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DispatcherBarrierState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DispatcherBarrierState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DispatcherBarrierState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DispatcherBarrierState.Merge(m, src)
+}
+func (m *DispatcherBarrierState) XXX_Size() int {
+	return m.Size()
+}
+func (m *DispatcherBarrierState) XXX_DiscardUnknown() {
+	xxx_messageInfo_DispatcherBarrierState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DispatcherBarrierState proto.InternalMessageInfo
+
+func (m *DispatcherBarrierState) GetDispatcherID() *DispatcherID {
+	if m != nil {
+		return m.DispatcherID
+	}
+	return nil
+}
+
+func (m *DispatcherBarrierState) GetBlockedEvents() []*BlockedEvent {
+	if m != nil {
+		return m.BlockedEvents
+	}
+	return nil
+}
+
+// NodeCapacity is the capacity limits of a node and the resources used by the dispatchers on it,
+// the limits are 0 if they are not set.
+type NodeCapacity struct {
+	MaxMaintainers int64  `protobuf:"varint,1,opt,name=max_maintainers,json=maxMaintainers,proto3" json:"max_maintainers,omitempty"`
+	MaxDispatchers int64  `protobuf:"varint,2,opt,name=max_dispatchers,json=maxDispatchers,proto3" json:"max_dispatchers,omitempty"`
+	MemoryBudget   uint64 `protobuf:"varint,3,opt,name=memory_budget,json=memoryBudget,proto3" json:"memory_budget,omitempty"`
+	// the number of the table dispatchers on the node.
+	DispatcherCount int64 `protobuf:"varint,4,opt,name=dispatcher_count,json=dispatcherCount,proto3" json:"dispatcher_count,omitempty"`
+	// the sum of the memory quotas of the changefeeds which have dispatchers on the node.
+	MemoryQuota uint64 `protobuf:"varint,5,opt,name=memory_quota,json=memoryQuota,proto3" json:"memory_quota,omitempty"`
+}
+
+func (m *NodeCapacity) Reset()         { *m = NodeCapacity{} }
+func (m *NodeCapacity) String() string { return proto.CompactTextString(m) }
+func (*NodeCapacity) ProtoMessage()    {}
+func (*NodeCapacity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{42}
+}
+func (m *NodeCapacity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeCapacity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeCapacity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeCapacity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeCapacity.Merge(m, src)
+}
+func (m *NodeCapacity) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeCapacity) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeCapacity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeCapacity proto.InternalMessageInfo
+
+func (m *NodeCapacity) GetMaxMaintainers() int64 {
+	if m != nil {
+		return m.MaxMaintainers
+	}
+	return 0
+}
+
+func (m *NodeCapacity) GetMaxDispatchers() int64 {
+	if m != nil {
+		return m.MaxDispatchers
+	}
+	return 0
+}
+
+func (m *NodeCapacity) GetMemoryBudget() uint64 {
+	if m != nil {
+		return m.MemoryBudget
+	}
+	return 0
+}
+
+func (m *NodeCapacity) GetDispatcherCount() int64 {
+	if m != nil {
+		return m.DispatcherCount
+	}
+	return 0
+}
+
+func (m *NodeCapacity) GetMemoryQuota() uint64 {
+	if m != nil {
+		return m.MemoryQuota
+	}
+	return 0
+}
+
+// NodeLoad is the resources in use on a node when it's reported.
+type NodeLoad struct {
+	// the memory accounted by the components of the node.
+	MemoryUsage uint64 `protobuf:"varint,1,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	// the bytes of the events pending in the queues of the dispatchers on the node.
+	DispatcherBacklog uint64 `protobuf:"varint,2,opt,name=dispatcher_backlog,json=dispatcherBacklog,proto3" json:"dispatcher_backlog,omitempty"`
+}
+
+func (m *NodeLoad) Reset()         { *m = NodeLoad{} }
+func (m *NodeLoad) String() string { return proto.CompactTextString(m) }
+func (*NodeLoad) ProtoMessage()    {}
+func (*NodeLoad) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{43}
+}
+func (m *NodeLoad) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeLoad) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeLoad.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeLoad) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeLoad.Merge(m, src)
+}
+func (m *NodeLoad) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeLoad) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeLoad.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeLoad proto.InternalMessageInfo
+
+func (m *NodeLoad) GetMemoryUsage() uint64 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
+func (m *NodeLoad) GetDispatcherBacklog() uint64 {
+	if m != nil {
+		return m.DispatcherBacklog
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("heartbeatpb.Action", Action_name, Action_value)
 	proto.RegisterEnum("heartbeatpb.ScheduleAction", ScheduleAction_name, ScheduleAction_value)
@@ -2401,6 +3136,8 @@ func init() {
 	proto.RegisterType((*ScheduleDispatcherRequest)(nil), "heartbeatpb.ScheduleDispatcherRequest")
 	proto.RegisterType((*MaintainerHeartbeat)(nil), "heartbeatpb.MaintainerHeartbeat")
 	proto.RegisterType((*MaintainerStatus)(nil), "heartbeatpb.MaintainerStatus")
+	proto.RegisterType((*BarrierCoverage)(nil), "heartbeatpb.BarrierCoverage")
+	proto.RegisterType((*TableBackfill)(nil), "heartbeatpb.TableBackfill")
 	proto.RegisterType((*CoordinatorBootstrapRequest)(nil), "heartbeatpb.CoordinatorBootstrapRequest")
 	proto.RegisterType((*CoordinatorBootstrapResponse)(nil), "heartbeatpb.CoordinatorBootstrapResponse")
 	proto.RegisterType((*AddMaintainerRequest)(nil), "heartbeatpb.AddMaintainerRequest")
@@ -2424,126 +3161,173 @@ func init() {
 	proto.RegisterType((*RunningError)(nil), "heartbeatpb.RunningError")
 	proto.RegisterType((*DispatcherID)(nil), "heartbeatpb.DispatcherID")
 	proto.RegisterType((*ChangefeedID)(nil), "heartbeatpb.ChangefeedID")
+	proto.RegisterType((*QuiesceRequest)(nil), "heartbeatpb.QuiesceRequest")
+	proto.RegisterType((*ResendTableSchemaRequest)(nil), "heartbeatpb.ResendTableSchemaRequest")
+	proto.RegisterType((*BlockedEvent)(nil), "heartbeatpb.BlockedEvent")
+	proto.RegisterType((*DispatcherBarrierState)(nil), "heartbeatpb.DispatcherBarrierState")
+	proto.RegisterType((*NodeCapacity)(nil), "heartbeatpb.NodeCapacity")
+	proto.RegisterType((*NodeLoad)(nil), "heartbeatpb.NodeLoad")
 }
 
 func init() { proto.RegisterFile("heartbeatpb/heartbeat.proto", fileDescriptor_6d584080fdadb670) }
 
 var fileDescriptor_6d584080fdadb670 = []byte{
-	// 1821 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4d, 0x6f, 0x1b, 0xc7,
-	0x55, 0xbb, 0x4b, 0x52, 0xe4, 0xa3, 0x24, 0x6f, 0xc6, 0xb1, 0x4d, 0x5b, 0x36, 0xa3, 0x4c, 0x7b,
-	0x60, 0x95, 0x56, 0x86, 0x95, 0x18, 0x69, 0x8b, 0xa6, 0xa9, 0x44, 0xb9, 0x09, 0x21, 0x98, 0x11,
-	0x86, 0x2a, 0xdc, 0xf4, 0x42, 0xac, 0x76, 0x47, 0xd4, 0x42, 0xe4, 0xee, 0x7a, 0x67, 0x29, 0xc9,
-	0x01, 0x7a, 0xea, 0xb5, 0x87, 0x1e, 0x7b, 0x68, 0x0f, 0xb9, 0xb5, 0xbf, 0xa4, 0x3d, 0xe6, 0xd4,
-	0xf6, 0xd0, 0x43, 0x61, 0xa3, 0x7f, 0xa0, 0x40, 0xd1, 0x6b, 0x31, 0xb3, 0x33, 0xfb, 0xc5, 0xa5,
-	0x24, 0x43, 0x44, 0x4e, 0x9c, 0x79, 0xf3, 0xbe, 0xe6, 0x7d, 0xcf, 0x12, 0xd6, 0x4f, 0xa8, 0x15,
-	0x46, 0x47, 0xd4, 0x8a, 0x82, 0xa3, 0xc7, 0xc9, 0x7a, 0x2b, 0x08, 0xfd, 0xc8, 0x47, 0xcd, 0xcc,
-	0x21, 0xfe, 0x12, 0x1a, 0x87, 0xd6, 0xd1, 0x98, 0x0e, 0x02, 0xcb, 0x43, 0x2d, 0x58, 0x16, 0x9b,
-	0xde, 0x5e, 0x4b, 0xdb, 0xd0, 0x3a, 0x06, 0x51, 0x5b, 0xf4, 0x00, 0xea, 0x83, 0xc8, 0x0a, 0xa3,
-	0x7d, 0xfa, 0xaa, 0xa5, 0x6f, 0x68, 0x9d, 0x15, 0x92, 0xec, 0xd1, 0x5d, 0xa8, 0x3d, 0xf3, 0x1c,
-	0x7e, 0x62, 0x88, 0x13, 0xb9, 0xc3, 0xbf, 0xd7, 0xc1, 0xfc, 0x9c, 0x8b, 0xda, 0xa5, 0x56, 0x44,
-	0xe8, 0xcb, 0x29, 0x65, 0x11, 0xfa, 0x04, 0x56, 0xec, 0x13, 0xcb, 0x1b, 0xd1, 0x63, 0x4a, 0x1d,
-	0x29, 0xa7, 0xb9, 0x7d, 0x7f, 0x2b, 0xa3, 0xd3, 0x56, 0x37, 0x83, 0x40, 0x72, 0xe8, 0xe8, 0x23,
-	0x68, 0x9c, 0x5b, 0x11, 0x0d, 0x27, 0x56, 0x78, 0x2a, 0x14, 0x69, 0x6e, 0xdf, 0xcd, 0xd1, 0xbe,
-	0x50, 0xa7, 0x24, 0x45, 0x44, 0x3f, 0x84, 0x3a, 0x8b, 0xac, 0x68, 0xca, 0x28, 0x6b, 0x19, 0x1b,
-	0x46, 0xa7, 0xb9, 0xfd, 0x30, 0x47, 0x94, 0x58, 0x60, 0x20, 0xb0, 0x48, 0x82, 0x8d, 0x3a, 0x70,
-	0xcb, 0xf6, 0x27, 0x01, 0x1d, 0xd3, 0x88, 0xc6, 0x87, 0xad, 0xca, 0x86, 0xd6, 0xa9, 0x93, 0x22,
-	0x18, 0x7d, 0x00, 0x06, 0x0d, 0xc3, 0x56, 0xb5, 0xe4, 0x3e, 0x64, 0xea, 0x79, 0xae, 0x37, 0x7a,
-	0x16, 0x86, 0x7e, 0x48, 0x38, 0x16, 0xb6, 0xa0, 0x91, 0x28, 0x8a, 0x30, 0x37, 0x09, 0xb5, 0x4f,
-	0x03, 0xdf, 0xf5, 0xa2, 0x43, 0x26, 0x4c, 0x52, 0x21, 0x39, 0x18, 0x6a, 0x03, 0x84, 0x94, 0xf9,
-	0xe3, 0x33, 0xea, 0x1c, 0x32, 0x71, 0xf1, 0x0a, 0xc9, 0x40, 0x90, 0x09, 0x06, 0xa3, 0x2f, 0x85,
-	0x03, 0x2a, 0x84, 0x2f, 0xf1, 0xaf, 0xc1, 0xdc, 0x73, 0x59, 0x60, 0x45, 0xf6, 0x09, 0x0d, 0x77,
-	0xec, 0xc8, 0xf5, 0x3d, 0xf4, 0x01, 0xd4, 0x2c, 0xb1, 0x12, 0x32, 0xd6, 0xb6, 0x6f, 0xe7, 0xd4,
-	0x8c, 0x91, 0x88, 0x44, 0xe1, 0x2e, 0xef, 0xfa, 0x93, 0x89, 0x1b, 0x25, 0x02, 0x93, 0x3d, 0xda,
-	0x80, 0x66, 0x8f, 0x0d, 0x5e, 0x79, 0xf6, 0x01, 0xd7, 0x4f, 0x88, 0xad, 0x93, 0x2c, 0x08, 0x77,
-	0xc1, 0xd8, 0xe9, 0xee, 0xe7, 0x98, 0x68, 0x97, 0x33, 0xd1, 0x67, 0x99, 0xfc, 0x46, 0x87, 0x3b,
-	0x3d, 0xef, 0x78, 0x3c, 0xa5, 0x9e, 0x4d, 0x9d, 0xf4, 0x3a, 0x0c, 0xfd, 0x0c, 0x56, 0x93, 0x83,
-	0xc3, 0x57, 0x01, 0x95, 0x17, 0x7a, 0x90, 0xbb, 0x50, 0x0e, 0x83, 0xe4, 0x09, 0xd0, 0xa7, 0xb0,
-	0x9a, 0x32, 0xec, 0xed, 0xf1, 0x3b, 0x1a, 0x33, 0x9e, 0xcb, 0x62, 0x90, 0x3c, 0xbe, 0x48, 0x09,
-	0xfb, 0x84, 0x4e, 0xac, 0xde, 0x9e, 0x30, 0x80, 0x41, 0x92, 0x3d, 0xda, 0x87, 0xdb, 0xf4, 0xc2,
-	0x1e, 0x4f, 0x1d, 0x9a, 0xa1, 0x71, 0x44, 0xe8, 0x5c, 0x2a, 0xa2, 0x8c, 0x0a, 0xff, 0x45, 0xcb,
-	0xba, 0x52, 0x86, 0xdb, 0x2f, 0xe1, 0x8e, 0x5b, 0x66, 0x19, 0x99, 0x50, 0xb8, 0xdc, 0x10, 0x59,
-	0x4c, 0x52, 0xce, 0x00, 0x3d, 0x4d, 0x82, 0x24, 0xce, 0xaf, 0x47, 0x73, 0xd4, 0x2d, 0x84, 0x0b,
-	0x06, 0xc3, 0xb2, 0x4f, 0x85, 0x25, 0x9a, 0xdb, 0x66, 0x3e, 0xb0, 0xba, 0xfb, 0x84, 0x1f, 0xe2,
-	0xaf, 0x35, 0x78, 0x27, 0x53, 0x11, 0x58, 0xe0, 0x7b, 0x8c, 0xde, 0xb4, 0x24, 0x3c, 0x07, 0xe4,
-	0x14, 0xac, 0x43, 0x95, 0x37, 0xe7, 0xe9, 0x2e, 0xf3, 0xbc, 0x84, 0x10, 0x5f, 0xc0, 0xed, 0x6e,
-	0x26, 0xf3, 0x9e, 0x53, 0xc6, 0xac, 0xd1, 0x8d, 0x95, 0x2c, 0xe6, 0xb8, 0x3e, 0x9b, 0xe3, 0xf8,
-	0xef, 0x39, 0x3f, 0x77, 0x7d, 0xef, 0xd8, 0x1d, 0xa1, 0x4d, 0xa8, 0xb0, 0xc0, 0xf2, 0xa4, 0xbc,
-	0xbb, 0xe5, 0x65, 0x8b, 0x08, 0x1c, 0x5e, 0xbe, 0x19, 0x2f, 0xca, 0x09, 0x7f, 0xb5, 0xe5, 0xda,
-	0x3b, 0x99, 0x38, 0x93, 0x5e, 0xba, 0x24, 0x10, 0x73, 0xe8, 0x3c, 0xd4, 0x99, 0x0a, 0xf5, 0x4a,
-	0x1c, 0xea, 0x6a, 0x8f, 0x30, 0xac, 0xda, 0xd3, 0x30, 0xa4, 0x5e, 0x34, 0x0c, 0x9c, 0x61, 0xc4,
-	0x44, 0x05, 0xac, 0x90, 0xa6, 0x04, 0x1e, 0x38, 0x87, 0x0c, 0xff, 0x4d, 0x83, 0xfb, 0x3c, 0x37,
-	0x9c, 0xe9, 0x38, 0x13, 0xda, 0x0b, 0x6a, 0x09, 0x4f, 0xa1, 0x66, 0x0b, 0x5b, 0x5d, 0x11, 0xaf,
-	0xb1, 0x41, 0x89, 0x44, 0x46, 0x5d, 0x58, 0x63, 0x52, 0xa5, 0x38, 0x92, 0x85, 0x51, 0xd6, 0xb6,
-	0xd7, 0x73, 0xe4, 0x83, 0x1c, 0x0a, 0x29, 0x90, 0xe0, 0x03, 0xb8, 0xfd, 0xdc, 0x72, 0xbd, 0xc8,
-	0x72, 0x3d, 0x1a, 0x7e, 0xae, 0xe8, 0xd0, 0x8f, 0x32, 0xfd, 0x46, 0x2b, 0x09, 0xc4, 0x94, 0xa6,
-	0xd8, 0x70, 0xf0, 0x7f, 0x35, 0x30, 0x8b, 0xc7, 0x37, 0xb5, 0xd0, 0x23, 0x00, 0xbe, 0x1a, 0x72,
-	0x21, 0x54, 0x58, 0xa9, 0x41, 0x1a, 0x1c, 0xc2, 0xd9, 0x53, 0xf4, 0x04, 0xaa, 0xf1, 0x49, 0x99,
-	0x01, 0xba, 0xfe, 0x24, 0xf0, 0x3d, 0xea, 0x45, 0x02, 0x97, 0xc4, 0x98, 0xe8, 0x3b, 0xb0, 0x9a,
-	0x86, 0x2e, 0x77, 0x7a, 0xa5, 0xa4, 0x67, 0x25, 0x1d, 0xd1, 0xb8, 0x46, 0x47, 0xfc, 0x18, 0xd6,
-	0xbb, 0xbe, 0x1f, 0x3a, 0xae, 0x67, 0x45, 0x7e, 0xb8, 0xeb, 0xfb, 0x11, 0x8b, 0x42, 0x2b, 0x50,
-	0x31, 0xd2, 0x82, 0xe5, 0x33, 0x1a, 0x32, 0xd5, 0xba, 0x0c, 0xa2, 0xb6, 0xf8, 0x4b, 0x78, 0x58,
-	0x4e, 0x28, 0xab, 0xcb, 0x0d, 0x7c, 0xf1, 0x27, 0x0d, 0xde, 0xdd, 0x71, 0x9c, 0x14, 0x43, 0x69,
-	0xf3, 0x3d, 0xd0, 0x5d, 0xe7, 0x6a, 0x2f, 0xe8, 0xae, 0xc3, 0x87, 0xa3, 0x4c, 0x74, 0xae, 0x24,
-	0xe1, 0x37, 0x63, 0x41, 0xa3, 0xc4, 0x82, 0x1d, 0x30, 0x5d, 0x36, 0xf4, 0xe8, 0xf9, 0x50, 0xf8,
-	0x93, 0xb3, 0x95, 0xe3, 0xc7, 0x9a, 0xcb, 0xfa, 0xf4, 0xbc, 0xab, 0xa0, 0xf8, 0x02, 0xee, 0x11,
-	0x3a, 0xf1, 0xcf, 0xe8, 0x8d, 0x94, 0x6d, 0xc1, 0xb2, 0x6d, 0x31, 0xdb, 0x72, 0xa8, 0xec, 0xc6,
-	0x6a, 0xcb, 0x4f, 0x42, 0xc1, 0xdf, 0x91, 0xcd, 0x5e, 0x6d, 0xf1, 0x1f, 0x75, 0x78, 0x90, 0x0a,
-	0x9d, 0x71, 0xdc, 0x0d, 0x43, 0x77, 0x9e, 0xf9, 0xee, 0x0b, 0xaf, 0x86, 0x19, 0xcb, 0x25, 0xb5,
-	0xce, 0x86, 0xf7, 0x23, 0x5e, 0x18, 0x87, 0x51, 0xe8, 0x8e, 0x46, 0x34, 0x1c, 0xd2, 0x33, 0x5e,
-	0x9c, 0xd2, 0x82, 0x36, 0x74, 0xaf, 0xd1, 0x89, 0x1f, 0x09, 0x1e, 0x87, 0x31, 0x8b, 0x67, 0x9c,
-	0x43, 0xb6, 0x27, 0x97, 0x7a, 0xa6, 0x5a, 0xea, 0x99, 0x7f, 0x6b, 0xb0, 0x5e, 0x6a, 0x9f, 0xc5,
-	0x74, 0xbf, 0xa7, 0x50, 0xe5, 0xb5, 0x5f, 0x35, 0xbc, 0xf7, 0x72, 0x74, 0x89, 0xb4, 0xb4, 0x53,
-	0xc4, 0xd8, 0x2a, 0x37, 0x8d, 0xeb, 0x4c, 0xab, 0xd7, 0xca, 0x76, 0xfc, 0x3f, 0x0d, 0xda, 0xe9,
-	0x3d, 0x0f, 0x7c, 0x16, 0x2d, 0x3a, 0x16, 0xae, 0xe5, 0x58, 0xfd, 0x86, 0x8e, 0x7d, 0x02, 0xcb,
-	0x71, 0x6b, 0x53, 0x2f, 0x85, 0x7b, 0x33, 0xfd, 0x60, 0x62, 0xf5, 0xbc, 0x63, 0x9f, 0x28, 0x3c,
-	0xfc, 0x1f, 0x0d, 0xde, 0x9b, 0x7b, 0xf3, 0xc5, 0x78, 0xf9, 0x5b, 0xb9, 0xfa, 0xdb, 0xc4, 0x04,
-	0xbe, 0x00, 0x48, 0x6d, 0x91, 0x9b, 0x85, 0xb5, 0xc2, 0x2c, 0xdc, 0x56, 0x98, 0x7d, 0x6b, 0xa2,
-	0xba, 0x4f, 0x06, 0x82, 0xb6, 0xa0, 0x26, 0xc2, 0x53, 0x19, 0xbc, 0x64, 0xc6, 0x11, 0xf6, 0x96,
-	0x58, 0xb8, 0x2b, 0x5f, 0xac, 0x42, 0xf0, 0xfc, 0x17, 0xeb, 0x43, 0x89, 0x96, 0x91, 0x9a, 0x02,
-	0xf0, 0x9f, 0x75, 0x40, 0xb3, 0xd9, 0xc1, 0x6b, 0xe5, 0x1c, 0xe7, 0xe4, 0x0c, 0xa9, 0xcb, 0x17,
-	0xb1, 0xba, 0xb2, 0x5e, 0xb8, 0xb2, 0x1a, 0xda, 0x8c, 0x6b, 0x0c, 0x6d, 0x3f, 0x07, 0xd3, 0x56,
-	0x3d, 0x76, 0xc8, 0xd2, 0x27, 0xe6, 0x15, 0x8d, 0xf8, 0x96, 0x9d, 0xdd, 0x4f, 0xd9, 0x6c, 0x92,
-	0x56, 0x4b, 0x1a, 0xca, 0x87, 0xd0, 0x3c, 0x1a, 0xfb, 0xf6, 0xa9, 0x1c, 0x05, 0x6a, 0x42, 0x3f,
-	0x94, 0x8f, 0x70, 0xc1, 0x1e, 0x04, 0x9a, 0x58, 0xe3, 0x97, 0x70, 0x37, 0x0d, 0xef, 0xee, 0xd8,
-	0x67, 0x74, 0x41, 0x09, 0x9d, 0x69, 0x2a, 0x7a, 0xbe, 0xa9, 0x84, 0x70, 0x6f, 0x46, 0xe4, 0x62,
-	0x32, 0x89, 0xcf, 0xc8, 0x53, 0xdb, 0xa6, 0x8c, 0x29, 0x99, 0x72, 0x8b, 0x7f, 0xab, 0x81, 0x99,
-	0x3e, 0x94, 0xe2, 0x60, 0x5b, 0xc0, 0x3b, 0xf3, 0x01, 0xd4, 0x65, 0x48, 0xc6, 0x35, 0xda, 0x20,
-	0xc9, 0xfe, 0xb2, 0x27, 0x24, 0xfe, 0x04, 0xaa, 0x02, 0xef, 0x8a, 0x8f, 0x32, 0x73, 0x42, 0x10,
-	0x7b, 0xb0, 0xa6, 0xd6, 0xb1, 0x35, 0x2e, 0xe1, 0xb3, 0x01, 0xcd, 0x2f, 0xc6, 0x4e, 0x81, 0x55,
-	0x16, 0xc4, 0x31, 0xfa, 0xf4, 0xbc, 0xa0, 0x6b, 0x16, 0x84, 0xbf, 0x36, 0xa0, 0x1a, 0x8f, 0x93,
-	0x0f, 0xa1, 0xd1, 0x63, 0xbb, 0x3c, 0x7c, 0x68, 0x3c, 0x76, 0xd4, 0x49, 0x0a, 0xe0, 0x5a, 0x88,
-	0x65, 0xfa, 0x46, 0x91, 0x5b, 0xf4, 0x29, 0x34, 0xe3, 0xa5, 0x2a, 0x06, 0xb3, 0xc3, 0x7c, 0xd1,
-	0x3d, 0x24, 0x4b, 0x81, 0xf6, 0xe1, 0x9d, 0x3e, 0xa5, 0xce, 0x5e, 0xe8, 0x07, 0x81, 0xc2, 0x90,
-	0x8d, 0xfe, 0x0a, 0x36, 0xb3, 0x74, 0xe8, 0x27, 0x70, 0x8b, 0x03, 0x77, 0x1c, 0x27, 0x61, 0x15,
-	0x0f, 0xb2, 0x68, 0x36, 0x9b, 0x49, 0x11, 0x95, 0x3f, 0x2e, 0x7e, 0x11, 0x38, 0x56, 0x44, 0xa5,
-	0x09, 0x59, 0xab, 0x26, 0x88, 0xd7, 0xcb, 0x9a, 0x89, 0x74, 0x10, 0x29, 0x90, 0x14, 0xbf, 0x8f,
-	0x2c, 0xcf, 0x7c, 0x1f, 0x41, 0x3f, 0x10, 0x93, 0xfb, 0x88, 0xb6, 0xea, 0x22, 0x2a, 0xf3, 0xad,
-	0x6a, 0x57, 0x66, 0xf0, 0x28, 0x9e, 0xda, 0x47, 0x14, 0x9f, 0xc2, 0xbb, 0x49, 0xf5, 0x51, 0xa7,
-	0xbc, 0x74, 0xbc, 0x45, 0xd5, 0xeb, 0xa8, 0xb7, 0x82, 0x3e, 0xb7, 0x74, 0xc4, 0x08, 0xf8, 0x9f,
-	0x1a, 0xdc, 0x2a, 0x7c, 0x57, 0x7b, 0x1b, 0x41, 0x65, 0x65, 0x51, 0x5f, 0x44, 0x59, 0x2c, 0x9b,
-	0xb3, 0x9f, 0xc0, 0x9d, 0xb8, 0xa1, 0x32, 0xf7, 0x2b, 0x3a, 0x0c, 0x68, 0x38, 0x64, 0xd4, 0xf6,
-	0xbd, 0x78, 0x4c, 0xd4, 0x09, 0x12, 0x87, 0x03, 0xf7, 0x2b, 0x7a, 0x40, 0xc3, 0x81, 0x38, 0xc1,
-	0x7f, 0xd0, 0x00, 0x65, 0x6c, 0xb8, 0xa0, 0x8a, 0xf8, 0x19, 0xac, 0x1e, 0xa5, 0x4c, 0x93, 0xcf,
-	0x18, 0xef, 0x97, 0x77, 0x90, 0xac, 0xfc, 0x3c, 0x1d, 0x76, 0x60, 0x25, 0xdb, 0xb3, 0x11, 0x82,
-	0x4a, 0xe4, 0x4e, 0xe2, 0xf2, 0xd5, 0x20, 0x62, 0xcd, 0x61, 0x9e, 0xef, 0xa8, 0xe6, 0x28, 0xd6,
-	0x1c, 0x66, 0x73, 0x98, 0x11, 0xc3, 0xf8, 0x9a, 0xa7, 0xec, 0x24, 0xfe, 0x0a, 0x22, 0xec, 0xd1,
-	0x20, 0x6a, 0x8b, 0x3f, 0x82, 0x95, 0xac, 0xe3, 0x38, 0xf5, 0x89, 0x3b, 0x3a, 0x91, 0x5f, 0xfa,
-	0xc4, 0x1a, 0x99, 0x60, 0x8c, 0xfd, 0x73, 0x99, 0xec, 0x7c, 0x89, 0x8f, 0x61, 0x25, 0x6b, 0x82,
-	0xeb, 0x51, 0x09, 0x6d, 0x79, 0x2b, 0x97, 0x9a, 0xf1, 0x35, 0x2f, 0x35, 0xfc, 0x97, 0x05, 0x96,
-	0xad, 0x74, 0x4b, 0x01, 0x9b, 0x8f, 0xa0, 0x26, 0xbf, 0x7b, 0x36, 0xa0, 0xfa, 0x22, 0x74, 0x23,
-	0x6a, 0x2e, 0xa1, 0x3a, 0x54, 0x0e, 0x2c, 0xc6, 0x4c, 0x6d, 0xb3, 0x13, 0x57, 0xc8, 0xf4, 0x35,
-	0x8f, 0x00, 0x6a, 0xdd, 0x90, 0x5a, 0x02, 0x0f, 0xa0, 0x16, 0x3f, 0xa8, 0x4c, 0x6d, 0xf3, 0xc7,
-	0x00, 0x69, 0x32, 0x71, 0x0e, 0xfd, 0x2f, 0xfa, 0xcf, 0xcc, 0x25, 0xd4, 0x84, 0xe5, 0x17, 0x3b,
-	0xbd, 0xc3, 0x5e, 0xff, 0x33, 0x53, 0x13, 0x1b, 0x12, 0x6f, 0x74, 0x8e, 0xb3, 0xc7, 0x71, 0x8c,
-	0xcd, 0xef, 0x17, 0x1a, 0x08, 0x5a, 0x06, 0x63, 0x67, 0x3c, 0x36, 0x97, 0x50, 0x0d, 0xf4, 0xbd,
-	0x5d, 0x53, 0xe3, 0x92, 0xfa, 0x7e, 0x38, 0xb1, 0xc6, 0xa6, 0xbe, 0xf9, 0x31, 0xac, 0xe5, 0x03,
-	0x5a, 0xb0, 0xf5, 0xc3, 0x53, 0xd7, 0x1b, 0xc5, 0x02, 0x07, 0x91, 0xa8, 0x52, 0xb1, 0xc0, 0x58,
-	0x43, 0xc7, 0xd4, 0x77, 0x7f, 0xfa, 0xd7, 0xd7, 0x6d, 0xed, 0x9b, 0xd7, 0x6d, 0xed, 0x5f, 0xaf,
-	0xdb, 0xda, 0xef, 0xde, 0xb4, 0x97, 0xbe, 0x79, 0xd3, 0x5e, 0xfa, 0xc7, 0x9b, 0xf6, 0xd2, 0xaf,
-	0xbe, 0x3b, 0x72, 0xa3, 0x93, 0xe9, 0xd1, 0x96, 0xed, 0x4f, 0x1e, 0x07, 0xae, 0x37, 0xb2, 0xad,
-	0xe0, 0x71, 0xe4, 0xda, 0x8e, 0xfd, 0x38, 0x13, 0x53, 0x47, 0x35, 0xf1, 0xd7, 0xc0, 0x87, 0xff,
-	0x0f, 0x00, 0x00, 0xff, 0xff, 0x8c, 0xfa, 0xc2, 0x4b, 0x39, 0x18, 0x00, 0x00,
+	// 2475 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4d, 0x6f, 0x1c, 0x49,
+	0xd5, 0xdd, 0x3d, 0x9e, 0x8f, 0x37, 0x63, 0x7b, 0x52, 0xf9, 0x9a, 0xc4, 0x89, 0xe3, 0x74, 0x90,
+	0x70, 0xbc, 0x6c, 0x42, 0xbc, 0x1b, 0xed, 0x82, 0x58, 0x82, 0x3d, 0xce, 0xee, 0x5a, 0x26, 0x4e,
+	0xb6, 0xec, 0x25, 0x80, 0x90, 0x46, 0xe5, 0xee, 0xf2, 0xb8, 0xe5, 0x9e, 0xee, 0x4e, 0x57, 0x4f,
+	0x6c, 0xaf, 0xc4, 0x01, 0x71, 0xe0, 0xc2, 0x61, 0xe1, 0xc0, 0x82, 0xc4, 0x65, 0xb9, 0x20, 0xae,
+	0xfc, 0x09, 0x38, 0xae, 0xc4, 0x01, 0x8e, 0x28, 0xf9, 0x07, 0xfc, 0x01, 0x50, 0x55, 0x75, 0x75,
+	0x57, 0xcf, 0xb4, 0x1d, 0x1b, 0x5b, 0x7b, 0xea, 0x7a, 0xaf, 0x5e, 0xbd, 0x7a, 0xfd, 0xea, 0x7d,
+	0xd5, 0x2b, 0x98, 0xdd, 0xa5, 0x24, 0x4e, 0xb6, 0x29, 0x49, 0xa2, 0xed, 0xfb, 0xd9, 0xf8, 0x5e,
+	0x14, 0x87, 0x49, 0x88, 0x9a, 0xda, 0xa4, 0xfd, 0x13, 0x68, 0x6c, 0x91, 0x6d, 0x9f, 0x6e, 0x46,
+	0x24, 0x40, 0x1d, 0xa8, 0x09, 0x60, 0x6d, 0xb5, 0x63, 0xcc, 0x1b, 0x0b, 0x16, 0x56, 0x20, 0xba,
+	0x0e, 0xf5, 0xcd, 0x84, 0xc4, 0xc9, 0x3a, 0x3d, 0xec, 0x98, 0xf3, 0xc6, 0x42, 0x0b, 0x67, 0x30,
+	0xba, 0x02, 0xd5, 0xc7, 0x81, 0xcb, 0x67, 0x2c, 0x31, 0x93, 0x42, 0xf6, 0x6f, 0x2c, 0x68, 0x7f,
+	0xcc, 0xb7, 0x5a, 0xa1, 0x24, 0xc1, 0xf4, 0xc5, 0x90, 0xb2, 0x04, 0x7d, 0x00, 0x2d, 0x67, 0x97,
+	0x04, 0x7d, 0xba, 0x43, 0xa9, 0x9b, 0xee, 0xd3, 0x5c, 0xba, 0x76, 0x4f, 0x93, 0xe9, 0x5e, 0x57,
+	0x23, 0xc0, 0x05, 0x72, 0xf4, 0x2e, 0x34, 0xf6, 0x49, 0x42, 0xe3, 0x01, 0x89, 0xf7, 0x84, 0x20,
+	0xcd, 0xa5, 0x2b, 0x85, 0xb5, 0xcf, 0xd5, 0x2c, 0xce, 0x09, 0xd1, 0xfb, 0x50, 0x67, 0x09, 0x49,
+	0x86, 0x8c, 0xb2, 0x8e, 0x35, 0x6f, 0x2d, 0x34, 0x97, 0x6e, 0x14, 0x16, 0x65, 0x1a, 0xd8, 0x14,
+	0x54, 0x38, 0xa3, 0x46, 0x0b, 0x30, 0xe3, 0x84, 0x83, 0x88, 0xfa, 0x34, 0xa1, 0x72, 0xb2, 0x53,
+	0x99, 0x37, 0x16, 0xea, 0x78, 0x14, 0x8d, 0xde, 0x02, 0x8b, 0xc6, 0x71, 0x67, 0xb2, 0xe4, 0x7f,
+	0xf0, 0x30, 0x08, 0xbc, 0xa0, 0xff, 0x38, 0x8e, 0xc3, 0x18, 0x73, 0x2a, 0x64, 0x43, 0x2b, 0x08,
+	0x5d, 0xba, 0x99, 0x84, 0x51, 0xe4, 0x05, 0xfd, 0x4e, 0x55, 0xf0, 0x2c, 0xe0, 0xd0, 0x0d, 0x68,
+	0xbc, 0x18, 0x7a, 0x94, 0x39, 0x74, 0x8b, 0x75, 0x6a, 0xf3, 0xc6, 0x42, 0x05, 0xe7, 0x08, 0xf4,
+	0x10, 0xea, 0x0e, 0x89, 0x88, 0xe3, 0x25, 0x87, 0x9d, 0x7a, 0xc9, 0x9e, 0x1b, 0xa1, 0x4b, 0xbb,
+	0x29, 0x01, 0xce, 0x48, 0x6d, 0x02, 0x8d, 0x4c, 0x43, 0x5c, 0x0a, 0x67, 0x97, 0x3a, 0x7b, 0x51,
+	0xe8, 0x05, 0xc9, 0x16, 0x13, 0x67, 0x51, 0xc1, 0x05, 0x1c, 0x9a, 0x03, 0x88, 0x29, 0x0b, 0xfd,
+	0x97, 0xd4, 0xdd, 0x62, 0x42, 0xe3, 0x15, 0xac, 0x61, 0x50, 0x1b, 0x2c, 0x46, 0x5f, 0x88, 0x93,
+	0xaf, 0x60, 0x3e, 0xb4, 0x7f, 0x0e, 0xed, 0x55, 0x8f, 0x45, 0x24, 0x71, 0x76, 0x69, 0xbc, 0xec,
+	0x24, 0x5e, 0x18, 0xa0, 0xb7, 0xa0, 0x4a, 0xc4, 0x48, 0xec, 0x31, 0xbd, 0x74, 0xb1, 0x20, 0xab,
+	0x24, 0xc2, 0x29, 0x09, 0xb7, 0xb5, 0x6e, 0x38, 0x18, 0x78, 0x49, 0xb6, 0x61, 0x06, 0xa3, 0x79,
+	0x68, 0xae, 0xb1, 0xcd, 0xc3, 0xc0, 0x79, 0xc6, 0xe5, 0x13, 0xdb, 0xd6, 0xb1, 0x8e, 0xb2, 0xbb,
+	0x60, 0x2d, 0x77, 0xd7, 0x0b, 0x4c, 0x8c, 0xe3, 0x99, 0x98, 0xe3, 0x4c, 0x7e, 0x69, 0xc2, 0xe5,
+	0xb5, 0x60, 0xc7, 0x1f, 0xd2, 0xc0, 0xa1, 0x6e, 0xfe, 0x3b, 0x0c, 0xfd, 0x00, 0xa6, 0xb2, 0x89,
+	0xad, 0xc3, 0x88, 0xa6, 0x3f, 0x74, 0xbd, 0xf0, 0x43, 0x05, 0x0a, 0x5c, 0x5c, 0x80, 0x1e, 0xc1,
+	0x54, 0xce, 0x70, 0x6d, 0x95, 0xff, 0xa3, 0x35, 0x76, 0x7c, 0x3a, 0x05, 0x2e, 0xd2, 0x0b, 0x5f,
+	0x74, 0x76, 0xe9, 0x80, 0xac, 0xad, 0x0a, 0x05, 0x58, 0x38, 0x83, 0xd1, 0x3a, 0x5c, 0xa4, 0x07,
+	0x8e, 0x3f, 0x74, 0xa9, 0xb6, 0xc6, 0x15, 0x36, 0x7b, 0xec, 0x16, 0x65, 0xab, 0xec, 0xbf, 0x19,
+	0xfa, 0x51, 0xa6, 0x76, 0xfe, 0x63, 0xb8, 0xec, 0x95, 0x69, 0x26, 0xf5, 0x64, 0xbb, 0x5c, 0x11,
+	0x3a, 0x25, 0x2e, 0x67, 0x80, 0x1e, 0x66, 0x46, 0x22, 0x1d, 0xfb, 0xe6, 0x11, 0xe2, 0x8e, 0x98,
+	0x8b, 0x0d, 0x16, 0x71, 0xf6, 0x84, 0x26, 0x9a, 0x4b, 0xed, 0xa2, 0x61, 0x75, 0xd7, 0x31, 0x9f,
+	0xb4, 0xbf, 0x30, 0xe1, 0x82, 0x16, 0x8a, 0x58, 0x14, 0x06, 0x8c, 0x9e, 0x35, 0x16, 0x3d, 0x01,
+	0xe4, 0x8e, 0x68, 0x87, 0xaa, 0xd3, 0x3c, 0x4a, 0xf6, 0x34, 0xc0, 0x94, 0x2c, 0x44, 0x6b, 0x30,
+	0xb5, 0x4d, 0xe2, 0xd8, 0x93, 0xa8, 0x2c, 0x52, 0xdd, 0x39, 0x82, 0xd3, 0x8a, 0x46, 0x8b, 0x8b,
+	0x2b, 0xd1, 0x5d, 0x68, 0x0f, 0x88, 0x17, 0x24, 0xc4, 0x0b, 0x68, 0xdc, 0xa3, 0x51, 0xe8, 0xec,
+	0x0a, 0x13, 0xa8, 0xe0, 0x99, 0x1c, 0xff, 0x98, 0xa3, 0xed, 0x03, 0xb8, 0xd8, 0xd5, 0xfc, 0xfd,
+	0x09, 0x65, 0x8c, 0xf4, 0xcf, 0xac, 0x9a, 0xd1, 0xc8, 0x62, 0x8e, 0x47, 0x16, 0xfb, 0x9f, 0x05,
+	0xeb, 0xea, 0x86, 0xc1, 0x8e, 0xd7, 0x47, 0x8b, 0x50, 0x61, 0x11, 0x09, 0x3a, 0x46, 0x49, 0x68,
+	0xcf, 0xa2, 0x34, 0xae, 0xb0, 0x34, 0x5b, 0x31, 0x9e, 0x83, 0x32, 0xfe, 0x0a, 0xe4, 0xd2, 0xbb,
+	0x9a, 0x75, 0x77, 0xac, 0x12, 0xe9, 0x0b, 0xe6, 0x5f, 0x20, 0xe7, 0x0e, 0xc6, 0x94, 0x83, 0x55,
+	0xa4, 0x83, 0x29, 0x18, 0xd9, 0x30, 0xe5, 0x0c, 0xe3, 0x98, 0x06, 0x49, 0x2f, 0x72, 0x7b, 0x09,
+	0x13, 0x01, 0xbf, 0x82, 0x9b, 0x29, 0xf2, 0x99, 0xbb, 0xc5, 0xec, 0x5f, 0x98, 0x70, 0x8d, 0x7b,
+	0xa4, 0x3b, 0xf4, 0x35, 0x87, 0x3a, 0xa7, 0x0c, 0xf8, 0x10, 0xaa, 0x8e, 0xd0, 0xd5, 0x1b, 0xbc,
+	0x44, 0x2a, 0x14, 0xa7, 0xc4, 0xa8, 0x0b, 0xd3, 0x2c, 0x15, 0x49, 0xfa, 0x8f, 0x50, 0xca, 0xf4,
+	0xd2, 0x6c, 0x61, 0xf9, 0x66, 0x81, 0x04, 0x8f, 0x2c, 0x39, 0x8d, 0x5d, 0xfd, 0xca, 0x80, 0x8b,
+	0x4f, 0x32, 0xdc, 0xc7, 0x6a, 0x0f, 0xf4, 0x1d, 0x2d, 0x15, 0x1b, 0x25, 0xae, 0x92, 0xaf, 0x19,
+	0xcb, 0xc5, 0x7a, 0xca, 0x33, 0x4f, 0x9e, 0xf2, 0xfe, 0x50, 0x81, 0xf6, 0x28, 0xd7, 0xb3, 0x1e,
+	0xc2, 0x4d, 0x00, 0x3e, 0xea, 0x71, 0xd9, 0xa8, 0x10, 0xa6, 0x81, 0x1b, 0x1c, 0xc3, 0xd9, 0x53,
+	0xf4, 0x00, 0x26, 0xe5, 0x4c, 0x99, 0x8e, 0xbb, 0xe1, 0x20, 0x0a, 0x03, 0x1a, 0x24, 0xd2, 0x75,
+	0x25, 0x25, 0xba, 0x03, 0x53, 0xb9, 0x77, 0x70, 0xbb, 0xaa, 0x94, 0x24, 0xe3, 0xac, 0xc6, 0xb0,
+	0x4e, 0x50, 0x63, 0xdc, 0x82, 0x66, 0xc2, 0x3d, 0xa6, 0xe7, 0x84, 0xc3, 0x20, 0x11, 0x25, 0x86,
+	0x85, 0x41, 0xa0, 0xba, 0x1c, 0x83, 0x1e, 0xc0, 0x65, 0xfa, 0x92, 0x1b, 0x32, 0xf3, 0x3e, 0xa3,
+	0xbd, 0x88, 0xc6, 0x3d, 0x46, 0x9d, 0x30, 0x70, 0x45, 0xb1, 0x61, 0x62, 0x24, 0x26, 0x37, 0xbd,
+	0xcf, 0xe8, 0x33, 0x1a, 0x6f, 0x8a, 0x19, 0x7e, 0x04, 0xfb, 0x24, 0xe6, 0x1b, 0xb1, 0x4e, 0xfd,
+	0x4d, 0x52, 0x64, 0xa4, 0xbc, 0xfe, 0x4a, 0x03, 0x14, 0xeb, 0x34, 0x4a, 0xea, 0xaf, 0x34, 0x96,
+	0x75, 0xc3, 0x97, 0x34, 0x26, 0x7d, 0x8a, 0x33, 0x6a, 0xf4, 0x3e, 0x34, 0xb6, 0x89, 0xb3, 0xb7,
+	0xe3, 0xf9, 0x3e, 0xeb, 0x80, 0x58, 0x7a, 0x7d, 0x3c, 0x28, 0xac, 0xa4, 0x24, 0x38, 0x27, 0xe6,
+	0x0a, 0x55, 0x5c, 0x7a, 0x3b, 0x43, 0xdf, 0xef, 0x34, 0x65, 0x8d, 0xa5, 0x90, 0x1f, 0x0e, 0x7d,
+	0xdf, 0xfe, 0xb3, 0x01, 0x33, 0x23, 0x9b, 0xa3, 0x6b, 0x50, 0xdf, 0xf6, 0x43, 0x67, 0x8f, 0x1f,
+	0x82, 0xac, 0x1c, 0x6a, 0x02, 0xde, 0x62, 0xdc, 0xf9, 0x3d, 0xd6, 0x63, 0x87, 0x81, 0xd3, 0x8b,
+	0xf4, 0xd2, 0xc1, 0xcb, 0x4b, 0x07, 0xbe, 0xaf, 0x7b, 0x18, 0x90, 0x81, 0xe7, 0xf4, 0x58, 0xe4,
+	0x7b, 0xaa, 0x46, 0x69, 0xa5, 0xc8, 0x4d, 0x8e, 0x43, 0xdf, 0x86, 0x9a, 0xc3, 0xf7, 0xa3, 0x3c,
+	0x35, 0x5b, 0xc7, 0x44, 0x3a, 0x45, 0x66, 0x3f, 0x85, 0xa9, 0xc2, 0xaf, 0x72, 0x31, 0xe5, 0xf1,
+	0x7a, 0xae, 0x2a, 0xd6, 0x05, 0xbc, 0xe6, 0x8e, 0xdb, 0x52, 0x59, 0xf8, 0x7d, 0x0f, 0x66, 0xbb,
+	0x61, 0x18, 0xbb, 0x5e, 0x40, 0x92, 0x30, 0x5e, 0x09, 0xc3, 0x84, 0x25, 0x31, 0x89, 0x54, 0x94,
+	0xea, 0x40, 0xed, 0x25, 0x8d, 0x99, 0x2a, 0xd9, 0x2c, 0xac, 0x40, 0xfb, 0x73, 0x03, 0x6e, 0x94,
+	0xaf, 0x4c, 0xd3, 0xea, 0xd7, 0xef, 0xe2, 0x7f, 0x35, 0xe1, 0xd2, 0xb2, 0xeb, 0xe6, 0x8c, 0xd5,
+	0x5f, 0xdc, 0x05, 0x33, 0x55, 0xcf, 0xb1, 0xce, 0x6d, 0x7a, 0x2e, 0xbf, 0xc5, 0x68, 0x71, 0xb5,
+	0x95, 0x05, 0xce, 0x31, 0x65, 0x5a, 0x25, 0x8e, 0xb9, 0x00, 0x6d, 0x8f, 0xf5, 0x02, 0xba, 0xdf,
+	0x13, 0x61, 0x82, 0xb3, 0x4d, 0xef, 0x09, 0xd3, 0x1e, 0xdb, 0xa0, 0xfb, 0x5d, 0x85, 0x45, 0x97,
+	0x60, 0x52, 0xc6, 0x4d, 0x99, 0x37, 0x24, 0x50, 0x70, 0x90, 0xea, 0xff, 0xef, 0x20, 0xb5, 0x53,
+	0x38, 0x88, 0x7d, 0x00, 0x57, 0x31, 0x1d, 0x84, 0x2f, 0xe9, 0x99, 0xd4, 0xd6, 0x81, 0x9a, 0x43,
+	0x98, 0x43, 0x5c, 0x9a, 0x3a, 0x83, 0x02, 0xf9, 0x4c, 0x2c, 0xf8, 0xbb, 0xa9, 0x0b, 0x28, 0xd0,
+	0xfe, 0xc2, 0x82, 0xeb, 0xf9, 0xa6, 0x63, 0xa6, 0x77, 0xc6, 0xd8, 0x7c, 0xd4, 0x41, 0x5e, 0x13,
+	0x66, 0x19, 0x6b, 0x67, 0x98, 0xd5, 0x0b, 0x0e, 0xdc, 0x96, 0xbe, 0x94, 0xc4, 0x5e, 0xbf, 0xcf,
+	0x53, 0x9b, 0x88, 0x8b, 0x79, 0x51, 0xd0, 0xf3, 0xe4, 0x79, 0x1e, 0x5b, 0x44, 0xdc, 0x14, 0x3c,
+	0xb6, 0x24, 0x8b, 0xc7, 0x9c, 0x83, 0x36, 0xed, 0x96, 0xda, 0xc8, 0x64, 0xa9, 0x8d, 0xdc, 0x85,
+	0xb6, 0xb8, 0xa9, 0x3b, 0xa1, 0xdf, 0x53, 0x4e, 0xc8, 0xc3, 0xf7, 0x14, 0x9e, 0x51, 0xf8, 0x1f,
+	0x49, 0xb4, 0x28, 0xb4, 0x48, 0x44, 0xb6, 0x3d, 0xdf, 0x4b, 0x3c, 0x2a, 0x2d, 0xa0, 0x81, 0x0b,
+	0xb8, 0xd2, 0xac, 0x5d, 0x2f, 0xcf, 0xda, 0xbf, 0xb3, 0x60, 0xb6, 0xf4, 0x64, 0xce, 0xa7, 0x62,
+	0x7e, 0x08, 0x93, 0xbc, 0x72, 0x53, 0x45, 0xf2, 0xad, 0xa2, 0x8d, 0xab, 0xdd, 0xf2, 0xe8, 0x27,
+	0xa9, 0x55, 0xda, 0xb3, 0x4e, 0x74, 0xb5, 0x3e, 0x51, 0x22, 0x2d, 0xd3, 0xf0, 0xe4, 0xc9, 0x34,
+	0x5c, 0x2d, 0xd1, 0xb0, 0x1e, 0xb6, 0x6a, 0x27, 0x0e, 0x5b, 0xe8, 0x2e, 0x54, 0xfc, 0x90, 0xb8,
+	0xe9, 0xfd, 0xfd, 0xf2, 0xd8, 0x92, 0x1f, 0x86, 0xc4, 0xc5, 0x82, 0xc4, 0xfe, 0x93, 0x09, 0x73,
+	0xf9, 0xc1, 0x3c, 0x0b, 0x59, 0x72, 0xde, 0x6e, 0x73, 0x22, 0x1f, 0x30, 0xcf, 0xe8, 0x03, 0x0f,
+	0xa0, 0x26, 0x2b, 0x69, 0x75, 0xbb, 0xb9, 0x3a, 0x56, 0x7e, 0x0e, 0xc8, 0x5a, 0xb0, 0x13, 0x62,
+	0x45, 0x77, 0x9a, 0x9a, 0xf3, 0x3f, 0x06, 0xdc, 0x3a, 0x52, 0x49, 0xe7, 0x63, 0xc1, 0x5f, 0x8b,
+	0x96, 0x4e, 0x63, 0xef, 0xf6, 0x01, 0x40, 0xae, 0xb6, 0x42, 0x6f, 0xc0, 0x18, 0xe9, 0x0d, 0xcc,
+	0x29, 0xca, 0x0d, 0x32, 0x50, 0x45, 0xab, 0x86, 0x41, 0xf7, 0xa0, 0x2a, 0x5c, 0x4f, 0x9d, 0x4d,
+	0x49, 0x4d, 0x22, 0x8e, 0x26, 0xa5, 0xb2, 0xbb, 0xd0, 0xc8, 0x90, 0xc7, 0xb4, 0x0e, 0x6f, 0xa4,
+	0x64, 0xda, 0xae, 0x39, 0xc2, 0xfe, 0x8b, 0x09, 0x68, 0xdc, 0xf3, 0x79, 0x06, 0x3a, 0xe2, 0x70,
+	0x0a, 0x8a, 0x34, 0xd3, 0xd6, 0xa4, 0xfa, 0x65, 0x73, 0xe4, 0x97, 0xd5, 0x75, 0xd2, 0x3a, 0xc1,
+	0x75, 0xf2, 0x43, 0x68, 0x3b, 0xaa, 0x34, 0xef, 0xb1, 0xbc, 0xd7, 0xf7, 0x86, 0xfa, 0x7d, 0xc6,
+	0xd1, 0xe1, 0x21, 0x1b, 0x0f, 0x40, 0x93, 0x25, 0x01, 0xe8, 0x1d, 0x68, 0xca, 0x22, 0x53, 0xde,
+	0x13, 0xaa, 0x42, 0x3e, 0x54, 0x74, 0x06, 0xc1, 0x1e, 0x04, 0x99, 0x18, 0xdb, 0x7f, 0x34, 0xe0,
+	0x4a, 0x6e, 0xdf, 0x5d, 0x3f, 0x64, 0xf4, 0x9c, 0x9c, 0x5f, 0xcb, 0xd5, 0x66, 0x21, 0x57, 0x97,
+	0xba, 0x9f, 0x55, 0xee, 0x7e, 0x31, 0x5c, 0x1d, 0x93, 0xee, 0x7c, 0xbc, 0x8e, 0xdf, 0xf4, 0x87,
+	0x8e, 0x43, 0x19, 0x53, 0xe2, 0xa5, 0xa0, 0xfd, 0x6b, 0x03, 0xda, 0x79, 0x93, 0x49, 0x1a, 0xe6,
+	0x39, 0xf4, 0xe8, 0xae, 0x43, 0x3d, 0x35, 0x5f, 0x99, 0xab, 0x2c, 0x9c, 0xc1, 0xc7, 0xb5, 0xdf,
+	0xec, 0x0f, 0x60, 0x52, 0xd0, 0xbd, 0xa1, 0x93, 0x7e, 0x84, 0xb9, 0xda, 0x01, 0x4c, 0xab, 0xb1,
+	0xd4, 0xc6, 0x31, 0x7c, 0xe6, 0xa1, 0xf9, 0xd4, 0x77, 0x47, 0x58, 0xe9, 0x28, 0x4e, 0xb1, 0x41,
+	0xf7, 0x47, 0x64, 0xd5, 0x51, 0xf6, 0x97, 0x16, 0x4c, 0xca, 0x1b, 0xeb, 0x0d, 0x68, 0xac, 0xb1,
+	0x15, 0x6e, 0x6a, 0x54, 0x16, 0x7e, 0x75, 0x9c, 0x23, 0xb8, 0x14, 0x2b, 0xf2, 0x0a, 0xa4, 0x3a,
+	0x2d, 0x29, 0x88, 0x1e, 0x41, 0x53, 0x0e, 0x55, 0xe0, 0x18, 0x6f, 0x49, 0x8c, 0x1e, 0x0f, 0xd6,
+	0x57, 0xa0, 0x75, 0xb8, 0xb0, 0x41, 0xa9, 0xbb, 0x1a, 0x87, 0x51, 0xa4, 0x28, 0x3a, 0x95, 0x93,
+	0xb0, 0x19, 0x5f, 0x87, 0xbe, 0x07, 0x33, 0x1c, 0xb9, 0xec, 0xba, 0x19, 0x2b, 0x79, 0x57, 0x46,
+	0xe3, 0x9e, 0x8f, 0x47, 0x49, 0x79, 0x8b, 0xe4, 0xd3, 0xc8, 0x25, 0x09, 0x4d, 0x55, 0xa8, 0x4a,
+	0xf1, 0xd9, 0xb2, 0x1c, 0x95, 0x1e, 0x10, 0x1e, 0x59, 0x32, 0xda, 0x5b, 0xae, 0x8d, 0xf5, 0x96,
+	0xd1, 0xdb, 0xa2, 0x39, 0xd0, 0xa7, 0x22, 0xed, 0x4f, 0x8f, 0x64, 0xc0, 0x95, 0xd4, 0xdb, 0xfb,
+	0xb2, 0x31, 0xd0, 0xa7, 0xf6, 0x1e, 0x5c, 0xca, 0x22, 0x95, 0x9a, 0xe5, 0x61, 0xe6, 0x14, 0x11,
+	0x72, 0x41, 0xb5, 0x23, 0xcc, 0x23, 0xc3, 0x8c, 0x24, 0xb0, 0xff, 0x6b, 0xc0, 0xcc, 0xc8, 0x63,
+	0xc8, 0x69, 0x36, 0x2a, 0x0b, 0xa1, 0xe6, 0x79, 0x84, 0xd0, 0xb2, 0x3b, 0xd7, 0x91, 0xed, 0x8b,
+	0xca, 0x91, 0xed, 0x8b, 0xdb, 0xd0, 0x62, 0x64, 0x10, 0xf9, 0xd4, 0xed, 0xed, 0xd1, 0x43, 0x69,
+	0x1c, 0x2d, 0xdc, 0x4c, 0x71, 0xeb, 0xf4, 0x90, 0xf1, 0x18, 0x8b, 0x34, 0x35, 0x9f, 0x53, 0x7c,
+	0xfd, 0x08, 0xa6, 0xb6, 0x73, 0xa6, 0x59, 0x97, 0xf8, 0x76, 0x79, 0x42, 0xd2, 0xf7, 0x2f, 0xae,
+	0xb3, 0x5d, 0x68, 0xe9, 0x25, 0x00, 0x42, 0x50, 0x49, 0xbc, 0x81, 0x8c, 0x70, 0x0d, 0x2c, 0xc6,
+	0x1c, 0xc7, 0x1f, 0x92, 0xd2, 0x5c, 0x2b, 0xc6, 0x1c, 0xe7, 0x70, 0x9c, 0x25, 0x71, 0x7c, 0xcc,
+	0xbd, 0x7a, 0x20, 0xdb, 0xbd, 0x42, 0x65, 0x0d, 0xac, 0x40, 0xfb, 0x5d, 0x68, 0xe9, 0x67, 0xcb,
+	0x57, 0xef, 0x7a, 0xfd, 0xdd, 0xb4, 0x1d, 0x22, 0xc6, 0xfc, 0xe1, 0xc7, 0x0f, 0xf7, 0xd3, 0x78,
+	0xc0, 0x87, 0xf6, 0x0e, 0xb4, 0x74, 0x15, 0x9c, 0x6c, 0x95, 0x90, 0x96, 0x0c, 0x32, 0xc9, 0xf8,
+	0x98, 0x47, 0x23, 0xfe, 0x65, 0x11, 0x71, 0x94, 0x6c, 0x39, 0xc2, 0xfe, 0xad, 0x01, 0xd3, 0x9f,
+	0xc8, 0x87, 0xb0, 0xf3, 0xbb, 0x32, 0xee, 0x86, 0x7e, 0xfe, 0xc0, 0x95, 0x42, 0xa7, 0x49, 0x7e,
+	0x5f, 0x1a, 0xd0, 0xc1, 0x94, 0xd1, 0x40, 0x46, 0x13, 0x19, 0x0e, 0xce, 0x49, 0xbc, 0x59, 0x68,
+	0xa8, 0x56, 0x4f, 0x96, 0x8e, 0xd2, 0x5e, 0xcf, 0xa9, 0x64, 0xdc, 0x85, 0x56, 0x1a, 0xd1, 0x45,
+	0xcd, 0x79, 0xb6, 0x37, 0x32, 0xbe, 0x9a, 0x51, 0x9f, 0x3a, 0x49, 0x76, 0xc1, 0xcf, 0x60, 0xfb,
+	0xf7, 0x06, 0x5c, 0x29, 0x7f, 0xaa, 0x18, 0xeb, 0xcd, 0x1b, 0xa7, 0xeb, 0xcd, 0x3f, 0x4a, 0x3d,
+	0x29, 0xfd, 0x87, 0xf2, 0xd7, 0x33, 0xfd, 0x2f, 0x71, 0x91, 0xde, 0xfe, 0x87, 0x01, 0x2d, 0xfd,
+	0x3e, 0x86, 0xbe, 0x09, 0x33, 0x03, 0x72, 0xd0, 0xcb, 0x95, 0xc5, 0xd2, 0x54, 0x3b, 0x3d, 0x20,
+	0x07, 0x79, 0x41, 0xc3, 0x14, 0xa1, 0xab, 0xbd, 0x79, 0x99, 0x19, 0xa1, 0xfe, 0x90, 0x75, 0x07,
+	0xa6, 0x06, 0x74, 0x10, 0xc6, 0x87, 0xbd, 0xed, 0xa1, 0xdb, 0xa7, 0x89, 0x0a, 0x5f, 0x12, 0xb9,
+	0x22, 0x70, 0xfc, 0xdc, 0xb4, 0x5b, 0x83, 0xec, 0xd1, 0xca, 0xc7, 0x86, 0x19, 0x57, 0x6b, 0xe2,
+	0xf3, 0x46, 0xed, 0x6d, 0x48, 0x97, 0xf6, 0x5e, 0x0c, 0xc3, 0x84, 0xa8, 0x27, 0x07, 0x89, 0xfb,
+	0x84, 0xa3, 0xec, 0x9f, 0x41, 0x5d, 0xdd, 0x18, 0x35, 0xf2, 0xa1, 0x70, 0x6e, 0x43, 0x27, 0xff,
+	0x94, 0xa3, 0xd0, 0xdb, 0xfa, 0xd3, 0x55, 0x8f, 0xf7, 0x84, 0xfc, 0xb0, 0x9f, 0x1a, 0xff, 0x05,
+	0x57, 0x3b, 0x38, 0x31, 0xb1, 0x78, 0x13, 0xaa, 0xe9, 0x0b, 0x40, 0x03, 0x26, 0x9f, 0xc7, 0x5e,
+	0x42, 0xdb, 0x13, 0xa8, 0x0e, 0x95, 0x67, 0x84, 0xb1, 0xb6, 0xb1, 0xb8, 0x20, 0xcb, 0x16, 0xed,
+	0xa1, 0x00, 0xa0, 0xda, 0x8d, 0x29, 0x11, 0x74, 0x00, 0x55, 0xd9, 0x67, 0x6a, 0x1b, 0x8b, 0xdf,
+	0x05, 0xc8, 0x33, 0x1c, 0xe7, 0xb0, 0xf1, 0x74, 0xe3, 0x71, 0x7b, 0x02, 0x35, 0xa1, 0xf6, 0x7c,
+	0x79, 0x6d, 0x6b, 0x6d, 0xe3, 0xa3, 0xb6, 0x21, 0x00, 0x2c, 0x01, 0x93, 0xd3, 0xac, 0x72, 0x1a,
+	0x6b, 0xf1, 0x5b, 0x23, 0x55, 0x1d, 0xaa, 0x81, 0xb5, 0xec, 0xfb, 0xed, 0x09, 0x54, 0x05, 0x73,
+	0x75, 0xa5, 0x6d, 0xf0, 0x9d, 0x36, 0xc2, 0x78, 0x40, 0xfc, 0xb6, 0xb9, 0xf8, 0x1e, 0x4c, 0x17,
+	0xb3, 0x8c, 0x60, 0x1b, 0xc6, 0x7b, 0x5e, 0xd0, 0x97, 0x1b, 0x8a, 0x87, 0x76, 0xea, 0xca, 0x0d,
+	0xa5, 0x84, 0x6e, 0xdb, 0x5c, 0xf9, 0xfe, 0xdf, 0x5f, 0xcd, 0x19, 0x5f, 0xbd, 0x9a, 0x33, 0xfe,
+	0xfd, 0x6a, 0xce, 0xf8, 0xfc, 0xf5, 0xdc, 0xc4, 0x57, 0xaf, 0xe7, 0x26, 0xfe, 0xf5, 0x7a, 0x6e,
+	0xe2, 0xa7, 0xdf, 0xe8, 0x7b, 0xc9, 0xee, 0x70, 0xfb, 0x9e, 0x13, 0x0e, 0xee, 0xf3, 0x17, 0x7a,
+	0x87, 0x44, 0xf7, 0x13, 0xcf, 0x71, 0x9d, 0xfb, 0x9a, 0xed, 0x6d, 0x57, 0x45, 0x03, 0xe1, 0x9d,
+	0xff, 0x0d, 0x00, 0xee, 0xa7, 0x6a, 0x08, 0x83, 0x21, 0x00, 0x00,
 }
 
 func (m *TableSpan) Marshal() (dAtA []byte, err error) {
@@ -2608,6 +3392,33 @@ func (m *HeartBeatRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Capacity != nil {
+		{
+			size, err := m.Capacity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.QuiesceTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.QuiesceTs))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.NodeStopping {
+		i--
+		if m.NodeStopping {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.Err != nil {
 		{
 			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
@@ -2928,10 +3739,15 @@ func (m *HeartBeatResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if len(m.DispatcherStatuses) > 0 {
-		for iNdEx := len(m.DispatcherStatuses) - 1; iNdEx >= 0; iNdEx-- {
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.BarrierStates) > 0 {
+		for iNdEx := len(m.BarrierStates) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.DispatcherStatuses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.BarrierStates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -2939,14 +3755,28 @@ func (m *HeartBeatResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x1a
 		}
 	}
-	if m.ChangefeedID != nil {
-		{
-			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.DispatcherStatuses) > 0 {
+		for iNdEx := len(m.DispatcherStatuses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DispatcherStatuses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
 			i -= size
 			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
@@ -3079,6 +3909,11 @@ func (m *ScheduleDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.ScheduleAction != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ScheduleAction))
 		i--
@@ -3131,6 +3966,18 @@ func (m *MaintainerHeartbeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Capacity != nil {
+		{
+			size, err := m.Capacity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Statuses) > 0 {
 		for iNdEx := len(m.Statuses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3168,6 +4015,69 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BarriersFull {
+		i--
+		if m.BarriersFull {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.Backfills) > 0 {
+		for iNdEx := len(m.Backfills) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Backfills[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Barriers) > 0 {
+		for iNdEx := len(m.Barriers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Barriers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.Warnings) > 0 {
+		for iNdEx := len(m.Warnings) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Warnings[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.EventSizePerSecond != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
+		i--
+		dAtA[i] = 0x3d
+	}
+	if m.TableCount != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableCount))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Err) > 0 {
 		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3214,6 +4124,101 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BarrierCoverage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BarrierCoverage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BarrierCoverage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Covered) > 0 {
+		for iNdEx := len(m.Covered) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Covered[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.DynamicSplit {
+		i--
+		if m.DynamicSplit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.IsSyncPoint {
+		i--
+		if m.IsSyncPoint {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BlockTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.BlockTs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TableBackfill) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableBackfill) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableBackfill) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CheckpointTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.TableId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CoordinatorBootstrapRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3262,6 +4267,18 @@ func (m *CoordinatorBootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	_ = i
 	var l int
 	_ = l
+	if m.Capacity != nil {
+		{
+			size, err := m.Capacity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Statuses) > 0 {
 		for iNdEx := len(m.Statuses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3299,6 +4316,39 @@ func (m *AddMaintainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Backfills) > 0 {
+		for iNdEx := len(m.Backfills) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Backfills[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Barriers) > 0 {
+		for iNdEx := len(m.Barriers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Barriers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Epoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.IsNewChangfeed {
 		i--
 		if m.IsNewChangfeed {
@@ -3411,6 +4461,25 @@ func (m *MaintainerBootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, err
 	_ = i
 	var l int
 	_ = l
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.ProtocolVersion != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ProtocolVersion))
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.IsNewChangfeed {
 		i--
 		if m.IsNewChangfeed {
@@ -3480,6 +4549,44 @@ func (m *MaintainerBootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, er
 	_ = i
 	var l int
 	_ = l
+	if m.Load != nil {
+		{
+			size, err := m.Load.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Capacity != nil {
+		{
+			size, err := m.Capacity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.ProtocolVersion != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ProtocolVersion))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.CheckpointTs != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
 		i--
@@ -3546,6 +4653,11 @@ func (m *MaintainerPostBootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int,
 	_ = i
 	var l int
 	_ = l
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Schemas) > 0 {
 		for iNdEx := len(m.Schemas) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3824,6 +4936,11 @@ func (m *MaintainerCloseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Removed {
 		i--
 		if m.Removed {
@@ -3920,21 +5037,21 @@ func (m *InfluencedTables) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x18
 	}
 	if len(m.TableIDs) > 0 {
-		dAtA32 := make([]byte, len(m.TableIDs)*10)
-		var j31 int
+		dAtA37 := make([]byte, len(m.TableIDs)*10)
+		var j36 int
 		for _, num1 := range m.TableIDs {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j31++
+				j36++
 			}
-			dAtA32[j31] = uint8(num)
-			j31++
+			dAtA37[j36] = uint8(num)
+			j36++
 		}
-		i -= j31
-		copy(dAtA[i:], dAtA32[:j31])
-		i = encodeVarintHeartbeat(dAtA, i, uint64(j31))
+		i -= j36
+		copy(dAtA[i:], dAtA37[:j36])
+		i = encodeVarintHeartbeat(dAtA, i, uint64(j36))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -4189,6 +5306,15 @@ func (m *TableSpanStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.SampledKeys) > 0 {
+		for iNdEx := len(m.SampledKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SampledKeys[iNdEx])
+			copy(dAtA[i:], m.SampledKeys[iNdEx])
+			i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.SampledKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if m.EventSizePerSecond != 0 {
 		i -= 4
 		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
@@ -4400,24 +5526,306 @@ func (m *ChangefeedID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintHeartbeat(dAtA []byte, offset int, v uint64) int {
-	offset -= sovHeartbeat(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *QuiesceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *TableSpan) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.TableID != 0 {
+
+func (m *QuiesceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuiesceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.HoldTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.HoldTs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResendTableSchemaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResendTableSchemaRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResendTableSchemaRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaintainerEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaintainerEpoch))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.TableIds) > 0 {
+		dAtA46 := make([]byte, len(m.TableIds)*10)
+		var j45 int
+		for _, num1 := range m.TableIds {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA46[j45] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j45++
+			}
+			dAtA46[j45] = uint8(num)
+			j45++
+		}
+		i -= j45
+		copy(dAtA[i:], dAtA46[:j45])
+		i = encodeVarintHeartbeat(dAtA, i, uint64(j45))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Selected {
+		i--
+		if m.Selected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.IsSyncPoint {
+		i--
+		if m.IsSyncPoint {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CommitTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CommitTs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DispatcherBarrierState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DispatcherBarrierState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DispatcherBarrierState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BlockedEvents) > 0 {
+		for iNdEx := len(m.BlockedEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BlockedEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.DispatcherID != nil {
+		{
+			size, err := m.DispatcherID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NodeCapacity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeCapacity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeCapacity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemoryQuota != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MemoryQuota))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.DispatcherCount != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.DispatcherCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MemoryBudget != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MemoryBudget))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxDispatchers != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaxDispatchers))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxMaintainers != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaxMaintainers))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NodeLoad) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeLoad) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeLoad) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DispatcherBacklog != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.DispatcherBacklog))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MemoryUsage != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MemoryUsage))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintHeartbeat(dAtA []byte, offset int, v uint64) int {
+	offset -= sovHeartbeat(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *TableSpan) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TableID != 0 {
 		n += 1 + sovHeartbeat(uint64(m.TableID))
 	}
 	l = len(m.StartKey)
@@ -4458,6 +5866,16 @@ func (m *HeartBeatRequest) Size() (n int) {
 		l = m.Err.Size()
 		n += 1 + l + sovHeartbeat(uint64(l))
 	}
+	if m.NodeStopping {
+		n += 2
+	}
+	if m.QuiesceTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.QuiesceTs))
+	}
+	if m.Capacity != nil {
+		l = m.Capacity.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
 	return n
 }
 
@@ -4574,6 +5992,15 @@ func (m *HeartBeatResponse) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if len(m.BarrierStates) > 0 {
+		for _, e := range m.BarrierStates {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
 	return n
 }
 
@@ -4636,6 +6063,9 @@ func (m *ScheduleDispatcherRequest) Size() (n int) {
 	if m.ScheduleAction != 0 {
 		n += 1 + sovHeartbeat(uint64(m.ScheduleAction))
 	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
 	return n
 }
 
@@ -4651,6 +6081,10 @@ func (m *MaintainerHeartbeat) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if m.Capacity != nil {
+		l = m.Capacity.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
 	return n
 }
 
@@ -4680,11 +6114,77 @@ func (m *MaintainerStatus) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
-	return n
-}
-
-func (m *CoordinatorBootstrapRequest) Size() (n int) {
-	if m == nil {
+	if m.TableCount != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableCount))
+	}
+	if m.EventSizePerSecond != 0 {
+		n += 5
+	}
+	if len(m.Warnings) > 0 {
+		for _, e := range m.Warnings {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if len(m.Barriers) > 0 {
+		for _, e := range m.Barriers {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if len(m.Backfills) > 0 {
+		for _, e := range m.Backfills {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.BarriersFull {
+		n += 2
+	}
+	return n
+}
+
+func (m *BarrierCoverage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.BlockTs))
+	}
+	if m.IsSyncPoint {
+		n += 2
+	}
+	if m.DynamicSplit {
+		n += 2
+	}
+	if len(m.Covered) > 0 {
+		for _, e := range m.Covered {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TableBackfill) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TableId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableId))
+	}
+	if m.CheckpointTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.CheckpointTs))
+	}
+	return n
+}
+
+func (m *CoordinatorBootstrapRequest) Size() (n int) {
+	if m == nil {
 		return 0
 	}
 	var l int
@@ -4707,6 +6207,10 @@ func (m *CoordinatorBootstrapResponse) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if m.Capacity != nil {
+		l = m.Capacity.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
 	return n
 }
 
@@ -4730,6 +6234,21 @@ func (m *AddMaintainerRequest) Size() (n int) {
 	if m.IsNewChangfeed {
 		n += 2
 	}
+	if m.Epoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Epoch))
+	}
+	if len(m.Barriers) > 0 {
+		for _, e := range m.Barriers {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if len(m.Backfills) > 0 {
+		for _, e := range m.Backfills {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -4776,6 +6295,18 @@ func (m *MaintainerBootstrapRequest) Size() (n int) {
 	if m.IsNewChangfeed {
 		n += 2
 	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ProtocolVersion))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
 	return n
 }
 
@@ -4802,6 +6333,23 @@ func (m *MaintainerBootstrapResponse) Size() (n int) {
 	if m.CheckpointTs != 0 {
 		n += 1 + sovHeartbeat(uint64(m.CheckpointTs))
 	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ProtocolVersion))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.Capacity != nil {
+		l = m.Capacity.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Load != nil {
+		l = m.Load.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
 	return n
 }
 
@@ -4825,6 +6373,9 @@ func (m *MaintainerPostBootstrapRequest) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
 	return n
 }
 
@@ -4930,6 +6481,9 @@ func (m *MaintainerCloseRequest) Size() (n int) {
 	if m.Removed {
 		n += 2
 	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
 	return n
 }
 
@@ -5081,6 +6635,12 @@ func (m *TableSpanStatus) Size() (n int) {
 	if m.EventSizePerSecond != 0 {
 		n += 5
 	}
+	if len(m.SampledKeys) > 0 {
+		for _, b := range m.SampledKeys {
+			l = len(b)
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -5166,6 +6726,124 @@ func (m *ChangefeedID) Size() (n int) {
 	return n
 }
 
+func (m *QuiesceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.HoldTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.HoldTs))
+	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
+	return n
+}
+
+func (m *ResendTableSchemaRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if len(m.TableIds) > 0 {
+		l = 0
+		for _, e := range m.TableIds {
+			l += sovHeartbeat(uint64(e))
+		}
+		n += 1 + sovHeartbeat(uint64(l)) + l
+	}
+	if m.MaintainerEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaintainerEpoch))
+	}
+	return n
+}
+
+func (m *BlockedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CommitTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.CommitTs))
+	}
+	if m.IsSyncPoint {
+		n += 2
+	}
+	if m.Selected {
+		n += 2
+	}
+	return n
+}
+
+func (m *DispatcherBarrierState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DispatcherID != nil {
+		l = m.DispatcherID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if len(m.BlockedEvents) > 0 {
+		for _, e := range m.BlockedEvents {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NodeCapacity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxMaintainers != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaxMaintainers))
+	}
+	if m.MaxDispatchers != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaxDispatchers))
+	}
+	if m.MemoryBudget != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MemoryBudget))
+	}
+	if m.DispatcherCount != 0 {
+		n += 1 + sovHeartbeat(uint64(m.DispatcherCount))
+	}
+	if m.MemoryQuota != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MemoryQuota))
+	}
+	return n
+}
+
+func (m *NodeLoad) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemoryUsage != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MemoryUsage))
+	}
+	if m.DispatcherBacklog != 0 {
+		n += 1 + sovHeartbeat(uint64(m.DispatcherBacklog))
+	}
+	return n
+}
+
 func sovHeartbeat(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -5500,6 +7178,81 @@ func (m *HeartBeatRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeStopping", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NodeStopping = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuiesceTs", wireType)
+			}
+			m.QuiesceTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QuiesceTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capacity == nil {
+				m.Capacity = &NodeCapacity{}
+			}
+			if err := m.Capacity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6240,24 +7993,77 @@ func (m *HeartBeatResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BarrierStates", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BarrierStates = append(m.BarrierStates, &DispatcherBarrierState{})
+			if err := m.BarrierStates[len(m.BarrierStates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
+			}
+			m.MaintainerEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
@@ -6665,6 +8471,25 @@ func (m *ScheduleDispatcherRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
+			}
+			m.MaintainerEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6749,6 +8574,42 @@ func (m *MaintainerHeartbeat) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capacity == nil {
+				m.Capacity = &NodeCapacity{}
+			}
+			if err := m.Capacity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6939,6 +8800,158 @@ func (m *MaintainerStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableCount", wireType)
+			}
+			m.TableCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSizePerSecond", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warnings = append(m.Warnings, &RunningError{})
+			if err := m.Warnings[len(m.Warnings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Barriers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Barriers = append(m.Barriers, &BarrierCoverage{})
+			if err := m.Barriers[len(m.Barriers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backfills", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Backfills = append(m.Backfills, &TableBackfill{})
+			if err := m.Backfills[len(m.Backfills)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BarriersFull", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BarriersFull = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6960,7 +8973,7 @@ func (m *MaintainerStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
+func (m *BarrierCoverage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6983,17 +8996,17 @@ func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CoordinatorBootstrapRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BarrierCoverage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CoordinatorBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BarrierCoverage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTs", wireType)
 			}
-			m.Version = 0
+			m.BlockTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7003,64 +9016,54 @@ func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				m.BlockTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsSyncPoint", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeartbeat
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			m.IsSyncPoint = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DynamicSplit", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CoordinatorBootstrapResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CoordinatorBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.DynamicSplit = bool(v != 0)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Covered", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7087,8 +9090,8 @@ func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Statuses = append(m.Statuses, &MaintainerStatus{})
-			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Covered = append(m.Covered, &TableSpan{})
+			if err := m.Covered[len(m.Covered)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7113,7 +9116,7 @@ func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
+func (m *TableBackfill) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7136,17 +9139,17 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddMaintainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TableBackfill: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TableBackfill: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableId", wireType)
 			}
-			var msglen int
+			m.TableId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7156,33 +9159,16 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.TableId |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Id == nil {
-				m.Id = &ChangefeedID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
 			}
-			var byteLen int
+			m.CheckpointTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7192,50 +9178,66 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.CheckpointTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthHeartbeat
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
-			if m.Config == nil {
-				m.Config = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
 			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.CheckpointTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CoordinatorBootstrapRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CoordinatorBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsNewChangfeed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7245,12 +9247,11 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsNewChangfeed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7272,7 +9273,7 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
+func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7295,15 +9296,15 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveMaintainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CoordinatorBootstrapResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CoordinatorBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7330,18 +9331,16 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ChangefeedID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Statuses = append(m.Statuses, &MaintainerStatus{})
+			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7351,32 +9350,28 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Cascade = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
 			}
-			m.Removed = bool(v != 0)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capacity == nil {
+				m.Capacity = &NodeCapacity{}
+			}
+			if err := m.Capacity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7398,7 +9393,7 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
+func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7421,15 +9416,15 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerBootstrapRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddMaintainerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7456,10 +9451,10 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
-			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &ChangefeedID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7499,9 +9494,9 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
 			}
-			m.StartTs = 0
+			m.CheckpointTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7511,48 +9506,12 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTs |= uint64(b&0x7F) << shift
+				m.CheckpointTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.TableTriggerEventDispatcherId == nil {
-				m.TableTriggerEventDispatcherId = &DispatcherID{}
-			}
-			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field IsNewChangfeed", wireType)
 			}
@@ -7572,61 +9531,11 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IsNewChangfeed = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeartbeat
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerBootstrapResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
-			var msglen int
+			m.Epoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7636,31 +9545,14 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Epoch |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
-			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Barriers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7687,14 +9579,14 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Spans = append(m.Spans, &BootstrapTableSpan{})
-			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Barriers = append(m.Barriers, &BarrierCoverage{})
+			if err := m.Barriers[len(m.Barriers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Backfills", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7721,32 +9613,11 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Err == nil {
-				m.Err = &RunningError{}
-			}
-			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Backfills = append(m.Backfills, &TableBackfill{})
+			if err := m.Backfills[len(m.Backfills)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
-			}
-			m.CheckpointTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7768,7 +9639,7 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
+func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7791,15 +9662,15 @@ func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerPostBootstrapRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveMaintainerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerPostBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7826,18 +9697,18 @@ func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
+			if m.Id == nil {
+				m.Id = &ChangefeedID{}
 			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7847,33 +9718,17 @@ func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.TableTriggerEventDispatcherId == nil {
-				m.TableTriggerEventDispatcherId = &DispatcherID{}
-			}
-			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Cascade = bool(v != 0)
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Schemas", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7883,26 +9738,12 @@ func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Schemas = append(m.Schemas, &SchemaInfo{})
-			if err := m.Schemas[len(m.Schemas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Removed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7924,7 +9765,7 @@ func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
+func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7947,10 +9788,10 @@ func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerPostBootstrapResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintainerBootstrapRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerPostBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintainerBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7991,9 +9832,9 @@ func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8003,33 +9844,31 @@ func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TableTriggerEventDispatcherId == nil {
-				m.TableTriggerEventDispatcherId = &DispatcherID{}
-			}
-			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
+			if m.Config == nil {
+				m.Config = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
 			}
-			var msglen int
+			m.StartTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8039,28 +9878,1614 @@ func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.StartTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TableTriggerEventDispatcherId == nil {
+				m.TableTriggerEventDispatcherId = &DispatcherID{}
+			}
+			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsNewChangfeed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsNewChangfeed = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
+			}
+			m.MaintainerEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintainerBootstrapResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintainerBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Spans = append(m.Spans, &BootstrapTableSpan{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Err == nil {
+				m.Err = &RunningError{}
+			}
+			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capacity == nil {
+				m.Capacity = &NodeCapacity{}
+			}
+			if err := m.Capacity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Load", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Load == nil {
+				m.Load = &NodeLoad{}
+			}
+			if err := m.Load.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintainerPostBootstrapRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintainerPostBootstrapRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintainerPostBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TableTriggerEventDispatcherId == nil {
+				m.TableTriggerEventDispatcherId = &DispatcherID{}
+			}
+			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schemas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schemas = append(m.Schemas, &SchemaInfo{})
+			if err := m.Schemas[len(m.Schemas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
+			}
+			m.MaintainerEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintainerPostBootstrapResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintainerPostBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TableTriggerEventDispatcherId == nil {
+				m.TableTriggerEventDispatcherId = &DispatcherID{}
+			}
+			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Err == nil {
+				m.Err = &RunningError{}
+			}
+			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchemaInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchemaInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchemaInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+			}
+			m.SchemaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SchemaName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tables", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tables = append(m.Tables, &TableInfo{})
+			if err := m.Tables[len(m.Tables)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TableInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TableInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableID", wireType)
+			}
+			m.TableID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BootstrapTableSpan: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BootstrapTableSpan: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ID == nil {
+				m.ID = &DispatcherID{}
+			}
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+			}
+			m.SchemaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
+			}
+			m.ComponentStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ComponentStatus |= ComponentState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockState == nil {
+				m.BlockState = &State{}
+			}
+			if err := m.BlockState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintainerCloseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintainerCloseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Removed = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
+			}
+			m.MaintainerEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintainerCloseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintainerCloseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Err == nil {
-				m.Err = &RunningError{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Success = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InfluencedTables) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InfluencedTables: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InfluencedTables: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InfluenceType", wireType)
+			}
+			m.InfluenceType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InfluenceType |= InfluenceType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHeartbeat
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.TableIDs = append(m.TableIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHeartbeat
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthHeartbeat
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthHeartbeat
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.TableIDs) == 0 {
+					m.TableIDs = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHeartbeat
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.TableIDs = append(m.TableIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableIDs", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
 			}
-			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.SchemaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8082,7 +11507,7 @@ func (m *MaintainerPostBootstrapResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SchemaInfo) Unmarshal(dAtA []byte) error {
+func (m *Table) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8105,17 +11530,17 @@ func (m *SchemaInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Table: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Table: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TableID", wireType)
 			}
-			m.SchemaID = 0
+			m.TableID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8125,48 +11550,16 @@ func (m *SchemaInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SchemaID |= int64(b&0x7F) << shift
+				m.TableID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SchemaName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tables", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
 			}
-			var msglen int
+			m.SchemaID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8176,26 +11569,11 @@ func (m *SchemaInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.SchemaID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Tables = append(m.Tables, &TableInfo{})
-			if err := m.Tables[len(m.Tables)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8217,7 +11595,7 @@ func (m *SchemaInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TableInfo) Unmarshal(dAtA []byte) error {
+func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8240,10 +11618,10 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TableInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchemaIDChange: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TableInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchemaIDChange: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8266,10 +11644,10 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldSchemaID", wireType)
 			}
-			var stringLen uint64
+			m.OldSchemaID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8279,24 +11657,30 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.OldSchemaID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewSchemaID", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.NewSchemaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewSchemaID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.TableName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8318,7 +11702,7 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
+func (m *State) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8341,15 +11725,54 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapTableSpan: wiretype end group for non-group")
+			return fmt.Errorf("proto: State: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapTableSpan: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: State: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsBlocked", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsBlocked = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTs", wireType)
+			}
+			m.BlockTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTables", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8376,18 +11799,18 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ID == nil {
-				m.ID = &DispatcherID{}
+			if m.BlockTables == nil {
+				m.BlockTables = &InfluencedTables{}
 			}
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BlockTables.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NeedDroppedTables", wireType)
 			}
-			m.SchemaID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8397,14 +11820,31 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SchemaID |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NeedDroppedTables == nil {
+				m.NeedDroppedTables = &InfluencedTables{}
+			}
+			if err := m.NeedDroppedTables.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NeedAddedTables", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8431,18 +11871,16 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Span == nil {
-				m.Span = &TableSpan{}
-			}
-			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NeedAddedTables = append(m.NeedAddedTables, &Table{})
+			if err := m.NeedAddedTables[len(m.NeedAddedTables)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedSchemas", wireType)
 			}
-			m.ComponentStatus = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8452,16 +11890,31 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ComponentStatus |= ComponentState(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatedSchemas = append(m.UpdatedSchemas, &SchemaIDChange{})
+			if err := m.UpdatedSchemas[len(m.UpdatedSchemas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsSyncPoint", wireType)
 			}
-			m.CheckpointTs = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8471,16 +11924,17 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+			m.IsSyncPoint = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
 			}
-			var msglen int
+			m.Stage = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8490,28 +11944,11 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Stage |= BlockStage(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.BlockState == nil {
-				m.BlockState = &State{}
-			}
-			if err := m.BlockState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8533,7 +11970,7 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
+func (m *TableSpanBlockStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8556,15 +11993,15 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerCloseRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TableSpanBlockStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerCloseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TableSpanBlockStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8591,18 +12028,18 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
+			if m.ID == nil {
+				m.ID = &DispatcherID{}
 			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8612,12 +12049,28 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.State == nil {
+				m.State = &State{}
+			}
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8639,7 +12092,7 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
+func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8662,15 +12115,15 @@ func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerCloseResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TableSpanStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerCloseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TableSpanStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8697,18 +12150,18 @@ func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
+			if m.ID == nil {
+				m.ID = &DispatcherID{}
 			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
 			}
-			var v int
+			m.ComponentStatus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8718,67 +12171,16 @@ func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.ComponentStatus |= ComponentState(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Success = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *InfluencedTables) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeartbeat
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: InfluencedTables: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InfluencedTables: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InfluenceType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
 			}
-			m.InfluenceType = 0
+			m.CheckpointTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8788,92 +12190,27 @@ func (m *InfluencedTables) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.InfluenceType |= InfluenceType(b&0x7F) << shift
+				m.CheckpointTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType == 0 {
-				var v int64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowHeartbeat
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= int64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.TableIDs = append(m.TableIDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowHeartbeat
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthHeartbeat
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthHeartbeat
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.TableIDs) == 0 {
-					m.TableIDs = make([]int64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v int64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowHeartbeat
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= int64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.TableIDs = append(m.TableIDs, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableIDs", wireType)
+		case 4:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSizePerSecond", wireType)
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.SchemaID = 0
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampledKeys", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8883,11 +12220,24 @@ func (m *InfluencedTables) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SchemaID |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SampledKeys = append(m.SampledKeys, make([]byte, postIndex-iNdEx))
+			copy(m.SampledKeys[len(m.SampledKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8909,7 +12259,7 @@ func (m *InfluencedTables) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Table) Unmarshal(dAtA []byte) error {
+func (m *BlockStatusRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8932,17 +12282,17 @@ func (m *Table) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Table: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockStatusRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Table: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
-			m.TableID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8952,16 +12302,33 @@ func (m *Table) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TableID |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockStatuses", wireType)
 			}
-			m.SchemaID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8971,11 +12338,26 @@ func (m *Table) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SchemaID |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockStatuses = append(m.BlockStatuses, &TableSpanBlockStatus{})
+			if err := m.BlockStatuses[len(m.BlockStatuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8997,7 +12379,7 @@ func (m *Table) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
+func (m *RunningError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9020,17 +12402,17 @@ func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaIDChange: wiretype end group for non-group")
+			return fmt.Errorf("proto: RunningError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaIDChange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RunningError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			m.TableID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9040,16 +12422,29 @@ func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TableID |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Time = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldSchemaID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
-			m.OldSchemaID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9059,16 +12454,29 @@ func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.OldSchemaID |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewSchemaID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.NewSchemaID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9078,11 +12486,56 @@ func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NewSchemaID |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -9104,7 +12557,7 @@ func (m *SchemaIDChange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *State) Unmarshal(dAtA []byte) error {
+func (m *DispatcherID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9127,17 +12580,17 @@ func (m *State) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: State: wiretype end group for non-group")
+			return fmt.Errorf("proto: DispatcherID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: State: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DispatcherID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsBlocked", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field High", wireType)
 			}
-			var v int
+			m.High = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9147,17 +12600,16 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.High |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsBlocked = bool(v != 0)
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Low", wireType)
 			}
-			m.BlockTs = 0
+			m.Low = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9167,16 +12619,85 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockTs |= uint64(b&0x7F) << shift
+				m.Low |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockTables", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangefeedID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangefeedID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field High", wireType)
+			}
+			m.High = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.High |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Low", wireType)
+			}
+			m.Low = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9186,33 +12707,16 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Low |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.BlockTables == nil {
-				m.BlockTables = &InfluencedTables{}
-			}
-			if err := m.BlockTables.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NeedDroppedTables", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9222,33 +12726,29 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NeedDroppedTables == nil {
-				m.NeedDroppedTables = &InfluencedTables{}
-			}
-			if err := m.NeedDroppedTables.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NeedAddedTables", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9258,29 +12758,77 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NeedAddedTables = append(m.NeedAddedTables, &Table{})
-			if err := m.NeedAddedTables[len(m.NeedAddedTables)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 6:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuiesceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuiesceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuiesceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedSchemas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9307,16 +12855,18 @@ func (m *State) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UpdatedSchemas = append(m.UpdatedSchemas, &SchemaIDChange{})
-			if err := m.UpdatedSchemas[len(m.UpdatedSchemas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsSyncPoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HoldTs", wireType)
 			}
-			var v int
+			m.HoldTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9326,17 +12876,16 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.HoldTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsSyncPoint = bool(v != 0)
-		case 8:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
 			}
-			m.Stage = 0
+			m.MaintainerEpoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9346,7 +12895,7 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Stage |= BlockStage(b&0x7F) << shift
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -9372,7 +12921,7 @@ func (m *State) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TableSpanBlockStatus) Unmarshal(dAtA []byte) error {
+func (m *ResendTableSchemaRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9395,15 +12944,15 @@ func (m *TableSpanBlockStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TableSpanBlockStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResendTableSchemaRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TableSpanBlockStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResendTableSchemaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9430,18 +12979,94 @@ func (m *TableSpanBlockStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ID == nil {
-				m.ID = &DispatcherID{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHeartbeat
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.TableIds = append(m.TableIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHeartbeat
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthHeartbeat
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthHeartbeat
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.TableIds) == 0 {
+					m.TableIds = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHeartbeat
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.TableIds = append(m.TableIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableIds", wireType)
 			}
-			var msglen int
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintainerEpoch", wireType)
+			}
+			m.MaintainerEpoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9451,28 +13076,11 @@ func (m *TableSpanBlockStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MaintainerEpoch |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.State == nil {
-				m.State = &State{}
-			}
-			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -9494,7 +13102,7 @@ func (m *TableSpanBlockStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
+func (m *BlockedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9517,17 +13125,17 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TableSpanStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockedEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TableSpanStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
 			}
-			var msglen int
+			m.CommitTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9537,33 +13145,16 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.CommitTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ID == nil {
-				m.ID = &DispatcherID{}
-			}
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsSyncPoint", wireType)
 			}
-			m.ComponentStatus = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9573,16 +13164,17 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ComponentStatus |= ComponentState(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.IsSyncPoint = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Selected", wireType)
 			}
-			m.CheckpointTs = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9592,22 +13184,12 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 5 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventSizePerSecond", wireType)
-			}
-			var v uint32
-			if (iNdEx + 4) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
-			iNdEx += 4
-			m.EventSizePerSecond = float32(math.Float32frombits(v))
+			m.Selected = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -9629,7 +13211,7 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockStatusRequest) Unmarshal(dAtA []byte) error {
+func (m *DispatcherBarrierState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9652,15 +13234,15 @@ func (m *BlockStatusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockStatusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DispatcherBarrierState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DispatcherBarrierState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9687,16 +13269,16 @@ func (m *BlockStatusRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
+			if m.DispatcherID == nil {
+				m.DispatcherID = &DispatcherID{}
 			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DispatcherID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockStatuses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockedEvents", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9723,8 +13305,8 @@ func (m *BlockStatusRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BlockStatuses = append(m.BlockStatuses, &TableSpanBlockStatus{})
-			if err := m.BlockStatuses[len(m.BlockStatuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.BlockedEvents = append(m.BlockedEvents, &BlockedEvent{})
+			if err := m.BlockedEvents[len(m.BlockedEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9749,7 +13331,7 @@ func (m *BlockStatusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RunningError) Unmarshal(dAtA []byte) error {
+func (m *NodeCapacity) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9772,17 +13354,17 @@ func (m *RunningError) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RunningError: wiretype end group for non-group")
+			return fmt.Errorf("proto: NodeCapacity: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RunningError: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NodeCapacity: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMaintainers", wireType)
 			}
-			var stringLen uint64
+			m.MaxMaintainers = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9792,29 +13374,16 @@ func (m *RunningError) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.MaxMaintainers |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Time = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDispatchers", wireType)
 			}
-			var stringLen uint64
+			m.MaxDispatchers = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9824,29 +13393,16 @@ func (m *RunningError) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.MaxDispatchers |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Node = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryBudget", wireType)
 			}
-			var stringLen uint64
+			m.MemoryBudget = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9856,111 +13412,16 @@ func (m *RunningError) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.MemoryBudget |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Code = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Message = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DispatcherID) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeartbeat
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DispatcherID: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DispatcherID: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field High", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherCount", wireType)
 			}
-			m.High = 0
+			m.DispatcherCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9970,16 +13431,16 @@ func (m *DispatcherID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.High |= uint64(b&0x7F) << shift
+				m.DispatcherCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Low", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryQuota", wireType)
 			}
-			m.Low = 0
+			m.MemoryQuota = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -9989,7 +13450,7 @@ func (m *DispatcherID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Low |= uint64(b&0x7F) << shift
+				m.MemoryQuota |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -10015,7 +13476,7 @@ func (m *DispatcherID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
+func (m *NodeLoad) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10038,17 +13499,17 @@ func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangefeedID: wiretype end group for non-group")
+			return fmt.Errorf("proto: NodeLoad: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangefeedID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NodeLoad: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field High", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsage", wireType)
 			}
-			m.High = 0
+			m.MemoryUsage = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -10058,67 +13519,16 @@ func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.High |= uint64(b&0x7F) << shift
+				m.MemoryUsage |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Low", wireType)
-			}
-			m.Low = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Low |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherBacklog", wireType)
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
-			}
-			var stringLen uint64
+			m.DispatcherBacklog = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -10128,24 +13538,11 @@ func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.DispatcherBacklog |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
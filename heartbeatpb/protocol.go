@@ -0,0 +1,76 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heartbeatpb
+
+// ProtocolVersion is the version of the protocol between the maintainer and the dispatcher manager.
+// It's exchanged in the bootstrap messages, 0 means the peer is older than the negotiation.
+const ProtocolVersion uint32 = 1
+
+// CapabilityNodeStopping means the maintainer reschedules the spans of a stopping node
+// by the final checkpoints reported in the heartbeat with NodeStopping set.
+const CapabilityNodeStopping = "node-stopping"
+
+// CapabilityQuiesce means the dispatcher manager handles the QuiesceRequest
+// and reports the hold ts in the heartbeat.
+const CapabilityQuiesce = "quiesce"
+
+// CapabilityResendTableSchema means the dispatcher manager handles the ResendTableSchemaRequest.
+const CapabilityResendTableSchema = "resend-table-schema"
+
+// CapabilityBatchScheduleRequest means the dispatcher manager handles all the ScheduleDispatcherRequests
+// in one message, the maintainer only batches the requests to the nodes with this capability.
+const CapabilityBatchScheduleRequest = "batch-schedule-request"
+
+// localCapabilities are the capabilities supported by this version,
+// a new feature which changes the behavior of the peer should be added here.
+var localCapabilities = []string{
+	CapabilityNodeStopping,
+	CapabilityQuiesce,
+	CapabilityResendTableSchema,
+	CapabilityBatchScheduleRequest,
+}
+
+// LocalCapabilities returns the capabilities supported by this version.
+func LocalCapabilities() []string {
+	return append([]string(nil), localCapabilities...)
+}
+
+// Capabilities is the set of the capabilities supported by both sides.
+type Capabilities map[string]struct{}
+
+// NegotiateCapabilities returns the capabilities supported by both this version and the peer
+// of the protocol version. The peer of version 0 is older than the negotiation, so it supports
+// none of the capabilities. The peer of another version supports the capabilities it reports,
+// since a capability keeps its behavior across the versions.
+func NegotiateCapabilities(version uint32, remote []string) Capabilities {
+	if version == 0 {
+		return Capabilities{}
+	}
+	caps := make(Capabilities, len(remote))
+	for _, r := range remote {
+		for _, l := range localCapabilities {
+			if r == l {
+				caps[r] = struct{}{}
+				break
+			}
+		}
+	}
+	return caps
+}
+
+// Has returns true if the capability is supported by both sides.
+func (c Capabilities) Has(capability string) bool {
+	_, ok := c[capability]
+	return ok
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heartbeatpb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCapabilitiesWithOldPeer(t *testing.T) {
+	// the peer of version 0 is older than the negotiation
+	require.Empty(t, NegotiateCapabilities(0, nil))
+	require.Empty(t, NegotiateCapabilities(0, LocalCapabilities()))
+
+	// the peer supports the negotiation but none of the capabilities
+	caps := NegotiateCapabilities(ProtocolVersion, nil)
+	require.Empty(t, caps)
+	for _, c := range LocalCapabilities() {
+		require.False(t, caps.Has(c))
+	}
+}
+
+func TestNegotiateCapabilitiesWithMismatchedVersion(t *testing.T) {
+	// the newer peer reports a capability unknown to this version
+	caps := NegotiateCapabilities(ProtocolVersion+1,
+		[]string{CapabilityQuiesce, CapabilityBatchScheduleRequest, "unknown"})
+	require.Len(t, caps, 2)
+	require.True(t, caps.Has(CapabilityQuiesce))
+	require.True(t, caps.Has(CapabilityBatchScheduleRequest))
+	require.False(t, caps.Has("unknown"))
+	require.False(t, caps.Has(CapabilityNodeStopping))
+}
+
+func TestNegotiateCapabilitiesIntersection(t *testing.T) {
+	caps := NegotiateCapabilities(ProtocolVersion, LocalCapabilities())
+	require.Len(t, caps, len(LocalCapabilities()))
+	for _, c := range LocalCapabilities() {
+		require.True(t, caps.Has(c))
+	}
+
+	caps = NegotiateCapabilities(ProtocolVersion,
+		[]string{CapabilityNodeStopping, "unknown", CapabilityNodeStopping})
+	require.Equal(t, Capabilities{CapabilityNodeStopping: {}}, caps)
+
+	// the local capabilities can't be changed by the caller
+	local := LocalCapabilities()
+	local[0] = "unknown"
+	require.NotContains(t, LocalCapabilities(), "unknown")
+}
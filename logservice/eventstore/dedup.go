@@ -0,0 +1,110 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"sync"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/utils/heap"
+)
+
+// dedupKey identifies a kv event, a transaction writes a key at most once,
+// so the events with the same dedupKey are the same event.
+type dedupKey struct {
+	commitTs uint64
+	startTs  uint64
+	opType   common.OpType
+	key      string
+}
+
+// dedupEntry is an event in the window, the entries are ordered by the commit ts.
+type dedupEntry struct {
+	key       dedupKey
+	heapIndex int
+}
+
+func (e *dedupEntry) SetHeapIndex(index int) { e.heapIndex = index }
+
+func (e *dedupEntry) GetHeapIndex() int { return e.heapIndex }
+
+func (e *dedupEntry) LessThan(other *dedupEntry) bool {
+	return e.key.commitTs < other.key.commitTs
+}
+
+// dedupWindow drops the kv events delivered again by the upstream after the regions
+// of a subscription are re-subscribed. The regions are re-subscribed from their own
+// resolved ts, so only the events committed after the resolved ts of the subscription
+// can be delivered again, and the events before it are removed from the window.
+type dedupWindow struct {
+	mu sync.Mutex
+	// the max number of the events in the window, the events beyond it are not remembered.
+	maxSize int
+	seen    map[dedupKey]struct{}
+	// entries are the events in seen, the ones with the smallest commit ts are on the top.
+	entries *heap.Heap[*dedupEntry]
+}
+
+func newDedupWindow(maxSize int) *dedupWindow {
+	return &dedupWindow{
+		maxSize: maxSize,
+		seen:    make(map[dedupKey]struct{}),
+		entries: heap.NewHeap[*dedupEntry](),
+	}
+}
+
+// filter removes the events seen before from the kvs in place, and returns the remaining events.
+func (w *dedupWindow) filter(kvs []common.RawKVEntry) []common.RawKVEntry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := kvs[:0]
+	dropped := 0
+	for _, kv := range kvs {
+		key := dedupKey{commitTs: kv.CRTs, startTs: kv.StartTs, opType: kv.OpType, key: string(kv.Key)}
+		if _, ok := w.seen[key]; ok {
+			dropped++
+			continue
+		}
+		if len(w.seen) < w.maxSize {
+			w.seen[key] = struct{}{}
+			w.entries.AddOrUpdate(&dedupEntry{key: key})
+		}
+		result = append(result, kv)
+	}
+	if dropped > 0 {
+		metrics.EventStoreDedupDroppedEventCount.Add(float64(dropped))
+	}
+	return result
+}
+
+// advance removes the events committed at or before the resolved ts from the window.
+func (w *dedupWindow) advance(resolvedTs uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for {
+		top, ok := w.entries.PeekTop()
+		if !ok || top.key.commitTs > resolvedTs {
+			return
+		}
+		w.entries.PopTop()
+		delete(w.seen, top.key)
+	}
+}
+
+func (w *dedupWindow) size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.seen)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newDedupTestKV(key string, startTs, commitTs uint64) common.RawKVEntry {
+	return common.RawKVEntry{OpType: common.OpTypePut, Key: []byte(key), StartTs: startTs, CRTs: commitTs}
+}
+
+func TestDedupWindowFilter(t *testing.T) {
+	w := newDedupWindow(10)
+	kvs := w.filter([]common.RawKVEntry{
+		newDedupTestKV("a", 1, 2),
+		newDedupTestKV("b", 1, 2),
+		newDedupTestKV("a", 3, 4),
+	})
+	require.Len(t, kvs, 3)
+	require.Equal(t, 3, w.size())
+
+	// the events delivered again are dropped, the new ones are kept
+	kvs = w.filter([]common.RawKVEntry{
+		newDedupTestKV("a", 1, 2),
+		newDedupTestKV("c", 3, 4),
+		newDedupTestKV("a", 3, 4),
+	})
+	require.Equal(t, []common.RawKVEntry{newDedupTestKV("c", 3, 4)}, kvs)
+	require.Equal(t, 4, w.size())
+
+	// the same key deleted in the same transaction is another event
+	del := newDedupTestKV("c", 3, 4)
+	del.OpType = common.OpTypeDelete
+	require.Len(t, w.filter([]common.RawKVEntry{del}), 1)
+}
+
+func TestDedupWindowMaxSize(t *testing.T) {
+	w := newDedupWindow(2)
+	kvs := w.filter([]common.RawKVEntry{
+		newDedupTestKV("a", 1, 2),
+		newDedupTestKV("b", 1, 2),
+		newDedupTestKV("c", 1, 2),
+	})
+	require.Len(t, kvs, 3)
+	require.Equal(t, 2, w.size())
+	// the events beyond the max size are not remembered
+	require.Len(t, w.filter([]common.RawKVEntry{newDedupTestKV("c", 1, 2)}), 1)
+	require.Len(t, w.filter([]common.RawKVEntry{newDedupTestKV("a", 1, 2)}), 0)
+}
+
+func TestDedupWindowAdvance(t *testing.T) {
+	w := newDedupWindow(10)
+	// the events are not added in the order of the commit ts
+	w.filter([]common.RawKVEntry{
+		newDedupTestKV("a", 5, 6),
+		newDedupTestKV("b", 1, 2),
+		newDedupTestKV("c", 7, 8),
+		newDedupTestKV("d", 3, 4),
+	})
+	require.Equal(t, 4, w.size())
+
+	w.advance(1)
+	require.Equal(t, 4, w.size())
+	w.advance(4)
+	require.Equal(t, 2, w.size())
+	// the events committed after the resolved ts are still deduplicated
+	require.Len(t, w.filter([]common.RawKVEntry{newDedupTestKV("a", 5, 6)}), 0)
+	// the events before the resolved ts are removed from the window
+	require.Len(t, w.filter([]common.RawKVEntry{newDedupTestKV("b", 1, 2)}), 1)
+	require.Equal(t, 3, w.size())
+
+	w.advance(8)
+	require.Equal(t, 0, w.size())
+	require.True(t, w.entries.IsEmpty())
+}
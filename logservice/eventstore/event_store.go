@@ -16,9 +16,11 @@ package eventstore
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"sort"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,6 +34,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/memory"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -48,6 +52,14 @@ var (
 	CounterResolved = metrics.EventStoreReceivedEventCount.WithLabelValues("resolved")
 )
 
+var writeBufferMemory = memory.GetGlobalRegistry().Register(memory.ComponentEventStoreWriteBuffer, memory.PriorityNormal)
+
+const (
+	// maxWakeDelay is the max time to delay waking up the subscriptions
+	// after their events are written while the memory is exceeded.
+	maxWakeDelay = time.Second
+)
+
 var (
 	metricEventStoreFirstReadDurationHistogram = metrics.EventStoreReadDurationHistogram.WithLabelValues("first")
 	metricEventStoreNextReadDurationHistogram  = metrics.EventStoreReadDurationHistogram.WithLabelValues("next")
@@ -69,15 +81,37 @@ type EventStore interface {
 		startTS uint64,
 		notifier ResolvedTsNotifier,
 		onlyReuse bool,
+		// disableOldValue is true if the dispatcher doesn't need the old values of the rows,
+		// a subscription without the old values can only be shared by such dispatchers.
+		disableOldValue bool,
 	) (bool, error)
 
 	UnregisterDispatcher(dispatcherID common.DispatcherID) error
 
+	// PauseDispatcher is called when the downstream of the dispatcher can't keep up,
+	// the upstream subscription is throttled if all the dispatchers sharing it are paused.
+	PauseDispatcher(dispatcherID common.DispatcherID)
+
+	// ResumeDispatcher resumes the dispatcher paused by PauseDispatcher.
+	ResumeDispatcher(dispatcherID common.DispatcherID)
+
 	// TODO: Implement this after checkpointTs is correctly reported by the downstream dispatcher.
 	UpdateDispatcherCheckpointTs(dispatcherID common.DispatcherID, checkpointTs uint64) error
 
 	GetDispatcherDMLEventState(dispatcherID common.DispatcherID) (bool, DMLEventState)
 
+	// GetDispatcherStoredBytes returns the estimated bytes of the events stored in each
+	// subscription the dispatcher depends on, keyed by the subscription id.
+	// The events of a subscription are shared by all the dispatchers depend on it.
+	GetDispatcherStoredBytes(dispatcherID common.DispatcherID) map[uint64]uint64
+
+	// WriteEngineStats writes the stats of the storage engine for debugging.
+	WriteEngineStats(w io.Writer)
+
+	// GetGCSafePoint returns the gc safe point of the upstream, the events committed
+	// before it can't be pulled by the event store.
+	GetGCSafePoint(ctx context.Context) (uint64, error)
+
 	// return an iterator which scan the data in ts range (dataRange.StartTs, dataRange.EndTs]
 	GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (EventIterator, error)
 }
@@ -103,18 +137,35 @@ type dispatcherStat struct {
 	// the max ts of events which is not needed by this dispatcher
 	checkpointTs uint64
 
-	subID logpuller.SubscriptionID
+	// the subscriptions which the events of the dispatcher are read from, their spans
+	// are disjoint and cover the span of the dispatcher. There are more than one
+	// subscription if some regions of the span are subscribed by other dispatchers.
+	subIDs []logpuller.SubscriptionID
+	// the part of the span read from each subscription in subIDs
+	subSpans []*heartbeatpb.TableSpan
+	// the min resolved ts of the subscriptions, it's only tracked if the dispatcher
+	// depends on more than one subscription.
+	resolvedTs atomic.Uint64
 }
 
 type subscriptionStat struct {
 	subID logpuller.SubscriptionID
 
 	tableID int64
+	// the span subscribed from the upstream, it's shared by all the dispatchers
+	// whose span is covered by it, no matter which changefeed they belong to.
+	tableSpan *heartbeatpb.TableSpan
+	// whether the old values of the rows are not captured by the subscription
+	disableOldValue bool
 
 	// dispatchers depend on this subscription
 	dispatchers struct {
 		sync.Mutex
 		notifiers map[common.DispatcherID]ResolvedTsNotifier
+		// the dispatchers whose downstream can't keep up
+		paused map[common.DispatcherID]bool
+		// whether the subscription is throttled in the log puller
+		throttled bool
 	}
 
 	dbIndex int
@@ -126,6 +177,10 @@ type subscriptionStat struct {
 	resolvedTs atomic.Uint64
 	// the max commit ts of dml event in the store
 	maxEventCommitTs atomic.Uint64
+	// the estimated bytes of the events on disk, it's updated periodically
+	storedBytes atomic.Uint64
+	// dedup is nil if the dedup of the events is disabled
+	dedup *dedupWindow
 }
 
 type eventWithCallback struct {
@@ -174,6 +229,10 @@ type eventStore struct {
 
 	encoder *zstd.Encoder
 	decoder *zstd.Decoder
+
+	// the max number of the events remembered for each subscription to find the
+	// duplicated events, it's 0 if the dedup is disabled.
+	dedupWindowSize int
 }
 
 const (
@@ -194,6 +253,33 @@ func New(
 	subClient *logpuller.SubscriptionClient,
 	pdClock pdutil.Clock,
 ) EventStore {
+	store := newEventStore(root, subClient, pdClock)
+
+	// recv and handle messages
+	messageCenter := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	store.messageCenter = messageCenter
+	messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
+
+	return store
+}
+
+// NewForUpstream creates the event store of a non-default upstream.
+// Its state is not reported to the log coordinator,
+// since the log coordinator only tracks the event stores of the default upstream.
+func NewForUpstream(
+	ctx context.Context,
+	root string,
+	subClient *logpuller.SubscriptionClient,
+	pdClock pdutil.Clock,
+) EventStore {
+	return newEventStore(root, subClient, pdClock)
+}
+
+func newEventStore(
+	root string,
+	subClient *logpuller.SubscriptionClient,
+	pdClock pdutil.Clock,
+) *eventStore {
 	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
 
 	// FIXME: avoid remove
@@ -223,6 +309,9 @@ func New(
 		encoder:   encoder,
 		decoder:   decoder,
 	}
+	if cfg := config.GetGlobalServerConfig().Debug.EventStore; cfg != nil && cfg.EnableDedup {
+		store.dedupWindowSize = cfg.DedupWindowSize
+	}
 
 	// TODO: update pebble options
 	for i := 0; i < dbCount; i++ {
@@ -238,12 +327,6 @@ func New(
 	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
 	store.dispatcherMeta.subscriptionStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
 	store.dispatcherMeta.tableToDispatchers = make(map[int64]map[common.DispatcherID]bool)
-
-	// recv and handle messages
-	messageCenter := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
-	store.messageCenter = messageCenter
-	messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
-
 	return store
 }
 
@@ -320,6 +403,10 @@ func (p *writeTaskPool) run(ctx context.Context) {
 						return
 					}
 					p.store.writeEvents(p.db, events)
+					for i := range events {
+						writeBufferMemory.Release(int64(eventWithCallbackSizer(events[i])))
+					}
+					throttleWake(ctx)
 					for i := range events {
 						events[i].callback()
 					}
@@ -330,6 +417,19 @@ func (p *writeTaskPool) run(ctx context.Context) {
 	}
 }
 
+// throttleWake delays waking up the subscriptions while the memory is exceeded,
+// the delay is bounded so the events buffered by other workers can still be drained.
+func throttleWake(ctx context.Context) {
+	deadline := time.Now().Add(maxWakeDelay)
+	for writeBufferMemory.Exceeded() && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
 func (e *eventStore) setCoordinatorInfo(id node.ID) {
 	e.coordinatorInfo.Lock()
 	defer e.coordinatorInfo.Unlock()
@@ -370,9 +470,11 @@ func (e *eventStore) Run(ctx context.Context) error {
 		return e.updateMetrics(ctx)
 	})
 
-	eg.Go(func() error {
-		return e.uploadStatePeriodically(ctx)
-	})
+	if e.messageCenter != nil {
+		eg.Go(func() error {
+			return e.uploadStatePeriodically(ctx)
+		})
+	}
 
 	return eg.Wait()
 }
@@ -398,11 +500,13 @@ func (e *eventStore) RegisterDispatcher(
 	startTs uint64,
 	notifier ResolvedTsNotifier,
 	onlyReuse bool,
+	disableOldValue bool,
 ) (bool, error) {
 	log.Info("register dispatcher",
 		zap.Any("dispatcherID", dispatcherID),
 		zap.String("span", tableSpan.String()),
-		zap.Uint64("startTs", startTs))
+		zap.Uint64("startTs", startTs),
+		zap.Bool("disableOldValue", disableOldValue))
 
 	start := time.Now()
 	defer func() {
@@ -420,38 +524,77 @@ func (e *eventStore) RegisterDispatcher(
 	}
 
 	e.dispatcherMeta.Lock()
-	if candidateIDs, ok := e.dispatcherMeta.tableToDispatchers[tableSpan.TableID]; ok {
-		for candidateID := range candidateIDs {
-			candidateDispatcher, ok := e.dispatcherMeta.dispatcherStats[candidateID]
-			if !ok {
-				log.Panic("should not happen")
+	candidates := e.findReusableSubscriptions(tableSpan, startTs, disableOldValue)
+	// The dispatchers of different changefeeds share one upstream subscription if its span
+	// covers the span of the new dispatcher. The subscription with exactly the same span is
+	// preferred, so the events don't need to be filtered when they are read.
+	var reused *subscriptionStat
+	for _, subscriptionStat := range candidates {
+		if !common.IsSubSpan(*tableSpan, *subscriptionStat.tableSpan) {
+			continue
+		}
+		if reused == nil || subscriptionStat.tableSpan.Equal(tableSpan) {
+			reused = subscriptionStat
+		}
+	}
+	if reused != nil {
+		stat.subIDs = []logpuller.SubscriptionID{reused.subID}
+		stat.subSpans = []*heartbeatpb.TableSpan{tableSpan}
+		e.addDispatcherToSubscription(stat, reused, notifier)
+		e.dispatcherMeta.Unlock()
+		log.Info("reuse existing subscription",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("subID", uint64(reused.subID)),
+			zap.String("subscribedSpan", reused.tableSpan.String()),
+			zap.Uint64("checkpointTs", reused.checkpointTs.Load()),
+			zap.Uint64("startTs", startTs))
+		return true, nil
+	}
+
+	// The regions subscribed by other dispatchers are multiplexed, only the regions
+	// not subscribed yet are subscribed for the new dispatcher.
+	pieces := coverSpan(*tableSpan, candidates)
+	if len(pieces) > 1 || len(pieces) == 1 && pieces[0].sub != nil {
+		gaps := 0
+		for _, piece := range pieces {
+			if piece.sub == nil {
+				gaps++
 			}
-			if candidateDispatcher.tableSpan.Equal(tableSpan) {
-				subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[candidateDispatcher.subID]
-				if !ok {
-					log.Panic("should not happen")
-				}
-				// check whether startTs is in the range [checkpointTs, resolvedTs]
-				// for `[checkpointTs`: because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
-				// for `resolvedTs]`: startTs == resolvedTs is a special case that no resolved ts has been recieved, so it is ok.
-				if subscriptionStat.checkpointTs.Load() <= startTs && startTs <= subscriptionStat.resolvedTs.Load() {
-					stat.subID = candidateDispatcher.subID
-					e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
-					// add dispatcher to existing subscription and return
-					subscriptionStat.dispatchers.Lock()
-					subscriptionStat.dispatchers.notifiers[dispatcherID] = notifier
-					subscriptionStat.dispatchers.Unlock()
-					candidateIDs[dispatcherID] = true
-					e.dispatcherMeta.Unlock()
-					log.Info("reuse existing subscription",
-						zap.Any("dispatcherID", dispatcherID),
-						zap.Uint64("subID", uint64(stat.subID)),
-						zap.Uint64("checkpointTs", subscriptionStat.checkpointTs.Load()),
-						zap.Uint64("startTs", startTs))
-					return true, nil
-				}
+		}
+		if onlyReuse && gaps > 0 {
+			e.dispatcherMeta.Unlock()
+			return false, nil
+		}
+		subStats := make([]*subscriptionStat, 0, len(pieces))
+		newSubStats := make([]*subscriptionStat, 0, gaps)
+		for _, piece := range pieces {
+			span := piece.span
+			subStat := piece.sub
+			if subStat == nil {
+				subStat = e.newSubscriptionStat(&span, startTs, disableOldValue)
+				e.dispatcherMeta.subscriptionStats[subStat.subID] = subStat
+				newSubStats = append(newSubStats, subStat)
 			}
+			subStats = append(subStats, subStat)
+			stat.subIDs = append(stat.subIDs, subStat.subID)
+			stat.subSpans = append(stat.subSpans, &span)
 		}
+		stat.resolvedTs.Store(startTs)
+		mergedNotifier := newMergedNotifier(stat, subStats, notifier)
+		for _, subStat := range subStats {
+			e.addDispatcherToSubscription(stat, subStat, mergedNotifier)
+		}
+		e.dispatcherMeta.Unlock()
+		log.Info("multiplex existing subscriptions",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Int("reusedCount", len(subStats)-len(newSubStats)),
+			zap.Int("newCount", len(newSubStats)),
+			zap.Uint64("startTs", startTs))
+		// Note: don't hold any lock when call Subscribe
+		for _, subStat := range newSubStats {
+			e.subscribe(subStat, startTs)
+		}
+		return true, nil
 	}
 	e.dispatcherMeta.Unlock()
 
@@ -460,37 +603,143 @@ func (e *eventStore) RegisterDispatcher(
 	}
 
 	// cannot share data from existing subscription, create a new subscription
+	subStat := e.newSubscriptionStat(tableSpan, startTs, disableOldValue)
+	stat.subIDs = []logpuller.SubscriptionID{subStat.subID}
+	stat.subSpans = []*heartbeatpb.TableSpan{tableSpan}
+
+	e.dispatcherMeta.Lock()
+	e.dispatcherMeta.subscriptionStats[subStat.subID] = subStat
+	e.addDispatcherToSubscription(stat, subStat, notifier)
+	e.dispatcherMeta.Unlock()
+
+	// Note: don't hold any lock when call Subscribe
+	e.subscribe(subStat, startTs)
+	return true, nil
+}
 
+// findReusableSubscriptions returns the subscriptions of the same table overlapping with the span,
+// whose data can be shared with a new dispatcher starting from startTs.
+// It must be called with dispatcherMeta locked.
+func (e *eventStore) findReusableSubscriptions(
+	tableSpan *heartbeatpb.TableSpan, startTs uint64, disableOldValue bool,
+) []*subscriptionStat {
+	candidateIDs, ok := e.dispatcherMeta.tableToDispatchers[tableSpan.TableID]
+	if !ok {
+		return nil
+	}
+	var result []*subscriptionStat
+	seen := make(map[logpuller.SubscriptionID]bool)
+	for candidateID := range candidateIDs {
+		candidateDispatcher, ok := e.dispatcherMeta.dispatcherStats[candidateID]
+		if !ok {
+			log.Panic("should not happen")
+		}
+		for _, subID := range candidateDispatcher.subIDs {
+			if seen[subID] {
+				continue
+			}
+			seen[subID] = true
+			subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[subID]
+			if !ok {
+				log.Panic("should not happen")
+			}
+			if common.IsEmptySpan(common.GetIntersectSpan(*tableSpan, *subscriptionStat.tableSpan)) {
+				continue
+			}
+			if subscriptionStat.disableOldValue && !disableOldValue {
+				continue
+			}
+			// check whether startTs is in the range [checkpointTs, resolvedTs]
+			// for `[checkpointTs`: because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
+			// for `resolvedTs]`: startTs == resolvedTs is a special case that no resolved ts has been recieved, so it is ok.
+			if subscriptionStat.checkpointTs.Load() <= startTs && startTs <= subscriptionStat.resolvedTs.Load() {
+				result = append(result, subscriptionStat)
+			}
+		}
+	}
+	// make the choice among the overlapped subscriptions stable
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].subID < result[j].subID
+	})
+	return result
+}
+
+// addDispatcherToSubscription adds the dispatcher to the subscription.
+// It must be called with dispatcherMeta locked.
+func (e *eventStore) addDispatcherToSubscription(
+	stat *dispatcherStat, subStat *subscriptionStat, notifier ResolvedTsNotifier,
+) {
+	e.dispatcherMeta.dispatcherStats[stat.dispatcherID] = stat
+	subStat.dispatchers.Lock()
+	subStat.dispatchers.notifiers[stat.dispatcherID] = notifier
+	e.updateSubscriptionThrottle(subStat)
+	subStat.dispatchers.Unlock()
+
+	tableID := stat.tableSpan.TableID
+	dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[tableID]
+	if !ok {
+		e.dispatcherMeta.tableToDispatchers[tableID] = map[common.DispatcherID]bool{stat.dispatcherID: true}
+	} else {
+		dispatchersForSameTable[stat.dispatcherID] = true
+	}
+}
+
+// newMergedNotifier returns the notifier of a dispatcher depending on several subscriptions,
+// the dispatcher is notified with the min resolved ts of the subscriptions.
+func newMergedNotifier(
+	stat *dispatcherStat, subStats []*subscriptionStat, notifier ResolvedTsNotifier,
+) ResolvedTsNotifier {
+	// the subscriptions advance concurrently, the notifier is called in order
+	var mu sync.Mutex
+	return func(_ uint64, _ uint64) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolvedTs, maxEventCommitTs := uint64(math.MaxUint64), uint64(0)
+		for _, subStat := range subStats {
+			resolvedTs = min(resolvedTs, subStat.resolvedTs.Load())
+			maxEventCommitTs = max(maxEventCommitTs, subStat.maxEventCommitTs.Load())
+		}
+		if util.CompareAndMonotonicIncrease(&stat.resolvedTs, resolvedTs) {
+			notifier(resolvedTs, maxEventCommitTs)
+		}
+	}
+}
+
+func (e *eventStore) newSubscriptionStat(
+	tableSpan *heartbeatpb.TableSpan, startTs uint64, disableOldValue bool,
+) *subscriptionStat {
 	// TODO: hash span is only needed when we need to reuse data after restart
 	// (if we finally decide not to reuse data after restart, use round robin instead)
 	// But if we need to share data for sub span, we need hash table id instead.
 	chIndex := common.HashTableSpan(tableSpan, len(e.chs))
-	stat.subID = e.subClient.AllocSubscriptionID()
 	subStat := &subscriptionStat{
-		subID:   stat.subID,
-		tableID: tableSpan.TableID,
-		dbIndex: chIndex,
-		eventCh: e.chs[chIndex],
+		subID:           e.subClient.AllocSubscriptionID(),
+		tableID:         tableSpan.TableID,
+		tableSpan:       tableSpan,
+		disableOldValue: disableOldValue,
+		dbIndex:         chIndex,
+		eventCh:         e.chs[chIndex],
+	}
+	if e.dedupWindowSize > 0 {
+		subStat.dedup = newDedupWindow(e.dedupWindowSize)
 	}
-
-	e.dispatcherMeta.Lock()
-	e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
 	subStat.dispatchers.notifiers = make(map[common.DispatcherID]ResolvedTsNotifier)
-	subStat.dispatchers.notifiers[dispatcherID] = notifier
+	subStat.dispatchers.paused = make(map[common.DispatcherID]bool)
 	subStat.checkpointTs.Store(startTs)
 	subStat.resolvedTs.Store(startTs)
 	subStat.maxEventCommitTs.Store(startTs)
-	e.dispatcherMeta.subscriptionStats[stat.subID] = subStat
-
-	dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[tableSpan.TableID]
-	if !ok {
-		e.dispatcherMeta.tableToDispatchers[tableSpan.TableID] = map[common.DispatcherID]bool{dispatcherID: true}
-	} else {
-		dispatchersForSameTable[dispatcherID] = true
-	}
-	e.dispatcherMeta.Unlock()
+	return subStat
+}
 
+// subscribe subscribes the span of the subscription from the upstream.
+func (e *eventStore) subscribe(subStat *subscriptionStat, startTs uint64) {
 	consumeKVEvents := func(kvs []common.RawKVEntry, finishCallback func()) bool {
+		if subStat.dedup != nil {
+			kvs = subStat.dedup.filter(kvs)
+			if len(kvs) == 0 {
+				return false
+			}
+		}
 		maxCommitTs := uint64(0)
 		// Must find the max commit ts in the kvs, since the kvs is not sorted yet.
 		for _, kv := range kvs {
@@ -499,12 +748,14 @@ func (e *eventStore) RegisterDispatcher(
 			}
 		}
 		util.CompareAndMonotonicIncrease(&subStat.maxEventCommitTs, maxCommitTs)
-		subStat.eventCh.Push(eventWithCallback{
+		ev := eventWithCallback{
 			subID:    subStat.subID,
 			tableID:  subStat.tableID,
 			kvs:      kvs,
 			callback: finishCallback,
-		})
+		}
+		writeBufferMemory.Consume(int64(eventWithCallbackSizer(ev)))
+		subStat.eventCh.Push(ev)
 		return true
 	}
 	advanceResolvedTs := func(ts uint64) {
@@ -515,6 +766,9 @@ func (e *eventStore) RegisterDispatcher(
 		}
 		// just do CompareAndSwap once, if failed, it means another goroutine has updated resolvedTs
 		if subStat.resolvedTs.CompareAndSwap(currentResolvedTs, ts) {
+			if subStat.dedup != nil {
+				subStat.dedup.advance(ts)
+			}
 			subStat.dispatchers.Lock()
 			defer subStat.dispatchers.Unlock()
 			for _, notifier := range subStat.dispatchers.notifiers {
@@ -523,10 +777,9 @@ func (e *eventStore) RegisterDispatcher(
 			CounterResolved.Inc()
 		}
 	}
-	// Note: don't hold any lock when call Subscribe
-	e.subClient.Subscribe(stat.subID, *tableSpan, startTs, consumeKVEvents, advanceResolvedTs, 600)
+	e.subClient.Subscribe(subStat.subID, *subStat.tableSpan, startTs,
+		consumeKVEvents, advanceResolvedTs, 600, subStat.disableOldValue)
 	metrics.EventStoreSubscriptionGauge.Inc()
-	return true, nil
 }
 
 func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
@@ -540,24 +793,28 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 	if !ok {
 		return nil
 	}
-	subID := stat.subID
 	tableID := stat.tableSpan.TableID
 	delete(e.dispatcherMeta.dispatcherStats, dispatcherID)
 
-	// delete the dispatcher from subscription
-	subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[subID]
-	if !ok {
-		log.Panic("should not happen")
-	}
-	subscriptionStat.dispatchers.Lock()
-	delete(subscriptionStat.dispatchers.notifiers, dispatcherID)
-	if len(subscriptionStat.dispatchers.notifiers) == 0 {
-		delete(e.dispatcherMeta.subscriptionStats, subID)
-		// TODO: do we need unlock before puller.Unsubscribe?
-		e.subClient.Unsubscribe(subID)
-		metrics.EventStoreSubscriptionGauge.Dec()
+	// delete the dispatcher from subscriptions
+	for _, subID := range stat.subIDs {
+		subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[subID]
+		if !ok {
+			log.Panic("should not happen")
+		}
+		subscriptionStat.dispatchers.Lock()
+		delete(subscriptionStat.dispatchers.notifiers, dispatcherID)
+		delete(subscriptionStat.dispatchers.paused, dispatcherID)
+		if len(subscriptionStat.dispatchers.notifiers) == 0 {
+			delete(e.dispatcherMeta.subscriptionStats, subID)
+			// TODO: do we need unlock before puller.Unsubscribe?
+			e.subClient.Unsubscribe(subID)
+			metrics.EventStoreSubscriptionGauge.Dec()
+		} else {
+			e.updateSubscriptionThrottle(subscriptionStat)
+		}
+		subscriptionStat.dispatchers.Unlock()
 	}
-	subscriptionStat.dispatchers.Unlock()
 
 	// delete the dispatcher from table subscriptions
 	dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[tableID]
@@ -572,6 +829,51 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 	return nil
 }
 
+func (e *eventStore) PauseDispatcher(dispatcherID common.DispatcherID) {
+	e.setDispatcherPaused(dispatcherID, true)
+}
+
+func (e *eventStore) ResumeDispatcher(dispatcherID common.DispatcherID) {
+	e.setDispatcherPaused(dispatcherID, false)
+}
+
+func (e *eventStore) setDispatcherPaused(dispatcherID common.DispatcherID, paused bool) {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+	if !ok {
+		return
+	}
+	for _, subID := range stat.subIDs {
+		subscriptionStat := e.dispatcherMeta.subscriptionStats[subID]
+		subscriptionStat.dispatchers.Lock()
+		if paused {
+			subscriptionStat.dispatchers.paused[dispatcherID] = true
+		} else {
+			delete(subscriptionStat.dispatchers.paused, dispatcherID)
+		}
+		e.updateSubscriptionThrottle(subscriptionStat)
+		subscriptionStat.dispatchers.Unlock()
+	}
+}
+
+// updateSubscriptionThrottle throttles the subscription in the log puller if all the
+// dispatchers sharing it are paused, and resumes it once any of them is resumed.
+// It must be called with subStat.dispatchers locked.
+func (e *eventStore) updateSubscriptionThrottle(subStat *subscriptionStat) {
+	throttle := len(subStat.dispatchers.notifiers) > 0 &&
+		len(subStat.dispatchers.paused) == len(subStat.dispatchers.notifiers)
+	if throttle == subStat.dispatchers.throttled {
+		return
+	}
+	subStat.dispatchers.throttled = throttle
+	if throttle {
+		e.subClient.Pause(subStat.subID)
+	} else {
+		e.subClient.Resume(subStat.subID)
+	}
+}
+
 func (e *eventStore) UpdateDispatcherCheckpointTs(
 	dispatcherID common.DispatcherID,
 	checkpointTs uint64,
@@ -629,10 +931,36 @@ func (e *eventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID
 			MaxEventCommitTs: math.MaxUint64,
 		}
 	}
-	subscriptionStat := e.dispatcherMeta.subscriptionStats[stat.subID]
-	return true, DMLEventState{
+	state := DMLEventState{}
+	for _, subID := range stat.subIDs {
 		// ResolvedTs:       subscriptionStat.resolvedTs,
-		MaxEventCommitTs: subscriptionStat.maxEventCommitTs.Load(),
+		state.MaxEventCommitTs = max(state.MaxEventCommitTs,
+			e.dispatcherMeta.subscriptionStats[subID].maxEventCommitTs.Load())
+	}
+	return true, state
+}
+
+func (e *eventStore) GetDispatcherStoredBytes(dispatcherID common.DispatcherID) map[uint64]uint64 {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+	if !ok {
+		return nil
+	}
+	storedBytes := make(map[uint64]uint64, len(stat.subIDs))
+	for _, subID := range stat.subIDs {
+		storedBytes[uint64(subID)] = e.dispatcherMeta.subscriptionStats[subID].storedBytes.Load()
+	}
+	return storedBytes
+}
+
+func (e *eventStore) GetGCSafePoint(ctx context.Context) (uint64, error) {
+	return e.subClient.GetGCSafePoint(ctx)
+}
+
+func (e *eventStore) WriteEngineStats(w io.Writer) {
+	for i, db := range e.dbs {
+		fmt.Fprintf(w, "*** db %d ***\n%s\n", i, db.Metrics().String())
 	}
 }
 
@@ -644,19 +972,53 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 		e.dispatcherMeta.RUnlock()
 		return nil, nil
 	}
-	subscriptionStat := e.dispatcherMeta.subscriptionStats[stat.subID]
-	if dataRange.StartTs < subscriptionStat.checkpointTs.Load() {
-		log.Panic("should not happen",
-			zap.Any("dispatcherID", dispatcherID),
-			zap.Uint64("checkpointTs", subscriptionStat.checkpointTs.Load()),
-			zap.Uint64("startTs", dataRange.StartTs))
+	subscriptionStats := make([]*subscriptionStat, 0, len(stat.subIDs))
+	for _, subID := range stat.subIDs {
+		subscriptionStat := e.dispatcherMeta.subscriptionStats[subID]
+		if dataRange.StartTs < subscriptionStat.checkpointTs.Load() {
+			log.Panic("should not happen",
+				zap.Any("dispatcherID", dispatcherID),
+				zap.Uint64("subID", uint64(subID)),
+				zap.Uint64("checkpointTs", subscriptionStat.checkpointTs.Load()),
+				zap.Uint64("startTs", dataRange.StartTs))
+		}
+		subscriptionStats = append(subscriptionStats, subscriptionStat)
 	}
-	db := e.dbs[subscriptionStat.dbIndex]
 	e.dispatcherMeta.RUnlock()
 
+	iters := make([]EventIterator, 0, len(subscriptionStats))
+	for i, subscriptionStat := range subscriptionStats {
+		iter, err := e.newSubscriptionIter(subscriptionStat, stat.subSpans[i], dataRange)
+		if err != nil {
+			for _, iter := range iters {
+				_, _ = iter.Close()
+			}
+			return nil, err
+		}
+		iters = append(iters, iter)
+	}
+	if len(iters) == 1 {
+		return iters[0], nil
+	}
+	return newMergedIter(iters), nil
+}
+
+// newSubscriptionIter returns the iterator of the events of the subscription in the span,
+// the span is a part of the span of a dispatcher.
+func (e *eventStore) newSubscriptionIter(
+	subscriptionStat *subscriptionStat, span *heartbeatpb.TableSpan, dataRange common.DataRange,
+) (*eventStoreIter, error) {
+	db := e.dbs[subscriptionStat.dbIndex]
+	// the subscription may be shared with the dispatchers of other spans,
+	// the events out of the span must be filtered.
+	var filterSpan *heartbeatpb.TableSpan
+	if !common.IsSubSpan(*subscriptionStat.tableSpan, *span) {
+		filterSpan = span
+	}
+
 	// convert range before pass it to pebble: (startTs, endTs] is equal to [startTs + 1, endTs + 1)
-	start := EncodeKeyPrefix(uint64(subscriptionStat.subID), stat.tableSpan.TableID, dataRange.StartTs+1)
-	end := EncodeKeyPrefix(uint64(subscriptionStat.subID), stat.tableSpan.TableID, dataRange.EndTs+1)
+	start := EncodeKeyPrefix(uint64(subscriptionStat.subID), span.TableID, dataRange.StartTs+1)
+	end := EncodeKeyPrefix(uint64(subscriptionStat.subID), span.TableID, dataRange.EndTs+1)
 	// TODO: optimize read performance
 	iter, err := db.NewIter(&pebble.IterOptions{
 		LowerBound: start,
@@ -671,7 +1033,8 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 	metrics.EventStoreScanRequestsCount.Inc()
 
 	return &eventStoreIter{
-		tableID:      stat.tableSpan.TableID,
+		tableID:      span.TableID,
+		filterSpan:   filterSpan,
 		innerIter:    iter,
 		prevStartTs:  0,
 		prevCommitTs: 0,
@@ -699,8 +1062,14 @@ func (e *eventStore) updateMetricsOnce() {
 	pdTime := e.pdClock.CurrentTime()
 	pdPhyTs := oracle.GetPhysical(pdTime)
 	minResolvedTs := uint64(0)
+	dedupWindowSize := 0
 	e.dispatcherMeta.RLock()
+	subscriptionStats := make([]*subscriptionStat, 0, len(e.dispatcherMeta.subscriptionStats))
 	for _, subscriptionStat := range e.dispatcherMeta.subscriptionStats {
+		subscriptionStats = append(subscriptionStats, subscriptionStat)
+		if subscriptionStat.dedup != nil {
+			dedupWindowSize += subscriptionStat.dedup.size()
+		}
 		// resolved ts lag
 		resolvedTs := subscriptionStat.resolvedTs.Load()
 		resolvedPhyTs := oracle.ExtractPhysical(resolvedTs)
@@ -716,6 +1085,17 @@ func (e *eventStore) updateMetricsOnce() {
 		metrics.EventStoreDispatcherWatermarkLagHist.Observe(float64(watermarkLag))
 	}
 	e.dispatcherMeta.RUnlock()
+	// estimating the disk usage may read the sstable indexes,
+	// so it's done without holding the lock.
+	for _, subscriptionStat := range subscriptionStats {
+		start := EncodeKeyPrefix(uint64(subscriptionStat.subID), subscriptionStat.tableID, 0)
+		end := EncodeKeyPrefix(uint64(subscriptionStat.subID), subscriptionStat.tableID, math.MaxUint64)
+		if storedBytes, err := e.dbs[subscriptionStat.dbIndex].EstimateDiskUsage(start, end); err == nil {
+			subscriptionStat.storedBytes.Store(storedBytes)
+		}
+	}
+	e.updateEngineMetrics()
+	metrics.EventStoreDedupWindowSizeGauge.Set(float64(dedupWindowSize))
 	if minResolvedTs == 0 {
 		metrics.EventStoreResolvedTsLagGauge.Set(0)
 		return
@@ -725,6 +1105,19 @@ func (e *eventStore) updateMetricsOnce() {
 	metrics.EventStoreResolvedTsLagGauge.Set(eventStoreResolvedTsLag)
 }
 
+func (e *eventStore) updateEngineMetrics() {
+	for i, db := range e.dbs {
+		id := strconv.Itoa(i)
+		stats := db.Metrics()
+		metrics.EventStoreOnDiskDataSizeGauge.WithLabelValues(id).Set(float64(stats.DiskSpaceUsage()))
+		metrics.EventStoreReadAmplificationGauge.WithLabelValues(id).Set(float64(stats.ReadAmp()))
+		total := stats.Total()
+		metrics.EventStoreWriteAmplificationGauge.WithLabelValues(id).Set(total.WriteAmp())
+		metrics.EventStoreCompactionPendingBytesGauge.WithLabelValues(id).Set(float64(stats.Compact.EstimatedDebt))
+		metrics.EventStoreCompactionInProgressBytesGauge.WithLabelValues(id).Set(float64(stats.Compact.InProgressBytes))
+	}
+}
+
 func (e *eventStore) writeEvents(db *pebble.DB, events []eventWithCallback) error {
 	metrics.EventStoreWriteRequestsCount.Inc()
 	batch := db.NewBatch()
@@ -761,7 +1154,9 @@ func (e *eventStore) deleteEvents(dbIndex int, uniqueKeyID uint64, tableID int64
 }
 
 type eventStoreIter struct {
-	tableID      common.TableID
+	tableID common.TableID
+	// filterSpan is not nil if the events out of it should be skipped
+	filterSpan   *heartbeatpb.TableSpan
 	innerIter    *pebble.Iterator
 	prevStartTs  uint64
 	prevCommitTs uint64
@@ -779,18 +1174,25 @@ func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
 		log.Panic("iter is nil")
 	}
 
-	if !iter.innerIter.Valid() {
-		return nil, false, nil
-	}
+	var rawKV *common.RawKVEntry
+	for {
+		if !iter.innerIter.Valid() {
+			return nil, false, nil
+		}
 
-	value := iter.innerIter.Value()
-	decompressedValue, err := iter.decoder.DecodeAll(value, nil)
-	if err != nil {
-		log.Panic("failed to decompress value", zap.Error(err))
+		value := iter.innerIter.Value()
+		decompressedValue, err := iter.decoder.DecodeAll(value, nil)
+		if err != nil {
+			log.Panic("failed to decompress value", zap.Error(err))
+		}
+		metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
+		rawKV = &common.RawKVEntry{}
+		rawKV.Decode(decompressedValue)
+		if iter.filterSpan == nil || common.KeyInSpan(common.ToComparableKey(rawKV.Key), *iter.filterSpan) {
+			break
+		}
+		iter.innerIter.Next()
 	}
-	metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
-	rawKV := &common.RawKVEntry{}
-	rawKV.Decode(decompressedValue)
 	isNewTxn := false
 	if iter.prevCommitTs == 0 || (rawKV.StartTs != iter.prevStartTs || rawKV.CRTs != iter.prevCommitTs) {
 		isNewTxn = true
@@ -848,18 +1250,19 @@ func (e *eventStore) uploadStatePeriodically(ctx context.Context) error {
 				subIDs := make(map[logpuller.SubscriptionID]bool)
 				for dispatcherID := range dispatcherIDs {
 					dispatcherStat := e.dispatcherMeta.dispatcherStats[dispatcherID]
-					subID := dispatcherStat.subID
-					subStat := e.dispatcherMeta.subscriptionStats[subID]
-					if _, ok := subIDs[subID]; ok {
-						continue
+					for _, subID := range dispatcherStat.subIDs {
+						if _, ok := subIDs[subID]; ok {
+							continue
+						}
+						subStat := e.dispatcherMeta.subscriptionStats[subID]
+						subStates = append(subStates, &logservicepb.SubscriptionState{
+							SubID:        uint64(subID),
+							Span:         subStat.tableSpan,
+							CheckpointTs: subStat.checkpointTs.Load(),
+							ResolvedTs:   subStat.resolvedTs.Load(),
+						})
+						subIDs[subID] = true
 					}
-					subStates = append(subStates, &logservicepb.SubscriptionState{
-						SubID:        uint64(subID),
-						Span:         dispatcherStat.tableSpan,
-						CheckpointTs: subStat.checkpointTs.Load(),
-						ResolvedTs:   subStat.resolvedTs.Load(),
-					})
-					subIDs[subID] = true
 				}
 				sort.Slice(subStates, func(i, j int) bool {
 					return subStates[i].SubID < subStates[j].SubID
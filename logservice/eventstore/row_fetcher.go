@@ -0,0 +1,216 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+// FetchedRow is the row version read from the event store.
+// The values are formatted as strings, and nil means NULL.
+type FetchedRow struct {
+	// Columns is the row written at the commitTs, it's nil if the row is deleted.
+	Columns map[string]interface{}
+	// PreColumns is the row before the commitTs, it's nil if the row is inserted.
+	PreColumns map[string]interface{}
+}
+
+// FetchRow reads the row changed at commitTs from the event store, the row is identified by
+// the values of its handle key columns. It's used to complete the messages which only contain
+// the handle key columns.
+//
+// The event store only retains the events of the tables subscribed by the dispatchers on this
+// node, and the events are kept as long as the subscription lives. The returned bool is false
+// if the events of the table at commitTs are not retained.
+func FetchRow(
+	store EventStore,
+	tableInfo *common.TableInfo,
+	tableID int64,
+	commitTs uint64,
+	handleKey map[string]string,
+	tz *time.Location,
+) (*FetchedRow, bool, error) {
+	if commitTs == 0 {
+		return nil, false, nil
+	}
+	span := rowSpan(tableInfo, tableID, handleKey)
+	dispatcherID := common.NewDispatcherID()
+	// only reuse the existing subscriptions, the events before the registration are
+	// not available in a new subscription.
+	ok, err := store.RegisterDispatcher(dispatcherID, span, commitTs-1, func(uint64, uint64) {}, true, false)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	defer func() {
+		if err := store.UnregisterDispatcher(dispatcherID); err != nil {
+			log.Warn("unregister the dispatcher for fetching row failed",
+				zap.Any("dispatcherID", dispatcherID), zap.Error(err))
+		}
+	}()
+
+	iter, err := store.GetIterator(dispatcherID, common.DataRange{
+		Span:    span,
+		StartTs: commitTs - 1,
+		EndTs:   commitTs,
+	})
+	if err != nil || iter == nil {
+		return nil, false, err
+	}
+	defer func() {
+		_, _ = iter.Close()
+	}()
+
+	mounter := event.NewMounter(tz)
+	for {
+		raw, _, err := iter.Next()
+		if err != nil {
+			return nil, true, errors.Trace(err)
+		}
+		if raw == nil {
+			return nil, true, nil
+		}
+		if raw.CRTs != commitTs {
+			continue
+		}
+		chk := chunk.NewChunkWithCapacity(tableInfo.GetFieldSlice(), 2)
+		if _, err = mounter.DecodeToChunk(raw, tableInfo, chk); err != nil {
+			return nil, true, errors.Trace(err)
+		}
+		if chk.NumRows() == 0 {
+			continue
+		}
+		result := &FetchedRow{}
+		idx := 0
+		if len(raw.OldValue) != 0 {
+			row := chk.GetRow(idx)
+			result.PreColumns, err = formatRow(&row, tableInfo)
+			if err != nil {
+				return nil, true, err
+			}
+			idx++
+		}
+		if len(raw.Value) != 0 {
+			row := chk.GetRow(idx)
+			result.Columns, err = formatRow(&row, tableInfo)
+			if err != nil {
+				return nil, true, err
+			}
+		}
+		matched := result.Columns
+		if matched == nil {
+			matched = result.PreColumns
+		}
+		if matchHandleKey(matched, tableInfo, handleKey) {
+			return result, true, nil
+		}
+	}
+}
+
+// rowSpan returns the span of the row if its handle is an integer, so it can be read from the
+// subscription of a sub span of the table. Otherwise the span of the whole table is returned.
+func rowSpan(tableInfo *common.TableInfo, tableID int64, handleKey map[string]string) *heartbeatpb.TableSpan {
+	if tableInfo.PKIsHandle() {
+		for _, col := range tableInfo.GetColumns() {
+			if col == nil || !mysql.HasPriKeyFlag(col.GetFlag()) {
+				continue
+			}
+			value, ok := handleKey[col.Name.O]
+			if !ok {
+				break
+			}
+			var handle int64
+			if mysql.HasUnsignedFlag(col.GetFlag()) {
+				v, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					break
+				}
+				handle = int64(v)
+			} else {
+				v, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					break
+				}
+				handle = v
+			}
+			key := tablecodec.EncodeRowKeyWithHandle(tableID, kv.IntHandle(handle))
+			span := common.ToSpan(key, key.Next())
+			span.TableID = tableID
+			return &span
+		}
+	}
+	span := spanz.TableIDToComparableSpan(tableID)
+	return &heartbeatpb.TableSpan{
+		TableID:  tableID,
+		StartKey: span.StartKey,
+		EndKey:   span.EndKey,
+	}
+}
+
+func formatRow(row *chunk.Row, tableInfo *common.TableInfo) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(tableInfo.GetColumns()))
+	for idx, col := range tableInfo.GetColumns() {
+		if col == nil {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch v := value.(type) {
+		case nil:
+			result[col.Name.O] = nil
+		case []byte:
+			result[col.Name.O] = string(v)
+		case string:
+			result[col.Name.O] = v
+		default:
+			result[col.Name.O] = fmt.Sprint(v)
+		}
+	}
+	return result, nil
+}
+
+func matchHandleKey(row map[string]interface{}, tableInfo *common.TableInfo, handleKey map[string]string) bool {
+	if row == nil || len(handleKey) == 0 {
+		return false
+	}
+	for _, col := range tableInfo.GetColumns() {
+		if col == nil || !tableInfo.GetColumnFlags()[col.ID].IsHandleKey() {
+			continue
+		}
+		expected, ok := handleKey[col.Name.O]
+		if !ok {
+			return false
+		}
+		actual, ok := row[col.Name.O].(string)
+		if !ok || actual != expected {
+			return false
+		}
+	}
+	return true
+}
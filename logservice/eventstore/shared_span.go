@@ -0,0 +1,179 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"bytes"
+	"container/heap"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+)
+
+// spanPiece is a part of the span of a dispatcher, its events are read from
+// the existing subscription sub, or from a new subscription if sub is nil.
+type spanPiece struct {
+	span heartbeatpb.TableSpan
+	sub  *subscriptionStat
+}
+
+// coverSpan splits the span into the pieces covered by the subscriptions and the gaps between them.
+// The subscriptions are region aligned since the spans of the dispatchers are split by regions, so
+// the regions subscribed by other changefeeds are multiplexed, and only the regions in the gaps are
+// subscribed again. The subscription reaching farthest is used if some subscriptions overlap.
+func coverSpan(span heartbeatpb.TableSpan, subs []*subscriptionStat) []spanPiece {
+	pieces := make([]spanPiece, 0, 1)
+	cursor := span.StartKey
+	for bytes.Compare(cursor, span.EndKey) < 0 {
+		var best *subscriptionStat
+		// the nearest start key of the subscriptions after the cursor
+		next := span.EndKey
+		for _, sub := range subs {
+			start, end := sub.tableSpan.StartKey, sub.tableSpan.EndKey
+			if bytes.Compare(start, cursor) <= 0 && bytes.Compare(cursor, end) < 0 {
+				if best == nil || bytes.Compare(end, best.tableSpan.EndKey) > 0 {
+					best = sub
+				}
+			} else if bytes.Compare(start, cursor) > 0 && bytes.Compare(start, next) < 0 {
+				next = start
+			}
+		}
+		end := next
+		if best != nil {
+			end = best.tableSpan.EndKey
+			if bytes.Compare(end, span.EndKey) > 0 {
+				end = span.EndKey
+			}
+		}
+		pieces = append(pieces, spanPiece{
+			span: heartbeatpb.TableSpan{TableID: span.TableID, StartKey: cursor, EndKey: end},
+			sub:  best,
+		})
+		cursor = end
+	}
+	return pieces
+}
+
+// mergedIter merges the events of a dispatcher read from several subscriptions, the events
+// are returned in the same order as they are stored in a single subscription.
+type mergedIter struct {
+	iters        []EventIterator
+	heap         mergedIterHeap
+	prevStartTs  uint64
+	prevCommitTs uint64
+	initialized  bool
+}
+
+func newMergedIter(iters []EventIterator) *mergedIter {
+	return &mergedIter{iters: iters}
+}
+
+func (m *mergedIter) init() error {
+	m.initialized = true
+	for _, iter := range m.iters {
+		if err := m.push(iter); err != nil {
+			return err
+		}
+	}
+	heap.Init(&m.heap)
+	return nil
+}
+
+func (m *mergedIter) push(iter EventIterator) error {
+	kv, _, err := iter.Next()
+	if err != nil {
+		return err
+	}
+	if kv != nil {
+		m.heap = append(m.heap, mergedIterItem{kv: kv, iter: iter})
+	}
+	return nil
+}
+
+func (m *mergedIter) Next() (*common.RawKVEntry, bool, error) {
+	if !m.initialized {
+		if err := m.init(); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(m.heap) == 0 {
+		return nil, false, nil
+	}
+	item := m.heap[0]
+	kv, _, err := item.iter.Next()
+	if err != nil {
+		return nil, false, err
+	}
+	if kv != nil {
+		m.heap[0].kv = kv
+		heap.Fix(&m.heap, 0)
+	} else {
+		heap.Pop(&m.heap)
+	}
+	isNewTxn := m.prevCommitTs == 0 || item.kv.StartTs != m.prevStartTs || item.kv.CRTs != m.prevCommitTs
+	m.prevCommitTs = item.kv.CRTs
+	m.prevStartTs = item.kv.StartTs
+	return item.kv, isNewTxn, nil
+}
+
+func (m *mergedIter) Close() (int64, error) {
+	var (
+		total    int64
+		firstErr error
+	)
+	for _, iter := range m.iters {
+		cnt, err := iter.Close()
+		total += cnt
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return total, firstErr
+}
+
+type mergedIterItem struct {
+	kv   *common.RawKVEntry
+	iter EventIterator
+}
+
+// mergedIterHeap orders the events by the encoded key of the store without the subscription id.
+type mergedIterHeap []mergedIterItem
+
+func (h mergedIterHeap) Len() int { return len(h) }
+
+func (h mergedIterHeap) Less(i, j int) bool {
+	a, b := h[i].kv, h[j].kv
+	if a.CRTs != b.CRTs {
+		return a.CRTs < b.CRTs
+	}
+	if a.StartTs != b.StartTs {
+		return a.StartTs < b.StartTs
+	}
+	if orderA, orderB := getDMLOrder(a), getDMLOrder(b); orderA != orderB {
+		return orderA < orderB
+	}
+	return bytes.Compare(a.Key, b.Key) < 0
+}
+
+func (h mergedIterHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergedIterHeap) Push(x any) { *h = append(*h, x.(mergedIterItem)) }
+
+func (h *mergedIterHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubscription(id uint64, start, end string) *subscriptionStat {
+	return &subscriptionStat{
+		subID:     logpuller.SubscriptionID(id),
+		tableSpan: &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte(start), EndKey: []byte(end)},
+	}
+}
+
+func TestCoverSpan(t *testing.T) {
+	span := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("b"), EndKey: []byte("h")}
+	type piece struct {
+		start, end string
+		subID      uint64
+	}
+	check := func(subs []*subscriptionStat, expected []piece) {
+		pieces := coverSpan(span, subs)
+		require.Len(t, pieces, len(expected))
+		for i, p := range pieces {
+			require.Equal(t, expected[i].start, string(p.span.StartKey))
+			require.Equal(t, expected[i].end, string(p.span.EndKey))
+			if expected[i].subID == 0 {
+				require.Nil(t, p.sub)
+			} else {
+				require.Equal(t, logpuller.SubscriptionID(expected[i].subID), p.sub.subID)
+			}
+		}
+	}
+
+	// no subscription
+	check(nil, []piece{{"b", "h", 0}})
+	// the subscriptions cover the head and the tail, the middle is a gap
+	check([]*subscriptionStat{
+		newTestSubscription(1, "a", "c"),
+		newTestSubscription(2, "f", "z"),
+	}, []piece{{"b", "c", 1}, {"c", "f", 0}, {"f", "h", 2}})
+	// the overlapped subscriptions, the one reaching farthest is used
+	check([]*subscriptionStat{
+		newTestSubscription(1, "b", "d"),
+		newTestSubscription(2, "b", "e"),
+		newTestSubscription(3, "d", "h"),
+	}, []piece{{"b", "e", 2}, {"e", "h", 3}})
+	// the gap at the head
+	check([]*subscriptionStat{
+		newTestSubscription(1, "d", "h"),
+	}, []piece{{"b", "d", 0}, {"d", "h", 1}})
+}
+
+type sliceIter struct {
+	kvs    []*common.RawKVEntry
+	closed bool
+}
+
+func (s *sliceIter) Next() (*common.RawKVEntry, bool, error) {
+	if len(s.kvs) == 0 {
+		return nil, false, nil
+	}
+	kv := s.kvs[0]
+	s.kvs = s.kvs[1:]
+	return kv, false, nil
+}
+
+func (s *sliceIter) Close() (int64, error) {
+	s.closed = true
+	return 1, nil
+}
+
+func TestMergedIter(t *testing.T) {
+	newKV := func(key string, startTs, commitTs uint64) *common.RawKVEntry {
+		return &common.RawKVEntry{OpType: common.OpTypePut, Key: []byte(key), StartTs: startTs, CRTs: commitTs}
+	}
+	iter1 := &sliceIter{kvs: []*common.RawKVEntry{newKV("a", 1, 2), newKV("b", 3, 4), newKV("a", 5, 6)}}
+	iter2 := &sliceIter{kvs: []*common.RawKVEntry{newKV("c", 1, 2), newKV("d", 5, 6)}}
+	iter3 := &sliceIter{}
+	iter := newMergedIter([]EventIterator{iter1, iter2, iter3})
+
+	type result struct {
+		key      string
+		commitTs uint64
+		isNewTxn bool
+	}
+	expected := []result{
+		{"a", 2, true}, {"c", 2, false}, {"b", 4, true}, {"a", 6, true}, {"d", 6, false},
+	}
+	for _, e := range expected {
+		kv, isNewTxn, err := iter.Next()
+		require.NoError(t, err)
+		require.Equal(t, e.key, string(kv.Key))
+		require.Equal(t, e.commitTs, kv.CRTs)
+		require.Equal(t, e.isNewTxn, isNewTxn)
+	}
+	kv, _, err := iter.Next()
+	require.NoError(t, err)
+	require.Nil(t, kv)
+
+	cnt, err := iter.Close()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), cnt)
+	require.True(t, iter1.closed && iter2.closed && iter3.closed)
+}
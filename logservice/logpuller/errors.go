@@ -44,3 +44,13 @@ func (e *rpcCtxUnavailableErr) Error() string {
 type sendRequestToStoreErr struct{}
 
 func (e *sendRequestToStoreErr) Error() string { return "send request to store error" }
+
+// matcherMemoryExceededErr is met when the unmatched prewrite rows reach the memory
+// hard limit, the region is re-subscribed to drop its unmatched prewrite rows.
+type matcherMemoryExceededErr struct {
+	unmatchedSize int64
+}
+
+func (e *matcherMemoryExceededErr) Error() string {
+	return fmt.Sprintf("matcher memory exceeded, unmatched size %d", e.unmatchedSize)
+}
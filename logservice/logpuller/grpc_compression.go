@@ -0,0 +1,44 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"github.com/coreos/go-semver/semver"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/version"
+	// Register the gzip compressor to grpc, it also decodes the responses
+	// compressed by TiKV with `server.grpc-compression-type = "gzip"`.
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// The minimal TiKV versions which accept the compressed ChangeData streams.
+// TiKV serves grpc with grpc core, which decodes the gzip requests in all
+// the compatible versions.
+var minTiKVVersionForCompression = map[string]*semver.Version{
+	config.GrpcCompressionGzip: version.MinTiKVVersion,
+}
+
+// negotiateCompression returns the compression to be used by the stream to a TiKV store
+// of the given version, it falls back to no compression if the store doesn't support it.
+func negotiateCompression(compression string, storeVersion string) string {
+	minVersion, ok := minTiKVVersionForCompression[compression]
+	if !ok {
+		return config.GrpcCompressionNone
+	}
+	ver, err := semver.NewVersion(version.SanitizeVersion(storeVersion))
+	if err != nil || ver.LessThan(*minVersion) {
+		return config.GrpcCompressionNone
+	}
+	return compression
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	t.Parallel()
+	minVersion := "v" + version.MinTiKVVersion.String()
+	require.Equal(t, config.GrpcCompressionGzip, negotiateCompression(config.GrpcCompressionGzip, "v8.5.1"))
+	require.Equal(t, config.GrpcCompressionGzip, negotiateCompression(config.GrpcCompressionGzip, minVersion))
+	require.Equal(t, config.GrpcCompressionNone, negotiateCompression(config.GrpcCompressionGzip, "v6.5.0"))
+	require.Equal(t, config.GrpcCompressionNone, negotiateCompression(config.GrpcCompressionGzip, "invalid"))
+	require.Equal(t, config.GrpcCompressionNone, negotiateCompression("zstd", "v8.5.1"))
+	require.Equal(t, config.GrpcCompressionNone, negotiateCompression(config.GrpcCompressionNone, "v8.5.1"))
+}
+
+func TestGzipCompressor(t *testing.T) {
+	t.Parallel()
+	// The compressor decodes both the compressed requests and responses.
+	c := encoding.GetCompressor(config.GrpcCompressionGzip)
+	require.NotNil(t, c)
+
+	data := bytes.Repeat([]byte("change data event "), 1024)
+	var buf bytes.Buffer
+	w, err := c.Compress(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Less(t, buf.Len(), len(data))
+
+	r, err := c.Decompress(&buf)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+func TestValidateGrpcCompression(t *testing.T) {
+	t.Parallel()
+	cfg := config.NewDefaultKVClientConfig()
+	cfg.GrpcCompression = ""
+	require.NoError(t, cfg.ValidateAndAdjust())
+	require.Equal(t, config.GrpcCompressionNone, cfg.GrpcCompression)
+
+	cfg.GrpcCompression = config.GrpcCompressionGzip
+	require.NoError(t, cfg.ValidateAndAdjust())
+
+	cfg.GrpcCompression = "zstd"
+	require.Error(t, cfg.ValidateAndAdjust())
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pingcap/kvproto/pkg/cdcpb"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/tiflow/pkg/security"
 	"google.golang.org/grpc"
@@ -112,7 +113,10 @@ type ConnAndClient struct {
 }
 
 // `Connect` returns a connection and client to remote store.
-func Connect(ctx context.Context, credential *security.Credential, target string) (*ConnAndClient, error) {
+// The stream is compressed with the given compression unless it is none.
+func Connect(
+	ctx context.Context, credential *security.Credential, target string, compression string,
+) (*ConnAndClient, error) {
 	clientConn, err := createGRPCConn(ctx, credential, target)
 	if err != nil {
 		return nil, err
@@ -120,7 +124,11 @@ func Connect(ctx context.Context, credential *security.Credential, target string
 
 	rpc := cdcpb.NewChangeDataClient(clientConn)
 	ctx = getContextFromFeatures(ctx, []string{rpcMetaFeatureStreamMultiplexing})
-	client, err := rpc.EventFeedV2(ctx)
+	var callOpts []grpc.CallOption
+	if compression != "" && compression != config.GrpcCompressionNone {
+		callOpts = append(callOpts, grpc.UseCompressor(compression))
+	}
+	client, err := rpc.EventFeedV2(ctx, callOpts...)
 	return &ConnAndClient{
 		Conn:   clientConn,
 		Client: client,
@@ -0,0 +1,318 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// regionErrorClass is the class of an error met by a region request,
+// the errors of the same class share the same retry policy.
+type regionErrorClass int
+
+const (
+	regionErrorNotLeader regionErrorClass = iota
+	regionErrorEpochNotMatch
+	regionErrorRegionNotFound
+	regionErrorCongested
+	regionErrorDuplicateRequest
+	regionErrorCompatibility
+	regionErrorClusterIDMismatch
+	regionErrorRPCCtxUnavailable
+	regionErrorStoreUnreachable
+	regionErrorMatcherMemory
+	regionErrorUnknown
+	regionErrorInternal
+	regionErrorClassCount
+)
+
+// The names are also the labels of the error counter, keep them unchanged.
+var regionErrorClassNames = [regionErrorClassCount]string{
+	regionErrorNotLeader:         "NotLeader",
+	regionErrorEpochNotMatch:     "EpochNotMatch",
+	regionErrorRegionNotFound:    "RegionNotFound",
+	regionErrorCongested:         "KvIsBusy",
+	regionErrorDuplicateRequest:  "DuplicateRequest",
+	regionErrorCompatibility:     "Compatibility",
+	regionErrorClusterIDMismatch: "ClusterIDMismatch",
+	regionErrorRPCCtxUnavailable: "RPCCtxUnavailable",
+	regionErrorStoreUnreachable:  "SendRequestToStore",
+	regionErrorMatcherMemory:     "MatcherMemoryExceeded",
+	regionErrorUnknown:           "Unknown",
+	regionErrorInternal:          "Internal",
+}
+
+var (
+	metricRegionErrorCounters  [regionErrorClassCount]prometheus.Counter
+	metricRegionRetryBackoffes [regionErrorClassCount]prometheus.Observer
+)
+
+func init() {
+	for c, name := range regionErrorClassNames {
+		metricRegionErrorCounters[c] = metrics.EventFeedErrorCounter.WithLabelValues(name)
+		metricRegionRetryBackoffes[c] = metrics.LogPullerRegionRetryBackoffHistogram.WithLabelValues(name)
+	}
+}
+
+func (c regionErrorClass) String() string {
+	return regionErrorClassNames[c]
+}
+
+// classifyRegionError returns the class of the error met by a region request.
+func classifyRegionError(err error) regionErrorClass {
+	switch eerr := errors.Cause(err).(type) {
+	case *eventError:
+		innerErr := eerr.err
+		switch {
+		case innerErr.GetNotLeader() != nil:
+			return regionErrorNotLeader
+		case innerErr.GetEpochNotMatch() != nil:
+			return regionErrorEpochNotMatch
+		case innerErr.GetRegionNotFound() != nil:
+			return regionErrorRegionNotFound
+		case innerErr.GetServerIsBusy() != nil, innerErr.GetCongested() != nil:
+			return regionErrorCongested
+		case innerErr.GetDuplicateRequest() != nil:
+			return regionErrorDuplicateRequest
+		case innerErr.GetCompatibility() != nil:
+			return regionErrorCompatibility
+		case innerErr.GetClusterIdMismatch() != nil:
+			return regionErrorClusterIDMismatch
+		}
+		return regionErrorUnknown
+	case *rpcCtxUnavailableErr:
+		return regionErrorRPCCtxUnavailable
+	case *sendRequestToStoreErr:
+		return regionErrorStoreUnreachable
+	case *matcherMemoryExceededErr:
+		return regionErrorMatcherMemory
+	}
+	return regionErrorInternal
+}
+
+// retryAction is how a failed region request is retried.
+type retryAction int
+
+const (
+	// retryRegion sends the request of the region again.
+	retryRegion retryAction = iota
+	// retryRange reloads the regions of the span from PD, as the region is changed.
+	retryRange
+)
+
+// retryPolicy is how a region error is retried. The backoffs are tuned for each kind of
+// the region errors, so the backoff policies of the retry classes set globally don't apply.
+type retryPolicy struct {
+	// fatal is true if the error fails the subscription instead of being retried.
+	fatal  bool
+	action retryAction
+	// The backoff before the n-th consecutive retry of a region is
+	// baseBackoff * 2^(n-1), capped by maxBackoff. 0 means no backoff.
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	// storeFault is true if the error indicates the store is unhealthy,
+	// it is counted by the circuit breaker of the store.
+	storeFault bool
+}
+
+var retryPolicies = [regionErrorClassCount]retryPolicy{
+	// The leader or the region is changed, retry at once with the new one.
+	regionErrorNotLeader:      {action: retryRegion},
+	regionErrorEpochNotMatch:  {action: retryRange},
+	regionErrorRegionNotFound: {action: retryRange},
+	// The store is overloaded, give it some time to recover.
+	regionErrorCongested: {
+		action:      retryRegion,
+		baseBackoff: 100 * time.Millisecond, maxBackoff: 5 * time.Second, storeFault: true,
+	},
+	regionErrorDuplicateRequest:  {fatal: true},
+	regionErrorCompatibility:     {fatal: true},
+	regionErrorClusterIDMismatch: {fatal: true},
+	regionErrorRPCCtxUnavailable: {
+		action:      retryRange,
+		baseBackoff: 50 * time.Millisecond, maxBackoff: 3 * time.Second,
+	},
+	regionErrorStoreUnreachable: {
+		action:      retryRegion,
+		baseBackoff: 200 * time.Millisecond, maxBackoff: 10 * time.Second, storeFault: true,
+	},
+	// Give the other regions some time to release the matcher memory.
+	regionErrorMatcherMemory: {
+		action:      retryRegion,
+		baseBackoff: time.Second, maxBackoff: 10 * time.Second,
+	},
+	regionErrorUnknown: {
+		action:      retryRegion,
+		baseBackoff: 100 * time.Millisecond, maxBackoff: 5 * time.Second, storeFault: true,
+	},
+	regionErrorInternal: {fatal: true},
+}
+
+func (p retryPolicy) backoff(attempts int) time.Duration {
+	if p.baseBackoff == 0 || attempts <= 0 {
+		return 0
+	}
+	d := p.baseBackoff
+	for i := 1; i < attempts && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.maxBackoff {
+		d = p.maxBackoff
+	}
+	return d
+}
+
+const (
+	// The consecutive failures of a region are forgotten if it doesn't fail in the interval.
+	regionRetryResetInterval = time.Minute
+	regionRetryGCThreshold   = 4096
+)
+
+// regionRetryTracker counts the consecutive failures of the regions to decide their backoff.
+// It is only accessed by the goroutine handling the region errors.
+type regionRetryTracker struct {
+	regions map[uint64]*regionRetryStat
+}
+
+type regionRetryStat struct {
+	attempts int
+	lastFail time.Time
+}
+
+func newRegionRetryTracker() *regionRetryTracker {
+	return &regionRetryTracker{regions: make(map[uint64]*regionRetryStat)}
+}
+
+// onFailure records a failure of the region and returns its consecutive failures.
+func (t *regionRetryTracker) onFailure(regionID uint64, now time.Time) int {
+	stat, ok := t.regions[regionID]
+	if !ok || now.Sub(stat.lastFail) > regionRetryResetInterval {
+		if len(t.regions) >= regionRetryGCThreshold {
+			t.gc(now)
+		}
+		stat = &regionRetryStat{}
+		t.regions[regionID] = stat
+	}
+	stat.attempts++
+	stat.lastFail = now
+	return stat.attempts
+}
+
+func (t *regionRetryTracker) gc(now time.Time) {
+	for regionID, stat := range t.regions {
+		if now.Sub(stat.lastFail) > regionRetryResetInterval {
+			delete(t.regions, regionID)
+		}
+	}
+}
+
+const (
+	// The circuit of a store is opened if it returns storeBreakerThreshold faults in storeBreakerWindow.
+	storeBreakerWindow    = 10 * time.Second
+	storeBreakerThreshold = 64
+	// The cooldown of the circuit doubles each time it is opened again, and is reset
+	// once the store returns less faults than the threshold in a window.
+	storeBreakerMinCooldown = time.Second
+	storeBreakerMaxCooldown = 30 * time.Second
+)
+
+// storeCircuitBreaker suspends the region requests to the stores that keep
+// returning errors, instead of reconnecting them at once again and again.
+type storeCircuitBreaker struct {
+	mu     sync.Mutex
+	stores map[uint64]*storeFaultStat
+}
+
+type storeFaultStat struct {
+	windowStart time.Time
+	faults      int
+	cooldown    time.Duration
+	openUntil   time.Time
+	open        bool
+}
+
+func newStoreCircuitBreaker() *storeCircuitBreaker {
+	return &storeCircuitBreaker{stores: make(map[uint64]*storeFaultStat)}
+}
+
+// onFault records a fault of the store, it returns true if the circuit is opened by the fault.
+func (b *storeCircuitBreaker) onFault(storeID uint64, addr string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stat, ok := b.stores[storeID]
+	if !ok {
+		stat = &storeFaultStat{windowStart: now}
+		b.stores[storeID] = stat
+	}
+	if now.Sub(stat.windowStart) > storeBreakerWindow {
+		if stat.faults < storeBreakerThreshold && !now.Before(stat.openUntil) {
+			stat.cooldown = 0
+		}
+		stat.windowStart = now
+		stat.faults = 0
+	}
+	stat.faults++
+	if stat.faults < storeBreakerThreshold || now.Before(stat.openUntil) {
+		return false
+	}
+
+	stat.cooldown *= 2
+	if stat.cooldown < storeBreakerMinCooldown {
+		stat.cooldown = storeBreakerMinCooldown
+	}
+	if stat.cooldown > storeBreakerMaxCooldown {
+		stat.cooldown = storeBreakerMaxCooldown
+	}
+	stat.openUntil = now.Add(stat.cooldown)
+	stat.windowStart = now
+	stat.faults = 0
+	if !stat.open {
+		stat.open = true
+		metrics.LogPullerStoreCircuitBreakerGauge.WithLabelValues(strconv.FormatUint(storeID, 10)).Set(1)
+	}
+	log.Warn("subscription client suspends the region requests to a store which keeps failing",
+		zap.Uint64("storeID", storeID),
+		zap.String("addr", addr),
+		zap.Duration("cooldown", stat.cooldown))
+	return true
+}
+
+// suspended returns how long the region requests to the store should still be suspended,
+// 0 means the requests are allowed.
+func (b *storeCircuitBreaker) suspended(storeID uint64, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stat, ok := b.stores[storeID]
+	if !ok {
+		return 0
+	}
+	if now.Before(stat.openUntil) {
+		return stat.openUntil.Sub(now)
+	}
+	if stat.open {
+		stat.open = false
+		metrics.LogPullerStoreCircuitBreakerGauge.WithLabelValues(strconv.FormatUint(storeID, 10)).Set(0)
+		log.Info("subscription client resumes the region requests to a store",
+			zap.Uint64("storeID", storeID))
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/cdcpb"
+	"github.com/pingcap/kvproto/pkg/errorpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyRegionError(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		err   error
+		class regionErrorClass
+	}{
+		{&eventError{err: &cdcpb.Error{NotLeader: &errorpb.NotLeader{}}}, regionErrorNotLeader},
+		{&eventError{err: &cdcpb.Error{EpochNotMatch: &errorpb.EpochNotMatch{}}}, regionErrorEpochNotMatch},
+		{&eventError{err: &cdcpb.Error{RegionNotFound: &errorpb.RegionNotFound{}}}, regionErrorRegionNotFound},
+		{&eventError{err: &cdcpb.Error{ServerIsBusy: &errorpb.ServerIsBusy{}}}, regionErrorCongested},
+		{&eventError{err: &cdcpb.Error{Congested: &cdcpb.Congested{}}}, regionErrorCongested},
+		{&eventError{err: &cdcpb.Error{Compatibility: &cdcpb.Compatibility{}}}, regionErrorCompatibility},
+		{&eventError{err: &cdcpb.Error{}}, regionErrorUnknown},
+		{errors.Trace(&sendRequestToStoreErr{}), regionErrorStoreUnreachable},
+		{&rpcCtxUnavailableErr{}, regionErrorRPCCtxUnavailable},
+		{&matcherMemoryExceededErr{}, regionErrorMatcherMemory},
+		{errors.New("internal"), regionErrorInternal},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.class, classifyRegionError(c.err), c.err.Error())
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+	p := retryPolicies[regionErrorCongested]
+	require.Equal(t, 100*time.Millisecond, p.backoff(1))
+	require.Equal(t, 400*time.Millisecond, p.backoff(3))
+	require.Equal(t, 5*time.Second, p.backoff(100))
+	require.Zero(t, retryPolicies[regionErrorNotLeader].backoff(10))
+
+	tracker := newRegionRetryTracker()
+	now := time.Now()
+	require.Equal(t, 1, tracker.onFailure(1, now))
+	require.Equal(t, 2, tracker.onFailure(1, now.Add(time.Second)))
+	require.Equal(t, 1, tracker.onFailure(2, now))
+	// the failures are forgotten after the reset interval
+	require.Equal(t, 1, tracker.onFailure(1, now.Add(time.Second+2*regionRetryResetInterval)))
+}
+
+func TestStoreCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	b := newStoreCircuitBreaker()
+	now := time.Now()
+	for i := 0; i < storeBreakerThreshold-1; i++ {
+		require.False(t, b.onFault(1, "store1", now))
+	}
+	require.Zero(t, b.suspended(1, now))
+	require.True(t, b.onFault(1, "store1", now))
+	require.Equal(t, storeBreakerMinCooldown, b.suspended(1, now))
+	require.Zero(t, b.suspended(2, now))
+
+	// the cooldown doubles if the store keeps failing after the circuit is closed
+	now = now.Add(storeBreakerMinCooldown)
+	require.Zero(t, b.suspended(1, now))
+	for i := 0; i < storeBreakerThreshold-1; i++ {
+		require.False(t, b.onFault(1, "store1", now))
+	}
+	require.True(t, b.onFault(1, "store1", now))
+	require.Equal(t, 2*storeBreakerMinCooldown, b.suspended(1, now))
+
+	// the cooldown is reset once the store recovers
+	now = now.Add(2 * storeBreakerWindow)
+	require.False(t, b.onFault(1, "store1", now))
+	now = now.Add(2 * storeBreakerWindow)
+	for i := 0; i < storeBreakerThreshold-1; i++ {
+		require.False(t, b.onFault(1, "store1", now))
+	}
+	require.True(t, b.onFault(1, "store1", now))
+	require.Equal(t, storeBreakerMinCooldown, b.suspended(1, now))
+}
@@ -74,6 +74,9 @@ func (h *regionEventHandler) Handle(span *subscribedSpan, events ...regionEvent)
 		}
 		if event.entries != nil {
 			handleEventEntries(span, event.state, event.entries)
+			if event.state.isStale() {
+				h.handleRegionError(event.state, event.worker)
+			}
 		} else if event.resolvedTs != 0 {
 			handleResolvedTs(span, event.state, event.resolvedTs)
 		} else if event.err != nil {
@@ -173,13 +176,14 @@ func handleEventEntries(span *subscribedSpan, state *regionFeedState, entries *c
 			log.Panic("meet unknown op type", zap.Any("entry", entry))
 		}
 		return common.RawKVEntry{
-			OpType:   opType,
-			Key:      entry.Key,
-			Value:    entry.GetValue(),
-			StartTs:  entry.StartTs,
-			CRTs:     entry.CommitTs,
-			RegionID: regionID,
-			OldValue: entry.GetOldValue(),
+			OpType:    opType,
+			Key:       entry.Key,
+			Value:     entry.GetValue(),
+			StartTs:   entry.StartTs,
+			CRTs:      entry.CommitTs,
+			RegionID:  regionID,
+			OldValue:  entry.GetOldValue(),
+			TxnSource: entry.GetTxnSource(),
 		}
 	}
 
@@ -209,6 +213,14 @@ func handleEventEntries(span *subscribedSpan, state *regionFeedState, entries *c
 			span.kvEventsCache = append(span.kvEventsCache, assembleRowEvent(regionID, entry))
 		case cdcpb.Event_PREWRITE:
 			state.matcher.putPrewriteRow(entry)
+			if state.matcher.exceeded() {
+				log.Warn("matcher memory exceeded, re-subscribe the region",
+					zap.Uint64("subscriptionID", uint64(span.subID)),
+					zap.Uint64("regionID", regionID),
+					zap.Int64("unmatchedSize", state.matcher.unmatchedSize))
+				state.markStopped(&matcherMemoryExceededErr{unmatchedSize: state.matcher.unmatchedSize})
+				return
+			}
 		case cdcpb.Event_COMMIT:
 			// NOTE: matchRow should always be called even if the event is stale.
 			if !state.matcher.matchRow(entry, state.isInitialized()) {
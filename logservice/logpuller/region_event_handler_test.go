@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/logpuller/regionlock"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/memory"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/stretchr/testify/require"
@@ -324,3 +325,46 @@ func TestHandleResolvedTs(t *testing.T) {
 	require.Equal(t, uint64(11), state2.getLastResolvedTs())
 	require.Equal(t, uint64(8), state3.getLastResolvedTs())
 }
+
+func TestHandleEventEntriesMatcherMemoryExceeded(t *testing.T) {
+	registry := memory.NewRegistry()
+	registry.SetLimits(0, 20)
+	account := registry.Register(memory.ComponentMatcher, memory.PriorityHigh)
+	origin := matcherMemory
+	matcherMemory = account
+	defer func() { matcherMemory = origin }()
+
+	subSpan := &subscribedSpan{subID: SubscriptionID(1), startTs: 1}
+	region := newRegionInfo(tikv.NewRegionVerID(1, 1, 1), heartbeatpb.TableSpan{}, &tikv.RPCContext{}, subSpan)
+	region.lockedRangeState = &regionlock.LockedRangeState{}
+	state := newRegionFeedState(region, 1)
+	state.start()
+
+	prewrite := func(startTs uint64, value string) *cdcpb.Event_Entries_ {
+		return &cdcpb.Event_Entries_{Entries: &cdcpb.Event_Entries{Entries: []*cdcpb.Event_Row{{
+			StartTs: startTs,
+			Type:    cdcpb.Event_PREWRITE,
+			OpType:  cdcpb.Event_Row_PUT,
+			Key:     []byte("key"),
+			Value:   []byte(value),
+		}}}}
+	}
+
+	// below the hard limit, the prewrite rows are buffered.
+	handleEventEntries(subSpan, state, prewrite(2, "value"))
+	require.False(t, state.isStale())
+	require.Equal(t, int64(8), account.Used())
+
+	// reach the hard limit, the region is stopped to be re-subscribed.
+	handleEventEntries(subSpan, state, prewrite(3, "large value"))
+	require.True(t, state.isStale())
+	err := state.takeError()
+	require.IsType(t, &matcherMemoryExceededErr{}, err)
+	require.Equal(t, regionErrorMatcherMemory, classifyRegionError(err))
+	require.False(t, retryPolicies[regionErrorMatcherMemory].fatal)
+
+	// removing the region releases the memory of its unmatched prewrite rows.
+	require.True(t, state.markRemoved())
+	require.Zero(t, account.Used())
+	require.False(t, account.Exceeded())
+}
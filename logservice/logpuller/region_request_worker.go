@@ -140,12 +140,12 @@ func (s *regionRequestWorker) run(ctx context.Context, credential *security.Cred
 		}
 	}
 
-	// FIXME: check tikv store version
-
+	compression := s.client.getStoreCompression(ctx, s.store.storeID)
 	log.Info("region request worker going to create grpc stream",
 		zap.Uint64("workerID", s.workerID),
 		zap.Uint64("storeID", s.store.storeID),
-		zap.String("addr", s.store.storeAddr))
+		zap.String("addr", s.store.storeAddr),
+		zap.String("compression", compression))
 
 	defer func() {
 		log.Info("region request worker exits",
@@ -156,7 +156,7 @@ func (s *regionRequestWorker) run(ctx context.Context, credential *security.Cred
 	}()
 
 	g, gctx := errgroup.WithContext(ctx)
-	conn, err := Connect(gctx, credential, s.store.storeAddr)
+	conn, err := Connect(gctx, credential, s.store.storeAddr, compression)
 	if err != nil {
 		log.Warn("region request worker create grpc stream failed",
 			zap.Uint64("workerID", s.workerID),
@@ -353,6 +353,10 @@ func (s *regionRequestWorker) processRegionSendTask(
 }
 
 func (s *regionRequestWorker) createRegionRequest(region regionInfo) *cdcpb.ChangeDataRequest {
+	extraOp := kvrpcpb.ExtraOp_ReadOldValue
+	if region.subscribedSpan.disableOldValue {
+		extraOp = kvrpcpb.ExtraOp_Noop
+	}
 	return &cdcpb.ChangeDataRequest{
 		Header:       &cdcpb.Header{ClusterId: s.client.clusterID, TicdcVersion: version.ReleaseSemver()},
 		RegionId:     region.verID.GetID(),
@@ -361,7 +365,7 @@ func (s *regionRequestWorker) createRegionRequest(region regionInfo) *cdcpb.Chan
 		CheckpointTs: region.resolvedTs(),
 		StartKey:     region.span.StartKey,
 		EndKey:       region.span.EndKey,
-		ExtraOp:      kvrpcpb.ExtraOp_ReadOldValue,
+		ExtraOp:      extraOp,
 		FilterLoop:   s.client.filterLoop,
 	}
 }
@@ -108,6 +108,10 @@ func newRegionFeedState(region regionInfo, requestID uint64) *regionFeedState {
 
 func (s *regionFeedState) start() {
 	s.matcher = newMatcher()
+	s.matcher.regionID = s.region.verID.GetID()
+	if s.region.subscribedSpan != nil {
+		s.matcher.subID = s.region.subscribedSpan.subID
+	}
 }
 
 // mark regionFeedState as stopped with the given error if possible.
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/logservice/txnutil"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/pdutil"
@@ -57,15 +58,6 @@ const (
 )
 
 var (
-	metricFeedNotLeaderCounter        = metrics.EventFeedErrorCounter.WithLabelValues("NotLeader")
-	metricFeedEpochNotMatchCounter    = metrics.EventFeedErrorCounter.WithLabelValues("EpochNotMatch")
-	metricFeedRegionNotFoundCounter   = metrics.EventFeedErrorCounter.WithLabelValues("RegionNotFound")
-	metricFeedDuplicateRequestCounter = metrics.EventFeedErrorCounter.WithLabelValues("DuplicateRequest")
-	metricFeedUnknownErrorCounter     = metrics.EventFeedErrorCounter.WithLabelValues("Unknown")
-	metricFeedRPCCtxUnavailable       = metrics.EventFeedErrorCounter.WithLabelValues("RPCCtxUnavailable")
-	metricStoreSendRequestErr         = metrics.EventFeedErrorCounter.WithLabelValues("SendRequestToStore")
-	metricKvIsBusyCounter             = metrics.EventFeedErrorCounter.WithLabelValues("KvIsBusy")
-
 	metricSubscriptionClientDSChannelSize     = metrics.DynamicStreamEventChanSize.WithLabelValues("event-store")
 	metricSubscriptionClientDSPendingQueueLen = metrics.DynamicStreamPendingQueueLen.WithLabelValues("event-store")
 	metricEventStoreDSAddPathNum              = metrics.DynamicStreamAddPathNum.WithLabelValues("event-store")
@@ -118,6 +110,9 @@ type subscribedSpan struct {
 
 	advanceInterval int64
 
+	// Whether the old values of the rows are not requested from TiKV.
+	disableOldValue bool
+
 	kvEventsCache []common.RawKVEntry
 
 	// To handle span removing.
@@ -130,6 +125,25 @@ type subscribedSpan struct {
 	lastAdvanceTime atomic.Int64
 	// This is used to calculate the resolvedTs lag for metrics.
 	resolvedTs atomic.Uint64
+
+	// To throttle the incremental scans when the downstream can't keep up.
+	// The range tasks of a paused span are held until it's resumed.
+	paused struct {
+		sync.Mutex
+		isPaused     bool
+		pendingTasks []rangeTask
+	}
+}
+
+// holdIfPaused keeps the range task if the span is paused, it returns false if the span is running.
+func (span *subscribedSpan) holdIfPaused(task rangeTask) bool {
+	span.paused.Lock()
+	defer span.paused.Unlock()
+	if !span.paused.isPaused {
+		return false
+	}
+	span.paused.pendingTasks = append(span.paused.pendingTasks, task)
+	return true
 }
 
 func (span *subscribedSpan) clearKVEventsCache() {
@@ -143,6 +157,8 @@ func (span *subscribedSpan) clearKVEventsCache() {
 type SubscriptionClientConfig struct {
 	// The number of region request workers to send region task for every tikv store
 	RegionRequestWorkerPerStore uint
+	// The compression of the grpc streams to TiKV, see config.KVClientConfig.GrpcCompression.
+	GrpcCompression string
 }
 
 type sharedClientMetrics struct {
@@ -192,6 +208,10 @@ type SubscriptionClient struct {
 	// errCh is used to receive region errors.
 	// The errors will be handled in `handleErrors` goroutine.
 	errCache *errCache
+	// retryTracker decides the backoff of the failed regions, it is only accessed in `handleErrors`.
+	retryTracker *regionRetryTracker
+	// storeBreaker suspends the region requests to the stores which keep failing.
+	storeBreaker *storeCircuitBreaker
 }
 
 // NewSubscriptionClient creates a client.
@@ -218,6 +238,8 @@ func NewSubscriptionClient(
 		regionCh:          make(chan regionInfo, 1024),
 		resolveLockTaskCh: make(chan resolveLockTask, 1024),
 		errCache:          newErrCache(),
+		retryTracker:      newRegionRetryTracker(),
+		storeBreaker:      newStoreCircuitBreaker(),
 	}
 	subClient.totalSpans.spanMap = make(map[SubscriptionID]*subscribedSpan)
 
@@ -263,6 +285,7 @@ func (s *SubscriptionClient) updateMetrics(ctx context.Context) error {
 			if resolvedTsLag > 0 {
 				metrics.LogPullerResolvedTsLag.Set(resolvedTsLag)
 			}
+			updateUnmatchedPrewriteMetrics(time.Now())
 		case <-ticker2.C:
 			dsMetrics := s.ds.GetMetrics()
 			metricSubscriptionClientDSChannelSize.Set(float64(dsMetrics.EventChanSize))
@@ -285,6 +308,7 @@ func (s *SubscriptionClient) Subscribe(
 	consumeKVEvents func(raw []common.RawKVEntry, wakeCallback func()) bool,
 	advanceResolvedTs func(ts uint64),
 	advanceInterval int64,
+	disableOldValue bool,
 ) {
 	if span.TableID == 0 {
 		log.Panic("subscription client subscribe with zero TableID")
@@ -292,7 +316,8 @@ func (s *SubscriptionClient) Subscribe(
 	}
 	log.Info("subscribes span",
 		zap.Uint64("subscriptionID", uint64(subID)),
-		zap.String("span", span.String()))
+		zap.String("span", span.String()),
+		zap.Bool("disableOldValue", disableOldValue))
 	defer func() {
 		log.Info("subscribes span done",
 			zap.Uint64("subscriptionID", uint64(subID)),
@@ -300,6 +325,7 @@ func (s *SubscriptionClient) Subscribe(
 	}()
 
 	rt := s.newSubscribedSpan(subID, span, startTs, consumeKVEvents, advanceResolvedTs, advanceInterval)
+	rt.disableOldValue = disableOldValue
 	s.totalSpans.Lock()
 	s.totalSpans.spanMap[subID] = rt
 	s.totalSpans.Unlock()
@@ -321,6 +347,13 @@ func (s *SubscriptionClient) Unsubscribe(subID SubscriptionID) {
 		return
 	}
 	s.ds.RemovePath(rt.subID)
+	rt.paused.Lock()
+	if rt.paused.isPaused {
+		rt.paused.isPaused = false
+		rt.paused.pendingTasks = nil
+		metrics.SubscriptionClientPausedSubscriptionGauge.Dec()
+	}
+	rt.paused.Unlock()
 	s.setTableStopped(rt)
 
 	log.Info("unsubscribe span success",
@@ -328,6 +361,56 @@ func (s *SubscriptionClient) Unsubscribe(subID SubscriptionID) {
 		zap.Bool("exists", rt != nil))
 }
 
+// Pause throttles the subscription at the source, no new region requests (and the incremental
+// scans with them) will be sent to TiKV for it until it's resumed. The regions which have been
+// subscribed keep pushing the changes.
+func (s *SubscriptionClient) Pause(subID SubscriptionID) {
+	s.totalSpans.RLock()
+	rt := s.totalSpans.spanMap[subID]
+	s.totalSpans.RUnlock()
+	if rt == nil {
+		return
+	}
+	rt.paused.Lock()
+	defer rt.paused.Unlock()
+	if !rt.paused.isPaused {
+		rt.paused.isPaused = true
+		metrics.SubscriptionClientPausedSubscriptionGauge.Inc()
+		log.Info("subscription paused", zap.Uint64("subscriptionID", uint64(subID)))
+	}
+}
+
+// Resume resumes the paused subscription, the held range tasks are scheduled again.
+func (s *SubscriptionClient) Resume(subID SubscriptionID) {
+	s.totalSpans.RLock()
+	rt := s.totalSpans.spanMap[subID]
+	s.totalSpans.RUnlock()
+	if rt == nil {
+		return
+	}
+	rt.paused.Lock()
+	if !rt.paused.isPaused {
+		rt.paused.Unlock()
+		return
+	}
+	rt.paused.isPaused = false
+	metrics.SubscriptionClientPausedSubscriptionGauge.Dec()
+	tasks := rt.paused.pendingTasks
+	rt.paused.pendingTasks = nil
+	rt.paused.Unlock()
+
+	log.Info("subscription resumed",
+		zap.Uint64("subscriptionID", uint64(subID)), zap.Int("pendingTasks", len(tasks)))
+	if len(tasks) > 0 {
+		// Note: don't block the caller, rangeTaskCh may be full
+		go func() {
+			for _, task := range tasks {
+				s.rangeTaskCh <- task
+			}
+		}()
+	}
+}
+
 func (s *SubscriptionClient) wakeSubscription(subID SubscriptionID) {
 	s.ds.Wake(subID)
 }
@@ -353,6 +436,17 @@ func (s *SubscriptionClient) RegionCount(subID SubscriptionID) uint64 {
 	return 0
 }
 
+// GetGCSafePoint returns the gc safe point of the upstream,
+// the incremental scan from a ts smaller than it fails.
+func (s *SubscriptionClient) GetGCSafePoint(ctx context.Context) (uint64, error) {
+	// the gc safe point never moves backward, so it's not changed by updating it to 0.
+	safePoint, err := s.pd.UpdateGCSafePoint(ctx, 0)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return safePoint, nil
+}
+
 func (s *SubscriptionClient) Run(ctx context.Context) error {
 	// s.consume = consume
 	if s.pd == nil {
@@ -412,6 +506,29 @@ func (s *SubscriptionClient) onRegionFail(errInfo regionErrorInfo) {
 	s.errCache.add(errInfo)
 }
 
+// getStoreCompression returns the compression of the grpc stream to the store,
+// according to the config and the version of the store.
+func (s *SubscriptionClient) getStoreCompression(ctx context.Context, storeID uint64) string {
+	compression := s.config.GrpcCompression
+	if compression == "" || compression == config.GrpcCompressionNone {
+		return config.GrpcCompressionNone
+	}
+	store, err := s.pd.GetStore(ctx, storeID)
+	if err != nil {
+		log.Warn("subscription client fails to get the store version, disable the grpc compression",
+			zap.Uint64("storeID", storeID), zap.Error(err))
+		return config.GrpcCompressionNone
+	}
+	negotiated := negotiateCompression(compression, store.GetVersion())
+	if negotiated != compression {
+		log.Info("the store doesn't support the grpc compression, disable it",
+			zap.Uint64("storeID", storeID),
+			zap.String("version", store.GetVersion()),
+			zap.String("compression", compression))
+	}
+	return negotiated
+}
+
 // requestedStore represents a store that has been connected.
 type requestedStore struct {
 	storeID   uint64
@@ -475,6 +592,23 @@ func (s *SubscriptionClient) handleRegions(ctx context.Context, eg *errgroup.Gro
 			if !ok {
 				continue
 			}
+			// Hold the region until the circuit of the store is closed, the rpc context
+			// is attached again then, as the leader may be transferred to another store.
+			if wait := s.storeBreaker.suspended(region.rpcCtx.Peer.StoreId, time.Now()); wait > 0 {
+				region := region
+				time.AfterFunc(wait, func() {
+					if region.subscribedSpan.stopped.Load() {
+						// the span is stopped while the region is held, release its locked range
+						s.onRegionFail(newRegionErrorInfo(region, &sendRequestToStoreErr{}))
+						return
+					}
+					select {
+					case s.regionCh <- region:
+					case <-ctx.Done():
+					}
+				})
+				continue
+			}
 
 			store := getStore(region.rpcCtx.Peer.StoreId, region.rpcCtx.Addr)
 			worker := store.getRequestWorker()
@@ -516,6 +650,9 @@ func (s *SubscriptionClient) handleRangeTasks(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case task := <-s.rangeTaskCh:
+			if task.subscribedSpan.holdIfPaused(task) {
+				continue
+			}
 			g.Go(func() error { return s.divideSpanAndScheduleRegionRequests(ctx, task.span, task.subscribedSpan) })
 		}
 	}
@@ -573,6 +710,14 @@ func (s *SubscriptionClient) divideSpanAndScheduleRegionRequests(
 		}
 
 		for _, regionMeta := range regionMetas {
+			// The span may be paused while its regions are being scheduled,
+			// the remaining part is held until the span is resumed.
+			if subscribedSpan.holdIfPaused(rangeTask{span: nextSpan, subscribedSpan: subscribedSpan}) {
+				log.Debug("subscription client hold the remaining span of a paused subscription",
+					zap.Uint64("subscriptionID", uint64(subscribedSpan.subID)),
+					zap.Any("span", nextSpan))
+				return nil
+			}
 			regionSpan := heartbeatpb.TableSpan{
 				StartKey: regionMeta.StartKey,
 				EndKey:   regionMeta.EndKey,
@@ -661,71 +806,74 @@ func (s *SubscriptionClient) doHandleError(ctx context.Context, errInfo regionEr
 		s.onTableDrained(errInfo.subscribedSpan)
 		return nil
 	}
+	if errInfo.subscribedSpan.stopped.Load() {
+		// the range is released, no need to retry the region of the stopped span
+		return nil
+	}
 
 	err := errors.Cause(errInfo.err)
-	switch eerr := err.(type) {
-	case *eventError:
-		innerErr := eerr.err
+	class := classifyRegionError(err)
+	policy := retryPolicies[class]
+	metricRegionErrorCounters[class].Inc()
+	if eerr, ok := err.(*eventError); ok {
 		log.Debug("cdc region error",
 			zap.Uint64("subscriptionID", uint64(errInfo.subscribedSpan.subID)),
-			zap.Stringer("error", innerErr))
-
-		if notLeader := innerErr.GetNotLeader(); notLeader != nil {
-			metricFeedNotLeaderCounter.Inc()
-			s.regionCache.UpdateLeader(errInfo.verID, notLeader.GetLeader(), errInfo.rpcCtx.AccessIdx)
-			s.scheduleRegionRequest(ctx, errInfo.regionInfo)
-			return nil
-		}
-		if innerErr.GetEpochNotMatch() != nil {
-			metricFeedEpochNotMatchCounter.Inc()
-			s.scheduleRangeRequest(ctx, errInfo.span, errInfo.subscribedSpan)
-			return nil
-		}
-		if innerErr.GetRegionNotFound() != nil {
-			metricFeedRegionNotFoundCounter.Inc()
-			s.scheduleRangeRequest(ctx, errInfo.span, errInfo.subscribedSpan)
-			return nil
-		}
-		if innerErr.GetServerIsBusy() != nil {
-			metricKvIsBusyCounter.Inc()
-			s.scheduleRegionRequest(ctx, errInfo.regionInfo)
-			return nil
-		}
-		if duplicated := innerErr.GetDuplicateRequest(); duplicated != nil {
-			// TODO(qupeng): It's better to add a new machanism to deregister one region.
-			metricFeedDuplicateRequestCounter.Inc()
-			return errors.New("duplicate request")
-		}
-		if compatibility := innerErr.GetCompatibility(); compatibility != nil {
-			return cerror.ErrVersionIncompatible.GenWithStackByArgs(compatibility)
-		}
-		if mismatch := innerErr.GetClusterIdMismatch(); mismatch != nil {
-			return cerror.ErrClusterIDMismatch.GenWithStackByArgs(mismatch.Current, mismatch.Request)
-		}
+			zap.Stringer("class", class),
+			zap.Stringer("error", eerr.err))
+	}
 
-		log.Warn("empty or unknown cdc error",
-			zap.Uint64("subscriptionID", uint64(errInfo.subscribedSpan.subID)),
-			zap.Stringer("error", innerErr))
-		metricFeedUnknownErrorCounter.Inc()
-		s.scheduleRegionRequest(ctx, errInfo.regionInfo)
-		return nil
-	case *rpcCtxUnavailableErr:
-		metricFeedRPCCtxUnavailable.Inc()
-		s.scheduleRangeRequest(ctx, errInfo.span, errInfo.subscribedSpan)
-		return nil
-	case *sendRequestToStoreErr:
-		metricStoreSendRequestErr.Inc()
+	switch class {
+	case regionErrorNotLeader:
+		notLeader := err.(*eventError).err.GetNotLeader()
+		s.regionCache.UpdateLeader(errInfo.verID, notLeader.GetLeader(), errInfo.rpcCtx.AccessIdx)
+	case regionErrorStoreUnreachable:
 		bo := tikv.NewBackoffer(ctx, tikvRequestMaxBackoff)
 		s.regionCache.OnSendFail(bo, errInfo.rpcCtx, regionScheduleReload, err)
-		s.scheduleRegionRequest(ctx, errInfo.regionInfo)
-		return nil
-	default:
+	case regionErrorDuplicateRequest:
+		// TODO(qupeng): It's better to add a new machanism to deregister one region.
+		return errors.New("duplicate request")
+	case regionErrorCompatibility:
+		return cerror.ErrVersionIncompatible.GenWithStackByArgs(err.(*eventError).err.GetCompatibility())
+	case regionErrorClusterIDMismatch:
+		mismatch := err.(*eventError).err.GetClusterIdMismatch()
+		return cerror.ErrClusterIDMismatch.GenWithStackByArgs(mismatch.Current, mismatch.Request)
+	case regionErrorUnknown:
+		log.Warn("empty or unknown cdc error",
+			zap.Uint64("subscriptionID", uint64(errInfo.subscribedSpan.subID)),
+			zap.Stringer("error", err.(*eventError).err))
+	}
+	if policy.fatal {
 		// TODO(qupeng): for some errors it's better to just deregister the region from TiKVs.
-		log.Warn("subscription client meets an internal error, fail the changefeed",
+		log.Warn("subscription client meets a fatal error, fail the changefeed",
 			zap.Uint64("subscriptionID", uint64(errInfo.subscribedSpan.subID)),
+			zap.Stringer("class", class),
 			zap.Error(err))
 		return err
 	}
+
+	now := time.Now()
+	if policy.storeFault && errInfo.rpcCtx != nil {
+		s.storeBreaker.onFault(errInfo.rpcCtx.Peer.StoreId, errInfo.rpcCtx.Addr, now)
+	}
+	backoff := policy.backoff(s.retryTracker.onFailure(errInfo.verID.GetID(), now))
+	metricRegionRetryBackoffes[class].Observe(backoff.Seconds())
+
+	retry := func() { s.scheduleRegionRequest(ctx, errInfo.regionInfo) }
+	if policy.action == retryRange {
+		retry = func() { s.scheduleRangeRequest(ctx, errInfo.span, errInfo.subscribedSpan) }
+	}
+	if backoff == 0 {
+		retry()
+	} else {
+		time.AfterFunc(backoff, func() {
+			// the span may be stopped during the backoff
+			if errInfo.subscribedSpan.stopped.Load() {
+				return
+			}
+			retry()
+		})
+	}
+	return nil
 }
 
 func (s *SubscriptionClient) handleResolveLockTasks(ctx context.Context) error {
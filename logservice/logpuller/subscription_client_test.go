@@ -97,6 +97,120 @@ func TestGenerateResolveLockTask(t *testing.T) {
 	close(client.resolveLockTaskCh)
 }
 
+func TestPauseAndResumeSubscription(t *testing.T) {
+	client := &SubscriptionClient{
+		rangeTaskCh: make(chan rangeTask, 10),
+	}
+	rawSpan := heartbeatpb.TableSpan{
+		TableID:  1,
+		StartKey: []byte{'a'},
+		EndKey:   []byte{'z'},
+	}
+	consumeKVEvents := func(_ []common.RawKVEntry, _ func()) bool { return false }
+	advanceResolvedTs := func(ts uint64) {}
+	span := client.newSubscribedSpan(SubscriptionID(1), rawSpan, 100, consumeKVEvents, advanceResolvedTs, 0)
+	client.totalSpans.spanMap = make(map[SubscriptionID]*subscribedSpan)
+	client.totalSpans.spanMap[SubscriptionID(1)] = span
+
+	task := rangeTask{span: rawSpan, subscribedSpan: span}
+	require.False(t, span.holdIfPaused(task))
+
+	// the range tasks are held when the subscription is paused
+	client.Pause(SubscriptionID(1))
+	require.True(t, span.holdIfPaused(task))
+	require.True(t, span.holdIfPaused(task))
+
+	// the held range tasks are scheduled again after resumed
+	client.Resume(SubscriptionID(1))
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-client.rangeTaskCh:
+			require.Equal(t, span, got.subscribedSpan)
+		case <-time.After(time.Second):
+			require.True(t, false, "must get the held range task")
+		}
+	}
+	require.False(t, span.holdIfPaused(task))
+
+	// pause or resume an unknown subscription is a no-op
+	client.Pause(SubscriptionID(2))
+	client.Resume(SubscriptionID(2))
+}
+
+func TestPauseInFlightRangeTask(t *testing.T) {
+	_, cluster, pdClient, _ := testutils.NewMockTiKV("", mockcopr.NewCoprRPCHandler())
+	pdClient = &mockPDClient{Client: pdClient, versionGen: defaultVersionGen}
+	regionCache := tikv.NewRegionCache(pdClient)
+	defer func() {
+		regionCache.Close()
+		pdClient.Close()
+	}()
+	cluster.AddStore(1, "localhost:1")
+	cluster.Bootstrap(11, []uint64{1}, []uint64{4}, 4)
+
+	client := &SubscriptionClient{
+		regionCache: regionCache,
+		regionCh:    make(chan regionInfo, 10),
+	}
+	rawSpan := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	consumeKVEvents := func(_ []common.RawKVEntry, _ func()) bool { return false }
+	span := client.newSubscribedSpan(SubscriptionID(1), rawSpan, 100, consumeKVEvents, func(uint64) {}, 0)
+	client.totalSpans.spanMap = map[SubscriptionID]*subscribedSpan{SubscriptionID(1): span}
+
+	// the subscription is paused after its range task is taken by handleRangeTasks,
+	// the regions of the range task are not scheduled.
+	client.Pause(SubscriptionID(1))
+	require.NoError(t, client.divideSpanAndScheduleRegionRequests(context.Background(), rawSpan, span))
+	require.Len(t, client.regionCh, 0)
+	require.Len(t, span.paused.pendingTasks, 1)
+	require.Equal(t, rawSpan, span.paused.pendingTasks[0].span)
+}
+
+func TestRetryStoppedSubscription(t *testing.T) {
+	client := &SubscriptionClient{
+		rangeTaskCh:  make(chan rangeTask, 10),
+		retryTracker: newRegionRetryTracker(),
+		storeBreaker: newStoreCircuitBreaker(),
+	}
+	rawSpan := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte{'a'}, EndKey: []byte{'z'}}
+	consumeKVEvents := func(_ []common.RawKVEntry, _ func()) bool { return false }
+	span := client.newSubscribedSpan(SubscriptionID(1), rawSpan, 100, consumeKVEvents, func(uint64) {}, 0)
+
+	newErrInfo := func(regionID uint64, start, end byte) regionErrorInfo {
+		res := span.rangeLock.LockRange(context.Background(), []byte{start}, []byte{end}, regionID, 1)
+		require.Equal(t, regionlock.LockRangeStatusSuccess, res.Status)
+		region := regionInfo{
+			verID:            tikv.NewRegionVerID(regionID, 1, 1),
+			span:             heartbeatpb.TableSpan{TableID: 1, StartKey: []byte{start}, EndKey: []byte{end}},
+			subscribedSpan:   span,
+			lockedRangeState: res.LockedRangeState,
+		}
+		// the range of the region is reloaded after a backoff
+		return newRegionErrorInfo(region, &rpcCtxUnavailableErr{verID: region.verID})
+	}
+	// keep a region locked, so the span is not drained after it's stopped
+	newErrInfo(1, 'a', 'b')
+
+	require.NoError(t, client.doHandleError(context.Background(), newErrInfo(2, 'b', 'c')))
+	select {
+	case task := <-client.rangeTaskCh:
+		require.Equal(t, []byte{'b'}, task.span.StartKey)
+	case <-time.After(5 * time.Second):
+		require.True(t, false, "must get the retried range task")
+	}
+
+	// the span is stopped during the backoff, the range is not retried
+	require.NoError(t, client.doHandleError(context.Background(), newErrInfo(3, 'c', 'd')))
+	span.stopped.Store(true)
+	// the region of the stopped span is not retried either
+	require.NoError(t, client.doHandleError(context.Background(), newErrInfo(4, 'd', 'e')))
+	select {
+	case <-client.rangeTaskCh:
+		require.True(t, false, "shouldn't retry the range of the stopped span")
+	case <-time.After(time.Second):
+	}
+}
+
 func TestSubscriptionWithFailedTiKV(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	wg := &sync.WaitGroup{}
@@ -170,7 +284,7 @@ func TestSubscriptionWithFailedTiKV(t *testing.T) {
 		case tsCh <- ts:
 		}
 	}
-	client.Subscribe(subID, span, 1, consumeKVEvents, advanceResolvedTs, 0)
+	client.Subscribe(subID, span, 1, consumeKVEvents, advanceResolvedTs, 0, false)
 
 	eventsCh1 <- mockInitializedEvent(11, uint64(subID))
 	targetTs := oracle.GoTimeToTS(pdClock.CurrentTime())
@@ -14,11 +14,18 @@
 package logpuller
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/kvproto/pkg/cdcpb"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/memory"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -30,6 +37,9 @@ const (
 var (
 	prewriteCacheRowNum = metrics.LogPullerPrewriteCacheRowNum
 	matcherCount        = metrics.LogPullerMatcherCount
+	// the prewrite rows can not be dropped before they are matched,
+	// so the matchers are only throttled when the hard limit is reached.
+	matcherMemory = memory.GetGlobalRegistry().Register(memory.ComponentMatcher, memory.PriorityHigh)
 )
 
 type matchKey struct {
@@ -41,18 +51,35 @@ func newMatchKey(row *cdcpb.Event_Row) matchKey {
 	return matchKey{startTs: row.GetStartTs(), key: string(row.GetKey())}
 }
 
+func rowSize(row *cdcpb.Event_Row) int64 {
+	return int64(len(row.GetKey()) + len(row.GetValue()) + len(row.GetOldValue()))
+}
+
 type matcher struct {
-	unmatchedValue   map[matchKey]*cdcpb.Event_Row
+	// The owner of the matcher, they are only used to dump the unmatched prewrites.
+	subID    SubscriptionID
+	regionID uint64
+
+	// mu protects the writes of unmatchedValue, as it is read by the debug dump.
+	mu             sync.Mutex
+	unmatchedValue map[matchKey]*cdcpb.Event_Row
+	// unmatchedSince is the unix nano time since which the region has unmatched
+	// prewrite rows, 0 means there is none. It is read by the metrics without lock.
+	unmatchedSince   atomic.Int64
 	cachedCommit     []*cdcpb.Event_Row
 	cachedRollback   []*cdcpb.Event_Row
 	lastPrewriteTime time.Time
+	// unmatchedSize is the bytes of the unmatched prewrite rows accounted to matcherMemory.
+	unmatchedSize int64
 }
 
 func newMatcher() *matcher {
 	matcherCount.Inc()
-	return &matcher{
+	m := &matcher{
 		unmatchedValue: make(map[matchKey]*cdcpb.Event_Row, prewriteCacheSize),
 	}
+	liveMatchers.add(m)
+	return m
 }
 
 func (m *matcher) putPrewriteRow(row *cdcpb.Event_Row) {
@@ -65,14 +92,25 @@ func (m *matcher) putPrewriteRow(row *cdcpb.Event_Row) {
 	// but the old value of the fake prewrite event is not empty.
 	// We can distinguish fake prewrite events by whether the value is empty,
 	// no matter the old-value is enabled or disabled
-	if _, exist := m.unmatchedValue[key]; exist && len(row.GetValue()) == 0 {
+	old, exist := m.unmatchedValue[key]
+	if exist && len(row.GetValue()) == 0 {
 		return
 	}
+	now := time.Now()
+	m.mu.Lock()
 	if m.unmatchedValue == nil {
 		m.unmatchedValue = make(map[matchKey]*cdcpb.Event_Row, prewriteCacheSize)
 	}
+	if exist {
+		m.releaseRow(old)
+	} else if len(m.unmatchedValue) == 0 {
+		m.unmatchedSince.Store(now.UnixNano())
+	}
 	m.unmatchedValue[key] = row
-	m.lastPrewriteTime = time.Now()
+	m.mu.Unlock()
+	m.unmatchedSize += rowSize(row)
+	matcherMemory.Consume(rowSize(row))
+	m.lastPrewriteTime = now
 	prewriteCacheRowNum.Inc()
 }
 
@@ -89,7 +127,8 @@ func (m *matcher) matchRow(row *cdcpb.Event_Row, initialized bool) bool {
 		}
 		row.Value = value.GetValue()
 		row.OldValue = value.GetOldValue()
-		delete(m.unmatchedValue, newMatchKey(row))
+		m.deleteUnmatched(newMatchKey(row))
+		m.releaseRow(value)
 		prewriteCacheRowNum.Dec()
 		return true
 	}
@@ -127,10 +166,34 @@ func (m *matcher) matchCachedRow(initialized bool) []*cdcpb.Event_Row {
 }
 
 func (m *matcher) rollbackRow(row *cdcpb.Event_Row) {
-	delete(m.unmatchedValue, newMatchKey(row))
+	key := newMatchKey(row)
+	if value, exist := m.unmatchedValue[key]; exist {
+		m.releaseRow(value)
+	}
+	m.deleteUnmatched(key)
 	prewriteCacheRowNum.Dec()
 }
 
+func (m *matcher) deleteUnmatched(key matchKey) {
+	m.mu.Lock()
+	delete(m.unmatchedValue, key)
+	m.mu.Unlock()
+	if len(m.unmatchedValue) == 0 {
+		m.unmatchedSince.Store(0)
+	}
+}
+
+// exceeded returns true if the unmatched prewrite rows reach the memory hard limit,
+// only the matchers holding unmatched rows are reset to release the memory.
+func (m *matcher) exceeded() bool {
+	return m.unmatchedSize > 0 && matcherMemory.Exceeded()
+}
+
+func (m *matcher) releaseRow(row *cdcpb.Event_Row) {
+	m.unmatchedSize -= rowSize(row)
+	matcherMemory.Release(rowSize(row))
+}
+
 func (m *matcher) cacheRollbackRow(row *cdcpb.Event_Row) {
 	m.cachedRollback = append(m.cachedRollback, row)
 }
@@ -161,16 +224,169 @@ func (m *matcher) tryCleanUnmatchedValue() {
 
 func (m *matcher) clearUnmatchedValue() {
 	m.lastPrewriteTime = time.Time{}
+	matcherMemory.Release(m.unmatchedSize)
+	m.unmatchedSize = 0
+	m.mu.Lock()
 	for k := range m.unmatchedValue {
 		delete(m.unmatchedValue, k)
 	}
 	m.unmatchedValue = nil
+	m.mu.Unlock()
+	m.unmatchedSince.Store(0)
 }
 
 func (m *matcher) clear() {
+	liveMatchers.remove(m)
 	matcherCount.Dec()
 	prewriteCacheRowNum.Sub(float64(len(m.unmatchedValue)))
 	m.clearUnmatchedValue()
 	m.cachedCommit = nil
 	m.cachedRollback = nil
 }
+
+// oldestUnmatchedTime returns the time since which the region has unmatched prewrite
+// rows, that is the receiving time of the oldest one if no row is matched in between.
+// It returns false if there is no unmatched prewrite row.
+func (m *matcher) oldestUnmatchedTime() (time.Time, bool) {
+	since := m.unmatchedSince.Load()
+	if since == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, since), true
+}
+
+// liveMatchers tracks all the matchers of the node, so the unmatched prewrites
+// can be inspected when the checkpoint is stuck by orphan transactions.
+// The matchers are added and removed by the regions concurrently, so a sync.Map
+// is used to avoid a node level lock.
+var liveMatchers = &matcherSet{}
+
+type matcherSet struct {
+	matchers sync.Map
+}
+
+func (s *matcherSet) add(m *matcher) {
+	s.matchers.Store(m, struct{}{})
+}
+
+func (s *matcherSet) remove(m *matcher) {
+	s.matchers.Delete(m)
+}
+
+func (s *matcherSet) contains(m *matcher) bool {
+	_, ok := s.matchers.Load(m)
+	return ok
+}
+
+func (s *matcherSet) forEach(fn func(m *matcher)) {
+	s.matchers.Range(func(key, _ any) bool {
+		fn(key.(*matcher))
+		return true
+	})
+}
+
+const (
+	// A region is counted by the long unmatched metric if its oldest unmatched
+	// prewrite row has been received for longer than the threshold.
+	longUnmatchedThreshold = 10 * time.Minute
+)
+
+// updateUnmatchedPrewriteMetrics updates the age of the oldest unmatched prewrite row
+// of all the regions, and the number of regions whose unmatched prewrites are too old.
+func updateUnmatchedPrewriteMetrics(now time.Time) {
+	var maxAge time.Duration
+	longUnmatched := 0
+	liveMatchers.forEach(func(m *matcher) {
+		oldest, ok := m.oldestUnmatchedTime()
+		if !ok {
+			return
+		}
+		age := now.Sub(oldest)
+		if age > maxAge {
+			maxAge = age
+		}
+		if age > longUnmatchedThreshold {
+			longUnmatched++
+		}
+	})
+	metrics.LogPullerOldestUnmatchedPrewriteAge.Set(maxAge.Seconds())
+	metrics.LogPullerLongUnmatchedRegionCount.Set(float64(longUnmatched))
+}
+
+// UnmatchedPrewrite is a prewrite row which hasn't been matched by its commit or rollback.
+type UnmatchedPrewrite struct {
+	// KeyHash is the hex encoded sha256 of the key, the key itself is not exposed.
+	KeyHash string
+	StartTs uint64
+	Size    int64
+	// Age is the age of the transaction, it is calculated from the startTs.
+	Age time.Duration
+}
+
+// RegionUnmatchedPrewrites is the unmatched prewrite rows of a region.
+type RegionUnmatchedPrewrites struct {
+	SubscriptionID SubscriptionID
+	RegionID       uint64
+	// OldestAge is the duration since which the region has unmatched prewrite rows.
+	OldestAge time.Duration
+	Count     int
+	Size      int64
+	// The unmatched prewrite rows ordered by startTs, the oldest one comes first.
+	Prewrites []UnmatchedPrewrite
+}
+
+// DumpUnmatchedPrewrites returns the regions of the node whose oldest unmatched prewrite
+// row is older than minAge, the oldest region comes first. At most limit prewrite rows
+// are dumped for each region, 0 means no limit.
+func DumpUnmatchedPrewrites(minAge time.Duration, limit int) []RegionUnmatchedPrewrites {
+	now := time.Now()
+	res := make([]RegionUnmatchedPrewrites, 0)
+	liveMatchers.forEach(func(m *matcher) {
+		if dump, ok := m.dumpUnmatched(now, minAge, limit); ok {
+			res = append(res, dump)
+		}
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].OldestAge > res[j].OldestAge
+	})
+	return res
+}
+
+func (m *matcher) dumpUnmatched(now time.Time, minAge time.Duration, limit int) (RegionUnmatchedPrewrites, bool) {
+	dump := RegionUnmatchedPrewrites{
+		SubscriptionID: m.subID,
+		RegionID:       m.regionID,
+	}
+	oldest, ok := m.oldestUnmatchedTime()
+	if !ok || now.Sub(oldest) < minAge {
+		return dump, false
+	}
+	dump.OldestAge = now.Sub(oldest)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dump.Count = len(m.unmatchedValue)
+	prewrites := make([]UnmatchedPrewrite, 0, len(m.unmatchedValue))
+	for key, row := range m.unmatchedValue {
+		size := rowSize(row)
+		dump.Size += size
+		hash := sha256.Sum256([]byte(key.key))
+		prewrites = append(prewrites, UnmatchedPrewrite{
+			KeyHash: hex.EncodeToString(hash[:]),
+			StartTs: key.startTs,
+			Size:    size,
+			Age:     now.Sub(oracle.GetTimeFromTS(key.startTs)),
+		})
+	}
+	if len(prewrites) == 0 {
+		return dump, false
+	}
+	sort.Slice(prewrites, func(i, j int) bool {
+		return prewrites[i].StartTs < prewrites[j].StartTs
+	})
+	if limit > 0 && len(prewrites) > limit {
+		prewrites = prewrites[:limit]
+	}
+	dump.Prewrites = prewrites
+	return dump, true
+}
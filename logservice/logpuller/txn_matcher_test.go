@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/kvproto/pkg/cdcpb"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 func TestMatchRow(t *testing.T) {
@@ -356,3 +357,58 @@ func TestMatcher_TryCleanUnmatchedValue(t *testing.T) {
 		})
 	}
 }
+
+func TestDumpUnmatchedPrewrites(t *testing.T) {
+	t.Parallel()
+	m := newMatcher()
+	m.subID, m.regionID = 1, 2
+	_, ok := m.oldestUnmatchedTime()
+	require.False(t, ok)
+
+	ts1 := oracle.GoTimeToTS(time.Now().Add(-time.Hour))
+	ts2 := oracle.GoTimeToTS(time.Now().Add(-time.Minute))
+	m.putPrewriteRow(&cdcpb.Event_Row{StartTs: ts2, Key: []byte("k2"), Value: []byte("value2")})
+	oldest, ok := m.oldestUnmatchedTime()
+	require.True(t, ok)
+	m.putPrewriteRow(&cdcpb.Event_Row{StartTs: ts1, Key: []byte("k1"), Value: []byte("v1")})
+	// overwriting the prewrite doesn't change the age
+	m.putPrewriteRow(&cdcpb.Event_Row{StartTs: ts1, Key: []byte("k1"), Value: []byte("value1")})
+	since, ok := m.oldestUnmatchedTime()
+	require.True(t, ok)
+	require.Equal(t, oldest, since)
+
+	now := oldest.Add(time.Minute)
+	_, ok = m.dumpUnmatched(now, 2*time.Minute, 0)
+	require.False(t, ok)
+	dump, ok := m.dumpUnmatched(now, time.Minute, 1)
+	require.True(t, ok)
+	require.Equal(t, SubscriptionID(1), dump.SubscriptionID)
+	require.Equal(t, uint64(2), dump.RegionID)
+	require.Equal(t, 2, dump.Count)
+	require.Equal(t, int64(16), dump.Size)
+	require.Equal(t, time.Minute, dump.OldestAge)
+	// the prewrite rows are ordered by startTs
+	require.Len(t, dump.Prewrites, 1)
+	require.Equal(t, ts1, dump.Prewrites[0].StartTs)
+	require.Equal(t, int64(8), dump.Prewrites[0].Size)
+	require.Equal(t, now.Sub(oracle.GetTimeFromTS(ts1)), dump.Prewrites[0].Age)
+	require.Len(t, dump.Prewrites[0].KeyHash, 64)
+
+	// the region keeps unmatched since the first prewrite until all rows are matched.
+	require.True(t, m.matchRow(&cdcpb.Event_Row{StartTs: ts1, Key: []byte("k1")}, true))
+	dump, ok = m.dumpUnmatched(now, 0, 0)
+	require.True(t, ok)
+	require.Equal(t, 1, dump.Count)
+	require.Equal(t, ts2, dump.Prewrites[0].StartTs)
+	require.Equal(t, time.Minute, dump.OldestAge)
+
+	require.True(t, m.matchRow(&cdcpb.Event_Row{StartTs: ts2, Key: []byte("k2")}, true))
+	_, ok = m.oldestUnmatchedTime()
+	require.False(t, ok)
+	_, ok = m.dumpUnmatched(now, 0, 0)
+	require.False(t, ok)
+
+	require.True(t, liveMatchers.contains(m))
+	m.clear()
+	require.False(t, liveMatchers.contains(m))
+}
@@ -291,6 +291,210 @@ func (m *ReusableEventServiceResponse) GetNodes() []string {
 	return nil
 }
 
+type SubscribeRequest struct {
+	SubscriberID string                 `protobuf:"bytes,1,opt,name=SubscriberID,proto3" json:"SubscriberID,omitempty"`
+	Span         *heartbeatpb.TableSpan `protobuf:"bytes,2,opt,name=Span,proto3" json:"Span,omitempty"`
+	StartTs      uint64                 `protobuf:"varint,3,opt,name=StartTs,proto3" json:"StartTs,omitempty"`
+	AckTs        uint64                 `protobuf:"varint,4,opt,name=AckTs,proto3" json:"AckTs,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{5}
+}
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetSubscriberID() string {
+	if m != nil {
+		return m.SubscriberID
+	}
+	return ""
+}
+
+func (m *SubscribeRequest) GetSpan() *heartbeatpb.TableSpan {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetStartTs() uint64 {
+	if m != nil {
+		return m.StartTs
+	}
+	return 0
+}
+
+func (m *SubscribeRequest) GetAckTs() uint64 {
+	if m != nil {
+		return m.AckTs
+	}
+	return 0
+}
+
+type SubscribedEvent struct {
+	OpType   uint32 `protobuf:"varint,1,opt,name=OpType,proto3" json:"OpType,omitempty"`
+	Key      []byte `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value    []byte `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+	OldValue []byte `protobuf:"bytes,4,opt,name=OldValue,proto3" json:"OldValue,omitempty"`
+	StartTs  uint64 `protobuf:"varint,5,opt,name=StartTs,proto3" json:"StartTs,omitempty"`
+	CommitTs uint64 `protobuf:"varint,6,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
+}
+
+func (m *SubscribedEvent) Reset()         { *m = SubscribedEvent{} }
+func (m *SubscribedEvent) String() string { return proto.CompactTextString(m) }
+func (*SubscribedEvent) ProtoMessage()    {}
+func (*SubscribedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{6}
+}
+func (m *SubscribedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribedEvent.Merge(m, src)
+}
+func (m *SubscribedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribedEvent proto.InternalMessageInfo
+
+func (m *SubscribedEvent) GetOpType() uint32 {
+	if m != nil {
+		return m.OpType
+	}
+	return 0
+}
+
+func (m *SubscribedEvent) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SubscribedEvent) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SubscribedEvent) GetOldValue() []byte {
+	if m != nil {
+		return m.OldValue
+	}
+	return nil
+}
+
+func (m *SubscribedEvent) GetStartTs() uint64 {
+	if m != nil {
+		return m.StartTs
+	}
+	return 0
+}
+
+func (m *SubscribedEvent) GetCommitTs() uint64 {
+	if m != nil {
+		return m.CommitTs
+	}
+	return 0
+}
+
+type SubscribeResponse struct {
+	Events     []*SubscribedEvent `protobuf:"bytes,1,rep,name=Events,proto3" json:"Events,omitempty"`
+	ResolvedTs uint64             `protobuf:"varint,2,opt,name=ResolvedTs,proto3" json:"ResolvedTs,omitempty"`
+}
+
+func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
+func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeResponse) ProtoMessage()    {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{7}
+}
+func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeResponse.Merge(m, src)
+}
+func (m *SubscribeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+
+func (m *SubscribeResponse) GetEvents() []*SubscribedEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *SubscribeResponse) GetResolvedTs() uint64 {
+	if m != nil {
+		return m.ResolvedTs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*SubscriptionState)(nil), "logservicepb.SubscriptionState")
 	proto.RegisterType((*SubscriptionStates)(nil), "logservicepb.SubscriptionStates")
@@ -298,6 +502,9 @@ func init() {
 	proto.RegisterMapType((map[int64]*SubscriptionStates)(nil), "logservicepb.EventStoreState.SubscriptionsEntry")
 	proto.RegisterType((*ReusableEventServiceRequest)(nil), "logservicepb.ReusableEventServiceRequest")
 	proto.RegisterType((*ReusableEventServiceResponse)(nil), "logservicepb.ReusableEventServiceResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "logservicepb.SubscribeRequest")
+	proto.RegisterType((*SubscribedEvent)(nil), "logservicepb.SubscribedEvent")
+	proto.RegisterType((*SubscribeResponse)(nil), "logservicepb.SubscribeResponse")
 }
 
 func init() {
@@ -305,34 +512,44 @@ func init() {
 }
 
 var fileDescriptor_a1db670929506a40 = []byte{
-	// 432 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x8b, 0x13, 0x31,
-	0x14, 0x6e, 0x66, 0x5a, 0xc5, 0xd7, 0x15, 0x35, 0x2c, 0x32, 0xee, 0xca, 0x38, 0x0c, 0x08, 0xa3,
-	0x87, 0xa9, 0x54, 0x10, 0xf1, 0x22, 0x68, 0x7b, 0xd8, 0x8b, 0x87, 0x4c, 0xf1, 0xa0, 0x07, 0x49,
-	0xa6, 0x8f, 0x36, 0x6c, 0x9d, 0xc4, 0x24, 0x53, 0xd8, 0x3f, 0x21, 0x5e, 0xfd, 0x47, 0x5e, 0x84,
-	0x3d, 0x7a, 0x94, 0xf6, 0x8f, 0x48, 0x27, 0xab, 0x3b, 0xb3, 0xbb, 0x20, 0x7b, 0x7b, 0xef, 0xe5,
-	0x7b, 0x5f, 0xbe, 0x2f, 0x1f, 0x81, 0x6c, 0xa5, 0x16, 0x16, 0xcd, 0x5a, 0x96, 0x38, 0x3a, 0x2f,
-	0xb5, 0x68, 0x35, 0xb9, 0x36, 0xca, 0x29, 0xba, 0xd7, 0x3e, 0x3e, 0x38, 0x5c, 0x22, 0x37, 0x4e,
-	0x20, 0x77, 0x5a, 0x8c, 0xfe, 0xd5, 0x1e, 0x9a, 0x7e, 0x27, 0x70, 0xaf, 0xa8, 0x85, 0x2d, 0x8d,
-	0xd4, 0x4e, 0xaa, 0xaa, 0x70, 0xdc, 0x21, 0xdd, 0x87, 0x41, 0x51, 0x8b, 0xa3, 0x49, 0x44, 0x12,
-	0x92, 0xf5, 0x99, 0x6f, 0xe8, 0x53, 0xe8, 0x17, 0x9a, 0x57, 0x51, 0x90, 0x90, 0x6c, 0x38, 0xbe,
-	0x9f, 0xb7, 0x78, 0xf3, 0x19, 0x17, 0x2b, 0xdc, 0x9d, 0xb2, 0x06, 0x43, 0x53, 0xd8, 0x7b, 0xbb,
-	0xc4, 0xf2, 0x58, 0x2b, 0x59, 0xb9, 0x99, 0x8d, 0xc2, 0x86, 0xa8, 0x33, 0xa3, 0x31, 0x00, 0x43,
-	0xab, 0x56, 0x6b, 0x9c, 0xcf, 0x6c, 0xd4, 0x6f, 0x10, 0xad, 0x49, 0xfa, 0x11, 0xe8, 0x25, 0x69,
-	0x96, 0x4e, 0xe1, 0x76, 0x7b, 0x6a, 0x23, 0x92, 0x84, 0xd9, 0x70, 0xfc, 0x28, 0x6f, 0x9b, 0xce,
-	0x2f, 0x2d, 0xb2, 0xee, 0x56, 0xfa, 0x93, 0xc0, 0x9d, 0xe9, 0x1a, 0x2b, 0x57, 0x38, 0x65, 0xd0,
-	0xdb, 0x7e, 0x7f, 0x35, 0xf5, 0xb3, 0x2e, 0xf5, 0x85, 0xad, 0xce, 0x55, 0x76, 0x5a, 0x39, 0x73,
-	0x72, 0xe1, 0xae, 0x03, 0xd1, 0x35, 0xe2, 0x41, 0xf4, 0x2e, 0x84, 0xc7, 0x78, 0xd2, 0x3c, 0x71,
-	0xc8, 0x76, 0x25, 0x7d, 0x01, 0x83, 0x35, 0x5f, 0xd5, 0x78, 0xf6, 0xc2, 0xc9, 0x7f, 0x2c, 0x59,
-	0xe6, 0xe1, 0xaf, 0x82, 0x97, 0x24, 0xfd, 0x4a, 0xe0, 0x90, 0x61, 0x6d, 0x77, 0x39, 0x78, 0x85,
-	0x7e, 0x91, 0xe1, 0x97, 0x1a, 0xad, 0xa3, 0x4f, 0x20, 0x38, 0xcb, 0x73, 0x38, 0x7e, 0xd0, 0x89,
-	0x6e, 0x22, 0xad, 0xe6, 0xae, 0x5c, 0xa2, 0x39, 0x9a, 0xb0, 0xe0, 0x9a, 0x39, 0x47, 0x70, 0xb3,
-	0x70, 0xdc, 0x9c, 0x47, 0xfc, 0xb7, 0x4d, 0x3f, 0xc1, 0xc3, 0xab, 0xf5, 0x58, 0xad, 0x2a, 0x8b,
-	0xd7, 0x11, 0xb4, 0x0f, 0x83, 0x77, 0x6a, 0x8e, 0x36, 0x0a, 0x92, 0x30, 0xbb, 0xc5, 0x7c, 0xf3,
-	0xe6, 0xf5, 0x8f, 0x4d, 0x4c, 0x4e, 0x37, 0x31, 0xf9, 0xbd, 0x89, 0xc9, 0xb7, 0x6d, 0xdc, 0x3b,
-	0xdd, 0xc6, 0xbd, 0x5f, 0xdb, 0xb8, 0xf7, 0xe1, 0xf1, 0x42, 0xba, 0x65, 0x2d, 0xf2, 0x52, 0x7d,
-	0x1e, 0x69, 0x59, 0x2d, 0x4a, 0xae, 0x47, 0x4e, 0x96, 0xf3, 0xb2, 0xf3, 0x6f, 0xc4, 0x8d, 0xe6,
-	0x0b, 0x3c, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0xf6, 0x53, 0xc3, 0x3e, 0x59, 0x03, 0x00, 0x00,
+	// 592 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6b, 0xd4, 0x40,
+	0x18, 0xee, 0x24, 0xbb, 0x6b, 0xfb, 0x76, 0x4b, 0xdb, 0xb1, 0x94, 0xb8, 0xd5, 0x74, 0x09, 0x08,
+	0xd1, 0x43, 0xb6, 0xac, 0x28, 0xe2, 0x45, 0xb4, 0xed, 0xa1, 0x08, 0x56, 0x26, 0x4b, 0x0f, 0x7a,
+	0x90, 0x24, 0x3b, 0xec, 0xc6, 0x6e, 0x93, 0x31, 0x33, 0x59, 0xd8, 0x3f, 0x21, 0xe2, 0xcd, 0xb3,
+	0x7f, 0xc6, 0x8b, 0xd0, 0xa3, 0x47, 0xe9, 0xfe, 0x11, 0xc9, 0x4c, 0x36, 0x4d, 0x76, 0xb7, 0x48,
+	0xc1, 0xdb, 0x3c, 0xef, 0xc7, 0xf3, 0x3e, 0xef, 0x47, 0x02, 0xf6, 0x28, 0x1e, 0x70, 0x9a, 0x8c,
+	0xc3, 0x80, 0x76, 0xae, 0x9f, 0xcc, 0x2f, 0x01, 0x87, 0x25, 0xb1, 0x88, 0x71, 0xb3, 0xec, 0x6e,
+	0xed, 0x0d, 0xa9, 0x97, 0x08, 0x9f, 0x7a, 0x82, 0xf9, 0x9d, 0xe2, 0xad, 0x42, 0xad, 0xef, 0x08,
+	0xb6, 0xdd, 0xd4, 0xe7, 0x41, 0x12, 0x32, 0x11, 0xc6, 0x91, 0x2b, 0x3c, 0x41, 0xf1, 0x0e, 0xd4,
+	0xdd, 0xd4, 0x3f, 0x39, 0x32, 0x50, 0x1b, 0xd9, 0x35, 0xa2, 0x00, 0x7e, 0x0c, 0x35, 0x97, 0x79,
+	0x91, 0xa1, 0xb5, 0x91, 0xbd, 0xde, 0xdd, 0x75, 0x4a, 0xbc, 0x4e, 0xcf, 0xf3, 0x47, 0x34, 0xf3,
+	0x12, 0x19, 0x83, 0x2d, 0x68, 0x1e, 0x0e, 0x69, 0x70, 0xce, 0xe2, 0x30, 0x12, 0x3d, 0x6e, 0xe8,
+	0x92, 0xa8, 0x62, 0xc3, 0x26, 0x00, 0xa1, 0x3c, 0x1e, 0x8d, 0x69, 0xbf, 0xc7, 0x8d, 0x9a, 0x8c,
+	0x28, 0x59, 0xac, 0x0f, 0x80, 0x17, 0xa4, 0x71, 0x7c, 0x0c, 0x1b, 0x65, 0x2b, 0x37, 0x50, 0x5b,
+	0xb7, 0xd7, 0xbb, 0xfb, 0x4e, 0xb9, 0x69, 0x67, 0x21, 0x91, 0x54, 0xb3, 0xac, 0x5f, 0x08, 0x36,
+	0x8f, 0xc7, 0x34, 0x12, 0xae, 0x88, 0x13, 0xaa, 0xda, 0x3e, 0x5b, 0x4e, 0x7d, 0x50, 0xa5, 0x9e,
+	0xcb, 0xaa, 0x94, 0xe2, 0xc7, 0x91, 0x48, 0x26, 0x73, 0xb5, 0x5a, 0x3e, 0xe0, 0xc5, 0x20, 0xbc,
+	0x05, 0xfa, 0x39, 0x9d, 0xc8, 0x11, 0xeb, 0x24, 0x7b, 0xe2, 0x67, 0x50, 0x1f, 0x7b, 0xa3, 0x94,
+	0xe6, 0x13, 0x6e, 0xff, 0xa3, 0x25, 0x4e, 0x54, 0xf8, 0x0b, 0xed, 0x39, 0xb2, 0xbe, 0x20, 0xd8,
+	0x23, 0x34, 0xe5, 0xd9, 0x1e, 0x94, 0x42, 0x95, 0x48, 0xe8, 0xe7, 0x94, 0x72, 0x81, 0x1f, 0x81,
+	0x96, 0xef, 0x73, 0xbd, 0x7b, 0xaf, 0xb2, 0xba, 0xa3, 0x90, 0x33, 0x4f, 0x04, 0x43, 0x9a, 0x9c,
+	0x1c, 0x11, 0xed, 0x96, 0x7b, 0x36, 0xe0, 0x8e, 0x2b, 0xbc, 0xe4, 0x7a, 0xc5, 0x33, 0x68, 0x7d,
+	0x84, 0xfb, 0xcb, 0xf5, 0x70, 0x16, 0x47, 0x9c, 0xde, 0x46, 0xd0, 0x0e, 0xd4, 0xdf, 0xc6, 0x7d,
+	0xca, 0x0d, 0xad, 0xad, 0xdb, 0x6b, 0x44, 0x01, 0xeb, 0x1b, 0x82, 0xad, 0x7c, 0x26, 0x7e, 0xd1,
+	0xa6, 0x05, 0xcd, 0xc2, 0x96, 0xe4, 0xfc, 0x6b, 0xa4, 0x62, 0xfb, 0x3f, 0xfd, 0x65, 0xa2, 0x5e,
+	0x05, 0xe7, 0xc5, 0xe1, 0x2a, 0x60, 0xfd, 0x40, 0xb0, 0x59, 0x14, 0xeb, 0xcb, 0xc6, 0xf1, 0x2e,
+	0x34, 0x4e, 0x59, 0x6f, 0xc2, 0xa8, 0x54, 0xb3, 0x41, 0x72, 0x94, 0x1d, 0xc0, 0x1b, 0x3a, 0x91,
+	0x32, 0x9a, 0x24, 0x7b, 0x66, 0x9c, 0x67, 0xf2, 0x00, 0x74, 0x69, 0x53, 0x00, 0xb7, 0x60, 0xf5,
+	0x74, 0xd4, 0x57, 0x8e, 0x9a, 0x74, 0x14, 0xb8, 0xac, 0xaf, 0x5e, 0xd5, 0xd7, 0x82, 0xd5, 0xc3,
+	0xf8, 0xe2, 0x22, 0xcc, 0x5c, 0x0d, 0xe9, 0x2a, 0xb0, 0xf5, 0x09, 0xb6, 0x4b, 0x93, 0xcb, 0x17,
+	0xf2, 0x14, 0x1a, 0x52, 0xef, 0xec, 0xec, 0x1f, 0x2c, 0x3d, 0xbf, 0x59, 0x57, 0x24, 0x0f, 0x9e,
+	0xfb, 0x8a, 0xb5, 0xf9, 0xaf, 0xb8, 0x3b, 0x80, 0xbb, 0x95, 0xcb, 0x55, 0x84, 0xf8, 0x1d, 0xac,
+	0x15, 0x8c, 0xd8, 0xbc, 0xa1, 0x54, 0xbe, 0xd5, 0xd6, 0xfe, 0x8d, 0x7e, 0xa5, 0xdd, 0x46, 0x07,
+	0xe8, 0xf5, 0xcb, 0x9f, 0x57, 0x26, 0xba, 0xbc, 0x32, 0xd1, 0x9f, 0x2b, 0x13, 0x7d, 0x9d, 0x9a,
+	0x2b, 0x97, 0x53, 0x73, 0xe5, 0xf7, 0xd4, 0x5c, 0x79, 0xff, 0x70, 0x10, 0x8a, 0x61, 0xea, 0x3b,
+	0x41, 0x7c, 0xd1, 0x61, 0x61, 0x34, 0x08, 0x3c, 0xd6, 0x11, 0x61, 0xd0, 0x0f, 0x2a, 0xff, 0x51,
+	0xbf, 0x21, 0x7f, 0x89, 0x4f, 0xfe, 0x0e, 0x00, 0x23, 0x72, 0x58, 0xaf, 0x69, 0x05, 0x00, 0x00,
 }
 
 func (m *SubscriptionState) Marshal() (dAtA []byte, err error) {
@@ -565,6 +782,159 @@ func (m *ReusableEventServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	return len(dAtA) - i, nil
 }
 
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AckTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.AckTs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.StartTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.StartTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Span != nil {
+		{
+			size, err := m.Span.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SubscriberID) > 0 {
+		i -= len(m.SubscriberID)
+		copy(dAtA[i:], m.SubscriberID)
+		i = encodeVarintLogservice(dAtA, i, uint64(len(m.SubscriberID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CommitTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.CommitTs))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.StartTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.StartTs))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.OldValue) > 0 {
+		i -= len(m.OldValue)
+		copy(dAtA[i:], m.OldValue)
+		i = encodeVarintLogservice(dAtA, i, uint64(len(m.OldValue)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintLogservice(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLogservice(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.OpType != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.OpType))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ResolvedTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.ResolvedTs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogservice(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintLogservice(dAtA []byte, offset int, v uint64) int {
 	offset -= sovLogservice(v)
 	base := offset
@@ -674,21 +1044,92 @@ func (m *ReusableEventServiceResponse) Size() (n int) {
 	return n
 }
 
-func sovLogservice(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozLogservice(x uint64) (n int) {
-	return sovLogservice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *SubscriptionState) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowLogservice
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SubscriberID)
+	if l > 0 {
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.Span != nil {
+		l = m.Span.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.StartTs != 0 {
+		n += 1 + sovLogservice(uint64(m.StartTs))
+	}
+	if m.AckTs != 0 {
+		n += 1 + sovLogservice(uint64(m.AckTs))
+	}
+	return n
+}
+
+func (m *SubscribedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OpType != 0 {
+		n += 1 + sovLogservice(uint64(m.OpType))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	l = len(m.OldValue)
+	if l > 0 {
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.StartTs != 0 {
+		n += 1 + sovLogservice(uint64(m.StartTs))
+	}
+	if m.CommitTs != 0 {
+		n += 1 + sovLogservice(uint64(m.CommitTs))
+	}
+	return n
+}
+
+func (m *SubscribeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovLogservice(uint64(l))
+		}
+	}
+	if m.ResolvedTs != 0 {
+		n += 1 + sovLogservice(uint64(m.ResolvedTs))
+	}
+	return n
+}
+
+func sovLogservice(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLogservice(x uint64) (n int) {
+	return sovLogservice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SubscriptionState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
 			}
 			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
@@ -1331,6 +1772,474 @@ func (m *ReusableEventServiceResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubscriberID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SubscriberID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &heartbeatpb.TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+			}
+			m.StartTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckTs", wireType)
+			}
+			m.AckTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AckTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpType", wireType)
+			}
+			m.OpType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OpType |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldValue = append(m.OldValue[:0], dAtA[iNdEx:postIndex]...)
+			if m.OldValue == nil {
+				m.OldValue = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+			}
+			m.StartTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+			}
+			m.CommitTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &SubscribedEvent{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolvedTs", wireType)
+			}
+			m.ResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipLogservice(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
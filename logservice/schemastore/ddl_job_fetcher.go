@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tablecodec"
 	"go.uber.org/zap"
 )
 
@@ -50,6 +51,9 @@ type ddlJobFetcher struct {
 	advanceResolvedTs func(resolvedTS uint64)
 
 	kvStorage kv.Storage
+	// importJobTableInfo is used to parse the finished IMPORT INTO jobs,
+	// it's nil if the upstream doesn't have the table `tidb_import_jobs`.
+	importJobTableInfo *event.ImportJobTableInfo
 }
 
 func newDDLJobFetcher(
@@ -67,7 +71,16 @@ func newDDLJobFetcher(
 	ddlJobFetcher.resolvedTsTracker.resolvedTsItemMap = make(map[logpuller.SubscriptionID]*resolvedTsItem)
 	ddlJobFetcher.resolvedTsTracker.resolvedTsHeap = heap.NewHeap[*resolvedTsItem]()
 
-	for _, span := range getAllDDLSpan() {
+	spans := getAllDDLSpan()
+	importJobTableInfo, err := getImportJobTableInfo(kvStorage)
+	if err != nil {
+		log.Fatal("get import job table info failed", zap.Error(err))
+	}
+	if importJobTableInfo != nil {
+		ddlJobFetcher.importJobTableInfo = importJobTableInfo
+		spans = append(spans, getImportJobSpan(importJobTableInfo.ImportJobTable.TableName.TableID))
+	}
+	for _, span := range spans {
 		subID := subClient.AllocSubscriptionID()
 		item := &resolvedTsItem{
 			resolvedTs: 0,
@@ -77,7 +90,7 @@ func newDDLJobFetcher(
 		advanceSubSpanResolvedTs := func(ts uint64) {
 			ddlJobFetcher.tryAdvanceResolvedTs(subID, ts)
 		}
-		subClient.Subscribe(subID, span, startTs, ddlJobFetcher.input, advanceSubSpanResolvedTs, 0)
+		subClient.Subscribe(subID, span, startTs, ddlJobFetcher.input, advanceSubSpanResolvedTs, 0, false)
 	}
 
 	return ddlJobFetcher
@@ -133,6 +146,10 @@ func (p *ddlJobFetcher) unmarshalDDL(rawKV *common.RawKVEntry) (*model.Job, erro
 	if rawKV.OpType != common.OpTypePut {
 		return nil, nil
 	}
+	if p.importJobTableInfo != nil && !event.IsLegacyFormatJob(rawKV) &&
+		tablecodec.DecodeTableID(rawKV.Key) == p.importJobTableInfo.ImportJobTable.TableName.TableID {
+		return event.ParseImportJob(rawKV, p.importJobTableInfo)
+	}
 	if !event.IsLegacyFormatJob(rawKV) {
 		once.Do(func() {
 			if err := initDDLTableInfo(p.kvStorage); err != nil {
@@ -198,6 +215,38 @@ func initDDLTableInfo(kvStorage kv.Storage) error {
 	return nil
 }
 
+// getImportJobTableInfo returns nil if the upstream doesn't have the table `tidb_import_jobs`.
+func getImportJobTableInfo(kvStorage kv.Storage) (*event.ImportJobTableInfo, error) {
+	version, err := kvStorage.CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snap := logpuller.GetSnapshotMeta(kvStorage, version.Ver)
+
+	dbInfos, err := snap.ListDatabases()
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMetaListDatabases, err)
+	}
+
+	db, err := findDBByName(dbInfos, mysql.SystemDB)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	tbls, err := snap.ListTables(db.ID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	for _, t := range tbls {
+		if t.Name.L == "tidb_import_jobs" {
+			return event.NewImportJobTableInfo(db.ID, db.Name.L, t)
+		}
+	}
+	log.Warn("table tidb_import_jobs is not found, the IMPORT INTO jobs are not tracked")
+	return nil, nil
+}
+
 // Below are some helper functions for ddl puller.
 func findDBByName(dbs []*model.DBInfo, name string) (*model.DBInfo, error) {
 	for _, db := range dbs {
@@ -256,6 +305,15 @@ func getAllDDLSpan() []heartbeatpb.TableSpan {
 	return spans
 }
 
+func getImportJobSpan(tableID int64) heartbeatpb.TableSpan {
+	start, end := common.GetTableRange(tableID)
+	return heartbeatpb.TableSpan{
+		TableID:  tableID,
+		StartKey: common.ToComparableKey(start),
+		EndKey:   common.ToComparableKey(end),
+	}
+}
+
 type resolvedTsItem struct {
 	resolvedTs uint64
 	heapIndex  int
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
@@ -241,6 +242,12 @@ func (p *persistentStorage) close() error {
 	return p.db.Close()
 }
 
+func (p *persistentStorage) getGcTs() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.gcTs
+}
+
 // getAllPhysicalTables returns all physical tables in the snapshot
 // caller must ensure current resolve ts is larger than snapTs
 func (p *persistentStorage) getAllPhysicalTables(snapTs uint64, tableFilter filter.Filter) ([]commonEvent.Table, error) {
@@ -249,7 +256,9 @@ func (p *persistentStorage) getAllPhysicalTables(snapTs uint64, tableFilter filt
 
 	p.mu.Lock()
 	if snapTs < p.gcTs {
-		return nil, fmt.Errorf("snapTs %d is smaller than gcTs %d", snapTs, p.gcTs)
+		gcTs := p.gcTs
+		p.mu.Unlock()
+		return nil, errors.ErrSnapshotLostByGC.GenWithStackByArgs(snapTs, gcTs)
 	}
 	gcTs := p.gcTs
 	p.mu.Unlock()
@@ -740,6 +749,17 @@ func shouldSkipDDL(job *model.Job, tableMap map[int64]*BasicTableInfo) bool {
 				zap.Int64("jobSchemaVersion", job.BinlogInfo.SchemaVersion))
 			return true
 		}
+	// The table may be dropped before the import job is finished.
+	case commonEvent.ActionImportInto:
+		if _, ok := tableMap[job.TableID]; !ok {
+			log.Warn("table of the import job not found. ignore it",
+				zap.Int64("jobID", job.ID),
+				zap.String("schemaName", job.SchemaName),
+				zap.String("tableName", job.TableName),
+				zap.Int64("tableID", job.TableID),
+				zap.Uint64("finishedTs", job.BinlogInfo.FinishedTS))
+			return true
+		}
 	// DDLs ignored
 	case model.ActionCreateSequence,
 		model.ActionAlterSequence,
@@ -410,6 +410,15 @@ var allDDLHandlers = map[model.ActionType]*persistStorageDDLHandler{
 		extractTableInfoFunc:       extractTableInfoFuncForRemovePartitioning,
 		buildDDLEventFunc:          buildDDLEventForRemovePartitioning,
 	},
+	// The import job doesn't change the schema, it only marks the data ingested into the table.
+	commonEvent.ActionImportInto: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForImportInto,
+		updateDDLHistoryFunc:       updateDDLHistoryForImportInto,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForImportInto,
+	},
 }
 
 func isPartitionTable(tableInfo *model.TableInfo) bool {
@@ -483,6 +492,17 @@ func buildPersistedDDLEventCommon(args buildPersistedDDLEventFuncArgs) Persisted
 	return event
 }
 
+func buildPersistedDDLEventForImportInto(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
+	event := buildPersistedDDLEventCommon(args)
+	event.CurrentSchemaID = getSchemaID(args.tableMap, event.CurrentTableID)
+	event.CurrentSchemaName = getSchemaName(args.databaseMap, event.CurrentSchemaID)
+	event.CurrentTableName = getTableName(args.tableMap, event.CurrentTableID)
+	// The import job doesn't have a query, build one to show which table is imported.
+	event.Query = fmt.Sprintf("IMPORT INTO `%s`.`%s`", event.CurrentSchemaName, event.CurrentTableName)
+	event.IsIngest = true
+	return event
+}
+
 func buildPersistedDDLEventForCreateDropSchema(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
 	event := buildPersistedDDLEventCommon(args)
 	log.Info("buildPersistedDDLEvent for create/drop schema",
@@ -760,6 +780,18 @@ func updateDDLHistoryForNormalDDLOnSingleTable(args updateDDLHistoryFuncArgs) []
 	return args.tableTriggerDDLHistory
 }
 
+func updateDDLHistoryForImportInto(args updateDDLHistoryFuncArgs) []uint64 {
+	// the table info isn't in the event, get the partitions from partitionMap
+	if partitionInfo, ok := args.partitionMap[args.ddlEvent.CurrentTableID]; ok {
+		for id := range partitionInfo {
+			args.appendTablesDDLHistory(args.ddlEvent.FinishedTs, id)
+		}
+	} else {
+		args.appendTablesDDLHistory(args.ddlEvent.FinishedTs, args.ddlEvent.CurrentTableID)
+	}
+	return args.tableTriggerDDLHistory
+}
+
 func updateDDLHistoryForTruncateTable(args updateDDLHistoryFuncArgs) []uint64 {
 	args.appendTableTriggerDDLHistory(args.ddlEvent.FinishedTs)
 	if isPartitionTable(args.ddlEvent.TableInfo) {
@@ -1523,6 +1555,28 @@ func buildDDLEventForNormalDDLOnSingleTable(rawEvent *PersistedDDLEvent, tableFi
 	return ddlEvent, true
 }
 
+// buildDDLEventForImportInto builds the ingest event of the import job, it's filtered by the
+// table name only, because it's not a ddl of TiDB.
+func buildDDLEventForImportInto(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
+	if tableFilter != nil && tableFilter.ShouldIgnoreTable(rawEvent.CurrentSchemaName, rawEvent.CurrentTableName) {
+		return commonEvent.DDLEvent{}, false
+	}
+	return commonEvent.DDLEvent{
+		Type:       rawEvent.Type,
+		SchemaID:   rawEvent.CurrentSchemaID,
+		TableID:    rawEvent.CurrentTableID,
+		SchemaName: rawEvent.CurrentSchemaName,
+		TableName:  rawEvent.CurrentTableName,
+		Query:      rawEvent.Query,
+		FinishedTs: rawEvent.FinishedTs,
+		IsIngest:   rawEvent.IsIngest,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{rawEvent.CurrentTableID},
+		},
+	}, true
+}
+
 func buildDDLEventForNormalDDLOnSingleTableForTiDB(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
 	ddlEvent, ok := buildDDLEventCommon(rawEvent, tableFilter, WithTiDBOnly)
 	if !ok {
@@ -1913,7 +1967,8 @@ func buildDDLEventForExchangeTablePartition(rawEvent *PersistedDDLEvent, tableFi
 			},
 		}
 	} else {
-		log.Fatal("should not happen")
+		// both tables are filtered out, the ddl is ignored
+		return ddlEvent, false
 	}
 	return ddlEvent, true
 }
@@ -1939,84 +1994,54 @@ func buildDDLEventForRenameTables(rawEvent *PersistedDDLEvent, tableFilter filte
 		}
 		resultQuerys = append(resultQuerys, querys[i])
 		allFiltered = false
-		if isPartitionTable(rawEvent.TableInfo) {
-			allPhysicalIDs := getAllPartitionIDs(rawEvent.TableInfo)
-			if !ignorePrevTable {
-				ddlEvent.BlockedTables.TableIDs = append(ddlEvent.BlockedTables.TableIDs, allPhysicalIDs...)
-				if !ignoreCurrentTable {
-					// check whether schema change
-					if rawEvent.PrevSchemaIDs[i] != rawEvent.CurrentSchemaIDs[i] {
-						for _, id := range allPhysicalIDs {
-							ddlEvent.UpdatedSchemas = append(ddlEvent.UpdatedSchemas, commonEvent.SchemaIDChange{
-								TableID:     id,
-								OldSchemaID: rawEvent.PrevSchemaIDs[i],
-								NewSchemaID: rawEvent.CurrentSchemaIDs[i],
-							})
-						}
-					}
-					addNames = append(addNames, commonEvent.SchemaTableName{
-						SchemaName: rawEvent.CurrentSchemaNames[i],
-						TableName:  tableInfo.Name.O,
-					})
-					dropNames = append(dropNames, commonEvent.SchemaTableName{
-						SchemaName: rawEvent.PrevSchemaNames[i],
-						TableName:  rawEvent.PrevTableNames[i],
-					})
-				} else {
-					// the table is filtered out after rename table, we need drop the table
-					if ddlEvent.NeedDroppedTables == nil {
-						ddlEvent.NeedDroppedTables = &commonEvent.InfluencedTables{
-							InfluenceType: commonEvent.InfluenceTypeNormal,
-						}
-					}
-					ddlEvent.NeedDroppedTables.TableIDs = append(ddlEvent.NeedDroppedTables.TableIDs, allPhysicalIDs...)
-					dropNames = append(dropNames, commonEvent.SchemaTableName{
-						SchemaName: rawEvent.PrevSchemaNames[i],
-						TableName:  rawEvent.PrevTableNames[i],
-					})
-				}
-			} else if !ignoreCurrentTable {
-				// TODO: return a ddl event with error
-			} else {
-				// if the table is both filtered out before and after rename table, ignore
-			}
-		} else {
-			if !ignorePrevTable {
-				ddlEvent.BlockedTables.TableIDs = append(ddlEvent.BlockedTables.TableIDs, tableInfo.ID)
-				if !ignoreCurrentTable {
-					if rawEvent.PrevSchemaIDs[i] != rawEvent.CurrentSchemaIDs[i] {
+		// every physical table of the renamed table is involved
+		physicalIDs := []int64{tableInfo.ID}
+		if isPartitionTable(tableInfo) {
+			physicalIDs = getAllPartitionIDs(tableInfo)
+		}
+		if !ignorePrevTable {
+			ddlEvent.BlockedTables.TableIDs = append(ddlEvent.BlockedTables.TableIDs, physicalIDs...)
+			if !ignoreCurrentTable {
+				// check whether schema change
+				if rawEvent.PrevSchemaIDs[i] != rawEvent.CurrentSchemaIDs[i] {
+					for _, id := range physicalIDs {
 						ddlEvent.UpdatedSchemas = append(ddlEvent.UpdatedSchemas, commonEvent.SchemaIDChange{
-							TableID:     tableInfo.ID,
+							TableID:     id,
 							OldSchemaID: rawEvent.PrevSchemaIDs[i],
 							NewSchemaID: rawEvent.CurrentSchemaIDs[i],
 						})
 					}
-					addNames = append(addNames, commonEvent.SchemaTableName{
-						SchemaName: rawEvent.CurrentSchemaNames[i],
-						TableName:  tableInfo.Name.O,
-					})
-					dropNames = append(dropNames, commonEvent.SchemaTableName{
-						SchemaName: rawEvent.PrevSchemaNames[i],
-						TableName:  rawEvent.PrevTableNames[i],
-					})
-				} else {
-					// the table is filtered out after rename table, we need drop the table
-					if ddlEvent.NeedDroppedTables == nil {
-						ddlEvent.NeedDroppedTables = &commonEvent.InfluencedTables{
-							InfluenceType: commonEvent.InfluenceTypeNormal,
-						}
-					}
-					ddlEvent.NeedDroppedTables.TableIDs = append(ddlEvent.NeedDroppedTables.TableIDs, tableInfo.ID)
-					dropNames = append(dropNames, commonEvent.SchemaTableName{
-						SchemaName: rawEvent.PrevSchemaNames[i],
-						TableName:  rawEvent.PrevTableNames[i],
-					})
 				}
-			} else if !ignoreCurrentTable {
-				// TODO: return a ddl event with error
+				addNames = append(addNames, commonEvent.SchemaTableName{
+					SchemaName: rawEvent.CurrentSchemaNames[i],
+					TableName:  tableInfo.Name.O,
+				})
 			} else {
-				// ignore
+				// the table is filtered out after rename table, we need drop the table
+				if ddlEvent.NeedDroppedTables == nil {
+					ddlEvent.NeedDroppedTables = &commonEvent.InfluencedTables{
+						InfluenceType: commonEvent.InfluenceTypeNormal,
+					}
+				}
+				ddlEvent.NeedDroppedTables.TableIDs = append(ddlEvent.NeedDroppedTables.TableIDs, physicalIDs...)
 			}
+			dropNames = append(dropNames, commonEvent.SchemaTableName{
+				SchemaName: rawEvent.PrevSchemaNames[i],
+				TableName:  rawEvent.PrevTableNames[i],
+			})
+		} else {
+			// the table is filtered out before rename table, we need add the table.
+			// it is not replicated yet, so only the table trigger event dispatcher is blocked.
+			for _, id := range physicalIDs {
+				ddlEvent.NeedAddedTables = append(ddlEvent.NeedAddedTables, commonEvent.Table{
+					SchemaID: rawEvent.CurrentSchemaIDs[i],
+					TableID:  id,
+				})
+			}
+			addNames = append(addNames, commonEvent.SchemaTableName{
+				SchemaName: rawEvent.CurrentSchemaNames[i],
+				TableName:  tableInfo.Name.O,
+			})
 		}
 	}
 	if allFiltered {
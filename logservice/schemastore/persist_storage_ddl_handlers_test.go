@@ -0,0 +1,131 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemastore
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDDLEventForRenameTablesWithPartitionTable(t *testing.T) {
+	rawEvent := &PersistedDDLEvent{
+		Type:  byte(model.ActionRenameTables),
+		Query: "RENAME TABLE `test`.`t1` TO `test2`.`t101`;RENAME TABLE `test3`.`t2` TO `test`.`t102`;",
+		MultipleTableInfos: []*model.TableInfo{
+			{
+				ID:   200,
+				Name: pmodel.NewCIStr("t101"),
+				Partition: &model.PartitionInfo{
+					Definitions: []model.PartitionDefinition{{ID: 201}, {ID: 202}},
+				},
+			},
+			{
+				ID:   300,
+				Name: pmodel.NewCIStr("t102"),
+			},
+		},
+		PrevSchemaIDs:      []int64{100, 110},
+		PrevSchemaNames:    []string{"test", "test3"},
+		PrevTableNames:     []string{"t1", "t2"},
+		CurrentSchemaIDs:   []int64{105, 100},
+		CurrentSchemaNames: []string{"test2", "test"},
+		FinishedTs:         1010,
+	}
+
+	// all partitions of the partition table are blocked and change the schema
+	ddlEvent, ok := buildDDLEventForRenameTables(rawEvent, nil)
+	require.True(t, ok)
+	require.Equal(t, []int64{0, 201, 202, 300}, ddlEvent.BlockedTables.TableIDs)
+	require.Equal(t, []commonEvent.SchemaIDChange{
+		{TableID: 201, OldSchemaID: 100, NewSchemaID: 105},
+		{TableID: 202, OldSchemaID: 100, NewSchemaID: 105},
+		{TableID: 300, OldSchemaID: 110, NewSchemaID: 100},
+	}, ddlEvent.UpdatedSchemas)
+	require.Nil(t, ddlEvent.NeedDroppedTables)
+	require.Nil(t, ddlEvent.NeedAddedTables)
+
+	// only schema test is replicated, the partition table is dropped
+	// and the normal table is added after rename
+	ddlEvent, ok = buildDDLEventForRenameTables(rawEvent, buildTableFilterByNameForTest("test", "*"))
+	require.True(t, ok)
+	require.Equal(t, []int64{0, 201, 202}, ddlEvent.BlockedTables.TableIDs)
+	require.Nil(t, ddlEvent.UpdatedSchemas)
+	require.Equal(t, []int64{201, 202}, ddlEvent.NeedDroppedTables.TableIDs)
+	require.Equal(t, []commonEvent.Table{{SchemaID: 100, TableID: 300}}, ddlEvent.NeedAddedTables)
+	require.Equal(t, []commonEvent.SchemaTableName{{SchemaName: "test", TableName: "t102"}},
+		ddlEvent.TableNameChange.AddName)
+	require.Equal(t, []commonEvent.SchemaTableName{{SchemaName: "test", TableName: "t1"}},
+		ddlEvent.TableNameChange.DropName)
+}
+
+func TestBuildDDLEventForExchangeTablePartition(t *testing.T) {
+	// the normal table 300 in schema test2 is exchanged with the partition 202 of the table 200 in schema test
+	rawEvent := &PersistedDDLEvent{
+		Type:              byte(model.ActionExchangeTablePartition),
+		Query:             "ALTER TABLE `test`.`t` EXCHANGE PARTITION `p1` WITH TABLE `test2`.`t2`",
+		PrevSchemaID:      105,
+		PrevTableID:       300,
+		PrevSchemaName:    "test2",
+		PrevTableName:     "t2",
+		CurrentSchemaID:   100,
+		CurrentTableID:    200,
+		CurrentSchemaName: "test",
+		CurrentTableName:  "t",
+		TableInfo: &model.TableInfo{
+			ID:   200,
+			Name: pmodel.NewCIStr("t"),
+			Partition: &model.PartitionInfo{
+				Definitions: []model.PartitionDefinition{{ID: 201}, {ID: 300}},
+			},
+		},
+		PrevPartitions: []int64{201, 202},
+		FinishedTs:     1010,
+	}
+
+	// both tables are replicated, the exchanged physical tables swap their schemas
+	ddlEvent, ok := buildDDLEventForExchangeTablePartition(rawEvent, nil)
+	require.True(t, ok)
+	require.Equal(t, []int64{300, 202, 0}, ddlEvent.BlockedTables.TableIDs)
+	require.Equal(t, []commonEvent.SchemaIDChange{
+		{TableID: 202, OldSchemaID: 100, NewSchemaID: 105},
+		{TableID: 300, OldSchemaID: 105, NewSchemaID: 100},
+	}, ddlEvent.UpdatedSchemas)
+	require.Nil(t, ddlEvent.NeedDroppedTables)
+	require.Nil(t, ddlEvent.NeedAddedTables)
+
+	// only the normal table is replicated, it becomes a partition and is dropped,
+	// while the exchanged partition becomes the normal table and is added
+	ddlEvent, ok = buildDDLEventForExchangeTablePartition(rawEvent, buildTableFilterByNameForTest("test2", "*"))
+	require.True(t, ok)
+	require.Equal(t, []int64{300, 0}, ddlEvent.BlockedTables.TableIDs)
+	require.Equal(t, []int64{300}, ddlEvent.NeedDroppedTables.TableIDs)
+	require.Equal(t, []commonEvent.Table{{SchemaID: 105, TableID: 202}}, ddlEvent.NeedAddedTables)
+	require.Nil(t, ddlEvent.UpdatedSchemas)
+
+	// only the partition table is replicated, the exchanged partition is dropped
+	// and the normal table is added as a new partition
+	ddlEvent, ok = buildDDLEventForExchangeTablePartition(rawEvent, buildTableFilterByNameForTest("test", "*"))
+	require.True(t, ok)
+	require.Equal(t, []int64{202, 0}, ddlEvent.BlockedTables.TableIDs)
+	require.Equal(t, []int64{202}, ddlEvent.NeedDroppedTables.TableIDs)
+	require.Equal(t, []commonEvent.Table{{SchemaID: 100, TableID: 300}}, ddlEvent.NeedAddedTables)
+
+	// both tables are filtered out
+	_, ok = buildDDLEventForExchangeTablePartition(rawEvent, buildTableFilterByNameForTest("test3", "*"))
+	require.False(t, ok)
+}
@@ -2321,3 +2321,65 @@ func TestGCPersistStorage(t *testing.T) {
 
 	// TODO: test obsolete data can be removed
 }
+
+func TestApplyImportIntoJob(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	pStorage := newPersistentStorageForTest(dbPath, nil)
+	for _, job := range []*model.Job{
+		buildCreateSchemaJobForTest(100, "test", 1000),
+		buildCreateTableJobForTest(100, 200, "t1", 1010),
+		buildCreatePartitionTableJobForTest(100, 300, "t2", []int64{301, 302}, 1020),
+		buildCreateTableJobForTest(100, 400, "t3", 1030),
+		buildImportIntoJobForTest(200, 1040),
+		buildImportIntoJobForTest(300, 1050),
+		buildDropTableJobForTest(100, 400, 1060),
+		// the table is dropped before the import job is finished
+		buildImportIntoJobForTest(400, 1070),
+	} {
+		require.Nil(t, pStorage.handleDDLJob(job))
+	}
+	require.NoError(t, pStorage.registerTable(200, 1010))
+
+	checkState := func() {
+		// the import jobs are only in the ddl history of the imported tables
+		require.Equal(t, []uint64{1000, 1010, 1020, 1030, 1060}, pStorage.tableTriggerDDLHistory)
+		require.Equal(t, []uint64{1010, 1040}, pStorage.tablesDDLHistory[200])
+		require.Equal(t, []uint64{1020, 1050}, pStorage.tablesDDLHistory[301])
+		require.Equal(t, []uint64{1020, 1050}, pStorage.tablesDDLHistory[302])
+		require.Equal(t, []uint64{1030, 1060}, pStorage.tablesDDLHistory[400])
+
+		events, err := pStorage.fetchTableDDLEvents(200, nil, 1010, 1040)
+		require.Nil(t, err)
+		require.Len(t, events, 1)
+		require.True(t, events[0].IsIngest)
+		require.Equal(t, byte(commonEvent.ActionImportInto), events[0].Type)
+		require.Equal(t, "IMPORT INTO `test`.`t1`", events[0].Query)
+		require.Equal(t, int64(100), events[0].SchemaID)
+		require.Equal(t, uint64(1040), events[0].FinishedTs)
+		require.Nil(t, events[0].TableInfo)
+		require.Equal(t, []int64{200}, events[0].BlockedTables.TableIDs)
+
+		events, err = pStorage.fetchTableDDLEvents(301, nil, 1020, 1050)
+		require.Nil(t, err)
+		require.Len(t, events, 1)
+		require.True(t, events[0].IsIngest)
+		require.Equal(t, "IMPORT INTO `test`.`t2`", events[0].Query)
+
+		// the ingest event is filtered by the table name
+		events, err = pStorage.fetchTableDDLEvents(200, buildTableFilterByNameForTest("test", "t2"), 1010, 1040)
+		require.Nil(t, err)
+		require.Empty(t, events)
+
+		// the import job doesn't change the table info
+		tableInfo, err := pStorage.getTableInfo(200, 1040)
+		require.Nil(t, err)
+		require.Equal(t, "t1", tableInfo.TableName.Table)
+	}
+	checkState()
+	pStorage.close()
+	// load from disk and check again
+	pStorage = loadPersistentStorageFromPathForTest(dbPath, math.MaxUint64)
+	require.NoError(t, pStorage.registerTable(200, 1010))
+	checkState()
+	pStorage.close()
+}
@@ -651,3 +651,13 @@ func buildRemovePartitioningJobForTest(
 		},
 	}
 }
+
+func buildImportIntoJobForTest(tableID int64, finishedTs uint64) *model.Job {
+	return &model.Job{
+		Type:    commonEvent.ActionImportInto,
+		TableID: tableID,
+		BinlogInfo: &model.HistoryInfo{
+			FinishedTS: finishedTs,
+		},
+	}
+}
@@ -54,6 +54,9 @@ type SchemaStore interface {
 	FetchTableDDLEvents(tableID int64, tableFilter filter.Filter, start, end uint64) ([]commonEvent.DDLEvent, error)
 
 	FetchTableTriggerDDLEvents(tableFilter filter.Filter, start uint64, limit int) ([]commonEvent.DDLEvent, uint64, error)
+
+	// GetGCTs returns the gc ts of the schema store, the schema of the ts smaller than it is not available.
+	GetGCTs() uint64
 }
 
 type DDLEventState struct {
@@ -176,6 +179,10 @@ func (s *schemaStore) updateResolvedTsPeriodically(ctx context.Context) error {
 				zap.Int("resolvedEventsLen", len(resolvedEvents)))
 
 			for _, event := range resolvedEvents {
+				if event.Job.Type == commonEvent.ActionImportInto {
+					s.handleImportJob(event)
+					continue
+				}
 				if event.Job.BinlogInfo.FinishedTS <= s.finishedDDLTs ||
 					event.Job.BinlogInfo.SchemaVersion == 0 /* means the ddl is ignored in upstream */ {
 					log.Info("skip already applied ddl job",
@@ -232,11 +239,34 @@ func (s *schemaStore) updateResolvedTsPeriodically(ctx context.Context) error {
 	}
 }
 
+// handleImportJob handles the finished import job, the job doesn't have a schema version,
+// so it doesn't update the schema version and the finished ddl ts of the store.
+func (s *schemaStore) handleImportJob(event DDLJobWithCommitTs) {
+	if event.Job.BinlogInfo.FinishedTS <= s.finishedDDLTs {
+		log.Info("skip already applied import job",
+			zap.Int64("jobID", event.Job.ID),
+			zap.Uint64("jobFinishTs", event.Job.BinlogInfo.FinishedTS),
+			zap.Uint64("storeFinishedDDLTS", s.finishedDDLTs))
+		return
+	}
+	log.Info("handle import job",
+		zap.Int64("jobID", event.Job.ID),
+		zap.String("schemaName", event.Job.SchemaName),
+		zap.String("tableName", event.Job.TableName),
+		zap.Int64("tableID", event.Job.TableID),
+		zap.Uint64("jobFinishTs", event.Job.BinlogInfo.FinishedTS))
+	s.dataStorage.handleDDLJob(event.Job)
+}
+
 func (s *schemaStore) GetAllPhysicalTables(snapTs uint64, filter filter.Filter) ([]commonEvent.Table, error) {
 	s.waitResolvedTs(0, snapTs, 10*time.Second)
 	return s.dataStorage.getAllPhysicalTables(snapTs, filter)
 }
 
+func (s *schemaStore) GetGCTs() uint64 {
+	return s.dataStorage.getGcTs()
+}
+
 func (s *schemaStore) RegisterTable(tableID int64, startTs uint64) error {
 	metrics.SchemaStoreResolvedRegisterTableGauge.Inc()
 	s.waitResolvedTs(tableID, startTs, 5*time.Second)
@@ -67,6 +67,10 @@ type PersistedDDLEvent struct {
 	// TODO: do we need the following two fields?
 	BDRRole        string `msg:"bdr_role"`
 	CDCWriteSource uint64 `msg:"cdc_write_source"`
+
+	// IsIngest is set if the event is built from a finished IMPORT INTO job,
+	// whose data is ingested into TiKV as SST files.
+	IsIngest bool `msg:"is_ingest"`
 }
 
 // TODO: use msgp.Raw to do version management
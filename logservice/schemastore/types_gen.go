@@ -259,6 +259,12 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "CDCWriteSource")
 				return
 			}
+		case "is_ingest":
+			z.IsIngest, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "IsIngest")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -272,9 +278,9 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 24
+	// map header, size 25
 	// write "id"
-	err = en.Append(0xde, 0x0, 0x18, 0xa2, 0x69, 0x64)
+	err = en.Append(0xde, 0x0, 0x19, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -562,15 +568,25 @@ func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "CDCWriteSource")
 		return
 	}
+	// write "is_ingest"
+	err = en.Append(0xa9, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.IsIngest)
+	if err != nil {
+		err = msgp.WrapError(err, "IsIngest")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 24
+	// map header, size 25
 	// string "id"
-	o = append(o, 0xde, 0x0, 0x18, 0xa2, 0x69, 0x64)
+	o = append(o, 0xde, 0x0, 0x19, 0xa2, 0x69, 0x64)
 	o = msgp.AppendInt64(o, z.ID)
 	// string "type"
 	o = append(o, 0xa4, 0x74, 0x79, 0x70, 0x65)
@@ -662,6 +678,9 @@ func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "cdc_write_source"
 	o = append(o, 0xb0, 0x63, 0x64, 0x63, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65)
 	o = msgp.AppendUint64(o, z.CDCWriteSource)
+	// string "is_ingest"
+	o = append(o, 0xa9, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74)
+	o = msgp.AppendBool(o, z.IsIngest)
 	return
 }
 
@@ -918,6 +937,12 @@ func (z *PersistedDDLEvent) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "CDCWriteSource")
 				return
 			}
+		case "is_ingest":
+			z.IsIngest, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IsIngest")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -948,7 +973,7 @@ func (z *PersistedDDLEvent) Msgsize() (s int) {
 	for za0007 := range z.MultipleTableInfosValue {
 		s += msgp.BytesPrefixSize + len(z.MultipleTableInfosValue[za0007])
 	}
-	s += 9 + msgp.StringPrefixSize + len(z.BDRRole) + 17 + msgp.Uint64Size
+	s += 9 + msgp.StringPrefixSize + len(z.BDRRole) + 17 + msgp.Uint64Size + 10 + msgp.BoolSize
 	return
 }
 
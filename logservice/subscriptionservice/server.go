@@ -0,0 +1,249 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscriptionservice
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxEventsPerResponse is the max number of events carried by one response.
+const maxEventsPerResponse = 1024
+
+// server implements the SubscriptionService, it serves the changes of the subscribed spans
+// from the event store to the external subscribers directly.
+type server struct {
+	logservicepb.UnimplementedSubscriptionServiceServer
+
+	eventStore eventstore.EventStore
+	credential *security.Credential
+}
+
+// NewServer creates a SubscriptionService server which reads the changes from the event store.
+// The service is served on the tcp listener of the server, which verifies the certificate and
+// the common name of the peers when they are configured in the credential.
+func NewServer(eventStore eventstore.EventStore, credential *security.Credential) logservicepb.SubscriptionServiceServer {
+	return &server{eventStore: eventStore, credential: credential}
+}
+
+// checkPeerVerified returns an error if the subscribers are not verified by the tls listener,
+// the raw changes of the upstream must not be served to an unauthenticated peer.
+func (s *server) checkPeerVerified() error {
+	if s.credential == nil || !s.credential.IsTLSEnabled() {
+		return status.Error(codes.PermissionDenied,
+			"subscription service requires tls to be enabled")
+	}
+	if !s.credential.MTLS && len(s.credential.CertAllowedCN) == 0 {
+		return status.Error(codes.PermissionDenied,
+			"subscription service requires the client certificates to be verified, "+
+				"either mtls or cert-allowed-cn must be configured")
+	}
+	return nil
+}
+
+// subscription is the state of a subscribe stream,
+// it is registered to the event store as a dispatcher.
+type subscription struct {
+	id           common.DispatcherID
+	subscriberID string
+	span         *heartbeatpb.TableSpan
+
+	// resolvedTs is the latest resolved ts notified by the event store.
+	resolvedTs atomic.Uint64
+	// sentResolvedTs is the max resolved ts sent to the subscriber.
+	sentResolvedTs atomic.Uint64
+	// ackTs is the progress acknowledged by the subscriber.
+	ackTs atomic.Uint64
+	// notifyCh is notified when the resolved ts is advanced.
+	notifyCh chan struct{}
+}
+
+func (s *subscription) onResolvedTs(watermark uint64, _ uint64) {
+	for {
+		old := s.resolvedTs.Load()
+		if watermark <= old {
+			return
+		}
+		if s.resolvedTs.CompareAndSwap(old, watermark) {
+			break
+		}
+	}
+	select {
+	case s.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe implements logservicepb.SubscriptionServiceServer.
+func (s *server) Subscribe(stream logservicepb.SubscriptionService_SubscribeServer) error {
+	if err := s.checkPeerVerified(); err != nil {
+		return err
+	}
+	req, err := stream.Recv()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if req.Span == nil {
+		return status.Error(codes.InvalidArgument, "the span to subscribe is not specified")
+	}
+
+	sub := &subscription{
+		id:           common.NewDispatcherID(),
+		subscriberID: req.SubscriberID,
+		span:         req.Span,
+		notifyCh:     make(chan struct{}, 1),
+	}
+	sub.resolvedTs.Store(req.StartTs)
+	sub.sentResolvedTs.Store(req.StartTs)
+	sub.ackTs.Store(req.StartTs)
+
+	ok, err := s.eventStore.RegisterDispatcher(sub.id, sub.span, req.StartTs, sub.onResolvedTs, false, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return status.Errorf(codes.Unavailable, "subscribe span %s failed", sub.span.String())
+	}
+	log.Info("external subscriber subscribed",
+		zap.String("subscriber", sub.subscriberID),
+		zap.Stringer("id", sub.id),
+		zap.String("span", sub.span.String()),
+		zap.Uint64("startTs", req.StartTs))
+	defer func() {
+		if err := s.eventStore.UnregisterDispatcher(sub.id); err != nil {
+			log.Warn("unregister external subscriber failed",
+				zap.Stringer("id", sub.id), zap.Error(err))
+		}
+		log.Info("external subscriber unsubscribed",
+			zap.String("subscriber", sub.subscriberID),
+			zap.Stringer("id", sub.id),
+			zap.Uint64("sentResolvedTs", sub.sentResolvedTs.Load()),
+			zap.Uint64("ackTs", sub.ackTs.Load()))
+	}()
+
+	g, ctx := errgroup.WithContext(stream.Context())
+	g.Go(func() error {
+		return s.receiveAcks(stream, sub)
+	})
+	g.Go(func() error {
+		return s.sendEvents(ctx, stream, sub)
+	})
+	return g.Wait()
+}
+
+// receiveAcks receives the acknowledged progress of the subscriber,
+// the events before it are no longer needed by the subscriber.
+func (s *server) receiveAcks(stream logservicepb.SubscriptionService_SubscribeServer, sub *subscription) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// the subscriber stops acknowledging, keep sending the events until the stream is closed.
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ackTs := req.AckTs
+		if sentResolvedTs := sub.sentResolvedTs.Load(); ackTs > sentResolvedTs {
+			ackTs = sentResolvedTs
+		}
+		if ackTs <= sub.ackTs.Load() {
+			continue
+		}
+		sub.ackTs.Store(ackTs)
+		if err := s.eventStore.UpdateDispatcherCheckpointTs(sub.id, ackTs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func (s *server) sendEvents(
+	ctx context.Context, stream logservicepb.SubscriptionService_SubscribeServer, sub *subscription,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-sub.notifyCh:
+		}
+		resolvedTs := sub.resolvedTs.Load()
+		if resolvedTs <= sub.sentResolvedTs.Load() {
+			continue
+		}
+		if err := s.scan(stream, sub, resolvedTs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// scan sends the events in range (sentResolvedTs, resolvedTs] to the subscriber,
+// followed by the resolved ts.
+func (s *server) scan(stream logservicepb.SubscriptionService_SubscribeServer, sub *subscription, resolvedTs uint64) error {
+	iter, err := s.eventStore.GetIterator(sub.id, common.DataRange{
+		Span:    sub.span,
+		StartTs: sub.sentResolvedTs.Load(),
+		EndTs:   resolvedTs,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	resp := &logservicepb.SubscribeResponse{}
+	if iter != nil {
+		defer iter.Close()
+		for {
+			e, _, err := iter.Next()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if e == nil {
+				break
+			}
+			resp.Events = append(resp.Events, &logservicepb.SubscribedEvent{
+				OpType:   uint32(e.OpType),
+				Key:      e.Key,
+				Value:    e.Value,
+				OldValue: e.OldValue,
+				StartTs:  e.StartTs,
+				CommitTs: e.CRTs,
+			})
+			if len(resp.Events) >= maxEventsPerResponse {
+				if err := stream.Send(resp); err != nil {
+					return errors.Trace(err)
+				}
+				resp = &logservicepb.SubscribeResponse{}
+			}
+		}
+	}
+
+	resp.ResolvedTs = resolvedTs
+	if err := stream.Send(resp); err != nil {
+		return errors.Trace(err)
+	}
+	sub.sentResolvedTs.Store(resolvedTs)
+	return nil
+}
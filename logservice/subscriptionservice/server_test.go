@@ -0,0 +1,241 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscriptionservice
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var _ eventstore.EventStore = &mockEventStore{}
+
+// mockEventStore holds the events of a single dispatcher.
+type mockEventStore struct {
+	mu           sync.Mutex
+	events       []*common.RawKVEntry
+	notifier     eventstore.ResolvedTsNotifier
+	checkpointTs uint64
+	registered   chan struct{}
+	unregistered chan struct{}
+}
+
+func newMockEventStore() *mockEventStore {
+	return &mockEventStore{
+		registered:   make(chan struct{}),
+		unregistered: make(chan struct{}),
+	}
+}
+
+func (m *mockEventStore) Name() string                    { return "mockEventStore" }
+func (m *mockEventStore) Run(ctx context.Context) error   { return nil }
+func (m *mockEventStore) Close(ctx context.Context) error { return nil }
+
+func (m *mockEventStore) RegisterDispatcher(
+	dispatcherID common.DispatcherID,
+	span *heartbeatpb.TableSpan,
+	startTS uint64,
+	notifier eventstore.ResolvedTsNotifier,
+	onlyReuse bool,
+	disableOldValue bool,
+) (bool, error) {
+	m.mu.Lock()
+	m.notifier = notifier
+	m.mu.Unlock()
+	close(m.registered)
+	return true, nil
+}
+
+func (m *mockEventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
+	close(m.unregistered)
+	return nil
+}
+
+func (m *mockEventStore) PauseDispatcher(dispatcherID common.DispatcherID)  {}
+func (m *mockEventStore) ResumeDispatcher(dispatcherID common.DispatcherID) {}
+
+func (m *mockEventStore) UpdateDispatcherCheckpointTs(dispatcherID common.DispatcherID, checkpointTs uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpointTs = checkpointTs
+	return nil
+}
+
+func (m *mockEventStore) getCheckpointTs() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkpointTs
+}
+
+func (m *mockEventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID) (bool, eventstore.DMLEventState) {
+	return true, eventstore.DMLEventState{}
+}
+
+func (m *mockEventStore) GetDispatcherStoredBytes(dispatcherID common.DispatcherID) map[uint64]uint64 {
+	return nil
+}
+
+func (m *mockEventStore) WriteEngineStats(w io.Writer) {}
+
+func (m *mockEventStore) GetGCSafePoint(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (m *mockEventStore) GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (eventstore.EventIterator, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	iter := &mockEventIterator{}
+	for _, e := range m.events {
+		if e.CRTs > dataRange.StartTs && e.CRTs <= dataRange.EndTs {
+			iter.events = append(iter.events, e)
+		}
+	}
+	return iter, nil
+}
+
+func (m *mockEventStore) advance(resolvedTs uint64, events ...*common.RawKVEntry) {
+	m.mu.Lock()
+	m.events = append(m.events, events...)
+	notifier := m.notifier
+	m.mu.Unlock()
+	notifier(resolvedTs, resolvedTs)
+}
+
+type mockEventIterator struct {
+	events []*common.RawKVEntry
+}
+
+func (iter *mockEventIterator) Next() (*common.RawKVEntry, bool, error) {
+	if len(iter.events) == 0 {
+		return nil, false, nil
+	}
+	e := iter.events[0]
+	iter.events = iter.events[1:]
+	return e, true, nil
+}
+
+func (iter *mockEventIterator) Close() (int64, error) {
+	return 0, nil
+}
+
+// verifiedCredential is a credential which verifies the client certificates,
+// the tls handshake is done by the listener so it's not needed by the tests.
+var verifiedCredential = &security.Credential{
+	CAPath:        "ca.pem",
+	CertPath:      "server.pem",
+	KeyPath:       "server-key.pem",
+	CertAllowedCN: []string{"client"},
+}
+
+func newTestClient(
+	t *testing.T, store eventstore.EventStore, credential *security.Credential,
+) logservicepb.SubscriptionServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	logservicepb.RegisterSubscriptionServiceServer(grpcServer, NewServer(store, credential))
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return logservicepb.NewSubscriptionServiceClient(conn)
+}
+
+func TestSubscribe(t *testing.T) {
+	store := newMockEventStore()
+	client := newTestClient(t, store, verifiedCredential)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := client.Subscribe(ctx)
+	require.NoError(t, err)
+	err = stream.Send(&logservicepb.SubscribeRequest{
+		SubscriberID: "test",
+		Span:         &heartbeatpb.TableSpan{TableID: 1},
+		StartTs:      100,
+	})
+	require.NoError(t, err)
+	<-store.registered
+
+	store.advance(110, &common.RawKVEntry{
+		OpType: common.OpTypePut, Key: []byte("k1"), Value: []byte("v1"), StartTs: 101, CRTs: 105,
+	})
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+	require.Equal(t, []byte("k1"), resp.Events[0].Key)
+	require.Equal(t, uint64(105), resp.Events[0].CommitTs)
+	require.Equal(t, uint64(110), resp.ResolvedTs)
+
+	// only the events after the sent resolved ts are sent.
+	store.advance(120, &common.RawKVEntry{
+		OpType: common.OpTypeDelete, Key: []byte("k1"), OldValue: []byte("v1"), StartTs: 111, CRTs: 115,
+	})
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+	require.Equal(t, uint32(common.OpTypeDelete), resp.Events[0].OpType)
+	require.Equal(t, uint64(120), resp.ResolvedTs)
+
+	// the ack ts is limited by the sent resolved ts.
+	require.NoError(t, stream.Send(&logservicepb.SubscribeRequest{AckTs: 200}))
+	require.Eventually(t, func() bool {
+		return store.getCheckpointTs() == 120
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-store.unregistered
+}
+
+func TestSubscribeRequiresVerifiedPeer(t *testing.T) {
+	credentials := []*security.Credential{
+		nil,
+		{},
+		// tls without verifying the client certificates
+		{CAPath: "ca.pem", CertPath: "server.pem", KeyPath: "server-key.pem"},
+	}
+	for _, credential := range credentials {
+		store := newMockEventStore()
+		client := newTestClient(t, store, credential)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		stream, err := client.Subscribe(ctx)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+		cancel()
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstreamservice
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const upstreamDataDir = "upstream"
+
+// LogService holds the log service modules of an upstream TiDB cluster.
+type LogService struct {
+	UpstreamID  uint64
+	Upstream    *upstream.Upstream
+	SchemaStore schemastore.SchemaStore
+	EventStore  eventstore.EventStore
+
+	subscriptionClient *logpuller.SubscriptionClient
+	// dataDir stores the data of the event store and the schema store.
+	dataDir string
+	// holders are the changefeed components using the log service,
+	// the log service is closed after all of them release it.
+	holders map[string]struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// Manager manages the log services of all upstreams, keyed by the upstream ID.
+// The log service of the default upstream is created by the server,
+// the others are created on demand when a changefeed of the upstream is scheduled to this node.
+type Manager struct {
+	ctx             context.Context
+	dataDir         string
+	upstreamManager *upstream.Manager
+
+	mu                sync.Mutex
+	services          map[uint64]*LogService
+	defaultUpstreamID uint64
+}
+
+// NewManager creates a new Manager, the log services of the non-default upstreams
+// are spawned from ctx and store their data in a sub directory of dataDir.
+func NewManager(ctx context.Context, dataDir string, upstreamManager *upstream.Manager) *Manager {
+	return &Manager{
+		ctx:             ctx,
+		dataDir:         dataDir,
+		upstreamManager: upstreamManager,
+		services:        make(map[uint64]*LogService),
+	}
+}
+
+// SetDefault sets the log service of the default upstream,
+// its modules are run and closed by the server.
+func (m *Manager) SetDefault(up *upstream.Upstream, schemaStore schemastore.SchemaStore, eventStore eventstore.EventStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultUpstreamID = up.ID
+	m.services[up.ID] = &LogService{
+		UpstreamID:  up.ID,
+		Upstream:    up,
+		SchemaStore: schemaStore,
+		EventStore:  eventStore,
+	}
+}
+
+// Get returns the log service of the upstream,
+// upstreamID 0 is regarded as the default upstream.
+func (m *Manager) Get(upstreamID uint64) (*LogService, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if upstreamID == 0 {
+		upstreamID = m.defaultUpstreamID
+	}
+	s, ok := m.services[upstreamID]
+	return s, ok
+}
+
+// Acquire returns the log service of the upstream described by info and records
+// the holder using it, the log service is created if it doesn't exist. The log
+// service of the default upstream is returned if info is nil.
+// ErrUpstreamNotReady is returned if the upstream is still initializing, the caller should retry later.
+func (m *Manager) Acquire(info *config.UpstreamInfo, holder string) (*LogService, error) {
+	if info == nil {
+		s, ok := m.Get(0)
+		if !ok {
+			return nil, cerror.ErrUpstreamNotFound.GenWithStackByArgs(0)
+		}
+		return s, nil
+	}
+	if s, ok := m.hold(info.ID, holder); ok {
+		return s, nil
+	}
+
+	up, err := m.upstreamManager.AddUpstreamByInfo(info)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := up.Error(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !up.IsNormal() {
+		return nil, cerror.ErrUpstreamNotReady.GenWithStackByArgs(info.ID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.services[info.ID]
+	if !ok {
+		s = m.newLogService(up)
+		m.services[info.ID] = s
+	}
+	s.holders[holder] = struct{}{}
+	return s, nil
+}
+
+func (m *Manager) hold(upstreamID uint64, holder string) (*LogService, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.services[upstreamID]
+	if ok && upstreamID != m.defaultUpstreamID {
+		s.holders[holder] = struct{}{}
+	}
+	return s, ok
+}
+
+// Release removes the holder of the log service of the upstream, the log service
+// of a non-default upstream is closed and its data is removed if it has no holder,
+// e.g. all the changefeeds of the upstream are removed or moved to other nodes.
+func (m *Manager) Release(upstreamID uint64, holder string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.services[upstreamID]
+	if !ok || upstreamID == 0 || upstreamID == m.defaultUpstreamID {
+		return
+	}
+	delete(s.holders, holder)
+	if len(s.holders) > 0 {
+		return
+	}
+	// close the log service with the lock held, so it's not created again
+	// with the same data directory before the data is removed.
+	delete(m.services, upstreamID)
+	s.close(m.ctx)
+	if err := os.RemoveAll(s.dataDir); err != nil {
+		log.Warn("remove the data of the upstream log service failed",
+			zap.Uint64("upstreamID", upstreamID), zap.String("dataDir", s.dataDir), zap.Error(err))
+	}
+	log.Info("upstream log service is closed since it's not used",
+		zap.Uint64("upstreamID", upstreamID))
+}
+
+// DispatcherManagerHolder is the holder of the log service used by the event dispatcher manager of the changefeed.
+func DispatcherManagerHolder(changefeedID common.ChangeFeedID) string {
+	return "dispatcher-manager/" + changefeedID.String()
+}
+
+// MaintainerHolder is the holder of the log service used by the maintainer of the changefeed.
+func MaintainerHolder(changefeedID common.ChangeFeedID) string {
+	return "maintainer/" + changefeedID.String()
+}
+
+func (m *Manager) newLogService(up *upstream.Upstream) *LogService {
+	root := filepath.Join(m.dataDir, upstreamDataDir, strconv.FormatUint(up.ID, 10))
+	ctx, cancel := context.WithCancel(m.ctx)
+
+	subscriptionClient := logpuller.NewSubscriptionClient(
+		&logpuller.SubscriptionClientConfig{
+			RegionRequestWorkerPerStore: 16,
+			GrpcCompression:             config.GetGlobalServerConfig().KVClient.GrpcCompression,
+		}, up.PDClient, up.RegionCache, up.PDClock,
+		txnutil.NewLockerResolver(up.KVStorage.(tikv.Storage)), up.SecurityConfig,
+	)
+	s := &LogService{
+		UpstreamID:         up.ID,
+		Upstream:           up,
+		SchemaStore:        schemastore.New(ctx, root, subscriptionClient, up.PDClient, up.PDClock, up.KVStorage),
+		EventStore:         eventstore.NewForUpstream(ctx, root, subscriptionClient, up.PDClock),
+		subscriptionClient: subscriptionClient,
+		dataDir:            root,
+		holders:            make(map[string]struct{}),
+		cancel:             cancel,
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		g, ctx := errgroup.WithContext(ctx)
+		for _, module := range s.modules() {
+			module := module
+			g.Go(func() error {
+				return module.Run(ctx)
+			})
+		}
+		err := g.Wait()
+		if err != nil && errors.Cause(err) != context.Canceled {
+			log.Warn("upstream log service exited with error",
+				zap.Uint64("upstreamID", up.ID), zap.Error(err))
+		}
+	}()
+	log.Info("upstream log service is created",
+		zap.Uint64("upstreamID", up.ID), zap.String("dataDir", root))
+	return s
+}
+
+func (s *LogService) modules() []common.SubModule {
+	return []common.SubModule{s.subscriptionClient, s.SchemaStore, s.EventStore}
+}
+
+func (s *LogService) close(ctx context.Context) {
+	s.cancel()
+	s.wg.Wait()
+	for _, module := range s.modules() {
+		if err := module.Close(ctx); err != nil {
+			log.Warn("close upstream log service module failed",
+				zap.Uint64("upstreamID", s.UpstreamID),
+				zap.String("module", module.Name()), zap.Error(err))
+		}
+	}
+}
+
+// Name implements common.SubModule.
+func (m *Manager) Name() string {
+	return appcontext.UpstreamLogService
+}
+
+// Run implements common.SubModule.
+func (m *Manager) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Close implements common.SubModule, it closes the log services of the non-default upstreams.
+func (m *Manager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, s := range m.services {
+		if id == m.defaultUpstreamID {
+			continue
+		}
+		s.close(ctx)
+		delete(m.services, id)
+	}
+	return nil
+}
@@ -14,9 +14,15 @@
 package maintainer
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/eventlog"
 	"github.com/pingcap/ticdc/maintainer/range_checker"
+	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -37,6 +43,8 @@ type Barrier struct {
 	blockedTs         map[eventKey]*BarrierEvent
 	controller        *Controller
 	splitTableEnabled bool
+	// ddlBatch holds the done block events waiting to be scheduled together
+	ddlBatch *ddlBatch
 }
 
 // eventKey is the key of the block event,
@@ -46,12 +54,14 @@ type eventKey struct {
 	isSyncPoint bool
 }
 
-// NewBarrier create a new barrier for the changefeed
-func NewBarrier(controller *Controller, splitTableEnabled bool) *Barrier {
+// NewBarrier create a new barrier for the changefeed,
+// the done block events within the ddlBatchWindow are scheduled together, 0 disables the batch.
+func NewBarrier(controller *Controller, splitTableEnabled bool, ddlBatchWindow time.Duration) *Barrier {
 	return &Barrier{
 		blockedTs:         make(map[eventKey]*BarrierEvent),
 		controller:        controller,
 		splitTableEnabled: splitTableEnabled,
+		ddlBatch:          newDDLBatch(ddlBatchWindow),
 	}
 }
 
@@ -101,7 +111,54 @@ func (b *Barrier) HandleStatus(from node.ID,
 		&heartbeatpb.HeartBeatResponse{
 			ChangefeedID:       request.ChangefeedID,
 			DispatcherStatuses: dispatcherStatus,
+			BarrierStates:      b.barrierStates(request),
+		})
+}
+
+// barrierStates returns the block events the maintainer is waiting for of each dispatcher
+// reported the block status, so the dispatcher can tell why it's blocked for a long time.
+func (b *Barrier) barrierStates(request *heartbeatpb.BlockStatusRequest) []*heartbeatpb.DispatcherBarrierState {
+	states := make([]*heartbeatpb.DispatcherBarrierState, 0, len(request.BlockStatuses))
+	seen := make(map[common.DispatcherID]struct{}, len(request.BlockStatuses))
+	for _, status := range request.BlockStatuses {
+		dispatcherID := common.NewDispatcherIDFromPB(status.ID)
+		if _, ok := seen[dispatcherID]; ok {
+			continue
+		}
+		seen[dispatcherID] = struct{}{}
+		span := b.controller.GetTask(dispatcherID)
+		if span == nil {
+			continue
+		}
+		states = append(states, &heartbeatpb.DispatcherBarrierState{
+			DispatcherID:  status.ID,
+			BlockedEvents: b.blockedEventsOf(span),
 		})
+	}
+	return states
+}
+
+// blockedEventsOf returns the unresolved block events involving the span, ordered by commit ts.
+func (b *Barrier) blockedEventsOf(span *replica.SpanReplication) []*heartbeatpb.BlockedEvent {
+	var events []*heartbeatpb.BlockedEvent
+	for _, event := range b.blockedTs {
+		if !event.influences(span) {
+			continue
+		}
+		events = append(events, &heartbeatpb.BlockedEvent{
+			CommitTs:    event.commitTs,
+			IsSyncPoint: event.isSyncPoint,
+			Selected:    event.selected,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].CommitTs != events[j].CommitTs {
+			return events[i].CommitTs < events[j].CommitTs
+		}
+		// the ddl is handled before the sync point at the same commit ts
+		return !events[i].IsSyncPoint && events[j].IsSyncPoint
+	})
+	return events
 }
 
 // HandleBootstrapResponse rebuild the block event from the bootstrap response
@@ -136,6 +193,9 @@ func (b *Barrier) HandleBootstrapResponse(bootstrapRespMap map[node.ID]*heartbea
 			event.markDispatcherEventDone(common.NewDispatcherIDFromPB(span.ID))
 		}
 	}
+	// the dispatchers which have reported the block events to the previous maintainer
+	// don't need to report again
+	b.restoreCoverages(b.controller.restoredBarriers)
 	// Here we iter the block event, to check each whether each blockTable each the target state.
 	//
 	// Because the maintainer is restarted, some dispatcher may finish push forward the ddl state
@@ -191,12 +251,61 @@ func (b *Barrier) HandleBootstrapResponse(bootstrapRespMap map[node.ID]*heartbea
 	}
 }
 
+// restoreCoverages resumes the coverages of the block events which are still blocking
+// some dispatchers after the maintainer is restarted.
+func (b *Barrier) restoreCoverages(coverages []*heartbeatpb.BarrierCoverage) {
+	for _, coverage := range coverages {
+		event, ok := b.blockedTs[getEventKey(coverage.BlockTs, coverage.IsSyncPoint)]
+		// the coverage is meaningless if the range checker of the event is changed
+		if !ok || event.rangeChecker == nil || event.dynamicSplitEnabled != coverage.DynamicSplit {
+			continue
+		}
+		for _, span := range coverage.Covered {
+			event.rangeChecker.AddSubRange(span.TableID, span.StartKey, span.EndKey)
+		}
+		log.Info("resume the coverage of the block event",
+			zap.String("changefeed", event.cfID.Name()),
+			zap.Uint64("blockTs", coverage.BlockTs),
+			zap.Bool("syncPoint", coverage.IsSyncPoint),
+			zap.Int("spans", len(coverage.Covered)))
+	}
+}
+
+// Coverages returns the coverages of the block events which are partially reported,
+// they are reported to the coordinator to resume the barriers if the maintainer is restarted.
+func (b *Barrier) Coverages() []*heartbeatpb.BarrierCoverage {
+	var coverages []*heartbeatpb.BarrierCoverage
+	for key, event := range b.blockedTs {
+		if event.selected || event.rangeChecker == nil || event.allDispatcherReported() {
+			continue
+		}
+		covered := event.rangeChecker.Coverage()
+		if len(covered) == 0 {
+			continue
+		}
+		coverages = append(coverages, &heartbeatpb.BarrierCoverage{
+			BlockTs:      key.blockTs,
+			IsSyncPoint:  key.isSyncPoint,
+			DynamicSplit: event.dynamicSplitEnabled,
+			Covered:      covered,
+		})
+	}
+	return coverages
+}
+
 // Resend resends the message to the dispatcher manger, the pass action is handle here
 func (b *Barrier) Resend() []*messaging.TargetMessage {
+	// schedule the batched block events if no more events come in the window
+	b.ddlBatch.flushIfExpired()
 	var msgs []*messaging.TargetMessage
-	for _, event := range b.blockedTs {
-		// todo: we can limit the number of messages to send in one round here
-		msgs = append(msgs, event.resend()...)
+	// the actions of the events in one round are sent to each node in one message
+	for _, round := range b.ddlBatch.rounds(b.blockedTs) {
+		var roundMsgs []*messaging.TargetMessage
+		for _, event := range round {
+			// todo: we can limit the number of messages to send in one round here
+			roundMsgs = append(roundMsgs, event.resend()...)
+		}
+		msgs = append(msgs, mergeActionMessages(roundMsgs)...)
 	}
 	return msgs
 }
@@ -205,6 +314,11 @@ func (b *Barrier) Resend() []*messaging.TargetMessage {
 // currently, when the block event is a create table event, we should block the checkpoint ts forwarding
 // because on the
 func (b *Barrier) ShouldBlockCheckpointTs() bool {
+	// the batched block events are acked but not scheduled yet, the checkpoint ts can't
+	// pass them, otherwise the tables are lost if the maintainer is restarted.
+	if !b.ddlBatch.empty() {
+		return true
+	}
 	for _, event := range b.blockedTs {
 		if event.hasNewTable {
 			return true
@@ -293,6 +407,7 @@ func (b *Barrier) handleBlockState(changefeedID common.ChangeFeedID,
 	// it's not a blocked event, it must be sent by table event trigger dispatcher
 	// and the ddl already synced to downstream , e.g.: create table, drop table
 	// if ack failed, dispatcher will send a heartbeat again, so we do not need to care about resend message here
+	b.ddlBatch.flushIfConflict(blockState)
 	event := NewBlockEvent(changefeedID, b.controller, blockState, b.splitTableEnabled)
 	// mark the event as selected, so we do not need to wait for all dispatchers to report the event
 	// and make the rangeChecker always return true
@@ -307,6 +422,8 @@ func (b *Barrier) getOrInsertNewEvent(changefeedID common.ChangeFeedID, key even
 ) *BarrierEvent {
 	event, ok := b.blockedTs[key]
 	if !ok {
+		// the new event may depend on the tables scheduled by the batched events
+		b.ddlBatch.flushIfConflict(blockState)
 		event = NewBlockEvent(changefeedID, b.controller, blockState, b.splitTableEnabled)
 		b.blockedTs[key] = event
 	}
@@ -325,7 +442,14 @@ func (b *Barrier) checkEvent(be *BarrierEvent,
 			zap.Uint64("committs", be.commitTs))
 		// already selected a dispatcher to write, now all dispatchers reported the block event
 		delete(b.blockedTs, getEventKey(be.commitTs, be.isSyncPoint))
-		be.scheduleBlockEvent()
+		kind := "ddl"
+		if be.isSyncPoint {
+			kind = "syncpoint"
+		}
+		b.controller.operatorController.EventLog().Record(eventlog.ReasonBarrierResolved,
+			fmt.Sprintf("the %s at %d is written by dispatcher %s and passed by all the blocked dispatchers",
+				kind, be.commitTs, be.writerDispatcher))
+		b.ddlBatch.add(be)
 		return nil
 	}
 	return be.onAllDispatcherReportedBlockEvent(dispatchers)
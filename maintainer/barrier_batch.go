@@ -0,0 +1,232 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// ddlBatch collects the block events which are done by all dispatchers but not scheduled yet,
+// the events are scheduled together in one round when the batch is flushed. A storm of ddls,
+// such as creating thousands of tables, adds or removes the tables in a few rounds instead of
+// one round per ddl.
+//
+// Only the contiguous events affecting disjoint tables within the window are batched, an event
+// which conflicts with the batch flushes it first, so the tables are always scheduled in the
+// order of the ddls. The unresolved events are grouped by the same rule, the write and pass
+// actions of a group are sent to each node in one message, see rounds.
+type ddlBatch struct {
+	// window is the max commit ts distance of the events in one batch, 0 means no batch.
+	window time.Duration
+
+	events []*BarrierEvent
+	// tables are the tables affected by the events in the batch
+	tables map[int64]struct{}
+	// startTs is the commit ts of the first event in the batch
+	startTs uint64
+	// startTime is when the first event is added to the batch,
+	// it's used to flush the batch if no more events come.
+	startTime time.Time
+}
+
+func newDDLBatch(window time.Duration) *ddlBatch {
+	return &ddlBatch{
+		window: window,
+		tables: make(map[int64]struct{}),
+	}
+}
+
+// empty returns true if there is no event waiting to be scheduled.
+func (b *ddlBatch) empty() bool {
+	return len(b.events) == 0
+}
+
+// add adds the done event to the batch, the batch is flushed first if the event can't join it.
+// The event is scheduled directly if the batch is disabled or the event can't be batched.
+func (b *ddlBatch) add(event *BarrierEvent) {
+	if b.window <= 0 {
+		event.scheduleBlockEvent()
+		return
+	}
+	tables, ok := affectedTables(event.blockedDispatchers, event.dropDispatchers, event.newTables, event.schemaIDChange)
+	if !ok || event.isSyncPoint {
+		b.flush()
+		event.scheduleBlockEvent()
+		return
+	}
+	if !b.empty() && (conflict(b.tables, tables) || outOfWindow(b.window, b.startTs, event.commitTs)) {
+		b.flush()
+	}
+	if b.empty() {
+		b.startTs = event.commitTs
+		b.startTime = time.Now()
+	}
+	b.events = append(b.events, event)
+	for _, id := range tables {
+		b.tables[id] = struct{}{}
+	}
+}
+
+// flushIfConflict flushes the batch if the new block event depends on the scheduling result of
+// the events in the batch, it must be called before the new event is created.
+func (b *ddlBatch) flushIfConflict(state *heartbeatpb.State) {
+	if b.empty() {
+		return
+	}
+	tables, ok := affectedTables(state.BlockTables, state.NeedDroppedTables, state.NeedAddedTables, state.UpdatedSchemas)
+	if !ok || state.IsSyncPoint || conflict(b.tables, tables) {
+		b.flush()
+	}
+}
+
+// flushIfExpired flushes the batch if it waits longer than the window.
+func (b *ddlBatch) flushIfExpired() {
+	if !b.empty() && time.Since(b.startTime) >= b.window {
+		b.flush()
+	}
+}
+
+// flush schedules all events in the batch in the order of their commit ts.
+func (b *ddlBatch) flush() {
+	if b.empty() {
+		return
+	}
+	log.Info("schedule the batched block events",
+		zap.String("changefeed", b.events[0].cfID.Name()),
+		zap.Int("eventCount", len(b.events)),
+		zap.Int("tableCount", len(b.tables)),
+		zap.Uint64("startTs", b.startTs),
+		zap.Uint64("endTs", b.events[len(b.events)-1].commitTs))
+	for _, event := range b.events {
+		event.scheduleBlockEvent()
+	}
+	b.events = b.events[:0]
+	b.tables = make(map[int64]struct{})
+}
+
+// rounds groups the unresolved block events into barrier rounds in the order of commit ts, the
+// contiguous events affecting disjoint tables within the window are in the same round. Each event
+// is a round if the batch is disabled.
+func (b *ddlBatch) rounds(events map[eventKey]*BarrierEvent) [][]*BarrierEvent {
+	sorted := make([]*BarrierEvent, 0, len(events))
+	for _, event := range events {
+		sorted = append(sorted, event)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].commitTs != sorted[j].commitTs {
+			return sorted[i].commitTs < sorted[j].commitTs
+		}
+		// the ddl is handled before the sync point at the same commit ts
+		return !sorted[i].isSyncPoint && sorted[j].isSyncPoint
+	})
+
+	var (
+		rounds [][]*BarrierEvent
+		// tables are the tables affected by the last round, nil if it can't be joined
+		tables  map[int64]struct{}
+		startTs uint64
+	)
+	for _, event := range sorted {
+		affected, ok := affectedTables(event.blockedDispatchers, event.dropDispatchers, event.newTables, event.schemaIDChange)
+		batchable := b.window > 0 && ok && !event.isSyncPoint
+		if !batchable {
+			rounds = append(rounds, []*BarrierEvent{event})
+			tables = nil
+			continue
+		}
+		if tables == nil || conflict(tables, affected) || outOfWindow(b.window, startTs, event.commitTs) {
+			rounds = append(rounds, nil)
+			tables = make(map[int64]struct{})
+			startTs = event.commitTs
+		}
+		rounds[len(rounds)-1] = append(rounds[len(rounds)-1], event)
+		for _, id := range affected {
+			tables[id] = struct{}{}
+		}
+	}
+	return rounds
+}
+
+// mergeActionMessages merges the heartbeat responses sent to the same node into one message,
+// the dispatcher statuses are kept in order.
+func mergeActionMessages(msgs []*messaging.TargetMessage) []*messaging.TargetMessage {
+	if len(msgs) <= 1 {
+		return msgs
+	}
+	merged := make(map[node.ID]*heartbeatpb.HeartBeatResponse, len(msgs))
+	result := make([]*messaging.TargetMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		resp := msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+		if m, ok := merged[msg.To]; ok {
+			m.DispatcherStatuses = append(m.DispatcherStatuses, resp.DispatcherStatuses...)
+			continue
+		}
+		merged[msg.To] = resp
+		result = append(result, msg)
+	}
+	return result
+}
+
+func conflict(batched map[int64]struct{}, tables []int64) bool {
+	for _, id := range tables {
+		if _, ok := batched[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func outOfWindow(window time.Duration, startTs, commitTs uint64) bool {
+	if commitTs < startTs {
+		return true
+	}
+	return oracle.GetTimeFromTS(commitTs).Sub(oracle.GetTimeFromTS(startTs)) > window
+}
+
+// affectedTables returns the tables affected by a block event, the table trigger event dispatcher
+// is excluded since it's involved in almost all ddls. It returns false if the event affects a
+// whole schema or all tables, which can't be batched.
+func affectedTables(blocked, dropped *heartbeatpb.InfluencedTables,
+	added []*heartbeatpb.Table, schemaChanges []*heartbeatpb.SchemaIDChange,
+) ([]int64, bool) {
+	var tables []int64
+	for _, influenced := range []*heartbeatpb.InfluencedTables{blocked, dropped} {
+		if influenced == nil {
+			continue
+		}
+		if influenced.InfluenceType != heartbeatpb.InfluenceType_Normal {
+			return nil, false
+		}
+		for _, id := range influenced.TableIDs {
+			if id != heartbeatpb.DDLSpan.TableID {
+				tables = append(tables, id)
+			}
+		}
+	}
+	for _, table := range added {
+		tables = append(tables, table.TableID)
+	}
+	for _, change := range schemaChanges {
+		tables = append(tables, change.TableID)
+	}
+	return tables, true
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/range_checker"
+	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/messaging"
@@ -78,7 +79,9 @@ func NewBlockEvent(cfID common.ChangeFeedID, controller *Controller,
 			if dynamicSplitEnabled {
 				event.rangeChecker = range_checker.NewTableSpanRangeChecker(status.BlockTables.TableIDs)
 			} else {
-				event.rangeChecker = range_checker.NewTableCountChecker(len(status.BlockTables.TableIDs))
+				// a multiple tables ddl may contain the same table more than once,
+				// count the distinct tables, otherwise the checker never be fully covered
+				event.rangeChecker = range_checker.NewTableCountChecker(distinctTableCount(status.BlockTables.TableIDs))
 			}
 		case heartbeatpb.InfluenceType_DB:
 			// add table trigger event dispatcher for InfluenceType_DB:
@@ -117,6 +120,14 @@ func NewBlockEvent(cfID common.ChangeFeedID, controller *Controller,
 	return event
 }
 
+func distinctTableCount(tableIDs []int64) int {
+	tables := make(map[int64]struct{}, len(tableIDs))
+	for _, id := range tableIDs {
+		tables[id] = struct{}{}
+	}
+	return len(tables)
+}
+
 // onAllDispatcherReportedBlockEvent is called when all dispatcher reported the block event
 // it will select a dispatcher as the writer, reset the range checker ,and move the event to the selected state
 // returns the dispatcher status to the dispatcher manager
@@ -206,6 +217,11 @@ func (be *BarrierEvent) scheduleBlockEvent() {
 			zap.Int64("table", change.TableID))
 		be.controller.UpdateSchemaID(change.TableID, change.NewSchemaID)
 	}
+	// the tables may be renamed by the ddl, their groups may be decided by the names
+	if !be.isSyncPoint && be.blockedDispatchers != nil &&
+		be.blockedDispatchers.InfluenceType == heartbeatpb.InfluenceType_Normal {
+		be.controller.UpdateTableGroups(be.blockedDispatchers.TableIDs, be.commitTs)
+	}
 }
 
 func (be *BarrierEvent) markDispatcherEventDone(dispatcherID common.DispatcherID) {
@@ -219,6 +235,28 @@ func (be *BarrierEvent) markDispatcherEventDone(dispatcherID common.DispatcherID
 	be.rangeChecker.AddSubRange(replicaSpan.Span.TableID, replicaSpan.Span.StartKey, replicaSpan.Span.EndKey)
 }
 
+// influences returns true if the dispatcher of the span is blocked by the event.
+func (be *BarrierEvent) influences(span *replica.SpanReplication) bool {
+	if be.blockedDispatchers == nil {
+		return false
+	}
+	switch be.blockedDispatchers.InfluenceType {
+	case heartbeatpb.InfluenceType_All:
+		return true
+	case heartbeatpb.InfluenceType_DB:
+		// the table trigger event dispatcher is always blocked by the schema level event
+		return span.GetSchemaID() == be.blockedDispatchers.SchemaID ||
+			span.Span.TableID == heartbeatpb.DDLSpan.TableID
+	default:
+		for _, id := range be.blockedDispatchers.TableIDs {
+			if id == span.Span.TableID {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func (be *BarrierEvent) allDispatcherReported() bool {
 	return be.rangeChecker.IsFullyCovered()
 }
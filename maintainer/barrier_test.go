@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -41,14 +42,14 @@ func TestOneBlockEvent(t *testing.T) {
 			CheckpointTs:    1,
 		}, "node1")
 	controller := NewController(cfID, 1, nil, tsoClient,
-		nil, nil, nil, ddlSpan, 1000, 0)
+		nil, nil, nil, ddlSpan, 1000, 0, 0)
 	startTs := uint64(10)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, startTs)
 	stm := controller.GetTasksByTableIDs(1)[0]
 	controller.replicationDB.BindSpanToNode("", "node1", stm)
 	controller.replicationDB.MarkSpanReplicating(stm)
 
-	barrier := NewBarrier(controller, false)
+	barrier := NewBarrier(controller, false, 0)
 	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
 		ChangefeedID: cfID.ToPB(),
 		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
@@ -169,7 +170,7 @@ func TestNormalBlock(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	var blockedDispatcherIDS []*heartbeatpb.DispatcherID
 	for id := 1; id < 4; id++ {
 		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 10)
@@ -186,7 +187,7 @@ func TestNormalBlock(t *testing.T) {
 	dropID := selectedRep.Span.TableID
 
 	newSpan := &heartbeatpb.Table{TableID: 10, SchemaID: 1}
-	barrier := NewBarrier(controller, false)
+	barrier := NewBarrier(controller, false, 0)
 
 	// first node block request
 	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
@@ -231,6 +232,11 @@ func TestNormalBlock(t *testing.T) {
 	require.Len(t, resp.DispatcherStatuses, 1)
 	require.True(t, resp.DispatcherStatuses[0].Ack.CommitTs == 10)
 	require.Len(t, resp.DispatcherStatuses[0].InfluencedDispatchers.DispatcherIDs, 2)
+	// the maintainer is waiting for other dispatchers reporting the event
+	require.Len(t, resp.BarrierStates, 2)
+	for _, state := range resp.BarrierStates {
+		require.Equal(t, []*heartbeatpb.BlockedEvent{{CommitTs: 10}}, state.BlockedEvents)
+	}
 
 	// other node block request
 	msg = barrier.HandleStatus("node2", &heartbeatpb.BlockStatusRequest{
@@ -264,6 +270,10 @@ func TestNormalBlock(t *testing.T) {
 	require.True(t, event.writerDispatcher == selectDispatcherID)
 	// all dispatcher reported, the reported status is reset
 	require.False(t, event.rangeChecker.IsFullyCovered())
+	// the maintainer is waiting for the writer
+	resp = msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+	require.Len(t, resp.BarrierStates, 1)
+	require.Equal(t, []*heartbeatpb.BlockedEvent{{CommitTs: 10, Selected: true}}, resp.BarrierStates[0].BlockedEvents)
 
 	// repeated status
 	barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
@@ -357,7 +367,7 @@ func TestNormalBlockWithTableTrigger(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	var blockedDispatcherIDS []*heartbeatpb.DispatcherID
 	for id := 1; id < 3; id++ {
 		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 10)
@@ -368,7 +378,7 @@ func TestNormalBlockWithTableTrigger(t *testing.T) {
 	}
 
 	newSpan := &heartbeatpb.Table{TableID: 10, SchemaID: 1}
-	barrier := NewBarrier(controller, false)
+	barrier := NewBarrier(controller, false, 0)
 
 	// first node block request
 	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
@@ -506,7 +516,7 @@ func TestSchemaBlock(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 2}, 1)
@@ -523,7 +533,7 @@ func TestSchemaBlock(t *testing.T) {
 	}
 
 	newTable := &heartbeatpb.Table{TableID: 10, SchemaID: 2}
-	barrier := NewBarrier(controller, true)
+	barrier := NewBarrier(controller, true, 0)
 
 	// first dispatcher  block request
 	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
@@ -710,7 +720,7 @@ func TestSyncPointBlock(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 2}, 1)
 	controller.AddNewTable(commonEvent.Table{SchemaID: 2, TableID: 3}, 1)
@@ -727,7 +737,7 @@ func TestSyncPointBlock(t *testing.T) {
 	controller.replicationDB.BindSpanToNode("node1", "node2", selectedRep)
 
 	newSpan := &heartbeatpb.Table{TableID: 10, SchemaID: 2}
-	barrier := NewBarrier(controller, true)
+	barrier := NewBarrier(controller, true, 0)
 	// first dispatcher  block request
 	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
 		ChangefeedID: cfID.ToPB(),
@@ -893,8 +903,8 @@ func TestNonBlocked(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
-	barrier := NewBarrier(controller, false)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	barrier := NewBarrier(controller, false, 0)
 
 	var blockedDispatcherIDS []*heartbeatpb.DispatcherID
 	for id := 1; id < 4; id++ {
@@ -930,6 +940,154 @@ func TestNonBlocked(t *testing.T) {
 	require.Equal(t, 2, barrier.controller.replicationDB.GetAbsentSize(), 2)
 }
 
+func TestNonBlockedBatch(t *testing.T) {
+	setNodeManagerAndMessageCenter()
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	barrier := NewBarrier(controller, false, time.Second)
+
+	handle := func(physical int64, state *heartbeatpb.State) {
+		state.BlockTs = oracle.ComposeTS(physical, 0)
+		msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+			ChangefeedID: cfID.ToPB(),
+			BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
+				{ID: tableTriggerEventDispatcherID.ToPB(), State: state},
+			},
+		})
+		// the event is acked even if it's batched
+		require.NotNil(t, msg)
+		resp := msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+		require.Equal(t, state.BlockTs, resp.DispatcherStatuses[0].Ack.CommitTs)
+	}
+	createTable := func(physical int64, tableID int64) {
+		handle(physical, &heartbeatpb.State{
+			NeedAddedTables: []*heartbeatpb.Table{{TableID: tableID, SchemaID: 1}},
+		})
+	}
+
+	// the create tables in the window are batched
+	createTable(1000, 1)
+	createTable(1100, 2)
+	require.Equal(t, 0, controller.replicationDB.GetAbsentSize())
+	require.True(t, barrier.ShouldBlockCheckpointTs())
+
+	// drop the table in the batch, the batch must be scheduled first
+	handle(1200, &heartbeatpb.State{
+		NeedDroppedTables: &heartbeatpb.InfluencedTables{
+			TableIDs:      []int64{1},
+			InfluenceType: heartbeatpb.InfluenceType_Normal,
+		},
+	})
+	require.Equal(t, 2, controller.replicationDB.GetAbsentSize())
+	require.Len(t, barrier.ddlBatch.events, 1)
+
+	// the event out of the window starts a new batch
+	createTable(5000, 3)
+	require.Equal(t, 1, controller.replicationDB.GetAbsentSize())
+	require.Len(t, barrier.ddlBatch.events, 1)
+
+	// a schema level ddl is not batched
+	handle(5100, &heartbeatpb.State{
+		NeedDroppedTables: &heartbeatpb.InfluencedTables{
+			SchemaID:      2,
+			InfluenceType: heartbeatpb.InfluenceType_DB,
+		},
+	})
+	require.Equal(t, 2, controller.replicationDB.GetAbsentSize())
+	require.True(t, barrier.ddlBatch.empty())
+	require.False(t, barrier.ShouldBlockCheckpointTs())
+
+	// the batch is scheduled if no more events come in the window
+	createTable(6000, 4)
+	require.Equal(t, 2, controller.replicationDB.GetAbsentSize())
+	barrier.Resend()
+	require.Equal(t, 2, controller.replicationDB.GetAbsentSize())
+	barrier.ddlBatch.startTime = time.Now().Add(-time.Second)
+	barrier.Resend()
+	require.Equal(t, 3, controller.replicationDB.GetAbsentSize())
+	require.False(t, barrier.ShouldBlockCheckpointTs())
+}
+
+func TestBlockEventRounds(t *testing.T) {
+	setNodeManagerAndMessageCenter()
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	var dispatcherIDs []*heartbeatpb.DispatcherID
+	for id := 1; id < 4; id++ {
+		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 10)
+		stm := controller.GetTasksByTableIDs(int64(id))[0]
+		dispatcherIDs = append(dispatcherIDs, stm.ID.ToPB())
+		controller.replicationDB.BindSpanToNode("", "node1", stm)
+		controller.replicationDB.MarkSpanReplicating(stm)
+	}
+
+	newBarrier := func(window time.Duration) *Barrier {
+		barrier := NewBarrier(controller, false, window)
+		// each ddl truncates a table, the dispatcher of the table is selected as the writer
+		block := func(physical int64, tableID int64, dispatcherID *heartbeatpb.DispatcherID) {
+			barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+				ChangefeedID: cfID.ToPB(),
+				BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
+					{
+						ID: dispatcherID,
+						State: &heartbeatpb.State{
+							IsBlocked: true,
+							BlockTs:   oracle.ComposeTS(physical, 0),
+							BlockTables: &heartbeatpb.InfluencedTables{
+								InfluenceType: heartbeatpb.InfluenceType_Normal,
+								TableIDs:      []int64{tableID},
+							},
+						},
+					},
+				},
+			})
+		}
+		block(1000, 1, dispatcherIDs[0])
+		block(1100, 2, dispatcherIDs[1])
+		// the table is affected by the previous ddl, it starts a new round
+		block(1200, 1, dispatcherIDs[0])
+		// out of the window
+		block(5000, 3, dispatcherIDs[2])
+		require.Len(t, barrier.blockedTs, 4)
+		return barrier
+	}
+
+	statusCounts := func(msgs []*messaging.TargetMessage) []int {
+		var counts []int
+		for _, msg := range msgs {
+			counts = append(counts, len(msg.Message[0].(*heartbeatpb.HeartBeatResponse).DispatcherStatuses))
+		}
+		return counts
+	}
+
+	// each write action is sent in one message if the batch is disabled
+	require.Equal(t, []int{1, 1, 1, 1}, statusCounts(newBarrier(0).Resend()))
+	// the write actions of the events in one round are sent together
+	msgs := newBarrier(time.Second).Resend()
+	require.Equal(t, []int{2, 1, 1}, statusCounts(msgs))
+	statuses := msgs[0].Message[0].(*heartbeatpb.HeartBeatResponse).DispatcherStatuses
+	require.Equal(t, oracle.ComposeTS(1000, 0), statuses[0].Action.CommitTs)
+	require.Equal(t, oracle.ComposeTS(1100, 0), statuses[1].Action.CommitTs)
+}
+
 func TestUpdateCheckpointTs(t *testing.T) {
 	setNodeManagerAndMessageCenter()
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
@@ -944,9 +1102,9 @@ func TestUpdateCheckpointTs(t *testing.T) {
 			CheckpointTs:    1,
 		}, "node1")
 	controller := NewController(cfID, 1, nil, tsoClient,
-		nil, nil, nil, ddlSpan, 1000, 0)
+		nil, nil, nil, ddlSpan, 1000, 0, 0)
 
-	barrier := NewBarrier(controller, false)
+	barrier := NewBarrier(controller, false, 0)
 	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
 		ChangefeedID: cfID.ToPB(),
 		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
@@ -1000,7 +1158,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	var dispatcherIDs []*heartbeatpb.DispatcherID
 	for id := 1; id < 4; id++ {
 		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 2)
@@ -1011,7 +1169,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 	}
 
 	// two waiting dispatcher
-	barrier := NewBarrier(controller, false)
+	barrier := NewBarrier(controller, false, 0)
 	barrier.HandleBootstrapResponse(map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
 		"nod1": {
 			ChangefeedID: cfID.ToPB(),
@@ -1050,7 +1208,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 	require.True(t, event.allDispatcherReported())
 
 	// one waiting dispatcher, and one writing
-	barrier = NewBarrier(controller, false)
+	barrier = NewBarrier(controller, false, 0)
 	barrier.HandleBootstrapResponse(map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
 		"nod1": {
 			ChangefeedID: cfID.ToPB(),
@@ -1088,7 +1246,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 	require.False(t, event.writerDispatcherAdvanced)
 
 	// two done dispatchers
-	barrier = NewBarrier(controller, false)
+	barrier = NewBarrier(controller, false, 0)
 	barrier.HandleBootstrapResponse(map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
 		"nod1": {
 			ChangefeedID: cfID.ToPB(),
@@ -1126,7 +1284,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 	require.True(t, event.writerDispatcherAdvanced)
 
 	// nil, none stage
-	barrier = NewBarrier(controller, false)
+	barrier = NewBarrier(controller, false, 0)
 	barrier.HandleBootstrapResponse(map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
 		"nod1": {
 			ChangefeedID: cfID.ToPB(),
@@ -1153,6 +1311,77 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 	require.Nil(t, event)
 }
 
+func TestRestoreBarrierCoverages(t *testing.T) {
+	setNodeManagerAndMessageCenter()
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	var dispatcherIDs []*heartbeatpb.DispatcherID
+	for id := 1; id < 4; id++ {
+		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 2)
+		stm := controller.GetTasksByTableIDs(int64(id))[0]
+		dispatcherIDs = append(dispatcherIDs, stm.ID.ToPB())
+		controller.replicationDB.BindSpanToNode("", "node1", stm)
+		controller.replicationDB.MarkSpanReplicating(stm)
+	}
+	waiting := func(ids ...*heartbeatpb.DispatcherID) map[node.ID]*heartbeatpb.MaintainerBootstrapResponse {
+		spans := make([]*heartbeatpb.BootstrapTableSpan, 0, len(ids))
+		for _, id := range ids {
+			spans = append(spans, &heartbeatpb.BootstrapTableSpan{
+				ID: id,
+				BlockState: &heartbeatpb.State{
+					IsBlocked: true,
+					BlockTs:   6,
+					BlockTables: &heartbeatpb.InfluencedTables{
+						InfluenceType: heartbeatpb.InfluenceType_All,
+					},
+					Stage: heartbeatpb.BlockStage_WAITING,
+				},
+			})
+		}
+		return map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
+			"node1": {ChangefeedID: cfID.ToPB(), Spans: spans},
+		}
+	}
+
+	// two dispatchers reported the event to the previous maintainer
+	barrier := NewBarrier(controller, false, 0)
+	barrier.HandleBootstrapResponse(waiting(dispatcherIDs[0], dispatcherIDs[1]))
+	coverages := barrier.Coverages()
+	require.Len(t, coverages, 1)
+	require.Equal(t, uint64(6), coverages[0].BlockTs)
+	require.False(t, coverages[0].DynamicSplit)
+	require.Len(t, coverages[0].Covered, 2)
+
+	// the coverage is resumed by the new maintainer
+	controller.restoredBarriers = coverages
+	barrier = NewBarrier(controller, false, 0)
+	barrier.HandleBootstrapResponse(waiting(dispatcherIDs[2]))
+	event := barrier.blockedTs[getEventKey(6, false)]
+	require.NotNil(t, event)
+	require.Len(t, event.rangeChecker.Coverage(), 3)
+	require.False(t, event.allDispatcherReported())
+	event.markDispatcherEventDone(tableTriggerEventDispatcherID)
+	require.True(t, event.allDispatcherReported())
+	require.Empty(t, barrier.Coverages())
+
+	// the coverage of the different range checker is ignored
+	coverages[0].DynamicSplit = true
+	controller.restoredBarriers = coverages
+	barrier = NewBarrier(controller, false, 0)
+	barrier.HandleBootstrapResponse(waiting(dispatcherIDs[2]))
+	event = barrier.blockedTs[getEventKey(6, false)]
+	require.Len(t, event.rangeChecker.Coverage(), 1)
+}
+
 func TestSyncPointBlockPerf(t *testing.T) {
 	setNodeManagerAndMessageCenter()
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
@@ -1165,8 +1394,8 @@ func TestSyncPointBlockPerf(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
-	barrier := NewBarrier(controller, true)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	barrier := NewBarrier(controller, true, 0)
 	for id := 1; id < 1000; id++ {
 		controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: int64(id)}, 1)
 	}
@@ -0,0 +1,92 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"sync"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler"
+)
+
+// nodeDispatcherLimit is the max dispatchers of a node and the dispatchers of the other changefeeds on it.
+type nodeDispatcherLimit struct {
+	maxDispatchers int64
+	// others is the number of the dispatchers of the other changefeeds on the node when it's reported.
+	others int64
+}
+
+// spanCapacities are the max dispatchers reported by the dispatcher managers of the changefeed,
+// they are updated by the maintainer event loop and read by the schedulers.
+type spanCapacities struct {
+	mu    sync.RWMutex
+	nodes map[node.ID]nodeDispatcherLimit
+}
+
+func newSpanCapacities() *spanCapacities {
+	return &spanCapacities{nodes: make(map[node.ID]nodeDispatcherLimit)}
+}
+
+// update updates the capacity of the node, own is the number of the spans of the changefeed on the node.
+func (c *spanCapacities) update(id node.ID, capacity *heartbeatpb.NodeCapacity, own int) {
+	if capacity == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes[id] = nodeDispatcherLimit{
+		maxDispatchers: capacity.MaxDispatchers,
+		others:         max(capacity.DispatcherCount-int64(own), 0),
+	}
+}
+
+func (c *spanCapacities) remove(id node.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.nodes, id)
+}
+
+// newSpanCapacity creates the capacity of the nodes for a schedule round, spans is the number of
+// the spans of the changefeed on each node.
+func (c *spanCapacities) newSpanCapacity(spans map[node.ID]int) scheduler.NodeCapacity[*replica.SpanReplication] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	limits := make(map[node.ID]nodeDispatcherLimit, len(c.nodes))
+	for id, limit := range c.nodes {
+		limits[id] = limit
+	}
+	return &spanCapacity{limits: limits, spans: spans}
+}
+
+// spanCapacity limits the spans placed on the nodes by the max dispatchers of the nodes,
+// the nodes which don't report the capacity are not limited. The dispatchers of the other
+// changefeeds are counted when the node reports the capacity, so the limit is approximate
+// when several changefeeds are scheduling the spans to the node at the same time.
+type spanCapacity struct {
+	limits map[node.ID]nodeDispatcherLimit
+	spans  map[node.ID]int
+}
+
+// Fits implements scheduler.NodeCapacity.
+func (c *spanCapacity) Fits(_ *replica.SpanReplication, id node.ID) bool {
+	limit, ok := c.limits[id]
+	return !ok || limit.maxDispatchers <= 0 || limit.others+int64(c.spans[id]) < limit.maxDispatchers
+}
+
+// Add implements scheduler.NodeCapacity.
+func (c *spanCapacity) Add(_ *replica.SpanReplication, id node.ID) {
+	c.spans[id]++
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/messaging"
+)
+
+// epochMessageCenter stamps the epoch of the maintainer on the commands sent to the
+// dispatcher managers, so a dispatcher manager can reject the commands of a stale
+// maintainer which is still running after a new one takes over the changefeed.
+type epochMessageCenter struct {
+	messaging.MessageCenter
+	epoch uint64
+}
+
+func newEpochMessageCenter(mc messaging.MessageCenter, epoch uint64) messaging.MessageCenter {
+	if epoch == 0 {
+		return mc
+	}
+	return &epochMessageCenter{MessageCenter: mc, epoch: epoch}
+}
+
+func (c *epochMessageCenter) SendCommand(cmd *messaging.TargetMessage) error {
+	for _, msg := range cmd.Message {
+		setMaintainerEpoch(msg, c.epoch)
+	}
+	return c.MessageCenter.SendCommand(cmd)
+}
+
+// setMaintainerEpoch sets the epoch of the message if it's a command to the dispatcher manager.
+// The messages may be resent, the field is only written when it's changed to avoid racing
+// with the message center which is still encoding the message sent last time.
+func setMaintainerEpoch(msg messaging.IOTypeT, epoch uint64) {
+	switch m := msg.(type) {
+	case *heartbeatpb.HeartBeatResponse:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	case *heartbeatpb.ScheduleDispatcherRequest:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	case *heartbeatpb.MaintainerBootstrapRequest:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	case *heartbeatpb.MaintainerPostBootstrapRequest:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	case *heartbeatpb.MaintainerCloseRequest:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	case *heartbeatpb.QuiesceRequest:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	case *heartbeatpb.ResendTableSchemaRequest:
+		if m.MaintainerEpoch != epoch {
+			m.MaintainerEpoch = epoch
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/stretchr/testify/require"
+)
+
+type recordMessageCenter struct {
+	messaging.MessageCenter
+	sent []*messaging.TargetMessage
+}
+
+func (c *recordMessageCenter) SendCommand(cmd *messaging.TargetMessage) error {
+	c.sent = append(c.sent, cmd)
+	return nil
+}
+
+func TestEpochMessageCenter(t *testing.T) {
+	mc := &recordMessageCenter{}
+	// the maintainer is not fenced without an epoch
+	require.Same(t, mc, newEpochMessageCenter(mc, 0))
+
+	epochMC := newEpochMessageCenter(mc, 42)
+	resp := &heartbeatpb.HeartBeatResponse{}
+	bootstrap := &heartbeatpb.MaintainerBootstrapRequest{}
+	require.NoError(t, epochMC.SendCommand(messaging.NewSingleTargetMessage("node-1",
+		messaging.HeartbeatCollectorTopic, resp)))
+	require.NoError(t, epochMC.SendCommand(messaging.NewSingleTargetMessage("node-1",
+		messaging.DispatcherManagerManagerTopic, bootstrap)))
+
+	// the schedule requests to the same node are batched in one message
+	schedules := messaging.NewSingleTargetMessage("node-1",
+		messaging.HeartbeatCollectorTopic, &heartbeatpb.ScheduleDispatcherRequest{})
+	schedules.Message = append(schedules.Message, &heartbeatpb.ScheduleDispatcherRequest{})
+	require.NoError(t, epochMC.SendCommand(schedules))
+
+	require.Len(t, mc.sent, 3)
+	require.Equal(t, uint64(42), resp.MaintainerEpoch)
+	require.Equal(t, uint64(42), bootstrap.MaintainerEpoch)
+	for _, msg := range schedules.Message {
+		require.Equal(t, uint64(42), msg.(*heartbeatpb.ScheduleDispatcherRequest).MaintainerEpoch)
+	}
+}
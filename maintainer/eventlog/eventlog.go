@@ -0,0 +1,102 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventlog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// The reasons of the scheduling events.
+const (
+	ReasonMovingSpan      = "MovingSpan"
+	ReasonSpanMoved       = "SpanMoved"
+	ReasonSplittingSpan   = "SplittingSpan"
+	ReasonSpanSplit       = "SpanSplit"
+	ReasonMergingSpans    = "MergingSpans"
+	ReasonSpansMerged     = "SpansMerged"
+	ReasonNodeRemoved     = "NodeRemoved"
+	ReasonBarrierResolved = "BarrierResolved"
+)
+
+// DefaultCapacity is the default number of the events kept by a log.
+const DefaultCapacity = 1024
+
+// Event is a scheduling milestone of a changefeed.
+type Event struct {
+	Time    time.Time
+	Reason  string
+	Message string
+}
+
+// Log keeps the latest scheduling events of a changefeed in memory, the oldest
+// events are dropped once the capacity is reached. It's safe for concurrent use.
+type Log struct {
+	mu     sync.Mutex
+	clock  clock.Clock
+	events []Event
+	// next is the index the next event is written to
+	next int
+	full bool
+}
+
+// NewLog creates a log which keeps at most capacity events.
+func NewLog(capacity int) *Log {
+	return &Log{
+		clock:  clock.New(),
+		events: make([]Event, capacity),
+	}
+}
+
+// SetClock replaces the clock of the log, it must be called before the log is used.
+func (l *Log) SetClock(clk clock.Clock) {
+	l.clock = clk
+}
+
+// Record appends an event to the log.
+func (l *Log) Record(reason, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events[l.next] = Event{
+		Time:    l.clock.Now(),
+		Reason:  reason,
+		Message: message,
+	}
+	l.next++
+	if l.next == len(l.events) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// List returns the events recorded after since in chronological order.
+func (l *Log) List(since time.Time) []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var ordered []Event
+	if l.full {
+		ordered = append(ordered, l.events[l.next:]...)
+	}
+	ordered = append(ordered, l.events[:l.next]...)
+
+	result := make([]Event, 0, len(ordered))
+	for _, event := range ordered {
+		if event.Time.After(since) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventlog
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	clk := clock.NewMock()
+	l := NewLog(3)
+	l.SetClock(clk)
+	start := clk.Now()
+	require.Empty(t, l.List(time.Time{}))
+
+	for i := 0; i < 5; i++ {
+		clk.Add(time.Second)
+		l.Record(ReasonSpanMoved, fmt.Sprintf("event %d", i))
+	}
+	// the oldest events are dropped
+	events := l.List(time.Time{})
+	require.Len(t, events, 3)
+	for i, event := range events {
+		require.Equal(t, ReasonSpanMoved, event.Reason)
+		require.Equal(t, fmt.Sprintf("event %d", i+2), event.Message)
+		require.Equal(t, start.Add(time.Duration(i+3)*time.Second), event.Time)
+	}
+
+	// only the events after since are returned
+	events = l.List(start.Add(4 * time.Second))
+	require.Len(t, events, 1)
+	require.Equal(t, "event 4", events[0].Message)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"sync"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
+	pkgReplica "github.com/pingcap/ticdc/pkg/scheduler/replica"
+)
+
+// groupTagMatcher is a parsed group-tags rule.
+type groupTagMatcher struct {
+	matcher filter.TableMatcher
+	groupID pkgReplica.GroupID
+}
+
+// spanGroups decides the scheduling groups of the spans covering whole tables by the
+// group-by setting of the changefeed, the spans of each group are balanced separately.
+type spanGroups struct {
+	groupBy  string
+	matchers []groupTagMatcher
+
+	mu sync.RWMutex
+	// tableGroups are the tag groups of the tables, they are matched by the table names
+	// when the tables are loaded from the schema store.
+	tableGroups map[int64]pkgReplica.GroupID
+}
+
+// newSpanGroups returns nil if the spans are not grouped by the changefeed config.
+func newSpanGroups(cfg *config.ReplicaConfig) (*spanGroups, error) {
+	if cfg == nil || cfg.Scheduler == nil || cfg.Scheduler.GroupBy == "" {
+		return nil, nil
+	}
+	g := &spanGroups{
+		groupBy:     cfg.Scheduler.GroupBy,
+		tableGroups: make(map[int64]pkgReplica.GroupID),
+	}
+	if g.groupBy != config.GroupByTag {
+		return g, nil
+	}
+	for _, rule := range cfg.Scheduler.GroupTags {
+		m, err := filter.NewTableMatcher(&config.FilterConfig{Rules: rule.Matcher}, cfg.CaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		g.matchers = append(g.matchers, groupTagMatcher{
+			matcher: m,
+			groupID: pkgReplica.RegisterTagGroup(rule.Tag),
+		})
+	}
+	return g, nil
+}
+
+// needTableNames returns true if the group of the table is decided by its name,
+// and the table is not observed yet.
+func (g *spanGroups) needTableNames(tableID int64) bool {
+	if g.groupBy != config.GroupByTag {
+		return false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.tableGroups[tableID]
+	return !ok
+}
+
+// observeTables matches the tags of the tables by their names, it returns the tables
+// observed before whose groups are changed.
+func (g *spanGroups) observeTables(tables ...commonEvent.Table) []int64 {
+	if g.groupBy != config.GroupByTag {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var changed []int64
+	for _, table := range tables {
+		if table.SchemaTableName == nil {
+			continue
+		}
+		groupID := pkgReplica.DefaultGroupID
+		for _, m := range g.matchers {
+			if m.matcher.MatchTable(table.SchemaName, table.TableName) {
+				groupID = m.groupID
+				break
+			}
+		}
+		if old, ok := g.tableGroups[table.TableID]; ok && old != groupID {
+			changed = append(changed, table.TableID)
+		}
+		g.tableGroups[table.TableID] = groupID
+	}
+	return changed
+}
+
+// groupedByTag returns true if the group of the tables are decided by their names.
+func (g *spanGroups) groupedByTag() bool {
+	return g.groupBy == config.GroupByTag
+}
+
+// close releases the tags of the group-tags rules.
+func (g *spanGroups) close() {
+	for _, m := range g.matchers {
+		pkgReplica.UnregisterTagGroup(m.groupID)
+	}
+	g.matchers = nil
+}
+
+// groupID returns the group of the span covering the whole table.
+func (g *spanGroups) groupID(schemaID, tableID int64) pkgReplica.GroupID {
+	switch g.groupBy {
+	case config.GroupBySchema:
+		return pkgReplica.GenGroupID(pkgReplica.GroupSchema, schemaID)
+	case config.GroupByTag:
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+		return g.tableGroups[tableID]
+	}
+	return pkgReplica.DefaultGroupID
+}
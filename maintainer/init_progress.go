@@ -0,0 +1,128 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// The phases of the initialization of a changefeed.
+const (
+	// InitPhaseBootstrapping means the maintainer is waiting for the bootstrap responses
+	// of the nodes, or loading the tables from the schema store.
+	InitPhaseBootstrapping = "bootstrapping"
+	// InitPhaseScheduling means the spans are created, and some of them are not working yet.
+	InitPhaseScheduling = "scheduling"
+	// InitPhaseWorking means all the spans have been working once, the changefeed is initialized.
+	InitPhaseWorking = "working"
+)
+
+// InitializationProgress is the progress of the initialization of a changefeed.
+// The counts are 0 before the tables are loaded in the bootstrap.
+type InitializationProgress struct {
+	Phase string
+	// TablesLoaded is the number of the tables loaded from the schema store.
+	TablesLoaded int
+	// SpansCreated is the number of the spans to replicate, the table trigger event dispatcher is excluded.
+	SpansCreated int
+	// SpansScheduled is the number of the spans bound to a node, including the working ones.
+	SpansScheduled int
+	// SpansWorking is the number of the spans whose dispatcher is working.
+	SpansWorking   int
+	PercentWorking float64
+}
+
+// initProgress tracks the initialization of the changefeed,
+// it's updated by the maintainer event loop and read by the API.
+type initProgress struct {
+	bootstrapped atomic.Bool
+	tablesLoaded atomic.Int64
+
+	readyOnce sync.Once
+	ready     chan struct{}
+}
+
+func newInitProgress() *initProgress {
+	return &initProgress{ready: make(chan struct{})}
+}
+
+func (p *initProgress) isReady() bool {
+	select {
+	case <-p.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetInitializationProgress returns the progress of the initialization of the changefeed.
+func (c *Controller) GetInitializationProgress() InitializationProgress {
+	if !c.progress.bootstrapped.Load() {
+		return InitializationProgress{Phase: InitPhaseBootstrapping}
+	}
+	// exclude the table trigger event dispatcher
+	total := c.replicationDB.TaskSize() - 1
+	absent := c.replicationDB.GetAbsentSize()
+	working := c.replicationDB.GetReplicatingSize()
+	progress := InitializationProgress{
+		Phase:          InitPhaseScheduling,
+		TablesLoaded:   int(c.progress.tablesLoaded.Load()),
+		SpansCreated:   total,
+		SpansScheduled: max(total-absent, working),
+		SpansWorking:   working,
+		PercentWorking: 100,
+	}
+	if total > 0 {
+		progress.PercentWorking = float64(working) * 100 / float64(total)
+	}
+	if c.progress.isReady() {
+		progress.Phase = InitPhaseWorking
+	}
+	return progress
+}
+
+// checkInitialized marks the changefeed initialized once all the spans are working,
+// it's called periodically by the maintainer event loop after the bootstrap.
+func (c *Controller) checkInitialized() {
+	if !c.progress.bootstrapped.Load() || c.progress.isReady() {
+		return
+	}
+	total := c.replicationDB.TaskSize() - 1
+	if c.replicationDB.GetReplicatingSize() < total {
+		return
+	}
+	c.progress.readyOnce.Do(func() {
+		close(c.progress.ready)
+		log.Info("changefeed initialized, all spans are working",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Int64("tables", c.progress.tablesLoaded.Load()),
+			zap.Int("spans", total))
+	})
+}
+
+// WaitForReady blocks until all the spans of the changefeed have been working once,
+// or the context is done.
+func (c *Controller) WaitForReady(ctx context.Context) error {
+	select {
+	case <-c.progress.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
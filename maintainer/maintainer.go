@@ -17,11 +17,16 @@ import (
 	"context"
 	"encoding/json"
 	"math"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamservice"
+	"github.com/pingcap/ticdc/maintainer/eventlog"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
 	"github.com/pingcap/ticdc/pkg/bootstrap"
@@ -29,25 +34,24 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/faultinject"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/pkg/tracing"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/chann"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
-const (
-	periodEventInterval = time.Millisecond * 200
-)
-
 // Maintainer is response for handle changefeed replication tasks. Maintainer should:
 // 1. schedule tables to dispatcher manager
 // 2. calculate changefeed checkpoint ts
@@ -69,6 +73,14 @@ type Maintainer struct {
 
 	pdClock pdutil.Clock
 
+	// epoch is assigned by the coordinator when the changefeed is scheduled to this node,
+	// 0 means the maintainer is not fenced.
+	epoch uint64
+
+	// periodEventInterval is the interval to calculate the checkpoint ts,
+	// it's decided by the latency mode of the changefeed.
+	periodEventInterval time.Duration
+
 	eventCh *chann.DrainableChann[*Event]
 
 	taskScheduler threadpool.ThreadPool
@@ -80,6 +92,16 @@ type Maintainer struct {
 	}
 
 	checkpointTsByCapture map[node.ID]heartbeatpb.Watermark
+	// nodeCapabilities are the capabilities negotiated with the dispatcher manager of each node
+	nodeCapabilities map[node.ID]heartbeatpb.Capabilities
+	// quiesceTs is the ts the dispatchers are held at, 0 means the changefeed is not quiesced
+	quiesceTs atomic.Uint64
+	// schemaResend is the table schema resend request waiting to be sent to the nodes
+	schemaResend struct {
+		sync.Mutex
+		pending  bool
+		tableIDs []int64
+	}
 
 	state        atomic.Int32
 	bootstrapper *bootstrap.Bootstrapper[heartbeatpb.MaintainerBootstrapResponse]
@@ -90,6 +112,15 @@ type Maintainer struct {
 
 	bootstrapped     bool
 	postBootstrapMsg *heartbeatpb.MaintainerPostBootstrapRequest
+	// bootstrapTimeout is the time waiting for the bootstrap responses of all nodes, 0 means no timeout.
+	bootstrapTimeout time.Duration
+	// bootstrapSkipTimeoutNodes is true if the bootstrap proceeds without the timeout nodes
+	bootstrapSkipTimeoutNodes bool
+	bootstrapTimeoutReported  bool
+	// schemaUnavailable is not nil if the bootstrap can't load the tables from the schema store,
+	// the maintainer retries the bootstrap in degraded mode while the working dispatchers keep
+	// replicating.
+	schemaUnavailable *schemaUnavailableState
 
 	// startCheckpointTs is the check point ts when the maintainer is created
 	// it's will be sent to dispatcher manager to initialize the checkpoint ts and get the real checkpoint ts
@@ -123,8 +154,11 @@ type Maintainer struct {
 	// false when otherwise, such as maintainer move to different nodes.
 	newChangefeed bool
 
-	errLock             sync.Mutex
-	runningErrors       map[node.ID]*heartbeatpb.RunningError
+	errLock       sync.Mutex
+	runningErrors map[node.ID]*heartbeatpb.RunningError
+	// runningWarnings are reported until the maintainer recovers from them,
+	// they don't change the state of the changefeed.
+	runningWarnings     []*heartbeatpb.RunningError
 	cancelUpdateMetrics context.CancelFunc
 
 	changefeedCheckpointTsGauge    prometheus.Gauge
@@ -136,6 +170,22 @@ type Maintainer struct {
 	runningTaskGauge               prometheus.Gauge
 	tableCountGauge                prometheus.Gauge
 	handleEventDuration            prometheus.Observer
+
+	// tableCount and eventSizePerSecond are reported to the coordinator as the weight of the changefeed.
+	tableCount         atomic.Int64
+	eventSizePerSecond atomic.Float32
+
+	// barrierCoverages are the coverages of the partially reported block events, they are
+	// reported to the coordinator to resume the barriers if the maintainer is restarted.
+	barrierCoverages     atomic.Pointer[[]*heartbeatpb.BarrierCoverage]
+	lastBarrierCoverTime time.Time
+	// reportedBarriers are the barrier coverages reported to the coordinator, only the changed
+	// ones are reported unless it's nil or all of them are not reported for barrierFullReportInterval.
+	reportedBarriers    map[barrierCoverageKey]*heartbeatpb.BarrierCoverage
+	lastFullBarrierTime time.Time
+	// backfills are the progress of the backfilling tables, they are reported to the
+	// coordinator to resume the backfills if the maintainer is restarted.
+	backfills atomic.Pointer[[]*heartbeatpb.TableBackfill]
 }
 
 // NewMaintainer create the maintainer for the changefeed
@@ -149,8 +199,9 @@ func NewMaintainer(cfID common.ChangeFeedID,
 	regionCache split.RegionCache,
 	checkpointTs uint64,
 	newChangfeed bool,
+	epoch uint64,
 ) *Maintainer {
-	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	mc := newEpochMessageCenter(appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter), epoch)
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
 	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID, tsoClient,
@@ -161,18 +212,30 @@ func NewMaintainer(cfID common.ChangeFeedID,
 			CheckpointTs:    checkpointTs,
 		}, selfNode.ID)
 
-	// TODO: Retrieve the correct pdClock from the context once multiple upstreams are supported.
-	// For now, since there is only one upstream, using the default pdClock is sufficient.
 	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
+	var schemaStore schemastore.SchemaStore
+	if cfg.UpstreamInfo != nil {
+		// the log service of the upstream is created by the maintainer manager before the maintainer.
+		logService, ok := appcontext.GetService[*upstreamservice.Manager](appcontext.UpstreamLogService).
+			Get(cfg.UpstreamID)
+		if !ok {
+			log.Panic("the log service of the upstream is not found",
+				zap.Stringer("changefeed", cfID), zap.Uint64("upstreamID", cfg.UpstreamID))
+		}
+		pdClock = logService.Upstream.PDClock
+		schemaStore = logService.SchemaStore
+	}
 	m := &Maintainer{
 		id:                cfID,
+		epoch:             epoch,
 		pdClock:           pdClock,
 		selfNode:          selfNode,
 		eventCh:           chann.NewAutoDrainChann[*Event](),
 		taskScheduler:     taskScheduler,
 		startCheckpointTs: checkpointTs,
 		controller: NewController(cfID, checkpointTs, pdAPI, tsoClient, regionCache, taskScheduler,
-			cfg.Config, ddlSpan, conf.AddTableBatchSize, time.Duration(conf.CheckBalanceInterval)),
+			cfg.Config, ddlSpan, conf.AddTableBatchSize,
+			time.Duration(conf.CheckBalanceInterval), time.Duration(conf.MaxCheckBalanceInterval)),
 		mc:              mc,
 		removed:         atomic.NewBool(false),
 		nodeManager:     nodeManager,
@@ -182,8 +245,11 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		cascadeRemoving: false,
 		config:          cfg,
 
+		periodEventInterval: latencyMode(cfg).Profile().CheckpointInterval,
+
 		ddlSpan:               ddlSpan,
 		checkpointTsByCapture: make(map[node.ID]heartbeatpb.Watermark),
+		nodeCapabilities:      make(map[node.ID]heartbeatpb.Capabilities),
 		runningErrors:         map[node.ID]*heartbeatpb.RunningError{},
 		newChangefeed:         newChangfeed,
 
@@ -202,10 +268,19 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		CheckpointTs: checkpointTs,
 		ResolvedTs:   checkpointTs,
 	}
+	m.controller.schemaStore = schemaStore
+	m.controller.setMessageCenter(mc)
+	// the hold is persisted in the changefeed info, it's kept after the maintainer is restarted
+	m.quiesceTs.Store(cfg.QuiesceTs)
 	m.state.Store(int32(heartbeatpb.ComponentState_Working))
 	m.bootstrapper = bootstrap.NewBootstrapper[heartbeatpb.MaintainerBootstrapResponse](m.id.Name(), m.getNewBootstrapFn())
+	if cfg.Config != nil && cfg.Config.Scheduler != nil {
+		m.bootstrapTimeout = time.Duration(cfg.Config.Scheduler.BootstrapTimeout)
+		m.bootstrapSkipTimeoutNodes = cfg.Config.Scheduler.BootstrapSkipTimeoutNodes
+	}
 	log.Info("changefeed maintainer is created", zap.String("id", cfID.String()),
 		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Uint64("epoch", epoch),
 		zap.String("ddlDispatcherID", tableTriggerEventDispatcherID.String()))
 	metrics.MaintainerGauge.WithLabelValues(cfID.Namespace(), cfID.Name()).Inc()
 	// Should update metrics immediately when maintainer is created
@@ -214,9 +289,18 @@ func NewMaintainer(cfID common.ChangeFeedID,
 	m.cancelUpdateMetrics = cancel
 	go m.runUpdateMetrics(ctx)
 	go m.runHandleEvents(ctx)
+	go m.controller.fetchGCSafePoint(ctx)
 	return m
 }
 
+// latencyMode returns the latency mode of the changefeed.
+func latencyMode(cfg *config.ChangeFeedInfo) config.LatencyMode {
+	if cfg.Config == nil || cfg.Config.LatencyMode == nil {
+		return config.LatencyModeBalanced
+	}
+	return *cfg.Config.LatencyMode
+}
+
 func NewMaintainerForRemove(cfID common.ChangeFeedID,
 	conf *config.SchedulerConfig,
 	selfNode *node.Info,
@@ -231,13 +315,13 @@ func NewMaintainerForRemove(cfID common.ChangeFeedID,
 		Config:       config.GetDefaultReplicaConfig(),
 	}
 	m := NewMaintainer(cfID, conf, unused, selfNode, taskScheduler, pdAPI,
-		tsoClient, regionCache, 1, false)
+		tsoClient, regionCache, 1, false, 0)
 	m.cascadeRemoving = true
 	// setup period event
 	m.submitScheduledEvent(m.taskScheduler, &Event{
 		changefeedID: m.id,
 		eventType:    EventPeriod,
-	}, time.Now().Add(periodEventInterval))
+	}, time.Now().Add(m.periodEventInterval))
 	return m
 }
 
@@ -315,10 +399,97 @@ func (m *Maintainer) GetMaintainerStatus() *heartbeatpb.MaintainerStatus {
 		State:        heartbeatpb.ComponentState(m.state.Load()),
 		CheckpointTs: m.getWatermark().CheckpointTs,
 		Err:          runningErrors,
+		Warnings:     m.runningWarnings,
+
+		TableCount:         m.tableCount.Load(),
+		EventSizePerSecond: m.eventSizePerSecond.Load(),
+	}
+	var coverages []*heartbeatpb.BarrierCoverage
+	if c := m.barrierCoverages.Load(); c != nil {
+		coverages = *c
+	}
+	status.Barriers, status.BarriersFull = m.newBarrierReport(coverages)
+	if backfills := m.backfills.Load(); backfills != nil {
+		status.Backfills = *backfills
 	}
 	return status
 }
 
+// restoreBackfills keeps the backfills reported by the previous maintainer of the changefeed,
+// they are reported as is until they are checked after the bootstrap.
+func (m *Maintainer) restoreBackfills(backfills []*heartbeatpb.TableBackfill) {
+	m.controller.restoreBackfills(backfills)
+	m.backfills.Store(&backfills)
+}
+
+// restoreBarriers keeps the barrier coverages reported by the previous maintainer of the changefeed,
+// they are reported as is until the barrier is rebuilt in the bootstrap.
+func (m *Maintainer) restoreBarriers(coverages []*heartbeatpb.BarrierCoverage) {
+	m.controller.restoredBarriers = coverages
+	m.barrierCoverages.Store(&coverages)
+}
+
+// barrierFullReportInterval is the max interval to report all the barrier coverages to the coordinator.
+const barrierFullReportInterval = time.Minute
+
+type barrierCoverageKey struct {
+	blockTs     uint64
+	isSyncPoint bool
+}
+
+// newBarrierReport returns the barrier coverages reported to the coordinator, they are all reported
+// if full is true, otherwise the changed ones and the removed ones without any covered span are reported.
+// The caller must hold the errLock.
+func (m *Maintainer) newBarrierReport(coverages []*heartbeatpb.BarrierCoverage) ([]*heartbeatpb.BarrierCoverage, bool) {
+	reported := make(map[barrierCoverageKey]*heartbeatpb.BarrierCoverage, len(coverages))
+	for _, c := range coverages {
+		reported[barrierCoverageKey{c.BlockTs, c.IsSyncPoint}] = c
+	}
+	previous := m.reportedBarriers
+	m.reportedBarriers = reported
+	if previous == nil || time.Since(m.lastFullBarrierTime) >= barrierFullReportInterval {
+		m.lastFullBarrierTime = time.Now()
+		return coverages, true
+	}
+
+	var changed []*heartbeatpb.BarrierCoverage
+	for key, c := range reported {
+		old, ok := previous[key]
+		if !ok || old.DynamicSplit != c.DynamicSplit || !reflect.DeepEqual(old.Covered, c.Covered) {
+			changed = append(changed, c)
+		}
+	}
+	for key := range previous {
+		if _, ok := reported[key]; !ok {
+			changed = append(changed, &heartbeatpb.BarrierCoverage{BlockTs: key.blockTs, IsSyncPoint: key.isSyncPoint})
+		}
+	}
+	return changed, false
+}
+
+// resetReportedBarriers makes all the barrier coverages reported in the next status,
+// it's called when the status is reported to a new coordinator.
+func (m *Maintainer) resetReportedBarriers() {
+	m.errLock.Lock()
+	defer m.errLock.Unlock()
+	m.reportedBarriers = nil
+}
+
+// barrierCoverInterval is the min interval to calculate the barrier coverages,
+// it's the same as the interval the status is reported to the coordinator at least.
+const barrierCoverInterval = 2 * time.Second
+
+// updateBarrierCoverages updates the barrier coverages reported to the coordinator,
+// they are calculated at most once per barrierCoverInterval.
+func (m *Maintainer) updateBarrierCoverages() {
+	if m.barrier == nil || time.Since(m.lastBarrierCoverTime) < barrierCoverInterval {
+		return
+	}
+	m.lastBarrierCoverTime = time.Now()
+	coverages := m.barrier.Coverages()
+	m.barrierCoverages.Store(&coverages)
+}
+
 func (m *Maintainer) initialize() error {
 	start := time.Now()
 	log.Info("start to initialize changefeed maintainer",
@@ -343,7 +514,7 @@ func (m *Maintainer) initialize() error {
 	m.submitScheduledEvent(m.taskScheduler, &Event{
 		changefeedID: m.id,
 		eventType:    EventPeriod,
-	}, time.Now().Add(periodEventInterval))
+	}, time.Now().Add(m.periodEventInterval))
 	log.Info("changefeed maintainer initialized",
 		zap.String("id", m.id.String()),
 		zap.Duration("duration", time.Since(start)))
@@ -362,6 +533,8 @@ func (m *Maintainer) cleanupMetrics() {
 	metrics.RunningScheduleTaskGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.TableGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.MaintainerHandleEventDuration.DeleteLabelValues(m.id.Namespace(), m.id.Name())
+	metrics.SpanCheckpointRegressionCounter.DeleteLabelValues(m.id.Namespace(), m.id.Name())
+	cleanupOrphanMetrics(m.id)
 }
 
 func (m *Maintainer) onInit() bool {
@@ -388,7 +561,7 @@ func (m *Maintainer) onMessage(msg *messaging.TargetMessage) {
 		req := msg.Message[0].(*heartbeatpb.RemoveMaintainerRequest)
 		m.onRemoveMaintainer(req.Cascade, req.Removed)
 	case messaging.TypeCheckpointTsMessage:
-		m.onCheckpointTsPersisted(msg.Message[0].(*heartbeatpb.CheckpointTsMessage))
+		m.onCheckpointTsPersisted(msg)
 	default:
 		log.Panic("unexpected message type",
 			zap.String("changefeed", m.id.Name()),
@@ -408,11 +581,14 @@ func (m *Maintainer) onRemoveMaintainer(cascade, changefeedRemoved bool) {
 	}
 }
 
-func (m *Maintainer) onCheckpointTsPersisted(msg *heartbeatpb.CheckpointTsMessage) {
+func (m *Maintainer) onCheckpointTsPersisted(msg *messaging.TargetMessage) {
+	ctx, span := tracing.Start(tracing.Extract(context.Background(), msg.TraceContext),
+		"maintainer.ForwardCheckpointTs", attribute.String("changefeed", m.id.Name()))
+	defer span.End()
 	// the table trigger dispatcher is on the same node with maintainer, and it will send the water marker to downstream
-	m.sendMessages([]*messaging.TargetMessage{
-		messaging.NewSingleTargetMessage(m.selfNode.ID, messaging.HeartbeatCollectorTopic, msg),
-	})
+	forwardMsg := messaging.NewSingleTargetMessage(m.selfNode.ID, messaging.HeartbeatCollectorTopic, msg.Message[0])
+	forwardMsg.TraceContext = tracing.Inject(ctx)
+	m.sendMessages([]*messaging.TargetMessage{forwardMsg})
 }
 
 func (m *Maintainer) onNodeChanged() {
@@ -430,7 +606,11 @@ func (m *Maintainer) onNodeChanged() {
 		if _, ok := activeNodes[id]; !ok {
 			removedNodes = append(removedNodes, id)
 			delete(m.checkpointTsByCapture, id)
+			delete(m.nodeCapabilities, id)
 			m.controller.RemoveNode(id)
+			if m.schemaUnavailable != nil {
+				delete(m.schemaUnavailable.resp, id)
+			}
 		}
 	}
 	log.Info("maintainer node changed", zap.String("id", m.id.String()),
@@ -539,6 +719,18 @@ func (m *Maintainer) onHeartBeatRequest(msg *messaging.TargetMessage) {
 		}
 	}
 	m.controller.HandleStatus(msg.From, req.Statuses)
+	m.controller.UpdateNodeCapacity(msg.From, req.Capacity)
+	m.syncQuiesceTs(msg.From, req.QuiesceTs)
+	if req.NodeStopping {
+		// the node is shutting down and the statuses carry the final checkpoints of its spans,
+		// reschedule them now instead of waiting for the node to be removed from the cluster.
+		log.Info("node is stopping, mark its spans absent",
+			zap.String("changefeed", m.id.Name()),
+			zap.String("from", msg.From.String()),
+			zap.Int("spanCount", len(req.Statuses)))
+		m.nodeManager.MarkNodeStopping(msg.From)
+		m.controller.RemoveNode(msg.From)
+	}
 	if req.Err != nil {
 		log.Warn("dispatcher report an error",
 			zap.String("changefeed", m.id.Name()),
@@ -565,7 +757,18 @@ func (m *Maintainer) onBlockStateRequest(msg *messaging.TargetMessage) {
 		return
 	}
 	req := msg.Message[0].(*heartbeatpb.BlockStatusRequest)
+	// the barrier handling is the root of the block event trace if the dispatcher does not carry one,
+	// the trace context is passed to the dispatchers by the ack and action message.
+	ctx, span := tracing.Start(tracing.Extract(context.Background(), msg.TraceContext),
+		"maintainer.HandleBlockStatus",
+		attribute.String("changefeed", m.id.Name()),
+		attribute.String("from", msg.From.String()),
+		attribute.Int("statusCount", len(req.BlockStatuses)))
+	defer span.End()
 	ackMsg := m.barrier.HandleStatus(msg.From, req)
+	if ackMsg != nil {
+		ackMsg.TraceContext = tracing.Inject(ctx)
+	}
 	m.sendMessages([]*messaging.TargetMessage{ackMsg})
 }
 
@@ -581,6 +784,17 @@ func (m *Maintainer) onMaintainerBootstrapResponse(msg *messaging.TargetMessage)
 		m.onError(msg.From, resp.Err)
 		return
 	}
+	if resp.ProtocolVersion != heartbeatpb.ProtocolVersion {
+		log.Info("dispatcher manager protocol version mismatch",
+			zap.String("changefeed", m.id.Name()),
+			zap.Any("server", msg.From),
+			zap.Uint32("remoteVersion", resp.ProtocolVersion),
+			zap.Uint32("localVersion", heartbeatpb.ProtocolVersion),
+			zap.Strings("capabilities", resp.Capabilities))
+	}
+	m.nodeCapabilities[msg.From] = heartbeatpb.NegotiateCapabilities(resp.ProtocolVersion, resp.Capabilities)
+	m.controller.operatorController.SetNodeCapabilities(msg.From, m.nodeCapabilities[msg.From])
+	m.controller.UpdateNodeCapacity(msg.From, resp.Capacity)
 	cachedResp := m.bootstrapper.HandleBootstrapResponse(msg.From, msg.Message[0].(*heartbeatpb.MaintainerBootstrapResponse))
 	m.onBootstrapDone(cachedResp)
 
@@ -618,9 +832,19 @@ func (m *Maintainer) onBootstrapDone(cachedResp map[node.ID]*heartbeatpb.Maintai
 	}
 	barrier, msg, err := m.controller.FinishBootstrap(cachedResp, isMysqlCompatibleBackend)
 	if err != nil {
+		if m.onSchemaUnavailable(cachedResp, err) {
+			return
+		}
 		m.handleError(err)
 		return
 	}
+	if m.schemaUnavailable != nil {
+		log.Info("maintainer recovered from schema store unavailable",
+			zap.String("changefeed", m.id.Name()),
+			zap.Duration("duration", time.Since(m.schemaUnavailable.since)))
+		m.schemaUnavailable = nil
+		m.setWarnings(nil)
+	}
 	m.barrier = barrier
 	m.bootstrapped = true
 
@@ -631,6 +855,105 @@ func (m *Maintainer) onBootstrapDone(cachedResp map[node.ID]*heartbeatpb.Maintai
 	m.sendPostBootstrapRequest()
 }
 
+// checkBootstrapTimeout reports the nodes which don't respond in the bootstrap timeout,
+// and proceeds the bootstrap without them if it's enabled. The node of the maintainer is never
+// skipped since the table trigger event dispatcher runs on it. The dispatchers of the skipped
+// nodes are unknown to the maintainer, they are reconciled as orphans after the nodes respond.
+func (m *Maintainer) checkBootstrapTimeout() {
+	if m.bootstrapped || m.schemaUnavailable != nil ||
+		m.bootstrapTimeout <= 0 || m.bootstrapper.Elapsed() < m.bootstrapTimeout {
+		return
+	}
+	missing := m.bootstrapper.GetUninitializedNodes()
+	skipped := make([]node.ID, 0, len(missing))
+	for _, id := range missing {
+		if id != m.selfNode.ID {
+			skipped = append(skipped, id)
+		}
+	}
+	if !m.bootstrapSkipTimeoutNodes || len(skipped) == 0 {
+		if !m.bootstrapTimeoutReported {
+			m.bootstrapTimeoutReported = true
+			log.Warn("maintainer bootstrap timeout, keep waiting for the missing nodes",
+				zap.String("changefeed", m.id.Name()),
+				zap.Duration("timeout", m.bootstrapTimeout),
+				zap.Any("missing", missing))
+		}
+		return
+	}
+	log.Warn("maintainer bootstrap timeout, proceed without the missing nodes",
+		zap.String("changefeed", m.id.Name()),
+		zap.Duration("timeout", m.bootstrapTimeout),
+		zap.Any("skipped", skipped))
+	m.onBootstrapDone(m.bootstrapper.SkipNodes(skipped))
+}
+
+// schemaUnavailableState is the state of the bootstrap retried in degraded mode.
+type schemaUnavailableState struct {
+	// resp is the bootstrap responses to retry the bootstrap with
+	resp      map[node.ID]*heartbeatpb.MaintainerBootstrapResponse
+	since     time.Time
+	backoff   time.Duration
+	nextRetry time.Time
+}
+
+const (
+	schemaRetryInitInterval = time.Second
+	schemaRetryMaxInterval  = 30 * time.Second
+)
+
+// onSchemaUnavailable enters or stays in degraded mode if the bootstrap fails because the
+// schema store is unavailable. The bootstrap is retried with backoff, and a warning is reported
+// in the changefeed status. It returns false if err is not caused by the schema store, or the
+// schema store is unavailable longer than the changefeed error stuck duration.
+func (m *Maintainer) onSchemaUnavailable(
+	cachedResp map[node.ID]*heartbeatpb.MaintainerBootstrapResponse, err error,
+) bool {
+	if code, ok := errors.RFCCode(err); !ok || code != errors.ErrSchemaStoreUnavailable.RFCCode() {
+		return false
+	}
+	now := time.Now()
+	s := m.schemaUnavailable
+	if s == nil {
+		s = &schemaUnavailableState{since: now, backoff: schemaRetryInitInterval}
+		m.schemaUnavailable = s
+		log.Warn("schema store is unavailable, maintainer enters degraded mode and retries the bootstrap",
+			zap.String("changefeed", m.id.Name()),
+			zap.Error(err))
+	} else {
+		s.backoff = min(2*s.backoff, schemaRetryMaxInterval)
+	}
+	s.resp = cachedResp
+
+	stuckDuration := *config.GetDefaultReplicaConfig().ChangefeedErrorStuckDuration
+	if m.config.Config != nil && m.config.Config.ChangefeedErrorStuckDuration != nil {
+		stuckDuration = *m.config.Config.ChangefeedErrorStuckDuration
+	}
+	if now.Sub(s.since) >= stuckDuration {
+		log.Error("schema store is unavailable for too long, stop retrying the bootstrap",
+			zap.String("changefeed", m.id.Name()),
+			zap.Duration("duration", now.Sub(s.since)))
+		m.schemaUnavailable = nil
+		m.setWarnings(nil)
+		return false
+	}
+	s.nextRetry = now.Add(s.backoff)
+	m.setWarnings([]*heartbeatpb.RunningError{{
+		Time:    s.since.String(),
+		Node:    m.selfNode.AdvertiseAddr,
+		Code:    string(errors.ErrSchemaStoreUnavailable.RFCCode()),
+		Message: err.Error(),
+	}})
+	return true
+}
+
+func (m *Maintainer) setWarnings(warnings []*heartbeatpb.RunningError) {
+	m.errLock.Lock()
+	m.runningWarnings = warnings
+	m.errLock.Unlock()
+	m.statusChanged.Store(true)
+}
+
 func (m *Maintainer) sendPostBootstrapRequest() {
 	if m.postBootstrapMsg != nil {
 		msg := messaging.NewSingleTargetMessage(m.selfNode.ID, messaging.DispatcherManagerManagerTopic, m.postBootstrapMsg)
@@ -653,6 +976,10 @@ func (m *Maintainer) handleResendMessage() {
 	}
 	// resend bootstrap message
 	m.sendMessages(m.bootstrapper.ResendBootstrapMessage())
+	m.checkBootstrapTimeout()
+	if s := m.schemaUnavailable; s != nil && !time.Now().Before(s.nextRetry) {
+		m.onBootstrapDone(s.resp)
+	}
 	if m.postBootstrapMsg != nil {
 		m.sendPostBootstrapRequest()
 	}
@@ -723,20 +1050,26 @@ func (m *Maintainer) handleError(err error) {
 // getNewBootstrapFn returns a function that creates a new bootstrap message to initialize
 // a changefeed dispatcher manager.
 func (m *Maintainer) getNewBootstrapFn() bootstrap.NewBootstrapMessageFn {
-	// cfgBytes only holds necessary fields to initialize a changefeed dispatcher.
-	cfgBytes, err := json.Marshal(m.config.ToChangefeedConfig())
-	if err != nil {
-		log.Panic("marshal changefeed config failed",
-			zap.String("changefeed", m.id.Name()),
-			zap.Error(err))
-	}
+	changefeedConfig := m.config.ToChangefeedConfig()
 	return func(id node.ID) *messaging.TargetMessage {
+		// cfgBytes only holds necessary fields to initialize a changefeed dispatcher,
+		// the new dispatcher manager is held at the current quiesce ts.
+		cfg := *changefeedConfig
+		cfg.QuiesceTs = m.quiesceTs.Load()
+		cfgBytes, err := json.Marshal(&cfg)
+		if err != nil {
+			log.Panic("marshal changefeed config failed",
+				zap.String("changefeed", m.id.Name()),
+				zap.Error(err))
+		}
 		msg := &heartbeatpb.MaintainerBootstrapRequest{
 			ChangefeedID:                  m.id.ToPB(),
 			Config:                        cfgBytes,
 			StartTs:                       m.startCheckpointTs,
 			TableTriggerEventDispatcherId: nil,
 			IsNewChangfeed:                false,
+			ProtocolVersion:               heartbeatpb.ProtocolVersion,
+			Capabilities:                  heartbeatpb.LocalCapabilities(),
 		}
 
 		// only send dispatcher id to dispatcher manager on the same node
@@ -757,14 +1090,27 @@ func (m *Maintainer) getNewBootstrapFn() bootstrap.NewBootstrapMessageFn {
 }
 
 func (m *Maintainer) onPeriodTask() {
+	// the killed maintainer reports the error to the coordinator,
+	// and the coordinator restarts the changefeed as if the maintainer crashed.
+	if faultinject.GetInjector().TakeMaintainerKill(m.id.DisplayName) {
+		m.handleError(errors.ErrInjectedFault.GenWithStackByArgs("maintainer is killed"))
+	}
 	// send scheduling messages
 	m.handleResendMessage()
 	m.collectMetrics()
 	m.calCheckpointTs()
+	m.updateBarrierCoverages()
+	m.sendSchemaResend()
+	if m.bootstrapped {
+		backfills := m.controller.updateBackfills(m.getWatermark().CheckpointTs)
+		m.backfills.Store(&backfills)
+		m.controller.ReconcileOrphans(time.Now())
+		m.controller.checkInitialized()
+	}
 	m.submitScheduledEvent(m.taskScheduler, &Event{
 		changefeedID: m.id,
 		eventType:    EventPeriod,
-	}, time.Now().Add(periodEventInterval))
+	}, time.Now().Add(m.periodEventInterval))
 }
 
 func (m *Maintainer) collectMetrics() {
@@ -779,6 +1125,14 @@ func (m *Maintainer) collectMetrics() {
 		absent := m.controller.replicationDB.GetAbsentSize()
 
 		m.tableCountGauge.Set(float64(total))
+		eventSizePerSecond := float32(0)
+		for _, span := range m.controller.replicationDB.GetAllTasks() {
+			if status := span.GetStatus(); status != nil {
+				eventSizePerSecond += status.EventSizePerSecond
+			}
+		}
+		m.tableCount.Store(int64(total))
+		m.eventSizePerSecond.Store(eventSizePerSecond)
 		m.scheduledTaskGauge.Set(float64(scheduling))
 		metrics.TableStateGauge.WithLabelValues(m.id.Namespace(), m.id.Name(), "Absent").Set(float64(absent))
 		metrics.TableStateGauge.WithLabelValues(m.id.Namespace(), m.id.Name(), "Working").Set(float64(working))
@@ -816,10 +1170,94 @@ func (m *Maintainer) MoveTable(tableId int64, targetNode node.ID) error {
 	return m.controller.moveTable(tableId, targetNode)
 }
 
+// BackfillTable re-replicates the table from the startTs, the events already replicated
+// are written to the downstream again in safe mode.
+func (m *Maintainer) BackfillTable(tableID int64, startTs uint64) error {
+	return m.controller.backfillTable(tableID, startTs)
+}
+
+// SplitTable splits the table to the spans by the comparable split keys.
+func (m *Maintainer) SplitTable(tableID int64, splitKeys [][]byte) error {
+	return m.controller.splitTable(tableID, splitKeys)
+}
+
+// MergeTable merges all the spans of the table to one span.
+func (m *Maintainer) MergeTable(tableID int64) error {
+	return m.controller.mergeTable(tableID)
+}
+
 func (m *Maintainer) GetTables() []*replica.SpanReplication {
 	return m.controller.replicationDB.GetAllTasks()
 }
 
+// GetInitializationProgress returns the progress of the initialization of the changefeed.
+func (m *Maintainer) GetInitializationProgress() InitializationProgress {
+	return m.controller.GetInitializationProgress()
+}
+
+// WaitForReady blocks until all the spans of the changefeed have been working once,
+// or the context is done.
+func (m *Maintainer) WaitForReady(ctx context.Context) error {
+	return m.controller.WaitForReady(ctx)
+}
+
+// GetScheduleEvents returns the scheduling events of the changefeed recorded after since,
+// such as the spans moved or split, the nodes removed and the barriers resolved.
+func (m *Maintainer) GetScheduleEvents(since time.Time) []eventlog.Event {
+	return m.controller.operatorController.EventLog().List(since)
+}
+
+// TableLag is the checkpoint progress of a table in the changefeed.
+type TableLag struct {
+	TableID int64
+	// NodeID is the node of the span which has the smallest checkpointTs in the table.
+	NodeID       node.ID
+	CheckpointTs uint64
+	// Lag is the duration between the checkpointTs and the current time.
+	Lag time.Duration
+}
+
+// GetLaggingTables returns at most limit tables which have the smallest checkpointTs,
+// these tables are the ones which drag the checkpointTs of the changefeed.
+func (m *Maintainer) GetLaggingTables(limit int) []TableLag {
+	return topLaggingTables(m.GetTables(), limit, m.pdClock.CurrentTime())
+}
+
+func topLaggingTables(spans []*replica.SpanReplication, limit int, now time.Time) []TableLag {
+	tables := make(map[int64]*TableLag)
+	for _, span := range spans {
+		// skip the table trigger event dispatcher
+		if span.Span.TableID == 0 {
+			continue
+		}
+		checkpointTs := span.GetStatus().CheckpointTs
+		table, ok := tables[span.Span.TableID]
+		if !ok || checkpointTs < table.CheckpointTs {
+			tables[span.Span.TableID] = &TableLag{
+				TableID:      span.Span.TableID,
+				NodeID:       span.GetNodeID(),
+				CheckpointTs: checkpointTs,
+			}
+		}
+	}
+
+	result := make([]TableLag, 0, len(tables))
+	for _, table := range tables {
+		table.Lag = now.Sub(oracle.GetTimeFromTS(table.CheckpointTs))
+		result = append(result, *table)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CheckpointTs == result[j].CheckpointTs {
+			return result[i].TableID < result[j].TableID
+		}
+		return result[i].CheckpointTs < result[j].CheckpointTs
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
 // SubmitScheduledEvent submits a task to controller pool to send a future event
 func (m *Maintainer) submitScheduledEvent(
 	scheduler threadpool.ThreadPool,
@@ -852,7 +1290,8 @@ func (m *Maintainer) getWatermark() heartbeatpb.Watermark {
 func (m *Maintainer) setWatermark(newWatermark heartbeatpb.Watermark) {
 	m.watermark.mu.Lock()
 	defer m.watermark.mu.Unlock()
-	if newWatermark.CheckpointTs != math.MaxUint64 {
+	// the checkpoint ts never goes back, a backfilling table reports a checkpoint ts older than it.
+	if newWatermark.CheckpointTs != math.MaxUint64 && newWatermark.CheckpointTs >= m.watermark.CheckpointTs {
 		m.watermark.CheckpointTs = newWatermark.CheckpointTs
 	}
 	if newWatermark.ResolvedTs != math.MaxUint64 {
@@ -860,6 +1299,11 @@ func (m *Maintainer) setWatermark(newWatermark heartbeatpb.Watermark) {
 	}
 }
 
+// GetChangefeedID returns the id of the changefeed of the maintainer.
+func (m *Maintainer) GetChangefeedID() common.ChangeFeedID {
+	return m.id
+}
+
 func (m *Maintainer) GetDispatcherCount() int {
 	return len(m.controller.GetAllTasks())
 }
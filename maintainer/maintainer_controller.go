@@ -15,6 +15,7 @@ package maintainer
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pingcap/log"
@@ -31,6 +32,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/scheduler"
@@ -38,6 +40,8 @@ import (
 	"github.com/pingcap/ticdc/utils"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -53,6 +57,10 @@ type Controller struct {
 	nodeManager         *watcher.NodeManager
 	tsoClient           replica.TSOClient
 
+	pdAPI pdutil.PDAPIClient
+	// gcSafePoint is the GC safepoint of the upstream fetched in background,
+	// it's used to check the start ts in bootstrap, 0 means it's not fetched.
+	gcSafePoint            atomic.Uint64
 	splitter               *split.Splitter
 	enableTableAcrossNodes bool
 	startCheckpointTs      uint64
@@ -60,9 +68,41 @@ type Controller struct {
 
 	cfConfig     *config.ReplicaConfig
 	changefeedID common.ChangeFeedID
+	// schemaStore is the schema store of the upstream,
+	// it's nil if the changefeed replicates from the default upstream.
+	schemaStore schemastore.SchemaStore
 
 	taskScheduler threadpool.ThreadPool
 	taskHandlers  []*threadpool.TaskHandle
+
+	// orphans are the working dispatchers reported by the nodes which are not bound to the nodes,
+	// they are reconciled periodically, it's only accessed by the maintainer event loop.
+	orphans              map[orphanKey]*orphanDispatcher
+	orphanRemovedCounter prometheus.Counter
+	orphanAdoptedCounter prometheus.Counter
+
+	progress *initProgress
+	// capacities are the max dispatchers of the nodes, the spans are only placed
+	// on the nodes which have the capacity for them.
+	capacities *spanCapacities
+	// bootstrapLoads are the loads of the nodes reported in the bootstrap responses,
+	// the spans are placed and balanced away from the loaded nodes.
+	bootstrapLoads *bootstrapLoads
+	// spanMoves are the spans moved by the last bulk move or balance triggered on demand.
+	spanMoves struct {
+		sync.Mutex
+		moves []SpanMove
+	}
+	// restoredBarriers are the barrier coverages reported by the previous maintainer,
+	// they are resumed when the barrier is rebuilt in the bootstrap.
+	restoredBarriers []*heartbeatpb.BarrierCoverage
+	// spanGroups decides the scheduling groups of the spans covering whole tables,
+	// it's nil if the spans are not grouped by the changefeed config.
+	spanGroups *spanGroups
+	// backfills are the tables being re-replicated from an older ts.
+	backfills tableBackfills
+	// balanceInterval decides when the spans are balanced and split, it's shared by the schedulers.
+	balanceInterval *scheduler.BalanceInterval
 }
 
 func NewController(changefeedID common.ChangeFeedID,
@@ -73,16 +113,30 @@ func NewController(changefeedID common.ChangeFeedID,
 	taskScheduler threadpool.ThreadPool,
 	cfConfig *config.ReplicaConfig,
 	ddlSpan *replica.SpanReplication,
-	batchSize int, balanceInterval time.Duration,
+	batchSize int, minBalanceInterval, maxBalanceInterval time.Duration,
 ) *Controller {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	enableTableAcrossNodes := false
 	var splitter *split.Splitter
+	placementStrategy, maxSpansPerTablePerNode := "", 0
+	if cfConfig != nil && cfConfig.Scheduler != nil {
+		placementStrategy = cfConfig.Scheduler.PlacementStrategy
+		maxSpansPerTablePerNode = cfConfig.Scheduler.MaxSpansPerTablePerNode
+	}
 	if cfConfig != nil && cfConfig.Scheduler.EnableTableAcrossNodes {
 		enableTableAcrossNodes = true
-		splitter = split.NewSplitter(changefeedID, pdapi, regionCache, cfConfig.Scheduler)
+		splitter = split.NewSplitter(changefeedID, pdapi, regionCache, cfConfig.Scheduler, cfConfig.MemoryQuota)
 	}
 	replicaSetDB := replica.NewReplicaSetDB(changefeedID, ddlSpan, enableTableAcrossNodes)
+	groups, err := newSpanGroups(cfConfig)
+	if err != nil {
+		log.Warn("invalid group-by setting, all tables are in one group",
+			zap.String("changefeed", changefeedID.Name()), zap.Error(err))
+		groups = nil
+	}
+	if groups != nil {
+		replicaSetDB.SetGroupFunc(groups.groupID)
+	}
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 	oc := operator.NewOperatorController(changefeedID, mc, replicaSetDB, nodeManager, batchSize)
 	s := &Controller{
@@ -97,13 +151,40 @@ func NewController(changefeedID common.ChangeFeedID,
 		taskScheduler:          taskScheduler,
 		cfConfig:               cfConfig,
 		tsoClient:              tsoClient,
+		pdAPI:                  pdapi,
 		splitter:               splitter,
 		enableTableAcrossNodes: enableTableAcrossNodes,
+		orphans:                make(map[orphanKey]*orphanDispatcher),
+		orphanRemovedCounter: metrics.OrphanDispatcherReconcileCounter.WithLabelValues(
+			changefeedID.Namespace(), changefeedID.Name(), orphanReconcileRemoved),
+		orphanAdoptedCounter: metrics.OrphanDispatcherReconcileCounter.WithLabelValues(
+			changefeedID.Namespace(), changefeedID.Name(), orphanReconcileAdopted),
+		progress:       newInitProgress(),
+		capacities:     newSpanCapacities(),
+		bootstrapLoads: newBootstrapLoads(),
+		spanGroups:     groups,
+		backfills:      tableBackfills{tables: make(map[int64]*tableBackfill)},
+		// the interval backs off while the spans are stable, and is tightened
+		// when the nodes change or the heartbeats report the spans are moved
+		balanceInterval: scheduler.NewBalanceInterval(minBalanceInterval, maxBalanceInterval),
 	}
-	s.schedulerController = NewScheduleController(changefeedID, batchSize, oc, replicaSetDB, nodeManager, balanceInterval, s.splitter)
+	newCapacity := func() scheduler.NodeCapacity[*replica.SpanReplication] {
+		return s.capacities.newSpanCapacity(replicaSetDB.GetTaskSizePerNode())
+	}
+	s.schedulerController = NewScheduleController(changefeedID, batchSize, oc, replicaSetDB, nodeManager, s.balanceInterval, s.splitter,
+		placementStrategy, maxSpansPerTablePerNode, newCapacity, func() map[node.ID]int {
+			return s.bootstrapLoads.get()
+		})
 	return s
 }
 
+// setMessageCenter replaces the message center used to send the schedule requests,
+// it must be called before the controller is used.
+func (c *Controller) setMessageCenter(mc messaging.MessageCenter) {
+	c.messageCenter = mc
+	c.operatorController.SetMessageCenter(mc)
+}
+
 // HandleStatus handle the status report from the node
 func (c *Controller) HandleStatus(from node.ID, statusList []*heartbeatpb.TableSpanStatus) {
 	for _, status := range statusList {
@@ -117,25 +198,33 @@ func (c *Controller) HandleStatus(from node.ID, statusList []*heartbeatpb.TableS
 			if op := c.operatorController.GetOperator(dispatcherID); op == nil {
 				// it's normal case when the span is not found in replication db
 				// the span is removed from replication db first, so here we only check if the span status is working or not
-				log.Warn("no span found, remove it",
+				log.Warn("no span found, record it as orphan",
 					zap.String("changefeed", c.changefeedID.Name()),
 					zap.String("from", from.String()),
 					zap.Any("status", status),
 					zap.String("span", dispatcherID.String()))
-				// if the span is not found, and the status is working, we need to remove it from dispatcher
-				_ = c.messageCenter.SendCommand(replica.NewRemoveDispatcherMessage(from, c.changefeedID, status.ID))
+				// if the span is still not found after the grace period, it's removed from the dispatcher
+				c.recordOrphan(from, dispatcherID, status)
 			}
 			continue
 		}
 		nodeID := stm.GetNodeID()
 		if nodeID != from {
-			// todo: handle the case that the node id is mismatch
 			log.Warn("node id not match",
 				zap.String("changefeed", c.changefeedID.Name()),
 				zap.Any("from", from),
 				zap.Stringer("node", nodeID))
+			if status.ComponentStatus == heartbeatpb.ComponentState_Working &&
+				c.operatorController.GetOperator(dispatcherID) == nil {
+				c.recordOrphan(from, dispatcherID, status)
+			}
 			continue
 		}
+		c.forgetOrphan(from, dispatcherID)
+		if old := stm.GetStatus(); old != nil && old.ComponentStatus != status.ComponentStatus {
+			// the spans on the node are started or stopped, the load of the nodes changes
+			c.balanceInterval.MarkChanged()
+		}
 		c.replicationDB.UpdateStatus(stm, status)
 	}
 }
@@ -171,6 +260,7 @@ func (c *Controller) AddNewTable(table commonEvent.Table, startTs uint64) {
 		StartKey: span.StartKey,
 		EndKey:   span.EndKey,
 	}
+	c.observeTableGroup(table, startTs)
 	tableSpans := []*heartbeatpb.TableSpan{tableSpan}
 	if c.enableTableAcrossNodes {
 		// split the whole table span base on the configuration, todo: background split table
@@ -196,36 +286,39 @@ func (c *Controller) FinishBootstrap(
 		zap.Int("size", len(cachedResp)))
 
 	// 1. get the real start ts from the table trigger event dispatcher
-	startTs := uint64(0)
-	for node, resp := range cachedResp {
-		log.Info("received bootstrap response",
-			zap.Any("changefeed", resp.ChangefeedID),
-			zap.Any("node", node),
-			zap.Any("startTs", resp.CheckpointTs))
-		if resp.CheckpointTs > startTs {
-			startTs = resp.CheckpointTs
-			// update the ddl dispatcher status
-			status := c.replicationDB.GetDDLDispatcher().GetStatus()
-			status.CheckpointTs = startTs
-			c.replicationDB.UpdateStatus(c.replicationDB.GetDDLDispatcher(), status)
-		}
-
-	}
-	if startTs == 0 {
-		log.Panic("cant not found the start ts from the bootstrap response",
-			zap.String("changefeed", c.changefeedID.Name()))
+	startTs, err := c.getBootstrapStartTs(cachedResp)
+	if err != nil {
+		return nil, nil, err
 	}
+	// update the ddl dispatcher status
+	status := c.replicationDB.GetDDLDispatcher().GetStatus()
+	status.CheckpointTs = startTs
+	c.replicationDB.UpdateStatus(c.replicationDB.GetDDLDispatcher(), status)
+
 	// 2. load tables from schema store using the start ts
 	tables, err := c.loadTables(startTs)
 	if err != nil {
 		log.Error("load table from scheme store failed",
 			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Uint64("startTs", startTs),
 			zap.Error(err))
-		return nil, nil, errors.Trace(err)
+		// the schema snapshot is lost or can't be used, retrying never succeeds
+		if code, ok := errors.RFCCode(err); errors.ShouldFailChangefeed(err) ||
+			(ok && errors.IsChangefeedGCFastFailErrorCode(code)) {
+			return nil, nil, errors.Trace(err)
+		}
+		return nil, nil, errors.ErrSchemaStoreUnavailable.Wrap(err).GenWithStackByArgs(
+			c.changefeedID.Name(), startTs)
+	}
+	c.progress.tablesLoaded.Store(int64(len(tables)))
+	if c.spanGroups != nil {
+		c.spanGroups.observeTables(tables...)
 	}
 
+	loads := make(map[node.ID]*heartbeatpb.NodeLoad, len(cachedResp))
 	workingMap := make(map[int64]utils.Map[*heartbeatpb.TableSpan, *replica.SpanReplication])
 	for server, bootstrapMsg := range cachedResp {
+		loads[server] = bootstrapMsg.Load
 		log.Info("received bootstrap response",
 			zap.String("changefeed", c.changefeedID.Name()),
 			zap.Any("server", server),
@@ -303,9 +396,12 @@ func (c *Controller) FinishBootstrap(
 			zap.Int64("id", tableID))
 	}
 
+	c.bootstrapLoads.set(loads, c.replicationDB.TaskSize())
+
 	// rebuild barrier status
-	barrier := NewBarrier(c, c.cfConfig.Scheduler.EnableTableAcrossNodes)
+	barrier := NewBarrier(c, c.cfConfig.Scheduler.EnableTableAcrossNodes, time.Duration(c.cfConfig.Scheduler.DDLBatchWindow))
 	barrier.HandleBootstrapResponse(cachedResp)
+	c.restoredBarriers = nil
 
 	// start scheduler
 	c.taskHandlers = append(c.taskHandlers, c.schedulerController.Start(c.taskScheduler)...)
@@ -313,6 +409,7 @@ func (c *Controller) FinishBootstrap(
 	c.taskHandlers = append(c.taskHandlers, c.taskScheduler.Submit(c.operatorController, time.Now()))
 
 	c.bootstrapped = true
+	c.progress.bootstrapped.Store(true)
 
 	initSchemaInfos := make([]*heartbeatpb.SchemaInfo, 0, len(schemaInfos))
 	for _, info := range schemaInfos {
@@ -329,6 +426,9 @@ func (c *Controller) Stop() {
 	for _, handler := range c.taskHandlers {
 		handler.Cancel()
 	}
+	if c.spanGroups != nil {
+		c.spanGroups.close()
+	}
 }
 
 // GetTask queries a task by dispatcherID, return nil if not found
@@ -369,9 +469,18 @@ func (c *Controller) UpdateSchemaID(tableID, newSchemaID int64) {
 
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
+	c.capacities.remove(id)
 	c.operatorController.OnNodeRemoved(id)
 }
 
+// UpdateNodeCapacity updates the capacity reported by the dispatcher manager of the node.
+func (c *Controller) UpdateNodeCapacity(id node.ID, capacity *heartbeatpb.NodeCapacity) {
+	if capacity == nil {
+		return
+	}
+	c.capacities.update(id, capacity, c.replicationDB.GetTaskSizePerNode()[id])
+}
+
 // ScheduleFinished return false if not all task are running in working state
 func (c *Controller) ScheduleFinished() bool {
 	return c.replicationDB.GetAbsentSize() == 0 && c.operatorController.OperatorSize() == 0
@@ -401,19 +510,164 @@ func (c *Controller) addNewSpans(schemaID int64, tableSpans []*heartbeatpb.Table
 	}
 }
 
+// observeTableGroup matches the group of the table by its name if the group is decided by the
+// name, the name of the table added by ddls is loaded from the schema store.
+func (c *Controller) observeTableGroup(table commonEvent.Table, startTs uint64) {
+	if c.spanGroups == nil {
+		return
+	}
+	if table.SchemaTableName == nil {
+		if !c.spanGroups.needTableNames(table.TableID) {
+			return
+		}
+		name, err := c.getTableName(table.TableID, startTs)
+		if err != nil {
+			log.Warn("load table name failed, the table is put in the default group",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.Int64("table", table.TableID),
+				zap.Uint64("startTs", startTs),
+				zap.Error(err))
+			return
+		}
+		table.SchemaTableName = name
+	}
+	c.spanGroups.observeTables(table)
+}
+
+// UpdateTableGroups matches the groups of the tables again by their names at the commit ts of a ddl,
+// the spans of the tables are moved to their new groups if the tables are renamed to another group.
+func (c *Controller) UpdateTableGroups(tableIDs []int64, commitTs uint64) {
+	if c.spanGroups == nil || !c.spanGroups.groupedByTag() {
+		return
+	}
+	tables := make([]commonEvent.Table, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		if tableID == heartbeatpb.DDLSpan.TableID || !c.replicationDB.IsTableExists(tableID) {
+			continue
+		}
+		name, err := c.getTableName(tableID, commitTs)
+		if err != nil {
+			log.Warn("load table name failed, the group of the table is not updated",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.Int64("table", tableID),
+				zap.Uint64("commitTs", commitTs),
+				zap.Error(err))
+			continue
+		}
+		tables = append(tables, commonEvent.Table{TableID: tableID, SchemaTableName: name})
+	}
+	for _, tableID := range c.spanGroups.observeTables(tables...) {
+		log.Info("table is moved to another group",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Int64("table", tableID),
+			zap.Uint64("commitTs", commitTs))
+		c.replicationDB.UpdateTableGroup(tableID)
+	}
+}
+
+func (c *Controller) getTableName(tableID int64, ts uint64) (*commonEvent.SchemaTableName, error) {
+	schemaStore := c.schemaStore
+	if schemaStore == nil {
+		schemaStore = appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	}
+	info, err := schemaStore.GetTableInfo(tableID, ts)
+	if err != nil {
+		return nil, err
+	}
+	return &commonEvent.SchemaTableName{
+		SchemaName: info.GetSchemaName(),
+		TableName:  info.GetTableName(),
+	}, nil
+}
+
 func (c *Controller) loadTables(startTs uint64) ([]commonEvent.Table, error) {
-	// todo: do we need to set timezone here?
-	f, err := filter.NewFilter(c.cfConfig.Filter, "", c.cfConfig.ForceReplicate)
+	f, err := filter.NewFilter(c.cfConfig.Filter, c.cfConfig.GetTimeZone(), c.cfConfig.CaseSensitive)
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
 
-	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	schemaStore := c.schemaStore
+	if schemaStore == nil {
+		schemaStore = appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	}
 	tables, err := schemaStore.GetAllPhysicalTables(startTs, f)
 	log.Info("get table ids", zap.Int("count", len(tables)), zap.String("changefeed", c.changefeedID.Name()))
 	return tables, err
 }
 
+// getBootstrapStartTs returns the start ts of the changefeed reported by the table trigger
+// event dispatcher in bootstrap, or the start ts specified by the user to override it.
+// The start ts is validated against the GC safepoint of the upstream.
+func (c *Controller) getBootstrapStartTs(
+	cachedResp map[node.ID]*heartbeatpb.MaintainerBootstrapResponse,
+) (uint64, error) {
+	startTs := uint64(0)
+	for node, resp := range cachedResp {
+		log.Info("received bootstrap response",
+			zap.Any("changefeed", resp.ChangefeedID),
+			zap.Any("node", node),
+			zap.Any("startTs", resp.CheckpointTs))
+		if resp.CheckpointTs > startTs {
+			startTs = resp.CheckpointTs
+		}
+	}
+	if c.cfConfig != nil && c.cfConfig.Scheduler != nil && c.cfConfig.Scheduler.BootstrapStartTs != 0 {
+		// the data before the checkpoint ts is already replicated,
+		// the start ts can't be rewound below it.
+		overrideTs := max(c.cfConfig.Scheduler.BootstrapStartTs, c.startCheckpointTs)
+		log.Warn("override the start ts of the changefeed in bootstrap",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Uint64("reportedStartTs", startTs),
+			zap.Uint64("specifiedStartTs", c.cfConfig.Scheduler.BootstrapStartTs),
+			zap.Uint64("checkpointTs", c.startCheckpointTs),
+			zap.Uint64("startTs", overrideTs))
+		startTs = overrideTs
+	}
+	if startTs == 0 {
+		log.Error("can not find the start ts from the bootstrap response",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Int("size", len(cachedResp)))
+		return 0, errors.ErrInvalidBootstrapStartTs.GenWithStackByArgs(
+			startTs, c.changefeedID.Name(), "no start ts is reported by the table trigger event dispatcher")
+	}
+	if startTs < c.startCheckpointTs {
+		log.Warn("the start ts is smaller than the checkpoint ts of the changefeed",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Uint64("startTs", startTs),
+			zap.Uint64("checkpointTs", c.startCheckpointTs))
+	}
+	gcSafePoint := c.gcSafePoint.Load()
+	if gcSafePoint == 0 {
+		// the start ts is checked by the schema store later, don't block the bootstrap
+		log.Warn("gc safepoint is not fetched, skip checking the start ts",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Uint64("startTs", startTs))
+		return startTs, nil
+	}
+	if startTs <= gcSafePoint {
+		return 0, errors.ErrSnapshotLostByGC.GenWithStackByArgs(startTs, gcSafePoint)
+	}
+	return startTs, nil
+}
+
+// fetchGCSafePoint fetches the GC safepoint of the upstream, it's called in background
+// when the maintainer is created, so the event loop is not blocked by the PD request.
+func (c *Controller) fetchGCSafePoint(ctx context.Context) {
+	if c.pdAPI == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	safepoints, err := c.pdAPI.ListGcServiceSafePoint(ctx)
+	if err != nil {
+		log.Warn("list gc safepoint failed",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Error(err))
+		return
+	}
+	c.gcSafePoint.Store(safepoints.GCSafePoint)
+}
+
 // only for test
 // moveTable is used for inner api(which just for make test cases convience) to force move a table to a target node.
 // moveTable only works for the complete table, not for the table splited.
@@ -461,6 +715,67 @@ func (c *Controller) moveTable(tableId int64, targetNode node.ID) error {
 	return nil
 }
 
+// splitTable splits the table which is not split yet to the spans by the comparable split keys,
+// the new spans start from the checkpoint ts of the table when its dispatcher is removed.
+func (c *Controller) splitTable(tableID int64, splitKeys [][]byte) error {
+	if !c.replicationDB.IsTableExists(tableID) {
+		return apperror.ErrTableIsNotFounded.GenWithStackByArgs("tableID", tableID)
+	}
+	replications := c.replicationDB.GetTasksByTableIDs(tableID)
+	if len(replications) != 1 {
+		return errors.ErrAPIInvalidParam.GenWithStack("table %d is already split to %d spans", tableID, len(replications))
+	}
+	// the barriers count the blocked tables instead of the spans if the table across nodes
+	// is disabled, the block events of a split table would be passed once one span reports.
+	if !c.cfConfig.Scheduler.EnableTableAcrossNodes {
+		return errors.ErrAPIInvalidParam.GenWithStack(
+			"table %d can't be split since the table across nodes is disabled", tableID)
+	}
+	replication := replications[0]
+	if replication.GetNodeID() == "" || c.operatorController.GetOperator(replication.ID) != nil {
+		return errors.ErrAPIInvalidParam.GenWithStack("table %d is in scheduling, retry later", tableID)
+	}
+	spans := split.SplitSpanByKeys(replication.Span, splitKeys)
+	if len(spans) < 2 {
+		return errors.ErrAPIInvalidParam.GenWithStack("no split key is in the range of table %d", tableID)
+	}
+	if !c.operatorController.AddOperator(c.operatorController.NewSplitOperator(replication, replication.GetNodeID(), spans)) {
+		return errors.ErrAPIInvalidParam.GenWithStack("table %d is in scheduling, retry later", tableID)
+	}
+	log.Info("split table",
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Int64("tableID", tableID),
+		zap.Int("spans", len(spans)))
+	return nil
+}
+
+// mergeTable merges all spans of the table to one span, the new span starts from the
+// min checkpoint ts of the spans when their dispatchers are removed.
+func (c *Controller) mergeTable(tableID int64) error {
+	if !c.replicationDB.IsTableExists(tableID) {
+		return apperror.ErrTableIsNotFounded.GenWithStackByArgs("tableID", tableID)
+	}
+	replications := c.replicationDB.GetTasksByTableIDs(tableID)
+	if len(replications) < 2 {
+		return errors.ErrAPIInvalidParam.GenWithStack("table %d is not split", tableID)
+	}
+	for _, replication := range replications {
+		if replication.GetNodeID() == "" || c.operatorController.GetOperator(replication.ID) != nil {
+			return errors.ErrAPIInvalidParam.GenWithStack("table %d is in scheduling, retry later", tableID)
+		}
+	}
+	span := spanz.TableIDToComparableSpan(tableID)
+	totalSpan := &heartbeatpb.TableSpan{
+		TableID:  span.TableID,
+		StartKey: span.StartKey,
+		EndKey:   span.EndKey,
+	}
+	if !c.operatorController.AddMergeSplitOperator(replications, []*heartbeatpb.TableSpan{totalSpan}) {
+		return errors.ErrAPIInvalidParam.GenWithStack("table %d is in scheduling, retry later", tableID)
+	}
+	return nil
+}
+
 func getSchemaInfo(table commonEvent.Table, isMysqlCompatibleBackend bool) *heartbeatpb.SchemaInfo {
 	schemaInfo := &heartbeatpb.SchemaInfo{}
 	if isMysqlCompatibleBackend {
@@ -28,10 +28,13 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/scheduler"
 	pkgOpearator "github.com/pingcap/ticdc/pkg/scheduler/operator"
+	pkgReplica "github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
@@ -56,7 +59,7 @@ func TestSchedule(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute, time.Minute)
 	for i := 0; i < 10; i++ {
 		controller.AddNewTable(commonEvent.Table{
 			SchemaID: 1,
@@ -76,6 +79,44 @@ func TestSchedule(t *testing.T) {
 	require.Equal(t, 3, controller.GetTaskSizeByNodeID("node3"))
 }
 
+func TestHandleStatusTightensBalanceInterval(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 10*time.Second, time.Minute)
+	nodes := nodeManager.GetAliveNodes()
+	controller.balanceInterval.Observe(nodes, 0, 0)
+	controller.balanceInterval.Observe(nodes, 0, 0)
+	require.Equal(t, 20*time.Second, controller.balanceInterval.Get())
+
+	// the heartbeat doesn't change the state of the span, keep backing off
+	controller.HandleStatus("node1", []*heartbeatpb.TableSpanStatus{{
+		ID:              tableTriggerEventDispatcherID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    2,
+	}})
+	require.Equal(t, 20*time.Second, controller.balanceInterval.Get())
+
+	// the span is stopped on the node, check the balance at the min interval
+	controller.HandleStatus("node1", []*heartbeatpb.TableSpanStatus{{
+		ID:              tableTriggerEventDispatcherID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Stopped,
+		CheckpointTs:    3,
+	}})
+	require.Equal(t, 10*time.Second, controller.balanceInterval.Get())
+	now := time.Now()
+	require.True(t, controller.balanceInterval.Due(now, now, nodes, func() int { return 0 }))
+}
+
 func TestRemoveAbsentTask(t *testing.T) {
 	setNodeManagerAndMessageCenter()
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
@@ -88,7 +129,7 @@ func TestRemoveAbsentTask(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute)
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute, time.Minute)
 	controller.AddNewTable(commonEvent.Table{
 		SchemaID: 1,
 		TableID:  int64(1),
@@ -112,7 +153,7 @@ func TestBalanceGlobalEven(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	for i := 0; i < 100; i++ {
 		// generate 100 groups
 		totalSpan := spanz.TableIDToComparableSpan(int64(i))
@@ -183,7 +224,7 @@ func TestBalanceGlobalUneven(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	for i := 0; i < 100; i++ {
 		// generate 100 groups
 		totalSpan := spanz.TableIDToComparableSpan(int64(i))
@@ -259,7 +300,7 @@ func TestBalance(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	for i := 0; i < 100; i++ {
 		sz := spanz.TableIDToComparableSpan(int64(i))
 		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
@@ -327,7 +368,7 @@ func TestStoppedWhenMoving(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 	for i := 0; i < 2; i++ {
 		sz := spanz.TableIDToComparableSpan(int64(i))
 		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
@@ -357,6 +398,144 @@ func TestStoppedWhenMoving(t *testing.T) {
 	require.Equal(t, 0, s.replicationDB.GetTaskSizeByNodeID("node2"))
 }
 
+func TestNodeStoppingHeartbeat(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	statuses := make([]*heartbeatpb.TableSpanStatus, 0)
+	for i := 1; i <= 2; i++ {
+		sz := spanz.TableIDToComparableSpan(int64(i))
+		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
+		dispatcherID := common.NewDispatcherID()
+		spanReplica := replica.NewReplicaSet(cfID, dispatcherID, tsoClient, 1, span, 1)
+		spanReplica.SetNodeID("node2")
+		s.replicationDB.AddReplicatingSpan(spanReplica)
+		statuses = append(statuses, &heartbeatpb.TableSpanStatus{
+			ID:              dispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Stopped,
+			CheckpointTs:    10,
+		})
+	}
+	m := &Maintainer{
+		id:                    cfID,
+		controller:            s,
+		nodeManager:           nodeManager,
+		bootstrapped:          true,
+		checkpointTsByCapture: make(map[node.ID]heartbeatpb.Watermark),
+	}
+	// a regular heartbeat only updates the status
+	m.onHeartBeatRequest(&messaging.TargetMessage{
+		From:    "node2",
+		Type:    messaging.TypeHeartBeatRequest,
+		Message: []messaging.IOTypeT{&heartbeatpb.HeartBeatRequest{ChangefeedID: cfID.ToPB()}},
+	})
+	require.Equal(t, 2, s.replicationDB.GetReplicatingSize())
+
+	// the final heartbeat of the stopping node marks its spans absent with the reported checkpoints
+	m.onHeartBeatRequest(&messaging.TargetMessage{
+		From: "node2",
+		Type: messaging.TypeHeartBeatRequest,
+		Message: []messaging.IOTypeT{&heartbeatpb.HeartBeatRequest{
+			ChangefeedID:    cfID.ToPB(),
+			Watermark:       &heartbeatpb.Watermark{CheckpointTs: 10, ResolvedTs: 10},
+			Statuses:        statuses,
+			CompeleteStatus: true,
+			NodeStopping:    true,
+		}},
+	})
+	require.Equal(t, 0, s.replicationDB.GetReplicatingSize())
+	require.Equal(t, 2, s.replicationDB.GetAbsentSize())
+	require.Equal(t, 0, s.replicationDB.GetTaskSizeByNodeID("node2"))
+	require.True(t, nodeManager.IsNodeStopping("node2"))
+	require.NotContains(t, nodeManager.GetSchedulableNodes(), node.ID("node2"))
+	for _, span := range s.replicationDB.GetTasksBySchemaID(1) {
+		require.Equal(t, uint64(10), span.GetStatus().CheckpointTs)
+	}
+}
+
+func TestReconcileOrphans(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+
+	newSpan := func(tableID int64) *replica.SpanReplication {
+		sz := spanz.TableIDToComparableSpan(tableID)
+		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
+		return replica.NewReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1, span, 1)
+	}
+	working := func(id common.DispatcherID) *heartbeatpb.TableSpanStatus {
+		return &heartbeatpb.TableSpanStatus{
+			ID:              id.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    10,
+		}
+	}
+	// the span is absent, but its dispatcher is still working on node2
+	absent := newSpan(1)
+	s.replicationDB.AddAbsentReplicaSet(absent)
+	// the span is replicating on node1, but another dispatcher is reported by node2
+	replicating := newSpan(2)
+	replicating.SetNodeID("node1")
+	s.replicationDB.AddReplicatingSpan(replicating)
+	// the dispatcher is unknown
+	unknownID := common.NewDispatcherID()
+
+	s.HandleStatus("node2", []*heartbeatpb.TableSpanStatus{
+		working(absent.ID), working(replicating.ID), working(unknownID),
+	})
+	require.Len(t, s.orphans, 3)
+
+	// nothing is reconciled in the grace period
+	s.ReconcileOrphans(time.Now())
+	require.Len(t, s.orphans, 3)
+	require.Equal(t, 1, s.replicationDB.GetAbsentSize())
+
+	s.ReconcileOrphans(time.Now().Add(orphanGracePeriod))
+	require.Len(t, s.orphans, 0)
+	// the dispatcher of the absent span is adopted
+	require.Equal(t, 0, s.replicationDB.GetAbsentSize())
+	require.Equal(t, node.ID("node2"), absent.GetNodeID())
+	require.Equal(t, uint64(10), absent.GetStatus().CheckpointTs)
+	// the replicating span is not changed
+	require.Equal(t, node.ID("node1"), replicating.GetNodeID())
+
+	// the orphan is forgotten if it's not reported anymore
+	s.HandleStatus("node2", []*heartbeatpb.TableSpanStatus{working(unknownID)})
+	require.Len(t, s.orphans, 1)
+	s.ReconcileOrphans(time.Now().Add(orphanExpiration + time.Second))
+	require.Len(t, s.orphans, 0)
+
+	// the dispatcher on the node the span is not bound to is removed
+	s.HandleStatus("node1", []*heartbeatpb.TableSpanStatus{working(absent.ID)})
+	s.HandleStatus("node2", []*heartbeatpb.TableSpanStatus{working(absent.ID)})
+	require.Len(t, s.orphans, 1)
+	s.ReconcileOrphans(time.Now().Add(orphanGracePeriod))
+	require.Len(t, s.orphans, 0)
+	require.Equal(t, node.ID("node2"), absent.GetNodeID())
+}
+
 func TestFinishBootstrap(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
@@ -372,7 +551,7 @@ func TestFinishBootstrap(t *testing.T) {
 			CheckpointTs:    1,
 		}, "node1")
 	s := NewController(cfID, 1, nil, tsoClient, nil, &mockThreadPool{},
-		config.GetDefaultReplicaConfig(), ddlSpan, 1000, 0)
+		config.GetDefaultReplicaConfig(), ddlSpan, 1000, 0, 0)
 	totalSpan := spanz.TableIDToComparableSpan(1)
 	span := &heartbeatpb.TableSpan{TableID: int64(1), StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}
 	schemaStore := &mockSchemaStore{
@@ -427,6 +606,91 @@ func TestFinishBootstrap(t *testing.T) {
 	})
 }
 
+func TestFinishBootstrapStartTs(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient,
+		heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	cfg := config.GetDefaultReplicaConfig()
+	pdAPI := &mockPdAPI{gcSafepoint: 10}
+	s := NewController(cfID, 6, pdAPI, tsoClient, nil, &mockThreadPool{}, cfg, ddlSpan, 1000, 0, 0)
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{})
+	s.fetchGCSafePoint(context.Background())
+
+	// no start ts is reported
+	resp := map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
+		"node1": {ChangefeedID: cfID.ToPB()},
+	}
+	_, _, err := s.FinishBootstrap(resp, false)
+	code, ok := errors.RFCCode(err)
+	require.True(t, ok)
+	require.Equal(t, errors.ErrInvalidBootstrapStartTs.RFCCode(), code)
+	require.False(t, s.bootstrapped)
+
+	// the start ts is specified by the user, but it's lost by GC
+	cfg.Scheduler.BootstrapStartTs = 8
+	_, _, err = s.FinishBootstrap(resp, false)
+	code, ok = errors.RFCCode(err)
+	require.True(t, ok)
+	require.Equal(t, errors.ErrSnapshotLostByGC.RFCCode(), code)
+	require.False(t, s.bootstrapped)
+
+	// the start ts specified by the user can't be smaller than the checkpoint ts
+	pdAPI.gcSafepoint = 4
+	s.fetchGCSafePoint(context.Background())
+	cfg.Scheduler.BootstrapStartTs = 5
+	_, _, err = s.FinishBootstrap(resp, false)
+	require.NoError(t, err)
+	require.True(t, s.bootstrapped)
+	require.Equal(t, uint64(6), s.replicationDB.GetDDLDispatcher().GetStatus().CheckpointTs)
+}
+
+func TestFinishBootstrapSchemaStoreError(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient,
+		heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	s := NewController(cfID, 1, nil, tsoClient, nil, &mockThreadPool{}, config.GetDefaultReplicaConfig(), ddlSpan, 1000, 0, 0)
+	schemaStore := &mockSchemaStore{}
+	appcontext.SetService(appcontext.SchemaStore, schemaStore)
+	resp := map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
+		"node1": {ChangefeedID: cfID.ToPB(), CheckpointTs: 10},
+	}
+
+	// the transient error is retried in the degraded mode
+	schemaStore.err = errors.New("pebble: closed")
+	_, _, err := s.FinishBootstrap(resp, false)
+	code, ok := errors.RFCCode(err)
+	require.True(t, ok)
+	require.Equal(t, errors.ErrSchemaStoreUnavailable.RFCCode(), code)
+
+	// the schema snapshot is lost, the error fails the changefeed
+	schemaStore.err = errors.ErrSnapshotLostByGC.GenWithStackByArgs(10, 20)
+	_, _, err = s.FinishBootstrap(resp, false)
+	code, ok = errors.RFCCode(err)
+	require.True(t, ok)
+	require.Equal(t, errors.ErrSnapshotLostByGC.RFCCode(), code)
+	require.False(t, s.bootstrapped)
+}
+
 // 4 tasks and 2 servers, then add one server, no re-balance will be triggered
 func TestBalanceUnEvenTask(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
@@ -442,7 +706,7 @@ func TestBalanceUnEvenTask(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
 
 	for i := 0; i < 4; i++ {
 		sz := spanz.TableIDToComparableSpan(int64(i))
@@ -535,7 +799,7 @@ func TestSplitTableWhenBootstrapFinished(t *testing.T) {
 		WriteKeyThreshold:      1,
 	}
 	s := NewController(cfID, 1,
-		pdAPI, tsoClient, nil, nil, defaultConfig, ddlSpan, 1000, 0)
+		pdAPI, tsoClient, nil, nil, defaultConfig, ddlSpan, 1000, 0, 0)
 	s.taskScheduler = &mockThreadPool{}
 	schemaStore := &mockSchemaStore{tables: []commonEvent.Table{
 		{TableID: 1, SchemaID: 1, SchemaTableName: &commonEvent.SchemaTableName{SchemaName: "test", TableName: "t"}},
@@ -617,7 +881,7 @@ func TestDynamicSplitTableBasic(t *testing.T) {
 				RegionThreshold:        0,
 				WriteKeyThreshold:      1,
 			},
-		}, ddlSpan, 1000, 0)
+		}, ddlSpan, 1000, 0, 0)
 	s.taskScheduler = &mockThreadPool{}
 
 	for i := 1; i <= 2; i++ {
@@ -703,7 +967,7 @@ func TestDynamicMergeAndSplitTable(t *testing.T) {
 				RegionThreshold:        0,
 				WriteKeyThreshold:      1,
 			},
-		}, ddlSpan, 1000, 0)
+		}, ddlSpan, 1000, 0, 0)
 	s.taskScheduler = &mockThreadPool{}
 
 	totalTables := 10
@@ -795,7 +1059,7 @@ func TestDynamicMergeTableBasic(t *testing.T) {
 				RegionThreshold:        0,
 				WriteKeyThreshold:      1,
 			},
-		}, ddlSpan, 1000, 0)
+		}, ddlSpan, 1000, 0, 0)
 	s.taskScheduler = &mockThreadPool{}
 
 	totalTables := 10
@@ -916,7 +1180,12 @@ func appendNew(origin []byte, c byte) []byte {
 
 type mockPdAPI struct {
 	pdutil.PDAPIClient
-	regions map[int64][]pdutil.RegionInfo
+	regions     map[int64][]pdutil.RegionInfo
+	gcSafepoint uint64
+}
+
+func (m *mockPdAPI) ListGcServiceSafePoint(_ context.Context) (*pdutil.ListServiceGCSafepoint, error) {
+	return &pdutil.ListServiceGCSafepoint{GCSafePoint: m.gcSafepoint}, nil
 }
 
 func (m *mockPdAPI) ScanRegions(_ context.Context, span tablepb.Span) ([]pdutil.RegionInfo, error) {
@@ -934,3 +1203,118 @@ func (m *mockThreadPool) Submit(_ threadpool.Task, _ time.Time) *threadpool.Task
 func (m *mockThreadPool) SubmitFunc(_ threadpool.FuncTask, _ time.Time) *threadpool.TaskHandle {
 	return nil
 }
+
+func TestInitializationProgress(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient,
+		heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	s := NewController(cfID, 1, nil, tsoClient, nil, &mockThreadPool{},
+		config.GetDefaultReplicaConfig(), ddlSpan, 1000, 0, 0)
+	require.Equal(t, InitializationProgress{Phase: InitPhaseBootstrapping}, s.GetInitializationProgress())
+
+	totalSpan := spanz.TableIDToComparableSpan(1)
+	span := &heartbeatpb.TableSpan{TableID: int64(1), StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{
+		tables: []commonEvent.Table{
+			{TableID: 1, SchemaID: 1, SchemaTableName: &commonEvent.SchemaTableName{SchemaName: "test", TableName: "t1"}},
+			{TableID: 2, SchemaID: 1, SchemaTableName: &commonEvent.SchemaTableName{SchemaName: "test", TableName: "t2"}},
+		},
+	})
+	_, _, err := s.FinishBootstrap(map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
+		"node1": {
+			ChangefeedID: cfID.ToPB(),
+			Spans: []*heartbeatpb.BootstrapTableSpan{
+				{
+					ID:              common.NewDispatcherID().ToPB(),
+					SchemaID:        1,
+					Span:            span,
+					ComponentStatus: heartbeatpb.ComponentState_Working,
+					CheckpointTs:    10,
+				},
+			},
+			CheckpointTs: 10,
+		},
+	}, false)
+	require.NoError(t, err)
+
+	s.checkInitialized()
+	require.Equal(t, InitializationProgress{
+		Phase:          InitPhaseScheduling,
+		TablesLoaded:   2,
+		SpansCreated:   2,
+		SpansScheduled: 1,
+		SpansWorking:   1,
+		PercentWorking: 50,
+	}, s.GetInitializationProgress())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.WaitForReady(ctx), context.DeadlineExceeded)
+
+	absent := s.replicationDB.GetAbsent()
+	require.Len(t, absent, 1)
+	s.replicationDB.BindSpanToNode("", "node1", absent[0])
+	require.Equal(t, 2, s.GetInitializationProgress().SpansScheduled)
+	s.replicationDB.MarkSpanReplicating(absent[0])
+	s.checkInitialized()
+	require.NoError(t, s.WaitForReady(context.Background()))
+	require.Equal(t, InitializationProgress{
+		Phase:          InitPhaseWorking,
+		TablesLoaded:   2,
+		SpansCreated:   2,
+		SpansScheduled: 2,
+		SpansWorking:   2,
+		PercentWorking: 100,
+	}, s.GetInitializationProgress())
+}
+
+func TestUpdateTableGroupsOnRename(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient,
+		heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Scheduler.GroupBy = config.GroupByTag
+	cfg.Scheduler.GroupTags = []*config.GroupTagRule{{Matcher: []string{"hot_rename.*"}, Tag: "hot-rename"}}
+	s := NewController(cfID, 1, nil, tsoClient, nil, &mockThreadPool{}, cfg, ddlSpan, 1000, 0, 0)
+	schemaStore := &mockSchemaStore{tables: []commonEvent.Table{{
+		TableID:         1,
+		SchemaID:        1,
+		SchemaTableName: &commonEvent.SchemaTableName{SchemaName: "cold", TableName: "t"},
+	}}}
+	s.schemaStore = schemaStore
+	hot := pkgReplica.GenTagGroupID("hot-rename")
+	require.Equal(t, "tag-hot-rename", pkgReplica.GetGroupName(hot))
+
+	// the name of the table added by a ddl is loaded from the schema store
+	s.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 10)
+	require.Equal(t, pkgReplica.DefaultGroupID, s.GetTasksByTableIDs(1)[0].GetGroupID())
+
+	// the table is moved to the tag group after it's renamed
+	schemaStore.tables[0].SchemaTableName = &commonEvent.SchemaTableName{SchemaName: "hot_rename", TableName: "t"}
+	s.UpdateTableGroups([]int64{heartbeatpb.DDLSpan.TableID, 1}, 20)
+	require.Equal(t, hot, s.GetTasksByTableIDs(1)[0].GetGroupID())
+	require.Len(t, s.replicationDB.GetAbsentByGroup(hot, 10), 1)
+
+	// the tag is released after the controller is stopped
+	s.Stop()
+	require.NotEqual(t, "tag-hot-rename", pkgReplica.GetGroupName(hot))
+}
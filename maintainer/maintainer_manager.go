@@ -21,15 +21,17 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/upstreamservice"
 	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/maintainer/split"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/utils/threadpool"
-	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
 
@@ -47,11 +49,15 @@ type Manager struct {
 
 	coordinatorID      node.ID
 	coordinatorVersion int64
+	// reportedCapacity is the capacity of the node last reported to the coordinator.
+	reportedCapacity heartbeatpb.NodeCapacity
 
 	selfNode    *node.Info
 	pdAPI       pdutil.PDAPIClient
 	tsoClient   replica.TSOClient
-	regionCache *tikv.RegionCache
+	regionCache split.RegionCache
+	// upstreamID -> pd api client of the non-default upstream
+	upstreamPDAPIs map[uint64]pdutil.PDAPIClient
 
 	// msgCh is used to cache messages from coordinator
 	msgCh chan *messaging.TargetMessage
@@ -65,19 +71,20 @@ func NewMaintainerManager(selfNode *node.Info,
 	conf *config.SchedulerConfig,
 	pdAPI pdutil.PDAPIClient,
 	pdClient replica.TSOClient,
-	regionCache *tikv.RegionCache,
+	regionCache split.RegionCache,
 ) *Manager {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	m := &Manager{
-		mc:            mc,
-		conf:          conf,
-		maintainers:   sync.Map{},
-		selfNode:      selfNode,
-		msgCh:         make(chan *messaging.TargetMessage, 1024),
-		taskScheduler: threadpool.NewThreadPoolDefault(),
-		pdAPI:         pdAPI,
-		tsoClient:     pdClient,
-		regionCache:   regionCache,
+		mc:             mc,
+		conf:           conf,
+		maintainers:    sync.Map{},
+		selfNode:       selfNode,
+		msgCh:          make(chan *messaging.TargetMessage, 1024),
+		taskScheduler:  threadpool.NewThreadPoolDefault(),
+		pdAPI:          pdAPI,
+		tsoClient:      pdClient,
+		regionCache:    regionCache,
+		upstreamPDAPIs: make(map[uint64]pdutil.PDAPIClient),
 	}
 
 	mc.RegisterHandler(messaging.MaintainerManagerTopic, m.recvMessages)
@@ -150,6 +157,10 @@ func (m *Manager) Run(ctx context.Context) error {
 					log.Info("maintainer removed, remove it from dynamic stream",
 						zap.String("changefeed", cf.id.String()))
 					m.maintainers.Delete(key)
+					if cf.config.UpstreamInfo != nil {
+						appcontext.GetService[*upstreamservice.Manager](appcontext.UpstreamLogService).
+							Release(cf.config.UpstreamInfo.ID, upstreamservice.MaintainerHolder(cf.id))
+					}
 				}
 				return true
 			})
@@ -189,9 +200,12 @@ func (m *Manager) onCoordinatorBootstrapRequest(msg *messaging.TargetMessage) {
 	m.coordinatorID = msg.From
 	m.coordinatorVersion = req.Version
 
-	response := &heartbeatpb.CoordinatorBootstrapResponse{}
+	response := &heartbeatpb.CoordinatorBootstrapResponse{Capacity: node.GetLocalCapacity()}
+	m.reportedCapacity = *response.Capacity
 	m.maintainers.Range(func(key, value interface{}) bool {
 		maintainer := value.(*Maintainer)
+		// the new coordinator may not know the barrier coverages reported before
+		maintainer.resetReportedBarriers()
 		response.Statuses = append(response.Statuses, maintainer.GetMaintainerStatus())
 		maintainer.statusChanged.Store(false)
 		maintainer.lastReportTime = time.Now()
@@ -225,8 +239,18 @@ func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest)
 			zap.Uint64("checkpointTs", req.CheckpointTs),
 			zap.Any("config", cfConfig))
 	}
+	pdAPI, tsoClient, regionCache, err := m.getUpstreamClients(cfID, cfConfig.UpstreamInfo)
+	if err != nil {
+		log.Warn("get upstream of the changefeed failed, coordinator will retry later",
+			zap.Stringer("changefeed", cfID),
+			zap.Uint64("upstreamID", cfConfig.UpstreamID),
+			zap.Error(err))
+		return
+	}
 	cf := NewMaintainer(cfID, m.conf, cfConfig, m.selfNode, m.taskScheduler,
-		m.pdAPI, m.tsoClient, m.regionCache, req.CheckpointTs, req.IsNewChangfeed)
+		pdAPI, tsoClient, regionCache, req.CheckpointTs, req.IsNewChangfeed, req.Epoch)
+	cf.restoreBarriers(req.Barriers)
+	cf.restoreBackfills(req.Backfills)
 	if err != nil {
 		log.Warn("add path to dynstream failed, coordinator will retry later", zap.Error(err))
 		return
@@ -236,6 +260,32 @@ func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest)
 	m.maintainers.Store(cfID, cf)
 }
 
+// getUpstreamClients returns the pd clients and the region cache of the upstream,
+// the clients of the default upstream are returned if info is nil.
+// The log service of a non-default upstream is created on demand.
+func (m *Manager) getUpstreamClients(cfID common.ChangeFeedID, info *config.UpstreamInfo) (
+	pdutil.PDAPIClient, replica.TSOClient, split.RegionCache, error,
+) {
+	if info == nil {
+		return m.pdAPI, m.tsoClient, m.regionCache, nil
+	}
+	logService, err := appcontext.GetService[*upstreamservice.Manager](appcontext.UpstreamLogService).
+		Acquire(info, upstreamservice.MaintainerHolder(cfID))
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	up := logService.Upstream
+	pdAPI, ok := m.upstreamPDAPIs[up.ID]
+	if !ok {
+		pdAPI, err = pdutil.NewPDAPIClient(up.PDClient, up.SecurityConfig)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		m.upstreamPDAPIs[up.ID] = pdAPI
+	}
+	return pdAPI, up.PDClient, up.RegionCache, nil
+}
+
 func (m *Manager) onRemoveMaintainerRequest(msg *messaging.TargetMessage) *heartbeatpb.MaintainerStatus {
 	req := msg.Message[0].(*heartbeatpb.RemoveMaintainerRequest)
 	cfID := common.NewChangefeedIDFromPB(req.GetId())
@@ -291,6 +341,11 @@ func (m *Manager) onDispatchMaintainerRequest(
 func (m *Manager) sendHeartbeat() {
 	if m.isBootstrap() {
 		response := &heartbeatpb.MaintainerHeartbeat{}
+		// report the capacity only if it's changed
+		if capacity := node.GetLocalCapacity(); *capacity != m.reportedCapacity {
+			response.Capacity = capacity
+			m.reportedCapacity = *capacity
+		}
 		m.maintainers.Range(func(key, value interface{}) bool {
 			cfMaintainer := value.(*Maintainer)
 			if cfMaintainer.statusChanged.Load() || time.Since(cfMaintainer.lastReportTime) > time.Second*2 {
@@ -300,7 +355,7 @@ func (m *Manager) sendHeartbeat() {
 			}
 			return true
 		})
-		if len(response.Statuses) != 0 {
+		if len(response.Statuses) != 0 || response.Capacity != nil {
 			m.sendMessages(response)
 		}
 	}
@@ -28,6 +28,7 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
@@ -35,6 +36,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/server/watcher"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
 	config2 "github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
@@ -428,10 +431,23 @@ func TestStopNotExistsMaintainer(t *testing.T) {
 type mockSchemaStore struct {
 	schemastore.SchemaStore
 	tables []commonEvent.Table
+	err    error
 }
 
 func (m *mockSchemaStore) GetAllPhysicalTables(snapTs common.Ts, filter filter.Filter) ([]commonEvent.Table, error) {
-	return m.tables, nil
+	return m.tables, m.err
+}
+
+func (m *mockSchemaStore) GetTableInfo(tableID int64, ts common.Ts) (*common.TableInfo, error) {
+	for _, table := range m.tables {
+		if table.TableID == tableID && table.SchemaTableName != nil {
+			return common.WrapTableInfo(table.SchemaID, table.SchemaName, &timodel.TableInfo{
+				ID:   table.TableID,
+				Name: pmodel.NewCIStr(table.TableName),
+			}), nil
+		}
+	}
+	return nil, errors.New("table not found")
 }
 
 type dispatcherNode struct {
@@ -485,3 +501,7 @@ func newMockEtcdClient(ownerID string) *mockEtcdClient {
 func (m *mockEtcdClient) GetOwnerID(ctx context.Context) (model.CaptureID, error) {
 	return model.CaptureID(m.ownerID), nil
 }
+
+func (m *mockEtcdClient) GetNodeInfo(ctx context.Context, id string) (*node.Info, error) {
+	return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(id)
+}
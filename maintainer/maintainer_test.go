@@ -26,19 +26,24 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/bootstrap"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/threadpool"
+	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -135,6 +140,9 @@ func (m *mockDispatcherManager) onBootstrapRequest(msg *messaging.TargetMessage)
 		ChangefeedID: req.ChangefeedID,
 		Spans:        m.bootstrapTables,
 		CheckpointTs: req.StartTs,
+		// the mock handles the batched schedule dispatcher requests
+		ProtocolVersion: heartbeatpb.ProtocolVersion,
+		Capabilities:    []string{heartbeatpb.CapabilityBatchScheduleRequest},
 	}
 	m.changefeedID = req.ChangefeedID
 	m.checkpointTs = req.StartTs
@@ -181,13 +189,20 @@ func (m *mockDispatcherManager) onPostBootstrapRequest(msg *messaging.TargetMess
 func (m *mockDispatcherManager) onDispatchRequest(
 	msg *messaging.TargetMessage,
 ) {
-	request := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
 	if m.maintainerID != msg.From {
 		log.Warn("ignore invalid maintainer id",
-			zap.Any("request", request),
+			zap.Any("request", msg.Message),
 			zap.Any("maintainer", msg.From))
 		return
 	}
+	for _, req := range msg.Message {
+		m.handleDispatchRequest(msg, req.(*heartbeatpb.ScheduleDispatcherRequest))
+	}
+}
+
+func (m *mockDispatcherManager) handleDispatchRequest(
+	msg *messaging.TargetMessage, request *heartbeatpb.ScheduleDispatcherRequest,
+) {
 	if request.ScheduleAction == heartbeatpb.ScheduleAction_Create {
 		if m.dispatchersMap[*request.Config.DispatcherID] != nil {
 			log.Warn("dispatcher already exists",
@@ -324,7 +339,7 @@ func TestMaintainerSchedule(t *testing.T) {
 		},
 		&config.ChangeFeedInfo{
 			Config: config.GetDefaultReplicaConfig(),
-		}, n, taskScheduler, nil, tsoClient, nil, 10, true)
+		}, n, taskScheduler, nil, tsoClient, nil, 10, true, 0)
 
 	mc.RegisterHandler(messaging.MaintainerManagerTopic,
 		func(ctx context.Context, msg *messaging.TargetMessage) error {
@@ -367,3 +382,177 @@ func TestMaintainerSchedule(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+func TestTopLaggingTables(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	now := time.Now()
+	tsAt := func(d time.Duration) uint64 {
+		return oracle.GoTimeToTS(now.Add(-d))
+	}
+	newSpan := func(tableID int64, checkpointTs uint64, nodeID node.ID) *replica.SpanReplication {
+		tableSpan := heartbeatpb.DDLSpan
+		if tableID != 0 {
+			totalSpan := spanz.TableIDToComparableSpan(tableID)
+			tableSpan = &heartbeatpb.TableSpan{TableID: tableID, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}
+		}
+		span := replica.NewReplicaSet(cfID, common.NewDispatcherID(), nil, 1, tableSpan, checkpointTs)
+		span.SetNodeID(nodeID)
+		return span
+	}
+	spans := []*replica.SpanReplication{
+		newSpan(0, tsAt(time.Hour), "node1"),
+		newSpan(1, tsAt(time.Second), "node1"),
+		// table 2 has two spans, the slower one decides the lag of the table
+		newSpan(2, tsAt(time.Second), "node1"),
+		newSpan(2, tsAt(time.Minute), "node2"),
+		newSpan(3, tsAt(10*time.Second), "node1"),
+	}
+
+	result := topLaggingTables(spans, 2, now)
+	require.Len(t, result, 2)
+	require.Equal(t, int64(2), result[0].TableID)
+	require.Equal(t, node.ID("node2"), result[0].NodeID)
+	require.Equal(t, tsAt(time.Minute), result[0].CheckpointTs)
+	require.InDelta(t, time.Minute.Seconds(), result[0].Lag.Seconds(), 0.01)
+	require.Equal(t, int64(3), result[1].TableID)
+
+	// no limit
+	result = topLaggingTables(spans, 0, now)
+	require.Len(t, result, 3)
+	require.Equal(t, int64(1), result[2].TableID)
+}
+
+func TestSchemaUnavailableDegradedMode(t *testing.T) {
+	cfg := config.GetDefaultReplicaConfig()
+	stuckDuration := time.Hour
+	cfg.ChangefeedErrorStuckDuration = &stuckDuration
+	m := &Maintainer{
+		id:            common.NewChangeFeedIDWithName("test"),
+		selfNode:      &node.Info{ID: "node1", AdvertiseAddr: "127.0.0.1:8300"},
+		config:        &config.ChangeFeedInfo{Config: cfg},
+		statusChanged: atomic.NewBool(false),
+		runningErrors: map[node.ID]*heartbeatpb.RunningError{},
+	}
+	m.watermark.Watermark = &heartbeatpb.Watermark{CheckpointTs: 10, ResolvedTs: 10}
+	resp := map[node.ID]*heartbeatpb.MaintainerBootstrapResponse{
+		"node1": {}, "node2": {},
+	}
+
+	// not caused by the schema store
+	require.False(t, m.onSchemaUnavailable(resp, errors.ErrInvalidBootstrapStartTs.GenWithStackByArgs(0, "test", "")))
+	require.Nil(t, m.schemaUnavailable)
+
+	err := errors.ErrSchemaStoreUnavailable.GenWithStackByArgs("test", 10)
+	require.True(t, m.onSchemaUnavailable(resp, err))
+	require.NotNil(t, m.schemaUnavailable)
+	require.Equal(t, schemaRetryInitInterval, m.schemaUnavailable.backoff)
+	status := m.GetMaintainerStatus()
+	require.Empty(t, status.Err)
+	require.Len(t, status.Warnings, 1)
+	require.Equal(t, string(errors.ErrSchemaStoreUnavailable.RFCCode()), status.Warnings[0].Code)
+
+	// the backoff grows and is capped
+	for i := 0; i < 10; i++ {
+		require.True(t, m.onSchemaUnavailable(resp, err))
+	}
+	require.Equal(t, schemaRetryMaxInterval, m.schemaUnavailable.backoff)
+
+	// the schema store is unavailable longer than the stuck duration
+	m.schemaUnavailable.since = time.Now().Add(-stuckDuration)
+	require.False(t, m.onSchemaUnavailable(resp, err))
+	require.Nil(t, m.schemaUnavailable)
+	require.Empty(t, m.GetMaintainerStatus().Warnings)
+}
+
+func TestMaintainerReportBarriersIncrementally(t *testing.T) {
+	m := &Maintainer{
+		id:            common.NewChangeFeedIDWithName("test"),
+		statusChanged: atomic.NewBool(false),
+		runningErrors: map[node.ID]*heartbeatpb.RunningError{},
+	}
+	m.watermark.Watermark = &heartbeatpb.Watermark{CheckpointTs: 10, ResolvedTs: 10}
+	coverage := func(blockTs uint64, tableIDs ...int64) *heartbeatpb.BarrierCoverage {
+		c := &heartbeatpb.BarrierCoverage{BlockTs: blockTs}
+		for _, id := range tableIDs {
+			c.Covered = append(c.Covered, &heartbeatpb.TableSpan{TableID: id})
+		}
+		return c
+	}
+	setCoverages := func(coverages ...*heartbeatpb.BarrierCoverage) {
+		m.barrierCoverages.Store(&coverages)
+	}
+
+	// all the coverages are reported at first
+	setCoverages(coverage(20, 1), coverage(30, 2))
+	status := m.GetMaintainerStatus()
+	require.True(t, status.BarriersFull)
+	require.Len(t, status.Barriers, 2)
+
+	// nothing is reported if the coverages are not changed
+	status = m.GetMaintainerStatus()
+	require.False(t, status.BarriersFull)
+	require.Empty(t, status.Barriers)
+
+	// the changed and the removed coverages are reported
+	setCoverages(coverage(30, 2, 3), coverage(40, 4))
+	status = m.GetMaintainerStatus()
+	require.False(t, status.BarriersFull)
+	require.ElementsMatch(t, []*heartbeatpb.BarrierCoverage{
+		coverage(20), coverage(30, 2, 3), coverage(40, 4),
+	}, status.Barriers)
+
+	// all the coverages are reported periodically
+	m.lastFullBarrierTime = time.Now().Add(-barrierFullReportInterval)
+	status = m.GetMaintainerStatus()
+	require.True(t, status.BarriersFull)
+	require.Len(t, status.Barriers, 2)
+
+	// and after they are reset for a new coordinator
+	m.resetReportedBarriers()
+	status = m.GetMaintainerStatus()
+	require.True(t, status.BarriersFull)
+	require.Len(t, status.Barriers, 2)
+}
+
+func TestBootstrapResponseRecordsCapabilities(t *testing.T) {
+	setNodeManagerAndMessageCenter()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	m := &Maintainer{
+		id:               cfID,
+		selfNode:         &node.Info{ID: "node1"},
+		controller:       NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 9, time.Minute, time.Minute),
+		nodeCapabilities: make(map[node.ID]heartbeatpb.Capabilities),
+		bootstrapper: bootstrap.NewBootstrapper[heartbeatpb.MaintainerBootstrapResponse](cfID.Name(),
+			func(id node.ID) *messaging.TargetMessage { return nil }),
+	}
+	onResponse := func(from node.ID, resp *heartbeatpb.MaintainerBootstrapResponse) {
+		resp.ChangefeedID = cfID.ToPB()
+		msg := messaging.NewSingleTargetMessage("node1", messaging.MaintainerManagerTopic, resp)
+		msg.From = from
+		m.onMaintainerBootstrapResponse(msg)
+	}
+
+	onResponse("node1", &heartbeatpb.MaintainerBootstrapResponse{
+		ProtocolVersion: heartbeatpb.ProtocolVersion,
+		Capabilities:    heartbeatpb.LocalCapabilities(),
+	})
+	// the old node reports neither the version nor the capabilities
+	onResponse("node2", &heartbeatpb.MaintainerBootstrapResponse{})
+	onResponse("node3", &heartbeatpb.MaintainerBootstrapResponse{
+		ProtocolVersion: heartbeatpb.ProtocolVersion + 1,
+		Capabilities:    []string{heartbeatpb.CapabilityQuiesce, "unknown"},
+	})
+
+	require.Len(t, m.nodeCapabilities, 3)
+	for _, c := range heartbeatpb.LocalCapabilities() {
+		require.True(t, m.nodeCapabilities["node1"].Has(c))
+	}
+	require.Empty(t, m.nodeCapabilities["node2"])
+	require.Equal(t, heartbeatpb.Capabilities{heartbeatpb.CapabilityQuiesce: {}}, m.nodeCapabilities["node3"])
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"math"
+	"sync"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// bootstrapLoads are the loads of the nodes reported in the bootstrap responses in the
+// unit of spans, they are set by the maintainer event loop and read by the schedulers.
+// Both the basic and the balance scheduler add them to the task sizes of the nodes, so
+// the balance doesn't move the spans back to the loaded nodes. The loads are kept until
+// the next bootstrap replaces them.
+type bootstrapLoads struct {
+	mu    sync.RWMutex
+	spans map[node.ID]int
+}
+
+func newBootstrapLoads() *bootstrapLoads {
+	return &bootstrapLoads{}
+}
+
+// set records the loads reported by the nodes, spans is the number of the spans of the changefeed.
+func (l *bootstrapLoads) set(loads map[node.ID]*heartbeatpb.NodeLoad, spans int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.spans = loadsToSpans(loads, spans)
+}
+
+// get returns the extra spans of the nodes, it's nil if no load is reported.
+func (l *bootstrapLoads) get() map[node.ID]int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if len(l.spans) == 0 {
+		return nil
+	}
+	res := make(map[node.ID]int, len(l.spans))
+	for id, n := range l.spans {
+		res[id] = n
+	}
+	return res
+}
+
+// loadsToSpans converts the loads of the nodes to the extra spans of them. A node is treated
+// as running the spans of the changefeed by its share of the memory usage and the backlog of
+// all the nodes, so the nodes already loaded by the other changefeeds get fewer absent spans.
+// The nodes which don't report the load are treated as idle.
+func loadsToSpans(loads map[node.ID]*heartbeatpb.NodeLoad, spans int) map[node.ID]int {
+	var totalMemory, totalBacklog uint64
+	for _, load := range loads {
+		if load == nil {
+			continue
+		}
+		totalMemory += load.MemoryUsage
+		totalBacklog += load.DispatcherBacklog
+	}
+	if totalMemory == 0 && totalBacklog == 0 {
+		return nil
+	}
+	res := make(map[node.ID]int, len(loads))
+	for id, load := range loads {
+		if load == nil {
+			continue
+		}
+		share, factors := 0.0, 0
+		if totalMemory > 0 {
+			share += float64(load.MemoryUsage) / float64(totalMemory)
+			factors++
+		}
+		if totalBacklog > 0 {
+			share += float64(load.DispatcherBacklog) / float64(totalBacklog)
+			factors++
+		}
+		res[id] = int(math.Round(float64(spans) * share / float64(factors)))
+	}
+	return res
+}
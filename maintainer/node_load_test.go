@@ -0,0 +1,56 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadsToSpans(t *testing.T) {
+	// no load is reported by the old nodes
+	require.Nil(t, loadsToSpans(map[node.ID]*heartbeatpb.NodeLoad{"node-1": nil, "node-2": nil}, 100))
+
+	spans := loadsToSpans(map[node.ID]*heartbeatpb.NodeLoad{
+		"node-1": {MemoryUsage: 300, DispatcherBacklog: 100},
+		"node-2": {MemoryUsage: 100, DispatcherBacklog: 0},
+		"node-3": nil,
+	}, 100)
+	require.Equal(t, map[node.ID]int{"node-1": 88, "node-2": 13}, spans)
+
+	// only the memory usage is reported
+	spans = loadsToSpans(map[node.ID]*heartbeatpb.NodeLoad{
+		"node-1": {MemoryUsage: 100},
+		"node-2": {MemoryUsage: 300},
+	}, 10)
+	require.Equal(t, map[node.ID]int{"node-1": 3, "node-2": 8}, spans)
+}
+
+func TestBootstrapLoads(t *testing.T) {
+	loads := newBootstrapLoads()
+	require.Nil(t, loads.get())
+
+	loads.set(map[node.ID]*heartbeatpb.NodeLoad{
+		"node-1": {MemoryUsage: 100},
+		"node-2": {MemoryUsage: 100},
+	}, 10)
+	require.Equal(t, map[node.ID]int{"node-1": 5, "node-2": 5}, loads.get())
+
+	// the next bootstrap replaces the loads
+	loads.set(map[node.ID]*heartbeatpb.NodeLoad{"node-1": nil}, 10)
+	require.Nil(t, loads.get())
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// BackfillDispatcherOperator is an operator to re-replicate a table span from an older ts.
+// The dispatcher is removed from the node and created again on the same node at the startTs,
+// the events already replicated are written by the sink in safe mode, since their commitTs
+// are less than the creation pd ts of the new dispatcher.
+type BackfillDispatcherOperator struct {
+	*MoveDispatcherOperator
+	startTs uint64
+	reset   bool
+}
+
+func NewBackfillDispatcherOperator(db *replica.ReplicationDB, replicaSet *replica.SpanReplication, nodeID node.ID, startTs uint64) *BackfillDispatcherOperator {
+	return &BackfillDispatcherOperator{
+		MoveDispatcherOperator: NewMoveDispatcherOperator(db, replicaSet, nodeID, nodeID),
+		startTs:                startTs,
+	}
+}
+
+func (m *BackfillDispatcherOperator) Schedule() *messaging.TargetMessage {
+	m.lck.Lock()
+	if m.originNodeStopped && !m.reset {
+		// the dispatcher is removed, it's created again from the startTs
+		log.Info("reset the checkpoint ts of the span to backfill",
+			zap.String("replicaSet", m.replicaSet.ID.String()),
+			zap.Uint64("checkpointTs", m.replicaSet.GetStatus().CheckpointTs),
+			zap.Uint64("startTs", m.startTs))
+		m.replicaSet.ResetCheckpointTs(m.startTs)
+		m.reset = true
+	}
+	m.lck.Unlock()
+	return m.MoveDispatcherOperator.Schedule()
+}
+
+func (m *BackfillDispatcherOperator) String() string {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	return fmt.Sprintf("backfill dispatcher operator: %s, node:%s, startTs:%d",
+		m.replicaSet.ID, m.dest, m.startTs)
+}
+
+func (m *BackfillDispatcherOperator) Type() string {
+	return "backfill"
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillDispatcherOperator(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan, false)
+	totalSpan := spanz.TableIDToComparableSpan(1)
+	span := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1,
+		&heartbeatpb.TableSpan{TableID: totalSpan.TableID, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey},
+		&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Working, CheckpointTs: 100}, "node1")
+	db.AddReplicatingSpan(span)
+
+	op := NewBackfillDispatcherOperator(db, span, "node1", 50)
+	op.Start()
+	// the dispatcher is removed first
+	msg := op.Schedule()
+	require.Equal(t, heartbeatpb.ScheduleAction_Remove, msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest).ScheduleAction)
+
+	// the dispatcher is created again at the start ts on the same node
+	op.Check("node1", &heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Stopped, CheckpointTs: 120})
+	span.UpdateStatus(&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Stopped, CheckpointTs: 120})
+	msg = op.Schedule()
+	require.Equal(t, "node1", msg.To.String())
+	req := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+	require.Equal(t, heartbeatpb.ScheduleAction_Create, req.ScheduleAction)
+	require.Equal(t, uint64(50), req.Config.StartTs)
+	require.False(t, op.IsFinished())
+
+	// the checkpoint ts of the span goes forward from the start ts
+	span.UpdateStatus(&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Working, CheckpointTs: 60})
+	require.Equal(t, uint64(60), span.GetStatus().CheckpointTs)
+	op.Check("node1", &heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Working, CheckpointTs: 60})
+	require.True(t, op.IsFinished())
+}
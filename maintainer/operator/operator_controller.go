@@ -15,12 +15,15 @@ package operator
 
 import (
 	"container/heap"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/eventlog"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/messaging"
@@ -42,10 +45,17 @@ type Controller struct {
 	messageCenter messaging.MessageCenter
 	replicationDB *replica.ReplicationDB
 	nodeManager   *watcher.NodeManager
+	// clock is used to mock the time in the unit test and the scheduler simulation
+	clock clock.Clock
+	// eventLog records the scheduling milestones of the changefeed
+	eventLog *eventlog.Log
 
 	lock         sync.RWMutex // protect the following fields
 	operators    map[common.DispatcherID]*operator.OperatorWithTime[common.DispatcherID, *heartbeatpb.TableSpanStatus]
 	runningQueue operator.OperatorQueue[common.DispatcherID, *heartbeatpb.TableSpanStatus]
+	// batchNodes are the nodes that handle the batched schedule dispatcher requests,
+	// the requests to other nodes are sent one per message, since they only read the first one.
+	batchNodes map[node.ID]struct{}
 }
 
 func NewOperatorController(
@@ -61,18 +71,41 @@ func NewOperatorController(
 		batchSize:     batchSize,
 		replicationDB: db,
 		nodeManager:   nodeManager,
+		clock:         clock.New(),
+		eventLog:      eventlog.NewLog(eventlog.DefaultCapacity),
+		batchNodes:    make(map[node.ID]struct{}),
 	}
 	return oc
 }
 
+// EventLog returns the log of the scheduling events of the changefeed.
+func (oc *Controller) EventLog() *eventlog.Log {
+	return oc.eventLog
+}
+
+// SetMessageCenter replaces the message center of the controller, it must be called before the controller is used.
+func (oc *Controller) SetMessageCenter(mc messaging.MessageCenter) {
+	oc.messageCenter = mc
+}
+
+// SetClock replaces the clock of the controller, it must be called before the controller is used.
+func (oc *Controller) SetClock(clk clock.Clock) {
+	oc.clock = clk
+	oc.eventLog.SetClock(clk)
+}
+
 // Execute periodically execute the operator
 // todo: use a better way to control the execution frequency
 func (oc *Controller) Execute() time.Time {
+	// the schedule dispatcher requests to the same node are sent in one message
+	batches := make(map[node.ID]*messaging.TargetMessage)
+	defer oc.sendBatchedMessages(batches)
+
 	executedItem := 0
 	for {
 		r, next := oc.pollQueueingOperator()
 		if !next {
-			return time.Now().Add(time.Millisecond * 200)
+			return oc.clock.Now().Add(time.Millisecond * 200)
 		}
 		if r == nil {
 			continue
@@ -81,21 +114,56 @@ func (oc *Controller) Execute() time.Time {
 		// is the lock necessary?
 		oc.lock.RLock()
 		msg := r.Schedule()
+		batchable := false
+		if msg != nil {
+			_, batchable = oc.batchNodes[msg.To]
+		}
 		oc.lock.RUnlock()
 
 		if msg != nil {
-			_ = oc.messageCenter.SendCommand(msg)
+			if msg.Type == messaging.TypeScheduleDispatcherRequest && batchable {
+				if batch, ok := batches[msg.To]; ok {
+					batch.Message = append(batch.Message, msg.Message...)
+				} else {
+					batches[msg.To] = msg
+				}
+			} else {
+				_ = oc.messageCenter.SendCommand(msg)
+			}
 			log.Info("send command to dispatcher",
 				zap.String("changefeed", oc.changefeedID.Name()),
 				zap.String("operator", r.String()))
 		}
 		executedItem++
 		if executedItem >= oc.batchSize {
-			return time.Now().Add(time.Millisecond * 50)
+			return oc.clock.Now().Add(time.Millisecond * 50)
 		}
 	}
 }
 
+// SetNodeCapabilities sets the capabilities negotiated with the dispatcher manager of the node.
+func (oc *Controller) SetNodeCapabilities(id node.ID, caps heartbeatpb.Capabilities) {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	if caps.Has(heartbeatpb.CapabilityBatchScheduleRequest) {
+		oc.batchNodes[id] = struct{}{}
+	} else {
+		delete(oc.batchNodes, id)
+	}
+}
+
+// sendBatchedMessages sends the batched schedule dispatcher requests, one message per node.
+func (oc *Controller) sendBatchedMessages(batches map[node.ID]*messaging.TargetMessage) {
+	for to, msg := range batches {
+		_ = oc.messageCenter.SendCommand(msg)
+		log.Debug("send batched schedule dispatcher requests",
+			zap.String("changefeed", oc.changefeedID.Name()),
+			zap.String("node", to.String()),
+			zap.Int("count", len(msg.Message)))
+	}
+}
+
 // RemoveAllTasks remove all tasks, and notify all operators to stop.
 // it is only called by the barrier when the changefeed is stopped.
 func (oc *Controller) RemoveAllTasks() {
@@ -149,6 +217,39 @@ func (oc *Controller) AddOperator(op operator.Operator[common.DispatcherID, *hea
 	return true
 }
 
+// AddOperators adds the move operators as a whole under the lock, none of them is added
+// if any of them can't be, e.g. the span is being scheduled by another operator, or it's
+// no longer on the origin node of the move.
+func (oc *Controller) AddOperators(ops ...*MoveDispatcherOperator) bool {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	seen := make(map[common.DispatcherID]struct{}, len(ops))
+	for _, op := range ops {
+		if _, ok := seen[op.ID()]; ok {
+			return false
+		}
+		seen[op.ID()] = struct{}{}
+		if _, ok := oc.operators[op.ID()]; ok {
+			log.Info("add operators failed, operator already exists",
+				zap.String("changefeed", oc.changefeedID.Name()),
+				zap.String("operator", op.String()))
+			return false
+		}
+		span := oc.replicationDB.GetTaskByID(op.ID())
+		if span == nil || span.GetNodeID() != op.origin {
+			log.Info("add operators failed, span not found or moved",
+				zap.String("changefeed", oc.changefeedID.Name()),
+				zap.String("operator", op.String()))
+			return false
+		}
+	}
+	for _, op := range ops {
+		oc.pushOperator(op)
+	}
+	return true
+}
+
 func (oc *Controller) UpdateOperatorStatus(id common.DispatcherID, from node.ID, status *heartbeatpb.TableSpanStatus) {
 	oc.lock.RLock()
 	defer oc.lock.RUnlock()
@@ -163,10 +264,15 @@ func (oc *Controller) UpdateOperatorStatus(id common.DispatcherID, from node.ID,
 // the controller will mark all spans on the node as absent if no operator is handling it,
 // then the controller will notify all operators.
 func (oc *Controller) OnNodeRemoved(n node.ID) {
-	oc.lock.RLock()
-	defer oc.lock.RUnlock()
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	delete(oc.batchNodes, n)
 
-	for _, span := range oc.replicationDB.GetTaskByNodeID(n) {
+	spans := oc.replicationDB.GetTaskByNodeID(n)
+	oc.eventLog.Record(eventlog.ReasonNodeRemoved,
+		fmt.Sprintf("node %s is removed, %d spans on it are rescheduled", n, len(spans)))
+	for _, span := range spans {
 		_, ok := oc.operators[span.ID]
 		if !ok {
 			oc.replicationDB.MarkSpanAbsent(span)
@@ -216,21 +322,22 @@ func (oc *Controller) pollQueueingOperator() (operator.Operator[common.Dispatche
 		op.PostFinish()
 		item.Removed = true
 		delete(oc.operators, opID)
+		oc.recordOperatorFinished(op)
 		metrics.FinishedOperatorCount.WithLabelValues(model.DefaultNamespace, oc.changefeedID.Name(), op.Type()).Inc()
-		metrics.OperatorDuration.WithLabelValues(model.DefaultNamespace, oc.changefeedID.Name(), op.Type()).Observe(time.Since(item.EnqueueTime).Seconds())
+		metrics.OperatorDuration.WithLabelValues(model.DefaultNamespace, oc.changefeedID.Name(), op.Type()).Observe(oc.clock.Since(item.EnqueueTime).Seconds())
 		log.Info("operator finished",
 			zap.String("changefeed", oc.changefeedID.Name()),
 			zap.String("operator", opID.String()),
 			zap.String("operator", op.String()))
 		return nil, true
 	}
-	now := time.Now()
+	now := oc.clock.Now()
 	if now.Before(item.Time) {
 		heap.Push(&oc.runningQueue, item)
 		return nil, false
 	}
 	// pushes with new notify time.
-	item.Time = time.Now().Add(time.Millisecond * 500)
+	item.Time = now.Add(time.Millisecond * 500)
 	heap.Push(&oc.runningQueue, item)
 	return op, true
 }
@@ -257,10 +364,11 @@ func (oc *Controller) pushOperator(op operator.Operator[common.DispatcherID, *he
 	log.Info("add operator to running queue",
 		zap.String("changefeed", oc.changefeedID.Name()),
 		zap.String("operator", op.String()))
-	withTime := operator.NewOperatorWithTime(op, time.Now())
+	withTime := operator.NewOperatorWithTime(op, oc.clock.Now())
 	oc.operators[op.ID()] = withTime
 	op.Start()
 	heap.Push(&oc.runningQueue, withTime)
+	oc.recordOperatorStarted(op)
 	metrics.CreatedOperatorCount.WithLabelValues(model.DefaultNamespace, oc.changefeedID.Name(), op.Type()).Inc()
 }
 
@@ -290,6 +398,10 @@ func (oc *Controller) NewMoveOperator(replicaSet *replica.SpanReplication, origi
 	}
 }
 
+func (oc *Controller) NewBackfillOperator(replicaSet *replica.SpanReplication, startTs uint64) operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus] {
+	return NewBackfillDispatcherOperator(oc.replicationDB, replicaSet, replicaSet.GetNodeID(), startTs)
+}
+
 func (oc *Controller) NewRemoveOperator(replicaSet *replica.SpanReplication) operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus] {
 	return &RemoveDispatcherOperator{
 		replicaSet: replicaSet,
@@ -297,12 +409,35 @@ func (oc *Controller) NewRemoveOperator(replicaSet *replica.SpanReplication) ope
 	}
 }
 
+// NewSplitOperator creates an operator to split the replica set to the split spans, the split spans
+// are added after the dispatcher is removed from the origin node, and start from its final checkpoint ts.
 func (oc *Controller) NewSplitOperator(
 	replicaSet *replica.SpanReplication, originNode node.ID, splitSpans []*heartbeatpb.TableSpan,
 ) operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus] {
 	return NewSplitDispatcherOperator(oc.replicationDB, replicaSet, originNode, splitSpans)
 }
 
+// NewMergeSplitOperators creates the operators to replace the affected replica sets with the split spans,
+// one operator for each affected replica set. A random one of them is the primary operator, which waits
+// for all the dispatchers to be removed and then adds the split spans starting from the min checkpoint ts
+// of the removed dispatchers, so the events after the checkpoint ts of any affected span are not lost.
+func (oc *Controller) NewMergeSplitOperators(
+	affectedReplicaSets []*replica.SpanReplication, splitSpans []*heartbeatpb.TableSpan,
+) []operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus] {
+	randomIdx := rand.Intn(len(affectedReplicaSets))
+	primaryID := affectedReplicaSets[randomIdx].ID
+	primaryOp := NewMergeSplitDispatcherOperator(oc.replicationDB, primaryID, affectedReplicaSets[randomIdx], affectedReplicaSets, splitSpans, nil)
+	ops := make([]operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus], 0, len(affectedReplicaSets))
+	for _, replicaSet := range affectedReplicaSets {
+		if replicaSet.ID == primaryID {
+			ops = append(ops, primaryOp)
+			continue
+		}
+		ops = append(ops, NewMergeSplitDispatcherOperator(oc.replicationDB, primaryID, replicaSet, nil, nil, primaryOp.onFinished))
+	}
+	return ops
+}
+
 // AddMergeSplitOperator adds a merge split operator to the controller.
 //  1. Merge Operator: len(affectedReplicaSets) > 1, len(splitSpans) == 1
 //  2. Split Operator: len(affectedReplicaSets) == 1, len(splitSpans) > 1
@@ -331,21 +466,13 @@ func (oc *Controller) AddMergeSplitOperator(
 			return false
 		}
 	}
-	randomIdx := rand.Intn(len(affectedReplicaSets))
-	primaryID := affectedReplicaSets[randomIdx].ID
-	primaryOp := NewMergeSplitDispatcherOperator(oc.replicationDB, primaryID, affectedReplicaSets[randomIdx], affectedReplicaSets, splitSpans, nil)
-	for _, replicaSet := range affectedReplicaSets {
-		var op *MergeSplitDispatcherOperator
-		if replicaSet.ID == primaryID {
-			op = primaryOp
-		} else {
-			op = NewMergeSplitDispatcherOperator(oc.replicationDB, primaryID, replicaSet, nil, nil, primaryOp.onFinished)
-		}
+	ops := oc.NewMergeSplitOperators(affectedReplicaSets, splitSpans)
+	for _, op := range ops {
 		oc.pushOperator(op)
 	}
 	log.Info("add merge split operator",
 		zap.String("changefeed", oc.changefeedID.Name()),
-		zap.String("primary", primaryID.String()),
+		zap.String("primary", ops[0].(*MergeSplitDispatcherOperator).primary.String()),
 		zap.Int64("tableID", splitSpans[0].TableID),
 		zap.Int("oldSpans", len(affectedReplicaSets)),
 		zap.Int("newSpans", len(splitSpans)),
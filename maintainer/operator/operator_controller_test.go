@@ -0,0 +1,189 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/eventlog"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMessageCenter captures the messages sent by the operator controller.
+type mockMessageCenter struct {
+	messaging.MessageCenter
+	messages []*messaging.TargetMessage
+}
+
+func (mc *mockMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	mc.messages = append(mc.messages, msg)
+	return nil
+}
+
+func TestExecuteBatchesScheduleRequestsByNode(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan, false)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	mc := &mockMessageCenter{}
+	oc := NewOperatorController(cfID, mc, db, nodeManager, 100)
+	// node2 is not upgraded yet, it only reads the first request of a message
+	oc.SetNodeCapabilities("node1", heartbeatpb.NegotiateCapabilities(heartbeatpb.ProtocolVersion, heartbeatpb.LocalCapabilities()))
+	oc.SetNodeCapabilities("node2", heartbeatpb.NegotiateCapabilities(0, nil))
+
+	for i := 0; i < 10; i++ {
+		totalSpan := spanz.TableIDToComparableSpan(int64(i + 1))
+		span := replica.NewReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1,
+			&heartbeatpb.TableSpan{TableID: totalSpan.TableID, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}, 1)
+		db.AddAbsentReplicaSet(span)
+		target := node.ID("node1")
+		if i%2 == 1 {
+			target = "node2"
+		}
+		require.True(t, oc.AddOperator(oc.NewAddOperator(span, target)))
+	}
+	oc.Execute()
+
+	// one message carries all requests to node1, and one message per request to node2
+	requests := make(map[node.ID]int)
+	messages := make(map[node.ID]int)
+	for _, msg := range mc.messages {
+		require.Equal(t, messaging.TypeScheduleDispatcherRequest, msg.Type)
+		for _, m := range msg.Message {
+			require.Equal(t, heartbeatpb.ScheduleAction_Create,
+				m.(*heartbeatpb.ScheduleDispatcherRequest).ScheduleAction)
+		}
+		requests[msg.To] += len(msg.Message)
+		messages[msg.To]++
+	}
+	require.Equal(t, map[node.ID]int{"node1": 5, "node2": 5}, requests)
+	require.Equal(t, map[node.ID]int{"node1": 1, "node2": 5}, messages)
+}
+
+func TestScheduleEventsRecorded(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan, false)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	oc := NewOperatorController(cfID, &mockMessageCenter{}, db, nodeManager, 100)
+	clk := clock.NewMock()
+	oc.SetClock(clk)
+
+	totalSpan := spanz.TableIDToComparableSpan(1)
+	span := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1,
+		&heartbeatpb.TableSpan{TableID: totalSpan.TableID, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey},
+		&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Working, CheckpointTs: 1}, "node1")
+	db.AddReplicatingSpan(span)
+
+	require.True(t, oc.AddOperator(oc.NewMoveOperator(span, "node1", "node2")))
+	events := oc.EventLog().List(time.Time{})
+	require.Len(t, events, 1)
+	require.Equal(t, eventlog.ReasonMovingSpan, events[0].Reason)
+
+	oc.UpdateOperatorStatus(span.ID, "node1", &heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Stopped})
+	oc.UpdateOperatorStatus(span.ID, "node2", &heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Working})
+	oc.Execute()
+	events = oc.EventLog().List(time.Time{})
+	require.Len(t, events, 2)
+	require.Equal(t, eventlog.ReasonSpanMoved, events[1].Reason)
+	require.Contains(t, events[1].Message, "from node1 to node2")
+
+	clk.Add(time.Second)
+	oc.OnNodeRemoved("node2")
+	events = oc.EventLog().List(events[1].Time)
+	require.Len(t, events, 1)
+	require.Equal(t, eventlog.ReasonNodeRemoved, events[0].Reason)
+}
+
+func TestAddOperatorsAtomically(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan, false)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	oc := NewOperatorController(cfID, &mockMessageCenter{}, db, nodeManager, 100)
+
+	spans := make([]*replica.SpanReplication, 0, 3)
+	for i := 0; i < 3; i++ {
+		totalSpan := spanz.TableIDToComparableSpan(int64(i + 1))
+		span := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1,
+			&heartbeatpb.TableSpan{TableID: totalSpan.TableID, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey},
+			&heartbeatpb.TableSpanStatus{
+				ComponentStatus: heartbeatpb.ComponentState_Working,
+				CheckpointTs:    1,
+			}, "node1")
+		db.AddReplicatingSpan(span)
+		spans = append(spans, span)
+	}
+	newMoves := func() []*MoveDispatcherOperator {
+		ops := make([]*MoveDispatcherOperator, 0, len(spans))
+		for _, span := range spans {
+			ops = append(ops, NewMoveDispatcherOperator(db, span, "node1", "node2"))
+		}
+		return ops
+	}
+
+	// the move whose origin is not the node of the span is rejected.
+	ops := newMoves()
+	ops[1] = NewMoveDispatcherOperator(db, spans[1], "node2", "node1")
+	require.False(t, oc.AddOperators(ops...))
+	require.Equal(t, 0, oc.OperatorSize())
+
+	// the duplicated moves are rejected.
+	ops = newMoves()
+	require.False(t, oc.AddOperators(append(ops, ops[0])...))
+	require.Equal(t, 0, oc.OperatorSize())
+
+	require.True(t, oc.AddOperators(newMoves()...))
+	require.Equal(t, 3, oc.OperatorSize())
+	for _, span := range spans {
+		require.NotNil(t, oc.GetOperator(span.ID))
+	}
+
+	// the last span is being scheduled, none of the moves is added.
+	oc = NewOperatorController(cfID, &mockMessageCenter{}, db, nodeManager, 100)
+	require.True(t, oc.AddOperator(oc.NewMoveOperator(spans[2], "node1", "node2")))
+	require.False(t, oc.AddOperators(newMoves()...))
+	require.Equal(t, 1, oc.OperatorSize())
+	require.Nil(t, oc.GetOperator(spans[0].ID))
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func newOperatorControllerForTest() (*Controller, *replica.ReplicationDB, *heartbeatpb.TableSpan) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, common.NewDispatcherID(), &replica.MockTsoClient{},
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan, false)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	oc := NewOperatorController(cfID, &mockMessageCenter{}, db, nodeManager, 100)
+	totalSpan := spanz.TableIDToComparableSpan(1)
+	return oc, db, &heartbeatpb.TableSpan{TableID: totalSpan.TableID, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}
+}
+
+func addWorkingSpanForTest(db *replica.ReplicationDB, span *heartbeatpb.TableSpan, checkpointTs uint64, nodeID node.ID) *replica.SpanReplication {
+	r := replica.NewWorkingReplicaSet(db.GetDDLDispatcher().ChangefeedID, common.NewDispatcherID(), &replica.MockTsoClient{}, 1, span,
+		&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Working, CheckpointTs: checkpointTs}, nodeID)
+	db.AddReplicatingSpan(r)
+	return r
+}
+
+func TestSplitOperatorCheckpointHandoff(t *testing.T) {
+	oc, db, totalSpan := newOperatorControllerForTest()
+	span := addWorkingSpanForTest(db, totalSpan, 10, "node1")
+	midKey := append(append([]byte{}, totalSpan.StartKey...), 'a')
+	splitSpans := []*heartbeatpb.TableSpan{
+		{TableID: 1, StartKey: totalSpan.StartKey, EndKey: midKey},
+		{TableID: 1, StartKey: midKey, EndKey: totalSpan.EndKey},
+	}
+	require.True(t, oc.AddOperator(oc.NewSplitOperator(span, "node1", splitSpans)))
+	oc.Execute()
+	require.Equal(t, 1, db.GetSchedulingSize())
+
+	// the dispatcher flushes more events before it's removed, the split spans
+	// start from its final checkpoint ts
+	oc.UpdateOperatorStatus(span.ID, "node1",
+		&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Stopped, CheckpointTs: 20})
+	oc.Execute()
+	require.Equal(t, 0, oc.OperatorSize())
+	newSpans := db.GetTasksByTableIDs(1)
+	require.Len(t, newSpans, 2)
+	for _, r := range newSpans {
+		require.Equal(t, uint64(20), r.GetStatus().CheckpointTs)
+		require.Equal(t, node.ID(""), r.GetNodeID())
+	}
+
+	// the split spans are not added if the table is dropped
+	table2 := spanz.TableIDToComparableSpan(2)
+	span = addWorkingSpanForTest(db, &heartbeatpb.TableSpan{TableID: 2, StartKey: table2.StartKey, EndKey: table2.EndKey}, 10, "node2")
+	midKey = append(append([]byte{}, table2.StartKey...), 'a')
+	require.True(t, oc.AddOperator(oc.NewSplitOperator(span, "node2", []*heartbeatpb.TableSpan{
+		{TableID: 2, StartKey: table2.StartKey, EndKey: midKey},
+		{TableID: 2, StartKey: midKey, EndKey: table2.EndKey},
+	})))
+	oc.RemoveTasksByTableIDs(2)
+	require.Len(t, db.GetTasksByTableIDs(2), 0)
+}
+
+func TestMergeSplitOperatorCheckpointHandoff(t *testing.T) {
+	oc, db, totalSpan := newOperatorControllerForTest()
+	midKey := append(append([]byte{}, totalSpan.StartKey...), 'a')
+	span1 := addWorkingSpanForTest(db, &heartbeatpb.TableSpan{TableID: 1, StartKey: totalSpan.StartKey, EndKey: midKey}, 10, "node1")
+	span2 := addWorkingSpanForTest(db, &heartbeatpb.TableSpan{TableID: 1, StartKey: midKey, EndKey: totalSpan.EndKey}, 10, "node2")
+
+	ops := oc.NewMergeSplitOperators([]*replica.SpanReplication{span1, span2}, []*heartbeatpb.TableSpan{totalSpan})
+	require.Len(t, ops, 2)
+	require.True(t, oc.AddMergeSplitOperator([]*replica.SpanReplication{span1, span2}, []*heartbeatpb.TableSpan{totalSpan}))
+	// the spans in merging can't be split again
+	require.False(t, oc.AddOperator(oc.NewSplitOperator(span1, "node1", nil)))
+	oc.Execute()
+
+	oc.UpdateOperatorStatus(span1.ID, "node1",
+		&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Stopped, CheckpointTs: 30})
+	oc.Execute()
+	// the merged span is added after all the dispatchers are removed
+	require.Len(t, db.GetTasksByTableIDs(1), 2)
+
+	oc.UpdateOperatorStatus(span2.ID, "node2",
+		&heartbeatpb.TableSpanStatus{ComponentStatus: heartbeatpb.ComponentState_Stopped, CheckpointTs: 20})
+	oc.Execute()
+	require.Equal(t, 0, oc.OperatorSize())
+	// the merged span starts from the min checkpoint ts, so the events after
+	// the checkpoint ts of span2 are not lost
+	merged := db.GetTasksByTableIDs(1)
+	require.Len(t, merged, 1)
+	require.Equal(t, totalSpan, merged[0].Span)
+	require.Equal(t, uint64(20), merged[0].GetStatus().CheckpointTs)
+}
@@ -39,6 +39,8 @@ type SplitDispatcherOperator struct {
 	splitSpanInfo string
 
 	finished atomic.Bool
+	// removed is true if the task is removed by ddl, the split spans are not added then.
+	removed bool
 
 	lck sync.Mutex
 }
@@ -104,6 +106,9 @@ func (m *SplitDispatcherOperator) Check(from node.ID, status *heartbeatpb.TableS
 		if status.CheckpointTs > m.checkpointTs {
 			m.checkpointTs = status.CheckpointTs
 		}
+		// the split spans start from the final checkpoint ts of the removed dispatcher,
+		// so the events after it are not lost.
+		m.replicaSet.UpdateStatus(status)
 		log.Info("replica set removed from origin node",
 			zap.Uint64("checkpointTs", m.checkpointTs),
 			zap.String("replicaSet", m.replicaSet.ID.String()))
@@ -121,6 +126,7 @@ func (m *SplitDispatcherOperator) OnTaskRemoved() {
 	defer m.lck.Unlock()
 
 	log.Info("task removed", zap.String("replicaSet", m.replicaSet.ID.String()))
+	m.removed = true
 	m.finished.Store(true)
 }
 
@@ -128,6 +134,11 @@ func (m *SplitDispatcherOperator) PostFinish() {
 	m.lck.Lock()
 	defer m.lck.Unlock()
 
+	if m.removed {
+		log.Info("split dispatcher operator finished since the task is removed",
+			zap.String("id", m.replicaSet.ID.String()))
+		return
+	}
 	log.Info("split dispatcher operator finished", zap.String("id", m.replicaSet.ID.String()))
 	m.db.ReplaceReplicaSet([]*replica.SpanReplication{m.replicaSet}, m.splitSpans, m.checkpointTs)
 }
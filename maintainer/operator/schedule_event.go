@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/eventlog"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/scheduler/operator"
+)
+
+// recordOperatorStarted records the scheduling event of the operator when it's added,
+// the operators which are not scheduling milestones, such as add and remove, are ignored.
+func (oc *Controller) recordOperatorStarted(op operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus]) {
+	switch m := op.(type) {
+	case *MoveDispatcherOperator:
+		oc.eventLog.Record(eventlog.ReasonMovingSpan,
+			fmt.Sprintf("moving span %s of table %d from %s to %s",
+				m.replicaSet.ID, m.replicaSet.Span.TableID, m.origin, m.dest))
+	case *SplitDispatcherOperator:
+		oc.eventLog.Record(eventlog.ReasonSplittingSpan,
+			fmt.Sprintf("splitting span %s of table %d into %d spans",
+				m.replicaSet.ID, m.replicaSet.Span.TableID, len(m.splitSpans)))
+	case *MergeSplitDispatcherOperator:
+		// the operators of the affected spans share one event, which is recorded by the primary
+		if !m.isPrimary() {
+			return
+		}
+		reason := eventlog.ReasonSplittingSpan
+		if len(m.affectedReplicaSets) > 1 {
+			reason = eventlog.ReasonMergingSpans
+		}
+		oc.eventLog.Record(reason, fmt.Sprintf("replacing %d spans of table %d with %d spans",
+			len(m.affectedReplicaSets), m.originReplicaSet.Span.TableID, len(m.splitSpans)))
+	}
+}
+
+// recordOperatorFinished records the scheduling event of the operator when it's finished,
+// nothing is recorded if the operator is stopped before it's done, such as the span is removed.
+func (oc *Controller) recordOperatorFinished(op operator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus]) {
+	switch m := op.(type) {
+	case *MoveDispatcherOperator:
+		m.lck.Lock()
+		stopped, origin, dest := m.noPostFinishNeed, m.origin, m.dest
+		m.lck.Unlock()
+		if stopped {
+			return
+		}
+		oc.eventLog.Record(eventlog.ReasonSpanMoved,
+			fmt.Sprintf("moved span %s of table %d from %s to %s",
+				m.replicaSet.ID, m.replicaSet.Span.TableID, origin, dest))
+	case *SplitDispatcherOperator:
+		m.lck.Lock()
+		removed := m.removed
+		m.lck.Unlock()
+		if removed {
+			return
+		}
+		oc.eventLog.Record(eventlog.ReasonSpanSplit,
+			fmt.Sprintf("split span %s of table %d into %d spans",
+				m.replicaSet.ID, m.replicaSet.Span.TableID, len(m.splitSpans)))
+	case *MergeSplitDispatcherOperator:
+		m.lck.Lock()
+		removed := m.removed
+		m.lck.Unlock()
+		if !m.isPrimary() || removed {
+			return
+		}
+		reason := eventlog.ReasonSpanSplit
+		if len(m.affectedReplicaSets) > 1 {
+			reason = eventlog.ReasonSpansMerged
+		}
+		oc.eventLog.Record(reason, fmt.Sprintf("replaced %d spans of table %d with %d spans",
+			len(m.affectedReplicaSets), m.originReplicaSet.Span.TableID, len(m.splitSpans)))
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+const (
+	// orphanGracePeriod is the time a dispatcher must stay orphan before it's reconciled,
+	// so the dispatchers created or removed by the in-flight schedule are not touched.
+	orphanGracePeriod = 10 * time.Second
+	// orphanExpiration is the time an orphan is forgotten if it's not reported again,
+	// it's longer than the interval of the complete heartbeat of the dispatcher manager.
+	orphanExpiration = 30 * time.Second
+)
+
+const (
+	orphanReconcileRemoved = "removed"
+	orphanReconcileAdopted = "adopted"
+)
+
+type orphanKey struct {
+	id   common.DispatcherID
+	node node.ID
+}
+
+// orphanDispatcher is a working dispatcher reported by a node, which is not
+// recorded on the node in the ReplicationDB, and no operator is scheduling it.
+type orphanDispatcher struct {
+	status    *heartbeatpb.TableSpanStatus
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// recordOrphan records a working dispatcher reported by a node that the span of it is not bound to.
+func (c *Controller) recordOrphan(from node.ID, id common.DispatcherID, status *heartbeatpb.TableSpanStatus) {
+	key := orphanKey{id: id, node: from}
+	now := time.Now()
+	if orphan, ok := c.orphans[key]; ok {
+		orphan.status = status
+		orphan.lastSeen = now
+		return
+	}
+	c.orphans[key] = &orphanDispatcher{status: status, firstSeen: now, lastSeen: now}
+}
+
+// forgetOrphan is called when the dispatcher is reported by the node the span is bound to.
+func (c *Controller) forgetOrphan(from node.ID, id common.DispatcherID) {
+	delete(c.orphans, orphanKey{id: id, node: from})
+}
+
+// ReconcileOrphans cross-checks the orphan dispatchers reported by the nodes against
+// the ReplicationDB and the operators. The dispatchers of the absent spans are adopted
+// by binding the spans to the reporting nodes, the others are removed from the nodes.
+func (c *Controller) ReconcileOrphans(now time.Time) {
+	for key, orphan := range c.orphans {
+		if now.Sub(orphan.lastSeen) > orphanExpiration {
+			// the dispatcher is not reported anymore, it's removed or the node is offline.
+			delete(c.orphans, key)
+			continue
+		}
+		if now.Sub(orphan.firstSeen) < orphanGracePeriod {
+			continue
+		}
+		delete(c.orphans, key)
+		if op := c.operatorController.GetOperator(key.id); op != nil {
+			// the span is being scheduled, the operator takes care of it.
+			continue
+		}
+		stm := c.GetTask(key.id)
+		switch {
+		case stm != nil && stm.GetNodeID() == key.node:
+			continue
+		case stm != nil && stm.GetNodeID() == "":
+			log.Info("adopt orphan dispatcher",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.String("node", key.node.String()),
+				zap.String("span", key.id.String()),
+				zap.Uint64("checkpointTs", orphan.status.CheckpointTs))
+			c.replicationDB.BindSpanToNode("", key.node, stm)
+			c.replicationDB.MarkSpanReplicating(stm)
+			c.replicationDB.UpdateStatus(stm, orphan.status)
+			c.orphanAdoptedCounter.Inc()
+		default:
+			log.Warn("remove orphan dispatcher",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.String("node", key.node.String()),
+				zap.String("span", key.id.String()),
+				zap.Bool("spanExists", stm != nil))
+			_ = c.messageCenter.SendCommand(replica.NewRemoveDispatcherMessage(key.node, c.changefeedID, key.id.ToPB()))
+			c.orphanRemovedCounter.Inc()
+		}
+	}
+}
+
+func cleanupOrphanMetrics(changefeedID common.ChangeFeedID) {
+	metrics.OrphanDispatcherReconcileCounter.DeleteLabelValues(changefeedID.Namespace(), changefeedID.Name(), orphanReconcileRemoved)
+	metrics.OrphanDispatcherReconcileCounter.DeleteLabelValues(changefeedID.Namespace(), changefeedID.Name(), orphanReconcileAdopted)
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// QuiesceState is the state of the quiesced changefeed.
+type QuiesceState string
+
+const (
+	// QuiesceStateNone means the changefeed is not quiesced.
+	QuiesceStateNone QuiesceState = "none"
+	// QuiesceStateHolding means the dispatchers are flushing the events before the hold ts.
+	QuiesceStateHolding QuiesceState = "holding"
+	// QuiesceStateFlushed means all sinks have flushed the events up to the hold ts,
+	// the downstream is consistent at the hold ts until the changefeed is released.
+	QuiesceStateFlushed QuiesceState = "flushed"
+	// QuiesceStateMissed means some dispatchers have passed the hold ts before they are held,
+	// the changefeed should be released and quiesced at a later ts.
+	QuiesceStateMissed QuiesceState = "missed"
+)
+
+// QuiesceStatus is the status of the quiesced changefeed.
+type QuiesceStatus struct {
+	State        QuiesceState
+	HoldTs       uint64
+	CheckpointTs uint64
+}
+
+// NewQuiesceTs checks the ts to hold the changefeed at and returns it, the current pd ts
+// is used if the holdTs is not specified.
+func (m *Maintainer) NewQuiesceTs(holdTs uint64) (uint64, error) {
+	if holdTs == 0 {
+		holdTs = oracle.GoTimeToTS(m.pdClock.CurrentTime())
+	}
+	if checkpointTs := m.getWatermark().CheckpointTs; holdTs <= checkpointTs {
+		return 0, errors.ErrAPIInvalidParam.GenWithStack(
+			"hold ts %d is not larger than the checkpoint ts %d", holdTs, checkpointTs)
+	}
+	return holdTs, nil
+}
+
+// Quiesce holds all dispatchers of the changefeed at the holdTs, the events after it are
+// not written to the sinks until the changefeed is released by Quiesce(0). The holdTs must
+// be persisted in the changefeed info before, so it's kept after the maintainer is restarted.
+// The hold ts is sent to the dispatcher managers when they report a different one in the heartbeat.
+func (m *Maintainer) Quiesce(holdTs uint64) {
+	m.quiesceTs.Store(holdTs)
+	if holdTs == 0 {
+		log.Info("changefeed is released from quiesce", zap.String("changefeed", m.id.Name()))
+		return
+	}
+	log.Info("changefeed is quiesced",
+		zap.String("changefeed", m.id.Name()),
+		zap.Uint64("holdTs", holdTs))
+}
+
+// GetQuiesceStatus returns the status of the quiesced changefeed.
+func (m *Maintainer) GetQuiesceStatus() QuiesceStatus {
+	holdTs := m.quiesceTs.Load()
+	checkpointTs := m.getWatermark().CheckpointTs
+	status := QuiesceStatus{State: QuiesceStateNone, HoldTs: holdTs, CheckpointTs: checkpointTs}
+	switch {
+	case holdTs == 0:
+	case checkpointTs < holdTs:
+		status.State = QuiesceStateHolding
+	case checkpointTs == holdTs:
+		status.State = QuiesceStateFlushed
+	default:
+		status.State = QuiesceStateMissed
+	}
+	return status
+}
+
+// syncQuiesceTs sends the hold ts to the node if it's different from the one the node reported.
+func (m *Maintainer) syncQuiesceTs(from node.ID, reported uint64) {
+	holdTs := m.quiesceTs.Load()
+	if holdTs == reported {
+		return
+	}
+	if caps, ok := m.nodeCapabilities[from]; !ok || !caps.Has(heartbeatpb.CapabilityQuiesce) {
+		if holdTs != 0 {
+			log.Warn("the node does not support quiesce, ignore it",
+				zap.String("changefeed", m.id.Name()),
+				zap.String("node", from.String()))
+		}
+		return
+	}
+	m.sendMessages([]*messaging.TargetMessage{messaging.NewSingleTargetMessage(from,
+		messaging.HeartbeatCollectorTopic,
+		&heartbeatpb.QuiesceRequest{
+			ChangefeedID: m.id.ToPB(),
+			HoldTs:       holdTs,
+		})})
+}
@@ -13,7 +13,11 @@
 
 package range_checker
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+)
 
 // BoolRangeChecker is a range checker that always returns the same value.
 type BoolRangeChecker struct {
@@ -42,3 +46,8 @@ func (f *BoolRangeChecker) Reset() {
 func (f *BoolRangeChecker) Detail() string {
 	return fmt.Sprintf("covered: %v", f.covered)
 }
+
+// Coverage returns nil since no sub span is recorded.
+func (f *BoolRangeChecker) Coverage() []*heartbeatpb.TableSpan {
+	return nil
+}
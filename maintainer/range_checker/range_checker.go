@@ -13,6 +13,8 @@
 
 package range_checker
 
+import "github.com/pingcap/ticdc/heartbeatpb"
+
 // RangeChecker is an interface for checking if a range is fully covered
 type RangeChecker interface {
 	// AddSubRange adds a sub table pan to the range checker.
@@ -23,4 +25,7 @@ type RangeChecker interface {
 	Reset()
 	// Detail returns the detail status of the range checker, it used for debugging.
 	Detail() string
+	// Coverage returns the reported sub spans compactly, it's used to resume
+	// the range checker after the maintainer is restarted.
+	Coverage() []*heartbeatpb.TableSpan
 }
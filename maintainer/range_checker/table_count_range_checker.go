@@ -13,7 +13,12 @@
 
 package range_checker
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+)
 
 // TableIDRangeChecker is used to check if all table IDs are covered.
 type TableIDRangeChecker struct {
@@ -48,3 +53,13 @@ func (rc *TableIDRangeChecker) Reset() {
 func (rc *TableIDRangeChecker) Detail() string {
 	return fmt.Sprintf("reported count: %d, require count: %d", len(rc.reportedMap), rc.needCount)
 }
+
+// Coverage returns the reported tables, the keys of the spans are not set.
+func (rc *TableIDRangeChecker) Coverage() []*heartbeatpb.TableSpan {
+	spans := make([]*heartbeatpb.TableSpan, 0, len(rc.reportedMap))
+	for id := range rc.reportedMap {
+		spans = append(spans, &heartbeatpb.TableSpan{TableID: id})
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].TableID < spans[j].TableID })
+	return spans
+}
@@ -16,9 +16,11 @@ package range_checker
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/btree"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/tiflow/pkg/spanz"
 )
 
@@ -74,6 +76,25 @@ func (rc *TableSpanRangeChecker) Detail() string {
 	return buf.String()
 }
 
+// Coverage returns the reported sub spans of all tables, the overlapping and adjacent
+// sub spans are already merged when they are added.
+func (rc *TableSpanRangeChecker) Coverage() []*heartbeatpb.TableSpan {
+	var spans []*heartbeatpb.TableSpan
+	for id, span := range rc.tableSpans {
+		span.tree.Ascend(func(node *RangeNode) bool {
+			spans = append(spans, &heartbeatpb.TableSpan{TableID: id, StartKey: node.start, EndKey: node.end})
+			return true
+		})
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].TableID != spans[j].TableID {
+			return spans[i].TableID < spans[j].TableID
+		}
+		return bytes.Compare(spans[i].StartKey, spans[j].StartKey) < 0
+	})
+	return spans
+}
+
 // SpanCoverageChecker use the span coverage checker to check if the entire range from start to end is covered.
 // only sub span can be added to the checker.
 type SpanCoverageChecker struct {
@@ -75,6 +75,30 @@ func TestTableSpanRangeChecker_IsFullyCovered(t *testing.T) {
 	require.True(t, rc.IsFullyCovered())
 }
 
+func TestTableSpanRangeChecker_Coverage(t *testing.T) {
+	rc := NewTableSpanRangeChecker([]int64{1, 2})
+	rc.AddSubRange(1, []byte{0x01}, []byte{0x02})
+	rc.AddSubRange(1, []byte{0x02}, []byte{0x03})
+	rc.AddSubRange(1, []byte{0x05}, []byte{0x06})
+	rc.AddSubRange(2, []byte{0x01}, []byte{0x02})
+
+	// the adjacent sub ranges are merged
+	coverage := rc.Coverage()
+	require.Len(t, coverage, 3)
+	require.Equal(t, int64(1), coverage[0].TableID)
+	require.Equal(t, []byte{0x01}, coverage[0].StartKey)
+	require.Equal(t, []byte{0x03}, coverage[0].EndKey)
+	require.Equal(t, []byte{0x05}, coverage[1].StartKey)
+	require.Equal(t, int64(2), coverage[2].TableID)
+
+	// the coverage resumes the checker
+	restored := NewTableSpanRangeChecker([]int64{1, 2})
+	for _, span := range coverage {
+		restored.AddSubRange(span.TableID, span.StartKey, span.EndKey)
+	}
+	require.Equal(t, coverage, restored.Coverage())
+}
+
 func TestTableSpanRangeChecker_Reset(t *testing.T) {
 	// Test the Reset function
 	rc := NewTableSpanRangeChecker([]int64{1})
@@ -14,8 +14,10 @@
 package replica
 
 import (
+	"bytes"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +45,13 @@ const (
 	HotSpanScoreThreshold = 3           // TODO: bump to 10 befroe release
 	DefaultScoreThreshold = 3
 
+	// HotSpanSplitNumber is the number of the spans a hot span is split to by the sampled keys.
+	HotSpanSplitNumber = 4
+	// the hot span is split by the sampled keys only if there are enough distinct keys,
+	// otherwise the split points are decided by the region boundaries.
+	minHotSpanSampledKeys = 8
+	maxHotSpanSampledKeys = 256
+
 	defaultHardImbalanceThreshold = float64(1.35) // used to trigger the rebalance
 	clearTimeout                  = 300           // seconds
 )
@@ -52,6 +61,9 @@ var MinSpanNumberCoefficient = 2
 type CheckResult struct {
 	OpType       OpType
 	Replications []*SpanReplication
+	// SplitKeys are the split points recommended by the sampled keys of the hot span,
+	// they are sorted and in the range of the span.
+	SplitKeys [][]byte
 }
 
 func (c CheckResult) String() string {
@@ -78,7 +90,7 @@ func getNewGroupChecker(
 	return func(groupID replica.GroupID) replica.GroupChecker[common.DispatcherID, *SpanReplication] {
 		groupType := replica.GetGroupType(groupID)
 		switch groupType {
-		case replica.GroupDefault:
+		case replica.GroupDefault, replica.GroupSchema, replica.GroupTag:
 			return newHotSpanChecker(cfID)
 		case replica.GroupTable:
 			return newImbalanceChecker(cfID)
@@ -148,6 +160,7 @@ func (s *hotSpanChecker) UpdateStatus(span *SpanReplication) {
 	}
 	hotSpan.score++
 	hotSpan.lastUpdateTime = time.Now()
+	hotSpan.addSampledKeys(status.SampledKeys)
 }
 
 func (s *hotSpanChecker) Check(batchSize int) replica.GroupCheckResult {
@@ -163,6 +176,7 @@ func (s *hotSpanChecker) Check(batchSize int) replica.GroupCheckResult {
 			cache = append(cache, CheckResult{
 				OpType:       OpSplit,
 				Replications: []*SpanReplication{hotSpan.SpanReplication},
+				SplitKeys:    hotSpan.splitKeys(HotSpanSplitNumber),
 			})
 			if len(cache) >= batchSize {
 				break
@@ -204,6 +218,40 @@ type hotSpanStatus struct {
 	// score add 1 when the eventSizePerSecond is larger than writeThreshold*imbalanceCoefficient
 	score          int
 	lastUpdateTime time.Time
+	// sampledKeys are the latest keys sampled from the dml events while the span is hot
+	sampledKeys [][]byte
+}
+
+func (s *hotSpanStatus) addSampledKeys(keys [][]byte) {
+	s.sampledKeys = append(s.sampledKeys, keys...)
+	if len(s.sampledKeys) > maxHotSpanSampledKeys {
+		s.sampledKeys = slices.Clone(s.sampledKeys[len(s.sampledKeys)-maxHotSpanSampledKeys:])
+	}
+}
+
+// splitKeys returns the keys which split the sampled keys in the span evenly to splitNum parts,
+// it returns nil if the sampled keys are not enough to find the split points.
+func (s *hotSpanStatus) splitKeys(splitNum int) [][]byte {
+	keys := make([][]byte, 0, len(s.sampledKeys))
+	for _, key := range s.sampledKeys {
+		// the start key can't be a split point
+		if bytes.Compare(key, s.Span.StartKey) > 0 && bytes.Compare(key, s.Span.EndKey) < 0 {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	keys = slices.CompactFunc(keys, bytes.Equal)
+	if len(keys) < minHotSpanSampledKeys {
+		return nil
+	}
+	splitKeys := make([][]byte, 0, splitNum-1)
+	for i := 1; i < splitNum; i++ {
+		key := keys[i*len(keys)/splitNum]
+		if len(splitKeys) == 0 || !bytes.Equal(splitKeys[len(splitKeys)-1], key) {
+			splitKeys = append(splitKeys, key)
+		}
+	}
+	return splitKeys
 }
 
 type rebalanceChecker struct {
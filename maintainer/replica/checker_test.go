@@ -85,6 +85,52 @@ func TestHotSpanChecker(t *testing.T) {
 	require.Equal(t, 1, len(checker.hotTasks))
 }
 
+func TestHotSpanCheckerSplitKeys(t *testing.T) {
+	t.Parallel()
+
+	db := newDBWithCheckerForTest(t)
+	totalSpan := getTableSpanByID(3)
+	replicaSpanID := common.NewDispatcherID()
+	replicaSpan := NewWorkingReplicaSet(db.changefeedID, replicaSpanID,
+		db.ddlSpan.tsoClient, 1, totalSpan, &heartbeatpb.TableSpanStatus{
+			ID:              replicaSpanID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db.AddReplicatingSpan(replicaSpan)
+	checker := db.GetGroupChecker(replicaSpan.GetGroupID()).(*hotSpanChecker)
+
+	// the keys out of the span and the start key are ignored
+	sampledKeys := [][]byte{totalSpan.StartKey, totalSpan.EndKey}
+	for i := 0; i < HotSpanScoreThreshold; i++ {
+		db.UpdateStatus(replicaSpan, &heartbeatpb.TableSpanStatus{
+			CheckpointTs:       9,
+			EventSizePerSecond: HotSpanWriteThreshold,
+			SampledKeys:        sampledKeys,
+		})
+	}
+	results := checker.Check(20).([]CheckResult)
+	require.Equal(t, 1, len(results))
+	require.Nil(t, results[0].SplitKeys)
+
+	sampledKeys = sampledKeys[:0]
+	for c := byte('a'); c < 'q'; c++ {
+		sampledKeys = append(sampledKeys, appendNew(totalSpan.StartKey, c))
+	}
+	db.UpdateStatus(replicaSpan, &heartbeatpb.TableSpanStatus{
+		CheckpointTs:       10,
+		EventSizePerSecond: HotSpanWriteThreshold,
+		SampledKeys:        sampledKeys,
+	})
+	results = checker.Check(20).([]CheckResult)
+	require.Equal(t, 1, len(results))
+	require.Equal(t, [][]byte{
+		appendNew(totalSpan.StartKey, 'e'),
+		appendNew(totalSpan.StartKey, 'i'),
+		appendNew(totalSpan.StartKey, 'm'),
+	}, results[0].SplitKeys)
+}
+
 // Not parallel because it will change the global node manager
 func TestRebalanceChecker(t *testing.T) {
 	oldMinSpanNumberCoefficient := MinSpanNumberCoefficient
@@ -19,8 +19,10 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
@@ -43,6 +45,11 @@ type ReplicationDB struct {
 	// LOCK protects the above maps
 	lock            sync.RWMutex
 	newGroupChecker func(groupID replica.GroupID) replica.GroupChecker[common.DispatcherID, *SpanReplication]
+	// groupFunc decides the group of the spans covering whole tables, they are in the
+	// default group if it's nil. The spans of a split table are always grouped by the table.
+	groupFunc func(schemaID, tableID int64) replica.GroupID
+
+	metricCheckpointRegression prometheus.Counter
 }
 
 // NewReplicaSetDB creates a new ReplicationDB and initializes the maps
@@ -53,12 +60,20 @@ func NewReplicaSetDB(
 		changefeedID:    changefeedID,
 		ddlSpan:         ddlSpan,
 		newGroupChecker: getNewGroupChecker(changefeedID, enableTableAcrossNodes),
+		metricCheckpointRegression: metrics.SpanCheckpointRegressionCounter.WithLabelValues(
+			changefeedID.Namespace(), changefeedID.Name()),
 	}
 	db.reset()
 	db.putDDLDispatcher(db.ddlSpan)
 	return db
 }
 
+// SetGroupFunc sets the function deciding the group of the spans covering whole tables,
+// it must be called before any span is added.
+func (db *ReplicationDB) SetGroupFunc(f func(schemaID, tableID int64) replica.GroupID) {
+	db.groupFunc = f
+}
+
 // GetTaskByID returns the replica set by the id, it will search the replicating, scheduling and absent map
 func (db *ReplicationDB) GetTaskByID(id common.DispatcherID) *SpanReplication {
 	db.lock.RLock()
@@ -226,6 +241,7 @@ func (db *ReplicationDB) ReplaceReplicaSet(oldReplications []*SpanReplication, n
 func (db *ReplicationDB) AddReplicatingSpan(span *SpanReplication) {
 	db.lock.Lock()
 	defer db.lock.Unlock()
+	db.assignGroup(span)
 	db.allTasks[span.ID] = span
 	db.addToSchemaAndTableMap(span)
 	db.AddReplicatingWithoutLock(span)
@@ -287,6 +303,10 @@ func (db *ReplicationDB) UpdateSchemaID(tableID, newSchemaID int64) {
 		oldSchemaID := replicaSet.GetSchemaID()
 		// update schemaID
 		replicaSet.SetSchemaID(newSchemaID)
+		// the group of the span may be decided by the schema
+		if groupID, ok := db.groupOf(replicaSet); ok {
+			db.RegroupWithoutLock(replicaSet, groupID, replicaSet.setGroupID)
+		}
 
 		// update schema map
 		schemaMap, ok := db.schemaTasks[oldSchemaID]
@@ -307,7 +327,36 @@ func (db *ReplicationDB) UpdateSchemaID(tableID, newSchemaID int64) {
 	}
 }
 
+// UpdateTableGroup moves the spans of the table to the group decided by the groupFunc,
+// it's called when the group of the table is changed, e.g. the table is renamed.
+func (db *ReplicationDB) UpdateTableGroup(tableID int64) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for _, replicaSet := range db.tableTasks[tableID] {
+		if groupID, ok := db.groupOf(replicaSet); ok {
+			db.RegroupWithoutLock(replicaSet, groupID, replicaSet.setGroupID)
+		}
+	}
+}
+
+// UpdateStatus updates the status reported by the dispatcher of the span, the status is rejected if
+// its checkpoint ts is less than the current one, since the span must not be re-replicated from the
+// regressed checkpoint ts, which writes duplicated events to the downstream. The checkpoint ts can
+// only be moved back by replacing the span or ResetCheckpointTs explicitly.
 func (db *ReplicationDB) UpdateStatus(span *SpanReplication, status *heartbeatpb.TableSpanStatus) {
+	if old := span.GetStatus(); status != nil && old != nil && status.CheckpointTs < old.CheckpointTs {
+		db.metricCheckpointRegression.Inc()
+		log.Warn("reject the span status since its checkpoint ts regresses",
+			zap.String("changefeed", db.changefeedID.Name()),
+			zap.String("span", span.ID.String()),
+			zap.Int64("tableID", span.Span.TableID),
+			zap.String("node", span.GetNodeID().String()),
+			zap.Uint64("checkpointTs", old.CheckpointTs),
+			zap.Uint64("reportedCheckpointTs", status.CheckpointTs),
+			zap.String("reportedState", status.ComponentStatus.String()))
+		return
+	}
 	span.UpdateStatus(status)
 	checker := db.GetGroupChecker(span.GetGroupID()) // Note: need RLock here
 
@@ -327,12 +376,29 @@ func (db *ReplicationDB) BindSpanToNode(old, new node.ID, span *SpanReplication)
 // addAbsentReplicaSetUnLock adds spans to absent map
 func (db *ReplicationDB) addAbsentReplicaSetUnLock(spans ...*SpanReplication) {
 	for _, span := range spans {
+		db.assignGroup(span)
 		db.allTasks[span.ID] = span
 		db.AddAbsentWithoutLock(span)
 		db.addToSchemaAndTableMap(span)
 	}
 }
 
+// assignGroup sets the group of the span decided by the groupFunc
+func (db *ReplicationDB) assignGroup(span *SpanReplication) {
+	if groupID, ok := db.groupOf(span); ok {
+		span.setGroupID(groupID)
+	}
+}
+
+// groupOf returns the group of the span decided by the groupFunc, it returns
+// false if the span is not grouped by the groupFunc.
+func (db *ReplicationDB) groupOf(span *SpanReplication) (replica.GroupID, bool) {
+	if db.groupFunc == nil || replica.GetGroupType(span.GetGroupID()) == replica.GroupTable {
+		return 0, false
+	}
+	return db.groupFunc(span.GetSchemaID(), span.Span.TableID), true
+}
+
 // removeSpanUnLock removes the spans from the db without lock
 func (db *ReplicationDB) removeSpanUnLock(spans ...*SpanReplication) {
 	for _, span := range spans {
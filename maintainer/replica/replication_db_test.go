@@ -20,6 +20,8 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	replica_mock "github.com/pingcap/ticdc/maintainer/replica/mock"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/stretchr/testify/require"
 )
@@ -151,6 +153,42 @@ func TestMarkSpanAbsent(t *testing.T) {
 	db.MarkSpanAbsent(replicaSpan)
 	require.Equal(t, 1, db.GetAbsentSize())
 	require.Equal(t, "", replicaSpan.GetNodeID().String())
+	require.Equal(t, 0, db.GetTaskSizeByNodeID("node1"))
+	require.Len(t, db.GetTasksPerNode(), 0)
+}
+
+func TestNodeIndex(t *testing.T) {
+	t.Parallel()
+
+	db := newDBWithCheckerForTest(t)
+	var spans []*SpanReplication
+	for i := 1; i <= 4; i++ {
+		span := NewReplicaSet(db.changefeedID, common.NewDispatcherID(), db.ddlSpan.tsoClient, 1, getTableSpanByID(int64(i)), 1)
+		db.AddAbsentReplicaSet(span)
+		spans = append(spans, span)
+	}
+	// the absent spans are not bound to any node
+	require.Len(t, db.GetTasksPerNode(), 0)
+
+	db.BindSpanToNode("", "node1", spans[0])
+	db.BindSpanToNode("", "node1", spans[1])
+	db.BindSpanToNode("", "node2", spans[2])
+	require.Equal(t, map[node.ID]int{"node1": 2, "node2": 1}, db.GetTaskSizePerNode())
+	require.ElementsMatch(t, []*SpanReplication{spans[0], spans[1]}, db.GetTaskByNodeID("node1"))
+	require.ElementsMatch(t, []*SpanReplication{spans[2]}, db.GetTasksPerNode()["node2"])
+
+	// move the span to another node
+	db.BindSpanToNode("node1", "node2", spans[1])
+	require.Equal(t, 1, db.GetTaskSizeByNodeID("node1"))
+	require.ElementsMatch(t, []*SpanReplication{spans[1], spans[2]}, db.GetTaskByNodeID("node2"))
+
+	db.MarkSpanAbsent(spans[0])
+	require.Equal(t, 0, db.GetTaskSizeByNodeID("node1"))
+	db.ForceRemove(spans[2].ID)
+	require.Equal(t, map[node.ID]int{"node2": 1}, db.GetTaskSizePerNode())
+
+	db.TryRemoveAll()
+	require.Len(t, db.GetTasksPerNode(), 0)
 }
 
 func TestForceRemove(t *testing.T) {
@@ -235,3 +273,78 @@ func newDBWithCheckerForTest(t *testing.T) *ReplicationDB {
 		}, "node1")
 	return NewReplicaSetDB(cfID, ddlSpan, true)
 }
+
+func TestUpdateStatusRejectRegression(t *testing.T) {
+	t.Parallel()
+
+	db := newDBWithCheckerForTest(t)
+	replicaSpanID := common.NewDispatcherID()
+	replicaSpan := NewWorkingReplicaSet(db.changefeedID, replicaSpanID,
+		db.ddlSpan.tsoClient, 1,
+		getTableSpanByID(3), &heartbeatpb.TableSpanStatus{
+			ID:              replicaSpanID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    10,
+		}, "node1")
+	db.AddReplicatingSpan(replicaSpan)
+
+	db.UpdateStatus(replicaSpan, &heartbeatpb.TableSpanStatus{
+		ID:              replicaSpanID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    20,
+	})
+	require.Equal(t, uint64(20), replicaSpan.GetStatus().CheckpointTs)
+
+	// the regressed checkpoint ts is rejected
+	db.UpdateStatus(replicaSpan, &heartbeatpb.TableSpanStatus{
+		ID:              replicaSpanID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    15,
+	})
+	require.Equal(t, uint64(20), replicaSpan.GetStatus().CheckpointTs)
+
+	// the checkpoint ts can be moved back explicitly
+	replicaSpan.ResetCheckpointTs(5)
+	require.Equal(t, uint64(5), replicaSpan.GetStatus().CheckpointTs)
+}
+
+func TestGroupBySchema(t *testing.T) {
+	t.Parallel()
+
+	db := newDBWithCheckerForTest(t)
+	db.SetGroupFunc(func(schemaID, _ int64) replica.GroupID {
+		return replica.GenGroupID(replica.GroupSchema, schemaID)
+	})
+	schema1 := replica.GenGroupID(replica.GroupSchema, 1)
+	schema2 := replica.GenGroupID(replica.GroupSchema, 2)
+
+	absent := NewReplicaSet(db.changefeedID, common.NewDispatcherID(), db.ddlSpan.tsoClient, 1, getTableSpanByID(4), 1)
+	db.AddAbsentReplicaSet(absent)
+	replicaSpanID := common.NewDispatcherID()
+	replicaSpan := NewWorkingReplicaSet(db.changefeedID, replicaSpanID,
+		db.ddlSpan.tsoClient, 2,
+		getTableSpanByID(3), &heartbeatpb.TableSpanStatus{
+			ID:              replicaSpanID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db.AddReplicatingSpan(replicaSpan)
+	// the spans of a split table are still grouped by the table
+	splitSpan := &heartbeatpb.TableSpan{TableID: 5, StartKey: appendNew(getTableSpanByID(5).StartKey, 'a'), EndKey: getTableSpanByID(5).EndKey}
+	split := NewReplicaSet(db.changefeedID, common.NewDispatcherID(), db.ddlSpan.tsoClient, 1, splitSpan, 1)
+	db.AddAbsentReplicaSet(split)
+
+	require.Equal(t, schema1, absent.GetGroupID())
+	require.Equal(t, schema2, replicaSpan.GetGroupID())
+	require.Equal(t, replica.GenGroupID(replica.GroupTable, 5), split.GetGroupID())
+	require.Len(t, db.GetAbsentByGroup(schema1, 10), 1)
+	require.Len(t, db.GetReplicatingByGroup(schema2), 1)
+
+	// the span is moved to the group of the new schema with its status kept
+	db.UpdateSchemaID(3, 1)
+	require.Equal(t, schema1, replicaSpan.GetGroupID())
+	require.Len(t, db.GetReplicatingByGroup(schema1), 1)
+	require.Equal(t, 1, db.GetTaskSizePerNodeByGroup(schema1)["node1"])
+	require.NotContains(t, db.GetGroups(), schema2)
+	require.Equal(t, "schema-1", replica.GetGroupName(schema1))
+}
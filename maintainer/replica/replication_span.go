@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/retry"
@@ -172,6 +173,14 @@ func (r *SpanReplication) UpdateStatus(newStatus *heartbeatpb.TableSpanStatus) {
 	}
 }
 
+// ResetCheckpointTs moves the checkpoint ts of the span back to ts,
+// it's used to re-replicate the span from an older ts.
+func (r *SpanReplication) ResetCheckpointTs(ts uint64) {
+	status := *r.status.Load()
+	status.CheckpointTs = ts
+	r.status.Store(&status)
+}
+
 func (r *SpanReplication) ShouldRun() bool {
 	return true
 }
@@ -213,6 +222,10 @@ func (r *SpanReplication) GetGroupID() replica.GroupID {
 	return r.groupID
 }
 
+func (r *SpanReplication) setGroupID(groupID replica.GroupID) {
+	r.groupID = groupID
+}
+
 func (r *SpanReplication) NewAddDispatcherMessage(server node.ID) (*messaging.TargetMessage, error) {
 	ts, err := getTs(r.tsoClient)
 	if err != nil {
@@ -262,6 +275,15 @@ func getTs(client TSOClient) (uint64, error) {
 		ts = oracle.ComposeTS(phy, logic)
 		return nil
 	}, retry.WithTotalRetryDuratoin(300*time.Millisecond),
-		retry.WithBackoffBaseDelay(100))
+		retry.WithErrorClassifier(classifyTsoError))
 	return ts, errors.Trace(err)
 }
+
+// classifyTsoError returns the class of the error met by getting a ts from PD, the
+// retryable ones are transient since the operator is scheduled again later if it fails.
+func classifyTsoError(err error) cerror.RetryClass {
+	if class := cerror.RetryClassOf(err); class == cerror.RetryClassFatal {
+		return class
+	}
+	return cerror.RetryClassTransient
+}
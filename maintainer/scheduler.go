@@ -15,6 +15,8 @@ package maintainer
 
 import (
 	"context"
+	"encoding/hex"
+	"strconv"
 	"time"
 
 	"github.com/pingcap/log"
@@ -23,6 +25,8 @@ import (
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler"
 	pkgReplica "github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"github.com/pingcap/ticdc/server/watcher"
@@ -36,12 +40,38 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 	oc *operator.Controller,
 	db *replica.ReplicationDB,
 	nodeM *watcher.NodeManager,
-	balanceInterval time.Duration,
+	balanceInterval *scheduler.BalanceInterval,
 	splitter *split.Splitter,
+	placementStrategy string,
+	maxSpansPerTablePerNode int,
+	newCapacity func() scheduler.NodeCapacity[*replica.SpanReplication],
+	nodeLoad func() map[node.ID]int,
 ) *scheduler.Controller {
-	schedulers := map[string]scheduler.Scheduler{
-		scheduler.BasicScheduler:   scheduler.NewBasicScheduler(changefeedID.String(), batchSize, oc, db, nodeM, oc.NewAddOperator),
-		scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(changefeedID.String(), batchSize, oc, db, nodeM, balanceInterval, oc.NewMoveOperator),
+	var schedulers map[string]scheduler.Scheduler
+	if placementStrategy == config.PlacementStrategyConsistentHash {
+		basic := scheduler.NewHashBasicScheduler(changefeedID.String(), batchSize, oc, db, nodeM, spanHashKey, oc.NewAddOperator)
+		basic.SetNodeCapacity(newCapacity)
+		balance := scheduler.NewHashBalanceScheduler(changefeedID.String(), batchSize, oc, db, nodeM, balanceInterval.Get(), spanHashKey, oc.NewMoveOperator)
+		balance.SetBalanceInterval(balanceInterval)
+		schedulers = map[string]scheduler.Scheduler{
+			scheduler.BasicScheduler:   basic,
+			scheduler.BalanceScheduler: balance,
+		}
+	} else {
+		// the spans of a split table are in the same group, limit the spans of the group on one node
+		basic := scheduler.NewBasicScheduler(changefeedID.String(), batchSize, oc, db, nodeM, oc.NewAddOperator)
+		basic.SetMaxTasksPerNodeInGroup(maxSpansPerTablePerNode)
+		basic.SetNodeCapacity(newCapacity)
+		basic.SetNodeLoad(nodeLoad)
+		balance := scheduler.NewBalanceScheduler(changefeedID.String(), batchSize, oc, db, nodeM, balanceInterval.Get(), oc.NewMoveOperator)
+		balance.SetBalanceInterval(balanceInterval)
+		balance.SetMaxTasksPerNodeInGroup(maxSpansPerTablePerNode)
+		balance.SetNodeCapacity(newCapacity)
+		balance.SetNodeLoad(nodeLoad)
+		schedulers = map[string]scheduler.Scheduler{
+			scheduler.BasicScheduler:   basic,
+			scheduler.BalanceScheduler: balance,
+		}
 	}
 	if splitter != nil {
 		schedulers[scheduler.SplitScheduler] = newSplitScheduler(changefeedID, batchSize, splitter, oc, db, nodeM, balanceInterval)
@@ -49,6 +79,12 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 	return scheduler.NewController(schedulers)
 }
 
+// spanHashKey returns the key of the span used by the consistent hash placement,
+// it's stable for the same span across the restarts of the changefeed.
+func spanHashKey(r *replica.SpanReplication) string {
+	return strconv.FormatInt(r.Span.TableID, 10) + "-" + hex.EncodeToString(r.Span.StartKey)
+}
+
 // splitScheduler is used to check the split status of all spans
 type splitScheduler struct {
 	changefeedID common.ChangeFeedID
@@ -58,8 +94,10 @@ type splitScheduler struct {
 	db           *replica.ReplicationDB
 	nodeManager  *watcher.NodeManager
 
-	maxCheckTime  time.Duration
-	checkInterval time.Duration
+	maxCheckTime time.Duration
+	// checkInterval is shared with the balance scheduler, the split check
+	// backs off and tightens together with the balance check.
+	checkInterval *scheduler.BalanceInterval
 	lastCheckTime time.Time
 
 	batchSize int
@@ -68,7 +106,7 @@ type splitScheduler struct {
 func newSplitScheduler(
 	changefeedID common.ChangeFeedID, batchSize int, splitter *split.Splitter,
 	oc *operator.Controller, db *replica.ReplicationDB, nodeManager *watcher.NodeManager,
-	checkInterval time.Duration,
+	checkInterval *scheduler.BalanceInterval,
 ) *splitScheduler {
 	return &splitScheduler{
 		changefeedID:  changefeedID,
@@ -86,10 +124,12 @@ func (s *splitScheduler) Execute() time.Time {
 	if s.splitter == nil {
 		return time.Time{}
 	}
-	if time.Since(s.lastCheckTime) < s.checkInterval {
-		return s.lastCheckTime.Add(s.checkInterval)
+	checkInterval := s.checkInterval.Get()
+	if time.Since(s.lastCheckTime) < checkInterval {
+		return s.lastCheckTime.Add(checkInterval)
 	}
 
+	s.tuneSplitThresholds()
 	log.Info("check split status", zap.String("changefeed", s.changefeedID.Name()),
 		zap.String("hotSpans", s.db.GetCheckerStat()), zap.String("groupDistribution", s.db.GetGroupStat()))
 
@@ -105,7 +145,19 @@ func (s *splitScheduler) Execute() time.Time {
 		batch -= checked
 		s.lastCheckTime = time.Now()
 	}
-	return s.lastCheckTime.Add(s.checkInterval)
+	return s.lastCheckTime.Add(checkInterval)
+}
+
+// tuneSplitThresholds tunes the thresholds of the splitter by the traffic
+// reported by the dispatchers and the alive node count.
+func (s *splitScheduler) tuneSplitThresholds() {
+	eventSizePerSecond := float64(0)
+	for _, span := range s.db.GetAllTasks() {
+		if status := span.GetStatus(); status != nil {
+			eventSizePerSecond += float64(status.EventSizePerSecond)
+		}
+	}
+	s.splitter.TuneThresholds(eventSizePerSecond, len(s.nodeManager.GetAliveNodes()))
 }
 
 func (s *splitScheduler) Name() string {
@@ -133,6 +185,19 @@ func (s *splitScheduler) doCheck(ret pkgReplica.GroupCheckResult, start time.Tim
 		case replica.OpMerge:
 			s.opController.AddMergeSplitOperator(ret.Replications, []*heartbeatpb.TableSpan{totalSpan})
 		case replica.OpSplit:
+			if len(ret.SplitKeys) > 0 {
+				// split the hot span by the sampled keys, so the hot ranges inside
+				// the span are spread to different spans
+				spans := split.SplitSpanByKeys(ret.Replications[0].Span, ret.SplitKeys)
+				if len(spans) > 1 {
+					log.Info("split hot span by sampled keys",
+						zap.String("changefeed", s.changefeedID.Name()),
+						zap.String("span", ret.Replications[0].Span.String()),
+						zap.Int("spanSize", len(spans)))
+					s.opController.AddMergeSplitOperator(ret.Replications, spans)
+					continue
+				}
+			}
 			fallthrough
 		case replica.OpMergeAndSplit:
 			expectedSpanNum := split.NextExpectedSpansNumber(len(ret.Replications))
@@ -0,0 +1,99 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// ResendTableSchema asks the sinks to resend the bootstrap messages of the tables, so the
+// consumers joining late can decode the rows without waiting for the next periodic bootstrap.
+// All tables are resent if tableIDs is empty. The request is sent to the nodes in the next
+// period task, or after the maintainer is bootstrapped if it's not yet.
+func (m *Maintainer) ResendTableSchema(tableIDs []int64) error {
+	if m.config.Config == nil || !m.config.Config.Sink.ShouldSendBootstrapMsg() {
+		return errors.ErrAPIInvalidParam.GenWithStack(
+			"the sink of the changefeed does not send the table schema in bootstrap messages")
+	}
+	for _, tableID := range tableIDs {
+		if !m.controller.replicationDB.IsTableExists(tableID) {
+			return errors.ErrAPIInvalidParam.GenWithStack("table %d is not replicated", tableID)
+		}
+	}
+
+	m.schemaResend.Lock()
+	defer m.schemaResend.Unlock()
+	switch {
+	case !m.schemaResend.pending:
+		m.schemaResend.tableIDs = append([]int64(nil), tableIDs...)
+	case len(m.schemaResend.tableIDs) == 0:
+		// all tables are already requested.
+	case len(tableIDs) == 0:
+		m.schemaResend.tableIDs = nil
+	default:
+		m.schemaResend.tableIDs = append(m.schemaResend.tableIDs, tableIDs...)
+	}
+	m.schemaResend.pending = true
+	log.Info("table schema resend is requested",
+		zap.String("changefeed", m.id.Name()),
+		zap.Int64s("tableIDs", tableIDs))
+	return nil
+}
+
+// sendSchemaResend sends the pending table schema resend request to all nodes. The request
+// is kept pending until a node supporting it is known, for example, it's requested before
+// the maintainer is bootstrapped, so it's not dropped silently.
+func (m *Maintainer) sendSchemaResend() {
+	capable := make([]node.ID, 0, len(m.nodeCapabilities))
+	for id, caps := range m.nodeCapabilities {
+		if caps.Has(heartbeatpb.CapabilityResendTableSchema) {
+			capable = append(capable, id)
+		}
+	}
+	if len(capable) == 0 {
+		return
+	}
+
+	m.schemaResend.Lock()
+	if !m.schemaResend.pending {
+		m.schemaResend.Unlock()
+		return
+	}
+	tableIDs := m.schemaResend.tableIDs
+	m.schemaResend.pending = false
+	m.schemaResend.tableIDs = nil
+	m.schemaResend.Unlock()
+
+	if len(capable) < len(m.nodeCapabilities) {
+		log.Warn("some nodes do not support resending table schema, ignore them",
+			zap.String("changefeed", m.id.Name()),
+			zap.Int("nodes", len(m.nodeCapabilities)),
+			zap.Int("supported", len(capable)))
+	}
+	msgs := make([]*messaging.TargetMessage, 0, len(capable))
+	for _, id := range capable {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id,
+			messaging.HeartbeatCollectorTopic,
+			&heartbeatpb.ResendTableSchemaRequest{
+				ChangefeedID: m.id.ToPB(),
+				TableIds:     tableIDs,
+			}))
+	}
+	m.sendMessages(msgs)
+}
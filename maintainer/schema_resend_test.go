@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResendTableSchema(t *testing.T) {
+	mc := &recordMessageCenter{}
+	replicaConfig := config.GetDefaultReplicaConfig()
+	m := &Maintainer{
+		id:     common.NewChangefeedID4Test("test", "test"),
+		mc:     mc,
+		config: &config.ChangeFeedInfo{Config: replicaConfig},
+		nodeCapabilities: map[node.ID]heartbeatpb.Capabilities{
+			"node-1": heartbeatpb.NegotiateCapabilities(heartbeatpb.ProtocolVersion, heartbeatpb.LocalCapabilities()),
+			// the old node does not support resending the table schema
+			"node-2": heartbeatpb.NegotiateCapabilities(0, nil),
+		},
+	}
+	// the default protocol does not send the bootstrap messages
+	require.Error(t, m.ResendTableSchema(nil))
+
+	replicaConfig.Sink.Protocol = util.AddressOf(config.ProtocolSimple.String())
+	require.NoError(t, m.ResendTableSchema(nil))
+	m.sendSchemaResend()
+	require.Len(t, mc.sent, 1)
+	require.Equal(t, node.ID("node-1"), mc.sent[0].To)
+	req := mc.sent[0].Message[0].(*heartbeatpb.ResendTableSchemaRequest)
+	require.Empty(t, req.TableIds)
+
+	// nothing is sent if there is no pending request
+	m.sendSchemaResend()
+	require.Len(t, mc.sent, 1)
+}
+
+func TestResendTableSchemaBeforeBootstrap(t *testing.T) {
+	mc := &recordMessageCenter{}
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Protocol = util.AddressOf(config.ProtocolSimple.String())
+	m := &Maintainer{
+		id:               common.NewChangefeedID4Test("test", "test"),
+		mc:               mc,
+		config:           &config.ChangeFeedInfo{Config: replicaConfig},
+		nodeCapabilities: make(map[node.ID]heartbeatpb.Capabilities),
+	}
+	require.NoError(t, m.ResendTableSchema([]int64{}))
+
+	// no node is known before the bootstrap, the request is kept pending
+	m.sendSchemaResend()
+	require.Empty(t, mc.sent)
+	// the old nodes can't handle the request either
+	m.nodeCapabilities["node-1"] = heartbeatpb.NegotiateCapabilities(0, nil)
+	m.sendSchemaResend()
+	require.Empty(t, mc.sent)
+
+	// the request is sent once a node supporting it is bootstrapped
+	m.nodeCapabilities["node-2"] = heartbeatpb.NegotiateCapabilities(heartbeatpb.ProtocolVersion, heartbeatpb.LocalCapabilities())
+	m.sendSchemaResend()
+	require.Len(t, mc.sent, 1)
+	require.Equal(t, node.ID("node-2"), mc.sent[0].To)
+	m.sendSchemaResend()
+	require.Len(t, mc.sent, 1)
+}
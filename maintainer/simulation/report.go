@@ -0,0 +1,99 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// NodeReport is the placement and the load of a node at the end of the simulation.
+type NodeReport struct {
+	ID node.ID
+	// Alive is false if the node is removed during the simulation.
+	Alive bool
+	// Tables is the number of tables replicated by the node.
+	Tables int
+	// Traffic is the rows per second written to the tables of the node.
+	Traffic float64
+	// Utilization is Traffic divided by the node capacity, 0 if the capacity is unlimited.
+	Utilization float64
+	// MaxLag is the max lag of the tables on the node during the simulation.
+	MaxLag time.Duration
+}
+
+// Report is the result of a simulation.
+type Report struct {
+	Duration time.Duration
+	Nodes    []NodeReport
+	// AddOperators and MoveOperators are the number of operators created by the schedulers.
+	AddOperators  int
+	MoveOperators int
+	// SettledAt is the virtual time after which no table is absent or being scheduled.
+	SettledAt time.Duration
+	// MaxLag and AvgLag are the max and the average lag of the changefeed.
+	MaxLag time.Duration
+	AvgLag time.Duration
+	// Operators is the operators created by the schedulers in order, with the virtual time they are created.
+	Operators []string
+}
+
+func (s *Simulator) report() *Report {
+	r := &Report{
+		Duration:      s.elapsed(),
+		AddOperators:  s.stat.added,
+		MoveOperators: s.stat.moved,
+		SettledAt:     s.stat.settledAt,
+		MaxLag:        s.stat.maxLag,
+		Operators:     s.stat.trace,
+	}
+	if s.stat.steps > 0 {
+		r.AvgLag = s.stat.totalLag / time.Duration(s.stat.steps)
+	}
+	for _, n := range s.nodes {
+		nr := NodeReport{
+			ID:     n.id,
+			Alive:  n.dispatchers != nil,
+			Tables: len(n.dispatchers),
+			MaxLag: n.maxLag,
+		}
+		for _, id := range s.tableIDs {
+			if t, ok := n.dispatchers[id]; ok {
+				nr.Traffic += t.traffic
+			}
+		}
+		if s.cfg.NodeCapacity > 0 {
+			nr.Utilization = nr.Traffic / s.cfg.NodeCapacity
+		}
+		r.Nodes = append(r.Nodes, nr)
+	}
+	return r
+}
+
+// String returns a human-readable report.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "duration: %s, settled at: %s, add operators: %d, move operators: %d\n",
+		r.Duration, r.SettledAt, r.AddOperators, r.MoveOperators)
+	fmt.Fprintf(&b, "lag: max %s, avg %s\n", r.MaxLag, r.AvgLag)
+	fmt.Fprintf(&b, "%-10s %-6s %8s %12s %12s %12s\n", "node", "alive", "tables", "traffic", "utilization", "max lag")
+	for _, n := range r.Nodes {
+		fmt.Fprintf(&b, "%-10s %-6t %8d %12.1f %11.1f%% %12s\n",
+			n.ID, n.Alive, n.Tables, n.Traffic, n.Utilization*100, n.MaxLag)
+	}
+	return b.String()
+}
@@ -0,0 +1,459 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simulation runs the schedulers and the operator controller of the maintainer
+// against a synthetic workload with a virtual clock, and reports the placement of the
+// tables and the replication lag.
+//
+// It's used to plan the capacity of a cluster and to test the balance algorithms.
+// The simulation is reproducible with the same seed: the random sources are seeded by it,
+// and the tasks and the nodes are visited in the order of their ids.
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler"
+	pkgOperator "github.com/pingcap/ticdc/pkg/scheduler/operator"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+// Config is the config of a simulation.
+type Config struct {
+	Workload
+	// Duration is the virtual time the simulation runs.
+	Duration time.Duration
+	// Step is the virtual time advanced in each step, 100ms by default.
+	Step time.Duration
+	// RPCLatency is the latency of the messages between the maintainer and the dispatchers, 10ms by default.
+	RPCLatency time.Duration
+	// BatchSize is the max number of the running operators, 1000 by default.
+	BatchSize int
+	// BalanceInterval is the interval of the balance scheduler, 1 minute by default.
+	BalanceInterval time.Duration
+	// Seed is the seed of the random source used by the workload and the balance scheduler.
+	Seed int64
+}
+
+func (c *Config) fillDefaults() {
+	if c.Step <= 0 {
+		c.Step = 100 * time.Millisecond
+	}
+	if c.RPCLatency <= 0 {
+		c.RPCLatency = 10 * time.Millisecond
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 1000
+	}
+	if c.BalanceInterval <= 0 {
+		c.BalanceInterval = time.Minute
+	}
+	if c.Distribution == "" {
+		c.Distribution = DistributionUniform
+	}
+}
+
+// table is a table of the workload.
+type table struct {
+	id      int64
+	traffic float64
+	// backlog is the rows written to the upstream but not replicated yet.
+	backlog float64
+}
+
+// lag returns how far the table falls behind the upstream.
+func (t *table) lag() time.Duration {
+	if t.traffic == 0 {
+		return 0
+	}
+	return time.Duration(t.backlog / t.traffic * float64(time.Second))
+}
+
+// simNode is a node in the simulation, it holds the dispatchers created by the maintainer.
+type simNode struct {
+	id          node.ID
+	dispatchers map[common.DispatcherID]*table
+	maxLag      time.Duration
+}
+
+// message is a message in flight between the maintainer and the dispatchers.
+type message struct {
+	deliverAt time.Time
+	from      node.ID
+	to        node.ID
+	// request is sent from the maintainer to the dispatcher manager.
+	request *heartbeatpb.ScheduleDispatcherRequest
+	// status is sent from the dispatcher to the maintainer.
+	status *heartbeatpb.TableSpanStatus
+}
+
+// messageCenter captures the messages sent by the operator controller,
+// they are delivered by the simulator after the rpc latency.
+type messageCenter struct {
+	messaging.MessageCenter
+	sim *Simulator
+}
+
+func (mc *messageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	for _, m := range msg.Message {
+		if req, ok := m.(*heartbeatpb.ScheduleDispatcherRequest); ok {
+			mc.sim.send(&message{to: msg.To, request: req})
+		}
+	}
+	return nil
+}
+
+// ownerEtcdClient is used by the node manager to get the coordinator id.
+type ownerEtcdClient struct {
+	etcd.CDCEtcdClient
+}
+
+func (c *ownerEtcdClient) GetOwnerID(_ context.Context) (model.CaptureID, error) {
+	return "", nil
+}
+
+// GetNodeInfo returns the node without metadata, the simulated nodes run the same version.
+func (c *ownerEtcdClient) GetNodeInfo(_ context.Context, id string) (*node.Info, error) {
+	return &node.Info{ID: node.ID(id)}, nil
+}
+
+// Simulator simulates a changefeed running on a cluster.
+type Simulator struct {
+	cfg    Config
+	clock  *clock.Mock
+	random *rand.Rand
+	start  time.Time
+
+	changefeedID common.ChangeFeedID
+	db           *replica.ReplicationDB
+	oc           *operator.Controller
+	sc           *scheduler.Controller
+	nodeManager  *watcher.NodeManager
+
+	tables map[common.DispatcherID]*table
+	// tableIDs is the ids of the tables in ascending order
+	tableIDs []common.DispatcherID
+	nodes    []*simNode
+	inFlight []*message
+	events   []NodeEvent
+
+	// the next time to run the operator controller and the schedulers
+	nextExecute  time.Time
+	nextSchedule map[string]time.Time
+
+	stat stat
+}
+
+// stat is the statistics collected during the simulation.
+type stat struct {
+	added     int
+	moved     int
+	settledAt time.Duration
+	maxLag    time.Duration
+	totalLag  time.Duration
+	steps     int
+	// trace is the operators created by the schedulers in order
+	trace []string
+}
+
+// New creates a simulator with the given config.
+func New(cfg Config) (*Simulator, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg.fillDefaults()
+	if cfg.Duration <= 0 {
+		return nil, errors.New("the duration of the simulation must be positive")
+	}
+
+	s := &Simulator{
+		cfg:          cfg,
+		clock:        clock.NewMock(),
+		random:       rand.New(rand.NewSource(cfg.Seed)),
+		changefeedID: common.NewChangeFeedIDWithName("simulation"),
+		tables:       make(map[common.DispatcherID]*table, cfg.Tables),
+		nextSchedule: make(map[string]time.Time),
+		events:       slices.Clone(cfg.Events),
+	}
+	slices.SortStableFunc(s.events, func(a, b NodeEvent) int {
+		return int(a.At - b.At)
+	})
+	s.start = s.clock.Now()
+	s.nextExecute = s.start
+
+	s.nodeManager = watcher.NewNodeManager(nil, &ownerEtcdClient{})
+	for i := 0; i < cfg.Nodes; i++ {
+		s.addNode()
+	}
+	s.refreshNodes()
+
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpanID := newDispatcherID(0)
+	ddlSpan := replica.NewWorkingReplicaSet(s.changefeedID, ddlSpanID, tsoClient,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              ddlSpanID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, s.nodes[0].id)
+	s.db = replica.NewReplicaSetDB(s.changefeedID, ddlSpan, false)
+	s.oc = operator.NewOperatorController(s.changefeedID, &messageCenter{sim: s}, s.db, s.nodeManager, cfg.BatchSize)
+	s.oc.SetClock(s.clock)
+
+	db := &sortedDB{scheduleGroup: s.db}
+	basic := scheduler.NewBasicScheduler(s.changefeedID.String(), cfg.BatchSize, s.oc, db, s.nodeManager,
+		func(r *replica.SpanReplication, target node.ID) pkgOperator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus] {
+			s.stat.added++
+			s.recordOperator("add table %d to %s", r.Span.TableID, target)
+			return s.oc.NewAddOperator(r, target)
+		})
+	basic.SetClock(s.clock)
+	balance := scheduler.NewBalanceScheduler(s.changefeedID.String(), cfg.BatchSize, s.oc, db, s.nodeManager, cfg.BalanceInterval,
+		func(r *replica.SpanReplication, origin, target node.ID) pkgOperator.Operator[common.DispatcherID, *heartbeatpb.TableSpanStatus] {
+			s.stat.moved++
+			s.recordOperator("move table %d from %s to %s", r.Span.TableID, origin, target)
+			return s.oc.NewMoveOperator(r, origin, target)
+		})
+	balance.SetClock(s.clock, rand.New(rand.NewSource(cfg.Seed)))
+	s.sc = scheduler.NewController(map[string]scheduler.Scheduler{
+		scheduler.BasicScheduler:   basic,
+		scheduler.BalanceScheduler: balance,
+	})
+
+	for i, traffic := range cfg.tableTraffic(s.random) {
+		tableID := int64(i + 1)
+		span := spanz.TableIDToComparableSpan(tableID)
+		id := newDispatcherID(tableID)
+		s.tables[id] = &table{id: tableID, traffic: traffic}
+		s.tableIDs = append(s.tableIDs, id)
+		s.db.AddAbsentReplicaSet(replica.NewReplicaSet(s.changefeedID, id, tsoClient, 1,
+			&heartbeatpb.TableSpan{TableID: tableID, StartKey: span.StartKey, EndKey: span.EndKey}, 1))
+	}
+	return s, nil
+}
+
+// newDispatcherID returns a stable dispatcher id for the table, so the simulation is reproducible.
+func newDispatcherID(tableID int64) common.DispatcherID {
+	return common.DispatcherID{Low: uint64(tableID), High: 1}
+}
+
+// recordOperator records an operator created by the schedulers with the virtual time.
+func (s *Simulator) recordOperator(format string, args ...any) {
+	s.stat.trace = append(s.stat.trace, fmt.Sprintf("%s: ", s.elapsed())+fmt.Sprintf(format, args...))
+}
+
+// Run runs the simulation and returns the report.
+func (s *Simulator) Run() *Report {
+	for s.elapsed() < s.cfg.Duration {
+		s.step()
+	}
+	return s.report()
+}
+
+func (s *Simulator) elapsed() time.Duration {
+	return s.clock.Now().Sub(s.start)
+}
+
+// step runs the simulation for one step.
+func (s *Simulator) step() {
+	now := s.clock.Now()
+	for len(s.events) > 0 && s.events[0].At <= s.elapsed() {
+		s.applyNodeEvent(s.events[0])
+		s.events = s.events[1:]
+	}
+	s.deliverMessages(now)
+
+	for _, sc := range s.sc.GetSchedulers() {
+		if next, ok := s.nextSchedule[sc.Name()]; ok && now.Before(next) {
+			continue
+		}
+		s.nextSchedule[sc.Name()] = sc.Execute()
+	}
+	if !now.Before(s.nextExecute) {
+		s.nextExecute = s.oc.Execute()
+	}
+	if s.db.GetAbsentSize() > 0 || s.oc.OperatorSize() > 0 {
+		s.stat.settledAt = s.elapsed() + s.cfg.Step
+	}
+
+	s.replicate(s.cfg.Step)
+	s.clock.Add(s.cfg.Step)
+}
+
+func (s *Simulator) addNode() {
+	n := &simNode{
+		id:          node.ID(fmt.Sprintf("node-%d", len(s.nodes))),
+		dispatchers: make(map[common.DispatcherID]*table),
+	}
+	s.nodes = append(s.nodes, n)
+}
+
+func (s *Simulator) aliveNodes() []*simNode {
+	alive := make([]*simNode, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		if n.dispatchers != nil {
+			alive = append(alive, n)
+		}
+	}
+	return alive
+}
+
+// refreshNodes notifies the node manager the alive nodes, as what the etcd watcher does.
+func (s *Simulator) refreshNodes() {
+	captures := make(map[model.CaptureID]*model.CaptureInfo, len(s.nodes))
+	for _, n := range s.aliveNodes() {
+		captures[model.CaptureID(n.id)] = &model.CaptureInfo{ID: model.CaptureID(n.id)}
+	}
+	_, _ = s.nodeManager.Tick(context.Background(), &orchestrator.GlobalReactorState{Captures: captures})
+}
+
+func (s *Simulator) applyNodeEvent(event NodeEvent) {
+	switch event.Type {
+	case NodeAdded:
+		s.addNode()
+		s.refreshNodes()
+	case NodeRemoved:
+		if event.Node < 0 || event.Node >= len(s.nodes) || s.nodes[event.Node].dispatchers == nil {
+			return
+		}
+		removed := s.nodes[event.Node]
+		// the removed node can not be used by the node manager,
+		// the dispatchers on it stop replicating.
+		removed.dispatchers = nil
+		s.inFlight = slices.DeleteFunc(s.inFlight, func(m *message) bool {
+			return m.from == removed.id || m.to == removed.id
+		})
+		s.refreshNodes()
+		s.oc.OnNodeRemoved(removed.id)
+	}
+}
+
+func (s *Simulator) send(m *message) {
+	m.deliverAt = s.clock.Now().Add(s.cfg.RPCLatency)
+	s.inFlight = append(s.inFlight, m)
+}
+
+// deliverMessages delivers the messages in the order they are sent.
+func (s *Simulator) deliverMessages(now time.Time) {
+	var pending []*message
+	for len(s.inFlight) > 0 {
+		inFlight := s.inFlight
+		s.inFlight = nil
+		for _, m := range inFlight {
+			if m.deliverAt.After(now) {
+				pending = append(pending, m)
+				continue
+			}
+			if m.request != nil {
+				s.handleRequest(m)
+			} else {
+				s.handleStatus(m.from, m.status)
+			}
+		}
+	}
+	s.inFlight = pending
+}
+
+// handleRequest handles the schedule request in the dispatcher manager of the target node.
+func (s *Simulator) handleRequest(m *message) {
+	var target *simNode
+	for _, n := range s.aliveNodes() {
+		if n.id == m.to {
+			target = n
+		}
+	}
+	if target == nil {
+		return
+	}
+	id := common.NewDispatcherIDFromPB(m.request.Config.DispatcherID)
+	status := &heartbeatpb.TableSpanStatus{ID: id.ToPB(), CheckpointTs: 1}
+	switch m.request.ScheduleAction {
+	case heartbeatpb.ScheduleAction_Create:
+		if t, ok := s.tables[id]; ok {
+			target.dispatchers[id] = t
+		}
+		status.ComponentStatus = heartbeatpb.ComponentState_Working
+	case heartbeatpb.ScheduleAction_Remove:
+		delete(target.dispatchers, id)
+		status.ComponentStatus = heartbeatpb.ComponentState_Stopped
+	}
+	// the response is sent after the rpc latency, it's delivered in the next steps.
+	response := &message{from: target.id, status: status}
+	response.deliverAt = s.clock.Now().Add(s.cfg.RPCLatency)
+	s.inFlight = append(s.inFlight, response)
+}
+
+// handleStatus handles the dispatcher status in the maintainer, as what Controller.HandleStatus does.
+func (s *Simulator) handleStatus(from node.ID, status *heartbeatpb.TableSpanStatus) {
+	id := common.NewDispatcherIDFromPB(status.ID)
+	s.oc.UpdateOperatorStatus(id, from, status)
+	span := s.db.GetTaskByID(id)
+	if span == nil || span.GetNodeID() != from {
+		return
+	}
+	s.db.UpdateStatus(span, status)
+}
+
+// replicate advances the traffic model, each node replicates the backlog
+// of its dispatchers up to its capacity.
+func (s *Simulator) replicate(step time.Duration) {
+	seconds := step.Seconds()
+	for _, id := range s.tableIDs {
+		t := s.tables[id]
+		t.backlog += t.traffic * seconds
+	}
+	for _, n := range s.aliveNodes() {
+		// the backlog is summed in the order of the ids, so the float result is reproducible
+		tables := make([]*table, 0, len(n.dispatchers))
+		for _, id := range s.tableIDs {
+			if t, ok := n.dispatchers[id]; ok {
+				tables = append(tables, t)
+			}
+		}
+		demand := 0.0
+		for _, t := range tables {
+			demand += t.backlog
+		}
+		ratio := 1.0
+		if capacity := s.cfg.NodeCapacity * seconds; s.cfg.NodeCapacity > 0 && demand > capacity {
+			ratio = capacity / demand
+		}
+		for _, t := range tables {
+			t.backlog -= t.backlog * ratio
+			n.maxLag = max(n.maxLag, t.lag())
+		}
+	}
+
+	lag := time.Duration(0)
+	for _, t := range s.tables {
+		lag = max(lag, t.lag())
+	}
+	s.stat.maxLag = max(s.stat.maxLag, lag)
+	s.stat.totalLag += lag
+	s.stat.steps++
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateInitialPlacement(t *testing.T) {
+	s, err := New(Config{
+		Workload: Workload{
+			Nodes:        3,
+			Tables:       90,
+			Traffic:      9000,
+			NodeCapacity: 5000,
+		},
+		Duration: time.Minute,
+	})
+	require.NoError(t, err)
+	report := s.Run()
+	require.Equal(t, time.Minute, report.Duration)
+	require.Equal(t, 90, report.AddOperators)
+	require.Zero(t, report.MoveOperators)
+	require.Less(t, report.SettledAt, 5*time.Second)
+	require.Len(t, report.Nodes, 3)
+	for _, n := range report.Nodes {
+		require.True(t, n.Alive)
+		require.Equal(t, 30, n.Tables)
+		require.InDelta(t, 3000, n.Traffic, 0.01)
+		require.InDelta(t, 0.6, n.Utilization, 0.01)
+	}
+	// the lag is caused by the initial scheduling only.
+	require.Less(t, report.MaxLag, 5*time.Second)
+}
+
+func TestSimulateNodeChanges(t *testing.T) {
+	s, err := New(Config{
+		Workload: Workload{
+			Nodes:        2,
+			Tables:       40,
+			Traffic:      4000,
+			Distribution: DistributionZipf,
+			Events: []NodeEvent{
+				{At: 10 * time.Second, Type: NodeAdded},
+				{At: 3 * time.Minute, Type: NodeRemoved, Node: 0},
+			},
+		},
+		Duration:        5 * time.Minute,
+		BalanceInterval: 30 * time.Second,
+		Seed:            1,
+	})
+	require.NoError(t, err)
+	report := s.Run()
+	require.Len(t, report.Nodes, 3)
+	require.False(t, report.Nodes[0].Alive)
+	require.Zero(t, report.Nodes[0].Tables)
+	require.Equal(t, 20, report.Nodes[1].Tables)
+	require.Equal(t, 20, report.Nodes[2].Tables)
+	require.InDelta(t, 4000, report.Nodes[1].Traffic+report.Nodes[2].Traffic, 0.01)
+	// the balance scheduler moves tables to the new node.
+	require.Positive(t, report.MoveOperators)
+	// the tables on the removed node are added again.
+	require.Greater(t, report.AddOperators, 40)
+	require.Greater(t, report.SettledAt, 3*time.Minute)
+	require.Less(t, report.SettledAt, 4*time.Minute)
+	require.NotEmpty(t, report.String())
+}
+
+func TestSimulateReproducible(t *testing.T) {
+	run := func() *Report {
+		s, err := New(Config{
+			Workload: Workload{
+				Nodes:        3,
+				Tables:       100,
+				Traffic:      10000,
+				Distribution: DistributionZipf,
+				Events: []NodeEvent{
+					{At: 10 * time.Second, Type: NodeAdded},
+					{At: time.Minute, Type: NodeRemoved, Node: 1},
+					{At: 2 * time.Minute, Type: NodeAdded},
+				},
+			},
+			Duration:        3 * time.Minute,
+			BalanceInterval: 30 * time.Second,
+			Seed:            42,
+		})
+		require.NoError(t, err)
+		return s.Run()
+	}
+	first := run()
+	require.Positive(t, first.MoveOperators)
+	require.Len(t, first.Operators, first.AddOperators+first.MoveOperators)
+	// the same operators are created at the same time, and the tables end up on the same nodes
+	require.Equal(t, first, run())
+}
+
+func TestInvalidWorkload(t *testing.T) {
+	_, err := New(Config{Workload: Workload{Nodes: 0, Tables: 1}, Duration: time.Second})
+	require.Error(t, err)
+	_, err = New(Config{Workload: Workload{Nodes: 1, Tables: 1, Distribution: "normal"}, Duration: time.Second})
+	require.Error(t, err)
+	_, err = New(Config{Workload: Workload{Nodes: 1, Tables: 1}})
+	require.Error(t, err)
+}
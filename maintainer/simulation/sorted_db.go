@@ -0,0 +1,106 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulation
+
+import (
+	"slices"
+
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	pkgReplica "github.com/pingcap/ticdc/pkg/scheduler/replica"
+)
+
+type scheduleGroup = pkgReplica.ScheduleGroup[common.DispatcherID, *replica.SpanReplication]
+
+// sortedDB returns the tasks of the replication db sorted by the dispatcher id.
+// The replication db keeps the tasks in maps, the schedulers get them in a random
+// order, so the schedulers are given a sortedDB to make the simulation reproducible.
+type sortedDB struct {
+	scheduleGroup
+}
+
+func sortSpans(spans []*replica.SpanReplication) []*replica.SpanReplication {
+	slices.SortFunc(spans, func(a, b *replica.SpanReplication) int {
+		switch {
+		case a.ID.Less(b.ID):
+			return -1
+		case b.ID.Less(a.ID):
+			return 1
+		}
+		return 0
+	})
+	return spans
+}
+
+func (db *sortedDB) GetAbsent() []*replica.SpanReplication {
+	return sortSpans(db.scheduleGroup.GetAbsent())
+}
+
+func (db *sortedDB) GetScheduling() []*replica.SpanReplication {
+	return sortSpans(db.scheduleGroup.GetScheduling())
+}
+
+func (db *sortedDB) GetReplicating() []*replica.SpanReplication {
+	return sortSpans(db.scheduleGroup.GetReplicating())
+}
+
+func (db *sortedDB) GetGroups() []pkgReplica.GroupID {
+	groups := db.scheduleGroup.GetGroups()
+	slices.Sort(groups)
+	return groups
+}
+
+// GetAbsentByGroup returns the first batch absent tasks of the group in the order of the ids.
+func (db *sortedDB) GetAbsentByGroup(id pkgReplica.GroupID, batch int) []*replica.SpanReplication {
+	absent := sortSpans(db.scheduleGroup.GetAbsentByGroup(id, db.scheduleGroup.GetAbsentSize()))
+	if len(absent) > batch {
+		absent = absent[:batch]
+	}
+	return absent
+}
+
+func (db *sortedDB) GetSchedulingByGroup(id pkgReplica.GroupID) []*replica.SpanReplication {
+	return sortSpans(db.scheduleGroup.GetSchedulingByGroup(id))
+}
+
+func (db *sortedDB) GetReplicatingByGroup(id pkgReplica.GroupID) []*replica.SpanReplication {
+	return sortSpans(db.scheduleGroup.GetReplicatingByGroup(id))
+}
+
+func (db *sortedDB) GetTaskByNodeID(id node.ID) []*replica.SpanReplication {
+	return sortSpans(db.scheduleGroup.GetTaskByNodeID(id))
+}
+
+// GetImbalanceGroupNodeTask picks the task with the smallest id as the victim of a node,
+// instead of a random one.
+func (db *sortedDB) GetImbalanceGroupNodeTask(
+	nodes map[node.ID]*node.Info,
+) (map[pkgReplica.GroupID]map[node.ID]*replica.SpanReplication, bool) {
+	groups, valid := db.scheduleGroup.GetImbalanceGroupNodeTask(nodes)
+	for gid, nodeTasks := range groups {
+		first := make(map[node.ID]*replica.SpanReplication, len(nodeTasks))
+		for _, span := range db.GetReplicatingByGroup(gid) {
+			if _, ok := first[span.GetNodeID()]; !ok {
+				first[span.GetNodeID()] = span
+			}
+		}
+		for id, task := range nodeTasks {
+			if task != nil && first[id] != nil {
+				nodeTasks[id] = first[id]
+			}
+		}
+	}
+	return groups, valid
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// Distribution is how the traffic is distributed among the tables.
+type Distribution string
+
+const (
+	// DistributionUniform distributes the traffic evenly among the tables.
+	DistributionUniform Distribution = "uniform"
+	// DistributionZipf distributes the traffic by the zipf's law,
+	// a few hot tables receive most of the traffic.
+	DistributionZipf Distribution = "zipf"
+)
+
+// NodeEventType is the type of the NodeEvent.
+type NodeEventType int
+
+const (
+	// NodeAdded adds a new node to the cluster.
+	NodeAdded NodeEventType = iota
+	// NodeRemoved removes a node from the cluster, the dispatchers on it are lost.
+	NodeRemoved
+)
+
+// NodeEvent changes the nodes of the cluster at the given virtual time.
+type NodeEvent struct {
+	// At is the virtual time since the simulation starts.
+	At   time.Duration
+	Type NodeEventType
+	// Node is the index of the removed node, it's ignored by NodeAdded.
+	// The nodes are indexed in the order they are added, starting from 0.
+	Node int
+}
+
+// Workload is the synthetic workload model of the simulation.
+type Workload struct {
+	// Nodes is the number of nodes when the simulation starts.
+	Nodes int
+	// Tables is the number of tables replicated by the changefeed.
+	Tables int
+	// Traffic is the total rows per second written to all tables.
+	Traffic float64
+	// Distribution is how the traffic is distributed among the tables.
+	Distribution Distribution
+	// ZipfExponent is the exponent of the zipf distribution, the larger it is,
+	// the more skewed the traffic is. It's 1 by default.
+	ZipfExponent float64
+	// NodeCapacity is the rows per second a node can replicate to the downstream,
+	// 0 means the capacity is unlimited.
+	NodeCapacity float64
+	// Events are the node changes during the simulation.
+	Events []NodeEvent
+}
+
+func (w *Workload) validate() error {
+	if w.Nodes <= 0 {
+		return errors.Errorf("the number of nodes must be positive, got %d", w.Nodes)
+	}
+	if w.Tables <= 0 {
+		return errors.Errorf("the number of tables must be positive, got %d", w.Tables)
+	}
+	if w.Traffic < 0 || w.NodeCapacity < 0 {
+		return errors.New("the traffic and the node capacity must not be negative")
+	}
+	switch w.Distribution {
+	case "", DistributionUniform, DistributionZipf:
+	default:
+		return errors.Errorf("unknown traffic distribution %s", w.Distribution)
+	}
+	return nil
+}
+
+// tableTraffic returns the rows per second of each table, the hot tables of
+// the zipf distribution are shuffled by the random source.
+func (w *Workload) tableTraffic(random *rand.Rand) []float64 {
+	weights := make([]float64, w.Tables)
+	total := 0.0
+	for i := range weights {
+		weights[i] = 1
+		if w.Distribution == DistributionZipf {
+			exponent := w.ZipfExponent
+			if exponent <= 0 {
+				exponent = 1
+			}
+			weights[i] = 1 / math.Pow(float64(i+1), exponent)
+		}
+		total += weights[i]
+	}
+	random.Shuffle(len(weights), func(i, j int) {
+		weights[i], weights[j] = weights[j], weights[i]
+	})
+	for i := range weights {
+		weights[i] = weights[i] / total * w.Traffic
+	}
+	return weights
+}
@@ -16,6 +16,7 @@ package split
 import (
 	"bytes"
 	"context"
+	"sync"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
@@ -58,8 +59,15 @@ type splitter interface {
 }
 
 type Splitter struct {
+	// mu protects the thresholds of the splitters from being tuned during splitting.
+	mu           sync.Mutex
 	splitters    []splitter
 	changefeedID common.ChangeFeedID
+
+	writeSplitter       *writeSplitter
+	regionCountSplitter *regionCountSplitter
+	// tuner is nil if the auto tuning of thresholds is disabled.
+	tuner *thresholdTuner
 }
 
 // NewSplitter returns a Splitter.
@@ -68,15 +76,46 @@ func NewSplitter(
 	pdapi pdutil.PDAPIClient,
 	regionCache RegionCache,
 	config *config.ChangefeedSchedulerConfig,
+	memoryQuota uint64,
 ) *Splitter {
-	return &Splitter{
-		changefeedID: changefeedID,
-		splitters: []splitter{
-			// write splitter has the highest priority.
-			newWriteSplitter(changefeedID, pdapi, config.WriteKeyThreshold),
-			newRegionCountSplitter(changefeedID, regionCache, config.RegionThreshold),
-		},
+	s := &Splitter{
+		changefeedID:        changefeedID,
+		writeSplitter:       newWriteSplitter(changefeedID, pdapi, config.WriteKeyThreshold),
+		regionCountSplitter: newRegionCountSplitter(changefeedID, regionCache, config.RegionThreshold),
+	}
+	// write splitter has the highest priority.
+	s.splitters = []splitter{s.writeSplitter, s.regionCountSplitter}
+	if config.EnableAutoTuneThreshold {
+		s.tuner = newThresholdTuner(config.RegionThreshold, config.WriteKeyThreshold, memoryQuota)
+	}
+	return s
+}
+
+// TuneThresholds adjusts the split thresholds by the total event size per
+// second of the changefeed and the alive node count, it's a no-op if the
+// auto tuning is disabled.
+func (s *Splitter) TuneThresholds(eventSizePerSecond float64, nodeCount int) {
+	if s.tuner == nil {
+		return
+	}
+	regionThreshold, writeKeyThreshold := s.tuner.tune(eventSizePerSecond, nodeCount)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if regionThreshold == s.regionCountSplitter.regionThreshold &&
+		writeKeyThreshold == s.writeSplitter.writeKeyThreshold {
+		return
 	}
+	log.Info("tune split thresholds",
+		zap.String("changefeed", s.changefeedID.Name()),
+		zap.Float64("eventSizePerSecond", eventSizePerSecond),
+		zap.Int("nodeCount", nodeCount),
+		zap.Int("oldRegionThreshold", s.regionCountSplitter.regionThreshold),
+		zap.Int("regionThreshold", regionThreshold),
+		zap.Int("oldWriteKeyThreshold", s.writeSplitter.writeKeyThreshold),
+		zap.Int("writeKeyThreshold", writeKeyThreshold))
+	s.regionCountSplitter.regionThreshold = regionThreshold
+	s.writeSplitter.writeKeyThreshold = writeKeyThreshold
 }
 
 func (s *Splitter) SplitSpans(ctx context.Context,
@@ -84,6 +123,9 @@ func (s *Splitter) SplitSpans(ctx context.Context,
 	totalCaptures int,
 	expectedSpanNum int,
 ) []*heartbeatpb.TableSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	spans := []*heartbeatpb.TableSpan{span}
 	for _, sp := range s.splitters {
 		spans = sp.split(ctx, span, totalCaptures, expectedSpanNum)
@@ -94,6 +136,29 @@ func (s *Splitter) SplitSpans(ctx context.Context,
 	return spans
 }
 
+// SplitSpanByKeys splits the span to the subspans by the sorted split keys,
+// the keys out of the range of the span are ignored.
+func SplitSpanByKeys(span *heartbeatpb.TableSpan, keys [][]byte) []*heartbeatpb.TableSpan {
+	spans := make([]*heartbeatpb.TableSpan, 0, len(keys)+1)
+	startKey := span.StartKey
+	for _, key := range keys {
+		if bytes.Compare(key, startKey) <= 0 || bytes.Compare(key, span.EndKey) >= 0 {
+			continue
+		}
+		spans = append(spans, &heartbeatpb.TableSpan{
+			TableID:  span.TableID,
+			StartKey: startKey,
+			EndKey:   key,
+		})
+		startKey = key
+	}
+	return append(spans, &heartbeatpb.TableSpan{
+		TableID:  span.TableID,
+		StartKey: startKey,
+		EndKey:   span.EndKey,
+	})
+}
+
 // FindHoles returns an array of Span that are not covered in the range
 func FindHoles(currentSpan utils.Map[*heartbeatpb.TableSpan, *replica.SpanReplication], totalSpan *heartbeatpb.TableSpan) []*heartbeatpb.TableSpan {
 	lastSpan := &heartbeatpb.TableSpan{
@@ -95,3 +95,16 @@ func TestMapFindHole(t *testing.T) {
 		require.Equalf(t, cs.expectedHole, holes, "case %d, %#v", i, cs)
 	}
 }
+
+func TestSplitSpanByKeys(t *testing.T) {
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("t1_0"), EndKey: []byte("t2_0")}
+	// the keys out of the span are ignored
+	spans := SplitSpanByKeys(span, [][]byte{[]byte("t1_0"), []byte("t1_1"), []byte("t1_2"), []byte("t2_0")})
+	require.Equal(t, []*heartbeatpb.TableSpan{
+		{TableID: 1, StartKey: []byte("t1_0"), EndKey: []byte("t1_1")},
+		{TableID: 1, StartKey: []byte("t1_1"), EndKey: []byte("t1_2")},
+		{TableID: 1, StartKey: []byte("t1_2"), EndKey: []byte("t2_0")},
+	}, spans)
+
+	require.Equal(t, []*heartbeatpb.TableSpan{span}, SplitSpanByKeys(span, nil))
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package split
+
+const (
+	// targetQuotaUsage is the expected ratio of the traffic per second of a node
+	// to the memory quota of the changefeed, the thresholds are kept unchanged at it.
+	targetQuotaUsage = 0.1
+	// minTuneFactor and maxTuneFactor bound the tuned thresholds to
+	// [base/maxTuneFactor, base/minTuneFactor].
+	minTuneFactor = 0.5
+	maxTuneFactor = 2.0
+)
+
+// thresholdTuner tunes the split thresholds by the traffic of the dispatchers
+// and the alive node count. The thresholds are lowered if the nodes are busy
+// relative to the memory quota, so the spans are split smaller and spread to
+// more nodes, and they are raised if the nodes are idle to avoid useless spans.
+type thresholdTuner struct {
+	baseRegionThreshold   int
+	baseWriteKeyThreshold int
+	memoryQuota           uint64
+}
+
+func newThresholdTuner(regionThreshold, writeKeyThreshold int, memoryQuota uint64) *thresholdTuner {
+	return &thresholdTuner{
+		baseRegionThreshold:   regionThreshold,
+		baseWriteKeyThreshold: writeKeyThreshold,
+		memoryQuota:           memoryQuota,
+	}
+}
+
+// tune returns the region threshold and the write key threshold for the
+// total event size per second of the changefeed and the node count.
+func (t *thresholdTuner) tune(eventSizePerSecond float64, nodeCount int) (int, int) {
+	if nodeCount <= 0 || t.memoryQuota == 0 {
+		return t.baseRegionThreshold, t.baseWriteKeyThreshold
+	}
+	usage := eventSizePerSecond / float64(nodeCount) / float64(t.memoryQuota)
+	factor := min(max(usage/targetQuotaUsage, minTuneFactor), maxTuneFactor)
+	return tuneThreshold(t.baseRegionThreshold, factor), tuneThreshold(t.baseWriteKeyThreshold, factor)
+}
+
+func tuneThreshold(base int, factor float64) int {
+	// 0 means the splitter is disabled, keep it.
+	if base == 0 {
+		return 0
+	}
+	return max(int(float64(base)/factor), 1)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package split
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdTuner(t *testing.T) {
+	tuner := newThresholdTuner(100, 1000, 1000)
+
+	// the traffic per node is at the target usage of the quota
+	regionThreshold, writeKeyThreshold := tuner.tune(200, 2)
+	require.Equal(t, 100, regionThreshold)
+	require.Equal(t, 1000, writeKeyThreshold)
+
+	// the nodes are busy, the thresholds are lowered
+	regionThreshold, writeKeyThreshold = tuner.tune(300, 2)
+	require.Equal(t, 66, regionThreshold)
+	require.Equal(t, 666, writeKeyThreshold)
+	regionThreshold, writeKeyThreshold = tuner.tune(100000, 2)
+	require.Equal(t, 50, regionThreshold)
+	require.Equal(t, 500, writeKeyThreshold)
+
+	// more nodes join, the traffic per node drops and the thresholds are raised
+	regionThreshold, writeKeyThreshold = tuner.tune(300, 6)
+	require.Equal(t, 200, regionThreshold)
+	require.Equal(t, 2000, writeKeyThreshold)
+
+	// no node or no quota, use the base thresholds
+	regionThreshold, writeKeyThreshold = tuner.tune(300, 0)
+	require.Equal(t, 100, regionThreshold)
+	require.Equal(t, 1000, writeKeyThreshold)
+
+	// the disabled splitter is kept disabled
+	regionThreshold, writeKeyThreshold = newThresholdTuner(1, 0, 1000).tune(100000, 1)
+	require.Equal(t, 1, regionThreshold)
+	require.Equal(t, 0, writeKeyThreshold)
+}
+
+func TestSplitterTuneThresholds(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	cfg := &config.ChangefeedSchedulerConfig{
+		EnableTableAcrossNodes: true,
+		RegionThreshold:        100,
+		WriteKeyThreshold:      1000,
+	}
+	// auto tuning is disabled
+	s := NewSplitter(cfID, nil, nil, cfg, 1000)
+	s.TuneThresholds(100000, 1)
+	require.Equal(t, 100, s.regionCountSplitter.regionThreshold)
+	require.Equal(t, 1000, s.writeSplitter.writeKeyThreshold)
+
+	cfg.EnableAutoTuneThreshold = true
+	s = NewSplitter(cfID, nil, nil, cfg, 1000)
+	s.TuneThresholds(100000, 1)
+	require.Equal(t, 50, s.regionCountSplitter.regionThreshold)
+	require.Equal(t, 500, s.writeSplitter.writeKeyThreshold)
+	s.TuneThresholds(0, 1)
+	require.Equal(t, 200, s.regionCountSplitter.regionThreshold)
+	require.Equal(t, 2000, s.writeSplitter.writeKeyThreshold)
+}
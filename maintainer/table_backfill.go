@@ -0,0 +1,158 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// tableBackfills are the tables being re-replicated from an older ts. The progress of them is
+// reported to the coordinator and persisted with the changefeed status, since the checkpoint ts
+// of the changefeed doesn't go back for them, the backfills are resumed by the next maintainer
+// after a failover.
+type tableBackfills struct {
+	sync.Mutex
+	tables map[int64]*tableBackfill
+}
+
+type tableBackfill struct {
+	// checkpointTs is the ts the table must be re-replicated from if the backfill is interrupted.
+	checkpointTs uint64
+	// restored is true if the backfill is reported by the previous maintainer, it's not known
+	// whether the dispatchers of the table still carry the progress before it's checked.
+	restored bool
+}
+
+// backfillTable re-replicates all spans of the table from the startTs on the nodes they are running,
+// the other tables of the changefeed are not touched.
+func (c *Controller) backfillTable(tableID int64, startTs uint64) error {
+	if !c.replicationDB.IsTableExists(tableID) {
+		return apperror.ErrTableIsNotFounded.GenWithStackByArgs("tableID", tableID)
+	}
+	replications := c.replicationDB.GetTasksByTableIDs(tableID)
+	for _, replication := range replications {
+		if replication.GetNodeID() == "" || c.operatorController.GetOperator(replication.ID) != nil {
+			return errors.ErrAPIInvalidParam.GenWithStack("table %d is in scheduling, retry later", tableID)
+		}
+		if checkpointTs := replication.GetStatus().CheckpointTs; startTs >= checkpointTs {
+			return errors.ErrAPIInvalidParam.GenWithStack(
+				"start ts %d is not less than the checkpoint ts %d of table %d", startTs, checkpointTs, tableID)
+		}
+	}
+	// record the backfill before the dispatchers are reset, so it's reported no later than them.
+	c.backfills.Lock()
+	if b, ok := c.backfills.tables[tableID]; ok {
+		startTs = min(startTs, b.checkpointTs)
+	}
+	c.backfills.tables[tableID] = &tableBackfill{checkpointTs: startTs}
+	c.backfills.Unlock()
+	for _, replication := range replications {
+		c.operatorController.AddOperator(c.operatorController.NewBackfillOperator(replication, startTs))
+	}
+	log.Info("backfill table",
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Int64("tableID", tableID),
+		zap.Int("spans", len(replications)),
+		zap.Uint64("startTs", startTs))
+	return nil
+}
+
+// restoreBackfills keeps the backfills reported by the previous maintainer,
+// they are checked after the spans of the tables are scheduled.
+func (c *Controller) restoreBackfills(backfills []*heartbeatpb.TableBackfill) {
+	c.backfills.Lock()
+	defer c.backfills.Unlock()
+	for _, b := range backfills {
+		c.backfills.tables[b.TableId] = &tableBackfill{checkpointTs: b.CheckpointTs, restored: true}
+	}
+}
+
+// updateBackfills updates the progress of the backfills and returns them, a backfill is finished
+// once the table catches up with the changefeed. A restored backfill is started again if the
+// dispatchers of the table are created at the checkpoint ts of the changefeed after the failover.
+func (c *Controller) updateBackfills(changefeedCheckpointTs uint64) []*heartbeatpb.TableBackfill {
+	for tableID, ts := range c.checkBackfills(changefeedCheckpointTs) {
+		log.Info("resume the interrupted backfill of the table",
+			zap.String("changefeed", c.changefeedID.Name()),
+			zap.Int64("tableID", tableID),
+			zap.Uint64("checkpointTs", ts))
+		if err := c.backfillTable(tableID, ts); err != nil {
+			log.Warn("resume the backfill of the table failed, retry later",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.Int64("tableID", tableID),
+				zap.Error(err))
+		}
+	}
+
+	c.backfills.Lock()
+	defer c.backfills.Unlock()
+	res := make([]*heartbeatpb.TableBackfill, 0, len(c.backfills.tables))
+	for tableID, b := range c.backfills.tables {
+		res = append(res, &heartbeatpb.TableBackfill{TableId: tableID, CheckpointTs: b.checkpointTs})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].TableId < res[j].TableId })
+	return res
+}
+
+// checkBackfills updates the progress of the backfills by the checkpoint ts of the spans,
+// it returns the restored backfills which need to be started again.
+func (c *Controller) checkBackfills(changefeedCheckpointTs uint64) map[int64]uint64 {
+	c.backfills.Lock()
+	defer c.backfills.Unlock()
+	restart := make(map[int64]uint64)
+	for tableID, b := range c.backfills.tables {
+		if !c.replicationDB.IsTableExists(tableID) {
+			log.Info("the backfilling table is removed",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.Int64("tableID", tableID))
+			delete(c.backfills.tables, tableID)
+			continue
+		}
+		minCheckpointTs, scheduling := uint64(math.MaxUint64), false
+		for _, replication := range c.replicationDB.GetTasksByTableIDs(tableID) {
+			if replication.GetNodeID() == "" || c.operatorController.GetOperator(replication.ID) != nil {
+				scheduling = true
+				break
+			}
+			minCheckpointTs = min(minCheckpointTs, replication.GetStatus().CheckpointTs)
+		}
+		if scheduling {
+			continue
+		}
+		if minCheckpointTs >= changefeedCheckpointTs {
+			if b.restored {
+				// the dispatchers are created at the checkpoint ts of the changefeed
+				restart[tableID] = b.checkpointTs
+				continue
+			}
+			log.Info("the backfilling table caught up with the changefeed",
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.Int64("tableID", tableID),
+				zap.Uint64("checkpointTs", minCheckpointTs))
+			delete(c.backfills.tables, tableID)
+			continue
+		}
+		b.restored = false
+		b.checkpointTs = max(b.checkpointTs, minCheckpointTs)
+	}
+	return restart
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackfillTestController(checkpointTs uint64) (*Controller, *replica.SpanReplication) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    checkpointTs,
+		}, "node1")
+	s := NewController(cfID, checkpointTs, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0, 0)
+	return s, addReplicatingTable(s, 1, checkpointTs)
+}
+
+// addReplicatingTable adds a span covering the whole table which is replicating on node1.
+func addReplicatingTable(s *Controller, tableID int64, checkpointTs uint64) *replica.SpanReplication {
+	sz := spanz.TableIDToComparableSpan(tableID)
+	span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
+	spanReplica := replica.NewReplicaSet(s.changefeedID, common.NewDispatcherID(), s.tsoClient, 1, span, checkpointTs)
+	spanReplica.SetNodeID("node1")
+	s.replicationDB.AddReplicatingSpan(spanReplica)
+	return spanReplica
+}
+
+func TestUpdateBackfills(t *testing.T) {
+	s, spanReplica := newBackfillTestController(80)
+
+	// the backfills reported by the previous maintainer, the dispatcher of table 1
+	// still carries the backfill progress and table 2 is removed
+	s.restoreBackfills([]*heartbeatpb.TableBackfill{
+		{TableId: 1, CheckpointTs: 50},
+		{TableId: 2, CheckpointTs: 60},
+	})
+	require.Equal(t, []*heartbeatpb.TableBackfill{{TableId: 1, CheckpointTs: 80}}, s.updateBackfills(100))
+	require.Nil(t, s.operatorController.GetOperator(spanReplica.ID))
+
+	// the progress never goes back
+	require.Equal(t, []*heartbeatpb.TableBackfill{{TableId: 1, CheckpointTs: 80}}, s.updateBackfills(100))
+
+	// the table catches up with the changefeed
+	spanReplica.UpdateStatus(&heartbeatpb.TableSpanStatus{
+		ID:              spanReplica.ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    100,
+	})
+	require.Empty(t, s.updateBackfills(100))
+}
+
+func TestResumeRestoredBackfill(t *testing.T) {
+	s, spanReplica := newBackfillTestController(100)
+
+	// the dispatcher of table 1 is created at the checkpoint ts of the changefeed,
+	// the backfill is started again from the saved ts
+	s.restoreBackfills([]*heartbeatpb.TableBackfill{{TableId: 1, CheckpointTs: 50}})
+	require.Equal(t, []*heartbeatpb.TableBackfill{{TableId: 1, CheckpointTs: 50}}, s.updateBackfills(100))
+	op := s.operatorController.GetOperator(spanReplica.ID)
+	require.NotNil(t, op)
+	require.Equal(t, "backfill", op.Type())
+
+	// the backfill is kept while the table is in scheduling
+	require.Equal(t, []*heartbeatpb.TableBackfill{{TableId: 1, CheckpointTs: 50}}, s.updateBackfills(100))
+}
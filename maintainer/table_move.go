@@ -0,0 +1,188 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler"
+	"go.uber.org/zap"
+)
+
+// The states of the span moves.
+const (
+	SpanMoveStatePlanned  = "planned"
+	SpanMoveStateMoving   = "moving"
+	SpanMoveStateFinished = "finished"
+	SpanMoveStateFailed   = "failed"
+)
+
+// TableMove moves a table to the target node.
+type TableMove struct {
+	TableID    int64
+	TargetNode node.ID
+}
+
+// SpanMove is the progress of a span moved by the bulk move or the balance triggered on demand.
+type SpanMove struct {
+	DispatcherID common.DispatcherID
+	TableID      int64
+	SourceNode   node.ID
+	TargetNode   node.ID
+	State        string
+}
+
+// MoveTables moves the tables to the target nodes atomically, either all the moves are
+// applied or none of them is, e.g. if any of them is invalid or any table is scheduled
+// by others before the moves are applied.
+func (m *Maintainer) MoveTables(moves []TableMove) ([]SpanMove, error) {
+	return m.controller.moveTables(moves)
+}
+
+// Rebalance runs a round of the balance immediately, the planned moves are returned
+// without being applied if dryRun is true.
+func (m *Maintainer) Rebalance(dryRun bool) ([]SpanMove, error) {
+	return m.controller.rebalance(dryRun)
+}
+
+// GetSpanMoves returns the progress of the last bulk move or balance triggered on demand.
+func (m *Maintainer) GetSpanMoves() []SpanMove {
+	return m.controller.getSpanMoves()
+}
+
+func (c *Controller) moveTables(moves []TableMove) ([]SpanMove, error) {
+	if len(moves) == 0 {
+		return nil, errors.ErrAPIInvalidParam.GenWithStack("no table to move")
+	}
+	aliveNodes := c.nodeManager.GetSchedulableNodes()
+	replications := make([]*replica.SpanReplication, 0, len(moves))
+	seen := make(map[int64]bool, len(moves))
+	for _, move := range moves {
+		if seen[move.TableID] {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack("table %d is moved more than once", move.TableID)
+		}
+		seen[move.TableID] = true
+		if _, ok := aliveNodes[move.TargetNode]; !ok {
+			return nil, apperror.ErrNodeIsNotFound.GenWithStackByArgs("targetNode", move.TargetNode)
+		}
+		if !c.replicationDB.IsTableExists(move.TableID) {
+			return nil, apperror.ErrTableIsNotFounded.GenWithStackByArgs("tableID", move.TableID)
+		}
+		spans := c.replicationDB.GetTasksByTableIDs(move.TableID)
+		if len(spans) != 1 {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack(
+				"table %d is split into %d spans, only the table of one span can be moved", move.TableID, len(spans))
+		}
+		span := spans[0]
+		if span.GetNodeID() == "" || c.operatorController.GetOperator(span.ID) != nil {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack("table %d is in scheduling, retry later", move.TableID)
+		}
+		if span.GetNodeID() == move.TargetNode {
+			return nil, errors.ErrAPIInvalidParam.GenWithStack(
+				"table %d is already on node %s", move.TableID, move.TargetNode)
+		}
+		replications = append(replications, span)
+	}
+
+	res := make([]SpanMove, 0, len(moves))
+	ops := make([]*operator.MoveDispatcherOperator, 0, len(moves))
+	for i, span := range replications {
+		move := SpanMove{
+			DispatcherID: span.ID,
+			TableID:      span.Span.TableID,
+			SourceNode:   span.GetNodeID(),
+			TargetNode:   moves[i].TargetNode,
+			State:        SpanMoveStateMoving,
+		}
+		ops = append(ops, operator.NewMoveDispatcherOperator(c.replicationDB, span, move.SourceNode, move.TargetNode))
+		res = append(res, move)
+	}
+	// the spans may be scheduled by the schedulers after the validation,
+	// the operators are added under the lock of the operator controller.
+	if !c.operatorController.AddOperators(ops...) {
+		return nil, errors.ErrAPIInvalidParam.GenWithStack("some tables are in scheduling, retry later")
+	}
+	log.Info("tables are moved in bulk",
+		zap.String("changefeed", c.changefeedID.Name()), zap.Int("count", len(res)))
+	c.setSpanMoves(res)
+	return res, nil
+}
+
+func (c *Controller) rebalance(dryRun bool) ([]SpanMove, error) {
+	if c.operatorController.OperatorSize() > 0 || c.replicationDB.GetAbsentSize() > 0 {
+		return nil, errors.ErrAPIInvalidParam.GenWithStack("the changefeed is in scheduling, retry later")
+	}
+	rebalancer, ok := c.schedulerController.GetScheduler(scheduler.BalanceScheduler).(scheduler.Rebalancer[*replica.SpanReplication])
+	if !ok {
+		return nil, errors.ErrAPIInvalidParam.GenWithStack(
+			"the balance on demand is not supported by the placement strategy of the changefeed")
+	}
+	planned := rebalancer.Rebalance(dryRun)
+	state := SpanMoveStateMoving
+	if dryRun {
+		state = SpanMoveStatePlanned
+	}
+	res := make([]SpanMove, 0, len(planned))
+	for _, move := range planned {
+		res = append(res, SpanMove{
+			DispatcherID: move.Task.ID,
+			TableID:      move.Task.Span.TableID,
+			SourceNode:   move.Source,
+			TargetNode:   move.Target,
+			State:        state,
+		})
+	}
+	if !dryRun {
+		c.setSpanMoves(res)
+	}
+	return res, nil
+}
+
+func (c *Controller) setSpanMoves(moves []SpanMove) {
+	c.spanMoves.Lock()
+	defer c.spanMoves.Unlock()
+	c.spanMoves.moves = moves
+}
+
+func (c *Controller) getSpanMoves() []SpanMove {
+	c.spanMoves.Lock()
+	defer c.spanMoves.Unlock()
+	res := make([]SpanMove, 0, len(c.spanMoves.moves))
+	for i := range c.spanMoves.moves {
+		move := &c.spanMoves.moves[i]
+		if move.State == SpanMoveStateMoving {
+			move.State = c.spanMoveState(move)
+		}
+		res = append(res, *move)
+	}
+	return res
+}
+
+// spanMoveState returns the state of the move which is not finished yet when it's last checked.
+func (c *Controller) spanMoveState(move *SpanMove) string {
+	if op := c.operatorController.GetOperator(move.DispatcherID); op != nil && !op.IsFinished() {
+		return SpanMoveStateMoving
+	}
+	span := c.replicationDB.GetTaskByID(move.DispatcherID)
+	if span == nil || span.GetNodeID() != move.TargetNode {
+		// the span is removed, or it's moved back since the target node failed
+		return SpanMoveStateFailed
+	}
+	return SpanMoveStateFinished
+}
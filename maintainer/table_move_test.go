@@ -0,0 +1,88 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveTables(t *testing.T) {
+	s, _ := newBackfillTestController(1)
+	for i := 2; i <= 10; i++ {
+		addReplicatingTable(s, int64(i), 1)
+	}
+	nodes := s.nodeManager.GetAliveNodes()
+	nodes["node2"] = &node.Info{ID: "node2"}
+
+	// none of the moves is applied if any of them is invalid
+	_, err := s.moveTables([]TableMove{{TableID: 1, TargetNode: "node2"}, {TableID: 2, TargetNode: "node3"}})
+	require.Error(t, err)
+	_, err = s.moveTables([]TableMove{{TableID: 1, TargetNode: "node2"}, {TableID: 100, TargetNode: "node2"}})
+	require.Error(t, err)
+	_, err = s.moveTables([]TableMove{{TableID: 1, TargetNode: "node2"}, {TableID: 1, TargetNode: "node2"}})
+	require.Error(t, err)
+	_, err = s.moveTables([]TableMove{{TableID: 1, TargetNode: "node1"}})
+	require.Error(t, err)
+	require.Equal(t, 0, s.operatorController.OperatorSize())
+	require.Empty(t, s.getSpanMoves())
+
+	moves, err := s.moveTables([]TableMove{{TableID: 1, TargetNode: "node2"}, {TableID: 2, TargetNode: "node2"}})
+	require.NoError(t, err)
+	require.Len(t, moves, 2)
+	require.Equal(t, 2, s.operatorController.OperatorSize())
+	for _, move := range s.getSpanMoves() {
+		require.Equal(t, node.ID("node1"), move.SourceNode)
+		require.Equal(t, node.ID("node2"), move.TargetNode)
+		require.Equal(t, SpanMoveStateMoving, move.State)
+	}
+	// the tables being moved can't be moved again
+	_, err = s.moveTables([]TableMove{{TableID: 1, TargetNode: "node2"}})
+	require.Error(t, err)
+}
+
+func TestRebalance(t *testing.T) {
+	s, _ := newBackfillTestController(1)
+	for i := 2; i <= 10; i++ {
+		addReplicatingTable(s, int64(i), 1)
+	}
+	nodes := s.nodeManager.GetAliveNodes()
+	moves, err := s.rebalance(true)
+	require.NoError(t, err)
+	require.Empty(t, moves)
+
+	nodes["node2"] = &node.Info{ID: "node2"}
+	moves, err = s.rebalance(true)
+	require.NoError(t, err)
+	require.Len(t, moves, 5)
+	for _, move := range moves {
+		require.Equal(t, SpanMoveStatePlanned, move.State)
+		require.Equal(t, node.ID("node2"), move.TargetNode)
+	}
+	// the planned moves are not applied
+	require.Equal(t, 0, s.operatorController.OperatorSize())
+	require.Empty(t, s.getSpanMoves())
+
+	moves, err = s.rebalance(false)
+	require.NoError(t, err)
+	require.Len(t, moves, 5)
+	require.Equal(t, 5, s.operatorController.OperatorSize())
+	require.Len(t, s.getSpanMoves(), 5)
+
+	// the changefeed is in scheduling
+	_, err = s.rebalance(true)
+	require.Error(t, err)
+}
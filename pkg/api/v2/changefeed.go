@@ -40,6 +40,12 @@ type ChangefeedInterface interface {
 		namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// Resume resumes a changefeed with given config
 	Resume(ctx context.Context, cfg *v2.ResumeChangefeedConfig, namespace string, name string) error
+	// FinishImport resumes a changefeed paused for import from the import finish ts
+	FinishImport(ctx context.Context, cfg *v2.ImportFinishConfig, namespace string, name string) error
+	// Export exports the definition and the checkpoint of a paused changefeed
+	Export(ctx context.Context, namespace string, name string) (*v2.ChangefeedExport, error)
+	// Import creates a changefeed exported from another cluster
+	Import(ctx context.Context, export *v2.ChangefeedExport) (*v2.ChangeFeedInfo, error)
 	// Delete deletes a changefeed by name
 	Delete(ctx context.Context, namespace string, name string) error
 	// Pause pauses a changefeed with given name
@@ -111,6 +117,43 @@ func (c *changefeeds) Resume(ctx context.Context,
 		Do(ctx).Error()
 }
 
+// FinishImport resumes a changefeed paused for import
+func (c *changefeeds) FinishImport(ctx context.Context,
+	cfg *v2.ImportFinishConfig, namespace string, name string,
+) error {
+	u := fmt.Sprintf("changefeeds/%s/import_finish?namespace=%s", name, namespace)
+	return c.client.Post().
+		WithURI(u).
+		WithBody(cfg).
+		Do(ctx).Error()
+}
+
+// Export a changefeed
+func (c *changefeeds) Export(ctx context.Context,
+	namespace string, name string,
+) (*v2.ChangefeedExport, error) {
+	result := &v2.ChangefeedExport{}
+	u := fmt.Sprintf("changefeeds/%s/export?namespace=%s", name, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Import a changefeed
+func (c *changefeeds) Import(ctx context.Context,
+	export *v2.ChangefeedExport,
+) (*v2.ChangeFeedInfo, error) {
+	result := &v2.ChangeFeedInfo{}
+	err := c.client.Post().
+		WithURI("changefeed_import").
+		WithBody(export).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
 // Delete a changefeed
 func (c *changefeeds) Delete(ctx context.Context,
 	namespace string, name string,
@@ -0,0 +1,179 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apperror
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pingcap/errors"
+	cerrors "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// ErrorClass is the class of the error reported by a changefeed.
+// It's exposed by the open api, so the automation tools can decide
+// how to deal with the changefeed based on it.
+type ErrorClass string
+
+const (
+	// ErrorClassUnknown is used when the error can not be classified.
+	ErrorClassUnknown ErrorClass = "unknown"
+	// ErrorClassScheduling is used for the errors of the coordinator, maintainer and dispatcher scheduling.
+	ErrorClassScheduling ErrorClass = "scheduling"
+	// ErrorClassCodec is used for the errors when encoding or decoding the events.
+	ErrorClassCodec ErrorClass = "codec"
+	// ErrorClassSink is used for the errors when writing to the downstream.
+	ErrorClassSink ErrorClass = "sink"
+	// ErrorClassPuller is used for the errors when pulling data from the upstream.
+	ErrorClassPuller ErrorClass = "puller"
+)
+
+// errorClassByCode is used to classify the errors whose class can not be
+// inferred from the prefix of the error code.
+var errorClassByCode = map[errors.RFCErrorCode]ErrorClass{
+	ErrChangefeedInitTableTriggerEventDispatcherFailed.RFCCode(): ErrorClassScheduling,
+	ErrTableIsNotFounded.RFCCode():                               ErrorClassScheduling,
+	ErrMaintainerNotFounded.RFCCode():                            ErrorClassScheduling,
+	ErrMoveTableTimeout.RFCCode():                                ErrorClassScheduling,
+	ErrNodeIsNotFound.RFCCode():                                  ErrorClassScheduling,
+	cerrors.ErrDispatcherFailed.RFCCode():                        ErrorClassScheduling,
+	cerrors.ErrOwnerUnknown.RFCCode():                            ErrorClassScheduling,
+	cerrors.ErrNotOwner.RFCCode():                                ErrorClassScheduling,
+	cerrors.ErrOwnerNotFound.RFCCode():                           ErrorClassScheduling,
+	cerrors.ErrSyncRenameTableFailed.RFCCode():                   ErrorClassScheduling,
+	cerrors.ErrInvalidBootstrapStartTs.RFCCode():                 ErrorClassScheduling,
+	cerrors.ErrSchemaStoreUnavailable.RFCCode():                  ErrorClassScheduling,
+
+	cerrors.ErrMarshalFailed.RFCCode():            ErrorClassCodec,
+	cerrors.ErrUnmarshalFailed.RFCCode():          ErrorClassCodec,
+	cerrors.ErrDatumUnflatten.RFCCode():           ErrorClassCodec,
+	cerrors.ErrMessageTooLarge.RFCCode():          ErrorClassCodec,
+	cerrors.ErrColumnSelectorFailed.RFCCode():     ErrorClassCodec,
+	cerrors.ErrIncompatibleSinkConfig.RFCCode():   ErrorClassSink,
+	cerrors.ErrCorruptedDataMutation.RFCCode():    ErrorClassSink,
+	cerrors.ErrSchemaSnapshotNotFound.RFCCode():   ErrorClassPuller,
+	cerrors.ErrSnapshotLostByGC.RFCCode():         ErrorClassPuller,
+	cerrors.ErrStartTsBeforeGC.RFCCode():          ErrorClassPuller,
+	cerrors.ErrGCTTLExceeded.RFCCode():            ErrorClassPuller,
+	cerrors.ErrDDLSchemaNotFound.RFCCode():        ErrorClassPuller,
+	cerrors.ErrFailedToFilterDML.RFCCode():        ErrorClassPuller,
+	cerrors.ErrExpressionColumnNotFound.RFCCode(): ErrorClassPuller,
+}
+
+// errorClassByPrefix classifies the errors by the prefix of the error code,
+// the prefixes are checked in order.
+var errorClassByPrefix = []struct {
+	prefix string
+	class  ErrorClass
+}{
+	{prefix: "CDC:ErrAvro", class: ErrorClassCodec},
+	{prefix: "CDC:ErrCanal", class: ErrorClassCodec},
+	{prefix: "CDC:ErrCodec", class: ErrorClassCodec},
+	{prefix: "CDC:ErrCraft", class: ErrorClassCodec},
+	{prefix: "CDC:ErrDebezium", class: ErrorClassCodec},
+	{prefix: "CDC:ErrDecode", class: ErrorClassCodec},
+	{prefix: "CDC:ErrEncode", class: ErrorClassCodec},
+	{prefix: "CDC:ErrMaxwell", class: ErrorClassCodec},
+	{prefix: "CDC:ErrOpenProtocol", class: ErrorClassCodec},
+	{prefix: "CDC:ErrSimple", class: ErrorClassCodec},
+
+	{prefix: "CDC:ErrKafka", class: ErrorClassSink},
+	{prefix: "CDC:ErrMySQL", class: ErrorClassSink},
+	{prefix: "CDC:ErrPulsar", class: ErrorClassSink},
+	{prefix: "CDC:ErrSink", class: ErrorClassSink},
+	{prefix: "CDC:ErrStorage", class: ErrorClassSink},
+
+	{prefix: "CDC:ErrEventFeed", class: ErrorClassPuller},
+	{prefix: "CDC:ErrGetTiKVRPCContext", class: ErrorClassPuller},
+	{prefix: "CDC:ErrKV", class: ErrorClassPuller},
+	{prefix: "CDC:ErrPuller", class: ErrorClassPuller},
+	{prefix: "CDC:ErrRegion", class: ErrorClassPuller},
+	{prefix: "CDC:ErrResolveLocks", class: ErrorClassPuller},
+
+	{prefix: "CDC:ErrCapture", class: ErrorClassScheduling},
+	{prefix: "CDC:ErrMaintainer", class: ErrorClassScheduling},
+	{prefix: "CDC:ErrOwner", class: ErrorClassScheduling},
+	{prefix: "CDC:ErrScheduler", class: ErrorClassScheduling},
+}
+
+// rfcCodePattern matches the RFC error codes in the error message,
+// e.g. "[CDC:ErrKafkaSendMessage]kafka send message failed".
+var rfcCodePattern = regexp.MustCompile(`CDC:Err[A-Za-z0-9]+`)
+
+// ClassifyErrorCode returns the class of the given RFC error code.
+func ClassifyErrorCode(code errors.RFCErrorCode) ErrorClass {
+	if class, ok := errorClassByCode[code]; ok {
+		return class
+	}
+	for _, c := range errorClassByPrefix {
+		if strings.HasPrefix(string(code), c.prefix) {
+			return c.class
+		}
+	}
+	return ErrorClassUnknown
+}
+
+// ErrorDetail is the structured information of a changefeed running error.
+type ErrorDetail struct {
+	// Code is the most specific RFC error code of the error.
+	Code string
+	// Class is the class of the error.
+	Class ErrorClass
+	// Retryable is false if the changefeed will not be restarted because of the error,
+	// it's derived from the retry class of the error.
+	Retryable bool
+}
+
+// NewErrorDetail builds the ErrorDetail from the code and the message of a running error.
+// The dispatchers only report ErrChangefeedRetryable or ErrChangefeedUnretryable as the code,
+// so the original error code is extracted from the message if it's available.
+func NewErrorDetail(code string, message string) ErrorDetail {
+	detail := ErrorDetail{
+		Code:  code,
+		Class: ErrorClassUnknown,
+	}
+	// prefer the first code which can be classified, otherwise the first specific code.
+	specific := false
+	codes := append([]string{code}, rfcCodePattern.FindAllString(message, -1)...)
+	for _, c := range codes {
+		if isGenericErrorCode(c) {
+			continue
+		}
+		if class := ClassifyErrorCode(errors.RFCErrorCode(c)); class != ErrorClassUnknown {
+			detail.Code = c
+			detail.Class = class
+			break
+		}
+		if !specific {
+			detail.Code = c
+			specific = true
+		}
+	}
+
+	detail.Retryable = cerrors.RetryClassOfCode(errors.RFCErrorCode(code), message) != cerrors.RetryClassFatal
+	return detail
+}
+
+// isGenericErrorCode returns true if the code does not indicate where the error comes from.
+func isGenericErrorCode(code string) bool {
+	switch errors.RFCErrorCode(code) {
+	case "",
+		ErrChangefeedRetryable.RFCCode(),
+		cerrors.ErrChangefeedUnretryable.RFCCode(),
+		cerrors.ErrUnexpected.RFCCode(),
+		cerrors.ErrInternalServerError.RFCCode():
+		return true
+	}
+	return false
+}
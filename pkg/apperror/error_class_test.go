@@ -0,0 +1,68 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apperror
+
+import (
+	"testing"
+
+	"github.com/pingcap/errors"
+	cerrors "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyErrorCode(t *testing.T) {
+	cases := []struct {
+		code  errors.RFCErrorCode
+		class ErrorClass
+	}{
+		{cerrors.ErrKafkaSendMessage.RFCCode(), ErrorClassSink},
+		{cerrors.ErrMySQLTxnError.RFCCode(), ErrorClassSink},
+		{cerrors.ErrCanalEncodeFailed.RFCCode(), ErrorClassCodec},
+		{cerrors.ErrMessageTooLarge.RFCCode(), ErrorClassCodec},
+		{cerrors.ErrSnapshotLostByGC.RFCCode(), ErrorClassPuller},
+		{"CDC:ErrPullerEventFeed", ErrorClassPuller},
+		{ErrMoveTableTimeout.RFCCode(), ErrorClassScheduling},
+		{cerrors.ErrOwnerUnknown.RFCCode(), ErrorClassScheduling},
+		{cerrors.ErrEtcdAPIError.RFCCode(), ErrorClassUnknown},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.class, ClassifyErrorCode(c.code), c.code)
+	}
+}
+
+func TestNewErrorDetail(t *testing.T) {
+	// the dispatcher reports a generic code, the original code is in the message.
+	err := cerrors.ErrKafkaSendMessage.GenWithStackByArgs()
+	detail := NewErrorDetail(string(ErrChangefeedRetryable.RFCCode()), err.Error())
+	require.Equal(t, string(cerrors.ErrKafkaSendMessage.RFCCode()), detail.Code)
+	require.Equal(t, ErrorClassSink, detail.Class)
+	require.True(t, detail.Retryable)
+
+	err = cerrors.ErrSinkURIInvalid.GenWithStackByArgs()
+	detail = NewErrorDetail(string(ErrorCode(err)), err.Error())
+	require.Equal(t, string(cerrors.ErrSinkURIInvalid.RFCCode()), detail.Code)
+	require.Equal(t, ErrorClassSink, detail.Class)
+	require.False(t, detail.Retryable)
+
+	// gc errors are never retried.
+	detail = NewErrorDetail(string(cerrors.ErrGCTTLExceeded.RFCCode()), "gc ttl exceeded")
+	require.Equal(t, ErrorClassPuller, detail.Class)
+	require.False(t, detail.Retryable)
+
+	// the code is kept if the error can not be classified.
+	detail = NewErrorDetail(string(cerrors.ErrEtcdAPIError.RFCCode()), "etcd api call error")
+	require.Equal(t, string(cerrors.ErrEtcdAPIError.RFCCode()), detail.Code)
+	require.Equal(t, ErrorClassUnknown, detail.Class)
+	require.True(t, detail.Retryable)
+}
@@ -77,6 +77,43 @@ func IsRetryableDMLError(err error) bool {
 	return dbutil.IsRetryableError(err)
 }
 
+// IsUnprocessableDMLError checks if the error is caused by the data of the rows,
+// such rows can never be applied to the downstream no matter how many times it retries.
+func IsUnprocessableDMLError(err error) bool {
+	err = errors.Cause(err)
+	mysqlErr, ok := err.(*gmysql.MySQLError)
+	if !ok {
+		return false
+	}
+	switch mysqlErr.Number {
+	case mysql.ErrDataTooLong,
+		mysql.ErrDupEntry,
+		mysql.ErrNoReferencedRow2,
+		mysql.ErrRowIsReferenced2,
+		mysql.ErrBadNull,
+		mysql.ErrWarnDataOutOfRange,
+		mysql.ErrTruncatedWrongValue,
+		mysql.ErrTruncatedWrongValueForField:
+		return true
+	}
+	return false
+}
+
+// ClassifyDDLError returns the class of the error met by executing a ddl in the downstream.
+func ClassifyDDLError(err error) cerror.RetryClass {
+	if !IsRetryableDDLError(err) {
+		return cerror.RetryClassFatal
+	}
+	return cerror.RetryClassOf(err)
+}
+
+// IsDupEntryError checks if the error is caused by a duplicated key, it happens if
+// the row has been applied before and is replayed without the safe mode.
+func IsDupEntryError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*gmysql.MySQLError)
+	return ok && mysqlErr.Number == mysql.ErrDupEntry
+}
+
 // IsRetryableDDLError check if the error is a retryable ddl error.
 func IsRetryableDDLError(err error) bool {
 	if IsRetryableDMLError(err) {
@@ -14,6 +14,7 @@
 package bootstrap
 
 import (
+	"sort"
 	"time"
 
 	"github.com/pingcap/log"
@@ -35,20 +36,30 @@ type Bootstrapper[T any] struct {
 	nodes           map[node.ID]*NodeStatus[T]
 	newBootstrapMsg NewBootstrapMessageFn
 
+	// startTime is when the bootstrapper is created, it's used to check the bootstrap timeout
+	startTime time.Time
+	// lastProgressTime is the last time the bootstrap progress is logged
+	lastProgressTime time.Time
+
 	// for ut test
-	timeNowFunc    func() time.Time
-	resendInterval time.Duration
+	timeNowFunc      func() time.Time
+	resendInterval   time.Duration
+	progressInterval time.Duration
 }
 
 // NewBootstrapper create a new bootstrap for a changefeed maintainer
 func NewBootstrapper[T any](id string, newBootstrapMsg NewBootstrapMessageFn) *Bootstrapper[T] {
+	now := time.Now()
 	return &Bootstrapper[T]{
-		id:              id,
-		nodes:           make(map[node.ID]*NodeStatus[T]),
-		bootstrapped:    false,
-		newBootstrapMsg: newBootstrapMsg,
-		timeNowFunc:     time.Now,
-		resendInterval:  time.Millisecond * 500,
+		id:               id,
+		nodes:            make(map[node.ID]*NodeStatus[T]),
+		bootstrapped:     false,
+		newBootstrapMsg:  newBootstrapMsg,
+		startTime:        now,
+		lastProgressTime: now,
+		timeNowFunc:      time.Now,
+		resendInterval:   time.Millisecond * 500,
+		progressInterval: time.Second * 10,
 	}
 }
 
@@ -109,13 +120,15 @@ func (b *Bootstrapper[T]) HandleRemoveNodes(nodeIDs []node.ID) map[node.ID]*T {
 	return b.firstBootstrap()
 }
 
-// ResendBootstrapMessage return rpc message that need to be resent
+// ResendBootstrapMessage return rpc message that need to be resent,
+// the skipped nodes are also resent until they report the bootstrap response.
 func (b *Bootstrapper[T]) ResendBootstrapMessage() []*messaging.TargetMessage {
 	var msgs []*messaging.TargetMessage
-	if !b.CheckAllNodeInitialized() {
+	if !b.CheckAllNodeInitialized() || b.hasSkippedNodes() {
 		now := b.timeNowFunc()
+		b.logProgress(now)
 		for id, status := range b.nodes {
-			if status.state == NodeStateUninitialized &&
+			if status.state != NodeStateInitialized &&
 				now.Sub(status.lastBootstrapTime) >= b.resendInterval {
 				msgs = append(msgs, b.newBootstrapMsg(id))
 				status.lastBootstrapTime = now
@@ -125,6 +138,78 @@ func (b *Bootstrapper[T]) ResendBootstrapMessage() []*messaging.TargetMessage {
 	return msgs
 }
 
+// Elapsed returns the duration since the bootstrapper is created.
+func (b *Bootstrapper[T]) Elapsed() time.Duration {
+	return b.timeNowFunc().Sub(b.startTime)
+}
+
+// GetUninitializedNodes returns the nodes which don't report the bootstrap response yet.
+func (b *Bootstrapper[T]) GetUninitializedNodes() []node.ID {
+	var ids []node.ID
+	for id, status := range b.nodes {
+		if status.state == NodeStateUninitialized {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// SkipNodes marks the uninitialized nodes as skipped, the bootstrap proceeds without
+// their bootstrap responses, so their dispatchers are unknown and the spans are absent.
+// return cached bootstrap response if all node are initialized or skipped,
+// the skipped nodes are not in the returned map.
+func (b *Bootstrapper[T]) SkipNodes(nodeIDs []node.ID) map[node.ID]*T {
+	for _, id := range nodeIDs {
+		status, ok := b.nodes[id]
+		if !ok || status.state != NodeStateUninitialized {
+			continue
+		}
+		status.state = NodeStateSkipped
+		log.Warn("skip the node in bootstrap",
+			zap.String("changefeed", b.id),
+			zap.String("captureAddr", status.node.AdvertiseAddr),
+			zap.Any("id", id),
+			zap.Duration("elapsed", b.Elapsed()))
+	}
+	return b.firstBootstrap()
+}
+
+func (b *Bootstrapper[T]) hasSkippedNodes() bool {
+	for _, status := range b.nodes {
+		if status.state == NodeStateSkipped {
+			return true
+		}
+	}
+	return false
+}
+
+// logProgress logs the nodes which don't report the bootstrap response periodically,
+// so a slow node blocking the bootstrap can be found.
+func (b *Bootstrapper[T]) logProgress(now time.Time) {
+	if now.Sub(b.lastProgressTime) < b.progressInterval {
+		return
+	}
+	b.lastProgressTime = now
+	var initialized, skipped []node.ID
+	for id, status := range b.nodes {
+		switch status.state {
+		case NodeStateInitialized:
+			initialized = append(initialized, id)
+		case NodeStateSkipped:
+			skipped = append(skipped, id)
+		}
+	}
+	log.Info("bootstrap is in progress",
+		zap.String("changefeed", b.id),
+		zap.Bool("bootstrapped", b.bootstrapped),
+		zap.Int("total", len(b.nodes)),
+		zap.Any("initialized", initialized),
+		zap.Any("skipped", skipped),
+		zap.Any("missing", b.GetUninitializedNodes()),
+		zap.Duration("elapsed", now.Sub(b.startTime)))
+}
+
 // GetAllNodes return all nodes the tracked by bootstrapper, the returned value must not be modified
 func (b *Bootstrapper[T]) GetAllNodes() map[node.ID]*NodeStatus[T] {
 	return b.nodes
@@ -159,6 +244,9 @@ func (b *Bootstrapper[T]) firstBootstrap() map[node.ID]*T {
 		b.bootstrapped = true
 		allCachedResp := make(map[node.ID]*T, len(b.nodes))
 		for _, status := range b.nodes {
+			if status.state == NodeStateSkipped {
+				continue
+			}
 			allCachedResp[status.node.ID] = status.cachedBootstrapResp
 			// clear the cached data
 			status.cachedBootstrapResp = nil
@@ -176,6 +264,9 @@ const (
 	NodeStateUninitialized NodeState = 1
 	// NodeStateInitialized means controller has received bootstrap response.
 	NodeStateInitialized NodeState = 2
+	// NodeStateSkipped means the bootstrap proceeds without the bootstrap response
+	// of the node, since it doesn't respond in time.
+	NodeStateSkipped NodeState = 3
 )
 
 func NewNodeStatus[T any](node *node.Info) *NodeStatus[T] {
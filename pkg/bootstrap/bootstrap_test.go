@@ -150,3 +150,34 @@ func TestGetAllNodes(t *testing.T) {
 	delete(nodes, "ab")
 	require.Equal(t, 1, len(nodes))
 }
+
+func TestSkipNodes(t *testing.T) {
+	b := NewBootstrapper[heartbeatpb.MaintainerBootstrapResponse]("test", func(id node.ID) *messaging.TargetMessage {
+		return &messaging.TargetMessage{
+			To: id,
+		}
+	})
+	b.HandleNewNodes([]*node.Info{{ID: "ab"}, {ID: "cd"}, {ID: "ef"}})
+	cached := b.HandleBootstrapResponse("ab", &heartbeatpb.MaintainerBootstrapResponse{
+		Spans: []*heartbeatpb.BootstrapTableSpan{{}},
+	})
+	require.Nil(t, cached)
+	require.Equal(t, []node.ID{"cd", "ef"}, b.GetUninitializedNodes())
+
+	// the skipped nodes are not in the cached responses
+	cached = b.SkipNodes([]node.ID{"ab", "cd", "ef"})
+	require.Len(t, cached, 1)
+	require.Len(t, cached["ab"].Spans, 1)
+	require.True(t, b.CheckAllNodeInitialized())
+	require.Empty(t, b.GetUninitializedNodes())
+
+	// the skipped nodes are resent until they respond
+	b.timeNowFunc = func() time.Time { return time.Now().Add(time.Second) }
+	msgs := b.ResendBootstrapMessage()
+	require.Len(t, msgs, 2)
+	require.Nil(t, b.HandleBootstrapResponse("cd", &heartbeatpb.MaintainerBootstrapResponse{}))
+	require.Nil(t, b.HandleBootstrapResponse("ef", &heartbeatpb.MaintainerBootstrapResponse{}))
+	b.timeNowFunc = func() time.Time { return time.Now().Add(time.Minute) }
+	require.Empty(t, b.ResendBootstrapMessage())
+	require.Equal(t, time.Minute, b.Elapsed().Round(time.Minute))
+}
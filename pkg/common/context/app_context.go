@@ -34,6 +34,8 @@ const (
 	DispatcherDynamicStream = "DispatcherDynamicStream"
 	MaintainerManager       = "MaintainerManager"
 	DispatcherOrchestrator  = "DispatcherOrchestrator"
+	UpstreamManager         = "UpstreamManager"
+	UpstreamLogService      = "UpstreamLogService"
 	DefaultPDClock          = "PDClock-0"
 )
 
@@ -60,3 +62,14 @@ func GetService[T any](name string) T {
 	v, _ := GetGlobalContext().serviceMap.Load(name)
 	return v.(T)
 }
+
+// TryGetService returns the service and true if it's registered.
+func TryGetService[T any](name string) (T, bool) {
+	v, ok := GetGlobalContext().serviceMap.Load(name)
+	if !ok {
+		var t T
+		return t, false
+	}
+	t, ok := v.(T)
+	return t, ok
+}
@@ -74,6 +74,20 @@ func (m *mounter) rawKVToChunkV2(value []byte, tableInfo *common.TableInfo, chk
 	return nil
 }
 
+// emptyRowV2 is a row of the new format without any column.
+var emptyRowV2 = []byte{rowcodec.CodecVer, 0, 0, 0, 0, 0}
+
+// handleToChunk appends a row which only has the handle key columns decoded from the handle.
+func (m *mounter) handleToChunk(tableInfo *common.TableInfo, chk *chunk.Chunk, handle kv.Handle) error {
+	handleColIDs, _, reqCols := tableInfo.GetRowColInfos()
+	appendNull := func(i int, chk *chunk.Chunk) error {
+		chk.AppendNull(i)
+		return nil
+	}
+	decoder := rowcodec.NewChunkDecoder(reqCols, handleColIDs, appendNull, m.tz)
+	return errors.Trace(decoder.DecodeToChunk(emptyRowV2, handle, chk))
+}
+
 // rawKVToChunkV1 is used to decode the old format of row data.
 func (m *mounter) rawKVToChunkV1(value []byte, tableInfo *common.TableInfo, chk *chunk.Chunk, handle kv.Handle) error {
 	if len(value) == 0 {
@@ -16,6 +16,7 @@ package event
 import (
 	"encoding/binary"
 	"encoding/json"
+	"math"
 	"strings"
 
 	"github.com/pingcap/log"
@@ -28,6 +29,10 @@ const (
 	DDLEventVersion = 0
 )
 
+// ActionImportInto is the type of the ingest events built from the finished IMPORT INTO jobs.
+// It's not a ddl type of TiDB, so it's placed out of the range of the TiDB ddl types.
+const ActionImportInto = model.ActionType(math.MaxUint8)
+
 type DDLEvent struct {
 	// Version is the version of the DDLEvent struct.
 	Version      byte                `json:"version"`
@@ -71,6 +76,10 @@ type DDLEvent struct {
 	TableNameChange *TableNameChange `json:"table_name_change"`
 
 	TiDBOnly bool `json:"tidb_only"`
+	// IsIngest is set if the event marks the data ingested into TiKV as SST files by a finished
+	// IMPORT INTO job, the ingested data is not in the change log, so it can't be replicated as
+	// row changes. The event has no ddl to execute in the downstream.
+	IsIngest bool `json:"is_ingest"`
 	// Call when event flush is completed
 	PostTxnFlushed []func() `json:"-"`
 	// eventSize is the size of the event in bytes. It is set when it's unmarshaled.
@@ -17,6 +17,7 @@ import (
 	"encoding/binary"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"go.uber.org/zap"
@@ -25,8 +26,10 @@ import (
 const (
 	// defaultRowCount is the start row count of a transaction.
 	defaultRowCount = 1
-	// DMLEventVersion is the version of the DMLEvent struct.
-	DMLEventVersion = 0
+	// DMLEventVersion is the version of the DMLEvent struct,
+	// version 1 carries the sampled key, which is not in version 0.
+	// version 2 carries the txn source, which is not in version 1.
+	DMLEventVersion = 2
 )
 
 // DMLEvent represent a batch of DMLs of a whole or partial of a transaction.
@@ -49,6 +52,11 @@ type DMLEvent struct {
 	// ApproximateSize is the approximate size of all rows in the transaction.
 	ApproximateSize int64     `json:"approximate_size"`
 	RowTypes        []RowType `json:"row_types"`
+	// SampledKey is the raw key of the first row in the transaction,
+	// the dispatcher samples the written keys of the table span from it.
+	SampledKey []byte `json:"sampled_key"`
+	// TxnSource is the source of the transaction set by the upstream TiDB.
+	TxnSource uint64 `json:"txn_source"`
 	// Rows is the rows of the transaction.
 	Rows *chunk.Chunk `json:"rows"`
 	// RawRows is the raw bytes of the rows.
@@ -62,6 +70,8 @@ type DMLEvent struct {
 	TableInfo *common.TableInfo `json:"table_info"`
 	// The following fields are set and used by dispatcher.
 	ReplicatingTs uint64 `json:"replicating_ts"`
+	// TableSpan is the table span of the dispatcher which the transaction belongs to.
+	TableSpan *heartbeatpb.TableSpan `json:"-"`
 	// PostTxnFlushed is the functions to be executed after the transaction is flushed.
 	// It is set and used by dispatcher.
 	PostTxnFlushed []func() `json:"-"`
@@ -111,6 +121,10 @@ func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	if err != nil {
 		return err
 	}
+	if t.Length == 0 {
+		t.SampledKey = append([]byte(nil), raw.Key...)
+		t.TxnSource = raw.TxnSource
+	}
 	if count == 1 {
 		t.RowTypes = append(t.RowTypes, RowType)
 	} else if count == 2 {
@@ -159,6 +173,11 @@ func (t *DMLEvent) AddPostFlushFunc(f func()) {
 	t.PostTxnFlushed = append(t.PostTxnFlushed, f)
 }
 
+// Rewind resets the row offset, so the rows can be iterated by GetNextRow again.
+func (t *DMLEvent) Rewind() {
+	t.offset = 0
+}
+
 func (t *DMLEvent) GetNextRow() (RowChange, bool) {
 	if t.offset >= len(t.RowTypes) {
 		return RowChange{}, false
@@ -227,11 +246,16 @@ func (t *DMLEvent) IsPaused() bool {
 }
 
 func (t *DMLEvent) encode() ([]byte, error) {
-	if t.Version != 0 {
-		log.Panic("DMLEvent: Only version 0 is supported right now", zap.Uint8("version", t.Version))
-		return nil, nil
+	switch t.Version {
+	case 0:
+		return t.encodeV0()
+	case 1:
+		return t.encodeV1()
+	case 2:
+		return t.encodeV2()
 	}
-	return t.encodeV0()
+	log.Panic("DMLEvent: unsupported version", zap.Uint8("version", t.Version))
+	return nil, nil
 }
 
 func (t *DMLEvent) encodeV0() ([]byte, error) {
@@ -239,8 +263,36 @@ func (t *DMLEvent) encodeV0() ([]byte, error) {
 		log.Panic("DMLEvent: invalid version, expect 0, got ", zap.Uint8("version", t.Version))
 		return nil, nil
 	}
+	return t.encodeFields(false, false), nil
+}
+
+// encodeV1 encodes the fields of version 0 with the sampled key after the row types.
+func (t *DMLEvent) encodeV1() ([]byte, error) {
+	if t.Version != 1 {
+		log.Panic("DMLEvent: invalid version, expect 1, got ", zap.Uint8("version", t.Version))
+		return nil, nil
+	}
+	return t.encodeFields(true, false), nil
+}
+
+// encodeV2 encodes the fields of version 1 with the txn source after the sampled key.
+func (t *DMLEvent) encodeV2() ([]byte, error) {
+	if t.Version != 2 {
+		log.Panic("DMLEvent: invalid version, expect 2, got ", zap.Uint8("version", t.Version))
+		return nil, nil
+	}
+	return t.encodeFields(true, true), nil
+}
+
+func (t *DMLEvent) encodeFields(withSampledKey, withTxnSource bool) []byte {
 	// Calculate the total size needed for the encoded data
 	size := 1 + t.DispatcherID.GetSize() + 6*8 + 4 + t.State.GetSize() + int(t.Length)
+	if withSampledKey {
+		size += 4 + len(t.SampledKey)
+	}
+	if withTxnSource {
+		size += 8
+	}
 
 	// Allocate a buffer with the calculated size
 	buf := make([]byte, size)
@@ -285,23 +337,37 @@ func (t *DMLEvent) encodeV0() ([]byte, error) {
 		buf[offset] = byte(rowType)
 		offset++
 	}
+	if withSampledKey {
+		// SampledKey
+		binary.LittleEndian.PutUint32(buf[offset:], uint32(len(t.SampledKey)))
+		offset += 4
+		copy(buf[offset:], t.SampledKey)
+		offset += len(t.SampledKey)
+	}
+	if withTxnSource {
+		// TxnSource
+		binary.LittleEndian.PutUint64(buf[offset:], t.TxnSource)
+	}
 
 	encoder := chunk.NewCodec(t.TableInfo.GetFieldSlice())
 	data := encoder.Encode(t.Rows)
 
 	// Append the encoded data to the buffer
-	result := append(buf, data...)
-
-	return result, nil
+	return append(buf, data...)
 }
 
 func (t *DMLEvent) decode(data []byte) error {
 	t.Version = data[0]
-	if t.Version != 0 {
-		log.Panic("DMLEvent: Only version 0 is supported right now", zap.Uint8("version", t.Version))
-		return nil
+	switch t.Version {
+	case 0:
+		return t.decodeV0(data)
+	case 1:
+		return t.decodeV1(data)
+	case 2:
+		return t.decodeV2(data)
 	}
-	return t.decodeV0(data)
+	log.Panic("DMLEvent: unsupported version", zap.Uint8("version", t.Version))
+	return nil
 }
 
 func (t *DMLEvent) decodeV0(data []byte) error {
@@ -309,6 +375,29 @@ func (t *DMLEvent) decodeV0(data []byte) error {
 		log.Panic("DMLEvent: invalid version, expect 0, got ", zap.Uint8("version", t.Version))
 		return nil
 	}
+	t.decodeFields(data, false, false)
+	return nil
+}
+
+func (t *DMLEvent) decodeV1(data []byte) error {
+	if t.Version != 1 {
+		log.Panic("DMLEvent: invalid version, expect 1, got ", zap.Uint8("version", t.Version))
+		return nil
+	}
+	t.decodeFields(data, true, false)
+	return nil
+}
+
+func (t *DMLEvent) decodeV2(data []byte) error {
+	if t.Version != 2 {
+		log.Panic("DMLEvent: invalid version, expect 2, got ", zap.Uint8("version", t.Version))
+		return nil
+	}
+	t.decodeFields(data, true, true)
+	return nil
+}
+
+func (t *DMLEvent) decodeFields(data []byte, withSampledKey, withTxnSource bool) {
 	offset := 1
 	t.DispatcherID.Unmarshal(data[offset:])
 	offset += t.DispatcherID.GetSize()
@@ -333,8 +422,19 @@ func (t *DMLEvent) decodeV0(data []byte) error {
 		t.RowTypes[i] = RowType(data[offset])
 		offset++
 	}
+	if withSampledKey {
+		sampledKeyLen := int(binary.LittleEndian.Uint32(data[offset:]))
+		offset += 4
+		if sampledKeyLen > 0 {
+			t.SampledKey = append([]byte(nil), data[offset:offset+sampledKeyLen]...)
+			offset += sampledKeyLen
+		}
+	}
+	if withTxnSource {
+		t.TxnSource = binary.LittleEndian.Uint64(data[offset:])
+		offset += 8
+	}
 	t.RawRows = data[offset:]
-	return nil
 }
 
 // AssembleRows assembles the Rows from the RawRows.
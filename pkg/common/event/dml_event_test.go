@@ -16,6 +16,7 @@ package event
 import (
 	"testing"
 
+	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -68,14 +69,21 @@ func TestEncodeAndDecodeV0(t *testing.T) {
 
 	dmlEvent := helper.DML2Event("test", "t", insertDataSQL)
 	require.NotNil(t, dmlEvent)
+	dmlEvent.Version = 0
 
 	data, err := dmlEvent.encodeV0()
 	require.NoError(t, err)
+	// the sampled key is not in version 0
+	require.NotEmpty(t, dmlEvent.SampledKey)
+	dataV1 := dmlEvent.encodeFields(true, false)
+	require.Equal(t, len(data)+4+len(dmlEvent.SampledKey), len(dataV1))
 
 	reverseEvent := &DMLEvent{}
 	// Set the TableInfo before decode, it is used in decode.
-	err = reverseEvent.decodeV0(data)
+	err = reverseEvent.decode(data)
 	require.NoError(t, err)
+	require.Nil(t, reverseEvent.SampledKey)
+	dmlEvent.SampledKey = nil
 	reverseEvent.AssembleRows(dmlEvent.TableInfo)
 	require.Equal(t, dmlEvent.Rows.ToString(dmlEvent.TableInfo.GetFieldSlice()), reverseEvent.Rows.ToString(dmlEvent.TableInfo.GetFieldSlice()))
 	for i := 0; i < dmlEvent.Rows.NumRows(); i++ {
@@ -92,3 +100,55 @@ func TestEncodeAndDecodeV0(t *testing.T) {
 	reverseEvent.eventSize = 0
 	require.Equal(t, dmlEvent, reverseEvent)
 }
+
+func TestEncodeAndDecodeV1(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	ddlJob := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, ddlJob)
+
+	dmlEvent := helper.DML2Event("test", "t", insertDataSQL)
+	require.NotNil(t, dmlEvent)
+	require.NotEmpty(t, dmlEvent.SampledKey)
+	dmlEvent.Version = 1
+	dmlEvent.TxnSource = common.SetTTLJobSource(0, common.TTLJobDeleteSource)
+
+	data, err := dmlEvent.Marshal()
+	require.NoError(t, err)
+
+	reverseEvent := &DMLEvent{}
+	err = reverseEvent.Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, dmlEvent.SampledKey, reverseEvent.SampledKey)
+	// the txn source is not in version 1
+	require.Zero(t, reverseEvent.TxnSource)
+	reverseEvent.AssembleRows(dmlEvent.TableInfo)
+	require.Equal(t, dmlEvent.Rows.ToString(dmlEvent.TableInfo.GetFieldSlice()), reverseEvent.Rows.ToString(dmlEvent.TableInfo.GetFieldSlice()))
+}
+
+func TestEncodeAndDecodeV2(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	ddlJob := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, ddlJob)
+
+	dmlEvent := helper.DML2Event("test", "t", insertDataSQL)
+	require.NotNil(t, dmlEvent)
+	require.Equal(t, byte(2), dmlEvent.Version)
+	dmlEvent.TxnSource = common.SetTTLJobSource(0, common.TTLJobDeleteSource)
+
+	data, err := dmlEvent.Marshal()
+	require.NoError(t, err)
+
+	reverseEvent := &DMLEvent{}
+	err = reverseEvent.Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, dmlEvent.SampledKey, reverseEvent.SampledKey)
+	require.Equal(t, dmlEvent.TxnSource, reverseEvent.TxnSource)
+	reverseEvent.AssembleRows(dmlEvent.TableInfo)
+	require.Equal(t, dmlEvent.Rows.ToString(dmlEvent.TableInfo.GetFieldSlice()), reverseEvent.Rows.ToString(dmlEvent.TableInfo.GetFieldSlice()))
+}
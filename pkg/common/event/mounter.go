@@ -78,6 +78,14 @@ func (m *mounter) DecodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableI
 	// 	return nil
 	// }
 	count := 0
+	if raw.OpType == common.OpTypeDelete && len(raw.OldValue) == 0 {
+		// The old value is not captured, only the handle key columns of the deleted
+		// row can be decoded from the key, the other columns are null.
+		if err := m.handleToChunk(tableInfo, chk, recordID); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return 1, nil
+	}
 	if len(raw.OldValue) != 0 {
 		if !rowcodec.IsNewFormat(raw.OldValue) {
 			err := m.rawKVToChunkV1(raw.OldValue, tableInfo, chk, recordID)
@@ -161,6 +169,89 @@ func ParseDDLJob(rawKV *common.RawKVEntry, ddlTableInfo *DDLTableInfo) (*model.J
 	return nil, fmt.Errorf("invalid tableID %v in rawKV.Key", tableID)
 }
 
+// importJobStatusFinished is the status of a finished IMPORT INTO job in `tidb_import_jobs`.
+const importJobStatusFinished = "finished"
+
+// ImportJobTableInfo contains the tableInfo about tidb_import_jobs
+// and the id of the columns used to build the ingest events.
+type ImportJobTableInfo struct {
+	ImportJobTable *common.TableInfo
+	// It holds the column id of `id`, `table_schema`, `table_name`, `table_id` and `status` in table `tidb_import_jobs`.
+	IDColumnID          int64
+	TableSchemaColumnID int64
+	TableNameColumnID   int64
+	TableIDColumnID     int64
+	StatusColumnID      int64
+}
+
+// NewImportJobTableInfo creates an ImportJobTableInfo from the table info of `tidb_import_jobs`.
+func NewImportJobTableInfo(schemaID int64, schemaName string, tableInfo *model.TableInfo) (*ImportJobTableInfo, error) {
+	info := &ImportJobTableInfo{
+		ImportJobTable: common.WrapTableInfo(schemaID, schemaName, tableInfo),
+	}
+	for name, id := range map[string]*int64{
+		"id":           &info.IDColumnID,
+		"table_schema": &info.TableSchemaColumnID,
+		"table_name":   &info.TableNameColumnID,
+		"table_id":     &info.TableIDColumnID,
+		"status":       &info.StatusColumnID,
+	} {
+		col := model.FindColumnInfo(tableInfo.Columns, name)
+		if col == nil {
+			return nil, errors.Errorf("can't find column %s in table %s", name, tableInfo.Name.O)
+		}
+		*id = col.ID
+	}
+	return info, nil
+}
+
+// ParseImportJob parses the IMPORT INTO job from the raw KV entry of `tidb_import_jobs`.
+// The data of the import job is ingested into TiKV as SST files, which bypasses the change log,
+// so it returns a job of ActionImportInto when the import job turns to finished at this entry,
+// and nil for the other entries.
+func ParseImportJob(rawKV *common.RawKVEntry, importJobTableInfo *ImportJobTableInfo) (*model.Job, error) {
+	if rawKV.OpType != common.OpTypePut {
+		return nil, nil
+	}
+	recordID, err := tablecodec.DecodeRowKey(rawKV.Key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the job is already finished before this entry
+	if len(rawKV.OldValue) != 0 {
+		oldRow, err := decodeRow(rawKV.OldValue, recordID, importJobTableInfo.ImportJobTable, time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		oldStatus := oldRow[importJobTableInfo.StatusColumnID]
+		if oldStatus.GetString() == importJobStatusFinished {
+			return nil, nil
+		}
+	}
+	row, err := decodeRow(rawKV.Value, recordID, importJobTableInfo.ImportJobTable, time.UTC)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	status := row[importJobTableInfo.StatusColumnID]
+	if status.GetString() != importJobStatusFinished {
+		return nil, nil
+	}
+	jobID := row[importJobTableInfo.IDColumnID]
+	schemaName := row[importJobTableInfo.TableSchemaColumnID]
+	tableName := row[importJobTableInfo.TableNameColumnID]
+	tableID := row[importJobTableInfo.TableIDColumnID]
+	return &model.Job{
+		ID:         jobID.GetInt64(),
+		Type:       ActionImportInto,
+		State:      model.JobStateDone,
+		SchemaName: schemaName.GetString(),
+		TableName:  tableName.GetString(),
+		TableID:    tableID.GetInt64(),
+		StartTS:    rawKV.StartTs,
+		BinlogInfo: &model.HistoryInfo{FinishedTS: rawKV.CRTs},
+	}, nil
+}
+
 // parseJob unmarshal the job from "v".
 // fromHistoryTable is used to distinguish the job is from tidb_dd_job or tidb_ddl_history
 // We need to be compatible with the two modes, enable_fast_create_table=on and enable_fast_create_table=off
@@ -729,3 +729,95 @@ func TestBinary(t *testing.T) {
 	binaryFormat := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A}
 	require.Equal(t, binaryFormat, v)
 }
+
+func TestDeleteWithoutOldValue(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(10), c int)`)
+	require.NotNil(t, job)
+	tableInfo := helper.GetTableInfo(job)
+	rawKVs := helper.DML2RawKv("test", "t", `insert into test.t values (10, "b", 11)`)
+	require.Len(t, rawKVs, 1)
+
+	// the deleted row only has the key if the old value is not captured.
+	deleteKV := &common.RawKVEntry{
+		OpType:  common.OpTypeDelete,
+		Key:     rawKVs[0].Key,
+		StartTs: rawKVs[0].StartTs,
+		CRTs:    rawKVs[0].CRTs,
+	}
+	dmlEvent := NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID,
+		deleteKV.StartTs, deleteKV.CRTs, tableInfo)
+	require.NoError(t, dmlEvent.AppendRow(deleteKV, NewMounter(time.UTC).DecodeToChunk))
+
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, RowTypeDelete, row.RowType)
+	colValue, err := common.FormatColVal(&row.PreRow, tableInfo.GetColumns()[0], 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, colValue)
+	for i := 1; i < 3; i++ {
+		colValue, err = common.FormatColVal(&row.PreRow, tableInfo.GetColumns()[i], i)
+		require.NoError(t, err)
+		require.Nil(t, colValue)
+	}
+}
+
+func TestParseImportJob(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	snap := helper.GetCurrentMeta()
+	dbs, err := snap.ListDatabases()
+	require.NoError(t, err)
+	var db *timodel.DBInfo
+	for _, d := range dbs {
+		if d.Name.L == mysql.SystemDB {
+			db = d
+		}
+	}
+	require.NotNil(t, db)
+	tables, err := snap.ListTables(db.ID)
+	require.NoError(t, err)
+	var importJobTable *timodel.TableInfo
+	for _, table := range tables {
+		if table.Name.L == "tidb_import_jobs" {
+			importJobTable = table
+		}
+	}
+	require.NotNil(t, importJobTable)
+	importJobTableInfo, err := NewImportJobTableInfo(db.ID, db.Name.L, importJobTable)
+	require.NoError(t, err)
+
+	rawKV := func(sql string, oldValue []byte) *common.RawKVEntry {
+		helper.Tk().MustExec(sql)
+		key, value := helper.getLastKeyValue(importJobTable.ID)
+		return &common.RawKVEntry{OpType: common.OpTypePut, Key: key, Value: value, OldValue: oldValue, StartTs: 9, CRTs: 10}
+	}
+
+	// the running import job is not returned
+	running := rawKV(`insert into mysql.tidb_import_jobs
+		(id, table_schema, table_name, table_id, created_by, parameters, source_file_size, status, step)
+		values (1, 'test', 't', 100, 'root', '{}', 0, 'running', 'importing')`, nil)
+	job, err := ParseImportJob(running, importJobTableInfo)
+	require.NoError(t, err)
+	require.Nil(t, job)
+
+	finished := rawKV(`update mysql.tidb_import_jobs set status = 'finished', step = '' where id = 1`, running.Value)
+	job, err = ParseImportJob(finished, importJobTableInfo)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	require.Equal(t, ActionImportInto, job.Type)
+	require.Equal(t, int64(1), job.ID)
+	require.Equal(t, "test", job.SchemaName)
+	require.Equal(t, "t", job.TableName)
+	require.Equal(t, int64(100), job.TableID)
+	require.Equal(t, uint64(10), job.BinlogInfo.FinishedTS)
+
+	// the job is returned only once when it turns to finished
+	updated := rawKV(`update mysql.tidb_import_jobs set summary = '{}' where id = 1`, finished.Value)
+	job, err = ParseImportJob(updated, importJobTableInfo)
+	require.NoError(t, err)
+	require.Nil(t, job)
+}
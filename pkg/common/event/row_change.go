@@ -236,6 +236,10 @@ type RowEvent struct {
 	Event          RowChange
 	ColumnSelector columnselector.Selector
 	Callback       func()
+
+	// Checksum for the event, only not nil if the upstream TiDB enable the row level checksum
+	// and TiCDC set the integrity check level to the correctness.
+	Checksum *integrity.Checksum
 }
 
 func (e *RowEvent) IsDelete() bool {
@@ -47,12 +47,14 @@ type RawKVEntry struct {
 	// Commit or resolved TS
 	// Additional debug info
 	RegionID uint64 `msg:"region_id"` // offset 20 bytes
+	// TxnSource is the source of the transaction set by the upstream TiDB.
+	TxnSource uint64 `msg:"txn_source"` // offset 28 bytes
 
-	KeyLen      uint32 `msg:"key_len"`       // offset 28 bytes
-	ValueLen    uint32 `msg:"value_len"`     // offset 32 bytes
-	OldValueLen uint32 `msg:"old_value_len"` // offset 36 bytes
+	KeyLen      uint32 `msg:"key_len"`       // offset 36 bytes
+	ValueLen    uint32 `msg:"value_len"`     // offset 40 bytes
+	OldValueLen uint32 `msg:"old_value_len"` // offset 44 bytes
 
-	Key []byte `msg:"key"` // offset 40 bytes
+	Key []byte `msg:"key"` // offset 48 bytes
 	// nil for delete type
 	Value []byte `msg:"value"`
 	// nil for insert type
@@ -84,13 +86,14 @@ func (v *RawKVEntry) ApproximateDataSize() int64 {
 // Encode serializes the RawKVEntry into a byte slice
 func (v *RawKVEntry) Encode() []byte {
 	// Calculate total size
-	totalSize := 4*5 + 8*3 + len(v.Key) + len(v.Value) + len(v.OldValue)
+	totalSize := 4*4 + 8*4 + len(v.Key) + len(v.Value) + len(v.OldValue)
 	buf := make([]byte, 0, totalSize)
 	// Use binary.LittleEndian.PutUint32/64 to write directly to the buffer
 	buf = binary.LittleEndian.AppendUint32(buf, uint32(v.OpType))
 	buf = binary.LittleEndian.AppendUint64(buf, v.CRTs)
 	buf = binary.LittleEndian.AppendUint64(buf, v.StartTs)
 	buf = binary.LittleEndian.AppendUint64(buf, v.RegionID)
+	buf = binary.LittleEndian.AppendUint64(buf, v.TxnSource)
 
 	v.KeyLen = uint32(len(v.Key))
 	v.ValueLen = uint32(len(v.Value))
@@ -109,7 +112,7 @@ func (v *RawKVEntry) Encode() []byte {
 
 // Decode deserializes a byte slice into a RawKVEntry
 func (v *RawKVEntry) Decode(data []byte) error {
-	if len(data) < 36 { // Minimum size for fixed-length fields
+	if len(data) < 44 { // Minimum size for fixed-length fields
 		return fmt.Errorf("insufficient data length")
 	}
 
@@ -122,6 +125,8 @@ func (v *RawKVEntry) Decode(data []byte) error {
 	offset += 8
 	v.RegionID = binary.LittleEndian.Uint64(data[offset : offset+8])
 	offset += 8
+	v.TxnSource = binary.LittleEndian.Uint64(data[offset : offset+8])
+	offset += 8
 
 	v.KeyLen = binary.LittleEndian.Uint32(data[offset : offset+4])
 	offset += 4
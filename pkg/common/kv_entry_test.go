@@ -49,13 +49,14 @@ func TestRawKVEntryEncodeDecode_PutOperation(t *testing.T) {
 
 func TestRawKVEntryEncodeDecode_DeleteOperation(t *testing.T) {
 	original := RawKVEntry{
-		OpType:   OpTypeDelete,
-		CRTs:     1111111111,
-		StartTs:  2222222222,
-		RegionID: 24,
-		Key:      []byte("delete_key"),
-		Value:    make([]byte, 0),
-		OldValue: []byte("old_value"),
+		OpType:    OpTypeDelete,
+		CRTs:      1111111111,
+		StartTs:   2222222222,
+		RegionID:  24,
+		TxnSource: SetTTLJobSource(0, TTLJobDeleteSource),
+		Key:       []byte("delete_key"),
+		Value:     make([]byte, 0),
+		OldValue:  []byte("old_value"),
 	}
 
 	encoded := original.Encode()
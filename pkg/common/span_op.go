@@ -119,6 +119,18 @@ func GetIntersectSpan(lhs heartbeatpb.TableSpan, rhs heartbeatpb.TableSpan) hear
 	}
 }
 
+// IsSubSpan returns true if the sub span is in the same table and covered by the parent span.
+func IsSubSpan(sub heartbeatpb.TableSpan, parent heartbeatpb.TableSpan) bool {
+	return sub.TableID == parent.TableID &&
+		StartCompare(parent.StartKey, sub.StartKey) <= 0 &&
+		EndCompare(sub.EndKey, parent.EndKey) <= 0
+}
+
+// KeyInSpan returns true if the memcomparable key k is in the span.
+func KeyInSpan(k []byte, span heartbeatpb.TableSpan) bool {
+	return StartCompare(k, span.StartKey) >= 0 && EndCompare(k, span.EndKey) < 0
+}
+
 // IsEmptySpan returns true if the span is empty.
 // TODO: check whether need span.StartKey >= span.EndKey
 func IsEmptySpan(span heartbeatpb.TableSpan) bool {
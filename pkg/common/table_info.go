@@ -325,6 +325,15 @@ func (ti *TableInfo) InitPrivateFields() {
 	ti.preSQLs.isInitialized.Store(true)
 }
 
+// CloneWithName returns a new table info with another schema and table name. Only the schema id,
+// the table id and the TTL are copied, the column schema is the same object as the original one's,
+// its reference count is increased, and the pre sqls are built with the new name.
+func (ti *TableInfo) CloneWithName(schema, table string) *TableInfo {
+	cloned := NewTableInfo(ti.SchemaID, schema, table, ti.TableName.TableID, ti.TableName.IsPartition, ti.columnSchema.Clone())
+	cloned.InitPrivateFields()
+	return cloned
+}
+
 func (ti *TableInfo) Marshal() ([]byte, error) {
 	// otherField | columnSchemaData | columnSchemaDataSize
 	data, err := json.Marshal(ti)
@@ -805,14 +805,14 @@ func placeHolder(n int) string {
 func (s *columnSchema) getColumnList(isUpdate bool) (int, string) {
 	var b strings.Builder
 	nonGeneratedColumnCount := 0
-	for i, col := range s.Columns {
+	for _, col := range s.Columns {
 		if col == nil || s.ColumnsFlag[col.ID].IsGeneratedColumn() {
 			continue
 		}
-		nonGeneratedColumnCount++
-		if i > 0 {
+		if nonGeneratedColumnCount > 0 {
 			b.WriteString(",")
 		}
+		nonGeneratedColumnCount++
 		b.WriteString(QuoteName(col.Name.O))
 		if isUpdate {
 			b.WriteString(" = ?")
@@ -0,0 +1,38 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+// The txn source is a bitmap set by the upstream TiDB for every transaction:
+// |RESERVED|TTL_JOB_SOURCE_BITS|LOSSY_DDL_REORG_SOURCE_BITS|CDC_WRITE_SOURCE_BITS|
+// |   40   |         8         |             8             |          8          |
+const (
+	cdcWriteSourceBits      = 8
+	lossyDDLReorgSourceBits = 8
+	ttlJobSourceBits        = 8
+	ttlJobSourceMax         = (1 << ttlJobSourceBits) - 1
+	ttlJobSourceShift       = cdcWriteSourceBits + lossyDDLReorgSourceBits
+	// TTLJobDeleteSource marks the transactions of the TTL jobs which delete the expired rows.
+	TTLJobDeleteSource uint64 = 1
+)
+
+// SetTTLJobSource sets the TTL job source in the txnSource.
+func SetTTLJobSource(txnSource uint64, value uint64) uint64 {
+	return txnSource | (value&ttlJobSourceMax)<<ttlJobSourceShift
+}
+
+// IsTTLJobSource returns true if the transaction is executed by a TTL job,
+// it's set by the internal session of the TTL job.
+func IsTTLJobSource(txnSource uint64) bool {
+	return (txnSource>>ttlJobSourceShift)&ttlJobSourceMax == TTLJobDeleteSource
+}
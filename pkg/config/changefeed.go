@@ -36,18 +36,38 @@ type ChangefeedConfig struct {
 	StartTS      uint64              `json:"start_ts"`
 	TargetTS     uint64              `json:"target_ts"`
 	SinkURI      string              `json:"sink_uri"`
-	// timezone used when checking sink uri
+	// TimeZone is the timezone configured for the changefeed, it is empty if
+	// the timezone of the TiCDC server is used.
 	TimeZone string `json:"timezone" default:"system"`
 	// if true, force to replicate some ineligible tables
-	ForceReplicate bool          `json:"force_replicate" default:"false"`
-	Filter         *FilterConfig `toml:"filter" json:"filter"`
-	MemoryQuota    uint64        `toml:"memory-quota" json:"memory-quota"`
+	ForceReplicate bool `json:"force_replicate" default:"false"`
+	// CaseSensitive is true if the table filter rules match the names case-sensitively
+	CaseSensitive bool          `json:"case_sensitive" default:"false"`
+	Filter        *FilterConfig `toml:"filter" json:"filter"`
+	MemoryQuota   uint64        `toml:"memory-quota" json:"memory-quota"`
 	// sync point related
 	// TODO: Is syncPointRetention|default can be removed?
 	EnableSyncPoint    bool          `json:"enable_sync_point" default:"false"`
 	SyncPointInterval  time.Duration `json:"sync_point_interval" default:"1m"`
 	SyncPointRetention time.Duration `json:"sync_point_retention" default:"24h"`
 	SinkConfig         *SinkConfig   `json:"sink_config"`
+	LatencyMode        LatencyMode   `json:"latency_mode"`
+	OldValueMode       OldValueMode  `json:"old_value_mode"`
+	// UpstreamID is the ID of the TiDB cluster the changefeed replicates from.
+	UpstreamID uint64 `json:"upstream_id"`
+	// UpstreamInfo is nil if the changefeed replicates from the default upstream,
+	// which is the TiDB cluster of the pd endpoints the TiCDC server started with.
+	UpstreamInfo *UpstreamInfo `json:"upstream_info,omitempty"`
+	// DDLInterventions are the ddls skipped or replaced by the users.
+	DDLInterventions []*DDLIntervention `json:"ddl_interventions,omitempty"`
+	// IngestStrategy decides how the ingest events are handled.
+	IngestStrategy IngestStrategy `json:"ingest_strategy"`
+	// AckedIngestTs are the commit ts of the ingest events acknowledged by the users.
+	AckedIngestTs []uint64 `json:"acked_ingest_ts,omitempty"`
+	// QuiesceTs is the ts the dispatchers are held at, 0 means the changefeed is not quiesced.
+	QuiesceTs uint64 `json:"quiesce_ts,omitempty"`
+	// SafeModeEndTs is the end of the window replicated again, the events committed before it are written in safe mode.
+	SafeModeEndTs uint64 `json:"safe_mode_end_ts,omitempty"`
 }
 
 // ChangeFeedInfo describes the detail of a ChangeFeed
@@ -77,6 +97,23 @@ type ChangeFeedInfo struct {
 	CreatorVersion string `json:"creator-version"`
 	// Epoch is the epoch of a changefeed, changes on every restart.
 	Epoch uint64 `json:"epoch"`
+	// PausedBySchedule is true if the changefeed is paused by a pause window of
+	// the schedule config, only such a changefeed is resumed when the window ends.
+	PausedBySchedule bool `json:"paused-by-schedule,omitempty"`
+	// PausedForImport is true if the changefeed is created paused at the start ts of a
+	// physical import, it's resumed from the finish ts of the import to skip the imported data.
+	PausedForImport bool `json:"paused-for-import,omitempty"`
+	// DDLInterventions are the ddls skipped or replaced by the users after they fail in the downstream.
+	DDLInterventions []*DDLIntervention `json:"ddl-interventions,omitempty"`
+	// AckedIngestTs are the commit ts of the ingest events acknowledged by the users,
+	// the changefeed paused at these events writes them after it's resumed.
+	AckedIngestTs []uint64 `json:"acked-ingest-ts,omitempty"`
+	// QuiesceTs is the ts the dispatchers are held at by the quiesce API, it's kept until the
+	// changefeed is released, so the restarted dispatchers are held at the same ts.
+	QuiesceTs uint64 `json:"quiesce-ts,omitempty"`
+	// SafeModeEndTs is the checkpoint ts before it's moved backward by a resume, the events
+	// committed before it may have been written, so they are written in safe mode again.
+	SafeModeEndTs uint64 `json:"safe-mode-end-ts,omitempty"`
 }
 
 func (info *ChangeFeedInfo) ToChangefeedConfig() *ChangefeedConfig {
@@ -86,16 +123,35 @@ func (info *ChangeFeedInfo) ToChangefeedConfig() *ChangefeedConfig {
 		TargetTS:           info.TargetTs,
 		SinkURI:            info.SinkURI,
 		ForceReplicate:     info.Config.ForceReplicate,
+		CaseSensitive:      info.Config.CaseSensitive,
+		TimeZone:           util.GetOrZero(info.Config.TimeZone),
 		SinkConfig:         info.Config.Sink,
 		Filter:             info.Config.Filter,
 		EnableSyncPoint:    util.GetOrZero(info.Config.EnableSyncPoint),
 		SyncPointInterval:  util.GetOrZero(info.Config.SyncPointInterval),
 		SyncPointRetention: util.GetOrZero(info.Config.SyncPointRetention),
 		MemoryQuota:        info.Config.MemoryQuota,
+		LatencyMode:        util.GetOrZero(info.Config.LatencyMode),
+		OldValueMode:       util.GetOrZero(info.Config.OldValueMode),
+		UpstreamID:         info.UpstreamID,
+		UpstreamInfo:       info.UpstreamInfo,
+		DDLInterventions:   info.DDLInterventions,
+		IngestStrategy:     util.GetOrZero(info.Config.IngestStrategy),
+		AckedIngestTs:      info.AckedIngestTs,
+		QuiesceTs:          info.QuiesceTs,
+		SafeModeEndTs:      info.SafeModeEndTs,
 		// other fields are not necessary for maintainer
 	}
 }
 
+// GetTimeZone returns the name of the timezone used by the changefeed.
+func (c *ChangefeedConfig) GetTimeZone() string {
+	if c.TimeZone != "" {
+		return c.TimeZone
+	}
+	return GetGlobalServerConfig().TZ
+}
+
 // NeedBlockGC returns true if the changefeed need to block the GC safepoint.
 // Note: if the changefeed is failed by GC, it should not block the GC safepoint.
 func (info *ChangeFeedInfo) NeedBlockGC() bool {
@@ -228,6 +284,9 @@ func (info *ChangeFeedInfo) VerifyAndComplete() {
 	if info.Config.SyncedStatus == nil {
 		info.Config.SyncedStatus = defaultConfig.SyncedStatus
 	}
+	if info.Config.LatencyMode == nil {
+		info.Config.LatencyMode = defaultConfig.LatencyMode
+	}
 	info.RmUnusedFields()
 }
 
@@ -484,6 +543,38 @@ type ChangeFeedStatus struct {
 	CheckpointTs uint64 `json:"checkpoint-ts"`
 	// Progress indicates changefeed progress status
 	Progress Progress `json:"progress"`
+	// Warnings are reported by the maintainer, they are not persisted.
+	Warnings []*model.RunningError `json:"-"`
+	// Backfills are the tables being re-replicated from an older ts, they are persisted
+	// so the backfills are resumed after the maintainer or the coordinator fails over.
+	Backfills []*TableBackfill `json:"backfills,omitempty"`
+	// Barriers are the coverages of the partially reported barriers, they are persisted so the
+	// barriers are resumed after the maintainer and the coordinator fail over at the same time.
+	Barriers []*BarrierCoverage `json:"barriers,omitempty"`
+}
+
+// TableBackfill is the progress of a table being re-replicated from an older ts.
+type TableBackfill struct {
+	TableID int64 `json:"table-id"`
+	// CheckpointTs is the ts the table is re-replicated from if the backfill is interrupted.
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+}
+
+// BarrierCoverage is the coverage of the dispatchers which have reported a barrier.
+type BarrierCoverage struct {
+	BlockTs     uint64 `json:"block-ts"`
+	IsSyncPoint bool   `json:"is-sync-point,omitempty"`
+	// DynamicSplit is true if the covered spans are the key ranges of the split tables,
+	// otherwise only the table ids of the covered spans are meaningful.
+	DynamicSplit bool           `json:"dynamic-split,omitempty"`
+	Covered      []*CoveredSpan `json:"covered"`
+}
+
+// CoveredSpan is a span covered by the dispatchers which have reported a barrier.
+type CoveredSpan struct {
+	TableID  int64  `json:"table-id"`
+	StartKey []byte `json:"start-key,omitempty"`
+	EndKey   []byte `json:"end-key,omitempty"`
 }
 
 // Marshal returns json encoded string of ChangeFeedStatus, only contains necessary fields stored in storage
@@ -0,0 +1,59 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// ChangefeedEventType is the type of a changefeed event.
+type ChangefeedEventType string
+
+const (
+	// ChangefeedEventCreated means the changefeed is created.
+	ChangefeedEventCreated ChangefeedEventType = "created"
+	// ChangefeedEventRemoved means the changefeed is removed.
+	ChangefeedEventRemoved ChangefeedEventType = "removed"
+	// ChangefeedEventStateChanged means the state of the changefeed is changed,
+	// such as normal to warning, or warning to failed.
+	ChangefeedEventStateChanged ChangefeedEventType = "state-changed"
+	// ChangefeedEventCheckpointStalled means the checkpoint of a working changefeed
+	// has not advanced for a while.
+	ChangefeedEventCheckpointStalled ChangefeedEventType = "checkpoint-stalled"
+	// ChangefeedEventCheckpointResumed means the checkpoint of a stalled changefeed advances again.
+	ChangefeedEventCheckpointResumed ChangefeedEventType = "checkpoint-resumed"
+	// ChangefeedEventMaintainerMoved means the maintainer of the changefeed is moved
+	// to another node, such as by rebalance or node failure.
+	ChangefeedEventMaintainerMoved ChangefeedEventType = "maintainer-moved"
+)
+
+// ChangefeedEvent is a change of a changefeed observed by the coordinator.
+type ChangefeedEvent struct {
+	// Revision increases by one for every event, watchers resume from the last revision they saw.
+	Revision     uint64              `json:"revision"`
+	Type         ChangefeedEventType `json:"type"`
+	Namespace    string              `json:"namespace"`
+	ChangefeedID string              `json:"changefeed_id"`
+	Time         time.Time           `json:"time"`
+	State        model.FeedState     `json:"state,omitempty"`
+	// PrevState is set for state-changed events.
+	PrevState    model.FeedState `json:"prev_state,omitempty"`
+	CheckpointTs uint64          `json:"checkpoint_ts,omitempty"`
+	// Node is the node of the maintainer, PrevNode is set for maintainer-moved events.
+	Node     string `json:"node,omitempty"`
+	PrevNode string `json:"prev_node,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
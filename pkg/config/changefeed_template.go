@@ -0,0 +1,48 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// ChangefeedTemplate is a named profile of the replica config, such as the sink, codec and
+// scheduler options. A changefeed created with the template uses its config as the base,
+// and the config specified in the create request overrides it.
+type ChangefeedTemplate struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Config      *ReplicaConfig `json:"config"`
+	UpdateTime  time.Time      `json:"update-time"`
+}
+
+// Marshal returns the json marshal format of a ChangefeedTemplate
+func (t *ChangefeedTemplate) Marshal() (string, error) {
+	data, err := json.Marshal(t)
+	return string(data), cerror.WrapError(cerror.ErrMarshalFailed, err)
+}
+
+// Unmarshal unmarshals into *ChangefeedTemplate from json marshal byte slice
+func (t *ChangefeedTemplate) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, t)
+	if err != nil {
+		return errors.Annotatef(
+			cerror.WrapError(cerror.ErrUnmarshalFailed, err), "Unmarshal data: %v", data)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const (
+	// DDLInterventionSkip skips the ddl, it's not executed in the downstream.
+	DDLInterventionSkip = "skip"
+	// DDLInterventionReplace executes the query supplied by the user instead of the ddl.
+	DDLInterventionReplace = "replace"
+)
+
+// DDLIntervention is a manual intervention on a ddl the downstream fails to execute,
+// the ddl is identified by its commit ts.
+type DDLIntervention struct {
+	CommitTs uint64 `json:"commit-ts"`
+	Action   string `json:"action"`
+	// Query is the query executed instead of the ddl if the action is replace.
+	Query string `json:"query,omitempty"`
+}
+
+// SetDDLIntervention adds the intervention to the changefeed, the intervention of the same ddl
+// is replaced, and the interventions of the ddls already replicated before the checkpoint ts are removed.
+func (info *ChangeFeedInfo) SetDDLIntervention(intervention *DDLIntervention, checkpointTs uint64) {
+	interventions := make([]*DDLIntervention, 0, len(info.DDLInterventions)+1)
+	for _, d := range info.DDLInterventions {
+		if d.CommitTs <= checkpointTs || d.CommitTs == intervention.CommitTs {
+			continue
+		}
+		interventions = append(interventions, d)
+	}
+	info.DDLInterventions = append(interventions, intervention)
+}
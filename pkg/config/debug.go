@@ -14,9 +14,11 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 )
 
 // DebugConfig represents config for ticdc unexposed feature configurations
@@ -34,6 +36,15 @@ type DebugConfig struct {
 	SchemaStore *SchemaStoreConfig `toml:"schema-store" json:"schema-store"`
 
 	EventService *EventServiceConfig `toml:"event-service" json:"event-service"`
+
+	EventStore *EventStoreConfig `toml:"event-store" json:"event-store"`
+
+	// Tracing is the configuration of the OpenTelemetry tracing.
+	Tracing *TracingConfig `toml:"tracing" json:"tracing"`
+
+	// EnableFaultInjection enables the fault injection APIs, which drop or delay the messages,
+	// kill the maintainers or stall the sinks. It's only used by the chaos tests.
+	EnableFaultInjection bool `toml:"enable-fault-injection" json:"enable-fault-injection"`
 }
 
 // ValidateAndAdjust validates and adjusts the debug configuration
@@ -47,6 +58,18 @@ func (c *DebugConfig) ValidateAndAdjust() error {
 	if err := c.Scheduler.ValidateAndAdjust(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.EventStore == nil {
+		c.EventStore = NewDefaultEventStoreConfig()
+	}
+	if err := c.EventStore.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Tracing == nil {
+		c.Tracing = NewDefaultTracingConfig()
+	}
+	if err := c.Tracing.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
 
 	return nil
 }
@@ -93,3 +116,65 @@ func NewDefaultEventServiceConfig() *EventServiceConfig {
 		ScanTaskQueueSize: 1024 * 8,
 	}
 }
+
+// EventStoreConfig represents config for event store
+type EventStoreConfig struct {
+	// EnableDedup is used to drop the duplicated events delivered again by the upstream
+	// after the regions are re-subscribed, before they are written to the event store.
+	EnableDedup bool `toml:"enable-dedup" json:"enable-dedup"`
+	// DedupWindowSize is the max number of the events remembered for each subscription
+	// to find the duplicated events, the events beyond it are not deduplicated.
+	DedupWindowSize int `toml:"dedup-window-size" json:"dedup-window-size"`
+}
+
+// NewDefaultEventStoreConfig return the default event store configuration
+func NewDefaultEventStoreConfig() *EventStoreConfig {
+	return &EventStoreConfig{
+		EnableDedup:     false,
+		DedupWindowSize: 64 * 1024,
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the event store configuration
+func (c *EventStoreConfig) ValidateAndAdjust() error {
+	if c.EnableDedup && c.DedupWindowSize <= 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"dedup-window-size should be positive if dedup is enabled")
+	}
+	return nil
+}
+
+// TracingConfig represents config for OpenTelemetry tracing
+type TracingConfig struct {
+	// Enable is used to enable exporting spans of the event path,
+	// such as barrier handling, message sending and sink flushing.
+	Enable bool `toml:"enable" json:"enable"`
+	// Endpoint is the address of the OTLP gRPC collector, e.g. "127.0.0.1:4317".
+	Endpoint string `toml:"endpoint" json:"endpoint"`
+	// SampleRatio is the ratio of the traces to be sampled, in range [0, 1].
+	SampleRatio float64 `toml:"sample-ratio" json:"sample-ratio"`
+}
+
+// NewDefaultTracingConfig return the default tracing configuration
+func NewDefaultTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Enable:      false,
+		Endpoint:    "127.0.0.1:4317",
+		SampleRatio: 0.01,
+	}
+}
+
+// ValidateAndAdjust validates and adjusts the tracing configuration
+func (c *TracingConfig) ValidateAndAdjust() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Endpoint == "" {
+		return cerror.ErrInvalidServerOption.GenWithStack("tracing endpoint must be specified when tracing is enabled")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			fmt.Sprintf("tracing sample-ratio must be in range [0, 1], got %f", c.SampleRatio))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/pingcap/ticdc/pkg/errors"
+)
+
+// ErrorRetryConfig overrides the default backoff policies of retrying the
+// errors by their classes, the policy of a class is unchanged if it's nil.
+type ErrorRetryConfig struct {
+	// Transient is the backoff of the errors expected to disappear soon.
+	Transient *BackoffPolicyConfig `toml:"transient" json:"transient,omitempty"`
+	// Backoff is the backoff of the errors needing some time to recover.
+	Backoff *BackoffPolicyConfig `toml:"backoff" json:"backoff,omitempty"`
+}
+
+// BackoffPolicyConfig is the backoff of retrying the errors of a class.
+type BackoffPolicyConfig struct {
+	BaseDelay TomlDuration `toml:"base-delay" json:"base-delay"`
+	MaxDelay  TomlDuration `toml:"max-delay" json:"max-delay"`
+}
+
+// ValidateAndAdjust validates the error retry configuration.
+func (c *ErrorRetryConfig) ValidateAndAdjust() error {
+	for name, policy := range map[string]*BackoffPolicyConfig{
+		"transient": c.Transient,
+		"backoff":   c.Backoff,
+	} {
+		if policy == nil {
+			continue
+		}
+		if policy.BaseDelay <= 0 || policy.MaxDelay < policy.BaseDelay {
+			return errors.ErrInvalidServerOption.GenWithStackByArgs(
+				"the base-delay of error-retry." + name +
+					" should be positive and not larger than the max-delay")
+		}
+	}
+	return nil
+}
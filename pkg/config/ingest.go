@@ -0,0 +1,62 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// IngestStrategy decides how the ingest events are handled by the changefeed.
+// An ingest event marks a finished IMPORT INTO job, whose data is ingested into TiKV
+// as SST files and bypasses the change log. The ddls are never affected by the strategy.
+type IngestStrategy string
+
+const (
+	// IngestStrategyReplicate keeps replicating the changes around the ingest event,
+	// the ingested data itself is not replicated. It is the default strategy.
+	IngestStrategyReplicate IngestStrategy = "replicate"
+	// IngestStrategyError fails the changefeed at the ingest event.
+	IngestStrategyError IngestStrategy = "error"
+	// IngestStrategySkip passes the ingest event with a warning.
+	IngestStrategySkip IngestStrategy = "skip"
+	// IngestStrategyPauseUntilAck pauses the changefeed at the ingest event, the event is
+	// passed after it's acknowledged by the ingest ack api.
+	IngestStrategyPauseUntilAck IngestStrategy = "pause-until-ack"
+)
+
+// Validate checks whether the strategy is supported, an empty strategy means the replicate strategy.
+func (s IngestStrategy) Validate() error {
+	switch s {
+	case "", IngestStrategyReplicate, IngestStrategyError, IngestStrategySkip, IngestStrategyPauseUntilAck:
+		return nil
+	}
+	return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+		fmt.Sprintf("The IngestStrategy:%s must be one of %s, %s, %s and %s",
+			s, IngestStrategyReplicate, IngestStrategyError, IngestStrategySkip, IngestStrategyPauseUntilAck))
+}
+
+// AckIngestEvent acknowledges the ingest event at the commit ts, and removes the
+// acknowledgements of the ingest events already replicated before the checkpoint ts.
+func (info *ChangeFeedInfo) AckIngestEvent(commitTs uint64, checkpointTs uint64) {
+	acked := make([]uint64, 0, len(info.AckedIngestTs)+1)
+	for _, ts := range info.AckedIngestTs {
+		if ts <= checkpointTs || ts == commitTs {
+			continue
+		}
+		acked = append(acked, ts)
+	}
+	info.AckedIngestTs = append(acked, commitTs)
+}
@@ -36,8 +36,19 @@ type KVClientConfig struct {
 	RegionScanLimit int `toml:"region-scan-limit" json:"region-scan-limit"`
 	// the total retry duration of connecting a region
 	RegionRetryDuration TomlDuration `toml:"region-retry-duration" json:"region-retry-duration"`
+	// the compression algorithm of the requests sent to TiKV, it is only used if
+	// the TiKV store supports it. The responses are compressed by TiKV according
+	// to its `server.grpc-compression-type`, they are always accepted.
+	GrpcCompression string `toml:"grpc-compression" json:"grpc-compression"`
 }
 
+const (
+	// GrpcCompressionNone disables the compression of the grpc streams to TiKV.
+	GrpcCompressionNone = "none"
+	// GrpcCompressionGzip compresses the grpc streams to TiKV with gzip.
+	GrpcCompressionGzip = "gzip"
+)
+
 // NewDefaultKVClientConfig return the default kv client configuration
 func NewDefaultKVClientConfig() *KVClientConfig {
 	return &KVClientConfig{
@@ -51,6 +62,7 @@ func NewDefaultKVClientConfig() *KVClientConfig {
 		// The default TiKV region election timeout is [10s, 20s],
 		// Use 1 minute to cover region leader missing.
 		RegionRetryDuration: TomlDuration(time.Minute),
+		GrpcCompression:     GrpcCompressionNone,
 	}
 }
 
@@ -64,5 +76,14 @@ func (c *KVClientConfig) ValidateAndAdjust() error {
 		return errors.ErrInvalidServerOption.GenWithStackByArgs(
 			"region-scan-limit should be positive")
 	}
+	switch c.GrpcCompression {
+	case "":
+		c.GrpcCompression = GrpcCompressionNone
+	case GrpcCompressionNone, GrpcCompressionGzip:
+	default:
+		// The grpc server of TiKV only decodes the algorithms of grpc core.
+		return errors.ErrInvalidServerOption.GenWithStackByArgs(
+			"grpc-compression should be one of none and gzip")
+	}
 	return nil
 }
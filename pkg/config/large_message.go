@@ -64,6 +64,13 @@ func (c *LargeMessageHandleConfig) AdjustAndValidate(protocol Protocol, enableTi
 
 	switch protocol {
 	case ProtocolOpen, ProtocolSimple:
+	case ProtocolDebezium:
+		// the claim check location can't be carried by the debezium message.
+		if c.LargeMessageHandleOption != LargeMessageHandleOptionHandleKeyOnly {
+			return cerror.ErrInvalidReplicaConfig.GenWithStack(
+				"large message handle is set to %s, protocol is %s, only handle-key-only is supported",
+				c.LargeMessageHandleOption, protocol.String())
+		}
 	case ProtocolCanalJSON:
 		if !enableTiDBExtension {
 			return cerror.ErrInvalidReplicaConfig.GenWithStack(
@@ -0,0 +1,101 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// LatencyMode decides whether a changefeed favors a lower checkpoint lag or a higher throughput.
+type LatencyMode string
+
+const (
+	// LatencyModeLowLatency flushes the sink and advances the checkpoint more often,
+	// with smaller batches.
+	LatencyModeLowLatency LatencyMode = "low-latency"
+	// LatencyModeBalanced is the default mode.
+	LatencyModeBalanced LatencyMode = "balanced"
+	// LatencyModeHighThroughput uses bigger batches and delays the flush and the
+	// checkpoint advancement, which costs more memory and a higher checkpoint lag.
+	LatencyModeHighThroughput LatencyMode = "high-throughput"
+)
+
+// LatencyProfile is the set of knobs derived from a LatencyMode. It's applied by the
+// dispatchers, the sinks and the maintainer of the changefeed, so they are tuned consistently.
+type LatencyProfile struct {
+	// SinkFlushInterval is the max time a mysql sink worker waits to collect a batch before flushing it.
+	SinkFlushInterval time.Duration
+	// MQBatchInterval is the max time a mq sink worker waits to collect a batch of messages.
+	MQBatchInterval time.Duration
+	// SinkBatchSize is the max number of messages in a batch of the mq sink.
+	SinkBatchSize int
+	// StatusBatchInterval is the time the dispatcher manager batches the status
+	// of the dispatchers before reporting them to the maintainer.
+	StatusBatchInterval time.Duration
+	// HeartbeatInterval is the interval the dispatcher manager reports the watermark to the maintainer.
+	HeartbeatInterval time.Duration
+	// CheckpointInterval is the interval the maintainer aggregates the checkpoint ts of the changefeed.
+	CheckpointInterval time.Duration
+}
+
+var latencyProfiles = map[LatencyMode]LatencyProfile{
+	LatencyModeLowLatency: {
+		SinkFlushInterval:   2 * time.Millisecond,
+		MQBatchInterval:     5 * time.Millisecond,
+		SinkBatchSize:       512,
+		StatusBatchInterval: 5 * time.Millisecond,
+		HeartbeatInterval:   100 * time.Millisecond,
+		CheckpointInterval:  100 * time.Millisecond,
+	},
+	LatencyModeBalanced: {
+		SinkFlushInterval:   10 * time.Millisecond,
+		MQBatchInterval:     15 * time.Millisecond,
+		SinkBatchSize:       2048,
+		StatusBatchInterval: 10 * time.Millisecond,
+		HeartbeatInterval:   200 * time.Millisecond,
+		CheckpointInterval:  200 * time.Millisecond,
+	},
+	LatencyModeHighThroughput: {
+		SinkFlushInterval:   50 * time.Millisecond,
+		MQBatchInterval:     50 * time.Millisecond,
+		SinkBatchSize:       8192,
+		StatusBatchInterval: 50 * time.Millisecond,
+		HeartbeatInterval:   time.Second,
+		CheckpointInterval:  time.Second,
+	},
+}
+
+// Validate checks whether the mode is supported, an empty mode means the balanced mode.
+func (m LatencyMode) Validate() error {
+	if m == "" {
+		return nil
+	}
+	if _, ok := latencyProfiles[m]; !ok {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The LatencyMode:%s must be one of %s, %s and %s",
+				m, LatencyModeLowLatency, LatencyModeBalanced, LatencyModeHighThroughput))
+	}
+	return nil
+}
+
+// Profile returns the knobs of the mode, the balanced profile is returned for an unknown mode.
+func (m LatencyMode) Profile() LatencyProfile {
+	if p, ok := latencyProfiles[m]; ok {
+		return p
+	}
+	return latencyProfiles[LatencyModeBalanced]
+}
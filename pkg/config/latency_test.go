@@ -0,0 +1,127 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyModeValidate(t *testing.T) {
+	cases := []struct {
+		mode  LatencyMode
+		valid bool
+	}{
+		{LatencyModeLowLatency, true},
+		{LatencyModeBalanced, true},
+		{LatencyModeHighThroughput, true},
+		// an empty mode means the balanced mode
+		{"", true},
+		{"unknown", false},
+		{"Low-Latency", false},
+		{"low_latency", false},
+		{" balanced", false},
+	}
+	for _, c := range cases {
+		err := c.mode.Validate()
+		if c.valid {
+			require.NoError(t, err, c.mode)
+			continue
+		}
+		require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err), c.mode)
+		require.ErrorContains(t, err, string(c.mode))
+	}
+}
+
+func TestLatencyModeProfile(t *testing.T) {
+	balanced := LatencyProfile{
+		SinkFlushInterval:   10 * time.Millisecond,
+		MQBatchInterval:     15 * time.Millisecond,
+		SinkBatchSize:       2048,
+		StatusBatchInterval: 10 * time.Millisecond,
+		HeartbeatInterval:   200 * time.Millisecond,
+		CheckpointInterval:  200 * time.Millisecond,
+	}
+	cases := []struct {
+		mode     LatencyMode
+		expected LatencyProfile
+	}{
+		{LatencyModeLowLatency, LatencyProfile{
+			SinkFlushInterval:   2 * time.Millisecond,
+			MQBatchInterval:     5 * time.Millisecond,
+			SinkBatchSize:       512,
+			StatusBatchInterval: 5 * time.Millisecond,
+			HeartbeatInterval:   100 * time.Millisecond,
+			CheckpointInterval:  100 * time.Millisecond,
+		}},
+		{LatencyModeBalanced, balanced},
+		{LatencyModeHighThroughput, LatencyProfile{
+			SinkFlushInterval:   50 * time.Millisecond,
+			MQBatchInterval:     50 * time.Millisecond,
+			SinkBatchSize:       8192,
+			StatusBatchInterval: 50 * time.Millisecond,
+			HeartbeatInterval:   time.Second,
+			CheckpointInterval:  time.Second,
+		}},
+		// the balanced profile is used for an empty or unknown mode
+		{"", balanced},
+		{"unknown", balanced},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expected, c.mode.Profile(), c.mode)
+	}
+}
+
+func TestReplicaConfigLatencyMode(t *testing.T) {
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+
+	// the balanced mode is the default
+	c := GetDefaultReplicaConfig()
+	require.Equal(t, LatencyModeBalanced, *c.LatencyMode)
+	require.NoError(t, c.ValidateAndAdjust(sinkURI))
+
+	cases := []struct {
+		mode  *LatencyMode
+		valid bool
+	}{
+		{nil, true},
+		{util.AddressOf(LatencyModeLowLatency), true},
+		{util.AddressOf(LatencyModeHighThroughput), true},
+		{util.AddressOf(LatencyMode("")), true},
+		{util.AddressOf(LatencyMode("fast")), false},
+	}
+	for _, c := range cases {
+		cfg := GetDefaultReplicaConfig()
+		cfg.LatencyMode = c.mode
+		err := cfg.ValidateAndAdjust(sinkURI)
+		if c.valid {
+			require.NoError(t, err, c.mode)
+			continue
+		}
+		require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err), c.mode)
+	}
+
+	// the changefeeds created before the latency mode is introduced use the balanced mode
+	info := &ChangeFeedInfo{SinkURI: sinkURI.String(), Config: GetDefaultReplicaConfig()}
+	info.Config.LatencyMode = nil
+	info.VerifyAndComplete()
+	require.Equal(t, LatencyModeBalanced, *info.Config.LatencyMode)
+	require.Equal(t, LatencyModeBalanced, info.ToChangefeedConfig().LatencyMode)
+}
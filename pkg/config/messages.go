@@ -41,6 +41,11 @@ type MessagesConfig struct {
 	// of Timeout and if no activity is seen even after that the connection is
 	// closed.
 	KeepAliveTimeout TomlDuration `toml:"keep-alive-timeout" json:"keep-alive-timeout"`
+
+	// StandbyConnection makes the message center maintain a standby connection
+	// to each peer besides the primary one. Messages fail over to the standby
+	// connection immediately when the primary connection is broken.
+	StandbyConnection bool `toml:"standby-connection" json:"standby-connection"`
 }
 
 // read only
@@ -165,6 +170,7 @@ func (c *MessagesConfig) Clone() *MessagesConfig {
 		MaxRecvMsgSize:               c.MaxRecvMsgSize,
 		KeepAliveTime:                c.KeepAliveTime,
 		KeepAliveTimeout:             c.KeepAliveTimeout,
+		StandbyConnection:            c.StandbyConnection,
 	}
 }
 
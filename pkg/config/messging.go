@@ -21,6 +21,9 @@ const (
 type MessageCenterConfig struct {
 	// The size of the channel for pending messages to be sent and received.
 	CacheChannelSize int
+	// StandbyConnection indicates whether to maintain a standby connection to
+	// each remote target, which takes over when the primary connection is broken.
+	StandbyConnection bool
 }
 
 func NewDefaultMessageCenterConfig() *MessageCenterConfig {
@@ -0,0 +1,59 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// OldValueMode decides whether the old values of the rows are captured from TiKV.
+// Capturing the old values costs TiKV an extra read for each update and delete,
+// it can be avoided if neither the sink nor the filter of the changefeed uses them.
+type OldValueMode string
+
+const (
+	// OldValueModeEnabled always captures the old values, it is the default mode.
+	OldValueModeEnabled OldValueMode = "enabled"
+	// OldValueModeDisabled never captures the old values, the changefeed can't be
+	// created if its sink or filter needs them.
+	OldValueModeDisabled OldValueMode = "disabled"
+	// OldValueModeAuto captures the old values only if the sink or the filter needs them.
+	OldValueModeAuto OldValueMode = "auto"
+)
+
+// Validate checks whether the mode is supported, an empty mode means the enabled mode.
+func (m OldValueMode) Validate() error {
+	switch m {
+	case "", OldValueModeEnabled, OldValueModeDisabled, OldValueModeAuto:
+		return nil
+	}
+	return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+		fmt.Sprintf("The OldValueMode:%s must be one of %s, %s and %s",
+			m, OldValueModeEnabled, OldValueModeDisabled, OldValueModeAuto))
+}
+
+// NeedOldValue returns true if the event filter rules evaluate the old values of the rows.
+func (c *FilterConfig) NeedOldValue() bool {
+	if c == nil {
+		return false
+	}
+	for _, rule := range c.EventFilters {
+		if rule.IgnoreUpdateOldValueExpr != "" || rule.IgnoreDeleteValueExpr != "" {
+			return true
+		}
+	}
+	return false
+}
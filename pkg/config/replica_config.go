@@ -41,9 +41,14 @@ const (
 	minChangeFeedErrorStuckDuration = time.Minute * 30
 	// DefaultTiDBSourceID is the default source ID of TiDB cluster.
 	DefaultTiDBSourceID = 1
+	// CurrentReplicaConfigVersion is the version of the replica config schema,
+	// it's increased when the schema changes incompatibly and the older configs
+	// need to be migrated.
+	CurrentReplicaConfigVersion = 1
 )
 
 var defaultReplicaConfig = &ReplicaConfig{
+	ConfigVersion:      CurrentReplicaConfigVersion,
 	MemoryQuota:        config.DefaultChangefeedMemoryQuota,
 	CaseSensitive:      false,
 	CheckGCSafePoint:   true,
@@ -108,6 +113,7 @@ var defaultReplicaConfig = &ReplicaConfig{
 	},
 	ChangefeedErrorStuckDuration: util.AddressOf(time.Minute * 30),
 	SyncedStatus:                 &SyncedStatusConfig{SyncedCheckInterval: 5 * 60, CheckpointInterval: 15},
+	LatencyMode:                  util.AddressOf(LatencyModeBalanced),
 }
 
 // GetDefaultReplicaConfig returns the default replica config.
@@ -131,10 +137,17 @@ func (d *Duration) UnmarshalText(text []byte) error {
 type ReplicaConfig replicaConfig
 
 type replicaConfig struct {
+	// ConfigVersion is the version of the config schema, the configs
+	// created before the version is introduced are regarded as version 1.
+	ConfigVersion    int    `toml:"config-version" json:"config-version,omitempty"`
 	MemoryQuota      uint64 `toml:"memory-quota" json:"memory-quota"`
 	CaseSensitive    bool   `toml:"case-sensitive" json:"case-sensitive"`
 	ForceReplicate   bool   `toml:"force-replicate" json:"force-replicate"`
 	CheckGCSafePoint bool   `toml:"check-gc-safe-point" json:"check-gc-safe-point"`
+	// TimeZone is used to evaluate the event filter expressions, to encode the
+	// temporal values and as the session timezone of the MySQL sink.
+	// The timezone of the TiCDC server is used if it is not set.
+	TimeZone *string `toml:"time-zone" json:"time-zone,omitempty"`
 	// EnableSyncPoint is only available when the downstream is a Database.
 	EnableSyncPoint    *bool `toml:"enable-sync-point" json:"enable-sync-point,omitempty"`
 	EnableTableMonitor *bool `toml:"enable-table-monitor" json:"enable-table-monitor"`
@@ -161,6 +174,14 @@ type replicaConfig struct {
 	Integrity                    *integrity.Config   `toml:"integrity" json:"integrity"`
 	ChangefeedErrorStuckDuration *time.Duration      `toml:"changefeed-error-stuck-duration" json:"changefeed-error-stuck-duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig `toml:"synced-status" json:"synced-status,omitempty"`
+	// LatencyMode decides whether the changefeed favors a lower checkpoint lag or a higher throughput.
+	LatencyMode *LatencyMode `toml:"latency-mode" json:"latency-mode,omitempty"`
+	// OldValueMode decides whether the old values of the rows are captured from TiKV.
+	OldValueMode *OldValueMode `toml:"old-value-mode" json:"old-value-mode,omitempty"`
+	// IngestStrategy decides how the import jobs whose data is ingested into TiKV directly are handled.
+	IngestStrategy *IngestStrategy `toml:"ingest-strategy" json:"ingest-strategy,omitempty"`
+	// Schedule is the configuration of the windows in which the changefeed is paused automatically.
+	Schedule *ScheduleConfig `toml:"schedule" json:"schedule,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `toml:"sql-mode" json:"sql-mode"`
@@ -230,6 +251,20 @@ func (c *ReplicaConfig) Clone() *ReplicaConfig {
 	return clone
 }
 
+// migrate migrates the config of an older version to the current version.
+func (c *ReplicaConfig) migrate() error {
+	if c.ConfigVersion > CurrentReplicaConfigVersion {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(fmt.Sprintf(
+			"config-version %d is not supported, the max supported version is %d",
+			c.ConfigVersion, CurrentReplicaConfigVersion))
+	}
+	if c.ConfigVersion <= 0 {
+		c.ConfigVersion = 1
+	}
+	// the migrations of the later versions are applied here in order.
+	return nil
+}
+
 func (c *replicaConfig) fillFromV1(v1 *outdated.ReplicaConfigV1) {
 	if v1 == nil || v1.Sink == nil {
 		return
@@ -244,6 +279,9 @@ func (c *replicaConfig) fillFromV1(v1 *outdated.ReplicaConfigV1) {
 
 // ValidateAndAdjust verifies and adjusts the replica configuration.
 func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sink uri
+	if err := c.migrate(); err != nil {
+		return err
+	}
 	if c.Sink != nil {
 		err := c.Sink.validateAndAdjust(sinkURI)
 		if err != nil {
@@ -251,6 +289,10 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 		}
 	}
 
+	if err := c.validateTimeZone(sinkURI); err != nil {
+		return err
+	}
+
 	if c.Consistent != nil {
 		err := c.Consistent.ValidateAndAdjust()
 		if err != nil {
@@ -315,6 +357,30 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 		}
 	}
 
+	if c.LatencyMode != nil {
+		if err := c.LatencyMode.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.OldValueMode != nil {
+		if err := c.OldValueMode.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.IngestStrategy != nil {
+		if err := c.IngestStrategy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Schedule != nil {
+		if err := c.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if c.ChangefeedErrorStuckDuration != nil &&
 		*c.ChangefeedErrorStuckDuration < minChangeFeedErrorStuckDuration {
 		return cerror.ErrInvalidReplicaConfig.
@@ -327,6 +393,49 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 	return nil
 }
 
+// GetTimeZone returns the name of the timezone used by the changefeed.
+func (c *ReplicaConfig) GetTimeZone() string {
+	if c.TimeZone != nil {
+		return *c.TimeZone
+	}
+	return GetGlobalServerConfig().TZ
+}
+
+// validateTimeZone checks that the timezone of the changefeed agrees with
+// the timezones given in the sink uri and the mysql sink config, otherwise
+// the downstream may see temporal values different from the ones evaluated
+// by the filter and the codec.
+func (c *ReplicaConfig) validateTimeZone(sinkURI *url.URL) error {
+	tz, err := util.GetTimezone(c.GetTimeZone())
+	if err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(err.Error())
+	}
+	check := func(source, name string) error {
+		if name == "" {
+			return nil
+		}
+		other, err := util.GetTimezone(name)
+		if err != nil {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(err.Error())
+		}
+		if other.String() != tz.String() {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("the timezone %s of the changefeed and the timezone %s of the %s are inconsistent",
+					tz.String(), other.String(), source))
+		}
+		return nil
+	}
+	if sinkURI != nil {
+		if err := check("sink-uri", sinkURI.Query().Get("time-zone")); err != nil {
+			return err
+		}
+	}
+	if c.Sink != nil && c.Sink.MySQLConfig != nil {
+		return check("mysql-config", util.GetOrZero(c.Sink.MySQLConfig.TimeZone))
+	}
+	return nil
+}
+
 // FixScheduler adjusts scheduler to default value
 func (c *ReplicaConfig) FixScheduler(inheritV66 bool) {
 	if c.Scheduler == nil {
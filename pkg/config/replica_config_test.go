@@ -0,0 +1,66 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTimeZone(t *testing.T) {
+	parse := func(uri string) *url.URL {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		return u
+	}
+
+	c := GetDefaultReplicaConfig()
+	c.TimeZone = util.AddressOf("UTC")
+	require.NoError(t, c.validateTimeZone(nil))
+	require.NoError(t, c.validateTimeZone(parse("mysql://127.0.0.1:3306/")))
+	require.NoError(t, c.validateTimeZone(parse("mysql://127.0.0.1:3306/?time-zone=UTC")))
+
+	// the timezone in the sink uri disagrees with the changefeed
+	err := c.validateTimeZone(parse("mysql://127.0.0.1:3306/?time-zone=Asia/Shanghai"))
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+	require.ErrorContains(t, err, "sink-uri")
+
+	// the timezone in the mysql config disagrees with the changefeed
+	c.Sink.MySQLConfig = &MySQLConfig{TimeZone: util.AddressOf("Asia/Shanghai")}
+	err = c.validateTimeZone(nil)
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+	require.ErrorContains(t, err, "mysql-config")
+	c.Sink.MySQLConfig.TimeZone = util.AddressOf("UTC")
+	require.NoError(t, c.validateTimeZone(nil))
+
+	// invalid timezones
+	err = c.validateTimeZone(parse("mysql://127.0.0.1:3306/?time-zone=Invalid/Zone"))
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+	c.TimeZone = util.AddressOf("Invalid/Zone")
+	err = c.validateTimeZone(nil)
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+
+	// the timezone of the server is used if the changefeed doesn't specify one
+	c = GetDefaultReplicaConfig()
+	serverConfig := GetGlobalServerConfig().Clone()
+	serverConfig.TZ = "Asia/Shanghai"
+	StoreGlobalServerConfig(serverConfig)
+	defer StoreGlobalServerConfig(GetDefaultServerConfig())
+	require.NoError(t, c.validateTimeZone(parse("mysql://127.0.0.1:3306/?time-zone=Asia/Shanghai")))
+	require.Error(t, c.validateTimeZone(parse("mysql://127.0.0.1:3306/?time-zone=UTC")))
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduleConfig is the configuration of the windows in which the changefeed
+// is paused automatically, e.g. the batch-load windows of the upstream.
+type ScheduleConfig struct {
+	PauseWindows []*PauseWindow `toml:"pause-windows" json:"pause-windows,omitempty"`
+}
+
+// PauseWindow is a window which starts at the time matched by the cron spec
+// and lasts for the duration.
+type PauseWindow struct {
+	// Cron is a standard 5 fields cron spec, e.g. "0 2 * * *" starts the window at 2:00 every day.
+	Cron string `toml:"cron" json:"cron"`
+	// Duration is the length of the window, e.g. "2h".
+	Duration string `toml:"duration" json:"duration"`
+}
+
+// Validate checks whether the cron spec and the duration of the windows are valid.
+func (c *ScheduleConfig) Validate() error {
+	for _, w := range c.PauseWindows {
+		if _, _, err := w.parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InPauseWindow returns true if t is in any of the pause windows.
+func (c *ScheduleConfig) InPauseWindow(t time.Time) bool {
+	_, ok := c.PauseWindowEnd(t)
+	return ok
+}
+
+// PauseWindowEnd returns the end of the pause windows containing t, the latest
+// one is returned if t is in several overlapped windows.
+func (c *ScheduleConfig) PauseWindowEnd(t time.Time) (time.Time, bool) {
+	if c == nil {
+		return time.Time{}, false
+	}
+	var end time.Time
+	for _, w := range c.PauseWindows {
+		sched, d, err := w.parse()
+		if err != nil {
+			continue
+		}
+		// the windows starting in (t-d, t] contain t
+		for start := sched.Next(t.Add(-d - time.Second)); !start.After(t); start = sched.Next(start) {
+			if t.Before(start.Add(d)) && start.Add(d).After(end) {
+				end = start.Add(d)
+			}
+		}
+	}
+	return end, !end.IsZero()
+}
+
+func (w *PauseWindow) parse() (cron.Schedule, time.Duration, error) {
+	sched, err := cron.ParseStandard(w.Cron)
+	if err != nil {
+		return nil, 0, cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("invalid cron spec %q of the pause window: %s", w.Cron, err.Error()))
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil || d <= 0 {
+		return nil, 0, cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("invalid duration %q of the pause window, it must be a positive duration", w.Duration))
+	}
+	return sched, d, nil
+}
@@ -18,6 +18,31 @@ import (
 	"time"
 
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+const (
+	// PlacementStrategyBalance places the spans to the nodes with the least
+	// spans, and moves spans to balance the span count of nodes.
+	PlacementStrategyBalance = "balance"
+	// PlacementStrategyConsistentHash places the spans by the rendezvous hashing
+	// of the span keys, adding or removing a node moves only about 1/N of spans.
+	PlacementStrategyConsistentHash = "consistent-hash"
+	// PlacementStrategyWeight moves the changefeed maintainers to balance the
+	// total weight of the changefeeds on each node, the weight of a changefeed
+	// is calculated by its table count and traffic. It's only used by the coordinator.
+	PlacementStrategyWeight = "weight"
+
+	// GroupBySchema groups the spans of the whole tables by their schemas, the
+	// tables of each schema are balanced across the nodes separately.
+	GroupBySchema = "schema"
+	// GroupByTag groups the spans of the whole tables by the tags given by the
+	// group-tags rules, the tables matched by no rule are in the default group.
+	GroupByTag = "tag"
+
+	// maxDDLBatchWindow is the max value of the ddl-batch-window, the checkpoint ts is
+	// blocked while the batched ddls are waiting to be scheduled.
+	maxDDLBatchWindow = 10 * time.Second
 )
 
 // ChangefeedSchedulerConfig is per changefeed scheduler settings.
@@ -29,10 +54,76 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region-threshold" json:"region-threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write-key-threshold" json:"write-key-threshold"`
+	// EnableAutoTuneThreshold set true to tune the region threshold and the write
+	// key threshold by the traffic of the changefeed and the node count, the
+	// configured thresholds are used as the baseline.
+	EnableAutoTuneThreshold bool `toml:"enable-auto-tune-threshold" json:"enable-auto-tune-threshold"`
+	// PlacementStrategy decides how the spans are placed to nodes, it's one of
+	// "balance" and "consistent-hash", empty means "balance".
+	PlacementStrategy string `toml:"placement-strategy" json:"placement-strategy,omitempty"`
+	// MaxSpansPerTablePerNode is the max span count of a split table on one node, the spans
+	// of a hot table are spread across the nodes instead of piling onto a few. It's only used
+	// by the balance placement strategy, 0 means no limit.
+	MaxSpansPerTablePerNode int `toml:"max-spans-per-table-per-node" json:"max-spans-per-table-per-node,omitempty"`
+	// DDLBatchWindow is the max commit ts distance of the ddls scheduled in one round, the
+	// contiguous ddls affecting disjoint tables within the window are batched, 0 disables it.
+	DDLBatchWindow TomlDuration `toml:"ddl-batch-window" json:"ddl-batch-window,omitempty"`
+	// BootstrapTimeout is the time the maintainer waits for the bootstrap responses of all
+	// nodes before it reports the missing nodes as timeout, 0 means waiting forever.
+	BootstrapTimeout TomlDuration `toml:"bootstrap-timeout" json:"bootstrap-timeout,omitempty"`
+	// BootstrapSkipTimeoutNodes set true to proceed the bootstrap without the nodes which
+	// don't respond in the bootstrap timeout, the spans of these nodes are treated as absent.
+	BootstrapSkipTimeoutNodes bool `toml:"bootstrap-skip-timeout-nodes" json:"bootstrap-skip-timeout-nodes,omitempty"`
+	// BootstrapStartTs overrides the start ts reported by the table trigger event dispatcher
+	// in bootstrap, it's used to recover a changefeed whose start ts is lost or invalid.
+	// 0 means using the reported start ts.
+	BootstrapStartTs uint64 `toml:"bootstrap-start-ts" json:"bootstrap-start-ts,omitempty"`
+	// GroupBy decides how the spans covering whole tables are grouped, the spans of each
+	// group are checked and balanced separately. It's one of "schema" and "tag", empty
+	// means all of them are in one group. The spans of a split table are always grouped
+	// by the table.
+	GroupBy string `toml:"group-by" json:"group-by,omitempty"`
+	// GroupTags are the rules to tag the tables when GroupBy is "tag", the first matched
+	// rule decides the tag of a table. The tables can be tagged by their traffic class
+	// or by the tenants they belong to, so each kind of tables is balanced on its own.
+	GroupTags []*GroupTagRule `toml:"group-tags" json:"group-tags,omitempty"`
+}
+
+// GroupTagRule tags the tables matched by the Matcher.
+type GroupTagRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	Tag     string   `toml:"tag" json:"tag"`
 }
 
 // Validate validates the config.
 func (c *ChangefeedSchedulerConfig) Validate() error {
+	switch c.PlacementStrategy {
+	case "", PlacementStrategyBalance, PlacementStrategyConsistentHash:
+	default:
+		return errors.New("placement-strategy must be one of balance and consistent-hash")
+	}
+	if c.MaxSpansPerTablePerNode < 0 {
+		return errors.New("max-spans-per-table-per-node must not be negative")
+	}
+	if c.DDLBatchWindow < 0 || time.Duration(c.DDLBatchWindow) > maxDDLBatchWindow {
+		return errors.New("ddl-batch-window must be in [0s, 10s]")
+	}
+	if c.BootstrapTimeout < 0 {
+		return errors.New("bootstrap-timeout must not be negative")
+	}
+	switch c.GroupBy {
+	case "", GroupBySchema, GroupByTag:
+	default:
+		return errors.New("group-by must be one of schema and tag")
+	}
+	for _, rule := range c.GroupTags {
+		if rule.Tag == "" {
+			return errors.New("the tag of group-tags must not be empty")
+		}
+		if _, err := tfilter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Matcher)
+		}
+	}
 	if !c.EnableTableAcrossNodes {
 		return nil
 	}
@@ -53,8 +144,17 @@ type SchedulerConfig struct {
 	CollectStatsTick int `toml:"collect-stats-tick" json:"collect-stats-tick"`
 	// MaxTaskConcurrency the maximum of concurrent running schedule tasks.
 	MaxTaskConcurrency int `toml:"max-task-concurrency" json:"max-task-concurrency"`
-	// CheckBalanceInterval the interval of balance tables between each capture.
-	CheckBalanceInterval TomlDuration `toml:"check-balance-interval" json:"check-balance-interval"`
+	// CheckBalanceInterval and MaxCheckBalanceInterval bound the interval of balance tables
+	// between each capture. The interval backs off to the max one while the tables are stable,
+	// and is tightened to CheckBalanceInterval when the captures or the tables on them change.
+	CheckBalanceInterval    TomlDuration `toml:"check-balance-interval" json:"check-balance-interval"`
+	MaxCheckBalanceInterval TomlDuration `toml:"max-check-balance-interval" json:"max-check-balance-interval"`
+	// MinChangefeedBalanceInterval and MaxChangefeedBalanceInterval bound the interval
+	// of balancing the changefeeds between each capture. The interval backs off to the max
+	// one while the cluster is stable, and is tightened to the min one when the captures
+	// or the distribution of the changefeeds change.
+	MinChangefeedBalanceInterval TomlDuration `toml:"min-changefeed-balance-interval" json:"min-changefeed-balance-interval"`
+	MaxChangefeedBalanceInterval TomlDuration `toml:"max-changefeed-balance-interval" json:"max-changefeed-balance-interval"`
 	// AddTableBatchSize is the batch size of adding tables on each tick,
 	// used by the `BasicScheduler`.
 	// When the new owner in power, other captures may not online yet, there might have hundreds of
@@ -63,6 +163,12 @@ type SchedulerConfig struct {
 	// When there are only 2 captures, and a large number of tables, this can be helpful to prevent
 	// oom caused by all tables dispatched to only one capture.
 	AddTableBatchSize int `toml:"add-table-batch-size" json:"add-table-batch-size"`
+	// MaintainerPlacementStrategy decides how the changefeed maintainers are balanced
+	// among nodes, it's one of "balance" and "weight", empty means "balance".
+	MaintainerPlacementStrategy string `toml:"maintainer-placement-strategy" json:"maintainer-placement-strategy,omitempty"`
+	// CoordinatorStandby set true to make the node a standby coordinator, it mirrors
+	// the changefeeds before it becomes the coordinator, so it takes over faster.
+	CoordinatorStandby bool `toml:"coordinator-standby" json:"coordinator-standby"`
 
 	// ChangefeedSettings is setting by changefeed.
 	ChangefeedSettings *ChangefeedSchedulerConfig `toml:"-" json:"-"`
@@ -77,8 +183,11 @@ func NewDefaultSchedulerConfig() *SchedulerConfig {
 		CollectStatsTick:   200, // 200 * 50ms = 10s.
 		MaxTaskConcurrency: 10,
 		// TODO: no need to check balance each minute, relax the interval.
-		CheckBalanceInterval: TomlDuration(time.Minute),
-		AddTableBatchSize:    1000,
+		CheckBalanceInterval:         TomlDuration(time.Minute),
+		MaxCheckBalanceInterval:      TomlDuration(5 * time.Minute),
+		MinChangefeedBalanceInterval: TomlDuration(10 * time.Second),
+		MaxChangefeedBalanceInterval: TomlDuration(5 * time.Minute),
+		AddTableBatchSize:            1000,
 	}
 }
 
@@ -100,9 +209,27 @@ func (c *SchedulerConfig) ValidateAndAdjust() error {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
 			"check-balance-interval must be larger than 1s")
 	}
+	if c.MaxCheckBalanceInterval < c.CheckBalanceInterval {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"max-check-balance-interval must not be less than check-balance-interval")
+	}
+	if time.Duration(c.MinChangefeedBalanceInterval) <= time.Second {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"min-changefeed-balance-interval must be larger than 1s")
+	}
+	if c.MaxChangefeedBalanceInterval < c.MinChangefeedBalanceInterval {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"max-changefeed-balance-interval must not be less than min-changefeed-balance-interval")
+	}
 	if c.AddTableBatchSize <= 0 {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
 			"add-table-batch-size must be large than 0")
 	}
+	switch c.MaintainerPlacementStrategy {
+	case "", PlacementStrategyBalance, PlacementStrategyWeight:
+	default:
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"maintainer-placement-strategy must be one of balance and weight")
+	}
 	return nil
 }
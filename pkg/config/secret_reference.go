@@ -0,0 +1,41 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/pingcap/ticdc/pkg/errors"
+)
+
+// SecretReferenceConfig allows the sink credentials to reference the environment variables
+// and the files of the server by env:// and file://. They are readable by anyone who can
+// create a changefeed, so nothing can be referenced unless it's allowed here.
+type SecretReferenceConfig struct {
+	// AllowedEnvs are the environment variables which can be referenced by env://.
+	AllowedEnvs []string `toml:"allowed-envs" json:"allowed-envs,omitempty"`
+	// AllowedDirs are the directories whose files can be referenced by file://.
+	AllowedDirs []string `toml:"allowed-dirs" json:"allowed-dirs,omitempty"`
+}
+
+// ValidateAndAdjust validates the secret reference configuration.
+func (c *SecretReferenceConfig) ValidateAndAdjust() error {
+	for _, dir := range c.AllowedDirs {
+		if !filepath.IsAbs(dir) {
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"secret-reference.allowed-dirs must be absolute paths, got %s", dir)
+		}
+	}
+	return nil
+}
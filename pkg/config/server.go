@@ -103,7 +103,9 @@ var defaultServerConfig = &ServerConfig{
 	},
 	DataDir: "",
 	GcTTL:   24 * 60 * 60, // 24H
-	TZ:      "System",
+	// 0 means the gc safepoint lag is capped by the GC TTL.
+	GcMaxLag: 0,
+	TZ:       "System",
 	// The default election-timeout in PD is 3s and minimum session TTL is 5s,
 	// which is calculated by `math.Ceil(3 * election-timeout / 2)`, we choose
 	// default capture session ttl to 10s to increase robust to PD jitter,
@@ -125,6 +127,8 @@ var defaultServerConfig = &ServerConfig{
 		Puller:       NewDefaultPullerConfig(),
 		SchemaStore:  NewDefaultSchemaStoreConfig(),
 		EventService: NewDefaultEventServiceConfig(),
+		EventStore:   NewDefaultEventStoreConfig(),
+		Tracing:      NewDefaultTracingConfig(),
 	},
 	ClusterID:              "default",
 	GcTunerMemoryThreshold: DisableMemoryLimit,
@@ -142,8 +146,11 @@ type ServerConfig struct {
 
 	DataDir string `toml:"data-dir" json:"data-dir"`
 
-	GcTTL int64  `toml:"gc-ttl" json:"gc-ttl"`
-	TZ    string `toml:"tz" json:"tz"`
+	GcTTL int64 `toml:"gc-ttl" json:"gc-ttl"`
+	// GcMaxLag is the max lag of the TiCDC service gc safepoint behind the current time,
+	// the changefeeds lagging behind it are failed instead of blocking the GC forever.
+	GcMaxLag TomlDuration `toml:"gc-max-lag" json:"gc-max-lag"`
+	TZ       string       `toml:"tz" json:"tz"`
 
 	CaptureSessionTTL int `toml:"capture-session-ttl" json:"capture-session-ttl"`
 
@@ -156,6 +163,32 @@ type ServerConfig struct {
 	Debug                  *DebugConfig         `toml:"debug" json:"debug"`
 	ClusterID              string               `toml:"cluster-id" json:"cluster-id"`
 	GcTunerMemoryThreshold uint64               `toml:"gc-tuner-memory-threshold" json:"gc-tuner-memory-threshold"`
+	// MemorySoftLimit is the memory in bytes held by the matchers, the event store write
+	// buffers and the dispatcher queues beyond which the components are throttled by
+	// their priorities, the dispatcher queues first. 0 means no limit.
+	MemorySoftLimit uint64 `toml:"memory-soft-limit" json:"memory-soft-limit"`
+	// MemoryHardLimit is the memory in bytes beyond which all these components are throttled.
+	// 0 means no limit.
+	MemoryHardLimit uint64 `toml:"memory-hard-limit" json:"memory-hard-limit"`
+
+	// MaxMaintainers is the max number of the changefeeds whose maintainer runs on this node.
+	// 0 means no limit.
+	MaxMaintainers int `toml:"max-maintainers" json:"max-maintainers"`
+	// MaxDispatchers is the max number of the table dispatchers on this node. 0 means no limit.
+	MaxDispatchers int `toml:"max-dispatchers" json:"max-dispatchers"`
+	// MemoryBudget is the max sum in bytes of the memory quotas of the changefeeds
+	// which have dispatchers on this node. 0 means no limit.
+	MemoryBudget uint64 `toml:"memory-budget" json:"memory-budget"`
+
+	// Labels are the deployment labels of this node, such as zone and host,
+	// they are registered with the node and shown in the node list.
+	Labels map[string]string `toml:"labels" json:"labels"`
+
+	// ErrorRetry overrides the default backoff of retrying the errors by their classes.
+	ErrorRetry *ErrorRetryConfig `toml:"error-retry" json:"error-retry,omitempty"`
+	// SecretReference allows the sink credentials to reference the environment variables
+	// and the files of this node, nothing can be referenced if it's nil.
+	SecretReference *SecretReferenceConfig `toml:"secret-reference" json:"secret-reference,omitempty"`
 
 	// Deprecated: we don't use this field anymore.
 	PerTableMemoryQuota uint64 `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
@@ -230,6 +263,20 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.GcTTL == 0 {
 		return cerror.ErrInvalidServerOption.GenWithStack("empty GC TTL is not allowed")
 	}
+	if c.GcMaxLag < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStack("negative GC max lag is not allowed")
+	}
+	if c.MemorySoftLimit > 0 && c.MemoryHardLimit > 0 && c.MemorySoftLimit > c.MemoryHardLimit {
+		return cerror.ErrInvalidServerOption.GenWithStack("memory-soft-limit must not be larger than memory-hard-limit")
+	}
+	if c.MaxMaintainers < 0 || c.MaxDispatchers < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStack("max-maintainers and max-dispatchers must not be negative")
+	}
+	for key := range c.Labels {
+		if strings.TrimSpace(key) == "" {
+			return cerror.ErrInvalidServerOption.GenWithStack("the key of the labels must not be empty")
+		}
+	}
 	// 5s is minimum lease ttl in etcd(PD)
 	if c.CaptureSessionTTL < 5 {
 		log.Warn("capture session ttl too small, set to default value 10s")
@@ -283,6 +330,17 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if err = c.Debug.ValidateAndAdjust(); err != nil {
 		return errors.Trace(err)
 	}
+
+	if c.ErrorRetry != nil {
+		if err = c.ErrorRetry.ValidateAndAdjust(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.SecretReference != nil {
+		if err = c.SecretReference.ValidateAndAdjust(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -16,6 +16,7 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter/matcher"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
@@ -142,6 +144,7 @@ type SinkConfig struct {
 	// SchemaRegistry is only available when the downstream is MQ using avro protocol.
 	SchemaRegistry *string `toml:"schema-registry" json:"schema-registry,omitempty"`
 	// EncoderConcurrency is only available when the downstream is MQ.
+	// It's the max number of encoders, the active ones are scaled by the encode queue latency.
 	EncoderConcurrency *int `toml:"encoder-concurrency" json:"encoder-concurrency,omitempty"`
 	// Terminator is NOT available when the downstream is DB.
 	Terminator *string `toml:"terminator" json:"terminator,omitempty"`
@@ -165,6 +168,10 @@ type SinkConfig struct {
 	// ContentCompatible is only available when the downstream is MQ.
 	ContentCompatible *bool `toml:"content-compatible" json:"content-compatible,omitempty"`
 
+	// SplitHandleKeyUpdate is only available when the downstream is MQ with the canal-json protocol,
+	// the update event which modifies the handle key is encoded as a delete event and an insert event.
+	SplitHandleKeyUpdate *bool `toml:"split-handle-key-update" json:"split-handle-key-update,omitempty"`
+
 	// TiDBSourceID is the source ID of the upstream TiDB,
 	// which is used to set the `tidb_cdc_write_source` session variable.
 	// Note: This field is only used internally and only used in the MySQL sink.
@@ -196,6 +203,42 @@ type SinkConfig struct {
 	SendAllBootstrapAtStart *bool `toml:"send-all-bootstrap-at-start" json:"send-all-bootstrap-at-start,omitempty"`
 	// Debezium only. Whether schema should be excluded in the output.
 	DebeziumDisableSchema *bool `toml:"debezium-disable-schema" json:"debezium-disable-schema,omitempty"`
+	// EnableRowCountAudit determines whether to record the number of rows flushed per table
+	// in each checkpoint interval. The counts are written to the audit table when the downstream
+	// is MySQL, or sent to the RowCountAuditTopic when the downstream is MQ.
+	EnableRowCountAudit *bool `toml:"enable-row-count-audit" json:"enable-row-count-audit,omitempty"`
+	// RowCountAuditTopic is the dedicated topic the row count audit messages are sent to,
+	// so the consumers of the data topics are not affected. It's required for the MQ sink
+	// if the row count audit is enabled.
+	RowCountAuditTopic *string `toml:"row-count-audit-topic" json:"row-count-audit-topic,omitempty"`
+	// AdditionalSinkURIs are the extra targets the changefeed writes to besides the sink-uri.
+	// The data is pulled once and fanned out to all targets, each target flushes independently
+	// and the changefeed checkpoint is the minimum of the checkpoints of all targets.
+	// It is only available when the downstreams are not MySQL.
+	AdditionalSinkURIs []string `toml:"additional-sink-uris" json:"additional-sink-uris,omitempty"`
+	// DeadLetterQueue is used to divert the rows which repeatedly fail to be encoded or applied
+	// to the downstream, instead of stopping the changefeed. It's disabled if it's nil.
+	DeadLetterQueue *DeadLetterQueueConfig `toml:"dead-letter-queue" json:"dead-letter-queue,omitempty"`
+	// DDLTopic routes the DDL events to a dedicated topic instead of the topics of the tables.
+	// It is only available when the downstream is Kafka.
+	DDLTopic *DDLTopicConfig `toml:"ddl-topic" json:"ddl-topic,omitempty"`
+	// MaxRowsPerSecond and MaxBytesPerSecond limit the rows and bytes the changefeed emits
+	// to the downstream per second on each node. The limit is disabled if it's nil or not positive.
+	MaxRowsPerSecond  *int64 `toml:"max-rows-per-second" json:"max-rows-per-second,omitempty"`
+	MaxBytesPerSecond *int64 `toml:"max-bytes-per-second" json:"max-bytes-per-second,omitempty"`
+	// RoutingRules map the upstream schema and table names to the downstream ones, such as
+	// merging the sharded tables into one table. The first matched rule is applied to the rows,
+	// the DDL statements and the schema and table names in the MQ messages.
+	RoutingRules []*RoutingRule `toml:"routing-rules" json:"routing-rules,omitempty"`
+	// Watermark sends the watermark messages to the topics periodically even if the tables are idle.
+	// It is only available when the downstream is MQ.
+	Watermark *WatermarkConfig `toml:"watermark" json:"watermark,omitempty"`
+	// TTLDeletes decides how to handle the rows deleted by the TiDB TTL jobs.
+	// They are replicated as the other rows if it's nil. It is only available when the downstream is MQ.
+	TTLDeletes *TTLDeletesConfig `toml:"ttl-deletes" json:"ttl-deletes,omitempty"`
+	// RemovalCleanup is the cleanup done in the downstream when the changefeed is removed,
+	// nothing is cleaned up if it's nil.
+	RemovalCleanup *RemovalCleanupConfig `toml:"removal-cleanup" json:"removal-cleanup,omitempty"`
 
 	// CSVConfig is only available when the downstream is Storage.
 	CSVConfig *CSVConfig `toml:"csv" json:"csv,omitempty"`
@@ -221,6 +264,9 @@ func (s *SinkConfig) MaskSensitiveData() {
 	if s.PulsarConfig != nil {
 		s.PulsarConfig.MaskSensitiveData()
 	}
+	for i, uri := range s.AdditionalSinkURIs {
+		s.AdditionalSinkURIs[i] = util.MaskSensitiveDataInURI(uri)
+	}
 }
 
 // ShouldSendBootstrapMsg returns whether the sink should send bootstrap message.
@@ -397,6 +443,11 @@ type DispatchRule struct {
 	Columns []string `toml:"columns" json:"columns"`
 
 	TopicRule string `toml:"topic" json:"topic"`
+
+	// Protocol overrides the protocol of the sink for the matched tables, their row changed,
+	// DDL and checkpoint events are encoded by it. The tables must be routed to the topics
+	// of their own by the TopicRule. Empty means the protocol of the sink.
+	Protocol string `toml:"protocol" json:"protocol,omitempty"`
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -411,8 +462,10 @@ type CodecConfig struct {
 	MaxBatchSize                   *int    `toml:"max-batch-size" json:"max-batch-size,omitempty"`
 	AvroEnableWatermark            *bool   `toml:"avro-enable-watermark" json:"avro-enable-watermark"`
 	AvroDecimalHandlingMode        *string `toml:"avro-decimal-handling-mode" json:"avro-decimal-handling-mode,omitempty"`
+	AvroDecimalMaxPrecision        *int    `toml:"avro-decimal-max-precision" json:"avro-decimal-max-precision,omitempty"`
 	AvroBigintUnsignedHandlingMode *string `toml:"avro-bigint-unsigned-handling-mode" json:"avro-bigint-unsigned-handling-mode,omitempty"`
 	EncodingFormat                 *string `toml:"encoding-format" json:"encoding-format,omitempty"`
+	DebeziumEmitTombstone          *bool   `toml:"debezium-emit-tombstone" json:"debezium-emit-tombstone,omitempty"`
 }
 
 // KafkaConfig represents a kafka sink configuration
@@ -427,6 +480,8 @@ type KafkaConfig struct {
 	DialTimeout                  *string                   `toml:"dial-timeout" json:"dial-timeout,omitempty"`
 	WriteTimeout                 *string                   `toml:"write-timeout" json:"write-timeout,omitempty"`
 	ReadTimeout                  *string                   `toml:"read-timeout" json:"read-timeout,omitempty"`
+	BatchLinger                  *string                   `toml:"batch-linger" json:"batch-linger,omitempty"`
+	BatchBytes                   *int                      `toml:"batch-bytes" json:"batch-bytes,omitempty"`
 	RequiredAcks                 *int                      `toml:"required-acks" json:"required-acks,omitempty"`
 	SASLUser                     *string                   `toml:"sasl-user" json:"sasl-user,omitempty"`
 	SASLPassword                 *string                   `toml:"sasl-password" json:"sasl-password,omitempty"`
@@ -682,6 +737,78 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `toml:"enable-batch-dml" json:"enable-batch-dml,omitempty"`
 	EnableMultiStatement         *bool   `toml:"enable-multi-statement" json:"enable-multi-statement,omitempty"`
 	EnableCachePreparedStatement *bool   `toml:"enable-cache-prepared-statement" json:"enable-cache-prepared-statement,omitempty"`
+	// SessionVariables are set on all the connections to the downstream, such as sql_mode,
+	// time_zone and tidb_skip_constraint_check, they override the ones set by TiCDC.
+	SessionVariables map[string]string `toml:"session-variables" json:"session-variables,omitempty"`
+	// DDLDryRun determines whether the DDLs are only validated against the downstream instead of
+	// being executed, it's for the users who manage the downstream schema manually. The DDLs which
+	// would run and the validation results are logged and recorded in the ddl history if it's enabled.
+	DDLDryRun *bool `toml:"ddl-dry-run" json:"ddl-dry-run,omitempty"`
+	// ExactlyOnce determines whether the last applied commit ts of each dispatcher is written to the
+	// downstream in the same transaction as its rows, so the transactions replayed after a failover
+	// are filtered instead of being applied again.
+	ExactlyOnce *bool `toml:"exactly-once" json:"exactly-once,omitempty"`
+	// TableOverrides give the matched tables their own dml workers, so a large table can be
+	// applied by more workers or with larger transactions without affecting the other tables.
+	// The first matched override is applied to a table.
+	TableOverrides []*MySQLTableOverride `toml:"table-overrides" json:"table-overrides,omitempty"`
+}
+
+// MySQLTableOverride is the sink concurrency of the tables matched by the Matcher.
+type MySQLTableOverride struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// WorkerCount is the number of the dml workers dedicated to the matched tables.
+	// The rows of a table are distributed among the workers by the table spans,
+	// so the table must be split to be applied concurrently.
+	WorkerCount int `toml:"worker-count" json:"worker-count"`
+	// MaxTxnRow is the max number of rows in a transaction of the matched tables,
+	// the global max-txn-row is used if it's 0.
+	MaxTxnRow int `toml:"max-txn-row" json:"max-txn-row,omitempty"`
+}
+
+var sessionVariableNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedSessionVariableNames are the parameters of the mysql driver,
+// they can't be used as the names of the session variables.
+var reservedSessionVariableNames = map[string]struct{}{
+	"allowallfiles": {}, "allowcleartextpasswords": {}, "allowfallbacktoplaintext": {},
+	"allownativepasswords": {}, "allowoldpasswords": {}, "charset": {}, "checkconnliveness": {},
+	"clientfoundrows": {}, "collation": {}, "columnswithalias": {}, "connectionattributes": {},
+	"interpolateparams": {}, "loc": {}, "maxallowedpacket": {}, "multistatements": {},
+	"parsetime": {}, "readtimeout": {}, "rejectreadonly": {}, "serverpubkey": {},
+	"timeout": {}, "tls": {}, "writetimeout": {},
+}
+
+func (c *MySQLConfig) validateSessionVariables() error {
+	for name := range c.SessionVariables {
+		if !sessionVariableNameRe.MatchString(name) {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("invalid session variable name %q in mysql-config", name))
+		}
+		if _, ok := reservedSessionVariableNames[strings.ToLower(name)]; ok {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("%s is a parameter of the mysql driver, it can't be set as a session variable", name))
+		}
+	}
+	return nil
+}
+
+func (c *MySQLConfig) validateTableOverrides() error {
+	for _, override := range c.TableOverrides {
+		// the rules are verified with their case folding too, which are used by a case-insensitive changefeed
+		if _, err := matcher.New(override.Matcher, false); err != nil {
+			return err
+		}
+		if override.WorkerCount <= 0 {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("the worker-count of the table override %v must be greater than 0", override.Matcher))
+		}
+		if override.MaxTxnRow < 0 {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("the max-txn-row of the table override %v can't be negative", override.Matcher))
+		}
+	}
+	return nil
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -712,6 +839,49 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 		return err
 	}
 
+	if err := s.validateAdditionalSinkURIs(sinkURI); err != nil {
+		return err
+	}
+
+	if s.DeadLetterQueue != nil && sinkURI != nil &&
+		sink.IsMQScheme(sinkURI.Scheme) && s.DeadLetterQueue.Topic == "" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"the topic of dead-letter-queue must be set when the downstream is MQ")
+	}
+
+	if err := s.validateRowCountAuditTopic(sinkURI); err != nil {
+		return err
+	}
+
+	if err := s.validateDDLTopic(sinkURI); err != nil {
+		return err
+	}
+
+	if err := s.validateRoutingRules(); err != nil {
+		return err
+	}
+
+	if err := s.validateWatermark(sinkURI); err != nil {
+		return err
+	}
+
+	if err := s.validateTTLDeletes(sinkURI); err != nil {
+		return err
+	}
+
+	if err := s.validateRemovalCleanup(sinkURI); err != nil {
+		return err
+	}
+
+	if s.MySQLConfig != nil {
+		if err := s.MySQLConfig.validateSessionVariables(); err != nil {
+			return err
+		}
+		if err := s.MySQLConfig.validateTableOverrides(); err != nil {
+			return err
+		}
+	}
+
 	if sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
 		return nil
 	}
@@ -776,6 +946,15 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 			rule.PartitionRule = rule.DispatcherRule
 			rule.DispatcherRule = ""
 		}
+		if rule.Protocol == "" {
+			continue
+		}
+		if _, err := ParseSinkProtocolFromString(rule.Protocol); err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+	}
+	if err := s.validateProtocolTopics(protocol); err != nil {
+		return err
 	}
 
 	if util.GetOrZero(s.EncoderConcurrency) < 0 {
@@ -826,6 +1005,365 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 	return nil
 }
 
+// DeadLetterQueueConfig represents the dead letter queue config of the sink.
+// The unprocessable rows are written into the tidb_cdc.dead_letter_queue table
+// when the downstream is MySQL, or sent to the Topic when the downstream is Kafka.
+type DeadLetterQueueConfig struct {
+	// Topic is the kafka topic the dead letter messages are sent to, it's required for the MQ sink.
+	Topic string `toml:"topic" json:"topic"`
+}
+
+// DDLTopicConfig represents the dedicated topic of the DDL events.
+// The DDL events are encoded by their own protocol, so the consumers of the
+// data topics are not affected by them, e.g. the data is encoded by avro while
+// the DDL events are encoded by canal-json.
+type DDLTopicConfig struct {
+	// Topic is the name of the DDL topic, it's required.
+	Topic string `toml:"topic" json:"topic"`
+	// Partition is the partition the DDL events are sent to. If it's not set,
+	// the DDL events are dispatched by the rule of the protocol, that is
+	// partition 0 for canal-json and all the partitions for the other protocols.
+	Partition *int32 `toml:"partition" json:"partition,omitempty"`
+	// Protocol is the protocol to encode the DDL events, it's the protocol of the sink by default.
+	Protocol *string `toml:"protocol" json:"protocol,omitempty"`
+}
+
+// GetProtocol returns the protocol of the DDL topic.
+func (c *DDLTopicConfig) GetProtocol(sinkProtocol string) string {
+	if c.Protocol != nil && *c.Protocol != "" {
+		return *c.Protocol
+	}
+	return sinkProtocol
+}
+
+// The actions of the rows deleted by the TiDB TTL jobs.
+const (
+	// TTLDeleteActionReplicate replicates the TTL deletes as the other rows.
+	TTLDeleteActionReplicate = "replicate"
+	// TTLDeleteActionDrop drops the TTL deletes.
+	TTLDeleteActionDrop = "drop"
+	// TTLDeleteActionRoute sends the TTL deletes to a separate topic.
+	TTLDeleteActionRoute = "route"
+)
+
+// TTLDeletesConfig represents how to handle the rows deleted by the TiDB TTL jobs.
+// A deleted row is considered as a TTL delete if its transaction is marked as a
+// TTL job transaction in the txn source by the upstream TiDB.
+type TTLDeletesConfig struct {
+	// Action is one of replicate, drop and route.
+	Action string `toml:"action" json:"action"`
+	// Topic is the topic the TTL deletes are sent to, it's required if the action is route.
+	Topic string `toml:"topic" json:"topic,omitempty"`
+}
+
+// GetAction returns the action of the TTL deletes, it's replicate if not set.
+func (c *TTLDeletesConfig) GetAction() string {
+	if c == nil || c.Action == "" {
+		return TTLDeleteActionReplicate
+	}
+	return c.Action
+}
+
+func (s *SinkConfig) validateTTLDeletes(sinkURI *url.URL) error {
+	if s.TTLDeletes == nil {
+		return nil
+	}
+	if sinkURI != nil && !sink.IsMQScheme(sinkURI.Scheme) {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"ttl-deletes is only available when the downstream is MQ")
+	}
+	switch s.TTLDeletes.GetAction() {
+	case TTLDeleteActionReplicate, TTLDeleteActionDrop:
+	case TTLDeleteActionRoute:
+		if s.TTLDeletes.Topic == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"the topic of ttl-deletes must be set if the action is route")
+		}
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("the action of ttl-deletes must be %s, %s or %s, but got %s",
+				TTLDeleteActionReplicate, TTLDeleteActionDrop, TTLDeleteActionRoute, s.TTLDeletes.Action))
+	}
+	return nil
+}
+
+// RemovalCleanupConfig is the cleanup done in the downstream when the changefeed is removed,
+// so the downstream consumers get a deterministic end of the stream.
+type RemovalCleanupConfig struct {
+	// DropSyncPointRows deletes the rows of the changefeed in the syncpoint table.
+	// It is only available when the downstream is MySQL compatible.
+	DropSyncPointRows *bool `toml:"drop-syncpoint-rows" json:"drop-syncpoint-rows,omitempty"`
+	// WriteEOFMessage broadcasts an EOF message to all partitions of the topics after the final
+	// checkpoint. The EOF is a JSON control message keyed by "ticdc-eof", whatever the protocol is.
+	// It is only available when the downstream is MQ.
+	WriteEOFMessage *bool `toml:"write-eof-message" json:"write-eof-message,omitempty"`
+}
+
+func (s *SinkConfig) validateRemovalCleanup(sinkURI *url.URL) error {
+	if s.RemovalCleanup == nil || sinkURI == nil {
+		return nil
+	}
+	if util.GetOrZero(s.RemovalCleanup.DropSyncPointRows) && !sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"drop-syncpoint-rows of removal-cleanup is only available when the downstream is MySQL compatible")
+	}
+	if util.GetOrZero(s.RemovalCleanup.WriteEOFMessage) && !sink.IsMQScheme(sinkURI.Scheme) {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"write-eof-message of removal-cleanup is only available when the downstream is MQ")
+	}
+	return nil
+}
+
+// hardCodeTopicRe matches the topic rules without any placeholder,
+// the matched tables are routed to the default topic.
+var hardCodeTopicRe = regexp.MustCompile(`^([A-Za-z0-9\._\-]+)$`)
+
+// validateProtocolTopics checks the topics of the dispatch rules overriding the protocol of the sink,
+// the messages of different protocols can't be consumed from the same topic, so a rule with an
+// override protocol must not route the tables to the default topic or the topic of another protocol.
+func (s *SinkConfig) validateProtocolTopics(protocol Protocol) error {
+	// the tables not matched by any rule are routed to the default topic by the protocol of the sink,
+	// the empty topic rule stands for the default topic.
+	topicProtocols := map[string]Protocol{"": protocol}
+	for _, rule := range s.DispatchRules {
+		ruleProtocol := protocol
+		if rule.Protocol != "" {
+			ruleProtocol, _ = ParseSinkProtocolFromString(rule.Protocol)
+		}
+		topic := rule.TopicRule
+		if hardCodeTopicRe.MatchString(topic) {
+			topic = ""
+		}
+		existing, ok := topicProtocols[topic]
+		if !ok {
+			topicProtocols[topic] = ruleProtocol
+			continue
+		}
+		if existing == ruleProtocol {
+			continue
+		}
+		if topic == "" {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"the dispatcher with protocol %s must not route the tables to the default topic "+
+					"of protocol %s, matcher: %v, topic: %s",
+				ruleProtocol, existing, rule.Matcher, rule.TopicRule)
+		}
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"the dispatcher with protocol %s must not route the tables to the topic %s "+
+				"of protocol %s, matcher: %v",
+			ruleProtocol, rule.TopicRule, existing, rule.Matcher)
+	}
+	return nil
+}
+
+func (s *SinkConfig) validateRowCountAuditTopic(sinkURI *url.URL) error {
+	if !util.GetOrZero(s.EnableRowCountAudit) || sinkURI == nil || !sink.IsMQScheme(sinkURI.Scheme) {
+		return nil
+	}
+	topic := util.GetOrZero(s.RowCountAuditTopic)
+	if topic == "" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"row-count-audit-topic must be set when the row count audit is enabled and the downstream is MQ")
+	}
+	if topic == strings.TrimFunc(sinkURI.Path, func(r rune) bool { return r == '/' }) {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("row-count-audit-topic %s must not be the default topic of the changefeed", topic))
+	}
+	return nil
+}
+
+func (s *SinkConfig) validateDDLTopic(sinkURI *url.URL) error {
+	if s.DDLTopic == nil {
+		return nil
+	}
+	if sinkURI != nil && !sink.IsMQScheme(sinkURI.Scheme) {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"ddl-topic is only available when the downstream is MQ")
+	}
+	if s.DDLTopic.Topic == "" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"the topic of ddl-topic must be set")
+	}
+	if util.GetOrZero(s.DDLTopic.Partition) < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("the partition of ddl-topic must not be negative, but got %d", *s.DDLTopic.Partition))
+	}
+	// The DDL events are encoded by the protocol of the sink if the DDL topic
+	// doesn't have its own, which must be able to encode the DDL events too.
+	name := s.DDLTopic.GetProtocol(util.GetOrZero(s.Protocol))
+	if name == "" {
+		return nil
+	}
+	protocol, err := ParseSinkProtocolFromString(name)
+	if err != nil {
+		return err
+	}
+	switch protocol {
+	case ProtocolAvro, ProtocolCsv:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("protocol %s can not be used to encode the DDL events of ddl-topic", protocol))
+	}
+	return nil
+}
+
+// RoutingRule maps the upstream tables whose names match the source patterns to the downstream.
+// The patterns are regular expressions matching the whole names, and the targets are templates
+// which can refer to the capture groups by ${1} or ${name}, the capture groups of source-schema are
+// numbered before the ones of source-table. For example, the rule
+//
+//	source-schema = "shard_(\\d+)", source-table = "orders_(\\d+)", target-schema = "merged", target-table = "orders"
+//
+// merges all the sharded orders tables into merged.orders.
+type RoutingRule struct {
+	SourceSchema string `toml:"source-schema" json:"source-schema"`
+	// SourceTable matches all the tables if it's empty, and the rule is applied to the DDL
+	// statements of the schema, such as CREATE DATABASE, as well.
+	SourceTable string `toml:"source-table" json:"source-table"`
+	// TargetSchema and TargetTable keep the upstream names if they are empty.
+	TargetSchema string `toml:"target-schema" json:"target-schema"`
+	TargetTable  string `toml:"target-table" json:"target-table"`
+}
+
+func (s *SinkConfig) validateRoutingRules() error {
+	for _, rule := range s.RoutingRules {
+		if rule.SourceSchema == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"the source-schema of the routing rule must be set")
+		}
+		if rule.TargetSchema == "" && rule.TargetTable == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("the routing rule of %s.%s must set target-schema or target-table",
+					rule.SourceSchema, rule.SourceTable))
+		}
+		for _, pattern := range []string{rule.SourceSchema, rule.SourceTable} {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+					fmt.Sprintf("invalid pattern %s in the routing rule: %s", pattern, err.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	// WatermarkScopePartition sends the watermark messages to all the partitions of the topics.
+	WatermarkScopePartition = "partition"
+	// WatermarkScopeTopic sends the watermark messages to the first partition of the topics.
+	WatermarkScopeTopic = "topic"
+
+	minWatermarkInterval = 100 * time.Millisecond
+)
+
+// WatermarkConfig is the config of the watermark messages. The checkpoint of the changefeed is sent
+// to the topics as a watermark message when it advances, and it's sent again if no watermark message
+// has been sent in the interval, so the consumers can tell the topics are complete up to the watermark
+// without waiting for the traffic of the idle tables.
+type WatermarkConfig struct {
+	// Interval is the max interval between two watermark messages, such as "1s".
+	Interval string `toml:"interval" json:"interval"`
+	// Scope is "partition" or "topic", it's "partition" by default.
+	Scope string `toml:"scope" json:"scope,omitempty"`
+}
+
+// GetInterval returns the interval of the watermark messages.
+func (c *WatermarkConfig) GetInterval() time.Duration {
+	interval, _ := time.ParseDuration(c.Interval)
+	return interval
+}
+
+// GetScope returns the scope of the watermark messages.
+func (c *WatermarkConfig) GetScope() string {
+	if c.Scope == "" {
+		return WatermarkScopePartition
+	}
+	return c.Scope
+}
+
+func (s *SinkConfig) validateWatermark(sinkURI *url.URL) error {
+	if s.Watermark == nil {
+		return nil
+	}
+	if sinkURI != nil && !sink.IsMQScheme(sinkURI.Scheme) {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"watermark is only available when the downstream is MQ")
+	}
+	interval, err := time.ParseDuration(s.Watermark.Interval)
+	if err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("invalid interval %s of watermark: %s", s.Watermark.Interval, err.Error()))
+	}
+	if interval < minWatermarkInterval {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("the interval of watermark must not be less than %s, but got %s",
+				minWatermarkInterval, s.Watermark.Interval))
+	}
+	switch s.Watermark.GetScope() {
+	case WatermarkScopePartition, WatermarkScopeTopic:
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("the scope of watermark must be %s or %s, but got %s",
+				WatermarkScopePartition, WatermarkScopeTopic, s.Watermark.Scope))
+	}
+
+	protocol, _ := ParseSinkProtocolFromString(util.GetOrZero(s.Protocol))
+	switch protocol {
+	case ProtocolOpen, ProtocolSimple:
+	case ProtocolCanalJSON:
+		// the watermark messages of canal-json are in the TiDB extension
+		enableTiDBExtension := s.KafkaConfig != nil && s.KafkaConfig.CodecConfig != nil &&
+			util.GetOrZero(s.KafkaConfig.CodecConfig.EnableTiDBExtension)
+		if sinkURI != nil {
+			if v := sinkURI.Query().Get("enable-tidb-extension"); v != "" {
+				enableTiDBExtension, err = strconv.ParseBool(v)
+				if err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		if !enableTiDBExtension {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"watermark requires enable-tidb-extension when the protocol is canal-json")
+		}
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("watermark is not supported by the protocol %s", protocol))
+	}
+	return nil
+}
+
+// validateAdditionalSinkURIs checks the additional sink uris can be fanned out together with the sink uri.
+// The MySQL sink is not supported because the start ts of its dispatchers depends on the ddl ts
+// recorded in the downstream, which can not be shared among multiple targets.
+func (s *SinkConfig) validateAdditionalSinkURIs(sinkURI *url.URL) error {
+	if len(s.AdditionalSinkURIs) == 0 {
+		return nil
+	}
+	if sinkURI != nil && sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
+		return cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+			"additional-sink-uris can not be used with the mysql compatible sink-uri")
+	}
+	seen := make(map[string]struct{}, len(s.AdditionalSinkURIs)+1)
+	if sinkURI != nil {
+		seen[sinkURI.String()] = struct{}{}
+	}
+	for _, uri := range s.AdditionalSinkURIs {
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		}
+		if sink.IsMySQLCompatibleScheme(parsed.Scheme) {
+			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+				fmt.Sprintf("mysql compatible sink %s can not be used as an additional sink", parsed.Scheme))
+		}
+		if _, ok := seen[parsed.String()]; ok {
+			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+				fmt.Sprintf("duplicated sink uri %s", util.MaskSensitiveDataInURI(uri)))
+		}
+		seen[parsed.String()] = struct{}{}
+	}
+	return nil
+}
+
 // validateAndAdjustSinkURI validate and adjust `Protocol` and `TxnAtomicity` by sinkURI.
 func (s *SinkConfig) validateAndAdjustSinkURI(sinkURI *url.URL) error {
 	if sinkURI == nil {
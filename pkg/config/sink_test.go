@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDDLTopic(t *testing.T) {
+	cases := []struct {
+		sinkProtocol string
+		ddlProtocol  *string
+		valid        bool
+	}{
+		{"canal-json", nil, true},
+		{"avro", util.AddressOf("canal-json"), true},
+		{"open-protocol", util.AddressOf(""), true},
+		// the DDL topic uses the protocol of the sink if it doesn't have its own.
+		{"avro", nil, false},
+		{"avro", util.AddressOf(""), false},
+		{"canal-json", util.AddressOf("avro"), false},
+		{"canal-json", util.AddressOf("unknown"), false},
+	}
+	for _, c := range cases {
+		sinkURI, err := url.Parse("kafka://127.0.0.1:9092/topic?protocol=" + c.sinkProtocol)
+		require.NoError(t, err)
+		s := GetDefaultReplicaConfig().Sink
+		s.DDLTopic = &DDLTopicConfig{Topic: "ddl", Protocol: c.ddlProtocol}
+		err = s.validateAndAdjust(sinkURI)
+		if c.valid {
+			require.NoError(t, err, c)
+			continue
+		}
+		require.Error(t, err, c)
+	}
+
+	s := GetDefaultReplicaConfig().Sink
+	s.DDLTopic = &DDLTopicConfig{Topic: "ddl"}
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	err = s.validateAndAdjust(sinkURI)
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+}
+
+func TestValidateProtocolTopics(t *testing.T) {
+	cases := []struct {
+		rules []*DispatchRule
+		valid bool
+	}{
+		{[]*DispatchRule{{Matcher: []string{"a.*"}, TopicRule: "a_{table}", Protocol: "canal-json"}}, true},
+		// the protocol of the sink is not overridden
+		{[]*DispatchRule{{Matcher: []string{"a.*"}, TopicRule: "a", Protocol: "open-protocol"}}, true},
+		{[]*DispatchRule{
+			{Matcher: []string{"a.*"}, TopicRule: "{schema}_{table}", Protocol: "canal-json"},
+			{Matcher: []string{"b.*"}, TopicRule: "{schema}_{table}", Protocol: "canal-json"},
+		}, true},
+		// the empty and the hard coded topics are the default topic
+		{[]*DispatchRule{{Matcher: []string{"a.*"}, Protocol: "canal-json"}}, false},
+		{[]*DispatchRule{{Matcher: []string{"a.*"}, TopicRule: "a", Protocol: "canal-json"}}, false},
+		{[]*DispatchRule{
+			{Matcher: []string{"a.*"}, TopicRule: "{schema}_{table}", Protocol: "canal-json"},
+			{Matcher: []string{"b.*"}, TopicRule: "{schema}_{table}"},
+		}, false},
+	}
+	for _, c := range cases {
+		sinkURI, err := url.Parse("kafka://127.0.0.1:9092/topic?protocol=open-protocol")
+		require.NoError(t, err)
+		s := GetDefaultReplicaConfig().Sink
+		s.Protocol = util.AddressOf("open-protocol")
+		s.DispatchRules = c.rules
+		err = s.validateAndAdjust(sinkURI)
+		if c.valid {
+			require.NoError(t, err, c)
+			continue
+		}
+		require.True(t, cerror.ErrSinkInvalidConfig.Equal(err), c)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// UnknownField is a field of the config which matches no valid field.
+type UnknownField struct {
+	// Path is the dotted path of the field, such as "sink.protocl".
+	Path string
+	// Suggestion is the closest valid name of the field, it's empty if
+	// no valid name is similar enough to be a misspelling.
+	Suggestion string
+}
+
+func (f UnknownField) String() string {
+	if f.Suggestion == "" {
+		return fmt.Sprintf("%q", f.Path)
+	}
+	return fmt.Sprintf("%q (did you mean %q?)", f.Path, f.Suggestion)
+}
+
+// NewUnknownFieldsError returns the error listing the unknown fields of the config.
+func NewUnknownFieldsError(source string, fields []UnknownField) error {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.String())
+	}
+	return cerror.ErrConfigUnknownField.GenWithStackByArgs(source, strings.Join(names, ", "))
+}
+
+// StrictDecodeReplicaConfigFile decodes the TOML file of the changefeed config into cfg,
+// it fails if the file contains any unknown field, instead of ignoring it silently.
+func StrictDecodeReplicaConfigFile(path string, cfg *ReplicaConfig) error {
+	raw := make(map[string]interface{})
+	if _, err := toml.DecodeFile(path, &raw); err != nil {
+		return cerror.WrapError(cerror.ErrDecodeFailed, err)
+	}
+	if fields := FindUnknownFields(raw, reflect.TypeOf(cfg), "toml"); len(fields) > 0 {
+		return NewUnknownFieldsError(path, fields)
+	}
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return cerror.WrapError(cerror.ErrDecodeFailed, err)
+	}
+	return nil
+}
+
+// StrictUnmarshalJSON unmarshals the JSON data into v, it fails if the data
+// contains any field not defined by the json tags of v.
+func StrictUnmarshalJSON(source string, data []byte, v interface{}) error {
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return cerror.WrapError(cerror.ErrDecodeFailed, err)
+	}
+	if fields := FindUnknownFields(raw, reflect.TypeOf(v), "json"); len(fields) > 0 {
+		return NewUnknownFieldsError(source, fields)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return cerror.WrapError(cerror.ErrDecodeFailed, err)
+	}
+	return nil
+}
+
+// FindUnknownFields returns the fields of the raw config which match no field of the type t,
+// the names of the fields are read from the given struct tag, and matched case-insensitively
+// like the decoders do. The unknown fields are sorted by their paths.
+func FindUnknownFields(raw map[string]interface{}, t reflect.Type, tag string) []UnknownField {
+	var fields []UnknownField
+	findUnknownFields(raw, t, tag, "", true, &fields)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+func findUnknownFields(raw interface{}, t reflect.Type, tag, path string, root bool, fields *[]UnknownField) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// the types decoded by themselves are not checked, except the root type, which
+	// may implement the unmarshaler only to fill the fields in a compatible way.
+	ptr := reflect.PointerTo(t)
+	if ptr.Implements(textUnmarshalerType) || (!root && tag == "json" && ptr.Implements(jsonUnmarshalerType)) {
+		return
+	}
+
+	switch values := raw.(type) {
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, v := range values {
+				findUnknownFields(v, t.Elem(), tag, fmt.Sprintf("%s[%d]", path, i), false, fields)
+			}
+		}
+	case []map[string]interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, v := range values {
+				findUnknownFields(v, t.Elem(), tag, fmt.Sprintf("%s[%d]", path, i), false, fields)
+			}
+		}
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for k, v := range values {
+				findUnknownFields(v, t.Elem(), tag, joinFieldPath(path, k), false, fields)
+			}
+		case reflect.Struct:
+			known := structFields(t, tag)
+			for k, v := range values {
+				field, ok := known[strings.ToLower(k)]
+				if !ok {
+					names := make([]string, 0, len(known))
+					for _, f := range known {
+						names = append(names, f.name)
+					}
+					*fields = append(*fields, UnknownField{
+						Path:       joinFieldPath(path, k),
+						Suggestion: ClosestName(k, names),
+					})
+					continue
+				}
+				findUnknownFields(v, field.typ, tag, joinFieldPath(path, k), false, fields)
+			}
+		}
+	}
+}
+
+type structField struct {
+	name string
+	typ  reflect.Type
+}
+
+// structFields returns the fields of the struct keyed by the lower case of their names,
+// the fields of the embedded structs without a name are promoted.
+func structFields(t reflect.Type, tag string) map[string]structField {
+	result := make(map[string]structField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range structFields(embedded, tag) {
+					result[k] = v
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		result[strings.ToLower(name)] = structField{name: name, typ: f.Type}
+	}
+	return result
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// ClosestName returns the candidate most similar to the input if it's likely a misspelling
+// of the candidate, and an empty string otherwise.
+func ClosestName(input string, candidates []string) string {
+	input = strings.ToLower(input)
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := editDistance(input, strings.ToLower(c))
+		if bestDistance < 0 || d < bestDistance || (d == bestDistance && c < best) {
+			best, bestDistance = c, d
+		}
+	}
+	// the candidate is too different to be a misspelling.
+	if bestDistance < 0 || bestDistance > len(best)/3+1 {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance of a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
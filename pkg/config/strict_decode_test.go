@@ -0,0 +1,74 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecodeReplicaConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "changefeed.toml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+memory-quota = 1024
+[sink]
+protocol = "canal-json"
+`), 0o644))
+	cfg := GetDefaultReplicaConfig()
+	require.NoError(t, StrictDecodeReplicaConfigFile(path, cfg))
+	require.Equal(t, uint64(1024), cfg.MemoryQuota)
+	require.Equal(t, "canal-json", *cfg.Sink.Protocol)
+
+	// the unknown fields are reported together with the closest valid names
+	require.NoError(t, os.WriteFile(path, []byte(`
+memroy-quota = 1024
+[sink]
+protocl = "canal-json"
+unrelated-field = 1
+`), 0o644))
+	err := StrictDecodeReplicaConfigFile(path, GetDefaultReplicaConfig())
+	require.True(t, cerror.ErrConfigUnknownField.Equal(err))
+	require.ErrorContains(t, err, `"memroy-quota" (did you mean "memory-quota"?)`)
+	require.ErrorContains(t, err, `"sink.protocl" (did you mean "protocol"?)`)
+	require.ErrorContains(t, err, `"sink.unrelated-field"`)
+	require.NotContains(t, err.Error(), `"sink.unrelated-field" (did you mean`)
+}
+
+func TestStrictUnmarshalJSON(t *testing.T) {
+	cfg := GetDefaultReplicaConfig()
+	require.NoError(t, StrictUnmarshalJSON("in the request body",
+		[]byte(`{"memory-quota": 1024, "sink": {"protocol": "canal-json"}}`), cfg))
+	require.Equal(t, uint64(1024), cfg.MemoryQuota)
+
+	err := StrictUnmarshalJSON("in the request body",
+		[]byte(`{"memory-quota": 1024, "sink": {"protocal": "canal-json"}}`), GetDefaultReplicaConfig())
+	require.True(t, cerror.ErrConfigUnknownField.Equal(err))
+	require.ErrorContains(t, err, `"sink.protocal" (did you mean "protocol"?)`)
+}
+
+func TestReplicaConfigVersion(t *testing.T) {
+	cfg := GetDefaultReplicaConfig()
+	cfg.ConfigVersion = 0
+	require.NoError(t, cfg.migrate())
+	require.Equal(t, 1, cfg.ConfigVersion)
+
+	cfg.ConfigVersion = CurrentReplicaConfigVersion + 1
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(cfg.migrate()))
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// UpgradeState is the state of the rolling upgrade of the cluster.
+type UpgradeState string
+
+const (
+	// UpgradeStateNone means no rolling upgrade is running.
+	UpgradeStateNone UpgradeState = "none"
+	// UpgradeStateRunning means the nodes are being upgraded one by one.
+	UpgradeStateRunning UpgradeState = "running"
+	// UpgradeStateFinished means all nodes are upgraded.
+	UpgradeStateFinished UpgradeState = "finished"
+)
+
+// UpgradeNodeState is the state of a node in the rolling upgrade.
+type UpgradeNodeState string
+
+const (
+	// UpgradeNodePending means the node waits for the previous nodes.
+	UpgradeNodePending UpgradeNodeState = "pending"
+	// UpgradeNodeDraining means the maintainers are being moved out of the node.
+	UpgradeNodeDraining UpgradeNodeState = "draining"
+	// UpgradeNodeReady means the node can be stopped and upgraded, the dispatchers
+	// are drained by the node itself when it's stopped gracefully.
+	UpgradeNodeReady UpgradeNodeState = "ready"
+	// UpgradeNodeRestarting means the node is offline and waits to rejoin the cluster.
+	UpgradeNodeRestarting UpgradeNodeState = "restarting"
+	// UpgradeNodeUpgraded means the node has rejoined the cluster.
+	UpgradeNodeUpgraded UpgradeNodeState = "upgraded"
+)
+
+// UpgradeNodeStatus is the status of a node in the rolling upgrade.
+type UpgradeNodeStatus struct {
+	// ID is the node id before the upgrade.
+	ID            string           `json:"id"`
+	AdvertiseAddr string           `json:"address"`
+	Version       string           `json:"version"`
+	State         UpgradeNodeState `json:"state"`
+	// NewID and NewVersion are set after the node rejoins the cluster.
+	NewID      string `json:"new_id,omitempty"`
+	NewVersion string `json:"new_version,omitempty"`
+}
+
+// UpgradeStatus is the status of the rolling upgrade of the cluster.
+type UpgradeStatus struct {
+	State UpgradeState `json:"state"`
+	// MaxLagSeconds is the max checkpoint lag of the changefeeds allowed to upgrade the next node.
+	MaxLagSeconds int64               `json:"max_lag_seconds"`
+	Nodes         []UpgradeNodeStatus `json:"nodes"`
+	// Message explains what the upgrade is waiting for.
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+)
+
+// RetryClass decides how an error is retried, the errors of the same
+// class share the same backoff policy. It's different from the class of
+// apperror, which tells where the error comes from.
+type RetryClass int
+
+const (
+	// RetryClassTransient is the errors which are expected to disappear soon,
+	// such as the leader is changed, they are retried with a short backoff.
+	RetryClassTransient RetryClass = iota
+	// RetryClassBackoff is the errors which need some time to recover,
+	// such as the downstream is unavailable, they are retried with a longer backoff.
+	RetryClassBackoff
+	// RetryClassFatal is the errors which can't be fixed by retrying.
+	RetryClassFatal
+	// RetryClassCount is the number of the error classes.
+	RetryClassCount
+)
+
+var retryClassNames = [RetryClassCount]string{
+	RetryClassTransient: "transient",
+	RetryClassBackoff:   "backoff",
+	RetryClassFatal:     "fatal",
+}
+
+func (c RetryClass) String() string {
+	if c < 0 || c >= RetryClassCount {
+		return "unknown"
+	}
+	return retryClassNames[c]
+}
+
+// retryClasses maps the RFC codes of the registered errors to their classes.
+var retryClasses sync.Map
+
+func init() {
+	RegisterRetryClass(RetryClassTransient,
+		ErrEtcdTryAgain, ErrNotOwner, ErrOwnerNotFound, ErrPDEtcdAPIError)
+	RegisterRetryClass(RetryClassFatal, ChangeFeedGCFastFailError...)
+	RegisterRetryClass(RetryClassFatal, ErrChangefeedUnretryable)
+}
+
+// RegisterRetryClass sets the class of the errors, it overrides the class registered before.
+// The errors which are not registered are classified as RetryClassBackoff.
+func RegisterRetryClass(class RetryClass, errs ...*errors.Error) {
+	for _, e := range errs {
+		retryClasses.Store(e.RFCCode(), class)
+	}
+}
+
+// RetryClassOf returns the class of the non-nil error. The canceled errors and the errors
+// failing the changefeed are fatal, the others are classified by their registered
+// classes, and the unregistered ones are retried with backoff.
+func RetryClassOf(err error) RetryClass {
+	switch errors.Cause(err) {
+	case context.Canceled, context.DeadlineExceeded:
+		return RetryClassFatal
+	}
+	if ShouldFailChangefeed(err) {
+		return RetryClassFatal
+	}
+	code, _ := RFCCode(err)
+	return retryClassOfCode(code)
+}
+
+// RetryClassOfCode returns the class of an error only known by its RFC code and message,
+// such as the running error reported by the dispatchers.
+func RetryClassOfCode(code errors.RFCErrorCode, message string) RetryClass {
+	if ShouldFailChangefeed(errors.New(message + string(code))) {
+		return RetryClassFatal
+	}
+	return retryClassOfCode(code)
+}
+
+func retryClassOfCode(code errors.RFCErrorCode) RetryClass {
+	if class, ok := retryClasses.Load(code); ok {
+		return class.(RetryClass)
+	}
+	return RetryClassBackoff
+}
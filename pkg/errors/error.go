@@ -24,6 +24,10 @@ var (
 		"changefeed not exists, %s",
 		errors.RFCCodeText("CDC:ErrChangeFeedNotExists"),
 	)
+	ErrChangefeedTemplateNotExists = errors.Normalize(
+		"changefeed template not exists, %s",
+		errors.RFCCodeText("CDC:ErrChangefeedTemplateNotExists"),
+	)
 	ErrEtcdAPIError = errors.Normalize(
 		"etcd api returns error",
 		errors.RFCCodeText("CDC:ErrEtcdAPIError"),
@@ -173,6 +177,10 @@ var (
 		"MySQL connection error",
 		errors.RFCCodeText("CDC:ErrMySQLConnectionError"),
 	)
+	ErrConsistencyCheck = errors.Normalize(
+		"consistency check failed: %s",
+		errors.RFCCodeText("CDC:ErrConsistencyCheck"),
+	)
 	ErrMySQLInvalidConfig = errors.Normalize(
 		"MySQL config invalid",
 		errors.RFCCodeText("CDC:ErrMySQLInvalidConfig"),
@@ -308,15 +316,39 @@ var (
 		"internal server error",
 		errors.RFCCodeText("CDC:ErrInternalServerError"),
 	)
+	ErrRowNotFound = errors.Normalize(
+		"row not found in the event store: %s",
+		errors.RFCCodeText("CDC:ErrRowNotFound"),
+	)
 	ErrChangefeedUpdateRefused = errors.Normalize(
 		"changefeed update error: %s",
 		errors.RFCCodeText("CDC:ErrChangefeedUpdateRefused"),
 	)
+	ErrRollingUpgradeRunning = errors.Normalize(
+		"rolling upgrade is already running",
+		errors.RFCCodeText("CDC:ErrRollingUpgradeRunning"),
+	)
+	ErrChangefeedMoveRefused = errors.Normalize(
+		"move the maintainer of changefeed %s is refused: %s",
+		errors.RFCCodeText("CDC:ErrChangefeedMoveRefused"),
+	)
 	ErrStartTsBeforeGC = errors.Normalize(
 		"fail to create or maintain changefeed because start-ts %d "+
 			"is earlier than or equal to GC safepoint at %d",
 		errors.RFCCodeText("CDC:ErrStartTsBeforeGC"),
 	)
+	ErrInvalidBootstrapStartTs = errors.Normalize(
+		"invalid start-ts %d of changefeed %s in bootstrap: %s",
+		errors.RFCCodeText("CDC:ErrInvalidBootstrapStartTs"),
+	)
+	ErrSchemaStoreUnavailable = errors.Normalize(
+		"schema store is unavailable to load the tables of changefeed %s at ts %d",
+		errors.RFCCodeText("CDC:ErrSchemaStoreUnavailable"),
+	)
+	ErrNodeCapacityExceeded = errors.Normalize(
+		"no node has the capacity for changefeed %s: %s",
+		errors.RFCCodeText("CDC:ErrNodeCapacityExceeded"),
+	)
 	ErrTargetTsBeforeStartTs = errors.Normalize(
 		"fail to create changefeed because target-ts %d is earlier than start-ts %d",
 		errors.RFCCodeText("CDC:ErrTargetTsBeforeStartTs"),
@@ -331,6 +363,10 @@ var (
 			"the GC TTL and the changefeed is blocking global GC progression",
 		errors.RFCCodeText("CDC:ErrGCTTLExceeded"),
 	)
+	ErrUpdateServiceSafepointFailed = errors.Normalize(
+		"updating service safepoint failed",
+		errors.RFCCodeText("CDC:ErrUpdateServiceSafepointFailed"),
+	)
 	ErrNotOwner = errors.Normalize(
 		"this capture is not a owner",
 		errors.RFCCodeText("CDC:ErrNotOwner"),
@@ -407,6 +443,10 @@ var (
 		"upstream missmatch,old: %d, new %d",
 		errors.RFCCodeText("CDC:ErrUpstreamMissMatch"),
 	)
+	ErrUpstreamNotReady = errors.Normalize(
+		"upstream is not ready, cluster-id: %d",
+		errors.RFCCodeText("CDC:ErrUpstreamNotReady"),
+	)
 
 	// cli error
 	ErrCliInvalidCheckpointTs = errors.Normalize(
@@ -445,6 +485,17 @@ var (
 			"if you want to replicate this table, please add its old name to filter rule.",
 		errors.RFCCodeText("CDC:ErrSyncRenameTableFailed"),
 	)
+	ErrIngestEventUnsupported = errors.Normalize(
+		"the data of the import job is ingested into TiKV directly and can't be replicated, "+
+			"commit ts: %d, import: [%s], change the ingest-strategy of the changefeed "+
+			"to skip or replicate it",
+		errors.RFCCodeText("CDC:ErrIngestEventUnsupported"),
+	)
+	ErrIngestEventNotAcked = errors.Normalize(
+		"the data of the import job is ingested into TiKV directly, the changefeed is paused until it's acknowledged, "+
+			"commit ts: %d, import: [%s]",
+		errors.RFCCodeText("CDC:ErrIngestEventNotAcked"),
+	)
 
 	// changefeed config error
 	ErrInvalidReplicaConfig = errors.Normalize(
@@ -452,6 +503,11 @@ var (
 		errors.RFCCodeText("CDC:ErrInvalidReplicaConfig"),
 	)
 
+	ErrConfigUnknownField = errors.Normalize(
+		"unknown fields in the config %s: %s",
+		errors.RFCCodeText("CDC:ErrConfigUnknownField"),
+	)
+
 	ErrInvalidGlueSchemaRegistryConfig = errors.Normalize(
 		"invalid glue schema registry config, %s",
 		errors.RFCCodeText("CDC:ErrInvalidGlueSchemaRegistryConfig"),
@@ -466,6 +522,10 @@ var (
 		"cdc met unexpected error: %s",
 		errors.RFCCodeText("CDC:ErrUnexpected"),
 	)
+	ErrInjectedFault = errors.Normalize(
+		"injected fault: %s",
+		errors.RFCCodeText("CDC:ErrInjectedFault"),
+	)
 
 	// credential related errors
 	ErrCredentialNotFound = errors.Normalize(
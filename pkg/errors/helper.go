@@ -86,6 +86,7 @@ var changefeedUnRetryableErrors = []*errors.Error{
 	ErrExpressionParseFailed,
 	ErrSchemaSnapshotNotFound,
 	ErrSyncRenameTableFailed,
+	ErrIngestEventUnsupported,
 	ErrChangefeedUnretryable,
 	ErrCorruptedDataMutation,
 	ErrDispatcherFailed,
@@ -97,9 +98,24 @@ var changefeedUnRetryableErrors = []*errors.Error{
 	ErrStorageSinkInvalidConfig,
 }
 
+// changefeedPauseErrors are the errors that pause the changefeed,
+// the changefeed is not retried until it's resumed by the users.
+var changefeedPauseErrors = []*errors.Error{
+	ErrIngestEventNotAcked,
+}
+
 // ShouldFailChangefeed returns true if an error is a changefeed not retry error.
 func ShouldFailChangefeed(err error) bool {
-	for _, e := range changefeedUnRetryableErrors {
+	return matchErrors(err, changefeedUnRetryableErrors)
+}
+
+// ShouldPauseChangefeed returns true if an error pauses the changefeed.
+func ShouldPauseChangefeed(err error) bool {
+	return matchErrors(err, changefeedPauseErrors)
+}
+
+func matchErrors(err error, errs []*errors.Error) bool {
+	for _, e := range errs {
 		if e.Equal(err) {
 			return true
 		}
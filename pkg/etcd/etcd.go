@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/pd/pkg/utils/tempurl"
@@ -71,6 +72,21 @@ func GetEtcdKeyChangeFeedInfo(clusterID string, changefeedID common.ChangeFeedDi
 		changefeedID.Namespace), changefeedID.Name)
 }
 
+// ChangefeedTemplateKeyPrefix is the prefix of the changefeed template keys
+func ChangefeedTemplateKeyPrefix(clusterID string) string {
+	return BaseKey(clusterID) + metaPrefix + templateKey
+}
+
+// GetEtcdKeyChangefeedTemplate returns the key of a changefeed template
+func GetEtcdKeyChangefeedTemplate(clusterID, name string) string {
+	return ChangefeedTemplateKeyPrefix(clusterID) + "/" + name
+}
+
+// UpgradeStatusKey is the key of the rolling upgrade status of the cluster
+func UpgradeStatusKey(clusterID string) string {
+	return BaseKey(clusterID) + metaPrefix + upgradeKey
+}
+
 // GetEtcdKeyCaptureInfo returns the key of a capture info
 func GetEtcdKeyCaptureInfo(clusterID, id string) string {
 	return CaptureInfoKeyPrefix(clusterID) + "/" + id
@@ -119,9 +135,23 @@ type CDCEtcdClient interface {
 
 	PutCaptureInfo(context.Context, *model.CaptureInfo, clientv3.LeaseID) error
 
+	// PutNodeInfo registers the node with its metadata, it's compatible with the capture info.
+	PutNodeInfo(context.Context, *node.Info, clientv3.LeaseID) error
+
+	// GetNodeInfo returns the node registered with its metadata.
+	GetNodeInfo(ctx context.Context, id string) (*node.Info, error)
+
 	DeleteCaptureInfo(context.Context, model.CaptureID) error
 
 	CheckMultipleCDCClusterExist(ctx context.Context) error
+
+	PutChangefeedTemplate(ctx context.Context, template *config.ChangefeedTemplate) error
+
+	GetChangefeedTemplate(ctx context.Context, name string) (*config.ChangefeedTemplate, error)
+
+	GetChangefeedTemplates(ctx context.Context) ([]*config.ChangefeedTemplate, error)
+
+	DeleteChangefeedTemplate(ctx context.Context, name string) error
 }
 
 // CDCEtcdClientImpl is a wrap of etcd client
@@ -505,6 +535,66 @@ func (c *CDCEtcdClientImpl) SaveChangeFeedInfo(ctx context.Context,
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
 
+// PutChangefeedTemplate creates or replaces a changefeed template
+func (c *CDCEtcdClientImpl) PutChangefeedTemplate(ctx context.Context,
+	template *config.ChangefeedTemplate,
+) error {
+	key := GetEtcdKeyChangefeedTemplate(c.ClusterID, template.Name)
+	value, err := template.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = c.Client.Put(ctx, key, value)
+	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+}
+
+// GetChangefeedTemplate queries a changefeed template by name
+func (c *CDCEtcdClientImpl) GetChangefeedTemplate(ctx context.Context,
+	name string,
+) (*config.ChangefeedTemplate, error) {
+	key := GetEtcdKeyChangefeedTemplate(c.ClusterID, name)
+	resp, err := c.Client.Get(ctx, key)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if resp.Count == 0 {
+		return nil, errors.ErrChangefeedTemplateNotExists.GenWithStackByArgs(name)
+	}
+	template := &config.ChangefeedTemplate{}
+	err = template.Unmarshal(resp.Kvs[0].Value)
+	return template, errors.Trace(err)
+}
+
+// GetChangefeedTemplates returns all changefeed templates
+func (c *CDCEtcdClientImpl) GetChangefeedTemplates(ctx context.Context) ([]*config.ChangefeedTemplate, error) {
+	resp, err := c.Client.Get(ctx, ChangefeedTemplateKeyPrefix(c.ClusterID)+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	templates := make([]*config.ChangefeedTemplate, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		template := &config.ChangefeedTemplate{}
+		if err := template.Unmarshal(kv.Value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// DeleteChangefeedTemplate deletes a changefeed template
+func (c *CDCEtcdClientImpl) DeleteChangefeedTemplate(ctx context.Context, name string) error {
+	key := GetEtcdKeyChangefeedTemplate(c.ClusterID, name)
+	resp, err := c.Client.Delete(ctx, key)
+	if err != nil {
+		return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if resp.Deleted == 0 {
+		return errors.ErrChangefeedTemplateNotExists.GenWithStackByArgs(name)
+	}
+	return nil
+}
+
 // PutCaptureInfo put capture info into etcd,
 // this happens when the capture starts.
 func (c *CDCEtcdClientImpl) PutCaptureInfo(
@@ -520,6 +610,42 @@ func (c *CDCEtcdClientImpl) PutCaptureInfo(
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
 
+// PutNodeInfo puts the node info into etcd with the lease, the node info is stored in
+// the key of the capture info, and it's a superset of the capture info in json.
+func (c *CDCEtcdClientImpl) PutNodeInfo(
+	ctx context.Context, info *node.Info, leaseID clientv3.LeaseID,
+) error {
+	data, err := info.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	key := GetEtcdKeyCaptureInfo(c.ClusterID, info.ID.String())
+	_, err = c.Client.Put(ctx, key, string(data), clientv3.WithLease(leaseID))
+	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+}
+
+// GetNodeInfo gets the node info from etcd, the metadata fields are empty
+// if the node is registered by an old version.
+// return ErrCaptureNotExist if the node not exists.
+func (c *CDCEtcdClientImpl) GetNodeInfo(ctx context.Context, id string) (*node.Info, error) {
+	key := GetEtcdKeyCaptureInfo(c.ClusterID, id)
+
+	resp, err := c.Client.Get(ctx, key)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(key)
+	}
+
+	info := new(node.Info)
+	if err = info.Unmarshal(resp.Kvs[0].Value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
 // DeleteCaptureInfo delete all capture related info from etcd.
 func (c *CDCEtcdClientImpl) DeleteCaptureInfo(ctx context.Context, captureID string) error {
 	key := GetEtcdKeyCaptureInfo(c.ClusterID, captureID)
@@ -0,0 +1,128 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	mock_etcd "github.com/pingcap/ticdc/pkg/etcd/mock"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// newMemoryEtcdClient returns the mock etcd client which keeps the keys in memory.
+func newMemoryEtcdClient(t *testing.T) (*mock_etcd.MockClient, map[string]string) {
+	kvs := make(map[string]string)
+	client := mock_etcd.NewMockClient(gomock.NewController(t))
+	client.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key, val string, _ ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+			kvs[key] = val
+			return &clientv3.PutResponse{}, nil
+		}).AnyTimes()
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+			prefix := len(clientv3.OpGet(key, opts...).RangeBytes()) > 0
+			resp := &clientv3.GetResponse{}
+			for k, v := range kvs {
+				if k == key || (prefix && strings.HasPrefix(k, key)) {
+					resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(v)})
+				}
+			}
+			sort.Slice(resp.Kvs, func(i, j int) bool {
+				return string(resp.Kvs[i].Key) < string(resp.Kvs[j].Key)
+			})
+			resp.Count = int64(len(resp.Kvs))
+			return resp, nil
+		}).AnyTimes()
+	client.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key string, _ ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+			resp := &clientv3.DeleteResponse{}
+			if _, ok := kvs[key]; ok {
+				delete(kvs, key)
+				resp.Deleted = 1
+			}
+			return resp, nil
+		}).AnyTimes()
+	return client, kvs
+}
+
+func newTestTemplate(name, protocol string) *config.ChangefeedTemplate {
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Protocol = &protocol
+	return &config.ChangefeedTemplate{
+		Name:        name,
+		Description: "template " + name,
+		Config:      replicaConfig,
+		UpdateTime:  time.Unix(1700000000, 0).UTC(),
+	}
+}
+
+func TestChangefeedTemplateCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, kvs := newMemoryEtcdClient(t)
+	cdcClient := &etcd.CDCEtcdClientImpl{Client: client, ClusterID: "default"}
+
+	// nothing is found before the templates are created
+	_, err := cdcClient.GetChangefeedTemplate(ctx, "canal")
+	require.True(t, errors.ErrChangefeedTemplateNotExists.Equal(err))
+	templates, err := cdcClient.GetChangefeedTemplates(ctx)
+	require.NoError(t, err)
+	require.Empty(t, templates)
+
+	canal := newTestTemplate("canal", "canal-json")
+	require.NoError(t, cdcClient.PutChangefeedTemplate(ctx, canal))
+	require.NoError(t, cdcClient.PutChangefeedTemplate(ctx, newTestTemplate("avro", "avro")))
+	require.Contains(t, kvs, etcd.GetEtcdKeyChangefeedTemplate("default", "canal"))
+	// the template of another cluster is not listed
+	otherCluster := &etcd.CDCEtcdClientImpl{Client: client, ClusterID: "other"}
+	require.NoError(t, otherCluster.PutChangefeedTemplate(ctx, newTestTemplate("other", "open-protocol")))
+
+	template, err := cdcClient.GetChangefeedTemplate(ctx, "canal")
+	require.NoError(t, err)
+	require.Equal(t, canal.Name, template.Name)
+	require.Equal(t, canal.Description, template.Description)
+	require.True(t, canal.UpdateTime.Equal(template.UpdateTime))
+	require.Equal(t, "canal-json", *template.Config.Sink.Protocol)
+
+	templates, err = cdcClient.GetChangefeedTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	require.Equal(t, "avro", templates[0].Name)
+	require.Equal(t, "canal", templates[1].Name)
+
+	// the template is replaced
+	require.NoError(t, cdcClient.PutChangefeedTemplate(ctx, newTestTemplate("canal", "simple")))
+	template, err = cdcClient.GetChangefeedTemplate(ctx, "canal")
+	require.NoError(t, err)
+	require.Equal(t, "simple", *template.Config.Sink.Protocol)
+
+	require.NoError(t, cdcClient.DeleteChangefeedTemplate(ctx, "canal"))
+	_, err = cdcClient.GetChangefeedTemplate(ctx, "canal")
+	require.True(t, errors.ErrChangefeedTemplateNotExists.Equal(err))
+	err = cdcClient.DeleteChangefeedTemplate(ctx, "canal")
+	require.True(t, errors.ErrChangefeedTemplateNotExists.Equal(err))
+	templates, err = cdcClient.GetChangefeedTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, "avro", templates[0].Name)
+}
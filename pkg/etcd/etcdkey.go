@@ -39,6 +39,8 @@ const (
 	// metaVersionKey is the key path for metadata version
 	metaVersionKey = "/meta/meta-version"
 	upstreamKey    = "/upstream"
+	templateKey    = "/changefeed-template"
+	upgradeKey     = "/upgrade"
 
 	// DeletionCounterKey is the key path for the counter of deleted keys
 	DeletionCounterKey = metaPrefix + "/meta/ticdc-delete-etcd-key-count"
@@ -12,6 +12,7 @@ import (
 	common "github.com/pingcap/ticdc/pkg/common"
 	config "github.com/pingcap/ticdc/pkg/config"
 	etcd "github.com/pingcap/ticdc/pkg/etcd"
+	node "github.com/pingcap/ticdc/pkg/node"
 	model "github.com/pingcap/tiflow/cdc/model"
 	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -137,6 +138,20 @@ func (mr *MockCDCEtcdClientMockRecorder) DeleteCaptureInfo(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCaptureInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).DeleteCaptureInfo), arg0, arg1)
 }
 
+// DeleteChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) DeleteChangefeedTemplate(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChangefeedTemplate", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChangefeedTemplate indicates an expected call of DeleteChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) DeleteChangefeedTemplate(ctx interface{}, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).DeleteChangefeedTemplate), ctx, name)
+}
+
 // GetAllCDCInfo mocks base method.
 func (m *MockCDCEtcdClient) GetAllCDCInfo(ctx context.Context) ([]*mvccpb.KeyValue, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +214,36 @@ func (mr *MockCDCEtcdClientMockRecorder) GetChangeFeedStatus(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedStatus", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangeFeedStatus), ctx, id)
 }
 
+// GetChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) GetChangefeedTemplate(ctx context.Context, name string) (*config.ChangefeedTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangefeedTemplate", ctx, name)
+	ret0, _ := ret[0].(*config.ChangefeedTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangefeedTemplate indicates an expected call of GetChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) GetChangefeedTemplate(ctx interface{}, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangefeedTemplate), ctx, name)
+}
+
+// GetChangefeedTemplates mocks base method.
+func (m *MockCDCEtcdClient) GetChangefeedTemplates(ctx context.Context) ([]*config.ChangefeedTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangefeedTemplates", ctx)
+	ret0, _ := ret[0].([]*config.ChangefeedTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangefeedTemplates indicates an expected call of GetChangefeedTemplates.
+func (mr *MockCDCEtcdClientMockRecorder) GetChangefeedTemplates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangefeedTemplates", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangefeedTemplates), ctx)
+}
+
 // GetClusterID mocks base method.
 func (m *MockCDCEtcdClient) GetClusterID() string {
 	m.ctrl.T.Helper()
@@ -255,6 +300,21 @@ func (mr *MockCDCEtcdClientMockRecorder) GetGCServiceID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCServiceID", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetGCServiceID))
 }
 
+// GetNodeInfo mocks base method.
+func (m *MockCDCEtcdClient) GetNodeInfo(ctx context.Context, id string) (*node.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeInfo", ctx, id)
+	ret0, _ := ret[0].(*node.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeInfo indicates an expected call of GetNodeInfo.
+func (mr *MockCDCEtcdClientMockRecorder) GetNodeInfo(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetNodeInfo), ctx, id)
+}
+
 // GetOwnerID mocks base method.
 func (m *MockCDCEtcdClient) GetOwnerID(arg0 context.Context) (model.CaptureID, error) {
 	m.ctrl.T.Helper()
@@ -314,6 +374,34 @@ func (mr *MockCDCEtcdClientMockRecorder) PutCaptureInfo(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCaptureInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).PutCaptureInfo), arg0, arg1, arg2)
 }
 
+// PutNodeInfo mocks base method.
+func (m *MockCDCEtcdClient) PutNodeInfo(arg0 context.Context, arg1 *node.Info, arg2 clientv3.LeaseID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutNodeInfo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutNodeInfo indicates an expected call of PutNodeInfo.
+func (mr *MockCDCEtcdClientMockRecorder) PutNodeInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutNodeInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).PutNodeInfo), arg0, arg1, arg2)
+}
+
+// PutChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) PutChangefeedTemplate(ctx context.Context, template *config.ChangefeedTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutChangefeedTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutChangefeedTemplate indicates an expected call of PutChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) PutChangefeedTemplate(ctx interface{}, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).PutChangefeedTemplate), ctx, template)
+}
+
 // MockEtcd is a mock of Etcd interface.
 type MockEtcd struct {
 	ctrl     *gomock.Controller
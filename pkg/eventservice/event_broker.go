@@ -713,9 +713,15 @@ func (c *eventBroker) sendMsg(ctx context.Context, tMsg *messaging.TargetMessage
 func (c *eventBroker) updateMetrics(ctx context.Context) {
 	ticker := time.NewTicker(10 * time.Second)
 	log.Info("update metrics goroutine is started")
+	// the changefeeds whose stored bytes are reported
+	reported := make(map[common.ChangeFeedID]struct{})
 	for {
 		select {
 		case <-ctx.Done():
+			for changefeedID := range reported {
+				metrics.EventStoreChangefeedStoredBytesGauge.DeleteLabelValues(
+					changefeedID.Namespace(), changefeedID.Name())
+			}
 			log.Info("update metrics goroutine is closing")
 			return
 		case <-ticker.C:
@@ -733,6 +739,21 @@ func (c *eventBroker) updateMetrics(ctx context.Context) {
 				}
 				return true
 			})
+			storedBytes := c.changefeedStoredBytes()
+			for changefeedID, bytes := range storedBytes {
+				metrics.EventStoreChangefeedStoredBytesGauge.WithLabelValues(
+					changefeedID.Namespace(), changefeedID.Name()).Set(float64(bytes))
+			}
+			for changefeedID := range reported {
+				if _, ok := storedBytes[changefeedID]; !ok {
+					metrics.EventStoreChangefeedStoredBytesGauge.DeleteLabelValues(
+						changefeedID.Namespace(), changefeedID.Name())
+					delete(reported, changefeedID)
+				}
+			}
+			for changefeedID := range storedBytes {
+				reported[changefeedID] = struct{}{}
+			}
 			if receivedMinResolvedTs == 0 {
 				continue
 			}
@@ -748,6 +769,43 @@ func (c *eventBroker) updateMetrics(ctx context.Context) {
 	}
 }
 
+// changefeedStoredBytes returns the estimated bytes of the events stored in the event
+// store for each changefeed which has dispatchers on this node. A subscription may be
+// shared by the dispatchers of several changefeeds, its bytes are counted only once and
+// split evenly among these changefeeds.
+func (c *eventBroker) changefeedStoredBytes() map[common.ChangeFeedID]uint64 {
+	type subscription struct {
+		storedBytes uint64
+		changefeeds map[common.ChangeFeedID]struct{}
+	}
+	subscriptions := make(map[uint64]*subscription)
+	result := make(map[common.ChangeFeedID]uint64)
+	c.dispatchers.Range(func(key, value interface{}) bool {
+		dispatcher := value.(*dispatcherStat)
+		changefeedID := dispatcher.changefeedStat.changefeedID
+		result[changefeedID] = 0
+		for subID, storedBytes := range c.eventStore.GetDispatcherStoredBytes(dispatcher.id) {
+			sub, ok := subscriptions[subID]
+			if !ok {
+				sub = &subscription{
+					storedBytes: storedBytes,
+					changefeeds: make(map[common.ChangeFeedID]struct{}),
+				}
+				subscriptions[subID] = sub
+			}
+			sub.changefeeds[changefeedID] = struct{}{}
+		}
+		return true
+	})
+	for _, sub := range subscriptions {
+		share := sub.storedBytes / uint64(len(sub.changefeeds))
+		for changefeedID := range sub.changefeeds {
+			result[changefeedID] += share
+		}
+	}
+	return result
+}
+
 // updateDispatcherSendTs updates the sendTs of the dispatcher periodically.
 // The eventStore need to know this to GC the stale data.
 func (c *eventBroker) reportDispatcherStatToStore(ctx context.Context) {
@@ -830,6 +888,7 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 		info.GetStartTs(),
 		func(resolvedTs uint64, latestCommitTs uint64) { c.onNotify(dispatcher, resolvedTs, latestCommitTs) },
 		info.IsOnlyReuse(),
+		info.IsOldValueDisabled(),
 	)
 	if err != nil {
 		log.Panic("register dispatcher to eventStore failed", zap.Error(err), zap.Any("dispatcherInfo", info))
@@ -893,6 +952,7 @@ func (c *eventBroker) pauseDispatcher(dispatcherInfo DispatcherInfo) {
 		zap.Uint64("sentResolvedTs", stat.sentResolvedTs.Load()),
 		zap.Uint64("seq", stat.seq.Load()))
 	stat.isRunning.Store(false)
+	c.eventStore.PauseDispatcher(stat.id)
 }
 
 func (c *eventBroker) resumeDispatcher(dispatcherInfo DispatcherInfo) {
@@ -906,6 +966,10 @@ func (c *eventBroker) resumeDispatcher(dispatcherInfo DispatcherInfo) {
 		zap.Uint64("sentResolvedTs", stat.sentResolvedTs.Load()),
 		zap.Uint64("seq", stat.seq.Load()))
 	stat.isRunning.Store(true)
+	// the dispatcher is still paused if its changefeed is paused
+	if stat.changefeedStat.isRunning.Load() {
+		c.eventStore.ResumeDispatcher(stat.id)
+	}
 }
 
 func (c *eventBroker) resetDispatcher(dispatcherInfo DispatcherInfo) {
@@ -940,6 +1004,7 @@ func (c *eventBroker) pauseChangefeed(dispatcherInfo DispatcherInfo) {
 	log.Info("pause changefeed",
 		zap.Any("changefeedID", stat.changefeedStat.changefeedID.String()))
 	stat.changefeedStat.isRunning.Store(false)
+	c.forEachDispatcherOfChangefeed(stat.changefeedStat, c.eventStore.PauseDispatcher)
 }
 
 func (c *eventBroker) resumeChangefeed(dispatcherInfo DispatcherInfo) {
@@ -950,4 +1015,20 @@ func (c *eventBroker) resumeChangefeed(dispatcherInfo DispatcherInfo) {
 	log.Info("resume changefeed",
 		zap.Any("changefeedID", stat.changefeedStat.changefeedID.String()))
 	stat.changefeedStat.isRunning.Store(true)
+	c.forEachDispatcherOfChangefeed(stat.changefeedStat, func(id common.DispatcherID) {
+		// the dispatcher paused by itself is still paused
+		if dispatcher, ok := c.getDispatcher(id); ok && dispatcher.isRunning.Load() {
+			c.eventStore.ResumeDispatcher(id)
+		}
+	})
+}
+
+func (c *eventBroker) forEachDispatcherOfChangefeed(changefeedStat *changefeedStatus, action func(id common.DispatcherID)) {
+	c.dispatchers.Range(func(key, value interface{}) bool {
+		dispatcher := value.(*dispatcherStat)
+		if dispatcher.changefeedStat == changefeedStat {
+			action(dispatcher.id)
+		}
+		return true
+	})
 }
@@ -228,3 +228,32 @@ func TestHandleResolvedTs(t *testing.T) {
 	msg := <-mc.messageCh
 	require.Equal(t, msg.Type, messaging.TypeBatchResolvedTs)
 }
+
+func TestChangefeedStoredBytes(t *testing.T) {
+	broker, es, _ := newEventBrokerForTest()
+	defer broker.close()
+
+	addDispatcher := func(changefeedID common.ChangeFeedID, storedBytes map[uint64]uint64) {
+		info := newMockDispatcherInfoForTest(t)
+		disp := newDispatcherStat(100, info, nil, 0, broker.getOrSetChangefeedStatus(changefeedID))
+		broker.dispatchers.Store(disp.id, disp)
+		if storedBytes != nil {
+			es.storedBytes.Store(disp.id, storedBytes)
+		}
+	}
+	cf1 := common.NewChangefeedID4Test("default", "cf1")
+	cf2 := common.NewChangefeedID4Test("default", "cf2")
+	cf3 := common.NewChangefeedID4Test("default", "cf3")
+	// the subscription 1 is shared by the dispatchers of cf1 and cf2.
+	addDispatcher(cf1, map[uint64]uint64{1: 100})
+	addDispatcher(cf1, map[uint64]uint64{1: 100, 2: 50})
+	addDispatcher(cf2, map[uint64]uint64{1: 100})
+	// the dispatcher of cf3 is not registered to the event store yet.
+	addDispatcher(cf3, nil)
+
+	require.Equal(t, map[common.ChangeFeedID]uint64{
+		cf1: 100,
+		cf2: 50,
+		cf3: 0,
+	}, broker.changefeedStoredBytes())
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamservice"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/filter"
@@ -42,6 +43,8 @@ type DispatcherInfo interface {
 	GetActionType() eventpb.ActionType
 	GetChangefeedID() common.ChangeFeedID
 	GetFilter() filter.Filter
+	// IsOldValueDisabled returns true if the dispatcher doesn't need the old values of the rows.
+	IsOldValueDisabled() bool
 
 	// sync point related
 	SyncPointEnabled() bool
@@ -141,12 +144,37 @@ func (s *eventService) registerDispatcher(ctx context.Context, info DispatcherIn
 	clusterID := info.GetClusterID()
 	c, ok := s.brokers[clusterID]
 	if !ok {
-		c = newEventBroker(ctx, clusterID, s.eventStore, s.schemaStore, s.mc, s.tz)
+		eventStore, schemaStore, ok := s.getStores(clusterID)
+		if !ok {
+			log.Warn("the log service of the upstream is not found, ignore the dispatcher",
+				zap.Uint64("clusterID", clusterID),
+				zap.Stringer("dispatcherID", info.GetID()))
+			return
+		}
+		c = newEventBroker(ctx, clusterID, eventStore, schemaStore, s.mc, s.tz)
 		s.brokers[clusterID] = c
 	}
 	c.addDispatcher(info)
 }
 
+// getStores returns the event store and schema store of the upstream.
+// The stores of the default upstream are used if the clusterID is not set,
+// or the log services of the upstreams are not managed.
+func (s *eventService) getStores(clusterID uint64) (eventstore.EventStore, schemastore.SchemaStore, bool) {
+	if clusterID == 0 {
+		return s.eventStore, s.schemaStore, true
+	}
+	manager, ok := appcontext.TryGetService[*upstreamservice.Manager](appcontext.UpstreamLogService)
+	if !ok {
+		return s.eventStore, s.schemaStore, true
+	}
+	logService, ok := manager.Get(clusterID)
+	if !ok {
+		return nil, nil, false
+	}
+	return logService.EventStore, logService.SchemaStore, true
+}
+
 func (s *eventService) deregisterDispatcher(dispatcherInfo DispatcherInfo) {
 	clusterID := dispatcherInfo.GetClusterID()
 	c, ok := s.brokers[clusterID]
@@ -15,6 +15,7 @@ package eventservice
 
 import (
 	"context"
+	"io"
 	"math"
 	"sort"
 	"sync"
@@ -197,6 +198,8 @@ var _ eventstore.EventStore = &mockEventStore{}
 type mockEventStore struct {
 	resolvedTsUpdateInterval time.Duration
 	spansMap                 sync.Map
+	// storedBytes is a map from the dispatcher id to its stored bytes of each subscription
+	storedBytes sync.Map
 }
 
 func newMockEventStore(resolvedTsUpdateInterval int) *mockEventStore {
@@ -254,11 +257,29 @@ func (m *mockEventStore) UpdateDispatcherCheckpointTs(dispatcherID common.Dispat
 	return nil
 }
 
+func (m *mockEventStore) PauseDispatcher(dispatcherID common.DispatcherID) {}
+
+func (m *mockEventStore) ResumeDispatcher(dispatcherID common.DispatcherID) {}
+
 func (m *mockEventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
 	m.spansMap.Delete(dispatcherID)
 	return nil
 }
 
+func (m *mockEventStore) GetDispatcherStoredBytes(dispatcherID common.DispatcherID) map[uint64]uint64 {
+	storedBytes, ok := m.storedBytes.Load(dispatcherID)
+	if !ok {
+		return nil
+	}
+	return storedBytes.(map[uint64]uint64)
+}
+
+func (m *mockEventStore) WriteEngineStats(w io.Writer) {}
+
+func (m *mockEventStore) GetGCSafePoint(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
 func (m *mockEventStore) GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (eventstore.EventIterator, error) {
 	iter := &mockEventIterator{
 		events: make([]*common.RawKVEntry, 0),
@@ -283,6 +304,7 @@ func (m *mockEventStore) RegisterDispatcher(
 	startTS common.Ts,
 	notifier eventstore.ResolvedTsNotifier,
 	onlyReuse bool,
+	disableOldValue bool,
 ) (bool, error) {
 	log.Info("subscribe table span", zap.Any("span", span), zap.Uint64("startTs", uint64(startTS)))
 	spanStats := &mockSpanStats{
@@ -417,6 +439,10 @@ func (m *mockSchemaStore) FetchTableTriggerDDLEvents(tableFilter filter.Filter,
 	return nil, 0, nil
 }
 
+func (m *mockSchemaStore) GetGCTs() uint64 {
+	return 0
+}
+
 type mockSpanStats struct {
 	mu                 sync.RWMutex
 	startTs            uint64
@@ -549,6 +575,10 @@ func (m *mockDispatcherInfo) IsOnlyReuse() bool {
 	return false
 }
 
+func (m *mockDispatcherInfo) IsOldValueDisabled() bool {
+	return false
+}
+
 func genEvents(helper *pevent.EventTestHelper, t *testing.T, ddl string, dmls ...string) (pevent.DDLEvent, []*common.RawKVEntry) {
 	job := helper.DDL2Job(ddl)
 	schema := job.SchemaName
@@ -0,0 +1,274 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faultinject injects faults into the messages, the maintainers and the sinks
+// of this node, so the chaos tests can target the scheduling deterministically.
+// It's enabled in the test builds, or by the enable-fault-injection debug config.
+package faultinject
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// Kind is the kind of the injected fault.
+type Kind string
+
+const (
+	// KindMessage drops or delays the messages sent from this node.
+	KindMessage Kind = "message"
+	// KindKillMaintainer kills the maintainer of the changefeed once it runs on this node.
+	KindKillMaintainer Kind = "kill-maintainer"
+	// KindStallSink stalls the sinks of the changefeed on this node.
+	KindStallSink Kind = "stall-sink"
+)
+
+// Action is the action applied to the messages matched by a message fault.
+type Action string
+
+const (
+	ActionNone  Action = ""
+	ActionDrop  Action = "drop"
+	ActionDelay Action = "delay"
+)
+
+// Fault is a fault injected into this node.
+type Fault struct {
+	ID   uint64
+	Kind Kind
+
+	// To and Topic match the messages sent from this node, the empty ones match any.
+	To     node.ID
+	Topic  string
+	Action Action
+	Delay  time.Duration
+
+	// Changefeed is the changefeed whose maintainer is killed or whose sinks are stalled.
+	Changefeed common.ChangeFeedDisplayName
+
+	// ExpireAt is the time the fault is removed, the fault is kept until
+	// it's removed if it's zero.
+	ExpireAt time.Time
+
+	// released is closed when the fault is removed, to wake up the stalled sinks.
+	released chan struct{}
+}
+
+func (f *Fault) expired(now time.Time) bool {
+	return !f.ExpireAt.IsZero() && !now.Before(f.ExpireAt)
+}
+
+func (f *Fault) matchMessage(to node.ID, topic string) bool {
+	return f.Kind == KindMessage &&
+		(f.To == "" || f.To == to) &&
+		(f.Topic == "" || f.Topic == topic)
+}
+
+// Injector holds the faults injected into this node.
+type Injector struct {
+	enabled atomic.Bool
+	// active is set if there is any fault, it's checked before
+	// the lock is acquired on the hot path, such as sending messages.
+	active atomic.Bool
+
+	mu     sync.Mutex
+	nextID uint64
+	faults map[uint64]*Fault
+}
+
+// NewInjector creates a new Injector.
+func NewInjector() *Injector {
+	i := &Injector{faults: make(map[uint64]*Fault)}
+	i.enabled.Store(enabledByDefault)
+	return i
+}
+
+// Enable enables the fault injection.
+func (i *Injector) Enable() {
+	i.enabled.Store(true)
+}
+
+// Enabled returns whether the faults can be injected.
+func (i *Injector) Enabled() bool {
+	return i.enabled.Load()
+}
+
+// Add injects the fault and returns its id.
+func (i *Injector) Add(f Fault) uint64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.nextID++
+	f.ID = i.nextID
+	f.released = make(chan struct{})
+	i.faults[f.ID] = &f
+	i.active.Store(true)
+	log.Info("fault injected",
+		zap.Uint64("id", f.ID),
+		zap.String("kind", string(f.Kind)),
+		zap.Stringer("to", f.To),
+		zap.String("topic", f.Topic),
+		zap.String("action", string(f.Action)),
+		zap.Duration("delay", f.Delay),
+		zap.Stringer("changefeed", f.Changefeed),
+		zap.Time("expireAt", f.ExpireAt))
+	return f.ID
+}
+
+// Remove removes the fault, it returns false if the fault is not found.
+func (i *Injector) Remove(id uint64) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	f, ok := i.faults[id]
+	if ok {
+		i.removeLocked(f)
+	}
+	return ok
+}
+
+// Clear removes all faults.
+func (i *Injector) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, f := range i.faults {
+		i.removeLocked(f)
+	}
+}
+
+// List returns the faults sorted by the id.
+func (i *Injector) List() []Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.gcLocked(time.Now())
+	faults := make([]Fault, 0, len(i.faults))
+	for _, f := range i.faults {
+		faults = append(faults, *f)
+	}
+	sort.Slice(faults, func(a, b int) bool { return faults[a].ID < faults[b].ID })
+	return faults
+}
+
+// MatchMessage returns the action applied to the message sent from this node,
+// and how long the message is delayed if the action is ActionDelay.
+// The fault added earlier is applied if multiple faults match the message.
+func (i *Injector) MatchMessage(to node.ID, topic string) (Action, time.Duration) {
+	if !i.active.Load() {
+		return ActionNone, 0
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.gcLocked(time.Now())
+	var matched *Fault
+	for _, f := range i.faults {
+		if f.matchMessage(to, topic) && (matched == nil || f.ID < matched.ID) {
+			matched = f
+		}
+	}
+	if matched == nil {
+		return ActionNone, 0
+	}
+	return matched.Action, matched.Delay
+}
+
+// TakeMaintainerKill returns true if the maintainer of the changefeed should be killed,
+// the fault is removed once it's taken, so the maintainer is killed only once.
+func (i *Injector) TakeMaintainerKill(changefeed common.ChangeFeedDisplayName) bool {
+	if !i.active.Load() {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.gcLocked(time.Now())
+	for _, f := range i.faults {
+		if f.Kind == KindKillMaintainer && f.Changefeed == changefeed {
+			i.removeLocked(f)
+			return true
+		}
+	}
+	return false
+}
+
+// WaitSinkStall blocks until the sinks of the changefeed are not stalled,
+// or the done channel is closed.
+func (i *Injector) WaitSinkStall(changefeed common.ChangeFeedDisplayName, done <-chan struct{}) {
+	for i.active.Load() {
+		stall := i.getSinkStall(changefeed)
+		if stall == nil {
+			return
+		}
+		if !waitReleased(stall, done) {
+			return
+		}
+	}
+}
+
+// waitReleased blocks until the fault is removed or expired,
+// it returns false if the done channel is closed.
+func waitReleased(f *Fault, done <-chan struct{}) bool {
+	var expired <-chan time.Time
+	if !f.ExpireAt.IsZero() {
+		timer := time.NewTimer(time.Until(f.ExpireAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-f.released:
+	case <-expired:
+	case <-done:
+		return false
+	}
+	return true
+}
+
+func (i *Injector) getSinkStall(changefeed common.ChangeFeedDisplayName) *Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.gcLocked(time.Now())
+	for _, f := range i.faults {
+		if f.Kind == KindStallSink && f.Changefeed == changefeed {
+			return f
+		}
+	}
+	return nil
+}
+
+// gcLocked removes the expired faults.
+func (i *Injector) gcLocked(now time.Time) {
+	for _, f := range i.faults {
+		if f.expired(now) {
+			i.removeLocked(f)
+		}
+	}
+}
+
+func (i *Injector) removeLocked(f *Fault) {
+	delete(i.faults, f.ID)
+	close(f.released)
+	i.active.Store(len(i.faults) > 0)
+	log.Info("fault removed",
+		zap.Uint64("id", f.ID),
+		zap.String("kind", string(f.Kind)))
+}
+
+var globalInjector = NewInjector()
+
+// GetInjector returns the injector of this node.
+func GetInjector() *Injector {
+	return globalInjector
+}
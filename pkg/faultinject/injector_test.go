@@ -0,0 +1,105 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchMessage(t *testing.T) {
+	i := NewInjector()
+	action, _ := i.MatchMessage("node-2", "topic")
+	require.Equal(t, ActionNone, action)
+
+	delayID := i.Add(Fault{Kind: KindMessage, To: "node-2", Action: ActionDelay, Delay: time.Second})
+	i.Add(Fault{Kind: KindMessage, Topic: "topic", Action: ActionDrop})
+
+	// the fault added earlier is applied
+	action, delay := i.MatchMessage("node-2", "topic")
+	require.Equal(t, ActionDelay, action)
+	require.Equal(t, time.Second, delay)
+	action, _ = i.MatchMessage("node-3", "topic")
+	require.Equal(t, ActionDrop, action)
+	action, _ = i.MatchMessage("node-3", "other")
+	require.Equal(t, ActionNone, action)
+
+	require.True(t, i.Remove(delayID))
+	require.False(t, i.Remove(delayID))
+	action, _ = i.MatchMessage("node-2", "topic")
+	require.Equal(t, ActionDrop, action)
+
+	i.Clear()
+	require.Empty(t, i.List())
+	action, _ = i.MatchMessage("node-2", "topic")
+	require.Equal(t, ActionNone, action)
+
+	// the expired fault is removed
+	i.Add(Fault{Kind: KindMessage, Action: ActionDrop, ExpireAt: time.Now().Add(-time.Second)})
+	action, _ = i.MatchMessage("node-2", "topic")
+	require.Equal(t, ActionNone, action)
+	require.Empty(t, i.List())
+}
+
+func TestTakeMaintainerKill(t *testing.T) {
+	i := NewInjector()
+	cf := common.NewChangeFeedDisplayName("test", "default")
+	require.False(t, i.TakeMaintainerKill(cf))
+
+	i.Add(Fault{Kind: KindKillMaintainer, Changefeed: cf})
+	require.False(t, i.TakeMaintainerKill(common.NewChangeFeedDisplayName("other", "default")))
+	require.True(t, i.TakeMaintainerKill(cf))
+	// the maintainer is killed only once
+	require.False(t, i.TakeMaintainerKill(cf))
+}
+
+func TestWaitSinkStall(t *testing.T) {
+	i := NewInjector()
+	cf := common.NewChangeFeedDisplayName("test", "default")
+	// not stalled
+	i.WaitSinkStall(cf, nil)
+
+	id := i.Add(Fault{Kind: KindStallSink, Changefeed: cf})
+	waited := make(chan struct{})
+	go func() {
+		i.WaitSinkStall(cf, nil)
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		require.FailNow(t, "the sink is not stalled")
+	case <-time.After(100 * time.Millisecond):
+	}
+	i.Remove(id)
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the sink is not released")
+	}
+
+	// the stall is released once it's expired
+	i.Add(Fault{Kind: KindStallSink, Changefeed: cf, ExpireAt: time.Now().Add(100 * time.Millisecond)})
+	start := time.Now()
+	i.WaitSinkStall(cf, nil)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// the wait returns once done is closed
+	i.Add(Fault{Kind: KindStallSink, Changefeed: cf})
+	done := make(chan struct{})
+	close(done)
+	i.WaitSinkStall(cf, done)
+}
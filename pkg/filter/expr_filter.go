@@ -30,6 +30,7 @@ import (
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
@@ -357,8 +358,13 @@ func newExprFilter(
 	cfg *config.FilterConfig,
 ) (*dmlExprFilter, error) {
 	res := &dmlExprFilter{}
+	// resolve the timezone first, the session does not accept "System" or "Local".
+	tz, err := util.GetTimezone(timezone)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	sessCtx := utils.NewSessionCtx(map[string]string{
-		"time_zone": timezone,
+		"time_zone": tz.String(),
 	})
 	for _, rule := range cfg.EventFilters {
 		err := res.addRule(sessCtx, rule)
@@ -24,7 +24,6 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
-	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
 	"go.uber.org/zap"
@@ -35,6 +34,17 @@ const (
 	SyncPointTable = "syncpoint_v1"
 	// DDLTsTable is the table name use to write ddl commitTs for each table when downstream is mysql-class
 	DDLTsTable = "ddl_ts_v1"
+	// DDLHistoryTable is the table name use to record the executed ddls when the ddl history is enabled.
+	DDLHistoryTable = "ddl_history"
+	// RowCountAuditTable is the table name use to record the number of rows flushed per table
+	// in each checkpoint interval when the row count audit is enabled.
+	RowCountAuditTable = "row_count_audit"
+	// DeadLetterQueueTable is the table name use to record the rows which can not be applied
+	// to the downstream when the dead letter queue is enabled.
+	DeadLetterQueueTable = "dead_letter_queue"
+	// DMLProgressTable is the table name use to record the last applied commitTs of each table span
+	// when the exactly-once mode is enabled.
+	DMLProgressTable = "dml_progress_v1"
 
 	// TiCDCSystemSchema is the schema only use by TiCDC.
 	TiCDCSystemSchema = "tidb_cdc"
@@ -68,7 +78,7 @@ type Filter interface {
 // filter implements Filter.
 type filter struct {
 	// tableFilter is used to filter in dml/ddl event by table name.
-	tableFilter tfilter.Filter
+	tableFilter TableMatcher
 	// dmlExprFilter is used to filter out dml event by its columns value.
 	dmlExprFilter *dmlExprFilter
 	// sqlEventFilter is used to filter out dml/ddl event by its type or query.
@@ -79,15 +89,11 @@ type filter struct {
 
 // NewFilter creates a filter.
 func NewFilter(cfg *config.FilterConfig, tz string, caseSensitive bool) (Filter, error) {
-	f, err := VerifyTableRules(cfg)
+	f, err := NewTableMatcher(cfg, caseSensitive)
 	if err != nil {
 		return nil, err
 	}
 
-	if !caseSensitive {
-		f = tfilter.CaseInsensitive(f)
-	}
-
 	dmlExprFilter, err := newExprFilter(tz, cfg)
 	if err != nil {
 		return nil, err
@@ -263,8 +269,6 @@ func GetSharedFilterStorage() *SharedFilterStorage {
 func (s *SharedFilterStorage) GetOrSetFilter(
 	changeFeedID common.ChangeFeedID,
 	cfg *eventpb.FilterConfig,
-	tz string,
-	caseSensitive bool,
 ) (Filter, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -292,7 +296,7 @@ func (s *SharedFilterStorage) GetOrSetFilter(
 		filterCfg.EventFilters = append(filterCfg.EventFilters, f)
 	}
 	// generate table filter
-	f, err := NewFilter(filterCfg, tz, caseSensitive)
+	f, err := NewFilter(filterCfg, cfg.GetTimeZone(), cfg.GetCaseSensitive())
 	if err != nil {
 		return nil, err
 	}
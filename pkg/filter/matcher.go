@@ -0,0 +1,28 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter/matcher"
+)
+
+// TableMatcher matches the schema and table names with the table filter rules.
+type TableMatcher = matcher.Matcher
+
+// NewTableMatcher parses the table filter rules of cfg, the names are compared by their
+// unicode case folding if caseSensitive is false, see matcher.New.
+func NewTableMatcher(cfg *config.FilterConfig, caseSensitive bool) (TableMatcher, error) {
+	return matcher.New(cfg.Rules, caseSensitive)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"golang.org/x/text/cases"
+)
+
+// Matcher matches the schema and table names with the table filter rules.
+type Matcher interface {
+	// MatchTable returns true if the table is matched by the rules.
+	MatchTable(schema string, table string) bool
+	// MatchSchema returns true if some tables of the schema may be matched by the rules.
+	MatchSchema(schema string) bool
+}
+
+// New parses the table filter rules, no rules match all tables. If caseSensitive is false,
+// the names are matched the way TiDB compares identifiers with a case-insensitive collation:
+// both the rules and the names are compared by their unicode case folding, so `Test.*` matches
+// the schema `TEST`, and the letters with multiple case forms, such as 'ſ' and 's', match each other.
+func New(rules []string, caseSensitive bool) (Matcher, error) {
+	if len(rules) == 0 {
+		rules = []string{"*.*"}
+	}
+	f, err := tfilter.Parse(rules)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rules)
+	}
+	if caseSensitive {
+		return f, nil
+	}
+	folded := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		folded = append(folded, foldIdentifier(rule))
+	}
+	f, err = tfilter.Parse(folded)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rules)
+	}
+	return foldedMatcher{wrapped: f}, nil
+}
+
+// foldedMatcher matches the folded names with the folded rules.
+type foldedMatcher struct {
+	wrapped tfilter.Filter
+}
+
+func (m foldedMatcher) MatchTable(schema string, table string) bool {
+	return m.wrapped.MatchTable(foldIdentifier(schema), foldIdentifier(table))
+}
+
+func (m foldedMatcher) MatchSchema(schema string) bool {
+	return m.wrapped.MatchSchema(foldIdentifier(schema))
+}
+
+func foldIdentifier(s string) string {
+	return cases.Fold().String(s)
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	rules := []string{"Test.*", "!Test.Skip*", "`Straße`.t"}
+
+	m, err := New(rules, true)
+	require.NoError(t, err)
+	require.True(t, m.MatchTable("Test", "t1"))
+	require.False(t, m.MatchTable("test", "t1"))
+	require.False(t, m.MatchTable("Test", "Skip1"))
+	require.True(t, m.MatchTable("Test", "skip1"))
+	require.True(t, m.MatchSchema("Test"))
+	require.False(t, m.MatchSchema("TEST"))
+
+	m, err = New(rules, false)
+	require.NoError(t, err)
+	require.True(t, m.MatchTable("TEST", "T1"))
+	require.True(t, m.MatchTable("test", "t1"))
+	require.False(t, m.MatchTable("test", "SKIP1"))
+	require.True(t, m.MatchSchema("tEsT"))
+	require.False(t, m.MatchSchema("other"))
+	// the names are compared by the case folding
+	require.True(t, m.MatchTable("STRASSE", "T"))
+	require.True(t, m.MatchTable("ſtraße", "t"))
+
+	// no rules match all tables
+	m, err = New(nil, false)
+	require.NoError(t, err)
+	require.True(t, m.MatchTable("a", "b"))
+
+	_, err = New([]string{"a.b.c"}, false)
+	require.Error(t, err)
+}
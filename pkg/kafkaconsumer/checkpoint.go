@@ -0,0 +1,128 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsumer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	checkpointSchema = "tidb_cdc"
+	checkpointTable  = "kafka_consumer_checkpoint"
+	// ddlPartition is the partition used to record the commitTs of the latest applied DDL.
+	ddlPartition = -1
+	// pendingDDLPartition is the partition used to record the commitTs of the latest DDL
+	// sent to the downstream, the DDL may be executed or not if the consumer crashes
+	// before it's recorded in ddlPartition.
+	pendingDDLPartition = -2
+)
+
+// checkpointStore records the watermark applied to the downstream of each partition,
+// it's stored in the downstream, so it's consistent with the applied data even if
+// the kafka offset is not committed.
+type checkpointStore struct {
+	db      *sql.DB
+	groupID string
+	topic   string
+}
+
+func newCheckpointStore(ctx context.Context, downstreamURI, groupID, topic string) (*checkpointStore, error) {
+	uri, err := url.Parse(downstreamURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dsn, err := mysql.GenBasicDSN(uri, mysql.NewConfig())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	db, err := openDB(ctx, dsn.FormatDSN())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &checkpointStore{db: db, groupID: groupID, topic: topic}
+	if err = s.init(ctx); err != nil {
+		_ = db.Close()
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (s *checkpointStore) init(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", checkpointSchema))
+	if err != nil {
+		return errors.WrapError(errors.ErrMySQLTxnError, err)
+	}
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.%s (
+		group_id VARCHAR(255) NOT NULL,
+		topic VARCHAR(255) NOT NULL,
+		partition_id INT NOT NULL,
+		watermark BIGINT UNSIGNED NOT NULL,
+		PRIMARY KEY (group_id, topic, partition_id)
+	)`, checkpointSchema, checkpointTable))
+	return errors.WrapError(errors.ErrMySQLTxnError, err)
+}
+
+// load returns the applied watermark of each partition, the key ddlPartition
+// is the commitTs of the latest applied DDL, and the key pendingDDLPartition is
+// the commitTs of the latest DDL sent to the downstream.
+func (s *checkpointStore) load(ctx context.Context) (map[int32]uint64, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT partition_id, watermark FROM %s.%s WHERE group_id = ? AND topic = ?",
+		checkpointSchema, checkpointTable), s.groupID, s.topic)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMySQLTxnError, err)
+	}
+	defer rows.Close()
+	result := make(map[int32]uint64)
+	for rows.Next() {
+		var (
+			partition int32
+			watermark uint64
+		)
+		if err = rows.Scan(&partition, &watermark); err != nil {
+			return nil, errors.WrapError(errors.ErrMySQLTxnError, err)
+		}
+		result[partition] = watermark
+	}
+	log.Info("consumer checkpoint loaded",
+		zap.String("groupID", s.groupID), zap.String("topic", s.topic), zap.Any("watermarks", result))
+	return result, errors.WrapError(errors.ErrMySQLTxnError, rows.Err())
+}
+
+// save records the watermark of the partition, it must be called after all events
+// before the watermark of the partition are applied to the downstream.
+// The events are applied by the sinks in their own transactions, so the watermark
+// can't be saved atomically with them, the events after the saved watermark are
+// replayed idempotently after a crash instead.
+func (s *checkpointStore) save(ctx context.Context, partition int32, watermark uint64) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s.%s (group_id, topic, partition_id, watermark) VALUES (?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE watermark = GREATEST(watermark, VALUES(watermark))",
+		checkpointSchema, checkpointTable), s.groupID, s.topic, partition, watermark)
+	return errors.WrapError(errors.ErrMySQLTxnError, err)
+}
+
+func (s *checkpointStore) close() {
+	if err := s.db.Close(); err != nil {
+		log.Warn("close the checkpoint db failed", zap.Error(err))
+	}
+}
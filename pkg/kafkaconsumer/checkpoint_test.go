@@ -0,0 +1,212 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsumer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	s := &checkpointStore{db: db, groupID: "group", topic: "topic"}
+	ctx := context.Background()
+
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS tidb_cdc.kafka_consumer_checkpoint").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, s.init(ctx))
+
+	// the watermark of each partition and the commitTs of the latest applied DDL are loaded
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT partition_id, watermark FROM tidb_cdc.kafka_consumer_checkpoint WHERE group_id = ? AND topic = ?")).
+		WithArgs("group", "topic").
+		WillReturnRows(sqlmock.NewRows([]string{"partition_id", "watermark"}).
+			AddRow(0, 100).AddRow(1, 200).AddRow(ddlPartition, 50))
+	watermarks, err := s.load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[int32]uint64{0: 100, 1: 200, ddlPartition: 50}, watermarks)
+
+	// the saved watermark never goes back
+	mock.ExpectExec(regexp.QuoteMeta("ON DUPLICATE KEY UPDATE watermark = GREATEST(watermark, VALUES(watermark))")).
+		WithArgs("group", "topic", int32(1), uint64(300)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, s.save(ctx, 1, 300))
+
+	mock.ExpectExec("INSERT INTO tidb_cdc.kafka_consumer_checkpoint").
+		WillReturnError(context.DeadlineExceeded)
+	require.Error(t, s.save(ctx, 1, 400))
+
+	mock.ExpectClose()
+	s.close()
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// mockDDLSink records the executed DDLs, it returns err if it's not nil.
+type mockDDLSink struct {
+	ddls []*model.DDLEvent
+	err  error
+}
+
+func (s *mockDDLSink) WriteDDLEvent(_ context.Context, ddl *model.DDLEvent) error {
+	s.ddls = append(s.ddls, ddl)
+	return s.err
+}
+
+func (s *mockDDLSink) WriteCheckpointTs(context.Context, uint64, []*model.TableInfo) error {
+	return nil
+}
+
+func (s *mockDDLSink) Close() {}
+
+// mockTableSink records the applied rows, the rows are flushed once the resolved ts is updated.
+type mockTableSink struct {
+	tablesink.TableSink
+	rows       []*model.RowChangedEvent
+	checkpoint model.ResolvedTs
+}
+
+func (s *mockTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	s.rows = append(s.rows, rows...)
+}
+
+func (s *mockTableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	s.checkpoint = resolvedTs
+	return nil
+}
+
+func (s *mockTableSink) GetCheckpointTs() model.ResolvedTs {
+	return s.checkpoint
+}
+
+// newCheckpointTestWriter creates a writer of one partition with the watermarks loaded from the checkpoint.
+func newCheckpointTestWriter(
+	t *testing.T, watermarks map[int32]uint64,
+) (*writer, sqlmock.Sqlmock, *mockDDLSink, *mockTableSink) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	ddlSink := &mockDDLSink{}
+	tableSink := &mockTableSink{}
+	progress := newPartitionProgress(0, nil)
+	progress.appliedWatermark = watermarks[0]
+	progress.tableSinkMap[1] = tableSink
+	w := &writer{
+		option:       &Option{},
+		ddlSink:      ddlSink,
+		appliedDDLTs: watermarks[ddlPartition],
+		pendingDDLTs: watermarks[pendingDDLPartition],
+		checkpoint:   &checkpointStore{db: db, groupID: "group", topic: "topic"},
+		progresses:   []*partitionProgress{progress},
+	}
+	return w, mock, ddlSink, tableSink
+}
+
+func expectSaveCheckpoint(mock sqlmock.Sqlmock, partition int32, watermark uint64) *sqlmock.ExpectedExec {
+	return mock.ExpectExec("INSERT INTO tidb_cdc.kafka_consumer_checkpoint").
+		WithArgs("group", "topic", partition, watermark)
+}
+
+func TestCrashBetweenDDLApplyAndSave(t *testing.T) {
+	ctx := context.Background()
+	ddl := &model.DDLEvent{CommitTs: 100, Query: "RENAME TABLE test.t1 TO test.t2"}
+
+	// the DDL is executed, but the consumer crashes before it's recorded as applied
+	w, mock, ddlSink, _ := newCheckpointTestWriter(t, nil)
+	w.appendDDL(ddl, 1)
+	require.NoError(t, w.progresses[0].updateWatermark(150, 2))
+	expectSaveCheckpoint(mock, pendingDDLPartition, 100).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectSaveCheckpoint(mock, ddlPartition, 100).WillReturnError(context.Canceled)
+	_, err := w.Write(ctx, model.MessageTypeResolved)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, ddlSink.ddls, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the DDL is replayed after restarting, the downstream rejects it since it's executed
+	w, mock, ddlSink, _ = newCheckpointTestWriter(t, map[int32]uint64{pendingDDLPartition: 100})
+	ddlSink.err = &dmysql.MySQLError{Number: 1146, Message: "Table 'test.t1' doesn't exist"}
+	w.appendDDL(ddl, 1)
+	require.NoError(t, w.progresses[0].updateWatermark(150, 2))
+	expectSaveCheckpoint(mock, pendingDDLPartition, 100).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectSaveCheckpoint(mock, ddlPartition, 100).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectSaveCheckpoint(mock, 0, 150).WillReturnResult(sqlmock.NewResult(0, 1))
+	flushed, err := w.Write(ctx, model.MessageTypeResolved)
+	require.NoError(t, err)
+	require.True(t, flushed)
+	require.Len(t, ddlSink.ddls, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the DDL which is never sent to the downstream before restarting fails as usual
+	w, mock, ddlSink, _ = newCheckpointTestWriter(t, map[int32]uint64{ddlPartition: 100, pendingDDLPartition: 100})
+	ddlSink.err = &dmysql.MySQLError{Number: 1146, Message: "Table 'test.t2' doesn't exist"}
+	w.appendDDL(&model.DDLEvent{CommitTs: 200, Query: "DROP INDEX idx ON test.t2"}, 3)
+	require.NoError(t, w.progresses[0].updateWatermark(250, 4))
+	expectSaveCheckpoint(mock, pendingDDLPartition, 200).WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = w.Write(ctx, model.MessageTypeResolved)
+	require.ErrorContains(t, err, "doesn't exist")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrashBetweenDMLApplyAndSave(t *testing.T) {
+	ctx := context.Background()
+	newRow := func() *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			CommitTs:        120,
+			PhysicalTableID: 1,
+			TableInfo:       &model.TableInfo{TableName: model.TableName{Schema: "test", Table: "t", TableID: 1}},
+		}
+	}
+	apply := func(w *writer) (bool, error) {
+		progress := w.progresses[0]
+		w.appendRow2Group(newRow(), progress, 1)
+		if err := progress.updateWatermark(150, 2); err != nil {
+			return false, err
+		}
+		w.resolveRowChangedEvents(progress, 150)
+		return w.Write(ctx, model.MessageTypeResolved)
+	}
+
+	// the row is applied, but the consumer crashes before the watermark is saved
+	w, mock, _, tableSink := newCheckpointTestWriter(t, map[int32]uint64{0: 100})
+	expectSaveCheckpoint(mock, 0, 150).WillReturnError(context.Canceled)
+	_, err := apply(w)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, tableSink.rows, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the row after the saved watermark is applied again after restarting, the safe
+	// mode of the downstream overwrites the row applied before
+	w, mock, _, tableSink = newCheckpointTestWriter(t, map[int32]uint64{0: 100})
+	expectSaveCheckpoint(mock, 0, 150).WillReturnResult(sqlmock.NewResult(0, 1))
+	flushed, err := apply(w)
+	require.NoError(t, err)
+	require.True(t, flushed)
+	require.Len(t, tableSink.rows, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the row before the saved watermark is skipped after restarting
+	w, mock, _, tableSink = newCheckpointTestWriter(t, map[int32]uint64{0: 150})
+	flushed, err = apply(w)
+	require.NoError(t, err)
+	require.True(t, flushed)
+	require.Empty(t, tableSink.rows)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
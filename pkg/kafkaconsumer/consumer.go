@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package kafkaconsumer
 
 import (
 	"context"
@@ -25,15 +25,15 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-func getPartitionNum(o *option) (int32, error) {
+func getPartitionNum(o *Option) (int32, error) {
 	configMap := &kafka.ConfigMap{
-		"bootstrap.servers": strings.Join(o.address, ","),
+		"bootstrap.servers": strings.Join(o.Address, ","),
 	}
-	if len(o.ca) != 0 {
+	if len(o.CA) != 0 {
 		_ = configMap.SetKey("security.protocol", "SSL")
-		_ = configMap.SetKey("ssl.ca.location", o.ca)
-		_ = configMap.SetKey("ssl.key.location", o.key)
-		_ = configMap.SetKey("ssl.certificate.location", o.cert)
+		_ = configMap.SetKey("ssl.ca.location", o.CA)
+		_ = configMap.SetKey("ssl.key.location", o.Key)
+		_ = configMap.SetKey("ssl.certificate.location", o.Cert)
 	}
 	admin, err := kafka.NewAdminClient(configMap)
 	if err != nil {
@@ -42,8 +42,8 @@ func getPartitionNum(o *option) (int32, error) {
 	defer admin.Close()
 
 	timeout := 3000
-	for i := 0; i <= o.retryTime; i++ {
-		resp, err := admin.GetMetadata(&o.topic, false, timeout)
+	for i := 0; i <= o.RetryTime; i++ {
+		resp, err := admin.GetMetadata(&o.Topic, false, timeout)
 		if err != nil {
 			if err.(kafka.Error).Code() == kafka.ErrTransport {
 				log.Info("retry get partition number", zap.Int("retryTime", i), zap.Int("timeout", timeout))
@@ -52,40 +52,43 @@ func getPartitionNum(o *option) (int32, error) {
 			}
 			return 0, errors.Trace(err)
 		}
-		if topicDetail, ok := resp.Topics[o.topic]; ok {
+		if topicDetail, ok := resp.Topics[o.Topic]; ok {
 			numPartitions := int32(len(topicDetail.Partitions))
 			log.Info("get partition number of topic",
-				zap.String("topic", o.topic),
+				zap.String("topic", o.Topic),
 				zap.Int32("partitionNum", numPartitions))
 			return numPartitions, nil
 		}
-		log.Info("retry get partition number", zap.String("topic", o.topic))
+		log.Info("retry get partition number", zap.String("topic", o.Topic))
 		time.Sleep(1 * time.Second)
 	}
-	return 0, errors.Errorf("get partition number(%s) timeout", o.topic)
+	return 0, errors.Errorf("get partition number(%s) timeout", o.Topic)
 }
 
-type consumer struct {
+// Consumer consumes the messages of any protocol produced by the changefeed from the kafka topic,
+// and applies them to the downstream in the order of the watermark of each partition.
+// The offset is committed only after the events before it are applied to the downstream.
+type Consumer struct {
 	client *kafka.Consumer
 	writer *writer
 }
 
-// newConsumer will create a consumer client.
-func newConsumer(ctx context.Context, o *option) *consumer {
+// New creates a consumer client, the option should be adjusted before.
+func New(ctx context.Context, o *Option) (*Consumer, error) {
 	partitionNum, err := getPartitionNum(o)
 	if err != nil {
-		log.Panic("cannot get the partition number", zap.String("topic", o.topic), zap.Error(err))
+		return nil, errors.Trace(err)
 	}
-	if o.partitionNum == 0 {
-		o.partitionNum = partitionNum
+	if o.PartitionNum == 0 {
+		o.PartitionNum = partitionNum
 	}
-	topics := strings.Split(o.topic, ",")
+	topics := strings.Split(o.Topic, ",")
 	if len(topics) == 0 {
-		log.Panic("no topic provided for the consumer")
+		return nil, errors.ErrKafkaInvalidConfig.GenWithStack("no topic provided for the consumer")
 	}
 	configMap := &kafka.ConfigMap{
-		"bootstrap.servers": strings.Join(o.address, ","),
-		"group.id":          o.groupID,
+		"bootstrap.servers": strings.Join(o.Address, ","),
+		"group.id":          o.GroupID,
 		// Start reading from the first message of each assigned
 		// partition if there are no previously committed offsets
 		// for this group.
@@ -94,41 +97,49 @@ func newConsumer(ctx context.Context, o *option) *consumer {
 		"enable.auto.offset.store": false,
 		"enable.auto.commit":       false,
 	}
-	if len(o.ca) != 0 {
+	if len(o.CA) != 0 {
 		_ = configMap.SetKey("security.protocol", "SSL")
-		_ = configMap.SetKey("ssl.ca.location", o.ca)
-		_ = configMap.SetKey("ssl.key.location", o.key)
-		_ = configMap.SetKey("ssl.certificate.location", o.cert)
+		_ = configMap.SetKey("ssl.ca.location", o.CA)
+		_ = configMap.SetKey("ssl.key.location", o.Key)
+		_ = configMap.SetKey("ssl.certificate.location", o.Cert)
 	}
-	if level, err := zapcore.ParseLevel(o.logLevel); err == nil && level.String() == "debug" {
+	if level, err := zapcore.ParseLevel(o.LogLevel); err == nil && level.String() == "debug" {
 		configMap.SetKey("debug", "all")
 	}
+	w, err := newWriter(ctx, o)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	client, err := kafka.NewConsumer(configMap)
 	if err != nil {
-		log.Panic("create kafka consumer failed", zap.Error(err))
+		w.close()
+		return nil, errors.Trace(err)
 	}
 	err = client.SubscribeTopics(topics, nil)
 	if err != nil {
-		log.Panic("subscribe topics failed", zap.Error(err))
+		w.close()
+		_ = client.Close()
+		return nil, errors.Trace(err)
 	}
-	return &consumer{
-		writer: newWriter(ctx, o),
+	return &Consumer{
+		writer: w,
 		client: client,
-	}
+	}, nil
 }
 
-// Consume will read message from Kafka.
-func (c *consumer) Consume(ctx context.Context) {
+// Consume will read message from Kafka until the context is canceled or an error occurs.
+func (c *Consumer) Consume(ctx context.Context) error {
 	defer func() {
+		c.writer.close()
 		if err := c.client.Close(); err != nil {
-			log.Panic("close kafka consumer failed", zap.Error(err))
+			log.Warn("close kafka consumer failed", zap.Error(err))
 		}
 	}()
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info("consumer exist: context cancelled")
-			return
+			return nil
 		default:
 		}
 		msg, err := c.client.ReadMessage(-1)
@@ -136,7 +147,10 @@ func (c *consumer) Consume(ctx context.Context) {
 			log.Error("read message failed, just continue to retry", zap.Error(err))
 			continue
 		}
-		needCommit := c.writer.WriteMessage(ctx, msg)
+		needCommit, err := c.writer.WriteMessage(ctx, msg)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		if !needCommit {
 			continue
 		}
@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package kafkaconsumer
 
 import (
 	"sort"
@@ -31,8 +31,8 @@ type eventsGroup struct {
 	highWatermark uint64
 }
 
-// NewEventsGroup will create new event group.
-func NewEventsGroup(partition int32, tableID int64) *eventsGroup {
+// newEventsGroup will create new event group.
+func newEventsGroup(partition int32, tableID int64) *eventsGroup {
 	return &eventsGroup{
 		partition: partition,
 		tableID:   tableID,
@@ -0,0 +1,250 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsumer
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/errors"
+	cmdUtil "github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+var (
+	defaultVersion   = "2.4.0"
+	defaultRetryTime = 30
+	defaultTimeout   = time.Second * 10
+)
+
+// Option is the option of the consumer.
+type Option struct {
+	Address      []string
+	Version      string
+	Topic        string
+	PartitionNum int32
+	// GroupID is the kafka consumer group id, it also identifies the checkpoint
+	// in the downstream, so it should be fixed to resume from the checkpoint.
+	GroupID string
+
+	MaxMessageBytes int
+	MaxBatchSize    int
+
+	Protocol config.Protocol
+
+	CodecConfig *common.Config
+	// the replicaConfig of the changefeed which produce data to the kafka topic
+	ReplicaConfig *config.ReplicaConfig
+
+	LogLevel      string
+	Timezone      string
+	CA, Cert, Key string
+
+	DownstreamURI string
+
+	// avro schema registry uri should be set if the encoding protocol is avro
+	SchemaRegistryURI string
+
+	// UpstreamTiDBDSN is the dsn of the upstream TiDB cluster
+	UpstreamTiDBDSN string
+	// TiCDCAddresses are the addresses of the TiCDC servers which produce the messages, if it's
+	// set, the full rows of the handle-key-only messages are fetched from the event store of
+	// TiCDC instead of the upstream TiDB. Only the canal-json protocol is supported.
+	TiCDCAddresses []string
+
+	// EnableCheckpoint records the applied watermark of each partition in the MySQL-compatible
+	// downstream, the events before it are skipped after the consumer restarts. The checkpoint
+	// is saved after the events are applied, so the events after it may be applied again if the
+	// consumer crashes in between. The rows are written in the safe mode of the downstream to
+	// overwrite the rows applied before, and a DDL which may be executed before the crash is
+	// treated as applied if the downstream rejects it. It's at-least-once delivery with
+	// idempotent writes, not exactly-once.
+	EnableCheckpoint bool
+
+	// connect kafka retry times, default 30
+	RetryTime int
+	// connect kafka  timeout, default 10s
+	Timeout time.Duration
+}
+
+// NewOption creates an Option with the default values.
+func NewOption() *Option {
+	return &Option{
+		Version:         defaultVersion,
+		MaxMessageBytes: math.MaxInt64,
+		MaxBatchSize:    math.MaxInt64,
+		RetryTime:       defaultRetryTime,
+		Timeout:         defaultTimeout,
+		LogLevel:        "info",
+		Timezone:        "System",
+	}
+}
+
+// Adjust the consumer option by the upstream uri passed in parameters.
+func (o *Option) Adjust(upstreamURI *url.URL, configFile string) error {
+	if scheme := strings.ToLower(upstreamURI.Scheme); scheme != "kafka" {
+		return errors.ErrKafkaInvalidConfig.GenWithStack(
+			"invalid upstream-uri scheme %s, the scheme of upstream-uri must be `kafka`", scheme)
+	}
+	s := upstreamURI.Query().Get("version")
+	if s != "" {
+		o.Version = s
+	}
+	o.Topic = strings.TrimFunc(upstreamURI.Path, func(r rune) bool {
+		return r == '/'
+	})
+	o.Address = strings.Split(upstreamURI.Host, ",")
+
+	s = upstreamURI.Query().Get("partition-num")
+	if s != "" {
+		c, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return errors.ErrKafkaInvalidConfig.GenWithStack("invalid partition-num of upstream-uri: %s", s)
+		}
+		o.PartitionNum = int32(c)
+	}
+
+	s = upstreamURI.Query().Get("max-message-bytes")
+	if s != "" {
+		c, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.ErrKafkaInvalidConfig.GenWithStack("invalid max-message-bytes of upstream-uri: %s", s)
+		}
+		o.MaxMessageBytes = c
+	}
+
+	s = upstreamURI.Query().Get("max-batch-size")
+	if s != "" {
+		c, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.ErrKafkaInvalidConfig.GenWithStack("invalid max-batch-size of upstream-uri: %s", s)
+		}
+		o.MaxBatchSize = c
+	}
+
+	s = upstreamURI.Query().Get("protocol")
+	if s == "" {
+		return errors.ErrKafkaInvalidConfig.GenWithStack("cannot found the protocol from the upstream-uri")
+	}
+	protocol, err := config.ParseSinkProtocolFromString(s)
+	if err != nil {
+		return errors.WrapError(errors.ErrKafkaInvalidConfig, err)
+	}
+	o.Protocol = protocol
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	// the TiDB source ID should never be set to 0
+	replicaConfig.Sink.TiDBSourceID = 1
+	replicaConfig.Sink.Protocol = util.AddressOf(protocol.String())
+	if configFile != "" {
+		err = cmdUtil.StrictDecodeFile(configFile, "kafka consumer", replicaConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err = filter.VerifyTableRules(replicaConfig.Filter); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	o.ReplicaConfig = replicaConfig
+
+	o.CodecConfig = common.NewConfig(protocol)
+	if err = o.CodecConfig.Apply(upstreamURI, o.ReplicaConfig); err != nil {
+		return errors.Trace(err)
+	}
+	tz, err := util.GetTimezone(o.Timezone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.CodecConfig.TimeZone = tz
+	// the downstream uses the timezone of the consumer, the global server config is not touched
+	o.DownstreamURI, err = withTimezone(o.DownstreamURI, tz.String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if protocol == config.ProtocolAvro {
+		o.CodecConfig.AvroEnableWatermark = true
+	}
+
+	if o.EnableCheckpoint {
+		o.DownstreamURI, err = enableSafeMode(o.DownstreamURI)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	log.Info("consumer option adjusted",
+		zap.String("configFile", configFile),
+		zap.String("address", strings.Join(o.Address, ",")),
+		zap.String("version", o.Version),
+		zap.String("topic", o.Topic),
+		zap.Int32("partitionNum", o.PartitionNum),
+		zap.String("groupID", o.GroupID),
+		zap.Int("maxMessageBytes", o.MaxMessageBytes),
+		zap.Int("maxBatchSize", o.MaxBatchSize),
+		zap.Bool("enableCheckpoint", o.EnableCheckpoint),
+		zap.String("upstreamURI", upstreamURI.String()),
+		zap.String("downstreamURI", o.DownstreamURI))
+	return nil
+}
+
+// withTimezone sets the timezone of the MySQL-compatible downstream if it's not specified
+// in the uri, the other downstreams are returned as is.
+func withTimezone(downstreamURI string, tz string) (string, error) {
+	uri, err := url.Parse(downstreamURI)
+	if err != nil {
+		return "", errors.WrapError(errors.ErrKafkaInvalidConfig, err)
+	}
+	if !sink.IsMySQLCompatibleScheme(uri.Scheme) {
+		return downstreamURI, nil
+	}
+	query := uri.Query()
+	if query.Has("time-zone") {
+		return downstreamURI, nil
+	}
+	query.Set("time-zone", tz)
+	uri.RawQuery = query.Encode()
+	return uri.String(), nil
+}
+
+// enableSafeMode turns on the safe mode of the MySQL-compatible downstream, so the events
+// replayed after the consumer restarts are applied idempotently. The checkpoint can't be
+// enabled without the safe mode.
+func enableSafeMode(downstreamURI string) (string, error) {
+	uri, err := url.Parse(downstreamURI)
+	if err != nil {
+		return "", errors.WrapError(errors.ErrKafkaInvalidConfig, err)
+	}
+	if !sink.IsMySQLCompatibleScheme(uri.Scheme) {
+		return "", errors.ErrKafkaInvalidConfig.GenWithStack(
+			"the checkpoint is only supported by the MySQL-compatible downstream, got %s", uri.Scheme)
+	}
+	query := uri.Query()
+	if query.Get("safe-mode") == "false" {
+		return "", errors.ErrKafkaInvalidConfig.GenWithStack(
+			"the checkpoint requires the safe mode of the downstream, the replayed events are not idempotent otherwise")
+	}
+	query.Set("safe-mode", "true")
+	uri.RawQuery = query.Encode()
+	return uri.String(), nil
+}
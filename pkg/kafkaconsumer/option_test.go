@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsumer
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionAdjust(t *testing.T) {
+	o := NewOption()
+	uri, err := url.Parse("kafka://127.0.0.1:9092,127.0.0.1:9093/topic?protocol=canal-json&partition-num=3&max-batch-size=16")
+	require.NoError(t, err)
+	require.NoError(t, o.Adjust(uri, ""))
+	require.Equal(t, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, o.Address)
+	require.Equal(t, "topic", o.Topic)
+	require.Equal(t, int32(3), o.PartitionNum)
+	require.Equal(t, 16, o.MaxBatchSize)
+	require.Equal(t, config.ProtocolCanalJSON, o.Protocol)
+
+	uri, err = url.Parse("kafka://127.0.0.1:9092/topic")
+	require.NoError(t, err)
+	require.Error(t, NewOption().Adjust(uri, ""))
+
+	uri, err = url.Parse("pulsar://127.0.0.1:6650/topic?protocol=canal-json")
+	require.NoError(t, err)
+	require.Error(t, NewOption().Adjust(uri, ""))
+}
+
+func TestWithTimezone(t *testing.T) {
+	uri, err := withTimezone("mysql://root@127.0.0.1:3306/?worker-count=4", "Asia/Shanghai")
+	require.NoError(t, err)
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Shanghai", parsed.Query().Get("time-zone"))
+	require.Equal(t, "4", parsed.Query().Get("worker-count"))
+
+	// the timezone specified in the uri is kept, even if it's empty.
+	uri, err = withTimezone("tidb://root@127.0.0.1:4000/?time-zone=UTC", "Asia/Shanghai")
+	require.NoError(t, err)
+	require.Equal(t, "tidb://root@127.0.0.1:4000/?time-zone=UTC", uri)
+	uri, err = withTimezone("mysql://root@127.0.0.1:3306/?time-zone=", "Asia/Shanghai")
+	require.NoError(t, err)
+	require.Equal(t, "mysql://root@127.0.0.1:3306/?time-zone=", uri)
+
+	uri, err = withTimezone("blackhole://", "Asia/Shanghai")
+	require.NoError(t, err)
+	require.Equal(t, "blackhole://", uri)
+
+	// the timezone of the consumer is set to the downstream without touching the server config.
+	o := NewOption()
+	o.Timezone = "Asia/Tokyo"
+	o.DownstreamURI = "mysql://root@127.0.0.1:3306/"
+	upstream, err := url.Parse("kafka://127.0.0.1:9092/topic?protocol=canal-json")
+	require.NoError(t, err)
+	require.NoError(t, o.Adjust(upstream, ""))
+	require.Equal(t, "mysql://root@127.0.0.1:3306/?time-zone=Asia%2FTokyo", o.DownstreamURI)
+	require.Equal(t, "Asia/Tokyo", o.CodecConfig.TimeZone.String())
+	require.NotEqual(t, "Asia/Tokyo", config.GetGlobalServerConfig().TZ)
+}
+
+func TestEnableSafeMode(t *testing.T) {
+	uri, err := enableSafeMode("mysql://root@127.0.0.1:3306/?worker-count=4")
+	require.NoError(t, err)
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "true", parsed.Query().Get("safe-mode"))
+	require.Equal(t, "4", parsed.Query().Get("worker-count"))
+
+	// the replayed events are not idempotent without the safe mode
+	_, err = enableSafeMode("tidb://root@127.0.0.1:4000/?safe-mode=false")
+	require.ErrorContains(t, err, "requires the safe mode")
+
+	_, err = enableSafeMode("kafka://127.0.0.1:9092/topic")
+	require.Error(t, err)
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsumer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const fetchRowPath = "/api/v2/fetch_row"
+
+type fetchRowRequest struct {
+	Schema    string            `json:"schema"`
+	Table     string            `json:"table"`
+	CommitTs  uint64            `json:"commit_ts"`
+	HandleKey map[string]string `json:"handle_key"`
+}
+
+type fetchRowResponse struct {
+	Columns    map[string]interface{} `json:"columns,omitempty"`
+	PreColumns map[string]interface{} `json:"pre_columns,omitempty"`
+	MySQLTypes map[string]string      `json:"mysql_types"`
+}
+
+type httpError struct {
+	Error string `json:"error_msg"`
+}
+
+// rowFetcher fetches the full row of the messages which only contain the handle key columns
+// from the event store of the TiCDC servers, so the upstream TiDB is not needed to complete
+// the messages. Only the servers which replicate the table retain its events, so the servers
+// are tried one by one.
+type rowFetcher struct {
+	addresses []string
+	client    *http.Client
+}
+
+func newRowFetcher(addresses []string, timeout time.Duration) *rowFetcher {
+	result := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addr = strings.TrimSuffix(strings.TrimSpace(addr), "/")
+		if addr == "" {
+			continue
+		}
+		if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
+			addr = "http://" + addr
+		}
+		result = append(result, addr)
+	}
+	return &rowFetcher{
+		addresses: result,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (f *rowFetcher) fetch(ctx context.Context, req *fetchRowRequest) (*fetchRowResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	var lastErr error
+	for _, addr := range f.addresses {
+		resp, err := f.fetchFrom(ctx, addr, body)
+		if err == nil {
+			return resp, nil
+		}
+		log.Debug("fetch row from the server failed, try the next one",
+			zap.String("address", addr), zap.String("schema", req.Schema),
+			zap.String("table", req.Table), zap.Uint64("commitTs", req.CommitTs), zap.Error(err))
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = cerror.ErrRowNotFound.GenWithStackByArgs("no TiCDC server address is specified")
+	}
+	return nil, lastErr
+}
+
+func (f *rowFetcher) fetchFrom(ctx context.Context, addr string, body []byte) (*fetchRowResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, addr+fetchRowPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := f.client.Do(httpReq)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e httpError
+		if err := json.Unmarshal(data, &e); err != nil || e.Error == "" {
+			return nil, cerror.Errorf("fetch row failed, status: %d, body: %s", resp.StatusCode, data)
+		}
+		return nil, cerror.Errorf("fetch row failed: %s", e.Error)
+	}
+	result := &fetchRowResponse{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, cerror.Trace(err)
+	}
+	return result, nil
+}
+
+// completeCanalJSON replaces the handle key columns in the canal-json message with the full row
+// fetched from TiCDC. The message is returned as is if it's not a handle-key-only message.
+func (f *rowFetcher) completeCanalJSON(ctx context.Context, value []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var msg map[string]interface{}
+	if err := decoder.Decode(&msg); err != nil {
+		return nil, cerror.Trace(err)
+	}
+	extension, ok := msg["_tidb"].(map[string]interface{})
+	if !ok {
+		return value, nil
+	}
+	if onlyHandleKey, _ := extension["onlyHandleKey"].(bool); !onlyHandleKey {
+		return value, nil
+	}
+	commitTs, err := jsonNumberToUint64(extension["commitTs"])
+	if err != nil {
+		return nil, err
+	}
+	mysqlTypes, _ := msg["mysqlType"].(map[string]interface{})
+	handleKey := make(map[string]string)
+	if rows, ok := msg["data"].([]interface{}); ok && len(rows) != 0 {
+		if row, ok := rows[0].(map[string]interface{}); ok {
+			for name, v := range row {
+				s, ok := v.(string)
+				if !ok {
+					continue
+				}
+				// the binary values are encoded in ISO8859-1 by canal-json, revert it to the raw bytes.
+				if mysqlType, ok := mysqlTypes[name].(string); ok && utils.IsBinaryMySQLType(mysqlType) {
+					s, err = charmap.ISO8859_1.NewEncoder().String(s)
+					if err != nil {
+						return nil, cerror.Trace(err)
+					}
+				}
+				handleKey[name] = s
+			}
+		}
+	}
+	schema, _ := msg["database"].(string)
+	table, _ := msg["table"].(string)
+	resp, err := f.fetch(ctx, &fetchRowRequest{
+		Schema:    schema,
+		Table:     table,
+		CommitTs:  commitTs,
+		HandleKey: handleKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]interface{}, len(resp.MySQLTypes))
+	for name, mysqlType := range resp.MySQLTypes {
+		types[name] = mysqlType
+	}
+	toCanalJSON := func(columns map[string]interface{}) ([]interface{}, error) {
+		row := make(map[string]interface{}, len(columns))
+		for name, v := range columns {
+			s, ok := v.(string)
+			if ok && utils.IsBinaryMySQLType(resp.MySQLTypes[name]) {
+				s, err = charmap.ISO8859_1.NewDecoder().String(s)
+				if err != nil {
+					return nil, cerror.Trace(err)
+				}
+				v = s
+			}
+			row[name] = v
+		}
+		return []interface{}{row}, nil
+	}
+
+	eventType, _ := msg["type"].(string)
+	switch eventType {
+	case "INSERT":
+		msg["data"], err = toCanalJSON(resp.Columns)
+	case "UPDATE":
+		msg["data"], err = toCanalJSON(resp.Columns)
+		if err == nil {
+			msg["old"], err = toCanalJSON(resp.PreColumns)
+		}
+	case "DELETE":
+		msg["data"], err = toCanalJSON(resp.PreColumns)
+	default:
+		return nil, cerror.Errorf("unknown event type %s of the handle key only message", eventType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg["mysqlType"] = types
+	delete(extension, "onlyHandleKey")
+	result, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	return result, nil
+}
+
+func jsonNumberToUint64(v interface{}) (uint64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, cerror.Errorf("invalid number %v", v)
+	}
+	result, err := strconv.ParseUint(n.String(), 10, 64)
+	if err != nil {
+		return 0, cerror.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteCanalJSON(t *testing.T) {
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error_msg":"row not found","error_code":"CDC:ErrRowNotFound"}`))
+	}))
+	defer notFound.Close()
+
+	var received fetchRowRequest
+	found := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fetchRowPath, r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_ = json.NewEncoder(w).Encode(&fetchRowResponse{
+			Columns:    map[string]interface{}{"id": "1", "name": "b", "note": nil},
+			PreColumns: map[string]interface{}{"id": "1", "name": "a", "note": nil},
+			MySQLTypes: map[string]string{"id": "int", "name": "varchar(16)", "note": "text"},
+		})
+	}))
+	defer found.Close()
+
+	fetcher := newRowFetcher([]string{notFound.URL, found.URL}, time.Second)
+	ctx := context.Background()
+
+	value := []byte(`{"id":0,"database":"test","table":"t","pkNames":["id"],"isDdl":false,"type":"UPDATE",` +
+		`"es":1,"ts":2,"sql":"","sqlType":{"id":4},"mysqlType":{"id":"int"},"data":[{"id":"1"}],` +
+		`"old":[{"id":"1"}],"_tidb":{"commitTs":434343434343434343,"onlyHandleKey":true}}`)
+	completed, err := fetcher.completeCanalJSON(ctx, value)
+	require.NoError(t, err)
+	require.Equal(t, fetchRowRequest{
+		Schema:    "test",
+		Table:     "t",
+		CommitTs:  434343434343434343,
+		HandleKey: map[string]string{"id": "1"},
+	}, received)
+
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal(completed, &msg))
+	require.Equal(t, []interface{}{map[string]interface{}{"id": "1", "name": "b", "note": nil}}, msg["data"])
+	require.Equal(t, []interface{}{map[string]interface{}{"id": "1", "name": "a", "note": nil}}, msg["old"])
+	require.Equal(t, map[string]interface{}{"id": "int", "name": "varchar(16)", "note": "text"}, msg["mysqlType"])
+	require.Equal(t, map[string]interface{}{"commitTs": float64(434343434343434343)}, msg["_tidb"])
+
+	// the message which contains all columns is not changed.
+	value = []byte(`{"database":"test","table":"t","type":"INSERT","data":[{"id":"1"}],"_tidb":{"commitTs":1}}`)
+	completed, err = fetcher.completeCanalJSON(ctx, value)
+	require.NoError(t, err)
+	require.Equal(t, value, completed)
+
+	// all servers fail to fetch the row.
+	fetcher = newRowFetcher([]string{notFound.URL}, time.Second)
+	value = []byte(`{"database":"test","table":"t","type":"DELETE","data":[{"id":"1"}],` +
+		`"_tidb":{"commitTs":1,"onlyHandleKey":true}}`)
+	_, err = fetcher.completeCanalJSON(ctx, value)
+	require.ErrorContains(t, err, "row not found")
+}
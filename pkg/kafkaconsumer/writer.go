@@ -11,17 +11,17 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package kafkaconsumer
 
 import (
 	"context"
 	"database/sql"
 	"errors"
 	"math"
-	"sync"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/log"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -38,29 +38,31 @@ import (
 	"github.com/pingcap/tiflow/pkg/sink/codec/simple"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
-// NewDecoder will create a new event decoder
-func NewDecoder(ctx context.Context, option *option, upstreamTiDB *sql.DB) (codec.RowEventDecoder, error) {
+// NewDecoder will create a new event decoder of the protocol in the option,
+// it can be used to decode the kafka messages without applying them to the downstream.
+func NewDecoder(ctx context.Context, option *Option, upstreamTiDB *sql.DB) (codec.RowEventDecoder, error) {
 	var (
 		decoder codec.RowEventDecoder
 		err     error
 	)
-	switch option.protocol {
+	switch option.Protocol {
 	case config.ProtocolOpen, config.ProtocolDefault:
-		decoder, err = open.NewBatchDecoder(ctx, option.codecConfig, upstreamTiDB)
+		decoder, err = open.NewBatchDecoder(ctx, option.CodecConfig, upstreamTiDB)
 	case config.ProtocolCanalJSON:
-		decoder, err = canal.NewBatchDecoder(ctx, option.codecConfig, upstreamTiDB)
+		decoder, err = canal.NewBatchDecoder(ctx, option.CodecConfig, upstreamTiDB)
 	case config.ProtocolAvro:
-		schemaM, err := avro.NewConfluentSchemaManager(ctx, option.schemaRegistryURI, nil)
+		schemaM, err := avro.NewConfluentSchemaManager(ctx, option.SchemaRegistryURI, nil)
 		if err != nil {
 			return decoder, cerror.Trace(err)
 		}
-		decoder = avro.NewDecoder(option.codecConfig, schemaM, option.topic, upstreamTiDB)
+		decoder = avro.NewDecoder(option.CodecConfig, schemaM, option.Topic, upstreamTiDB)
 	case config.ProtocolSimple:
-		decoder, err = simple.NewDecoder(ctx, option.codecConfig, upstreamTiDB)
+		decoder, err = simple.NewDecoder(ctx, option.CodecConfig, upstreamTiDB)
 	default:
-		log.Panic("Protocol not supported", zap.Any("Protocol", option.protocol))
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack("protocol %s is not supported", option.Protocol)
 	}
 	if err != nil {
 		return nil, cerror.Trace(err)
@@ -72,6 +74,9 @@ type partitionProgress struct {
 	partition       int32
 	watermark       uint64
 	watermarkOffset kafka.Offset
+	// appliedWatermark is the watermark loaded from the checkpoint, the events
+	// before it have been applied to the downstream before the consumer restarts.
+	appliedWatermark uint64
 
 	tableSinkMap map[model.TableID]tablesink.TableSink
 	eventGroups  map[model.TableID]*eventsGroup
@@ -87,25 +92,28 @@ func newPartitionProgress(partition int32, decoder codec.RowEventDecoder) *parti
 	}
 }
 
-func (p *partitionProgress) updateWatermark(newWatermark uint64, offset kafka.Offset) {
+func (p *partitionProgress) updateWatermark(newWatermark uint64, offset kafka.Offset) error {
 	watermark := p.loadWatermark()
 	if newWatermark >= watermark {
 		p.watermark = newWatermark
 		p.watermarkOffset = offset
 		log.Info("watermark received", zap.Int32("partition", p.partition), zap.Any("offset", offset),
 			zap.Uint64("watermark", newWatermark))
-		return
+		return nil
 	}
 	if offset > p.watermarkOffset {
-		log.Panic("partition resolved ts fallback",
+		log.Error("partition resolved ts fallback",
 			zap.Int32("partition", p.partition),
 			zap.Uint64("newWatermark", newWatermark), zap.Any("offset", offset),
 			zap.Uint64("watermark", watermark), zap.Any("watermarkOffset", p.watermarkOffset))
+		return cerror.ErrDecodeFailed.GenWithStack("partition %d resolved ts fallback from %d to %d",
+			p.partition, watermark, newWatermark)
 	}
 	log.Warn("partition resolved ts fall back, ignore it, since consumer read old offset message",
 		zap.Int32("partition", p.partition),
 		zap.Uint64("newWatermark", newWatermark), zap.Any("offset", offset),
 		zap.Uint64("watermark", watermark), zap.Any("watermarkOffset", p.watermarkOffset))
+	return nil
 }
 
 func (p *partitionProgress) loadWatermark() uint64 {
@@ -113,59 +121,88 @@ func (p *partitionProgress) loadWatermark() uint64 {
 }
 
 type writer struct {
-	option *option
+	option *Option
 
 	ddlList            []*model.DDLEvent
 	ddlWithMaxCommitTs *model.DDLEvent
 	ddlSink            ddlsink.Sink
+	// appliedDDLTs is the commitTs of the latest applied DDL loaded from the checkpoint.
+	appliedDDLTs uint64
+	// pendingDDLTs is the commitTs of the latest DDL sent to the downstream loaded from
+	// the checkpoint, the DDLs after appliedDDLTs and before it may be executed before
+	// the consumer restarts.
+	pendingDDLTs uint64
+	// checkpoint is nil if the checkpoint is disabled.
+	checkpoint *checkpointStore
 
 	// sinkFactory is used to create table sink for each table.
 	sinkFactory *eventsinkfactory.SinkFactory
 	progresses  []*partitionProgress
 
 	eventRouter *dispatcher.EventRouter
+	// rowFetcher is nil if the full rows of the handle-key-only messages are not fetched from TiCDC.
+	rowFetcher *rowFetcher
 }
 
-func newWriter(ctx context.Context, o *option) *writer {
+func newWriter(ctx context.Context, o *Option) (*writer, error) {
 	w := &writer{
 		option:     o,
-		progresses: make([]*partitionProgress, o.partitionNum),
+		progresses: make([]*partitionProgress, o.PartitionNum),
 	}
 	var (
 		db  *sql.DB
 		err error
 	)
-	if o.upstreamTiDBDSN != "" {
-		db, err = openDB(ctx, o.upstreamTiDBDSN)
+	if o.UpstreamTiDBDSN != "" {
+		db, err = openDB(ctx, o.UpstreamTiDBDSN)
 		if err != nil {
-			log.Panic("cannot open the upstream TiDB, handle key only enabled",
-				zap.String("dsn", o.upstreamTiDBDSN))
+			return nil, cerror.Trace(err)
+		}
+	}
+	if len(o.TiCDCAddresses) != 0 {
+		if o.Protocol != config.ProtocolCanalJSON {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"fetching rows from TiCDC is not supported by the protocol %s", o.Protocol)
 		}
+		w.rowFetcher = newRowFetcher(o.TiCDCAddresses, o.Timeout)
 	}
-	for i := 0; i < int(o.partitionNum); i++ {
+	for i := 0; i < int(o.PartitionNum); i++ {
 		decoder, err := NewDecoder(ctx, o, db)
 		if err != nil {
-			log.Panic("cannot create the decoder", zap.Error(err))
+			return nil, cerror.Trace(err)
 		}
 		w.progresses[i] = newPartitionProgress(int32(i), decoder)
 	}
 
-	eventRouter, err := dispatcher.NewEventRouter(o.replicaConfig, o.protocol, o.topic, "kafka")
+	if o.EnableCheckpoint {
+		w.checkpoint, err = newCheckpointStore(ctx, o.DownstreamURI, o.GroupID, o.Topic)
+		if err != nil {
+			return nil, cerror.Trace(err)
+		}
+		watermarks, err := w.checkpoint.load(ctx)
+		if err != nil {
+			return nil, cerror.Trace(err)
+		}
+		for _, p := range w.progresses {
+			p.appliedWatermark = watermarks[p.partition]
+		}
+		w.appliedDDLTs = watermarks[ddlPartition]
+		w.pendingDDLTs = watermarks[pendingDDLPartition]
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(o.ReplicaConfig, o.Protocol, o.Topic, "kafka")
 	if err != nil {
-		log.Panic("initialize the event router failed",
-			zap.Any("protocol", o.protocol), zap.Any("topic", o.topic),
-			zap.Any("dispatcherRules", o.replicaConfig.Sink.DispatchRules), zap.Error(err))
+		return nil, cerror.Trace(err)
 	}
 	w.eventRouter = eventRouter
-	log.Info("event router created", zap.Any("protocol", o.protocol),
-		zap.Any("topic", o.topic), zap.Any("dispatcherRules", o.replicaConfig.Sink.DispatchRules))
+	log.Info("event router created", zap.Any("protocol", o.Protocol),
+		zap.Any("topic", o.Topic), zap.Any("dispatcherRules", o.ReplicaConfig.Sink.DispatchRules))
 
-	config.GetGlobalServerConfig().TZ = o.timezone
 	errChan := make(chan error, 1)
 	changefeed := model.DefaultChangeFeedID("kafka-consumer")
-	f, err := eventsinkfactory.New(ctx, changefeed, o.downstreamURI, o.replicaConfig, errChan, nil)
+	f, err := eventsinkfactory.New(ctx, changefeed, o.DownstreamURI, o.ReplicaConfig, errChan, nil)
 	if err != nil {
-		log.Panic("cannot create the event sink factory", zap.Error(err))
+		return nil, cerror.Trace(err)
 	}
 	w.sinkFactory = f
 
@@ -178,12 +215,18 @@ func newWriter(ctx context.Context, o *option) *writer {
 		}
 	}()
 
-	ddlSink, err := ddlsinkfactory.New(ctx, changefeed, o.downstreamURI, o.replicaConfig)
+	ddlSink, err := ddlsinkfactory.New(ctx, changefeed, o.DownstreamURI, o.ReplicaConfig)
 	if err != nil {
-		log.Panic("cannot create the ddl sink factory", zap.Error(err))
+		return nil, cerror.Trace(err)
 	}
 	w.ddlSink = ddlSink
-	return w
+	return w, nil
+}
+
+func (w *writer) close() {
+	if w.checkpoint != nil {
+		w.checkpoint.close()
+	}
 }
 
 // append DDL wait to be handled, only consider the constraint among DDLs.
@@ -237,20 +280,19 @@ func (w *writer) getMinWatermark() uint64 {
 }
 
 // partition progress could be executed at the same time
-func (w *writer) forEachPartition(fn func(p *partitionProgress)) {
-	var wg sync.WaitGroup
+func (w *writer) forEachPartition(ctx context.Context, fn func(p *partitionProgress) error) error {
+	g, _ := errgroup.WithContext(ctx)
 	for _, p := range w.progresses {
-		wg.Add(1)
-		go func(p *partitionProgress) {
-			defer wg.Done()
-			fn(p)
-		}(p)
+		p := p
+		g.Go(func() error {
+			return fn(p)
+		})
 	}
-	wg.Wait()
+	return g.Wait()
 }
 
 // Write will synchronously write data downstream
-func (w *writer) Write(ctx context.Context, messageType model.MessageType) bool {
+func (w *writer) Write(ctx context.Context, messageType model.MessageType) (bool, error) {
 	watermark := w.getMinWatermark()
 	var todoDDL *model.DDLEvent
 	for {
@@ -263,21 +305,39 @@ func (w *writer) Write(ctx context.Context, messageType model.MessageType) bool
 			break
 		}
 		// flush DMLs
-		w.forEachPartition(func(sink *partitionProgress) {
-			syncFlushRowChangedEvents(ctx, sink, todoDDL.CommitTs)
+		err := w.forEachPartition(ctx, func(sink *partitionProgress) error {
+			return syncFlushRowChangedEvents(ctx, sink, todoDDL.CommitTs)
 		})
+		if err != nil {
+			return false, cerror.Trace(err)
+		}
+		if todoDDL.CommitTs <= w.appliedDDLTs {
+			log.Info("DDL event is already applied before the consumer restarts, skip it",
+				zap.Uint64("commitTs", todoDDL.CommitTs), zap.Uint64("appliedDDLTs", w.appliedDDLTs),
+				zap.String("DDL", todoDDL.Query))
+			w.popDDL()
+			continue
+		}
 		// DDL can be executed, do it first.
-		if err := w.ddlSink.WriteDDLEvent(ctx, todoDDL); err != nil {
-			log.Panic("write DDL event failed", zap.Error(err),
-				zap.String("DDL", todoDDL.Query), zap.Uint64("commitTs", todoDDL.CommitTs))
+		if err = w.writeDDLEvent(ctx, todoDDL); err != nil {
+			return false, cerror.Trace(err)
 		}
 		w.popDDL()
 	}
 
 	if messageType == model.MessageTypeResolved {
-		w.forEachPartition(func(sink *partitionProgress) {
-			syncFlushRowChangedEvents(ctx, sink, watermark)
+		err := w.forEachPartition(ctx, func(sink *partitionProgress) error {
+			if err := syncFlushRowChangedEvents(ctx, sink, watermark); err != nil {
+				return err
+			}
+			if w.checkpoint == nil || watermark <= sink.appliedWatermark {
+				return nil
+			}
+			return w.checkpoint.save(ctx, sink.partition, watermark)
 		})
+		if err != nil {
+			return false, cerror.Trace(err)
+		}
 	}
 
 	// The DDL events will only execute in partition0
@@ -286,13 +346,37 @@ func (w *writer) Write(ctx context.Context, messageType model.MessageType) bool
 			zap.Uint64("watermark", watermark),
 			zap.Uint64("CommitTs", todoDDL.CommitTs),
 			zap.String("Query", todoDDL.Query))
-		return false
+		return false, nil
 	}
-	return true
+	return true, nil
 }
 
-// WriteMessage is to decode kafka message to event.
-func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool {
+// writeDDLEvent executes the DDL and records it in the checkpoint. The DDL is
+// recorded as pending before it's executed, since it can't be executed and recorded
+// atomically. After the consumer restarts, a pending DDL may be executed already,
+// so it's treated as applied if the downstream rejects it.
+func (w *writer) writeDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	if w.checkpoint == nil {
+		return cerror.Trace(w.ddlSink.WriteDDLEvent(ctx, ddl))
+	}
+	if err := w.checkpoint.save(ctx, pendingDDLPartition, ddl.CommitTs); err != nil {
+		return cerror.Trace(err)
+	}
+	if err := w.ddlSink.WriteDDLEvent(ctx, ddl); err != nil {
+		var mysqlErr *dmysql.MySQLError
+		if ddl.CommitTs > w.pendingDDLTs || !errors.As(cerror.Cause(err), &mysqlErr) {
+			return cerror.Trace(err)
+		}
+		log.Warn("DDL event may be applied before the consumer restarts, ignore the error",
+			zap.Uint64("commitTs", ddl.CommitTs), zap.Uint64("pendingDDLTs", w.pendingDDLTs),
+			zap.String("DDL", ddl.Query), zap.Error(err))
+	}
+	return cerror.Trace(w.checkpoint.save(ctx, ddlPartition, ddl.CommitTs))
+}
+
+// WriteMessage is to decode kafka message to event, it returns true if the message
+// and all messages before it are applied to the downstream and can be committed.
+func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) (bool, error) {
 	var (
 		key       = message.Key
 		value     = message.Value
@@ -300,10 +384,21 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 		offset    = message.TopicPartition.Offset
 	)
 
+	if w.rowFetcher != nil {
+		var err error
+		value, err = w.rowFetcher.completeCanalJSON(ctx, value)
+		if err != nil {
+			log.Error("fetch the full row of the message failed",
+				zap.Int32("partition", partition), zap.Any("offset", offset), zap.Error(err))
+			return false, cerror.Trace(err)
+		}
+	}
+
 	progress := w.progresses[partition]
 	if err := progress.decoder.AddKeyValue(key, value); err != nil {
-		log.Panic("add key value to the decoder failed",
+		log.Error("add key value to the decoder failed",
 			zap.Int32("partition", partition), zap.Any("offset", offset), zap.Error(err))
+		return false, cerror.Trace(err)
 	}
 	var (
 		counter     int
@@ -313,19 +408,22 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 	for {
 		ty, hasNext, err := progress.decoder.HasNext()
 		if err != nil {
-			log.Panic("decode message key failed",
+			log.Error("decode message key failed",
 				zap.Int32("partition", partition), zap.Any("offset", offset), zap.Error(err))
+			return false, cerror.Trace(err)
 		}
 		if !hasNext {
 			break
 		}
 		counter++
 		// If the message containing only one event exceeds the length limit, CDC will allow it and issue a warning.
-		if len(key)+len(value) > w.option.maxMessageBytes && counter > 1 {
-			log.Panic("kafka max-messages-bytes exceeded",
+		if len(key)+len(value) > w.option.MaxMessageBytes && counter > 1 {
+			log.Error("kafka max-messages-bytes exceeded",
 				zap.Int32("partition", partition), zap.Any("offset", offset),
-				zap.Int("max-message-bytes", w.option.maxMessageBytes),
+				zap.Int("max-message-bytes", w.option.MaxMessageBytes),
 				zap.Int("receivedBytes", len(key)+len(value)))
+			return false, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"kafka max-messages-bytes %d exceeded", w.option.MaxMessageBytes)
 		}
 		messageType = ty
 		switch messageType {
@@ -338,15 +436,18 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 			// but all DDL event messages should be consumed.
 			ddl, err := progress.decoder.NextDDLEvent()
 			if err != nil {
-				log.Panic("decode message value failed",
+				log.Error("decode message value failed",
 					zap.Int32("partition", partition), zap.Any("offset", offset),
 					zap.ByteString("value", value), zap.Error(err))
+				return false, cerror.Trace(err)
 			}
 
 			if dec, ok := progress.decoder.(*simple.Decoder); ok {
 				cachedEvents := dec.GetCachedEvents()
 				for _, row := range cachedEvents {
-					w.checkPartition(row, partition, message.TopicPartition.Offset)
+					if err = w.checkPartition(row, partition, message.TopicPartition.Offset); err != nil {
+						return false, err
+					}
 					log.Info("simple protocol cached event resolved, append to the group",
 						zap.Int64("tableID", row.GetTableID()), zap.Uint64("commitTs", row.CommitTs),
 						zap.Int32("partition", partition), zap.Any("offset", offset))
@@ -366,43 +467,52 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 		case model.MessageTypeRow:
 			row, err := progress.decoder.NextRowChangedEvent()
 			if err != nil {
-				log.Panic("decode message value failed",
+				log.Error("decode message value failed",
 					zap.Int32("partition", partition), zap.Any("offset", offset),
 					zap.ByteString("value", value),
 					zap.Error(err))
+				return false, cerror.Trace(err)
 			}
 			// when using simple protocol, the row may be nil, since it's table info not received yet,
 			// it's cached in the decoder, so just continue here.
-			if w.option.protocol == config.ProtocolSimple && row == nil {
+			if w.option.Protocol == config.ProtocolSimple && row == nil {
 				continue
 			}
-			w.checkPartition(row, partition, message.TopicPartition.Offset)
+			if err = w.checkPartition(row, partition, message.TopicPartition.Offset); err != nil {
+				return false, err
+			}
 			w.appendRow2Group(row, progress, offset)
 		case model.MessageTypeResolved:
 			newWatermark, err := progress.decoder.NextResolvedEvent()
 			if err != nil {
-				log.Panic("decode message value failed",
+				log.Error("decode message value failed",
 					zap.Int32("partition", partition), zap.Any("offset", offset),
 					zap.ByteString("value", value), zap.Error(err))
+				return false, cerror.Trace(err)
 			}
 
-			progress.updateWatermark(newWatermark, offset)
+			if err = progress.updateWatermark(newWatermark, offset); err != nil {
+				return false, err
+			}
 			w.resolveRowChangedEvents(progress, newWatermark)
 			needFlush = true
 		default:
-			log.Panic("unknown message type", zap.Any("messageType", messageType),
+			log.Error("unknown message type", zap.Any("messageType", messageType),
 				zap.Int32("partition", partition), zap.Any("offset", offset))
+			return false, cerror.ErrDecodeFailed.GenWithStack("unknown message type %d", messageType)
 		}
 	}
 
-	if counter > w.option.maxBatchSize {
-		log.Panic("Open Protocol max-batch-size exceeded",
-			zap.Int("maxBatchSize", w.option.maxBatchSize), zap.Int("actualBatchSize", counter),
+	if counter > w.option.MaxBatchSize {
+		log.Error("Open Protocol max-batch-size exceeded",
+			zap.Int("maxBatchSize", w.option.MaxBatchSize), zap.Int("actualBatchSize", counter),
 			zap.Int32("partition", partition), zap.Any("offset", offset))
+		return false, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"max-batch-size %d exceeded, actual %d", w.option.MaxBatchSize, counter)
 	}
 
 	if !needFlush {
-		return false
+		return false, nil
 	}
 	// flush when received DDL event or resolvedTs
 	return w.Write(ctx, messageType)
@@ -427,21 +537,26 @@ func (w *writer) resolveRowChangedEvents(progress *partitionProgress, newWaterma
 	}
 }
 
-func (w *writer) checkPartition(row *model.RowChangedEvent, partition int32, offset kafka.Offset) {
-	target, _, err := w.eventRouter.GetPartitionForRowChange(row, w.option.partitionNum)
+func (w *writer) checkPartition(row *model.RowChangedEvent, partition int32, offset kafka.Offset) error {
+	target, _, err := w.eventRouter.GetPartitionForRowChange(row, w.option.PartitionNum)
 	if err != nil {
-		log.Panic("cannot calculate partition for the row changed event",
+		log.Error("cannot calculate partition for the row changed event",
 			zap.Int32("partition", partition), zap.Any("offset", offset),
-			zap.Int32("partitionNum", w.option.partitionNum), zap.Int64("tableID", row.GetTableID()),
+			zap.Int32("partitionNum", w.option.PartitionNum), zap.Int64("tableID", row.GetTableID()),
 			zap.Error(err), zap.Any("event", row))
+		return cerror.Trace(err)
 	}
 	if partition != target {
-		log.Panic("RowChangedEvent dispatched to wrong partition",
+		log.Error("RowChangedEvent dispatched to wrong partition",
 			zap.Int32("partition", partition), zap.Int32("expected", target),
-			zap.Int32("partitionNum", w.option.partitionNum), zap.Any("offset", offset),
+			zap.Int32("partitionNum", w.option.PartitionNum), zap.Any("offset", offset),
 			zap.Int64("tableID", row.GetTableID()), zap.Any("row", row),
 		)
+		return cerror.ErrDecodeFailed.GenWithStack(
+			"row changed event of table %d dispatched to partition %d, expected %d",
+			row.GetTableID(), partition, target)
 	}
+	return nil
 }
 
 func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partitionProgress, offset kafka.Offset) {
@@ -451,9 +566,16 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 	partition := progress.partition
 
 	tableID := row.GetTableID()
+	if row.CommitTs <= progress.appliedWatermark {
+		log.Debug("RowChangedEvent is already applied before the consumer restarts, ignore it",
+			zap.Int64("tableID", tableID), zap.Int32("partition", partition),
+			zap.Uint64("commitTs", row.CommitTs), zap.Any("offset", offset),
+			zap.Uint64("appliedWatermark", progress.appliedWatermark))
+		return
+	}
 	group := progress.eventGroups[tableID]
 	if group == nil {
-		group = NewEventsGroup(partition, tableID)
+		group = newEventsGroup(partition, tableID)
 		progress.eventGroups[tableID] = group
 	}
 	if row.CommitTs < watermark {
@@ -463,14 +585,14 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 			zap.Uint64("watermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
 			zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
 			zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
-			zap.String("protocol", w.option.protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
+			zap.String("protocol", w.option.Protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
 		return
 	}
 	if row.CommitTs >= group.highWatermark {
 		group.Append(row, offset)
 		return
 	}
-	switch w.option.protocol {
+	switch w.option.Protocol {
 	case config.ProtocolSimple, config.ProtocolOpen, config.ProtocolCanalJSON:
 		// simple protocol set the table id for all row message, it can be known which table the row message belongs to,
 		// also consider the table partition.
@@ -484,7 +606,7 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 			zap.Any("partitionWatermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
 			zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
 			zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
-			zap.String("protocol", w.option.protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
+			zap.String("protocol", w.option.Protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
 		return
 	default:
 	}
@@ -495,30 +617,30 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 		zap.Any("partitionWatermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
 		zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
 		zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
-		zap.String("protocol", w.option.protocol.String()))
+		zap.String("protocol", w.option.Protocol.String()))
 	group.Append(row, offset)
 }
 
-func syncFlushRowChangedEvents(ctx context.Context, progress *partitionProgress, watermark uint64) {
+func syncFlushRowChangedEvents(ctx context.Context, progress *partitionProgress, watermark uint64) error {
 	resolvedTs := model.NewResolvedTs(watermark)
 	for {
 		select {
 		case <-ctx.Done():
 			log.Warn("sync flush row changed event canceled", zap.Error(ctx.Err()))
-			return
+			return cerror.Trace(ctx.Err())
 		default:
 		}
 		flushedResolvedTs := true
 		for _, tableSink := range progress.tableSinkMap {
 			if err := tableSink.UpdateResolvedTs(resolvedTs); err != nil {
-				log.Panic("Failed to update resolved ts", zap.Error(err))
+				return cerror.Trace(err)
 			}
 			if tableSink.GetCheckpointTs().Less(resolvedTs) {
 				flushedResolvedTs = false
 			}
 		}
 		if flushedResolvedTs {
-			return
+			return nil
 		}
 	}
 }
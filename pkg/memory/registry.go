@@ -0,0 +1,236 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// The components accounted by the global registry.
+const (
+	// ComponentMatcher is the prewrite caches of the txn matchers in the log puller.
+	ComponentMatcher = "logpuller-matcher"
+	// ComponentEventStoreWriteBuffer is the events waiting to be written into the event store.
+	ComponentEventStoreWriteBuffer = "eventstore-write-buffer"
+	// ComponentDispatcherQueue is the events pending in the queues of the dispatchers.
+	ComponentDispatcherQueue = "dispatcher-queue"
+)
+
+// Priority decides the order in which the components are throttled when the
+// memory usage grows beyond the soft limit.
+// The components with a lower priority are throttled first, so the memory they
+// hold is drained before the components with a higher priority are affected.
+type Priority int
+
+const (
+	// PriorityLow components are throttled once the soft limit is reached.
+	PriorityLow Priority = iota
+	// PriorityNormal components are throttled halfway between the soft and the hard limit.
+	PriorityNormal
+	// PriorityHigh components are only throttled once the hard limit is reached.
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+var globalRegistry = NewRegistry()
+
+// GetGlobalRegistry returns the registry shared by all the components of the server.
+func GetGlobalRegistry() *Registry {
+	return globalRegistry
+}
+
+// Registry accounts the memory held by the components of the server and
+// decides which of them should stop buffering more data when the total usage
+// exceeds the soft or the hard limit.
+// A limit of 0 means no limit.
+type Registry struct {
+	mu       sync.Mutex
+	accounts map[string]*Account
+
+	total     atomic.Int64
+	softLimit atomic.Int64
+	hardLimit atomic.Int64
+}
+
+// NewRegistry creates a registry without limits.
+func NewRegistry() *Registry {
+	return &Registry{
+		accounts: make(map[string]*Account),
+	}
+}
+
+// SetLimits sets the soft and the hard limit of the registry in bytes.
+func (r *Registry) SetLimits(soft, hard int64) {
+	r.softLimit.Store(soft)
+	r.hardLimit.Store(hard)
+	metrics.MemoryLimitGauge.WithLabelValues("soft").Set(float64(soft))
+	metrics.MemoryLimitGauge.WithLabelValues("hard").Set(float64(hard))
+	log.Info("memory limits updated", zap.Int64("softLimit", soft), zap.Int64("hardLimit", hard))
+}
+
+// Register returns the account of the component, the account is created with
+// the given priority if the component is not registered yet.
+func (r *Registry) Register(component string, priority Priority) *Account {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.accounts[component]; ok {
+		return a
+	}
+	a := &Account{
+		component: component,
+		priority:  priority,
+		registry:  r,
+		gauge:     metrics.MemoryUsageGauge.WithLabelValues(component),
+	}
+	r.accounts[component] = a
+	log.Info("memory account registered",
+		zap.String("component", component), zap.Stringer("priority", priority))
+	return a
+}
+
+// Accounts returns the registered accounts, ordered by priority from the
+// first to the last throttled.
+func (r *Registry) Accounts() []*Account {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]*Account, 0, len(r.accounts))
+	for _, a := range r.accounts {
+		res = append(res, a)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].priority != res[j].priority {
+			return res[i].priority < res[j].priority
+		}
+		return res[i].component < res[j].component
+	})
+	return res
+}
+
+// Limits returns the soft and the hard limit of the registry.
+func (r *Registry) Limits() (soft, hard int64) {
+	return r.softLimit.Load(), r.hardLimit.Load()
+}
+
+// Total returns the memory accounted by all the components.
+func (r *Registry) Total() int64 {
+	return r.total.Load()
+}
+
+// threshold returns the usage at which the components of the priority are throttled,
+// 0 means they are never throttled.
+func (r *Registry) threshold(priority Priority) int64 {
+	soft, hard := r.softLimit.Load(), r.hardLimit.Load()
+	if soft <= 0 || (hard > 0 && soft >= hard) {
+		return hard
+	}
+	switch priority {
+	case PriorityLow:
+		return soft
+	case PriorityNormal:
+		if hard <= 0 {
+			return soft
+		}
+		return soft + (hard-soft)/2
+	}
+	return hard
+}
+
+// Account accounts the memory held by a single component.
+// It is safe to be used concurrently.
+type Account struct {
+	component string
+	priority  Priority
+	registry  *Registry
+	gauge     prometheus.Gauge
+
+	used      atomic.Int64
+	throttled atomic.Bool
+}
+
+// Consume accounts n more bytes to the component.
+func (a *Account) Consume(n int64) {
+	if n == 0 {
+		return
+	}
+	a.used.Add(n)
+	a.registry.total.Add(n)
+	a.gauge.Add(float64(n))
+}
+
+// Release returns n bytes accounted to the component.
+func (a *Account) Release(n int64) {
+	a.Consume(-n)
+}
+
+// Used returns the memory accounted to the component.
+func (a *Account) Used() int64 {
+	return a.used.Load()
+}
+
+// Component returns the name of the component.
+func (a *Account) Component() string {
+	return a.component
+}
+
+// Priority returns the priority of the component.
+func (a *Account) Priority() Priority {
+	return a.priority
+}
+
+// Throttled returns the result of the last Exceeded call.
+func (a *Account) Throttled() bool {
+	return a.throttled.Load()
+}
+
+// Exceeded returns true if the component should stop buffering more data,
+// that is the total usage reaches the limit of the priority of the component.
+func (a *Account) Exceeded() bool {
+	threshold := a.registry.threshold(a.priority)
+	exceeded := threshold > 0 && a.registry.Total() >= threshold
+	if a.throttled.CompareAndSwap(!exceeded, exceeded) {
+		if exceeded {
+			metrics.MemoryThrottledGauge.WithLabelValues(a.component).Set(1)
+			log.Warn("memory limit exceeded, throttle the component",
+				zap.String("component", a.component),
+				zap.Stringer("priority", a.priority),
+				zap.Int64("used", a.Used()),
+				zap.Int64("total", a.registry.Total()),
+				zap.Int64("threshold", threshold))
+		} else {
+			metrics.MemoryThrottledGauge.WithLabelValues(a.component).Set(0)
+			log.Info("memory usage is below the limit, resume the component",
+				zap.String("component", a.component),
+				zap.Int64("total", a.registry.Total()))
+		}
+	}
+	return exceeded
+}
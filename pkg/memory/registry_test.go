@@ -0,0 +1,73 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryThrottleByPriority(t *testing.T) {
+	r := NewRegistry()
+	low := r.Register("low", PriorityLow)
+	normal := r.Register("normal", PriorityNormal)
+	high := r.Register("high", PriorityHigh)
+	require.Same(t, low, r.Register("low", PriorityHigh))
+
+	// no limits, never throttled
+	high.Consume(1000)
+	require.False(t, low.Exceeded())
+	require.False(t, high.Exceeded())
+
+	r.SetLimits(100, 200)
+	high.Release(1000)
+	low.Consume(60)
+	normal.Consume(40)
+	require.Equal(t, int64(100), r.Total())
+	require.True(t, low.Exceeded())
+	require.False(t, normal.Exceeded())
+	require.False(t, high.Exceeded())
+
+	high.Consume(50)
+	require.True(t, normal.Exceeded())
+	require.False(t, high.Exceeded())
+
+	high.Consume(50)
+	require.True(t, high.Exceeded())
+	require.True(t, high.Throttled())
+
+	low.Release(60)
+	require.Equal(t, int64(140), r.Total())
+	require.True(t, low.Exceeded())
+	require.False(t, normal.Exceeded())
+	require.False(t, high.Exceeded())
+
+	accounts := r.Accounts()
+	require.Len(t, accounts, 3)
+	require.Equal(t, "low", accounts[0].Component())
+	require.Equal(t, "normal", accounts[1].Component())
+	require.Equal(t, "high", accounts[2].Component())
+	require.Equal(t, int64(100), accounts[2].Used())
+}
+
+func TestRegistryHardLimitOnly(t *testing.T) {
+	r := NewRegistry()
+	low := r.Register("low", PriorityLow)
+	r.SetLimits(0, 100)
+	low.Consume(99)
+	require.False(t, low.Exceeded())
+	low.Consume(1)
+	require.True(t, low.Exceeded())
+}
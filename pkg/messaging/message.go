@@ -26,6 +26,8 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -82,6 +84,9 @@ const (
 	TypeMaintainerCloseResponse
 
 	TypeMessageHandShake
+
+	TypeQuiesceRequest
+	TypeResendTableSchemaRequest
 )
 
 func (t IOType) String() string {
@@ -144,6 +149,10 @@ func (t IOType) String() string {
 		return "MessageHandShake"
 	case TypeCheckpointTsMessage:
 		return "CheckpointTsMessage"
+	case TypeQuiesceRequest:
+		return "QuiesceRequest"
+	case TypeResendTableSchemaRequest:
+		return "ResendTableSchemaRequest"
 	default:
 	}
 	return "Unknown"
@@ -166,7 +175,7 @@ func (r RegisterDispatcherRequest) GetID() common.DispatcherID {
 }
 
 func (r RegisterDispatcherRequest) GetClusterID() uint64 {
-	return 0
+	return r.ClusterId
 }
 
 func (r RegisterDispatcherRequest) GetTopic() string {
@@ -191,13 +200,17 @@ func (r RegisterDispatcherRequest) GetChangefeedID() common.ChangeFeedID {
 
 func (r RegisterDispatcherRequest) GetFilter() filter.Filter {
 	changefeedID := r.GetChangefeedID()
-	filter, err := filter.GetSharedFilterStorage().GetOrSetFilter(changefeedID, r.RegisterDispatcherRequest.FilterConfig, "", false)
+	filter, err := filter.GetSharedFilterStorage().GetOrSetFilter(changefeedID, r.RegisterDispatcherRequest.FilterConfig)
 	if err != nil {
 		log.Panic("create filter failed", zap.Error(err), zap.Any("filterConfig", r.RegisterDispatcherRequest.FilterConfig))
 	}
 	return filter
 }
 
+func (r RegisterDispatcherRequest) IsOldValueDisabled() bool {
+	return r.RegisterDispatcherRequest.GetFilterConfig().GetDisableOldValue()
+}
+
 func (r RegisterDispatcherRequest) SyncPointEnabled() bool {
 	return r.EnableSyncPoint
 }
@@ -278,6 +291,10 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &heartbeatpb.MaintainerBootstrapRequest{}
 	case TypeCheckpointTsMessage:
 		m = &heartbeatpb.CheckpointTsMessage{}
+	case TypeQuiesceRequest:
+		m = &heartbeatpb.QuiesceRequest{}
+	case TypeResendTableSchemaRequest:
+		m = &heartbeatpb.ResendTableSchemaRequest{}
 	default:
 		log.Panic("Unimplemented IOType", zap.Stringer("Type", ioType))
 	}
@@ -300,6 +317,10 @@ type TargetMessage struct {
 	// Group is used to group messages into a same group.
 	// Different groups can be processed in different goroutines.
 	Group uint64
+
+	// TraceContext carries the trace context of the sender, it is used to
+	// link the spans across nodes. It's empty when tracing is disabled.
+	TraceContext map[string]string
 }
 
 // NewSingleTargetMessage creates a new TargetMessage to be sent to a target server, with a single message.
@@ -365,6 +386,10 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT, Group ...
 		ioType = TypeMaintainerCloseResponse
 	case *heartbeatpb.CheckpointTsMessage:
 		ioType = TypeCheckpointTsMessage
+	case *heartbeatpb.QuiesceRequest:
+		ioType = TypeQuiesceRequest
+	case *heartbeatpb.ResendTableSchemaRequest:
+		ioType = TypeResendTableSchemaRequest
 	default:
 		panic("unknown io type")
 	}
@@ -391,3 +416,18 @@ func (m *TargetMessage) String() string {
 func (m *TargetMessage) GetGroup() uint64 {
 	return m.Group
 }
+
+// traceMessage records a span named name for the message if it carries a trace context,
+// and replaces the trace context with the new span, so the next hop is linked to it.
+func traceMessage(m *TargetMessage, name string) {
+	if len(m.TraceContext) == 0 {
+		return
+	}
+	ctx, span := tracing.StartFromRemote(m.TraceContext, name,
+		attribute.String("topic", m.Topic),
+		attribute.String("type", m.Type.String()),
+		attribute.String("from", m.From.String()),
+		attribute.String("to", m.To.String()))
+	m.TraceContext = tracing.Inject(ctx)
+	span.End()
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/faultinject"
 	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -68,6 +69,7 @@ type grpcReceiver interface {
 
 type grpcSender interface {
 	Send(*proto.Message) error
+	Context() context.Context
 }
 
 // messageCenter is the core of the messaging system.
@@ -220,6 +222,14 @@ func (mc *messageCenter) SendEvent(msg *TargetMessage) error {
 	if msg == nil {
 		return nil
 	}
+	if mc.injectFault(msg, mc.sendEvent) {
+		return nil
+	}
+	return mc.sendEvent(msg)
+}
+
+func (mc *messageCenter) sendEvent(msg *TargetMessage) error {
+	traceMessage(msg, "messaging.Send")
 
 	if msg.To == mc.id {
 		return mc.localTarget.sendEvent(msg)
@@ -245,6 +255,14 @@ func (mc *messageCenter) SendCommand(msg *TargetMessage) error {
 	if msg == nil {
 		return nil
 	}
+	if mc.injectFault(msg, mc.sendCommand) {
+		return nil
+	}
+	return mc.sendCommand(msg)
+}
+
+func (mc *messageCenter) sendCommand(msg *TargetMessage) error {
+	traceMessage(msg, "messaging.Send")
 
 	if msg.To == mc.id {
 		return mc.localTarget.sendCommand(msg)
@@ -259,6 +277,27 @@ func (mc *messageCenter) SendCommand(msg *TargetMessage) error {
 	return target.sendCommand(msg)
 }
 
+// injectFault applies the injected message faults to the message, it returns true if
+// the message is dropped or delayed, and the delayed message is sent by send later.
+func (mc *messageCenter) injectFault(msg *TargetMessage, send func(*TargetMessage) error) bool {
+	action, delay := faultinject.GetInjector().MatchMessage(msg.To, msg.Topic)
+	switch action {
+	case faultinject.ActionDrop:
+		log.Debug("message is dropped by the injected fault",
+			zap.Stringer("to", msg.To), zap.String("topic", msg.Topic), zap.Stringer("type", msg.Type))
+		return true
+	case faultinject.ActionDelay:
+		time.AfterFunc(delay, func() {
+			if err := send(msg); err != nil {
+				log.Warn("send the message delayed by the injected fault failed",
+					zap.Stringer("to", msg.To), zap.String("topic", msg.Topic), zap.Error(err))
+			}
+		})
+		return true
+	}
+	return false
+}
+
 func (mc *messageCenter) ReceiveEvent() (*TargetMessage, error) {
 	return <-mc.receiveEventCh, nil
 }
@@ -60,6 +60,7 @@ func (r *router) runDispatch(ctx context.Context, out <-chan *TargetMessage) {
 				log.Debug("no handler for message, drop it", zap.Any("msg", msg))
 				continue
 			}
+			traceMessage(msg, "messaging.Receive")
 			err := handler(ctx, msg)
 			if err != nil {
 				log.Error("Handle message failed", zap.Error(err), zap.Any("msg", msg))
@@ -42,6 +42,10 @@ const (
 	reconnectInterval = 2 * time.Second
 	msgTypeEvent      = "event"
 	msgTypeCommand    = "command"
+
+	// maxSendStreams is the max number of send streams of each kind for a remote target,
+	// which is one primary stream and one standby stream.
+	maxSendStreams = 2
 )
 
 // remoteMessageTarget implements the SendMessageChannel interface.
@@ -57,19 +61,21 @@ type remoteMessageTarget struct {
 	targetAddr  string
 	security    *security.Credential
 
-	// senderMu is used to protect the eventSender and commandSender.
-	// It is used to ensure that there is only one eventStream and commandStream for the target.
-	senderMu      sync.Mutex
+	// eventSender and commandSender hold the streams opened by the remote target,
+	// at most one primary stream and one standby stream for each of them.
 	eventSender   *sendStreamWrapper
 	commandSender *sendStreamWrapper
 
-	// For receiving events and commands
+	// For receiving events and commands.
+	// Each link is a separate grpc connection, the first one is the primary link,
+	// and the second one is the standby link if it is enabled.
 	conn struct {
 		sync.RWMutex
-		c *grpc.ClientConn
+		links []*grpc.ClientConn
+		// generations are increased every time the link is reconnected, they are used
+		// to ignore the stale errors reported by the streams of the previous connection.
+		generations []uint64
 	}
-	eventRecvStream   grpcReceiver
-	commandRecvStream grpcReceiver
 
 	// We push the events and commands to remote send streams.
 	// The send streams are created when the target is added to the message center.
@@ -88,7 +94,7 @@ type remoteMessageTarget struct {
 	// cancel is used to stop the grpc stream, and the goroutine spawned by remoteMessageTarget.
 	cancel context.CancelFunc
 	// errCh is used to gather the error from the goroutine spawned by remoteMessageTarget.
-	errCh chan AppError
+	errCh chan linkError
 
 	sendEventCounter           prometheus.Counter
 	dropEventCounter           prometheus.Counter
@@ -103,6 +109,14 @@ type remoteMessageTarget struct {
 	receivedFailedErrorCounter     prometheus.Counter
 	connectionNotfoundErrorCounter prometheus.Counter
 	connectionFailedErrorCounter   prometheus.Counter
+	streamFailoverCounter          prometheus.Counter
+}
+
+// linkError is the error reported by a link of the remote target.
+type linkError struct {
+	AppError
+	link       int
+	generation uint64
 }
 
 func (s *remoteMessageTarget) isReadyToSend() bool {
@@ -166,15 +180,15 @@ func newRemoteMessageTarget(
 		targetAddr:         addr,
 		targetId:           targetId,
 		security:           security,
-		eventSender:        &sendStreamWrapper{ready: atomic.Bool{}},
-		commandSender:      &sendStreamWrapper{ready: atomic.Bool{}},
+		eventSender:        newSendStreamWrapper(),
+		commandSender:      newSendStreamWrapper(),
 		ctx:                ctx,
 		cancel:             cancel,
 		sendEventCh:        make(chan *proto.Message, cfg.CacheChannelSize),
 		sendCmdCh:          make(chan *proto.Message, cfg.CacheChannelSize),
 		recvEventCh:        recvEventCh,
 		recvCmdCh:          recvCmdCh,
-		errCh:              make(chan AppError, 8),
+		errCh:              make(chan linkError, 8),
 		wg:                 &sync.WaitGroup{},
 
 		sendEventCounter:           metrics.MessagingSendMsgCounter.WithLabelValues(string(addr), "event"),
@@ -190,7 +204,14 @@ func newRemoteMessageTarget(
 		receivedFailedErrorCounter:     metrics.MessagingErrorCounter.WithLabelValues(string(addr), "message", "message_received_failed"),
 		connectionNotfoundErrorCounter: metrics.MessagingErrorCounter.WithLabelValues(string(addr), "message", "connection_not_found"),
 		connectionFailedErrorCounter:   metrics.MessagingErrorCounter.WithLabelValues(string(addr), "message", "connection_failed"),
+		streamFailoverCounter:          metrics.MessagingErrorCounter.WithLabelValues(string(addr), "message", "stream_failover"),
+	}
+	linkCount := 1
+	if cfg.StandbyConnection {
+		linkCount = 2
 	}
+	rt.conn.links = make([]*grpc.ClientConn, linkCount)
+	rt.conn.generations = make([]uint64, linkCount)
 	rt.targetEpoch.Store(targetEpoch)
 	rt.runHandleErr(ctx)
 	return rt
@@ -199,7 +220,9 @@ func newRemoteMessageTarget(
 // close stops the grpc stream and the goroutine spawned by remoteMessageTarget.
 func (s *remoteMessageTarget) close() {
 	log.Info("Closing remote target", zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Any("addr", s.targetAddr))
-	s.closeConn()
+	for link := range s.conn.links {
+		s.closeConn(link)
+	}
 	s.cancel()
 	s.wg.Wait()
 	log.Info("Close remote target done", zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId))
@@ -220,10 +243,15 @@ func (s *remoteMessageTarget) runHandleErr(ctx context.Context) {
 			case err := <-s.errCh:
 				switch err.Type {
 				case ErrorTypeMessageReceiveFailed, ErrorTypeConnectionFailed:
+					if !s.isCurrentGeneration(err.link, err.generation) {
+						// The link has been reconnected after the error is reported.
+						continue
+					}
 					log.Warn("received message from remote failed, will be reconnect",
-						zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
+						zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId),
+						zap.Int("link", err.link), zap.Error(err))
 					time.Sleep(reconnectInterval)
-					s.resetConnect()
+					s.resetConnect(err.link)
 				default:
 					log.Error("Error in remoteMessageTarget, error:", zap.Error(err))
 				}
@@ -233,7 +261,7 @@ func (s *remoteMessageTarget) runHandleErr(ctx context.Context) {
 	}()
 }
 
-func (s *remoteMessageTarget) collectErr(err AppError) {
+func (s *remoteMessageTarget) collectErr(link int, generation uint64, err AppError) {
 	switch err.Type {
 	case ErrorTypeMessageReceiveFailed:
 		s.receivedFailedErrorCounter.Inc()
@@ -241,21 +269,29 @@ func (s *remoteMessageTarget) collectErr(err AppError) {
 		s.connectionFailedErrorCounter.Inc()
 	}
 	select {
-	case s.errCh <- err:
+	case s.errCh <- linkError{AppError: err, link: link, generation: generation}:
 	default:
 	}
 }
 
+// connect connects all the links to the remote target.
 func (s *remoteMessageTarget) connect() {
-	if _, ok := s.getConn(); ok {
+	for link := range s.conn.links {
+		s.connectLink(link)
+	}
+}
+
+func (s *remoteMessageTarget) connectLink(link int) {
+	if _, ok := s.getConn(link); ok {
 		return
 	}
+	generation := s.nextGeneration(link)
 
 	conn, err := conn.Connect(string(s.targetAddr), s.security)
 	if err != nil {
 		log.Info("Cannot create grpc client",
-			zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
-		s.collectErr(AppError{
+			zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Int("link", link), zap.Error(err))
+		s.collectErr(link, generation, AppError{
 			Type:   ErrorTypeConnectionFailed,
 			Reason: fmt.Sprintf("Cannot create grpc client on address %s, error: %s", s.targetAddr, err.Error()),
 		})
@@ -273,8 +309,9 @@ func (s *remoteMessageTarget) connect() {
 	eventStream, err := client.SendEvents(s.ctx, handshake)
 	if err != nil {
 		log.Info("Cannot establish event grpc stream",
-			zap.Any("messageCenterID", s.messageCenterID), zap.Stringer("remote", s.targetId), zap.Error(err))
-		s.collectErr(AppError{
+			zap.Any("messageCenterID", s.messageCenterID), zap.Stringer("remote", s.targetId), zap.Int("link", link), zap.Error(err))
+		conn.Close()
+		s.collectErr(link, generation, AppError{
 			Type:   ErrorTypeConnectionFailed,
 			Reason: fmt.Sprintf("Cannot open event grpc stream, error: %s", err.Error()),
 		})
@@ -284,99 +321,109 @@ func (s *remoteMessageTarget) connect() {
 	commandStream, err := client.SendCommands(s.ctx, handshake)
 	if err != nil {
 		log.Info("Cannot establish command grpc stream",
-			zap.Any("messageCenterID", s.messageCenterID), zap.Stringer("remote", s.targetId), zap.Error(err))
-		s.collectErr(AppError{
+			zap.Any("messageCenterID", s.messageCenterID), zap.Stringer("remote", s.targetId), zap.Int("link", link), zap.Error(err))
+		conn.Close()
+		s.collectErr(link, generation, AppError{
 			Type:   ErrorTypeConnectionFailed,
 			Reason: fmt.Sprintf("Cannot open event grpc stream, error: %s", err.Error()),
 		})
 		return
 	}
 
-	s.setConn(conn)
-	s.eventRecvStream = eventStream
-	s.commandRecvStream = commandStream
-	s.runReceiveMessages(eventStream, s.recvEventCh)
-	s.runReceiveMessages(commandStream, s.recvCmdCh)
+	s.setConn(link, conn)
+	s.runReceiveMessages(link, generation, eventStream, s.recvEventCh)
+	s.runReceiveMessages(link, generation, commandStream, s.recvCmdCh)
 	log.Info("Connected to remote target",
 		zap.Any("messageCenterID", s.messageCenterID),
 		zap.Any("remote", s.targetId),
-		zap.Any("remoteAddr", s.targetAddr))
+		zap.Any("remoteAddr", s.targetAddr),
+		zap.Int("link", link))
 }
 
-func (s *remoteMessageTarget) resetConnect() {
+func (s *remoteMessageTarget) resetConnect(link int) {
 	log.Info("reconnect to remote target",
 		zap.Any("messageCenterID", s.messageCenterID),
-		zap.Any("remote", s.targetId))
+		zap.Any("remote", s.targetId),
+		zap.Int("link", link))
 	// Close the old streams
-	s.closeConn()
-	s.eventRecvStream = nil
-	s.commandRecvStream = nil
-	// Clear the error channel
-LOOP:
-	for {
-		select {
-		case <-s.errCh:
-		default:
-			break LOOP
-		}
-	}
+	s.closeConn(link)
 	// Reconnect
-	s.connect()
+	s.connectLink(link)
 }
 
 func (s *remoteMessageTarget) runEventSendStream(eventStream grpcSender) error {
-	s.senderMu.Lock()
-	if s.eventSender.stream != nil {
-		s.senderMu.Unlock()
-		return nil
-	}
-	s.eventSender.stream = eventStream
-	s.eventSender.ready.Store(true)
-	s.senderMu.Unlock()
-
-	err := s.runSendMessages(s.ctx, s.eventSender.stream, s.sendEventCh)
-	log.Info("Event send stream closed",
-		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
-	s.eventSender.ready.Store(false)
-	return err
+	return s.runSendStream(s.eventSender, eventStream, s.sendEventCh, msgTypeEvent)
 }
 
 func (s *remoteMessageTarget) runCommandSendStream(commandStream grpcSender) error {
-	s.senderMu.Lock()
-	if s.commandSender.stream != nil {
-		s.senderMu.Unlock()
+	return s.runSendStream(s.commandSender, commandStream, s.sendCmdCh, msgTypeCommand)
+}
+
+// runSendStream sends the messages through the stream until it is broken.
+// If the stream is the primary one, a standby stream takes over when it is broken,
+// and resends the message failed to be sent.
+func (s *remoteMessageTarget) runSendStream(
+	sender *sendStreamWrapper, stream grpcSender, sendChan chan *proto.Message, msgType string,
+) error {
+	if !sender.add(stream) {
+		log.Info("Too many send streams, reject the new one",
+			zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.String("type", msgType))
 		return nil
 	}
-	s.commandSender.stream = commandStream
-	s.commandSender.ready.Store(true)
-	s.senderMu.Unlock()
-
-	err := s.runSendMessages(s.ctx, s.commandSender.stream, s.sendCmdCh)
-	log.Info("Command send stream closed",
-		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
-	s.commandSender.ready.Store(false)
+
+	failed, err := s.runSendMessages(s.ctx, sender, stream, sendChan)
+	if sender.remove(stream, failed) {
+		s.streamFailoverCounter.Inc()
+		log.Warn("Send stream is broken, fail over to the standby stream",
+			zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.String("type", msgType))
+	}
+	log.Info("Send stream closed",
+		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId),
+		zap.String("type", msgType), zap.Error(err))
 	return err
 }
 
-func (s *remoteMessageTarget) runSendMessages(sendCtx context.Context, stream grpcSender, sendChan chan *proto.Message) error {
+// runSendMessages returns the message failed to be sent if the stream is broken.
+func (s *remoteMessageTarget) runSendMessages(
+	sendCtx context.Context, sender *sendStreamWrapper, stream grpcSender, sendChan chan *proto.Message,
+) (*proto.Message, error) {
 	for {
-		select {
-		case <-sendCtx.Done():
-			return sendCtx.Err()
-		case message := <-sendChan:
-			if err := stream.Send(message); err != nil {
-				log.Error("Error when sending message to remote",
-					zap.Error(err),
-					zap.Any("messageCenterID", s.messageCenterID),
-					zap.Any("remote", s.targetId))
-				err = AppError{Type: ErrorTypeMessageSendFailed, Reason: err.Error()}
-				return err
+		isPrimary, changed := sender.isPrimary(stream)
+		if !isPrimary {
+			// The standby stream is idle until the primary stream is broken.
+			select {
+			case <-sendCtx.Done():
+				return nil, sendCtx.Err()
+			case <-stream.Context().Done():
+				return nil, stream.Context().Err()
+			case <-changed:
+			}
+			continue
+		}
+
+		message := sender.takePending()
+		if message == nil {
+			select {
+			case <-sendCtx.Done():
+				return nil, sendCtx.Err()
+			case <-stream.Context().Done():
+				return nil, stream.Context().Err()
+			case message = <-sendChan:
 			}
 		}
+		if err := stream.Send(message); err != nil {
+			log.Error("Error when sending message to remote",
+				zap.Error(err),
+				zap.Any("messageCenterID", s.messageCenterID),
+				zap.Any("remote", s.targetId))
+			return message, AppError{Type: ErrorTypeMessageSendFailed, Reason: err.Error()}
+		}
 	}
 }
 
-func (s *remoteMessageTarget) runReceiveMessages(stream grpcReceiver, receiveCh chan *TargetMessage) {
+func (s *remoteMessageTarget) runReceiveMessages(
+	link int, generation uint64, stream grpcReceiver, receiveCh chan *TargetMessage,
+) {
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
@@ -390,7 +437,7 @@ func (s *remoteMessageTarget) runReceiveMessages(stream grpcReceiver, receiveCh
 			if err != nil {
 				err := AppError{Type: ErrorTypeMessageReceiveFailed, Reason: errors.Trace(err).Error()}
 				// return the error to close the stream, the client side is responsible to reconnect.
-				s.collectErr(err)
+				s.collectErr(link, generation, err)
 				return
 			}
 			mt := IOType(message.Type)
@@ -405,6 +452,8 @@ func (s *remoteMessageTarget) runReceiveMessages(stream grpcReceiver, receiveCh
 				Epoch:    message.Epoch,
 				Sequence: message.Seqnum,
 				Type:     mt,
+
+				TraceContext: message.TraceContext,
 			}
 			for _, payload := range message.Payload {
 				msg, err := decodeIOType(mt, payload)
@@ -441,29 +490,44 @@ func (s *remoteMessageTarget) newMessage(msg ...*TargetMessage) *proto.Message {
 		Topic:   string(msg[0].Topic),
 		Type:    int32(msg[0].Type),
 		Payload: msgBytes,
+
+		TraceContext: msg[0].TraceContext,
 	}
 	return protoMsg
 }
 
-func (s *remoteMessageTarget) getConn() (*grpc.ClientConn, bool) {
+func (s *remoteMessageTarget) getConn(link int) (*grpc.ClientConn, bool) {
 	s.conn.RLock()
 	defer s.conn.RUnlock()
-	return s.conn.c, s.conn.c != nil
+	return s.conn.links[link], s.conn.links[link] != nil
 }
 
-func (s *remoteMessageTarget) setConn(conn *grpc.ClientConn) {
+func (s *remoteMessageTarget) setConn(link int, conn *grpc.ClientConn) {
 	s.conn.Lock()
 	defer s.conn.Unlock()
-	s.conn.c = conn
+	s.conn.links[link] = conn
 }
 
-func (s *remoteMessageTarget) closeConn() {
-	if conn, ok := s.getConn(); ok {
+func (s *remoteMessageTarget) closeConn(link int) {
+	if conn, ok := s.getConn(link); ok {
 		conn.Close()
-		s.setConn(nil)
+		s.setConn(link, nil)
 	}
 }
 
+func (s *remoteMessageTarget) nextGeneration(link int) uint64 {
+	s.conn.Lock()
+	defer s.conn.Unlock()
+	s.conn.generations[link]++
+	return s.conn.generations[link]
+}
+
+func (s *remoteMessageTarget) isCurrentGeneration(link int, generation uint64) bool {
+	s.conn.RLock()
+	defer s.conn.RUnlock()
+	return s.conn.generations[link] == generation
+}
+
 // localMessageTarget implements the SendMessageChannel interface.
 // It is used to send messages to the local server.
 // It simply pushes the messages to the messageCenter's channel directly.
@@ -541,7 +605,70 @@ func (s *localMessageTarget) sendMsgToChan(ch chan *TargetMessage, msg ...*Targe
 	return nil
 }
 
+// sendStreamWrapper holds the send streams of the same kind opened by a remote target.
+// Messages are only sent through the primary stream to keep them in order,
+// and a standby stream takes over when the primary stream is broken.
 type sendStreamWrapper struct {
-	stream grpcSender
-	ready  atomic.Bool
+	mu sync.Mutex
+	// streams[0] is the primary stream, and the others are the standby streams.
+	streams []grpcSender
+	// pending is the message failed to be sent by the broken primary stream,
+	// it is resent by the stream that takes over.
+	pending *proto.Message
+	// changed is closed when the primary stream is changed.
+	changed chan struct{}
+	ready   atomic.Bool
+}
+
+func newSendStreamWrapper() *sendStreamWrapper {
+	return &sendStreamWrapper{changed: make(chan struct{})}
+}
+
+func (w *sendStreamWrapper) add(stream grpcSender) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.streams) >= maxSendStreams {
+		return false
+	}
+	w.streams = append(w.streams, stream)
+	w.ready.Store(true)
+	return true
+}
+
+// remove removes the stream, and keeps the failed message to be resent.
+// It returns true if a standby stream takes over the removed primary stream.
+func (w *sendStreamWrapper) remove(stream grpcSender, failed *proto.Message) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, s := range w.streams {
+		if s != stream {
+			continue
+		}
+		w.streams = append(w.streams[:i], w.streams[i+1:]...)
+		if i != 0 {
+			return false
+		}
+		if failed != nil {
+			w.pending = failed
+		}
+		w.ready.Store(len(w.streams) > 0)
+		close(w.changed)
+		w.changed = make(chan struct{})
+		return len(w.streams) > 0
+	}
+	return false
+}
+
+func (w *sendStreamWrapper) isPrimary(stream grpcSender) (bool, <-chan struct{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.streams) > 0 && w.streams[0] == stream, w.changed
+}
+
+func (w *sendStreamWrapper) takePending() *proto.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	message := w.pending
+	w.pending = nil
+	return message
 }
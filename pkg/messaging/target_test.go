@@ -15,10 +15,14 @@ package messaging
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -51,3 +55,78 @@ func TestRemoteTargetNewMessage(t *testing.T) {
 	require.Equal(t, TypeMessageHandShake, IOType(msg2.Type))
 	require.Equal(t, rt.messageCenterEpoch, uint64(msg2.Epoch))
 }
+
+type mockSendStream struct {
+	ctx  context.Context
+	fail bool
+
+	mu   sync.Mutex
+	sent []*proto.Message
+}
+
+func (m *mockSendStream) Send(msg *proto.Message) error {
+	if m.fail {
+		return errors.New("connection reset")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func (m *mockSendStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockSendStream) sentMessages() []*proto.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*proto.Message(nil), m.sent...)
+}
+
+func TestRemoteTargetSendStreamFailover(t *testing.T) {
+	rt := newRemoteMessageTargetForTest()
+	defer rt.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	primary := &mockSendStream{ctx: ctx, fail: true}
+	standby := &mockSendStream{ctx: ctx}
+
+	primaryDone := make(chan error, 1)
+	go func() {
+		primaryDone <- rt.runEventSendStream(primary)
+	}()
+	require.Eventually(t, rt.eventSender.ready.Load, time.Second, 10*time.Millisecond)
+	go func() {
+		_ = rt.runEventSendStream(standby)
+	}()
+	require.Eventually(t, func() bool {
+		rt.eventSender.mu.Lock()
+		defer rt.eventSender.mu.Unlock()
+		return len(rt.eventSender.streams) == 2
+	}, time.Second, 10*time.Millisecond)
+	// The third stream is rejected.
+	require.NoError(t, rt.runEventSendStream(&mockSendStream{ctx: ctx}))
+
+	msg := &TargetMessage{Type: TypeMessageHandShake, Topic: "test"}
+	require.NoError(t, rt.sendEvent(msg))
+	require.Error(t, <-primaryDone)
+
+	// The message failed to be sent by the primary stream is resent by the standby stream.
+	require.Eventually(t, func() bool {
+		return len(standby.sentMessages()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, rt.eventSender.ready.Load())
+
+	require.NoError(t, rt.sendEvent(msg))
+	require.Eventually(t, func() bool {
+		return len(standby.sentMessages()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The sender is not ready after all the streams are closed.
+	cancel()
+	require.Eventually(t, func() bool {
+		return !rt.eventSender.ready.Load()
+	}, time.Second, 10*time.Millisecond)
+}
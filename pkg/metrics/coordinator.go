@@ -48,6 +48,24 @@ var (
 			Name:      "changefeed_state",
 			Help:      "The total number of changefeed in different replication states",
 		}, []string{"state"})
+
+	// CoordinatorGCSafepointGauge is the physical time of the service gc safepoint set by TiCDC.
+	CoordinatorGCSafepointGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "coordinator",
+			Name:      "gc_safepoint",
+			Help:      "The physical time of the service gc safepoint set by TiCDC",
+		})
+
+	// CoordinatorMinServiceGCSafepointGauge is the physical time of the min service gc safepoint in PD.
+	CoordinatorMinServiceGCSafepointGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "coordinator",
+			Name:      "min_service_gc_safepoint",
+			Help:      "The physical time of the min service gc safepoint in PD",
+		})
 )
 
 func InitCoordinatorMetrics(registry *prometheus.Registry) {
@@ -55,4 +73,6 @@ func InitCoordinatorMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(CoordinatorFinishedOperatorCount)
 	registry.MustRegister(CoordinatorOperatorDuration)
 	registry.MustRegister(ChangefeedStateGauge)
+	registry.MustRegister(CoordinatorGCSafepointGauge)
+	registry.MustRegister(CoordinatorMinServiceGCSafepointGauge)
 }
@@ -81,6 +81,39 @@ var (
 			Help:      "Checkpoint ts lag of event dispatcher manager(changefeed) in seconds",
 		}, []string{"namespace", "changefeed"})
 
+	EventDispatcherManagerBlockedDispatcherGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatchermanager",
+			Name:      "blocked_dispatcher_count",
+			Help:      "The number of dispatchers waiting for the maintainer to resolve the block event",
+		}, []string{"namespace", "changefeed"})
+
+	DispatcherTableFlushLagDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatcher",
+			Name:      "table_flush_lag_duration",
+			Help:      "The duration between the commitTs of the dml event and the time it's flushed to downstream, per table",
+			Buckets:   LagBucket(),
+		}, []string{"namespace", "changefeed", "table_id"})
+
+	DispatcherRateLimitThrottledDuration = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatcher",
+			Name:      "rate_limit_throttled_duration",
+			Help:      "The total duration (s) the dispatchers are throttled by the rate limit of the changefeed",
+		}, []string{"namespace", "changefeed"})
+
+	DispatcherHeartbeatStatusCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "dispatcher",
+			Name:      "heartbeat_status_count",
+			Help:      "The number of the dispatcher statuses reported to the maintainer in the heartbeats",
+		}, []string{"namespace", "changefeed", "type"}) // type is complete or delta
+
 	HandleDispatcherRequsetCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "ticdc",
@@ -130,6 +163,10 @@ func InitDispatcherMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventDispatcherManagerResolvedTsLagGauge)
 	registry.MustRegister(EventDispatcherManagerCheckpointTsGauge)
 	registry.MustRegister(EventDispatcherManagerCheckpointTsLagGauge)
+	registry.MustRegister(EventDispatcherManagerBlockedDispatcherGauge)
+	registry.MustRegister(DispatcherTableFlushLagDuration)
+	registry.MustRegister(DispatcherRateLimitThrottledDuration)
+	registry.MustRegister(DispatcherHeartbeatStatusCount)
 	registry.MustRegister(HandleDispatcherRequsetCounter)
 	registry.MustRegister(DispatcherReceivedEventCount)
 	registry.MustRegister(EventCollectorRegisteredDispatcherCount)
@@ -116,6 +116,22 @@ var (
 			Help:      "The compression ratio of the event data.",
 		})
 
+	EventStoreDedupDroppedEventCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "dedup_dropped_event_count",
+			Help:      "The number of the duplicated events dropped before they are written to the event store.",
+		})
+
+	EventStoreDedupWindowSizeGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "dedup_window_size",
+			Help:      "The number of the events remembered to find the duplicated events.",
+		})
+
 	EventStoreWriteBatchEventsCountHist = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "ticdc",
@@ -149,6 +165,54 @@ var (
 		Help:      "Bucketed histogram of event store sorter iterator read duration",
 		Buckets:   prometheus.ExponentialBuckets(0.004, 2.0, 20),
 	}, []string{"type"})
+
+	EventStoreOnDiskDataSizeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "on_disk_data_size",
+			Help:      "The amount of the data on disk of each db in event store.",
+		}, []string{"id"})
+
+	EventStoreWriteAmplificationGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "write_amplification",
+			Help:      "The write amplification of each db in event store.",
+		}, []string{"id"})
+
+	EventStoreReadAmplificationGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "read_amplification",
+			Help:      "The read amplification of each db in event store.",
+		}, []string{"id"})
+
+	EventStoreCompactionPendingBytesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "compaction_pending_bytes",
+			Help:      "The estimated bytes that need to be compacted of each db in event store.",
+		}, []string{"id"})
+
+	EventStoreCompactionInProgressBytesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "compaction_in_progress_bytes",
+			Help:      "The bytes of the sstables being compacted of each db in event store.",
+		}, []string{"id"})
+
+	EventStoreChangefeedStoredBytesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "changefeed_stored_bytes",
+			Help:      "The estimated bytes of the events stored in event store for each changefeed, the events shared by several changefeeds are split among them.",
+		}, []string{"namespace", "changefeed"})
 )
 
 func InitEventStoreMetrics(registry *prometheus.Registry) {
@@ -164,8 +228,16 @@ func InitEventStoreMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventStoreResolvedTsLagGauge)
 	registry.MustRegister(EventStoreDispatcherWatermarkLagHist)
 	registry.MustRegister(EventStoreCompressRatio)
+	registry.MustRegister(EventStoreDedupDroppedEventCount)
+	registry.MustRegister(EventStoreDedupWindowSizeGauge)
 	registry.MustRegister(EventStoreWriteBatchEventsCountHist)
 	registry.MustRegister(EventStoreWriteBatchSizeHist)
 	registry.MustRegister(EventStoreWriteRequestsCount)
 	registry.MustRegister(EventStoreReadDurationHistogram)
+	registry.MustRegister(EventStoreOnDiskDataSizeGauge)
+	registry.MustRegister(EventStoreWriteAmplificationGauge)
+	registry.MustRegister(EventStoreReadAmplificationGauge)
+	registry.MustRegister(EventStoreCompactionPendingBytesGauge)
+	registry.MustRegister(EventStoreCompactionInProgressBytesGauge)
+	registry.MustRegister(EventStoreChangefeedStoredBytesGauge)
 }
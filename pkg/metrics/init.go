@@ -36,6 +36,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	InitLogPullerMetrics(registry)
 	common.InitCommonMetrics(registry)
 	InitDynamicStreamMetrics(registry)
+	InitMemoryMetrics(registry)
 	kafka.InitMetrics(registry)
 	codec.InitMetrics(registry)
 }
@@ -23,6 +23,20 @@ var (
 			Name:      "prewrite_cache_row_num",
 			Help:      "The number of rows in prewrite cache",
 		})
+	LogPullerOldestUnmatchedPrewriteAge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "oldest_unmatched_prewrite_age",
+			Help:      "The age in seconds of the oldest prewrite row which is not matched by its commit or rollback",
+		})
+	LogPullerLongUnmatchedRegionCount = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "long_unmatched_region_count",
+			Help:      "The number of regions which have prewrite rows unmatched for more than 10 minutes",
+		})
 	LogPullerMatcherCount = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "ticdc",
@@ -45,10 +59,40 @@ var (
 			Name:      "resolved_ts_lag",
 			Help:      "The resolved ts lag of subscription client.",
 		})
+
+	LogPullerRegionRetryBackoffHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "region_retry_backoff_duration",
+			Help:      "Bucketed histogram of the backoff before retrying a failed region request",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12), // 10ms ~ 20s
+		}, []string{"class"})
+
+	LogPullerStoreCircuitBreakerGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "log_puller",
+			Name:      "store_circuit_breaker_open",
+			Help:      "Whether the region requests to the store are suspended because it keeps returning errors",
+		}, []string{"store"})
+
+	SubscriptionClientPausedSubscriptionGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "subscription_client",
+			Name:      "paused_subscription_count",
+			Help:      "The number of subscriptions paused by the backpressure of the downstream.",
+		})
 )
 
 func InitLogPullerMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(LogPullerPrewriteCacheRowNum)
 	registry.MustRegister(LogPullerMatcherCount)
+	registry.MustRegister(LogPullerOldestUnmatchedPrewriteAge)
+	registry.MustRegister(LogPullerLongUnmatchedRegionCount)
 	registry.MustRegister(LogPullerResolvedTsLag)
+	registry.MustRegister(SubscriptionClientPausedSubscriptionGauge)
+	registry.MustRegister(LogPullerRegionRetryBackoffHistogram)
+	registry.MustRegister(LogPullerStoreCircuitBreakerGauge)
 }
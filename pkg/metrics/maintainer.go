@@ -49,6 +49,22 @@ var (
 			Help:      "Bucketed histogram of processing time (s) of finished operator.",
 			Buckets:   []float64{0.5, 1, 2, 4, 8, 16, 20, 40, 60, 90, 120, 180, 240, 300, 480, 600, 720, 900, 1200, 1800, 3600},
 		}, []string{"namespace", "changefeed", "type"})
+
+	OrphanDispatcherReconcileCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "maintainer",
+			Name:      "orphan_dispatcher_reconcile_count",
+			Help:      "number of reconciled orphan dispatchers",
+		}, []string{"namespace", "changefeed", "type"})
+
+	SpanCheckpointRegressionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "maintainer",
+			Name:      "span_checkpoint_regression_count",
+			Help:      "number of rejected span statuses whose checkpoint ts is less than the current one",
+		}, []string{"namespace", "changefeed"})
 )
 
 func InitMaintainerMetrics(registry *prometheus.Registry) {
@@ -56,4 +72,6 @@ func InitMaintainerMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(CreatedOperatorCount)
 	registry.MustRegister(FinishedOperatorCount)
 	registry.MustRegister(OperatorDuration)
+	registry.MustRegister(OrphanDispatcherReconcileCounter)
+	registry.MustRegister(SpanCheckpointRegressionCounter)
 }
@@ -0,0 +1,53 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// MemoryUsageGauge is the memory accounted by each component of the memory registry.
+	MemoryUsageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "memory",
+			Name:      "usage_bytes",
+			Help:      "The memory accounted by each component",
+		}, []string{"component"})
+
+	// MemoryLimitGauge is the soft and hard memory limits of the memory registry.
+	MemoryLimitGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "memory",
+			Name:      "limit_bytes",
+			Help:      "The soft and hard memory limits of the accounted components",
+		}, []string{"type"}) // types : soft, hard.
+
+	// MemoryThrottledGauge is 1 if the component is throttled by the memory limits.
+	MemoryThrottledGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "memory",
+			Name:      "throttled",
+			Help:      "Whether the component is throttled by the memory limits",
+		}, []string{"component"})
+)
+
+func InitMemoryMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(MemoryUsageGauge)
+	registry.MustRegister(MemoryLimitGauge)
+	registry.MustRegister(MemoryThrottledGauge)
+}
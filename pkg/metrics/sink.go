@@ -197,6 +197,34 @@ var (
 			Name:      "mq_checkpoint_ts_message_count",
 			Help:      "Number of checkpoint ts messages sent.",
 		}, []string{"namespace", "changefeed"})
+
+	// DeadLetterQueueRowsCounter is the number of rows diverted to the dead letter queue.
+	DeadLetterQueueRowsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "dead_letter_queue_rows_count",
+			Help:      "Number of rows diverted to the dead letter queue.",
+		}, []string{"namespace", "changefeed"})
+
+	// DownstreamServerHealthGauge is the health of each downstream server when the mysql sink
+	// has multiple addresses, 1 means the server is healthy.
+	DownstreamServerHealthGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "downstream_server_health",
+			Help:      "Health of each downstream server of the mysql sink, 1 means healthy.",
+		}, []string{"namespace", "changefeed", "address"})
+
+	// multi sink metrics
+	SinkTargetCheckpointTsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "target_checkpoint_ts",
+			Help:      "Checkpoint ts of each target when the changefeed has multiple sink targets.",
+		}, []string{"namespace", "changefeed", "target"})
 )
 
 // InitMetrics registers all metrics in this file.
@@ -208,6 +236,7 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventSizeHistogram)
 	registry.MustRegister(ExecutionErrorCounter)
 	registry.MustRegister(ExecDMLEventCounter)
+	registry.MustRegister(DeadLetterQueueRowsCounter)
 
 	// txn sink metrics
 	registry.MustRegister(ConflictDetectDuration)
@@ -218,6 +247,7 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(SinkDMLBatchCommit)
 	registry.MustRegister(SinkDMLBatchCallback)
 	registry.MustRegister(PrepareStatementErrors)
+	registry.MustRegister(DownstreamServerHealthGauge)
 
 	// kafka sink metrics
 	registry.MustRegister(WorkerSendMessageDuration)
@@ -225,4 +255,7 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(WorkerBatchDuration)
 	registry.MustRegister(CheckpointTsMessageDuration)
 	registry.MustRegister(CheckpointTsMessageCount)
+
+	// multi sink metrics
+	registry.MustRegister(SinkTargetCheckpointTsGauge)
 }
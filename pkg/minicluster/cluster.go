@@ -0,0 +1,295 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package minicluster runs a ticdc cluster in process for the integration tests
+// of the scheduling and the block event protocol, without tikv, pd and a real downstream.
+//
+// The first node runs the real maintainer manager, the changefeeds created in the
+// cluster are always maintained by it. Every node, including the first one, hosts the
+// real dispatchers scheduled by the maintainer, sends them the events of the Source,
+// and the dispatchers write the block events to the shared in-memory Sink.
+// The nodes talk to each other by the real message center over grpc, so the node
+// crashes can be injected by stopping a node, and the tables can be moved
+// between the nodes by Maintainer.MoveTable.
+//
+// The maintainer manager relies on the global services in appcontext, so only one
+// Cluster can be running in a process at the same time.
+package minicluster
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerrors "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// Options are the options of the Cluster.
+type Options struct {
+	// Nodes is the number of nodes when the cluster starts.
+	Nodes int
+	// TickInterval is the interval the dispatchers advance and report the heartbeat.
+	TickInterval time.Duration
+	// Scheduler is the config of the maintainer scheduler.
+	Scheduler *config.SchedulerConfig
+}
+
+// Cluster is an in-process ticdc cluster.
+type Cluster struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   Options
+
+	source *Source
+	sink   *Sink
+
+	nodeManager *watcher.NodeManager
+	manager     *maintainer.Manager
+
+	mu    sync.Mutex
+	nodes []*Node
+}
+
+// ownerEtcdClient is used by the node manager to get the coordinator id and the node infos.
+type ownerEtcdClient struct {
+	etcd.CDCEtcdClient
+	ownerID node.ID
+	cluster *Cluster
+}
+
+func (c *ownerEtcdClient) GetOwnerID(_ context.Context) (model.CaptureID, error) {
+	return model.CaptureID(c.ownerID), nil
+}
+
+func (c *ownerEtcdClient) GetNodeInfo(_ context.Context, id string) (*node.Info, error) {
+	c.cluster.mu.Lock()
+	defer c.cluster.mu.Unlock()
+	for _, n := range c.cluster.nodes {
+		if n.ID() == node.ID(id) {
+			return n.info, nil
+		}
+	}
+	return nil, cerrors.ErrCaptureNotExist.GenWithStackByArgs(id)
+}
+
+// regionCache reports every span is in one region, so the tables are not split
+// by the region count, they are only split by Maintainer.SplitTable.
+type regionCache struct{}
+
+func (regionCache) ListRegionIDsInKeyRange(_ *tikv.Backoffer, _, _ []byte) ([]uint64, error) {
+	return []uint64{1}, nil
+}
+
+func (regionCache) LocateRegionByID(_ *tikv.Backoffer, regionID uint64) (*tikv.KeyLocation, error) {
+	return nil, errors.Errorf("region %d is not found", regionID)
+}
+
+// New starts a cluster, the tables and the events of all changefeeds come from the source.
+func New(ctx context.Context, source *Source, opts Options) (*Cluster, error) {
+	if opts.Nodes <= 0 {
+		opts.Nodes = 1
+	}
+	if opts.TickInterval <= 0 {
+		opts.TickInterval = 50 * time.Millisecond
+	}
+	if opts.Scheduler == nil {
+		opts.Scheduler = config.GetGlobalServerConfig().Debug.Scheduler
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c := &Cluster{
+		ctx:    ctx,
+		cancel: cancel,
+		opts:   opts,
+		source: source,
+		sink:   &Sink{},
+	}
+
+	first, err := newNode(ctx, source, c.sink, opts.TickInterval)
+	if err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+	c.nodes = append(c.nodes, first)
+
+	c.nodeManager = watcher.NewNodeManager(nil, &ownerEtcdClient{ownerID: first.ID(), cluster: c})
+	// the node change handler is registered once, so the crashed nodes are not notified.
+	c.nodeManager.RegisterNodeChangeHandler("minicluster", c.onNodeChanges)
+	appcontext.SetService(watcher.NodeManagerName, c.nodeManager)
+	appcontext.SetService(appcontext.DefaultPDClock, pdutil.NewClock4Test())
+	appcontext.SetService(appcontext.SchemaStore, &schemaStore{source: source})
+	appcontext.SetService(appcontext.MessageCenter, first.mc)
+
+	// the maintainer manager reports the status of the maintainers to the coordinator.
+	first.mc.RegisterHandler(messaging.CoordinatorTopic, func(context.Context, *messaging.TargetMessage) error {
+		return nil
+	})
+	c.manager = maintainer.NewMaintainerManager(first.info, opts.Scheduler, nil, &replica.MockTsoClient{}, regionCache{})
+	go func() {
+		_ = c.manager.Run(ctx)
+	}()
+	err = first.mc.SendCommand(messaging.NewSingleTargetMessage(first.ID(),
+		messaging.MaintainerManagerTopic, &heartbeatpb.CoordinatorBootstrapRequest{Version: 1}))
+	if err != nil {
+		c.Close()
+		return nil, errors.Trace(err)
+	}
+
+	for i := 1; i < opts.Nodes; i++ {
+		if _, err = c.AddNode(); err != nil {
+			c.Close()
+			return nil, errors.Trace(err)
+		}
+	}
+	c.refreshNodes()
+	return c, nil
+}
+
+func (c *Cluster) onNodeChanges(activeNodes map[node.ID]*node.Info) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, n := range c.nodes {
+		n.mc.OnNodeChanges(activeNodes)
+	}
+}
+
+// refreshNodes notifies the node manager the alive nodes, as what the etcd watcher does.
+func (c *Cluster) refreshNodes() {
+	c.mu.Lock()
+	captures := make(map[model.CaptureID]*model.CaptureInfo, len(c.nodes))
+	for _, n := range c.nodes {
+		captures[model.CaptureID(n.ID())] = &model.CaptureInfo{
+			ID:            model.CaptureID(n.ID()),
+			AdvertiseAddr: n.info.AdvertiseAddr,
+		}
+	}
+	c.mu.Unlock()
+	_, _ = c.nodeManager.Tick(c.ctx, &orchestrator.GlobalReactorState{Captures: captures})
+}
+
+// Source returns the source of the cluster.
+func (c *Cluster) Source() *Source {
+	return c.source
+}
+
+// Sink returns the sink shared by all nodes.
+func (c *Cluster) Sink() *Sink {
+	return c.sink
+}
+
+// Nodes returns the alive nodes, the first one runs the maintainers.
+func (c *Cluster) Nodes() []*Node {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nodes := make([]*Node, len(c.nodes))
+	copy(nodes, c.nodes)
+	return nodes
+}
+
+// AddNode starts a new node and joins it to the cluster.
+func (c *Cluster) AddNode() (*Node, error) {
+	n, err := newNode(c.ctx, c.source, c.sink, c.opts.TickInterval)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.mu.Lock()
+	c.nodes = append(c.nodes, n)
+	c.mu.Unlock()
+	c.refreshNodes()
+	return n, nil
+}
+
+// CrashNode stops the node and removes it from the cluster, the dispatchers
+// on the node are lost. The first node can not be crashed since it runs the maintainers.
+func (c *Cluster) CrashNode(id node.ID) error {
+	c.mu.Lock()
+	var crashed *Node
+	for i, n := range c.nodes {
+		if n.ID() != id {
+			continue
+		}
+		if i == 0 {
+			c.mu.Unlock()
+			return errors.Errorf("node %s runs the maintainers and can not be crashed", id)
+		}
+		crashed = n
+		c.nodes = append(c.nodes[:i], c.nodes[i+1:]...)
+		break
+	}
+	c.mu.Unlock()
+	if crashed == nil {
+		return errors.Errorf("node %s is not found", id)
+	}
+	crashed.stop()
+	c.refreshNodes()
+	return nil
+}
+
+// CreateChangefeed creates a changefeed of the default config starting from the checkpoint ts.
+func (c *Cluster) CreateChangefeed(name string, checkpointTs uint64) (common.ChangeFeedID, error) {
+	return c.CreateChangefeedWithConfig(name, checkpointTs, config.GetDefaultReplicaConfig())
+}
+
+// CreateChangefeedWithConfig creates a changefeed of the config starting from the checkpoint ts.
+func (c *Cluster) CreateChangefeedWithConfig(
+	name string, checkpointTs uint64, cfg *config.ReplicaConfig,
+) (common.ChangeFeedID, error) {
+	cfID := common.NewChangeFeedIDWithName(name)
+	info := &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		StartTs:      checkpointTs,
+		Config:       cfg,
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return cfID, errors.Trace(err)
+	}
+	first := c.Nodes()[0]
+	err = first.mc.SendCommand(messaging.NewSingleTargetMessage(first.ID(),
+		messaging.MaintainerManagerTopic, &heartbeatpb.AddMaintainerRequest{
+			Id:             cfID.ToPB(),
+			Config:         data,
+			CheckpointTs:   checkpointTs,
+			IsNewChangfeed: true,
+		}))
+	return cfID, errors.Trace(err)
+}
+
+// Maintainer returns the maintainer of the changefeed.
+// The tests can schedule the tables by the maintainer, e.g. Maintainer.MoveTable.
+func (c *Cluster) Maintainer(changefeedID common.ChangeFeedID) (*maintainer.Maintainer, bool) {
+	return c.manager.GetMaintainerForChangefeed(changefeedID)
+}
+
+// Close stops all nodes of the cluster.
+func (c *Cluster) Close() {
+	c.cancel()
+	for _, n := range c.Nodes() {
+		n.stop()
+	}
+}
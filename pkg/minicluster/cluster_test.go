@@ -0,0 +1,222 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minicluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/faultinject"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCluster(t *testing.T, nodes int) *Cluster {
+	tables := make([]commonEvent.Table, 0, 4)
+	for i := 1; i <= 4; i++ {
+		tables = append(tables, commonEvent.Table{
+			SchemaID: 1,
+			TableID:  int64(i),
+			SchemaTableName: &commonEvent.SchemaTableName{
+				SchemaName: "test",
+				TableName:  fmt.Sprintf("t%d", i),
+			},
+		})
+	}
+	c, err := New(context.Background(), NewSource(tables, 10), Options{Nodes: nodes})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	return c
+}
+
+func waitReplicating(t *testing.T, c *Cluster, cfID common.ChangeFeedID) *maintainer.Maintainer {
+	var m *maintainer.Maintainer
+	require.Eventually(t, func() bool {
+		var ok bool
+		m, ok = c.Maintainer(cfID)
+		if !ok {
+			return false
+		}
+		total := 0
+		for _, n := range c.Nodes() {
+			total += n.DispatcherCount(cfID)
+		}
+		// 4 tables and the table trigger event dispatcher
+		return total == 5 && m.GetMaintainerStatus().CheckpointTs >= 10
+	}, 20*time.Second, 50*time.Millisecond)
+	return m
+}
+
+func tableOnNode(m *maintainer.Maintainer, id node.ID) int64 {
+	for _, span := range m.GetTables() {
+		if span.Span.TableID != heartbeatpb.DDLSpan.TableID && span.GetNodeID() == id {
+			return span.Span.TableID
+		}
+	}
+	return 0
+}
+
+func TestNodeCrashDuringDDL(t *testing.T) {
+	c := newTestCluster(t, 2)
+	cfID, err := c.CreateChangefeed("crash-during-ddl", 10)
+	require.NoError(t, err)
+	m := waitReplicating(t, c, cfID)
+
+	nodes := c.Nodes()
+	require.Eventually(t, func() bool {
+		return nodes[1].DispatcherCount(cfID) > 0
+	}, 20*time.Second, 50*time.Millisecond)
+
+	// the ddl blocks all tables and is written by the table trigger event dispatcher.
+	c.Source().AddDDL(&DDL{
+		CommitTs:      20,
+		BlockedTables: &commonEvent.InfluencedTables{InfluenceType: commonEvent.InfluenceTypeAll},
+	})
+	c.Source().AdvanceResolvedTs(30)
+	// let the dispatchers on the crashed node report the ddl.
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, c.CrashNode(nodes[1].ID()))
+
+	require.Eventually(t, func() bool {
+		return m.GetMaintainerStatus().CheckpointTs >= 30
+	}, 20*time.Second, 50*time.Millisecond)
+	require.Equal(t, 5, nodes[0].DispatcherCount(cfID))
+	events := c.Sink().WrittenEvents(cfID)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(20), events[0].CommitTs)
+	require.Equal(t, heartbeatpb.DDLSpan.TableID, events[0].TableID)
+}
+
+func TestMoveTableDuringDDL(t *testing.T) {
+	c := newTestCluster(t, 2)
+	cfID, err := c.CreateChangefeed("move-during-ddl", 10)
+	require.NoError(t, err)
+	m := waitReplicating(t, c, cfID)
+
+	nodes := c.Nodes()
+	var tableID int64
+	require.Eventually(t, func() bool {
+		tableID = tableOnNode(m, nodes[1].ID())
+		return tableID != 0
+	}, 20*time.Second, 50*time.Millisecond)
+	another := tableOnNode(m, nodes[0].ID())
+	require.NotZero(t, another)
+
+	// the ddl blocks two tables on different nodes, e.g. rename tables.
+	c.Source().AddDDL(&DDL{
+		CommitTs: 20,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{tableID, another},
+		},
+	})
+	c.Source().AdvanceResolvedTs(30)
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, m.MoveTable(tableID, nodes[0].ID()))
+
+	require.Eventually(t, func() bool {
+		return m.GetMaintainerStatus().CheckpointTs >= 30
+	}, 20*time.Second, 50*time.Millisecond)
+	for _, span := range m.GetTables() {
+		if span.Span.TableID == tableID {
+			require.Equal(t, nodes[0].ID(), span.GetNodeID())
+		}
+	}
+	events := c.Sink().WrittenEvents(cfID)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(20), events[0].CommitTs)
+}
+
+func TestSplitTableDuringMove(t *testing.T) {
+	c := newTestCluster(t, 2)
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Scheduler.EnableTableAcrossNodes = true
+	cfID, err := c.CreateChangefeedWithConfig("split-during-move", 10, cfg)
+	require.NoError(t, err)
+	m := waitReplicating(t, c, cfID)
+
+	// hold the move by delaying the messages to the origin node.
+	nodes := c.Nodes()
+	injector := faultinject.GetInjector()
+	injector.Enable()
+	faultID := injector.Add(faultinject.Fault{
+		Kind:   faultinject.KindMessage,
+		To:     nodes[1].ID(),
+		Action: faultinject.ActionDelay,
+		Delay:  time.Second,
+	})
+	t.Cleanup(func() { injector.Remove(faultID) })
+	// retry if the table is being scheduled by the maintainer.
+	var tableID int64
+	require.Eventually(t, func() bool {
+		tableID = tableOnNode(m, nodes[1].ID())
+		if tableID == 0 {
+			return false
+		}
+		_, err := m.MoveTables([]maintainer.TableMove{{TableID: tableID, TargetNode: nodes[0].ID()}})
+		return err == nil
+	}, 20*time.Second, 50*time.Millisecond)
+	var splitKey []byte
+	for _, span := range m.GetTables() {
+		if span.Span.TableID == tableID {
+			splitKey = append(bytes.Clone(span.Span.StartKey), 1)
+		}
+	}
+
+	// the table can't be split until the move is finished.
+	err = m.SplitTable(tableID, [][]byte{splitKey})
+	require.ErrorContains(t, err, "in scheduling")
+	injector.Remove(faultID)
+	require.Eventually(t, func() bool {
+		return m.GetSpanMoves()[0].State == maintainer.SpanMoveStateFinished
+	}, 20*time.Second, 50*time.Millisecond)
+	// the balance may move the table again.
+	require.Eventually(t, func() bool {
+		return m.SplitTable(tableID, [][]byte{splitKey}) == nil
+	}, 20*time.Second, 50*time.Millisecond)
+	require.Eventually(t, func() bool {
+		spans := 0
+		for _, span := range m.GetTables() {
+			if span.Span.TableID == tableID && span.GetNodeID() != "" {
+				spans++
+			}
+		}
+		return spans == 2 && m.GetMaintainerStatus().CheckpointTs >= 10
+	}, 20*time.Second, 50*time.Millisecond)
+
+	// the ddl of the split table blocks its spans, it's written by one of them.
+	c.Source().AddDDL(&DDL{
+		CommitTs: 20,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{tableID},
+		},
+	})
+	c.Source().AdvanceResolvedTs(30)
+	require.Eventually(t, func() bool {
+		return m.GetMaintainerStatus().CheckpointTs >= 30
+	}, 20*time.Second, 50*time.Millisecond)
+	events := c.Sink().WrittenEvents(cfID)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(20), events[0].CommitTs)
+	require.Equal(t, tableID, events[0].TableID)
+}
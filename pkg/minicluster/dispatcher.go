@@ -0,0 +1,486 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minicluster
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// tableDispatcher is a real dispatcher hosted by the dispatcherManager, which plays
+// the role of the event collector and sends the events of the Source to it.
+type tableDispatcher struct {
+	*dispatcher.Dispatcher
+	// sentTs is the commit ts of the last event sent to the dispatcher.
+	sentTs uint64
+	// blocked is set while the dispatcher handles an event which blocks it,
+	// the following events are sent after it wakes up, as what the dynamic stream does.
+	blocked atomic.Bool
+	// ready is false for the table trigger event dispatcher until its table schema
+	// store is initialized by the post bootstrap request.
+	ready bool
+}
+
+// feed sends the resolved ts and the next ddl of the source to the dispatcher.
+func (d *tableDispatcher) feed(source *Source) {
+	if !d.ready || d.blocked.Load() {
+		return
+	}
+	tableID := d.GetTableSpan().TableID
+	resolvedTs, ddl := source.next(d.GetSchemaID(), tableID, d.sentTs)
+	if resolvedTs > d.sentTs {
+		d.sentTs = resolvedTs
+		d.handle(commonEvent.NewResolvedEvent(resolvedTs, d.GetId()))
+	}
+	if ddl != nil {
+		d.sentTs = ddl.CommitTs
+		d.handle(ddl.event(d.GetId(), tableID))
+	}
+}
+
+func (d *tableDispatcher) handle(event commonEvent.Event) {
+	d.blocked.Store(true)
+	events := []dispatcher.DispatcherEvent{dispatcher.NewDispatcherEvent(nil, event)}
+	if !d.HandleEvents(events, func() { d.blocked.Store(false) }) {
+		d.blocked.Store(false)
+	}
+}
+
+// changefeedDispatchers holds all dispatchers of a changefeed in a node,
+// and the states shared by them as what the event dispatcher manager does.
+type changefeedDispatchers struct {
+	id           *heartbeatpb.ChangefeedID
+	maintainerID node.ID
+
+	sink                  *memorySink
+	blockStatusesChan     chan *heartbeatpb.TableSpanBlockStatus
+	errCh                 chan error
+	schemaIDToDispatchers *dispatcher.SchemaIDToDispatchers
+
+	tableTriggerEventDispatcher *tableDispatcher
+	dispatchers                 map[common.DispatcherID]*tableDispatcher
+}
+
+// dispatcherManager hosts the dispatchers of a node, it does the work of the dispatcher
+// orchestrator, the event dispatcher manager and the heartbeat collector in one goroutine.
+// They are not used directly, since the event dispatcher managers of a changefeed
+// on all nodes would share the process level services, such as the heartbeat collector.
+type dispatcherManager struct {
+	self   node.ID
+	mc     messaging.MessageCenter
+	source *Source
+	sink   *Sink
+
+	tickInterval time.Duration
+	msgCh        chan *messaging.TargetMessage
+
+	mu          sync.Mutex
+	changefeeds map[common.ChangeFeedID]*changefeedDispatchers
+}
+
+func newDispatcherManager(
+	self node.ID,
+	mc messaging.MessageCenter,
+	source *Source,
+	sink *Sink,
+	tickInterval time.Duration,
+) *dispatcherManager {
+	m := &dispatcherManager{
+		self:         self,
+		mc:           mc,
+		source:       source,
+		sink:         sink,
+		tickInterval: tickInterval,
+		msgCh:        make(chan *messaging.TargetMessage, 1024),
+		changefeeds:  make(map[common.ChangeFeedID]*changefeedDispatchers),
+	}
+	mc.RegisterHandler(messaging.DispatcherManagerManagerTopic, m.recvMessages)
+	mc.RegisterHandler(messaging.HeartbeatCollectorTopic, m.recvMessages)
+	return m
+}
+
+func (m *dispatcherManager) recvMessages(ctx context.Context, msg *messaging.TargetMessage) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case m.msgCh <- msg:
+	}
+	return nil
+}
+
+func (m *dispatcherManager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.tickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-m.msgCh:
+			m.handleMessage(msg)
+		case <-ticker.C:
+			m.tick()
+		}
+	}
+}
+
+func (m *dispatcherManager) handleMessage(msg *messaging.TargetMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch msg.Type {
+	case messaging.TypeMaintainerBootstrapRequest:
+		m.onBootstrapRequest(msg)
+	case messaging.TypeMaintainerPostBootstrapRequest:
+		m.onPostBootstrapRequest(msg)
+	case messaging.TypeScheduleDispatcherRequest:
+		m.onScheduleDispatcherRequest(msg)
+	case messaging.TypeHeartBeatResponse:
+		m.onHeartBeatResponse(msg)
+	case messaging.TypeMaintainerCloseRequest:
+		m.onMaintainerCloseRequest(msg)
+	case messaging.TypeCheckpointTsMessage:
+		// the memory sink does not need the checkpoint ts
+	default:
+		log.Panic("unknown message type", zap.Any("message", msg))
+	}
+}
+
+func (m *dispatcherManager) sendToMaintainer(to node.ID, msg messaging.IOTypeT) {
+	err := m.mc.SendCommand(messaging.NewSingleTargetMessage(to, messaging.MaintainerManagerTopic, msg))
+	if err != nil {
+		log.Debug("send message to maintainer failed",
+			zap.Stringer("node", m.self), zap.Error(err))
+	}
+}
+
+func (m *dispatcherManager) newDispatcher(
+	cf *changefeedDispatchers, id common.DispatcherID, span *heartbeatpb.TableSpan, schemaID int64, startTs, pdTs uint64,
+) *tableDispatcher {
+	d := &tableDispatcher{
+		Dispatcher: dispatcher.NewDispatcher(
+			cf.sink.changefeedID, id, span, cf.sink, startTs,
+			cf.blockStatusesChan, schemaID, cf.schemaIDToDispatchers,
+			nil, // syncPointConfig
+			nil, // filterConfig
+			0,   // upstreamID
+			pdTs,
+			cf.errCh,
+			nil, // rateLimiter
+			nil, // quiescer
+			nil, // ingestPolicy
+		),
+		sentTs: startTs,
+	}
+	if d.IsTableTriggerEventDispatcher() {
+		cf.tableTriggerEventDispatcher = d
+	} else {
+		cf.schemaIDToDispatchers.Set(schemaID, id)
+		d.ready = true
+	}
+	cf.dispatchers[id] = d
+	return d
+}
+
+func (m *dispatcherManager) onBootstrapRequest(msg *messaging.TargetMessage) {
+	req := msg.Message[0].(*heartbeatpb.MaintainerBootstrapRequest)
+	cfID := common.NewChangefeedIDFromPB(req.ChangefeedID)
+	cf, ok := m.changefeeds[cfID]
+	if !ok {
+		cf = &changefeedDispatchers{
+			id:                    req.ChangefeedID,
+			sink:                  &memorySink{changefeedID: cfID, node: m.self, downstream: m.sink},
+			blockStatusesChan:     make(chan *heartbeatpb.TableSpanBlockStatus, 1024*1024),
+			errCh:                 make(chan error, 1),
+			schemaIDToDispatchers: dispatcher.NewSchemaIDToDispatchers(),
+			dispatchers:           make(map[common.DispatcherID]*tableDispatcher),
+		}
+		m.changefeeds[cfID] = cf
+	}
+	cf.maintainerID = msg.From
+
+	response := &heartbeatpb.MaintainerBootstrapResponse{
+		ChangefeedID: req.ChangefeedID,
+		// the batched schedule dispatcher requests are handled one by one
+		ProtocolVersion: heartbeatpb.ProtocolVersion,
+		Capabilities:    []string{heartbeatpb.CapabilityBatchScheduleRequest},
+	}
+	if req.TableTriggerEventDispatcherId != nil {
+		id := common.NewDispatcherIDFromPB(req.TableTriggerEventDispatcherId)
+		d, ok := cf.dispatchers[id]
+		if !ok {
+			d = m.newDispatcher(cf, id, heartbeatpb.DDLSpan, 0, req.StartTs, 0)
+		}
+		response.CheckpointTs = d.GetCheckpointTs()
+	}
+	for id, d := range cf.dispatchers {
+		response.Spans = append(response.Spans, &heartbeatpb.BootstrapTableSpan{
+			ID:              id.ToPB(),
+			SchemaID:        d.GetSchemaID(),
+			Span:            d.GetTableSpan(),
+			ComponentStatus: d.GetComponentStatus(),
+			CheckpointTs:    d.GetCheckpointTs(),
+			BlockState:      d.GetBlockEventStatus(),
+		})
+	}
+	m.sendToMaintainer(msg.From, response)
+	log.Info("minicluster dispatcher manager bootstrapped",
+		zap.Stringer("node", m.self),
+		zap.String("changefeed", cfID.Name()),
+		zap.Int("dispatchers", len(cf.dispatchers)))
+}
+
+func (m *dispatcherManager) onPostBootstrapRequest(msg *messaging.TargetMessage) {
+	req := msg.Message[0].(*heartbeatpb.MaintainerPostBootstrapRequest)
+	cf, ok := m.changefeeds[common.NewChangefeedIDFromPB(req.ChangefeedID)]
+	if !ok || cf.tableTriggerEventDispatcher == nil {
+		log.Warn("ignore post bootstrap request without the table trigger event dispatcher",
+			zap.Stringer("node", m.self))
+		return
+	}
+	response := &heartbeatpb.MaintainerPostBootstrapResponse{
+		ChangefeedID:                  req.ChangefeedID,
+		TableTriggerEventDispatcherId: req.TableTriggerEventDispatcherId,
+	}
+	d := cf.tableTriggerEventDispatcher
+	if err := d.InitializeTableSchemaStore(req.Schemas); err != nil {
+		response.Err = &heartbeatpb.RunningError{
+			Time:    time.Now().String(),
+			Node:    m.self.String(),
+			Message: err.Error(),
+		}
+	} else {
+		d.ready = true
+	}
+	m.sendToMaintainer(msg.From, response)
+}
+
+// onScheduleDispatcherRequest handles all the requests in the message,
+// the maintainer may batch the requests to the same node in one message.
+func (m *dispatcherManager) onScheduleDispatcherRequest(msg *messaging.TargetMessage) {
+	for _, r := range msg.Message {
+		m.handleScheduleDispatcherRequest(msg.From, r.(*heartbeatpb.ScheduleDispatcherRequest))
+	}
+}
+
+func (m *dispatcherManager) handleScheduleDispatcherRequest(from node.ID, req *heartbeatpb.ScheduleDispatcherRequest) {
+	cf, ok := m.changefeeds[common.NewChangefeedIDFromPB(req.ChangefeedID)]
+	if !ok || cf.maintainerID != from {
+		log.Warn("ignore schedule request from unknown maintainer",
+			zap.Stringer("node", m.self), zap.Stringer("from", from))
+		return
+	}
+	id := common.NewDispatcherIDFromPB(req.Config.DispatcherID)
+	switch req.ScheduleAction {
+	case heartbeatpb.ScheduleAction_Create:
+		if _, ok := cf.dispatchers[id]; ok {
+			return
+		}
+		m.newDispatcher(cf, id, req.Config.Span, req.Config.SchemaID, req.Config.StartTs, req.Config.CurrentPdTs)
+	case heartbeatpb.ScheduleAction_Remove:
+		d, ok := cf.dispatchers[id]
+		if !ok {
+			// report the dispatcher is stopped, the maintainer may resend the request
+			// if the dispatcher has been removed.
+			m.sendToMaintainer(cf.maintainerID, &heartbeatpb.HeartBeatRequest{
+				ChangefeedID: cf.id,
+				Statuses: []*heartbeatpb.TableSpanStatus{{
+					ID:              id.ToPB(),
+					ComponentStatus: heartbeatpb.ComponentState_Stopped,
+				}},
+			})
+			return
+		}
+		// the dispatcher is closed and reported in the next heartbeat.
+		d.Remove()
+	}
+}
+
+func (m *dispatcherManager) onMaintainerCloseRequest(msg *messaging.TargetMessage) {
+	req := msg.Message[0].(*heartbeatpb.MaintainerCloseRequest)
+	cfID := common.NewChangefeedIDFromPB(req.ChangefeedID)
+	if cf, ok := m.changefeeds[cfID]; ok {
+		cf.removeAll()
+		delete(m.changefeeds, cfID)
+	}
+	err := m.mc.SendCommand(messaging.NewSingleTargetMessage(msg.From,
+		messaging.MaintainerTopic, &heartbeatpb.MaintainerCloseResponse{
+			ChangefeedID: req.ChangefeedID,
+			Success:      true,
+		}))
+	if err != nil {
+		log.Debug("send close response failed", zap.Error(err))
+	}
+}
+
+// onHeartBeatResponse pushes the acks and the actions of the block events to the
+// influenced dispatchers by the dispatcher status dynamic stream, as what the heartbeat
+// collector does.
+func (m *dispatcherManager) onHeartBeatResponse(msg *messaging.TargetMessage) {
+	resp := msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+	cf, ok := m.changefeeds[common.NewChangefeedIDFromPB(resp.ChangefeedID)]
+	if !ok {
+		return
+	}
+	ds := dispatcher.GetDispatcherStatusDynamicStream()
+	for _, status := range resp.DispatcherStatuses {
+		for _, id := range cf.influencedDispatchers(status.InfluencedDispatchers) {
+			ds.Push(id, dispatcher.NewDispatcherStatusWithID(status, msg.TraceContext, id))
+		}
+	}
+	for _, state := range resp.BarrierStates {
+		if d, ok := cf.dispatchers[common.NewDispatcherIDFromPB(state.DispatcherID)]; ok {
+			d.HandleBarrierState(state)
+		}
+	}
+}
+
+// influencedDispatchers returns the dispatchers in this node influenced by the dispatcher status.
+func (cf *changefeedDispatchers) influencedDispatchers(influenced *heartbeatpb.InfluencedDispatchers) []common.DispatcherID {
+	var result []common.DispatcherID
+	switch influenced.InfluenceType {
+	case heartbeatpb.InfluenceType_Normal:
+		for _, id := range influenced.DispatcherIDs {
+			result = append(result, common.NewDispatcherIDFromPB(id))
+		}
+		return result
+	case heartbeatpb.InfluenceType_DB:
+		result = cf.schemaIDToDispatchers.GetDispatcherIDs(influenced.SchemaID)
+		if cf.tableTriggerEventDispatcher != nil {
+			result = append(result, cf.tableTriggerEventDispatcher.GetId())
+		}
+	default:
+		for id := range cf.dispatchers {
+			result = append(result, id)
+		}
+	}
+	if influenced.ExcludeDispatcherId != nil {
+		exclude := common.NewDispatcherIDFromPB(influenced.ExcludeDispatcherId)
+		result = slices.DeleteFunc(result, func(id common.DispatcherID) bool { return id == exclude })
+	}
+	return result
+}
+
+// removeAll removes all dispatchers of the changefeed, they don't receive
+// the dispatcher statuses any more.
+func (cf *changefeedDispatchers) removeAll() {
+	for _, d := range cf.dispatchers {
+		d.Remove()
+	}
+}
+
+// tick sends the events to the dispatchers, and reports the block statuses and the heartbeats.
+func (m *dispatcherManager) tick() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, cf := range m.changefeeds {
+		if cf.maintainerID == "" {
+			continue
+		}
+		for _, d := range cf.dispatchers {
+			d.feed(m.source)
+		}
+		m.sendBlockStatuses(cf)
+		m.sendHeartbeat(cf)
+	}
+}
+
+func (m *dispatcherManager) sendBlockStatuses(cf *changefeedDispatchers) {
+	var blockStatuses []*heartbeatpb.TableSpanBlockStatus
+loop:
+	for {
+		select {
+		case status := <-cf.blockStatusesChan:
+			blockStatuses = append(blockStatuses, status)
+		case err := <-cf.errCh:
+			log.Warn("dispatcher meets error",
+				zap.Stringer("node", m.self), zap.Error(err))
+		default:
+			break loop
+		}
+	}
+	if len(blockStatuses) > 0 {
+		m.sendToMaintainer(cf.maintainerID, &heartbeatpb.BlockStatusRequest{
+			ChangefeedID:  cf.id,
+			BlockStatuses: blockStatuses,
+		})
+	}
+}
+
+func (m *dispatcherManager) sendHeartbeat(cf *changefeedDispatchers) {
+	message := &heartbeatpb.HeartBeatRequest{
+		ChangefeedID:    cf.id,
+		CompeleteStatus: true,
+		Watermark:       heartbeatpb.NewMaxWatermark(),
+	}
+	info := &dispatcher.HeartBeatInfo{}
+	for id, d := range cf.dispatchers {
+		d.GetHeartBeatInfo(info)
+		if info.IsRemoving {
+			if watermark, ok := d.TryClose(); ok {
+				message.Watermark.UpdateMin(watermark)
+				message.Statuses = append(message.Statuses, &heartbeatpb.TableSpanStatus{
+					ID:              id.ToPB(),
+					ComponentStatus: heartbeatpb.ComponentState_Stopped,
+					CheckpointTs:    watermark.CheckpointTs,
+				})
+				delete(cf.dispatchers, id)
+				cf.schemaIDToDispatchers.Delete(d.GetSchemaID(), id)
+				if cf.tableTriggerEventDispatcher == d {
+					cf.tableTriggerEventDispatcher = nil
+				}
+				continue
+			}
+		}
+		message.Watermark.UpdateMin(info.Watermark)
+		message.Statuses = append(message.Statuses, &heartbeatpb.TableSpanStatus{
+			ID:              id.ToPB(),
+			ComponentStatus: info.ComponentStatus,
+			CheckpointTs:    info.Watermark.CheckpointTs,
+		})
+	}
+	if len(message.Statuses) > 0 {
+		m.sendToMaintainer(cf.maintainerID, message)
+	}
+}
+
+// close removes all dispatchers in the node when the node is stopped.
+func (m *dispatcherManager) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, cf := range m.changefeeds {
+		cf.removeAll()
+	}
+}
+
+// dispatcherCount returns the number of dispatchers of the changefeed in this node.
+func (m *dispatcherManager) dispatcherCount(changefeedID common.ChangeFeedID) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cf, ok := m.changefeeds[changefeedID]
+	if !ok {
+		return 0
+	}
+	return len(cf.dispatchers)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minicluster
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/messaging/proto"
+	"github.com/pingcap/ticdc/pkg/node"
+	"google.golang.org/grpc"
+)
+
+// Node is a cdc server in the mini-cluster, it runs a message center with
+// a grpc server listening on a random local port, and a mock dispatcher manager.
+type Node struct {
+	info *node.Info
+	mc   messaging.MessageCenter
+
+	grpcServer        *grpc.Server
+	dispatcherManager *dispatcherManager
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newNode(ctx context.Context, source *Source, sink *Sink, tickInterval time.Duration) (*Node, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	info := node.NewInfo(lis.Addr().String(), "")
+	ctx, cancel := context.WithCancel(ctx)
+	mc := messaging.NewMessageCenter(ctx, info.ID, 0, config.NewDefaultMessageCenterConfig(), nil)
+
+	n := &Node{
+		info:              info,
+		mc:                mc,
+		grpcServer:        grpc.NewServer(),
+		dispatcherManager: newDispatcherManager(info.ID, mc, source, sink, tickInterval),
+		cancel:            cancel,
+	}
+	proto.RegisterMessageCenterServer(n.grpcServer, messaging.NewMessageCenterServer(mc))
+	n.wg.Add(2)
+	go func() {
+		defer n.wg.Done()
+		_ = n.grpcServer.Serve(lis)
+	}()
+	go func() {
+		defer n.wg.Done()
+		_ = n.dispatcherManager.Run(ctx)
+	}()
+	return n, nil
+}
+
+// ID returns the id of the node.
+func (n *Node) ID() node.ID {
+	return n.info.ID
+}
+
+// DispatcherCount returns the number of dispatchers of the changefeed in this node.
+func (n *Node) DispatcherCount(changefeedID common.ChangeFeedID) int {
+	return n.dispatcherManager.dispatcherCount(changefeedID)
+}
+
+// stop stops the node without notifying the other nodes, just like the process is killed.
+func (n *Node) stop() {
+	n.cancel()
+	n.mc.Close()
+	n.grpcServer.Stop()
+	n.wg.Wait()
+	n.dispatcherManager.close()
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minicluster
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"go.uber.org/zap"
+)
+
+// WrittenEvent is a block event written to the downstream by a writer dispatcher.
+type WrittenEvent struct {
+	ChangefeedID common.ChangeFeedID
+	DispatcherID common.DispatcherID
+	TableID      int64
+	CommitTs     uint64
+	Node         node.ID
+}
+
+// Sink is the in-memory downstream shared by all nodes, it records the block events
+// written by the dispatchers, so the tests can check a ddl is written exactly once.
+type Sink struct {
+	mu     sync.Mutex
+	events []WrittenEvent
+}
+
+func (s *Sink) write(event WrittenEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+// WrittenEvents returns the block events written by the changefeed in order.
+func (s *Sink) WrittenEvents(changefeedID common.ChangeFeedID) []WrittenEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.DeleteFunc(slices.Clone(s.events), func(e WrittenEvent) bool {
+		return e.ChangefeedID != changefeedID
+	})
+}
+
+// memorySink is the sink.Sink of the dispatchers of a changefeed in a node,
+// it flushes the events at once and records the written block events to the Sink.
+type memorySink struct {
+	changefeedID common.ChangeFeedID
+	node         node.ID
+	downstream   *Sink
+}
+
+var _ sink.Sink = (*memorySink)(nil)
+
+func (s *memorySink) SinkType() common.SinkType {
+	return common.BlackHoleSinkType
+}
+
+func (s *memorySink) IsNormal() bool {
+	return true
+}
+
+func (s *memorySink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	event.PostFlush()
+}
+
+func (s *memorySink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	switch event.GetType() {
+	case commonEvent.TypeDDLEvent:
+		e := event.(*commonEvent.DDLEvent)
+		s.downstream.write(WrittenEvent{
+			ChangefeedID: s.changefeedID,
+			DispatcherID: e.DispatcherID,
+			TableID:      e.TableID,
+			CommitTs:     e.FinishedTs,
+			Node:         s.node,
+		})
+	case commonEvent.TypeSyncPointEvent:
+	default:
+		log.Panic("unknown event type", zap.Any("event", event))
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (s *memorySink) PassBlockEvent(event commonEvent.BlockEvent) {
+	event.PostFlush()
+}
+
+func (s *memorySink) AddCheckpointTs(_ uint64) {}
+
+func (s *memorySink) AuditRowCount(_ uint64) {}
+
+func (s *memorySink) ResendTableSchema(_ []int64) {}
+
+func (s *memorySink) WriteEOF() {}
+
+func (s *memorySink) SetTableSchemaStore(_ *util.TableSchemaStore) {}
+
+func (s *memorySink) Close(_ bool) {}
+
+func (s *memorySink) Run(_ context.Context) error {
+	return nil
+}
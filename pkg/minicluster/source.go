@@ -0,0 +1,136 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package minicluster
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/filter"
+)
+
+// DDL is a block event injected into the cluster by the Source.
+// Every dispatcher influenced by BlockedTables receives the DDL once the
+// resolved ts of the source reaches CommitTs.
+type DDL struct {
+	CommitTs          uint64
+	BlockedTables     *commonEvent.InfluencedTables
+	NeedDroppedTables *commonEvent.InfluencedTables
+	NeedAddedTables   []commonEvent.Table
+	UpdatedSchemas    []commonEvent.SchemaIDChange
+}
+
+// influence returns true if the dispatcher of the table receives the DDL.
+func (d *DDL) influence(schemaID, tableID int64) bool {
+	switch d.BlockedTables.InfluenceType {
+	case commonEvent.InfluenceTypeNormal:
+		return slices.Contains(d.BlockedTables.TableIDs, tableID)
+	case commonEvent.InfluenceTypeDB:
+		// the table trigger event dispatcher always receives the db level ddl.
+		return tableID == heartbeatpb.DDLSpan.TableID || d.BlockedTables.SchemaID == schemaID
+	default:
+		return true
+	}
+}
+
+// event returns the ddl event received by the dispatcher, the TableID of the event
+// is the table of the dispatcher, so the tests can tell which dispatcher writes it.
+func (d *DDL) event(dispatcherID common.DispatcherID, tableID int64) *commonEvent.DDLEvent {
+	return &commonEvent.DDLEvent{
+		DispatcherID:      dispatcherID,
+		TableID:           tableID,
+		FinishedTs:        d.CommitTs,
+		BlockedTables:     d.BlockedTables,
+		NeedDroppedTables: d.NeedDroppedTables,
+		NeedAddedTables:   d.NeedAddedTables,
+		UpdatedSchemas:    d.UpdatedSchemas,
+	}
+}
+
+// Source is the mock of the log puller and the schema store, it controls the
+// tables, the resolved ts and the ddl events seen by all dispatchers in the cluster.
+// The resolved ts only advances when the test calls AdvanceResolvedTs,
+// so the behavior of the dispatchers is deterministic.
+type Source struct {
+	mu         sync.RWMutex
+	tables     []commonEvent.Table
+	resolvedTs uint64
+	ddls       []*DDL
+}
+
+// NewSource creates a Source with the given tables and the initial resolved ts.
+func NewSource(tables []commonEvent.Table, resolvedTs uint64) *Source {
+	return &Source{
+		tables:     tables,
+		resolvedTs: resolvedTs,
+	}
+}
+
+// AdvanceResolvedTs sets the resolved ts of the source if it's larger than the current one.
+func (s *Source) AdvanceResolvedTs(ts uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ts > s.resolvedTs {
+		s.resolvedTs = ts
+	}
+}
+
+// AddDDL injects a ddl event, the commit ts must be larger than the resolved ts.
+func (s *Source) AddDDL(ddl *DDL) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ddls = append(s.ddls, ddl)
+	slices.SortFunc(s.ddls, func(a, b *DDL) int {
+		if a.CommitTs < b.CommitTs {
+			return -1
+		} else if a.CommitTs > b.CommitTs {
+			return 1
+		}
+		return 0
+	})
+}
+
+// next returns the resolved ts for the dispatcher which has received the events up to sentTs,
+// and the ddl following the resolved ts if any.
+func (s *Source) next(schemaID, tableID int64, sentTs uint64) (uint64, *DDL) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ddl := range s.ddls {
+		if ddl.CommitTs <= sentTs || !ddl.influence(schemaID, tableID) {
+			continue
+		}
+		if ddl.CommitTs > s.resolvedTs {
+			break
+		}
+		return ddl.CommitTs - 1, ddl
+	}
+	return max(sentTs, s.resolvedTs), nil
+}
+
+// schemaStore implements the schemastore.SchemaStore used by the maintainer
+// to get the initial tables of a changefeed.
+type schemaStore struct {
+	schemastore.SchemaStore
+	source *Source
+}
+
+func (s *schemaStore) GetAllPhysicalTables(_ uint64, _ filter.Filter) ([]commonEvent.Table, error) {
+	s.source.mu.RLock()
+	defer s.source.mu.RUnlock()
+	return slices.Clone(s.source.tables), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"sync/atomic"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/memory"
+)
+
+// the resources used by the dispatchers on this node, they are reported to the coordinator
+// and the maintainers with the capacity limits of the node.
+var (
+	dispatcherCount atomic.Int64
+	memoryQuota     atomic.Int64
+)
+
+// AddDispatcherCount adds delta to the number of the table dispatchers on this node.
+func AddDispatcherCount(delta int64) {
+	dispatcherCount.Add(delta)
+}
+
+// AddMemoryQuota adds delta to the sum of the memory quotas of the changefeeds
+// which have dispatchers on this node.
+func AddMemoryQuota(delta int64) {
+	memoryQuota.Add(delta)
+}
+
+// GetLocalCapacity returns the capacity limits of this node and the resources used by the dispatchers.
+func GetLocalCapacity() *heartbeatpb.NodeCapacity {
+	cfg := config.GetGlobalServerConfig()
+	return &heartbeatpb.NodeCapacity{
+		MaxMaintainers:  int64(cfg.MaxMaintainers),
+		MaxDispatchers:  int64(cfg.MaxDispatchers),
+		MemoryBudget:    cfg.MemoryBudget,
+		DispatcherCount: max(dispatcherCount.Load(), 0),
+		MemoryQuota:     uint64(max(memoryQuota.Load(), 0)),
+	}
+}
+
+// GetLocalLoad returns the memory in use and the backlog of the dispatchers on this node.
+func GetLocalLoad() *heartbeatpb.NodeLoad {
+	registry := memory.GetGlobalRegistry()
+	load := &heartbeatpb.NodeLoad{MemoryUsage: uint64(max(registry.Total(), 0))}
+	for _, account := range registry.Accounts() {
+		if account.Component() == memory.ComponentDispatcherQueue {
+			load.DispatcherBacklog = uint64(max(account.Used(), 0))
+		}
+	}
+	return load
+}
@@ -15,12 +15,15 @@ package node
 
 import (
 	"encoding/json"
+	"runtime"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/version"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tiflow/cdc/model"
 )
 
@@ -50,6 +53,14 @@ type Info struct {
 
 	// Epoch represents how many times the node has been restarted.
 	Epoch uint64 `json:"epoch"`
+
+	// CPUCores is the number of the cpu cores available to the process.
+	CPUCores int `json:"cpu-cores,omitempty"`
+	// MemoryTotal is the memory in bytes available to the process, it's the memory
+	// limit of the container if the node runs in a container.
+	MemoryTotal uint64 `json:"memory-total,omitempty"`
+	// Labels are the deployment labels of the node, such as zone and host.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func NewInfo(addr string, deployPath string) *Info {
@@ -60,6 +71,9 @@ func NewInfo(addr string, deployPath string) *Info {
 		GitHash:        version.GitHash,
 		DeployPath:     deployPath,
 		StartTimestamp: time.Now().Unix(),
+		CPUCores:       runtime.GOMAXPROCS(0),
+		MemoryTotal:    memory.GetMemTotalIgnoreErr(),
+		Labels:         config.GetGlobalServerConfig().Labels,
 	}
 }
 
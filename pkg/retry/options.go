@@ -39,6 +39,8 @@ type retryOptions struct {
 	backoffBaseInMs    float64
 	backoffCapInMs     float64
 	isRetryable        IsRetryable
+	// classifier is nil if the errors are not retried by their classes.
+	classifier ErrorClassifier
 }
 
 func newRetryOptions() *retryOptions {
@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"sync"
+	"time"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// BackoffPolicy is the backoff of retrying the errors of a class. The backoff
+// before the n-th retry grows exponentially from BaseDelay, capped by MaxDelay.
+type BackoffPolicy struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+var (
+	policyMu sync.RWMutex
+	// The transient errors use the default backoff of Do, and the others
+	// use the backoff of retrying the downstream.
+	backoffPolicies = [cerror.RetryClassCount]BackoffPolicy{
+		cerror.RetryClassTransient: {
+			BaseDelay: defaultBackoffBaseInMs * time.Millisecond,
+			MaxDelay:  defaultBackoffCapInMs * time.Millisecond,
+		},
+		cerror.RetryClassBackoff: {BaseDelay: 500 * time.Millisecond, MaxDelay: time.Minute},
+	}
+)
+
+// SetBackoffPolicy sets the backoff policy of the error class globally,
+// it is ignored for the fatal errors as they are never retried.
+func SetBackoffPolicy(class cerror.RetryClass, policy BackoffPolicy) {
+	if class == cerror.RetryClassFatal || class < 0 || class >= cerror.RetryClassCount {
+		return
+	}
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	backoffPolicies[class] = policy
+}
+
+// GetBackoffPolicy returns the backoff policy of the error class.
+func GetBackoffPolicy(class cerror.RetryClass) BackoffPolicy {
+	if class < 0 || class >= cerror.RetryClassCount {
+		return BackoffPolicy{}
+	}
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return backoffPolicies[class]
+}
+
+// ErrorClassifier returns the class of an error.
+type ErrorClassifier func(error) cerror.RetryClass
+
+// backoffPolicy returns the backoff policy of the error, it returns false if the error is fatal.
+func (f ErrorClassifier) backoffPolicy(err error) (BackoffPolicy, bool) {
+	class := f(err)
+	if class == cerror.RetryClassFatal {
+		return BackoffPolicy{}, false
+	}
+	return GetBackoffPolicy(class), true
+}
+
+// WithErrorClassifier configures the errors to be retried by the backoff policies of
+// their classes, the fatal errors are not retried. It overrides WithIsRetryableErr and
+// the backoff delays, pass cerror.RetryClassOf if there is no special error.
+func WithErrorClassifier(f ErrorClassifier) Option {
+	return func(o *retryOptions) {
+		o.classifier = f
+	}
+}
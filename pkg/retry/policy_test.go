@@ -0,0 +1,89 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryClassOf(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, cerror.RetryClassFatal, cerror.RetryClassOf(errors.Annotate(context.Canceled, "test")))
+	require.Equal(t, cerror.RetryClassFatal, cerror.RetryClassOf(cerror.ErrSinkURIInvalid.GenWithStackByArgs()))
+	require.Equal(t, cerror.RetryClassFatal, cerror.RetryClassOf(cerror.ErrSnapshotLostByGC.GenWithStackByArgs()))
+	require.Equal(t, cerror.RetryClassTransient, cerror.RetryClassOf(cerror.ErrEtcdTryAgain.GenWithStackByArgs()))
+	require.Equal(t, cerror.RetryClassBackoff, cerror.RetryClassOf(errors.New("test")))
+	require.Equal(t, cerror.RetryClassBackoff,
+		cerror.RetryClassOf(cerror.WrapError(cerror.ErrMySQLTxnError, errors.New("test"))))
+}
+
+func TestRetryClassOfCode(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, cerror.RetryClassFatal, cerror.RetryClassOfCode(cerror.ErrSnapshotLostByGC.RFCCode(), ""))
+	require.Equal(t, cerror.RetryClassFatal, cerror.RetryClassOfCode(cerror.ErrChangefeedUnretryable.RFCCode(), ""))
+	// the code of the error failing the changefeed is only in the message
+	err := cerror.ErrSinkURIInvalid.GenWithStackByArgs()
+	require.Equal(t, cerror.RetryClassFatal, cerror.RetryClassOfCode("", err.Error()))
+	require.Equal(t, cerror.RetryClassTransient, cerror.RetryClassOfCode(cerror.ErrEtcdTryAgain.RFCCode(), ""))
+	require.Equal(t, cerror.RetryClassBackoff, cerror.RetryClassOfCode(cerror.ErrMySQLTxnError.RFCCode(), "test"))
+	require.Equal(t, cerror.RetryClassBackoff, cerror.RetryClassOfCode("", "test"))
+}
+
+func TestDoWithErrorClassifier(t *testing.T) {
+	t.Parallel()
+
+	var callCount int
+	f := func() error {
+		callCount++
+		if callCount == 2 {
+			return errors.Annotate(context.Canceled, "test")
+		}
+		return cerror.ErrEtcdTryAgain.GenWithStackByArgs()
+	}
+	start := time.Now()
+	err := Do(context.Background(), f, WithMaxTries(3), WithErrorClassifier(cerror.RetryClassOf))
+	// the fatal error is not retried
+	require.Equal(t, context.Canceled, errors.Cause(err))
+	require.Equal(t, 2, callCount)
+	// the transient error is retried with the short backoff
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestBackoffPolicy(t *testing.T) {
+	origin := GetBackoffPolicy(cerror.RetryClassBackoff)
+	defer SetBackoffPolicy(cerror.RetryClassBackoff, origin)
+
+	policy := BackoffPolicy{BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	SetBackoffPolicy(cerror.RetryClassBackoff, policy)
+	require.Equal(t, policy, GetBackoffPolicy(cerror.RetryClassBackoff))
+	// the fatal errors have no backoff
+	SetBackoffPolicy(cerror.RetryClassFatal, policy)
+	require.Equal(t, BackoffPolicy{}, GetBackoffPolicy(cerror.RetryClassFatal))
+
+	var callCount int
+	err := Do(context.Background(), func() error {
+		callCount++
+		return errors.New("test")
+	}, WithMaxTries(5), WithErrorClassifier(cerror.RetryClassOf))
+	require.Error(t, err)
+	require.Equal(t, 5, callCount)
+}
@@ -59,7 +59,15 @@ func run(ctx context.Context, op Operation, retryOption *retryOptions) error {
 			return nil
 		}
 
-		if !retryOption.isRetryable(err) {
+		backoffBaseInMs, backoffCapInMs := retryOption.backoffBaseInMs, retryOption.backoffCapInMs
+		if retryOption.classifier != nil {
+			policy, ok := retryOption.classifier.backoffPolicy(err)
+			if !ok {
+				return err
+			}
+			backoffBaseInMs = float64(policy.BaseDelay.Milliseconds())
+			backoffCapInMs = float64(policy.MaxDelay.Milliseconds())
+		} else if !retryOption.isRetryable(err) {
 			return err
 		}
 
@@ -77,7 +85,7 @@ func run(ctx context.Context, op Operation, retryOption *retryOptions) error {
 			}
 		}
 
-		backOff = getBackoffInMs(retryOption.backoffBaseInMs, retryOption.backoffCapInMs, float64(try))
+		backOff = getBackoffInMs(backoffBaseInMs, backoffCapInMs, float64(try))
 		if t == nil {
 			t = time.NewTimer(backOff)
 			defer t.Stop()
@@ -0,0 +1,151 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"sort"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"go.uber.org/zap"
+)
+
+// AntiAffinitySchedule schedules the absent tasks of a group, such as the spans of a split table,
+// to the least loaded node which holds fewer than maxPerNode tasks of the group. If all nodes
+// reach the limit, the node holding the fewest tasks of the group is chosen.
+// nodeTasks is the task size of all groups per node, groupTasks is the task size of the group per node.
+func AntiAffinitySchedule[T replica.ReplicationID, R replica.Replication[T]](
+	availableSize int,
+	absent []R,
+	nodeTasks map[node.ID]int,
+	groupTasks map[node.ID]int,
+	maxPerNode int,
+	preferred map[node.ID]*node.Info,
+	schedule func(R, node.ID) bool,
+) {
+	if len(nodeTasks) == 0 {
+		log.Warn("scheduler: no node available, skip")
+		return
+	}
+	nodes := sortedNodes(nodeTasks, preferred)
+	taskSize := 0
+	for _, r := range absent {
+		target := antiAffinityTarget(nodes, nodeTasks, groupTasks, maxPerNode)
+		if schedule(r, target) {
+			nodeTasks[target]++
+			groupTasks[target]++
+			taskSize++
+		}
+		if taskSize >= availableSize {
+			break
+		}
+	}
+}
+
+// AntiAffinityBalance moves the replicating tasks of a group from the nodes holding more than
+// maxPerNode tasks of the group to the least loaded nodes under the limit.
+// It returns the number of the moved tasks.
+func AntiAffinityBalance[T replica.ReplicationID, R replica.Replication[T]](
+	batchSize int,
+	activeNodes map[node.ID]*node.Info,
+	replicating []R,
+	nodeTasks map[node.ID]int,
+	maxPerNode int,
+	move func(R, node.ID) bool,
+) (movedSize int) {
+	groupTasks := make(map[node.ID][]R, len(activeNodes))
+	for _, r := range replicating {
+		groupTasks[r.GetNodeID()] = append(groupTasks[r.GetNodeID()], r)
+	}
+	groupSize := make(map[node.ID]int, len(activeNodes))
+	for id := range activeNodes {
+		groupSize[id] = len(groupTasks[id])
+		if _, ok := nodeTasks[id]; !ok {
+			nodeTasks[id] = 0
+		}
+	}
+	candidates := make([]node.ID, 0, len(activeNodes))
+	for _, id := range sortedNodes(nodeTasks, nil) {
+		if _, ok := activeNodes[id]; ok {
+			candidates = append(candidates, id)
+		}
+	}
+	for _, source := range candidates {
+		for groupSize[source] > maxPerNode && movedSize < batchSize {
+			target := antiAffinityTarget(candidates, nodeTasks, groupSize, maxPerNode)
+			if groupSize[target] >= maxPerNode {
+				// all nodes reach the limit
+				return
+			}
+			tasks := groupTasks[source]
+			if !move(tasks[0], target) {
+				break
+			}
+			groupTasks[source] = tasks[1:]
+			groupSize[source]--
+			groupSize[target]++
+			nodeTasks[source]--
+			nodeTasks[target]++
+			movedSize++
+		}
+	}
+	if movedSize > 0 {
+		log.Info("scheduler: anti-affinity balance done",
+			zap.Int("movedSize", movedSize),
+			zap.Int("maxPerNode", maxPerNode))
+	}
+	return movedSize
+}
+
+// antiAffinityTarget returns the least loaded node under the limit,
+// or the node holding the fewest tasks of the group if all nodes reach the limit.
+func antiAffinityTarget(nodes []node.ID, nodeTasks, groupTasks map[node.ID]int, maxPerNode int) node.ID {
+	var target node.ID
+	for _, id := range nodes {
+		if groupTasks[id] >= maxPerNode {
+			continue
+		}
+		if target == "" || nodeTasks[id] < nodeTasks[target] {
+			target = id
+		}
+	}
+	if target != "" {
+		return target
+	}
+	for _, id := range nodes {
+		if target == "" || groupTasks[id] < groupTasks[target] ||
+			(groupTasks[id] == groupTasks[target] && nodeTasks[id] < nodeTasks[target]) {
+			target = id
+		}
+	}
+	return target
+}
+
+// sortedNodes returns the nodes sorted by the ids, the preferred nodes are put in front,
+// so they are chosen first by the schedulers if the nodes are loaded equally.
+func sortedNodes(nodeTasks map[node.ID]int, preferred map[node.ID]*node.Info) []node.ID {
+	nodes := make([]node.ID, 0, len(nodeTasks))
+	for id := range nodeTasks {
+		nodes = append(nodes, id)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		iPreferred, jPreferred := preferred[nodes[i]] != nil, preferred[nodes[j]] != nil
+		if iPreferred != jPreferred {
+			return iPreferred
+		}
+		return nodes[i] < nodes[j]
+	})
+	return nodes
+}
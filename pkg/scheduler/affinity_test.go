@@ -0,0 +1,69 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAntiAffinitySchedule(t *testing.T) {
+	absent := make([]*testTask, 0, 6)
+	for i := 0; i < 6; i++ {
+		absent = append(absent, newTestTask(fmt.Sprintf("span%d", i), "", 0))
+	}
+	schedule := func(r *testTask, target node.ID) bool {
+		r.SetNodeID(target)
+		return true
+	}
+	// node1 is the least loaded, but it can hold at most 2 spans of the table
+	nodeTasks := map[node.ID]int{"node1": 0, "node2": 10, "node3": 20}
+	groupTasks := map[node.ID]int{}
+	AntiAffinitySchedule(10, absent, nodeTasks, groupTasks, 2, nil, schedule)
+	require.Equal(t, map[node.ID]int{"node1": 2, "node2": 2, "node3": 2}, groupTasks)
+
+	// all nodes reach the limit, the spans are spread evenly
+	more := []*testTask{newTestTask("span6", "", 0), newTestTask("span7", "", 0)}
+	AntiAffinitySchedule(10, more, nodeTasks, groupTasks, 2, nil, schedule)
+	require.Equal(t, node.ID("node1"), more[0].nodeID)
+	require.Equal(t, node.ID("node2"), more[1].nodeID)
+}
+
+func TestAntiAffinityBalance(t *testing.T) {
+	nodes := map[node.ID]*node.Info{"node1": {ID: "node1"}, "node2": {ID: "node2"}, "node3": {ID: "node3"}}
+	replicating := make([]*testTask, 0, 5)
+	for i := 0; i < 4; i++ {
+		replicating = append(replicating, newTestTask(fmt.Sprintf("span%d", i), "node1", 0))
+	}
+	replicating = append(replicating, newTestTask("span4", "node2", 0))
+	move := func(r *testTask, target node.ID) bool {
+		r.SetNodeID(target)
+		return true
+	}
+	nodeTasks := map[node.ID]int{"node1": 4, "node2": 1, "node3": 10}
+	moved := AntiAffinityBalance(10, nodes, replicating, nodeTasks, 2, move)
+	require.Equal(t, 2, moved)
+	count := make(map[node.ID]int)
+	for _, r := range replicating {
+		count[r.nodeID]++
+	}
+	// the least loaded node2 takes the spans until it reaches the limit
+	require.Equal(t, map[node.ID]int{"node1": 2, "node2": 2, "node3": 1}, count)
+
+	// no node exceeds the limit
+	require.Equal(t, 0, AntiAffinityBalance(10, nodes, replicating, nodeTasks, 2, move))
+}
@@ -16,8 +16,11 @@ package scheduler
 import (
 	"math"
 	"math/rand"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -30,16 +33,20 @@ import (
 
 // balanceScheduler is used to check the balance status of all spans among all nodes
 type balanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]] struct {
+	// mu serializes the periodic balance and the balance triggered on demand.
+	mu sync.Mutex
+
 	id        string
 	batchSize int
 
 	operatorController operator.Controller[T, S]
 	db                 replica.ScheduleGroup[T, R]
 	nodeManager        *watcher.NodeManager
+	clock              clock.Clock
 
 	random               *rand.Rand
 	lastRebalanceTime    time.Time
-	checkBalanceInterval time.Duration
+	checkBalanceInterval *BalanceInterval
 	// forceBalance forces the scheduler to produce schedule tasks regardless of
 	// `checkBalanceInterval`.
 	// It is set to true when the last time `Schedule` produces some tasks,
@@ -47,8 +54,34 @@ type balanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R re
 	// `Schedule`.
 	// It speeds up rebalance.
 	forceBalance bool
+	// maxTasksPerNodeInGroup is the max task size of a table group on one node, 0 means no limit.
+	maxTasksPerNodeInGroup int
+	// newCapacity creates the capacity of the nodes, the tasks are not moved to the nodes which are full.
+	newCapacity func() NodeCapacity[R]
+	// nodeLoad returns the extra load of the nodes in the unit of tasks, the tasks are balanced
+	// by the task size plus the extra load of the nodes. It's nil if not set.
+	nodeLoad func() map[node.ID]int
 
 	newMoveOperator func(r R, source, target node.ID) operator.Operator[T, S]
+
+	// planned records the moves of the balance triggered on demand, it's nil otherwise.
+	planned []PlannedMove[R]
+	// dryRun is true if the moves are only planned without adding the operators.
+	dryRun bool
+}
+
+// PlannedMove is a task move planned by the balance scheduler.
+type PlannedMove[R any] struct {
+	Task   R
+	Source node.ID
+	Target node.ID
+}
+
+// Rebalancer is implemented by the balance schedulers which can run the balance on demand.
+type Rebalancer[R any] interface {
+	// Rebalance runs a round of the balance immediately and returns the moves of it,
+	// the operators are not added if dryRun is true.
+	Rebalance(dryRun bool) []PlannedMove[R]
 }
 
 func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
@@ -64,50 +97,186 @@ func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R
 		operatorController:   oc,
 		db:                   db,
 		nodeManager:          nodeManager,
-		checkBalanceInterval: balanceInterval,
+		clock:                clock.New(),
+		checkBalanceInterval: NewFixedBalanceInterval(balanceInterval),
 		lastRebalanceTime:    time.Now(),
 		newMoveOperator:      newMoveOperator,
 	}
 }
 
 func (s *balanceScheduler[T, S, R]) Execute() time.Time {
-	if !s.forceBalance && time.Since(s.lastRebalanceTime) < s.checkBalanceInterval {
-		return s.lastRebalanceTime.Add(s.checkBalanceInterval)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	aliveNodes := s.nodeManager.GetSchedulableNodes()
+	imbalance := func() int {
+		return CheckBalanceStatus(withLoad(s.db.GetTaskSizePerNode(), s.getNodeLoad(), aliveNodes), aliveNodes)
+	}
+	if !s.forceBalance && !s.checkBalanceInterval.Due(s.lastRebalanceTime, now, aliveNodes, imbalance) {
+		return s.checkBalanceInterval.NextCheckTime(s.lastRebalanceTime, now)
 	}
-	now := time.Now()
 
 	failpoint.Inject("StopBalanceScheduler", func() time.Time {
-		return now.Add(s.checkBalanceInterval)
+		return s.checkBalanceInterval.NextCheckTime(now, now)
 	})
 
 	if s.operatorController.OperatorSize() > 0 || s.db.GetAbsentSize() > 0 {
 		// not in stable schedule state, skip balance
-		return now.Add(s.checkBalanceInterval)
+		return s.checkBalanceInterval.NextCheckTime(now, now)
 	}
 
-	nodes := s.nodeManager.GetAliveNodes()
-	moved := s.schedulerGroup(nodes)
-	if moved == 0 {
-		// all groups are balanced, safe to do the global balance
-		moved = s.schedulerGlobal(nodes)
+	nodes, ok := s.balanceNodes(aliveNodes)
+	if !ok {
+		return s.checkBalanceInterval.NextCheckTime(now, now)
 	}
+	observed := imbalance()
+	moved := s.balance(nodes)
 
 	s.forceBalance = moved >= s.batchSize
 	s.lastRebalanceTime = now
-	return now.Add(s.checkBalanceInterval)
+	s.checkBalanceInterval.Observe(aliveNodes, observed, moved)
+	return s.checkBalanceInterval.NextCheckTime(now, now)
+}
+
+// Rebalance implements Rebalancer.
+func (s *balanceScheduler[T, S, R]) Rebalance(dryRun bool) []PlannedMove[R] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes, ok := s.balanceNodes(s.nodeManager.GetSchedulableNodes())
+	if !ok {
+		return nil
+	}
+	s.planned, s.dryRun = make([]PlannedMove[R], 0), dryRun
+	defer func() {
+		s.planned, s.dryRun = nil, false
+	}()
+	moved := s.balance(nodes)
+	if !dryRun {
+		s.forceBalance = moved >= s.batchSize
+		s.lastRebalanceTime = s.clock.Now()
+	}
+	log.Info("scheduler: finish the balance on demand",
+		zap.String("id", s.id), zap.Bool("dryRun", dryRun), zap.Int("moved", moved))
+	return s.planned
+}
+
+// balanceNodes returns the nodes the tasks can be moved among, it's false if the balance should be skipped.
+func (s *balanceScheduler[T, S, R]) balanceNodes(nodes map[node.ID]*node.Info) (map[node.ID]*node.Info, bool) {
+	if len(NewestVersionNodes(nodes)) != len(nodes) {
+		// the nodes run different versions during a rolling upgrade, skip the balance since
+		// the tasks on the old nodes are moved anyway when they are restarted
+		return nil, false
+	}
+	if s.newCapacity != nil {
+		nodes = availableNodes(nodes, s.newCapacity())
+		if len(nodes) == 0 {
+			return nil, false
+		}
+	}
+	return nodes, true
 }
 
-func (s *balanceScheduler[T, S, R]) schedulerGroup(nodes map[node.ID]*node.Info) int {
+// balance moves the tasks among the nodes and returns the number of the moved tasks.
+func (s *balanceScheduler[T, S, R]) balance(nodes map[node.ID]*node.Info) int {
+	load := s.getNodeLoad()
+	moved := s.schedulerGroup(nodes, load)
+	if moved == 0 {
+		// all groups are balanced, safe to do the global balance
+		moved = s.schedulerGlobal(nodes, load)
+	}
+	return moved
+}
+
+// SetBalanceInterval replaces the fixed interval of the balance check,
+// the adaptive interval checks more frequently when the cluster changes.
+func (s *balanceScheduler[T, S, R]) SetBalanceInterval(interval *BalanceInterval) {
+	s.checkBalanceInterval = interval
+}
+
+// SetClock replaces the clock and the random source of the scheduler,
+// so the scheduler simulation can run the balance deterministically.
+func (s *balanceScheduler[T, S, R]) SetClock(clk clock.Clock, random *rand.Rand) {
+	s.clock = clk
+	s.random = random
+	s.lastRebalanceTime = clk.Now()
+}
+
+// SetMaxTasksPerNodeInGroup sets the max task size of a table group on one node,
+// the tasks on the nodes exceeding the limit are moved to the other nodes.
+func (s *balanceScheduler[T, S, R]) SetMaxTasksPerNodeInGroup(max int) {
+	s.maxTasksPerNodeInGroup = max
+}
+
+// SetNodeCapacity sets the function to create the capacity of the nodes,
+// the nodes which can't take any more tasks are excluded from the balance.
+func (s *balanceScheduler[T, S, R]) SetNodeCapacity(newCapacity func() NodeCapacity[R]) {
+	s.newCapacity = newCapacity
+}
+
+// SetNodeLoad sets the function to get the extra load of the nodes in the unit of tasks,
+// the tasks are moved away from the loaded nodes, the same as the basic scheduler does.
+func (s *balanceScheduler[T, S, R]) SetNodeLoad(nodeLoad func() map[node.ID]int) {
+	s.nodeLoad = nodeLoad
+}
+
+func (s *balanceScheduler[T, S, R]) getNodeLoad() map[node.ID]int {
+	if s.nodeLoad == nil {
+		return nil
+	}
+	return s.nodeLoad()
+}
+
+// withLoad adds the extra load of the nodes to their task sizes.
+func withLoad(sizes map[node.ID]int, load map[node.ID]int, nodes map[node.ID]*node.Info) map[node.ID]int {
+	for id := range nodes {
+		if extra := load[id]; extra > 0 {
+			sizes[id] += extra
+		}
+	}
+	return sizes
+}
+
+// groupLoad splits the extra load of the nodes to a group by the share of its tasks in all
+// the tasks, so the groups are balanced consistently with the global balance.
+func groupLoad(load map[node.ID]int, groupSize, totalSize int) map[node.ID]int {
+	if len(load) == 0 || totalSize == 0 {
+		return nil
+	}
+	res := make(map[node.ID]int, len(load))
+	for id, extra := range load {
+		res[id] = int(math.Round(float64(extra) * float64(groupSize) / float64(totalSize)))
+	}
+	return res
+}
+
+func (s *balanceScheduler[T, S, R]) schedulerGroup(nodes map[node.ID]*node.Info, load map[node.ID]int) int {
 	availableSize, totalMoved := s.batchSize, 0
+	totalSize := 0
+	for _, size := range s.db.GetTaskSizePerNode() {
+		totalSize += size
+	}
 	for _, group := range s.db.GetGroups() {
+		if s.maxTasksPerNodeInGroup > 0 && replica.GetGroupType(group) == replica.GroupTable {
+			moveSize := AntiAffinityBalance(availableSize, nodes, s.db.GetReplicatingByGroup(group),
+				s.db.GetTaskSizePerNode(), s.maxTasksPerNodeInGroup, s.doMove)
+			if moveSize > 0 {
+				totalMoved += moveSize
+				if totalMoved >= s.batchSize {
+					break
+				}
+				availableSize -= moveSize
+				continue
+			}
+		}
+		replicas := s.db.GetReplicatingByGroup(group)
+		extra := groupLoad(load, len(replicas), totalSize)
 		// fast path, check the balance status
-		moveSize := CheckBalanceStatus(s.db.GetTaskSizePerNodeByGroup(group), nodes)
+		moveSize := CheckBalanceStatus(withLoad(s.db.GetTaskSizePerNodeByGroup(group), extra, nodes), nodes)
 		if moveSize <= 0 {
 			// no need to do the balance, skip
 			continue
 		}
-		replicas := s.db.GetReplicatingByGroup(group)
-		moveSize = Balance(availableSize, s.random, nodes, replicas, s.doMove)
+		moveSize = Balance(availableSize, s.random, nodes, replicas, extra, s.doMove)
 		totalMoved += moveSize
 		if totalMoved >= s.batchSize {
 			break
@@ -118,10 +287,10 @@ func (s *balanceScheduler[T, S, R]) schedulerGroup(nodes map[node.ID]*node.Info)
 }
 
 // TODO: refactor and simplify the implementation and limit max group size
-func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info) int {
+func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info, load map[node.ID]int) int {
 	var zero R
 	// fast path, check the balance status
-	moveSize := CheckBalanceStatus(s.db.GetTaskSizePerNode(), nodes)
+	moveSize := CheckBalanceStatus(withLoad(s.db.GetTaskSizePerNode(), load, nodes), nodes)
 	if moveSize <= 0 {
 		// no need to do the balance, skip
 		return 0
@@ -143,6 +312,12 @@ func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info
 			}
 		}
 	}
+	for id := range nodes {
+		if extra := load[id]; extra > 0 {
+			totalTasks += extra
+			sizePerNode[id] += extra
+		}
+	}
 	lowerLimitPerNode := int(math.Floor(float64(totalTasks) / float64(len(nodes))))
 
 	// fast path check again
@@ -152,10 +327,18 @@ func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info
 		return 0
 	}
 
+	groups := make([]replica.GroupID, 0, len(groupNodetasks))
+	for gid := range groupNodetasks {
+		groups = append(groups, gid)
+	}
+	slices.Sort(groups)
+
 	moved := 0
-	for _, nodeTasks := range groupNodetasks {
+	for _, gid := range groups {
+		nodeTasks := groupNodetasks[gid]
 		availableNodes, victims, nextVictim := []node.ID{}, []node.ID{}, 0
-		for id, task := range nodeTasks {
+		for _, id := range sortedNodeIDs(nodeTasks) {
+			task := nodeTasks[id]
 			if task != zero && sizePerNode[id] > lowerLimitPerNode {
 				victims = append(victims, id)
 			} else if task == zero && sizePerNode[id] < lowerLimitPerNode {
@@ -186,14 +369,33 @@ func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info
 }
 
 func (s *balanceScheduler[T, S, R]) doMove(replication R, id node.ID) bool {
-	op := s.newMoveOperator(replication, replication.GetNodeID(), id)
-	return s.operatorController.AddOperator(op)
+	move := PlannedMove[R]{Task: replication, Source: replication.GetNodeID(), Target: id}
+	if !s.dryRun {
+		op := s.newMoveOperator(replication, move.Source, id)
+		if !s.operatorController.AddOperator(op) {
+			return false
+		}
+	}
+	if s.planned != nil {
+		s.planned = append(s.planned, move)
+	}
+	return true
 }
 
 func (s *balanceScheduler[T, S, R]) Name() string {
 	return BalanceScheduler
 }
 
+// sortedNodeIDs returns the node ids of the map in ascending order.
+func sortedNodeIDs[V any](m map[node.ID]V) []node.ID {
+	ids := make([]node.ID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 // CheckBalanceStatus checks the dispatcher scheduling balance status
 // returns the table size need to be moved
 func CheckBalanceStatus(nodeTaskSize map[node.ID]int, allNodes map[node.ID]*node.Info) int {
@@ -219,12 +421,13 @@ func CheckBalanceStatus(nodeTaskSize map[node.ID]int, allNodes map[node.ID]*node
 	return moveSize
 }
 
-// Balance balances the running task by task size per node
+// Balance balances the running task by task size per node, the extra load of the
+// nodes is added to their task sizes, it can be nil.
 func Balance[T replica.ReplicationID, R replica.Replication[T]](
 	// id string,
 	batchSize int, random *rand.Rand,
 	activeNodes map[node.ID]*node.Info,
-	replicating []R, move func(R, node.ID) bool,
+	replicating []R, extraLoad map[node.ID]int, move func(R, node.ID) bool,
 ) (movedSize int) {
 	nodeTasks := make(map[node.ID][]R)
 	for _, task := range replicating {
@@ -245,6 +448,9 @@ func Balance[T replica.ReplicationID, R replica.Replication[T]](
 	}
 
 	totalSize := len(replicating)
+	for nodeID := range nodeTasks {
+		totalSize += extraLoad[nodeID]
+	}
 	lowerLimitPerCapture := int(math.Floor(float64(totalSize) / float64(len(nodeTasks))))
 	minPriorityQueue := priorityQueue[T, R]{
 		h:    heap.NewHeap[*item[T, R]](),
@@ -257,9 +463,17 @@ func Balance[T replica.ReplicationID, R replica.Replication[T]](
 		rand: random,
 	}
 	totalMoveSize := 0
-	for nodeID, tasks := range nodeTasks {
-		tableNum2Add := lowerLimitPerCapture - len(tasks)
+	// the random source is consumed in the order of the node ids, so the balance is
+	// reproducible with a seeded random source
+	for _, nodeID := range sortedNodeIDs(nodeTasks) {
+		tasks := nodeTasks[nodeID]
+		load := len(tasks) + extraLoad[nodeID]
+		tableNum2Add := lowerLimitPerCapture - load
 		if tableNum2Add <= 0 {
+			if len(tasks) == 0 {
+				// the node is only loaded by the others, nothing to move
+				continue
+			}
 			// Complexity note: Shuffle has O(n), where `n` is the number of tables.
 			// Also, during a single call of `Schedule`, Shuffle can be called at most
 			// `c` times, where `c` is the number of captures (RiCDC nodes).
@@ -269,10 +483,10 @@ func Balance[T replica.ReplicationID, R replica.Replication[T]](
 			random.Shuffle(len(tasks), func(i, j int) {
 				tasks[i], tasks[j] = tasks[j], tasks[i]
 			})
-			maxPriorityQueue.InitItem(nodeID, len(tasks), tasks)
+			maxPriorityQueue.InitItem(nodeID, load, tasks)
 			continue
 		} else {
-			minPriorityQueue.InitItem(nodeID, len(tasks), nil)
+			minPriorityQueue.InitItem(nodeID, load, nil)
 			totalMoveSize += tableNum2Add
 		}
 	}
@@ -287,7 +501,11 @@ func Balance[T replica.ReplicationID, R replica.Replication[T]](
 			// the minimum workload has reached the lower limit
 			break
 		}
-		victim, _ := maxPriorityQueue.PeekTop()
+		victim, ok := maxPriorityQueue.PeekTop()
+		if !ok || victim.Load-target.Load <= 1 {
+			// moving more tasks doesn't make the nodes more balanced
+			break
+		}
 		task := victim.Tasks[0]
 		if move(task, target.Node) {
 			// update the task size priority queue
@@ -301,7 +519,11 @@ func Balance[T replica.ReplicationID, R replica.Replication[T]](
 		}
 
 		minPriorityQueue.AddOrUpdate(target)
-		maxPriorityQueue.AddOrUpdate(victim)
+		if len(victim.Tasks) == 0 {
+			maxPriorityQueue.h.Remove(victim)
+		} else {
+			maxPriorityQueue.AddOrUpdate(victim)
+		}
 	}
 
 	log.Info("scheduler: balance done",
@@ -0,0 +1,135 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// BalanceInterval decides when the balance scheduler checks the balance status.
+// If the max interval is larger than the min one, the interval is adaptive: it backs
+// off while the cluster is stable, and is tightened to the min interval as soon as the
+// node membership or the imbalance of the tasks changes. Otherwise, the interval is fixed.
+// The changes reported out of the balance check, like the heartbeats, are marked by MarkChanged.
+type BalanceInterval struct {
+	mu sync.Mutex
+
+	minInterval time.Duration
+	maxInterval time.Duration
+	current     time.Duration
+
+	// nodes and imbalance are observed in the last balance check.
+	nodes     map[node.ID]struct{}
+	imbalance int
+	// marked is set by MarkChanged and cleared by the next balance check.
+	marked bool
+}
+
+// NewBalanceInterval creates an adaptive balance interval between the min and max intervals.
+func NewBalanceInterval(minInterval, maxInterval time.Duration) *BalanceInterval {
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return &BalanceInterval{
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		current:     minInterval,
+	}
+}
+
+// NewFixedBalanceInterval creates a balance interval which never changes.
+func NewFixedBalanceInterval(interval time.Duration) *BalanceInterval {
+	return NewBalanceInterval(interval, interval)
+}
+
+// Get returns the current interval.
+func (b *BalanceInterval) Get() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+// MarkChanged marks the cluster as changed, for example, a heartbeat reports that the
+// tasks on a node are started or stopped. The next check is due and the interval is
+// tightened to the min one. It's a no-op for the fixed interval.
+func (b *BalanceInterval) MarkChanged() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.adaptive() {
+		b.marked = true
+		b.current = b.minInterval
+	}
+}
+
+func (b *BalanceInterval) adaptive() bool {
+	return b.maxInterval > b.minInterval
+}
+
+// Due returns true if the balance check should run now, that is the current interval
+// has elapsed since the last check, or the cluster has changed since then.
+// imbalance is the number of tasks need to be moved to balance the nodes.
+func (b *BalanceInterval) Due(last, now time.Time, nodes map[node.ID]*node.Info, imbalance func() int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Sub(last) >= b.current || b.marked {
+		return true
+	}
+	return b.adaptive() && b.changed(nodes, imbalance())
+}
+
+// NextCheckTime returns the time to call Due again. The adaptive interval looks for
+// the cluster changes every min interval before the current interval elapses.
+func (b *BalanceInterval) NextCheckTime(last, now time.Time) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	next := last.Add(b.current)
+	if b.adaptive() && now.Add(b.minInterval).Before(next) {
+		return now.Add(b.minInterval)
+	}
+	return next
+}
+
+// Observe records the cluster status of a finished balance check and adjusts the interval.
+// The interval is reset to the min one if the cluster has changed or some tasks are moved,
+// otherwise it is doubled until the max one.
+func (b *BalanceInterval) Observe(nodes map[node.ID]*node.Info, imbalance, moved int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if moved > 0 || b.marked || b.changed(nodes, imbalance) {
+		b.current = b.minInterval
+	} else {
+		b.current = min(2*b.current, b.maxInterval)
+	}
+	b.nodes = make(map[node.ID]struct{}, len(nodes))
+	for id := range nodes {
+		b.nodes[id] = struct{}{}
+	}
+	b.imbalance = imbalance
+	b.marked = false
+}
+
+func (b *BalanceInterval) changed(nodes map[node.ID]*node.Info, imbalance int) bool {
+	if b.nodes == nil || imbalance != b.imbalance || len(nodes) != len(b.nodes) {
+		return true
+	}
+	for id := range nodes {
+		if _, ok := b.nodes[id]; !ok {
+			return true
+		}
+	}
+	return false
+}
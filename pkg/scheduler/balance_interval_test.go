@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveBalanceInterval(t *testing.T) {
+	b := NewBalanceInterval(10*time.Second, time.Minute)
+	nodes := map[node.ID]*node.Info{"node1": {ID: "node1"}, "node2": {ID: "node2"}}
+	imbalance := func() int { return 0 }
+	now := time.Now()
+
+	// the first check observes the cluster, keep the min interval
+	b.Observe(nodes, 0, 0)
+	require.Equal(t, 10*time.Second, b.Get())
+	// back off while the cluster is stable
+	b.Observe(nodes, 0, 0)
+	require.Equal(t, 20*time.Second, b.Get())
+	b.Observe(nodes, 0, 0)
+	b.Observe(nodes, 0, 0)
+	require.Equal(t, time.Minute, b.Get())
+
+	// look for the cluster changes every min interval before the interval elapses
+	require.False(t, b.Due(now, now.Add(10*time.Second), nodes, imbalance))
+	require.Equal(t, now.Add(20*time.Second), b.NextCheckTime(now, now.Add(10*time.Second)))
+	require.Equal(t, now.Add(time.Minute), b.NextCheckTime(now, now.Add(55*time.Second)))
+	require.True(t, b.Due(now, now.Add(time.Minute), nodes, imbalance))
+
+	// the membership change makes the check due
+	nodes["node3"] = &node.Info{ID: "node3"}
+	require.True(t, b.Due(now, now.Add(10*time.Second), nodes, imbalance))
+	b.Observe(nodes, 0, 0)
+	require.Equal(t, 10*time.Second, b.Get())
+
+	// the imbalance change makes the check due
+	b.Observe(nodes, 0, 0)
+	require.False(t, b.Due(now, now.Add(10*time.Second), nodes, imbalance))
+	require.True(t, b.Due(now, now.Add(10*time.Second), nodes, func() int { return 2 }))
+
+	// moving tasks tightens the interval
+	b.Observe(nodes, 0, 1)
+	require.Equal(t, 10*time.Second, b.Get())
+
+	// the changes marked by the heartbeats make the check due and tighten the interval
+	b.Observe(nodes, 0, 0)
+	b.Observe(nodes, 0, 0)
+	require.Equal(t, 40*time.Second, b.Get())
+	require.False(t, b.Due(now, now.Add(10*time.Second), nodes, imbalance))
+	b.MarkChanged()
+	require.Equal(t, 10*time.Second, b.Get())
+	require.True(t, b.Due(now, now.Add(time.Second), nodes, imbalance))
+	// the mark is cleared by the next check, which doesn't back off
+	b.Observe(nodes, 0, 0)
+	require.Equal(t, 10*time.Second, b.Get())
+	require.False(t, b.Due(now, now.Add(time.Second), nodes, imbalance))
+
+	// the fixed interval never changes
+	fixed := NewFixedBalanceInterval(time.Minute)
+	fixed.MarkChanged()
+	require.False(t, fixed.Due(now, now.Add(10*time.Second), nodes, imbalance))
+	fixed.Observe(nodes, 0, 1)
+	require.Equal(t, time.Minute, fixed.Get())
+	delete(nodes, "node3")
+	require.False(t, fixed.Due(now, now.Add(10*time.Second), nodes, imbalance))
+	require.Equal(t, now.Add(time.Minute), fixed.NextCheckTime(now, now.Add(10*time.Second)))
+}
@@ -16,12 +16,14 @@ package scheduler
 import (
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler/operator"
 	"github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/heap"
+	"go.uber.org/zap"
 )
 
 // basicScheduler generates operators for the spans, and push them to the operator controller
@@ -34,9 +36,22 @@ type basicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R repl
 	operatorController operator.Controller[T, S]
 	db                 replica.ScheduleGroup[T, R]
 	nodeManager        *watcher.NodeManager
+	clock              clock.Clock
 
 	absent         []R                                               // buffer for the absent spans
 	newAddOperator func(r R, target node.ID) operator.Operator[T, S] // scheduler r to target node
+	// hashKey is set if the absent spans are placed by the rendezvous hashing of the keys.
+	hashKey func(r R) string
+	// maxTasksPerNodeInGroup is the max task size of a table group on one node, that is the spans
+	// of a split table, the tasks of the group are spread across the nodes. 0 means no limit.
+	maxTasksPerNodeInGroup int
+	// newCapacity creates the capacity of the nodes for a schedule round, it's nil if the nodes are not limited.
+	newCapacity func() NodeCapacity[R]
+	// nodeLoad returns the extra load of the nodes in the unit of tasks, it's added to the task
+	// size of the nodes when choosing the nodes for the absent tasks. It's nil if not set.
+	nodeLoad func() map[node.ID]int
+	// queued is the number of the absent tasks which don't fit any node in the last round.
+	queued int
 }
 
 func NewBasicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
@@ -51,6 +66,7 @@ func NewBasicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R r
 		operatorController: oc,
 		db:                 db,
 		nodeManager:        nodeManager,
+		clock:              clock.New(),
 		absent:             make([]R, 0, batchSize),
 		newAddOperator:     newAddOperator,
 	}
@@ -61,11 +77,11 @@ func (s *basicScheduler[T, S, R]) Execute() time.Time {
 	availableSize := s.batchSize - s.operatorController.OperatorSize()
 	if s.db.GetAbsentSize() <= 0 || availableSize <= 0 {
 		// can not schedule more operators, skip
-		return time.Now().Add(time.Millisecond * 500)
+		return s.clock.Now().Add(time.Millisecond * 500)
 	}
 	if availableSize < s.batchSize/2 {
 		// too many running operators, skip
-		return time.Now().Add(time.Millisecond * 100)
+		return s.clock.Now().Add(time.Millisecond * 100)
 	}
 
 	for _, id := range s.db.GetGroups() {
@@ -74,24 +90,114 @@ func (s *basicScheduler[T, S, R]) Execute() time.Time {
 			break
 		}
 	}
-	return time.Now().Add(time.Millisecond * 500)
+	return s.clock.Now().Add(time.Millisecond * 500)
+}
+
+// SetClock replaces the clock of the scheduler, it's used by the scheduler simulation.
+func (s *basicScheduler[T, S, R]) SetClock(clk clock.Clock) {
+	s.clock = clk
+}
+
+// SetMaxTasksPerNodeInGroup sets the max task size of a table group on one node.
+func (s *basicScheduler[T, S, R]) SetMaxTasksPerNodeInGroup(max int) {
+	s.maxTasksPerNodeInGroup = max
+}
+
+// SetNodeCapacity sets the function to create the capacity of the nodes for each schedule round,
+// the absent tasks are only placed on the nodes which have the capacity for them.
+func (s *basicScheduler[T, S, R]) SetNodeCapacity(newCapacity func() NodeCapacity[R]) {
+	s.newCapacity = newCapacity
+}
+
+// SetNodeLoad sets the function to get the extra load of the nodes in the unit of tasks,
+// the absent tasks are placed away from the loaded nodes.
+func (s *basicScheduler[T, S, R]) SetNodeLoad(nodeLoad func() map[node.ID]int) {
+	s.nodeLoad = nodeLoad
+}
+
+// addNodeLoad adds the extra load of the nodes to the task size of them.
+func (s *basicScheduler[T, S, R]) addNodeLoad(nodeTasks map[node.ID]int) {
+	if s.nodeLoad == nil {
+		return
+	}
+	for id, load := range s.nodeLoad() {
+		if _, ok := nodeTasks[id]; ok {
+			nodeTasks[id] += load
+		}
+	}
 }
 
 func (s *basicScheduler[T, S, R]) schedule(id replica.GroupID, availableSize int) (scheduled int) {
 	absent := s.db.GetAbsentByGroup(id, availableSize)
+	var capacity NodeCapacity[R]
+	if s.newCapacity != nil {
+		capacity = s.newCapacity()
+	}
+	schedule := func(replication R, id node.ID) bool {
+		if capacity != nil && !capacity.Fits(replication, id) {
+			return false
+		}
+		op := s.newAddOperator(replication, id)
+		if !s.operatorController.AddOperator(op) {
+			return false
+		}
+		if capacity != nil {
+			capacity.Add(replication, id)
+		}
+		// only the tasks which get an operator are scheduled
+		scheduled++
+		return true
+	}
+	if s.hashKey != nil {
+		nodes := make([]node.ID, 0)
+		for id := range s.nodeManager.GetSchedulableNodes() {
+			nodes = append(nodes, id)
+		}
+		HashSchedule(availableSize, absent, nodes, s.hashKey, schedule)
+		s.absent = absent[:0]
+		return
+	}
 	nodeSize := s.db.GetTaskSizePerNodeByGroup(id)
+	aliveNodes := s.nodeManager.GetSchedulableNodes()
+	// the nodes running the newest version are preferred if the nodes are loaded equally,
+	// so fewer tasks are moved again when the old nodes are restarted during a rolling upgrade.
+	// The version is not a hard filter, otherwise all the tasks pile onto the first upgraded node.
+	preferred := NewestVersionNodes(aliveNodes)
+	for id := range nodeSize {
+		if _, ok := aliveNodes[id]; !ok {
+			delete(nodeSize, id)
+		}
+	}
 	// add the absent node to the node size map
-	for id := range s.nodeManager.GetAliveNodes() {
+	for id := range aliveNodes {
 		if _, ok := nodeSize[id]; !ok {
 			nodeSize[id] = 0
 		}
 	}
+	if s.maxTasksPerNodeInGroup > 0 && replica.GetGroupType(id) == replica.GroupTable {
+		nodeTasks := make(map[node.ID]int, len(nodeSize))
+		allTasks := s.db.GetTaskSizePerNode()
+		for id := range nodeSize {
+			nodeTasks[id] = allTasks[id]
+		}
+		s.addNodeLoad(nodeTasks)
+		AntiAffinitySchedule(availableSize, absent, nodeTasks, nodeSize, s.maxTasksPerNodeInGroup, preferred, schedule)
+		s.absent = absent[:0]
+		return
+	}
+	s.addNodeLoad(nodeSize)
+	if capacity != nil {
+		queued := CapacitySchedule(availableSize, absent, nodeSize, capacity, preferred, schedule)
+		if queued > 0 && queued != s.queued {
+			log.Warn("scheduler: some tasks are queued since no node has the capacity for them",
+				zap.String("id", s.id), zap.Int("queued", queued))
+			s.queued = queued
+		}
+		s.absent = absent[:0]
+		return
+	}
 	// what happens if the some node removed when scheduling?
-	BasicSchedule(availableSize, absent, nodeSize, func(replication R, id node.ID) bool {
-		op := s.newAddOperator(replication, id)
-		return s.operatorController.AddOperator(op)
-	})
-	scheduled = len(absent)
+	BasicSchedule(availableSize, absent, nodeSize, preferred, schedule)
 	s.absent = absent[:0]
 	return
 }
@@ -100,11 +206,13 @@ func (s *basicScheduler[T, S, R]) Name() string {
 	return BasicScheduler
 }
 
-// BasicSchedule schedules the absent tasks to the available nodes
+// BasicSchedule schedules the absent tasks to the available nodes,
+// the preferred nodes are chosen first if the nodes are loaded equally.
 func BasicSchedule[T replica.ReplicationID, R replica.Replication[T]](
 	availableSize int,
 	absent []R,
 	nodeTasks map[node.ID]int,
+	preferred map[node.ID]*node.Info,
 	schedule func(R, node.ID) bool,
 ) {
 	if len(nodeTasks) == 0 {
@@ -112,8 +220,9 @@ func BasicSchedule[T replica.ReplicationID, R replica.Replication[T]](
 		return
 	}
 	minPriorityQueue := priorityQueue[T, R]{
-		h:    heap.NewHeap[*item[T, R]](),
-		less: func(a, b int) bool { return a < b },
+		h:         heap.NewHeap[*item[T, R]](),
+		less:      func(a, b int) bool { return a < b },
+		preferred: preferred,
 	}
 	for key, size := range nodeTasks {
 		minPriorityQueue.InitItem(key, size, nil)
@@ -0,0 +1,113 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/operator"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/stretchr/testify/require"
+)
+
+// testOperatorController accepts the operators of the tasks which are not rejected.
+type testOperatorController struct {
+	rejected map[testTaskID]bool
+	added    map[testTaskID]node.ID
+}
+
+func (c *testOperatorController) AddOperator(op operator.Operator[testTaskID, any]) bool {
+	id := op.ID()
+	if c.rejected[id] {
+		return false
+	}
+	c.added[id] = op.(*testAddOperator).target
+	return true
+}
+
+func (c *testOperatorController) GetOperator(testTaskID) operator.Operator[testTaskID, any] {
+	return nil
+}
+
+func (c *testOperatorController) OperatorSize() int { return 0 }
+
+type testAddOperator struct {
+	operator.Operator[testTaskID, any]
+	id     testTaskID
+	target node.ID
+}
+
+func (o *testAddOperator) ID() testTaskID { return o.id }
+
+func newTestBasicScheduler(
+	tasks int, rejected map[testTaskID]bool, hash bool,
+) (*basicScheduler[testTaskID, any, *testTask], *testOperatorController) {
+	db := replica.NewReplicationDB[testTaskID, *testTask]("test",
+		func(action func()) { action() }, replica.NewEmptyChecker[testTaskID, *testTask])
+	for i := 0; i < tasks; i++ {
+		db.AddAbsentWithoutLock(newTestTask(fmt.Sprintf("task%d", i), "", 0))
+	}
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	oc := &testOperatorController{rejected: rejected, added: make(map[testTaskID]node.ID)}
+	newAddOperator := func(r *testTask, target node.ID) operator.Operator[testTaskID, any] {
+		return &testAddOperator{id: r.GetID(), target: target}
+	}
+	if hash {
+		s := NewHashBasicScheduler[testTaskID, any, *testTask]("test", 10, oc, db, nodeManager,
+			func(r *testTask) string { return string(r.GetID()) }, newAddOperator)
+		return s, oc
+	}
+	return NewBasicScheduler[testTaskID, any, *testTask]("test", 10, oc, db, nodeManager, newAddOperator), oc
+}
+
+func TestBasicSchedulerCountScheduled(t *testing.T) {
+	for _, hash := range []bool{false, true} {
+		rejected := map[testTaskID]bool{"task1": true, "task3": true}
+		s, oc := newTestBasicScheduler(5, rejected, hash)
+		// the tasks whose operators are rejected are not counted
+		require.Equal(t, 3, s.schedule(replica.DefaultGroupID, 10))
+		require.Len(t, oc.added, 3)
+		require.NotContains(t, oc.added, testTaskID("task1"))
+		require.NotContains(t, oc.added, testTaskID("task3"))
+	}
+}
+
+func TestBasicSchedulerCountScheduledWithCapacity(t *testing.T) {
+	s, oc := newTestBasicScheduler(5, map[testTaskID]bool{"task0": true}, false)
+	s.SetNodeCapacity(func() NodeCapacity[*testTask] {
+		return &testCapacity{
+			max:   map[node.ID]int{"node1": 1, "node2": 2},
+			tasks: map[node.ID]int{},
+		}
+	})
+	// the queued tasks and the rejected ones are not counted
+	require.Equal(t, 3, s.schedule(replica.DefaultGroupID, 10))
+	require.Len(t, oc.added, 3)
+}
+
+func TestBasicSchedulerSkipStoppingNode(t *testing.T) {
+	for _, hash := range []bool{false, true} {
+		s, oc := newTestBasicScheduler(5, nil, hash)
+		s.nodeManager.MarkNodeStopping("node2")
+		require.Equal(t, 5, s.schedule(replica.DefaultGroupID, 10))
+		for _, target := range oc.added {
+			require.Equal(t, node.ID("node1"), target)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+)
+
+// NodeCapacity limits the tasks placed on the nodes, it's created for each schedule round
+// from the capacity limits and the resources used by the nodes.
+type NodeCapacity[R any] interface {
+	// Fits returns true if the task can be placed on the node. r is the zero value
+	// when the scheduler checks whether the node can take any more tasks.
+	Fits(r R, id node.ID) bool
+	// Add accounts the task placed on the node in this round.
+	Add(r R, id node.ID)
+}
+
+// CapacitySchedule schedules the absent tasks to the least loaded nodes which have the capacity
+// for them, the tasks which don't fit any node are left absent until some capacity is released.
+// It returns the number of the tasks left absent.
+func CapacitySchedule[T replica.ReplicationID, R replica.Replication[T]](
+	availableSize int,
+	absent []R,
+	nodeTasks map[node.ID]int,
+	capacity NodeCapacity[R],
+	preferred map[node.ID]*node.Info,
+	schedule func(R, node.ID) bool,
+) (queued int) {
+	if len(nodeTasks) == 0 {
+		log.Warn("scheduler: no node available, skip")
+		return len(absent)
+	}
+	nodes := sortedNodes(nodeTasks, preferred)
+	taskSize := 0
+	for i, r := range absent {
+		if taskSize >= availableSize {
+			return queued + len(absent) - i
+		}
+		var target node.ID
+		for _, id := range nodes {
+			if capacity.Fits(r, id) && (target == "" || nodeTasks[id] < nodeTasks[target]) {
+				target = id
+			}
+		}
+		if target == "" {
+			queued++
+			continue
+		}
+		if schedule(r, target) {
+			nodeTasks[target]++
+			taskSize++
+		}
+	}
+	return queued
+}
+
+// availableNodes returns the nodes which can take more tasks.
+func availableNodes[R any](nodes map[node.ID]*node.Info, capacity NodeCapacity[R]) map[node.ID]*node.Info {
+	var zero R
+	available := make(map[node.ID]*node.Info, len(nodes))
+	for id, info := range nodes {
+		if capacity.Fits(zero, id) {
+			available[id] = info
+		}
+	}
+	return available
+}
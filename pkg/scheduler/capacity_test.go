@@ -0,0 +1,74 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+// testCapacity limits the task size of the nodes.
+type testCapacity struct {
+	max   map[node.ID]int
+	tasks map[node.ID]int
+}
+
+func (c *testCapacity) Fits(_ *testTask, id node.ID) bool {
+	max, ok := c.max[id]
+	return !ok || c.tasks[id] < max
+}
+
+func (c *testCapacity) Add(_ *testTask, id node.ID) {
+	c.tasks[id]++
+}
+
+func TestCapacitySchedule(t *testing.T) {
+	absent := make([]*testTask, 0, 6)
+	for i := 0; i < 6; i++ {
+		absent = append(absent, newTestTask(fmt.Sprintf("span%d", i), "", 0))
+	}
+	capacity := &testCapacity{
+		max:   map[node.ID]int{"node1": 1, "node2": 3},
+		tasks: map[node.ID]int{"node1": 0, "node2": 1},
+	}
+	schedule := func(r *testTask, target node.ID) bool {
+		r.SetNodeID(target)
+		capacity.Add(r, target)
+		return true
+	}
+	// node1 and node2 take 1 and 2 more tasks, the others are queued
+	nodeTasks := map[node.ID]int{"node1": 0, "node2": 1}
+	queued := CapacitySchedule(10, absent, nodeTasks, capacity, nil, schedule)
+	require.Equal(t, 3, queued)
+	require.Equal(t, map[node.ID]int{"node1": 1, "node2": 3}, nodeTasks)
+	for _, r := range absent[3:] {
+		require.Equal(t, node.ID(""), r.nodeID)
+	}
+
+	// the unlimited node takes all the left tasks
+	nodeTasks["node3"] = 10
+	queued = CapacitySchedule(10, absent[3:], nodeTasks, capacity, nil, schedule)
+	require.Equal(t, 0, queued)
+	require.Equal(t, 13, nodeTasks["node3"])
+
+	// the tasks beyond the available size are not scheduled
+	more := []*testTask{newTestTask("span6", "", 0), newTestTask("span7", "", 0)}
+	require.Equal(t, 1, CapacitySchedule(1, more, nodeTasks, capacity, nil, schedule))
+
+	nodes := map[node.ID]*node.Info{"node1": {ID: "node1"}, "node3": {ID: "node3"}}
+	require.Equal(t, map[node.ID]*node.Info{"node3": {ID: "node3"}}, availableNodes[*testTask](nodes, capacity))
+}
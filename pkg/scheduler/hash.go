@@ -0,0 +1,198 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/operator"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
+)
+
+// NewHashBasicScheduler returns a basic scheduler which places the absent
+// tasks by the rendezvous hashing of their keys instead of the task size of nodes.
+func NewHashBasicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
+	id string, batchSize int,
+	oc operator.Controller[T, S], db replica.ScheduleGroup[T, R],
+	nodeManager *watcher.NodeManager,
+	hashKey func(R) string,
+	newAddOperator func(R, node.ID) operator.Operator[T, S],
+) *basicScheduler[T, S, R] {
+	s := NewBasicScheduler(id, batchSize, oc, db, nodeManager, newAddOperator)
+	s.hashKey = hashKey
+	return s
+}
+
+// hashBalanceScheduler moves the replicating tasks which are not on the node
+// chosen by the rendezvous hashing of their keys. When a node is added or
+// removed, only about 1/N of the tasks are moved.
+type hashBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]] struct {
+	id        string
+	batchSize int
+
+	operatorController operator.Controller[T, S]
+	db                 replica.ScheduleGroup[T, R]
+	nodeManager        *watcher.NodeManager
+	clock              clock.Clock
+
+	lastRebalanceTime    time.Time
+	checkBalanceInterval *BalanceInterval
+	// forceBalance is set when the batch size is reached in the last balance,
+	// so the left tasks are moved without waiting for the interval.
+	forceBalance bool
+
+	hashKey         func(R) string
+	newMoveOperator func(r R, source, target node.ID) operator.Operator[T, S]
+}
+
+func NewHashBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
+	id string, batchSize int,
+	oc operator.Controller[T, S], db replica.ScheduleGroup[T, R],
+	nodeManager *watcher.NodeManager, balanceInterval time.Duration,
+	hashKey func(R) string,
+	newMoveOperator func(R, node.ID, node.ID) operator.Operator[T, S],
+) *hashBalanceScheduler[T, S, R] {
+	return &hashBalanceScheduler[T, S, R]{
+		id:                   id,
+		batchSize:            batchSize,
+		operatorController:   oc,
+		db:                   db,
+		nodeManager:          nodeManager,
+		clock:                clock.New(),
+		checkBalanceInterval: NewFixedBalanceInterval(balanceInterval),
+		lastRebalanceTime:    time.Now(),
+		hashKey:              hashKey,
+		newMoveOperator:      newMoveOperator,
+	}
+}
+
+func (s *hashBalanceScheduler[T, S, R]) Execute() time.Time {
+	now := s.clock.Now()
+	aliveNodes := s.nodeManager.GetSchedulableNodes()
+	// the placement only depends on the nodes, the membership changes are
+	// observed by the interval, so the imbalance is not counted
+	noImbalance := func() int { return 0 }
+	if !s.forceBalance && !s.checkBalanceInterval.Due(s.lastRebalanceTime, now, aliveNodes, noImbalance) {
+		return s.checkBalanceInterval.NextCheckTime(s.lastRebalanceTime, now)
+	}
+
+	failpoint.Inject("StopBalanceScheduler", func() time.Time {
+		return s.checkBalanceInterval.NextCheckTime(now, now)
+	})
+
+	if s.operatorController.OperatorSize() > 0 || s.db.GetAbsentSize() > 0 {
+		// not in stable schedule state, skip balance
+		return s.checkBalanceInterval.NextCheckTime(now, now)
+	}
+
+	nodes := make([]node.ID, 0)
+	for id := range aliveNodes {
+		nodes = append(nodes, id)
+	}
+	moved := 0
+	if len(nodes) > 0 {
+		for _, r := range s.db.GetReplicating() {
+			target := RendezvousNode(s.hashKey(r), nodes)
+			if target == r.GetNodeID() {
+				continue
+			}
+			if s.operatorController.AddOperator(s.newMoveOperator(r, r.GetNodeID(), target)) {
+				moved++
+			}
+			if moved >= s.batchSize {
+				break
+			}
+		}
+	}
+	if moved > 0 {
+		log.Info("scheduler: finish hash balance", zap.String("id", s.id), zap.Int("moved", moved))
+	}
+
+	s.forceBalance = moved >= s.batchSize
+	s.lastRebalanceTime = now
+	s.checkBalanceInterval.Observe(aliveNodes, 0, moved)
+	return s.checkBalanceInterval.NextCheckTime(now, now)
+}
+
+// SetBalanceInterval replaces the fixed interval of the balance check,
+// the adaptive interval checks more frequently when the cluster changes.
+func (s *hashBalanceScheduler[T, S, R]) SetBalanceInterval(interval *BalanceInterval) {
+	s.checkBalanceInterval = interval
+}
+
+func (s *hashBalanceScheduler[T, S, R]) Name() string {
+	return BalanceScheduler
+}
+
+// HashSchedule schedules the absent tasks to the nodes chosen by the rendezvous hashing of their keys.
+func HashSchedule[T replica.ReplicationID, R replica.Replication[T]](
+	availableSize int,
+	absent []R,
+	nodes []node.ID,
+	hashKey func(R) string,
+	schedule func(R, node.ID) bool,
+) {
+	if len(nodes) == 0 {
+		log.Warn("scheduler: no node available, skip")
+		return
+	}
+	taskSize := 0
+	for _, r := range absent {
+		if schedule(r, RendezvousNode(hashKey(r), nodes)) {
+			taskSize++
+		}
+		if taskSize >= availableSize {
+			break
+		}
+	}
+}
+
+// RendezvousNode returns the node with the highest hash weight of the key,
+// the result only depends on the key and the node set.
+func RendezvousNode(key string, nodes []node.ID) node.ID {
+	var (
+		target    node.ID
+		maxWeight uint64
+	)
+	for _, id := range nodes {
+		weight := rendezvousWeight(key, id)
+		if target == "" || weight > maxWeight || (weight == maxWeight && id < target) {
+			target, maxWeight = id, weight
+		}
+	}
+	return target
+}
+
+func rendezvousWeight(key string, id node.ID) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(id))
+	// fnv doesn't mix the last bytes well, use the finalizer of splitmix64
+	// to make the weights of similar keys independent.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRendezvousNode(t *testing.T) {
+	require.Equal(t, node.ID(""), RendezvousNode("t1", nil))
+	require.Equal(t, node.ID("node1"), RendezvousNode("t1", []node.ID{"node1"}))
+
+	keys := make([]string, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		keys = append(keys, fmt.Sprintf("%d-7480000000000000ff", i))
+	}
+	nodes := []node.ID{"node1", "node2", "node3", "node4"}
+	placement := make(map[string]node.ID, len(keys))
+	count := make(map[node.ID]int)
+	for _, key := range keys {
+		placement[key] = RendezvousNode(key, nodes)
+		count[placement[key]]++
+		// the order of nodes doesn't matter
+		require.Equal(t, placement[key], RendezvousNode(key, []node.ID{"node4", "node3", "node2", "node1"}))
+	}
+	for _, id := range nodes {
+		require.InDelta(t, 2500, count[id], 250)
+	}
+
+	// add a node, only the keys moved to the new node are changed
+	moved := 0
+	for _, key := range keys {
+		target := RendezvousNode(key, append(nodes, "node5"))
+		if target != placement[key] {
+			require.Equal(t, node.ID("node5"), target)
+			moved++
+		}
+	}
+	require.InDelta(t, 2000, moved, 250)
+
+	// remove a node, only the keys on the removed node are changed
+	for _, key := range keys {
+		target := RendezvousNode(key, nodes[1:])
+		if placement[key] != "node1" {
+			require.Equal(t, placement[key], target)
+		}
+	}
+}
@@ -45,6 +45,8 @@ func randomizeWorkload(random *rand.Rand, input int) int {
 type priorityQueue[T replica.ReplicationID, R replica.Replication[T]] struct {
 	h    *heap.Heap[*item[T, R]]
 	less func(a, b int) bool
+	// preferred is the nodes chosen first when the loads of the nodes are equal, it's nil if no node is preferred.
+	preferred map[node.ID]*node.Info
 
 	rand *rand.Rand
 }
@@ -55,6 +57,8 @@ func (q *priorityQueue[T, R]) InitItem(node node.ID, load int, tasks []R) {
 		Tasks: tasks,
 		Load:  load,
 		less:  q.less,
+
+		preferred: q.preferred[node] != nil,
 	})
 }
 
@@ -78,6 +82,7 @@ type item[T replica.ReplicationID, R replica.Replication[T]] struct {
 	index             int
 	randomizeWorkload int
 	less              func(randomizeWorkloadA, randomizeWorkloadB int) bool
+	preferred         bool
 }
 
 func (i *item[T, R]) SetHeapIndex(idx int) {
@@ -89,5 +94,12 @@ func (i *item[T, R]) GetHeapIndex() int {
 }
 
 func (i *item[T, R]) LessThan(t *item[T, R]) bool {
+	if i.randomizeWorkload == t.randomizeWorkload {
+		if i.preferred != t.preferred {
+			return i.preferred
+		}
+		// break the tie by the node id, so the order doesn't depend on the order the items are added
+		return i.Node < t.Node
+	}
 	return i.less(i.randomizeWorkload, t.randomizeWorkload)
 }
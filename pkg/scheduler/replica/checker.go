@@ -13,7 +13,11 @@
 
 package replica
 
-import "fmt"
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
 
 type (
 	GroupID           = int64
@@ -27,10 +31,30 @@ const DefaultGroupID GroupID = 0
 const (
 	GroupDefault GroupTpye = iota
 	GroupTable
+	// GroupSchema groups the tasks by the schema id.
+	GroupSchema
+	// GroupTag groups the tasks by a user defined tag.
+	GroupTag
 	// add more group strategy later
 	// groupHotLevel1
 )
 
+// groupValueMask is the mask of the value of a group id, the high 8 bits
+// of a group id store the group type.
+const groupValueMask = 0x00FFFFFFFFFFFFFF
+
+// tagNames maps the group ids of the registered tag groups to the tags, it's used to
+// print the name of the tag groups. A tag is removed when it's not referenced any more.
+var tagNames = struct {
+	sync.RWMutex
+	tags map[GroupID]*tagRef
+}{tags: make(map[GroupID]*tagRef)}
+
+type tagRef struct {
+	tag  string
+	refs int
+}
+
 // Notice: all methods are NOT thread-safe.
 type GroupChecker[T ReplicationID, R Replication[T]] interface {
 	AddReplica(replication R)
@@ -68,9 +92,18 @@ func (c *EmptyStatusChecker[T, R]) Stat() string {
 }
 
 func GetGroupName(id GroupID) string {
-	gt := GroupTpye(id >> 56)
-	if gt == GroupTable {
-		return fmt.Sprintf("%s-%d", gt.String(), id&0x00FFFFFFFFFFFFFF)
+	gt := GetGroupType(id)
+	switch gt {
+	case GroupTable, GroupSchema:
+		return fmt.Sprintf("%s-%d", gt.String(), id&groupValueMask)
+	case GroupTag:
+		tagNames.RLock()
+		ref, ok := tagNames.tags[id]
+		tagNames.RUnlock()
+		if ok {
+			return fmt.Sprintf("%s-%s", gt.String(), ref.tag)
+		}
+		return fmt.Sprintf("%s-%d", gt.String(), id&groupValueMask)
 	}
 	return gt.String()
 }
@@ -85,21 +118,64 @@ func (gt GroupTpye) String() string {
 		return "default"
 	case GroupTable:
 		return "table"
+	case GroupSchema:
+		return "schema"
+	case GroupTag:
+		return "tag"
 	default:
 		// return "HotLevel" + strconv.Itoa(int(gt-groupHotLevel1))
 		panic("unreachable")
 	}
 }
 
-func GenGroupID(gt GroupTpye, tableID int64) GroupID {
+// GenGroupID generates the group id of the group type, value is the table id
+// of GroupTable and the schema id of GroupSchema, it's ignored by GroupDefault.
+func GenGroupID(gt GroupTpye, value int64) GroupID {
 	// use high 8 bits to store the group type
 	id := int64(gt) << 56
-	if gt == GroupTable {
-		return id | tableID
+	if gt != GroupDefault {
+		return id | (value & groupValueMask)
+	}
+	return id
+}
+
+// GenTagGroupID generates the group id of the tag, the same tag always
+// gets the same group id.
+func GenTagGroupID(tag string) GroupID {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(tag))
+	return GenGroupID(GroupTag, int64(h.Sum64()&groupValueMask))
+}
+
+// RegisterTagGroup returns the group id of the tag and keeps the tag to print the
+// name of the group, UnregisterTagGroup must be called when the group is not used.
+func RegisterTagGroup(tag string) GroupID {
+	id := GenTagGroupID(tag)
+	tagNames.Lock()
+	defer tagNames.Unlock()
+	ref, ok := tagNames.tags[id]
+	if !ok {
+		ref = &tagRef{tag: tag}
+		tagNames.tags[id] = ref
 	}
+	ref.refs++
 	return id
 }
 
+// UnregisterTagGroup releases the tag registered by RegisterTagGroup.
+func UnregisterTagGroup(id GroupID) {
+	tagNames.Lock()
+	defer tagNames.Unlock()
+	ref, ok := tagNames.tags[id]
+	if !ok {
+		return
+	}
+	ref.refs--
+	if ref.refs <= 0 {
+		delete(tagNames.tags, id)
+	}
+}
+
 func GetGroupType(id GroupID) GroupTpye {
 	return GroupTpye(id >> 56)
 }
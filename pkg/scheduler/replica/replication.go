@@ -62,6 +62,7 @@ type ScheduleGroup[T ReplicationID, R Replication[T]] interface {
 	GetTaskByNodeID(id node.ID) []R
 	GetTaskSizeByNodeID(id node.ID) int
 	GetTaskSizePerNode() map[node.ID]int
+	GetTasksPerNode() map[node.ID][]R
 	GetImbalanceGroupNodeTask(nodes map[node.ID]*node.Info) (groups map[GroupID]map[node.ID]R, valid bool)
 	GetTaskSizePerNodeByGroup(groupID GroupID) map[node.ID]int
 
@@ -88,6 +89,9 @@ type ReplicationDB[T ReplicationID, R Replication[T]] interface {
 
 	BindReplicaToNodeWithoutLock(old, new node.ID, task R)
 	RemoveReplicaWithoutLock(task R)
+	// RegroupWithoutLock moves the task to the group of groupID, setGroupID is called to
+	// update the group id of the task. The scheduling status and the node of the task are kept.
+	RegroupWithoutLock(task R, groupID GroupID, setGroupID func(GroupID))
 }
 
 func NewReplicationDB[T ReplicationID, R Replication[T]](
@@ -96,6 +100,7 @@ func NewReplicationDB[T ReplicationID, R Replication[T]](
 	r := &replicationDB[T, R]{
 		id:         id,
 		taskGroups: make(map[GroupID]*replicationGroup[T, R]),
+		nodeTasks:  make(map[node.ID]map[T]R),
 		withRLock:  withRLock,
 		newChecker: newChecker,
 	}
@@ -108,6 +113,9 @@ type replicationDB[T ReplicationID, R Replication[T]] struct {
 	withRLock  func(action func())
 	newChecker func(GroupID) GroupChecker[T, R]
 	taskGroups map[GroupID]*replicationGroup[T, R]
+	// nodeTasks groups the tasks of all groups by the node id for the O(1) node lookups,
+	// it's updated together with the node maps of the groups.
+	nodeTasks map[node.ID]map[T]R
 }
 
 func (db *replicationDB[T, R]) GetGroups() []GroupID {
@@ -290,12 +298,25 @@ func (db *replicationDB[T, R]) GetImbalanceGroupNodeTask(nodes map[node.ID]*node
 
 // GetTaskSizePerNode returns the size of the task per node
 func (db *replicationDB[T, R]) GetTaskSizePerNode() (sizeMap map[node.ID]int) {
-	sizeMap = make(map[node.ID]int)
 	db.withRLock(func() {
-		for _, g := range db.taskGroups {
-			for nodeID, tasks := range g.GetNodeTasks() {
-				sizeMap[nodeID] += len(tasks)
+		sizeMap = make(map[node.ID]int, len(db.nodeTasks))
+		for nodeID, tasks := range db.nodeTasks {
+			sizeMap[nodeID] = len(tasks)
+		}
+	})
+	return
+}
+
+// GetTasksPerNode returns the tasks bound to each node
+func (db *replicationDB[T, R]) GetTasksPerNode() (tasksMap map[node.ID][]R) {
+	db.withRLock(func() {
+		tasksMap = make(map[node.ID][]R, len(db.nodeTasks))
+		for nodeID, tasks := range db.nodeTasks {
+			ret := make([]R, 0, len(tasks))
+			for _, task := range tasks {
+				ret = append(ret, task)
 			}
+			tasksMap[nodeID] = ret
 		}
 	})
 	return
@@ -303,10 +324,9 @@ func (db *replicationDB[T, R]) GetTaskSizePerNode() (sizeMap map[node.ID]int) {
 
 func (db *replicationDB[T, R]) GetTaskByNodeID(id node.ID) (ret []R) {
 	db.withRLock(func() {
-		for _, g := range db.taskGroups {
-			for _, value := range g.GetNodeTasks()[id] {
-				ret = append(ret, value)
-			}
+		ret = make([]R, 0, len(db.nodeTasks[id]))
+		for _, value := range db.nodeTasks[id] {
+			ret = append(ret, value)
 		}
 	})
 	return
@@ -314,9 +334,7 @@ func (db *replicationDB[T, R]) GetTaskByNodeID(id node.ID) (ret []R) {
 
 func (db *replicationDB[T, R]) GetTaskSizeByNodeID(id node.ID) (size int) {
 	db.withRLock(func() {
-		for _, g := range db.taskGroups {
-			size += g.GetTaskSizeByNodeID(id)
-		}
+		size = len(db.nodeTasks[id])
 	})
 	return
 }
@@ -387,7 +405,7 @@ func (db *replicationDB[T, R]) getOrCreateGroup(task R) *replicationGroup[T, R]
 		db.taskGroups[groupID] = g
 		log.Info("scheduler: add new task group", zap.String("schedulerID", db.id),
 			zap.String("group", GetGroupName(groupID)),
-			zap.Stringer("groupType", GroupTpye(groupID)))
+			zap.Stringer("groupType", GetGroupType(groupID)))
 	}
 	return g
 }
@@ -399,7 +417,7 @@ func (db *replicationDB[T, R]) maybeRemoveGroup(g *replicationGroup[T, R]) {
 	delete(db.taskGroups, g.groupID)
 	log.Info("scheduler: remove task group", zap.String("schedulerID", db.id),
 		zap.String("group", GetGroupName(g.groupID)),
-		zap.Stringer("groupType", GroupTpye(g.groupID)))
+		zap.Stringer("groupType", GetGroupType(g.groupID)))
 }
 
 func (db *replicationDB[T, R]) mustGetGroup(groupID GroupID) *replicationGroup[T, R] {
@@ -413,6 +431,7 @@ func (db *replicationDB[T, R]) mustGetGroup(groupID GroupID) *replicationGroup[T
 func (db *replicationDB[T, R]) AddReplicatingWithoutLock(task R) {
 	g := db.getOrCreateGroup(task)
 	g.AddReplicatingReplica(task)
+	db.updateNodeMap("", task.GetNodeID(), task)
 }
 
 func (db *replicationDB[T, R]) AddAbsentWithoutLock(task R) {
@@ -422,7 +441,9 @@ func (db *replicationDB[T, R]) AddAbsentWithoutLock(task R) {
 
 func (db *replicationDB[T, R]) MarkAbsentWithoutLock(task R) {
 	g := db.mustGetGroup(task.GetGroupID())
+	originNodeID := task.GetNodeID()
 	g.MarkReplicaAbsent(task)
+	db.updateNodeMap(originNodeID, "", task)
 }
 
 func (db *replicationDB[T, R]) MarkSchedulingWithoutLock(task R) {
@@ -438,10 +459,55 @@ func (db *replicationDB[T, R]) MarkReplicatingWithoutLock(task R) {
 func (db *replicationDB[T, R]) BindReplicaToNodeWithoutLock(old, new node.ID, replica R) {
 	g := db.mustGetGroup(replica.GetGroupID())
 	g.BindReplicaToNode(old, new, replica)
+	db.updateNodeMap(old, new, replica)
 }
 
 func (db *replicationDB[T, R]) RemoveReplicaWithoutLock(replica R) {
 	g := db.mustGetGroup(replica.GetGroupID())
 	g.RemoveReplica(replica)
 	db.maybeRemoveGroup(g)
+	db.updateNodeMap(replica.GetNodeID(), "", replica)
+}
+
+// updateNodeMap moves the task from the old node to the new node in the node map of all groups,
+// the task is regrouped without changing its node, so the map is not updated by RegroupWithoutLock.
+func (db *replicationDB[T, R]) updateNodeMap(old, new node.ID, task R) {
+	if old != "" {
+		if oldMap, ok := db.nodeTasks[old]; ok {
+			delete(oldMap, task.GetID())
+			if len(oldMap) == 0 {
+				delete(db.nodeTasks, old)
+			}
+		}
+	}
+	if new != "" {
+		newMap, ok := db.nodeTasks[new]
+		if !ok {
+			newMap = make(map[T]R)
+			db.nodeTasks[new] = newMap
+		}
+		newMap[task.GetID()] = task
+	}
+}
+
+func (db *replicationDB[T, R]) RegroupWithoutLock(replica R, groupID GroupID, setGroupID func(GroupID)) {
+	if replica.GetGroupID() == groupID {
+		return
+	}
+	old := db.mustGetGroup(replica.GetGroupID())
+	_, scheduling := old.scheduling[replica.GetID()]
+	_, replicating := old.replicating[replica.GetID()]
+	old.RemoveReplica(replica)
+	db.maybeRemoveGroup(old)
+
+	setGroupID(groupID)
+	g := db.getOrCreateGroup(replica)
+	switch {
+	case replicating:
+		g.AddReplicatingReplica(replica)
+	case scheduling:
+		g.AddSchedulingReplica(replica)
+	default:
+		g.AddAbsentReplica(replica)
+	}
 }
@@ -154,6 +154,14 @@ func (g *replicationGroup[T, R]) updateNodeMap(old, new node.ID, replica R) {
 	}
 }
 
+// AddSchedulingReplica adds a replica which is being scheduled to its node
+func (g *replicationGroup[T, R]) AddSchedulingReplica(replica R) {
+	g.mustVerifyGroupID(replica.GetGroupID())
+	g.scheduling[replica.GetID()] = replica
+	g.updateNodeMap("", replica.GetNodeID(), replica)
+	g.checker.AddReplica(replica)
+}
+
 func (g *replicationGroup[T, R]) AddAbsentReplica(replica R) {
 	g.mustVerifyGroupID(replica.GetGroupID())
 	g.absent[replica.GetID()] = replica
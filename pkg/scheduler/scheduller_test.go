@@ -14,6 +14,8 @@
 package scheduler
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/pingcap/ticdc/pkg/node"
@@ -52,3 +54,98 @@ func TestCheckBalanceStatus(t *testing.T) {
 		"node3": {ID: "node3"},
 	}))
 }
+
+func TestBalanceWithExtraLoad(t *testing.T) {
+	nodes := map[node.ID]*node.Info{"node1": {ID: "node1"}, "node2": {ID: "node2"}, "node3": {ID: "node3"}}
+	replicating := make([]*testTask, 0, 9)
+	for i := 0; i < 9; i++ {
+		replicating = append(replicating, newTestTask(fmt.Sprintf("span%d", i), node.ID(fmt.Sprintf("node%d", i%3+1)), 0))
+	}
+	move := func(r *testTask, target node.ID) bool {
+		r.SetNodeID(target)
+		return true
+	}
+	count := func() map[node.ID]int {
+		res := make(map[node.ID]int)
+		for _, r := range replicating {
+			res[r.nodeID]++
+		}
+		return res
+	}
+	// the tasks are balanced by the task size
+	require.Equal(t, 0, Balance(10, rand.New(rand.NewSource(1)), nodes, replicating, nil, move))
+
+	// node1 is loaded by the others, all its tasks are moved away
+	extra := map[node.ID]int{"node1": 6}
+	require.Equal(t, 3, Balance(10, rand.New(rand.NewSource(1)), nodes, replicating, extra, move))
+	require.Equal(t, 0, count()["node1"])
+	require.Equal(t, 9, count()["node2"]+count()["node3"])
+	require.InDelta(t, count()["node2"], count()["node3"], 1)
+	// the tasks are not moved back and forth
+	require.Equal(t, 0, Balance(10, rand.New(rand.NewSource(1)), nodes, replicating, extra, move))
+
+	require.Equal(t, 2, groupLoad(extra, 3, 9)["node1"])
+	require.Nil(t, groupLoad(nil, 3, 9))
+	require.Equal(t, map[node.ID]int{"node1": 8, "node2": 1},
+		withLoad(map[node.ID]int{"node1": 2, "node2": 1}, extra, nodes))
+}
+
+func TestNewestVersionNodes(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1", Version: "v8.5.0"},
+		"node2": {ID: "node2", Version: "v9.0.0-alpha-12-g1234567"},
+		"node3": {ID: "node3", Version: "v9.0.0-alpha"},
+	}
+	newest := NewestVersionNodes(nodes)
+	require.Len(t, newest, 2)
+	require.Contains(t, newest, node.ID("node2"))
+	require.Contains(t, newest, node.ID("node3"))
+
+	// all the nodes are returned if any version can't be parsed
+	nodes["node4"] = &node.Info{ID: "node4", Version: "None"}
+	require.Len(t, NewestVersionNodes(nodes), 4)
+}
+
+// Ensure the newest version is only a tie-breaker, the tasks are still spread across the
+// old nodes during a rolling upgrade instead of piling onto the first upgraded node.
+func TestScheduleWithNewestVersionNodes(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1", Version: "v8.5.0"},
+		"node2": {ID: "node2", Version: "v8.5.0"},
+		"node3": {ID: "node3", Version: "v9.0.0"},
+	}
+	preferred := NewestVersionNodes(nodes)
+	newAbsent := func(n int) []*testTask {
+		absent := make([]*testTask, 0, n)
+		for i := 0; i < n; i++ {
+			absent = append(absent, newTestTask(fmt.Sprintf("span%d", i), "", 0))
+		}
+		return absent
+	}
+	scheduled := make(map[node.ID]int)
+	schedule := func(r *testTask, target node.ID) bool {
+		r.SetNodeID(target)
+		scheduled[target]++
+		return true
+	}
+
+	absent := newAbsent(6)
+	BasicSchedule(10, absent, map[node.ID]int{"node1": 0, "node2": 0, "node3": 0}, preferred, schedule)
+	require.Equal(t, node.ID("node3"), absent[0].nodeID)
+	require.Equal(t, map[node.ID]int{"node1": 2, "node2": 2, "node3": 2}, scheduled)
+
+	clear(scheduled)
+	absent = newAbsent(6)
+	AntiAffinitySchedule(10, absent, map[node.ID]int{"node1": 0, "node2": 0, "node3": 0},
+		map[node.ID]int{}, 2, preferred, schedule)
+	require.Equal(t, node.ID("node3"), absent[0].nodeID)
+	require.Equal(t, map[node.ID]int{"node1": 2, "node2": 2, "node3": 2}, scheduled)
+
+	clear(scheduled)
+	absent = newAbsent(3)
+	capacity := &testCapacity{max: map[node.ID]int{}, tasks: map[node.ID]int{}}
+	require.Equal(t, 0, CapacitySchedule(10, absent, map[node.ID]int{"node1": 0, "node2": 0, "node3": 0},
+		capacity, preferred, schedule))
+	require.Equal(t, node.ID("node3"), absent[0].nodeID)
+	require.Equal(t, map[node.ID]int{"node1": 1, "node2": 1, "node3": 1}, scheduled)
+}
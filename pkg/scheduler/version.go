@@ -0,0 +1,50 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/coreos/go-semver/semver"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/version"
+)
+
+// NewestVersionNodes returns the nodes running the newest version. The nodes run different
+// versions during a rolling upgrade, the upgraded nodes are preferred by the schedulers then
+// if the nodes are loaded equally, so fewer tasks are moved again when the old nodes are
+// restarted. All the nodes are returned if they run the same version, or the version of any
+// node can't be parsed.
+func NewestVersionNodes(nodes map[node.ID]*node.Info) map[node.ID]*node.Info {
+	var newest *semver.Version
+	versions := make(map[node.ID]*semver.Version, len(nodes))
+	for id, info := range nodes {
+		v, err := semver.NewVersion(version.SanitizeVersion(info.Version))
+		if err != nil {
+			return nodes
+		}
+		versions[id] = v
+		if newest == nil || newest.LessThan(*v) {
+			newest = v
+		}
+	}
+	if newest == nil {
+		return nodes
+	}
+	result := make(map[node.ID]*node.Info, len(nodes))
+	for id, info := range nodes {
+		if !versions[id].LessThan(*newest) {
+			result[id] = info
+		}
+	}
+	return result
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/operator"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
+)
+
+// weightBalanceTolerance is the tolerated ratio of the weight gap between the heaviest
+// and the lightest node to the average weight, the tasks are not moved within it,
+// so the fluctuation of the weight does not cause the tasks to move back and forth.
+const weightBalanceTolerance = 0.1
+
+// weightBalanceScheduler moves the replicating tasks to balance the total weight
+// of the tasks on each node, instead of the task count.
+type weightBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]] struct {
+	id        string
+	batchSize int
+
+	operatorController operator.Controller[T, S]
+	db                 replica.ScheduleGroup[T, R]
+	nodeManager        *watcher.NodeManager
+	clock              clock.Clock
+
+	lastRebalanceTime    time.Time
+	checkBalanceInterval *BalanceInterval
+	// forceBalance is set when the batch size is reached in the last balance,
+	// so the left tasks are moved without waiting for the interval.
+	forceBalance bool
+
+	weight          func(R) int64
+	newMoveOperator func(r R, source, target node.ID) operator.Operator[T, S]
+	// newCapacity creates the capacity of the nodes, the tasks are not moved to the nodes which are full.
+	newCapacity func() NodeCapacity[R]
+}
+
+func NewWeightBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
+	id string, batchSize int,
+	oc operator.Controller[T, S], db replica.ScheduleGroup[T, R],
+	nodeManager *watcher.NodeManager, balanceInterval time.Duration,
+	weight func(R) int64,
+	newMoveOperator func(R, node.ID, node.ID) operator.Operator[T, S],
+) *weightBalanceScheduler[T, S, R] {
+	return &weightBalanceScheduler[T, S, R]{
+		id:                   id,
+		batchSize:            batchSize,
+		operatorController:   oc,
+		db:                   db,
+		nodeManager:          nodeManager,
+		clock:                clock.New(),
+		checkBalanceInterval: NewFixedBalanceInterval(balanceInterval),
+		lastRebalanceTime:    time.Now(),
+		weight:               weight,
+		newMoveOperator:      newMoveOperator,
+	}
+}
+
+func (s *weightBalanceScheduler[T, S, R]) Execute() time.Time {
+	now := s.clock.Now()
+	nodes := s.nodeManager.GetSchedulableNodes()
+	imbalance := func() int {
+		return CheckBalanceStatus(s.db.GetTaskSizePerNode(), nodes)
+	}
+	if !s.forceBalance && !s.checkBalanceInterval.Due(s.lastRebalanceTime, now, nodes, imbalance) {
+		return s.checkBalanceInterval.NextCheckTime(s.lastRebalanceTime, now)
+	}
+
+	failpoint.Inject("StopBalanceScheduler", func() time.Time {
+		return s.checkBalanceInterval.NextCheckTime(now, now)
+	})
+
+	if s.operatorController.OperatorSize() > 0 || s.db.GetAbsentSize() > 0 {
+		// not in stable schedule state, skip balance
+		return s.checkBalanceInterval.NextCheckTime(now, now)
+	}
+
+	var capacity NodeCapacity[R]
+	if s.newCapacity != nil {
+		capacity = s.newCapacity()
+	}
+	observed := imbalance()
+	moved := WeightBalance(s.batchSize, nodes, s.db.GetReplicating(), s.weight,
+		func(r R, target node.ID) bool {
+			if capacity != nil && !capacity.Fits(r, target) {
+				return false
+			}
+			if !s.operatorController.AddOperator(s.newMoveOperator(r, r.GetNodeID(), target)) {
+				return false
+			}
+			if capacity != nil {
+				capacity.Add(r, target)
+			}
+			return true
+		})
+	if moved > 0 {
+		log.Info("scheduler: finish weight balance", zap.String("id", s.id), zap.Int("moved", moved))
+	}
+
+	s.forceBalance = moved >= s.batchSize
+	s.lastRebalanceTime = now
+	s.checkBalanceInterval.Observe(nodes, observed, moved)
+	return s.checkBalanceInterval.NextCheckTime(now, now)
+}
+
+// SetBalanceInterval replaces the fixed interval of the balance check,
+// the adaptive interval checks more frequently when the cluster changes.
+func (s *weightBalanceScheduler[T, S, R]) SetBalanceInterval(interval *BalanceInterval) {
+	s.checkBalanceInterval = interval
+}
+
+// SetNodeCapacity sets the function to create the capacity of the nodes,
+// the tasks are not moved to the nodes which don't have the capacity for them.
+func (s *weightBalanceScheduler[T, S, R]) SetNodeCapacity(newCapacity func() NodeCapacity[R]) {
+	s.newCapacity = newCapacity
+}
+
+func (s *weightBalanceScheduler[T, S, R]) Name() string {
+	return BalanceScheduler
+}
+
+// WeightBalance moves the tasks from the heaviest node to the lightest node one by one,
+// until the weight gap between them can not be narrowed by moving a task or the batch size is reached.
+// Each time the heaviest task whose weight is not larger than half of the gap is moved,
+// so the target node never becomes heavier than the source node.
+// It returns the number of the moved tasks.
+func WeightBalance[T replica.ReplicationID, R replica.Replication[T]](
+	batchSize int,
+	nodes map[node.ID]*node.Info,
+	replicating []R,
+	weight func(R) int64,
+	move func(R, node.ID) bool,
+) int {
+	if len(nodes) <= 1 {
+		return 0
+	}
+	weightPerNode := make(map[node.ID]int64, len(nodes))
+	tasksPerNode := make(map[node.ID][]R, len(nodes))
+	for id := range nodes {
+		weightPerNode[id] = 0
+	}
+	totalWeight := int64(0)
+	for _, r := range replicating {
+		id := r.GetNodeID()
+		if _, ok := weightPerNode[id]; !ok {
+			// the node is offline, the task will be rescheduled by the basic scheduler.
+			continue
+		}
+		w := weight(r)
+		weightPerNode[id] += w
+		tasksPerNode[id] = append(tasksPerNode[id], r)
+		totalWeight += w
+	}
+	tolerance := int64(float64(totalWeight) / float64(len(nodes)) * weightBalanceTolerance)
+
+	moved := 0
+	for moved < batchSize {
+		var heaviest, lightest node.ID
+		for id, w := range weightPerNode {
+			if heaviest == "" || w > weightPerNode[heaviest] ||
+				(w == weightPerNode[heaviest] && id < heaviest) {
+				heaviest = id
+			}
+			if lightest == "" || w < weightPerNode[lightest] ||
+				(w == weightPerNode[lightest] && id < lightest) {
+				lightest = id
+			}
+		}
+		gap := weightPerNode[heaviest] - weightPerNode[lightest]
+		if gap <= tolerance {
+			break
+		}
+
+		victim, victimWeight := -1, int64(0)
+		for i, r := range tasksPerNode[heaviest] {
+			w := weight(r)
+			if w <= 0 || 2*w > gap {
+				continue
+			}
+			if w > victimWeight {
+				victim, victimWeight = i, w
+			}
+		}
+		if victim < 0 {
+			break
+		}
+		r := tasksPerNode[heaviest][victim]
+		if !move(r, lightest) {
+			break
+		}
+		weightPerNode[heaviest] -= victimWeight
+		weightPerNode[lightest] += victimWeight
+		tasksPerNode[heaviest] = append(tasksPerNode[heaviest][:victim], tasksPerNode[heaviest][victim+1:]...)
+		tasksPerNode[lightest] = append(tasksPerNode[lightest], r)
+		moved++
+	}
+	return moved
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/stretchr/testify/require"
+)
+
+type testTaskID string
+
+func (id testTaskID) String() string { return string(id) }
+
+type testTask struct {
+	id     testTaskID
+	nodeID node.ID
+	weight int64
+}
+
+func (t *testTask) GetID() testTaskID           { return t.id }
+func (t *testTask) GetGroupID() replica.GroupID { return replica.DefaultGroupID }
+func (t *testTask) GetNodeID() node.ID          { return t.nodeID }
+func (t *testTask) SetNodeID(id node.ID)        { t.nodeID = id }
+func (t *testTask) ShouldRun() bool             { return true }
+
+func testTaskWeight(t *testTask) int64 { return t.weight }
+
+func newTestTask(id string, n node.ID, w int64) *testTask {
+	return &testTask{id: testTaskID(id), nodeID: n, weight: w}
+}
+
+func TestWeightBalance(t *testing.T) {
+	nodes := map[node.ID]*node.Info{"node1": {ID: "node1"}, "node2": {ID: "node2"}, "node3": {ID: "node3"}}
+	// a large changefeed and some small ones pile onto node1.
+	tasks := []*testTask{
+		newTestTask("cf1", "node1", 100),
+		newTestTask("cf2", "node1", 30),
+		newTestTask("cf3", "node1", 30),
+		newTestTask("cf4", "node1", 20),
+		newTestTask("cf5", "node2", 10),
+		newTestTask("cf6", "node3", 10),
+	}
+	move := func(r *testTask, target node.ID) bool {
+		r.SetNodeID(target)
+		return true
+	}
+	moved := WeightBalance(10, nodes, tasks, testTaskWeight, move)
+	require.Equal(t, 3, moved)
+	weightPerNode := make(map[node.ID]int64)
+	for _, task := range tasks {
+		weightPerNode[task.nodeID] += task.weight
+	}
+	// the large changefeed stays, the small ones are moved to the other nodes.
+	require.Equal(t, node.ID("node1"), tasks[0].nodeID)
+	require.Equal(t, map[node.ID]int64{"node1": 100, "node2": 60, "node3": 40}, weightPerNode)
+
+	// balanced, nothing is moved.
+	require.Equal(t, 0, WeightBalance(10, nodes, tasks, testTaskWeight, move))
+
+	// the batch size is respected.
+	for _, task := range tasks {
+		task.nodeID = "node1"
+	}
+	require.Equal(t, 1, WeightBalance(1, nodes, tasks, testTaskWeight, move))
+
+	// single node, nothing is moved.
+	require.Equal(t, 0, WeightBalance(10, map[node.ID]*node.Info{"node1": {ID: "node1"}}, tasks, testTaskWeight, move))
+}
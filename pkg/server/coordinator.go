@@ -15,9 +15,11 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
 )
 
 // Coordinator is the master of the ticdc cluster,
@@ -44,4 +46,19 @@ type Coordinator interface {
 	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64, overwriteCheckpointTs bool) error
 	// UpdateChangefeed updates a changefeed
 	UpdateChangefeed(ctx context.Context, change *config.ChangeFeedInfo) error
+	// QuiesceChangefeed persists the ts the changefeed is held at, 0 releases the changefeed
+	QuiesceChangefeed(ctx context.Context, id common.ChangeFeedID, holdTs uint64) error
+	// MoveChangefeed moves the maintainer of a changefeed to the target node
+	MoveChangefeed(ctx context.Context, id common.ChangeFeedID, target node.ID) error
+	// StartUpgrade starts the rolling upgrade of the cluster, the lag of the changefeeds
+	// is kept under maxLag before upgrading every node
+	StartUpgrade(ctx context.Context, maxLag time.Duration) error
+	// StopUpgrade stops the rolling upgrade
+	StopUpgrade(ctx context.Context) error
+	// GetUpgradeStatus returns the status of the rolling upgrade
+	GetUpgradeStatus(ctx context.Context) (*config.UpgradeStatus, error)
+	// WatchChangefeedEvents returns the changefeed events after the revision, it blocks until
+	// there are such events or ctx is done. If id is not nil, only the events of the changefeed
+	// are returned. The latest revision is returned for the next watch.
+	WatchChangefeedEvents(ctx context.Context, revision uint64, id *common.ChangeFeedDisplayName) ([]*config.ChangefeedEvent, uint64, error)
 }
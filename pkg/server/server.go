@@ -29,6 +29,9 @@ import (
 type Server interface {
 	Run(ctx context.Context) error
 	Close(ctx context.Context)
+	// Drain prepares the server for a graceful shutdown,
+	// the returned channel is closed when it's done.
+	Drain() <-chan struct{}
 
 	SelfInfo() (*node.Info, error)
 	Liveness() model.Liveness
@@ -0,0 +1,126 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// RowCount is the number of rows of a table flushed to the downstream,
+// whose commitTs are in the checkpoint interval (StartTs, EndTs].
+type RowCount struct {
+	Schema  string `json:"schema"`
+	Table   string `json:"table"`
+	TableID int64  `json:"table_id"`
+	StartTs uint64 `json:"start_ts"`
+	EndTs   uint64 `json:"end_ts"`
+	Count   int64  `json:"count"`
+}
+
+type tableCounter struct {
+	schema string
+	table  string
+	// rows is the number of flushed rows grouped by commitTs.
+	rows map[uint64]int64
+}
+
+// Counter counts the rows flushed to the downstream per table,
+// and reports the counts when the checkpointTs advances.
+// It's safe for concurrent use.
+type Counter struct {
+	mu               sync.Mutex
+	lastCheckpointTs uint64
+	tables           map[int64]*tableCounter
+}
+
+// NewCounter creates a Counter whose first checkpoint interval starts from startTs.
+func NewCounter(startTs uint64) *Counter {
+	return &Counter{
+		lastCheckpointTs: startTs,
+		tables:           make(map[int64]*tableCounter),
+	}
+}
+
+// Add counts the rows of the table committed at commitTs,
+// it should be called after the rows are flushed to the downstream.
+func (c *Counter) Add(schema, table string, tableID int64, commitTs uint64, rows int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counter, ok := c.tables[tableID]
+	if !ok {
+		counter = &tableCounter{rows: make(map[uint64]int64)}
+		c.tables[tableID] = counter
+	}
+	// the table may be renamed, always use the latest name.
+	counter.schema, counter.table = schema, table
+	counter.rows[commitTs] += rows
+}
+
+// Advance returns the row counts of the tables in the interval (lastCheckpointTs, checkpointTs],
+// the rows committed after checkpointTs are kept for the following intervals.
+// The rows committed before lastCheckpointTs, which may come from the tables moved in
+// after the last checkpoint, are counted into the current interval.
+func (c *Counter) Advance(checkpointTs uint64) []RowCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if checkpointTs <= c.lastCheckpointTs {
+		return nil
+	}
+
+	var result []RowCount
+	for tableID, counter := range c.tables {
+		var count int64
+		for commitTs, rows := range counter.rows {
+			if commitTs <= checkpointTs {
+				count += rows
+				delete(counter.rows, commitTs)
+			}
+		}
+		if len(counter.rows) == 0 {
+			delete(c.tables, tableID)
+		}
+		if count == 0 {
+			continue
+		}
+		result = append(result, RowCount{
+			Schema:  counter.schema,
+			Table:   counter.table,
+			TableID: tableID,
+			StartTs: c.lastCheckpointTs,
+			EndTs:   checkpointTs,
+			Count:   count,
+		})
+	}
+	c.lastCheckpointTs = checkpointTs
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TableID < result[j].TableID
+	})
+	return result
+}
+
+// message is the audit message sent to the MQ downstream.
+type message struct {
+	Type      string     `json:"type"`
+	RowCounts []RowCount `json:"row_counts"`
+}
+
+// MessageType is the type of the audit message sent to the MQ downstream.
+const MessageType = "ROW_COUNT_AUDIT"
+
+// EncodeMessage encodes the row counts into the audit message sent to the MQ downstream.
+func EncodeMessage(counts []RowCount) ([]byte, error) {
+	return json.Marshal(&message{Type: MessageType, RowCounts: counts})
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterAdvance(t *testing.T) {
+	c := NewCounter(100)
+	c.Add("test", "t1", 1, 101, 2)
+	c.Add("test", "t1", 1, 105, 3)
+	c.Add("test", "t2", 2, 103, 1)
+	c.Add("test", "t2", 2, 120, 4)
+
+	// stale checkpoint
+	require.Nil(t, c.Advance(100))
+
+	counts := c.Advance(110)
+	require.Equal(t, []RowCount{
+		{Schema: "test", Table: "t1", TableID: 1, StartTs: 100, EndTs: 110, Count: 5},
+		{Schema: "test", Table: "t2", TableID: 2, StartTs: 100, EndTs: 110, Count: 1},
+	}, counts)
+
+	// table t2 is renamed
+	c.Add("test", "t3", 2, 115, 1)
+	counts = c.Advance(130)
+	require.Equal(t, []RowCount{
+		{Schema: "test", Table: "t3", TableID: 2, StartTs: 110, EndTs: 130, Count: 5},
+	}, counts)
+
+	require.Empty(t, c.Advance(140))
+	require.Empty(t, c.tables)
+
+	value, err := EncodeMessage([]RowCount{{Schema: "test", Table: "t1", TableID: 1, StartTs: 1, EndTs: 2, Count: 3}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"ROW_COUNT_AUDIT","row_counts":[{"schema":"test","table":"t1","table_id":1,"start_ts":1,"end_ts":2,"count":3}]}`, string(value))
+}
@@ -32,6 +32,7 @@ import (
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/tikv/client-go/v2/oracle"
@@ -73,19 +74,63 @@ type avroEncodeResult struct {
 	header []byte
 }
 
+// newAvroEncodeInput collects the selected columns of the row,
+// only the handle key columns are collected if onlyHandleKey is true.
+func newAvroEncodeInput(
+	e *commonEvent.RowEvent, row *chunk.Row, onlyHandleKey bool,
+) (*avroEncodeInput, error) {
+	columns := e.TableInfo.GetColumns()
+	input := &avroEncodeInput{
+		columns:  make([]*commonType.Column, 0, len(columns)),
+		colInfos: make([]rowcodec.ColInfo, 0, len(columns)),
+	}
+	for idx, col := range columns {
+		if col == nil || !e.ColumnSelector.Select(col) {
+			continue
+		}
+		flag := e.TableInfo.GetColumnFlags()[col.ID]
+		if onlyHandleKey && !flag.IsHandleKey() {
+			continue
+		}
+		value, err := commonType.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrAvroEncodeFailed, err)
+		}
+		input.columns = append(input.columns, &commonType.Column{
+			Name:      col.Name.O,
+			Type:      col.GetType(),
+			Charset:   col.GetCharset(),
+			Collation: col.GetCollate(),
+			Flag:      *flag,
+			Value:     value,
+			Default:   col.GetDefaultValue(),
+		})
+		input.colInfos = append(input.colInfos, rowcodec.ColInfo{
+			ID:            col.ID,
+			IsPKHandle:    flag.IsPrimaryKey() && e.TableInfo.PKIsHandle(),
+			VirtualGenCol: col.IsGenerated(),
+			Ft:            &col.FieldType,
+		})
+	}
+	return input, nil
+}
+
 func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
-	cols, colInfos := e.HandleKeyColInfos()
+	row := e.GetRows()
+	if e.IsDelete() {
+		row = e.GetPreRows()
+	}
+	keyColumns, err := newAvroEncodeInput(e, row, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// result may be nil if the event has no handle key columns, this may happen in the force replicate mode.
 	// todo: disallow force replicate mode if using the avro.
-	if len(cols) == 0 {
+	if len(keyColumns.columns) == 0 {
 		return nil, nil
 	}
 
-	keyColumns := &avroEncodeInput{
-		columns:  cols,
-		colInfos: colInfos,
-	}
-	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, keyColumns)
+	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.UpdateTS(), keyColumns)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -118,7 +163,7 @@ func topicName2SchemaSubjects(topicName, subjectSuffix string) string {
 }
 
 func (a *BatchEncoder) getValueSchemaCodec(
-	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint16, input *avroEncodeInput,
+	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint64, input *avroEncodeInput,
 ) (*goavro.Codec, []byte, error) {
 	schemaGen := func() (string, error) {
 		schema, err := a.value2AvroSchema(tableName, input)
@@ -138,7 +183,7 @@ func (a *BatchEncoder) getValueSchemaCodec(
 }
 
 func (a *BatchEncoder) getKeySchemaCodec(
-	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint16, keyColumns *avroEncodeInput,
+	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint64, keyColumns *avroEncodeInput,
 ) (*goavro.Codec, []byte, error) {
 	schemaGen := func() (string, error) {
 		schema, err := a.key2AvroSchema(tableName, keyColumns)
@@ -157,20 +202,20 @@ func (a *BatchEncoder) getKeySchemaCodec(
 	return avroCodec, header, nil
 }
 
-func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowChangedEvent) ([]byte, error) {
+func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
 	if e.IsDelete() {
 		return nil, nil
 	}
 
-	input := &avroEncodeInput{
-		columns:  e.GetColumns(),
-		colInfos: e.TableInfo.GetColInfosForRowChangedEvent(),
+	input, err := newAvroEncodeInput(e, e.GetRows(), false)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	if len(input.columns) == 0 {
 		return nil, nil
 	}
 
-	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, input)
+	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.UpdateTS(), input)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -207,7 +252,6 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	ctx context.Context,
 	topic string,
 	e *commonEvent.RowEvent,
-	callback func(),
 ) error {
 	topic = sanitizeTopic(topic)
 
@@ -224,7 +268,7 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	}
 
 	message := common.NewMsg(key, value)
-	message.Callback = callback
+	message.Callback = e.Callback
 	message.IncRowsCount()
 
 	if message.Length() > a.config.MaxMessageBytes {
@@ -305,7 +349,7 @@ const (
 	updateOperation = "u"
 )
 
-func getOperation(e *commonEvent.RowChangedEvent) string {
+func getOperation(e *commonEvent.RowEvent) string {
 	if e.IsInsert() {
 		return insertOperation
 	} else if e.IsUpdate() {
@@ -316,12 +360,13 @@ func getOperation(e *commonEvent.RowChangedEvent) string {
 
 func (a *BatchEncoder) nativeValueWithExtension(
 	native map[string]interface{},
-	e *commonEvent.RowChangedEvent,
+	e *commonEvent.RowEvent,
 ) map[string]interface{} {
 	native[tidbOp] = getOperation(e)
 	native[tidbCommitTs] = int64(e.CommitTs)
 	native[tidbPhysicalTime] = oracle.ExtractPhysical(e.CommitTs)
 
+	// the checksum fields are filled by their default values if the upstream doesn't calculate the checksum.
 	if a.config.EnableRowChecksum && e.Checksum != nil {
 		native[tidbRowLevelChecksum] = strconv.FormatUint(uint64(e.Checksum.Current), 10)
 		native[tidbCorrupted] = e.Checksum.Corrupted
@@ -570,9 +615,7 @@ func (a *BatchEncoder) columns2AvroSchema(
 		field := make(map[string]interface{})
 		field["name"] = sanitizeName(col.Name)
 
-		copied := *col
-		copied.Value = copied.Default
-		defaultValue, _, err := a.columnToAvroData(&copied, input.colInfos[i].Ft)
+		defaultValue, err := a.getDefaultValue(col, input.colInfos[i].Ft, avroType)
 		if err != nil {
 			log.Error("fail to get default value for avro schema")
 			return nil, errors.Trace(err)
@@ -588,6 +631,7 @@ func (a *BatchEncoder) columns2AvroSchema(
 			}
 		} else {
 			if col.Flag.IsNullable() {
+				// the default value must match the first type of the union
 				// https://stackoverflow.com/questions/22938124/avro-field-default-values
 				if defaultValue == nil {
 					field["type"] = []interface{}{"null", avroType}
@@ -597,9 +641,7 @@ func (a *BatchEncoder) columns2AvroSchema(
 				field["default"] = defaultValue
 			} else {
 				field["type"] = avroType
-				if defaultValue != nil {
-					field["default"] = defaultValue
-				}
+				field["default"] = defaultValue
 			}
 		}
 		top.Fields = append(top.Fields, field)
@@ -607,13 +649,57 @@ func (a *BatchEncoder) columns2AvroSchema(
 	return top, nil
 }
 
+// getDefaultValue returns the default value of the column in the avro schema.
+// A field added to the schema must have a default value, or the schema registry
+// rejects it under the BACKWARD compatibility, so the zero value of the type is
+// used for the not null column without a default value, the same as MySQL does
+// for the existing rows when such a column is added.
+func (a *BatchEncoder) getDefaultValue(
+	col *commonType.Column, ft *types.FieldType, avroType interface{},
+) (interface{}, error) {
+	copied := *col
+	copied.Value = copied.Default
+	defaultValue, _, err := a.columnToAvroData(&copied, ft)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if defaultValue == nil {
+		if col.Flag.IsNullable() {
+			return nil, nil
+		}
+		schema, ok := avroType.(avroSchema)
+		if !ok {
+			return nil, nil
+		}
+		switch schema.Type {
+		case "int", "long", "float", "double":
+			return 0, nil
+		default:
+			// string and bytes
+			return "", nil
+		}
+	}
+	// the default value of the bytes type is a string whose code points
+	// in the range of 0-255 are mapped to the bytes.
+	if v, ok := defaultValue.([]byte); ok {
+		runes := make([]rune, 0, len(v))
+		for _, b := range v {
+			runes = append(runes, rune(b))
+		}
+		return string(runes), nil
+	}
+	return defaultValue, nil
+}
+
 func (a *BatchEncoder) value2AvroSchema(
 	tableName *commonType.TableName,
 	input *avroEncodeInput,
 ) (string, error) {
-	if a.config.EnableRowChecksum {
-		sort.Sort(input)
-	}
+	// Always order the fields by the column ID, so the field order is stable across
+	// the table versions and the added columns are appended at the end, which keeps
+	// the schema backward compatible for the downstream readers. The row level checksum
+	// is also calculated in the order of the column ID.
+	sort.Sort(input)
 
 	top, err := a.columns2AvroSchema(tableName, input)
 	if err != nil {
@@ -630,7 +716,7 @@ func (a *BatchEncoder) value2AvroSchema(
 	}
 	log.Info("avro: row to schema",
 		zap.ByteString("schema", str),
-		zap.Bool("enableTiDBExtension", a.config.EnableRowChecksum),
+		zap.Bool("enableTiDBExtension", a.config.EnableTiDBExtension),
 		zap.Bool("enableRowLevelChecksum", a.config.EnableRowChecksum))
 	return string(str), nil
 }
@@ -733,16 +819,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 			},
 		}, nil
 	case mysql.TypeNewDecimal:
-		if a.config.AvroDecimalHandlingMode == common.DecimalHandlingModePrecise {
-			defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
-			displayFlen, displayDecimal := ft.GetFlen(), ft.GetDecimal()
-			// length not specified, set it to system type default
-			if displayFlen == -1 {
-				displayFlen = defaultFlen
-			}
-			if displayDecimal == -1 {
-				displayDecimal = defaultDecimal
-			}
+		if displayFlen, displayDecimal, ok := a.config.AvroDecimalPrecisionAndScale(ft); ok {
 			return avroLogicalTypeSchema{
 				avroSchema: avroSchema{
 					Type:       "bytes",
@@ -900,7 +977,7 @@ func (a *BatchEncoder) columnToAvroData(
 		}
 		return []byte(types.NewBinaryLiteralFromUint(col.Value.(uint64), -1)), "bytes", nil
 	case mysql.TypeNewDecimal:
-		if a.config.AvroDecimalHandlingMode == common.DecimalHandlingModePrecise {
+		if _, _, ok := a.config.AvroDecimalPrecisionAndScale(ft); ok {
 			v, succ := new(big.Rat).SetString(col.Value.(string))
 			if !succ {
 				return nil, "", errors.ErrAvroEncodeFailed.GenWithStack(
@@ -971,6 +1048,9 @@ func (a *BatchEncoder) columnToAvroData(
 		if vec, ok := col.Value.(types.VectorFloat32); ok {
 			return vec.String(), "string", nil
 		}
+		if v, ok := col.Value.(string); ok {
+			return v, "string", nil
+		}
 		return nil, "", errors.ErrAvroEncodeFailed
 	default:
 		log.Error("unknown mysql type", zap.Any("value", col.Value), zap.Any("mysqlType", col.Type))
@@ -0,0 +1,357 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/stretchr/testify/require"
+)
+
+// backwardSchemaManager mocks a confluent schema registry whose compatibility
+// level is BACKWARD, the new schema of a subject must be able to read the data
+// written by the latest schema.
+type backwardSchemaManager struct {
+	SchemaManager
+
+	schemas map[string]string
+	codecs  map[string]*goavro.Codec
+}
+
+func newBackwardSchemaManager() *backwardSchemaManager {
+	return &backwardSchemaManager{
+		schemas: make(map[string]string),
+		codecs:  make(map[string]*goavro.Codec),
+	}
+}
+
+func (m *backwardSchemaManager) GetCachedOrRegister(
+	_ context.Context, subject string, _ uint64, schemaGen SchemaGenerator,
+) (*goavro.Codec, []byte, error) {
+	schema, err := schemaGen()
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest, ok := m.schemas[subject]; ok {
+		if err := checkBackwardCompatible(latest, schema); err != nil {
+			return nil, nil, err
+		}
+	}
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	m.schemas[subject] = schema
+	m.codecs[subject] = codec
+	return codec, nil, nil
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    json.RawMessage `json:"type"`
+	Default json.RawMessage `json:"default"`
+}
+
+// checkBackwardCompatible checks the reader schema can read the data written by
+// the writer schema, following the rules of the schema registry for the records:
+// the fields only in the reader must have a default value, and the fields in both
+// schemas must have the same type, the order of the union branches is ignored.
+func checkBackwardCompatible(writer, reader string) error {
+	parse := func(schema string) (map[string]avroField, error) {
+		var top struct {
+			Fields []avroField `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(schema), &top); err != nil {
+			return nil, err
+		}
+		fields := make(map[string]avroField, len(top.Fields))
+		for _, field := range top.Fields {
+			fields[field.Name] = field
+		}
+		return fields, nil
+	}
+	writerFields, err := parse(writer)
+	if err != nil {
+		return err
+	}
+	readerFields, err := parse(reader)
+	if err != nil {
+		return err
+	}
+	for name, field := range readerFields {
+		old, ok := writerFields[name]
+		if !ok {
+			if field.Default == nil {
+				return fmt.Errorf("the added field %s has no default value", name)
+			}
+			continue
+		}
+		if normalizeAvroType(old.Type) != normalizeAvroType(field.Type) {
+			return fmt.Errorf("the type of the field %s is changed from %s to %s", name, old.Type, field.Type)
+		}
+	}
+	return nil
+}
+
+// normalizeAvroType returns the canonical form of the type, the keys of the
+// objects are sorted by json.Marshal, and so are the branches of the union.
+func normalizeAvroType(tp json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(tp, &value); err != nil {
+		return string(tp)
+	}
+	branches, ok := value.([]interface{})
+	if !ok {
+		branches = []interface{}{value}
+	}
+	result := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		data, _ := json.Marshal(branch)
+		result = append(result, string(data))
+	}
+	sort.Strings(result)
+	return fmt.Sprint(result)
+}
+
+func newTestEncoder(schemaM SchemaManager) *BatchEncoder {
+	codecConfig := common.NewConfig(config.ProtocolAvro)
+	codecConfig.MaxMessageBytes = config.DefaultMaxMessageBytes
+	return &BatchEncoder{
+		namespace: "default",
+		schemaM:   schemaM,
+		config:    codecConfig,
+	}
+}
+
+func schemaFields(t *testing.T, schema string) map[string]map[string]interface{} {
+	var top avroSchemaTop
+	require.NoError(t, json.Unmarshal([]byte(schema), &top))
+	fields := make(map[string]map[string]interface{}, len(top.Fields))
+	for _, field := range top.Fields {
+		fields[field["name"].(string)] = field
+	}
+	return fields
+}
+
+func TestAvroSchemaDefaultValue(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key,
+		b int not null default 10,
+		c int,
+		d int default 20,
+		e int not null,
+		f varchar(10) not null,
+		g varchar(10) default 'x',
+		h varbinary(10) not null default 'ab',
+		i decimal(10, 2),
+		j decimal(10, 2) not null)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t(a, e, f, j) values (1, 2, 'y', 1.5)`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	rowEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	}
+
+	encoder := newTestEncoder(nil)
+	input, err := newAvroEncodeInput(rowEvent, rowEvent.GetRows(), false)
+	require.NoError(t, err)
+	schema, err := encoder.value2AvroSchema(&tableInfo.TableName, input)
+	require.NoError(t, err)
+	// goavro validates the default values against the field types.
+	_, err = goavro.NewCodec(schema)
+	require.NoError(t, err)
+
+	fields := schemaFields(t, schema)
+	// the primary key is not null and has no default value.
+	require.Equal(t, float64(0), fields["a"]["default"])
+	require.Equal(t, float64(10), fields["b"]["default"])
+	// the nullable column without a default value puts null first.
+	require.Equal(t, "null", fields["c"]["type"].([]interface{})[0])
+	require.Contains(t, fields["c"], "default")
+	require.Nil(t, fields["c"]["default"])
+	// the nullable column with a default value puts the type of the default value first.
+	require.Equal(t, "null", fields["d"]["type"].([]interface{})[1])
+	require.Equal(t, float64(20), fields["d"]["default"])
+	require.Equal(t, float64(0), fields["e"]["default"])
+	require.Equal(t, "", fields["f"]["default"])
+	require.Equal(t, "x", fields["g"]["default"])
+	require.Equal(t, "ab", fields["h"]["default"])
+	require.Equal(t, "null", fields["i"]["type"].([]interface{})[0])
+	require.Nil(t, fields["i"]["default"])
+	// goavro doesn't support the default value of the logical type.
+	require.NotContains(t, fields["j"], "default")
+}
+
+func TestAvroSchemaBackwardCompatible(t *testing.T) {
+	for _, enableRowChecksum := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enableRowChecksum=%t", enableRowChecksum), func(t *testing.T) {
+			testAvroSchemaBackwardCompatible(t, enableRowChecksum)
+		})
+	}
+}
+
+func testAvroSchemaBackwardCompatible(t *testing.T, enableRowChecksum bool) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	schemaM := newBackwardSchemaManager()
+	encoder := newTestEncoder(schemaM)
+	encoder.config.EnableTiDBExtension = enableRowChecksum
+	encoder.config.EnableRowChecksum = enableRowChecksum
+	const (
+		topic   = "test_t"
+		subject = topic + valueSchemaSuffix
+	)
+	appendRow := func(job string, dml string) []byte {
+		tableInfo := helper.GetTableInfo(helper.DDL2Job(job))
+		dmlEvent := helper.DML2Event("test", "t", dml)
+		row, ok := dmlEvent.GetNextRow()
+		require.True(t, ok)
+		err := encoder.AppendRowChangedEvent(context.Background(), topic, &pevent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       1,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() {},
+		})
+		require.NoError(t, err)
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+		return messages[0].Value
+	}
+	fieldNames := func() []string {
+		var top avroSchemaTop
+		require.NoError(t, json.Unmarshal([]byte(schemaM.schemas[subject]), &top))
+		names := make([]string, 0, len(top.Fields))
+		for _, field := range top.Fields {
+			if name := field["name"].(string); !strings.HasPrefix(name, "_tidb") {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	value := appendRow(`create table test.t(a int primary key, b varchar(10))`,
+		`insert into test.t values (1, 'v1')`)
+	oldCodec := schemaM.codecs[subject]
+	// the value starts with the envelope header, which is empty in the mock.
+	oldNative, _, err := oldCodec.NativeFromBinary(value)
+	require.NoError(t, err)
+
+	appendRow(`alter table test.t add column c int, add column d int not null default 5,
+		add column e varchar(10) not null, add column f varchar(10) default 'x'`,
+		`insert into test.t values (2, 'v2', 3, 4, 'e', 'f')`)
+	newCodec := schemaM.codecs[subject]
+
+	// the new schema reads the data written by the old schema, the added fields
+	// are filled by their default values.
+	bin, err := newCodec.BinaryFromNative(nil, oldNative)
+	require.NoError(t, err)
+	newNative, _, err := newCodec.NativeFromBinary(bin)
+	require.NoError(t, err)
+	for name := range newNative.(map[string]interface{}) {
+		if strings.HasPrefix(name, "_tidb") {
+			delete(newNative.(map[string]interface{}), name)
+		}
+	}
+	require.Equal(t, map[string]interface{}{
+		"a": int32(1),
+		"b": map[string]interface{}{"string": "v1"},
+		"c": nil,
+		"d": int32(5),
+		"e": "",
+		"f": map[string]interface{}{"string": "x"},
+	}, newNative)
+
+	// dropping a column is backward compatible.
+	appendRow(`alter table test.t drop column c`, `insert into test.t values (3, 'v3', 4, 'e', 'f')`)
+	require.Equal(t, []string{"a", "b", "d", "e", "f"}, fieldNames())
+
+	// the column added at the first position is appended to the fields.
+	appendRow(`alter table test.t add column g int first`, `insert into test.t values (5, 4, 'v4', 4, 'e', 'f')`)
+	require.Equal(t, []string{"a", "b", "d", "e", "f", "g"}, fieldNames())
+
+	// a field without a default value is rejected.
+	var top avroSchemaTop
+	require.NoError(t, json.Unmarshal([]byte(schemaM.schemas[subject]), &top))
+	top.Fields = append(top.Fields, map[string]interface{}{"name": "h", "type": "int"})
+	schema, err := json.Marshal(top)
+	require.NoError(t, err)
+	err = checkBackwardCompatible(schemaM.schemas[subject], string(schema))
+	require.ErrorContains(t, err, "the added field h has no default value")
+}
+
+func TestAvroRowChecksum(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	tableInfo := helper.GetTableInfo(helper.DDL2Job(`create table test.t(a int primary key, b varchar(10))`))
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'v1')`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	schemaM := newBackwardSchemaManager()
+	encoder := newTestEncoder(schemaM)
+	encoder.config.EnableTiDBExtension = true
+	encoder.config.EnableRowChecksum = true
+	encode := func(checksum *integrity.Checksum) map[string]interface{} {
+		err := encoder.AppendRowChangedEvent(context.Background(), "test_t", &pevent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       1,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() {},
+			Checksum:       checksum,
+		})
+		require.NoError(t, err)
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+		native, _, err := schemaM.codecs["test_t"+valueSchemaSuffix].NativeFromBinary(messages[0].Value)
+		require.NoError(t, err)
+		return native.(map[string]interface{})
+	}
+
+	// the checksum of the upstream is emitted.
+	native := encode(&integrity.Checksum{Current: 12345, Corrupted: true, Version: 1})
+	require.Equal(t, "12345", native[tidbRowLevelChecksum])
+	require.Equal(t, true, native[tidbCorrupted])
+	require.Equal(t, int32(1), native[tidbChecksumVersion])
+
+	// the default values are used if the upstream doesn't calculate the checksum.
+	native = encode(nil)
+	require.Equal(t, "", native[tidbRowLevelChecksum])
+	require.Equal(t, false, native[tidbCorrupted])
+	require.Equal(t, int32(0), native[tidbChecksumVersion])
+}
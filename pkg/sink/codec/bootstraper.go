@@ -105,6 +105,32 @@ func (b *bootstrapWorker) run(ctx context.Context) error {
 	}
 }
 
+// requestResend marks the tables to send the bootstrap message at the next tick
+// regardless of the interval and the row count, it's used by the consumers which
+// join late and can not decode the rows without the schema.
+func (b *bootstrapWorker) requestResend(tableIDs []int64) {
+	if len(tableIDs) == 0 {
+		b.activeTables.Range(func(_, value interface{}) bool {
+			value.(*tableStatistic).forceSend.Store(true)
+			return true
+		})
+	}
+	for _, id := range tableIDs {
+		table, ok := b.activeTables.Load(id)
+		if !ok {
+			// the bootstrap message is sent once the table receives the first row.
+			log.Info("table is not active, ignore the bootstrap resend request",
+				zap.Int64("tableID", id),
+				zap.Stringer("changefeed", b.changefeedID))
+			continue
+		}
+		table.(*tableStatistic).forceSend.Store(true)
+	}
+	log.Info("bootstrap messages are requested to be resent",
+		zap.Int64s("tableIDs", tableIDs),
+		zap.Stringer("changefeed", b.changefeedID))
+}
+
 func (b *bootstrapWorker) addEvent(
 	ctx context.Context,
 	key model.TopicPartitionKey,
@@ -228,6 +254,8 @@ type tableStatistic struct {
 	// tableInfo is the tableInfo of the table
 	// It is used to generate bootstrap message
 	tableInfo atomic.Value
+	// forceSend is set if the bootstrap message is requested to be resent
+	forceSend atomic.Bool
 }
 
 func newTableStatistic(key model.TopicPartitionKey, row *commonEvent.RowChangedEvent) *tableStatistic {
@@ -248,6 +276,9 @@ func (t *tableStatistic) shouldSendBootstrapMsg(
 	sendBootstrapInterval time.Duration,
 	sendBootstrapMsgCountInterval int32,
 ) bool {
+	if t.forceSend.Load() {
+		return true
+	}
 	lastSendTime := t.lastSendTime.Load().(time.Time)
 	return time.Since(lastSendTime) >= sendBootstrapInterval ||
 		t.counter.Load() >= sendBootstrapMsgCountInterval
@@ -277,4 +308,5 @@ func (t *tableStatistic) isInactive(maxInactiveDuration time.Duration) bool {
 func (t *tableStatistic) reset() {
 	t.lastSendTime.Store(time.Now())
 	t.counter.Store(0)
+	t.forceSend.Store(false)
 }
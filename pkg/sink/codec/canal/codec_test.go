@@ -704,6 +704,71 @@ func TestDMLTypeEvent(t *testing.T) {
 	require.Equal(t, `[{"a":"1","b":"2"}]`, string(newValue))
 }
 
+func TestSplitHandleKeyUpdate(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b tinyint)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	protocolConfig := common.NewConfig(config.ProtocolCanalJSON)
+	protocolConfig.SplitHandleKeyUpdate = true
+	encoder, err := NewJSONRowEventEncoder(context.Background(), protocolConfig)
+	require.NoError(t, err)
+
+	oldRow, ok := helper.DML2Event("test", "t", `insert into test.t(a,b) values (1,3)`).GetNextRow()
+	require.True(t, ok)
+	sameKeyRow, ok := helper.DML2Event("test", "t", `update test.t set b = 4 where a = 1`).GetNextRow()
+	require.True(t, ok)
+	newRow, ok := helper.DML2Event("test", "t", `insert into test.t(a,b) values (2,3)`).GetNextRow()
+	require.True(t, ok)
+
+	called := 0
+	newUpdateEvent := func(row chunk.Row) *pevent.RowEvent {
+		return &pevent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       2,
+			Event:          pevent.RowChange{PreRow: oldRow.Row, Row: row, RowType: pevent.RowTypeUpdate},
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() { called++ },
+		}
+	}
+
+	// the update which modifies the primary key is split into delete and insert.
+	err = encoder.AppendRowChangedEvent(context.Background(), "", newUpdateEvent(newRow.Row))
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+
+	var deleteValue, insertValue JSONMessage
+	require.NoError(t, json.Unmarshal(messages[0].Value, &deleteValue))
+	require.Equal(t, "DELETE", deleteValue.EventType)
+	data, err := json.Marshal(deleteValue.Data)
+	require.NoError(t, err)
+	require.Equal(t, `[{"a":"1","b":"3"}]`, string(data))
+
+	require.NoError(t, json.Unmarshal(messages[1].Value, &insertValue))
+	require.Equal(t, "INSERT", insertValue.EventType)
+	data, err = json.Marshal(insertValue.Data)
+	require.NoError(t, err)
+	require.Equal(t, `[{"a":"2","b":"3"}]`, string(data))
+
+	// the callback is only called after the insert message is sent.
+	require.Nil(t, messages[0].Callback)
+	messages[1].Callback()
+	require.Equal(t, 1, called)
+
+	// the update which doesn't modify the primary key is kept.
+	err = encoder.AppendRowChangedEvent(context.Background(), "", newUpdateEvent(sameKeyRow.Row))
+	require.NoError(t, err)
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+	var updateValue JSONMessage
+	require.NoError(t, json.Unmarshal(messages[0].Value, &updateValue))
+	require.Equal(t, "UPDATE", updateValue.EventType)
+}
+
 // ddl
 func TestDDLTypeEvent(t *testing.T) {
 	helper := pevent.NewEventTestHelper(t)
@@ -785,3 +850,77 @@ func TestCheckpointTs(t *testing.T) {
 	require.Equal(t, int64(1>>18), value.ExecutionTime)
 	require.Equal(t, uint64(1), value.Extensions.WatermarkTs)
 }
+
+func BenchmarkCanalJSONAppendRowChangedEvent(b *testing.B) {
+	ctx := context.Background()
+	helper := pevent.NewEventTestHelper(b)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(255), c double)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, "benchmark", 3.14)`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(b, ok)
+
+	rowEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() {},
+	}
+
+	protocolConfig := common.NewConfig(config.ProtocolCanalJSON)
+	encoder, err := NewJSONRowEventEncoder(ctx, protocolConfig)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := encoder.AppendRowChangedEvent(ctx, "", rowEvent); err != nil {
+			b.Fatal(err)
+		}
+		for _, message := range encoder.Build() {
+			message.Ack()
+		}
+	}
+}
+
+func TestMessageSizeEstimation(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b varchar(255))`)
+	tableInfo := helper.GetTableInfo(job)
+	row, ok := helper.DML2Event("test", "t", `insert into test.t(a,b) values (1,"abc")`).GetNextRow()
+	require.True(t, ok)
+	rowEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() {},
+	}
+
+	ctx := context.Background()
+	encoder, err := NewJSONRowEventEncoder(ctx, common.NewConfig(config.ProtocolCanalJSON))
+	require.NoError(t, err)
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", rowEvent))
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	length := messages[0].Length()
+	require.Equal(t, length, common.NewSingleRowSizeEstimator().EstimateMessageSize(nil, messages[0].Value))
+
+	// the message which is exactly max-message-bytes can be sent.
+	encoder, err = NewJSONRowEventEncoder(ctx, common.NewConfig(config.ProtocolCanalJSON).WithMaxMessageBytes(length))
+	require.NoError(t, err)
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", rowEvent))
+	require.Len(t, encoder.Build(), 1)
+
+	encoder, err = NewJSONRowEventEncoder(ctx, common.NewConfig(config.ProtocolCanalJSON).WithMaxMessageBytes(length-1))
+	require.NoError(t, err)
+	err = encoder.AppendRowChangedEvent(ctx, "", rowEvent)
+	require.ErrorIs(t, err, errors.ErrMessageTooLarge)
+}
@@ -219,7 +219,10 @@ func newJSONMessageForDML(
 			continue
 		}
 		flag := e.TableInfo.GetColumnFlags()[col.ID]
-		value, javaType := formatColumnValue(row, idx, col, flag)
+		value, javaType, err := formatColumnValue(row, idx, col, flag)
+		if err != nil {
+			return nil, err
+		}
 		valueMap[col.ID] = value
 		javaTypeMap[col.ID] = javaType
 	}
@@ -300,7 +303,10 @@ func newJSONMessageForDML(
 				continue
 			}
 			flag := e.TableInfo.GetColumnFlags()[col.ID]
-			value, _ := formatColumnValue(preRow, idx, col, flag)
+			value, _, err := formatColumnValue(preRow, idx, col, flag)
+			if err != nil {
+				return nil, err
+			}
 			oldValueMap[col.ID] = value
 		}
 
@@ -364,6 +370,9 @@ type JSONRowEventEncoder struct {
 	bytesDecoder *encoding.Decoder
 
 	claimCheck *claimcheck.ClaimCheck
+	// sizeEstimator estimates the size of the message before it's built,
+	// each row is encoded into one message by canal-json.
+	sizeEstimator common.MessageSizeEstimator
 
 	config *common.Config
 }
@@ -375,10 +384,11 @@ func NewJSONRowEventEncoder(ctx context.Context, config *common.Config) (common.
 		return nil, errors.Trace(err)
 	}
 	return &JSONRowEventEncoder{
-		messages:     make([]*common.Message, 0, 1),
-		bytesDecoder: charmap.ISO8859_1.NewDecoder(),
-		config:       config,
-		claimCheck:   claimCheck,
+		messages:      make([]*common.Message, 0, 1),
+		bytesDecoder:  charmap.ISO8859_1.NewDecoder(),
+		config:        config,
+		claimCheck:    claimCheck,
+		sizeEstimator: common.NewSingleRowSizeEstimator(),
 	}, nil
 }
 
@@ -447,6 +457,23 @@ func (c *JSONRowEventEncoder) AppendRowChangedEvent(
 	_ string,
 	e *commonEvent.RowEvent,
 ) error {
+	if c.config.SplitHandleKeyUpdate && e.IsUpdate() {
+		updated, err := isHandleKeyUpdated(e)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if updated {
+			deleteEvent, insertEvent := splitUpdateEvent(e)
+			if err := c.appendRowEvent(ctx, deleteEvent); err != nil {
+				return errors.Trace(err)
+			}
+			return c.appendRowEvent(ctx, insertEvent)
+		}
+	}
+	return c.appendRowEvent(ctx, e)
+}
+
+func (c *JSONRowEventEncoder) appendRowEvent(ctx context.Context, e *commonEvent.RowEvent) error {
 	value, err := newJSONMessageForDML(e, c.config, false, "")
 	if err != nil {
 		return errors.Trace(err)
@@ -459,12 +486,12 @@ func (c *JSONRowEventEncoder) AppendRowChangedEvent(
 		return errors.Trace(err)
 	}
 
-	m := common.NewMsg(nil, value)
+	originLength := c.sizeEstimator.EstimateMessageSize(nil, value)
+	m := common.NewPooledMsg(nil, value)
 	m.Callback = e.Callback
 	m.IncRowsCount()
 
-	originLength := m.Length()
-	if m.Length() > c.config.MaxMessageBytes {
+	if originLength > c.config.MaxMessageBytes {
 		// for single message that is longer than max-message-bytes, do not send it.
 		if c.config.LargeMessageHandle.Disabled() {
 			log.Error("Single message is too large for canal-json",
@@ -486,8 +513,8 @@ func (c *JSONRowEventEncoder) AppendRowChangedEvent(
 				return errors.Trace(err)
 			}
 
-			m.Value = value
-			length := m.Length()
+			m.Value = append(m.Value[:0], value...)
+			length := c.sizeEstimator.EstimateMessageSize(nil, value)
 			if length > c.config.MaxMessageBytes {
 				log.Error("Single message is still too large for canal-json only encode handle-key columns",
 					zap.Int("maxMessageBytes", c.config.MaxMessageBytes),
@@ -540,7 +567,7 @@ func (c *JSONRowEventEncoder) newClaimCheckLocationMessage(
 	result.Callback = event.Callback
 	result.IncRowsCount()
 
-	length := result.Length()
+	length := c.sizeEstimator.EstimateMessageSize(nil, value)
 	if length > c.config.MaxMessageBytes {
 		log.Warn("Single message is too large for canal-json, when create the claim check location message",
 			zap.Int("maxMessageBytes", c.config.MaxMessageBytes),
@@ -18,8 +18,9 @@ import (
 	"math"
 	"strconv"
 
-	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/sink/codec/internal" // nolint:staticcheck
 	mm "github.com/pingcap/tidb/pkg/meta/model"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
@@ -27,10 +28,9 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	canal "github.com/pingcap/tiflow/proto/canal"
-	"go.uber.org/zap"
 )
 
-func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo, flag *common.ColumnFlagType) (string, internal.JavaSQLType) {
+func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo, flag *common.ColumnFlagType) (string, internal.JavaSQLType, error) {
 	colType := columnInfo.GetType()
 
 	var value string
@@ -45,7 +45,7 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		} else {
 			uintValue, err := d.GetMysqlBit().ToInt(types.DefaultStmtNoWarningContext)
 			if err != nil {
-				log.Panic("failed to convert bit to int", zap.Any("data", d), zap.Error(err))
+				return "", javaType, errors.WrapError(errors.ErrCanalEncodeFailed, err)
 			}
 			value = strconv.FormatUint(uintValue, 10)
 		}
@@ -64,7 +64,7 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		if flag.IsBinary() {
 			decoded, err := bytesDecoder.Bytes(bytesValue)
 			if err != nil {
-				log.Panic("failed to decode bytes", zap.Any("bytes", bytesValue), zap.Error(err))
+				return "", javaType, errors.WrapError(errors.ErrCanalEncodeFailed, err)
 			}
 			value = string(decoded)
 		} else {
@@ -85,7 +85,7 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		if flag.IsBinary() {
 			decoded, err := bytesDecoder.Bytes(bytesValue)
 			if err != nil {
-				log.Panic("failed to decode bytes", zap.Any("bytes", bytesValue), zap.Error(err))
+				return "", javaType, errors.WrapError(errors.ErrCanalEncodeFailed, err)
 			}
 			value = string(decoded)
 		} else {
@@ -105,7 +105,7 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		if flag.IsBinary() {
 			decoded, err := bytesDecoder.Bytes(bytesValue)
 			if err != nil {
-				log.Panic("failed to decode bytes", zap.Any("bytes", bytesValue), zap.Error(err))
+				return "", javaType, errors.WrapError(errors.ErrCanalEncodeFailed, err)
 			}
 			value = string(decoded)
 		} else {
@@ -297,7 +297,7 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 			value = fmt.Sprintf("%v", d.GetValue())
 		}
 	}
-	return value, javaType
+	return value, javaType, nil
 }
 
 // convert ts in tidb to timestamp(in ms) in canal
@@ -339,3 +339,45 @@ func convertDdlEventType(t byte) canal.EventType {
 		return canal.EventType_QUERY
 	}
 }
+
+// isHandleKeyUpdated returns true if the update event modifies the value of any handle key column.
+func isHandleKeyUpdated(e *commonEvent.RowEvent) (bool, error) {
+	preRow, row := e.GetPreRows(), e.GetRows()
+	for idx, col := range e.TableInfo.GetColumns() {
+		flag := e.TableInfo.GetColumnFlags()[col.ID]
+		if !flag.IsHandleKey() {
+			continue
+		}
+		preValue, _, err := formatColumnValue(preRow, idx, col, flag)
+		if err != nil {
+			return false, err
+		}
+		value, _, err := formatColumnValue(row, idx, col, flag)
+		if err != nil {
+			return false, err
+		}
+		if preValue != value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// splitUpdateEvent splits the update event into a delete event of the old row and
+// an insert event of the new row, the callback is called after the insert event is sent.
+func splitUpdateEvent(e *commonEvent.RowEvent) (*commonEvent.RowEvent, *commonEvent.RowEvent) {
+	deleteEvent := &commonEvent.RowEvent{
+		TableInfo:      e.TableInfo,
+		CommitTs:       e.CommitTs,
+		Event:          commonEvent.RowChange{PreRow: e.Event.PreRow, RowType: commonEvent.RowTypeDelete},
+		ColumnSelector: e.ColumnSelector,
+	}
+	insertEvent := &commonEvent.RowEvent{
+		TableInfo:      e.TableInfo,
+		CommitTs:       e.CommitTs,
+		Event:          commonEvent.RowChange{Row: e.Event.Row, RowType: commonEvent.RowTypeInsert},
+		ColumnSelector: e.ColumnSelector,
+		Callback:       e.Callback,
+	}
+	return deleteEvent, insertEvent
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import "sync"
+
+const (
+	// defaultBufferSize is the initial capacity of a buffer allocated by the pool.
+	defaultBufferSize = 1024
+	// maxPooledBufferSize is the largest buffer that is put back to the pool,
+	// larger buffers are left to the GC to avoid holding too much memory.
+	maxPooledBufferSize = 1024 * 1024
+)
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, defaultBufferSize)
+		return &buf
+	},
+}
+
+// getBuffer returns an empty byte slice whose capacity is at least size.
+func getBuffer(size int) []byte {
+	buf := *(bufferPool.Get().(*[]byte))
+	if cap(buf) < size {
+		putBuffer(buf)
+		return make([]byte, 0, size)
+	}
+	return buf[:0]
+}
+
+// putBuffer returns the byte slice to the pool.
+func putBuffer(buf []byte) {
+	if buf == nil || cap(buf) > maxPooledBufferSize {
+		return
+	}
+	buf = buf[:0]
+	bufferPool.Put(&buf)
+}
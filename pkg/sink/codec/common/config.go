@@ -14,17 +14,18 @@
 package common
 
 import (
-	"net/http"
 	"net/url"
 	"time"
 
-	"github.com/gin-gonic/gin/binding"
 	"github.com/imdario/mergo"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/sinkuri"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
@@ -51,8 +52,12 @@ type Config struct {
 	EnableRowChecksum   bool
 
 	// avro only
-	AvroConfluentSchemaRegistry    string
-	AvroDecimalHandlingMode        string
+	AvroConfluentSchemaRegistry string
+	AvroDecimalHandlingMode     string
+	// AvroDecimalMaxPrecision is the max precision of the decimal encoded as the decimal
+	// logical type in the precise mode, the decimal with a larger precision is encoded
+	// as a string. 0 means no limit.
+	AvroDecimalMaxPrecision        int
 	AvroBigintUnsignedHandlingMode string
 	AvroGlueSchemaRegistry         *config.GlueSchemaRegistryConfig
 	// EnableWatermarkEvent set to true, avro encode DDL and checkpoint event
@@ -63,6 +68,9 @@ type Config struct {
 
 	// canal-json only
 	ContentCompatible bool
+	// SplitHandleKeyUpdate encodes the update event which modifies the handle key
+	// as a delete event of the old key followed by an insert event of the new key.
+	SplitHandleKeyUpdate bool
 
 	// for sinking to cloud storage
 	Delimiter            string
@@ -89,6 +97,28 @@ type Config struct {
 	DebeziumDisableSchema bool
 	// Debezium only. Whether before value should be included in the output.
 	DebeziumOutputOldValue bool
+	// Debezium only. Whether a tombstone message, which has the key and null value,
+	// is sent after the delete event, so the log compaction of Kafka can remove the row.
+	DebeziumEmitTombstone bool
+}
+
+// NeedOldValue returns true if the encoded messages contain the old values of the rows,
+// the old values are the content of the deleted rows and the before values of the updates.
+func (c *Config) NeedOldValue() bool {
+	// Without the old values only the handle key columns of a deleted row are known,
+	// so the protocols encoding the full deleted rows need them.
+	fullDelete := !c.DeleteOnlyHandleKeyColumns
+	switch c.Protocol {
+	case config.ProtocolOpen:
+		return c.OpenOutputOldValue || fullDelete
+	case config.ProtocolDebezium:
+		return c.DebeziumOutputOldValue || fullDelete
+	case config.ProtocolAvro:
+		// the deleted rows are encoded as the tombstone messages with the key only.
+		return false
+	}
+	// csv, canal-json and the simple protocol always encode the full deleted rows.
+	return true
 }
 
 // EncodingFormatType is the type of encoding format
@@ -135,6 +165,7 @@ func NewConfig(protocol config.Protocol) *Config {
 const (
 	codecOPTEnableTiDBExtension            = "enable-tidb-extension"
 	codecOPTAvroDecimalHandlingMode        = "avro-decimal-handling-mode"
+	codecOPTAvroDecimalMaxPrecision        = "avro-decimal-max-precision"
 	codecOPTAvroBigintUnsignedHandlingMode = "avro-bigint-unsigned-handling-mode"
 	codecOPTAvroSchemaRegistry             = "schema-registry"
 	coderOPTAvroGlueSchemaRegistry         = "glue-schema-registry"
@@ -156,6 +187,7 @@ type urlConfig struct {
 	MaxBatchSize                   *int    `form:"max-batch-size"`
 	MaxMessageBytes                *int    `form:"max-message-bytes"`
 	AvroDecimalHandlingMode        *string `form:"avro-decimal-handling-mode"`
+	AvroDecimalMaxPrecision        *int    `form:"avro-decimal-max-precision"`
 	AvroBigintUnsignedHandlingMode *string `form:"avro-bigint-unsigned-handling-mode"`
 
 	// AvroEnableWatermark is the option for enabling watermark in avro protocol
@@ -166,8 +198,10 @@ type urlConfig struct {
 	AvroSchemaRegistry       string `form:"schema-registry"`
 	OnlyOutputUpdatedColumns *bool  `form:"only-output-updated-columns"`
 	ContentCompatible        *bool  `form:"content-compatible"`
+	SplitHandleKeyUpdate     *bool  `form:"split-handle-key-update"`
 
 	DebeziumDisableSchema *bool `form:"debezium-disable-schema"`
+	DebeziumEmitTombstone *bool `form:"debezium-emit-tombstone"`
 	// EncodingFormatType is only works for the simple protocol,
 	// can be `json` and `avro`, default to `json`.
 	EncodingFormatType *string `form:"encoding-format"`
@@ -175,11 +209,10 @@ type urlConfig struct {
 
 // Apply fill the Config
 func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
-	req := &http.Request{URL: sinkURI}
 	var err error
 	urlParameter := &urlConfig{}
-	if err := binding.Query.Bind(req, urlParameter); err != nil {
-		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	if err := sinkuri.BindQuery(sinkURI, urlParameter); err != nil {
+		return err
 	}
 	if urlParameter, err = mergeConfig(sinkConfig, urlParameter); err != nil {
 		return err
@@ -202,6 +235,9 @@ func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
 		*urlParameter.AvroDecimalHandlingMode != "" {
 		c.AvroDecimalHandlingMode = *urlParameter.AvroDecimalHandlingMode
 	}
+	if urlParameter.AvroDecimalMaxPrecision != nil {
+		c.AvroDecimalMaxPrecision = *urlParameter.AvroDecimalMaxPrecision
+	}
 	if urlParameter.AvroBigintUnsignedHandlingMode != nil &&
 		*urlParameter.AvroBigintUnsignedHandlingMode != "" {
 		c.AvroBigintUnsignedHandlingMode = *urlParameter.AvroBigintUnsignedHandlingMode
@@ -263,6 +299,7 @@ func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
 		if c.ContentCompatible {
 			c.OnlyOutputUpdatedColumns = true
 		}
+		c.SplitHandleKeyUpdate = util.GetOrZero(urlParameter.SplitHandleKeyUpdate)
 	}
 
 	if c.Protocol == config.ProtocolSimple {
@@ -281,6 +318,9 @@ func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
 	if urlParameter.DebeziumDisableSchema != nil {
 		c.DebeziumDisableSchema = *urlParameter.DebeziumDisableSchema
 	}
+	if urlParameter.DebeziumEmitTombstone != nil && c.Protocol == config.ProtocolDebezium {
+		c.DebeziumEmitTombstone = *urlParameter.DebeziumEmitTombstone
+	}
 
 	return nil
 }
@@ -294,6 +334,7 @@ func mergeConfig(
 		dest.AvroSchemaRegistry = util.GetOrZero(sinkConfig.SchemaRegistry)
 		dest.OnlyOutputUpdatedColumns = sinkConfig.OnlyOutputUpdatedColumns
 		dest.ContentCompatible = sinkConfig.ContentCompatible
+		dest.SplitHandleKeyUpdate = sinkConfig.SplitHandleKeyUpdate
 		if util.GetOrZero(dest.ContentCompatible) {
 			dest.OnlyOutputUpdatedColumns = util.AddressOf(true)
 		}
@@ -305,8 +346,10 @@ func mergeConfig(
 				dest.MaxBatchSize = codecConfig.MaxBatchSize
 				dest.AvroEnableWatermark = codecConfig.AvroEnableWatermark
 				dest.AvroDecimalHandlingMode = codecConfig.AvroDecimalHandlingMode
+				dest.AvroDecimalMaxPrecision = codecConfig.AvroDecimalMaxPrecision
 				dest.AvroBigintUnsignedHandlingMode = codecConfig.AvroBigintUnsignedHandlingMode
 				dest.EncodingFormatType = codecConfig.EncodingFormat
+				dest.DebeziumEmitTombstone = codecConfig.DebeziumEmitTombstone
 			}
 		}
 		if sinkConfig.DebeziumDisableSchema != nil {
@@ -368,6 +411,12 @@ func (c *Config) Validate() error {
 			)
 		}
 
+		if c.AvroDecimalMaxPrecision < 0 || c.AvroDecimalMaxPrecision > mysql.MaxDecimalWidth {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value should be in [0, %d], but got %d`,
+				codecOPTAvroDecimalMaxPrecision, mysql.MaxDecimalWidth, c.AvroDecimalMaxPrecision)
+		}
+
 		if c.AvroBigintUnsignedHandlingMode != BigintUnsignedHandlingModeLong &&
 			c.AvroBigintUnsignedHandlingMode != BigintUnsignedHandlingModeString {
 			return cerror.ErrCodecInvalidConfig.GenWithStack(
@@ -420,6 +469,28 @@ const (
 	SchemaRegistryTypeGlue = "glue"
 )
 
+// AvroDecimalPrecisionAndScale returns the precision and scale of the decimal column, and
+// whether it's encoded as the avro decimal logical type. The decimal is encoded as a string
+// in the string mode, or if its precision exceeds the max precision in the precise mode.
+func (c *Config) AvroDecimalPrecisionAndScale(ft *types.FieldType) (int, int, bool) {
+	if c.AvroDecimalHandlingMode != DecimalHandlingModePrecise {
+		return 0, 0, false
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	displayFlen, displayDecimal := ft.GetFlen(), ft.GetDecimal()
+	// length not specified, set it to system type default
+	if displayFlen == -1 {
+		displayFlen = defaultFlen
+	}
+	if displayDecimal == -1 {
+		displayDecimal = defaultDecimal
+	}
+	if c.AvroDecimalMaxPrecision > 0 && displayFlen > c.AvroDecimalMaxPrecision {
+		return 0, 0, false
+	}
+	return displayFlen, displayDecimal, true
+}
+
 // SchemaRegistryType returns the type of schema registry
 func (c *Config) SchemaRegistryType() string {
 	if c.AvroConfluentSchemaRegistry != "" {
@@ -0,0 +1,115 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroDecimalMaxPrecisionConfig(t *testing.T) {
+	uri, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=avro&avro-decimal-max-precision=38")
+	require.NoError(t, err)
+	c := NewConfig(config.ProtocolAvro)
+	c.AvroConfluentSchemaRegistry = "http://127.0.0.1:8081"
+	require.NoError(t, c.Apply(uri, config.GetDefaultReplicaConfig().Sink))
+	require.Equal(t, 38, c.AvroDecimalMaxPrecision)
+	require.NoError(t, c.Validate())
+
+	// the option in the sink config is overridden by the sink uri
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.KafkaConfig = &config.KafkaConfig{
+		CodecConfig: &config.CodecConfig{AvroDecimalMaxPrecision: util.AddressOf(20)},
+	}
+	uri, err = url.Parse("kafka://127.0.0.1:9092/test?protocol=avro")
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolAvro)
+	require.NoError(t, c.Apply(uri, sinkConfig))
+	require.Equal(t, 20, c.AvroDecimalMaxPrecision)
+
+	c.AvroConfluentSchemaRegistry = "http://127.0.0.1:8081"
+	c.AvroDecimalMaxPrecision = mysql.MaxDecimalWidth + 1
+	require.Error(t, c.Validate())
+	c.AvroDecimalMaxPrecision = -1
+	require.Error(t, c.Validate())
+}
+
+func TestDebeziumEmitTombstoneConfig(t *testing.T) {
+	uri, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=debezium&debezium-emit-tombstone=true")
+	require.NoError(t, err)
+	c := NewConfig(config.ProtocolDebezium)
+	require.NoError(t, c.Apply(uri, config.GetDefaultReplicaConfig().Sink))
+	require.True(t, c.DebeziumEmitTombstone)
+
+	// the option in the sink config is kept if it's not in the sink uri
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.KafkaConfig = &config.KafkaConfig{
+		CodecConfig: &config.CodecConfig{DebeziumEmitTombstone: util.AddressOf(true)},
+	}
+	uri, err = url.Parse("kafka://127.0.0.1:9092/test?protocol=debezium")
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolDebezium)
+	require.NoError(t, c.Apply(uri, sinkConfig))
+	require.True(t, c.DebeziumEmitTombstone)
+
+	// the option is ignored by other protocols
+	uri, err = url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json&debezium-emit-tombstone=true")
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolCanalJSON)
+	require.NoError(t, c.Apply(uri, config.GetDefaultReplicaConfig().Sink))
+	require.False(t, c.DebeziumEmitTombstone)
+}
+
+func TestAvroDecimalPrecisionAndScale(t *testing.T) {
+	newDecimal := func(flen, decimal int) *types.FieldType {
+		ft := types.NewFieldType(mysql.TypeNewDecimal)
+		ft.SetFlen(flen)
+		ft.SetDecimal(decimal)
+		return ft
+	}
+	c := NewConfig(config.ProtocolAvro)
+	c.AvroDecimalHandlingMode = DecimalHandlingModePrecise
+
+	precision, scale, ok := c.AvroDecimalPrecisionAndScale(newDecimal(65, 30))
+	require.True(t, ok)
+	require.Equal(t, 65, precision)
+	require.Equal(t, 30, scale)
+
+	// the length not specified is set to the default of the type
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeNewDecimal)
+	precision, scale, ok = c.AvroDecimalPrecisionAndScale(newDecimal(-1, -1))
+	require.True(t, ok)
+	require.Equal(t, defaultFlen, precision)
+	require.Equal(t, defaultDecimal, scale)
+
+	// the decimal exceeding the max precision falls back to a string
+	c.AvroDecimalMaxPrecision = 38
+	precision, scale, ok = c.AvroDecimalPrecisionAndScale(newDecimal(38, 10))
+	require.True(t, ok)
+	require.Equal(t, 38, precision)
+	require.Equal(t, 10, scale)
+	_, _, ok = c.AvroDecimalPrecisionAndScale(newDecimal(39, 10))
+	require.False(t, ok)
+
+	// all decimals are strings in the string mode
+	c.AvroDecimalHandlingMode = DecimalHandlingModeString
+	_, _, ok = c.AvroDecimalPrecisionAndScale(newDecimal(10, 2))
+	require.False(t, ok)
+}
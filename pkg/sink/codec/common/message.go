@@ -45,6 +45,10 @@ type Message struct {
 	Value     []byte
 	rowsCount int    // rows in one Message
 	Callback  func() // Callback function will be called when the message is sent to the sink.
+
+	// pooled indicates the Key and Value are borrowed from the buffer pool,
+	// they should be returned by Release once the message is acked.
+	pooled bool
 }
 
 // Length returns the expected size of the Kafka message
@@ -69,6 +73,61 @@ func (m *Message) IncRowsCount() {
 	m.rowsCount++
 }
 
+// Ack should be called after the message is acked by the downstream,
+// it runs the callback and then releases the buffers held by the message.
+// The message must not be accessed after Ack is called.
+func (m *Message) Ack() {
+	if m.Callback != nil {
+		m.Callback()
+	}
+	m.Release()
+}
+
+// Release returns the pooled buffers held by the message, it's a no-op
+// if the message is not created by NewPooledMsg.
+func (m *Message) Release() {
+	if !m.pooled {
+		return
+	}
+	putBuffer(m.Key)
+	putBuffer(m.Value)
+	m.Key = nil
+	m.Value = nil
+	m.pooled = false
+}
+
+// NewPooledMsg creates a Message whose key and value are copied into buffers
+// borrowed from the buffer pool, which avoids allocating new slices for each message.
+// The caller can keep appending to the Key and Value, the buffers are returned
+// to the pool by Release.
+func NewPooledMsg(
+	key []byte,
+	value []byte,
+) *Message {
+	ret := &Message{
+		pooled: true,
+	}
+	if key != nil {
+		ret.Key = append(getBuffer(len(key)), key...)
+	}
+	if value != nil {
+		ret.Value = append(getBuffer(len(value)), value...)
+	}
+	return ret
+}
+
+// NewTombstoneMsg creates the tombstone of the message, which has the same key and a null value.
+// The callback is moved to the tombstone, so the row is considered flushed after both messages are sent.
+func NewTombstoneMsg(m *Message) *Message {
+	tombstone := &Message{
+		Key:      m.Key,
+		Value:    nil,
+		Callback: m.Callback,
+	}
+	m.Callback = nil
+	return tombstone
+}
+
 // NewMsg should be used when creating a Message struct.
 // todo: shall we really copy the input byte slices? does it takes observable extra resources?
 // It copies the input byte slices to avoid any surprises in asynchronous MQ writes.
@@ -0,0 +1,46 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+// MessageSizeEstimator estimates the size of the messages built by the encoder of a protocol.
+// The encoder checks the estimated size before the row is added, so the batch is split before
+// it exceeds the max-message-bytes, and the single row which exceeds the limit is handled by
+// the large message handler instead of failing at the producer.
+type MessageSizeEstimator interface {
+	// EstimateMessageSize returns the size of a new message which only contains the row
+	// encoded into the key and value.
+	EstimateMessageSize(key, value []byte) int
+	// EstimateAppendSize returns the increased size of the message after the row encoded
+	// into the key and value is appended to it.
+	EstimateAppendSize(key, value []byte) int
+}
+
+type singleRowSizeEstimator struct{}
+
+// NewSingleRowSizeEstimator returns the estimator of the protocols which encode one row into
+// one message, the message is sent as is, so only the record overhead of Kafka is added.
+func NewSingleRowSizeEstimator() MessageSizeEstimator {
+	return singleRowSizeEstimator{}
+}
+
+// EstimateMessageSize implements the MessageSizeEstimator interface.
+func (singleRowSizeEstimator) EstimateMessageSize(key, value []byte) int {
+	return len(key) + len(value) + MaxRecordOverhead
+}
+
+// EstimateAppendSize implements the MessageSizeEstimator interface.
+// The rows are never appended to the same message, so it's the size of a new message.
+func (e singleRowSizeEstimator) EstimateAppendSize(key, value []byte) int {
+	return e.EstimateMessageSize(key, value)
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTombstoneMsg(t *testing.T) {
+	acked := 0
+	m := &Message{
+		Key:      []byte("key"),
+		Value:    []byte("value"),
+		Callback: func() { acked++ },
+	}
+	tombstone := NewTombstoneMsg(m)
+	require.Equal(t, []byte("key"), tombstone.Key)
+	require.Nil(t, tombstone.Value)
+
+	// the row is acked only after the tombstone is sent
+	m.Ack()
+	require.Equal(t, 0, acked)
+	tombstone.Ack()
+	require.Equal(t, 1, acked)
+}
@@ -24,10 +24,12 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	ticommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/tikv/client-go/v2/oracle"
@@ -40,17 +42,44 @@ type dbzCodec struct {
 	nowFunc   func() time.Time
 }
 
+// newDebeziumColumns collects the selected columns of the row and their field types.
+func newDebeziumColumns(e *commonEvent.RowEvent, row *chunk.Row) ([]*common.Column, []*types.FieldType, error) {
+	columns := e.TableInfo.GetColumns()
+	cols := make([]*common.Column, 0, len(columns))
+	fts := make([]*types.FieldType, 0, len(columns))
+	for idx, col := range columns {
+		if col == nil || !e.ColumnSelector.Select(col) {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+		}
+		cols = append(cols, &common.Column{
+			Name:  col.Name.O,
+			Type:  col.GetType(),
+			Flag:  *e.TableInfo.GetColumnFlags()[col.ID],
+			Value: value,
+		})
+		fts = append(fts, &col.FieldType)
+	}
+	return cols, fts, nil
+}
+
 func (c *dbzCodec) writeDebeziumFieldValues(
 	writer *util.JSONWriter,
 	fieldName string,
 	cols []*common.Column,
-	tableInfo *common.TableInfo,
+	fts []*types.FieldType,
+	onlyHandleKey bool,
 ) error {
 	var err error
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
 	writer.WriteObjectField(fieldName, func() {
 		for i, col := range cols {
-			err = c.writeDebeziumFieldValue(writer, col, colInfos[i].Ft)
+			if onlyHandleKey && !col.Flag.IsHandleKey() {
+				continue
+			}
+			err = c.writeDebeziumFieldValue(writer, col, fts[i])
 			if err != nil {
 				break
 			}
@@ -299,6 +328,11 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
 		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		// the value of the column with a non-binary charset is formatted as a string
+		if v, ok := col.Value.(string); ok {
+			writer.WriteStringField(col.Name, v)
+			return nil
+		}
 		v, ok := col.Value.([]byte)
 		if !ok {
 			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
@@ -509,7 +543,13 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 			return nil
 		}
 	case mysql.TypeTiDBVectorFloat32:
-		v := col.Value.(types.VectorFloat32).String()
+		v, ok := col.Value.(string)
+		if !ok {
+			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
+				"unexpected column value type %T for vector column %s",
+				col.Value,
+				col.Name)
+		}
 		writer.WriteStringField(col.Name, v)
 		return nil
 	}
@@ -523,16 +563,90 @@ func (c *dbzCodec) writeBinaryField(writer *util.JSONWriter, fieldName string, v
 	writer.WriteBase64StringField(fieldName, value)
 }
 
+// EncodeKey encodes the handle key columns of the row as the Debezium message key, so the
+// messages of the same row can be compacted by Kafka. Debezium MySQL Connector uses the
+// primary key as the message key, the format is the same as the value envelope:
+// {"payload": {"id": 1}, "schema": {"type": "struct", "name": "xxx.Key", "fields": [...]}}
+func (c *dbzCodec) EncodeKey(
+	e *commonEvent.RowEvent,
+	dest io.Writer,
+) error {
+	row := e.GetRows()
+	if e.IsDelete() {
+		row = e.GetPreRows()
+	}
+	cols, fts, err := newDebeziumColumns(e, row)
+	if err != nil {
+		return err
+	}
+
+	jWriter := util.BorrowJSONWriter(dest)
+	defer util.ReturnJSONWriter(jWriter)
+	jWriter.WriteObject(func() {
+		jWriter.WriteObjectField("payload", func() {
+			for i, col := range cols {
+				if col == nil || !col.Flag.IsHandleKey() {
+					continue
+				}
+				err = c.writeDebeziumFieldValue(jWriter, col, fts[i])
+				if err != nil {
+					break
+				}
+			}
+		})
+		if !c.config.DebeziumDisableSchema {
+			jWriter.WriteObjectField("schema", func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("name", fmt.Sprintf("%s.%s.%s.Key",
+					c.clusterID,
+					e.TableInfo.GetSchemaName(),
+					e.TableInfo.GetTableName()))
+				jWriter.WriteArrayField("fields", func() {
+					for i, col := range cols {
+						if col == nil || !col.Flag.IsHandleKey() {
+							continue
+						}
+						c.writeDebeziumFieldSchema(jWriter, col, fts[i])
+					}
+				})
+			})
+		}
+	})
+	return err
+}
+
+// EncodeRowChangedEvent encodes the row as the Debezium message value, only the handle key
+// columns are encoded if onlyHandleKey is true, it's used to handle the large message.
 func (c *dbzCodec) EncodeRowChangedEvent(
-	e *common.RowChangedEvent,
+	e *commonEvent.RowEvent,
+	onlyHandleKey bool,
 	dest io.Writer,
 ) error {
+	var cols, preCols []*common.Column
+	var fts []*types.FieldType
+	var err error
+	if !e.IsDelete() {
+		cols, fts, err = newDebeziumColumns(e, e.GetRows())
+		if err != nil {
+			return err
+		}
+	}
+	if !e.IsInsert() {
+		preCols, fts, err = newDebeziumColumns(e, e.GetPreRows())
+		if err != nil {
+			return err
+		}
+	}
+
 	jWriter := util.BorrowJSONWriter(dest)
 	defer util.ReturnJSONWriter(jWriter)
 
 	commitTime := oracle.GetTimeFromTS(e.CommitTs)
-
-	var err error
+	// only the handle key columns are sent for the delete event in the delete key-only mode
+	if e.IsDelete() && c.config.DeleteOnlyHandleKeyColumns {
+		onlyHandleKey = true
+	}
 
 	jWriter.WriteObject(func() {
 		jWriter.WriteObjectField("payload", func() {
@@ -584,18 +698,18 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 				// after: An optional field that specifies the state of the row after the event occurred.
 				// Optional field that specifies the state of the row after the event occurred.
 				// In a delete event value, the after field is null, signifying that the row no longer exists.
-				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "after", cols, fts, onlyHandleKey)
 			} else if e.IsDelete() {
 				jWriter.WriteStringField("op", "d")
 				jWriter.WriteNullField("after")
-				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "before", preCols, fts, onlyHandleKey)
 			} else if e.IsUpdate() {
 				jWriter.WriteStringField("op", "u")
 				if c.config.DebeziumOutputOldValue {
-					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "before", preCols, fts, onlyHandleKey)
 				}
 				if err == nil {
-					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "after", cols, fts, onlyHandleKey)
 				}
 			}
 		})
@@ -616,17 +730,15 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 					{
 						fieldsBuf := &bytes.Buffer{}
 						fieldsWriter := util.BorrowJSONWriter(fieldsBuf)
-						var validCols []*common.Column
-						if e.IsInsert() {
-							validCols = e.GetColumns()
-						} else if e.IsDelete() {
-							validCols = e.GetPreColumns()
-						} else if e.IsUpdate() {
-							validCols = e.GetColumns()
+						validCols := cols
+						if e.IsDelete() {
+							validCols = preCols
 						}
-						colInfos := e.TableInfo.GetColInfosForRowChangedEvent()
 						for i, col := range validCols {
-							c.writeDebeziumFieldSchema(fieldsWriter, col, colInfos[i].Ft)
+							if onlyHandleKey && !col.Flag.IsHandleKey() {
+								continue
+							}
+							c.writeDebeziumFieldSchema(fieldsWriter, col, fts[i])
 						}
 						util.ReturnJSONWriter(fieldsWriter)
 						fieldsJSON = fieldsBuf.String()
@@ -18,9 +18,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/pingcap/log"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
 )
 
 // BatchEncoder encodes message into Debezium format.
@@ -29,6 +31,8 @@ type BatchEncoder struct {
 
 	config *common.Config
 	codec  *dbzCodec
+	// sizeEstimator estimates the size of the message, each row is encoded into one message.
+	sizeEstimator common.MessageSizeEstimator
 }
 
 // EncodeCheckpointEvent implements the RowEventEncoder interface
@@ -41,34 +45,78 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 func (d *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
 	_ string,
-	e *commonEvent.RowChangedEvent,
-	callback func(),
+	e *commonEvent.RowEvent,
 ) error {
-	valueBuf := bytes.Buffer{}
-	err := d.codec.EncodeRowChangedEvent(e, &valueBuf)
+	value, err := d.encodeValue(e, false)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// TODO: Use a streaming compression is better.
-	value, err := common.Compress(
-		d.config.ChangefeedID,
-		d.config.LargeMessageHandle.LargeMessageHandleCompression,
-		valueBuf.Bytes(),
-	)
-	if err != nil {
-		return errors.Trace(err)
+	var key []byte
+	if d.config.DebeziumEmitTombstone {
+		keyBuf := bytes.Buffer{}
+		if err = d.codec.EncodeKey(e, &keyBuf); err != nil {
+			return errors.Trace(err)
+		}
+		key = keyBuf.Bytes()
+	}
+	originLength := d.sizeEstimator.EstimateMessageSize(key, value)
+	if originLength > d.config.MaxMessageBytes {
+		// for single message that is longer than max-message-bytes, do not send it.
+		if !d.config.LargeMessageHandle.HandleKeyOnly() {
+			log.Error("Single message is too large for debezium",
+				zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+				zap.Int("length", originLength),
+				zap.Any("table", e.TableInfo.TableName))
+			return errors.ErrMessageTooLarge.GenWithStackByArgs()
+		}
+
+		value, err = d.encodeValue(e, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		length := d.sizeEstimator.EstimateMessageSize(key, value)
+		if length > d.config.MaxMessageBytes {
+			log.Error("Single message is still too large for debezium only encode handle-key columns",
+				zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+				zap.Int("originLength", originLength),
+				zap.Int("length", length),
+				zap.Any("table", e.TableInfo.TableName))
+			return errors.ErrMessageTooLarge.GenWithStackByArgs()
+		}
+		log.Warn("Single message is too large for debezium, only encode handle-key columns",
+			zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+			zap.Int("originLength", originLength),
+			zap.Int("length", length),
+			zap.Any("table", e.TableInfo.TableName))
 	}
 	m := &common.Message{
-		Key:      nil,
+		Key:      key,
 		Value:    value,
-		Callback: callback,
+		Callback: e.Callback,
 	}
 	m.IncRowsCount()
-
 	d.messages = append(d.messages, m)
+
+	// the tombstone is used by Kafka to remove all the messages of the row in log compaction.
+	if d.config.DebeziumEmitTombstone && e.IsDelete() {
+		d.messages = append(d.messages, common.NewTombstoneMsg(m))
+	}
 	return nil
 }
 
+func (d *BatchEncoder) encodeValue(e *commonEvent.RowEvent, onlyHandleKey bool) ([]byte, error) {
+	valueBuf := bytes.Buffer{}
+	if err := d.codec.EncodeRowChangedEvent(e, onlyHandleKey, &valueBuf); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// TODO: Use a streaming compression is better.
+	return common.Compress(
+		d.config.ChangefeedID,
+		d.config.LargeMessageHandle.LargeMessageHandleCompression,
+		valueBuf.Bytes(),
+	)
+}
+
 // EncodeDDLEvent implements the RowEventEncoder interface
 // DDL message unresolved tso
 func (d *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*common.Message, error) {
@@ -99,6 +147,7 @@ func NewBatchEncoder(c *common.Config, clusterID string) common.EventEncoder {
 			clusterID: clusterID,
 			nowFunc:   time.Now,
 		},
+		sizeEstimator: common.NewSingleRowSizeEstimator(),
 	}
 	return batch
 }
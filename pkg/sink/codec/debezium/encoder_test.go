@@ -0,0 +1,151 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRowEvents returns an insert and a delete event of the same row of test.t.
+func newTestRowEvents(t *testing.T, callback func()) (*pevent.RowEvent, *pevent.RowEvent) {
+	helper := pevent.NewEventTestHelper(t)
+	t.Cleanup(helper.Close)
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(255))`)
+	tableInfo := helper.GetTableInfo(job)
+
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'abc')`)
+	require.NotNil(t, dmlEvent)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	insertEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          insertRow,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       callback,
+	}
+
+	deleteRow := insertRow
+	deleteRow.PreRow = insertRow.Row
+	deleteRow.Row = chunk.Row{}
+	deleteEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       2,
+		Event:          deleteRow,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       callback,
+	}
+	return insertEvent, deleteEvent
+}
+
+func decodePayload(t *testing.T, data []byte) map[string]interface{} {
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &msg))
+	return msg["payload"].(map[string]interface{})
+}
+
+func TestEncodeRowEvents(t *testing.T) {
+	insertEvent, deleteEvent := newTestRowEvents(t, func() {})
+	encoder := NewBatchEncoder(common.NewConfig(config.ProtocolDebezium), "test-cluster")
+
+	require.NoError(t, encoder.AppendRowChangedEvent(context.Background(), "", insertEvent))
+	require.NoError(t, encoder.AppendRowChangedEvent(context.Background(), "", deleteEvent))
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+
+	payload := decodePayload(t, messages[0].Value)
+	require.Equal(t, "c", payload["op"])
+	require.Nil(t, payload["before"])
+	require.Equal(t, map[string]interface{}{"a": float64(1), "b": "abc"}, payload["after"])
+
+	payload = decodePayload(t, messages[1].Value)
+	require.Equal(t, "d", payload["op"])
+	require.Nil(t, payload["after"])
+	require.Equal(t, map[string]interface{}{"a": float64(1), "b": "abc"}, payload["before"])
+	// no key and no tombstone without the option
+	require.Nil(t, messages[1].Key)
+}
+
+func TestEncodeTombstone(t *testing.T) {
+	flushed := 0
+	insertEvent, deleteEvent := newTestRowEvents(t, func() { flushed++ })
+	codecConfig := common.NewConfig(config.ProtocolDebezium)
+	codecConfig.DebeziumEmitTombstone = true
+	encoder := NewBatchEncoder(codecConfig, "test-cluster")
+
+	require.NoError(t, encoder.AppendRowChangedEvent(context.Background(), "", insertEvent))
+	require.NoError(t, encoder.AppendRowChangedEvent(context.Background(), "", deleteEvent))
+	messages := encoder.Build()
+	// the delete event is followed by a tombstone, the insert event is not
+	require.Len(t, messages, 3)
+	require.NotNil(t, messages[0].Value)
+	require.Equal(t, "c", decodePayload(t, messages[0].Value)["op"])
+
+	deleteMsg, tombstone := messages[1], messages[2]
+	require.Equal(t, "d", decodePayload(t, deleteMsg.Value)["op"])
+	require.Nil(t, tombstone.Value)
+	require.Equal(t, deleteMsg.Key, tombstone.Key)
+	require.Equal(t, map[string]interface{}{"a": float64(1)}, decodePayload(t, tombstone.Key))
+
+	// the row is flushed after the tombstone is sent
+	require.Nil(t, deleteMsg.Callback)
+	messages[0].Callback()
+	tombstone.Callback()
+	require.Equal(t, 2, flushed)
+}
+
+func TestEncodeLargeMessage(t *testing.T) {
+	insertEvent, _ := newTestRowEvents(t, func() {})
+	codecConfig := common.NewConfig(config.ProtocolDebezium)
+	codecConfig.DebeziumDisableSchema = true
+	encoder := NewBatchEncoder(codecConfig, "test-cluster")
+	require.NoError(t, encoder.AppendRowChangedEvent(context.Background(), "", insertEvent))
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	fullLength := messages[0].Length()
+
+	// the row is too large and the large message handle is disabled
+	codecConfig.MaxMessageBytes = fullLength - 1
+	encoder = NewBatchEncoder(codecConfig, "test-cluster")
+	err := encoder.AppendRowChangedEvent(context.Background(), "", insertEvent)
+	require.True(t, errors.ErrMessageTooLarge.Equal(err))
+
+	// only the handle key columns are encoded
+	codecConfig.LargeMessageHandle.LargeMessageHandleOption = config.LargeMessageHandleOptionHandleKeyOnly
+	encoder = NewBatchEncoder(codecConfig, "test-cluster")
+	require.NoError(t, encoder.AppendRowChangedEvent(context.Background(), "", insertEvent))
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+	require.Less(t, messages[0].Length(), fullLength)
+	payload := decodePayload(t, messages[0].Value)
+	require.Equal(t, "c", payload["op"])
+	require.Equal(t, map[string]interface{}{"a": float64(1)}, payload["after"])
+
+	// the handle key columns are still too large
+	codecConfig.MaxMessageBytes = 1
+	encoder = NewBatchEncoder(codecConfig, "test-cluster")
+	err = encoder.AppendRowChangedEvent(context.Background(), "", insertEvent)
+	require.True(t, errors.ErrMessageTooLarge.Equal(err))
+}
@@ -15,6 +15,8 @@ package codec
 
 import (
 	"context"
+	"hash/fnv"
+	"slices"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
@@ -34,6 +37,15 @@ import (
 const (
 	defaultInputChanSize  = 128
 	defaultMetricInterval = 15 * time.Second
+
+	// defaultScaleInterval is the interval to adjust the number of active encoders.
+	defaultScaleInterval = 5 * time.Second
+	// scaleUpQueueLatency is the average time events wait in the input channel
+	// above which one more encoder is activated.
+	scaleUpQueueLatency = 50 * time.Millisecond
+	// scaleDownQueueLatency is the average time events wait in the input channel
+	// below which one encoder is deactivated.
+	scaleDownQueueLatency = 5 * time.Millisecond
 )
 
 // EncoderGroup manages a group of encoders
@@ -41,10 +53,16 @@ type EncoderGroup interface {
 	// Run start the group
 	Run(ctx context.Context) error
 	// AddEvents add events into the group and encode them by one of the encoders in the group.
+	// Events of the same topic and partition are always encoded by the same encoder
+	// unless the group is rescaled, and the futures are output in the order they are added.
 	// Note: The caller should make sure all events should belong to the same topic and partition.
 	AddEvents(ctx context.Context, key model.TopicPartitionKey, events ...*commonEvent.RowEvent) error
 	// Output returns a channel produce futures
 	Output() <-chan *future
+	// ResendBootstrap makes the bootstrap messages of the tables resent as soon as possible,
+	// all active tables are resent if tableIDs is empty. It returns false if the protocol
+	// does not send bootstrap messages.
+	ResendBootstrap(tableIDs []int64) bool
 }
 
 type encoderGroup struct {
@@ -54,9 +72,25 @@ type encoderGroup struct {
 	concurrency int
 	// inputCh is the input channel for each encoder pipeline
 	inputCh []chan *future
-	index   uint64
+	// activeCount is the number of encoder pipelines which accept new events,
+	// it's adjusted between 1 and concurrency according to the queue latency.
+	activeCount atomic.Int32
+
+	// queueLatencySum and queueLatencyCount collect the time futures wait
+	// in the input channels since the last scale check.
+	queueLatencySum   atomic.Int64
+	queueLatencyCount atomic.Int64
 
 	rowEventEncoders []common.EventEncoder
+	// protocolEncoders are the encoders of the protocols overriding the protocol of the sink
+	// for some tables, each encoder pipeline has one encoder of every protocol.
+	protocolEncoders map[config.Protocol][]common.EventEncoder
+	// protocolOf returns the protocol of the table, it's nil if no protocol is overridden.
+	protocolOf func(schema, table string) config.Protocol
+
+	// deadLetterQueue indicates the rows which fail to be encoded are diverted
+	// to the dead letter queue instead of failing the changefeed.
+	deadLetterQueue bool
 
 	outputCh chan *future
 
@@ -105,15 +139,46 @@ func NewEncoderGroup(
 		)
 	}
 
-	return &encoderGroup{
+	g := &encoderGroup{
 		changefeedID:     changefeedID,
 		rowEventEncoders: rowEventEncoders,
 		concurrency:      concurrency,
 		inputCh:          inputCh,
-		index:            0,
 		outputCh:         outCh,
 		bootstrapWorker:  bw,
-	}, nil
+		deadLetterQueue:  cfg.DeadLetterQueue != nil,
+	}
+	g.activeCount.Store(int32(concurrency))
+	return g, nil
+}
+
+// SetProtocolOverrides makes the rows of the tables whose protocol returned by protocolOf
+// is one of the override protocols encoded by the encoders of that protocol, encoderConfigs
+// are the encoder configs of the override protocols. It must be called before Run.
+func (g *encoderGroup) SetProtocolOverrides(
+	ctx context.Context,
+	protocolOf func(schema, table string) config.Protocol,
+	encoderConfigs map[config.Protocol]*common.Config,
+) error {
+	if len(encoderConfigs) == 0 {
+		return nil
+	}
+	g.protocolEncoders = make(map[config.Protocol][]common.EventEncoder, len(encoderConfigs))
+	for protocol, encoderConfig := range encoderConfigs {
+		encoders := make([]common.EventEncoder, g.concurrency)
+		for i := 0; i < g.concurrency; i++ {
+			encoder, err := NewEventEncoder(ctx, encoderConfig)
+			if err != nil {
+				log.Error("failed to create row event encoder",
+					zap.String("protocol", protocol.String()), zap.Error(err))
+				return errors.Trace(err)
+			}
+			encoders[i] = encoder
+		}
+		g.protocolEncoders[protocol] = encoders
+	}
+	g.protocolOf = protocolOf
+	return nil
 }
 
 func (g *encoderGroup) Run(ctx context.Context) error {
@@ -131,6 +196,12 @@ func (g *encoderGroup) Run(ctx context.Context) error {
 		})
 	}
 
+	if g.concurrency > 1 {
+		eg.Go(func() error {
+			return g.runScaler(ctx)
+		})
+	}
+
 	if g.bootstrapWorker != nil {
 		eg.Go(func() error {
 			return g.bootstrapWorker.run(ctx)
@@ -140,10 +211,55 @@ func (g *encoderGroup) Run(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// runScaler periodically adjusts the number of active encoder pipelines
+// according to the average time futures wait in the input channels.
+func (g *encoderGroup) runScaler(ctx context.Context) error {
+	ticker := time.NewTicker(defaultScaleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			g.scale()
+		}
+	}
+}
+
+func (g *encoderGroup) scale() {
+	count := g.queueLatencyCount.Swap(0)
+	sum := g.queueLatencySum.Swap(0)
+	if count == 0 {
+		// no events since the last check, keep the current concurrency.
+		return
+	}
+	avgLatency := time.Duration(sum / count)
+	active := g.activeCount.Load()
+	switch {
+	case avgLatency > scaleUpQueueLatency && int(active) < g.concurrency:
+		active++
+	case avgLatency < scaleDownQueueLatency && active > 1:
+		active--
+	default:
+		return
+	}
+	g.activeCount.Store(active)
+	encoderGroupActiveEncoderGauge.
+		WithLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name()).Set(float64(active))
+	log.Info("encoder group rescaled",
+		zap.String("namespace", g.changefeedID.Namespace()),
+		zap.String("changefeed", g.changefeedID.Name()),
+		zap.Duration("avgQueueLatency", avgLatency),
+		zap.Int32("activeEncoders", active),
+		zap.Int("concurrency", g.concurrency))
+}
+
 func (g *encoderGroup) runEncoder(ctx context.Context, idx int) error {
 	inputCh := g.inputCh[idx]
 	metric := encoderGroupInputChanSizeGauge.
 		WithLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name(), strconv.Itoa(idx))
+	latencyMetric := encoderGroupQueueLatencyHistogram.
+		WithLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name())
 	ticker := time.NewTicker(defaultMetricInterval)
 	defer ticker.Stop()
 	for {
@@ -153,10 +269,28 @@ func (g *encoderGroup) runEncoder(ctx context.Context, idx int) error {
 		case <-ticker.C:
 			metric.Set(float64(len(inputCh)))
 		case future := <-inputCh:
+			latency := time.Since(future.createTime)
+			latencyMetric.Observe(latency.Seconds())
+			g.queueLatencySum.Add(int64(latency))
+			g.queueLatencyCount.Add(1)
+			if g.protocolOf != nil {
+				if err := g.encodeWithOverrides(ctx, idx, future); err != nil {
+					return errors.Trace(err)
+				}
+				close(future.done)
+				continue
+			}
 			for _, event := range future.events {
 				err := g.rowEventEncoders[idx].AppendRowChangedEvent(ctx, future.Key.Topic, event)
 				if err != nil {
-					return errors.Trace(err)
+					if !g.deadLetterQueue || errors.Cause(err) == context.Canceled {
+						return errors.Trace(err)
+					}
+					message, dlqErr := newDeadLetterMessage(event, err)
+					if dlqErr != nil {
+						return errors.Trace(dlqErr)
+					}
+					future.DeadLetters = append(future.DeadLetters, message)
 				}
 			}
 			future.Messages = g.rowEventEncoders[idx].Build()
@@ -166,6 +300,44 @@ func (g *encoderGroup) runEncoder(ctx context.Context, idx int) error {
 	}
 }
 
+// encodeWithOverrides encodes the rows of the future by the encoders of their protocols,
+// the messages of each encoder are output in the order the encoders are first used.
+func (g *encoderGroup) encodeWithOverrides(ctx context.Context, idx int, future *future) error {
+	var (
+		used      []common.EventEncoder
+		lastTable *commonType.TableInfo
+		encoder   common.EventEncoder
+	)
+	for _, event := range future.events {
+		if event.TableInfo != lastTable {
+			lastTable = event.TableInfo
+			encoder = g.rowEventEncoders[idx]
+			protocol := g.protocolOf(event.TableInfo.GetSchemaName(), event.TableInfo.GetTableName())
+			if encoders, ok := g.protocolEncoders[protocol]; ok {
+				encoder = encoders[idx]
+			}
+			if !slices.Contains(used, encoder) {
+				used = append(used, encoder)
+			}
+		}
+		err := encoder.AppendRowChangedEvent(ctx, future.Key.Topic, event)
+		if err != nil {
+			if !g.deadLetterQueue || errors.Cause(err) == context.Canceled {
+				return errors.Trace(err)
+			}
+			message, dlqErr := newDeadLetterMessage(event, err)
+			if dlqErr != nil {
+				return errors.Trace(dlqErr)
+			}
+			future.DeadLetters = append(future.DeadLetters, message)
+		}
+	}
+	for _, encoder := range used {
+		future.Messages = append(future.Messages, encoder.Build()...)
+	}
+	return nil
+}
+
 func (g *encoderGroup) AddEvents(
 	ctx context.Context,
 	key model.TopicPartitionKey,
@@ -180,7 +352,7 @@ func (g *encoderGroup) AddEvents(
 	// }
 
 	future := newFuture(key, events...)
-	index := atomic.AddUint64(&g.index, 1) % uint64(g.concurrency)
+	index := g.getIndex(key)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -196,34 +368,89 @@ func (g *encoderGroup) AddEvents(
 	return nil
 }
 
+// getIndex returns the index of the encoder pipeline for the topic and partition.
+// Events of the same partition are dispatched to the same pipeline, so they are
+// appended to the encoder in order, while different partitions are encoded in parallel.
+func (g *encoderGroup) getIndex(key model.TopicPartitionKey) int {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(key.Topic))
+	hash := uint64(hasher.Sum32()) + uint64(key.Partition)
+	return int(hash % uint64(g.activeCount.Load()))
+}
+
 func (g *encoderGroup) Output() <-chan *future {
 	return g.outputCh
 }
 
+func (g *encoderGroup) ResendBootstrap(tableIDs []int64) bool {
+	if g.bootstrapWorker == nil {
+		return false
+	}
+	g.bootstrapWorker.requestResend(tableIDs)
+	return true
+}
+
 func (g *encoderGroup) cleanMetrics() {
-	encoderGroupInputChanSizeGauge.DeleteLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name())
+	for i := 0; i < g.concurrency; i++ {
+		encoderGroupInputChanSizeGauge.DeleteLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name(), strconv.Itoa(i))
+	}
+	encoderGroupActiveEncoderGauge.DeleteLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name())
+	encoderGroupQueueLatencyHistogram.DeleteLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name())
 	for _, encoder := range g.rowEventEncoders {
 		encoder.Clean()
 	}
+	for _, encoders := range g.protocolEncoders {
+		for _, encoder := range encoders {
+			encoder.Clean()
+		}
+	}
 	common.CleanMetrics(g.changefeedID)
 }
 
+// newDeadLetterMessage creates the message of the row which fails to be encoded,
+// the message is acked instead of the row.
+func newDeadLetterMessage(event *commonEvent.RowEvent, cause error) (*common.Message, error) {
+	record, err := dlq.NewRecord(event.TableInfo, event.CommitTs, &event.Event, 0, cause)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	log.Warn("divert the row which fails to be encoded to the dead letter queue",
+		zap.String("schema", record.Schema),
+		zap.String("table", record.Table),
+		zap.Uint64("commitTs", record.CommitTs),
+		zap.Error(cause))
+	value, err := record.Encode()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	message := common.NewMsg(nil, value)
+	message.Callback = event.Callback
+	message.SetRowsCount(1)
+	return message, nil
+}
+
 // future is a wrapper of the result of encoding events
 // It's used to notify the caller that the result is ready.
 type future struct {
 	Key      model.TopicPartitionKey
 	events   []*commonEvent.RowEvent
 	Messages []*common.Message
-	done     chan struct{}
+	// DeadLetters are the messages of the rows which fail to be encoded,
+	// they are sent to the dead letter queue topic.
+	DeadLetters []*common.Message
+	done        chan struct{}
+	// createTime is used to calculate the time the future waits in the input channel.
+	createTime time.Time
 }
 
 func newFuture(key model.TopicPartitionKey,
 	events ...*commonEvent.RowEvent,
 ) *future {
 	return &future{
-		Key:    key,
-		events: events,
-		done:   make(chan struct{}),
+		Key:        key,
+		events:     events,
+		done:       make(chan struct{}),
+		createTime: time.Now(),
 	}
 }
 
@@ -0,0 +1,253 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+// testEncoder records the appended events, every event is built as a message
+// whose value is the commit ts of the event.
+type testEncoder struct {
+	mu       sync.Mutex
+	appended map[string][]uint64
+	pending  []*common.Message
+}
+
+func newTestEncoder() *testEncoder {
+	return &testEncoder{appended: make(map[string][]uint64)}
+}
+
+func (e *testEncoder) EncodeCheckpointEvent(uint64) (*common.Message, error) { return nil, nil }
+
+func (e *testEncoder) EncodeDDLEvent(*commonEvent.DDLEvent) (*common.Message, error) {
+	return nil, nil
+}
+
+func (e *testEncoder) AppendRowChangedEvent(_ context.Context, topic string, event *commonEvent.RowEvent) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.appended[topic] = append(e.appended[topic], event.CommitTs)
+	e.pending = append(e.pending, common.NewMsg(nil, []byte(strconv.FormatUint(event.CommitTs, 10))))
+	return nil
+}
+
+func (e *testEncoder) Build() []*common.Message {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	messages := e.pending
+	e.pending = nil
+	return messages
+}
+
+func (e *testEncoder) Clean() {}
+
+func newTestEncoderGroup(concurrency int) (*encoderGroup, []*testEncoder) {
+	encoders := make([]*testEncoder, concurrency)
+	g := &encoderGroup{
+		changefeedID:     commonType.NewChangeFeedIDWithName("test"),
+		concurrency:      concurrency,
+		inputCh:          make([]chan *future, concurrency),
+		rowEventEncoders: make([]common.EventEncoder, concurrency),
+		outputCh:         make(chan *future, defaultInputChanSize*concurrency),
+	}
+	for i := 0; i < concurrency; i++ {
+		encoders[i] = newTestEncoder()
+		g.inputCh[i] = make(chan *future, defaultInputChanSize)
+		g.rowEventEncoders[i] = encoders[i]
+	}
+	g.activeCount.Store(int32(concurrency))
+	return g, encoders
+}
+
+func TestEncoderGroupGetIndex(t *testing.T) {
+	g, _ := newTestEncoderGroup(4)
+	used := make(map[int]bool)
+	for partition := int32(0); partition < 16; partition++ {
+		key := model.TopicPartitionKey{Topic: "test", Partition: partition}
+		index := g.getIndex(key)
+		// the same partition is always routed to the same encoder
+		require.Equal(t, index, g.getIndex(key))
+		used[index] = true
+	}
+	// the partitions are spread to all the encoders
+	require.Len(t, used, 4)
+
+	// only the active encoders are used after scaled down
+	g.activeCount.Store(2)
+	for partition := int32(0); partition < 16; partition++ {
+		require.Less(t, g.getIndex(model.TopicPartitionKey{Topic: "test", Partition: partition}), 2)
+	}
+}
+
+func TestEncoderGroupOutputInOrder(t *testing.T) {
+	g, encoders := newTestEncoderGroup(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = g.Run(ctx)
+	}()
+
+	const partitions, eventsPerPartition = 8, 20
+	expected := make([]uint64, 0, partitions*eventsPerPartition)
+	for i := 0; i < eventsPerPartition; i++ {
+		for partition := 0; partition < partitions; partition++ {
+			commitTs := uint64(i*partitions + partition + 1)
+			key := model.TopicPartitionKey{Topic: "p" + strconv.Itoa(partition), Partition: int32(partition)}
+			require.NoError(t, g.AddEvents(ctx, key, &commonEvent.RowEvent{CommitTs: commitTs}))
+			expected = append(expected, commitTs)
+		}
+	}
+
+	// the futures are output in the order they are added
+	for _, commitTs := range expected {
+		select {
+		case future := <-g.Output():
+			require.NoError(t, future.Ready(ctx))
+			require.Len(t, future.Messages, 1)
+			require.Equal(t, strconv.FormatUint(commitTs, 10), string(future.Messages[0].Value))
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "the future is not output in time")
+		}
+	}
+
+	// the events of a partition are appended to a single encoder in order
+	for partition := 0; partition < partitions; partition++ {
+		topic := "p" + strconv.Itoa(partition)
+		owners := 0
+		for _, encoder := range encoders {
+			encoder.mu.Lock()
+			appended := encoder.appended[topic]
+			encoder.mu.Unlock()
+			if len(appended) == 0 {
+				continue
+			}
+			owners++
+			require.Len(t, appended, eventsPerPartition)
+			for i := 1; i < len(appended); i++ {
+				require.Less(t, appended[i-1], appended[i])
+			}
+		}
+		require.Equal(t, 1, owners)
+	}
+}
+
+func TestEncoderGroupScale(t *testing.T) {
+	g, _ := newTestEncoderGroup(3)
+	g.activeCount.Store(1)
+
+	observe := func(latency time.Duration) {
+		g.queueLatencySum.Store(int64(latency))
+		g.queueLatencyCount.Store(1)
+		g.scale()
+	}
+	// no events since the last check
+	g.scale()
+	require.Equal(t, int32(1), g.activeCount.Load())
+
+	observe(time.Second)
+	require.Equal(t, int32(2), g.activeCount.Load())
+	observe(time.Second)
+	require.Equal(t, int32(3), g.activeCount.Load())
+	// never exceed the concurrency
+	observe(time.Second)
+	require.Equal(t, int32(3), g.activeCount.Load())
+
+	// the latency between the thresholds keeps the active count
+	observe(20 * time.Millisecond)
+	require.Equal(t, int32(3), g.activeCount.Load())
+
+	observe(time.Millisecond)
+	require.Equal(t, int32(2), g.activeCount.Load())
+	observe(time.Millisecond)
+	observe(time.Millisecond)
+	// at least one encoder is active
+	require.Equal(t, int32(1), g.activeCount.Load())
+}
+
+func TestEncoderGroupProtocolOverrides(t *testing.T) {
+	g, encoders := newTestEncoderGroup(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// no override protocol
+	require.NoError(t, g.SetProtocolOverrides(ctx, nil, nil))
+	require.Nil(t, g.protocolOf)
+
+	overrides := []*testEncoder{newTestEncoder()}
+	g.protocolEncoders = map[config.Protocol][]common.EventEncoder{config.ProtocolCanalJSON: {overrides[0]}}
+	g.protocolOf = func(schema, _ string) config.Protocol {
+		if schema == "legacy" {
+			return config.ProtocolCanalJSON
+		}
+		return config.ProtocolOpen
+	}
+	go func() {
+		_ = g.Run(ctx)
+	}()
+
+	legacy := commonType.WrapTableInfo(1, "legacy", &timodel.TableInfo{ID: 1, Name: pmodel.NewCIStr("t")})
+	normal := commonType.WrapTableInfo(2, "test", &timodel.TableInfo{ID: 2, Name: pmodel.NewCIStr("t")})
+	key := model.TopicPartitionKey{Topic: "test"}
+	require.NoError(t, g.AddEvents(ctx, key,
+		&commonEvent.RowEvent{CommitTs: 1, TableInfo: legacy},
+		&commonEvent.RowEvent{CommitTs: 2, TableInfo: normal},
+		&commonEvent.RowEvent{CommitTs: 3, TableInfo: legacy},
+	))
+	select {
+	case future := <-g.Output():
+		require.NoError(t, future.Ready(ctx))
+		// the messages of each encoder are output in the order the encoders are first used
+		require.Len(t, future.Messages, 3)
+		require.Equal(t, "1", string(future.Messages[0].Value))
+		require.Equal(t, "3", string(future.Messages[1].Value))
+		require.Equal(t, "2", string(future.Messages[2].Value))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the future is not output in time")
+	}
+	require.Equal(t, []uint64{1, 3}, overrides[0].appended["test"])
+	require.Equal(t, []uint64{2}, encoders[0].appended["test"])
+}
+
+func TestEncoderGroupSetProtocolOverrides(t *testing.T) {
+	g, _ := newTestEncoderGroup(2)
+	ctx := context.Background()
+	protocolOf := func(string, string) config.Protocol { return config.ProtocolCanalJSON }
+
+	err := g.SetProtocolOverrides(ctx, protocolOf, map[config.Protocol]*common.Config{
+		config.ProtocolCanalJSON: common.NewConfig(config.ProtocolCanalJSON),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, g.protocolOf)
+	// every encoder pipeline has an encoder of the override protocol
+	require.Len(t, g.protocolEncoders[config.ProtocolCanalJSON], 2)
+
+	err = g.SetProtocolOverrides(ctx, protocolOf, map[config.Protocol]*common.Config{
+		config.ProtocolAvro: common.NewConfig(config.ProtocolAvro),
+	})
+	require.Error(t, err)
+}
@@ -34,11 +34,28 @@ var (
 			Name:      "encoder_group_output_chan_size",
 			Help:      "The size of output channel of encoder group",
 		}, []string{"namespace", "changefeed"})
+	encoderGroupActiveEncoderGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "encoder_group_active_encoder_count",
+			Help:      "The number of active encoders in the encoder group",
+		}, []string{"namespace", "changefeed"})
+	encoderGroupQueueLatencyHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "encoder_group_queue_latency_seconds",
+			Help:      "The duration events wait in the input channel of encoder group",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 18), // 0.1ms~13s
+		}, []string{"namespace", "changefeed"})
 )
 
 // InitMetrics registers all metrics in this file
 func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(encoderGroupInputChanSizeGauge)
 	registry.MustRegister(EncoderGroupOutputChanSizeGauge)
+	registry.MustRegister(encoderGroupActiveEncoderGauge)
+	registry.MustRegister(encoderGroupQueueLatencyHistogram)
 	common.InitMetrics(registry)
 }
@@ -103,8 +103,7 @@ func encodeRowChangedEvent(e *commonEvent.RowEvent, config *common.Config, large
 	}
 
 	// for single message that is longer than max-message-bytes
-	// 16 is the length of `keyLenByte` and `valueLenByte`, 8 is the length of `versionHead`
-	length := len(key) + len(valueCompressed) + common.MaxRecordOverhead + 16 + 8
+	length := sizeEstimator.EstimateMessageSize(key, valueCompressed)
 	return key, valueCompressed, length, nil
 }
 
@@ -28,8 +28,30 @@ import (
 
 const (
 	batchVersion1 uint64 = 1
+
+	// versionHeadSize is the size of the version at the beginning of the batched key.
+	versionHeadSize = 8
+	// lengthPrefixSize is the size of the length before each key and value.
+	lengthPrefixSize = 8
 )
 
+// sizeEstimator estimates the size of the open protocol message.
+var sizeEstimator common.MessageSizeEstimator = batchSizeEstimator{}
+
+// batchSizeEstimator estimates the size of the batched message, each key and value is
+// prefixed by its length, and the keys are prefixed by the version of the batch.
+type batchSizeEstimator struct{}
+
+// EstimateMessageSize implements the MessageSizeEstimator interface.
+func (e batchSizeEstimator) EstimateMessageSize(key, value []byte) int {
+	return versionHeadSize + e.EstimateAppendSize(key, value) + common.MaxRecordOverhead
+}
+
+// EstimateAppendSize implements the MessageSizeEstimator interface.
+func (batchSizeEstimator) EstimateAppendSize(key, value []byte) int {
+	return 2*lengthPrefixSize + len(key) + len(value)
+}
+
 // BatchEncoder for open protocol will batch multiple row changed events into a single message.
 // One message can contain at most MaxBatchSize events, and the total size of the message cannot exceed MaxMessageBytes.
 type BatchEncoder struct {
@@ -123,7 +145,7 @@ func (d *BatchEncoder) Build() (messages []*common.Message) {
 }
 
 func (d *BatchEncoder) pushMessage(key, value []byte, callback func()) {
-	length := len(key) + len(value) + 16
+	length := sizeEstimator.EstimateAppendSize(key, value)
 
 	var (
 		keyLenByte   [8]byte
@@ -132,13 +154,14 @@ func (d *BatchEncoder) pushMessage(key, value []byte, callback func()) {
 	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
 	binary.BigEndian.PutUint64(valueLenByte[:], uint64(len(value)))
 
+	// split the batch before the message exceeds the max-message-bytes.
 	if len(d.messages) == 0 || d.messages[len(d.messages)-1].Length()+length > d.config.MaxMessageBytes || d.messages[len(d.messages)-1].GetRowsCount() >= d.config.MaxBatchSize {
 		d.finalizeCallback()
 		// create a new message
 		versionHead := make([]byte, 8)
 		binary.BigEndian.PutUint64(versionHead, batchVersion1)
 
-		message := common.NewMsg(versionHead, valueLenByte[:])
+		message := common.NewPooledMsg(versionHead, valueLenByte[:])
 		message.Key = append(message.Key, keyLenByte[:]...)
 		message.Key = append(message.Key, key...)
 		message.Value = append(message.Value, value...)
@@ -277,3 +277,99 @@ func TestLargeMessageWithoutHandle(t *testing.T) {
 	err = batchEncoder.AppendRowChangedEvent(ctx, "", insertRowEvent)
 	require.ErrorIs(t, err, errors.ErrOpenProtocolCodecInvalidData)
 }
+
+func BenchmarkEncoderAppendRowChangedEvent(b *testing.B) {
+	ctx := context.Background()
+	config := common.NewConfig(config.ProtocolOpen)
+	batchEncoder, err := NewBatchEncoder(ctx, config)
+	require.NoError(b, err)
+
+	helper := pevent.NewEventTestHelper(b)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(255), c double)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, "benchmark", 3.14)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(b, ok)
+
+	rowEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          insertRow,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() {},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := batchEncoder.AppendRowChangedEvent(ctx, "", rowEvent); err != nil {
+			b.Fatal(err)
+		}
+		for _, message := range batchEncoder.Build() {
+			message.Ack()
+		}
+	}
+}
+
+func TestMessageSizeEstimation(t *testing.T) {
+	ctx := context.Background()
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a tinyint primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 123)`,
+		`insert into test.t values (2, 223)`, `insert into test.t values (3, 333)`)
+
+	var rowEvents []*pevent.RowEvent
+	for {
+		row, ok := dmlEvent.GetNextRow()
+		if !ok {
+			break
+		}
+		rowEvents = append(rowEvents, &pevent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       1,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() {},
+		})
+	}
+
+	// the estimated size of a batch of the first two rows.
+	codecConfig := common.NewConfig(config.ProtocolOpen)
+	key, value, length, err := encodeRowChangedEvent(rowEvents[0], codecConfig, false, "")
+	require.NoError(t, err)
+	require.Equal(t, length, sizeEstimator.EstimateMessageSize(key, value))
+	key, value, _, err = encodeRowChangedEvent(rowEvents[1], codecConfig, false, "")
+	require.NoError(t, err)
+	length += sizeEstimator.EstimateAppendSize(key, value)
+
+	// the batch is split exactly when the next row exceeds the max-message-bytes.
+	batchEncoder, err := NewBatchEncoder(ctx, codecConfig.WithMaxMessageBytes(length))
+	require.NoError(t, err)
+	for _, e := range rowEvents {
+		require.NoError(t, batchEncoder.AppendRowChangedEvent(ctx, "", e))
+	}
+	messages := batchEncoder.Build()
+	require.Len(t, messages, 2)
+	require.Equal(t, 2, messages[0].GetRowsCount())
+	require.Equal(t, length, messages[0].Length())
+	require.Equal(t, 1, messages[1].GetRowsCount())
+
+	batchEncoder, err = NewBatchEncoder(ctx, codecConfig.WithMaxMessageBytes(length-1))
+	require.NoError(t, err)
+	for _, e := range rowEvents {
+		require.NoError(t, batchEncoder.AppendRowChangedEvent(ctx, "", e))
+	}
+	messages = batchEncoder.Build()
+	require.Len(t, messages, 3)
+	for _, m := range messages {
+		require.Equal(t, 1, m.GetRowsCount())
+		require.LessOrEqual(t, m.Length(), length-1)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// MessageType is the type of the dead letter message sent to the MQ downstream.
+const MessageType = "DEAD_LETTER"
+
+// Row types of the record.
+const (
+	RowTypeInsert = "insert"
+	RowTypeUpdate = "update"
+	RowTypeDelete = "delete"
+)
+
+// Record is a row which can not be processed by the sink and is diverted
+// to the dead letter queue, together with the error metadata.
+type Record struct {
+	Type      string `json:"type"`
+	Schema    string `json:"schema"`
+	Table     string `json:"table"`
+	CommitTs  uint64 `json:"commit_ts"`
+	RowType   string `json:"row_type"`
+	ErrorCode int    `json:"error_code,omitempty"`
+	Error     string `json:"error"`
+	// Columns and PreColumns are the values of the row after and before the change.
+	Columns    map[string]interface{} `json:"columns,omitempty"`
+	PreColumns map[string]interface{} `json:"pre_columns,omitempty"`
+}
+
+// NewRecord creates the dead letter record of the row.
+func NewRecord(
+	tableInfo *common.TableInfo, commitTs uint64, row *commonEvent.RowChange, errorCode int, cause error,
+) (*Record, error) {
+	record := &Record{
+		Type:      MessageType,
+		Schema:    tableInfo.GetSchemaName(),
+		Table:     tableInfo.GetTableName(),
+		CommitTs:  commitTs,
+		ErrorCode: errorCode,
+	}
+	if cause != nil {
+		record.Error = cause.Error()
+	}
+	switch {
+	case row.PreRow.IsEmpty():
+		record.RowType = RowTypeInsert
+	case row.Row.IsEmpty():
+		record.RowType = RowTypeDelete
+	default:
+		record.RowType = RowTypeUpdate
+	}
+
+	var err error
+	if !row.Row.IsEmpty() {
+		record.Columns, err = columnValues(tableInfo, &row.Row)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !row.PreRow.IsEmpty() {
+		record.PreColumns, err = columnValues(tableInfo, &row.PreRow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// Encode encodes the record into json.
+func (r *Record) Encode() ([]byte, error) {
+	data, err := json.Marshal(r)
+	return data, errors.Trace(err)
+}
+
+func columnValues(tableInfo *common.TableInfo, row *chunk.Row) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(tableInfo.GetColumns()))
+	for i, col := range tableInfo.GetColumns() {
+		if col == nil {
+			continue
+		}
+		v, err := common.FormatColVal(row, col, i)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		values[col.Name.O] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecord(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 10
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	record, err := NewRecord(dmlEvent.TableInfo, dmlEvent.CommitTs, &row, 1406, errors.New("data too long"))
+	require.NoError(t, err)
+	require.Equal(t, "test", record.Schema)
+	require.Equal(t, "t", record.Table)
+	require.Equal(t, uint64(10), record.CommitTs)
+	require.Equal(t, RowTypeInsert, record.RowType)
+	require.Nil(t, record.PreColumns)
+
+	data, err := record.Encode()
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, MessageType, decoded["type"])
+	require.Equal(t, "data too long", decoded["error"])
+	require.Equal(t, float64(1406), decoded["error_code"])
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "test"}, decoded["columns"])
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// unknownBroker is used if the leader of the partition is not known yet.
+	unknownBroker int32 = -1
+
+	// an ack is considered as an anomaly if its latency is larger than both
+	// anomalyLatencyFactor times the average latency of the broker and anomalyMinLatency.
+	anomalyLatencyFactor = 5
+	anomalyMinLatency    = 500 * time.Millisecond
+	// latencySmoothFactor is the weight of the new latency in the average latency.
+	latencySmoothFactor = 0.1
+	// minLeaderRefreshInterval limits the frequency of the proactive metadata refreshing.
+	minLeaderRefreshInterval = 10 * time.Second
+)
+
+// messageMeta is attached to the produced message to track it until it is acked.
+type messageMeta struct {
+	callback func()
+	broker   int32
+	sendTime time.Time
+}
+
+// metadataClient is the part of the kafka client used to find the partition leaders.
+type metadataClient interface {
+	Partitions(topic string) ([]int32, error)
+	LeaderID(topic string, partition int32) (int32, error)
+	RefreshMetadata(topics ...string) error
+}
+
+type saramaMetadataClient struct {
+	sarama.Client
+}
+
+func (c saramaMetadataClient) LeaderID(topic string, partition int32) (int32, error) {
+	leader, err := c.Leader(topic, partition)
+	if err != nil {
+		return unknownBroker, err
+	}
+	return leader.ID(), nil
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// brokerTracker tracks the in-flight messages and the ack latency of each broker,
+// and refreshes the partition leaders proactively if the latency of a broker becomes
+// abnormal, which usually means the leader is moved and the cached metadata is stale.
+type brokerTracker struct {
+	changefeedID common.ChangeFeedID
+	client       metadataClient
+
+	// inFlight caches the in-flight messages gauge of each broker.
+	inFlight sync.Map
+	// leaders caches the leader broker of each partition, it's loaded in the background
+	// since the client may refresh the metadata synchronously to find the leader.
+	leaders sync.Map
+	// loading is the topics whose leaders are being loaded.
+	loading sync.Map
+
+	// avgLatency and lastRefresh are only accessed by the callback goroutine.
+	avgLatency  map[int32]time.Duration
+	lastRefresh time.Time
+	refreshing  atomic.Bool
+}
+
+func newBrokerTracker(changefeedID common.ChangeFeedID, client sarama.Client) *brokerTracker {
+	return &brokerTracker{
+		changefeedID: changefeedID,
+		client:       saramaMetadataClient{Client: client},
+		avgLatency:   make(map[int32]time.Duration),
+	}
+}
+
+// onSend returns the meta of the message sent to the given partition.
+func (t *brokerTracker) onSend(topic string, partition int32, callback func()) *messageMeta {
+	broker := t.leaderOf(topic, partition)
+	t.inFlightGauge(broker).Inc()
+	return &messageMeta{
+		callback: callback,
+		broker:   broker,
+		sendTime: time.Now(),
+	}
+}
+
+// onAck records the latency of the acked message, and refreshes the metadata
+// of the topic if the latency is abnormal.
+func (t *brokerTracker) onAck(topic string, meta *messageMeta) {
+	t.inFlightGauge(meta.broker).Dec()
+	if meta.broker == unknownBroker {
+		return
+	}
+	latency := time.Since(meta.sendTime)
+	avg, ok := t.avgLatency[meta.broker]
+	if !ok {
+		t.avgLatency[meta.broker] = latency
+		return
+	}
+	t.avgLatency[meta.broker] = avg + time.Duration(latencySmoothFactor*float64(latency-avg))
+	if latency < anomalyMinLatency || latency < anomalyLatencyFactor*avg {
+		return
+	}
+	t.refreshLeaders(topic, meta.broker, latency, avg)
+}
+
+// onError releases the message which is failed to be produced, the leaders of the topic
+// are reloaded since the error may be caused by the moved leaders.
+func (t *brokerTracker) onError(topic string, meta *messageMeta) {
+	t.inFlightGauge(meta.broker).Dec()
+	t.loadLeaders(topic)
+}
+
+// leaderOf returns the cached leader of the partition, the leaders of the topic are
+// loaded in the background if it's not cached, and unknownBroker is returned.
+func (t *brokerTracker) leaderOf(topic string, partition int32) int32 {
+	if broker, ok := t.leaders.Load(topicPartition{topic: topic, partition: partition}); ok {
+		return broker.(int32)
+	}
+	t.loadLeaders(topic)
+	return unknownBroker
+}
+
+// loadLeaders loads the leaders of the topic in the background, it's a no-op if
+// the leaders of the topic are being loaded.
+func (t *brokerTracker) loadLeaders(topic string) {
+	if _, loading := t.loading.LoadOrStore(topic, struct{}{}); loading {
+		return
+	}
+	go func() {
+		defer t.loading.Delete(topic)
+		t.updateLeaders(topic)
+	}()
+}
+
+// updateLeaders caches the leaders of the topic found in the metadata of the client.
+func (t *brokerTracker) updateLeaders(topic string) {
+	partitions, err := t.client.Partitions(topic)
+	if err != nil {
+		log.Warn("get kafka topic partitions failed",
+			zap.String("namespace", t.changefeedID.Namespace()),
+			zap.String("changefeed", t.changefeedID.Name()),
+			zap.String("topic", topic),
+			zap.Error(err))
+		return
+	}
+	for _, partition := range partitions {
+		key := topicPartition{topic: topic, partition: partition}
+		broker, err := t.client.LeaderID(topic, partition)
+		if err != nil {
+			t.leaders.Delete(key)
+			continue
+		}
+		t.leaders.Store(key, broker)
+	}
+}
+
+func (t *brokerTracker) refreshLeaders(
+	topic string, broker int32, latency, avg time.Duration,
+) {
+	if time.Since(t.lastRefresh) < minLeaderRefreshInterval || !t.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	t.lastRefresh = time.Now()
+	leaderRefreshCounter.WithLabelValues(t.changefeedID.Namespace(), t.changefeedID.Name()).Inc()
+	log.Info("kafka producer ack latency is abnormal, refresh the partition leaders",
+		zap.String("namespace", t.changefeedID.Namespace()),
+		zap.String("changefeed", t.changefeedID.Name()),
+		zap.String("topic", topic),
+		zap.Int32("broker", broker),
+		zap.Duration("latency", latency),
+		zap.Duration("avgLatency", avg))
+	// refresh the metadata in the background to not block the callbacks.
+	go func() {
+		defer t.refreshing.Store(false)
+		start := time.Now()
+		if err := t.client.RefreshMetadata(topic); err != nil {
+			log.Warn("refresh kafka partition leaders failed",
+				zap.String("namespace", t.changefeedID.Namespace()),
+				zap.String("changefeed", t.changefeedID.Name()),
+				zap.String("topic", topic),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+			return
+		}
+		t.updateLeaders(topic)
+	}()
+}
+
+func (t *brokerTracker) inFlightGauge(broker int32) prometheus.Gauge {
+	if g, ok := t.inFlight.Load(broker); ok {
+		return g.(prometheus.Gauge)
+	}
+	g, _ := t.inFlight.LoadOrStore(broker, inFlightMessagesGauge.WithLabelValues(
+		t.changefeedID.Namespace(), t.changefeedID.Name(), strconv.Itoa(int(broker))))
+	return g.(prometheus.Gauge)
+}
+
+func (t *brokerTracker) cleanupMetrics() {
+	t.inFlight.Range(func(key, _ any) bool {
+		inFlightMessagesGauge.DeleteLabelValues(
+			t.changefeedID.Namespace(), t.changefeedID.Name(), strconv.Itoa(int(key.(int32))))
+		return true
+	})
+	leaderRefreshCounter.DeleteLabelValues(t.changefeedID.Namespace(), t.changefeedID.Name())
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMetadataClient struct {
+	mu        sync.Mutex
+	leaders   map[int32]int32
+	lookups   int
+	refreshed int
+}
+
+func (c *mockMetadataClient) Partitions(string) ([]int32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	partitions := make([]int32, 0, len(c.leaders))
+	for partition := range c.leaders {
+		partitions = append(partitions, partition)
+	}
+	return partitions, nil
+}
+
+func (c *mockMetadataClient) LeaderID(_ string, partition int32) (int32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lookups++
+	if broker, ok := c.leaders[partition]; ok {
+		return broker, nil
+	}
+	return unknownBroker, errors.New("leader not available")
+}
+
+func (c *mockMetadataClient) RefreshMetadata(...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshed++
+	return nil
+}
+
+func (c *mockMetadataClient) setLeader(partition, broker int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.leaders[partition] = broker
+}
+
+func TestBrokerTrackerCachesLeaders(t *testing.T) {
+	client := &mockMetadataClient{leaders: map[int32]int32{0: 1, 1: 2}}
+	tracker := &brokerTracker{
+		changefeedID: common.NewChangeFeedIDWithName("test"),
+		client:       client,
+		avgLatency:   make(map[int32]time.Duration),
+	}
+	defer tracker.cleanupMetrics()
+
+	// the leaders are loaded in the background at the first send
+	meta := tracker.onSend("topic", 0, nil)
+	require.Equal(t, unknownBroker, meta.broker)
+	require.Eventually(t, func() bool {
+		return tracker.leaderOf("topic", 1) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the cached leaders are used without looking up the client
+	client.mu.Lock()
+	lookups := client.lookups
+	client.mu.Unlock()
+	for i := 0; i < 10; i++ {
+		meta = tracker.onSend("topic", 0, nil)
+		require.Equal(t, int32(1), meta.broker)
+		tracker.onAck("topic", meta)
+	}
+	client.mu.Lock()
+	require.Equal(t, lookups, client.lookups)
+	client.mu.Unlock()
+
+	// the leaders are reloaded after a produce error
+	client.setLeader(0, 3)
+	tracker.onError("topic", meta)
+	require.Eventually(t, func() bool {
+		return tracker.leaderOf("topic", 0) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// and after the metadata is refreshed by an abnormal ack latency
+	tracker.onAck("topic", tracker.onSend("topic", 0, nil))
+	client.setLeader(0, 4)
+	meta = tracker.onSend("topic", 0, nil)
+	meta.sendTime = time.Now().Add(-anomalyLatencyFactor * anomalyMinLatency)
+	tracker.onAck("topic", meta)
+	require.Eventually(t, func() bool {
+		return tracker.leaderOf("topic", 0) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	client.mu.Lock()
+	require.Equal(t, 1, client.refreshed)
+	client.mu.Unlock()
+}
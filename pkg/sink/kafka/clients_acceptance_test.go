@@ -0,0 +1,262 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	codecCommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/pingcap/ticdc/pkg/sink/kafka/franz"
+	v2 "github.com/pingcap/ticdc/pkg/sink/kafka/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+const (
+	acceptanceTopic      = "acceptance"
+	acceptancePartitions = 3
+)
+
+// acceptanceClients are all the kafka clients which can be selected by the sink uri,
+// every client must pass the same acceptance suite.
+var acceptanceClients = map[string]kafka.FactoryCreator{
+	kafka.ClientSarama:  kafka.NewSaramaFactory,
+	kafka.ClientKafkaGo: v2.NewFactory,
+	kafka.ClientFranzGo: franz.NewFactory,
+}
+
+func newAcceptanceFactory(
+	t *testing.T, creator kafka.FactoryCreator,
+) (kafka.Factory, *kfake.Cluster) {
+	cluster, err := kfake.NewCluster(
+		kfake.NumBrokers(3),
+		kfake.SeedTopics(acceptancePartitions, acceptanceTopic),
+	)
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	// kfake only accepts the record batches whose partition leader epoch is -1,
+	// but sarama always sends 0, the epoch is not covered by the CRC.
+	cluster.ControlKey(int16(kmsg.Produce), func(req kmsg.Request) (kmsg.Response, error, bool) {
+		for _, topic := range req.(*kmsg.ProduceRequest).Topics {
+			for _, partition := range topic.Partitions {
+				if len(partition.Records) >= 16 {
+					binary.BigEndian.PutUint32(partition.Records[12:16], math.MaxUint32)
+				}
+			}
+		}
+		return nil, nil, false
+	})
+
+	options := kafka.NewOptions()
+	options.BrokerEndpoints = cluster.ListenAddrs()
+	options.ClientID = "ticdc-acceptance"
+	options.DialTimeout = 5 * time.Second
+	factory, err := creator(context.Background(), options, common.NewChangeFeedIDWithName("acceptance"))
+	require.NoError(t, err)
+	return factory, cluster
+}
+
+// consumeAll reads `count` records of the topic from the beginning.
+func consumeAll(t *testing.T, cluster *kfake.Cluster, topic string, count int) []*kgo.Record {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	records := make([]*kgo.Record, 0, count)
+	for len(records) < count {
+		fetches := client.PollFetches(ctx)
+		require.NoError(t, ctx.Err())
+		fetches.EachError(func(_ string, _ int32, err error) {
+			require.NoError(t, err)
+		})
+		records = append(records, fetches.Records()...)
+	}
+	require.Len(t, records, count)
+	return records
+}
+
+func TestClientsAcceptanceAdmin(t *testing.T) {
+	for name, creator := range acceptanceClients {
+		t.Run(name, func(t *testing.T) {
+			factory, _ := newAcceptanceFactory(t, creator)
+			admin, err := factory.AdminClient()
+			require.NoError(t, err)
+			defer admin.Close()
+			ctx := context.Background()
+
+			brokers, err := admin.GetAllBrokers(ctx)
+			require.NoError(t, err)
+			require.Len(t, brokers, 3)
+
+			value, err := admin.GetBrokerConfig(ctx, kafka.BrokerMessageMaxBytesConfigName)
+			require.NoError(t, err)
+			require.Equal(t, "1048588", value)
+			value, err = admin.GetTopicConfig(ctx, acceptanceTopic, kafka.TopicMaxMessageBytesConfigName)
+			require.NoError(t, err)
+			require.Equal(t, "1048588", value)
+
+			partitions, err := admin.GetTopicsPartitionsNum(ctx, []string{acceptanceTopic})
+			require.NoError(t, err)
+			require.Equal(t, map[string]int32{acceptanceTopic: acceptancePartitions}, partitions)
+
+			meta, err := admin.GetTopicsMeta(ctx, []string{acceptanceTopic, "unknown"}, true)
+			require.NoError(t, err)
+			require.Len(t, meta, 1)
+			require.Equal(t, int32(acceptancePartitions), meta[acceptanceTopic].NumPartitions)
+
+			detail := &kafka.TopicDetail{Name: "created", NumPartitions: 2, ReplicationFactor: 1}
+			require.NoError(t, admin.CreateTopic(ctx, detail, true))
+			require.NoError(t, admin.CreateTopic(ctx, detail, false))
+			// creating an existing topic is not an error.
+			require.NoError(t, admin.CreateTopic(ctx, detail, false))
+			require.Eventually(t, func() bool {
+				partitions, err = admin.GetTopicsPartitionsNum(ctx, []string{detail.Name})
+				return err == nil && partitions[detail.Name] == 2
+			}, 10*time.Second, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestClientsAcceptanceSyncProducer(t *testing.T) {
+	for name, creator := range acceptanceClients {
+		t.Run(name, func(t *testing.T) {
+			factory, cluster := newAcceptanceFactory(t, creator)
+			producer, err := factory.SyncProducer()
+			require.NoError(t, err)
+			defer producer.Close()
+			ctx := context.Background()
+
+			message := &codecCommon.Message{Key: []byte("key"), Value: []byte("value")}
+			require.NoError(t, producer.SendMessage(ctx, acceptanceTopic, 1, message))
+			// SendMessages broadcasts the message to all the partitions.
+			broadcast := &codecCommon.Message{Key: []byte("checkpoint"), Value: []byte("ts")}
+			require.NoError(t, producer.SendMessages(ctx, acceptanceTopic, acceptancePartitions, broadcast))
+
+			records := consumeAll(t, cluster, acceptanceTopic, 1+acceptancePartitions)
+			broadcastPartitions := make(map[int32]struct{})
+			for _, record := range records {
+				switch string(record.Key) {
+				case "key":
+					require.Equal(t, "value", string(record.Value))
+					require.Equal(t, int32(1), record.Partition)
+				case "checkpoint":
+					require.Equal(t, "ts", string(record.Value))
+					broadcastPartitions[record.Partition] = struct{}{}
+				default:
+					t.Fatalf("unexpected record key %s", record.Key)
+				}
+			}
+			require.Len(t, broadcastPartitions, acceptancePartitions)
+		})
+	}
+}
+
+func TestClientsAcceptanceAsyncProducer(t *testing.T) {
+	for name, creator := range acceptanceClients {
+		t.Run(name, func(t *testing.T) {
+			factory, cluster := newAcceptanceFactory(t, creator)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			producer, err := factory.AsyncProducer(ctx)
+			require.NoError(t, err)
+			defer producer.Close()
+
+			callbackErr := make(chan error, 1)
+			go func() {
+				callbackErr <- producer.AsyncRunCallback(ctx)
+			}()
+
+			const count = 100
+			var (
+				mu    sync.Mutex
+				acked = make(map[int32][]int)
+			)
+			done := make(chan struct{}, count)
+			for i := 0; i < count; i++ {
+				partition := int32(i % acceptancePartitions)
+				message := &codecCommon.Message{
+					Key:   []byte(fmt.Sprintf("key-%d", i)),
+					Value: []byte(fmt.Sprintf("value-%d", i)),
+				}
+				message.Callback = func() {
+					mu.Lock()
+					acked[partition] = append(acked[partition], i)
+					mu.Unlock()
+					done <- struct{}{}
+				}
+				require.NoError(t, producer.AsyncSend(ctx, acceptanceTopic, partition, message))
+			}
+			for i := 0; i < count; i++ {
+				select {
+				case <-done:
+				case err := <-callbackErr:
+					t.Fatalf("callback loop exited: %v", err)
+				case <-time.After(30 * time.Second):
+					t.Fatalf("only %d messages are acknowledged", i)
+				}
+			}
+
+			// the callbacks of the same partition run in the sending order.
+			mu.Lock()
+			for partition, indexes := range acked {
+				for j := 1; j < len(indexes); j++ {
+					require.Less(t, indexes[j-1], indexes[j], "partition %d", partition)
+				}
+			}
+			mu.Unlock()
+
+			records := consumeAll(t, cluster, acceptanceTopic, count)
+			for _, record := range records {
+				var i int
+				_, err := fmt.Sscanf(string(record.Key), "key-%d", &i)
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("value-%d", i), string(record.Value))
+				require.Equal(t, int32(i%acceptancePartitions), record.Partition)
+			}
+		})
+	}
+}
+
+func TestClientsAcceptanceUnknownTopic(t *testing.T) {
+	for name, creator := range acceptanceClients {
+		t.Run(name, func(t *testing.T) {
+			factory, _ := newAcceptanceFactory(t, creator)
+			admin, err := factory.AdminClient()
+			require.NoError(t, err)
+			defer admin.Close()
+
+			_, err = admin.GetTopicsMeta(context.Background(), []string{"unknown"}, false)
+			require.Error(t, err)
+			_, err = admin.GetBrokerConfig(context.Background(), "unknown.config")
+			require.True(t, cerror.ErrKafkaConfigNotFound.Equal(err), err)
+		})
+	}
+}
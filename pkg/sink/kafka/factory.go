@@ -163,9 +163,11 @@ type saramaAsyncProducer struct {
 	producer     sarama.AsyncProducer
 	changefeedID commonType.ChangeFeedID
 	failpointCh  chan error
+	tracker      *brokerTracker
 }
 
 func (p *saramaAsyncProducer) Close() {
+	p.tracker.cleanupMetrics()
 	go func() {
 		// We need to close it asynchronously. Otherwise, we might get stuck
 		// with an unhealthy(i.e. Network jitter, isolation) state of Kafka.
@@ -233,9 +235,10 @@ func (p *saramaAsyncProducer) AsyncRunCallback(
 			return errors.Trace(err)
 		case ack := <-p.producer.Successes():
 			if ack != nil {
-				callback := ack.Metadata.(func())
-				if callback != nil {
-					callback()
+				meta := ack.Metadata.(*messageMeta)
+				p.tracker.onAck(ack.Topic, meta)
+				if meta.callback != nil {
+					meta.callback()
 				}
 			}
 		case err := <-p.producer.Errors():
@@ -247,6 +250,9 @@ func (p *saramaAsyncProducer) AsyncRunCallback(
 			if err == nil {
 				return nil
 			}
+			if err.Msg != nil {
+				p.tracker.onError(err.Msg.Topic, err.Msg.Metadata.(*messageMeta))
+			}
 			return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
 		}
 	}
@@ -260,14 +266,25 @@ func (p *saramaAsyncProducer) AsyncSend(
 	msg := &sarama.ProducerMessage{
 		Topic:     topic,
 		Partition: partition,
-		Key:       sarama.StringEncoder(message.Key),
+		Key:       messageKey(message.Key),
 		Value:     sarama.ByteEncoder(message.Value),
-		Metadata:  message.Callback,
+		Metadata:  p.tracker.onSend(topic, partition, message.Ack),
 	}
 	select {
 	case <-ctx.Done():
+		p.tracker.onError(topic, msg.Metadata.(*messageMeta))
 		return errors.Trace(ctx.Err())
 	case p.producer.Input() <- msg:
 	}
 	return nil
 }
+
+// messageKey returns the encoder of the message key. The messages without a key, like
+// the canal-json DML messages, are sent with an empty key rather than a null one, since
+// the compacted topics reject the messages with a null key.
+func messageKey(key []byte) sarama.Encoder {
+	if key == nil {
+		return sarama.ByteEncoder([]byte{})
+	}
+	return sarama.ByteEncoder(key)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncSendKeepsEmptyKey(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config)
+	defer producer.Close()
+	p := &saramaAsyncProducer{
+		producer: producer,
+		tracker: &brokerTracker{
+			changefeedID: commonType.NewChangeFeedIDWithName("test"),
+			client:       &mockMetadataClient{leaders: map[int32]int32{0: 1}},
+			avgLatency:   make(map[int32]time.Duration),
+		},
+	}
+	defer p.tracker.cleanupMetrics()
+
+	cases := []struct {
+		key      []byte
+		expected []byte
+	}{
+		// the canal-json DML messages have no key, they are sent with an empty key
+		{nil, []byte{}},
+		{[]byte{}, []byte{}},
+		{[]byte("key"), []byte("key")},
+	}
+	for _, c := range cases {
+		producer.ExpectInputAndSucceed()
+		err := p.AsyncSend(context.Background(), "topic", 0, common.NewMsg(c.key, []byte("value")))
+		require.NoError(t, err)
+		msg := <-producer.Successes()
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		require.NotNil(t, key)
+		require.Equal(t, c.expected, key)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+type admin struct {
+	client       *kadm.Client
+	changefeedID common.ChangeFeedID
+}
+
+func newClusterAdminClient(
+	client *kgo.Client,
+	changefeedID common.ChangeFeedID,
+) pkafka.ClusterAdminClient {
+	return &admin{
+		client:       kadm.NewClient(client),
+		changefeedID: changefeedID,
+	}
+}
+
+func (a *admin) GetAllBrokers(ctx context.Context) ([]pkafka.Broker, error) {
+	meta, err := a.client.BrokerMetadata(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make([]pkafka.Broker, 0, len(meta.Brokers))
+	for _, broker := range meta.Brokers {
+		result = append(result, pkafka.Broker{
+			ID: broker.NodeID,
+		})
+	}
+	return result, nil
+}
+
+func (a *admin) GetBrokerConfig(ctx context.Context, configName string) (string, error) {
+	meta, err := a.client.BrokerMetadata(ctx)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	configs, err := a.client.DescribeBrokerConfigs(ctx, meta.Controller)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok, err := findConfig(configs, configName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !ok {
+		log.Warn("Kafka config item not found",
+			zap.String("configName", configName))
+		return "", cerror.ErrKafkaConfigNotFound.GenWithStack(
+			"cannot find the `%s` from the broker's configuration", configName)
+	}
+	return value, nil
+}
+
+func (a *admin) GetTopicConfig(ctx context.Context, topicName string, configName string) (string, error) {
+	configs, err := a.client.DescribeTopicConfigs(ctx, topicName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok, err := findConfig(configs, configName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !ok {
+		log.Warn("Kafka config item not found",
+			zap.String("configName", configName))
+		return "", cerror.ErrKafkaConfigNotFound.GenWithStack(
+			"cannot find the `%s` from the topic's configuration", configName)
+	}
+	log.Info("Kafka config item found",
+		zap.String("namespace", a.changefeedID.Namespace()),
+		zap.String("changefeed", a.changefeedID.Name()),
+		zap.String("configName", configName),
+		zap.String("configValue", value))
+	return value, nil
+}
+
+// findConfig returns the value of the `configName` in the first resource.
+// For compatibility with KOP, we checked all return values.
+// 1. Kafka only returns requested configs.
+// 2. Kop returns all configs.
+func findConfig(configs kadm.ResourceConfigs, configName string) (string, bool, error) {
+	if len(configs) == 0 {
+		return "", false, nil
+	}
+	if configs[0].Err != nil {
+		return "", false, configs[0].Err
+	}
+	for _, entry := range configs[0].Configs {
+		if entry.Key == configName && entry.Value != nil {
+			return *entry.Value, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (a *admin) GetTopicsMeta(
+	ctx context.Context,
+	topics []string,
+	ignoreTopicError bool,
+) (map[string]pkafka.TopicDetail, error) {
+	meta, err := a.client.Metadata(ctx, topics...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[string]pkafka.TopicDetail, len(meta.Topics))
+	for _, topic := range meta.Topics {
+		if topic.Err != nil {
+			if !ignoreTopicError {
+				return nil, errors.Trace(topic.Err)
+			}
+			log.Warn("fetch topic meta failed",
+				zap.String("topic", topic.Topic), zap.Error(topic.Err))
+			continue
+		}
+		result[topic.Topic] = pkafka.TopicDetail{
+			Name:          topic.Topic,
+			NumPartitions: int32(len(topic.Partitions)),
+		}
+	}
+	return result, nil
+}
+
+func (a *admin) GetTopicsPartitionsNum(
+	ctx context.Context, topics []string,
+) (map[string]int32, error) {
+	meta, err := a.client.Metadata(ctx, topics...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[string]int32, len(topics))
+	for _, topic := range meta.Topics {
+		result[topic.Topic] = int32(len(topic.Partitions))
+	}
+	return result, nil
+}
+
+func (a *admin) CreateTopic(
+	ctx context.Context,
+	detail *pkafka.TopicDetail,
+	validateOnly bool,
+) error {
+	create := a.client.CreateTopics
+	if validateOnly {
+		create = a.client.ValidateCreateTopics
+	}
+	responses, err := create(ctx, detail.NumPartitions, detail.ReplicationFactor, nil, detail.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, response := range responses {
+		if response.Err != nil && errors.Cause(response.Err) != kerr.TopicAlreadyExists {
+			return errors.Trace(response.Err)
+		}
+	}
+	return nil
+}
+
+func (a *admin) Close() {
+	log.Info("admin client start closing",
+		zap.String("namespace", a.changefeedID.Namespace()),
+		zap.String("changefeed", a.changefeedID.Name()))
+	a.client.Close()
+	log.Info("kafka admin client is fully closed",
+		zap.String("namespace", a.changefeedID.Namespace()),
+		zap.String("changefeed", a.changefeedID.Name()))
+}
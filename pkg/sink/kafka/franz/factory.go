@@ -0,0 +1,393 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"go.uber.org/zap"
+)
+
+// closeTimeout is the max time to wait for the buffered messages to be flushed
+// when the producer is closed.
+const closeTimeout = 10 * time.Second
+
+type factory struct {
+	changefeedID commonType.ChangeFeedID
+	options      *pkafka.Options
+	// baseOpts are shared by the admin client and all producers.
+	baseOpts []kgo.Opt
+
+	// metrics is registered as a hook of every producer created by the factory.
+	metrics *MetricsCollector
+}
+
+// NewFactory returns a factory implemented based on franz-go
+func NewFactory(
+	_ context.Context,
+	options *pkafka.Options,
+	changefeedID commonType.ChangeFeedID,
+) (pkafka.Factory, error) {
+	baseOpts, err := newBaseOpts(options)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &factory{
+		changefeedID: changefeedID,
+		options:      options,
+		baseOpts:     baseOpts,
+		metrics:      NewMetricsCollector(changefeedID),
+	}, nil
+}
+
+func newBaseOpts(o *pkafka.Options) ([]kgo.Opt, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(o.BrokerEndpoints...),
+		kgo.ClientID(o.ClientID),
+	}
+	if o.DialTimeout > 0 {
+		opts = append(opts, kgo.DialTimeout(o.DialTimeout))
+	}
+	tlsConfig, err := completeSSLConfig(o)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+	mechanism, err := completeSASLConfig(o)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mechanism != nil {
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+	return opts, nil
+}
+
+func completeSSLConfig(options *pkafka.Options) (*tls.Config, error) {
+	if options.EnableTLS {
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			NextProtos: []string{"h2", "http/1.1"},
+		}
+
+		// for SSL encryption with self-signed CA certificate, we reassign the
+		// config.Net.TLS.Config using the relevant credential files.
+		if options.Credential != nil && options.Credential.IsTLSEnabled() {
+			tlsConfig, err := pkafka.NewReloadableTLSConfig(options.Credential, options.InsecureSkipVerify)
+			return tlsConfig, errors.Trace(err)
+		}
+
+		tlsConfig.InsecureSkipVerify = options.InsecureSkipVerify
+		return tlsConfig, nil
+	}
+	return nil, nil
+}
+
+func completeSASLConfig(o *pkafka.Options) (sasl.Mechanism, error) {
+	if o.SASL == nil || o.SASL.SASLMechanism == "" {
+		return nil, nil
+	}
+	switch strings.ToUpper(string(o.SASL.SASLMechanism)) {
+	case pkafka.SASLTypeSCRAMSHA256:
+		return scram.Auth{User: o.SASL.SASLUser, Pass: o.SASL.SASLPassword}.AsSha256Mechanism(), nil
+	case pkafka.SASLTypeSCRAMSHA512:
+		return scram.Auth{User: o.SASL.SASLUser, Pass: o.SASL.SASLPassword}.AsSha512Mechanism(), nil
+	case pkafka.SASLTypePlaintext:
+		return plain.Auth{User: o.SASL.SASLUser, Pass: o.SASL.SASLPassword}.AsMechanism(), nil
+	}
+	return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+		"%s is not yet supported in the franz-go kafka client", o.SASL.SASLMechanism)
+}
+
+// producerOpts returns the options used by the producers, the batch bytes
+// can't exceed the max message bytes.
+func (f *factory) producerOpts() []kgo.Opt {
+	o := f.options
+	opts := append([]kgo.Opt{}, f.baseOpts...)
+	opts = append(opts,
+		kgo.RecordPartitioner(kgo.ManualPartitioner()),
+		kgo.WithHooks(f.metrics),
+	)
+	switch o.RequiredAcks {
+	case pkafka.NoResponse:
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	case pkafka.WaitForLocal:
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	default:
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+	if o.WriteTimeout > 0 {
+		opts = append(opts, kgo.ProduceRequestTimeout(o.WriteTimeout))
+	}
+
+	batchBytes := o.MaxMessageBytes
+	if o.BatchBytes > 0 && o.BatchBytes < batchBytes {
+		batchBytes = o.BatchBytes
+	}
+	if batchBytes > 0 {
+		opts = append(opts, kgo.ProducerBatchMaxBytes(int32(batchBytes)))
+	}
+	// set linger to 5ms to avoid waste too much time on waiting for messages.
+	linger := 5 * time.Millisecond
+	if o.BatchLinger > 0 {
+		linger = o.BatchLinger
+	}
+	opts = append(opts, kgo.ProducerLinger(linger))
+
+	compression := strings.ToLower(strings.TrimSpace(o.Compression))
+	switch compression {
+	case "none", "":
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.NoCompression()))
+	case "gzip":
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.GzipCompression()))
+	case "snappy":
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.SnappyCompression()))
+	case "lz4":
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.Lz4Compression()))
+	case "zstd":
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.ZstdCompression()))
+	default:
+		log.Warn("Unsupported compression algorithm",
+			zap.String("namespace", f.changefeedID.Namespace()),
+			zap.String("changefeed", f.changefeedID.Name()),
+			zap.String("compression", o.Compression))
+	}
+	log.Info("Kafka producer uses "+o.Compression+" compression algorithm",
+		zap.String("namespace", f.changefeedID.Namespace()),
+		zap.String("changefeed", f.changefeedID.Name()))
+	return opts
+}
+
+func (f *factory) AdminClient() (pkafka.ClusterAdminClient, error) {
+	client, err := kgo.NewClient(f.baseOpts...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	return newClusterAdminClient(client, f.changefeedID), nil
+}
+
+// SyncProducer creates a sync producer to writer message to kafka
+func (f *factory) SyncProducer() (pkafka.SyncProducer, error) {
+	client, err := kgo.NewClient(f.producerOpts()...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	return &syncProducer{
+		client:       client,
+		changefeedID: f.changefeedID,
+	}, nil
+}
+
+// AsyncProducer creates an async producer to writer message to kafka
+func (f *factory) AsyncProducer(
+	_ context.Context,
+) (pkafka.AsyncProducer, error) {
+	client, err := kgo.NewClient(f.producerOpts()...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	return &asyncProducer{
+		client:       client,
+		changefeedID: f.changefeedID,
+		failpointCh:  make(chan error, 1),
+		errorsChan:   make(chan error, 1),
+		successes:    make(chan func(), 1024),
+		closed:       make(chan struct{}),
+	}, nil
+}
+
+// MetricsCollector returns the kafka metrics collector
+func (f *factory) MetricsCollector(
+	_ pkafka.ClusterAdminClient,
+) pkafka.MetricsCollector {
+	return f.metrics
+}
+
+type syncProducer struct {
+	changefeedID commonType.ChangeFeedID
+	client       *kgo.Client
+}
+
+func (s *syncProducer) SendMessage(
+	ctx context.Context,
+	topic string, partitionNum int32,
+	message *common.Message,
+) error {
+	err := s.client.ProduceSync(ctx, &kgo.Record{
+		Topic:     topic,
+		Partition: partitionNum,
+		Key:       message.Key,
+		Value:     message.Value,
+	}).FirstErr()
+	return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
+}
+
+// SendMessages produces a given set of messages, and returns only when all
+// messages in the set have either succeeded or failed. Note that messages
+// can succeed and fail individually; if some succeed and some fail,
+// SendMessages will return an error.
+func (s *syncProducer) SendMessages(
+	ctx context.Context, topic string, partitionNum int32, message *common.Message,
+) error {
+	records := make([]*kgo.Record, int(partitionNum))
+	for i := 0; i < int(partitionNum); i++ {
+		records[i] = &kgo.Record{
+			Topic:     topic,
+			Partition: int32(i),
+			Key:       message.Key,
+			Value:     message.Value,
+		}
+	}
+	err := s.client.ProduceSync(ctx, records...).FirstErr()
+	return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
+}
+
+// Close shuts down the producer; you must call this function before a producer
+// object passes out of scope, as it may otherwise leak memory.
+func (s *syncProducer) Close() {
+	log.Info("kafka sync producer start closing",
+		zap.String("namespace", s.changefeedID.Namespace()),
+		zap.String("changefeed", s.changefeedID.Name()))
+	start := time.Now()
+	s.client.Close()
+	log.Info("Close kafka sync producer success",
+		zap.String("namespace", s.changefeedID.Namespace()),
+		zap.String("changefeed", s.changefeedID.Name()),
+		zap.Duration("duration", time.Since(start)))
+}
+
+type asyncProducer struct {
+	client       *kgo.Client
+	changefeedID commonType.ChangeFeedID
+	failpointCh  chan error
+	errorsChan   chan error
+	// successes holds the callbacks of the acknowledged messages, they are
+	// run by AsyncRunCallback in the order of the acknowledgements.
+	successes chan func()
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Close shuts down the producer and waits for any buffered messages to be
+// flushed. You must call this function before a producer object passes out of
+// scope, as it may otherwise leak memory. You must call this before process
+// shutting down, or you may lose messages.
+func (a *asyncProducer) Close() {
+	a.closeOnce.Do(func() {
+		log.Info("kafka async producer start closing",
+			zap.String("namespace", a.changefeedID.Namespace()),
+			zap.String("changefeed", a.changefeedID.Name()))
+		close(a.closed)
+		go func() {
+			start := time.Now()
+			ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+			defer cancel()
+			if err := a.client.Flush(ctx); err != nil {
+				log.Warn("Flush kafka async producer failed",
+					zap.String("namespace", a.changefeedID.Namespace()),
+					zap.String("changefeed", a.changefeedID.Name()),
+					zap.Duration("duration", time.Since(start)),
+					zap.Error(err))
+			}
+			a.client.Close()
+			log.Info("Close kafka async producer success",
+				zap.String("namespace", a.changefeedID.Namespace()),
+				zap.String("changefeed", a.changefeedID.Name()),
+				zap.Duration("duration", time.Since(start)))
+		}()
+	})
+}
+
+// AsyncSend is the input channel for the user to write messages to that they
+// wish to send.
+func (a *asyncProducer) AsyncSend(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-a.closed:
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	default:
+	}
+	ack := message.Ack
+	a.client.Produce(ctx, &kgo.Record{
+		Topic:     topic,
+		Partition: partition,
+		Key:       message.Key,
+		Value:     message.Value,
+	}, func(_ *kgo.Record, err error) {
+		if err != nil {
+			select {
+			case <-a.closed:
+			case a.errorsChan <- err:
+			default:
+				log.Warn("async producer report error failed, since the err channel is full",
+					zap.String("namespace", a.changefeedID.Namespace()),
+					zap.String("changefeed", a.changefeedID.Name()),
+					zap.Error(err))
+			}
+			return
+		}
+		select {
+		case <-a.closed:
+		case a.successes <- ack:
+		}
+	})
+	return nil
+}
+
+// AsyncRunCallback process the messages that has sent to kafka,
+// and run tha attached callback. the caller should call this
+// method in a background goroutine
+func (a *asyncProducer) AsyncRunCallback(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-a.closed:
+			return nil
+		case err := <-a.failpointCh:
+			log.Warn("Receive from failpoint chan in kafka producer",
+				zap.String("namespace", a.changefeedID.Namespace()),
+				zap.String("changefeed", a.changefeedID.Name()),
+				zap.Error(err))
+			return errors.Trace(err)
+		case err := <-a.errorsChan:
+			if err == nil {
+				return nil
+			}
+			return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
+		case callback := <-a.successes:
+			callback()
+		}
+	}
+}
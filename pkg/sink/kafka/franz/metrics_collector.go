@@ -0,0 +1,171 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+var (
+	_ kgo.HookBrokerE2E               = (*MetricsCollector)(nil)
+	_ kgo.HookProduceBatchWritten     = (*MetricsCollector)(nil)
+	_ kgo.HookProduceRecordUnbuffered = (*MetricsCollector)(nil)
+)
+
+// brokerStats is the statistics of a broker accumulated between two collections.
+type brokerStats struct {
+	requests     int64
+	latency      time.Duration
+	bytesWritten int64
+}
+
+// MetricsCollector is the kafka metrics collector based on franz-go library.
+// It's registered as the hooks of the producer clients and accumulates the
+// statistics, which are reported to prometheus periodically.
+type MetricsCollector struct {
+	changefeedID common.ChangeFeedID
+
+	mu      sync.Mutex
+	brokers map[int32]*brokerStats
+	// reported records the brokers whose metrics were reported, so they can be cleaned up.
+	reported     map[int32]struct{}
+	batches      int64
+	batchRecords int64
+	batchBytes   int64
+	errors       int64
+}
+
+// NewMetricsCollector return a kafka metrics collector
+func NewMetricsCollector(changefeedID common.ChangeFeedID) *MetricsCollector {
+	return &MetricsCollector{
+		changefeedID: changefeedID,
+		brokers:      make(map[int32]*brokerStats),
+		reported:     make(map[int32]struct{}),
+	}
+}
+
+// OnBrokerE2E implements the kgo.HookBrokerE2E interface.
+func (m *MetricsCollector) OnBrokerE2E(meta kgo.BrokerMetadata, _ int16, e2e kgo.BrokerE2E) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats, ok := m.brokers[meta.NodeID]
+	if !ok {
+		stats = &brokerStats{}
+		m.brokers[meta.NodeID] = stats
+	}
+	stats.requests++
+	stats.latency += e2e.DurationE2E()
+	stats.bytesWritten += int64(e2e.BytesWritten)
+}
+
+// OnProduceBatchWritten implements the kgo.HookProduceBatchWritten interface.
+func (m *MetricsCollector) OnProduceBatchWritten(
+	_ kgo.BrokerMetadata, _ string, _ int32, metrics kgo.ProduceBatchMetrics,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches++
+	m.batchRecords += int64(metrics.NumRecords)
+	m.batchBytes += int64(metrics.UncompressedBytes)
+}
+
+// OnProduceRecordUnbuffered implements the kgo.HookProduceRecordUnbuffered interface.
+func (m *MetricsCollector) OnProduceRecordUnbuffered(_ *kgo.Record, err error) {
+	if err == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors++
+}
+
+// Run implement the MetricsCollector interface
+func (m *MetricsCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(kafka.RefreshMetricsInterval)
+	defer func() {
+		ticker.Stop()
+		m.cleanupMetrics()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Kafka metrics collector stopped",
+				zap.String("namespace", m.changefeedID.Namespace()),
+				zap.String("changefeed", m.changefeedID.Name()))
+			return
+		case <-ticker.C:
+			m.collectMetrics()
+		}
+	}
+}
+
+func (m *MetricsCollector) collectMetrics() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	namespace, changefeed := m.changefeedID.Namespace(), m.changefeedID.Name()
+	seconds := kafka.RefreshMetricsInterval.Seconds()
+	for id, stats := range m.brokers {
+		brokerID := strconv.Itoa(int(id))
+		kafka.RequestRateGauge.WithLabelValues(namespace, changefeed, brokerID).
+			Set(float64(stats.requests) / seconds)
+		kafka.OutgoingByteRateGauge.WithLabelValues(namespace, changefeed, brokerID).
+			Set(float64(stats.bytesWritten) / seconds)
+		latency := time.Duration(0)
+		if stats.requests > 0 {
+			latency = stats.latency / time.Duration(stats.requests)
+		}
+		kafka.RequestLatencyGauge.WithLabelValues(namespace, changefeed, brokerID, "avg").
+			Set(latency.Seconds())
+		m.reported[id] = struct{}{}
+	}
+	clear(m.brokers)
+
+	var avgRecords, avgBytes float64
+	if m.batches > 0 {
+		avgRecords = float64(m.batchRecords) / float64(m.batches)
+		avgBytes = float64(m.batchBytes) / float64(m.batches)
+	}
+	kafka.BatchMessageCountGauge.WithLabelValues(namespace, changefeed).Set(avgRecords)
+	kafka.BatchSizeGauge.WithLabelValues(namespace, changefeed).Set(avgBytes)
+	m.batches, m.batchRecords, m.batchBytes = 0, 0, 0
+
+	kafka.ClientErrorGauge.WithLabelValues(namespace, changefeed).Set(float64(m.errors))
+}
+
+func (m *MetricsCollector) cleanupMetrics() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	namespace, changefeed := m.changefeedID.Namespace(), m.changefeedID.Name()
+	for id := range m.reported {
+		brokerID := strconv.Itoa(int(id))
+		kafka.RequestRateGauge.DeleteLabelValues(namespace, changefeed, brokerID)
+		kafka.OutgoingByteRateGauge.DeleteLabelValues(namespace, changefeed, brokerID)
+		kafka.RequestLatencyGauge.DeleteLabelValues(namespace, changefeed, brokerID, "avg")
+	}
+	kafka.BatchMessageCountGauge.DeleteLabelValues(namespace, changefeed)
+	kafka.BatchSizeGauge.DeleteLabelValues(namespace, changefeed)
+	kafka.ClientErrorGauge.DeleteLabelValues(namespace, changefeed)
+}
@@ -24,6 +24,22 @@ var (
 			Help: "The current number of in-flight requests" +
 				" awaiting a response for all brokers.",
 		}, []string{"namespace", "changefeed", "broker"})
+	// inFlightMessagesGauge tracks the messages sent to each broker but not acked yet.
+	inFlightMessagesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "kafka_producer_in_flight_messages",
+			Help:      "The current number of messages sent to the broker but not acked yet.",
+		}, []string{"namespace", "changefeed", "broker"})
+	// leaderRefreshCounter counts the metadata refreshing caused by the abnormal ack latency.
+	leaderRefreshCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "kafka_producer_leader_refresh_count",
+			Help:      "The number of partition leader refreshing caused by abnormal ack latency.",
+		}, []string{"namespace", "changefeed"})
 	// OutgoingByteRateGauge for outgoing events.
 	// Meter mark for each request's size in bytes.
 	OutgoingByteRateGauge = prometheus.NewGaugeVec(
@@ -130,6 +146,8 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(RequestLatencyGauge)
 	registry.MustRegister(requestsInFlightGauge)
 	registry.MustRegister(responseRateGauge)
+	registry.MustRegister(inFlightMessagesGauge)
+	registry.MustRegister(leaderRefreshCounter)
 
 	// only used by kafka sink v2.
 	registry.MustRegister(BatchDurationGauge)
@@ -156,9 +156,9 @@ func (p *MockSaramaAsyncProducer) AsyncSend(ctx context.Context, topic string, p
 	msg := &sarama.ProducerMessage{
 		Topic:     topic,
 		Partition: partition,
-		Key:       sarama.StringEncoder(message.Key),
+		Key:       messageKey(message.Key),
 		Value:     sarama.ByteEncoder(message.Value),
-		Metadata:  message.Callback,
+		Metadata:  message.Ack,
 	}
 	select {
 	case <-ctx.Done():
@@ -17,20 +17,19 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/gin-gonic/gin/binding"
 	"github.com/imdario/mergo"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/sinkuri"
 	"github.com/pingcap/tiflow/pkg/security"
 	"go.uber.org/zap"
 )
@@ -64,6 +63,16 @@ const (
 	MinInsyncReplicasConfigName = "min.insync.replicas"
 )
 
+// The kafka clients which can be selected by the `kafka-client` parameter of the sink uri.
+const (
+	// ClientSarama is the default kafka client implemented by sarama.
+	ClientSarama = "sarama"
+	// ClientKafkaGo is the kafka client implemented by kafka-go.
+	ClientKafkaGo = "kafka-go"
+	// ClientFranzGo is the kafka client implemented by franz-go.
+	ClientFranzGo = "franz-go"
+)
+
 const (
 	// SASLTypePlaintext represents the plain mechanism
 	SASLTypePlaintext = "PLAIN"
@@ -121,6 +130,8 @@ type urlConfig struct {
 	DialTimeout                  *string `form:"dial-timeout"`
 	WriteTimeout                 *string `form:"write-timeout"`
 	ReadTimeout                  *string `form:"read-timeout"`
+	BatchLinger                  *string `form:"batch-linger"`
+	BatchBytes                   *int    `form:"batch-bytes"`
 	RequiredAcks                 *int    `form:"required-acks"`
 	SASLUser                     *string `form:"sasl-user"`
 	SASLPassword                 *string `form:"sasl-password"`
@@ -170,6 +181,13 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// BatchLinger and BatchBytes control how long and how many bytes the producer
+	// accumulates the messages before flushing them. The messages are accumulated
+	// per broker, so a produce request carries the messages of all the partitions
+	// led by the same broker. Zero means flushing the messages as soon as possible.
+	BatchLinger time.Duration
+	BatchBytes  int
 }
 
 // NewOptions returns a default Kafka configuration
@@ -227,10 +245,9 @@ func (o *Options) Apply(changefeedID common.ChangeFeedID,
 	o.BrokerEndpoints = strings.Split(sinkURI.Host, ",")
 
 	var err error
-	req := &http.Request{URL: sinkURI}
 	urlParameter := &urlConfig{}
-	if err = binding.Query.Bind(req, urlParameter); err != nil {
-		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	if err = sinkuri.BindQuery(sinkURI, urlParameter); err != nil {
+		return err
 	}
 	if urlParameter, err = mergeConfig(sinkConfig, urlParameter); err != nil {
 		return err
@@ -300,6 +317,26 @@ func (o *Options) Apply(changefeedID common.ChangeFeedID,
 		o.ReadTimeout = a
 	}
 
+	if urlParameter.BatchLinger != nil && *urlParameter.BatchLinger != "" {
+		a, err := time.ParseDuration(*urlParameter.BatchLinger)
+		if err != nil {
+			return err
+		}
+		if a < 0 {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"batch-linger %s must not be negative", *urlParameter.BatchLinger)
+		}
+		o.BatchLinger = a
+	}
+
+	if urlParameter.BatchBytes != nil {
+		if *urlParameter.BatchBytes < 0 {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"batch-bytes %d must not be negative", *urlParameter.BatchBytes)
+		}
+		o.BatchBytes = *urlParameter.BatchBytes
+	}
+
 	if urlParameter.RequiredAcks != nil {
 		r, err := requireAcksFromString(*urlParameter.RequiredAcks)
 		if err != nil {
@@ -338,6 +375,8 @@ func mergeConfig(
 		dest.DialTimeout = fileConifg.DialTimeout
 		dest.WriteTimeout = fileConifg.WriteTimeout
 		dest.ReadTimeout = fileConifg.ReadTimeout
+		dest.BatchLinger = fileConifg.BatchLinger
+		dest.BatchBytes = fileConifg.BatchBytes
 		dest.RequiredAcks = fileConifg.RequiredAcks
 		dest.SASLUser = fileConifg.SASLUser
 		dest.SASLPassword = fileConifg.SASLPassword
@@ -363,16 +402,23 @@ func mergeConfig(
 }
 
 func (o *Options) applyTLS(params *urlConfig) error {
+	var err error
 	if params.CA != nil && *params.CA != "" {
-		o.Credential.CAPath = *params.CA
+		if o.Credential.CAPath, err = resolveSecretPath(*params.CA); err != nil {
+			return err
+		}
 	}
 
 	if params.Cert != nil && *params.Cert != "" {
-		o.Credential.CertPath = *params.Cert
+		if o.Credential.CertPath, err = resolveSecretPath(*params.Cert); err != nil {
+			return err
+		}
 	}
 
 	if params.Key != nil && *params.Key != "" {
-		o.Credential.KeyPath = *params.Key
+		if o.Credential.KeyPath, err = resolveSecretPath(*params.Key); err != nil {
+			return err
+		}
 	}
 
 	if o.Credential != nil && !o.Credential.IsEmpty() &&
@@ -410,12 +456,17 @@ func (o *Options) applyTLS(params *urlConfig) error {
 }
 
 func (o *Options) applySASL(urlParameter *urlConfig, sinkConfig *config.SinkConfig) error {
+	var err error
 	if urlParameter.SASLUser != nil && *urlParameter.SASLUser != "" {
-		o.SASL.SASLUser = *urlParameter.SASLUser
+		if o.SASL.SASLUser, err = resolveSecret(*urlParameter.SASLUser); err != nil {
+			return err
+		}
 	}
 
 	if urlParameter.SASLPassword != nil && *urlParameter.SASLPassword != "" {
-		o.SASL.SASLPassword = *urlParameter.SASLPassword
+		if o.SASL.SASLPassword, err = resolveSecret(*urlParameter.SASLPassword); err != nil {
+			return err
+		}
 	}
 
 	if urlParameter.SASLMechanism != nil && *urlParameter.SASLMechanism != "" {
@@ -452,7 +503,9 @@ func (o *Options) applySASL(urlParameter *urlConfig, sinkConfig *config.SinkConf
 	}
 
 	if urlParameter.SASLGssAPIPassword != nil && *urlParameter.SASLGssAPIPassword != "" {
-		o.SASL.GSSAPI.Password = *urlParameter.SASLGssAPIPassword
+		if o.SASL.GSSAPI.Password, err = resolveSecret(*urlParameter.SASLGssAPIPassword); err != nil {
+			return err
+		}
 	}
 
 	if urlParameter.SASLGssAPIRealm != nil && *urlParameter.SASLGssAPIRealm != "" {
@@ -479,14 +532,21 @@ func (o *Options) applySASL(urlParameter *urlConfig, sinkConfig *config.SinkConf
 					"OAuth2 client secret cannot be empty")
 			}
 
-			// BASE64 decode the client secret
-			decodedClientSecret, err := base64.StdEncoding.DecodeString(clientSecret)
-			if err != nil {
-				log.Error("OAuth2 client secret is not base64 encoded", zap.Error(err))
-				return cerror.ErrKafkaInvalidConfig.GenWithStack(
-					"OAuth2 client secret is not base64 encoded")
+			// the referenced secret is not stored in the changefeed config, so it is used as is.
+			if isSecretReference(clientSecret) {
+				if o.SASL.OAuth2.ClientSecret, err = resolveSecret(clientSecret); err != nil {
+					return err
+				}
+			} else {
+				// BASE64 decode the client secret
+				decodedClientSecret, err := base64.StdEncoding.DecodeString(clientSecret)
+				if err != nil {
+					log.Error("OAuth2 client secret is not base64 encoded", zap.Error(err))
+					return cerror.ErrKafkaInvalidConfig.GenWithStack(
+						"OAuth2 client secret is not base64 encoded")
+				}
+				o.SASL.OAuth2.ClientSecret = string(decodedClientSecret)
 			}
-			o.SASL.OAuth2.ClientSecret = string(decodedClientSecret)
 		}
 
 		if sinkConfig.KafkaConfig.SASLOAuthTokenURL != nil {
@@ -59,10 +59,13 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 	config.Producer.Retry.Max = 0
 	config.Producer.Retry.Backoff = 100 * time.Millisecond
 
-	// make sure sarama producer flush messages as soon as possible.
-	config.Producer.Flush.Bytes = 0
+	// make sure sarama producer flush messages as soon as possible by default.
+	// sarama accumulates the messages per broker, if the batch is enabled, the messages
+	// of all partitions led by the same broker are flushed in one produce request,
+	// which reduces the requests a lot if there are many partitions.
+	config.Producer.Flush.Bytes = o.BatchBytes
 	config.Producer.Flush.Messages = 0
-	config.Producer.Flush.Frequency = time.Duration(0)
+	config.Producer.Flush.Frequency = o.BatchLinger
 	config.Producer.Flush.MaxMessages = o.MaxMessages
 
 	config.Net.MaxOpenRequests = 1
@@ -107,13 +110,13 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 		// for SSL encryption with self-signed CA certificate, we reassign the
 		// config.Net.TLS.Config using the relevant credential files.
 		if o.Credential != nil && o.Credential.IsTLSEnabled() {
-			config.Net.TLS.Config, err = o.Credential.ToTLSConfig()
+			config.Net.TLS.Config, err = NewReloadableTLSConfig(o.Credential, o.InsecureSkipVerify)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
+		} else {
+			config.Net.TLS.Config.InsecureSkipVerify = o.InsecureSkipVerify
 		}
-
-		config.Net.TLS.Config.InsecureSkipVerify = o.InsecureSkipVerify
 	}
 
 	err = completeSaramaSASLConfig(ctx, config, o)
@@ -120,6 +120,7 @@ func (f *saramaFactory) AsyncProducer(_ context.Context) (AsyncProducer, error)
 		producer:     p,
 		changefeedID: f.changefeedID,
 		failpointCh:  make(chan error, 1),
+		tracker:      newBrokerTracker(f.changefeedID, client),
 	}, nil
 }
 
@@ -0,0 +1,151 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+const (
+	// secretEnvScheme references a secret stored in an environment variable, e.g. env://KAFKA_PASSWORD.
+	secretEnvScheme = "env://"
+	// secretFileScheme references a secret stored in a file, e.g. file:///etc/kafka/password.
+	secretFileScheme = "file://"
+	// secretMountScheme references a key of a mounted kubernetes-style secret,
+	// e.g. secret://kafka-credential/password is resolved to <SecretMountPath>/kafka-credential/password.
+	secretMountScheme = "secret://"
+)
+
+// SecretMountPath is the directory where the kubernetes-style secrets are mounted.
+var SecretMountPath = "/var/run/secrets/ticdc"
+
+// isSecretReference returns true if the value references a secret instead of holding it in plaintext.
+func isSecretReference(value string) bool {
+	return strings.HasPrefix(value, secretEnvScheme) ||
+		strings.HasPrefix(value, secretFileScheme) ||
+		strings.HasPrefix(value, secretMountScheme)
+}
+
+// secretMountFile returns the file path of the key of a mounted secret referenced by ref,
+// ref must be in the form of name/key.
+func secretMountFile(ref string) (string, error) {
+	name, key, ok := strings.Cut(ref, "/")
+	if !ok || name == "" || key == "" || strings.Contains(key, "/") ||
+		name == ".." || key == ".." {
+		return "", cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"invalid secret reference %s%s, it should be in the form of %sname/key",
+			secretMountScheme, ref, secretMountScheme)
+	}
+	return filepath.Join(SecretMountPath, name, key), nil
+}
+
+// secretEnv returns the value of the environment variable referenced by env://,
+// the variable must be allowed by the secret reference config of the server.
+func secretEnv(name string) (string, error) {
+	cfg := config.GetGlobalServerConfig().SecretReference
+	if cfg == nil || !slices.Contains(cfg.AllowedEnvs, name) {
+		return "", cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"environment variable %s is not allowed to be referenced by the secret, "+
+				"add it to secret-reference.allowed-envs of the server config", name)
+	}
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"environment variable %s referenced by the secret is not set", name)
+	}
+	return secret, nil
+}
+
+// secretFile returns the path of the file referenced by file://, the file must be
+// in one of the directories allowed by the secret reference config of the server.
+// The symbolic links are resolved before checking, so they can't escape the directories.
+func secretFile(path string) (string, error) {
+	cfg := config.GetGlobalServerConfig().SecretReference
+	if cfg != nil && filepath.IsAbs(path) {
+		resolved := evalSymlinks(path)
+		for _, dir := range cfg.AllowedDirs {
+			rel, err := filepath.Rel(evalSymlinks(dir), resolved)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return path, nil
+			}
+		}
+	}
+	return "", cerror.ErrKafkaInvalidConfig.GenWithStack(
+		"file %s is not allowed to be referenced by the secret, "+
+			"add its directory to secret-reference.allowed-dirs of the server config", path)
+}
+
+func evalSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// resolveSecret returns the plaintext of the value. The value can reference a secret
+// by the env://, file:// or secret:// scheme, or hold the plaintext directly.
+// The env:// and file:// references must be allowed by the server config.
+func resolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretEnvScheme):
+		return secretEnv(strings.TrimPrefix(value, secretEnvScheme))
+	case strings.HasPrefix(value, secretFileScheme):
+		path, err := secretFile(strings.TrimPrefix(value, secretFileScheme))
+		if err != nil {
+			return "", err
+		}
+		return readSecretFile(path)
+	case strings.HasPrefix(value, secretMountScheme):
+		path, err := secretMountFile(strings.TrimPrefix(value, secretMountScheme))
+		if err != nil {
+			return "", err
+		}
+		return readSecretFile(path)
+	default:
+		return value, nil
+	}
+}
+
+// resolveSecretPath returns the file path of the value, it is used by the options
+// which are file paths, such as the TLS certificates. The file:// and secret:// references
+// are resolved to the referenced file, and env:// is resolved to the path held by the
+// environment variable, so that the rotated files can be reloaded from the same path.
+func resolveSecretPath(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretEnvScheme):
+		return resolveSecret(value)
+	case strings.HasPrefix(value, secretFileScheme):
+		return secretFile(strings.TrimPrefix(value, secretFileScheme))
+	case strings.HasPrefix(value, secretMountScheme):
+		return secretMountFile(strings.TrimPrefix(value, secretMountScheme))
+	default:
+		return value, nil
+	}
+}
+
+func readSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrKafkaInvalidConfig,
+			errors.Annotatef(err, "read secret file %s failed", path))
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// setSecretReferenceConfig sets the secret reference config of the server for the test.
+func setSecretReferenceConfig(t *testing.T, cfg *config.SecretReferenceConfig) {
+	serverConfig := config.GetDefaultServerConfig()
+	serverConfig.SecretReference = cfg
+	config.StoreGlobalServerConfig(serverConfig)
+	t.Cleanup(func() { config.StoreGlobalServerConfig(config.GetDefaultServerConfig()) })
+}
+
+func TestResolveSecretPlaintext(t *testing.T) {
+	setSecretReferenceConfig(t, nil)
+	for _, value := range []string{"", "password", "envx://KAFKA_PASSWORD", "/etc/kafka/password"} {
+		require.False(t, isSecretReference(value))
+		secret, err := resolveSecret(value)
+		require.NoError(t, err)
+		require.Equal(t, value, secret)
+	}
+}
+
+func TestResolveSecretEnv(t *testing.T) {
+	t.Setenv("TEST_KAFKA_PASSWORD", "env-password")
+	t.Setenv("TEST_KAFKA_OTHER", "other")
+
+	// nothing can be referenced without the config
+	setSecretReferenceConfig(t, nil)
+	_, err := resolveSecret("env://TEST_KAFKA_PASSWORD")
+	require.ErrorContains(t, err, "not allowed")
+
+	setSecretReferenceConfig(t, &config.SecretReferenceConfig{
+		AllowedEnvs: []string{"TEST_KAFKA_PASSWORD", "TEST_KAFKA_UNSET"},
+	})
+	require.True(t, isSecretReference("env://TEST_KAFKA_PASSWORD"))
+	secret, err := resolveSecret("env://TEST_KAFKA_PASSWORD")
+	require.NoError(t, err)
+	require.Equal(t, "env-password", secret)
+
+	// the variable is not in the allow-list
+	_, err = resolveSecret("env://TEST_KAFKA_OTHER")
+	require.ErrorContains(t, err, "not allowed")
+	// the variable is allowed but not set
+	_, err = resolveSecret("env://TEST_KAFKA_UNSET")
+	require.ErrorContains(t, err, "not set")
+
+	// the path option is resolved to the value of the variable
+	path, err := resolveSecretPath("env://TEST_KAFKA_PASSWORD")
+	require.NoError(t, err)
+	require.Equal(t, "env-password", path)
+}
+
+func TestResolveSecretFile(t *testing.T) {
+	root := t.TempDir()
+	allowed := filepath.Join(root, "allowed")
+	outside := filepath.Join(root, "allowedx")
+	require.NoError(t, os.MkdirAll(allowed, 0o700))
+	require.NoError(t, os.MkdirAll(outside, 0o700))
+	secretPath := filepath.Join(allowed, "password")
+	outsidePath := filepath.Join(outside, "password")
+	require.NoError(t, os.WriteFile(secretPath, []byte("file-password\n"), 0o600))
+	require.NoError(t, os.WriteFile(outsidePath, []byte("outside-password"), 0o600))
+
+	// nothing can be referenced without the config
+	setSecretReferenceConfig(t, nil)
+	_, err := resolveSecret("file://" + secretPath)
+	require.ErrorContains(t, err, "not allowed")
+
+	setSecretReferenceConfig(t, &config.SecretReferenceConfig{AllowedDirs: []string{allowed}})
+	require.True(t, isSecretReference("file://"+secretPath))
+	secret, err := resolveSecret("file://" + secretPath)
+	require.NoError(t, err)
+	require.Equal(t, "file-password", secret)
+	path, err := resolveSecretPath("file://" + secretPath)
+	require.NoError(t, err)
+	require.Equal(t, secretPath, path)
+
+	// the file is not in the allowed directories, including the one sharing the prefix
+	_, err = resolveSecret("file://" + outsidePath)
+	require.ErrorContains(t, err, "not allowed")
+	_, err = resolveSecretPath("file://" + outsidePath)
+	require.ErrorContains(t, err, "not allowed")
+	// the relative path is rejected
+	_, err = resolveSecret("file://allowed/password")
+	require.ErrorContains(t, err, "not allowed")
+	// the path escapes the allowed directory by ..
+	_, err = resolveSecret("file://" + allowed + "/../allowedx/password")
+	require.ErrorContains(t, err, "not allowed")
+	_, err = resolveSecret("file://" + allowed + "/..")
+	require.ErrorContains(t, err, "not allowed")
+
+	// the symbolic link escapes the allowed directory
+	link := filepath.Join(allowed, "link")
+	require.NoError(t, os.Symlink(outsidePath, link))
+	_, err = resolveSecret("file://" + link)
+	require.ErrorContains(t, err, "not allowed")
+	dirLink := filepath.Join(allowed, "dir")
+	require.NoError(t, os.Symlink(outside, dirLink))
+	_, err = resolveSecret("file://" + filepath.Join(dirLink, "password"))
+	require.ErrorContains(t, err, "not allowed")
+
+	// the symbolic link to the allowed directory is resolved before checking
+	allowedLink := filepath.Join(root, "allowed-link")
+	require.NoError(t, os.Symlink(allowed, allowedLink))
+	setSecretReferenceConfig(t, &config.SecretReferenceConfig{AllowedDirs: []string{allowedLink}})
+	secret, err = resolveSecret("file://" + secretPath)
+	require.NoError(t, err)
+	require.Equal(t, "file-password", secret)
+
+	// the allowed file does not exist
+	_, err = resolveSecret("file://" + filepath.Join(allowed, "missing"))
+	require.ErrorContains(t, err, "read secret file")
+}
+
+func TestResolveSecretMount(t *testing.T) {
+	setSecretReferenceConfig(t, nil)
+	mountPath := SecretMountPath
+	SecretMountPath = t.TempDir()
+	defer func() { SecretMountPath = mountPath }()
+	secretDir := filepath.Join(SecretMountPath, "kafka-credential")
+	require.NoError(t, os.MkdirAll(secretDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(secretDir, "password"), []byte(" mount-password "), 0o600))
+
+	// the mounted secrets are allowed without the config
+	require.True(t, isSecretReference("secret://kafka-credential/password"))
+	secret, err := resolveSecret("secret://kafka-credential/password")
+	require.NoError(t, err)
+	require.Equal(t, "mount-password", secret)
+	path, err := resolveSecretPath("secret://kafka-credential/password")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(secretDir, "password"), path)
+
+	for _, ref := range []string{
+		"kafka-credential",
+		"kafka-credential/",
+		"/password",
+		"../password",
+		"kafka-credential/..",
+		"kafka-credential/../../etc/passwd",
+		"kafka-credential/sub/password",
+	} {
+		_, err = resolveSecret(secretMountScheme + ref)
+		require.ErrorContains(t, err, "invalid secret reference", ref)
+		_, err = resolveSecretPath(secretMountScheme + ref)
+		require.ErrorContains(t, err, "invalid secret reference", ref)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+)
+
+// certReloader holds the CA pool and the client certificate loaded from the credential files,
+// and reloads them once the files are rotated, so that the new connections
+// created by the producers use the rotated certificates without recreating the sink.
+type certReloader struct {
+	credential *security.Credential
+
+	mu      sync.RWMutex
+	modTime map[string]time.Time
+	caPool  *x509.CertPool
+	cert    *tls.Certificate
+}
+
+func newCertReloader(credential *security.Credential) (*certReloader, error) {
+	r := &certReloader{credential: credential}
+	modTime, err := r.statFiles()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) statFiles() (map[string]time.Time, error) {
+	modTime := make(map[string]time.Time, 3)
+	for _, path := range []string{r.credential.CAPath, r.credential.CertPath, r.credential.KeyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, errors.Trace(err))
+		}
+		modTime[path] = info.ModTime()
+	}
+	return modTime, nil
+}
+
+func (r *certReloader) load(modTime map[string]time.Time) error {
+	ca, err := os.ReadFile(r.credential.CAPath)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaInvalidConfig, errors.Trace(err))
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(ca) {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"failed to append ca certs from %s", r.credential.CAPath)
+	}
+	cert, err := tls.LoadX509KeyPair(r.credential.CertPath, r.credential.KeyPath)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaInvalidConfig, errors.Trace(err))
+	}
+
+	r.mu.Lock()
+	r.modTime = modTime
+	r.caPool = caPool
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// current returns the CA pool and the client certificate, they are reloaded
+// if any of the credential files has been modified since the last load.
+// The previous ones are kept if the rotated files can not be loaded.
+func (r *certReloader) current() (*x509.CertPool, *tls.Certificate) {
+	modTime, err := r.statFiles()
+	if err == nil && r.changed(modTime) {
+		if err = r.load(modTime); err == nil {
+			log.Info("kafka tls certificates reloaded",
+				zap.String("ca", r.credential.CAPath),
+				zap.String("cert", r.credential.CertPath),
+				zap.String("key", r.credential.KeyPath))
+		}
+	}
+	if err != nil {
+		log.Warn("reload kafka tls certificates failed, keep using the previous ones", zap.Error(err))
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.caPool, r.cert
+}
+
+func (r *certReloader) changed(modTime map[string]time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for path, t := range modTime {
+		if !t.Equal(r.modTime[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyConnection verifies the certificates of the server against the current CA pool,
+// it is the same as the default verification of crypto/tls except that the CA pool is reloadable.
+func (r *certReloader) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack("no certificate is provided by the kafka broker")
+	}
+	caPool, _ := r.current()
+	opts := x509.VerifyOptions{
+		Roots:         caPool,
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return errors.Trace(err)
+}
+
+// NewReloadableTLSConfig returns the tls config of the kafka clients built from the credential files,
+// it's used by all the kafka clients. The rotated certificates are reloaded automatically when a new
+// connection is established, so the clients don't need to be recreated after the rotation.
+func NewReloadableTLSConfig(credential *security.Credential, insecureSkipVerify bool) (*tls.Config, error) {
+	reloader, err := newCertReloader(credential)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			_, cert := reloader.current()
+			return cert, nil
+		},
+		// The server certificates are verified by VerifyConnection with the reloadable CA pool,
+		// so the default verification, which uses a fixed RootCAs, is skipped.
+		InsecureSkipVerify: true,
+	}
+	if !insecureSkipVerify {
+		tlsConfig.VerifyConnection = reloader.verifyConnection
+	}
+	return tlsConfig, nil
+}
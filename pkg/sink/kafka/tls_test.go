@@ -0,0 +1,199 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert creates a certificate signed by the parent, or a self-signed CA if parent is nil.
+func newTestCert(t *testing.T, serial int64, parent *testCert, dnsName string) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{dnsName}
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeCredentialFile writes the file and sets its modification time,
+// so that the rotation is detected regardless of the precision of the file system.
+func writeCredentialFile(t *testing.T, path string, content []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestCertReloaderReload(t *testing.T) {
+	dir := t.TempDir()
+	credential := &security.Credential{
+		CAPath:   filepath.Join(dir, "ca.pem"),
+		CertPath: filepath.Join(dir, "client.pem"),
+		KeyPath:  filepath.Join(dir, "client-key.pem"),
+	}
+	ca := newTestCert(t, 1, nil, "ca")
+	client1 := newTestCert(t, 2, ca, "client")
+	modTime := time.Now().Add(-time.Minute)
+	writeCredentialFile(t, credential.CAPath, ca.certPEM, modTime)
+	writeCredentialFile(t, credential.CertPath, client1.certPEM, modTime)
+	writeCredentialFile(t, credential.KeyPath, client1.keyPEM, modTime)
+
+	r, err := newCertReloader(credential)
+	require.NoError(t, err)
+	_, cert := r.current()
+	require.Equal(t, client1.cert.Raw, cert.Certificate[0])
+
+	// the content is not reloaded if the modification time is not changed
+	client2 := newTestCert(t, 3, ca, "client")
+	writeCredentialFile(t, credential.CertPath, client2.certPEM, modTime)
+	writeCredentialFile(t, credential.KeyPath, client2.keyPEM, modTime)
+	_, cert = r.current()
+	require.Equal(t, client1.cert.Raw, cert.Certificate[0])
+
+	// the rotated certificate is reloaded once the modification time is changed
+	modTime = modTime.Add(time.Second)
+	writeCredentialFile(t, credential.CertPath, client2.certPEM, modTime)
+	writeCredentialFile(t, credential.KeyPath, client2.keyPEM, modTime)
+	_, cert = r.current()
+	require.Equal(t, client2.cert.Raw, cert.Certificate[0])
+
+	// the previous certificates are kept if the rotated files are invalid
+	modTime = modTime.Add(time.Second)
+	writeCredentialFile(t, credential.CertPath, []byte("invalid"), modTime)
+	caPool, cert := r.current()
+	require.NotNil(t, caPool)
+	require.Equal(t, client2.cert.Raw, cert.Certificate[0])
+	writeCredentialFile(t, credential.CAPath, []byte("invalid"), modTime)
+	caPool2, cert := r.current()
+	require.Same(t, caPool, caPool2)
+	require.Equal(t, client2.cert.Raw, cert.Certificate[0])
+
+	// the previous certificates are kept if the files are removed
+	require.NoError(t, os.Remove(credential.KeyPath))
+	_, cert = r.current()
+	require.Equal(t, client2.cert.Raw, cert.Certificate[0])
+
+	// the reloader can't be created from the invalid files
+	_, err = newCertReloader(credential)
+	require.Error(t, err)
+}
+
+func TestReloadableTLSConfigVerifyConnection(t *testing.T) {
+	dir := t.TempDir()
+	credential := &security.Credential{
+		CAPath:   filepath.Join(dir, "ca.pem"),
+		CertPath: filepath.Join(dir, "client.pem"),
+		KeyPath:  filepath.Join(dir, "client-key.pem"),
+	}
+	ca := newTestCert(t, 1, nil, "ca")
+	client := newTestCert(t, 2, ca, "client")
+	modTime := time.Now().Add(-time.Minute)
+	writeCredentialFile(t, credential.CAPath, ca.certPEM, modTime)
+	writeCredentialFile(t, credential.CertPath, client.certPEM, modTime)
+	writeCredentialFile(t, credential.KeyPath, client.keyPEM, modTime)
+
+	tlsConfig, err := NewReloadableTLSConfig(credential, false)
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig.VerifyConnection)
+	cert, err := tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	require.Equal(t, client.cert.Raw, cert.Certificate[0])
+
+	broker := newTestCert(t, 3, ca, "kafka.example.com")
+	require.NoError(t, tlsConfig.VerifyConnection(tls.ConnectionState{
+		ServerName:       "kafka.example.com",
+		PeerCertificates: []*x509.Certificate{broker.cert},
+	}))
+	// the server name does not match the certificate
+	require.Error(t, tlsConfig.VerifyConnection(tls.ConnectionState{
+		ServerName:       "other.example.com",
+		PeerCertificates: []*x509.Certificate{broker.cert},
+	}))
+	// no certificate is provided by the peer
+	require.Error(t, tlsConfig.VerifyConnection(tls.ConnectionState{ServerName: "kafka.example.com"}))
+
+	// the peer is not signed by the CA
+	otherCA := newTestCert(t, 4, nil, "other-ca")
+	forged := newTestCert(t, 5, otherCA, "kafka.example.com")
+	require.Error(t, tlsConfig.VerifyConnection(tls.ConnectionState{
+		ServerName:       "kafka.example.com",
+		PeerCertificates: []*x509.Certificate{forged.cert},
+	}))
+	// the intermediate certificate of the peer is not a trusted root
+	require.Error(t, tlsConfig.VerifyConnection(tls.ConnectionState{
+		ServerName:       "kafka.example.com",
+		PeerCertificates: []*x509.Certificate{forged.cert, otherCA.cert},
+	}))
+
+	// the peer signed by the rotated CA is trusted once the CA is reloaded
+	writeCredentialFile(t, credential.CAPath, otherCA.certPEM, modTime.Add(time.Second))
+	require.NoError(t, tlsConfig.VerifyConnection(tls.ConnectionState{
+		ServerName:       "kafka.example.com",
+		PeerCertificates: []*x509.Certificate{forged.cert},
+	}))
+	require.Error(t, tlsConfig.VerifyConnection(tls.ConnectionState{
+		ServerName:       "kafka.example.com",
+		PeerCertificates: []*x509.Certificate{broker.cert},
+	}))
+
+	// the server certificates are not verified if insecure-skip-verify is set
+	tlsConfig, err = NewReloadableTLSConfig(credential, true)
+	require.NoError(t, err)
+	require.Nil(t, tlsConfig.VerifyConnection)
+}
@@ -102,7 +102,7 @@ func completeSSLConfig(options *pkafka.Options) (*tls.Config, error) {
 		// for SSL encryption with self-signed CA certificate, we reassign the
 		// config.Net.TLS.Config using the relevant credential files.
 		if options.Credential != nil && options.Credential.IsTLSEnabled() {
-			tlsConfig, err := options.Credential.ToTLSConfig()
+			tlsConfig, err := pkafka.NewReloadableTLSConfig(options.Credential, options.InsecureSkipVerify)
 			return tlsConfig, errors.Trace(err)
 		}
 
@@ -219,15 +219,27 @@ func (f *factory) SyncProducer() (pkafka.SyncProducer, error) {
 	}, nil
 }
 
+// applyBatchOptions sets how long and how many bytes the writer accumulates the messages
+// before flushing them, the batch bytes can't exceed the max message bytes.
+func applyBatchOptions(w *kafka.Writer, o *pkafka.Options) {
+	// set batch timeout to 5ms to avoid waste too much time on waiting for messages.
+	w.BatchTimeout = 5 * time.Millisecond
+	if o.BatchLinger > 0 {
+		w.BatchTimeout = o.BatchLinger
+	}
+	if o.BatchBytes > 0 && int64(o.BatchBytes) < w.BatchBytes {
+		w.BatchBytes = int64(o.BatchBytes)
+	}
+	// assume each message is 1KB.
+	w.BatchSize = max(1, int(w.BatchBytes/1024))
+}
+
 // AsyncProducer creates an async producer to writer message to kafka
 func (f *factory) AsyncProducer(
 	ctx context.Context,
 ) (pkafka.AsyncProducer, error) {
 	w := f.newWriter(true)
-	// assume each message is 1KB,
-	// and set batch timeout to 5ms to avoid waste too much time on waiting for messages.
-	w.BatchTimeout = 5 * time.Millisecond
-	w.BatchSize = int(w.BatchBytes / 1024)
+	applyBatchOptions(w, f.options)
 	aw := &asyncWriter{
 		w:            w,
 		changefeedID: f.changefeedID,
@@ -371,7 +383,7 @@ func (a *asyncWriter) AsyncSend(ctx context.Context, topic string, partition int
 		Partition:  int(partition),
 		Key:        message.Key,
 		Value:      message.Value,
-		WriterData: message.Callback,
+		WriterData: message.Ack,
 	})
 }
 
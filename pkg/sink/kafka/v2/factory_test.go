@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+	"time"
+
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBatchOptions(t *testing.T) {
+	o := pkafka.NewOptions()
+	w := &kafka.Writer{BatchBytes: 1024 * 1024}
+	applyBatchOptions(w, o)
+	require.Equal(t, 5*time.Millisecond, w.BatchTimeout)
+	require.Equal(t, int64(1024*1024), w.BatchBytes)
+	require.Equal(t, 1024, w.BatchSize)
+
+	o.BatchLinger = 20 * time.Millisecond
+	o.BatchBytes = 64 * 1024
+	w = &kafka.Writer{BatchBytes: 1024 * 1024}
+	applyBatchOptions(w, o)
+	require.Equal(t, 20*time.Millisecond, w.BatchTimeout)
+	require.Equal(t, int64(64*1024), w.BatchBytes)
+	require.Equal(t, 64, w.BatchSize)
+
+	// the batch can't exceed the max message bytes
+	o.BatchBytes = 4 * 1024 * 1024
+	w = &kafka.Writer{BatchBytes: 1024 * 1024}
+	applyBatchOptions(w, o)
+	require.Equal(t, int64(1024*1024), w.BatchBytes)
+
+	// at least one message is batched
+	o.BatchBytes = 100
+	w = &kafka.Writer{BatchBytes: 1024 * 1024}
+	applyBatchOptions(w, o)
+	require.Equal(t, 1, w.BatchSize)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/sink"
@@ -85,10 +86,15 @@ const (
 	prepStmtCacheSize int = 16 * 1024
 
 	defaultHasVectorType = false
+
+	defaultFlushInterval = 10 * time.Millisecond
 )
 
 type MysqlConfig struct {
-	sinkURI                *url.URL
+	sinkURI *url.URL
+	// Addresses are the downstream servers in the sink uri, the connections are
+	// spread over them and fail over between them if there are multiple ones.
+	Addresses              []string
 	WorkerCount            int
 	MaxTxnRow              int
 	MaxMultiUpdateRowCount int
@@ -101,6 +107,13 @@ type MysqlConfig struct {
 	Timezone               string
 	TLS                    string
 	ForceReplicate         bool
+	// GeneratedColumnMode decides whether the stored generated columns are written to the downstream.
+	GeneratedColumnMode string
+	// SessionVariables are set on all the connections to the downstream,
+	// they override the ones set by TiCDC.
+	SessionVariables map[string]string
+	// TableRouter maps the upstream tables to the downstream ones, it's nil if there is no routing rule.
+	TableRouter *sinkutil.TableRouter
 
 	// retry number for dml
 	DMLMaxRetry uint64
@@ -116,10 +129,31 @@ type MysqlConfig struct {
 	CachePrepStmts  bool
 	// DryRun is used to enable dry-run mode. In dry-run mode, the writer will not write data to the downstream.
 	DryRun bool
+	// EnableDDLHistory is used to record every executed ddl into the ddl history table in the downstream.
+	EnableDDLHistory bool
+	// DDLDryRun is used to validate the ddls against the downstream instead of executing them.
+	DDLDryRun bool
+	// ExactlyOnce is used to write the applied commit ts of the dispatchers in the same transaction
+	// as the rows, and filter the transactions already applied.
+	ExactlyOnce bool
+	// DDLInterventions are the ddls skipped or replaced by the users, keyed by the commit ts.
+	DDLInterventions map[uint64]*config.DDLIntervention
+	// TableOverrides are the tables applied by their own dml workers.
+	TableOverrides []*TableOverride
+	// EnableRowCountAudit is used to record the number of rows flushed per table into the audit table.
+	EnableRowCountAudit bool
+	// EnableDeadLetterQueue is used to write the rows which can not be applied into the dead letter queue table.
+	EnableDeadLetterQueue bool
+	// DropSyncPointOnRemove is used to delete the syncpoint rows of the changefeed when it's removed.
+	DropSyncPointOnRemove bool
 
 	// sync point
 	SyncPointRetention time.Duration
 
+	// FlushInterval is the max time a dml worker waits to collect a batch of transactions,
+	// it's decided by the latency mode of the changefeed.
+	FlushInterval time.Duration
+
 	// implement stmtCache to improve performance, especially when the downstream is TiDB
 	stmtCache        *lru.Cache
 	MaxAllowedPacket int64
@@ -145,6 +179,8 @@ func NewMysqlConfig() *MysqlConfig {
 		SourceID:               config.DefaultTiDBSourceID,
 		DMLMaxRetry:            8,
 		HasVectorType:          defaultHasVectorType,
+		FlushInterval:          defaultFlushInterval,
+		GeneratedColumnMode:    GeneratedColumnModeAuto,
 	}
 }
 
@@ -184,7 +220,7 @@ func (c *MysqlConfig) Apply(
 	if err = getSafeMode(query, &c.SafeMode); err != nil {
 		return err
 	}
-	if err = getTimezone(config.TimeZone, query, &c.Timezone); err != nil {
+	if err = getTimezone(config, query, &c.Timezone); err != nil {
 		return err
 	}
 	if err = getDuration(query, "read-timeout", &c.ReadTimeout); err != nil {
@@ -202,13 +238,50 @@ func (c *MysqlConfig) Apply(
 	if err = getMultiStmtEnable(query, &c.MultiStmtEnable); err != nil {
 		return err
 	}
+	if err = getDDLHistoryEnable(query, &c.EnableDDLHistory); err != nil {
+		return err
+	}
+	if err = getGeneratedColumnMode(query, &c.GeneratedColumnMode); err != nil {
+		return err
+	}
 
 	// c.EnableOldValue = config.EnableOldValue
 	c.ForceReplicate = config.ForceReplicate
 	c.SourceID = config.SinkConfig.TiDBSourceID
+	c.EnableRowCountAudit = util.GetOrZero(config.SinkConfig.EnableRowCountAudit)
+	c.EnableDeadLetterQueue = config.SinkConfig.DeadLetterQueue != nil
+	if config.SinkConfig.RemovalCleanup != nil {
+		c.DropSyncPointOnRemove = util.GetOrZero(config.SinkConfig.RemovalCleanup.DropSyncPointRows)
+	}
+	c.FlushInterval = config.LatencyMode.Profile().SinkFlushInterval
+	if config.SinkConfig.MySQLConfig != nil {
+		c.SessionVariables = config.SinkConfig.MySQLConfig.SessionVariables
+		c.DDLDryRun = util.GetOrZero(config.SinkConfig.MySQLConfig.DDLDryRun)
+		c.ExactlyOnce = util.GetOrZero(config.SinkConfig.MySQLConfig.ExactlyOnce)
+		c.TableOverrides, err = newTableOverrides(
+			config.SinkConfig.MySQLConfig.TableOverrides, config.CaseSensitive, c.MaxTxnRow)
+		if err != nil {
+			return err
+		}
+	}
+	c.DDLInterventions = newDDLInterventions(config.DDLInterventions)
+	if c.TableRouter, err = sinkutil.NewTableRouter(config.SinkConfig.RoutingRules); err != nil {
+		return err
+	}
 	return nil
 }
 
+func newDDLInterventions(interventions []*config.DDLIntervention) map[uint64]*config.DDLIntervention {
+	if len(interventions) == 0 {
+		return nil
+	}
+	result := make(map[uint64]*config.DDLIntervention, len(interventions))
+	for _, intervention := range interventions {
+		result[intervention.CommitTs] = intervention
+	}
+	return result
+}
+
 func NewMySQLConfig(changefeedID common.ChangeFeedID, sinkURI *url.URL, config *config.ChangefeedConfig) (*MysqlConfig, error) {
 	cfg := NewMysqlConfig()
 	err := cfg.Apply(sinkURI, changefeedID, config)
@@ -231,7 +304,7 @@ func NewMysqlConfigAndDB(ctx context.Context, changefeedID common.ChangeFeedID,
 		return nil, nil, err
 	}
 
-	db, err := CreateMysqlDBConn(dsnStr)
+	db, err := openDB(ctx, changefeedID, cfg, dsnStr)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -493,13 +566,18 @@ func getSafeMode(values url.Values, safeMode *bool) error {
 	return nil
 }
 
-func getTimezone(serverTimezone string, values url.Values, timezone *string) error {
+func getTimezone(config *config.ChangefeedConfig, values url.Values, timezone *string) error {
 	const pleaseSpecifyTimezone = "We recommend that you specify the time-zone explicitly. " +
 		"Please make sure that the timezone of the TiCDC server, " +
 		"sink-uri and the downstream database are consistent. " +
 		"If the downstream database does not load the timezone information, " +
 		"you can refer to https://dev.mysql.com/doc/refman/8.0/en/mysql-tzinfo-to-sql.html."
 	s := values.Get("time-zone")
+	if len(s) == 0 && config.TimeZone != "" {
+		// use the timezone of the changefeed as the session timezone,
+		// so the downstream sees the same temporal values as the filter and the codec.
+		s = config.TimeZone
+	}
 	if len(s) == 0 {
 		*timezone = ""
 		log.Warn("Because time-zone is empty, " +
@@ -508,20 +586,24 @@ func getTimezone(serverTimezone string, values url.Values, timezone *string) err
 		return nil
 	}
 
-	changefeedTimezone, err := util.GetTimezone(s)
+	sinkTimezone, err := util.GetTimezone(s)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
 	}
-	*timezone = fmt.Sprintf(`"%s"`, changefeedTimezone.String())
-	// We need to check whether the timezone of the TiCDC server and the sink-uri are consistent.
+	*timezone = fmt.Sprintf(`"%s"`, sinkTimezone.String())
+	// We need to check whether the timezone of the changefeed and the sink-uri are consistent.
 	// If they are inconsistent, it may cause the data to be inconsistent.
-	if changefeedTimezone.String() != serverTimezone {
+	changefeedTimezone, err := util.GetTimezone(config.GetTimeZone())
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if sinkTimezone.String() != changefeedTimezone.String() {
 		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, errors.Errorf(
-			"the timezone of the TiCDC server and the sink-uri are inconsistent. "+
-				"TiCDC server timezone: %s, sink-uri timezone: %s. "+
+			"the timezone of the changefeed and the sink-uri are inconsistent. "+
+				"changefeed timezone: %s, sink-uri timezone: %s. "+
 				"Please make sure that the timezone of the TiCDC server, "+
 				"sink-uri and the downstream database are consistent.",
-			serverTimezone, changefeedTimezone.String()))
+			changefeedTimezone.String(), sinkTimezone.String()))
 	}
 
 	return nil
@@ -552,6 +634,32 @@ func getBatchDMLEnable(values url.Values, batchDMLEnable *bool) error {
 	return nil
 }
 
+func getGeneratedColumnMode(values url.Values, mode *string) error {
+	s := strings.ToLower(values.Get("generated-column"))
+	if len(s) == 0 {
+		return nil
+	}
+	switch s {
+	case GeneratedColumnModeAuto, GeneratedColumnModeSkip, GeneratedColumnModeInclude:
+		*mode = s
+		return nil
+	}
+	return cerror.ErrMySQLInvalidConfig.GenWithStack(
+		"invalid generated-column %s, it must be one of auto, skip and include", s)
+}
+
+func getDDLHistoryEnable(values url.Values, enableDDLHistory *bool) error {
+	s := values.Get("enable-ddl-history")
+	if len(s) > 0 {
+		enable, err := strconv.ParseBool(s)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		*enableDDLHistory = enable
+	}
+	return nil
+}
+
 func getMultiStmtEnable(values url.Values, multiStmtEnable *bool) error {
 	s := values.Get("multi-stmt-enable")
 	if len(s) > 0 {
@@ -176,10 +176,47 @@ func TestGenerateDSNByConfig(t *testing.T) {
 		}
 	}
 
+	testSessionVariables := func() {
+		db, err := MockTestDB(false)
+		require.Nil(t, err)
+		defer db.Close()
+
+		dsn, err := dmysql.ParseDSN("root:123456@tcp(127.0.0.1:4000)/")
+		require.Nil(t, err)
+		uri, err := url.Parse("mysql://127.0.0.1:3306/")
+		require.Nil(t, err)
+		cfg := NewMysqlConfig()
+		changefeedConfig := &config.ChangefeedConfig{
+			TimeZone: "UTC",
+			SinkConfig: &config.SinkConfig{
+				MySQLConfig: &config.MySQLConfig{
+					SessionVariables: map[string]string{
+						"tidb_skip_constraint_check": "1",
+						"tidb_txn_mode":              "pessimistic",
+						"time_zone":                  "+08:00",
+					},
+				},
+			},
+		}
+		err = cfg.Apply(uri, common.NewChangefeedID4Test("default", "changefeed-01"), changefeedConfig)
+		require.Nil(t, err)
+		dsnStr, err := generateDSNByConfig(dsn, cfg, db)
+		require.Nil(t, err)
+		expectedCfg := []string{
+			"tidb_skip_constraint_check=1",
+			"tidb_txn_mode=pessimistic",
+			"time_zone=%27%2B08%3A00%27",
+		}
+		for _, param := range expectedCfg {
+			require.Contains(t, dsnStr, param)
+		}
+	}
+
 	testDefaultConfig()
 	testTimezoneParam()
 	testTimeoutConfig()
 	testIsolationConfig()
+	testSessionVariables()
 }
 
 func TestApplySinkURIParamsToConfig(t *testing.T) {
@@ -361,7 +398,7 @@ func TestApplyTimezone(t *testing.T) {
 			name:                 "no changefeed timezone",
 			noChangefeedTimezone: true,
 			serverTimezone:       time.UTC,
-			expected:             "",
+			expected:             "\"UTC\"",
 			expectedHasErr:       false,
 		},
 		{
@@ -369,7 +406,7 @@ func TestApplyTimezone(t *testing.T) {
 			noChangefeedTimezone: false,
 			changefeedTimezone:   "",
 			serverTimezone:       time.UTC,
-			expected:             "",
+			expected:             "\"UTC\"",
 			expectedHasErr:       false,
 		},
 		{
@@ -433,3 +470,12 @@ func TestApplyTimezone(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatSessionVariableValue(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "1024", formatSessionVariableValue("1024"))
+	require.Equal(t, "ON", formatSessionVariableValue("ON"))
+	require.Equal(t, "'+08:00'", formatSessionVariableValue("+08:00"))
+	require.Equal(t, `'a\'b\\c'`, formatSessionVariableValue(`a'b\c`))
+	require.Equal(t, `'a\"b'`, formatSessionVariableValue(`a"b`))
+}
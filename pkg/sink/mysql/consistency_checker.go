@@ -0,0 +1,343 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"go.uber.org/zap"
+)
+
+// DefaultConsistencyCheckChunkSize is the default number of rows in a chunk
+// when checking the consistency of a table.
+const DefaultConsistencyCheckChunkSize = 10000
+
+// ChunkMismatch is a chunk of the table whose data is different between upstream and downstream.
+// The chunk contains the rows whose primary key is in (LowerBound, UpperBound],
+// a nil bound means the chunk is unbounded in that direction.
+type ChunkMismatch struct {
+	Index              int
+	LowerBound         []string
+	UpperBound         []string
+	UpstreamCount      int64
+	DownstreamCount    int64
+	UpstreamChecksum   uint64
+	DownstreamChecksum uint64
+}
+
+// ConsistencyCheckResult is the result of checking a table.
+type ConsistencyCheckResult struct {
+	// PrimaryTs and SecondaryTs are the upstream and downstream ts of the syncpoint used to check.
+	PrimaryTs   uint64
+	SecondaryTs uint64
+	ChunkCount  int
+	Mismatches  []ChunkMismatch
+}
+
+// ConsistencyChecker compares the data of a table between the upstream and the downstream
+// chunk by chunk, like sync-diff-inspector. The syncpoint of the changefeed is used as the
+// consistent cut, the upstream is read at the primary ts and the downstream is read at the
+// secondary ts of the syncpoint, so the spans are quiesced without pausing the changefeed.
+type ConsistencyChecker struct {
+	changefeedID common.ChangeFeedID
+	upstream     *sql.DB
+	downstream   *sql.DB
+	chunkSize    int
+}
+
+// NewConsistencyChecker creates a new ConsistencyChecker.
+func NewConsistencyChecker(
+	changefeedID common.ChangeFeedID,
+	upstream, downstream *sql.DB,
+	chunkSize int,
+) *ConsistencyChecker {
+	if chunkSize <= 0 {
+		chunkSize = DefaultConsistencyCheckChunkSize
+	}
+	return &ConsistencyChecker{
+		changefeedID: changefeedID,
+		upstream:     upstream,
+		downstream:   downstream,
+		chunkSize:    chunkSize,
+	}
+}
+
+// GetSyncPoint returns the latest syncpoint of the changefeed whose primary ts is in [startTs, endTs].
+func (c *ConsistencyChecker) GetSyncPoint(ctx context.Context, startTs, endTs uint64) (uint64, uint64, error) {
+	query := fmt.Sprintf("SELECT primary_ts, secondary_ts FROM %s.%s WHERE ticdc_cluster_id = ? AND changefeed = ? "+
+		"AND CAST(primary_ts AS UNSIGNED) >= ? AND CAST(primary_ts AS UNSIGNED) <= ? "+
+		"ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT 1",
+		filter.TiCDCSystemSchema, filter.SyncPointTable)
+	var primary, secondary string
+	err := c.downstream.QueryRowContext(ctx, query,
+		config.GetGlobalServerConfig().ClusterID, c.changefeedID.String(), startTs, endTs).
+		Scan(&primary, &secondary)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return 0, 0, cerror.ErrConsistencyCheck.GenWithStackByArgs(
+				fmt.Sprintf("no syncpoint found in [%d, %d]", startTs, endTs))
+		}
+		return 0, 0, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, "failed to query syncpoint table"))
+	}
+	primaryTs, err := strconv.ParseUint(primary, 10, 64)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	secondaryTs, err := strconv.ParseUint(secondary, 10, 64)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return primaryTs, secondaryTs, nil
+}
+
+// Check compares the table between the upstream and the downstream at the latest syncpoint
+// in [startTs, endTs], and returns the chunks which are mismatched.
+func (c *ConsistencyChecker) Check(
+	ctx context.Context, schema, table string, startTs, endTs uint64,
+) (*ConsistencyCheckResult, error) {
+	primaryTs, secondaryTs, err := c.GetSyncPoint(ctx, startTs, endTs)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := snapshotConn(ctx, c.upstream, primaryTs)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSnapshotConn(upstream)
+	downstream, err := snapshotConn(ctx, c.downstream, secondaryTs)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSnapshotConn(downstream)
+
+	columns, pkColumns, err := queryTableColumns(ctx, upstream, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := c.splitChunks(ctx, upstream, schema, table, pkColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConsistencyCheckResult{
+		PrimaryTs:   primaryTs,
+		SecondaryTs: secondaryTs,
+		ChunkCount:  len(bounds) + 1,
+	}
+	// the chunks are (nil, bounds[0]], (bounds[0], bounds[1]], ..., (bounds[n-1], nil)
+	var lower []string
+	for i := 0; i <= len(bounds); i++ {
+		var upper []string
+		if i < len(bounds) {
+			upper = bounds[i]
+		}
+		upCount, upChecksum, err := chunkChecksum(ctx, upstream, schema, table, columns, pkColumns, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		downCount, downChecksum, err := chunkChecksum(ctx, downstream, schema, table, columns, pkColumns, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		if upCount != downCount || upChecksum != downChecksum {
+			result.Mismatches = append(result.Mismatches, ChunkMismatch{
+				Index:              i,
+				LowerBound:         lower,
+				UpperBound:         upper,
+				UpstreamCount:      upCount,
+				DownstreamCount:    downCount,
+				UpstreamChecksum:   upChecksum,
+				DownstreamChecksum: downChecksum,
+			})
+		}
+		lower = upper
+	}
+	log.Info("consistency check finished",
+		zap.String("changefeed", c.changefeedID.String()),
+		zap.String("schema", schema),
+		zap.String("table", table),
+		zap.Uint64("primaryTs", primaryTs),
+		zap.Uint64("secondaryTs", secondaryTs),
+		zap.Int("chunkCount", result.ChunkCount),
+		zap.Int("mismatchCount", len(result.Mismatches)))
+	return result, nil
+}
+
+// splitChunks returns the upper bounds of the chunks, each chunk contains chunkSize rows
+// except the last one. The table is checked as a whole if it has no primary key.
+func (c *ConsistencyChecker) splitChunks(
+	ctx context.Context, conn *sql.Conn, schema, table string, pkColumns []string,
+) ([][]string, error) {
+	if len(pkColumns) == 0 {
+		return nil, nil
+	}
+	var (
+		bounds [][]string
+		lower  []string
+	)
+	for {
+		var builder strings.Builder
+		builder.WriteString("SELECT ")
+		builder.WriteString(quoteColumns(pkColumns))
+		builder.WriteString(" FROM ")
+		builder.WriteString(common.QuoteSchema(schema, table))
+		where, args := chunkWhere(pkColumns, lower, nil)
+		builder.WriteString(where)
+		builder.WriteString(" ORDER BY ")
+		builder.WriteString(quoteColumns(pkColumns))
+		builder.WriteString(fmt.Sprintf(" LIMIT 1 OFFSET %d", c.chunkSize-1))
+		query := builder.String()
+
+		values := make([]string, len(pkColumns))
+		dest := make([]interface{}, len(pkColumns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		err := conn.QueryRowContext(ctx, query, args...).Scan(dest...)
+		if err != nil {
+			if errors.Cause(err) == sql.ErrNoRows {
+				return bounds, nil
+			}
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, fmt.Sprintf("failed to split chunks; Query is %s", query)))
+		}
+		bounds = append(bounds, values)
+		lower = values
+	}
+}
+
+func queryTableColumns(ctx context.Context, conn *sql.Conn, schema, table string) ([]string, []string, error) {
+	queryColumns := func(query string) ([]string, error) {
+		rows, err := conn.QueryContext(ctx, query, schema, table)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, fmt.Sprintf("failed to query columns; Query is %s", query)))
+		}
+		defer rows.Close()
+		var columns []string
+		for rows.Next() {
+			var column string
+			if err := rows.Scan(&column); err != nil {
+				return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+			}
+			columns = append(columns, column)
+		}
+		return columns, errors.Trace(rows.Err())
+	}
+	columns, err := queryColumns("SELECT COLUMN_NAME FROM information_schema.COLUMNS " +
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(columns) == 0 {
+		return nil, nil, cerror.ErrConsistencyCheck.GenWithStackByArgs(
+			fmt.Sprintf("table %s not found in upstream", common.QuoteSchema(schema, table)))
+	}
+	pkColumns, err := queryColumns("SELECT COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE " +
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY' ORDER BY ORDINAL_POSITION")
+	if err != nil {
+		return nil, nil, err
+	}
+	return columns, pkColumns, nil
+}
+
+// chunkChecksum returns the row count and the checksum of the rows in the chunk.
+func chunkChecksum(
+	ctx context.Context, conn *sql.Conn,
+	schema, table string, columns, pkColumns []string, lower, upper []string,
+) (int64, uint64, error) {
+	quoted := make([]string, 0, len(columns)*2)
+	for _, column := range columns {
+		quoted = append(quoted, common.QuoteName(column))
+	}
+	// ISNULL is used to distinguish the NULL value from the empty string.
+	for _, column := range columns {
+		quoted = append(quoted, "ISNULL("+common.QuoteName(column)+")")
+	}
+	where, args := chunkWhere(pkColumns, lower, upper)
+	query := fmt.Sprintf("SELECT COUNT(*), COALESCE(BIT_XOR(CAST(CRC32(CONCAT_WS(',', %s)) AS UNSIGNED)), 0) FROM %s%s",
+		strings.Join(quoted, ", "), common.QuoteSchema(schema, table), where)
+	var (
+		count    int64
+		checksum uint64
+	)
+	err := conn.QueryRowContext(ctx, query, args...).Scan(&count, &checksum)
+	if err != nil {
+		return 0, 0, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, fmt.Sprintf("failed to calculate checksum; Query is %s", query)))
+	}
+	return count, checksum, nil
+}
+
+// chunkWhere returns the where clause of the rows whose primary key is in (lower, upper].
+func chunkWhere(pkColumns []string, lower, upper []string) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(pkColumns)), ", ") + ")"
+	if lower != nil {
+		conditions = append(conditions, "("+quoteColumns(pkColumns)+") > "+placeholders)
+		for _, v := range lower {
+			args = append(args, v)
+		}
+	}
+	if upper != nil {
+		conditions = append(conditions, "("+quoteColumns(pkColumns)+") <= "+placeholders)
+		for _, v := range upper {
+			args = append(args, v)
+		}
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func quoteColumns(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quoted = append(quoted, common.QuoteName(column))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// snapshotConn returns a connection which reads the snapshot at ts.
+func snapshotConn(ctx context.Context, db *sql.DB, ts uint64) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	if _, err = conn.ExecContext(ctx, fmt.Sprintf("SET @@tidb_snapshot = '%d'", ts)); err != nil {
+		_ = conn.Close()
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, "failed to set tidb_snapshot"))
+	}
+	return conn, nil
+}
+
+// closeSnapshotConn resets the snapshot before returning the connection to the pool.
+func closeSnapshotConn(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "SET @@tidb_snapshot = ''"); err != nil {
+		log.Warn("failed to reset tidb_snapshot", zap.Error(err))
+	}
+	_ = conn.Close()
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyChecker_Check(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	upstream, upMock := newTestMockDB(t)
+	defer upstream.Close()
+	downstream, downMock := newTestMockDB(t)
+	defer downstream.Close()
+
+	downMock.ExpectQuery("SELECT primary_ts, secondary_ts FROM tidb_cdc.syncpoint_v1 WHERE ticdc_cluster_id = ? AND changefeed = ? "+
+		"AND CAST(primary_ts AS UNSIGNED) >= ? AND CAST(primary_ts AS UNSIGNED) <= ? "+
+		"ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT 1").
+		WithArgs(config.GetGlobalServerConfig().ClusterID, changefeedID.String(), 10, 200).
+		WillReturnRows(sqlmock.NewRows([]string{"primary_ts", "secondary_ts"}).AddRow("100", "101"))
+	upMock.ExpectExec("SET @@tidb_snapshot = '100'").WillReturnResult(sqlmock.NewResult(0, 0))
+	downMock.ExpectExec("SET @@tidb_snapshot = '101'").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	upMock.ExpectQuery("SELECT COLUMN_NAME FROM information_schema.COLUMNS "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION").
+		WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id").AddRow("v"))
+	upMock.ExpectQuery("SELECT COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY' ORDER BY ORDINAL_POSITION").
+		WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id"))
+
+	// split the table into chunks (nil, 2] and (2, nil)
+	upMock.ExpectQuery("SELECT `id` FROM `test`.`t` ORDER BY `id` LIMIT 1 OFFSET 1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+	upMock.ExpectQuery("SELECT `id` FROM `test`.`t` WHERE (`id`) > (?) ORDER BY `id` LIMIT 1 OFFSET 1").
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	checksumQuery := "SELECT COUNT(*), COALESCE(BIT_XOR(CAST(CRC32(CONCAT_WS(',', `id`, `v`, ISNULL(`id`), ISNULL(`v`))) AS UNSIGNED)), 0) FROM `test`.`t`"
+	upMock.ExpectQuery(checksumQuery + " WHERE (`id`) <= (?)").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(2, 10))
+	downMock.ExpectQuery(checksumQuery + " WHERE (`id`) <= (?)").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(2, 10))
+	upMock.ExpectQuery(checksumQuery + " WHERE (`id`) > (?)").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(1, 5))
+	downMock.ExpectQuery(checksumQuery + " WHERE (`id`) > (?)").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(0, 0))
+
+	downMock.ExpectExec("SET @@tidb_snapshot = ''").WillReturnResult(sqlmock.NewResult(0, 0))
+	upMock.ExpectExec("SET @@tidb_snapshot = ''").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	checker := NewConsistencyChecker(changefeedID, upstream, downstream, 2)
+	result, err := checker.Check(context.Background(), "test", "t", 10, 200)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), result.PrimaryTs)
+	require.Equal(t, uint64(101), result.SecondaryTs)
+	require.Equal(t, 2, result.ChunkCount)
+	require.Equal(t, []ChunkMismatch{{
+		Index:              1,
+		LowerBound:         []string{"2"},
+		UpstreamCount:      1,
+		DownstreamCount:    0,
+		UpstreamChecksum:   5,
+		DownstreamChecksum: 0,
+	}}, result.Mismatches)
+
+	require.NoError(t, upMock.ExpectationsWereMet())
+	require.NoError(t, downMock.ExpectationsWereMet())
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPort = "4000"
+	// healthCheckInterval is the interval to probe the downstream servers.
+	healthCheckInterval = 5 * time.Second
+	// failoverConnMaxLifetime is the max lifetime of the connections when there are multiple
+	// downstream servers, the connections are recycled so they are rebalanced after a server recovers.
+	failoverConnMaxLifetime = 5 * time.Minute
+)
+
+// parseSinkAddresses returns the downstream addresses in the host of the sink uri,
+// multiple addresses are separated by commas, such as mysql://root@tidb-1:4000,tidb-2:4000/.
+func parseSinkAddresses(sinkURI *url.URL) ([]string, error) {
+	hosts := strings.Split(sinkURI.Host, ",")
+	if len(hosts) == 1 {
+		// This will handle the IPv6 address format.
+		port := sinkURI.Port()
+		if port == "" {
+			port = defaultPort
+		}
+		return []string{net.JoinHostPort(sinkURI.Hostname(), port)}, nil
+	}
+	addrs := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if host == "" {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack("empty address in the sink uri host %s", sinkURI.Host)
+		}
+		hostName, port, err := net.SplitHostPort(host)
+		if err != nil {
+			// the port is omitted
+			hostName, port = host, defaultPort
+		}
+		addrs = append(addrs, net.JoinHostPort(hostName, port))
+	}
+	return addrs, nil
+}
+
+// isConnectionError returns true if the error is caused by the connection to the downstream,
+// instead of the statement, the statement may succeed on another connection.
+func isConnectionError(err error) bool {
+	err = errors.Cause(err)
+	if err == nil {
+		return false
+	}
+	if stderrors.Is(err, driver.ErrBadConn) || stderrors.Is(err, dmysql.ErrInvalidConn) ||
+		stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) ||
+		stderrors.Is(err, syscall.ECONNRESET) || stderrors.Is(err, syscall.ECONNREFUSED) ||
+		stderrors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return stderrors.As(err, &netErr)
+}
+
+// openDB opens the connection pool to the downstream, the pool fails over between
+// the downstream servers if there are multiple addresses in the sink uri.
+func openDB(
+	ctx context.Context, changefeedID common.ChangeFeedID, cfg *MysqlConfig, dsnStr string,
+) (*sql.DB, error) {
+	if len(cfg.Addresses) <= 1 {
+		return CreateMysqlDBConn(dsnStr)
+	}
+	dialTimeout, err := time.ParseDuration(cfg.DialTimeout)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	connector, err := newFailoverConnector(changefeedID, dsnStr, cfg.Addresses, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	connector.start()
+	db := sql.OpenDB(connector)
+	if err = db.PingContext(ctx); err != nil {
+		// close db to recycle resources, the health checker is stopped as well
+		if closeErr := db.Close(); closeErr != nil {
+			log.Warn("close db failed", zap.Error(closeErr))
+		}
+		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+	}
+	db.SetConnMaxLifetime(failoverConnMaxLifetime)
+	log.Info("mysql sink fails over between multiple downstream servers",
+		zap.String("changefeed", changefeedID.String()),
+		zap.Strings("addresses", cfg.Addresses))
+	return db, nil
+}
+
+// downstreamServer is one of the downstream servers of the failover connector.
+type downstreamServer struct {
+	addr      string
+	connector driver.Connector
+	healthy   atomic.Bool
+	gauge     prometheus.Gauge
+}
+
+// failoverConnector dials the connections to multiple downstream servers, such as the TiDB
+// servers behind a load balancer. The new connections are spread over the healthy servers
+// in turn. A server is excluded once it fails to be dialed, until the health checker finds
+// it reachable again.
+type failoverConnector struct {
+	changefeedID common.ChangeFeedID
+	servers      []*downstreamServer
+	next         atomic.Uint64
+	dialTimeout  time.Duration
+	// probe checks whether the server is reachable, it's replaced in tests.
+	probe func(ctx context.Context, addr string) error
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newFailoverConnector(
+	changefeedID common.ChangeFeedID, dsnStr string, addrs []string, dialTimeout time.Duration,
+) (*failoverConnector, error) {
+	dsn, err := dmysql.ParseDSN(dsnStr)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	c := &failoverConnector{
+		changefeedID: changefeedID,
+		servers:      make([]*downstreamServer, 0, len(addrs)),
+		dialTimeout:  dialTimeout,
+	}
+	c.probe = c.dialProbe
+	for _, addr := range addrs {
+		cfg := dsn.Clone()
+		cfg.Addr = addr
+		connector, err := dmysql.NewConnector(cfg)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		s := &downstreamServer{
+			addr:      addr,
+			connector: connector,
+			gauge: metrics.DownstreamServerHealthGauge.WithLabelValues(
+				changefeedID.Namespace(), changefeedID.Name(), addr),
+		}
+		s.healthy.Store(true)
+		s.gauge.Set(1)
+		c.servers = append(c.servers, s)
+	}
+	return c, nil
+}
+
+// start runs the health checker in background, it's stopped by Close.
+func (c *failoverConnector) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.checkHealth(ctx)
+			}
+		}
+	}()
+}
+
+// Connect implements driver.Connector, it dials the healthy servers in turn,
+// and falls back to the unhealthy ones if none of the healthy servers is reachable.
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	start := c.next.Add(1)
+	candidates := make([]*downstreamServer, 0, len(c.servers))
+	var unhealthy []*downstreamServer
+	for i := range c.servers {
+		s := c.servers[(start+uint64(i))%uint64(len(c.servers))]
+		if s.healthy.Load() {
+			candidates = append(candidates, s)
+		} else {
+			unhealthy = append(unhealthy, s)
+		}
+	}
+	candidates = append(candidates, unhealthy...)
+
+	var lastErr error
+	for _, s := range candidates {
+		conn, err := s.connector.Connect(ctx)
+		if err == nil {
+			c.setHealth(s, true, nil)
+			return conn, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if isConnectionError(err) {
+			c.setHealth(s, false, err)
+		}
+	}
+	return nil, lastErr
+}
+
+// Driver implements driver.Connector.
+func (c *failoverConnector) Driver() driver.Driver {
+	return c.servers[0].connector.Driver()
+}
+
+// Close stops the health checker, it's called when the sql.DB is closed.
+func (c *failoverConnector) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	for _, s := range c.servers {
+		metrics.DownstreamServerHealthGauge.DeleteLabelValues(
+			c.changefeedID.Namespace(), c.changefeedID.Name(), s.addr)
+	}
+	return nil
+}
+
+func (c *failoverConnector) checkHealth(ctx context.Context) {
+	for _, s := range c.servers {
+		err := c.probe(ctx, s.addr)
+		if ctx.Err() != nil {
+			return
+		}
+		c.setHealth(s, err == nil, err)
+	}
+}
+
+func (c *failoverConnector) dialProbe(ctx context.Context, addr string) error {
+	dialer := net.Dialer{Timeout: c.dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (c *failoverConnector) setHealth(s *downstreamServer, healthy bool, err error) {
+	if !s.healthy.CompareAndSwap(!healthy, healthy) {
+		return
+	}
+	if healthy {
+		s.gauge.Set(1)
+		log.Info("downstream server recovered, resume dialing it",
+			zap.String("changefeed", c.changefeedID.String()),
+			zap.String("address", s.addr))
+		return
+	}
+	s.gauge.Set(0)
+	log.Warn("downstream server is unreachable, stop dialing it until it recovers",
+		zap.String("changefeed", c.changefeedID.String()),
+		zap.String("address", s.addr),
+		zap.Error(err))
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/url"
+	"syscall"
+	"testing"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSinkAddresses(t *testing.T) {
+	cases := []struct {
+		uri      string
+		expected []string
+	}{
+		{"mysql://root@127.0.0.1:3306/", []string{"127.0.0.1:3306"}},
+		{"mysql://root@127.0.0.1/", []string{"127.0.0.1:4000"}},
+		{"mysql://root@[::1]:3306/", []string{"[::1]:3306"}},
+		{"tidb://root@tidb-1:4000,tidb-2,tidb-3:4001/", []string{"tidb-1:4000", "tidb-2:4000", "tidb-3:4001"}},
+	}
+	for _, c := range cases {
+		sinkURI, err := url.Parse(c.uri)
+		require.NoError(t, err)
+		addrs, err := parseSinkAddresses(sinkURI)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, addrs)
+	}
+
+	sinkURI, err := url.Parse("mysql://root@tidb-1:4000,,tidb-2:4000/")
+	require.NoError(t, err)
+	_, err = parseSinkAddresses(sinkURI)
+	require.Error(t, err)
+}
+
+func TestIsConnectionError(t *testing.T) {
+	require.True(t, isConnectionError(driver.ErrBadConn))
+	require.True(t, isConnectionError(cerror.WrapError(cerror.ErrMySQLTxnError, dmysql.ErrInvalidConn)))
+	require.True(t, isConnectionError(errors.Trace(syscall.ECONNREFUSED)))
+	require.False(t, isConnectionError(nil))
+	require.False(t, isConnectionError(&dmysql.MySQLError{Number: 1062}))
+}
+
+type mockConnector struct {
+	err   error
+	dials int
+}
+
+func (c *mockConnector) Connect(context.Context) (driver.Conn, error) {
+	c.dials++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return nil, nil
+}
+
+func (c *mockConnector) Driver() driver.Driver {
+	return &dmysql.MySQLDriver{}
+}
+
+func TestFailoverConnector(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	c, err := newFailoverConnector(changefeedID, "root@tcp(tidb-1:4000)/",
+		[]string{"tidb-1:4000", "tidb-2:4000", "tidb-3:4000"}, 0)
+	require.NoError(t, err)
+	defer c.Close()
+
+	connectors := make([]*mockConnector, len(c.servers))
+	for i, s := range c.servers {
+		connectors[i] = &mockConnector{}
+		s.connector = connectors[i]
+	}
+
+	// the connections are spread over the servers
+	for i := 0; i < 6; i++ {
+		_, err = c.Connect(context.Background())
+		require.NoError(t, err)
+	}
+	for _, connector := range connectors {
+		require.Equal(t, 2, connector.dials)
+	}
+
+	// the unreachable server is excluded until it recovers
+	connectors[1].err = syscall.ECONNREFUSED
+	for i := 0; i < 6; i++ {
+		_, err = c.Connect(context.Background())
+		require.NoError(t, err)
+	}
+	require.False(t, c.servers[1].healthy.Load())
+	require.LessOrEqual(t, connectors[1].dials, 3)
+	dials := connectors[1].dials
+	for i := 0; i < 6; i++ {
+		_, err = c.Connect(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, dials, connectors[1].dials)
+
+	connectors[1].err = nil
+	c.probe = func(context.Context, string) error { return nil }
+	c.checkHealth(context.Background())
+	require.True(t, c.servers[1].healthy.Load())
+
+	// the unhealthy servers are still dialed if all the servers are unhealthy
+	c.probe = func(context.Context, string) error { return syscall.ECONNREFUSED }
+	c.checkHealth(context.Background())
+	for _, s := range c.servers {
+		require.False(t, s.healthy.Load())
+	}
+	_, err = c.Connect(context.Background())
+	require.NoError(t, err)
+
+	// the errors which are not caused by the connection don't change the health
+	for _, connector := range connectors {
+		connector.err = &dmysql.MySQLError{Number: 1045}
+	}
+	c.probe = func(context.Context, string) error { return nil }
+	c.checkHealth(context.Background())
+	_, err = c.Connect(context.Background())
+	require.Error(t, err)
+	for _, s := range c.servers {
+		require.True(t, s.healthy.Load())
+	}
+}
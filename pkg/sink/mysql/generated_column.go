@@ -0,0 +1,117 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// The modes of writing the stored generated columns to the downstream.
+// The virtual generated columns are never written, since their values are not in the change events.
+const (
+	// GeneratedColumnModeAuto writes a stored generated column if the column of the downstream
+	// table is not a generated column, it's detected from the downstream schema.
+	GeneratedColumnModeAuto = "auto"
+	// GeneratedColumnModeSkip never writes the generated columns, the downstream generates them.
+	GeneratedColumnModeSkip = "skip"
+	// GeneratedColumnModeInclude always writes the stored generated columns, it's used when the
+	// downstream doesn't support generated columns.
+	GeneratedColumnModeInclude = "include"
+)
+
+// generatedColumnsCache is the stored generated columns of a table written to the downstream.
+type generatedColumnsCache struct {
+	tableInfo *common.TableInfo
+	columns   generatedColumns
+}
+
+// getGeneratedColumns returns the stored generated columns of the table written to the downstream,
+// the result is cached until the schema of the table changes.
+func (w *MysqlWriter) getGeneratedColumns(tableInfo *common.TableInfo) generatedColumns {
+	if w.cfg.GeneratedColumnMode == GeneratedColumnModeSkip {
+		return nil
+	}
+	stored := make(generatedColumns)
+	for _, col := range tableInfo.GetColumns() {
+		if col != nil && col.IsGenerated() && col.GeneratedStored {
+			stored[col.ID] = struct{}{}
+		}
+	}
+	if len(stored) == 0 || w.cfg.GeneratedColumnMode == GeneratedColumnModeInclude {
+		return stored
+	}
+
+	tableID := tableInfo.TableName.TableID
+	if cache, ok := w.generatedColumns[tableID]; ok && cache.tableInfo == tableInfo {
+		return cache.columns
+	}
+	downstream, err := w.queryGeneratedColumns(tableInfo.TableName.Schema, tableInfo.TableName.Table)
+	if err != nil {
+		// skip the generated columns as before, the detection is retried by the next batch
+		log.Warn("failed to detect the generated columns of the downstream table, skip them",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.String("table", tableInfo.TableName.String()),
+			zap.Error(err))
+		return nil
+	}
+	for _, col := range tableInfo.GetColumns() {
+		if _, ok := stored[col.ID]; !ok {
+			continue
+		}
+		// the column is generated by the downstream, or doesn't exist in the downstream
+		if isGenerated, ok := downstream[strings.ToLower(col.Name.O)]; !ok || isGenerated {
+			delete(stored, col.ID)
+		}
+	}
+	if w.generatedColumns == nil {
+		w.generatedColumns = make(map[int64]generatedColumnsCache)
+	}
+	w.generatedColumns[tableID] = generatedColumnsCache{tableInfo: tableInfo, columns: stored}
+	log.Info("detect the generated columns of the downstream table",
+		zap.String("changefeed", w.ChangefeedID.String()),
+		zap.String("table", tableInfo.TableName.String()),
+		zap.Int("writtenGeneratedColumns", len(stored)))
+	return stored
+}
+
+// queryGeneratedColumns returns the columns of the downstream table, the value is true if the column is generated.
+func (w *MysqlWriter) queryGeneratedColumns(schema, table string) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(w.ctx, networkDriftDuration)
+	defer cancel()
+	rows, err := w.db.QueryContext(ctx,
+		"SELECT COLUMN_NAME, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+		schema, table)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name, extra string
+		if err = rows.Scan(&name, &extra); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		columns[strings.ToLower(name)] = strings.Contains(strings.ToUpper(extra), "GENERATED")
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return columns, nil
+}
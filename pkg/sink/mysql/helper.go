@@ -18,7 +18,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
-	"net"
+	"regexp"
 	"strconv"
 
 	"github.com/coreos/go-semver/semver"
@@ -32,6 +32,7 @@ import (
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
 	dmutils "github.com/pingcap/tiflow/dm/pkg/conn"
 	"go.uber.org/zap"
 )
@@ -95,11 +96,11 @@ func GenBasicDSN(cfg *MysqlConfig) (*dmysql.Config, error) {
 	}
 	password, _ := cfg.sinkURI.User.Password()
 
-	hostName := cfg.sinkURI.Hostname()
-	port := cfg.sinkURI.Port()
-	if port == "" {
-		port = "4000"
+	addrs, err := parseSinkAddresses(cfg.sinkURI)
+	if err != nil {
+		return nil, err
 	}
+	cfg.Addresses = addrs
 
 	dryRun := cfg.sinkURI.Query().Get("dry-run")
 	if dryRun == "true" {
@@ -107,11 +108,8 @@ func GenBasicDSN(cfg *MysqlConfig) (*dmysql.Config, error) {
 		cfg.DryRun = true
 	}
 
-	// This will handle the IPv6 address format.
 	var dsn *dmysql.Config
-	var err error
-	host := net.JoinHostPort(hostName, port)
-	dsnStr := fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, host, cfg.TLS)
+	dsnStr := fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, addrs[0], cfg.TLS)
 	if dsn, err = dmysql.ParseDSN(dsnStr); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -235,6 +233,10 @@ func generateDSNByConfig(
 		// set the `tidb_enable_external_ts_read` to `OFF`, so cdc could write to the sink
 		dsnCfg.Params["tidb_enable_external_ts_read"] = fmt.Sprintf(`"%s"`, tidbEnableExternalTSRead)
 	}
+	// the session variables configured by the user override the ones above
+	for name, value := range cfg.SessionVariables {
+		dsnCfg.Params[name] = formatSessionVariableValue(value)
+	}
 	dsnClone := dsnCfg.Clone()
 	dsnClone.Passwd = "******"
 	log.Info("sink uri is configured", zap.String("dsn", dsnClone.FormatDSN()))
@@ -242,6 +244,31 @@ func generateDSNByConfig(
 	return dsnCfg.FormatDSN(), nil
 }
 
+var plainSessionVariableValueRe = regexp.MustCompile(`^-?[A-Za-z0-9_.]+$`)
+
+// formatSessionVariableValue formats the value to be used in the SET statement,
+// the numbers and the keywords such as ON are used as is, the others are quoted
+// as MySQL string literals.
+func formatSessionVariableValue(value string) string {
+	if plainSessionVariableValueRe.MatchString(value) {
+		return value
+	}
+	return "'" + sqlescape.EscapeString(value) + "'"
+}
+
+// checkSessionVariables sets the session variables on the downstream,
+// so the invalid ones are reported before the sink is created.
+func checkSessionVariables(db *sql.DB, variables map[string]string) error {
+	for name, value := range variables {
+		query := fmt.Sprintf("SET SESSION %s = %s", name, formatSessionVariableValue(value))
+		if _, err := db.ExecContext(context.Background(), query); err != nil {
+			return cerror.ErrMySQLInvalidConfig.Wrap(err).GenWithStack(
+				"invalid session variable %s=%s", name, value)
+		}
+	}
+	return nil
+}
+
 // check whether the target charset is supported
 func checkCharsetSupport(db *sql.DB, charsetName string) (bool, error) {
 	// validate charsetName
@@ -271,8 +298,17 @@ func GenerateDSN(cfg *MysqlConfig) (string, error) {
 		return "", err
 	}
 
+	// The variables are detected on the first reachable downstream server.
 	var testDB *sql.DB
-	testDB, err = GetTestDB(dsn)
+	for i, addr := range cfg.Addresses {
+		dsn.Addr = addr
+		testDB, err = GetTestDB(dsn)
+		if err == nil || !isConnectionError(err) || i == len(cfg.Addresses)-1 {
+			break
+		}
+		log.Warn("downstream server is unreachable, try the next one",
+			zap.String("address", addr), zap.Error(err))
+	}
 	if err != nil {
 		return "", err
 	}
@@ -291,6 +327,9 @@ func GenerateDSN(cfg *MysqlConfig) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if err = checkSessionVariables(testDB, cfg.SessionVariables); err != nil {
+		return "", err
+	}
 
 	// check if GBK charset is supported by downstream
 	var gbkSupported bool
@@ -17,14 +17,18 @@ import (
 	"context"
 	"database/sql"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"go.uber.org/zap"
 )
 
 const (
@@ -49,6 +53,17 @@ type MysqlWriter struct {
 	ddlTsTableInit   bool
 	tableSchemaStore *util.TableSchemaStore
 
+	// ddlHistoryTableInit is accessed by the async ddl goroutine too.
+	ddlHistoryTableInit atomic.Bool
+
+	rowCountAuditTableInit bool
+
+	deadLetterQueueTableInit bool
+	dmlProgressTableInit     bool
+	// appliedCommitTs caches the last commit ts applied to the downstream of the table spans,
+	// it's only used in the exactly-once mode.
+	appliedCommitTs *common.SpanHashMap[uint64]
+
 	// asyncDDLState is used to store the state of async ddl.
 	// key: tableID, value: state(0: unknown state , 1: executing, 2: no executing ddl)
 	asyncDDLState sync.Map
@@ -61,6 +76,10 @@ type MysqlWriter struct {
 
 	statistics *metrics.Statistics
 	needFormat bool
+
+	// generatedColumns caches the stored generated columns of the tables written to the downstream,
+	// the key is the table id.
+	generatedColumns map[int64]generatedColumnsCache
 }
 
 func NewMysqlWriter(
@@ -85,6 +104,7 @@ func NewMysqlWriter(
 		stmtCache:              cfg.stmtCache,
 		statistics:             statistics,
 		needFormat:             needFormatVectorType,
+		appliedCommitTs:        common.NewSpanHashMap[uint64](),
 	}
 }
 
@@ -99,7 +119,19 @@ func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 	}
 
 	// check the ddl should by async or sync executed.
-	if needAsyncExecDDL(event.GetDDLType()) && w.cfg.IsTiDB {
+	if w.applyDDLIntervention(event) {
+		// the skipped ddl is regarded as executed, so it's not executed after the changefeed is restarted.
+		if err := w.FlushDDLTs(event); err != nil {
+			return err
+		}
+	} else if w.cfg.DDLDryRun {
+		// the ddl is only validated, but the ddl ts is still flushed, so the
+		// ddl is not validated again after the changefeed is restarted.
+		w.dryRunDDL(event)
+		if err := w.FlushDDLTs(event); err != nil {
+			return err
+		}
+	} else if needAsyncExecDDL(event.GetDDLType()) && w.cfg.IsTiDB {
 		// for async exec ddl, we don't flush ddl ts here. Because they don't block checkpointTs.
 		err := w.asyncExecAddIndexDDLIfTimeout(event)
 		if err != nil {
@@ -131,6 +163,12 @@ func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 }
 
 func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent) error {
+	if w.cfg.ExactlyOnce && !w.cfg.DryRun {
+		var err error
+		if events, err = w.filterAppliedEvents(events); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	dmls, err := w.prepareDMLs(events)
 	if err != nil {
 		return errors.Trace(err)
@@ -142,8 +180,28 @@ func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent) error {
 	}
 
 	if !w.cfg.DryRun {
-		if err = w.execDMLWithMaxRetries(dmls); err != nil {
-			return errors.Trace(err)
+		err = w.execDMLWithMaxRetries(dmls)
+		if err != nil && isAmbiguousCommitError(err) {
+			if w.cfg.ExactlyOnce {
+				events, err = w.execDMLAfterAmbiguousCommit(events, err)
+			} else {
+				err = w.execDMLInSafeMode(events, err)
+			}
+		}
+		if err != nil && w.cfg.EnableDeadLetterQueue && !w.cfg.ExactlyOnce && apperror.IsDupEntryError(err) {
+			// the duplicated rows have been applied before, they are not diverted
+			// to the dead letter queue but applied again in safe mode.
+			err = w.execDMLInSafeMode(events, err)
+		}
+		if err != nil {
+			if !w.cfg.EnableDeadLetterQueue || !apperror.IsUnprocessableDMLError(err) {
+				return errors.Trace(err)
+			}
+			log.Warn("failed to apply the dmls, try to apply them row by row",
+				zap.String("changefeed", w.ChangefeedID.String()), zap.Error(err))
+			if err = w.execDMLRowByRow(events); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	} else {
 		if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
@@ -153,6 +211,9 @@ func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent) error {
 		}
 	}
 
+	if w.cfg.ExactlyOnce && !w.cfg.DryRun {
+		w.updateAppliedCommitTs(events)
+	}
 	for _, event := range events {
 		for _, callback := range event.PostTxnFlushed {
 			callback()
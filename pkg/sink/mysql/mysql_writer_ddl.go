@@ -26,7 +26,6 @@ import (
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/retry"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
-	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"go.uber.org/zap"
 )
 
@@ -195,7 +194,9 @@ func (w *MysqlWriter) execDDL(event *commonEvent.DDLEvent) error {
 }
 
 func (w *MysqlWriter) execDDLWithMaxRetries(event *commonEvent.DDLEvent) error {
-	return retry.Do(w.ctx, func() error {
+	start := time.Now()
+	var ignoredErr error
+	err := retry.Do(w.ctx, func() error {
 		err := w.statistics.RecordDDLExecution(func() error { return w.execDDL(event) })
 		if err != nil {
 			if apperror.IsIgnorableMySQLDDLError(err) {
@@ -204,6 +205,7 @@ func (w *MysqlWriter) execDDLWithMaxRetries(event *commonEvent.DDLEvent) error {
 					zap.String("ddl", event.Query),
 					zap.Error(err))
 				// If the error is ignorable, we will ignore the error directly.
+				ignoredErr = err
 				return nil
 			}
 			log.Warn("Execute DDL with error, retry later",
@@ -215,10 +217,12 @@ func (w *MysqlWriter) execDDLWithMaxRetries(event *commonEvent.DDLEvent) error {
 			zap.String("changefeed", w.ChangefeedID.String()),
 			zap.Any("ddl", event))
 		return nil
-	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
-		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
-		retry.WithMaxTries(defaultDDLMaxRetry),
-		retry.WithIsRetryableErr(apperror.IsRetryableDDLError))
+	}, retry.WithMaxTries(defaultDDLMaxRetry),
+		retry.WithErrorClassifier(apperror.ClassifyDDLError))
+	if w.cfg.EnableDDLHistory && !w.cfg.DryRun {
+		w.recordDDLHistory(event, time.Since(start), ignoredErr, err)
+	}
+	return err
 }
 
 func (w *MysqlWriter) waitAsyncDDLDone(event *commonEvent.DDLEvent) {
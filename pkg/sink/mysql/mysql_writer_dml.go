@@ -22,15 +22,45 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/retry"
-	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// ambiguousCommitError is returned if the connection is lost while committing a transaction
+// which can't be applied twice, the transaction may be committed or not.
+type ambiguousCommitError struct {
+	err error
+}
+
+func (e *ambiguousCommitError) Error() string {
+	return "the result of the commit is unknown: " + e.err.Error()
+}
+
+// classifyDMLError returns the class of the error met by executing the dmls in the downstream.
+func classifyDMLError(err error) cerror.RetryClass {
+	// the transaction is retried in safe mode by the caller if the result of the commit is unknown
+	if isAmbiguousCommitError(err) {
+		return cerror.RetryClassFatal
+	}
+	return cerror.RetryClassOf(err)
+}
+
+func isAmbiguousCommitError(err error) bool {
+	_, ok := errors.Cause(err).(*ambiguousCommitError)
+	return ok
+}
+
 func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs, error) {
+	return w.prepareDMLsWithSafeMode(events, w.cfg.SafeMode)
+}
+
+// prepareDMLsWithSafeMode prepares the dmls of the events, all the rows are written by
+// REPLACE and DELETE if safeMode is true, so the dmls can be applied more than once.
+func (w *MysqlWriter) prepareDMLsWithSafeMode(events []*commonEvent.DMLEvent, safeMode bool) (*preparedDMLs, error) {
 	dmls := dmlsPool.Get().(*preparedDMLs)
 	dmls.reset()
 
@@ -46,12 +76,14 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 			dmls.startTs = append(dmls.startTs, event.StartTs)
 		}
 
-		translateToInsert := !w.cfg.SafeMode && event.CommitTs > event.ReplicatingTs
+		translateToInsert := !safeMode && event.CommitTs > event.ReplicatingTs
 		log.Debug("translate to insert",
 			zap.Bool("translateToInsert", translateToInsert),
 			zap.Uint64("firstRowCommitTs", event.CommitTs),
 			zap.Uint64("firstRowReplicatingTs", event.ReplicatingTs),
-			zap.Bool("safeMode", w.cfg.SafeMode))
+			zap.Bool("safeMode", safeMode))
+		dmls.translateToInsert = dmls.translateToInsert || translateToInsert
+		generated := w.getGeneratedColumns(event.TableInfo)
 
 		for {
 			row, ok := event.GetNextRow()
@@ -59,44 +91,19 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 				break
 			}
 
-			var query string
-			var args []interface{}
 			var err error
-
-			switch row.RowType {
-			case commonEvent.RowTypeUpdate:
-				if translateToInsert {
-					query, args, err = buildUpdate(event.TableInfo, row)
-				} else {
-					query, args, err = buildDelete(event.TableInfo, row)
-					if err != nil {
-						dmlsPool.Put(dmls) // Return to pool on error
-						return nil, errors.Trace(err)
-					}
-					if query != "" {
-						dmls.sqls = append(dmls.sqls, query)
-						dmls.values = append(dmls.values, args)
-					}
-					query, args, err = buildInsert(event.TableInfo, row, translateToInsert)
-				}
-			case commonEvent.RowTypeDelete:
-				query, args, err = buildDelete(event.TableInfo, row)
-			case commonEvent.RowTypeInsert:
-				query, args, err = buildInsert(event.TableInfo, row, translateToInsert)
-			}
-
+			dmls.sqls, dmls.values, err = appendRowDMLs(dmls.sqls, dmls.values, event.TableInfo, row, translateToInsert, generated)
 			if err != nil {
 				dmlsPool.Put(dmls) // Return to pool on error
 				return nil, errors.Trace(err)
 			}
-
-			if query != "" {
-				dmls.sqls = append(dmls.sqls, query)
-				dmls.values = append(dmls.values, args)
-			}
 		}
 	}
 
+	if w.cfg.ExactlyOnce && dmls.rowCount > 0 {
+		w.appendDMLProgress(dmls, events)
+	}
+
 	// Pre-check log level to avoid dmls.String() being called unnecessarily
 	// This method is expensive, so we only log it when the log level is debug.
 	if log.GetLevel() == zapcore.DebugLevel {
@@ -106,6 +113,48 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 	return dmls, nil
 }
 
+// appendRowDMLs appends the sqls and args of the row change,
+// an update is split into a delete and an insert if it's not translated to insert.
+func appendRowDMLs(
+	sqls []string, values [][]interface{},
+	tableInfo *common.TableInfo, row commonEvent.RowChange, translateToInsert bool, generated generatedColumns,
+) ([]string, [][]interface{}, error) {
+	var query string
+	var args []interface{}
+	var err error
+
+	switch row.RowType {
+	case commonEvent.RowTypeUpdate:
+		if translateToInsert {
+			query, args, err = buildUpdate(tableInfo, row, generated)
+		} else {
+			query, args, err = buildDelete(tableInfo, row)
+			if err != nil {
+				return sqls, values, errors.Trace(err)
+			}
+			if query != "" {
+				sqls = append(sqls, query)
+				values = append(values, args)
+			}
+			query, args, err = buildInsert(tableInfo, row, translateToInsert, generated)
+		}
+	case commonEvent.RowTypeDelete:
+		query, args, err = buildDelete(tableInfo, row)
+	case commonEvent.RowTypeInsert:
+		query, args, err = buildInsert(tableInfo, row, translateToInsert, generated)
+	}
+
+	if err != nil {
+		return sqls, values, errors.Trace(err)
+	}
+
+	if query != "" {
+		sqls = append(sqls, query)
+		values = append(values, args)
+	}
+	return sqls, values, nil
+}
+
 func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	if len(dmls.sqls) != len(dmls.values) {
 		return cerror.ErrUnexpected.FastGenByArgs(fmt.Sprintf("unexpected number of sqls and values, sqls is %s, values is %s", dmls.sqls, dmls.values))
@@ -151,6 +200,9 @@ func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 		}
 
 		if err = tx.Commit(); err != nil {
+			if dmls.translateToInsert && isConnectionError(err) {
+				return 0, 0, &ambiguousCommitError{err: err}
+			}
 			return 0, 0, err
 		}
 		log.Debug("Exec Rows succeeded")
@@ -162,9 +214,25 @@ func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 			return errors.Trace(err)
 		}
 		return nil
-	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
-		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
-		retry.WithMaxTries(w.cfg.DMLMaxRetry))
+	}, retry.WithMaxTries(w.cfg.DMLMaxRetry),
+		retry.WithErrorClassifier(classifyDMLError))
+}
+
+// execDMLInSafeMode applies the events again in safe mode, it's called if the connection is
+// lost while committing them, or some of their rows are duplicated, since they may be
+// committed already and can't be applied twice.
+func (w *MysqlWriter) execDMLInSafeMode(events []*commonEvent.DMLEvent, cause error) error {
+	log.Warn("the transactions may be applied before, apply them again in safe mode",
+		zap.String("changefeed", w.ChangefeedID.String()), zap.Error(cause))
+	for _, event := range events {
+		event.Rewind()
+	}
+	dmls, err := w.prepareDMLsWithSafeMode(events, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer dmlsPool.Put(dmls)
+	return w.execDMLWithMaxRetries(dmls)
 }
 
 func (w *MysqlWriter) sequenceExecute(
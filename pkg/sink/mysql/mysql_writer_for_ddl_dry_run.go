@@ -0,0 +1,141 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"go.uber.org/zap"
+)
+
+// grantRe matches the privileges and the target of a grant returned by SHOW GRANTS,
+// the grants of the roles, which have no target, are not matched.
+var grantRe = regexp.MustCompile("^GRANT (.+) ON (\\S+) TO ")
+
+// dryRunDDL validates the ddl against the downstream instead of executing it. The ddl is parsed
+// and translated as it would be executed, and the privilege it needs is checked against the grants
+// of the downstream user. The result is reported instead of failing the changefeed, since the users
+// manage the downstream schema by themselves in the dry-run mode.
+func (w *MysqlWriter) dryRunDDL(event *commonEvent.DDLEvent) {
+	query, err := w.validateDDL(event.GetDDLSchemaName(), event.GetDDLQuery())
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+		log.Warn("DDL dry run, the ddl can't be executed in the downstream",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("schema", event.GetDDLSchemaName()),
+			zap.String("ddl", query),
+			zap.Error(err))
+	} else {
+		log.Info("DDL dry run, the ddl would be executed in the downstream",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("schema", event.GetDDLSchemaName()),
+			zap.String("ddl", query))
+	}
+	if !w.cfg.EnableDDLHistory {
+		return
+	}
+	if err = w.sendDDLHistory(event, 0, ddlHistoryResultDryRun, errMsg); err != nil {
+		log.Warn("failed to record ddl history",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("ddl", event.GetDDLQuery()),
+			zap.Error(err))
+	}
+}
+
+// validateDDL returns the query which would be executed in the downstream, and an error
+// if the query can't be parsed or the downstream user lacks the privilege to execute it.
+func (w *MysqlWriter) validateDDL(schema, query string) (string, error) {
+	stmt, err := parser.New().ParseOneStmt(query, "", "")
+	if err != nil {
+		return query, errors.Trace(err)
+	}
+	if w.needFormat {
+		query = formatQuery(query)
+	}
+	privilege := requiredDDLPrivilege(stmt)
+	if privilege == "" {
+		return query, nil
+	}
+	rows, err := w.db.QueryContext(w.ctx, "SHOW GRANTS")
+	if err != nil {
+		return query, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	var grants []string
+	for rows.Next() {
+		var grant string
+		if err = rows.Scan(&grant); err != nil {
+			return query, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		grants = append(grants, grant)
+	}
+	if err = rows.Err(); err != nil {
+		return query, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	if !hasPrivilege(grants, privilege, schema) {
+		return query, errors.Errorf("the downstream user has no %s privilege on schema %s", privilege, schema)
+	}
+	return query, nil
+}
+
+// requiredDDLPrivilege returns the privilege needed to execute the ddl,
+// it's empty if the privilege of the ddl is not checked.
+func requiredDDLPrivilege(stmt ast.StmtNode) string {
+	switch stmt.(type) {
+	case *ast.CreateDatabaseStmt, *ast.CreateTableStmt, *ast.CreateSequenceStmt:
+		return "CREATE"
+	case *ast.CreateViewStmt:
+		return "CREATE VIEW"
+	case *ast.DropDatabaseStmt, *ast.DropTableStmt, *ast.DropSequenceStmt, *ast.TruncateTableStmt:
+		return "DROP"
+	case *ast.AlterDatabaseStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
+		return "ALTER"
+	case *ast.CreateIndexStmt, *ast.DropIndexStmt:
+		return "INDEX"
+	}
+	return ""
+}
+
+// hasPrivilege returns true if the grants have the privilege on all the schemas or the schema,
+// the grants on the tables are not taken into account.
+func hasPrivilege(grants []string, privilege, schema string) bool {
+	for _, grant := range grants {
+		matches := grantRe.FindStringSubmatch(grant)
+		if len(matches) != 3 {
+			continue
+		}
+		target := strings.ReplaceAll(matches[2], "`", "")
+		if target != "*.*" && !strings.EqualFold(target, schema+".*") {
+			continue
+		}
+		for _, p := range strings.Split(matches[1], ",") {
+			p = strings.ToUpper(strings.TrimSpace(p))
+			if p == privilege || p == "ALL" || p == "ALL PRIVILEGES" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"go.uber.org/zap"
+)
+
+const (
+	ddlHistoryResultSuccess = "success"
+	ddlHistoryResultIgnored = "ignored"
+	ddlHistoryResultFailed  = "failed"
+	ddlHistoryResultDryRun  = "dry-run"
+	ddlHistoryResultSkipped = "skipped"
+)
+
+func (w *MysqlWriter) CreateDDLHistoryTable() error {
+	database := filter.TiCDCSystemSchema
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		id bigint NOT NULL AUTO_INCREMENT,
+		ticdc_cluster_id varchar (255),
+		changefeed varchar(255),
+		commit_ts bigint unsigned,
+		ddl_type varchar(64),
+		schema_name varchar(255),
+		table_name varchar(255),
+		query text,
+		execution_time_ms bigint,
+		result varchar(16),
+		error text,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		INDEX (ticdc_cluster_id, changefeed, commit_ts),
+		INDEX (created_at),
+		PRIMARY KEY (id)
+	);`
+	query = fmt.Sprintf(query, filter.DDLHistoryTable)
+	return w.CreateTable(database, filter.DDLHistoryTable, query)
+}
+
+// recordDDLHistory writes the execution of the ddl into the ddl history table.
+// The history is only for audit, so the failure is logged instead of blocking the changefeed.
+func (w *MysqlWriter) recordDDLHistory(event *commonEvent.DDLEvent, duration time.Duration, ignoredErr, execErr error) {
+	result, errMsg := ddlHistoryResultSuccess, ""
+	if execErr != nil {
+		result, errMsg = ddlHistoryResultFailed, execErr.Error()
+	} else if ignoredErr != nil {
+		result, errMsg = ddlHistoryResultIgnored, ignoredErr.Error()
+	}
+	if err := w.sendDDLHistory(event, duration, result, errMsg); err != nil {
+		log.Warn("failed to record ddl history",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("ddl", event.GetDDLQuery()),
+			zap.Error(err))
+	}
+}
+
+func (w *MysqlWriter) sendDDLHistory(event *commonEvent.DDLEvent, duration time.Duration, result, errMsg string) error {
+	if !w.ddlHistoryTableInit.Load() {
+		// create ddl history table if not exist
+		if err := w.CreateDDLHistoryTable(); err != nil {
+			return err
+		}
+		w.ddlHistoryTableInit.Store(true)
+	}
+	query := fmt.Sprintf("INSERT INTO %s.%s (ticdc_cluster_id, changefeed, commit_ts, ddl_type, schema_name, table_name, query, execution_time_ms, result, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		filter.TiCDCSystemSchema, filter.DDLHistoryTable)
+	_, err := w.db.ExecContext(w.ctx, query,
+		config.GetGlobalServerConfig().ClusterID,
+		w.ChangefeedID.String(),
+		event.GetCommitTs(),
+		event.GetDDLType().String(),
+		event.GetDDLSchemaName(),
+		event.TableName,
+		event.GetDDLQuery(),
+		duration.Milliseconds(),
+		result,
+		errMsg)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to write ddl history table; Query is %s", query)))
+	}
+	return nil
+}
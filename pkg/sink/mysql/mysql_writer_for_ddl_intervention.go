@@ -0,0 +1,57 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"go.uber.org/zap"
+)
+
+// applyDDLIntervention applies the intervention the users made on the ddl after it failed in the downstream,
+// the query of the ddl is replaced in place, and true is returned if the ddl is skipped.
+func (w *MysqlWriter) applyDDLIntervention(event *commonEvent.DDLEvent) bool {
+	intervention, ok := w.cfg.DDLInterventions[event.GetCommitTs()]
+	if !ok {
+		return false
+	}
+	switch intervention.Action {
+	case config.DDLInterventionReplace:
+		log.Info("the ddl is replaced by the user",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("ddl", event.Query),
+			zap.String("replacedBy", intervention.Query))
+		event.Query = intervention.Query
+		return false
+	case config.DDLInterventionSkip:
+		log.Info("the ddl is skipped by the user",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("ddl", event.Query))
+		if !w.cfg.EnableDDLHistory {
+			return true
+		}
+		if err := w.sendDDLHistory(event, 0, ddlHistoryResultSkipped, ""); err != nil {
+			log.Warn("failed to record ddl history",
+				zap.String("changefeed", w.ChangefeedID.String()),
+				zap.Uint64("commitTs", event.GetCommitTs()),
+				zap.String("ddl", event.Query),
+				zap.Error(err))
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
+	"go.uber.org/zap"
+)
+
+func (w *MysqlWriter) CreateDeadLetterQueueTable() error {
+	database := filter.TiCDCSystemSchema
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		id bigint NOT NULL AUTO_INCREMENT,
+		ticdc_cluster_id varchar (255),
+		changefeed varchar(255),
+		schema_name varchar(255),
+		table_name varchar(255),
+		commit_ts bigint unsigned,
+		row_type varchar(16),
+		error_code int,
+		error_message text,
+		columns longtext,
+		pre_columns longtext,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		INDEX (ticdc_cluster_id, changefeed, commit_ts),
+		INDEX (created_at),
+		PRIMARY KEY (id)
+	);`
+	query = fmt.Sprintf(query, filter.DeadLetterQueueTable)
+	return w.CreateTable(database, filter.DeadLetterQueueTable, query)
+}
+
+// execDMLRowByRow applies the rows of the events one by one after the batch failed
+// with an unprocessable error. The rows which still fail with an unprocessable error
+// are written into the dead letter queue table, so that the changefeed can keep progressing.
+// Note the rows of a transaction are no longer applied atomically in this case.
+func (w *MysqlWriter) execDMLRowByRow(events []*commonEvent.DMLEvent) error {
+	var records []*dlq.Record
+	for _, event := range events {
+		event.Rewind()
+		translateToInsert := !w.cfg.SafeMode && event.CommitTs > event.ReplicatingTs
+		generated := w.getGeneratedColumns(event.TableInfo)
+		for {
+			row, ok := event.GetNextRow()
+			if !ok {
+				break
+			}
+			sqls, values, err := appendRowDMLs(nil, nil, event.TableInfo, row, translateToInsert, generated)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = w.execRowDMLs(sqls, values)
+			if err != nil && translateToInsert && apperror.IsDupEntryError(err) {
+				// the row has been applied before, e.g. it's replayed after the changefeed
+				// restarts, so apply it in safe mode instead of diverting it.
+				log.Info("the row is duplicated, apply it in safe mode",
+					zap.String("changefeed", w.ChangefeedID.String()),
+					zap.Stringer("table", event.TableInfo.TableName),
+					zap.Uint64("commitTs", event.CommitTs))
+				sqls, values, err = appendRowDMLs(nil, nil, event.TableInfo, row, false, generated)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				err = w.execRowDMLs(sqls, values)
+			}
+			if err == nil {
+				continue
+			}
+			// a duplicated row is never a poison row, it's retried by the changefeed.
+			if !apperror.IsUnprocessableDMLError(err) || apperror.IsDupEntryError(err) {
+				return errors.Trace(err)
+			}
+			errorCode := 0
+			if mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError); ok {
+				errorCode = int(mysqlErr.Number)
+			}
+			record, encodeErr := dlq.NewRecord(event.TableInfo, event.CommitTs, &row, errorCode, err)
+			if encodeErr != nil {
+				return errors.Trace(encodeErr)
+			}
+			log.Warn("divert the unprocessable row to the dead letter queue",
+				zap.String("changefeed", w.ChangefeedID.String()),
+				zap.String("schema", record.Schema),
+				zap.String("table", record.Table),
+				zap.Uint64("commitTs", record.CommitTs),
+				zap.Error(err))
+			records = append(records, record)
+		}
+	}
+	return w.FlushDeadLetterQueue(records)
+}
+
+// execRowDMLs executes the sqls of a row in a transaction.
+func (w *MysqlWriter) execRowDMLs(sqls []string, values [][]interface{}) error {
+	tx, err := w.db.BeginTx(w.ctx, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = SetWriteSource(w.cfg, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for i, query := range sqls {
+		if _, err = tx.ExecContext(w.ctx, query, values[i]...); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && errors.Cause(rbErr) != context.Canceled {
+				log.Warn("failed to rollback txn", zap.Error(rbErr))
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// FlushDeadLetterQueue writes the records into the dead letter queue table.
+func (w *MysqlWriter) FlushDeadLetterQueue(records []*dlq.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if !w.deadLetterQueueTableInit {
+		// create dead letter queue table if not exist
+		if err := w.CreateDeadLetterQueueTable(); err != nil {
+			return err
+		}
+		w.deadLetterQueueTableInit = true
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("INSERT INTO %s.%s (ticdc_cluster_id, changefeed, schema_name, table_name, commit_ts, row_type, error_code, error_message, columns, pre_columns) VALUES ",
+		filter.TiCDCSystemSchema, filter.DeadLetterQueueTable))
+	args := make([]interface{}, 0, len(records)*10)
+	for i, record := range records {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		columns, err := encodeColumns(record.Columns)
+		if err != nil {
+			return err
+		}
+		preColumns, err := encodeColumns(record.PreColumns)
+		if err != nil {
+			return err
+		}
+		builder.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			config.GetGlobalServerConfig().ClusterID,
+			w.ChangefeedID.String(),
+			record.Schema,
+			record.Table,
+			record.CommitTs,
+			record.RowType,
+			record.ErrorCode,
+			record.Error,
+			columns,
+			preColumns)
+	}
+	query := builder.String()
+	_, err := w.db.ExecContext(w.ctx, query, args...)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to write dead letter queue table; Query is %s", query)))
+	}
+	metrics.DeadLetterQueueRowsCounter.
+		WithLabelValues(w.ChangefeedID.Namespace(), w.ChangefeedID.Name()).Add(float64(len(records)))
+	return nil
+}
+
+func encodeColumns(columns map[string]interface{}) (interface{}, error) {
+	if columns == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(columns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"go.uber.org/zap"
+)
+
+// In the exactly-once mode, the last applied commit ts of each table span is written into the dml
+// progress table in the same transaction as the rows of the span. After a failover, the dispatcher
+// replays the transactions after its checkpoint ts, and the ones not after the applied commit ts
+// are filtered, since they are committed in the downstream already.
+// The progress is keyed by the table span rather than the dispatcher, the dispatcher id is generated
+// again after a restart or a table move, while the span of the table is kept. The progress of the old
+// spans is kept after a span is split or merged, so a key is applied to the max commit ts of the spans
+// covering it, and the new span is applied to the min of its keys.
+
+func (w *MysqlWriter) CreateDMLProgressTable() error {
+	database := filter.TiCDCSystemSchema
+	// the cluster id and the changefeed id are ascii, so the primary key fits the max index length.
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		ticdc_cluster_id varchar (255) CHARACTER SET ascii,
+		changefeed varchar(255) CHARACTER SET ascii,
+		table_id bigint,
+		start_key varbinary(1024),
+		end_key varbinary(1024),
+		commit_ts bigint unsigned,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (ticdc_cluster_id, changefeed, table_id, start_key, end_key)
+	);`
+	query = fmt.Sprintf(query, filter.DMLProgressTable)
+	return w.CreateTable(database, filter.DMLProgressTable, query)
+}
+
+// getTableSpan returns the table span which the progress of the event is recorded for,
+// the event not from a dispatcher is regarded as from the span of the whole table.
+func getTableSpan(event *commonEvent.DMLEvent) heartbeatpb.TableSpan {
+	if event.TableSpan != nil {
+		return *event.TableSpan
+	}
+	span := common.ToSpan(common.GetTableRange(event.PhysicalTableID))
+	span.TableID = event.PhysicalTableID
+	return span
+}
+
+// filterAppliedEvents returns the events not applied to the downstream yet, the applied
+// events are regarded as flushed, and their callbacks are called.
+func (w *MysqlWriter) filterAppliedEvents(events []*commonEvent.DMLEvent) ([]*commonEvent.DMLEvent, error) {
+	if !w.dmlProgressTableInit {
+		if err := w.CreateDMLProgressTable(); err != nil {
+			return nil, err
+		}
+		w.dmlProgressTableInit = true
+	}
+	pending := make([]*commonEvent.DMLEvent, 0, len(events))
+	for _, event := range events {
+		appliedCommitTs, err := w.getAppliedCommitTs(getTableSpan(event))
+		if err != nil {
+			return nil, err
+		}
+		if event.CommitTs > appliedCommitTs {
+			pending = append(pending, event)
+			continue
+		}
+		log.Debug("the transaction is already applied to the downstream, skip it",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Stringer("dispatcherID", event.DispatcherID),
+			zap.Int64("tableID", event.PhysicalTableID),
+			zap.Uint64("commitTs", event.CommitTs),
+			zap.Uint64("appliedCommitTs", appliedCommitTs))
+		for _, callback := range event.PostTxnFlushed {
+			callback()
+		}
+	}
+	return pending, nil
+}
+
+// getAppliedCommitTs returns the last commit ts applied to the downstream of the span,
+// it's read from the dml progress table only once, and maintained in memory after that.
+func (w *MysqlWriter) getAppliedCommitTs(span heartbeatpb.TableSpan) (uint64, error) {
+	if commitTs, ok := w.appliedCommitTs.Get(span); ok {
+		return commitTs, nil
+	}
+	commitTs, err := w.readAppliedCommitTs(span)
+	if err != nil {
+		return 0, err
+	}
+	w.appliedCommitTs.ReplaceOrInsert(span, commitTs)
+	return commitTs, nil
+}
+
+// readAppliedCommitTs reads the applied commit ts of the span from the dml progress table.
+// The rows of the spans overlapping with the span are read, the span may be split from or merged
+// by them.
+func (w *MysqlWriter) readAppliedCommitTs(span heartbeatpb.TableSpan) (uint64, error) {
+	query := fmt.Sprintf("SELECT start_key, end_key, commit_ts FROM %s.%s "+
+		"WHERE ticdc_cluster_id = ? AND changefeed = ? AND table_id = ? AND start_key < ? ORDER BY start_key",
+		filter.TiCDCSystemSchema, filter.DMLProgressTable)
+	rows, err := w.db.QueryContext(w.ctx, query,
+		config.GetGlobalServerConfig().ClusterID, w.ChangefeedID.String(), span.TableID, span.EndKey)
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, fmt.Sprintf("failed to read dml progress; Query is %s", query)))
+	}
+	defer rows.Close()
+
+	var progresses []spanProgress
+	for rows.Next() {
+		var p spanProgress
+		if err = rows.Scan(&p.startKey, &p.endKey, &p.commitTs); err != nil {
+			return 0, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, fmt.Sprintf("failed to read dml progress; Query is %s", query)))
+		}
+		if bytes.Compare(p.endKey, span.StartKey) <= 0 {
+			continue
+		}
+		progresses = append(progresses, p)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, cerror.WrapError(cerror.ErrMySQLQueryError, errors.WithMessage(err, fmt.Sprintf("failed to read dml progress; Query is %s", query)))
+	}
+	return appliedCommitTsOf(span, progresses), nil
+}
+
+// spanProgress is a row of the dml progress table.
+type spanProgress struct {
+	startKey, endKey []byte
+	commitTs         uint64
+}
+
+// appliedCommitTsOf returns the commit ts the span is applied to by the progresses overlapping it.
+// A key is applied to the max commit ts of the progresses covering it, the progress of a split or
+// merged span may be older than the progress of the new spans. The span is applied to the min of
+// its keys, it's 0 if some part of the span is not covered by any progress.
+func appliedCommitTsOf(span heartbeatpb.TableSpan, progresses []spanProgress) uint64 {
+	// the boundaries of the progresses split the span into the segments covered by the same progresses
+	bounds := [][]byte{span.StartKey, span.EndKey}
+	for _, p := range progresses {
+		for _, key := range [][]byte{p.startKey, p.endKey} {
+			if bytes.Compare(key, span.StartKey) > 0 && bytes.Compare(key, span.EndKey) < 0 {
+				bounds = append(bounds, key)
+			}
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bytes.Compare(bounds[i], bounds[j]) < 0 })
+
+	var applied uint64
+	first := true
+	for i := 0; i+1 < len(bounds); i++ {
+		if bytes.Equal(bounds[i], bounds[i+1]) {
+			continue
+		}
+		var (
+			segment uint64
+			covered bool
+		)
+		for _, p := range progresses {
+			if bytes.Compare(p.startKey, bounds[i]) <= 0 && bytes.Compare(p.endKey, bounds[i+1]) >= 0 {
+				covered = true
+				segment = max(segment, p.commitTs)
+			}
+		}
+		if !covered {
+			return 0
+		}
+		if first || segment < applied {
+			applied = segment
+			first = false
+		}
+	}
+	return applied
+}
+
+// appendDMLProgress appends the statement which records the max commit ts of each table span
+// in the events to the dmls, so the progress is committed in the same transaction as the rows.
+func (w *MysqlWriter) appendDMLProgress(dmls *preparedDMLs, events []*commonEvent.DMLEvent) {
+	progress := common.NewSpanHashMap[uint64]()
+	spans := make([]heartbeatpb.TableSpan, 0, len(events))
+	for _, event := range events {
+		span := getTableSpan(event)
+		commitTs, ok := progress.Get(span)
+		if !ok {
+			spans = append(spans, span)
+		}
+		if event.CommitTs > commitTs {
+			progress.ReplaceOrInsert(span, event.CommitTs)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("INSERT INTO %s.%s (ticdc_cluster_id, changefeed, table_id, start_key, end_key, commit_ts) VALUES ",
+		filter.TiCDCSystemSchema, filter.DMLProgressTable))
+	args := make([]interface{}, 0, len(spans)*6)
+	for i, span := range spans {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString("(?, ?, ?, ?, ?, ?)")
+		args = append(args, config.GetGlobalServerConfig().ClusterID, w.ChangefeedID.String(),
+			span.TableID, span.StartKey, span.EndKey, progress.GetV(span))
+	}
+	builder.WriteString(" ON DUPLICATE KEY UPDATE commit_ts = VALUES(commit_ts), updated_at = CURRENT_TIMESTAMP")
+	dmls.sqls = append(dmls.sqls, builder.String())
+	dmls.values = append(dmls.values, args)
+}
+
+// updateAppliedCommitTs updates the applied commit ts in memory after the events are committed.
+func (w *MysqlWriter) updateAppliedCommitTs(events []*commonEvent.DMLEvent) {
+	for _, event := range events {
+		span := getTableSpan(event)
+		if event.CommitTs > w.appliedCommitTs.GetV(span) {
+			w.appliedCommitTs.ReplaceOrInsert(span, event.CommitTs)
+		}
+	}
+}
+
+// resetAppliedCommitTs drops the applied commit ts in memory of the spans of the events,
+// so it's read from the downstream again, it's called if the result of the commit is unknown.
+func (w *MysqlWriter) resetAppliedCommitTs(events []*commonEvent.DMLEvent) {
+	for _, event := range events {
+		w.appliedCommitTs.Delete(getTableSpan(event))
+	}
+}
+
+// RemoveDMLProgress removes the dml progress of the changefeed when it's removed.
+func (w *MysqlWriter) RemoveDMLProgress() error {
+	if !w.cfg.ExactlyOnce {
+		return nil
+	}
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE ticdc_cluster_id = ? AND changefeed = ?",
+		filter.TiCDCSystemSchema, filter.DMLProgressTable)
+	_, err := w.db.ExecContext(w.ctx, query, config.GetGlobalServerConfig().ClusterID, w.ChangefeedID.String())
+	if err != nil {
+		if apperror.IsTableNotExistsErr(err) {
+			return nil
+		}
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to delete dml progress; Query is %s", query)))
+	}
+	return nil
+}
+
+// execDMLAfterAmbiguousCommit applies the events again if the result of the commit is unknown,
+// the progress is committed with the rows, so the events committed already are filtered by it.
+// It returns the events applied by the retry.
+func (w *MysqlWriter) execDMLAfterAmbiguousCommit(events []*commonEvent.DMLEvent, cause error) ([]*commonEvent.DMLEvent, error) {
+	log.Warn("the result of the commit is unknown, apply the transactions not committed again",
+		zap.String("changefeed", w.ChangefeedID.String()), zap.Error(cause))
+	w.resetAppliedCommitTs(events)
+	pending, err := w.filterAppliedEvents(events)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, event := range pending {
+		event.Rewind()
+	}
+	dmls, err := w.prepareDMLs(pending)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer dmlsPool.Put(dmls)
+	if dmls.rowCount == 0 {
+		return pending, nil
+	}
+	return pending, w.execDMLWithMaxRetries(dmls)
+}
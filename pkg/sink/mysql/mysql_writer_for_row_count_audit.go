@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
+)
+
+func (w *MysqlWriter) CreateRowCountAuditTable() error {
+	database := filter.TiCDCSystemSchema
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		id bigint NOT NULL AUTO_INCREMENT,
+		ticdc_cluster_id varchar (255),
+		changefeed varchar(255),
+		schema_name varchar(255),
+		table_name varchar(255),
+		table_id bigint,
+		start_ts bigint unsigned,
+		end_ts bigint unsigned,
+		row_count bigint,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		INDEX (ticdc_cluster_id, changefeed, table_id, end_ts),
+		INDEX (created_at),
+		PRIMARY KEY (id)
+	);`
+	query = fmt.Sprintf(query, filter.RowCountAuditTable)
+	return w.CreateTable(database, filter.RowCountAuditTable, query)
+}
+
+// FlushRowCountAudit writes the row counts of the tables into the row count audit table.
+func (w *MysqlWriter) FlushRowCountAudit(counts []audit.RowCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	if !w.rowCountAuditTableInit {
+		// create row count audit table if not exist
+		if err := w.CreateRowCountAuditTable(); err != nil {
+			return err
+		}
+		w.rowCountAuditTableInit = true
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("INSERT INTO %s.%s (ticdc_cluster_id, changefeed, schema_name, table_name, table_id, start_ts, end_ts, row_count) VALUES ",
+		filter.TiCDCSystemSchema, filter.RowCountAuditTable))
+	args := make([]interface{}, 0, len(counts)*8)
+	for i, count := range counts {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString("(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			config.GetGlobalServerConfig().ClusterID,
+			w.ChangefeedID.String(),
+			count.Schema,
+			count.Table,
+			count.TableID,
+			count.StartTs,
+			count.EndTs,
+			count.Count)
+	}
+	query := builder.String()
+	_, err := w.db.ExecContext(w.ctx, query, args...)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to write row count audit table; Query is %s", query)))
+	}
+	return nil
+}
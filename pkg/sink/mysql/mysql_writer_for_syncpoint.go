@@ -45,6 +45,34 @@ func (w *MysqlWriter) CreateSyncTable() error {
 	return w.CreateTable(database, filter.SyncPointTable, query)
 }
 
+// RemoveSyncPointRows deletes the rows of the changefeed in the syncpoint table.
+func (w *MysqlWriter) RemoveSyncPointRows() error {
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.SyncPointTable)
+	builder.WriteString(" WHERE ticdc_cluster_id = '")
+	builder.WriteString(config.GetGlobalServerConfig().ClusterID)
+	builder.WriteString("' and changefeed = '")
+	builder.WriteString(w.ChangefeedID.String())
+	builder.WriteString("'")
+	query := builder.String()
+
+	_, err := w.db.ExecContext(w.ctx, query)
+	if err != nil {
+		if apperror.IsTableNotExistsErr(err) {
+			// the syncpoint table is created with the first syncpoint, nothing to remove.
+			return nil
+		}
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to delete syncpoint rows; Query is %s", query)))
+	}
+	log.Info("syncpoint rows removed",
+		zap.String("namespace", w.ChangefeedID.Namespace()),
+		zap.String("changefeed", w.ChangefeedID.Name()))
+	return nil
+}
+
 func (w *MysqlWriter) FlushSyncPointEvent(event *commonEvent.SyncPointEvent) error {
 	if !w.syncPointTableInit {
 		// create sync point table if not exist
@@ -20,9 +20,15 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/audit"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/stretchr/testify/require"
 )
@@ -81,9 +87,254 @@ func TestMysqlWriter_FlushDML(t *testing.T) {
 	require.NoError(t, err)
 }
 
-// Test flush ddl event
-// Ensure the ddl query will be write to the databases
-// and the ddl_ts_v1 table will be updated with the ddl_ts and table_id
+const queryDMLProgress = "SELECT start_key, end_key, commit_ts FROM tidb_cdc.dml_progress_v1 " +
+	"WHERE ticdc_cluster_id = ? AND changefeed = ? AND table_id = ? AND start_key < ? ORDER BY start_key"
+
+const insertDMLProgress = "INSERT INTO tidb_cdc.dml_progress_v1 (ticdc_cluster_id, changefeed, table_id, start_key, end_key, commit_ts) " +
+	"VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE commit_ts = VALUES(commit_ts), updated_at = CURRENT_TIMESTAMP"
+
+func TestMysqlWriter_FlushDMLExactlyOnce(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.ExactlyOnce = true
+	writer.dmlProgressTableInit = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dispatcherID := common.NewDispatcherID()
+	flushed := 0
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	dmlEvent.DispatcherID = dispatcherID
+	dmlEvent.AddPostFlushFunc(func() { flushed++ })
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values (3, 'test3');")
+	dmlEvent2.CommitTs = 3
+	dmlEvent2.ReplicatingTs = 4
+	dmlEvent2.DispatcherID = dispatcherID
+	dmlEvent2.AddPostFlushFunc(func() { flushed++ })
+	span := getTableSpan(dmlEvent)
+
+	// the transaction at 2 is applied before the failover, only the one at 3 is applied,
+	// and the progress is written in the same transaction
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", span.TableID, span.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(span.StartKey, span.EndKey, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?);"+insertDMLProgress).
+		WithArgs(3, "test3", "default", "test/test", span.TableID, span.StartKey, span.EndKey, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent, dmlEvent2}))
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, 2, flushed)
+
+	// the replayed transaction is filtered by the progress in memory
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent2}))
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, 3, flushed)
+}
+
+// Ensure the progress is found by the dispatcher created after a restart, whose id is different.
+func TestMysqlWriter_FlushDMLExactlyOnceAfterRestart(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	span := getTableSpan(dmlEvent)
+	dmlEvent.TableSpan = &span
+
+	// the progress is written by the dispatcher before the restart
+	writer, db, mock := newTestMysqlWriter(t)
+	writer.cfg.ExactlyOnce = true
+	writer.dmlProgressTableInit = true
+	dmlEvent.DispatcherID = common.NewDispatcherID()
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", span.TableID, span.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);"+insertDMLProgress).
+		WithArgs(1, "test", "default", "test/test", span.TableID, span.StartKey, span.EndKey, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+	db.Close()
+
+	// the dispatcher of the same span after the restart skips the replayed transaction
+	writer, db, mock = newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.ExactlyOnce = true
+	writer.dmlProgressTableInit = true
+	dmlEvent.DispatcherID = common.NewDispatcherID()
+	dmlEvent.Rewind()
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", span.TableID, span.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(span.StartKey, span.EndKey, 2))
+	flushed := false
+	dmlEvent.PostTxnFlushed = []func(){func() { flushed = true }}
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.True(t, flushed)
+}
+
+// Ensure the spans split from a span inherit its progress, and the span
+// not covered by the progress completely is not regarded as applied.
+func TestMysqlWriter_FlushDMLExactlyOnceAfterSplit(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.ExactlyOnce = true
+	writer.dmlProgressTableInit = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values (3, 'test3')")
+	dmlEvent2.CommitTs = 2
+	dmlEvent2.ReplicatingTs = 1
+
+	// the table span is split into two spans at the middle key
+	span := getTableSpan(dmlEvent)
+	middleKey := append(append([]byte{}, span.StartKey...), 0x80)
+	left := heartbeatpb.TableSpan{TableID: span.TableID, StartKey: span.StartKey, EndKey: middleKey}
+	right := heartbeatpb.TableSpan{TableID: span.TableID, StartKey: middleKey, EndKey: span.EndKey}
+	dmlEvent.TableSpan = &left
+	dmlEvent2.TableSpan = &right
+
+	// the left span is covered by the progress of the table span, the transaction
+	// is skipped, the right span is covered partially, the transaction is applied.
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", left.TableID, left.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(span.StartKey, span.EndKey, 2))
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", right.TableID, right.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(span.StartKey, append(append([]byte{}, middleKey...), 0x80), 2))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);"+insertDMLProgress).
+		WithArgs(3, "test3", "default", "test/test", right.TableID, right.StartKey, right.EndKey, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent, dmlEvent2}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the spans are merged again, the progress is the min commit ts of them
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", span.TableID, span.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(left.StartKey, left.EndKey, 5).
+			AddRow(right.StartKey, right.EndKey, 3))
+	applied, err := writer.getAppliedCommitTs(span)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Ensure the progress of a span is kept after the span is split, the child span sharing its
+// start key writes a new row instead of overwriting it, and the sibling span keeps its progress.
+func TestMysqlWriter_FlushDMLExactlyOnceSplitProgress(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.ExactlyOnce = true
+	writer.dmlProgressTableInit = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 5
+	dmlEvent.ReplicatingTs = 1
+	span := getTableSpan(dmlEvent)
+	middleKey := append(append([]byte{}, span.StartKey...), 0x80)
+	left := heartbeatpb.TableSpan{TableID: span.TableID, StartKey: span.StartKey, EndKey: middleKey}
+	right := heartbeatpb.TableSpan{TableID: span.TableID, StartKey: middleKey, EndKey: span.EndKey}
+	dmlEvent.TableSpan = &left
+
+	// the table span is applied to 2 before the split, the left span applies the transaction
+	// at 5 first, its progress is a new row since the end key is a part of the primary key.
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", left.TableID, left.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(span.StartKey, span.EndKey, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);"+insertDMLProgress).
+		WithArgs(1, "test", "default", "test/test", left.TableID, left.StartKey, left.EndKey, 5).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the right span is still covered by the progress of the table span
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", right.TableID, right.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(left.StartKey, left.EndKey, 5).
+			AddRow(span.StartKey, span.EndKey, 2))
+	applied, err := writer.getAppliedCommitTs(right)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the left span is applied to the newer progress after a restart
+	writer.appliedCommitTs.Delete(left)
+	mock.ExpectQuery(queryDMLProgress).
+		WithArgs("default", "test/test", left.TableID, left.EndKey).
+		WillReturnRows(sqlmock.NewRows([]string{"start_key", "end_key", "commit_ts"}).
+			AddRow(left.StartKey, left.EndKey, 5).
+			AddRow(span.StartKey, span.EndKey, 2))
+	applied, err = writer.getAppliedCommitTs(left)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAppliedCommitTsOf(t *testing.T) {
+	span := heartbeatpb.TableSpan{StartKey: []byte("a"), EndKey: []byte("d")}
+	cases := []struct {
+		progresses []spanProgress
+		expected   uint64
+	}{
+		{nil, 0},
+		{[]spanProgress{{[]byte("a"), []byte("d"), 3}}, 3},
+		// a gap in the span
+		{[]spanProgress{{[]byte("a"), []byte("b"), 3}, {[]byte("c"), []byte("d"), 3}}, 0},
+		// the split spans
+		{[]spanProgress{{[]byte("a"), []byte("b"), 5}, {[]byte("b"), []byte("d"), 3}}, 3},
+		// the old span and the new split spans
+		{[]spanProgress{{[]byte("a"), []byte("b"), 5}, {[]byte("a"), []byte("d"), 2}, {[]byte("b"), []byte("d"), 4}}, 4},
+		// the span is covered by a larger span
+		{[]spanProgress{{[]byte(""), []byte("e"), 7}}, 7},
+	}
+	for i, c := range cases {
+		require.Equal(t, c.expected, appliedCommitTsOf(span, c.progresses), "case %d", i)
+	}
+}
+
 func TestMysqlWriter_FlushDDLEvent(t *testing.T) {
 	writer, db, mock := newTestMysqlWriter(t)
 	defer db.Close()
@@ -169,6 +420,155 @@ func TestMysqlWriter_FlushDDLEvent(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test flush ddl event with the ddl history enabled
+// Ensure the execution of the ddl is recorded into the ddl_history table
+func TestMysqlWriter_FlushDDLEventWithDDLHistory(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.EnableDDLHistory = true
+	writer.ddlTsTableInit = true
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionAddColumn),
+		Query:      "alter table t add column age int;",
+		SchemaName: "test",
+		TableName:  "t",
+		FinishedTs: 2,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{1},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("alter table t add column age int;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("USE tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS ddl_history
+		(
+			id bigint NOT NULL AUTO_INCREMENT,
+			ticdc_cluster_id varchar (255),
+			changefeed varchar(255),
+			commit_ts bigint unsigned,
+			ddl_type varchar(64),
+			schema_name varchar(255),
+			table_name varchar(255),
+			query text,
+			execution_time_ms bigint,
+			result varchar(16),
+			error text,
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			INDEX (ticdc_cluster_id, changefeed, commit_ts),
+			INDEX (created_at),
+			PRIMARY KEY (id)
+		);`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("INSERT INTO tidb_cdc.ddl_history (ticdc_cluster_id, changefeed, commit_ts, ddl_type, schema_name, table_name, query, execution_time_ms, result, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("default", "test/test", 2, "add column", "test", "t", "alter table t add column age int;", sqlmock.AnyArg(), "success", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id) VALUES ('default', 'test/test', '2', 1) ON DUPLICATE KEY UPDATE ddl_ts=VALUES(ddl_ts), created_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.FlushDDLEvent(ddlEvent)
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+// Test flush ddl event in the ddl dry-run mode
+// Ensure the ddl is validated against the grants instead of being executed
+func TestMysqlWriter_FlushDDLEventDryRun(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.DDLDryRun = true
+	writer.ddlTsTableInit = true
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionAddColumn),
+		Query:      "alter table t add column age int;",
+		SchemaName: "test",
+		TableName:  "t",
+		FinishedTs: 2,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{1},
+		},
+	}
+
+	mock.ExpectQuery("SHOW GRANTS").WillReturnRows(sqlmock.NewRows([]string{"Grants"}).
+		AddRow("GRANT SELECT,INSERT ON *.* TO 'cdc'@'%'").
+		AddRow("GRANT CREATE,ALTER ON `test`.* TO 'cdc'@'%'"))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id) VALUES ('default', 'test/test', '2', 1) ON DUPLICATE KEY UPDATE ddl_ts=VALUES(ddl_ts), created_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.FlushDDLEvent(ddlEvent)
+	require.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+
+	// the ddls which can't be executed are reported instead of failing the changefeed
+	mock.ExpectQuery("SHOW GRANTS").WillReturnRows(sqlmock.NewRows([]string{"Grants"}).
+		AddRow("GRANT ALTER ON `test`.* TO 'cdc'@'%'"))
+	_, err = writer.validateDDL("test", "drop table t")
+	require.ErrorContains(t, err, "no DROP privilege")
+	_, err = writer.validateDDL("test", "drop tablee t")
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.True(t, hasPrivilege([]string{"GRANT ALL PRIVILEGES ON *.* TO 'root'@'%' WITH GRANT OPTION"}, "DROP", "test"))
+	require.False(t, hasPrivilege([]string{"GRANT DROP ON `test2`.* TO 'cdc'@'%'", "GRANT `r1` TO 'cdc'@'%'"}, "DROP", "test"))
+}
+
+func TestMysqlWriter_FlushDDLEventIntervention(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.ddlTsTableInit = true
+	writer.cfg.DDLInterventions = newDDLInterventions([]*config.DDLIntervention{
+		{CommitTs: 2, Action: config.DDLInterventionSkip},
+		{CommitTs: 3, Action: config.DDLInterventionReplace, Query: "alter table t add column age bigint;"},
+	})
+
+	newDDLEvent := func(commitTs uint64) *commonEvent.DDLEvent {
+		return &commonEvent.DDLEvent{
+			Type:       byte(timodel.ActionAddColumn),
+			Query:      "alter table t add column age int;",
+			SchemaName: "test",
+			TableName:  "t",
+			FinishedTs: commitTs,
+			BlockedTables: &commonEvent.InfluencedTables{
+				InfluenceType: commonEvent.InfluenceTypeNormal,
+				TableIDs:      []int64{1},
+			},
+		}
+	}
+
+	// the skipped ddl is not executed, but the ddl ts is flushed
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id) VALUES ('default', 'test/test', '2', 1) ON DUPLICATE KEY UPDATE ddl_ts=VALUES(ddl_ts), created_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.FlushDDLEvent(newDDLEvent(2)))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the query supplied by the user is executed instead of the ddl
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("alter table t add column age bigint;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id) VALUES ('default', 'test/test', '3', 1) ON DUPLICATE KEY UPDATE ddl_ts=VALUES(ddl_ts), created_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.FlushDDLEvent(newDDLEvent(3)))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMysqlWriter_Flush_EmptyEvents(t *testing.T) {
 	writer, db, mock := newTestMysqlWriter(t)
 	defer db.Close()
@@ -228,6 +628,55 @@ func TestMysqlWriter_FlushSyncPointEvent(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMysqlWriter_FlushRowCountAudit(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	counts := []audit.RowCount{
+		{Schema: "test", Table: "t1", TableID: 1, StartTs: 1, EndTs: 10, Count: 3},
+		{Schema: "test", Table: "t2", TableID: 2, StartTs: 1, EndTs: 10, Count: 5},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("USE tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS row_count_audit
+		(
+			id bigint NOT NULL AUTO_INCREMENT,
+			ticdc_cluster_id varchar (255),
+			changefeed varchar(255),
+			schema_name varchar(255),
+			table_name varchar(255),
+			table_id bigint,
+			start_ts bigint unsigned,
+			end_ts bigint unsigned,
+			row_count bigint,
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			INDEX (ticdc_cluster_id, changefeed, table_id, end_ts),
+			INDEX (created_at),
+			PRIMARY KEY (id)
+		);`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("INSERT INTO tidb_cdc.row_count_audit (ticdc_cluster_id, changefeed, schema_name, table_name, table_id, start_ts, end_ts, row_count) VALUES (?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("default", "test/test", "test", "t1", 1, 1, 10, 3, "default", "test/test", "test", "t2", 2, 1, 10, 5).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+
+	err := writer.FlushRowCountAudit(counts)
+	require.NoError(t, err)
+
+	// the table is only created once
+	mock.ExpectExec("INSERT INTO tidb_cdc.row_count_audit (ticdc_cluster_id, changefeed, schema_name, table_name, table_id, start_ts, end_ts, row_count) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("default", "test/test", "test", "t1", 1, 10, 20, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	err = writer.FlushRowCountAudit([]audit.RowCount{
+		{Schema: "test", Table: "t1", TableID: 1, StartTs: 10, EndTs: 20, Count: 1},
+	})
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
 func TestMysqlWriter_RemoveDDLTsTable(t *testing.T) {
 	writer, db, mock := newTestMysqlWriter(t)
 	defer db.Close()
@@ -239,3 +688,214 @@ func TestMysqlWriter_RemoveDDLTsTable(t *testing.T) {
 	err := writer.RemoveDDLTsItem()
 	require.NoError(t, err)
 }
+
+func TestMysqlWriter_RemoveSyncPointRows(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM tidb_cdc.syncpoint_v1 WHERE ticdc_cluster_id = 'default' and changefeed = 'test/test'").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	err := writer.RemoveSyncPointRows()
+	require.NoError(t, err)
+
+	// the syncpoint table doesn't exist if no syncpoint has been written
+	mock.ExpectExec("DELETE FROM tidb_cdc.syncpoint_v1 WHERE ticdc_cluster_id = 'default' and changefeed = 'test/test'").
+		WillReturnError(&dmysql.MySQLError{Number: mysql.ErrNoSuchTable, Message: "Table 'tidb_cdc.syncpoint_v1' doesn't exist"})
+	err = writer.RemoveSyncPointRows()
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestMysqlWriter_FlushDMLWithDeadLetterQueue(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.DMLMaxRetry = 1
+	writer.cfg.EnableDeadLetterQueue = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	flushed := false
+	dmlEvent.AddPostFlushFunc(func() { flushed = true })
+
+	dataTooLong := &dmysql.MySQLError{Number: mysql.ErrDataTooLong, Message: "Data too long for column 'name'"}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnError(dataTooLong)
+	mock.ExpectRollback()
+
+	// apply the rows one by one, the second row is diverted to the dead letter queue.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(2, "test2").
+		WillReturnError(dataTooLong)
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("USE tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS dead_letter_queue
+		(
+			id bigint NOT NULL AUTO_INCREMENT,
+			ticdc_cluster_id varchar (255),
+			changefeed varchar(255),
+			schema_name varchar(255),
+			table_name varchar(255),
+			commit_ts bigint unsigned,
+			row_type varchar(16),
+			error_code int,
+			error_message text,
+			columns longtext,
+			pre_columns longtext,
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			INDEX (ticdc_cluster_id, changefeed, commit_ts),
+			INDEX (created_at),
+			PRIMARY KEY (id)
+		);`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("INSERT INTO tidb_cdc.dead_letter_queue (ticdc_cluster_id, changefeed, schema_name, table_name, commit_ts, row_type, error_code, error_message, columns, pre_columns) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("default", "test/test", "test", "t", 2, "insert", mysql.ErrDataTooLong, dataTooLong.Error(), `{"id":2,"name":"test2"}`, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.NoError(t, err)
+	require.True(t, flushed)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+// Ensure the duplicated rows are applied in safe mode instead of being diverted
+// to the dead letter queue.
+func TestMysqlWriter_FlushDMLDupEntryWithDeadLetterQueue(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.DMLMaxRetry = 1
+	writer.cfg.EnableDeadLetterQueue = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	flushed := false
+	dmlEvent.AddPostFlushFunc(func() { flushed = true })
+
+	dupEntry := &dmysql.MySQLError{Number: mysql.ErrDupEntry, Message: "Duplicate entry '2' for key 'PRIMARY'"}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnError(dupEntry)
+	mock.ExpectRollback()
+
+	// the transaction is applied again in safe mode, nothing is diverted
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?);REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.NoError(t, err)
+	require.True(t, flushed)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+// Ensure the transactions are applied again in safe mode if the connection is lost
+// while committing them, since they may be committed already.
+func TestMysqlWriter_FlushDMLAmbiguousCommit(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(dmysql.ErrInvalidConn)
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?);REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Ensure the stored generated columns are written if they are not generated by the downstream.
+func TestMysqlWriter_FlushDMLWithGeneratedColumns(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.GeneratedColumnMode = GeneratedColumnModeAuto
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, a int, b int as (a + 1) stored, c int as (a + 2) virtual);")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t(id, a) values (1, 1)")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+
+	mock.ExpectQuery("SELECT COLUMN_NAME, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?").
+		WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "EXTRA"}).
+			AddRow("id", "").AddRow("a", "").AddRow("b", "").AddRow("c", "VIRTUAL GENERATED"))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`a`,`b`) VALUES (?,?,?)").
+		WithArgs(1, 1, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+
+	// the detection is cached, and the generated columns are skipped in skip mode
+	writer.cfg.GeneratedColumnMode = GeneratedColumnModeSkip
+	dmlEvent = helper.DML2Event("test", "t", "insert into t(id, a) values (2, 2)")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`a`) VALUES (?,?)").
+		WithArgs(2, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/pkg/quotes"
 )
@@ -32,6 +33,9 @@ type preparedDMLs struct {
 	rowCount        int
 	approximateSize int64
 	startTs         []uint64
+	// translateToInsert is true if some rows are written by INSERT or UPDATE,
+	// they can't be applied twice, unlike REPLACE and DELETE.
+	translateToInsert bool
 }
 
 func (d *preparedDMLs) String() string {
@@ -63,6 +67,43 @@ func (d *preparedDMLs) reset() {
 	d.startTs = d.startTs[:0]
 	d.rowCount = 0
 	d.approximateSize = 0
+	d.translateToInsert = false
+}
+
+// generatedColumns is the set of the stored generated columns written to the downstream,
+// the generated columns are skipped if it's empty.
+type generatedColumns map[int64]struct{}
+
+// skip returns true if the column is not written to the downstream.
+func (g generatedColumns) skip(tableInfo *common.TableInfo, col *model.ColumnInfo) bool {
+	if col == nil {
+		return true
+	}
+	if !tableInfo.GetColumnFlags()[col.ID].IsGeneratedColumn() {
+		return false
+	}
+	_, ok := g[col.ID]
+	return !ok
+}
+
+// columnList returns the number and the quoted names of the columns written to the downstream.
+func (g generatedColumns) columnList(tableInfo *common.TableInfo, isUpdate bool) (int, string) {
+	var b strings.Builder
+	count := 0
+	for _, col := range tableInfo.GetColumns() {
+		if g.skip(tableInfo, col) {
+			continue
+		}
+		if count > 0 {
+			b.WriteString(",")
+		}
+		count++
+		b.WriteString(quotes.QuoteName(col.Name.O))
+		if isUpdate {
+			b.WriteString(" = ?")
+		}
+	}
+	return count, b.String()
 }
 
 // prepareReplace builds a parametrics REPLACE statement as following
@@ -71,8 +112,9 @@ func buildInsert(
 	tableInfo *common.TableInfo,
 	row commonEvent.RowChange,
 	translateToInsert bool,
+	generated generatedColumns,
 ) (string, []interface{}, error) {
-	args, err := getArgs(&row.Row, tableInfo)
+	args, err := getArgs(&row.Row, tableInfo, generated)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
@@ -81,7 +123,15 @@ func buildInsert(
 	}
 
 	var sql string
-	if translateToInsert {
+	if len(generated) > 0 {
+		verb := "REPLACE"
+		if translateToInsert {
+			verb = "INSERT"
+		}
+		count, columnList := generated.columnList(tableInfo, false)
+		sql = fmt.Sprintf("%s INTO %s (%s) VALUES (%s)",
+			verb, tableInfo.TableName.QuoteString(), columnList, strings.TrimSuffix(strings.Repeat("?,", count), ","))
+	} else if translateToInsert {
 		sql = tableInfo.GetPreInsertSQL()
 	} else {
 		sql = tableInfo.GetPreReplaceSQL()
@@ -129,14 +179,24 @@ func buildDelete(tableInfo *common.TableInfo, row commonEvent.RowChange) (string
 	return sql, args, nil
 }
 
-func buildUpdate(tableInfo *common.TableInfo, row commonEvent.RowChange) (string, []interface{}, error) {
+func buildUpdate(
+	tableInfo *common.TableInfo, row commonEvent.RowChange, generated generatedColumns,
+) (string, []interface{}, error) {
 	var builder strings.Builder
-	if tableInfo.GetPreUpdateSQL() == "" {
-		log.Panic("PreUpdateSQL should not be empty")
+	if len(generated) > 0 {
+		_, columnList := generated.columnList(tableInfo, true)
+		builder.WriteString("UPDATE ")
+		builder.WriteString(tableInfo.TableName.QuoteString())
+		builder.WriteString(" SET ")
+		builder.WriteString(columnList)
+	} else {
+		if tableInfo.GetPreUpdateSQL() == "" {
+			log.Panic("PreUpdateSQL should not be empty")
+		}
+		builder.WriteString(tableInfo.GetPreUpdateSQL())
 	}
-	builder.WriteString(tableInfo.GetPreUpdateSQL())
 
-	args, err := getArgs(&row.Row, tableInfo)
+	args, err := getArgs(&row.Row, tableInfo, generated)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
@@ -172,10 +232,10 @@ func buildUpdate(tableInfo *common.TableInfo, row commonEvent.RowChange) (string
 	return sql, args, nil
 }
 
-func getArgs(row *chunk.Row, tableInfo *common.TableInfo) ([]interface{}, error) {
+func getArgs(row *chunk.Row, tableInfo *common.TableInfo, generated generatedColumns) ([]interface{}, error) {
 	args := make([]interface{}, 0, len(tableInfo.GetColumns()))
 	for i, col := range tableInfo.GetColumns() {
-		if col == nil || tableInfo.GetColumnFlags()[col.ID].IsGeneratedColumn() {
+		if generated.skip(tableInfo, col) {
 			continue
 		}
 		v, err := common.FormatColVal(row, col, i)
@@ -207,6 +267,10 @@ func whereSlice(row *chunk.Row, tableInfo *common.TableInfo) ([]string, []interf
 	// if no explicit row id but force replicate, use all key-values in where condition
 	if len(colNames) == 0 {
 		for i, col := range tableInfo.GetColumns() {
+			// the values of the virtual generated columns are not in the row
+			if col == nil || col.IsVirtualGenerated() {
+				continue
+			}
 			colNames = append(colNames, col.Name.O)
 			v, err := common.FormatColVal(row, col, i)
 			if err != nil {
@@ -25,7 +25,7 @@ func BenchmarkBuildInsert(b *testing.B) {
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
-			_, _, _ = buildInsert(tableInfo, insert, false)
+			_, _, _ = buildInsert(tableInfo, insert, false, nil)
 		}
 	})
 }
@@ -50,7 +50,7 @@ func BenchmarkBuildUpdate(b *testing.B) {
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
-			_, _, _ = buildUpdate(tableInfo, update)
+			_, _, _ = buildUpdate(tableInfo, update, nil)
 		}
 	})
 }
@@ -169,7 +169,7 @@ func TestBuildInsert(t *testing.T) {
 
 	// case 1: Convert to INSERT INTO
 	exportedSQL := "INSERT INTO `test`.`t` (`id`,`c_tinyint`,`c_smallint`,`c_mediumint`,`c_int`,`c_bigint`,`c_unsigned_tinyint`,`c_unsigned_smallint`,`c_unsigned_mediumint`,`c_unsigned_int`,`c_unsigned_bigint`,`c_float`,`c_double`,`c_decimal`,`c_decimal_2`,`c_unsigned_float`,`c_unsigned_double`,`c_unsigned_decimal`,`c_unsigned_decimal_2`,`c_date`,`c_datetime`,`c_timestamp`,`c_time`,`c_year`,`c_tinytext`,`c_text`,`c_mediumtext`,`c_longtext`,`c_tinyblob`,`c_blob`,`c_mediumblob`,`c_longblob`,`c_char`,`c_varchar`,`c_binary`,`c_varbinary`,`c_enum`,`c_set`,`c_bit`,`c_json`,`name`,`country`,`city`,`description`,`image`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
-	sql, args, err := buildInsert(tableInfo, insert, true)
+	sql, args, err := buildInsert(tableInfo, insert, true, nil)
 	require.NoError(t, err)
 	require.Equal(t, exportedSQL, sql)
 	require.Len(t, args, 45)
@@ -177,7 +177,7 @@ func TestBuildInsert(t *testing.T) {
 
 	// case 2: Convert to REPLACE INTO
 	exportedSQL = "REPLACE INTO `test`.`t` (`id`,`c_tinyint`,`c_smallint`,`c_mediumint`,`c_int`,`c_bigint`,`c_unsigned_tinyint`,`c_unsigned_smallint`,`c_unsigned_mediumint`,`c_unsigned_int`,`c_unsigned_bigint`,`c_float`,`c_double`,`c_decimal`,`c_decimal_2`,`c_unsigned_float`,`c_unsigned_double`,`c_unsigned_decimal`,`c_unsigned_decimal_2`,`c_date`,`c_datetime`,`c_timestamp`,`c_time`,`c_year`,`c_tinytext`,`c_text`,`c_mediumtext`,`c_longtext`,`c_tinyblob`,`c_blob`,`c_mediumblob`,`c_longblob`,`c_char`,`c_varchar`,`c_binary`,`c_varbinary`,`c_enum`,`c_set`,`c_bit`,`c_json`,`name`,`country`,`city`,`description`,`image`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
-	sql, args, err = buildInsert(tableInfo, insert, false)
+	sql, args, err = buildInsert(tableInfo, insert, false, nil)
 	require.NoError(t, err)
 	require.Equal(t, exportedSQL, sql)
 	require.Len(t, args, 45)
@@ -336,7 +336,7 @@ func TestBuildUpdate(t *testing.T) {
 
 	expectedSQL := "UPDATE `test`.`t` SET `id` = ?,`name` = ? WHERE `id` = ? LIMIT 1"
 	expectedArgs := []interface{}{int64(1), "test2", int64(1)}
-	sql, args, err := buildUpdate(event.TableInfo, row)
+	sql, args, err := buildUpdate(event.TableInfo, row, nil)
 	require.NoError(t, err)
 	require.Equal(t, expectedSQL, sql)
 	require.Len(t, args, 3)
@@ -365,7 +365,7 @@ func TestBuildUpdate(t *testing.T) {
 
 	expectedSQL = "UPDATE `test`.`t2` SET `id` = ?,`name` = ?,`age` = ? WHERE `name` = ? AND `age` = ? LIMIT 1"
 	expectedArgs = []interface{}{int64(1), "test2", int64(20), "test", int64(20)}
-	sql, args, err = buildUpdate(event.TableInfo, row)
+	sql, args, err = buildUpdate(event.TableInfo, row, nil)
 	require.NoError(t, err)
 	require.Equal(t, expectedSQL, sql)
 	require.Len(t, args, 5)
@@ -0,0 +1,59 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
+)
+
+// TableOverride is the dml workers dedicated to the tables matched by the filter.
+type TableOverride struct {
+	filter filter.TableMatcher
+	// WorkerCount is the number of the dml workers of the matched tables.
+	WorkerCount int
+	// MaxTxnRow is the max number of rows in a transaction of the matched tables.
+	MaxTxnRow int
+}
+
+// Match returns true if the table is matched by the override.
+func (o *TableOverride) Match(schema, table string) bool {
+	return o.filter.MatchTable(schema, table)
+}
+
+func newTableOverrides(
+	overrides []*config.MySQLTableOverride, caseSensitive bool, defaultMaxTxnRow int,
+) ([]*TableOverride, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	result := make([]*TableOverride, 0, len(overrides))
+	for _, override := range overrides {
+		// the tables are matched the same way as the filter of the changefeed
+		f, err := filter.NewTableMatcher(&config.FilterConfig{Rules: override.Matcher}, caseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		maxTxnRow := override.MaxTxnRow
+		if maxTxnRow == 0 {
+			maxTxnRow = defaultMaxTxnRow
+		}
+		result = append(result, &TableOverride{
+			filter:      f,
+			WorkerCount: override.WorkerCount,
+			MaxTxnRow:   maxTxnRow,
+		})
+	}
+	return result, nil
+}
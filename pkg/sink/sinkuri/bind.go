@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkuri
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// BindQuery sets the fields of dest by the options in the query of the sink uri, each field
+// is bound to the option named by its `form` tag. The field must be a string, or a pointer
+// to a string, bool or integer, the pointer is left nil if the option is not set.
+func BindQuery(sinkURI *url.URL, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return cerror.ErrSinkURIInvalid.GenWithStack("can't bind the sink uri to %T", dest)
+	}
+	query := sinkURI.Query()
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("form")
+		if name == "" {
+			continue
+		}
+		values, ok := query[name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if err := setField(v.Field(i), name, values[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, name, value string) error {
+	target := field
+	if field.Kind() == reflect.Pointer {
+		target = reflect.New(field.Type().Elem()).Elem()
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"invalid value %q of option %q in the sink uri, it should be true or false", value, name)
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, target.Type().Bits())
+		if err != nil {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"invalid value %q of option %q in the sink uri, it should be an integer in %d bits",
+				value, name, target.Type().Bits())
+		}
+		target.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, target.Type().Bits())
+		if err != nil {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"invalid value %q of option %q in the sink uri, it should be an unsigned integer in %d bits",
+				value, name, target.Type().Bits())
+		}
+		target.SetUint(n)
+	default:
+		return cerror.ErrSinkURIInvalid.GenWithStack(
+			"option %q can't be bound to the field of type %s", name, field.Type())
+	}
+	if field.Kind() == reflect.Pointer {
+		field.Set(target.Addr())
+	}
+	return nil
+}
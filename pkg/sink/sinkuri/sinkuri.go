@@ -0,0 +1,226 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sinkuri parses and validates the sink uri shared by all sinks. It checks the
+// scheme, the options in the query and the combination of them, the errors tell what's
+// wrong and how to fix it, such as the correct spelling of a misspelled option.
+package sinkuri
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+)
+
+// option is an option which can be set in the query of the sink uri.
+type option struct {
+	name string
+	// protocols are the protocols which support the option, empty means all protocols.
+	protocols []config.Protocol
+	// requires is the option which must be set together with this option.
+	requires string
+}
+
+var mysqlOptions = []option{
+	{name: "worker-count"},
+	{name: "max-txn-row"},
+	{name: "max-multi-update-row"},
+	{name: "max-multi-update-row-size"},
+	{name: "tidb-txn-mode"},
+	{name: "ssl-ca"},
+	{name: "ssl-cert", requires: "ssl-key"},
+	{name: "ssl-key", requires: "ssl-cert"},
+	{name: "safe-mode"},
+	{name: "time-zone"},
+	{name: "batch-dml-enable"},
+	{name: "generated-column"},
+	{name: "batch-replace-enable"},
+	{name: "batch-replace-size", requires: "batch-replace-enable"},
+	{name: "multi-stmt-enable"},
+	{name: "enable-ddl-history"},
+	{name: "dry-run"},
+	{name: "read-timeout"},
+	{name: "write-timeout"},
+	{name: "timeout"},
+	{name: "cache-prep-stmts"},
+	{name: "transaction-atomicity"},
+}
+
+var kafkaOptions = []option{
+	{name: config.ProtocolKey},
+	{name: "transaction-atomicity"},
+	{name: "partition-num"},
+	{name: "replication-factor"},
+	{name: "kafka-version"},
+	{name: "max-message-bytes"},
+	{name: "max-batch-size"},
+	{name: "compression"},
+	{name: "kafka-client-id"},
+	{name: "kafka-client"},
+	{name: "auto-create-topic"},
+	{name: "dial-timeout"},
+	{name: "write-timeout"},
+	{name: "read-timeout"},
+	{name: "batch-linger"},
+	{name: "batch-bytes"},
+	{name: "required-acks"},
+	{name: "sasl-user"},
+	{name: "sasl-password", requires: "sasl-user"},
+	{name: "sasl-mechanism"},
+	{name: "sasl-gssapi-auth-type"},
+	{name: "sasl-gssapi-keytab-path"},
+	{name: "sasl-gssapi-kerberos-config-path"},
+	{name: "sasl-gssapi-service-name"},
+	{name: "sasl-gssapi-user"},
+	{name: "sasl-gssapi-password"},
+	{name: "sasl-gssapi-realm"},
+	{name: "sasl-gssapi-disable-pafxfast"},
+	{name: "enable-tls"},
+	{name: "ca"},
+	{name: "cert", requires: "key"},
+	{name: "key", requires: "cert"},
+	{name: "insecure-skip-verify"},
+	{name: "enable-tidb-extension", protocols: []config.Protocol{config.ProtocolCanalJSON, config.ProtocolAvro}},
+	{name: "only-output-updated-columns", protocols: []config.Protocol{config.ProtocolCanalJSON, config.ProtocolOpen}},
+	{name: "content-compatible", protocols: []config.Protocol{config.ProtocolCanalJSON}},
+	{name: "split-handle-key-update", protocols: []config.Protocol{config.ProtocolCanalJSON}},
+	{name: "schema-registry", protocols: []config.Protocol{config.ProtocolAvro}},
+	{name: "avro-enable-watermark", protocols: []config.Protocol{config.ProtocolAvro}},
+	{name: "avro-decimal-handling-mode", protocols: []config.Protocol{config.ProtocolAvro}},
+	{name: "avro-decimal-max-precision", protocols: []config.Protocol{config.ProtocolAvro}},
+	{name: "avro-bigint-unsigned-handling-mode", protocols: []config.Protocol{config.ProtocolAvro}},
+	{name: "debezium-disable-schema", protocols: []config.Protocol{config.ProtocolDebezium}},
+	{name: "debezium-emit-tombstone", protocols: []config.Protocol{config.ProtocolDebezium}},
+	{name: "encoding-format", protocols: []config.Protocol{config.ProtocolSimple}},
+}
+
+// schemeOptions are the options of each supported scheme, nil means the options are not checked.
+var schemeOptions = map[string][]option{
+	sink.MySQLScheme:     mysqlOptions,
+	sink.MySQLSSLScheme:  mysqlOptions,
+	sink.TiDBScheme:      mysqlOptions,
+	sink.TiDBSSLScheme:   mysqlOptions,
+	sink.KafkaScheme:     kafkaOptions,
+	sink.KafkaSSLScheme:  kafkaOptions,
+	sink.BlackHoleScheme: nil,
+}
+
+var protocolNames = []string{
+	"open-protocol", "canal", "canal-json", "avro", "flat-avro", "maxwell", "craft", "csv", "debezium", "simple",
+}
+
+// Parse parses the sink uri and validates it.
+func Parse(sinkURI string) (*url.URL, error) {
+	if sinkURI == "" {
+		return nil, cerror.ErrSinkURIInvalid.GenWithStack("the sink uri is empty")
+	}
+	u, err := url.Parse(sinkURI)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if err = Validate(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Validate checks the scheme and the options of the sink uri.
+func Validate(sinkURI *url.URL) error {
+	scheme := sink.GetScheme(sinkURI)
+	options, ok := schemeOptions[scheme]
+	if !ok {
+		schemes := make([]string, 0, len(schemeOptions))
+		for s := range schemeOptions {
+			schemes = append(schemes, s)
+		}
+		sort.Strings(schemes)
+		return cerror.ErrSinkURIInvalid.GenWithStack(
+			"unsupported scheme %q in the sink uri%s, the supported schemes are %s",
+			scheme, suggestion(scheme, schemes), strings.Join(schemes, ", "))
+	}
+	if options == nil {
+		return nil
+	}
+
+	query := sinkURI.Query()
+	protocol := config.ProtocolUnknown
+	if s := query.Get(config.ProtocolKey); s != "" {
+		p, err := config.ParseSinkProtocolFromString(s)
+		if err != nil {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"unknown protocol %q in the sink uri%s", s, suggestion(s, protocolNames))
+		}
+		protocol = p
+	}
+
+	known := make(map[string]option, len(options))
+	names := make([]string, 0, len(options))
+	for _, o := range options {
+		known[o.name] = o
+		names = append(names, o.name)
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	// check the options in order, so the error is stable.
+	sort.Strings(keys)
+	for _, key := range keys {
+		o, ok := known[key]
+		if !ok {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"unknown option %q in the sink uri of scheme %s%s", key, scheme, suggestion(key, names))
+		}
+		if len(query[key]) > 1 {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"option %q is set %d times in the sink uri, it can only be set once", key, len(query[key]))
+		}
+		if len(o.protocols) != 0 && protocol != config.ProtocolUnknown && !supportProtocol(o, protocol) {
+			supported := make([]string, 0, len(o.protocols))
+			for _, p := range o.protocols {
+				supported = append(supported, p.String())
+			}
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"option %q can't be used with protocol %s, it's only supported by %s",
+				key, protocol, strings.Join(supported, ", "))
+		}
+		if o.requires != "" && query.Get(o.requires) == "" {
+			return cerror.ErrSinkURIInvalid.GenWithStack(
+				"option %q must be set together with option %q in the sink uri", key, o.requires)
+		}
+	}
+	return nil
+}
+
+func supportProtocol(o option, protocol config.Protocol) bool {
+	for _, p := range o.protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestion returns a hint of the most similar candidate if it's likely a misspelling.
+func suggestion(input string, candidates []string) string {
+	best := config.ClosestName(input, candidates)
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkuri
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		uri string
+		err string
+	}{
+		{uri: "mysql://root@127.0.0.1:3306/?worker-count=16&safe-mode=true"},
+		{uri: "tidb://root@127.0.0.1:4000/?generated-column=skip"},
+		{uri: "kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-tidb-extension=true&split-handle-key-update=true"},
+		{uri: "kafka://127.0.0.1:9092/topic?partition-num=3"},
+		{uri: "blackhole://?aa=bb"},
+		{uri: "", err: "the sink uri is empty"},
+		{uri: "kafak://127.0.0.1:9092/topic", err: `unsupported scheme "kafak" in the sink uri, did you mean "kafka"?`},
+		{uri: "unknown://127.0.0.1:9092/topic", err: `unsupported scheme "unknown" in the sink uri, the supported schemes are`},
+		{uri: "mysql://root@127.0.0.1:3306/?worker-cout=16", err: `unknown option "worker-cout" in the sink uri of scheme mysql, did you mean "worker-count"?`},
+		{uri: "mysql://root@127.0.0.1:3306/?protocol=canal-json", err: `unknown option "protocol" in the sink uri of scheme mysql`},
+		{uri: "kafka://127.0.0.1:9092/topic?protocol=canal-jsn", err: `unknown protocol "canal-jsn" in the sink uri, did you mean "canal-json"?`},
+		{uri: "kafka://127.0.0.1:9092/topic?protocol=avro&content-compatible=true", err: `option "content-compatible" can't be used with protocol avro, it's only supported by canal-json`},
+		{uri: "kafka://127.0.0.1:9092/topic?partition-num=3&partition-num=4", err: `option "partition-num" is set 2 times`},
+		{uri: "kafka://127.0.0.1:9092/topic?cert=a.pem", err: `option "cert" must be set together with option "key"`},
+	}
+	for _, c := range cases {
+		u, err := Parse(c.uri)
+		if c.err == "" {
+			require.NoError(t, err, c.uri)
+			require.NotNil(t, u)
+			continue
+		}
+		require.ErrorContains(t, err, c.err, c.uri)
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	type config struct {
+		Protocol     string  `form:"protocol"`
+		PartitionNum *int32  `form:"partition-num"`
+		Replication  *int16  `form:"replication-factor"`
+		EnableTLS    *bool   `form:"enable-tls"`
+		Compression  *string `form:"compression"`
+		NotBound     *int
+	}
+	u, err := url.Parse("kafka://127.0.0.1:9092/topic?protocol=open-protocol&partition-num=3&enable-tls=true")
+	require.NoError(t, err)
+	cfg := &config{}
+	require.NoError(t, BindQuery(u, cfg))
+	require.Equal(t, "open-protocol", cfg.Protocol)
+	require.Equal(t, int32(3), *cfg.PartitionNum)
+	require.True(t, *cfg.EnableTLS)
+	require.Nil(t, cfg.Replication)
+	require.Nil(t, cfg.Compression)
+	require.Nil(t, cfg.NotBound)
+
+	u, err = url.Parse("kafka://127.0.0.1:9092/topic?enable-tls=yes")
+	require.NoError(t, err)
+	require.ErrorContains(t, BindQuery(u, &config{}),
+		`invalid value "yes" of option "enable-tls" in the sink uri, it should be true or false`)
+
+	u, err = url.Parse("kafka://127.0.0.1:9092/topic?replication-factor=40000")
+	require.NoError(t, err)
+	require.ErrorContains(t, BindQuery(u, &config{}),
+		`invalid value "40000" of option "replication-factor" in the sink uri, it should be an integer in 16 bits`)
+}
@@ -36,6 +36,7 @@ func GetEncoderConfig(
 	sinkURI *url.URL,
 	protocol config.Protocol,
 	sinkConfig *ticonfig.SinkConfig,
+	timezone string,
 	maxMsgBytes int,
 ) (*common.Config, error) {
 	encoderConfig := common.NewConfig(protocol)
@@ -49,7 +50,7 @@ func GetEncoderConfig(
 		WithMaxMessageBytes(maxMsgBytes).
 		WithChangefeedID(changefeedID)
 
-	tz, err := util.GetTimezone(config.GetGlobalServerConfig().TZ)
+	tz, err := util.GetTimezone(timezone)
 	if err != nil {
 		return nil, errors.WrapError(errors.ErrSinkInvalidConfig, err)
 	}
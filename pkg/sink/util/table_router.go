@@ -0,0 +1,398 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"go.uber.org/zap"
+)
+
+// nameSeparator separates the schema and the table name when they are matched together,
+// it can't appear in the names.
+const nameSeparator = "\x00"
+
+type routingRule struct {
+	// pattern matches "<schema>\x00<table>".
+	pattern *regexp.Regexp
+	// schemaPattern matches the schema, it's nil if the rule is not applied to the schemas.
+	schemaPattern *regexp.Regexp
+	targetSchema  string
+	targetTable   string
+	// merges is true if the rule may route several upstream tables to the same downstream table.
+	merges bool
+}
+
+type routedTableInfo struct {
+	source *commonType.TableInfo
+	routed *commonType.TableInfo
+}
+
+// TableRouter maps the upstream schema and table names to the downstream ones by the routing rules,
+// the routed names are used by the DML events, the DDL statements and the MQ messages consistently.
+type TableRouter struct {
+	rules []*routingRule
+
+	mu     sync.Mutex
+	names  map[commonEvent.SchemaTableName]commonEvent.SchemaTableName
+	tables map[int64]routedTableInfo
+	// created are the merged downstream tables whose CREATE TABLE is forwarded.
+	created map[commonEvent.SchemaTableName]struct{}
+}
+
+// NewTableRouter creates a TableRouter, it returns nil if there is no routing rule.
+func NewTableRouter(rules []*config.RoutingRule) (*TableRouter, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	r := &TableRouter{
+		rules:   make([]*routingRule, 0, len(rules)),
+		names:   make(map[commonEvent.SchemaTableName]commonEvent.SchemaTableName),
+		tables:  make(map[int64]routedTableInfo),
+		created: make(map[commonEvent.SchemaTableName]struct{}),
+	}
+	for _, rule := range rules {
+		sourceTable := rule.SourceTable
+		if sourceTable == "" {
+			sourceTable = ".*"
+		}
+		pattern, err := regexp.Compile("^(?:" + rule.SourceSchema + ")" + nameSeparator + "(?:" + sourceTable + ")$")
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrSinkInvalidConfig, err)
+		}
+		compiled := &routingRule{
+			pattern:      pattern,
+			targetSchema: rule.TargetSchema,
+			targetTable:  rule.TargetTable,
+			merges: mergesNames(rule.SourceSchema, rule.TargetSchema) ||
+				mergesNames(sourceTable, rule.TargetTable),
+		}
+		if rule.SourceTable == "" {
+			compiled.schemaPattern = regexp.MustCompile("^(?:" + rule.SourceSchema + ")$")
+		}
+		r.rules = append(r.rules, compiled)
+	}
+	return r, nil
+}
+
+// Route returns the downstream schema and table name of the upstream table.
+func (r *TableRouter) Route(schema, table string) (string, string) {
+	source := commonEvent.SchemaTableName{SchemaName: schema, TableName: table}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if target, ok := r.names[source]; ok {
+		return target.SchemaName, target.TableName
+	}
+	target := source
+	src := schema + nameSeparator + table
+	if rule, match := r.matchRule(schema, table); rule != nil {
+		if rule.targetSchema != "" {
+			target.SchemaName = string(rule.pattern.ExpandString(nil, rule.targetSchema, src, match))
+		}
+		if rule.targetTable != "" {
+			target.TableName = string(rule.pattern.ExpandString(nil, rule.targetTable, src, match))
+		}
+	}
+	r.names[source] = target
+	return target.SchemaName, target.TableName
+}
+
+// matchRule returns the first rule matching the table and the submatch indexes, it returns nil if none matches.
+func (r *TableRouter) matchRule(schema, table string) (*routingRule, []int) {
+	src := schema + nameSeparator + table
+	for _, rule := range r.rules {
+		if match := rule.pattern.FindStringSubmatchIndex(src); match != nil {
+			return rule, match
+		}
+	}
+	return nil, nil
+}
+
+// isMerged returns true if the table may be routed to the same downstream table as other tables.
+func (r *TableRouter) isMerged(schema, table string) bool {
+	rule, _ := r.matchRule(schema, table)
+	return rule != nil && rule.merges
+}
+
+// mergesNames returns true if the names matched by the source pattern may be routed to the same
+// target, it's the case when the target is fixed and the pattern matches more than one name.
+func mergesNames(source, target string) bool {
+	if target == "" || strings.Contains(target, "$") {
+		return false
+	}
+	re, err := syntax.Parse(source, syntax.Perl)
+	if err != nil {
+		return true
+	}
+	re = re.Simplify()
+	return re.Op != syntax.OpLiteral && re.Op != syntax.OpEmptyMatch
+}
+
+// RouteSchema returns the downstream schema of the upstream schema, it's used by the
+// DDL statements of the schema, only the rules which match all the tables are applied.
+func (r *TableRouter) RouteSchema(schema string) string {
+	for _, rule := range r.rules {
+		if rule.schemaPattern == nil || rule.targetSchema == "" {
+			continue
+		}
+		match := rule.schemaPattern.FindStringSubmatchIndex(schema)
+		if match == nil {
+			continue
+		}
+		return string(rule.schemaPattern.ExpandString(nil, rule.targetSchema, schema, match))
+	}
+	return schema
+}
+
+// RouteTableInfo returns the table info with the downstream schema and table name,
+// the routed table info is cached until the table info of the table changes.
+func (r *TableRouter) RouteTableInfo(tableInfo *commonType.TableInfo) *commonType.TableInfo {
+	if tableInfo == nil {
+		return nil
+	}
+	r.mu.Lock()
+	cached, ok := r.tables[tableInfo.TableName.TableID]
+	r.mu.Unlock()
+	if ok && cached.source == tableInfo {
+		return cached.routed
+	}
+	routed := tableInfo
+	schema, table := r.Route(tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	if schema != tableInfo.GetSchemaName() || table != tableInfo.GetTableName() {
+		routed = tableInfo.CloneWithName(schema, table)
+	}
+	r.mu.Lock()
+	r.tables[tableInfo.TableName.TableID] = routedTableInfo{source: tableInfo, routed: routed}
+	r.mu.Unlock()
+	return routed
+}
+
+// RouteDMLEvent returns the event with the routed table info. The event is copied if it's routed,
+// so the event held by the dispatcher is not changed.
+func (r *TableRouter) RouteDMLEvent(event *commonEvent.DMLEvent) *commonEvent.DMLEvent {
+	routed := r.RouteTableInfo(event.TableInfo)
+	if routed == event.TableInfo {
+		return event
+	}
+	copied := *event
+	copied.TableInfo = routed
+	return &copied
+}
+
+// RouteSchemaTableNames returns the downstream names of the upstream tables, the duplicated ones are removed.
+func (r *TableRouter) RouteSchemaTableNames(names []*commonEvent.SchemaTableName) []*commonEvent.SchemaTableName {
+	result := make([]*commonEvent.SchemaTableName, 0, len(names))
+	seen := make(map[commonEvent.SchemaTableName]struct{}, len(names))
+	for _, name := range names {
+		schema, table := r.Route(name.SchemaName, name.TableName)
+		routed := commonEvent.SchemaTableName{SchemaName: schema, TableName: table}
+		if _, ok := seen[routed]; ok {
+			continue
+		}
+		seen[routed] = struct{}{}
+		result = append(result, &routed)
+	}
+	return result
+}
+
+// RouteDDLEvent returns a copy of the DDL event whose names and query are routed to the downstream.
+// It returns nil if the DDL must not be applied to the downstream, see skipMergedTableDDL.
+func (r *TableRouter) RouteDDLEvent(event *commonEvent.DDLEvent) (*commonEvent.DDLEvent, error) {
+	if r.skipMergedTableDDL(event) {
+		return nil, nil
+	}
+	query, err := r.routeDDLQuery(event.Query, event.GetDDLSchemaName())
+	if err != nil {
+		return nil, err
+	}
+	copied := *event
+	copied.Query = query
+	copied.SchemaName, copied.TableName = r.routeName(event.SchemaName, event.TableName)
+	copied.PrevSchemaName, copied.PrevTableName = r.routeName(event.PrevSchemaName, event.PrevTableName)
+	copied.TableInfo = r.RouteTableInfo(event.TableInfo)
+	if len(event.MultipleTableInfos) > 0 {
+		copied.MultipleTableInfos = make([]*commonType.TableInfo, 0, len(event.MultipleTableInfos))
+		for _, tableInfo := range event.MultipleTableInfos {
+			copied.MultipleTableInfos = append(copied.MultipleTableInfos, r.RouteTableInfo(tableInfo))
+		}
+	}
+	if event.TableNameChange != nil {
+		copied.TableNameChange = &commonEvent.TableNameChange{
+			AddName:          r.routeNames(event.TableNameChange.AddName),
+			DropName:         r.routeNames(event.TableNameChange.DropName),
+			DropDatabaseName: event.TableNameChange.DropDatabaseName,
+		}
+		if copied.TableNameChange.DropDatabaseName != "" {
+			copied.TableNameChange.DropDatabaseName = r.RouteSchema(copied.TableNameChange.DropDatabaseName)
+		}
+	}
+	if query != event.Query {
+		log.Info("route ddl query",
+			zap.String("query", event.Query),
+			zap.String("routedQuery", query))
+	}
+	return &copied, nil
+}
+
+// skipMergedTableDDL returns true if the DDL of an upstream table is not applied to the merged
+// downstream table. Only the first CREATE TABLE is forwarded, and dropping or truncating one of
+// the upstream tables is skipped, otherwise the rows of the other upstream tables are removed.
+func (r *TableRouter) skipMergedTableDDL(event *commonEvent.DDLEvent) bool {
+	ddlType := event.GetDDLType()
+	switch ddlType {
+	case timodel.ActionCreateTable, timodel.ActionDropTable, timodel.ActionTruncateTable,
+		timodel.ActionDropTablePartition, timodel.ActionTruncateTablePartition:
+	default:
+		return false
+	}
+	if !r.isMerged(event.SchemaName, event.TableName) {
+		return false
+	}
+	schema, table := r.Route(event.SchemaName, event.TableName)
+	if ddlType == timodel.ActionCreateTable {
+		target := commonEvent.SchemaTableName{SchemaName: schema, TableName: table}
+		r.mu.Lock()
+		_, ok := r.created[target]
+		r.created[target] = struct{}{}
+		r.mu.Unlock()
+		if !ok {
+			return false
+		}
+	}
+	log.Warn("skip the ddl of the table merged into another downstream table",
+		zap.String("query", event.Query),
+		zap.String("targetSchema", schema),
+		zap.String("targetTable", table))
+	return true
+}
+
+// routeName routes the table, or the schema if the table is empty.
+func (r *TableRouter) routeName(schema, table string) (string, string) {
+	if schema == "" {
+		return schema, table
+	}
+	if table == "" {
+		return r.RouteSchema(schema), table
+	}
+	return r.Route(schema, table)
+}
+
+func (r *TableRouter) routeNames(names []commonEvent.SchemaTableName) []commonEvent.SchemaTableName {
+	if names == nil {
+		return nil
+	}
+	result := make([]commonEvent.SchemaTableName, 0, len(names))
+	for _, name := range names {
+		schema, table := r.Route(name.SchemaName, name.TableName)
+		result = append(result, commonEvent.SchemaTableName{SchemaName: schema, TableName: table})
+	}
+	return result
+}
+
+// routeDDLQuery rewrites the schema and table names in the query, the unqualified
+// table names are qualified by the routed schema of the default schema.
+func (r *TableRouter) routeDDLQuery(query, defaultSchema string) (string, error) {
+	if query == "" {
+		return query, nil
+	}
+	stmts, _, err := parser.New().ParseSQL(query)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	visitor := &routeVisitor{router: r, defaultSchema: defaultSchema}
+	for _, stmt := range stmts {
+		stmt.Accept(visitor)
+	}
+	// keep the query as it is if none of the names is routed
+	if !visitor.routed {
+		return query, nil
+	}
+	results := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		var sb strings.Builder
+		restoreFlags := format.RestoreTiDBSpecialComment | format.RestoreNameBackQuotes |
+			format.RestoreKeyWordUppercase | format.RestoreStringSingleQuotes
+		if err = stmt.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+			return "", errors.Trace(err)
+		}
+		results = append(results, sb.String())
+	}
+	return strings.Join(results, ";"), nil
+}
+
+type routeVisitor struct {
+	router        *TableRouter
+	defaultSchema string
+	// routed is true if any of the names is routed.
+	routed bool
+}
+
+func (v *routeVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	case *ast.TableName:
+		schema := node.Schema.O
+		if schema == "" {
+			schema = v.defaultSchema
+		}
+		targetSchema, targetTable := v.router.Route(schema, node.Name.O)
+		if targetSchema != schema || targetTable != node.Name.O {
+			node.Schema = pmodel.NewCIStr(targetSchema)
+			node.Name = pmodel.NewCIStr(targetTable)
+			v.routed = true
+		}
+	case *ast.CreateTableStmt:
+		// the merged table may be created by the other upstream tables before the changefeed restarts
+		schema := node.Table.Schema.O
+		if schema == "" {
+			schema = v.defaultSchema
+		}
+		if !node.IfNotExists && v.router.isMerged(schema, node.Table.Name.O) {
+			node.IfNotExists = true
+			v.routed = true
+		}
+	case *ast.CreateDatabaseStmt:
+		v.routeSchema(&node.Name)
+	case *ast.AlterDatabaseStmt:
+		if !node.AlterDefaultDatabase {
+			v.routeSchema(&node.Name)
+		}
+	case *ast.DropDatabaseStmt:
+		v.routeSchema(&node.Name)
+	}
+	return in, false
+}
+
+func (v *routeVisitor) routeSchema(name *pmodel.CIStr) {
+	if target := v.router.RouteSchema(name.O); target != name.O {
+		*name = pmodel.NewCIStr(target)
+		v.routed = true
+	}
+}
+
+func (v *routeVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
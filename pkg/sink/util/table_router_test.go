@@ -0,0 +1,214 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTableRouter(t *testing.T) *TableRouter {
+	router, err := NewTableRouter([]*config.RoutingRule{
+		{
+			SourceSchema: `shard_(\d+)`,
+			SourceTable:  `orders_(\d+)`,
+			TargetSchema: "merged",
+			TargetTable:  "orders",
+		},
+		{
+			SourceSchema: `shard_(\d+)`,
+			SourceTable:  `(?P<table>.*)`,
+			TargetTable:  "s${1}_${table}",
+		},
+		{
+			SourceSchema: "app",
+			TargetSchema: "bak_app",
+		},
+	})
+	require.NoError(t, err)
+	return router
+}
+
+func TestTableRouterRoute(t *testing.T) {
+	router, err := NewTableRouter(nil)
+	require.NoError(t, err)
+	require.Nil(t, router)
+
+	router = newTestTableRouter(t)
+	cases := []struct {
+		schema, table             string
+		targetSchema, targetTable string
+	}{
+		{"shard_1", "orders_1", "merged", "orders"},
+		{"shard_2", "orders_10", "merged", "orders"},
+		{"shard_2", "users", "shard_2", "s2_users"},
+		{"app", "users", "bak_app", "users"},
+		{"other", "orders_1", "other", "orders_1"},
+		// the patterns match the whole names
+		{"xshard_1", "orders_1", "xshard_1", "orders_1"},
+	}
+	for _, c := range cases {
+		schema, table := router.Route(c.schema, c.table)
+		require.Equal(t, c.targetSchema, schema, "%s.%s", c.schema, c.table)
+		require.Equal(t, c.targetTable, table, "%s.%s", c.schema, c.table)
+	}
+
+	// only the rules matching all the tables are applied to the schemas
+	require.Equal(t, "shard_1", router.RouteSchema("shard_1"))
+	require.Equal(t, "bak_app", router.RouteSchema("app"))
+
+	names := router.RouteSchemaTableNames([]*commonEvent.SchemaTableName{
+		{SchemaName: "shard_1", TableName: "orders_1"},
+		{SchemaName: "shard_2", TableName: "orders_2"},
+		{SchemaName: "app", TableName: "users"},
+	})
+	require.Equal(t, []*commonEvent.SchemaTableName{
+		{SchemaName: "merged", TableName: "orders"},
+		{SchemaName: "bak_app", TableName: "users"},
+	}, names)
+}
+
+func TestTableRouterRouteEvents(t *testing.T) {
+	router := newTestTableRouter(t)
+
+	tableInfo := common.WrapTableInfo(1, "shard_1", &timodel.TableInfo{
+		ID:   100,
+		Name: pmodel.NewCIStr("orders_1"),
+	})
+	dml := &commonEvent.DMLEvent{PhysicalTableID: 100, TableInfo: tableInfo}
+	routed := router.RouteDMLEvent(dml)
+	require.NotSame(t, dml, routed)
+	require.Same(t, tableInfo, dml.TableInfo)
+	require.Equal(t, "`merged`.`orders`", routed.TableInfo.TableName.QuoteString())
+	// the routed table info is cached
+	require.Same(t, routed.TableInfo, router.RouteDMLEvent(dml).TableInfo)
+
+	// the event is not copied if it's not routed
+	other := &commonEvent.DMLEvent{TableInfo: common.WrapTableInfo(2, "other", &timodel.TableInfo{
+		ID:   200,
+		Name: pmodel.NewCIStr("t"),
+	})}
+	require.Same(t, other, router.RouteDMLEvent(other))
+
+	ddl := &commonEvent.DDLEvent{
+		SchemaName: "shard_1",
+		TableName:  "orders_1",
+		Query:      "ALTER TABLE `orders_1` ADD COLUMN `c` INT",
+		TableInfo:  tableInfo,
+	}
+	routedDDL, err := router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "ALTER TABLE `merged`.`orders` ADD COLUMN `c` INT", routedDDL.Query)
+	require.Equal(t, "merged", routedDDL.SchemaName)
+	require.Equal(t, "orders", routedDDL.TableName)
+	require.Equal(t, "ALTER TABLE `orders_1` ADD COLUMN `c` INT", ddl.Query)
+
+	ddl = &commonEvent.DDLEvent{
+		SchemaName:     "app",
+		TableName:      "users_new",
+		PrevSchemaName: "shard_1",
+		PrevTableName:  "users",
+		Query:          "RENAME TABLE `shard_1`.`users` TO `app`.`users_new`",
+		TableNameChange: &commonEvent.TableNameChange{
+			AddName:  []commonEvent.SchemaTableName{{SchemaName: "app", TableName: "users_new"}},
+			DropName: []commonEvent.SchemaTableName{{SchemaName: "shard_1", TableName: "users"}},
+		},
+	}
+	routedDDL, err = router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "RENAME TABLE `shard_1`.`s1_users` TO `bak_app`.`users_new`", routedDDL.Query)
+	require.Equal(t, "shard_1", routedDDL.PrevSchemaName)
+	require.Equal(t, "s1_users", routedDDL.PrevTableName)
+	require.Equal(t, []commonEvent.SchemaTableName{{SchemaName: "bak_app", TableName: "users_new"}},
+		routedDDL.TableNameChange.AddName)
+	require.Equal(t, []commonEvent.SchemaTableName{{SchemaName: "shard_1", TableName: "users"}},
+		ddl.TableNameChange.DropName)
+
+	ddl = &commonEvent.DDLEvent{SchemaName: "app", Query: "CREATE DATABASE `app`"}
+	routedDDL, err = router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "CREATE DATABASE `bak_app`", routedDDL.Query)
+	require.Equal(t, "bak_app", routedDDL.SchemaName)
+
+	// the query is kept if none of the names is routed
+	ddl = &commonEvent.DDLEvent{SchemaName: "other", TableName: "t", Query: "create table t (a int)"}
+	routedDDL, err = router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "create table t (a int)", routedDDL.Query)
+}
+
+func TestTableRouterRouteMergedTableDDL(t *testing.T) {
+	router := newTestTableRouter(t)
+
+	// only the first CREATE TABLE of the merged table is forwarded
+	ddl := &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "shard_1",
+		TableName:  "orders_1",
+		Query:      "CREATE TABLE `orders_1` (`a` INT)",
+	}
+	routedDDL, err := router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS `merged`.`orders` (`a` INT)", routedDDL.Query)
+	ddl = &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "shard_2",
+		TableName:  "orders_2",
+		Query:      "CREATE TABLE `orders_2` (`a` INT)",
+	}
+	routedDDL, err = router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Nil(t, routedDDL)
+
+	// dropping or truncating one of the merged tables is skipped
+	for _, c := range []struct {
+		ddlType timodel.ActionType
+		query   string
+	}{
+		{timodel.ActionDropTable, "DROP TABLE `orders_1`"},
+		{timodel.ActionTruncateTable, "TRUNCATE TABLE `orders_1`"},
+		{timodel.ActionTruncateTablePartition, "ALTER TABLE `orders_1` TRUNCATE PARTITION `p0`"},
+	} {
+		ddl = &commonEvent.DDLEvent{Type: byte(c.ddlType), SchemaName: "shard_1", TableName: "orders_1", Query: c.query}
+		routedDDL, err = router.RouteDDLEvent(ddl)
+		require.NoError(t, err)
+		require.Nil(t, routedDDL, c.query)
+	}
+
+	// the tables which are only renamed are not merged
+	ddl = &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionDropTable),
+		SchemaName: "shard_1",
+		TableName:  "users",
+		Query:      "DROP TABLE `users`",
+	}
+	routedDDL, err = router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "DROP TABLE `shard_1`.`s1_users`", routedDDL.Query)
+	ddl = &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "app",
+		TableName:  "users",
+		Query:      "CREATE TABLE `users` (`a` INT)",
+	}
+	routedDDL, err = router.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE `bak_app`.`users` (`a` INT)", routedDDL.Query)
+}
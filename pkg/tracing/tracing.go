@@ -0,0 +1,126 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	tracerName  = "github.com/pingcap/ticdc"
+	serviceName = "ticdc"
+)
+
+// propagator encodes the span context in the W3C trace context format,
+// it's used to carry the span context across nodes by the message center.
+var propagator = propagation.TraceContext{}
+
+// Init sets up the global tracer provider which exports spans to the OTLP collector.
+// If tracing is disabled, the global no-op tracer provider is kept, so all the spans
+// created by Start are no-op and cost nearly nothing.
+// The returned function must be called to flush the pending spans when the server exits.
+func Init(ctx context.Context, cfg *config.TracingConfig, instanceID string) (func(context.Context) error, error) {
+	if cfg == nil || !cfg.Enable {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.instance.id", instanceID),
+		attribute.String("service.version", version.ReleaseVersion),
+	)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	log.Info("tracing is enabled",
+		zap.String("endpoint", cfg.Endpoint),
+		zap.Float64("sampleRatio", cfg.SampleRatio))
+	return provider.Shutdown, nil
+}
+
+// Start creates a span and a context containing the newly-created span.
+// If the ctx contains a span, the new span will be its child.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartFromRemote creates a span whose parent is the span context in the carrier,
+// which is received from other nodes. Unlike Start, it never creates a root span,
+// a no-op span is returned if the carrier is empty.
+func StartFromRemote(carrier map[string]string, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if len(carrier) == 0 {
+		ctx := context.Background()
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return Start(Extract(context.Background(), carrier), name, attrs...)
+}
+
+// End ends the span, and records the error to the span if it's not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject returns the trace context carried by the ctx, which can be sent to other nodes.
+// It returns nil if the ctx does not carry a valid span, so nothing is sent when tracing is disabled.
+// Note the sampling decision is also carried, so the remote spans follow the decision of the root span.
+func Inject(ctx context.Context) map[string]string {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a copy of parent with the span context in the carrier,
+// it's the reverse operation of Inject.
+func Extract(parent context.Context, carrier map[string]string) context.Context {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if len(carrier) == 0 {
+		return parent
+	}
+	return propagator.Extract(parent, propagation.MapCarrier(carrier))
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitDisabled(t *testing.T) {
+	shutdown, err := Init(context.Background(), config.NewDefaultTracingConfig(), "node-1")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	// the global provider is a no-op one, so nothing is injected.
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+	require.Nil(t, Inject(ctx))
+}
+
+func TestInjectAndExtract(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	old := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(old)
+
+	rootCtx, root := Start(context.Background(), "root")
+	carrier := Inject(rootCtx)
+	require.NotEmpty(t, carrier)
+
+	_, child := StartFromRemote(carrier, "child")
+	child.End()
+	root.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "child", spans[0].Name())
+	require.Equal(t, root.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	require.Equal(t, root.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.True(t, spans[0].Parent().IsRemote())
+
+	// no root span is created if the carrier is empty.
+	ctx, span := StartFromRemote(nil, "orphan")
+	span.End()
+	require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	require.Len(t, recorder.Ended(), 2)
+}
+
+func TestEndWithError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	old := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(old)
+
+	// the failed flush is marked as an error
+	_, span := Start(context.Background(), "sink.FlushDMLEvents", attribute.String("sink", "mysql"))
+	End(span, errors.New("flush failed"))
+	_, span = Start(context.Background(), "sink.FlushDMLEvents", attribute.String("sink", "kafka"))
+	End(span, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "flush failed", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+	require.Contains(t, spans[1].Attributes(), attribute.String("sink", "kafka"))
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// gcSafepointUpdateInterval is the minimum interval that CDC can update gc safepoint
+const gcSafepointUpdateInterval = time.Minute
+
+// Manager manages the service gc safepoint of all changefeeds in the cluster.
+type Manager interface {
+	// TryUpdateGCSafePoint tries to update TiCDC service GC safepoint to the
+	// min checkpoint ts of all changefeeds, the safepoint never lags behind
+	// the current time more than the max lag.
+	// Manager may skip update when it thinks it is too frequent.
+	// Set `forceUpdate` to force Manager update.
+	TryUpdateGCSafePoint(ctx context.Context, minCheckpointTs uint64, forceUpdate bool) error
+	// CheckStaleCheckpointTs returns an error if the data of the changefeed
+	// has been or will be GC, the changefeed should be failed in this case.
+	CheckStaleCheckpointTs(changefeedID common.ChangeFeedID, checkpointTs uint64) error
+}
+
+type gcManager struct {
+	gcServiceID string
+	pdClient    pd.Client
+	pdClock     pdutil.Clock
+	gcTTL       int64
+	// maxLag is the max lag of the service gc safepoint behind the current time.
+	maxLag time.Duration
+
+	lastUpdatedTime   time.Time
+	lastSucceededTime time.Time
+	lastSafePointTs   atomic.Uint64
+	isTiCDCBlockGC    atomic.Bool
+}
+
+// NewManager creates a new Manager. The max lag is capped by the gc ttl, 0 means using the gc ttl.
+func NewManager(gcServiceID string, pdClient pd.Client, pdClock pdutil.Clock, gcTTL int64, maxLag time.Duration) Manager {
+	maxLag = effectiveMaxLag(gcTTL, maxLag)
+	return &gcManager{
+		gcServiceID:       gcServiceID,
+		pdClient:          pdClient,
+		pdClock:           pdClock,
+		lastSucceededTime: time.Now(),
+		gcTTL:             gcTTL,
+		maxLag:            maxLag,
+	}
+}
+
+// effectiveMaxLag returns the max lag capped by the gc ttl, 0 means using the gc ttl.
+func effectiveMaxLag(gcTTL int64, maxLag time.Duration) time.Duration {
+	if maxLag <= 0 || maxLag > time.Duration(gcTTL)*time.Second {
+		return time.Duration(gcTTL) * time.Second
+	}
+	return maxLag
+}
+
+// CheckStartTsLag returns an error if the start ts lags behind the current ts more
+// than the max lag, the gc safepoint is never held back that far by the gc manager,
+// so the data after the start ts is not protected.
+func CheckStartTsLag(currentTs, startTs uint64, gcTTL int64, maxLag time.Duration) error {
+	lowerBound := oracle.GoTimeToTS(oracle.GetTimeFromTS(currentTs).Add(-effectiveMaxLag(gcTTL, maxLag)))
+	if startTs < lowerBound {
+		return errors.ErrStartTsBeforeGC.GenWithStackByArgs(startTs, lowerBound)
+	}
+	return nil
+}
+
+func (m *gcManager) TryUpdateGCSafePoint(
+	ctx context.Context, minCheckpointTs uint64, forceUpdate bool,
+) error {
+	if time.Since(m.lastUpdatedTime) < gcSafepointUpdateInterval && !forceUpdate {
+		return nil
+	}
+	m.lastUpdatedTime = time.Now()
+
+	// When the changefeed starts up, CDC will do a snapshot read at
+	// (checkpointTs - 1) from TiKV, so (checkpointTs - 1) should be an upper
+	// bound for the GC safepoint.
+	safePoint := minCheckpointTs - 1
+	lowerBound := oracle.GoTimeToTS(m.pdClock.CurrentTime().Add(-m.maxLag))
+	if safePoint < lowerBound {
+		// the stale changefeeds will be failed by CheckStaleCheckpointTs,
+		// they should not block the GC forever.
+		log.Warn("gc safepoint lags too much, advance it to the max lag",
+			zap.Uint64("safePoint", safePoint),
+			zap.Uint64("lowerBound", lowerBound),
+			zap.Duration("maxLag", m.maxLag))
+		safePoint = lowerBound
+	}
+
+	actual, err := SetServiceGCSafepoint(ctx, m.pdClient, m.gcServiceID, m.gcTTL, safePoint)
+	if err != nil {
+		log.Warn("updateGCSafePoint failed",
+			zap.Uint64("safePointTs", safePoint),
+			zap.Error(err))
+		if time.Since(m.lastSucceededTime) >= time.Second*time.Duration(m.gcTTL) {
+			return errors.ErrUpdateServiceSafepointFailed.Wrap(err)
+		}
+		return nil
+	}
+	if actual == safePoint {
+		log.Info("update gc safe point success", zap.Uint64("gcSafePointTs", safePoint))
+	}
+	if actual > safePoint {
+		log.Warn("update gc safe point failed, the gc safe point is larger than checkpointTs",
+			zap.Uint64("actual", actual), zap.Uint64("safePoint", safePoint))
+	}
+	// if the min checkpoint ts is equal to the current gc safe point, it
+	// means that the service gc safe point set by TiCDC is the min service
+	// gc safe point
+	m.isTiCDCBlockGC.Store(actual == safePoint)
+	m.lastSafePointTs.Store(actual)
+	m.lastSucceededTime = time.Now()
+	metrics.CoordinatorMinServiceGCSafepointGauge.Set(float64(oracle.ExtractPhysical(actual)))
+	metrics.CoordinatorGCSafepointGauge.Set(float64(oracle.ExtractPhysical(safePoint)))
+	return nil
+}
+
+func (m *gcManager) CheckStaleCheckpointTs(
+	changefeedID common.ChangeFeedID, checkpointTs uint64,
+) error {
+	gcSafepointUpperBound := checkpointTs - 1
+	pdTime := m.pdClock.CurrentTime()
+	if pdTime.Sub(oracle.GetTimeFromTS(gcSafepointUpperBound)) > m.maxLag {
+		return errors.ErrGCTTLExceeded.GenWithStackByArgs(checkpointTs, changefeedID.Name())
+	}
+	if !m.isTiCDCBlockGC.Load() && gcSafepointUpperBound < m.lastSafePointTs.Load() {
+		// another service gc safepoint less than the min checkpoint ts,
+		// the data of the changefeed has been GC.
+		return errors.ErrSnapshotLostByGC.GenWithStackByArgs(checkpointTs, m.lastSafePointTs.Load())
+	}
+	return nil
+}
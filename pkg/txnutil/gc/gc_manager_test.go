@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+)
+
+// mockPDClient mocks pd.Client to facilitate unit testing.
+type mockPDClient struct {
+	pd.Client
+	// gcSafePoint is the gc safepoint of the cluster, the service safepoint
+	// less than it is rejected.
+	gcSafePoint uint64
+	safePoint   uint64
+}
+
+func (m *mockPDClient) UpdateServiceGCSafePoint(_ context.Context, _ string, _ int64, safePoint uint64) (uint64, error) {
+	if safePoint < m.gcSafePoint {
+		return m.gcSafePoint, nil
+	}
+	m.safePoint = safePoint
+	return safePoint, nil
+}
+
+func TestUpdateGCSafePointWithMaxLag(t *testing.T) {
+	pdClient := &mockPDClient{}
+	m := NewManager("test", pdClient, pdutil.NewClock4Test(), 3600, 10*time.Minute)
+	cfID := common.NewChangeFeedIDWithName("test")
+
+	// the checkpoint is fresh, it's used as the safepoint
+	checkpointTs := oracle.GoTimeToTS(time.Now().Add(-time.Minute))
+	require.NoError(t, m.TryUpdateGCSafePoint(context.Background(), checkpointTs, true))
+	require.Equal(t, checkpointTs-1, pdClient.safePoint)
+	require.NoError(t, m.CheckStaleCheckpointTs(cfID, checkpointTs))
+
+	// the checkpoint lags too much, the safepoint is capped by the max lag
+	staleTs := oracle.GoTimeToTS(time.Now().Add(-time.Hour))
+	require.NoError(t, m.TryUpdateGCSafePoint(context.Background(), staleTs, true))
+	require.Greater(t, pdClient.safePoint, staleTs)
+	err := m.CheckStaleCheckpointTs(cfID, staleTs)
+	require.True(t, errors.ErrGCTTLExceeded.Equal(err))
+
+	// the updating is skipped if it's too frequent
+	require.NoError(t, m.TryUpdateGCSafePoint(context.Background(), checkpointTs, false))
+	require.Greater(t, checkpointTs, pdClient.safePoint)
+}
+
+func TestCheckSnapshotLostByGC(t *testing.T) {
+	now := time.Now()
+	pdClient := &mockPDClient{gcSafePoint: oracle.GoTimeToTS(now.Add(-time.Minute))}
+	m := NewManager("test", pdClient, pdutil.NewClock4Test(), 3600, 0)
+	cfID := common.NewChangeFeedIDWithName("test")
+
+	// the gc safepoint is larger than the checkpoint, the data before it is lost
+	checkpointTs := oracle.GoTimeToTS(now.Add(-2 * time.Minute))
+	require.NoError(t, m.TryUpdateGCSafePoint(context.Background(), checkpointTs, true))
+	err := m.CheckStaleCheckpointTs(cfID, checkpointTs)
+	require.True(t, errors.ErrSnapshotLostByGC.Equal(err))
+	require.NoError(t, m.CheckStaleCheckpointTs(cfID, oracle.GoTimeToTS(now)))
+}
+
+func TestCheckStartTsLag(t *testing.T) {
+	now := time.Now()
+	currentTs := oracle.GoTimeToTS(now)
+
+	require.NoError(t, CheckStartTsLag(currentTs, oracle.GoTimeToTS(now.Add(-time.Minute)), 3600, 10*time.Minute))
+	err := CheckStartTsLag(currentTs, oracle.GoTimeToTS(now.Add(-20*time.Minute)), 3600, 10*time.Minute)
+	require.True(t, errors.ErrStartTsBeforeGC.Equal(err))
+	// the max lag is capped by the gc ttl
+	require.NoError(t, CheckStartTsLag(currentTs, oracle.GoTimeToTS(now.Add(-20*time.Minute)), 3600, 0))
+	err = CheckStartTsLag(currentTs, oracle.GoTimeToTS(now.Add(-2*time.Hour)), 3600, 0)
+	require.True(t, errors.ErrStartTsBeforeGC.Equal(err))
+}
@@ -32,6 +32,8 @@ const (
 	EnsureGCServiceResuming = "-resuming-"
 	// EnsureGCServiceInitializing is a tag of GC service id for changefeed initialization
 	EnsureGCServiceInitializing = "-initializing-"
+	// EnsureGCServiceBackfilling is a tag of GC service id for table backfilling
+	EnsureGCServiceBackfilling = "-backfilling-"
 )
 
 // EnsureChangefeedStartTsSafety checks if the startTs less than the minimum of
@@ -20,6 +20,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
 	pd "github.com/tikv/pd/client"
@@ -144,6 +145,48 @@ func (m *Manager) AddUpstream(info *UpstreamInfo) *Upstream {
 		})
 }
 
+// AddUpstreamByInfo adds the upstream described by the upstream info of a changefeed,
+// the upstream is initialized asynchronously, use Upstream.IsNormal to check
+// whether it's ready. The default upstream is returned if info is nil.
+func (m *Manager) AddUpstreamByInfo(info *config.UpstreamInfo) (*Upstream, error) {
+	if info == nil {
+		return m.GetDefaultUpstream()
+	}
+	return m.AddUpstream(&UpstreamInfo{
+		ID:            info.ID,
+		PDEndpoints:   info.PDEndpoints,
+		KeyPath:       info.KeyPath,
+		CertPath:      info.CertPath,
+		CAPath:        info.CAPath,
+		CertAllowedCN: info.CertAllowedCN,
+	}), nil
+}
+
+// Connect connects to the upstream by the pd endpoints and adds it after it's
+// initialized. It's used when the upstream ID is unknown, e.g. creating a
+// changefeed with the pd endpoints of the upstream.
+func (m *Manager) Connect(pdEndpoints []string, conf *security.Credential) (*Upstream, error) {
+	up := newUpstream(pdEndpoints, conf)
+	if err := m.initUpstreamFunc(m.ctx, up); err != nil {
+		up.Close()
+		return nil, cerror.Trace(err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.ups.Load(up.ID); ok {
+		// the upstream is already added, close the new one.
+		up.Close()
+		existing := v.(*Upstream)
+		existing.resetIdleTime()
+		return existing, nil
+	}
+	m.ups.Store(up.ID, up)
+	log.Info("new upstream is connected",
+		zap.Uint64("id", up.ID), zap.Strings("pdEndpoints", pdEndpoints))
+	return up, nil
+}
+
 // Get gets a upstream by upstreamID.
 func (m *Manager) Get(upstreamID uint64) (*Upstream, bool) {
 	v, ok := m.ups.Load(upstreamID)
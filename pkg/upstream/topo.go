@@ -0,0 +1,79 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	clientV3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// defaultTopologyTimeout is the timeout of fetching the topology from the etcd of pd.
+const defaultTopologyTimeout = 2 * time.Second
+
+// GetTiDBAddresses returns the addresses of the alive TiDB servers of the upstream,
+// they are registered in the etcd of pd by the TiDB servers.
+func (up *Upstream) GetTiDBAddresses(ctx context.Context) ([]string, error) {
+	if up.etcdCli == nil {
+		return nil, errors.ErrUpstreamNotFound.GenWithStackByArgs(up.ID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultTopologyTimeout)
+	defer cancel()
+	resp, err := up.etcdCli.Get(ctx, infosync.TopologyInformationPath, clientV3.WithPrefix())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+
+	registered := make(map[string]struct{}, len(resp.Kvs))
+	alive := make(map[string]struct{}, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		// the key looks like /topology/tidb/{ip:port}/info or /topology/tidb/{ip:port}/ttl
+		key := strings.TrimPrefix(string(kv.Key), infosync.TopologyInformationPath+"/")
+		parts := strings.Split(key, "/")
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[1] {
+		case "info":
+			registered[parts[0]] = struct{}{}
+		case "ttl":
+			unixNano, err := strconv.ParseInt(string(kv.Value), 10, 64)
+			if err != nil {
+				log.Warn("ignored invalid tidb topology ttl entry",
+					zap.String("key", string(kv.Key)), zap.ByteString("value", kv.Value))
+				continue
+			}
+			if time.Since(time.Unix(0, unixNano)) <= infosync.TopologySessionTTL*time.Second {
+				alive[parts[0]] = struct{}{}
+			}
+		}
+	}
+
+	addrs := make([]string, 0, len(registered))
+	for addr := range registered {
+		if _, ok := alive[addr]; ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+	return addrs, nil
+}
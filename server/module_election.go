@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	logcoordinator "github.com/pingcap/ticdc/logservice/coordinator"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -38,7 +39,9 @@ type elector struct {
 	election *concurrency.Election
 	// election used for log coordinator
 	logElection *concurrency.Election
-	svr         *server
+	// standby mirrors the changefeeds if the node is a standby coordinator
+	standby *changefeed.StandbyBackend
+	svr     *server
 }
 
 func NewElector(server *server) common.SubModule {
@@ -46,17 +49,25 @@ func NewElector(server *server) common.SubModule {
 		etcd.CaptureOwnerKey(server.EtcdClient.GetClusterID()))
 	logElection := concurrency.NewElection(server.session,
 		LogCoordinatorKey(server.EtcdClient.GetClusterID()))
-	return &elector{
+	e := &elector{
 		election:    election,
 		logElection: logElection,
 		svr:         server,
 	}
+	if schedulerCfg := config.GetGlobalServerConfig().Debug.Scheduler; schedulerCfg != nil && schedulerCfg.CoordinatorStandby {
+		e.standby = changefeed.NewStandbyBackend(server.EtcdClient)
+	}
+	return e
 }
 
 func (e *elector) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
 	g.Go(func() error { return e.campaignCoordinator(ctx) })
 	g.Go(func() error { return e.campaignLogCoordinator(ctx) })
+	if e.standby != nil {
+		log.Info("the node is a standby coordinator", zap.Any("captureID", e.svr.info.ID))
+		g.Go(func() error { return e.standby.Run(ctx) })
+	}
 	return g.Wait()
 }
 
@@ -130,10 +141,17 @@ func (e *elector) campaignCoordinator(ctx context.Context) error {
 			zap.String("captureID", string(e.svr.info.ID)),
 			zap.Int64("coordinatorVersion", coordinatorVersion))
 
+		var backend changefeed.Backend = changefeed.NewEtcdBackend(e.svr.EtcdClient)
+		if e.standby != nil {
+			backend = e.standby
+		}
+		schedulerCfg := config.GetGlobalServerConfig().Debug.Scheduler
 		co := coordinator.New(e.svr.info,
-			e.svr.pdClient, e.svr.PDClock, changefeed.NewEtcdBackend(e.svr.EtcdClient),
+			e.svr.pdClient, e.svr.PDClock, backend,
 			e.svr.EtcdClient.GetClusterID(),
-			coordinatorVersion, 10000, time.Minute)
+			coordinatorVersion, 10000,
+			time.Duration(schedulerCfg.MinChangefeedBalanceInterval),
+			time.Duration(schedulerCfg.MaxChangefeedBalanceInterval))
 		e.svr.setCoordinator(co)
 		err = co.Run(ctx)
 		// When coordinator exits, we need to stop it.
@@ -18,10 +18,14 @@ import (
 	"net"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/logservice/subscriptionservice"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/messaging/proto"
+	"github.com/pingcap/tiflow/pkg/security"
 	"google.golang.org/grpc"
 )
 
@@ -30,12 +34,13 @@ type GrpcModule struct {
 	lis        net.Listener
 }
 
-func NewGrpcServer(lis net.Listener) common.SubModule {
+func NewGrpcServer(lis net.Listener, eventStore eventstore.EventStore, credential *security.Credential) common.SubModule {
 	option := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(256 * 1024 * 1024), // 256MB
 	}
 	grpcServer := grpc.NewServer(option...)
 	proto.RegisterMessageCenterServer(grpcServer, messaging.NewMessageCenterServer(appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)))
+	logservicepb.RegisterSubscriptionServiceServer(grpcServer, subscriptionservice.NewServer(eventStore, credential))
 	return &GrpcModule{
 		grpcServer: grpcServer,
 		lis:        lis,
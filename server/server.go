@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/logservice/upstreamservice"
 	"github.com/pingcap/ticdc/maintainer"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
@@ -35,10 +36,14 @@ import (
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/eventservice"
+	"github.com/pingcap/ticdc/pkg/faultinject"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/retry"
 	tiserver "github.com/pingcap/ticdc/pkg/server"
+	"github.com/pingcap/ticdc/pkg/tracing"
+	"github.com/pingcap/ticdc/pkg/upstream"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -53,6 +58,9 @@ import (
 
 const (
 	cleanMetaDuration = 10 * time.Second
+	// drainDuration is the max time to wait for the dispatchers to flush the sink
+	// when the server is shutting down gracefully.
+	drainDuration = 30 * time.Second
 )
 
 type server struct {
@@ -77,8 +85,16 @@ type server struct {
 	RegionCache *tikv.RegionCache
 	PDClock     pdutil.Clock
 
+	upstreamManager *upstream.Manager
+
 	tcpServer  tcpserver.TCPServer
 	subModules []common.SubModule
+
+	dispatcherOrchestrator *dispatcherorchestrator.DispatcherOrchestrator
+	nodeManager            *watcher.NodeManager
+
+	// shutdownTracing flushes the pending spans and stops the tracer provider.
+	shutdownTracing func(context.Context) error
 }
 
 // New returns a new Server instance
@@ -109,6 +125,29 @@ func New(conf *config.ServerConfig, pdEndpoints []string) (tiserver.Server, erro
 	return s, nil
 }
 
+// setBackoffPolicies overrides the default backoff policies of the error classes.
+func setBackoffPolicies(cfg *config.ErrorRetryConfig) {
+	if cfg == nil {
+		return
+	}
+	for class, policy := range map[errors.RetryClass]*config.BackoffPolicyConfig{
+		errors.RetryClassTransient: cfg.Transient,
+		errors.RetryClassBackoff:   cfg.Backoff,
+	} {
+		if policy == nil {
+			continue
+		}
+		retry.SetBackoffPolicy(class, retry.BackoffPolicy{
+			BaseDelay: time.Duration(policy.BaseDelay),
+			MaxDelay:  time.Duration(policy.MaxDelay),
+		})
+		log.Info("backoff policy is set",
+			zap.Stringer("class", class),
+			zap.Duration("baseDelay", time.Duration(policy.BaseDelay)),
+			zap.Duration("maxDelay", time.Duration(policy.MaxDelay)))
+	}
+}
+
 // initialize the server before run it.
 func (c *server) initialize(ctx context.Context) error {
 	if err := c.prepare(ctx); err != nil {
@@ -117,29 +156,53 @@ func (c *server) initialize(ctx context.Context) error {
 	}
 
 	appcontext.SetService(appcontext.DefaultPDClock, c.PDClock)
+	appcontext.SetService(appcontext.UpstreamManager, c.upstreamManager)
+
+	conf := config.GetGlobalServerConfig()
+	shutdownTracing, err := tracing.Init(ctx, conf.Debug.Tracing, c.info.ID.String())
+	if err != nil {
+		log.Error("init tracing failed", zap.Error(err))
+		return errors.Trace(err)
+	}
+	c.shutdownTracing = shutdownTracing
+	setBackoffPolicies(conf.ErrorRetry)
+	if conf.Debug.EnableFaultInjection {
+		faultinject.GetInjector().Enable()
+		log.Warn("fault injection is enabled, it should only be used in the tests")
+	}
 
 	appcontext.SetID(c.info.ID.String())
-	messageCenter := messaging.NewMessageCenter(ctx, c.info.ID, c.info.Epoch, config.NewDefaultMessageCenterConfig(), c.security)
+	messageCenterConfig := config.NewDefaultMessageCenterConfig()
+	messageCenterConfig.StandbyConnection = conf.Debug.Messages.StandbyConnection
+	messageCenter := messaging.NewMessageCenter(ctx, c.info.ID, c.info.Epoch, messageCenterConfig, c.security)
 	appcontext.SetService(appcontext.MessageCenter, messageCenter)
 
 	appcontext.SetService(appcontext.EventCollector, eventcollector.New(ctx, c.info.ID))
 	appcontext.SetService(appcontext.HeartbeatCollector, dispatchermanager.NewHeartBeatCollector(c.info.ID))
-	appcontext.SetService(appcontext.DispatcherOrchestrator, dispatcherorchestrator.New())
+	c.dispatcherOrchestrator = dispatcherorchestrator.New()
+	appcontext.SetService(appcontext.DispatcherOrchestrator, c.dispatcherOrchestrator)
 
 	nodeManager := watcher.NewNodeManager(c.session, c.EtcdClient)
+	c.nodeManager = nodeManager
 	nodeManager.RegisterNodeChangeHandler(
 		appcontext.MessageCenter,
 		appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter).OnNodeChanges)
 
-	conf := config.GetGlobalServerConfig()
 	subscriptionClient := logpuller.NewSubscriptionClient(
 		&logpuller.SubscriptionClientConfig{
 			RegionRequestWorkerPerStore: 16,
+			GrpcCompression:             conf.KVClient.GrpcCompression,
 		}, c.pdClient, c.RegionCache, c.PDClock,
 		txnutil.NewLockerResolver(c.KVStorage.(tikv.Storage)), c.security,
 	)
 	schemaStore := schemastore.New(ctx, conf.DataDir, subscriptionClient, c.pdClient, c.PDClock, c.KVStorage)
 	eventStore := eventstore.New(ctx, conf.DataDir, subscriptionClient, c.PDClock)
+	defaultUpstream, err := c.upstreamManager.GetDefaultUpstream()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	upstreamLogService := upstreamservice.NewManager(ctx, conf.DataDir, c.upstreamManager)
+	upstreamLogService.SetDefault(defaultUpstream, schemaStore, eventStore)
 	eventService := eventservice.New(eventStore, schemaStore)
 	c.subModules = []common.SubModule{
 		nodeManager,
@@ -147,11 +210,12 @@ func (c *server) initialize(ctx context.Context) error {
 		schemaStore,
 		NewElector(c),
 		NewHttpServer(c, c.tcpServer.HTTP1Listener()),
-		NewGrpcServer(c.tcpServer.GrpcListener()),
+		NewGrpcServer(c.tcpServer.GrpcListener(), eventStore, c.security),
 		maintainer.NewMaintainerManager(c.info, conf.Debug.Scheduler,
 			c.pdAPIClient, c.pdClient, c.RegionCache),
 		eventStore,
 		eventService,
+		upstreamLogService,
 	}
 	// register it into global var
 	for _, subModule := range c.subModules {
@@ -249,6 +313,16 @@ func (c *server) Close(ctx context.Context) {
 		log.Info("sub module closed", zap.String("module", subModule.Name()))
 	}
 
+	if c.upstreamManager != nil {
+		c.upstreamManager.Close()
+	}
+
+	if c.shutdownTracing != nil {
+		if err := c.shutdownTracing(ctx); err != nil {
+			log.Warn("failed to shutdown tracing", zap.Error(err))
+		}
+	}
+
 	// delete server info from etcd
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), cleanMetaDuration)
 	if err := c.EtcdClient.DeleteCaptureInfo(timeoutCtx, model.CaptureID(c.info.ID)); err != nil {
@@ -259,6 +333,29 @@ func (c *server) Close(ctx context.Context) {
 	cancel()
 }
 
+// Drain marks the server as stopping, flushes the dispatchers on it and reports
+// their final checkpoints to the maintainers, so the tables can be rescheduled to
+// other nodes without waiting for the server to be expired in etcd.
+// The returned channel is closed when the draining is done.
+func (c *server) Drain() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.liveness.Store(model.LivenessCaptureStopping)
+		if c.nodeManager != nil {
+			// the local maintainers must not schedule new spans to this node
+			c.nodeManager.MarkNodeStopping(c.info.ID)
+		}
+		if c.dispatcherOrchestrator == nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), drainDuration)
+		defer cancel()
+		c.dispatcherOrchestrator.Drain(ctx)
+	}()
+	return done
+}
+
 // Liveness returns liveness of the server.
 func (c *server) Liveness() model.Liveness {
 	return c.liveness.Load()
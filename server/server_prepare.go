@@ -18,7 +18,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -26,13 +25,12 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/memory"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/upstream"
 	"github.com/pingcap/tidb/pkg/util/gctuner"
-	"github.com/pingcap/tiflow/cdc/kv"
-	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/fsutil"
-	"github.com/tikv/client-go/v2/tikv"
 	pd "github.com/tikv/pd/client"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.uber.org/zap"
@@ -111,25 +109,16 @@ func (c *server) prepare(ctx context.Context) error {
 	}
 	c.pdEndpoints = append(c.pdEndpoints, allPDEndpoints...)
 
-	// Update meta-region label to ensure that meta region isolated from data regions.
-	err = pdAPIClient.UpdateMetaLabel(ctx)
-	if err != nil {
-		log.Warn("Fail to verify region label rule",
-			zap.Error(err),
-			zap.Stringer("upstreamID", c.info.ID),
-			zap.Strings("upstreamEndpoints", c.pdEndpoints))
-	}
-
-	c.KVStorage, err = kv.CreateTiStore(strings.Join(allPDEndpoints, ","), conf.Security)
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	c.RegionCache = tikv.NewRegionCache(c.pdClient)
-	c.PDClock, err = pdutil.NewClock(ctx, c.pdClient)
+	// The TiDB cluster of the pd endpoints the server started with is the default upstream,
+	// the kv storage, region cache and pd clock of the server are shared with it.
+	c.upstreamManager = upstream.NewManager(ctx)
+	defaultUpstream, err := c.upstreamManager.AddDefaultUpstream(c.pdEndpoints, conf.Security, c.pdClient, etcdCli)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	c.KVStorage = defaultUpstream.KVStorage
+	c.RegionCache = defaultUpstream.RegionCache
+	c.PDClock = defaultUpstream.PDClock
 
 	if err = c.initDir(); err != nil {
 		return errors.Trace(err)
@@ -153,6 +142,7 @@ func (c *server) prepare(ctx context.Context) error {
 
 func (c *server) setMemoryLimit() {
 	conf := config.GetGlobalServerConfig()
+	memory.GetGlobalRegistry().SetLimits(int64(conf.MemorySoftLimit), int64(conf.MemoryHardLimit))
 	if conf.GcTunerMemoryThreshold > maxGcTunerMemory {
 		// If total memory is larger than 512GB, we will not set memory limit.
 		// Because the memory limit is not accurate, and it is not necessary to set memory limit.
@@ -203,15 +193,7 @@ func (c *server) setUpDir() {
 
 // registerNodeToEtcd the server by put the server's information in etcd
 func (c *server) registerNodeToEtcd(ctx context.Context) error {
-	cInfo := &model.CaptureInfo{
-		ID:             model.CaptureID(c.info.ID),
-		AdvertiseAddr:  c.info.AdvertiseAddr,
-		Version:        c.info.Version,
-		GitHash:        c.info.GitHash,
-		DeployPath:     c.info.DeployPath,
-		StartTimestamp: c.info.StartTimestamp,
-	}
-	err := c.EtcdClient.PutCaptureInfo(ctx, cInfo, c.session.Lease())
+	err := c.EtcdClient.PutNodeInfo(ctx, c.info, c.session.Lease())
 	if err != nil {
 		return errors.WrapError(errors.ErrCaptureRegister, err)
 	}
@@ -29,7 +29,11 @@ import (
 	"go.uber.org/zap"
 )
 
-const NodeManagerName = "node-manager"
+const (
+	NodeManagerName = "node-manager"
+	// getNodeInfoTimeout is the timeout of reading the metadata of a new node from etcd.
+	getNodeInfoTimeout = 5 * time.Second
+)
 
 type (
 	NodeChangeHandler  func(map[node.ID]*node.Info)
@@ -43,6 +47,12 @@ type NodeManager struct {
 	etcdClient    etcd.CDCEtcdClient
 	coordinatorID atomic.Value
 	nodes         atomic.Pointer[map[node.ID]*node.Info]
+	// nodesMu serializes the updates of the nodes, which are made by
+	// the tick and the goroutines reading the metadata of the new nodes.
+	nodesMu sync.Mutex
+	// stoppingNodes are the alive nodes which are shutting down gracefully,
+	// no task should be scheduled to them.
+	stoppingNodes sync.Map
 
 	nodeChangeHandlers struct {
 		sync.RWMutex
@@ -80,9 +90,38 @@ func (c *NodeManager) Name() string {
 	return NodeManagerName
 }
 
+// loadNodeInfo reads the metadata of the new node in the background, such as the resources
+// and the labels, which are not kept in the capture info, it's read only once when the node
+// joins. The node is kept with the capture info only if the metadata can't be read.
+func (c *NodeManager) loadNodeInfo(ctx context.Context, placeholder *node.Info) {
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, getNodeInfoTimeout)
+		defer cancel()
+		info, err := c.etcdClient.GetNodeInfo(ctx, string(placeholder.ID))
+		if err != nil {
+			log.Warn("get node info failed, the node metadata is ignored",
+				zap.Stringer("node", placeholder.ID), zap.Error(err))
+			return
+		}
+		c.nodesMu.Lock()
+		defer c.nodesMu.Unlock()
+		oldMap := *c.nodes.Load()
+		// the node has left or joined again when the metadata is read
+		if oldMap[placeholder.ID] != placeholder {
+			return
+		}
+		allNodes := make(map[node.ID]*node.Info, len(oldMap))
+		for id, n := range oldMap {
+			allNodes[id] = n
+		}
+		allNodes[placeholder.ID] = info
+		c.nodes.Store(&allNodes)
+	}()
+}
+
 // Tick is triggered by the server update events
 func (c *NodeManager) Tick(
-	_ context.Context,
+	ctx context.Context,
 	raw orchestrator.ReactorState,
 ) (orchestrator.ReactorState, error) {
 	state := raw.(*orchestrator.GlobalReactorState)
@@ -108,16 +147,25 @@ func (c *NodeManager) Tick(
 	for _, info := range oldMap {
 		if _, exist := state.Captures[model.CaptureID(info.ID)]; !exist {
 			changed = true
+			c.stoppingNodes.Delete(info.ID)
 		}
 	}
 
+	c.nodesMu.Lock()
+	oldMap = *c.nodes.Load()
 	for _, capture := range state.Captures {
-		if _, exist := oldMap[node.ID(capture.ID)]; !exist {
-			changed = true
+		if old, exist := oldMap[node.ID(capture.ID)]; exist {
+			allNodes[old.ID] = old
+			continue
 		}
-		allNodes[node.ID(capture.ID)] = node.CaptureInfoToNodeInfo(capture)
+		changed = true
+		// the metadata is read off the tick path, the tick is not blocked by etcd
+		info := node.CaptureInfoToNodeInfo(capture)
+		allNodes[info.ID] = info
+		c.loadNodeInfo(ctx, info)
 	}
 	c.nodes.Store(&allNodes)
+	c.nodesMu.Unlock()
 
 	if changed {
 		log.Info("server change detected")
@@ -146,6 +194,48 @@ func (c *NodeManager) GetAliveNodes() map[node.ID]*node.Info {
 	return *c.nodes.Load()
 }
 
+// MarkNodeStopping marks the node is shutting down gracefully, the mark is
+// cleared when the node leaves the cluster.
+func (c *NodeManager) MarkNodeStopping(id node.ID) {
+	if _, loaded := c.stoppingNodes.LoadOrStore(id, struct{}{}); !loaded {
+		log.Info("node is marked as stopping", zap.Stringer("node", id))
+	}
+}
+
+// UnmarkNodeStopping clears the stopping mark of the node, the node is schedulable again.
+func (c *NodeManager) UnmarkNodeStopping(id node.ID) {
+	if _, loaded := c.stoppingNodes.LoadAndDelete(id); loaded {
+		log.Info("node is unmarked as stopping", zap.Stringer("node", id))
+	}
+}
+
+// IsNodeStopping returns true if the node is shutting down gracefully.
+func (c *NodeManager) IsNodeStopping(id node.ID) bool {
+	_, ok := c.stoppingNodes.Load(id)
+	return ok
+}
+
+// GetSchedulableNodes returns the alive captures which are not stopping,
+// the new tasks should only be scheduled to them. The caller mustn't modify the returned map.
+func (c *NodeManager) GetSchedulableNodes() map[node.ID]*node.Info {
+	nodes := *c.nodes.Load()
+	stopping := false
+	c.stoppingNodes.Range(func(_, _ any) bool {
+		stopping = true
+		return false
+	})
+	if !stopping {
+		return nodes
+	}
+	schedulable := make(map[node.ID]*node.Info, len(nodes))
+	for id, info := range nodes {
+		if !c.IsNodeStopping(id) {
+			schedulable[id] = info
+		}
+	}
+	return schedulable
+}
+
 func (c *NodeManager) Run(ctx context.Context) error {
 	cfg := config.GetGlobalServerConfig()
 	watcher := NewEtcdWatcher(c.etcdClient,
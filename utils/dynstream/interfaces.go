@@ -205,6 +205,8 @@ type Option struct {
 
 	EnableMemoryControl bool // Enable the memory control. By default false.
 
+	MemoryAccount MemoryAccount // Account the pending events to an external registry. Only used with memory control. By default nil.
+
 	UseBuffer bool // Use buffers inside the dynamic stream. By default false.
 
 	handleWait *sync.WaitGroup // For testing. Don't handle events until this wait group is done.
@@ -232,6 +234,14 @@ func (o *Option) fix() {
 	}
 }
 
+// MemoryAccount accounts the memory of the pending events to an external registry.
+// Exceeded returns true if the memory is exceeded globally, then all the areas are paused.
+type MemoryAccount interface {
+	Consume(n int64)
+	Release(n int64)
+	Exceeded() bool
+}
+
 type AreaSettings struct {
 	MaxPendingSize   int           // The max memory usage of the pending events of the area. Must be larger than 0. By default 128 MB.
 	FeedbackInterval time.Duration // The interval of sending feedbacks to the upstream. < 0 means no feedback. Must be larger than 0. By default 1 second.
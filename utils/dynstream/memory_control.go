@@ -84,6 +84,9 @@ func (as *areaMemStat[A, P, T, D, H]) appendEvent(
 	// Update the pending size.
 	path.pendingSize.Add(uint32(event.eventSize))
 	as.totalPendingSize.Add(int64(event.eventSize))
+	if as.memControl.account != nil {
+		as.memControl.account.Consume(int64(event.eventSize))
+	}
 	return true
 }
 
@@ -160,6 +163,11 @@ func (as *areaMemStat[A, P, T, D, H]) shouldPausePath(path *pathInfo[A, P, T, D,
 // shouldPauseArea determines if the area should be paused based on memory usage.
 // If the memory usage is greater than the 80% of max pending size, the area should be paused.
 func (as *areaMemStat[A, P, T, D, H]) shouldPauseArea() bool {
+	// Pause all the areas if the memory is exceeded globally,
+	// they are resumed by the ratio below once the memory is released.
+	if as.memControl.account != nil && as.memControl.account.Exceeded() {
+		return true
+	}
 	memoryUsageRatio := float64(as.totalPendingSize.Load()) / float64(as.settings.Load().MaxPendingSize)
 
 	log.Debug("fizz: should pause area",
@@ -179,6 +187,9 @@ func (as *areaMemStat[A, P, T, D, H]) shouldPauseArea() bool {
 }
 
 func (as *areaMemStat[A, P, T, D, H]) decPendingSize(size int64) {
+	if as.memControl.account != nil {
+		as.memControl.account.Release(size)
+	}
 	as.totalPendingSize.Add(int64(-size))
 	if as.totalPendingSize.Load() < 0 {
 		log.Debug("fizz: total pending size is less than 0, reset it to 0", zap.Int64("totalPendingSize", as.totalPendingSize.Load()))
@@ -193,6 +204,8 @@ type memControl[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	mutex sync.Mutex
 
 	areaStatMap map[A]*areaMemStat[A, P, T, D, H]
+	// account is nil if the pending events are not accounted externally.
+	account MemoryAccount
 }
 
 func newMemControl[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]]() *memControl[A, P, T, D, H] {
@@ -65,6 +65,7 @@ func newParallelDynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T
 		log.Info("Dynamic stream enable memory control")
 		s.feedbackChan = make(chan Feedback[A, P, D], 1024)
 		s.memControl = newMemControl[A, P, T, D, H]()
+		s.memControl.account = option.MemoryAccount
 	}
 	for i := range option.StreamCount {
 		s.streams = append(s.streams, newStream(i, handler, option))